@@ -19,6 +19,7 @@ import (
 	"log"
 	"os"
 
+	"github.com/googleapis/gapic-showcase/server"
 	"google.golang.org/grpc"
 )
 
@@ -29,7 +30,11 @@ func init() {
 	errLog = log.New(os.Stderr, "", log.Ldate|log.Ltime)
 }
 
-type loggerObserver struct{}
+// loggerObserver logs every message, with the fields its redactor names
+// redacted.
+type loggerObserver struct {
+	redactor *server.LogRedactor
+}
 
 func (l *loggerObserver) GetName() string { return "loggerObserver" }
 
@@ -40,9 +45,9 @@ func (l *loggerObserver) ObserveUnary(
 	info *grpc.UnaryServerInfo,
 	err error) {
 	stdLog.Printf("Received Unary Request for Method: %s\n", info.FullMethod)
-	stdLog.Printf("    Request:  %+v\n", req)
+	stdLog.Printf("    Request:  %s\n", l.redactor.Format(req))
 	if err == nil {
-		stdLog.Printf("    Returning Response: %+v\n", resp)
+		stdLog.Printf("    Returning Response: %s\n", l.redactor.Format(resp))
 	} else {
 		stdLog.Printf("    Returning Error: %+v\n", err)
 	}
@@ -55,7 +60,7 @@ func (l *loggerObserver) ObserveStreamRequest(
 	info *grpc.StreamServerInfo,
 	_ error) {
 	stdLog.Printf("%s Stream for Method: %s\n", streamType(info), info.FullMethod)
-	stdLog.Printf("    Recieving Message:  %v\n", req)
+	stdLog.Printf("    Recieving Message:  %s\n", l.redactor.Format(req))
 	stdLog.Println("")
 }
 
@@ -65,7 +70,7 @@ func (l *loggerObserver) ObserveStreamResponse(
 	info *grpc.StreamServerInfo,
 	_ error) {
 	stdLog.Printf("%s Stream for Method: %s\n", streamType(info), info.FullMethod)
-	stdLog.Printf("    Sending Message:  %+v\n", resp)
+	stdLog.Printf("    Sending Message:  %s\n", l.redactor.Format(resp))
 	stdLog.Println("")
 }
 
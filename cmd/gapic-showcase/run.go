@@ -15,54 +15,195 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
-	"strings"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/googleapis/gapic-showcase/server"
 	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/interceptors"
 	"github.com/googleapis/gapic-showcase/server/services"
 	"github.com/spf13/cobra"
 	lropb "google.golang.org/genproto/googleapis/longrunning"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/channelz/service"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/reflection"
 )
 
 func init() {
 	var port string
+	var network string
+	var maxRPCsPerConnection int
+	var maxConcurrentStreams uint32
+	var maxConcurrentRPCs int
+	var maxPollWait time.Duration
+	var pageTokenTTL time.Duration
+	var channelz bool
+	var clientAttemptHeader string
+	var httpPort string
+	var configFile string
+	var maxBatchEchoSize int32
+	var maxSendMessageBytes int32
+	var enableAdmin bool
+	var enableNonconforming bool
+	var enableJSONCodec bool
+	var operationTTL time.Duration
+	var blobTTL time.Duration
+	var maxStreamDuration time.Duration
+	var namespaceByteBudget int64
+	var byteBudgetWindow time.Duration
+	var deterministic bool
+	var instanceID string
+	var replicas int
+	var failingReplica int
+	var captureFile string
+	var captureMaxBytes int64
+	var operationWorkers int
+	var readyFile string
+	transport := server.DefaultTransportOptions()
 	runCmd := &cobra.Command{
 		Use:   "run",
 		Short: "Runs the showcase server",
 		Run: func(cmd *cobra.Command, args []string) {
-			// Ensure port is of the right form.
-			if !strings.HasPrefix(port, ":") {
-				port = ":" + port
+			// Freeze the clock and randomness before any service reads them.
+			server.SetDeterministic(deterministic)
+
+			if operationWorkers < 0 {
+				log.Fatalf("Showcase failed to start: --operation-workers must not be negative, got %d", operationWorkers)
+			}
+			if operationWorkers > 0 {
+				// The workers stop after the server, which is deferred later.
+				defer server.UseOperationWorkers(operationWorkers).Shutdown()
+			}
+
+			if err := transport.Validate(); err != nil {
+				log.Fatalf("Showcase failed to configure its transport: %v", err)
 			}
 
 			// Start listening.
-			lis, err := net.Listen("tcp", port)
+			listeners, err := server.GetReplicaSetInstance().ListenReplicas(network, port, replicas, failingReplica)
 			if err != nil {
-				log.Fatalf("Showcase failed to listen on port '%s': %v", port, err)
+				log.Fatalf("Showcase failed to listen on '%s': %v", port, err)
 			}
-			stdLog.Printf("Showcase listening on port: %s", port)
+			for i, l := range listeners {
+				if replicas > 1 {
+					stdLog.Printf("Showcase listening on %s: %s (replica %d)", network, l.Addr(), i)
+				} else {
+					stdLog.Printf("Showcase listening on %s: %s", network, l.Addr())
+				}
+			}
+			if captureFile != "" {
+				f, err := os.Create(captureFile)
+				if err != nil {
+					log.Fatalf("Showcase failed to create its capture file: %v", err)
+				}
+				defer f.Close()
+				// Captures are for debugging transports, so they keep the
+				// wall-clock time even in deterministic mode.
+				capture, err := server.NewCapture(f, captureMaxBytes, time.Now)
+				if err != nil {
+					log.Fatalf("Showcase failed to start its capture: %v", err)
+				}
+				for i, l := range listeners {
+					listeners[i] = capture.Listener(l)
+				}
+				stdLog.Printf("Showcase capturing its connections to %s", captureFile)
+			}
+			lis := listeners[0]
+			server.GetForwarderInstance().Watch(lis.Addr())
 
 			// Setup Server.
-			logger := &loggerObserver{}
+			settings := server.GetSettingsInstance().Get()
+			settings.MaxPollWait = maxPollWait
+			settings.PageTokenTTL = pageTokenTTL
+			settings.ClientAttemptHeader = clientAttemptHeader
+			settings.MaxBatchEchoSize = maxBatchEchoSize
+			settings.MaxSendMessageBytes = maxSendMessageBytes
+			settings.EnableAdmin = enableAdmin
+			settings.EnableNonconforming = enableNonconforming
+			settings.OperationTTL = operationTTL
+			settings.BlobTTL = blobTTL
+			settings.MaxStreamDuration = maxStreamDuration
+			settings.NamespaceByteBudget = namespaceByteBudget
+			settings.ByteBudgetWindow = byteBudgetWindow
+			if instanceID == "" {
+				instanceID = server.NewInstanceID()
+			}
+			settings.InstanceID = instanceID
+			server.GetSettingsInstance().Set(settings)
+			if configFile != "" {
+				if _, err := server.LoadSettingsFile(server.GetSettingsInstance(), configFile); err != nil {
+					log.Fatalf("Showcase failed to load its config file: %v", err)
+				}
+				settings = server.GetSettingsInstance().Get()
+				go reloadOnHangup(configFile)
+			}
+
+			logger := &loggerObserver{redactor: server.NewLogRedactor(server.GetSettingsInstance())}
 			observerRegistry := server.ShowcaseObserverRegistry()
 			observerRegistry.RegisterUnaryObserver(logger)
 			observerRegistry.RegisterStreamRequestObserver(logger)
 			observerRegistry.RegisterStreamResponseObserver(logger)
 
-			opts := []grpc.ServerOption{
-				grpc.StreamInterceptor(observerRegistry.StreamInterceptor),
-				grpc.UnaryInterceptor(observerRegistry.UnaryInterceptor),
+			// Registers gzip, so that the server compresses its responses like
+			// the requests of clients that use it, and rejects the requests it
+			// cannot decompress as invalid.
+			encoding.RegisterCompressor(server.NewCheckedCompressor(encoding.GetCompressor(gzip.Name), server.MaxReceiveMessageBytes))
+			var jsonCodec *server.JSONCodec
+			if enableJSONCodec {
+				jsonCodec = server.NewJSONCodec()
+				encoding.RegisterCodec(jsonCodec)
+			}
+			var replicaSet *server.ReplicaSet
+			if replicas > 1 || failingReplica >= 0 {
+				replicaSet = server.GetReplicaSetInstance()
+			}
+			opts, err := interceptors.ServerOptions(interceptors.Options{
+				Metrics:            server.GetMetricsInstance(),
+				Settings:           server.GetSettingsInstance(),
+				Replicas:           replicaSet,
+				StateSessions:      server.GetStateSessionsInstance(),
+				ConcurrencyLimiter: server.NewConcurrencyLimiter(maxConcurrentRPCs, server.GetMetricsInstance()),
+				ByteBudgets:        server.GetByteBudgetsInstance(),
+				OverloadLimiter:    server.GetOverloadLimiterInstance(),
+				ErrorInjector:      server.NewErrorInjector(server.GetSettingsInstance(), nil),
+				Scenarios:          server.GetScenarioStoreInstance(),
+				Expectations:       server.GetExpectationStoreInstance(),
+				StreamDuration:     server.NewStreamDurationLimiter(server.GetSettingsInstance()),
+				JSONCodec:          jsonCodec,
+				Observers:          observerRegistry,
+			})
+			if err != nil {
+				log.Fatalf("Showcase failed to configure its server: %v", err)
+			}
+			opts = append(opts, grpc.MaxSendMsgSize(int(settings.MaxSendMessageBytes)))
+			opts = append(opts, grpc.MaxRecvMsgSize(server.MaxReceiveMessageBytes))
+			opts = append(opts, transport.ServerOptions()...)
+			if maxConcurrentStreams > 0 {
+				opts = append(opts, grpc.MaxConcurrentStreams(maxConcurrentStreams))
+			}
+			if maxRPCsPerConnection > 0 {
+				drainer := server.NewConnectionDrainer(maxRPCsPerConnection)
+				opts = append(opts, grpc.StatsHandler(drainer))
+				for i, l := range listeners {
+					listeners[i] = drainer.Listener(l)
+				}
+				lis = listeners[0]
 			}
 			s := grpc.NewServer(opts...)
 			defer s.GracefulStop()
 
 			// Register Services to the server.
-			pb.RegisterEchoServer(s, services.NewEchoServer())
+			echoServer := services.NewEchoServer()
+			pb.RegisterEchoServer(s, echoServer)
 			identityServer := services.NewIdentityServer()
 			pb.RegisterIdentityServer(s, identityServer)
 			messagingServer := services.NewMessagingServer(identityServer)
@@ -71,8 +212,56 @@ func init() {
 			pb.RegisterTestingServer(s, services.NewTestingServer(observerRegistry))
 			lropb.RegisterOperationsServer(s, operationsServer)
 
+			// Expire done operations until the server stops.
+			ctx, stopCollector := context.WithCancel(context.Background())
+			collectorStopped := services.NewOperationCollector().Start(ctx)
+			defer func() {
+				stopCollector()
+				<-collectorStopped
+			}()
+
+			if channelz {
+				service.RegisterChannelzServiceToServer(s)
+				server.GetChannelzSummarizerInstance().Watch(lis.Addr())
+			}
+
+			var httpLis net.Listener
+			if httpPort != "" {
+				httpLis, err = net.Listen("tcp", httpPort)
+				if err != nil {
+					log.Fatalf("Showcase failed to listen for HTTP/JSON on '%s': %v", httpPort, err)
+				}
+				mux := http.NewServeMux()
+				echoHandler := server.NewEchoHTTPHandler(echoServer)
+				mux.Handle(server.EchoPath, echoHandler)
+				mux.Handle(server.WriteStatusPath, echoHandler)
+				mux.Handle(server.NumericEdgeCasesPath, echoHandler)
+				mux.Handle("/", server.NewIndexHandler(s, lis.Addr()))
+				go func() {
+					stdLog.Printf("Showcase serving HTTP/JSON Echo, GetWriteStatus, RepeatNumericEdgeCases and an index of its RPCs on %s", httpLis.Addr())
+					err := http.Serve(httpLis, mux)
+					log.Printf("Showcase failed to serve HTTP/JSON on '%s': %v", httpPort, err)
+				}()
+			}
+
 			// Register reflection service on gRPC server.
 			reflection.Register(s)
+
+			// Every listener accepts connections by now, and the services
+			// are registered, so calls made on reading the banner are
+			// answered once Serve starts.
+			banner := server.NewReadyBanner(s, listeners, httpLis, instanceID)
+			if err := banner.Write(os.Stdout); err != nil {
+				log.Fatalf("Showcase failed to print its ready banner: %v", err)
+			}
+			if readyFile != "" {
+				if err := banner.WriteFile(readyFile); err != nil {
+					log.Fatalf("Showcase failed to write its ready file: %v", err)
+				}
+			}
+			for _, l := range listeners[1:] {
+				go s.Serve(l)
+			}
 			s.Serve(lis)
 		},
 	}
@@ -82,5 +271,215 @@ func init() {
 		"port",
 		"p",
 		":7469",
-		"The port that showcase will be served on.")
+		"The port that showcase will be served on. A host may be given as "+
+			"host:port, with IPv6 literals in brackets such as [::1]:7469.")
+	runCmd.Flags().StringVar(
+		&network,
+		"network",
+		"tcp",
+		"The network to listen on: tcp4, tcp6, tcp for whichever families the host supports, "+
+			"or "+server.DualStackNetwork+" to fail unless the host can listen on both.")
+	runCmd.Flags().IntVar(
+		&replicas,
+		"replicas",
+		1,
+		"The number of replicas to serve the same services on, on consecutive ports "+
+			"starting at --port. Each call is answered with the ID of its replica "+
+			"in the "+server.ReplicaHeader+" header.")
+	runCmd.Flags().IntVar(
+		&failingReplica,
+		"failing-replica",
+		-1,
+		"If not negative, the ID of a replica that fails every call but "+
+			"Testing.GetEndpoints with UNAVAILABLE, for testing failover.")
+	runCmd.Flags().IntVar(
+		&maxRPCsPerConnection,
+		"max-rpcs-per-connection",
+		0,
+		"If positive, each connection is sent a GOAWAY once it has served this many RPCs, "+
+			"so that clients reconnect. RPCs in flight are allowed to finish.")
+	runCmd.Flags().Uint32Var(
+		&maxConcurrentStreams,
+		"max-concurrent-streams",
+		0,
+		"If positive, the HTTP/2 MAX_CONCURRENT_STREAMS setting of each connection. "+
+			"Clients queue calls beyond it.")
+	runCmd.Flags().IntVar(
+		&transport.WriteBufferSize,
+		"write-buffer-size",
+		transport.WriteBufferSize,
+		"The bytes written to each connection at once. Zero writes each HTTP/2 frame as it "+
+			"is framed, and a negative size keeps gRPC's default of 32KiB. Frames are still "+
+			"at most 16KiB and the client's window, whatever the buffer.")
+	runCmd.Flags().IntVar(
+		&transport.ReadBufferSize,
+		"read-buffer-size",
+		transport.ReadBufferSize,
+		"The most bytes read from each connection at once. Zero reads unbuffered, and a "+
+			"negative size keeps gRPC's default of 32KiB.")
+	runCmd.Flags().Int32Var(
+		&transport.InitialWindowSize,
+		"initial-window-size",
+		0,
+		"If positive, the HTTP/2 flow-control window of each stream, at least 65535. "+
+			"Small windows make clients wait for WINDOW_UPDATE frames as they send large requests.")
+	runCmd.Flags().Int32Var(
+		&transport.InitialConnWindowSize,
+		"initial-conn-window-size",
+		0,
+		"If positive, the HTTP/2 flow-control window of each connection, at least 65535.")
+	runCmd.Flags().IntVar(
+		&maxConcurrentRPCs,
+		"max-concurrent-rpcs",
+		0,
+		"If positive, the most RPCs the server handles at once. Calls beyond it fail with "+
+			"RESOURCE_EXHAUSTED, and streams count until they end.")
+	runCmd.Flags().DurationVar(
+		&maxPollWait,
+		"max-poll-wait",
+		server.DefaultSettings().MaxPollWait,
+		"The longest a GetOperation call holds for its "+server.PollWaitHeader+" metadata.")
+	runCmd.Flags().DurationVar(
+		&pageTokenTTL,
+		"page-token-ttl",
+		0,
+		"If positive, how long after they are issued page tokens are accepted. "+
+			"Requests may set their own page_token_ttl.")
+	runCmd.Flags().StringVar(
+		&clientAttemptHeader,
+		"client-attempt-header",
+		server.DefaultClientAttemptHeader,
+		"The metadata key of the attempt number that generated clients set when they "+
+			"retry calls themselves.")
+	runCmd.Flags().Int32Var(
+		&maxBatchEchoSize,
+		"max-batch-echo-size",
+		server.DefaultSettings().MaxBatchEchoSize,
+		"The most requests an Echo.BatchEcho call may carry.")
+	runCmd.Flags().Int32Var(
+		&maxSendMessageBytes,
+		"max-send-message-bytes",
+		server.DefaultSettings().MaxSendMessageBytes,
+		"The largest message, in bytes, the server sends. It is fixed at startup.")
+	runCmd.Flags().StringVar(
+		&httpPort,
+		"http-port",
+		"",
+		"If set, the port that Echo.Echo is also served on over HTTP/JSON, which honors the "+
+			server.JSONNameStyleHeader+" and "+server.JSONStrictHeader+" headers. An index of the "+
+			"RPCs is served at / as HTML and at "+server.IndexJSONPath+" as JSON.")
+	runCmd.Flags().StringVar(
+		&configFile,
+		"config-file",
+		"",
+		"A JSON ShowcaseSettings file whose settings override the flags. It is re-read "+
+			"on SIGHUP, and the settings it holds are updated without a restart.")
+	runCmd.Flags().DurationVar(
+		&blobTTL,
+		"blob-ttl",
+		server.DefaultSettings().BlobTTL,
+		"How long after its last write a blob of Echo.WriteBlob is kept, complete or not. "+
+			"Expired blobs release their storage. Zero keeps blobs until their namespace is purged.")
+	runCmd.Flags().DurationVar(
+		&operationTTL,
+		"operation-ttl",
+		0,
+		"If positive, how long after they are done operations expire. GetOperation returns "+
+			"NOT_FOUND for expired operations, and their recorded polls are forgotten.")
+	runCmd.Flags().IntVar(
+		&operationWorkers,
+		"operation-workers",
+		0,
+		"If positive, the number of workers that complete Wait operations, each taking "+
+			"the operation's duration, instead of the operations completing at their end time. "+
+			"Operations queue for a free worker, as reported by the "+
+			server.OperationQueueDepthMetric+" metric.")
+	runCmd.Flags().DurationVar(
+		&maxStreamDuration,
+		"max-stream-duration",
+		0,
+		"If positive, the longest a streaming call may run before it is ended with ABORTED.")
+	runCmd.Flags().Int64Var(
+		&namespaceByteBudget,
+		"namespace-byte-budget",
+		0,
+		"If positive, the most bytes of requests and responses the calls of each namespace "+
+			"may exchange in a --byte-budget-window before they fail with RESOURCE_EXHAUSTED.")
+	runCmd.Flags().DurationVar(
+		&byteBudgetWindow,
+		"byte-budget-window",
+		0,
+		"How long usage counts against a namespace's byte budget. Zero counts it until "+
+			"Testing.ResetByteBudget is called.")
+	runCmd.Flags().StringVar(
+		&instanceID,
+		"instance-id",
+		"",
+		"The ID recorded in the names of the operations this server starts, so that "+
+			"GetOperation calls routed to another instance fail with FAILED_PRECONDITION. "+
+			"A random ID is used when unset.")
+	runCmd.Flags().StringVar(
+		&captureFile,
+		"capture-file",
+		"",
+		"If set, the file to record the raw bytes of every connection into, in both "+
+			"directions. server.PrintCapture decodes the HTTP/2 frames of the file.")
+	runCmd.Flags().Int64Var(
+		&captureMaxBytes,
+		"capture-max-bytes",
+		server.DefaultCaptureMaxBytes,
+		"The largest the --capture-file may grow. Connections are no longer recorded once it is full.")
+	runCmd.Flags().StringVar(
+		&readyFile,
+		"ready-file",
+		"",
+		"If set, the path of a file to write, once the server is ready for calls, "+
+			"with the JSON line it prints to stdout: its addresses, services, "+
+			"instance ID and pid. The file appears complete or not at all.")
+	runCmd.Flags().BoolVar(
+		&enableAdmin,
+		"enable-admin",
+		false,
+		"Whether to enable Testing.DumpState, which returns the settings and most of the "+
+			"state the server holds.")
+	runCmd.Flags().BoolVar(
+		&enableNonconforming,
+		"enable-nonconforming",
+		false,
+		"Whether to enable responses that deliberately break the protocol, such as those of "+
+			"EchoRequest.corrupt_utf8_response, for testing how clients handle them.")
+	runCmd.Flags().BoolVar(
+		&enableJSONCodec,
+		"enable-json-codec",
+		false,
+		"Whether to accept gRPC calls encoded as JSON, with the content-type application/grpc+json.")
+	runCmd.Flags().BoolVar(
+		&deterministic,
+		"deterministic",
+		false,
+		"Whether to make responses reproducible for golden tests. The server's clock starts at "+
+			server.DeterministicStart.Format(time.RFC3339)+" and advances "+
+			server.DeterministicStep.String()+" each time it is read, and its randomness "+
+			"has a fixed seed.")
+	runCmd.Flags().BoolVar(
+		&channelz,
+		"channelz",
+		true,
+		"Whether to serve the gRPC channelz service. Testing.GetChannelzSummary "+
+			"returns an empty summary without it.")
+}
+
+// reloadOnHangup updates the settings from the config file each time the
+// process receives SIGHUP. Invalid files are logged and leave the settings
+// unchanged.
+func reloadOnHangup(configFile string) {
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	for range hangups {
+		if _, err := server.LoadSettingsFile(server.GetSettingsInstance(), configFile); err != nil {
+			log.Printf("Showcase failed to reload its config file: %v", err)
+			continue
+		}
+		stdLog.Printf("Showcase reloaded %s", configFile)
+	}
 }
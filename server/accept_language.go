@@ -0,0 +1,128 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// LanguageRange is a weighted language range from an Accept-Language value.
+type LanguageRange struct {
+	// The language range, lower cased, e.g. "en-us" or "*".
+	Tag string
+
+	// The quality of the range, between 0 and 1.
+	Q float64
+}
+
+var (
+	languageTagRegexp = regexp.MustCompile(`^(\*|[a-z]{1,8}(-[a-z0-9]{1,8})*)$`)
+	qValueRegexp      = regexp.MustCompile(`^(0(\.[0-9]{0,3})?|1(\.0{0,3})?)$`)
+)
+
+// ParseAcceptLanguage parses an Accept-Language value as defined by RFC 7231,
+// returning the ranges in the order given. Ranges without a q-value have a
+// quality of 1. The returned error names the first invalid element.
+func ParseAcceptLanguage(value string) ([]LanguageRange, error) {
+	ranges := []LanguageRange{}
+	for _, element := range strings.Split(value, ",") {
+		element = strings.TrimSpace(element)
+		if element == "" {
+			continue
+		}
+
+		parts := strings.Split(element, ";")
+		tag := strings.ToLower(strings.TrimSpace(parts[0]))
+		if !languageTagRegexp.MatchString(tag) {
+			return nil, fmt.Errorf("invalid language range %q", element)
+		}
+
+		q := 1.0
+		for _, param := range parts[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") || len(parts) > 2 {
+				return nil, fmt.Errorf("invalid parameter %q in %q", param, element)
+			}
+			weight := strings.TrimPrefix(param, "q=")
+			if !qValueRegexp.MatchString(weight) {
+				return nil, fmt.Errorf("invalid quality %q in %q", weight, element)
+			}
+			q, _ = strconv.ParseFloat(weight, 64)
+		}
+		ranges = append(ranges, LanguageRange{Tag: tag, Q: q})
+	}
+	return ranges, nil
+}
+
+// MatchLanguage returns the supported locale that best satisfies the ranges.
+//
+// A locale takes the quality of its most specific matching range: an exact
+// match, then a range that the locale is a prefix of (so "es-mx" matches
+// "es"), then a range that is a prefix of the locale, then "*". The locale
+// with the highest quality wins, ties going to the locale whose range came
+// first and then to the earlier supported locale. Locales with a quality of 0
+// are not acceptable. If no locale is acceptable, the first supported locale
+// is returned as the default, even if a range gave it a quality of 0.
+func MatchLanguage(ranges []LanguageRange, supported []string) string {
+	if len(supported) == 0 {
+		return ""
+	}
+
+	best, bestQ, bestIndex := supported[0], 0.0, len(ranges)
+	for _, locale := range supported {
+		q, index, ok := localeQuality(ranges, strings.ToLower(locale))
+		if !ok || q == 0 {
+			continue
+		}
+		if q > bestQ || (q == bestQ && index < bestIndex) {
+			best, bestQ, bestIndex = locale, q, index
+		}
+	}
+	return best
+}
+
+// localeQuality returns the quality of the most specific range matching the
+// locale and the position of that range.
+func localeQuality(ranges []LanguageRange, locale string) (float64, int, bool) {
+	const (
+		noMatch = iota
+		wildcard
+		rangePrefix
+		localePrefix
+		exact
+	)
+
+	bestSpecificity, q, index := noMatch, 0.0, 0
+	for i, r := range ranges {
+		specificity := noMatch
+		switch {
+		case r.Tag == locale:
+			specificity = exact
+		case strings.HasPrefix(r.Tag, locale+"-"):
+			specificity = localePrefix
+		case strings.HasPrefix(locale, r.Tag+"-"):
+			specificity = rangePrefix
+		case r.Tag == "*":
+			specificity = wildcard
+		}
+		if specificity > bestSpecificity {
+			bestSpecificity, q, index = specificity, r.Q, i
+		}
+	}
+	return q, index, bestSpecificity != noMatch
+}
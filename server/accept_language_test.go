@@ -0,0 +1,121 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []LanguageRange
+	}{
+		{"", []LanguageRange{}},
+		{"en", []LanguageRange{{"en", 1}}},
+		{"EN-us", []LanguageRange{{"en-us", 1}}},
+		{"da, en-gb;q=0.8, en;q=0.7", []LanguageRange{{"da", 1}, {"en-gb", 0.8}, {"en", 0.7}}},
+		{"*;q=0.1,ja", []LanguageRange{{"*", 0.1}, {"ja", 1}}},
+		{"es ; q=0 ,, fr;q=1.000", []LanguageRange{{"es", 0}, {"fr", 1}}},
+		{"zh-hant-tw;q=0.", []LanguageRange{{"zh-hant-tw", 0}}},
+	}
+	for _, test := range tests {
+		got, err := ParseAcceptLanguage(test.value)
+		if err != nil {
+			t.Errorf("ParseAcceptLanguage(%q): unexpected err %+v", test.value, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ParseAcceptLanguage(%q): want %v got %v", test.value, test.want, got)
+		}
+	}
+}
+
+func TestParseAcceptLanguage_invalid(t *testing.T) {
+	tests := []struct {
+		value string
+		bad   string
+	}{
+		{"e n", `"e n"`},
+		{"en, toolonglanguage", `"toolonglanguage"`},
+		{"en-", `"en-"`},
+		{"*-us", `"*-us"`},
+		{"en;q=1.5", `"1.5"`},
+		{"en;q=0.1234", `"0.1234"`},
+		{"en;q=", `""`},
+		{"en;level=1", `"level=1"`},
+		{"en;q=0.5;q=0.4", `"q=0.5"`},
+	}
+	for _, test := range tests {
+		_, err := ParseAcceptLanguage(test.value)
+		if err == nil {
+			t.Errorf("ParseAcceptLanguage(%q): want err", test.value)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.bad) {
+			t.Errorf("ParseAcceptLanguage(%q): want err naming %s got %v", test.value, test.bad, err)
+		}
+	}
+}
+
+func TestMatchLanguage(t *testing.T) {
+	supported := []string{"en", "es", "ja"}
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"ja", "ja"},
+		{"es-MX", "es"},
+		{"fr, es;q=0.5", "es"},
+		{"es;q=0.5, ja;q=0.9", "ja"},
+		// Unsupported only falls back to the first supported locale.
+		{"fr, de", "en"},
+		{"", "en"},
+		// Wildcards match any locale not otherwise excluded.
+		{"*", "en"},
+		{"en;q=0, *", "es"},
+		{"fr, *;q=0.5", "en"},
+		{"ja;q=0.2, *;q=0.5", "en"},
+		// With every locale excluded, the first is the default.
+		{"en;q=0, es;q=0, ja;q=0", "en"},
+		{"*;q=0", "en"},
+		{"es;q=0, *;q=0", "en"},
+		// Ties go to the first range, then to the first supported locale.
+		{"ja, es", "ja"},
+		{"es;q=0.8, ja;q=0.8", "es"},
+		// More specific ranges take precedence over less specific ones.
+		{"es-mx;q=0.9, es;q=0.1, ja;q=0.5", "ja"},
+		{"es;q=0.1, es-mx;q=0.9, ja;q=0.5", "ja"},
+		{"es-mx;q=0.9, ja;q=0.5", "es"},
+	}
+	for _, test := range tests {
+		ranges, err := ParseAcceptLanguage(test.value)
+		if err != nil {
+			t.Fatalf("ParseAcceptLanguage(%q): unexpected err %+v", test.value, err)
+		}
+		if got := MatchLanguage(ranges, supported); got != test.want {
+			t.Errorf("MatchLanguage(%q): want %s got %s", test.value, test.want, got)
+		}
+	}
+
+	if got := MatchLanguage([]LanguageRange{{"en", 1}}, nil); got != "" {
+		t.Errorf("MatchLanguage: want no locale when none are supported, got %s", got)
+	}
+	if got := MatchLanguage([]LanguageRange{{"en", 1}}, []string{"en-US", "fr"}); got != "en-US" {
+		t.Errorf("MatchLanguage: want en-US got %s", got)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// MaxAttemptCounts is the most requests the AttemptCounter singleton
+	// counts the attempts of.
+	MaxAttemptCounts = 1000
+
+	// AttemptCountTTL is how long after the last attempt of a request the
+	// AttemptCounter singleton forgets it.
+	AttemptCountTTL = 10 * time.Minute
+)
+
+var attemptCounterSingleton = NewAttemptCounter(Now, MaxAttemptCounts, AttemptCountTTL)
+
+// GetAttemptCounterInstance returns the attempt counter singleton.
+func GetAttemptCounterInstance() AttemptCounter {
+	return attemptCounterSingleton
+}
+
+// AttemptCounter counts the attempts of requests by a key that identifies the
+// logical request, so that the server can fail the first attempts of a
+// request and let a retry succeed.
+type AttemptCounter interface {
+	// Next records an attempt of the request with the key and returns its
+	// number, counting from 1. A request whose last attempt is older than
+	// the ttl of the counter starts again from 1.
+	Next(namespace, key string) int64
+
+	// PurgeNamespace forgets all requests of the namespace, and returns how
+	// many it forgot.
+	PurgeNamespace(namespace string) int
+}
+
+// NewAttemptCounter returns an empty AttemptCounter that uses nowF as its
+// clock, counts the attempts of at most maxRequests requests, forgetting the
+// one attempted longest ago first, and forgets a request ttl after its last
+// attempt.
+func NewAttemptCounter(nowF func() time.Time, maxRequests int, ttl time.Duration) AttemptCounter {
+	return &attemptCounter{
+		nowF:        nowF,
+		maxRequests: maxRequests,
+		ttl:         ttl,
+		counts:      map[namespacedName]attemptCount{},
+	}
+}
+
+type attemptCounter struct {
+	nowF        func() time.Time
+	maxRequests int
+	ttl         time.Duration
+
+	mu     sync.Mutex
+	counts map[namespacedName]attemptCount
+}
+
+type attemptCount struct {
+	attempts int64
+	last     time.Time
+}
+
+func (c *attemptCounter) Next(namespace, key string) int64 {
+	defer ChangeState()()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.nowF()
+	k := namespacedName{namespace, key}
+	count, ok := c.counts[k]
+	if ok && now.Sub(count.last) >= c.ttl {
+		count = attemptCount{}
+	}
+	if !ok && len(c.counts) >= c.maxRequests {
+		c.evict(now)
+	}
+	count.attempts++
+	count.last = now
+	c.counts[k] = count
+	return count.attempts
+}
+
+// evict forgets the expired requests or, if there are none, the one
+// attempted longest ago. The caller must hold mu.
+func (c *attemptCounter) evict(now time.Time) {
+	var oldest namespacedName
+	var oldestLast time.Time
+	evicted := false
+	for k, count := range c.counts {
+		if now.Sub(count.last) >= c.ttl {
+			delete(c.counts, k)
+			evicted = true
+			continue
+		}
+		if oldestLast.IsZero() || count.last.Before(oldestLast) {
+			oldest, oldestLast = k, count.last
+		}
+	}
+	if !evicted {
+		delete(c.counts, oldest)
+	}
+}
+
+func (c *attemptCounter) PurgeNamespace(namespace string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := 0
+	for k := range c.counts {
+		if k.namespace == namespace {
+			delete(c.counts, k)
+			n++
+		}
+	}
+	return n
+}
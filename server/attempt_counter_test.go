@@ -0,0 +1,75 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAttemptCounter(t *testing.T) {
+	now := time.Unix(1000, 0)
+	c := NewAttemptCounter(func() time.Time { return now }, 10, time.Minute)
+	for want := int64(1); want <= 3; want++ {
+		if got := c.Next("ns", "a"); got != want {
+			t.Errorf("Next(a): want attempt %d got %d", want, got)
+		}
+		now = now.Add(50 * time.Second)
+	}
+	if got := c.Next("ns", "b"); got != 1 {
+		t.Errorf("Next(b): want attempt 1 got %d", got)
+	}
+	if got := c.Next("other", "a"); got != 1 {
+		t.Errorf("Next(other, a): want attempt 1 in another namespace got %d", got)
+	}
+
+	// The ttl runs from the last attempt.
+	now = now.Add(time.Minute)
+	if got := c.Next("ns", "a"); got != 1 {
+		t.Errorf("Next(a): want attempt 1 once the count has expired got %d", got)
+	}
+}
+
+func TestAttemptCounter_evict(t *testing.T) {
+	now := time.Unix(1000, 0)
+	c := NewAttemptCounter(func() time.Time { return now }, 2, time.Minute)
+	for _, key := range []string{"a", "b", "a", "c"} {
+		c.Next("ns", key)
+		now = now.Add(time.Second)
+	}
+	// a was attempted again after b, so b was forgotten.
+	if got := c.Next("ns", "a"); got != 3 {
+		t.Errorf("Next(a): want attempt 3 got %d", got)
+	}
+	if got := c.Next("ns", "b"); got != 1 {
+		t.Errorf("Next(b): want attempt 1 once forgotten got %d", got)
+	}
+}
+
+func TestAttemptCounter_PurgeNamespace(t *testing.T) {
+	c := NewAttemptCounter(time.Now, 10, time.Minute)
+	c.Next("a", "x")
+	c.Next("a", "y")
+	c.Next("b", "x")
+	if got := c.PurgeNamespace("a"); got != 2 {
+		t.Errorf("PurgeNamespace(a): want 2 got %d", got)
+	}
+	if got := c.Next("a", "x"); got != 1 {
+		t.Errorf("Next(a, x): want attempt 1 after the purge got %d", got)
+	}
+	if got := c.Next("b", "x"); got != 2 {
+		t.Errorf("Next(b, x): want attempt 2 in another namespace got %d", got)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// PreviousRPCAttemptsHeader is the metadata key gRPC clients set on
+// transparent retries to the number of attempts that came before.
+const PreviousRPCAttemptsHeader = "grpc-previous-rpc-attempts"
+
+// DefaultClientAttemptHeader is the default metadata key of the attempt
+// number that a generated client sets when it retries calls itself.
+const DefaultClientAttemptHeader = "x-gapic-attempt"
+
+// Attempt returns the attempt number in the incoming metadata of the context
+// under the given key. A missing value is attempt 0.
+func Attempt(ctx context.Context, key string) (int64, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(key)
+	if len(values) == 0 {
+		return 0, nil
+	}
+	if len(values) > 1 {
+		return 0, fmt.Errorf("%s metadata must be given at most once", key)
+	}
+	attempt, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil || attempt < 0 {
+		return 0, fmt.Errorf("%s metadata %q must be a non-negative integer", key, values[0])
+	}
+	return attempt, nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func TestAttempt(t *testing.T) {
+	tests := []struct {
+		md   metadata.MD
+		want int64
+		ok   bool
+	}{
+		{nil, 0, true},
+		{metadata.Pairs(PreviousRPCAttemptsHeader, "2"), 2, true},
+		{metadata.Pairs(PreviousRPCAttemptsHeader, "two"), 0, false},
+		{metadata.Pairs(PreviousRPCAttemptsHeader, "-1"), 0, false},
+		{metadata.Pairs(PreviousRPCAttemptsHeader, "1", PreviousRPCAttemptsHeader, "2"), 0, false},
+	}
+	for _, test := range tests {
+		ctx := metadata.NewIncomingContext(context.Background(), test.md)
+		got, err := Attempt(ctx, PreviousRPCAttemptsHeader)
+		if got != test.want || (err == nil) != test.ok {
+			t.Errorf("Attempt(%v): want (%d, ok=%t) got (%d, %v)", test.md, test.want, test.ok, got, err)
+		}
+	}
+}
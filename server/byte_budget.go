@@ -0,0 +1,251 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var byteBudgetsSingleton = NewByteBudgets(GetSettingsInstance(), Now)
+
+// GetByteBudgetsInstance returns the byte budgets singleton.
+func GetByteBudgetsInstance() ByteBudgets {
+	return byteBudgetsSingleton
+}
+
+// ByteBudgets count the bytes of the requests and responses of each
+// namespace against the NamespaceByteBudget setting, and fail the calls of
+// the namespaces that have used it with RESOURCE_EXHAUSTED. Calls to the
+// Testing service are neither counted nor failed, so that tests can always
+// reset the budgets they exhausted.
+type ByteBudgets interface {
+	// Reset forgets the bytes the namespace has used, returning how many
+	// it had used in the current window.
+	Reset(namespace string) int64
+
+	// PurgeNamespace forgets the bytes the namespace has used, returning 1
+	// if it had used any and 0 otherwise.
+	PurgeNamespace(namespace string) int
+
+	// UnaryInterceptor implements the grpc.UnaryServerInterceptor type.
+	UnaryInterceptor(
+		context.Context,
+		interface{},
+		*grpc.UnaryServerInfo,
+		grpc.UnaryHandler) (interface{}, error)
+
+	// StreamInterceptor implements the grpc.StreamServerInterceptor type.
+	StreamInterceptor(
+		interface{},
+		grpc.ServerStream,
+		*grpc.StreamServerInfo,
+		grpc.StreamHandler) error
+}
+
+// NewByteBudgets returns ByteBudgets that read the budget and its window
+// from the settings, and measure windows with the given clock.
+func NewByteBudgets(settings SettingsStore, nowF func() time.Time) ByteBudgets {
+	return &byteBudgets{
+		settings: settings,
+		nowF:     nowF,
+		usage:    map[string]*byteUsage{},
+	}
+}
+
+type byteBudgets struct {
+	settings SettingsStore
+	nowF     func() time.Time
+
+	mu    sync.Mutex
+	usage map[string]*byteUsage
+}
+
+// byteUsage is the bytes a namespace has used since the start of its window.
+type byteUsage struct {
+	used  int64
+	start time.Time
+}
+
+// current returns the usage of the namespace in its current window, starting
+// a new window if the last one has ended. b.mu must be held.
+func (b *byteBudgets) current(namespace string, window time.Duration) *byteUsage {
+	now := b.nowF()
+	u, ok := b.usage[namespace]
+	if !ok || (window > 0 && !now.Before(u.start.Add(window))) {
+		u = &byteUsage{start: now}
+		b.usage[namespace] = u
+	}
+	return u
+}
+
+// admit returns an error if the namespace has used its budget.
+func (b *byteBudgets) admit(namespace string) error {
+	s := b.settings.Get()
+	if s.NamespaceByteBudget <= 0 {
+		return nil
+	}
+	b.mu.Lock()
+	used := b.current(namespace, s.ByteBudgetWindow).used
+	b.mu.Unlock()
+	if used < s.NamespaceByteBudget {
+		return nil
+	}
+	return byteBudgetExceeded(namespace, s.NamespaceByteBudget, used)
+}
+
+// spend counts the size of the message against the budget of the namespace.
+func (b *byteBudgets) spend(namespace string, m interface{}) {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return
+	}
+	s := b.settings.Get()
+	if s.NamespaceByteBudget <= 0 {
+		return
+	}
+	n := int64(proto.Size(msg))
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.current(namespace, s.ByteBudgetWindow).used += n
+}
+
+func (b *byteBudgets) Reset(namespace string) int64 {
+	defer ChangeState()()
+	window := b.settings.Get().ByteBudgetWindow
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.usage[namespace]; !ok {
+		return 0
+	}
+	used := b.current(namespace, window).used
+	delete(b.usage, namespace)
+	return used
+}
+
+func (b *byteBudgets) PurgeNamespace(namespace string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.usage[namespace]; !ok {
+		return 0
+	}
+	delete(b.usage, namespace)
+	return 1
+}
+
+// byteBudgetExempt reports whether the calls of the method are exempt from
+// the budgets.
+func byteBudgetExempt(method string) bool {
+	return strings.HasPrefix(method, "/google.showcase.v1beta1.Testing/")
+}
+
+func (b *byteBudgets) UnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	if byteBudgetExempt(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	namespace := NamespaceFromContext(ctx)
+	if err := b.admit(namespace); err != nil {
+		return nil, err
+	}
+	b.spend(namespace, req)
+	resp, err := handler(ctx, req)
+	if err == nil {
+		b.spend(namespace, resp)
+	}
+	return resp, err
+}
+
+func (b *byteBudgets) StreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	if byteBudgetExempt(info.FullMethod) {
+		return handler(srv, ss)
+	}
+	namespace := NamespaceFromContext(ss.Context())
+	if err := b.admit(namespace); err != nil {
+		return err
+	}
+	return handler(srv, &byteBudgetStream{ServerStream: ss, budgets: b, namespace: namespace})
+}
+
+// byteBudgetStream counts the messages of a stream against the budget of its
+// namespace.
+type byteBudgetStream struct {
+	grpc.ServerStream
+	budgets   *byteBudgets
+	namespace string
+}
+
+func (s *byteBudgetStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.budgets.spend(s.namespace, m)
+	}
+	return err
+}
+
+func (s *byteBudgetStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.budgets.spend(s.namespace, m)
+	}
+	return err
+}
+
+func byteBudgetExceeded(namespace string, budget, used int64) error {
+	overage := used - budget
+	violation, _ := ptypes.MarshalAny(&errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{{
+			Subject:     "namespaces/" + namespace,
+			Description: fmt.Sprintf("The namespace used %d bytes of its budget of %d bytes, %d over.", used, budget, overage),
+		}},
+	})
+	return status.ErrorProto(&spb.Status{
+		Code: int32(codes.ResourceExhausted),
+		Message: fmt.Sprintf(
+			"The namespace %q has used its budget of %d bytes, and is %d bytes over.",
+			namespace,
+			budget,
+			overage),
+		Details: []*any.Any{
+			showcaseerrors.ErrorInfo(showcaseerrors.ByteBudgetExceeded, showcaseerrors.Domain, map[string]string{
+				"namespace":     namespace,
+				"budget_bytes":  strconv.FormatInt(budget, 10),
+				"overage_bytes": strconv.FormatInt(overage, 10),
+			}),
+			violation,
+		},
+	})
+}
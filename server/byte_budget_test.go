@@ -0,0 +1,146 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestByteBudgets(t *testing.T) {
+	now := time.Unix(1000, 0)
+	settings := NewSettingsStore(DefaultSettings())
+	s := settings.Get()
+	s.NamespaceByteBudget = 10
+	s.ByteBudgetWindow = time.Minute
+	settings.Set(s)
+	budgets := NewByteBudgets(settings, func() time.Time { return now })
+
+	req := &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hello"}}
+	size := int64(2 * proto.Size(req))
+	info := &grpc.UnaryServerInfo{FullMethod: "/google.showcase.v1beta1.Echo/Echo"}
+	echo := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &pb.EchoResponse{Content: req.(*pb.EchoRequest).GetContent()}, nil
+	}
+	call := func(namespace string) error {
+		ctx := WithNamespace(context.Background(), namespace)
+		_, err := budgets.UnaryInterceptor(ctx, req, info, echo)
+		return err
+	}
+
+	// The call that crosses the budget succeeds, and the next one fails.
+	if err := call("a"); err != nil {
+		t.Fatalf("Echo within the budget: %v", err)
+	}
+	err := call("a")
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Echo over the budget: want ResourceExhausted, got %v", err)
+	}
+	st := status.Convert(err)
+	want := showcaseerrors.ErrorInfo("BYTE_BUDGET_EXCEEDED", showcaseerrors.Domain, map[string]string{
+		"namespace":     "a",
+		"budget_bytes":  "10",
+		"overage_bytes": strconv.FormatInt(size-10, 10),
+	})
+	if got := st.Proto().GetDetails()[0]; !proto.Equal(got, want) {
+		t.Errorf("Echo over the budget: want %v, got %v", want, got)
+	}
+	var failure errdetails.QuotaFailure
+	if err := ptypes.UnmarshalAny(st.Proto().GetDetails()[1], &failure); err != nil {
+		t.Fatal(err)
+	}
+	if v := failure.GetViolations(); len(v) != 1 || v[0].GetSubject() != "namespaces/a" {
+		t.Errorf("Echo over the budget: want a QuotaFailure for namespaces/a, got %v", v)
+	}
+
+	// Other namespaces and the Testing service are unaffected.
+	if err := call("b"); err != nil {
+		t.Errorf("Echo in another namespace: %v", err)
+	}
+	ctx := WithNamespace(context.Background(), "a")
+	testingInfo := &grpc.UnaryServerInfo{FullMethod: "/google.showcase.v1beta1.Testing/ResetByteBudget"}
+	if _, err := budgets.UnaryInterceptor(ctx, req, testingInfo, echo); err != nil {
+		t.Errorf("Testing call over the budget: %v", err)
+	}
+
+	// The budget is restored once the window rolls over.
+	now = now.Add(time.Minute - time.Second)
+	if err := call("a"); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Echo before the window rolls over: want ResourceExhausted, got %v", err)
+	}
+	now = now.Add(time.Second)
+	if err := call("a"); err != nil {
+		t.Errorf("Echo once the window rolled over: %v", err)
+	}
+
+	// Or once it is reset.
+	if used := budgets.Reset("a"); used != size {
+		t.Errorf("Reset: want %d bytes used, got %d", size, used)
+	}
+	if err := call("a"); err != nil {
+		t.Errorf("Echo once the budget was reset: %v", err)
+	}
+	if n := budgets.PurgeNamespace("a"); n != 1 {
+		t.Errorf("PurgeNamespace: want 1 removed, got %d", n)
+	}
+	if used := budgets.Reset("a"); used != 0 {
+		t.Errorf("Reset of a purged namespace: want 0 bytes used, got %d", used)
+	}
+}
+
+func TestByteBudgets_stream(t *testing.T) {
+	settings := NewSettingsStore(DefaultSettings())
+	s := settings.Get()
+	s.NamespaceByteBudget = 1
+	settings.Set(s)
+	budgets := NewByteBudgets(settings, time.Now)
+	info := &grpc.StreamServerInfo{FullMethod: "/google.showcase.v1beta1.Echo/Expand"}
+	ss := &testServerStream{}
+	expand := func(srv interface{}, ss grpc.ServerStream) error {
+		return ss.SendMsg(&pb.EchoResponse{Content: "a"})
+	}
+	if err := budgets.StreamInterceptor(nil, ss, info, expand); err != nil {
+		t.Fatalf("Expand within the budget: %v", err)
+	}
+	if err := budgets.StreamInterceptor(nil, ss, info, expand); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expand over the budget: want ResourceExhausted, got %v", err)
+	}
+}
+
+func TestByteBudgets_disabled(t *testing.T) {
+	budgets := NewByteBudgets(NewSettingsStore(DefaultSettings()), time.Now)
+	info := &grpc.UnaryServerInfo{FullMethod: "/google.showcase.v1beta1.Echo/Echo"}
+	for i := 0; i < 3; i++ {
+		if _, err := budgets.UnaryInterceptor(context.Background(), &pb.EchoRequest{}, info, func(context.Context, interface{}) (interface{}, error) {
+			return &pb.EchoResponse{}, nil
+		}); err != nil {
+			t.Fatalf("Echo without a budget: %v", err)
+		}
+	}
+	if n := budgets.PurgeNamespace(DefaultNamespace); n != 0 {
+		t.Errorf("PurgeNamespace without a budget: want nothing counted, got %d", n)
+	}
+}
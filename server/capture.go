@@ -0,0 +1,307 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// CaptureMagic begins every capture file written by a Capture.
+const CaptureMagic = "SHOWCAP1"
+
+// DefaultCaptureMaxBytes is the default size limit of a capture file.
+const DefaultCaptureMaxBytes = 64 << 20
+
+// captureRecordHeaderSize is the size of the header of a capture record: the
+// time in Unix nanoseconds, the connection ID, the direction and the length
+// of the bytes that follow.
+const captureRecordHeaderSize = 8 + 4 + 1 + 4
+
+// CaptureDirection is the direction in which captured bytes crossed a
+// connection.
+type CaptureDirection byte
+
+const (
+	// CaptureReceived marks bytes the server read from the client.
+	CaptureReceived CaptureDirection = iota
+
+	// CaptureSent marks bytes the server wrote to the client.
+	CaptureSent
+)
+
+func (d CaptureDirection) String() string {
+	if d == CaptureReceived {
+		return "<-"
+	}
+	return "->"
+}
+
+// Capture records the raw bytes of the connections of its listeners, in both
+// directions, into a capture file. The file is CaptureMagic followed by
+// records, each a header of the big-endian time in Unix nanoseconds, the
+// 32-bit connection ID, the direction byte and the 32-bit length of the
+// bytes that follow it.
+//
+// Once the file would grow past its size limit the Capture stops recording,
+// so a capture holds the start of the traffic.
+type Capture struct {
+	nowF     func() time.Time
+	maxBytes int64
+
+	mu      sync.Mutex
+	w       io.Writer
+	written int64
+	full    bool
+	err     error
+	conns   uint32
+}
+
+// NewCapture returns a Capture that writes to w, at most maxBytes bytes
+// including CaptureMagic, and uses nowF as its clock.
+func NewCapture(w io.Writer, maxBytes int64, nowF func() time.Time) (*Capture, error) {
+	if maxBytes < int64(len(CaptureMagic)) {
+		return nil, fmt.Errorf("the capture size limit %d is smaller than the %d bytes of its header", maxBytes, len(CaptureMagic))
+	}
+	if _, err := io.WriteString(w, CaptureMagic); err != nil {
+		return nil, err
+	}
+	return &Capture{nowF: nowF, maxBytes: maxBytes, w: w, written: int64(len(CaptureMagic))}, nil
+}
+
+// Listener wraps lis so that the bytes of the connections it accepts are
+// captured.
+func (c *Capture) Listener(lis net.Listener) net.Listener {
+	return &captureListener{Listener: lis, capture: c}
+}
+
+// Full reports whether the capture reached its size limit and stopped
+// recording.
+func (c *Capture) Full() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.full
+}
+
+// Err returns the first error writing the capture, after which it stopped
+// recording.
+func (c *Capture) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+func (c *Capture) record(conn uint32, dir CaptureDirection, b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.full || c.err != nil {
+		return
+	}
+	size := int64(captureRecordHeaderSize + len(b))
+	if c.written+size > c.maxBytes {
+		c.full = true
+		return
+	}
+	header := make([]byte, captureRecordHeaderSize)
+	binary.BigEndian.PutUint64(header[0:], uint64(c.nowF().UnixNano()))
+	binary.BigEndian.PutUint32(header[8:], conn)
+	header[12] = byte(dir)
+	binary.BigEndian.PutUint32(header[13:], uint32(len(b)))
+	if _, err := c.w.Write(append(header, b...)); err != nil {
+		c.err = err
+		return
+	}
+	c.written += size
+}
+
+type captureListener struct {
+	net.Listener
+	capture *Capture
+}
+
+func (l *captureListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	l.capture.mu.Lock()
+	l.capture.conns++
+	id := l.capture.conns
+	l.capture.mu.Unlock()
+	return &captureConn{Conn: conn, capture: l.capture, id: id}, nil
+}
+
+// captureConn records the bytes read from and written to its connection.
+type captureConn struct {
+	net.Conn
+	capture *Capture
+	id      uint32
+}
+
+func (c *captureConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	c.capture.record(c.id, CaptureReceived, b[:n])
+	return n, err
+}
+
+func (c *captureConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	c.capture.record(c.id, CaptureSent, b[:n])
+	return n, err
+}
+
+// CaptureRecord is a run of bytes recorded by a Capture.
+type CaptureRecord struct {
+	Time      time.Time
+	Conn      uint32
+	Direction CaptureDirection
+	Bytes     []byte
+}
+
+// ReadCapture reads the records of a capture file.
+func ReadCapture(r io.Reader) ([]CaptureRecord, error) {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(CaptureMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || string(magic) != CaptureMagic {
+		return nil, errors.New("not a capture file")
+	}
+	records := []CaptureRecord{}
+	header := make([]byte, captureRecordHeaderSize)
+	for {
+		if _, err := io.ReadFull(br, header); err == io.EOF {
+			return records, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("the capture record %d is truncated", len(records))
+		}
+		rec := CaptureRecord{
+			Time:      time.Unix(0, int64(binary.BigEndian.Uint64(header[0:]))),
+			Conn:      binary.BigEndian.Uint32(header[8:]),
+			Direction: CaptureDirection(header[12]),
+			Bytes:     make([]byte, binary.BigEndian.Uint32(header[13:])),
+		}
+		if _, err := io.ReadFull(br, rec.Bytes); err != nil {
+			return nil, fmt.Errorf("the capture record %d is truncated", len(records))
+		}
+		records = append(records, rec)
+	}
+}
+
+// http2Preface begins the bytes a client sends on an HTTP/2 connection.
+const http2Preface = "PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n"
+
+// http2FrameTypes names the frame types of RFC 7540.
+var http2FrameTypes = []string{
+	"DATA",
+	"HEADERS",
+	"PRIORITY",
+	"RST_STREAM",
+	"SETTINGS",
+	"PUSH_PROMISE",
+	"PING",
+	"GOAWAY",
+	"WINDOW_UPDATE",
+	"CONTINUATION",
+}
+
+// CaptureFrame is an HTTP/2 frame decoded from a capture.
+type CaptureFrame struct {
+	// The time of the record that completed the frame.
+	Time      time.Time
+	Conn      uint32
+	Direction CaptureDirection
+	Type      string
+	Flags     byte
+	StreamID  uint32
+	Length    int
+}
+
+func (f CaptureFrame) String() string {
+	return fmt.Sprintf(
+		"%s conn %d %s %s stream=%d flags=0x%02x length=%d",
+		f.Time.UTC().Format(time.RFC3339Nano), f.Conn, f.Direction, f.Type, f.StreamID, f.Flags, f.Length)
+}
+
+// CaptureFrames decodes the HTTP/2 frames of the records, in the order they
+// were completed. The bytes of each connection and direction are joined, and
+// the preface the client sends first is skipped. Incomplete frames at the end
+// of a capture are left out.
+func CaptureFrames(records []CaptureRecord) []CaptureFrame {
+	type stream struct {
+		conn uint32
+		dir  CaptureDirection
+	}
+	pending := map[stream][]byte{}
+	prefaced := map[stream]bool{}
+	frames := []CaptureFrame{}
+	for _, rec := range records {
+		s := stream{rec.Conn, rec.Direction}
+		b := append(pending[s], rec.Bytes...)
+		if s.dir == CaptureReceived && !prefaced[s] {
+			if len(b) < len(http2Preface) {
+				pending[s] = b
+				continue
+			}
+			b = bytes.TrimPrefix(b, []byte(http2Preface))
+			prefaced[s] = true
+		}
+		for len(b) >= 9 {
+			length := int(b[0])<<16 | int(b[1])<<8 | int(b[2])
+			if len(b) < 9+length {
+				break
+			}
+			name := fmt.Sprintf("UNKNOWN(0x%x)", b[3])
+			if int(b[3]) < len(http2FrameTypes) {
+				name = http2FrameTypes[b[3]]
+			}
+			frames = append(frames, CaptureFrame{
+				Time:      rec.Time,
+				Conn:      rec.Conn,
+				Direction: rec.Direction,
+				Type:      name,
+				Flags:     b[4],
+				StreamID:  binary.BigEndian.Uint32(b[5:]) &^ (1 << 31),
+				Length:    length,
+			})
+			b = b[9+length:]
+		}
+		pending[s] = b
+	}
+	return frames
+}
+
+// PrintCapture writes the HTTP/2 frames of the capture file r to w, one per
+// line.
+func PrintCapture(w io.Writer, r io.Reader) error {
+	records, err := ReadCapture(r)
+	if err != nil {
+		return err
+	}
+	for _, f := range CaptureFrames(records) {
+		if _, err := fmt.Fprintln(w, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
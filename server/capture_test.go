@@ -0,0 +1,129 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"google.golang.org/grpc"
+)
+
+// captureEcho serves testEchoServer on a listener captured into buf, and
+// makes one Echo call.
+func captureEcho(t *testing.T, buf *bytes.Buffer, maxBytes int64) *Capture {
+	capture, err := NewCapture(buf, maxBytes, func() time.Time { return time.Unix(100, 0) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	pb.RegisterEchoServer(s, testEchoServer{})
+	go s.Serve(capture.Listener(lis))
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pb.NewEchoClient(conn).Echo(context.Background(), &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}}); err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+	s.Stop()
+	return capture
+}
+
+func TestCapture(t *testing.T) {
+	buf := &bytes.Buffer{}
+	captureEcho(t, buf, DefaultCaptureMaxBytes)
+
+	records, err := ReadCapture(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) == 0 || records[0].Conn != 1 || !records[0].Time.Equal(time.Unix(100, 0)) {
+		t.Fatalf("ReadCapture: want records of connection 1 at the fake time got %v", records)
+	}
+	seen := map[string]bool{}
+	for _, f := range CaptureFrames(records) {
+		if f.StreamID == 1 {
+			seen[f.Direction.String()+" "+f.Type] = true
+		}
+	}
+	// The request and the response each have headers and data.
+	for _, want := range []string{"<- HEADERS", "<- DATA", "-> HEADERS", "-> DATA"} {
+		if !seen[want] {
+			t.Errorf("CaptureFrames: want %s on stream 1 got %v", want, seen)
+		}
+	}
+
+	out := &bytes.Buffer{}
+	if err := PrintCapture(out, bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "conn 1 <- HEADERS stream=1") || !strings.Contains(out.String(), "conn 1 <- SETTINGS stream=0") {
+		t.Errorf("PrintCapture: want the frames of connection 1 got\n%s", out)
+	}
+}
+
+func TestCapture_maxBytes(t *testing.T) {
+	buf := &bytes.Buffer{}
+	capture := captureEcho(t, buf, 100)
+	if !capture.Full() {
+		t.Errorf("Full: want the capture to fill up")
+	}
+	if buf.Len() > 100 {
+		t.Errorf("Capture: want at most 100 bytes got %d", buf.Len())
+	}
+	if _, err := ReadCapture(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Errorf("ReadCapture: want a readable capture got %v", err)
+	}
+
+	if _, err := NewCapture(&bytes.Buffer{}, 4, time.Now); err == nil {
+		t.Errorf("NewCapture(4): want an error for a limit smaller than the header")
+	}
+}
+
+func TestReadCapture_invalid(t *testing.T) {
+	for _, in := range []string{"", "NOTACAP1", CaptureMagic + "\x00\x00"} {
+		if _, err := ReadCapture(strings.NewReader(in)); err == nil {
+			t.Errorf("ReadCapture(%q): want an error", in)
+		}
+	}
+}
+
+func TestCaptureFrames_split(t *testing.T) {
+	// A HEADERS frame on stream 3, split across records after the preface.
+	frame := []byte{0, 0, 2, 0x1, 0x4, 0, 0, 0, 3, 'a', 'b'}
+	in := append([]byte(http2Preface), frame...)
+	records := []CaptureRecord{
+		{Conn: 1, Direction: CaptureReceived, Bytes: in[:10]},
+		{Conn: 1, Direction: CaptureReceived, Bytes: in[10:30]},
+		{Conn: 1, Direction: CaptureReceived, Bytes: in[30:]},
+	}
+	frames := CaptureFrames(records)
+	want := CaptureFrame{Conn: 1, Direction: CaptureReceived, Type: "HEADERS", Flags: 0x4, StreamID: 3, Length: 2}
+	if len(frames) != 1 || frames[0] != want {
+		t.Errorf("CaptureFrames: want %v got %v", want, frames)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"google.golang.org/grpc"
+	channelzpb "google.golang.org/grpc/channelz/grpc_channelz_v1"
+	"google.golang.org/grpc/channelz/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var channelzSummarizerSingleton = NewChannelzSummarizer()
+
+// GetChannelzSummarizerInstance returns the channelz summarizer singleton.
+func GetChannelzSummarizerInstance() ChannelzSummarizer {
+	return channelzSummarizerSingleton
+}
+
+// ChannelzSummarizer totals the channelz data of the sockets of a gRPC
+// server.
+type ChannelzSummarizer interface {
+	// Watch makes summaries cover the servers listening on the address.
+	Watch(addr net.Addr)
+
+	// Summary returns the totals over the open sockets of the watched
+	// servers, or an empty summary if none are watched.
+	Summary(ctx context.Context) (*pb.ChannelzSummary, error)
+}
+
+// NewChannelzSummarizer returns a summarizer that watches no server.
+func NewChannelzSummarizer() ChannelzSummarizer {
+	return &channelzSummarizer{}
+}
+
+type channelzSummarizer struct {
+	mu     sync.Mutex
+	addr   string
+	client channelzpb.ChannelzClient
+}
+
+func (c *channelzSummarizer) Watch(addr net.Addr) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.addr = addr.String()
+}
+
+// channelzClient returns a client of a channelz service of its own, started
+// on first use. Channelz data is kept for the whole process, but querying the
+// watched server itself would count the queries in the summary.
+func (c *channelzSummarizer) channelzClient() (channelzpb.ChannelzClient, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.addr == "" || c.client != nil {
+		return c.client, c.addr, nil
+	}
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return nil, "", status.Errorf(codes.Internal, "Could not start channelz: %s.", err)
+	}
+	s := grpc.NewServer()
+	service.RegisterChannelzServiceToServer(s)
+	go s.Serve(lis)
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		s.Stop()
+		return nil, "", status.Errorf(codes.Internal, "Could not connect to channelz: %s.", err)
+	}
+	c.client = channelzpb.NewChannelzClient(conn)
+	return c.client, c.addr, nil
+}
+
+func (c *channelzSummarizer) Summary(ctx context.Context) (*pb.ChannelzSummary, error) {
+	summary := &pb.ChannelzSummary{}
+	client, addr, err := c.channelzClient()
+	if err != nil || client == nil {
+		return summary, err
+	}
+
+	var servers []int64
+	for start := int64(0); ; {
+		resp, err := client.GetServers(ctx, &channelzpb.GetServersRequest{StartServerId: start})
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range resp.GetServer() {
+			for _, ls := range s.GetListenSocket() {
+				if ls.GetName() == addr {
+					servers = append(servers, s.GetRef().GetServerId())
+					break
+				}
+			}
+			start = s.GetRef().GetServerId() + 1
+		}
+		if resp.GetEnd() || len(resp.GetServer()) == 0 {
+			break
+		}
+	}
+
+	for _, id := range servers {
+		for start := int64(0); ; {
+			resp, err := client.GetServerSockets(ctx, &channelzpb.GetServerSocketsRequest{ServerId: id, StartSocketId: start})
+			if err != nil {
+				return nil, err
+			}
+			for _, ref := range resp.GetSocketRef() {
+				start = ref.GetSocketId() + 1
+				socket, err := client.GetSocket(ctx, &channelzpb.GetSocketRequest{SocketId: ref.GetSocketId()})
+				if status.Code(err) == codes.NotFound {
+					// The socket closed since it was listed.
+					continue
+				}
+				if err != nil {
+					return nil, err
+				}
+				data := socket.GetSocket().GetData()
+				summary.OpenSockets++
+				summary.StreamsStarted += data.GetStreamsStarted()
+				summary.StreamsSucceeded += data.GetStreamsSucceeded()
+				summary.StreamsFailed += data.GetStreamsFailed()
+				summary.KeepAlivesSent += data.GetKeepAlivesSent()
+			}
+			if resp.GetEnd() || len(resp.GetSocketRef()) == 0 {
+				break
+			}
+		}
+	}
+	return summary, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"google.golang.org/grpc"
+)
+
+func TestChannelzSummarizer_unwatched(t *testing.T) {
+	got, err := NewChannelzSummarizer().Summary(context.Background())
+	if err != nil || !proto.Equal(got, &pb.ChannelzSummary{}) {
+		t.Errorf("Summary: want an empty summary got %v, %v", got, err)
+	}
+}
+
+// awaitSummary returns the first summary that is as wanted, or the last one
+// after a second. Channelz counts a stream once the server has finished it,
+// which may be just after the client sees its end.
+func awaitSummary(t *testing.T, summarizer ChannelzSummarizer, want *pb.ChannelzSummary) *pb.ChannelzSummary {
+	var got *pb.ChannelzSummary
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		var err error
+		if got, err = summarizer.Summary(context.Background()); err != nil {
+			t.Fatalf("Summary: unexpected err %+v", err)
+		}
+		if proto.Equal(got, want) {
+			break
+		}
+	}
+	return got
+}
+
+func TestChannelzSummarizer(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	pb.RegisterEchoServer(s, testEchoServer{})
+	go s.Serve(lis)
+	defer s.Stop()
+
+	summarizer := NewChannelzSummarizer()
+	summarizer.Watch(lis.Addr())
+	if got := awaitSummary(t, summarizer, &pb.ChannelzSummary{}); !proto.Equal(got, &pb.ChannelzSummary{}) {
+		t.Errorf("Summary without connections: want an empty summary got %v", got)
+	}
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewEchoClient(conn)
+	for i := 0; i < 5; i++ {
+		if err := echoHi(client, time.Second); err != nil {
+			t.Fatalf("Echo: unexpected err %+v", err)
+		}
+	}
+	want := &pb.ChannelzSummary{OpenSockets: 1, StreamsStarted: 5, StreamsSucceeded: 5}
+	if got := awaitSummary(t, summarizer, want); !proto.Equal(got, want) {
+		t.Errorf("Summary after 5 Echos: want %v got %v", want, got)
+	}
+
+	// A stream the client cancels ends with an error.
+	ctx, cancel := context.WithCancel(context.Background())
+	chat, err := client.Chat(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := chat.Send(&pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chat.Recv(); err != nil {
+		t.Fatalf("Chat.Recv: unexpected err %+v", err)
+	}
+	cancel()
+	want = &pb.ChannelzSummary{OpenSockets: 1, StreamsStarted: 6, StreamsSucceeded: 5, StreamsFailed: 1}
+	if got := awaitSummary(t, summarizer, want); !proto.Equal(got, want) {
+		t.Errorf("Summary after a cancelled Chat: want %v got %v", want, got)
+	}
+}
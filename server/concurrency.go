@@ -0,0 +1,98 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The metrics that a ConcurrencyLimiter reports.
+const (
+	InFlightUnaryRPCsMetric     = "in_flight_unary_rpcs"
+	InFlightStreamingRPCsMetric = "in_flight_streaming_rpcs"
+	RejectedRPCsMetric          = "concurrency_rejected_rpcs"
+)
+
+// ConcurrencyLimiter limits the number of RPCs the server handles at once.
+// Unlike the HTTP/2 MAX_CONCURRENT_STREAMS setting, which makes clients queue
+// their calls, calls beyond the limit fail at once with RESOURCE_EXHAUSTED.
+// Streaming RPCs count against the limit until the stream ends.
+type ConcurrencyLimiter struct {
+	max     int
+	metrics Metrics
+
+	mu       sync.Mutex
+	inFlight int
+}
+
+// NewConcurrencyLimiter returns a limiter that admits at most max concurrent
+// RPCs, or any number if max is not positive. It reports the RPCs in flight
+// to metrics.
+func NewConcurrencyLimiter(max int, metrics Metrics) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{max: max, metrics: metrics}
+}
+
+func (l *ConcurrencyLimiter) acquire(metric string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.max > 0 && l.inFlight >= l.max {
+		l.metrics.Add(RejectedRPCsMetric, 1)
+		return status.Errorf(
+			codes.ResourceExhausted,
+			"The server is already handling its limit of %d concurrent RPCs.",
+			l.max)
+	}
+	l.inFlight++
+	l.metrics.Add(metric, 1)
+	return nil
+}
+
+func (l *ConcurrencyLimiter) release(metric string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight--
+	l.metrics.Add(metric, -1)
+}
+
+// UnaryInterceptor implements the grpc.UnaryServerInterceptor type.
+func (l *ConcurrencyLimiter) UnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	if err := l.acquire(InFlightUnaryRPCsMetric); err != nil {
+		return nil, err
+	}
+	defer l.release(InFlightUnaryRPCsMetric)
+	return handler(ctx, req)
+}
+
+// StreamInterceptor implements the grpc.StreamServerInterceptor type.
+func (l *ConcurrencyLimiter) StreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	if err := l.acquire(InFlightStreamingRPCsMetric); err != nil {
+		return err
+	}
+	defer l.release(InFlightStreamingRPCsMetric)
+	return handler(srv, ss)
+}
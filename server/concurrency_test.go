@@ -0,0 +1,145 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// startEchoServer serves testEchoServer with the given options and returns a
+// client of it.
+func startEchoServer(t *testing.T, opts ...grpc.ServerOption) (pb.EchoClient, func()) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer(opts...)
+	pb.RegisterEchoServer(s, testEchoServer{})
+	go s.Serve(lis)
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pb.NewEchoClient(conn), func() {
+		conn.Close()
+		s.Stop()
+	}
+}
+
+// openChat starts a Chat stream and waits until the server is handling it.
+func openChat(t *testing.T, client pb.EchoClient) pb.Echo_ChatClient {
+	chat, err := client.Chat(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := chat.Send(&pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chat.Recv(); err != nil {
+		t.Fatalf("Chat.Recv: unexpected err %+v", err)
+	}
+	return chat
+}
+
+func closeChat(t *testing.T, chat pb.Echo_ChatClient) {
+	chat.CloseSend()
+	if _, err := chat.Recv(); err != io.EOF {
+		t.Fatalf("Chat.Recv: want EOF got %v", err)
+	}
+}
+
+func echoHi(client pb.EchoClient, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	_, err := client.Echo(ctx, &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}})
+	return err
+}
+
+func TestConcurrencyLimiter(t *testing.T) {
+	metrics := NewMetrics()
+	limiter := NewConcurrencyLimiter(2, metrics)
+	client, stop := startEchoServer(t,
+		grpc.UnaryInterceptor(limiter.UnaryInterceptor),
+		grpc.StreamInterceptor(limiter.StreamInterceptor))
+	defer stop()
+
+	// Saturate the limit with streams, which count while they are open.
+	first, second := openChat(t, client), openChat(t, client)
+	if got := metrics.Get(InFlightStreamingRPCsMetric); got != 2 {
+		t.Errorf("ConcurrencyLimiter: want 2 streams in flight got %d", got)
+	}
+	if err := echoHi(client, 5*time.Second); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Echo beyond the limit: want ResourceExhausted got %v", err)
+	}
+	third, err := client.Chat(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The error of a stream arrives on its first Recv.
+	if _, err := third.Recv(); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Chat beyond the limit: want ResourceExhausted got %v", err)
+	}
+	if got := metrics.Get(RejectedRPCsMetric); got != 2 {
+		t.Errorf("ConcurrencyLimiter: want 2 rejected RPCs got %d", got)
+	}
+
+	closeChat(t, first)
+	closeChat(t, second)
+	if err := echoHi(client, 5*time.Second); err != nil {
+		t.Errorf("Echo after the streams ended: unexpected err %+v", err)
+	}
+	if got := metrics.Get(InFlightStreamingRPCsMetric) + metrics.Get(InFlightUnaryRPCsMetric); got != 0 {
+		t.Errorf("ConcurrencyLimiter: want no RPCs in flight got %d", got)
+	}
+}
+
+func TestConcurrencyLimiter_unlimited(t *testing.T) {
+	metrics := NewMetrics()
+	limiter := NewConcurrencyLimiter(0, metrics)
+	for i := 0; i < 3; i++ {
+		if err := limiter.acquire(InFlightUnaryRPCsMetric); err != nil {
+			t.Fatalf("acquire %d: unexpected err %+v", i, err)
+		}
+	}
+	if got := metrics.Get(InFlightUnaryRPCsMetric); got != 3 {
+		t.Errorf("ConcurrencyLimiter: want 3 RPCs in flight got %d", got)
+	}
+}
+
+// MAX_CONCURRENT_STREAMS makes the client queue calls instead of failing
+// them, which is what distinguishes it from a ConcurrencyLimiter.
+func TestMaxConcurrentStreams(t *testing.T) {
+	client, stop := startEchoServer(t, grpc.MaxConcurrentStreams(1))
+	defer stop()
+
+	chat := openChat(t, client)
+	if err := echoHi(client, 200*time.Millisecond); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("Echo beyond MAX_CONCURRENT_STREAMS: want DeadlineExceeded while queued got %v", err)
+	}
+	closeChat(t, chat)
+	if err := echoHi(client, 5*time.Second); err != nil {
+		t.Errorf("Echo after the stream ended: unexpected err %+v", err)
+	}
+}
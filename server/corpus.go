@@ -0,0 +1,139 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sort"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// MaxCorpora is the maximum number of corpora a CorpusStore keeps.
+	MaxCorpora = 100
+
+	// MaxCorpusWords is the maximum number of words in a single corpus.
+	MaxCorpusWords = 10000
+)
+
+var corpusStoreSingleton = NewCorpusStore()
+
+// GetCorpusStoreInstance returns the corpus store singleton.
+func GetCorpusStoreInstance() CorpusStore {
+	return corpusStoreSingleton
+}
+
+// CorpusStore holds named lists of words for the Echo service to stream. Each
+// namespace has its own corpora, but the limits apply to the whole store.
+type CorpusStore interface {
+	// Create stores a copy of the words under the name. It fails with
+	// ALREADY_EXISTS if the name is taken and RESOURCE_EXHAUSTED if the store
+	// is full or the corpus is too large.
+	Create(namespace, name string, words []string) error
+
+	// Get returns the words of the named corpus. The returned slice must not
+	// be modified, and stays valid after the corpus is deleted.
+	Get(namespace, name string) ([]string, bool)
+
+	// Delete removes the named corpus, reporting whether it existed.
+	Delete(namespace, name string) bool
+
+	// PurgeNamespace removes all corpora of the namespace, and returns how
+	// many it removed.
+	PurgeNamespace(namespace string) int
+
+	// List returns all corpora, ordered by namespace and name.
+	List() []Corpus
+}
+
+// Corpus is a corpus of a CorpusStore.
+type Corpus struct {
+	Namespace string
+	Name      string
+	Words     []string
+}
+
+// NewCorpusStore returns an empty CorpusStore.
+func NewCorpusStore() CorpusStore {
+	return &corpusStore{corpora: map[namespacedName][]string{}}
+}
+
+type corpusStore struct {
+	mu      sync.Mutex
+	corpora map[namespacedName][]string
+}
+
+func (c *corpusStore) Create(namespace, name string, words []string) error {
+	key := namespacedName{namespace, name}
+	if len(words) > MaxCorpusWords {
+		return status.Errorf(codes.ResourceExhausted, "A corpus may have at most %d words.", MaxCorpusWords)
+	}
+	defer ChangeState()()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.corpora[key]; ok {
+		return status.Errorf(codes.AlreadyExists, "The corpus %q already exists.", name)
+	}
+	if len(c.corpora) >= MaxCorpora {
+		return status.Errorf(codes.ResourceExhausted, "At most %d corpora may be stored.", MaxCorpora)
+	}
+	c.corpora[key] = append([]string(nil), words...)
+	return nil
+}
+
+func (c *corpusStore) Get(namespace, name string) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	words, ok := c.corpora[namespacedName{namespace, name}]
+	return words, ok
+}
+
+func (c *corpusStore) Delete(namespace, name string) bool {
+	key := namespacedName{namespace, name}
+	defer ChangeState()()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.corpora[key]
+	delete(c.corpora, key)
+	return ok
+}
+
+func (c *corpusStore) PurgeNamespace(namespace string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := 0
+	for key := range c.corpora {
+		if key.namespace == namespace {
+			delete(c.corpora, key)
+			n++
+		}
+	}
+	return n
+}
+
+func (c *corpusStore) List() []Corpus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	corpora := make([]Corpus, 0, len(c.corpora))
+	for key, words := range c.corpora {
+		corpora = append(corpora, Corpus{Namespace: key.namespace, Name: key.name, Words: words})
+	}
+	sort.Slice(corpora, func(i, j int) bool {
+		return namespacedName{corpora[i].Namespace, corpora[i].Name}.less(namespacedName{corpora[j].Namespace, corpora[j].Name})
+	})
+	return corpora
+}
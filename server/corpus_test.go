@@ -0,0 +1,114 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCorpusStore(t *testing.T) {
+	store := NewCorpusStore()
+	words := []string{"a", "b"}
+	if err := store.Create("ns", "letters", words); err != nil {
+		t.Fatalf("Create: unexpected err %+v", err)
+	}
+	// The store keeps its own copy.
+	words[0] = "z"
+	got, ok := store.Get("ns", "letters")
+	if !ok || !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("Get: want [a b] got %v, %t", got, ok)
+	}
+	if err := store.Create("ns", "letters", words); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Create of an existing corpus: want AlreadyExists got %v", err)
+	}
+
+	if !store.Delete("ns", "letters") {
+		t.Error("Delete: want true for an existing corpus")
+	}
+	if store.Delete("ns", "letters") {
+		t.Error("Delete: want false for a deleted corpus")
+	}
+	if _, ok := store.Get("ns", "letters"); ok {
+		t.Error("Get: want no corpus after Delete")
+	}
+	// A snapshot taken before the delete is still intact.
+	if !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("Get after Delete: want the snapshot [a b] got %v", got)
+	}
+}
+
+func TestCorpusStore_limits(t *testing.T) {
+	store := NewCorpusStore()
+	if err := store.Create("ns", "big", make([]string, MaxCorpusWords+1)); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Create of a corpus with too many words: want ResourceExhausted got %v", err)
+	}
+	for i := 0; i < MaxCorpora; i++ {
+		if err := store.Create("ns", fmt.Sprint(i), []string{"w"}); err != nil {
+			t.Fatalf("Create(%d): unexpected err %+v", i, err)
+		}
+	}
+	if err := store.Create("ns", "full", []string{"w"}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Create in a full store: want ResourceExhausted got %v", err)
+	}
+	store.Delete("ns", "0")
+	if err := store.Create("ns", "full", []string{"w"}); err != nil {
+		t.Errorf("Create after Delete: unexpected err %+v", err)
+	}
+}
+
+func TestGetCorpusStoreInstance(t *testing.T) {
+	if GetCorpusStoreInstance() != GetCorpusStoreInstance() {
+		t.Error("GetCorpusStoreInstance: want the same store on every call")
+	}
+}
+
+func TestCorpusStore_namespaces(t *testing.T) {
+	store := NewCorpusStore()
+	store.Create("a", "words", []string{"a"})
+	if err := store.Create("b", "words", []string{"b"}); err != nil {
+		t.Fatalf("Create of the same name in another namespace: unexpected err %+v", err)
+	}
+	if got, _ := store.Get("b", "words"); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Errorf("Get: want the corpus of namespace b got %v", got)
+	}
+
+	store.PurgeNamespace("a")
+	if _, ok := store.Get("a", "words"); ok {
+		t.Error("PurgeNamespace: want the corpora of the namespace removed")
+	}
+	if _, ok := store.Get("b", "words"); !ok {
+		t.Error("PurgeNamespace: want the corpora of other namespaces kept")
+	}
+}
+
+func TestCorpusStore_List(t *testing.T) {
+	store := NewCorpusStore()
+	store.Create("b", "x", []string{"1"})
+	store.Create("a", "y", []string{"2"})
+	store.Create("a", "x", []string{"3"})
+	want := []Corpus{
+		{Namespace: "a", Name: "x", Words: []string{"3"}},
+		{Namespace: "a", Name: "y", Words: []string{"2"}},
+		{Namespace: "b", Name: "x", Words: []string{"1"}},
+	}
+	if got := store.List(); !reflect.DeepEqual(got, want) {
+		t.Errorf("List: want %v got %v", want, got)
+	}
+}
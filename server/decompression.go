@@ -0,0 +1,198 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"io/ioutil"
+	"reflect"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/status"
+)
+
+// MaxReceiveMessageBytes is the size of the largest request the server
+// accepts, which is gRPC's default.
+const MaxReceiveMessageBytes = 4 * 1024 * 1024
+
+// decompressionFailureField is the field number of the unknown field that
+// marks requests that could not be decompressed. It is the largest field
+// number proto allows, which no Showcase message uses.
+const decompressionFailureField = 1<<29 - 1
+
+// decompressionFailureKey is chosen when the server starts, and begins the
+// value of the field that marks requests that could not be decompressed, so
+// that clients cannot mark requests themselves.
+var decompressionFailureKey = func() []byte {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	return key
+}()
+
+// CheckedCompressor wraps a compressor so that the requests it cannot
+// decompress fail with INVALID_ARGUMENT and the reason
+// REQUEST_DECOMPRESSION_FAILED, rather than the INTERNAL error gRPC reports
+// in words that vary by version.
+//
+// gRPC decompresses the requests of unary calls before the interceptors
+// run, and returns decompression errors without calling them. So the
+// compressor never fails: a request it cannot decompress decodes instead as
+// a message holding only an unknown field that names the encoding, which
+// DecompressionUnaryInterceptor and DecompressionStreamInterceptor reject.
+//
+// Compressors are registered for the whole process, so the clients in it
+// decompress their responses with the CheckedCompressor too. The forwarder
+// fails the responses it could not decompress with
+// decompressionClientInterceptor, as gRPC would have, rather than take them
+// as empty messages.
+type CheckedCompressor struct {
+	encoding.Compressor
+	limit int
+}
+
+// NewCheckedCompressor returns a CheckedCompressor of the compressor, which is
+// to be registered with encoding.RegisterCompressor in its place. Requests
+// are decompressed up to one byte past the limit, for gRPC to reject as too
+// large.
+func NewCheckedCompressor(c encoding.Compressor, limit int) *CheckedCompressor {
+	return &CheckedCompressor{Compressor: c, limit: limit}
+}
+
+// Decompress returns a reader of the decompressed request, or of the
+// unknown field marking it if it cannot be decompressed.
+func (c *CheckedCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	d, err := c.Compressor.Decompress(r)
+	if err == nil {
+		var b []byte
+		if b, err = ioutil.ReadAll(io.LimitReader(d, int64(c.limit)+1)); err == nil {
+			return bytes.NewReader(b), nil
+		}
+	}
+	return bytes.NewReader(decompressionFailure(c.Name())), nil
+}
+
+// decompressionFailure returns the encoding of the unknown field marking a
+// request of the encoding that could not be decompressed.
+func decompressionFailure(name string) []byte {
+	b := proto.NewBuffer(nil)
+	b.EncodeVarint(decompressionFailureField<<3 | proto.WireBytes)
+	b.EncodeRawBytes(append(append([]byte(nil), decompressionFailureKey...), name...))
+	return b.Bytes()
+}
+
+// failedEncoding returns the encoding of the request encoded by data, if it
+// is the field marking a request that could not be decompressed.
+func failedEncoding(data []byte) (string, bool) {
+	b := proto.NewBuffer(data)
+	tag, err := b.DecodeVarint()
+	if err != nil || tag != decompressionFailureField<<3|proto.WireBytes {
+		return "", false
+	}
+	value, err := b.DecodeRawBytes(false)
+	if err != nil || !bytes.HasPrefix(value, decompressionFailureKey) {
+		return "", false
+	}
+	return string(value[len(decompressionFailureKey):]), true
+}
+
+// failedMessageEncoding returns the encoding of the message, if it is one
+// that a CheckedCompressor could not decompress.
+func failedMessageEncoding(m interface{}) (string, bool) {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return "", false
+	}
+	unknown := v.Elem().FieldByName("XXX_unrecognized")
+	if !unknown.IsValid() || unknown.Type() != reflect.TypeOf([]byte(nil)) {
+		return "", false
+	}
+	return failedEncoding(unknown.Bytes())
+}
+
+// decompressionError returns the error of a request that could not be
+// decompressed, or nil if the request is not one.
+func decompressionError(req interface{}) error {
+	name, ok := failedMessageEncoding(req)
+	if !ok {
+		return nil
+	}
+	return decompressionFailed(name)
+}
+
+func decompressionFailed(name string) error {
+	return showcaseerrors.Decompression(name, "The request could not be decompressed with its grpc-encoding %q.", name)
+}
+
+// DecompressionUnaryInterceptor fails unary calls whose request could not be
+// decompressed by a CheckedCompressor.
+func DecompressionUnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	if err := decompressionError(req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// DecompressionStreamInterceptor fails the receipt of the messages of
+// streaming calls that could not be decompressed by a CheckedCompressor.
+func DecompressionStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	return handler(srv, &decompressionStream{ss})
+}
+
+// decompressionClientInterceptor fails the unary calls of a client whose
+// response could not be decompressed by a CheckedCompressor, with the
+// INTERNAL code gRPC gives those of other clients.
+func decompressionClientInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption) error {
+	if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+		return err
+	}
+	if name, ok := failedMessageEncoding(reply); ok {
+		return status.Errorf(codes.Internal, "The response could not be decompressed with its grpc-encoding %q.", name)
+	}
+	return nil
+}
+
+type decompressionStream struct {
+	grpc.ServerStream
+}
+
+func (s *decompressionStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return decompressionError(m)
+}
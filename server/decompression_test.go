@@ -0,0 +1,314 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	gz "compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/hpack"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/status"
+)
+
+func gzipped(t *testing.T, m proto.Message) []byte {
+	b, err := proto.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	w := gz.NewWriter(&buf)
+	w.Write(b)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// corrupt returns the gzip data with its body flipped, which fails its
+// checksum.
+func corrupt(data []byte) []byte {
+	c := append([]byte(nil), data...)
+	for i := 10; i < len(c)-8; i++ {
+		c[i] ^= 0xff
+	}
+	return c
+}
+
+func checkedGzip() *CheckedCompressor {
+	return NewCheckedCompressor(encoding.GetCompressor(gzip.Name), MaxReceiveMessageBytes)
+}
+
+func TestCheckedCompressor(t *testing.T) {
+	c := checkedGzip()
+	if c.Name() != "gzip" {
+		t.Errorf("Name: want gzip got %s", c.Name())
+	}
+	want := &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}}
+	data := gzipped(t, want)
+	for name, test := range map[string]struct {
+		data    []byte
+		wantErr bool
+	}{
+		"valid":     {data, false},
+		"corrupt":   {corrupt(data), true},
+		"truncated": {data[:len(data)-4], true},
+		"not gzip":  {[]byte("hello"), true},
+	} {
+		r, err := c.Decompress(bytes.NewReader(test.data))
+		if err != nil {
+			t.Errorf("Decompress(%s): want no error got %v", name, err)
+			continue
+		}
+		b, _ := ioutil.ReadAll(r)
+		got := &pb.EchoRequest{}
+		if err := proto.Unmarshal(b, got); err != nil {
+			t.Errorf("Decompress(%s): want a request got %v", name, err)
+			continue
+		}
+		err = decompressionError(got)
+		if !test.wantErr {
+			if err != nil || !proto.Equal(got, want) {
+				t.Errorf("Decompress(%s): want %v got %v, %v", name, want, got, err)
+			}
+			continue
+		}
+		st := status.Convert(err)
+		if st.Code() != codes.InvalidArgument || len(st.Details()) != 1 || got.GetContent() != "" {
+			t.Errorf("Decompress(%s): want an empty request that fails with InvalidArgument got %v, %v", name, got, err)
+			continue
+		}
+		wantInfo := showcaseerrors.ErrorInfo(showcaseerrors.RequestDecompressionFailed, showcaseerrors.Domain, map[string]string{"encoding": "gzip"})
+		if details := st.Proto().GetDetails(); !proto.Equal(details[0], wantInfo) {
+			t.Errorf("Decompress(%s): want %v got %v", name, wantInfo, details[0])
+		}
+	}
+}
+
+func TestCheckedCompressor_limit(t *testing.T) {
+	c := NewCheckedCompressor(encoding.GetCompressor(gzip.Name), 3)
+	r, err := c.Decompress(bytes.NewReader(gzipped(t, &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hello"}})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := ioutil.ReadAll(r); len(b) != 4 {
+		t.Errorf("Decompress: want 4 bytes, one past the limit, got %d", len(b))
+	}
+}
+
+func TestDecompressionError_spoofed(t *testing.T) {
+	// A client cannot mark its own request, as it does not know the key.
+	b := proto.NewBuffer(nil)
+	b.EncodeVarint(decompressionFailureField<<3 | proto.WireBytes)
+	b.EncodeRawBytes(append(make([]byte, len(decompressionFailureKey)), "gzip"...))
+	req := &pb.EchoRequest{}
+	if err := proto.Unmarshal(b.Bytes(), req); err != nil {
+		t.Fatal(err)
+	}
+	if err := decompressionError(req); err != nil {
+		t.Errorf("decompressionError: want a request without the key accepted got %v", err)
+	}
+	if err := decompressionError(nil); err != nil {
+		t.Errorf("decompressionError(nil): want nil got %v", err)
+	}
+}
+
+func TestJSONCodec_decompressionFailed(t *testing.T) {
+	c := NewJSONCodec()
+	req := &pb.EchoRequest{}
+	c.Unmarshal(decompressionFailure("gzip"), req)
+	_, err := c.UnaryInterceptor(context.Background(), req, &grpc.UnaryServerInfo{}, nil)
+	st := status.Convert(err)
+	want := showcaseerrors.ErrorInfo(showcaseerrors.RequestDecompressionFailed, showcaseerrors.Domain, map[string]string{"encoding": "gzip"})
+	if details := st.Proto().GetDetails(); st.Code() != codes.InvalidArgument || len(details) != 1 || !proto.Equal(details[0], want) {
+		t.Errorf("UnaryInterceptor: want REQUEST_DECOMPRESSION_FAILED got %v", err)
+	}
+}
+
+// rawCall calls the method over a raw HTTP/2 connection to the address with
+// the single message, flagged as compressed with the encoding, and returns
+// the status of the call and the messages it returned.
+func rawCall(t *testing.T, addr, method, grpcEncoding string, message []byte) (*spb.Status, [][]byte) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+	if _, err := conn.Write([]byte(http2.ClientPreface)); err != nil {
+		t.Fatal(err)
+	}
+	framer := http2.NewFramer(conn, conn)
+	if err := framer.WriteSettings(); err != nil {
+		t.Fatal(err)
+	}
+
+	var headers bytes.Buffer
+	enc := hpack.NewEncoder(&headers)
+	for _, f := range [][2]string{
+		{":method", "POST"},
+		{":scheme", "http"},
+		{":path", method},
+		{":authority", addr},
+		{"content-type", "application/grpc"},
+		{"te", "trailers"},
+		{"grpc-encoding", grpcEncoding},
+	} {
+		enc.WriteField(hpack.HeaderField{Name: f[0], Value: f[1]})
+	}
+	if err := framer.WriteHeaders(http2.HeadersFrameParam{StreamID: 1, BlockFragment: headers.Bytes(), EndHeaders: true}); err != nil {
+		t.Fatal(err)
+	}
+	body := make([]byte, 5, 5+len(message))
+	body[0] = 1
+	binary.BigEndian.PutUint32(body[1:], uint32(len(message)))
+	if err := framer.WriteData(1, true, append(body, message...)); err != nil {
+		t.Fatal(err)
+	}
+
+	st := &spb.Status{}
+	var data []byte
+	dec := hpack.NewDecoder(4096, func(f hpack.HeaderField) {
+		switch f.Name {
+		case "grpc-status":
+			c, _ := strconv.Atoi(f.Value)
+			st.Code = int32(c)
+		case "grpc-status-details-bin":
+			b, err := base64.RawStdEncoding.DecodeString(f.Value)
+			if err != nil {
+				t.Errorf("grpc-status-details-bin: %v", err)
+			}
+			if err := proto.Unmarshal(b, st); err != nil {
+				t.Errorf("grpc-status-details-bin: %v", err)
+			}
+		}
+	})
+	for {
+		frame, err := framer.ReadFrame()
+		if err != nil {
+			t.Fatalf("ReadFrame: %v", err)
+		}
+		switch f := frame.(type) {
+		case *http2.SettingsFrame:
+			if !f.IsAck() {
+				framer.WriteSettingsAck()
+			}
+		case *http2.DataFrame:
+			data = append(data, f.Data()...)
+		case *http2.HeadersFrame:
+			if _, err := dec.Write(f.HeaderBlockFragment()); err != nil {
+				t.Fatal(err)
+			}
+			if f.StreamEnded() {
+				var messages [][]byte
+				for len(data) >= 5 {
+					n := binary.BigEndian.Uint32(data[1:5])
+					messages = append(messages, data[5:5+n])
+					data = data[5+n:]
+				}
+				return st, messages
+			}
+		case *http2.RSTStreamFrame:
+			t.Fatalf("the server reset the stream: %v", f.ErrCode)
+		}
+	}
+}
+
+func TestDecompressionInterceptors(t *testing.T) {
+	encoding.RegisterCompressor(checkedGzip())
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(DecompressionUnaryInterceptor),
+		grpc.StreamInterceptor(DecompressionStreamInterceptor))
+	pb.RegisterEchoServer(s, testEchoServer{})
+	go s.Serve(lis)
+	defer s.Stop()
+
+	valid := gzipped(t, &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}})
+	wantInfo := showcaseerrors.ErrorInfo(showcaseerrors.RequestDecompressionFailed, showcaseerrors.Domain, map[string]string{"encoding": "gzip"})
+	for _, method := range []string{"/google.showcase.v1beta1.Echo/Echo", "/google.showcase.v1beta1.Echo/Chat"} {
+		st, messages := rawCall(t, lis.Addr().String(), method, "gzip", valid)
+		if st.GetCode() != int32(codes.OK) || len(messages) != 1 {
+			t.Errorf("%s of valid gzip: want one response got %v, %d messages", method, st, len(messages))
+		}
+
+		st, messages = rawCall(t, lis.Addr().String(), method, "gzip", corrupt(valid))
+		if st.GetCode() != int32(codes.InvalidArgument) || len(messages) != 0 {
+			t.Errorf("%s of corrupt gzip: want InvalidArgument got %v, %d messages", method, st, len(messages))
+			continue
+		}
+		if details := st.GetDetails(); len(details) != 1 || !proto.Equal(details[0], wantInfo) {
+			t.Errorf("%s of corrupt gzip: want %v got %v", method, wantInfo, details)
+		}
+	}
+}
+
+// corruptGzip compresses the responses of a server with gzip whose checksum
+// fails.
+type corruptGzip struct{}
+
+func (corruptGzip) Do(w io.Writer, p []byte) error {
+	var buf bytes.Buffer
+	z := gz.NewWriter(&buf)
+	z.Write(p)
+	if err := z.Close(); err != nil {
+		return err
+	}
+	_, err := w.Write(corrupt(buf.Bytes()))
+	return err
+}
+
+func (corruptGzip) Type() string { return "gzip" }
+
+func TestDecompressionClientInterceptor(t *testing.T) {
+	// The CheckedCompressor decompresses the responses to the forwarder,
+	// which is in the same process as the server.
+	encoding.RegisterCompressor(checkedGzip())
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer(grpc.RPCCompressor(corruptGzip{}))
+	pb.RegisterEchoServer(s, testEchoServer{})
+	go s.Serve(lis)
+	defer s.Stop()
+
+	f := NewForwarder()
+	f.Watch(lis.Addr())
+	resp, err := f.Forward(context.Background(), 0, &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("Forward of a corrupt gzip response: want Internal got %v, %v", resp, err)
+	}
+}
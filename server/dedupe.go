@@ -0,0 +1,169 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// MaxDedupeEntries is the most responses the DedupeCache singleton keeps.
+const MaxDedupeEntries = 1000
+
+var dedupeCacheSingleton = NewDedupeCache(Now, MaxDedupeEntries)
+
+// GetDedupeCacheInstance returns the dedupe cache singleton.
+func GetDedupeCacheInstance() DedupeCache {
+	return dedupeCacheSingleton
+}
+
+// RequestHash returns the hex-encoded SHA-256 of the deterministic
+// serialization of the request.
+func RequestHash(req proto.Message) (string, error) {
+	h := sha256.New()
+	if err := writeDeterministic(h, req); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// DedupeCache holds responses by the hash of the request that produced them,
+// so that identical requests within a window are answered without being
+// processed again.
+type DedupeCache interface {
+	// Get returns a copy of the response stored under the key less than
+	// window ago.
+	Get(namespace, key string, window time.Duration) (proto.Message, bool)
+
+	// Put stores a copy of the response under the key, to be kept for at
+	// least ttl. When the cache is full, expired responses are evicted
+	// first, then the oldest.
+	Put(namespace, key string, resp proto.Message, ttl time.Duration)
+
+	// PurgeNamespace removes all responses of the namespace, and returns how
+	// many it removed.
+	PurgeNamespace(namespace string) int
+
+	// List returns copies of all responses, ordered by namespace and key.
+	List() []DedupeEntry
+}
+
+// DedupeEntry is a response held by a DedupeCache.
+type DedupeEntry struct {
+	Namespace string
+	Key       string
+	Response  proto.Message
+	Stored    time.Time
+	Expires   time.Time
+}
+
+// NewDedupeCache returns an empty DedupeCache that holds at most maxEntries
+// responses and uses nowF as its clock.
+func NewDedupeCache(nowF func() time.Time, maxEntries int) DedupeCache {
+	return &dedupeCache{nowF: nowF, maxEntries: maxEntries, entries: map[namespacedName]dedupeEntry{}}
+}
+
+type dedupeCache struct {
+	nowF       func() time.Time
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[namespacedName]dedupeEntry
+}
+
+type dedupeEntry struct {
+	resp    proto.Message
+	stored  time.Time
+	expires time.Time
+}
+
+func (c *dedupeCache) Get(namespace, key string, window time.Duration) (proto.Message, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[namespacedName{namespace, key}]
+	if !ok || c.nowF().Sub(e.stored) >= window {
+		return nil, false
+	}
+	return proto.Clone(e.resp), true
+}
+
+func (c *dedupeCache) Put(namespace, key string, resp proto.Message, ttl time.Duration) {
+	defer ChangeState()()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.nowF()
+	k := namespacedName{namespace, key}
+	if _, ok := c.entries[k]; !ok && len(c.entries) >= c.maxEntries {
+		c.evict(now)
+	}
+	c.entries[k] = dedupeEntry{resp: proto.Clone(resp), stored: now, expires: now.Add(ttl)}
+}
+
+// evict removes the expired entries or, if there are none, the oldest.
+func (c *dedupeCache) evict(now time.Time) {
+	var oldest namespacedName
+	var oldestStored time.Time
+	evicted := false
+	for k, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, k)
+			evicted = true
+			continue
+		}
+		if oldestStored.IsZero() || e.stored.Before(oldestStored) {
+			oldest, oldestStored = k, e.stored
+		}
+	}
+	if !evicted {
+		delete(c.entries, oldest)
+	}
+}
+
+func (c *dedupeCache) PurgeNamespace(namespace string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := 0
+	for k := range c.entries {
+		if k.namespace == namespace {
+			delete(c.entries, k)
+			n++
+		}
+	}
+	return n
+}
+
+func (c *dedupeCache) List() []DedupeEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entries := make([]DedupeEntry, 0, len(c.entries))
+	for k, e := range c.entries {
+		entries = append(entries, DedupeEntry{
+			Namespace: k.namespace,
+			Key:       k.name,
+			Response:  proto.Clone(e.resp),
+			Stored:    e.stored,
+			Expires:   e.expires,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return namespacedName{entries[i].Namespace, entries[i].Key}.less(namespacedName{entries[j].Namespace, entries[j].Key})
+	})
+	return entries
+}
@@ -0,0 +1,126 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+)
+
+func TestRequestHash(t *testing.T) {
+	a, _ := RequestHash(&pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "a"}})
+	again, _ := RequestHash(&pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "a"}})
+	b, _ := RequestHash(&pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "b"}})
+	if a != again || a == b || len(a) != 64 {
+		t.Errorf("RequestHash: want equal hashes of equal requests only got %s, %s, %s", a, again, b)
+	}
+}
+
+func TestDedupeCache(t *testing.T) {
+	now := time.Unix(0, 0)
+	cache := NewDedupeCache(func() time.Time { return now }, 10)
+	resp := &pb.EchoResponse{Content: "hi"}
+	cache.Put("ns", "k", resp, time.Minute)
+
+	// The cache keeps its own copy.
+	resp.Content = "changed"
+	got, ok := cache.Get("ns", "k", time.Minute)
+	if !ok || got.(*pb.EchoResponse).GetContent() != "hi" {
+		t.Errorf("Get: want the stored response got %v, %t", got, ok)
+	}
+	got.(*pb.EchoResponse).Content = "changed"
+	if got, _ := cache.Get("ns", "k", time.Minute); got.(*pb.EchoResponse).GetContent() != "hi" {
+		t.Errorf("Get: want a copy of the response got %v", got)
+	}
+
+	if _, ok := cache.Get("ns", "other", time.Minute); ok {
+		t.Error("Get of another key: want a miss")
+	}
+	if _, ok := cache.Get("other", "k", time.Minute); ok {
+		t.Error("Get in another namespace: want a miss")
+	}
+
+	now = now.Add(30 * time.Second)
+	if _, ok := cache.Get("ns", "k", 30*time.Second); ok {
+		t.Error("Get outside the window: want a miss")
+	}
+	if _, ok := cache.Get("ns", "k", time.Minute); !ok {
+		t.Error("Get inside the window: want a hit")
+	}
+
+	cache.PurgeNamespace("ns")
+	if _, ok := cache.Get("ns", "k", time.Minute); ok {
+		t.Error("PurgeNamespace: want the responses of the namespace removed")
+	}
+}
+
+func TestDedupeCache_eviction(t *testing.T) {
+	now := time.Unix(0, 0)
+	cache := NewDedupeCache(func() time.Time { return now }, 3)
+	resp := &pb.EchoResponse{}
+
+	cache.Put("ns", "short", resp, time.Second)
+	now = now.Add(time.Millisecond)
+	cache.Put("ns", "oldest", resp, time.Hour)
+	now = now.Add(time.Millisecond)
+	cache.Put("ns", "newer", resp, time.Hour)
+
+	// The expired entry goes first, even though it is not the oldest left.
+	now = now.Add(time.Second)
+	cache.Put("ns", "a", resp, time.Hour)
+	if _, ok := cache.Get("ns", "oldest", time.Hour); !ok {
+		t.Error("Put into a full cache: want expired entries evicted first")
+	}
+
+	// Then the oldest.
+	cache.Put("ns", "b", resp, time.Hour)
+	for key, want := range map[string]bool{"oldest": false, "newer": true, "a": true, "b": true} {
+		if _, ok := cache.Get("ns", key, time.Hour); ok != want {
+			t.Errorf("Get(%s) after eviction: want %t got %t", key, want, ok)
+		}
+	}
+
+	// Replacing an entry does not evict another.
+	cache.Put("ns", "b", &pb.EchoResponse{Content: "b"}, time.Hour)
+	if _, ok := cache.Get("ns", "newer", time.Hour); !ok {
+		t.Error("Put of an existing key: want no eviction")
+	}
+	if got, _ := cache.Get("ns", "b", time.Hour); !proto.Equal(got, &pb.EchoResponse{Content: "b"}) {
+		t.Errorf("Put of an existing key: want the new response got %v", got)
+	}
+}
+
+func TestGetDedupeCacheInstance(t *testing.T) {
+	if GetDedupeCacheInstance() != GetDedupeCacheInstance() {
+		t.Error("GetDedupeCacheInstance: want the same cache on every call")
+	}
+}
+
+func TestDedupeCache_List(t *testing.T) {
+	now := time.Unix(0, 0)
+	cache := NewDedupeCache(func() time.Time { return now }, 10)
+	cache.Put("b", "k", &pb.EchoResponse{Content: "b"}, time.Minute)
+	cache.Put("a", "k", &pb.EchoResponse{Content: "a"}, time.Second)
+	got := cache.List()
+	if len(got) != 2 || got[0].Namespace != "a" || got[1].Namespace != "b" {
+		t.Fatalf("List: want the entries of a then b got %v", got)
+	}
+	if !proto.Equal(got[0].Response, &pb.EchoResponse{Content: "a"}) || !got[0].Stored.Equal(now) || !got[0].Expires.Equal(now.Add(time.Second)) {
+		t.Errorf("List: want the entry of a got %+v", got[0])
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+// DeterministicStart is the time the clock of a deterministic server starts
+// at.
+var DeterministicStart = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// DeterministicStep is how far the clock of a deterministic server advances
+// each time it is read.
+const DeterministicStep = time.Millisecond
+
+// DeterministicSeed seeds every pseudo-random source of a deterministic
+// server.
+const DeterministicSeed = 1
+
+var (
+	determinismMu sync.RWMutex
+	deterministic bool
+	clockReads    int64
+)
+
+// SetDeterministic makes the server reproducible when true, for golden
+// tests: Now returns DeterministicStart plus DeterministicStep for each
+// earlier read, and NewRand seeds its sources with DeterministicSeed. Setting
+// it again restarts the clock. Timers still run in real time. It should be
+// called before the services are created, as some of them read the clock
+// when they are.
+func SetDeterministic(on bool) {
+	determinismMu.Lock()
+	defer determinismMu.Unlock()
+	deterministic = on
+	atomic.StoreInt64(&clockReads, 0)
+}
+
+// Deterministic reports whether the server is deterministic.
+func Deterministic() bool {
+	determinismMu.RLock()
+	defer determinismMu.RUnlock()
+	return deterministic
+}
+
+// Now is the clock of the server. It is time.Now unless the server is
+// deterministic. Components take it as their nowF rather than calling
+// time.Now, so that they follow SetDeterministic.
+func Now() time.Time {
+	if !Deterministic() {
+		return time.Now()
+	}
+	reads := atomic.AddInt64(&clockReads, 1) - 1
+	return DeterministicStart.Add(time.Duration(reads) * DeterministicStep)
+}
+
+// NewRand returns a new pseudo-random source for a component of the server,
+// seeded with the time unless the server is deterministic. A source is not
+// safe for concurrent use.
+func NewRand() *rand.Rand {
+	if Deterministic() {
+		return rand.New(rand.NewSource(DeterministicSeed))
+	}
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
+}
+
+// TimestampNow returns Now as a Timestamp, in place of ptypes.TimestampNow.
+func TimestampNow() *timestamp.Timestamp {
+	ts, err := ptypes.TimestampProto(Now())
+	if err != nil {
+		panic(err)
+	}
+	return ts
+}
@@ -0,0 +1,57 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetDeterministic(t *testing.T) {
+	SetDeterministic(true)
+	defer SetDeterministic(false)
+	if !Deterministic() {
+		t.Fatal("Deterministic: want true")
+	}
+	for i := 0; i < 3; i++ {
+		if got, want := Now(), DeterministicStart.Add(time.Duration(i)*DeterministicStep); !got.Equal(want) {
+			t.Errorf("Now: want %s got %s", want, got)
+		}
+	}
+	if got := TimestampNow(); got.GetSeconds() != DeterministicStart.Unix() || got.GetNanos() != int32(3*DeterministicStep) {
+		t.Errorf("TimestampNow: want the fourth reading of the clock got %v", got)
+	}
+
+	// Setting it again restarts the clock.
+	SetDeterministic(true)
+	if got := Now(); !got.Equal(DeterministicStart) {
+		t.Errorf("Now after SetDeterministic: want %s got %s", DeterministicStart, got)
+	}
+
+	a, b := NewRand(), NewRand()
+	for i := 0; i < 5; i++ {
+		if x, y := a.Int63(), b.Int63(); x != y {
+			t.Errorf("NewRand: want sources with the same seed got %d and %d", x, y)
+		}
+	}
+
+	SetDeterministic(false)
+	if Deterministic() {
+		t.Error("Deterministic: want false")
+	}
+	if got := Now(); time.Since(got) > time.Minute {
+		t.Errorf("Now: want the real time got %s", got)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// EchoDigestHeader is the metadata key that asks the server to return
+	// the digest of the requests it decoded. Its value must be "true".
+	EchoDigestHeader = "showcase-echo-digest"
+
+	// RequestDigestTrailer is the trailer key of the hex-encoded SHA-256 of
+	// the deterministic serialization of the decoded request. For streaming
+	// calls it is the SHA-256 of the concatenated serializations of the
+	// requests received, in order.
+	RequestDigestTrailer = "showcase-request-digest"
+)
+
+// wantsEchoDigest reports whether the incoming metadata of the context asks
+// for the request digest.
+func wantsEchoDigest(ctx context.Context) bool {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(EchoDigestHeader)
+	return len(values) == 1 && values[0] == "true"
+}
+
+// writeDeterministic writes the deterministic serialization of the message
+// to the hash.
+func writeDeterministic(h hash.Hash, m interface{}) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return nil
+	}
+	buf := proto.NewBuffer(nil)
+	buf.SetDeterministic(true)
+	if err := buf.Marshal(msg); err != nil {
+		return err
+	}
+	h.Write(buf.Bytes())
+	return nil
+}
+
+func digestTrailer(h hash.Hash) metadata.MD {
+	return metadata.Pairs(RequestDigestTrailer, hex.EncodeToString(h.Sum(nil)))
+}
+
+// EchoDigestUnaryInterceptor returns the digest of the decoded request in a
+// trailer of unary calls that ask for it.
+func EchoDigestUnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	if !wantsEchoDigest(ctx) {
+		return handler(ctx, req)
+	}
+	h := sha256.New()
+	if err := writeDeterministic(h, req); err != nil {
+		return nil, err
+	}
+	if err := grpc.SetTrailer(ctx, digestTrailer(h)); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// EchoDigestStreamInterceptor returns the digest of the decoded requests in a
+// trailer of streaming calls that ask for it.
+func EchoDigestStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	if !wantsEchoDigest(ss.Context()) {
+		return handler(srv, ss)
+	}
+	stream := &digestStream{ServerStream: ss, h: sha256.New()}
+	err := handler(srv, stream)
+	ss.SetTrailer(digestTrailer(stream.h))
+	return err
+}
+
+type digestStream struct {
+	grpc.ServerStream
+	h hash.Hash
+}
+
+func (s *digestStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return writeDeterministic(s.h, m)
+}
@@ -0,0 +1,136 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// wantDigest returns the hex-encoded SHA-256 of the concatenated
+// deterministic serializations of the messages.
+func wantDigest(t *testing.T, msgs ...proto.Message) string {
+	h := sha256.New()
+	for _, m := range msgs {
+		buf := proto.NewBuffer(nil)
+		buf.SetDeterministic(true)
+		if err := buf.Marshal(m); err != nil {
+			t.Fatal(err)
+		}
+		h.Write(buf.Bytes())
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func startDigestServer(t *testing.T) (pb.EchoClient, func()) {
+	return startEchoServer(t,
+		grpc.UnaryInterceptor(EchoDigestUnaryInterceptor),
+		grpc.StreamInterceptor(EchoDigestStreamInterceptor))
+}
+
+func digestContext() context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), EchoDigestHeader, "true")
+}
+
+func TestEchoDigestUnaryInterceptor(t *testing.T) {
+	client, stop := startDigestServer(t)
+	defer stop()
+
+	req := &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hello"}, ClientSequence: 3}
+	var trailer metadata.MD
+	if _, err := client.Echo(digestContext(), req, grpc.Trailer(&trailer)); err != nil {
+		t.Fatalf("Echo: unexpected err %+v", err)
+	}
+	if got, want := trailer.Get(RequestDigestTrailer), wantDigest(t, req); len(got) != 1 || got[0] != want {
+		t.Errorf("Echo: want digest %q got %q", want, got)
+	}
+
+	trailer = nil
+	if _, err := client.Echo(context.Background(), req, grpc.Trailer(&trailer)); err != nil {
+		t.Fatalf("Echo: unexpected err %+v", err)
+	}
+	if got := trailer.Get(RequestDigestTrailer); len(got) != 0 {
+		t.Errorf("Echo without %s: want no digest got %q", EchoDigestHeader, got)
+	}
+}
+
+// digestTransportStream records the trailers set on it.
+type digestTransportStream struct {
+	grpc.ServerTransportStream
+	trailer metadata.MD
+}
+
+func (s *digestTransportStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+func TestEchoDigestUnaryInterceptor_map(t *testing.T) {
+	// Maps are serialized in an arbitrary order unless the serialization is
+	// deterministic.
+	req := &pb.ServerMetrics{Values: map[string]int64{"a": 1, "b": 2, "c": 3, "d": 4}}
+	want := wantDigest(t, req)
+	for i := 0; i < 10; i++ {
+		ts := &digestTransportStream{}
+		ctx := grpc.NewContextWithServerTransportStream(
+			metadata.NewIncomingContext(context.Background(), metadata.Pairs(EchoDigestHeader, "true")), ts)
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		}
+		if _, err := EchoDigestUnaryInterceptor(ctx, req, &grpc.UnaryServerInfo{}, handler); err != nil {
+			t.Fatalf("EchoDigestUnaryInterceptor: unexpected err %+v", err)
+		}
+		if got := ts.trailer.Get(RequestDigestTrailer); len(got) != 1 || got[0] != want {
+			t.Fatalf("EchoDigestUnaryInterceptor: want digest %q got %q", want, got)
+		}
+	}
+}
+
+func TestEchoDigestStreamInterceptor(t *testing.T) {
+	client, stop := startDigestServer(t)
+	defer stop()
+
+	chat, err := client.Chat(digestContext())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reqs []proto.Message
+	for _, content := range []string{"a", "b", "c"} {
+		req := &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: content}}
+		if err := chat.Send(req); err != nil {
+			t.Fatal(err)
+		}
+		reqs = append(reqs, req)
+	}
+	chat.CloseSend()
+	for {
+		if _, err := chat.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("Chat.Recv: unexpected err %+v", err)
+		}
+	}
+	if got, want := chat.Trailer().Get(RequestDigestTrailer), wantDigest(t, reqs...); len(got) != 1 || got[0] != want {
+		t.Errorf("Chat: want digest %q got %q", want, got)
+	}
+}
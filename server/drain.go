@@ -0,0 +1,226 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"google.golang.org/grpc/stats"
+)
+
+// ConnectionDrainer asks clients to move to a new connection once a
+// connection has served a given number of RPCs. It sends the connection an
+// HTTP/2 GOAWAY frame as the last of those RPCs starts, so RPCs in flight,
+// including streams, run to completion while new ones go to a new connection.
+// Sending it before the last response rather than after means a client that
+// issues RPCs one at a time never races the GOAWAY with its next RPC.
+//
+// The drainer must both wrap the listener the server serves on and be
+// installed with grpc.StatsHandler. It writes the GOAWAY frame into the raw
+// connection, so it only works for servers without transport security.
+type ConnectionDrainer struct {
+	maxRPCs int
+
+	mu    sync.Mutex
+	conns map[string]*drainConn
+}
+
+// NewConnectionDrainer returns a drainer that drains each connection once it
+// has served maxRPCs RPCs.
+func NewConnectionDrainer(maxRPCs int) *ConnectionDrainer {
+	return &ConnectionDrainer{maxRPCs: maxRPCs, conns: map[string]*drainConn{}}
+}
+
+// Listener wraps lis so that the drainer can drain the connections it accepts.
+func (d *ConnectionDrainer) Listener(lis net.Listener) net.Listener {
+	return &drainListener{Listener: lis, drainer: d}
+}
+
+type drainConnKey struct{}
+
+// TagConn implements the stats.Handler interface.
+func (d *ConnectionDrainer) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if c, ok := d.conns[connKey(info.LocalAddr, info.RemoteAddr)]; ok {
+		return context.WithValue(ctx, drainConnKey{}, c)
+	}
+	return ctx
+}
+
+// HandleConn implements the stats.Handler interface.
+func (d *ConnectionDrainer) HandleConn(ctx context.Context, s stats.ConnStats) {
+	if _, ok := s.(*stats.ConnEnd); !ok {
+		return
+	}
+	if c, ok := ctx.Value(drainConnKey{}).(*drainConn); ok {
+		d.forget(c)
+	}
+}
+
+// TagRPC implements the stats.Handler interface.
+func (d *ConnectionDrainer) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+// HandleRPC implements the stats.Handler interface.
+func (d *ConnectionDrainer) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	if _, ok := s.(*stats.Begin); !ok {
+		return
+	}
+	if c, ok := ctx.Value(drainConnKey{}).(*drainConn); ok && c.startRPC() >= d.maxRPCs {
+		c.drain()
+	}
+}
+
+func (d *ConnectionDrainer) forget(c *drainConn) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.conns[c.key] == c {
+		delete(d.conns, c.key)
+	}
+}
+
+func connKey(local, remote net.Addr) string {
+	return local.String() + " " + remote.String()
+}
+
+type drainListener struct {
+	net.Listener
+	drainer *ConnectionDrainer
+}
+
+func (l *drainListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	c := &drainConn{
+		Conn:    conn,
+		drainer: l.drainer,
+		key:     connKey(conn.LocalAddr(), conn.RemoteAddr()),
+	}
+	l.drainer.mu.Lock()
+	l.drainer.conns[c.key] = c
+	l.drainer.mu.Unlock()
+	return c, nil
+}
+
+// goAwayFrame is an HTTP/2 GOAWAY frame with NO_ERROR. Its last stream ID is
+// the largest possible, as in the first frame of a graceful shutdown, so
+// clients finish every stream they have started.
+var goAwayFrame = []byte{
+	0, 0, 8, // Length.
+	0x7,        // Type GOAWAY.
+	0,          // Flags.
+	0, 0, 0, 0, // Stream 0.
+	0x7f, 0xff, 0xff, 0xff, // Last stream ID.
+	0, 0, 0, 0, // NO_ERROR.
+}
+
+// drainConn is a server connection that can have a GOAWAY frame slipped in
+// between the frames the server writes.
+type drainConn struct {
+	net.Conn
+	drainer *ConnectionDrainer
+	key     string
+
+	mu   sync.Mutex
+	rpcs int
+	// The header of the frame being written, and the bytes of its payload
+	// left to write.
+	header      [9]byte
+	headerLen   int
+	payloadLeft int
+	// Whether a GOAWAY frame is waiting for a frame boundary, and whether one
+	// has been sent.
+	pending bool
+	drained bool
+}
+
+func (c *drainConn) startRPC() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rpcs++
+	return c.rpcs
+}
+
+// drain sends the GOAWAY frame now if no frame is partially written, and
+// otherwise once the frame being written is complete.
+func (c *drainConn) drain() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.drained || c.pending {
+		return
+	}
+	c.pending = true
+	if c.headerLen == 0 {
+		c.sendGoAway()
+	}
+}
+
+// sendGoAway writes the GOAWAY frame. It must be called with the lock held at
+// a frame boundary.
+func (c *drainConn) sendGoAway() error {
+	c.pending = false
+	c.drained = true
+	_, err := c.Conn.Write(goAwayFrame)
+	return err
+}
+
+func (c *drainConn) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	written := 0
+	for len(p) > 0 {
+		var n int
+		if c.headerLen < len(c.header) {
+			n = copy(c.header[c.headerLen:], p)
+			c.headerLen += n
+			if c.headerLen == len(c.header) {
+				c.payloadLeft = int(c.header[0])<<16 | int(c.header[1])<<8 | int(c.header[2])
+			}
+		} else {
+			n = c.payloadLeft
+			if n > len(p) {
+				n = len(p)
+			}
+			c.payloadLeft -= n
+		}
+		if c.headerLen == len(c.header) && c.payloadLeft == 0 {
+			c.headerLen = 0
+		}
+
+		m, err := c.Conn.Write(p[:n])
+		written += m
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+		if c.pending && c.headerLen == 0 {
+			if err := c.sendGoAway(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+func (c *drainConn) Close() error {
+	c.drainer.forget(c)
+	return c.Conn.Close()
+}
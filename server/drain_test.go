@@ -0,0 +1,220 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// testEchoServer echoes the content of Echo and Chat requests, and the client
+// sequence of Echo requests. Every blob it has a write status for is five
+// bytes long and uncommitted.
+type testEchoServer struct {
+	pb.EchoServer
+}
+
+func (testEchoServer) Echo(ctx context.Context, in *pb.EchoRequest) (*pb.EchoResponse, error) {
+	if in.GetCacheControl() != nil {
+		SetHeader(ctx, metadata.Pairs(CacheControlHeader, fmt.Sprintf("max-age=%d", in.GetCacheControl().GetMaxAge())))
+	}
+	return &pb.EchoResponse{Content: in.GetContent(), ClientSequence: in.GetClientSequence()}, nil
+}
+
+func (testEchoServer) GetWriteStatus(_ context.Context, in *pb.GetWriteStatusRequest) (*pb.WriteStatus, error) {
+	return &pb.WriteStatus{BlobId: in.GetBlobId(), TotalSize: 5}, nil
+}
+
+func (testEchoServer) RepeatNumericEdgeCases(_ context.Context, in *pb.RepeatNumericEdgeCasesRequest) (*pb.RepeatNumericEdgeCasesResponse, error) {
+	return &pb.RepeatNumericEdgeCasesResponse{Values: in.GetValues()}, nil
+}
+
+func (testEchoServer) Chat(stream pb.Echo_ChatServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&pb.EchoResponse{Content: req.GetContent()}); err != nil {
+			return err
+		}
+	}
+}
+
+// countingListener counts the connections it accepts.
+type countingListener struct {
+	net.Listener
+
+	mu    sync.Mutex
+	conns int
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		l.mu.Lock()
+		l.conns++
+		l.mu.Unlock()
+	}
+	return conn, err
+}
+
+func (l *countingListener) count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.conns
+}
+
+// startDrainingServer serves an Echo server that drains connections after
+// maxRPCs RPCs, and returns a client of it.
+func startDrainingServer(t *testing.T, maxRPCs int) (pb.EchoClient, *countingListener, func()) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter := &countingListener{Listener: lis}
+	drainer := NewConnectionDrainer(maxRPCs)
+	s := grpc.NewServer(grpc.StatsHandler(drainer))
+	pb.RegisterEchoServer(s, testEchoServer{})
+	go s.Serve(drainer.Listener(counter))
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pb.NewEchoClient(conn), counter, func() {
+		conn.Close()
+		s.Stop()
+	}
+}
+
+// echoAcrossDrains calls Echo, retrying an RPC that the client started on a
+// connection it had just been told to leave. This version of gRPC fails such
+// an RPC with UNAVAILABLE instead of moving it to the new connection, which
+// is the client's choice to make rather than the server's.
+func echoAcrossDrains(client pb.EchoClient) error {
+	req := &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}}
+	for attempt := 0; ; attempt++ {
+		_, err := client.Echo(context.Background(), req, grpc.WaitForReady(true))
+		if status.Code(err) != codes.Unavailable || attempt == 2 {
+			return err
+		}
+	}
+}
+
+func TestConnectionDrainer(t *testing.T) {
+	const maxRPCs = 3
+	client, counter, stop := startDrainingServer(t, maxRPCs)
+	defer stop()
+
+	for i := 0; i < maxRPCs+5; i++ {
+		if err := echoAcrossDrains(client); err != nil {
+			t.Fatalf("Echo %d: unexpected err %+v", i, err)
+		}
+	}
+	if n := counter.count(); n < 2 {
+		t.Errorf("ConnectionDrainer: want at least 2 connections for %d RPCs got %d", maxRPCs+5, n)
+	}
+}
+
+func TestConnectionDrainer_streamInFlight(t *testing.T) {
+	const maxRPCs = 2
+	client, counter, stop := startDrainingServer(t, maxRPCs)
+	defer stop()
+
+	chat, err := client.Chat(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	send := func(content string) {
+		if err := chat.Send(&pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: content}}); err != nil {
+			t.Fatalf("Chat.Send(%s): unexpected err %+v", content, err)
+		}
+		resp, err := chat.Recv()
+		if err != nil || resp.GetContent() != content {
+			t.Fatalf("Chat.Recv: want %s got %v, %v", content, resp, err)
+		}
+	}
+	send("before")
+
+	// Drain the stream's connection.
+	for i := 0; i < maxRPCs+1; i++ {
+		if err := echoAcrossDrains(client); err != nil {
+			t.Fatalf("Echo %d: unexpected err %+v", i, err)
+		}
+	}
+	if n := counter.count(); n < 2 {
+		t.Errorf("ConnectionDrainer: want a new connection after draining got %d", n)
+	}
+
+	// The stream keeps working on the drained connection.
+	send("after")
+	if err := chat.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chat.Recv(); err != io.EOF {
+		t.Errorf("Chat.Recv: want EOF got %v", err)
+	}
+}
+
+// frameRecorder records what is written to it.
+type frameRecorder struct {
+	net.Conn
+	buf bytes.Buffer
+}
+
+func (r *frameRecorder) Write(p []byte) (int, error) {
+	return r.buf.Write(p)
+}
+
+func TestDrainConn_frameBoundary(t *testing.T) {
+	rec := &frameRecorder{}
+	c := &drainConn{Conn: rec, drainer: NewConnectionDrainer(1)}
+
+	frame := []byte{0, 0, 2, 0x0, 0, 0, 0, 0, 1, 'h', 'i'}
+	// Split the frame mid-header and mid-payload.
+	c.Write(frame[:4])
+	c.drain()
+	if rec.buf.Len() != 4 {
+		t.Fatalf("drain: want the GOAWAY deferred to the frame boundary, wrote %v", rec.buf.Bytes())
+	}
+	c.Write(frame[4:10])
+	c.Write(append(frame[10:], frame...))
+
+	want := append(append(append([]byte(nil), frame...), goAwayFrame...), frame...)
+	if !bytes.Equal(rec.buf.Bytes(), want) {
+		t.Errorf("drainConn: want %v got %v", want, rec.buf.Bytes())
+	}
+
+	// A drained connection is not drained again.
+	c.drain()
+	if !bytes.Equal(rec.buf.Bytes(), want) {
+		t.Errorf("drain: want a single GOAWAY got %v", rec.buf.Bytes())
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sort"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MaxEchoResources is the maximum number of resources an EchoResourceStore
+// keeps.
+const MaxEchoResources = 1000
+
+var echoResourceStoreSingleton = NewEchoResourceStore()
+
+// GetEchoResourceStoreInstance returns the echo resource store singleton.
+func GetEchoResourceStoreInstance() EchoResourceStore {
+	return echoResourceStoreSingleton
+}
+
+// EchoResourceStore holds the names of the resources created with
+// Echo.CreateEchoResource. Each namespace has its own resources, but the
+// limit applies to the whole store.
+type EchoResourceStore interface {
+	// Create adds the name. It fails with ALREADY_EXISTS if the name is
+	// taken and RESOURCE_EXHAUSTED if the store is full.
+	Create(namespace, name string) error
+
+	// Exists reports whether the name has been created and not deleted.
+	Exists(namespace, name string) bool
+
+	// Delete removes the name, reporting whether it existed.
+	Delete(namespace, name string) bool
+
+	// PurgeNamespace removes all resources of the namespace, and returns how
+	// many it removed.
+	PurgeNamespace(namespace string) int
+
+	// List returns the names of the resources of each namespace, in order.
+	List() map[string][]string
+}
+
+// NewEchoResourceStore returns an empty EchoResourceStore.
+func NewEchoResourceStore() EchoResourceStore {
+	return &echoResourceStore{names: map[namespacedName]bool{}}
+}
+
+type echoResourceStore struct {
+	mu    sync.Mutex
+	names map[namespacedName]bool
+}
+
+func (e *echoResourceStore) Create(namespace, name string) error {
+	key := namespacedName{namespace, name}
+	defer ChangeState()()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.names[key] {
+		return status.Errorf(codes.AlreadyExists, "The echo resource %q already exists.", name)
+	}
+	if len(e.names) >= MaxEchoResources {
+		return status.Errorf(codes.ResourceExhausted, "At most %d echo resources may be stored.", MaxEchoResources)
+	}
+	e.names[key] = true
+	return nil
+}
+
+func (e *echoResourceStore) Exists(namespace, name string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.names[namespacedName{namespace, name}]
+}
+
+func (e *echoResourceStore) Delete(namespace, name string) bool {
+	key := namespacedName{namespace, name}
+	defer ChangeState()()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ok := e.names[key]
+	delete(e.names, key)
+	return ok
+}
+
+func (e *echoResourceStore) PurgeNamespace(namespace string) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	n := 0
+	for key := range e.names {
+		if key.namespace == namespace {
+			delete(e.names, key)
+			n++
+		}
+	}
+	return n
+}
+
+func (e *echoResourceStore) List() map[string][]string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	names := map[string][]string{}
+	for key := range e.names {
+		names[key.namespace] = append(names[key.namespace], key.name)
+	}
+	for _, n := range names {
+		sort.Strings(n)
+	}
+	return names
+}
@@ -0,0 +1,88 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEchoResourceStore(t *testing.T) {
+	store := NewEchoResourceStore()
+	if store.Exists("ns", "r") {
+		t.Error("Exists: want false before Create")
+	}
+	if err := store.Create("ns", "r"); err != nil {
+		t.Fatalf("Create: unexpected err %+v", err)
+	}
+	if !store.Exists("ns", "r") {
+		t.Error("Exists: want true after Create")
+	}
+	if err := store.Create("ns", "r"); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Create of an existing resource: want AlreadyExists got %v", err)
+	}
+	if !store.Delete("ns", "r") {
+		t.Error("Delete: want true for an existing resource")
+	}
+	if store.Delete("ns", "r") || store.Exists("ns", "r") {
+		t.Error("Delete: want the resource gone")
+	}
+}
+
+func TestEchoResourceStore_limit(t *testing.T) {
+	store := NewEchoResourceStore()
+	for i := 0; i < MaxEchoResources; i++ {
+		if err := store.Create("ns", fmt.Sprint(i)); err != nil {
+			t.Fatalf("Create(%d): unexpected err %+v", i, err)
+		}
+	}
+	if err := store.Create("ns", "full"); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Create in a full store: want ResourceExhausted got %v", err)
+	}
+}
+
+func TestEchoResourceStore_namespaces(t *testing.T) {
+	store := NewEchoResourceStore()
+	store.Create("a", "r")
+	if store.Exists("b", "r") {
+		t.Error("Exists: want resources scoped to their namespace")
+	}
+	store.Create("b", "r")
+	store.PurgeNamespace("a")
+	if store.Exists("a", "r") || !store.Exists("b", "r") {
+		t.Error("PurgeNamespace: want only the resources of the namespace removed")
+	}
+}
+
+func TestGetEchoResourceStoreInstance(t *testing.T) {
+	if GetEchoResourceStoreInstance() != GetEchoResourceStoreInstance() {
+		t.Error("GetEchoResourceStoreInstance: want the same store on every call")
+	}
+}
+
+func TestEchoResourceStore_List(t *testing.T) {
+	store := NewEchoResourceStore()
+	store.Create("a", "y")
+	store.Create("a", "x")
+	store.Create("b", "z")
+	want := map[string][]string{"a": {"x", "y"}, "b": {"z"}}
+	if got := store.List(); !reflect.DeepEqual(got, want) {
+		t.Errorf("List: want %v got %v", want, got)
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	descpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	_ "google.golang.org/genproto/googleapis/longrunning"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorInjection is a rate of artificial errors.
+type ErrorInjection struct {
+	// The fraction of calls, from 0 to 1, that fail.
+	ErrorRate float64
+
+	// The code of the errors. OK means UNAVAILABLE.
+	Code codes.Code
+
+	// If positive, the errors carry a RetryInfo with this delay.
+	RetryInfoDelay time.Duration
+}
+
+// updateSettingsMethod is the full name of the UpdateShowcaseSettings
+// method, which is never failed so that the errors can be turned off.
+const updateSettingsMethod = "/google.showcase.v1beta1.Testing/UpdateShowcaseSettings"
+
+// showcaseServiceFiles are the proto files of the services the Showcase
+// server registers.
+var showcaseServiceFiles = []string{
+	"google/showcase/v1beta1/echo.proto",
+	"google/showcase/v1beta1/identity.proto",
+	"google/showcase/v1beta1/messaging.proto",
+	"google/showcase/v1beta1/testing.proto",
+	"google/longrunning/operations.proto",
+}
+
+var (
+	showcaseMethodsOnce sync.Once
+	showcaseMethods     map[string]showcaseMethod
+)
+
+// showcaseMethod holds the full proto names of the request and response
+// messages of a method, and whether each side streams them.
+type showcaseMethod struct {
+	input, output                string
+	clientStreams, serverStreams bool
+}
+
+// ShowcaseMethods returns the full gRPC names of the methods the Showcase
+// server registers, sorted.
+func ShowcaseMethods() []string {
+	names := []string{}
+	for name := range showcaseMethodTypes() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ShowcaseMethodInput returns the full proto name of the request message of
+// the Showcase method with the full gRPC name, or reports false if there is
+// no such method.
+func ShowcaseMethodInput(method string) (string, bool) {
+	m, ok := showcaseMethodTypes()[method]
+	return m.input, ok
+}
+
+// ShowcaseMethodOutput returns the full proto name of the response message
+// of the Showcase method with the full gRPC name, or reports false if there
+// is no such method.
+func ShowcaseMethodOutput(method string) (string, bool) {
+	m, ok := showcaseMethodTypes()[method]
+	return m.output, ok
+}
+
+// ShowcaseMethodStreams reports whether the client and the server of the
+// Showcase method with the full gRPC name stream their messages. The last
+// return value is false if there is no such method.
+func ShowcaseMethodStreams(method string) (client, server, ok bool) {
+	m, ok := showcaseMethodTypes()[method]
+	return m.clientStreams, m.serverStreams, ok
+}
+
+// showcaseMethodTypes returns the message types of the Showcase methods, by
+// the full gRPC names of the methods.
+func showcaseMethodTypes() map[string]showcaseMethod {
+	showcaseMethodsOnce.Do(func() {
+		showcaseMethods = map[string]showcaseMethod{}
+		for _, file := range showcaseServiceFiles {
+			fd, err := fileDescriptor(file)
+			if err != nil {
+				panic(err)
+			}
+			for _, svc := range fd.GetService() {
+				for _, m := range svc.GetMethod() {
+					showcaseMethods[fmt.Sprintf("/%s.%s/%s", fd.GetPackage(), svc.GetName(), m.GetName())] = showcaseMethod{
+						input:         strings.TrimPrefix(m.GetInputType(), "."),
+						output:        strings.TrimPrefix(m.GetOutputType(), "."),
+						clientStreams: m.GetClientStreaming(),
+						serverStreams: m.GetServerStreaming(),
+					}
+				}
+			}
+		}
+	})
+	return showcaseMethods
+}
+
+// fileDescriptor decodes the registered descriptor of a proto file.
+func fileDescriptor(file string) (*descpb.FileDescriptorProto, error) {
+	gz := proto.FileDescriptor(file)
+	if gz == nil {
+		return nil, fmt.Errorf("the proto file %s is not registered", file)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	fd := &descpb.FileDescriptorProto{}
+	return fd, proto.Unmarshal(b, fd)
+}
+
+// validateErrorInjection returns an INVALID_ARGUMENT error if the setting
+// named field is not a valid ErrorInjection.
+func validateErrorInjection(field string, inj ErrorInjection) error {
+	if inj.ErrorRate < 0 || inj.ErrorRate > 1 {
+		return showcaseerrors.Setting(field, "The setting `%s.error_rate` must be between 0 and 1.", field)
+	}
+	if inj.Code < 0 || inj.Code > codes.Unauthenticated {
+		return showcaseerrors.Setting(field, "The setting `%s.code` is not a valid google.rpc.Code.", field)
+	}
+	if inj.RetryInfoDelay < 0 {
+		return showcaseerrors.Setting(field, "The setting `%s.retry_info_delay` must not be negative.", field)
+	}
+	return nil
+}
+
+// ErrorInjector fails calls at the rates of the ErrorInjection settings,
+// before they reach their handlers.
+type ErrorInjector struct {
+	settings SettingsStore
+
+	mu    sync.Mutex
+	randF func() float64
+}
+
+// NewErrorInjector returns an ErrorInjector that reads its rates from the
+// settings and draws from randF, which returns numbers in [0, 1). A nil
+// randF draws from a source returned by NewRand.
+func NewErrorInjector(settings SettingsStore, randF func() float64) *ErrorInjector {
+	if randF == nil {
+		randF = NewRand().Float64
+	}
+	return &ErrorInjector{settings: settings, randF: randF}
+}
+
+// inject returns the artificial error of a call to the method, if it is to
+// fail.
+func (e *ErrorInjector) inject(method string) error {
+	if method == updateSettingsMethod {
+		return nil
+	}
+	settings := e.settings.Get()
+	inj, ok := settings.MethodErrorInjection[method]
+	if !ok {
+		inj = settings.ErrorInjection
+	}
+	if inj.ErrorRate <= 0 {
+		return nil
+	}
+	e.mu.Lock()
+	draw := e.randF()
+	e.mu.Unlock()
+	if draw >= inj.ErrorRate {
+		return nil
+	}
+	code := inj.Code
+	if code == codes.OK {
+		code = codes.Unavailable
+	}
+	st := status.Newf(code, "The call to %s failed with an injected error.", method)
+	if inj.RetryInfoDelay > 0 {
+		st, _ = st.WithDetails(&errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(inj.RetryInfoDelay)})
+	}
+	return st.Err()
+}
+
+// UnaryInterceptor implements the grpc.UnaryServerInterceptor type.
+func (e *ErrorInjector) UnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	if err := e.inject(info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor implements the grpc.StreamServerInterceptor type.
+func (e *ErrorInjector) StreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	if err := e.inject(info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
@@ -0,0 +1,189 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	listBlurbsMethod = "/google.showcase.v1beta1.Messaging/ListBlurbs"
+	getBlurbMethod   = "/google.showcase.v1beta1.Messaging/GetBlurb"
+)
+
+func TestShowcaseMethods(t *testing.T) {
+	methods := map[string]bool{}
+	for _, m := range ShowcaseMethods() {
+		methods[m] = true
+	}
+	for _, want := range []string{
+		"/google.showcase.v1beta1.Echo/Echo",
+		listBlurbsMethod,
+		updateSettingsMethod,
+		"/google.showcase.v1beta1.Identity/GetUser",
+		"/google.longrunning.Operations/GetOperation",
+	} {
+		if !methods[want] {
+			t.Errorf("ShowcaseMethods: want %s got %v", want, ShowcaseMethods())
+		}
+	}
+}
+
+func TestShowcaseMethodTypes(t *testing.T) {
+	input, ok := ShowcaseMethodInput("/google.showcase.v1beta1.Echo/Expand")
+	if !ok || input != "google.showcase.v1beta1.ExpandRequest" {
+		t.Errorf("ShowcaseMethodInput(Expand): want google.showcase.v1beta1.ExpandRequest got %q, %t", input, ok)
+	}
+	output, ok := ShowcaseMethodOutput("/google.longrunning.Operations/GetOperation")
+	if !ok || output != "google.longrunning.Operation" {
+		t.Errorf("ShowcaseMethodOutput(GetOperation): want google.longrunning.Operation got %q, %t", output, ok)
+	}
+	if _, ok := ShowcaseMethodOutput("/google.showcase.v1beta1.Echo/Nothing"); ok {
+		t.Errorf("ShowcaseMethodOutput of an unknown method: want false")
+	}
+	for method, want := range map[string][2]bool{
+		"/google.showcase.v1beta1.Echo/Echo":    {false, false},
+		"/google.showcase.v1beta1.Echo/Expand":  {false, true},
+		"/google.showcase.v1beta1.Echo/Collect": {true, false},
+		"/google.showcase.v1beta1.Echo/Chat":    {true, true},
+	} {
+		if client, server, ok := ShowcaseMethodStreams(method); !ok || [2]bool{client, server} != want {
+			t.Errorf("ShowcaseMethodStreams(%s): want client and server streams %v got %t, %t, %t", method, want, client, server, ok)
+		}
+	}
+}
+
+// draws returns a randF that cycles through the draws.
+func draws(values ...float64) func() float64 {
+	i := 0
+	return func() float64 {
+		d := values[i%len(values)]
+		i++
+		return d
+	}
+}
+
+// callInjector makes n unary calls of the method through the injector and
+// returns the number that reached the handler and the last error.
+func callInjector(e *ErrorInjector, method string, n int) (int, error) {
+	handled := 0
+	var last error
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handled++
+		return req, nil
+	}
+	for i := 0; i < n; i++ {
+		if _, err := e.UnaryInterceptor(context.Background(), "req", &grpc.UnaryServerInfo{FullMethod: method}, handler); err != nil {
+			last = err
+		}
+	}
+	return handled, last
+}
+
+func TestErrorInjector_methodOverridesGlobal(t *testing.T) {
+	store := NewSettingsStore(DefaultSettings())
+	_, _, err := UpdateSettings(store, &pb.ShowcaseSettings{
+		ErrorInjection: &pb.ErrorInjection{ErrorRate: 0.5},
+		MethodErrorInjection: map[string]*pb.ErrorInjection{
+			listBlurbsMethod: {ErrorRate: 1, Code: int32(codes.ResourceExhausted), RetryInfoDelay: ptypes.DurationProto(3 * time.Second)},
+			getBlurbMethod:   {ErrorRate: 0},
+		},
+	}, []string{"error_injection", "method_error_injection"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Draws spread evenly over [0, 1).
+	e := NewErrorInjector(store, draws(0, 0.25, 0.5, 0.75))
+
+	handled, err := callInjector(e, listBlurbsMethod, 100)
+	if handled != 0 {
+		t.Errorf("ListBlurbs at 100%%: want no calls handled got %d", handled)
+	}
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted || len(st.Details()) != 1 {
+		t.Fatalf("ListBlurbs at 100%%: want ResourceExhausted with a RetryInfo got %v", err)
+	}
+	info, ok := st.Details()[0].(*errdetails.RetryInfo)
+	if !ok {
+		t.Fatalf("ListBlurbs at 100%%: want a RetryInfo got %v", st.Details()[0])
+	}
+	if d, _ := ptypes.Duration(info.GetRetryDelay()); d != 3*time.Second {
+		t.Errorf("ListBlurbs at 100%%: want a retry delay of 3s got %s", d)
+	}
+
+	if handled, err := callInjector(e, getBlurbMethod, 100); handled != 100 {
+		t.Errorf("GetBlurb at 0%%: want every call handled got %d, %v", handled, err)
+	}
+
+	handled, err = callInjector(e, "/google.showcase.v1beta1.Echo/Echo", 100)
+	if handled != 50 {
+		t.Errorf("Echo at the global 50%%: want 50 calls handled got %d", handled)
+	}
+	if st := status.Convert(err); st.Code() != codes.Unavailable || len(st.Details()) != 0 {
+		t.Errorf("Echo at the global 50%%: want Unavailable without details got %v", err)
+	}
+
+	if handled, err := callInjector(e, updateSettingsMethod, 100); handled != 100 {
+		t.Errorf("UpdateShowcaseSettings: want every call handled got %d, %v", handled, err)
+	}
+}
+
+func TestErrorInjector_stream(t *testing.T) {
+	settings := DefaultSettings()
+	settings.MethodErrorInjection = map[string]ErrorInjection{"/google.showcase.v1beta1.Echo/Expand": {ErrorRate: 1, Code: codes.Aborted}}
+	e := NewErrorInjector(NewSettingsStore(settings), nil)
+	handled := false
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		handled = true
+		return nil
+	}
+	err := e.StreamInterceptor(nil, nil, &grpc.StreamServerInfo{FullMethod: "/google.showcase.v1beta1.Echo/Expand"}, handler)
+	if status.Code(err) != codes.Aborted || handled {
+		t.Errorf("StreamInterceptor: want Aborted before the handler got %v, handled %t", err, handled)
+	}
+	err = e.StreamInterceptor(nil, nil, &grpc.StreamServerInfo{FullMethod: "/google.showcase.v1beta1.Echo/Chat"}, handler)
+	if err != nil || !handled {
+		t.Errorf("StreamInterceptor: want other methods handled got %v, handled %t", err, handled)
+	}
+}
+
+func TestUpdateSettings_unknownErrorInjectionMethod(t *testing.T) {
+	store := NewSettingsStore(DefaultSettings())
+	_, _, err := UpdateSettings(store, &pb.ShowcaseSettings{
+		MethodErrorInjection: map[string]*pb.ErrorInjection{"/google.showcase.v1beta1.Messaging/ListBlurb": {ErrorRate: 1}},
+	}, []string{"method_error_injection"})
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("UpdateSettings: want InvalidArgument got %v", err)
+	}
+	for _, want := range []string{"\"/google.showcase.v1beta1.Messaging/ListBlurb\"", listBlurbsMethod, getBlurbMethod} {
+		if !strings.Contains(st.Message(), want) {
+			t.Errorf("UpdateSettings: want the error to mention %s got %q", want, st.Message())
+		}
+	}
+	if len(store.Get().MethodErrorInjection) != 0 {
+		t.Errorf("UpdateSettings: want an unknown method to change nothing got %v", store.Get().MethodErrorInjection)
+	}
+}
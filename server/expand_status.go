@@ -0,0 +1,136 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+)
+
+const (
+	// MaxExpandStatuses is the most statuses the ExpandStatusStore
+	// singleton keeps.
+	MaxExpandStatuses = 1000
+
+	// ExpandStatusTTL is how long the ExpandStatusStore singleton keeps a
+	// status.
+	ExpandStatusTTL = 10 * time.Minute
+)
+
+var expandStatusStoreSingleton = NewExpandStatusStore(Now, MaxExpandStatuses, ExpandStatusTTL)
+
+// GetExpandStatusStoreInstance returns the Expand status store singleton.
+func GetExpandStatusStoreInstance() ExpandStatusStore {
+	return expandStatusStoreSingleton
+}
+
+// ExpandStatusStore holds how far the Expand streams with a stream ID got
+// before they ended.
+type ExpandStatusStore interface {
+	// Put stores a copy of the status under its stream ID, replacing the
+	// status of an earlier stream with the ID.
+	Put(namespace string, status *pb.ExpandStatus)
+
+	// Get returns a copy of the status stored under the stream ID, unless it
+	// has expired.
+	Get(namespace, streamID string) (*pb.ExpandStatus, bool)
+
+	// PurgeNamespace removes all statuses of the namespace, and returns how
+	// many it removed.
+	PurgeNamespace(namespace string) int
+}
+
+// NewExpandStatusStore returns an empty ExpandStatusStore that uses nowF as
+// its clock, holds at most maxStatuses statuses, forgetting the oldest first,
+// and keeps each for ttl.
+func NewExpandStatusStore(nowF func() time.Time, maxStatuses int, ttl time.Duration) ExpandStatusStore {
+	return &expandStatusStore{
+		nowF:        nowF,
+		maxStatuses: maxStatuses,
+		ttl:         ttl,
+		statuses:    map[namespacedName]expandStatus{},
+	}
+}
+
+type expandStatusStore struct {
+	nowF        func() time.Time
+	maxStatuses int
+	ttl         time.Duration
+
+	mu       sync.Mutex
+	statuses map[namespacedName]expandStatus
+	order    []namespacedName
+}
+
+type expandStatus struct {
+	status  *pb.ExpandStatus
+	expires time.Time
+}
+
+func (s *expandStatusStore) Put(namespace string, status *pb.ExpandStatus) {
+	defer ChangeState()()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k := namespacedName{namespace, status.GetStreamId()}
+	if _, ok := s.statuses[k]; ok {
+		s.remove(k)
+	}
+	for len(s.order) > 0 && len(s.statuses) >= s.maxStatuses {
+		delete(s.statuses, s.order[0])
+		s.order = s.order[1:]
+	}
+	s.statuses[k] = expandStatus{
+		status:  proto.Clone(status).(*pb.ExpandStatus),
+		expires: s.nowF().Add(s.ttl),
+	}
+	s.order = append(s.order, k)
+}
+
+func (s *expandStatusStore) Get(namespace, streamID string) (*pb.ExpandStatus, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.statuses[namespacedName{namespace, streamID}]
+	if !ok || !s.nowF().Before(e.expires) {
+		return nil, false
+	}
+	return proto.Clone(e.status).(*pb.ExpandStatus), true
+}
+
+func (s *expandStatusStore) PurgeNamespace(namespace string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for k := range s.statuses {
+		if k.namespace == namespace {
+			s.remove(k)
+			n++
+		}
+	}
+	return n
+}
+
+// remove forgets the status under the key. The caller must hold mu.
+func (s *expandStatusStore) remove(k namespacedName) {
+	delete(s.statuses, k)
+	for i, o := range s.order {
+		if o == k {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+)
+
+func TestExpandStatusStore(t *testing.T) {
+	now := time.Unix(1000, 0)
+	s := NewExpandStatusStore(func() time.Time { return now }, 2, time.Minute)
+
+	a := &pb.ExpandStatus{StreamId: "a", MessagesSent: 3, BytesSent: 30, Termination: pb.ExpandStatus_CANCELLED}
+	s.Put("ns", a)
+	a.MessagesSent = 100
+	got, ok := s.Get("ns", "a")
+	if !ok || got.GetMessagesSent() != 3 {
+		t.Errorf("Get(a): want a copy of the stored status, got %v, %t", got, ok)
+	}
+	if _, ok := s.Get("other", "a"); ok {
+		t.Errorf("Get(other, a): want no status in another namespace")
+	}
+
+	b := &pb.ExpandStatus{StreamId: "a", MessagesSent: 5, Termination: pb.ExpandStatus_COMPLETED}
+	s.Put("ns", b)
+	if got, ok := s.Get("ns", "a"); !ok || !proto.Equal(got, b) {
+		t.Errorf("Get(a): want the later status %v, got %v, %t", b, got, ok)
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := s.Get("ns", "a"); ok {
+		t.Errorf("Get(a): want no status once it has expired")
+	}
+}
+
+func TestExpandStatusStore_evict(t *testing.T) {
+	s := NewExpandStatusStore(time.Now, 2, time.Minute)
+	for _, id := range []string{"a", "b", "a", "c"} {
+		s.Put("ns", &pb.ExpandStatus{StreamId: id})
+	}
+	// Replacing a refreshes it, so b is the oldest.
+	for id, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, ok := s.Get("ns", id); ok != want {
+			t.Errorf("Get(%s): want %t got %t", id, want, ok)
+		}
+	}
+}
+
+func TestExpandStatusStore_PurgeNamespace(t *testing.T) {
+	s := NewExpandStatusStore(time.Now, 10, time.Minute)
+	s.Put("a", &pb.ExpandStatus{StreamId: "x"})
+	s.Put("b", &pb.ExpandStatus{StreamId: "x"})
+	s.PurgeNamespace("a")
+	if _, ok := s.Get("a", "x"); ok {
+		t.Errorf("Get(a, x): want the status purged")
+	}
+	if _, ok := s.Get("b", "x"); !ok {
+		t.Errorf("Get(b, x): want the status of another namespace kept")
+	}
+}
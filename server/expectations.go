@@ -0,0 +1,353 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MaxExpectations is the most expectations the expectation store keeps. The
+// oldest are forgotten first.
+const MaxExpectations = 1000
+
+var expectationStoreSingleton = NewExpectationStore(MaxExpectations)
+
+// GetExpectationStoreInstance returns the expectation store singleton.
+func GetExpectationStoreInstance() ExpectationStore {
+	return expectationStoreSingleton
+}
+
+// ExpectationStore keeps the requests that tests expect, by namespace, and
+// checks the requests that arrive against them.
+type ExpectationStore interface {
+	// Expect registers the next request to the method in the namespace,
+	// compared over the fields of the mask, and returns the pending
+	// expectation.
+	Expect(namespace, method string, expected proto.Message, mask *field_mask.FieldMask, failOnMismatch bool) *pb.Expectation
+
+	// Check compares the request with the oldest pending expectation of the
+	// method in the namespace, if any. It fails if they differ and the
+	// expectation asked to fail mismatches.
+	Check(namespace, method string, req proto.Message) error
+
+	// Get returns the expectation with the ID in the namespace.
+	Get(namespace, id string) (*pb.Expectation, bool)
+
+	// PurgeNamespace removes all expectations of the namespace, and returns
+	// how many it removed.
+	PurgeNamespace(namespace string) int
+}
+
+// NewExpectationStore returns an empty store that keeps at most max
+// expectations.
+func NewExpectationStore(max int) ExpectationStore {
+	return &expectationStore{max: max, expectations: map[namespacedName]*expectation{}}
+}
+
+type expectation struct {
+	result         *pb.Expectation
+	expected       proto.Message
+	mask           *field_mask.FieldMask
+	failOnMismatch bool
+}
+
+type expectationStore struct {
+	max int
+
+	mu           sync.Mutex
+	next         int64
+	expectations map[namespacedName]*expectation
+	// order holds the keys of the expectations, oldest first.
+	order []namespacedName
+}
+
+func (s *expectationStore) Expect(namespace, method string, expected proto.Message, mask *field_mask.FieldMask, failOnMismatch bool) *pb.Expectation {
+	defer ChangeState()()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next++
+	id := strconv.FormatInt(s.next, 10)
+	e := &expectation{
+		result:         &pb.Expectation{Id: id, Method: method, State: pb.Expectation_PENDING},
+		expected:       proto.Clone(expected),
+		mask:           mask,
+		failOnMismatch: failOnMismatch,
+	}
+	k := namespacedName{namespace: namespace, name: id}
+	s.expectations[k] = e
+	s.order = append(s.order, k)
+	if len(s.order) > s.max {
+		delete(s.expectations, s.order[0])
+		s.order = s.order[1:]
+	}
+	return proto.Clone(e.result).(*pb.Expectation)
+}
+
+func (s *expectationStore) Check(namespace, method string, req proto.Message) error {
+	defer ChangeState()()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, k := range s.order {
+		e := s.expectations[k]
+		if k.namespace != namespace || e.result.GetMethod() != method || e.result.GetState() != pb.Expectation_PENDING {
+			continue
+		}
+		e.result.Diffs = DiffMessages(e.expected, req, e.mask)
+		if len(e.result.GetDiffs()) == 0 {
+			e.result.State = pb.Expectation_MATCHED
+			return nil
+		}
+		e.result.State = pb.Expectation_MISMATCHED
+		if !e.failOnMismatch {
+			return nil
+		}
+		paths := make([]string, 0, len(e.result.GetDiffs()))
+		for _, d := range e.result.GetDiffs() {
+			paths = append(paths, d.GetFieldPath())
+		}
+		return status.ErrorProto(&spb.Status{
+			Code:    int32(codes.FailedPrecondition),
+			Message: fmt.Sprintf("The request differs from the expectation %s in %s.", e.result.GetId(), strings.Join(paths, ", ")),
+			Details: []*any.Any{showcaseerrors.ErrorInfo(showcaseerrors.RequestMismatch, showcaseerrors.Domain, map[string]string{
+				"expectation": e.result.GetId(),
+				"fields":      strings.Join(paths, ","),
+			})},
+		})
+	}
+	return nil
+}
+
+func (s *expectationStore) Get(namespace, id string) (*pb.Expectation, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.expectations[namespacedName{namespace: namespace, name: id}]
+	if !ok {
+		return nil, false
+	}
+	return proto.Clone(e.result).(*pb.Expectation), true
+}
+
+func (s *expectationStore) PurgeNamespace(namespace string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	order := s.order[:0]
+	for _, k := range s.order {
+		if k.namespace == namespace {
+			delete(s.expectations, k)
+			continue
+		}
+		order = append(order, k)
+	}
+	n := len(s.order) - len(order)
+	s.order = order
+	return n
+}
+
+// ExpectationInterceptor checks the requests of calls against the
+// expectations of their namespace.
+type ExpectationInterceptor struct {
+	store ExpectationStore
+}
+
+// NewExpectationInterceptor returns an ExpectationInterceptor of the store.
+func NewExpectationInterceptor(store ExpectationStore) *ExpectationInterceptor {
+	return &ExpectationInterceptor{store: store}
+}
+
+// UnaryInterceptor checks the request of a unary call.
+func (i *ExpectationInterceptor) UnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	if msg, ok := req.(proto.Message); ok {
+		if err := i.store.Check(NamespaceFromContext(ctx), info.FullMethod, msg); err != nil {
+			return nil, err
+		}
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor checks the first request of a streaming call.
+func (i *ExpectationInterceptor) StreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	return handler(srv, &expectationStream{ServerStream: ss, store: i.store, method: info.FullMethod})
+}
+
+type expectationStream struct {
+	grpc.ServerStream
+	store   ExpectationStore
+	method  string
+	checked bool
+}
+
+func (s *expectationStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil || s.checked {
+		return err
+	}
+	s.checked = true
+	if msg, ok := m.(proto.Message); ok {
+		return s.store.Check(NamespaceFromContext(s.Context()), s.method, msg)
+	}
+	return nil
+}
+
+// DiffMessages returns the fields in which actual differs from expected over
+// the fields the mask selects, or over every field if it is empty. Fields of
+// messages are compared one by one, so that the paths of the differences are
+// as long as they can be; repeated and map fields are compared whole.
+func DiffMessages(expected, actual proto.Message, mask *field_mask.FieldMask) []*pb.FieldDiff {
+	e := reflect.ValueOf(MaskedCopy(mask, expected))
+	a := reflect.ValueOf(MaskedCopy(mask, actual))
+	var diffs []*pb.FieldDiff
+	diffMessages("", e, a, &diffs)
+	return diffs
+}
+
+// diffMessages appends the differences between the messages e and a, which
+// are pointers to structs of the same type and may be nil, to diffs.
+func diffMessages(prefix string, e, a reflect.Value, diffs *[]*pb.FieldDiff) {
+	t := e.Type().Elem()
+	ev, av := reflect.Zero(t), reflect.Zero(t)
+	if !e.IsNil() {
+		ev = e.Elem()
+	}
+	if !a.IsNil() {
+		av = a.Elem()
+	}
+	ef, af := protoFields(ev), protoFields(av)
+	for i := range ef {
+		path := prefix + ef[i].name
+		ei, ai := ef[i].value, af[i].value
+		if isMessage(ei) && isMessage(ai) && (!ei.IsNil() || !ai.IsNil()) {
+			n := len(*diffs)
+			diffMessages(path+".", ei, ai, diffs)
+			if len(*diffs) == n && ei.IsNil() != ai.IsNil() {
+				// The messages differ only in whether they are set.
+				*diffs = append(*diffs, &pb.FieldDiff{FieldPath: path, Expected: formatValue(ei), Actual: formatValue(ai)})
+			}
+			continue
+		}
+		if es, as := formatValue(ei), formatValue(ai); es != as {
+			*diffs = append(*diffs, &pb.FieldDiff{FieldPath: path, Expected: es, Actual: as})
+		}
+	}
+}
+
+// protoField is a field of a message, or an option of one of its oneofs.
+type protoField struct {
+	name string
+	// value is invalid for the options of a oneof that are not set.
+	value reflect.Value
+}
+
+// protoFields returns the fields of the message struct v in field order,
+// with every option of its oneofs.
+func protoFields(v reflect.Value) []protoField {
+	t := v.Type()
+	props := proto.GetProperties(t)
+	var fields []protoField
+	for i := 0; i < t.NumField(); i++ {
+		f, fv := t.Field(i), v.Field(i)
+		if strings.HasPrefix(f.Name, "XXX_") {
+			continue
+		}
+		if f.Tag.Get("protobuf_oneof") == "" {
+			fields = append(fields, protoField{name: props.Prop[i].OrigName, value: fv})
+			continue
+		}
+		var options []*proto.OneofProperties
+		for _, oneof := range props.OneofTypes {
+			if oneof.Field == i {
+				options = append(options, oneof)
+			}
+		}
+		sort.Slice(options, func(i, j int) bool { return options[i].Prop.Tag < options[j].Prop.Tag })
+		for _, oneof := range options {
+			var value reflect.Value
+			if !fv.IsNil() && fv.Elem().Type() == oneof.Type {
+				// The value of a oneof is the only field of its wrapper.
+				value = fv.Elem().Elem().Field(0)
+			}
+			fields = append(fields, protoField{name: oneof.Prop.OrigName, value: value})
+		}
+	}
+	return fields
+}
+
+var protoMessageType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+
+// isMessage reports whether v is a message, which may be nil.
+func isMessage(v reflect.Value) bool {
+	return v.IsValid() && v.Kind() == reflect.Ptr && v.Type().Implements(protoMessageType)
+}
+
+// formatValue returns the field value v in protobuf text format, or "" if it
+// is invalid, a nil message, or an empty repeated or map field.
+func formatValue(v reflect.Value) string {
+	switch {
+	case !v.IsValid():
+		return ""
+	case isMessage(v):
+		if v.IsNil() {
+			return ""
+		}
+		return "{" + strings.TrimSpace(proto.CompactTextString(v.Interface().(proto.Message))) + "}"
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		return strconv.Quote(string(v.Bytes()))
+	case v.Kind() == reflect.Slice:
+		if v.Len() == 0 {
+			return ""
+		}
+		elems := make([]string, v.Len())
+		for i := range elems {
+			elems[i] = formatValue(v.Index(i))
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	case v.Kind() == reflect.Map:
+		if v.Len() == 0 {
+			return ""
+		}
+		entries := make([]string, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			entries = append(entries, formatValue(k)+": "+formatValue(v.MapIndex(k)))
+		}
+		sort.Strings(entries)
+		return "{" + strings.Join(entries, ", ") + "}"
+	case v.Kind() == reflect.String:
+		return strconv.Quote(v.String())
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDiffMessages(t *testing.T) {
+	expected := &pb.EchoRequest{
+		Response:       &pb.EchoRequest_Error{Error: &spb.Status{Code: 3, Message: "bad"}},
+		ClientSequence: 7,
+		Trailers:       map[string]string{"a": "1"},
+	}
+	tests := []struct {
+		name   string
+		actual *pb.EchoRequest
+		mask   []string
+		want   []*pb.FieldDiff
+	}{
+		{"equal", proto.Clone(expected).(*pb.EchoRequest), nil, nil},
+		{
+			"nested field",
+			&pb.EchoRequest{
+				Response:       &pb.EchoRequest_Error{Error: &spb.Status{Code: 5, Message: "bad"}},
+				ClientSequence: 7,
+				Trailers:       map[string]string{"a": "1"},
+			},
+			nil,
+			[]*pb.FieldDiff{{FieldPath: "error.code", Expected: "3", Actual: "5"}},
+		},
+		{
+			"oneof case",
+			&pb.EchoRequest{
+				Response:       &pb.EchoRequest_Content{Content: "hi"},
+				ClientSequence: 8,
+				Trailers:       map[string]string{"a": "2"},
+			},
+			nil,
+			[]*pb.FieldDiff{
+				{FieldPath: "content", Actual: `"hi"`},
+				{FieldPath: "error", Expected: `{code:3 message:"bad"}`},
+				{FieldPath: "client_sequence", Expected: "7", Actual: "8"},
+				{FieldPath: "trailers", Expected: `{"a": "1"}`, Actual: `{"a": "2"}`},
+			},
+		},
+		{
+			"masked",
+			&pb.EchoRequest{Response: &pb.EchoRequest_Error{Error: &spb.Status{Code: 3, Message: "other"}}, ClientSequence: 7},
+			[]string{"error.code", "client_sequence"},
+			nil,
+		},
+		{
+			"unset message",
+			&pb.EchoRequest{Response: &pb.EchoRequest_Error{Error: &spb.Status{}}, ClientSequence: 7, Trailers: map[string]string{"a": "1"}},
+			[]string{"error", "client_sequence"},
+			[]*pb.FieldDiff{
+				{FieldPath: "error.code", Expected: "3", Actual: "0"},
+				{FieldPath: "error.message", Expected: `"bad"`, Actual: `""`},
+			},
+		},
+	}
+	for _, test := range tests {
+		var mask *field_mask.FieldMask
+		if test.mask != nil {
+			mask = &field_mask.FieldMask{Paths: test.mask}
+		}
+		got := DiffMessages(expected, test.actual, mask)
+		if len(got) != len(test.want) {
+			t.Errorf("DiffMessages(%s): want %v, got %v", test.name, test.want, got)
+			continue
+		}
+		for i := range got {
+			if !proto.Equal(got[i], test.want[i]) {
+				t.Errorf("DiffMessages(%s): want %v, got %v", test.name, test.want[i], got[i])
+			}
+		}
+	}
+}
+
+func TestDiffMessages_presence(t *testing.T) {
+	got := DiffMessages(&pb.EchoRequest{Response: &pb.EchoRequest_Error{Error: &spb.Status{}}}, &pb.EchoRequest{}, nil)
+	want := &pb.FieldDiff{FieldPath: "error", Expected: "{}"}
+	if len(got) != 1 || !proto.Equal(got[0], want) {
+		t.Errorf("DiffMessages of an empty message with none: want %v, got %v", want, got)
+	}
+}
+
+func TestExpectationStore(t *testing.T) {
+	store := NewExpectationStore(2)
+	const method = "/google.showcase.v1beta1.Echo/Echo"
+	first := store.Expect("a", method, &pb.EchoRequest{ClientSequence: 1}, nil, false)
+	second := store.Expect("a", method, &pb.EchoRequest{ClientSequence: 2}, nil, true)
+	if first.GetState() != pb.Expectation_PENDING || first.GetId() == second.GetId() {
+		t.Errorf("Expect: want distinct pending expectations, got %v and %v", first, second)
+	}
+
+	// Other namespaces and methods do not use the expectations.
+	if err := store.Check("b", method, &pb.EchoRequest{}); err != nil {
+		t.Errorf("Check in another namespace: %v", err)
+	}
+	if err := store.Check("a", "/google.showcase.v1beta1.Echo/Wait", &pb.WaitRequest{}); err != nil {
+		t.Errorf("Check of another method: %v", err)
+	}
+	if got, _ := store.Get("a", first.GetId()); got.GetState() != pb.Expectation_PENDING {
+		t.Errorf("Get: want the first expectation pending, got %v", got)
+	}
+
+	// Expectations are used oldest first.
+	if err := store.Check("a", method, &pb.EchoRequest{ClientSequence: 1}); err != nil {
+		t.Errorf("Check of a matching request: %v", err)
+	}
+	err := store.Check("a", method, &pb.EchoRequest{ClientSequence: 3})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Check of a mismatch that fails: want FailedPrecondition, got %v", err)
+	}
+	if got, _ := store.Get("a", first.GetId()); got.GetState() != pb.Expectation_MATCHED {
+		t.Errorf("Get: want the first expectation matched, got %v", got)
+	}
+	got, _ := store.Get("a", second.GetId())
+	if got.GetState() != pb.Expectation_MISMATCHED || len(got.GetDiffs()) != 1 || got.GetDiffs()[0].GetFieldPath() != "client_sequence" {
+		t.Errorf("Get: want the second expectation mismatched in client_sequence, got %v", got)
+	}
+	if _, ok := store.Get("b", first.GetId()); ok {
+		t.Errorf("Get in another namespace: want no expectation")
+	}
+
+	// The oldest expectation is forgotten past the limit.
+	store.Expect("a", method, &pb.EchoRequest{}, nil, false)
+	if _, ok := store.Get("a", first.GetId()); ok {
+		t.Errorf("Get past the limit: want the oldest expectation forgotten")
+	}
+	if n := store.PurgeNamespace("a"); n != 2 {
+		t.Errorf("PurgeNamespace: want 2 removed, got %d", n)
+	}
+}
+
+func TestExpectationInterceptor_stream(t *testing.T) {
+	store := NewExpectationStore(MaxExpectations)
+	const method = "/google.showcase.v1beta1.Echo/Collect"
+	e := store.Expect(DefaultNamespace, method, &pb.EchoRequest{ClientSequence: 1}, nil, false)
+	i := NewExpectationInterceptor(store)
+	ss := &echoRequestStream{contextStream: contextStream{ctx: context.Background()}, reqs: []*pb.EchoRequest{{ClientSequence: 2}, {ClientSequence: 1}}}
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		for range ss.(*expectationStream).ServerStream.(*echoRequestStream).reqs {
+			if err := ss.RecvMsg(&pb.EchoRequest{}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := i.StreamInterceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: method}, handler); err != nil {
+		t.Fatal(err)
+	}
+	// Only the first message of the stream is checked.
+	if got, _ := store.Get(DefaultNamespace, e.GetId()); got.GetState() != pb.Expectation_MISMATCHED {
+		t.Errorf("StreamInterceptor: want the expectation checked against the first message, got %v", got)
+	}
+}
+
+// echoRequestStream receives a copy of the next of its requests each time.
+type echoRequestStream struct {
+	contextStream
+	reqs []*pb.EchoRequest
+	next int
+}
+
+func (s *echoRequestStream) RecvMsg(m interface{}) error {
+	proto.Merge(m.(proto.Message), s.reqs[s.next])
+	s.next++
+	return nil
+}
@@ -0,0 +1,182 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
+)
+
+// CheckFieldMask returns an INVALID_ARGUMENT error with a BadRequest detail
+// on field if a path of the mask does not name a field of messages like msg.
+// Paths must not select fields within repeated or map fields, as AIP-161
+// does not allow it. A path may end in `*`, which selects every field of
+// the message it names, and a mask of `*` selects everything.
+func CheckFieldMask(field string, mask *field_mask.FieldMask, msg proto.Message) error {
+	for _, path := range mask.GetPaths() {
+		if desc := checkMaskPath(reflect.TypeOf(msg).Elem(), path); desc != "" {
+			return showcaseerrors.BadRequest(showcaseerrors.FieldInvalid, field, fmt.Sprintf("The field `%s` has the path `%s`, %s.", field, path, desc))
+		}
+	}
+	return nil
+}
+
+// checkMaskPath describes what is wrong with a path of a mask over messages
+// of the struct type t, or returns "" if it is valid.
+func checkMaskPath(t reflect.Type, path string) string {
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		if segment == "*" {
+			if !last {
+				return "but `*` may only end a path"
+			}
+			return ""
+		}
+		f, ok := jsonFields(t)[segment]
+		if !ok || f.origName != segment {
+			return fmt.Sprintf("but %s has no field `%s`", protoName(t), segment)
+		}
+		if last {
+			return ""
+		}
+		if k := f.typ.Kind(); k == reflect.Map || k == reflect.Slice && f.typ.Elem().Kind() != reflect.Uint8 {
+			return fmt.Sprintf("but `%s` is repeated, and fields within repeated fields cannot be selected", segment)
+		}
+		if f.typ.Kind() != reflect.Ptr || f.typ.Elem().Kind() != reflect.Struct {
+			return fmt.Sprintf("but `%s` is not a message", segment)
+		}
+		t = f.typ.Elem()
+	}
+	return ""
+}
+
+// protoName returns the full proto name of the messages of struct type t.
+func protoName(t reflect.Type) string {
+	if msg, ok := reflect.New(t).Interface().(proto.Message); ok {
+		return proto.MessageName(msg)
+	}
+	return t.Name()
+}
+
+// maskNode is a field selected by a mask. A node without children selects
+// all of its field.
+type maskNode map[string]maskNode
+
+// ApplyFieldMask clears the fields of msg that the mask does not select,
+// along with its unknown fields. A nil or empty mask selects every field.
+// The mask should have been checked with CheckFieldMask; paths naming
+// fields msg does not have are ignored.
+func ApplyFieldMask(mask *field_mask.FieldMask, msg proto.Message) {
+	if len(mask.GetPaths()) == 0 || msg == nil {
+		return
+	}
+	for _, path := range mask.GetPaths() {
+		if path == "*" {
+			return
+		}
+	}
+	v := reflect.ValueOf(msg)
+	if v.IsNil() {
+		return
+	}
+	pruneMessage(v.Elem(), maskTree(mask.GetPaths()))
+}
+
+// maskTree returns the tree of the fields the paths select. A path segment
+// of `*` selects the whole field before it.
+func maskTree(paths []string) maskNode {
+	root := maskNode{}
+	for _, path := range paths {
+		node := root
+		segments := strings.Split(path, ".")
+		for i, segment := range segments {
+			if segment == "*" {
+				break
+			}
+			child, ok := node[segment]
+			if ok && len(child) == 0 {
+				// Already selected whole by a shorter path.
+				break
+			}
+			if i == len(segments)-1 || segments[i+1] == "*" {
+				node[segment] = maskNode{}
+				break
+			}
+			if !ok {
+				child = maskNode{}
+				node[segment] = child
+			}
+			node = child
+		}
+	}
+	return root
+}
+
+// pruneMessage clears the fields of the message struct v that node does not
+// select.
+func pruneMessage(v reflect.Value, node maskNode) {
+	t := v.Type()
+	props := proto.GetProperties(t)
+	for i := 0; i < t.NumField(); i++ {
+		f, fv := t.Field(i), v.Field(i)
+		if f.Name == "XXX_unrecognized" {
+			fv.Set(reflect.Zero(f.Type))
+			continue
+		}
+		if strings.HasPrefix(f.Name, "XXX_") {
+			continue
+		}
+		name := props.Prop[i].OrigName
+		if f.Tag.Get("protobuf_oneof") != "" {
+			if fv.IsNil() {
+				continue
+			}
+			name = ""
+			for _, oneof := range props.OneofTypes {
+				if oneof.Type == fv.Elem().Type() {
+					name = oneof.Prop.OrigName
+				}
+			}
+			// The value of a oneof is the only field of its wrapper.
+			fv = fv.Elem().Elem().Field(0)
+		}
+		child, ok := node[name]
+		if !ok {
+			v.Field(i).Set(reflect.Zero(f.Type))
+			continue
+		}
+		if len(child) > 0 && fv.Kind() == reflect.Ptr && !fv.IsNil() && fv.Elem().Kind() == reflect.Struct {
+			pruneMessage(fv.Elem(), child)
+		}
+	}
+}
+
+// MaskedCopy returns msg with the fields the mask does not select cleared.
+// Unless the mask selects everything, msg is copied first and left as it
+// was.
+func MaskedCopy(mask *field_mask.FieldMask, msg proto.Message) proto.Message {
+	if len(mask.GetPaths()) == 0 {
+		return msg
+	}
+	c := proto.Clone(msg)
+	ApplyFieldMask(mask, c)
+	return c
+}
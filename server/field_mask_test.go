@@ -0,0 +1,196 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func maskOf(paths ...string) *field_mask.FieldMask {
+	return &field_mask.FieldMask{Paths: paths}
+}
+
+// fullRequest returns an EchoRequest with its nested messages set.
+func fullRequest() *pb.EchoRequest {
+	return &pb.EchoRequest{
+		Response:       &pb.EchoRequest_Error{Error: &spb.Status{Code: 3, Message: "bad"}},
+		CacheControl:   &pb.CacheControl{MaxAge: 60, NoStore: true},
+		IdleTimeout:    ptypes.DurationProto(time.Second),
+		ClientSequence: 7,
+		ReadMask:       maskOf("content"),
+	}
+}
+
+func TestCheckFieldMask(t *testing.T) {
+	valid := [][]string{
+		nil,
+		{"*"},
+		{"content", "client_sequence"},
+		{"cache_control.max_age"},
+		{"cache_control.*"},
+		{"error.code"},
+		{"idle_timeout.seconds"},
+		{"read_mask.paths"},
+	}
+	for _, paths := range valid {
+		if err := CheckFieldMask("read_mask", maskOf(paths...), &pb.EchoRequest{}); err != nil {
+			t.Errorf("CheckFieldMask(%q): want valid got %v", paths, err)
+		}
+	}
+
+	invalid := []struct {
+		paths []string
+		want  string
+	}{
+		{
+			[]string{"content", "contents"},
+			"The field `read_mask` has the path `contents`, but google.showcase.v1beta1.EchoRequest has no field `contents`.",
+		},
+		{
+			[]string{"cache_control.max_ages"},
+			"The field `read_mask` has the path `cache_control.max_ages`, but google.showcase.v1beta1.CacheControl has no field `max_ages`.",
+		},
+		{
+			[]string{"clientSequence"},
+			"The field `read_mask` has the path `clientSequence`, but google.showcase.v1beta1.EchoRequest has no field `clientSequence`.",
+		},
+		{
+			[]string{"content.length"},
+			"The field `read_mask` has the path `content.length`, but `content` is not a message.",
+		},
+		{
+			[]string{"*.content"},
+			"The field `read_mask` has the path `*.content`, but `*` may only end a path.",
+		},
+		{
+			[]string{"read_mask.paths.length"},
+			"The field `read_mask` has the path `read_mask.paths.length`, but `paths` is repeated, and fields within repeated fields cannot be selected.",
+		},
+		{
+			[]string{"error.details.type_url"},
+			"The field `read_mask` has the path `error.details.type_url`, but `details` is repeated, and fields within repeated fields cannot be selected.",
+		},
+	}
+	for _, test := range invalid {
+		err := CheckFieldMask("read_mask", maskOf(test.paths...), &pb.EchoRequest{})
+		st := status.Convert(err)
+		if st.Code() != codes.InvalidArgument || st.Message() != test.want {
+			t.Errorf("CheckFieldMask(%q): want InvalidArgument %q got %v", test.paths, test.want, err)
+			continue
+		}
+		if len(st.Details()) != 2 {
+			t.Fatalf("CheckFieldMask(%q): want a BadRequest and an ErrorInfo got %v", test.paths, st.Details())
+		}
+		br, ok := st.Details()[0].(*errdetails.BadRequest)
+		if !ok || br.GetFieldViolations()[0].GetField() != "read_mask" {
+			t.Errorf("CheckFieldMask(%q): want a BadRequest on read_mask got %v", test.paths, st.Details()[0])
+		}
+	}
+}
+
+func TestApplyFieldMask(t *testing.T) {
+	tests := []struct {
+		paths []string
+		want  *pb.EchoRequest
+	}{
+		{nil, fullRequest()},
+		{[]string{"*"}, fullRequest()},
+		{[]string{"client_sequence", "*"}, fullRequest()},
+		{[]string{"client_sequence"}, &pb.EchoRequest{ClientSequence: 7}},
+		{
+			[]string{"cache_control.max_age", "client_sequence"},
+			&pb.EchoRequest{CacheControl: &pb.CacheControl{MaxAge: 60}, ClientSequence: 7},
+		},
+		{
+			[]string{"cache_control.*"},
+			&pb.EchoRequest{CacheControl: &pb.CacheControl{MaxAge: 60, NoStore: true}},
+		},
+		// A path selecting a whole field wins over those within it.
+		{
+			[]string{"cache_control.no_store", "cache_control"},
+			&pb.EchoRequest{CacheControl: &pb.CacheControl{MaxAge: 60, NoStore: true}},
+		},
+		{
+			[]string{"cache_control", "cache_control.no_store"},
+			&pb.EchoRequest{CacheControl: &pb.CacheControl{MaxAge: 60, NoStore: true}},
+		},
+		// Oneofs are selected by the name of the field that is set.
+		{
+			[]string{"error.message"},
+			&pb.EchoRequest{Response: &pb.EchoRequest_Error{Error: &spb.Status{Message: "bad"}}},
+		},
+		{[]string{"content"}, &pb.EchoRequest{}},
+		{
+			[]string{"idle_timeout", "read_mask"},
+			&pb.EchoRequest{IdleTimeout: ptypes.DurationProto(time.Second), ReadMask: maskOf("content")},
+		},
+		// Unset messages stay unset.
+		{[]string{"content", "cache_control.max_age"}, &pb.EchoRequest{CacheControl: &pb.CacheControl{MaxAge: 60}}},
+	}
+	for _, test := range tests {
+		got := fullRequest()
+		ApplyFieldMask(maskOf(test.paths...), got)
+		if !proto.Equal(got, test.want) {
+			t.Errorf("ApplyFieldMask(%q): want %v got %v", test.paths, test.want, got)
+		}
+	}
+
+	unset := &pb.EchoRequest{}
+	ApplyFieldMask(maskOf("cache_control.max_age"), unset)
+	if unset.GetCacheControl() != nil {
+		t.Errorf("ApplyFieldMask: want unset messages left unset got %v", unset)
+	}
+}
+
+func TestApplyFieldMask_unknownFields(t *testing.T) {
+	b, _ := proto.Marshal(&pb.EchoResponse{Content: "hi"})
+	b = append(b, 0x80, 0x7d, 1) // Field 2000, varint 1.
+	resp := &pb.EchoResponse{}
+	if err := proto.Unmarshal(b, resp); err != nil {
+		t.Fatal(err)
+	}
+	ApplyFieldMask(maskOf("*"), resp)
+	if len(resp.XXX_unrecognized) == 0 {
+		t.Fatalf("ApplyFieldMask(*): want unknown fields kept got %v", resp)
+	}
+	ApplyFieldMask(maskOf("content"), resp)
+	if len(resp.XXX_unrecognized) != 0 || resp.GetContent() != "hi" {
+		t.Errorf("ApplyFieldMask(content): want only the content got %v, unknown %v", resp, resp.XXX_unrecognized)
+	}
+}
+
+func TestMaskedCopy(t *testing.T) {
+	user := &pb.User{Name: "users/1", DisplayName: "One", Email: "one@example.com"}
+	if got := MaskedCopy(nil, user); got != user {
+		t.Errorf("MaskedCopy(nil): want the message itself got %v", got)
+	}
+	got := MaskedCopy(maskOf("name"), user).(*pb.User)
+	if !proto.Equal(got, &pb.User{Name: "users/1"}) {
+		t.Errorf("MaskedCopy(name): want only the name got %v", got)
+	}
+	if user.GetEmail() == "" {
+		t.Errorf("MaskedCopy(name): want the original unchanged got %v", user)
+	}
+}
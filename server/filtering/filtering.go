@@ -0,0 +1,320 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package filtering parses and evaluates the subset of AIP-160 filters that
+// Showcase list methods support: comparisons of a field with a literal by
+// =, !=, < or >, joined by AND.
+package filtering
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Type is the type of a field that filters may compare.
+type Type int
+
+const (
+	// Int fields are compared with integer literals, such as 3 or -1.
+	Int Type = iota
+
+	// String fields are compared with quoted strings, such as "a b", or
+	// bare words, such as abc. Strings order byte by byte.
+	String
+)
+
+func (t Type) String() string {
+	if t == Int {
+		return "an integer"
+	}
+	return "a string"
+}
+
+// SyntaxError is a filter that does not parse, with the byte offset of the
+// token at fault.
+type SyntaxError struct {
+	Offset  int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("offset %d: %s", e.Offset, e.Message)
+}
+
+// Filter is a parsed filter. The nil Filter matches everything.
+type Filter struct {
+	comparisons []comparison
+}
+
+type comparison struct {
+	field string
+	op    string
+	value interface{}
+}
+
+// Parse parses a filter over fields of the given types. An empty filter
+// gives a nil Filter. Errors are SyntaxErrors.
+func Parse(filter string, fields map[string]Type) (*Filter, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	p := &parser{tokens: tokens, fields: fields, end: len(filter)}
+	f := &Filter{}
+	for {
+		c, err := p.comparison()
+		if err != nil {
+			return nil, err
+		}
+		f.comparisons = append(f.comparisons, c)
+		t, ok := p.next()
+		if !ok {
+			return f, nil
+		}
+		if t.kind != keyword {
+			return nil, &SyntaxError{t.offset, fmt.Sprintf("expected AND but found `%s`", t.text)}
+		}
+	}
+}
+
+// Matches reports whether an item matches the filter. value returns the
+// value of a field of the item: an int64 for Int fields and a string for
+// String fields.
+func (f *Filter) Matches(value func(field string) interface{}) bool {
+	if f == nil {
+		return true
+	}
+	for _, c := range f.comparisons {
+		if !c.matches(value(c.field)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c comparison) matches(v interface{}) bool {
+	cmp := 0
+	switch want := c.value.(type) {
+	case int64:
+		got, ok := v.(int64)
+		if !ok {
+			return false
+		}
+		if got < want {
+			cmp = -1
+		} else if got > want {
+			cmp = 1
+		}
+	case string:
+		got, ok := v.(string)
+		if !ok {
+			return false
+		}
+		cmp = strings.Compare(got, want)
+	}
+	switch c.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	default:
+		return cmp > 0
+	}
+}
+
+// String returns the canonical form of the filter, which is the same for
+// filters that differ only in spacing or quoting.
+func (f *Filter) String() string {
+	if f == nil {
+		return ""
+	}
+	terms := make([]string, len(f.comparisons))
+	for i, c := range f.comparisons {
+		value := fmt.Sprint(c.value)
+		if s, ok := c.value.(string); ok {
+			value = strconv.Quote(s)
+		}
+		terms[i] = fmt.Sprintf("%s %s %s", c.field, c.op, value)
+	}
+	return strings.Join(terms, " AND ")
+}
+
+type tokenKind int
+
+const (
+	identifier tokenKind = iota
+	number
+	quoted
+	operator
+	keyword
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+
+	// The unquoted text of a quoted token.
+	value string
+}
+
+// tokenize splits a filter into its tokens.
+func tokenize(s string) ([]token, error) {
+	tokens := []token{}
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '=' || c == '<' || c == '>':
+			tokens = append(tokens, token{kind: operator, text: s[i : i+1], offset: i})
+			i++
+		case c == '!':
+			if i+1 >= len(s) || s[i+1] != '=' {
+				return nil, &SyntaxError{i, "expected `!=`"}
+			}
+			tokens = append(tokens, token{kind: operator, text: "!=", offset: i})
+			i += 2
+		case c == '"':
+			j := i + 1
+			for j < len(s) && s[j] != '"' {
+				if s[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(s) {
+				return nil, &SyntaxError{i, "the string is not terminated"}
+			}
+			value, err := strconv.Unquote(s[i : j+1])
+			if err != nil {
+				return nil, &SyntaxError{i, "the string has an invalid escape"}
+			}
+			tokens = append(tokens, token{kind: quoted, text: s[i : j+1], offset: i, value: value})
+			i = j + 1
+		case c == '-' || c >= '0' && c <= '9':
+			j := i + 1
+			for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+				j++
+			}
+			tokens = append(tokens, token{kind: number, text: s[i:j], offset: i})
+			i = j
+		case isIdentifierByte(c):
+			j := i + 1
+			for j < len(s) && (isIdentifierByte(s[j]) || s[j] >= '0' && s[j] <= '9' || s[j] == '.') {
+				j++
+			}
+			kind := identifier
+			if s[i:j] == "AND" {
+				kind = keyword
+			}
+			tokens = append(tokens, token{kind: kind, text: s[i:j], offset: i})
+			i = j
+		default:
+			return nil, &SyntaxError{i, fmt.Sprintf("unexpected character %q", c)}
+		}
+	}
+	return tokens, nil
+}
+
+func isIdentifierByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	fields map[string]Type
+
+	// The length of the filter, the offset of errors at its end.
+	end int
+}
+
+func (p *parser) next() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	return t, true
+}
+
+// expect returns the next token, failing at the end of the filter.
+func (p *parser) expect(what string) (token, error) {
+	t, ok := p.next()
+	if !ok {
+		return token{}, &SyntaxError{p.end, fmt.Sprintf("expected %s but the filter ended", what)}
+	}
+	return t, nil
+}
+
+func (p *parser) comparison() (comparison, error) {
+	field, err := p.expect("a field")
+	if err != nil {
+		return comparison{}, err
+	}
+	if field.kind != identifier {
+		return comparison{}, &SyntaxError{field.offset, fmt.Sprintf("expected a field but found `%s`", field.text)}
+	}
+	typ, ok := p.fields[field.text]
+	if !ok {
+		return comparison{}, &SyntaxError{field.offset, fmt.Sprintf("unknown field `%s`, the fields are %s", field.text, fieldNames(p.fields))}
+	}
+	op, err := p.expect("a comparison")
+	if err != nil {
+		return comparison{}, err
+	}
+	if op.kind != operator {
+		return comparison{}, &SyntaxError{op.offset, fmt.Sprintf("expected =, !=, < or > but found `%s`", op.text)}
+	}
+	value, err := p.expect("a value")
+	if err != nil {
+		return comparison{}, err
+	}
+	c := comparison{field: field.text, op: op.text}
+	switch {
+	case typ == Int && value.kind == number:
+		n, err := strconv.ParseInt(value.text, 10, 64)
+		if err != nil {
+			return comparison{}, &SyntaxError{value.offset, fmt.Sprintf("the integer `%s` is invalid", value.text)}
+		}
+		c.value = n
+	case typ == String && value.kind == quoted:
+		c.value = value.value
+	case typ == String && (value.kind == identifier || value.kind == number):
+		c.value = value.text
+	case value.kind == operator || value.kind == keyword:
+		return comparison{}, &SyntaxError{value.offset, fmt.Sprintf("expected a value but found `%s`", value.text)}
+	default:
+		return comparison{}, &SyntaxError{value.offset, fmt.Sprintf("the field `%s` is compared with %s, not `%s`", field.text, typ, value.text)}
+	}
+	return c, nil
+}
+
+// fieldNames lists the names of the fields, sorted.
+func fieldNames(fields map[string]Type) string {
+	names := []string{}
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
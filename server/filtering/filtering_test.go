@@ -0,0 +1,211 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtering
+
+import (
+	"strings"
+	"testing"
+)
+
+var testFields = map[string]Type{
+	"index":   Int,
+	"length":  Int,
+	"content": String,
+}
+
+// testItem returns the fields of an item for Matches.
+func testItem(index, length int64, content string) func(string) interface{} {
+	return func(field string) interface{} {
+		switch field {
+		case "index":
+			return index
+		case "length":
+			return length
+		default:
+			return content
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"index = 1", "index = 1"},
+		{"index=1", "index = 1"},
+		{"  index   !=  -12  ", "index != -12"},
+		{"length < 3 AND length > 1", "length < 3 AND length > 1"},
+		{"content = abc", "content = \"abc\""},
+		{"content = 12", "content = \"12\""},
+		{"content = -", "content = \"-\""},
+		{"content = a.b_c", "content = \"a.b_c\""},
+		{"content != \"a b\"", "content != \"a b\""},
+		{"content = \"say \\\"hi\\\"\"", "content = \"say \\\"hi\\\"\""},
+		{"content = \"\"", "content = \"\""},
+		{"index>0 AND content<\"m\" AND length!=2", "index > 0 AND content < \"m\" AND length != 2"},
+	}
+	for _, test := range tests {
+		got, err := Parse(test.in, testFields)
+		if err != nil {
+			t.Errorf("Parse(%q): unexpected err %v", test.in, err)
+			continue
+		}
+		if got.String() != test.want {
+			t.Errorf("Parse(%q): want %q got %q", test.in, test.want, got.String())
+		}
+	}
+}
+
+func TestParse_empty(t *testing.T) {
+	for _, in := range []string{"", "   ", "\t\n"} {
+		f, err := Parse(in, testFields)
+		if f != nil || err != nil {
+			t.Errorf("Parse(%q): want nil, nil got %v, %v", in, f, err)
+		}
+		if !f.Matches(testItem(0, 0, "")) || f.String() != "" {
+			t.Errorf("Parse(%q): want a filter matching everything", in)
+		}
+	}
+}
+
+func TestParse_invalid(t *testing.T) {
+	tests := []struct {
+		in         string
+		wantOffset int
+		wantMsg    string
+	}{
+		{"size = 1", 0, "unknown field `size`, the fields are content, index, length"},
+		{"index = 1 AND size = 1", 14, "unknown field `size`"},
+		{"= 1", 0, "expected a field but found `=`"},
+		{"1 = index", 0, "expected a field but found `1`"},
+		{"\"index\" = 1", 0, "expected a field but found `\"index\"`"},
+		{"AND", 0, "expected a field but found `AND`"},
+		{"index", 5, "expected a comparison but the filter ended"},
+		{"index 1", 6, "expected =, !=, < or > but found `1`"},
+		{"index AND", 6, "expected =, !=, < or > but found `AND`"},
+		{"index =", 7, "expected a value but the filter ended"},
+		{"index = =", 8, "expected a value but found `=`"},
+		{"index = AND", 8, "expected a value but found `AND`"},
+		{"index = abc", 8, "the field `index` is compared with an integer, not `abc`"},
+		{"index = \"1\"", 8, "compared with an integer, not `\"1\"`"},
+		{"index = -", 8, "the integer `-` is invalid"},
+		{"index = 99999999999999999999", 8, "the integer `99999999999999999999` is invalid"},
+		{"index = 1 length = 2", 10, "expected AND but found `length`"},
+		{"index = 1 and length = 2", 10, "expected AND but found `and`"},
+		{"index = 1 = 2", 10, "expected AND but found `=`"},
+		{"index = 1 AND", 13, "expected a field but the filter ended"},
+		{"index = 1 AND AND", 14, "expected a field but found `AND`"},
+		{"index ! 1", 6, "expected `!=`"},
+		{"index !", 6, "expected `!=`"},
+		{"index = 1 @", 10, "unexpected character '@'"},
+		{"index >= 1", 7, "expected a value but found `=`"},
+		{"content = \"abc", 10, "the string is not terminated"},
+		{"content = \"abc\\\"", 10, "the string is not terminated"},
+		{"content = \"\\q\"", 10, "the string has an invalid escape"},
+		{"content = (a)", 10, "unexpected character '('"},
+	}
+	for _, test := range tests {
+		_, err := Parse(test.in, testFields)
+		syntax, ok := err.(*SyntaxError)
+		if !ok {
+			t.Errorf("Parse(%q): want a SyntaxError got %v", test.in, err)
+			continue
+		}
+		if syntax.Offset != test.wantOffset || !strings.Contains(syntax.Message, test.wantMsg) {
+			t.Errorf("Parse(%q): want %q at offset %d got %v", test.in, test.wantMsg, test.wantOffset, err)
+		}
+	}
+}
+
+func TestSyntaxError(t *testing.T) {
+	err := &SyntaxError{Offset: 3, Message: "expected a value"}
+	if got, want := err.Error(), "offset 3: expected a value"; got != want {
+		t.Errorf("Error: want %q got %q", want, got)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		filter string
+		item   func(string) interface{}
+		want   bool
+	}{
+		{"index = 1", testItem(1, 0, ""), true},
+		{"index = 1", testItem(2, 0, ""), false},
+		{"index != 1", testItem(2, 0, ""), true},
+		{"index != 1", testItem(1, 0, ""), false},
+		{"index < 1", testItem(0, 0, ""), true},
+		{"index < 1", testItem(1, 0, ""), false},
+		{"index > 1", testItem(2, 0, ""), true},
+		{"index > 1", testItem(1, 0, ""), false},
+		{"index > -1", testItem(0, 0, ""), true},
+		{"content = abc", testItem(0, 0, "abc"), true},
+		{"content = \"a b\"", testItem(0, 0, "a b"), true},
+		{"content != abc", testItem(0, 0, "abd"), true},
+		{"content < b", testItem(0, 0, "abc"), true},
+		{"content < b", testItem(0, 0, "b"), false},
+		{"content > b", testItem(0, 0, "ba"), true},
+		// Strings order byte by byte, so capitals come first.
+		{"content < a", testItem(0, 0, "Z"), true},
+		{"content = 10", testItem(0, 0, "10"), true},
+		{"index > 0 AND length < 3", testItem(1, 2, ""), true},
+		{"index > 0 AND length < 3", testItem(1, 3, ""), false},
+		{"index > 0 AND length < 3", testItem(0, 2, ""), false},
+		{"index > 0 AND index < 3 AND content != x", testItem(2, 0, "y"), true},
+		{"index > 0 AND index < 3 AND content != x", testItem(2, 0, "x"), false},
+	}
+	for _, test := range tests {
+		f, err := Parse(test.filter, testFields)
+		if err != nil {
+			t.Fatalf("Parse(%q): unexpected err %v", test.filter, err)
+		}
+		if got := f.Matches(test.item); got != test.want {
+			t.Errorf("Matches(%q): want %t got %t", test.filter, test.want, got)
+		}
+	}
+}
+
+func TestMatches_wrongType(t *testing.T) {
+	// A value of the wrong type never matches, even for !=.
+	f, _ := Parse("index != 1", testFields)
+	if f.Matches(func(string) interface{} { return "2" }) {
+		t.Errorf("Matches: want a string index to not match")
+	}
+}
+
+func TestString_roundTrip(t *testing.T) {
+	for _, in := range []string{
+		"index = 1",
+		"content = \"a b\" AND index > -3",
+		"content != \"quote \\\" and \\\\ slash\"",
+		"content = \"tab\\there\"",
+	} {
+		f, err := Parse(in, testFields)
+		if err != nil {
+			t.Fatalf("Parse(%q): unexpected err %v", in, err)
+		}
+		again, err := Parse(f.String(), testFields)
+		if err != nil || again.String() != f.String() {
+			t.Errorf("Parse(%q).String() = %q: want it to parse the same got %v, %v", in, f.String(), again, err)
+		}
+	}
+}
+
+func TestType_String(t *testing.T) {
+	if Int.String() != "an integer" || String.String() != "a string" {
+		t.Errorf("Type.String: got %q and %q", Int.String(), String.String())
+	}
+}
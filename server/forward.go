@@ -0,0 +1,163 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MaxForwardDepth is the most calls an Echo request may be forwarded along.
+const MaxForwardDepth = 5
+
+// ForwardHopHeader is the metadata key in which forwarded calls carry their
+// position in the chain of forwarded calls.
+const ForwardHopHeader = "showcase-forward-hop"
+
+// ForwardPropagatePrefix prefixes the metadata keys that forwarded calls
+// keep, in addition to the namespace and quota project.
+const ForwardPropagatePrefix = "showcase-propagate-"
+
+var forwarderSingleton = NewForwarder()
+
+// GetForwarderInstance returns the forwarder singleton.
+func GetForwarderInstance() Forwarder {
+	return forwarderSingleton
+}
+
+// Forwarder makes the Echo calls a server forwards to itself.
+type Forwarder interface {
+	// Watch makes forwarded calls go to the server listening on the address.
+	Watch(addr net.Addr)
+
+	// Forward calls Echo on the watched server with the deadline and
+	// propagated metadata of the incoming context, as the next hop after
+	// the given one.
+	Forward(ctx context.Context, hop int32, in *pb.EchoRequest) (*pb.EchoResponse, error)
+}
+
+// NewForwarder returns a forwarder that watches no server.
+func NewForwarder() Forwarder {
+	return &forwarder{}
+}
+
+type forwarder struct {
+	mu     sync.Mutex
+	addr   string
+	client pb.EchoClient
+}
+
+func (f *forwarder) Watch(addr net.Addr) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.addr = addr.String()
+	f.client = nil
+}
+
+// echoClient returns a client of the watched server, connected on first use.
+func (f *forwarder) echoClient() (pb.EchoClient, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.client != nil {
+		return f.client, nil
+	}
+	if f.addr == "" {
+		return nil, status.Error(codes.FailedPrecondition, "The server does not know its own address to forward calls to.")
+	}
+	conn, err := grpc.Dial(f.addr, grpc.WithInsecure(), grpc.WithUnaryInterceptor(decompressionClientInterceptor))
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "Could not connect to %s to forward calls: %s.", f.addr, err)
+	}
+	f.client = pb.NewEchoClient(conn)
+	return f.client, nil
+}
+
+func (f *forwarder) Forward(ctx context.Context, hop int32, in *pb.EchoRequest) (*pb.EchoResponse, error) {
+	client, err := f.echoClient()
+	if err != nil {
+		return nil, err
+	}
+	incoming, _ := metadata.FromIncomingContext(ctx)
+	outgoing := metadata.Pairs(ForwardHopHeader, strconv.Itoa(int(hop+1)))
+	for key, values := range incoming {
+		if propagated(key) {
+			outgoing[key] = values
+		}
+	}
+	return client.Echo(metadata.NewOutgoingContext(ctx, outgoing), in)
+}
+
+// propagated reports whether forwarded calls keep the metadata key.
+func propagated(key string) bool {
+	return key == NamespaceHeader || key == QuotaProjectHeader || strings.HasPrefix(key, ForwardPropagatePrefix)
+}
+
+// ForwardHop describes the call of the context as a hop of a chain of
+// forwarded calls that goes depth calls further. It reports false if the call
+// is neither forwarded nor forwards. It fails if the hop header is malformed,
+// or if the chain would be longer than MaxForwardDepth, which a call that
+// forwards to a server other than itself could loop into.
+func ForwardHop(ctx context.Context, depth int32) (*pb.ForwardHop, bool, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(ForwardHopHeader)
+	if len(values) == 0 && depth == 0 {
+		return nil, false, nil
+	}
+	hop := 0
+	if len(values) > 0 {
+		var err error
+		if hop, err = strconv.Atoi(values[0]); err != nil || hop < 0 || len(values) > 1 {
+			return nil, false, showcaseerrors.Metadata(
+				ForwardHopHeader,
+				"The %s metadata must be a single non-negative integer.",
+				ForwardHopHeader)
+		}
+	}
+	if hop+int(depth) > MaxForwardDepth {
+		return nil, false, status.ErrorProto(&spb.Status{
+			Code:    int32(codes.Aborted),
+			Message: fmt.Sprintf("The call is hop %d of a chain of forwarded calls longer than %d.", hop, MaxForwardDepth),
+			Details: []*any.Any{showcaseerrors.ErrorInfo(showcaseerrors.ForwardLoop, showcaseerrors.Domain, map[string]string{
+				"hop":       strconv.Itoa(hop),
+				"max_depth": strconv.Itoa(MaxForwardDepth),
+			})},
+		})
+	}
+	h := &pb.ForwardHop{Hop: int32(hop), Headers: map[string]string{}}
+	if deadline, ok := ctx.Deadline(); ok {
+		h.RemainingDeadline = ptypes.DurationProto(time.Until(deadline))
+	}
+	for key, values := range md {
+		if !strings.HasPrefix(key, ":") {
+			h.Headers[key] = strings.Join(values, ",")
+		}
+	}
+	return h, true, nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestForwardHop(t *testing.T) {
+	if _, ok, err := ForwardHop(context.Background(), 0); ok || err != nil {
+		t.Errorf("ForwardHop of a call that does not forward: want false, got %t, %v", ok, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	ctx = metadata.NewIncomingContext(ctx, metadata.MD{
+		ForwardHopHeader: {"2"},
+		"x-other":        {"a", "b"},
+	})
+	hop, ok, err := ForwardHop(ctx, 0)
+	if !ok || err != nil {
+		t.Fatalf("ForwardHop of a forwarded call: want true, got %t, %v", ok, err)
+	}
+	if hop.GetHop() != 2 {
+		t.Errorf("ForwardHop: want hop 2, got %d", hop.GetHop())
+	}
+	if got := hop.GetHeaders()["x-other"]; got != "a,b" {
+		t.Errorf("ForwardHop: want the header x-other a,b, got %q", got)
+	}
+	if d, err := ptypes.Duration(hop.GetRemainingDeadline()); err != nil || d <= 0 || d > time.Minute {
+		t.Errorf("ForwardHop: want a remaining deadline of at most a minute, got %v", hop.GetRemainingDeadline())
+	}
+
+	// A call with no hop header is the first hop.
+	hop, ok, err = ForwardHop(context.Background(), 1)
+	if !ok || err != nil || hop.GetHop() != 0 || hop.GetRemainingDeadline() != nil {
+		t.Errorf("ForwardHop of a call that forwards: want hop 0 without a deadline, got %v, %t, %v", hop, ok, err)
+	}
+}
+
+func TestForwardHop_errors(t *testing.T) {
+	tests := []struct {
+		hop   []string
+		depth int32
+		want  codes.Code
+	}{
+		{[]string{"x"}, 0, codes.InvalidArgument},
+		{[]string{"-1"}, 1, codes.InvalidArgument},
+		{[]string{"1", "2"}, 0, codes.InvalidArgument},
+		{[]string{"5"}, 1, codes.Aborted},
+		{[]string{"6"}, 0, codes.Aborted},
+	}
+	for _, test := range tests {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{ForwardHopHeader: test.hop})
+		if _, _, err := ForwardHop(ctx, test.depth); status.Code(err) != test.want {
+			t.Errorf("ForwardHop(%q, %d): want %s, got %v", test.hop, test.depth, test.want, err)
+		}
+	}
+}
+
+// headerEchoServer answers Echo with the metadata it received as a hop.
+type headerEchoServer struct {
+	pb.EchoServer
+}
+
+func (headerEchoServer) Echo(ctx context.Context, _ *pb.EchoRequest) (*pb.EchoResponse, error) {
+	hop, _, err := ForwardHop(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.EchoResponse{ForwardHops: []*pb.ForwardHop{hop}}, nil
+}
+
+func TestForwarder(t *testing.T) {
+	f := NewForwarder()
+	if _, err := f.Forward(context.Background(), 0, &pb.EchoRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Forward without an address: want FailedPrecondition, got %v", err)
+	}
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	pb.RegisterEchoServer(s, headerEchoServer{})
+	go s.Serve(lis)
+	defer s.Stop()
+	f.Watch(lis.Addr())
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		NamespaceHeader, "team-a",
+		QuotaProjectHeader, "my-project",
+		ForwardPropagatePrefix+"trace", "abc",
+		"x-other", "dropped",
+		ForwardHopHeader, "1"))
+	resp, err := f.Forward(ctx, 1, &pb.EchoRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := resp.GetForwardHops()[0]
+	if got.GetHop() != 2 {
+		t.Errorf("Forward: want hop 2, got %d", got.GetHop())
+	}
+	for key, want := range map[string]string{
+		NamespaceHeader:                  "team-a",
+		QuotaProjectHeader:               "my-project",
+		ForwardPropagatePrefix + "trace": "abc",
+		"x-other":                        "",
+	} {
+		if got.GetHeaders()[key] != want {
+			t.Errorf("Forward: want the header %s %q, got %q", key, want, got.GetHeaders()[key])
+		}
+	}
+}
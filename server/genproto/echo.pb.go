@@ -8,10 +8,14 @@ import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	duration "github.com/golang/protobuf/ptypes/duration"
+	empty "github.com/golang/protobuf/ptypes/empty"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	longrunning "google.golang.org/genproto/googleapis/longrunning"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
 	status "google.golang.org/genproto/googleapis/rpc/status"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status1 "google.golang.org/grpc/status"
@@ -29,16 +33,273 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// The standard error detail types that can be attached to the error.
+type FailEchoWithDetailsRequest_DetailType int32
+
+const (
+	FailEchoWithDetailsRequest_DETAIL_TYPE_UNSPECIFIED FailEchoWithDetailsRequest_DetailType = 0
+	// A google.rpc.LocalizedMessage built from `locale` and
+	// `localized_message`.
+	FailEchoWithDetailsRequest_LOCALIZED_MESSAGE FailEchoWithDetailsRequest_DetailType = 1
+	// A google.rpc.Help built from `links`.
+	FailEchoWithDetailsRequest_HELP FailEchoWithDetailsRequest_DetailType = 2
+	// A google.rpc.QuotaFailure built from `quota_violations`.
+	FailEchoWithDetailsRequest_QUOTA_FAILURE FailEchoWithDetailsRequest_DetailType = 3
+	// A google.rpc.PreconditionFailure built from `precondition_violations`.
+	FailEchoWithDetailsRequest_PRECONDITION_FAILURE FailEchoWithDetailsRequest_DetailType = 4
+)
+
+var FailEchoWithDetailsRequest_DetailType_name = map[int32]string{
+	0: "DETAIL_TYPE_UNSPECIFIED",
+	1: "LOCALIZED_MESSAGE",
+	2: "HELP",
+	3: "QUOTA_FAILURE",
+	4: "PRECONDITION_FAILURE",
+}
+
+var FailEchoWithDetailsRequest_DetailType_value = map[string]int32{
+	"DETAIL_TYPE_UNSPECIFIED": 0,
+	"LOCALIZED_MESSAGE":       1,
+	"HELP":                    2,
+	"QUOTA_FAILURE":           3,
+	"PRECONDITION_FAILURE":    4,
+}
+
+func (x FailEchoWithDetailsRequest_DetailType) String() string {
+	return proto.EnumName(FailEchoWithDetailsRequest_DetailType_name, int32(x))
+}
+
+func (FailEchoWithDetailsRequest_DetailType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{15, 0}
+}
+
+// How an Expand stream ended.
+type ExpandStatus_Termination int32
+
+const (
+	ExpandStatus_TERMINATION_UNSPECIFIED ExpandStatus_Termination = 0
+	// The stream sent all its messages and ended with OK.
+	ExpandStatus_COMPLETED ExpandStatus_Termination = 1
+	// The stream ended with an error, such as an `ExpandRequest.error`.
+	ExpandStatus_FAILED ExpandStatus_Termination = 2
+	// The client cancelled the stream.
+	ExpandStatus_CANCELLED ExpandStatus_Termination = 3
+	// The deadline of the stream passed.
+	ExpandStatus_DEADLINE_EXCEEDED ExpandStatus_Termination = 4
+)
+
+var ExpandStatus_Termination_name = map[int32]string{
+	0: "TERMINATION_UNSPECIFIED",
+	1: "COMPLETED",
+	2: "FAILED",
+	3: "CANCELLED",
+	4: "DEADLINE_EXCEEDED",
+}
+
+var ExpandStatus_Termination_value = map[string]int32{
+	"TERMINATION_UNSPECIFIED": 0,
+	"COMPLETED":               1,
+	"FAILED":                  2,
+	"CANCELLED":               3,
+	"DEADLINE_EXCEEDED":       4,
+}
+
+func (x ExpandStatus_Termination) String() string {
+	return proto.EnumName(ExpandStatus_Termination_name, int32(x))
+}
+
+func (ExpandStatus_Termination) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{25, 0}
+}
+
+// Canned sets of numeric edge cases.
+type RepeatNumericEdgeCasesRequest_EdgeCases int32
+
+const (
+	RepeatNumericEdgeCasesRequest_EDGE_CASES_UNSPECIFIED RepeatNumericEdgeCasesRequest_EdgeCases = 0
+	// NaN as a double and as a float.
+	RepeatNumericEdgeCasesRequest_NOT_A_NUMBER RepeatNumericEdgeCasesRequest_EdgeCases = 1
+	// Positive and then negative infinity, as a double and as a float.
+	RepeatNumericEdgeCasesRequest_INFINITIES RepeatNumericEdgeCasesRequest_EdgeCases = 2
+	// The largest and then the smallest values of each integer type.
+	RepeatNumericEdgeCasesRequest_INTEGER_LIMITS RepeatNumericEdgeCasesRequest_EdgeCases = 3
+	// 2^53-1 and then 2^53+1, the largest integer a double holds exactly
+	// and the smallest it does not, as each integer type.
+	RepeatNumericEdgeCasesRequest_SAFE_INTEGER_BOUNDS RepeatNumericEdgeCasesRequest_EdgeCases = 4
+	// Each of the sets above, in order.
+	RepeatNumericEdgeCasesRequest_ALL RepeatNumericEdgeCasesRequest_EdgeCases = 5
+)
+
+var RepeatNumericEdgeCasesRequest_EdgeCases_name = map[int32]string{
+	0: "EDGE_CASES_UNSPECIFIED",
+	1: "NOT_A_NUMBER",
+	2: "INFINITIES",
+	3: "INTEGER_LIMITS",
+	4: "SAFE_INTEGER_BOUNDS",
+	5: "ALL",
+}
+
+var RepeatNumericEdgeCasesRequest_EdgeCases_value = map[string]int32{
+	"EDGE_CASES_UNSPECIFIED": 0,
+	"NOT_A_NUMBER":           1,
+	"INFINITIES":             2,
+	"INTEGER_LIMITS":         3,
+	"SAFE_INTEGER_BOUNDS":    4,
+	"ALL":                    5,
+}
+
+func (x RepeatNumericEdgeCasesRequest_EdgeCases) String() string {
+	return proto.EnumName(RepeatNumericEdgeCasesRequest_EdgeCases_name, int32(x))
+}
+
+func (RepeatNumericEdgeCasesRequest_EdgeCases) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{42, 0}
+}
+
 // The request message used for the Echo, Collect and Chat methods. If content
 // is set in this message then the request will succeed. If a status is
 type EchoRequest struct {
 	// Types that are valid to be assigned to Response:
 	//	*EchoRequest_Content
 	//	*EchoRequest_Error
-	Response             isEchoRequest_Response `protobuf_oneof:"response"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+	Response isEchoRequest_Response `protobuf_oneof:"response"`
+	// If set, the content must match this regular expression (RE2 syntax),
+	// otherwise an INVALID_ARGUMENT error with a google.rpc.BadRequest detail
+	// on `content` is returned. The pattern is unanchored, so use `^` and `$`
+	// to match the whole content. Only the Echo method validates content.
+	ValidateContentRegex string `protobuf:"bytes,3,opt,name=validate_content_regex,json=validateContentRegex,proto3" json:"validate_content_regex,omitempty"`
+	// If set, the Echo method returns these caching hints in the
+	// `showcase-cache-control` response header, formatted as an HTTP
+	// `Cache-Control` header value.
+	CacheControl *CacheControl `protobuf:"bytes,4,opt,name=cache_control,json=cacheControl,proto3" json:"cache_control,omitempty"`
+	// If set on the first message of a Chat stream, the server closes the
+	// stream with ABORTED when no message arrives within this long of the
+	// previous one. It sends a final response explaining why first.
+	IdleTimeout *duration.Duration `protobuf:"bytes,5,opt,name=idle_timeout,json=idleTimeout,proto3" json:"idle_timeout,omitempty"`
+	// By default the Echo method copies fields of the request that the server
+	// does not know into its response, byte for byte. If true, they are dropped
+	// instead.
+	StripUnknownFields bool `protobuf:"varint,6,opt,name=strip_unknown_fields,json=stripUnknownFields,proto3" json:"strip_unknown_fields,omitempty"`
+	// An identifier of the request chosen by the client. The Echo and Chat
+	// methods return it in `EchoResponse.client_sequence`, so that a client
+	// issuing many concurrent requests can check that each response belongs to
+	// the request it answers.
+	ClientSequence int64 `protobuf:"varint,7,opt,name=client_sequence,json=clientSequence,proto3" json:"client_sequence,omitempty"`
+	// The number of times `content` is repeated in
+	// `EchoResponse.repeated_content`. Must not be negative. If the response
+	// would exceed the server's message size limit, the Echo method fails with
+	// RESOURCE_EXHAUSTED and an ErrorInfo with reason `RESPONSE_TOO_LARGE`,
+	// whose metadata suggests the Expand method instead.
+	RepeatCount int32 `protobuf:"varint,8,opt,name=repeat_count,json=repeatCount,proto3" json:"repeat_count,omitempty"`
+	// If true, the response carries the attempt numbers of the call's
+	// `grpc-previous-rpc-attempts` metadata and of the client attempt metadata
+	// named by the server's `client_attempt_header` setting.
+	EchoAttempts bool `protobuf:"varint,9,opt,name=echo_attempts,json=echoAttempts,proto3" json:"echo_attempts,omitempty"`
+	// If true on a message of a Collect stream, the server responds at once
+	// with the content collected so far, including this message's. Messages
+	// sent after it are discarded, and their number is returned in the
+	// `showcase-collect-discarded` trailer once the client closes the stream.
+	Flush bool `protobuf:"varint,10,opt,name=flush,proto3" json:"flush,omitempty"`
+	// If set, the Echo method answers a request identical to one it answered
+	// less than this long ago, apart from this field, with the earlier
+	// response and `EchoResponse.served_from_cache` set instead of processing
+	// it again. Failed requests are not remembered. Must not be negative.
+	DedupeWindow *duration.Duration `protobuf:"bytes,11,opt,name=dedupe_window,json=dedupeWindow,proto3" json:"dedupe_window,omitempty"`
+	// The fields of the response to return. If unset, all of them are. Paths
+	// that name unknown fields, or fields within repeated fields, fail with
+	// INVALID_ARGUMENT. Only the Echo method applies it.
+	ReadMask *field_mask.FieldMask `protobuf:"bytes,12,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	// If true, the Echo method appends `"\r\n100% %41%%0A ✓ 🙂"` (a CR/LF pair,
+	// literal and percent-encoding-like `%` sequences, and non-ASCII and emoji
+	// characters) to the message of `error`, which must have a code other
+	// than OK. gRPC percent-encodes status messages in the `grpc-message`
+	// trailer, and clients should receive the message exactly.
+	HazardousErrorMessage bool `protobuf:"varint,13,opt,name=hazardous_error_message,json=hazardousErrorMessage,proto3" json:"hazardous_error_message,omitempty"`
+	// If true on the first message of a Collect stream, messages with an
+	// `error` do not fail the stream. Their errors are returned, with the
+	// positions of the messages, in `EchoResponse.collect_failures`, and the
+	// content of the other messages is collected as usual.
+	ContinueOnError bool `protobuf:"varint,14,opt,name=continue_on_error,json=continueOnError,proto3" json:"continue_on_error,omitempty"`
+	// If true on the first message of a Chat stream, each response carries an
+	// `ack_sequence`, and the client acknowledges responses with `ack`. The
+	// number of responses never acknowledged, and the number of responses sent
+	// again, are returned in the `showcase-chat-unacked` and
+	// `showcase-chat-resent` trailers when the stream ends.
+	AckMode bool `protobuf:"varint,15,opt,name=ack_mode,json=ackMode,proto3" json:"ack_mode,omitempty"`
+	// The most times a Chat stream in `ack_mode` sends a response again before
+	// it fails with DATA_LOSS. If unset, 3. Must not be negative. It is read
+	// from the first message of the stream.
+	MaxResends int32 `protobuf:"varint,16,opt,name=max_resends,json=maxResends,proto3" json:"max_resends,omitempty"`
+	// Acknowledgements of the responses of a Chat stream in `ack_mode`. A
+	// message that carries neither `content` nor `error` is only an
+	// acknowledgement, and is not answered.
+	Ack *ChatAck `protobuf:"bytes,17,opt,name=ack,proto3" json:"ack,omitempty"`
+	// Trailing metadata to end a Chat stream with, whatever its status, if set
+	// on its first message. Keys must be lowercase letters, digits, `-`, `_`
+	// and `.`, and must neither start with `grpc-` nor end with `-bin`. Values
+	// must be printable ASCII.
+	Trailers map[string]string `protobuf:"bytes,18,rep,name=trailers,proto3" json:"trailers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// If true on the first message of a Chat stream, a message with an
+	// `error` ends the stream with the error and an EchoResponse detail
+	// summarizing the responses sent before it, with `is_summary`,
+	// `message_count` and `checksum` set as by `ExpandRequest.with_summary`.
+	ErrorSummary bool `protobuf:"varint,19,opt,name=error_summary,json=errorSummary,proto3" json:"error_summary,omitempty"`
+	// If true, the response reports how the request reached the server in
+	// `transport_info`.
+	IncludeTransportInfo bool `protobuf:"varint,20,opt,name=include_transport_info,json=includeTransportInfo,proto3" json:"include_transport_info,omitempty"`
+	// If set on the first message of a Collect stream, the stream is read in
+	// full as usual, but instead of the response the server ends it with this
+	// error. The response it would have sent is appended to the details of
+	// the error as an EchoResponse. The code must not be OK.
+	ErrorAfterClose *status.Status `protobuf:"bytes,21,opt,name=error_after_close,json=errorAfterClose,proto3" json:"error_after_close,omitempty"`
+	// The position of the message in a Collect stream, as numbered by the
+	// client. If the first message of the stream carries one, every message
+	// must, and each must be greater than the one before it, or the stream
+	// fails with FAILED_PRECONDITION.
+	Sequence *wrappers.Int64Value `protobuf:"bytes,22,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// If true on the first message of a Collect stream with a `sequence`, each
+	// `sequence` must be exactly one more than the one before it.
+	RequireContiguous bool `protobuf:"varint,23,opt,name=require_contiguous,json=requireContiguous,proto3" json:"require_contiguous,omitempty"`
+	// If positive, the server answers an Echo request by calling Echo on
+	// itself with `forward_depth` one less, and returns the response of that
+	// call. The forwarded call keeps the deadline of the request, and only its
+	// `showcase-namespace`, `x-goog-user-project` and `showcase-propagate-*`
+	// metadata. At most 5.
+	ForwardDepth int32 `protobuf:"varint,24,opt,name=forward_depth,json=forwardDepth,proto3" json:"forward_depth,omitempty"`
+	// If positive, the Echo method fails the first this many attempts of the
+	// request with UNAVAILABLE and an ErrorInfo with reason `ATTEMPT_FAILED`,
+	// and answers the attempts after them as usual. Attempts are those with
+	// the same `request_id` or, if it is unset, identical requests. A request
+	// not attempted for 10 minutes is counted from its first attempt again.
+	// Must not be negative.
+	FailFirstAttempts int32 `protobuf:"varint,25,opt,name=fail_first_attempts,json=failFirstAttempts,proto3" json:"fail_first_attempts,omitempty"`
+	// An identifier of the logical request chosen by the client, the same for
+	// each of its attempts, by which `fail_first_attempts` counts them.
+	RequestId string `protobuf:"bytes,26,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// If true, the Echo method returns `content` followed by the bytes
+	// `0xFF 0xFE`, which are not valid UTF-8, in `EchoResponse.content`. proto3
+	// requires string fields to be valid UTF-8, so this response does not
+	// conform, and serves only to document whether clients reject it. The
+	// content is written as raw wire bytes, so it is empty in JSON responses.
+	// Calls setting it fail with PERMISSION_DENIED unless the server was
+	// started with `--enable-nonconforming`.
+	CorruptUtf8Response bool `protobuf:"varint,27,opt,name=corrupt_utf8_response,json=corruptUtf8Response,proto3" json:"corrupt_utf8_response,omitempty"`
+	// If positive on the first message of a Chat stream, the most messages the
+	// server reads from the stream, counting the first. Once it has answered
+	// that many, it sends a response with `limit_reached` set, even past
+	// `max_sent_messages`, stops reading and ends the stream with OUT_OF_RANGE
+	// and an ErrorInfo with reason `RECEIVE_LIMIT_REACHED`. At most 10000.
+	// Must not be negative.
+	MaxReceivedMessages int32 `protobuf:"varint,28,opt,name=max_received_messages,json=maxReceivedMessages,proto3" json:"max_received_messages,omitempty"`
+	// If positive on the first message of a Chat stream, the most responses
+	// the server sends on the stream. It keeps reading messages after that
+	// until the client closes the stream, without answering them, and returns
+	// the number of responses it dropped in the `showcase-chat-dropped`
+	// trailer. At most 10000. Must not be negative.
+	MaxSentMessages      int32    `protobuf:"varint,29,opt,name=max_sent_messages,json=maxSentMessages,proto3" json:"max_sent_messages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *EchoRequest) Reset()         { *m = EchoRequest{} }
@@ -103,483 +364,3701 @@ func (m *EchoRequest) GetError() *status.Status {
 	return nil
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*EchoRequest) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*EchoRequest_Content)(nil),
-		(*EchoRequest_Error)(nil),
+func (m *EchoRequest) GetValidateContentRegex() string {
+	if m != nil {
+		return m.ValidateContentRegex
 	}
+	return ""
 }
 
-// The response message for the Echo methods.
-type EchoResponse struct {
-	// The content specified in the request.
-	Content              string   `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *EchoRequest) GetCacheControl() *CacheControl {
+	if m != nil {
+		return m.CacheControl
+	}
+	return nil
 }
 
-func (m *EchoResponse) Reset()         { *m = EchoResponse{} }
-func (m *EchoResponse) String() string { return proto.CompactTextString(m) }
-func (*EchoResponse) ProtoMessage()    {}
-func (*EchoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6220e75e6899216f, []int{1}
+func (m *EchoRequest) GetIdleTimeout() *duration.Duration {
+	if m != nil {
+		return m.IdleTimeout
+	}
+	return nil
 }
 
-func (m *EchoResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_EchoResponse.Unmarshal(m, b)
-}
-func (m *EchoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_EchoResponse.Marshal(b, m, deterministic)
-}
-func (m *EchoResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EchoResponse.Merge(m, src)
-}
-func (m *EchoResponse) XXX_Size() int {
-	return xxx_messageInfo_EchoResponse.Size(m)
-}
-func (m *EchoResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_EchoResponse.DiscardUnknown(m)
+func (m *EchoRequest) GetStripUnknownFields() bool {
+	if m != nil {
+		return m.StripUnknownFields
+	}
+	return false
 }
 
-var xxx_messageInfo_EchoResponse proto.InternalMessageInfo
-
-func (m *EchoResponse) GetContent() string {
+func (m *EchoRequest) GetClientSequence() int64 {
 	if m != nil {
-		return m.Content
+		return m.ClientSequence
 	}
-	return ""
+	return 0
 }
 
-// The request message for the Expand method.
-type ExpandRequest struct {
-	// The content that will be split into words and returned on the stream.
-	Content string `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
-	// The error that is thrown after all words are sent on the stream.
-	Error                *status.Status `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+func (m *EchoRequest) GetRepeatCount() int32 {
+	if m != nil {
+		return m.RepeatCount
+	}
+	return 0
 }
 
-func (m *ExpandRequest) Reset()         { *m = ExpandRequest{} }
-func (m *ExpandRequest) String() string { return proto.CompactTextString(m) }
-func (*ExpandRequest) ProtoMessage()    {}
-func (*ExpandRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6220e75e6899216f, []int{2}
+func (m *EchoRequest) GetEchoAttempts() bool {
+	if m != nil {
+		return m.EchoAttempts
+	}
+	return false
 }
 
-func (m *ExpandRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ExpandRequest.Unmarshal(m, b)
+func (m *EchoRequest) GetFlush() bool {
+	if m != nil {
+		return m.Flush
+	}
+	return false
 }
-func (m *ExpandRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ExpandRequest.Marshal(b, m, deterministic)
+
+func (m *EchoRequest) GetDedupeWindow() *duration.Duration {
+	if m != nil {
+		return m.DedupeWindow
+	}
+	return nil
 }
-func (m *ExpandRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ExpandRequest.Merge(m, src)
+
+func (m *EchoRequest) GetReadMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.ReadMask
+	}
+	return nil
 }
-func (m *ExpandRequest) XXX_Size() int {
-	return xxx_messageInfo_ExpandRequest.Size(m)
+
+func (m *EchoRequest) GetHazardousErrorMessage() bool {
+	if m != nil {
+		return m.HazardousErrorMessage
+	}
+	return false
 }
-func (m *ExpandRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ExpandRequest.DiscardUnknown(m)
+
+func (m *EchoRequest) GetContinueOnError() bool {
+	if m != nil {
+		return m.ContinueOnError
+	}
+	return false
 }
 
-var xxx_messageInfo_ExpandRequest proto.InternalMessageInfo
+func (m *EchoRequest) GetAckMode() bool {
+	if m != nil {
+		return m.AckMode
+	}
+	return false
+}
 
-func (m *ExpandRequest) GetContent() string {
+func (m *EchoRequest) GetMaxResends() int32 {
 	if m != nil {
-		return m.Content
+		return m.MaxResends
 	}
-	return ""
+	return 0
 }
 
-func (m *ExpandRequest) GetError() *status.Status {
+func (m *EchoRequest) GetAck() *ChatAck {
 	if m != nil {
-		return m.Error
+		return m.Ack
 	}
 	return nil
 }
 
-// The request for the PagedExpand method.
-type PagedExpandRequest struct {
-	// The string to expand.
-	Content string `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
-	// The amount of words to returned in each page.
-	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	// The position of the page to be returned.
-	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *EchoRequest) GetTrailers() map[string]string {
+	if m != nil {
+		return m.Trailers
+	}
+	return nil
 }
 
-func (m *PagedExpandRequest) Reset()         { *m = PagedExpandRequest{} }
-func (m *PagedExpandRequest) String() string { return proto.CompactTextString(m) }
-func (*PagedExpandRequest) ProtoMessage()    {}
-func (*PagedExpandRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6220e75e6899216f, []int{3}
+func (m *EchoRequest) GetErrorSummary() bool {
+	if m != nil {
+		return m.ErrorSummary
+	}
+	return false
 }
 
-func (m *PagedExpandRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PagedExpandRequest.Unmarshal(m, b)
+func (m *EchoRequest) GetIncludeTransportInfo() bool {
+	if m != nil {
+		return m.IncludeTransportInfo
+	}
+	return false
 }
-func (m *PagedExpandRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PagedExpandRequest.Marshal(b, m, deterministic)
+
+func (m *EchoRequest) GetErrorAfterClose() *status.Status {
+	if m != nil {
+		return m.ErrorAfterClose
+	}
+	return nil
 }
-func (m *PagedExpandRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PagedExpandRequest.Merge(m, src)
+
+func (m *EchoRequest) GetSequence() *wrappers.Int64Value {
+	if m != nil {
+		return m.Sequence
+	}
+	return nil
 }
-func (m *PagedExpandRequest) XXX_Size() int {
-	return xxx_messageInfo_PagedExpandRequest.Size(m)
+
+func (m *EchoRequest) GetRequireContiguous() bool {
+	if m != nil {
+		return m.RequireContiguous
+	}
+	return false
 }
-func (m *PagedExpandRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_PagedExpandRequest.DiscardUnknown(m)
+
+func (m *EchoRequest) GetForwardDepth() int32 {
+	if m != nil {
+		return m.ForwardDepth
+	}
+	return 0
 }
 
-var xxx_messageInfo_PagedExpandRequest proto.InternalMessageInfo
+func (m *EchoRequest) GetFailFirstAttempts() int32 {
+	if m != nil {
+		return m.FailFirstAttempts
+	}
+	return 0
+}
 
-func (m *PagedExpandRequest) GetContent() string {
+func (m *EchoRequest) GetRequestId() string {
 	if m != nil {
-		return m.Content
+		return m.RequestId
 	}
 	return ""
 }
 
-func (m *PagedExpandRequest) GetPageSize() int32 {
+func (m *EchoRequest) GetCorruptUtf8Response() bool {
 	if m != nil {
-		return m.PageSize
+		return m.CorruptUtf8Response
+	}
+	return false
+}
+
+func (m *EchoRequest) GetMaxReceivedMessages() int32 {
+	if m != nil {
+		return m.MaxReceivedMessages
 	}
 	return 0
 }
 
-func (m *PagedExpandRequest) GetPageToken() string {
+func (m *EchoRequest) GetMaxSentMessages() int32 {
 	if m != nil {
-		return m.PageToken
+		return m.MaxSentMessages
 	}
-	return ""
+	return 0
 }
 
-// The response for the PagedExpand method.
-type PagedExpandResponse struct {
-	// The words that were expanded.
-	Responses []*EchoResponse `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"`
-	// The next page token.
-	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*EchoRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*EchoRequest_Content)(nil),
+		(*EchoRequest_Error)(nil),
+	}
+}
+
+// Acknowledgements of responses of a Chat stream, by their `ack_sequence`.
+type ChatAck struct {
+	// The responses the client received. Acknowledging a response again has
+	// no effect.
+	AckedSequences []int64 `protobuf:"varint,1,rep,packed,name=acked_sequences,json=ackedSequences,proto3" json:"acked_sequences,omitempty"`
+	// The responses the client wants sent again.
+	NackedSequences      []int64  `protobuf:"varint,2,rep,packed,name=nacked_sequences,json=nackedSequences,proto3" json:"nacked_sequences,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PagedExpandResponse) Reset()         { *m = PagedExpandResponse{} }
-func (m *PagedExpandResponse) String() string { return proto.CompactTextString(m) }
-func (*PagedExpandResponse) ProtoMessage()    {}
-func (*PagedExpandResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6220e75e6899216f, []int{4}
+func (m *ChatAck) Reset()         { *m = ChatAck{} }
+func (m *ChatAck) String() string { return proto.CompactTextString(m) }
+func (*ChatAck) ProtoMessage()    {}
+func (*ChatAck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{1}
 }
 
-func (m *PagedExpandResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PagedExpandResponse.Unmarshal(m, b)
+func (m *ChatAck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChatAck.Unmarshal(m, b)
 }
-func (m *PagedExpandResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PagedExpandResponse.Marshal(b, m, deterministic)
+func (m *ChatAck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChatAck.Marshal(b, m, deterministic)
 }
-func (m *PagedExpandResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PagedExpandResponse.Merge(m, src)
+func (m *ChatAck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChatAck.Merge(m, src)
 }
-func (m *PagedExpandResponse) XXX_Size() int {
-	return xxx_messageInfo_PagedExpandResponse.Size(m)
+func (m *ChatAck) XXX_Size() int {
+	return xxx_messageInfo_ChatAck.Size(m)
 }
-func (m *PagedExpandResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_PagedExpandResponse.DiscardUnknown(m)
+func (m *ChatAck) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChatAck.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PagedExpandResponse proto.InternalMessageInfo
+var xxx_messageInfo_ChatAck proto.InternalMessageInfo
 
-func (m *PagedExpandResponse) GetResponses() []*EchoResponse {
+func (m *ChatAck) GetAckedSequences() []int64 {
 	if m != nil {
-		return m.Responses
+		return m.AckedSequences
 	}
 	return nil
 }
 
-func (m *PagedExpandResponse) GetNextPageToken() string {
+func (m *ChatAck) GetNackedSequences() []int64 {
 	if m != nil {
-		return m.NextPageToken
+		return m.NackedSequences
 	}
-	return ""
-}
-
-// The request for Wait method.
-type WaitRequest struct {
-	// Types that are valid to be assigned to End:
-	//	*WaitRequest_EndTime
-	//	*WaitRequest_Ttl
-	End isWaitRequest_End `protobuf_oneof:"end"`
-	// Types that are valid to be assigned to Response:
-	//	*WaitRequest_Error
-	//	*WaitRequest_Success
-	Response             isWaitRequest_Response `protobuf_oneof:"response"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+	return nil
 }
 
-func (m *WaitRequest) Reset()         { *m = WaitRequest{} }
-func (m *WaitRequest) String() string { return proto.CompactTextString(m) }
-func (*WaitRequest) ProtoMessage()    {}
-func (*WaitRequest) Descriptor() ([]byte, []int) {
+// Caching hints for a response.
+type CacheControl struct {
+	// The number of seconds the response may be cached for. Must not be
+	// negative, and must be zero if `no_store` is set.
+	MaxAge int32 `protobuf:"varint,1,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	// Whether the response must not be cached at all.
+	NoStore              bool     `protobuf:"varint,2,opt,name=no_store,json=noStore,proto3" json:"no_store,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CacheControl) Reset()         { *m = CacheControl{} }
+func (m *CacheControl) String() string { return proto.CompactTextString(m) }
+func (*CacheControl) ProtoMessage()    {}
+func (*CacheControl) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{2}
+}
+
+func (m *CacheControl) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CacheControl.Unmarshal(m, b)
+}
+func (m *CacheControl) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CacheControl.Marshal(b, m, deterministic)
+}
+func (m *CacheControl) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CacheControl.Merge(m, src)
+}
+func (m *CacheControl) XXX_Size() int {
+	return xxx_messageInfo_CacheControl.Size(m)
+}
+func (m *CacheControl) XXX_DiscardUnknown() {
+	xxx_messageInfo_CacheControl.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CacheControl proto.InternalMessageInfo
+
+func (m *CacheControl) GetMaxAge() int32 {
+	if m != nil {
+		return m.MaxAge
+	}
+	return 0
+}
+
+func (m *CacheControl) GetNoStore() bool {
+	if m != nil {
+		return m.NoStore
+	}
+	return false
+}
+
+// The response message for the Echo methods.
+type EchoResponse struct {
+	// The content specified in the request.
+	Content string `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	// The locale chosen from the `accept-language` metadata of an Echo request.
+	// When one is chosen, the content is prefixed with a greeting in that
+	// locale.
+	Locale string `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	// The `client_sequence` of the request this response answers.
+	ClientSequence int64 `protobuf:"varint,3,opt,name=client_sequence,json=clientSequence,proto3" json:"client_sequence,omitempty"`
+	// A number the server assigns each response of the Echo and Chat methods,
+	// from a single counter shared across them. It increases in the order the
+	// server handled the requests, starting at 1.
+	ServerSequence int64 `protobuf:"varint,4,opt,name=server_sequence,json=serverSequence,proto3" json:"server_sequence,omitempty"`
+	// Whether this is a heartbeat of the Expand method rather than content.
+	IsHeartbeat bool `protobuf:"varint,5,opt,name=is_heartbeat,json=isHeartbeat,proto3" json:"is_heartbeat,omitempty"`
+	// The content repeated `EchoRequest.repeat_count` times.
+	RepeatedContent []string `protobuf:"bytes,6,rep,name=repeated_content,json=repeatedContent,proto3" json:"repeated_content,omitempty"`
+	// The `grpc-previous-rpc-attempts` of the call, if
+	// `EchoRequest.echo_attempts` is set. Missing metadata is attempt 0.
+	PreviousRpcAttempts int64 `protobuf:"varint,7,opt,name=previous_rpc_attempts,json=previousRpcAttempts,proto3" json:"previous_rpc_attempts,omitempty"`
+	// The client attempt number of the call, if `EchoRequest.echo_attempts` is
+	// set. Missing metadata is attempt 0.
+	ClientAttempt int64 `protobuf:"varint,8,opt,name=client_attempt,json=clientAttempt,proto3" json:"client_attempt,omitempty"`
+	// Whether the response is one given earlier to an identical request
+	// within its `dedupe_window`.
+	ServedFromCache bool `protobuf:"varint,9,opt,name=served_from_cache,json=servedFromCache,proto3" json:"served_from_cache,omitempty"`
+	// The position of a word in an Expand stream with
+	// `ExpandRequest.with_summary` set, starting at 1. Heartbeats and the
+	// summary have no index.
+	Index int64 `protobuf:"varint,10,opt,name=index,proto3" json:"index,omitempty"`
+	// Whether this is the summary that ends an Expand stream with
+	// `ExpandRequest.with_summary` set.
+	IsSummary bool `protobuf:"varint,11,opt,name=is_summary,json=isSummary,proto3" json:"is_summary,omitempty"`
+	// The number of words sent before the summary.
+	MessageCount int64 `protobuf:"varint,12,opt,name=message_count,json=messageCount,proto3" json:"message_count,omitempty"`
+	// The CRC-32C (Castagnoli) of the words sent before the summary, each
+	// followed by a newline, in order.
+	Checksum uint32 `protobuf:"varint,13,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	// The errors of the messages of a Collect stream with `continue_on_error`
+	// set, in the order they were received.
+	CollectFailures []*CollectFailure `protobuf:"bytes,14,rep,name=collect_failures,json=collectFailures,proto3" json:"collect_failures,omitempty"`
+	// The position of the response in a Chat stream in `ack_mode`, starting at
+	// 1. A response sent again keeps its sequence.
+	AckSequence int64 `protobuf:"varint,15,opt,name=ack_sequence,json=ackSequence,proto3" json:"ack_sequence,omitempty"`
+	// The number of times this response was sent before.
+	ResendCount int32 `protobuf:"varint,16,opt,name=resend_count,json=resendCount,proto3" json:"resend_count,omitempty"`
+	// The ID of the Chat stream, on every response of a stream that asked for
+	// a handshake with the `showcase-chat-handshake` metadata. The handshake
+	// is the first response of the stream, sent before any message is read,
+	// and has nothing else set.
+	SessionId string `protobuf:"bytes,17,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// The indices of the words that were compressed, in the summary of an
+	// Expand stream with `ExpandRequest.report_compression` set.
+	CompressedIndices []int64 `protobuf:"varint,18,rep,packed,name=compressed_indices,json=compressedIndices,proto3" json:"compressed_indices,omitempty"`
+	// How the request reached the server, if it set `include_transport_info`.
+	TransportInfo *TransportInfo `protobuf:"bytes,19,opt,name=transport_info,json=transportInfo,proto3" json:"transport_info,omitempty"`
+	// The quota project of the request, given by its `x-goog-user-project`
+	// metadata.
+	QuotaProject string `protobuf:"bytes,20,opt,name=quota_project,json=quotaProject,proto3" json:"quota_project,omitempty"`
+	// The calls of a chain of forwarded Echo calls, outermost first, if the
+	// request set `forward_depth`.
+	ForwardHops []*ForwardHop `protobuf:"bytes,21,rep,name=forward_hops,json=forwardHops,proto3" json:"forward_hops,omitempty"`
+	// Whether this is the last response of a Chat stream that reached its
+	// `EchoRequest.max_received_messages`.
+	LimitReached         bool     `protobuf:"varint,22,opt,name=limit_reached,json=limitReached,proto3" json:"limit_reached,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EchoResponse) Reset()         { *m = EchoResponse{} }
+func (m *EchoResponse) String() string { return proto.CompactTextString(m) }
+func (*EchoResponse) ProtoMessage()    {}
+func (*EchoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{3}
+}
+
+func (m *EchoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EchoResponse.Unmarshal(m, b)
+}
+func (m *EchoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EchoResponse.Marshal(b, m, deterministic)
+}
+func (m *EchoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EchoResponse.Merge(m, src)
+}
+func (m *EchoResponse) XXX_Size() int {
+	return xxx_messageInfo_EchoResponse.Size(m)
+}
+func (m *EchoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EchoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EchoResponse proto.InternalMessageInfo
+
+func (m *EchoResponse) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func (m *EchoResponse) GetLocale() string {
+	if m != nil {
+		return m.Locale
+	}
+	return ""
+}
+
+func (m *EchoResponse) GetClientSequence() int64 {
+	if m != nil {
+		return m.ClientSequence
+	}
+	return 0
+}
+
+func (m *EchoResponse) GetServerSequence() int64 {
+	if m != nil {
+		return m.ServerSequence
+	}
+	return 0
+}
+
+func (m *EchoResponse) GetIsHeartbeat() bool {
+	if m != nil {
+		return m.IsHeartbeat
+	}
+	return false
+}
+
+func (m *EchoResponse) GetRepeatedContent() []string {
+	if m != nil {
+		return m.RepeatedContent
+	}
+	return nil
+}
+
+func (m *EchoResponse) GetPreviousRpcAttempts() int64 {
+	if m != nil {
+		return m.PreviousRpcAttempts
+	}
+	return 0
+}
+
+func (m *EchoResponse) GetClientAttempt() int64 {
+	if m != nil {
+		return m.ClientAttempt
+	}
+	return 0
+}
+
+func (m *EchoResponse) GetServedFromCache() bool {
+	if m != nil {
+		return m.ServedFromCache
+	}
+	return false
+}
+
+func (m *EchoResponse) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *EchoResponse) GetIsSummary() bool {
+	if m != nil {
+		return m.IsSummary
+	}
+	return false
+}
+
+func (m *EchoResponse) GetMessageCount() int64 {
+	if m != nil {
+		return m.MessageCount
+	}
+	return 0
+}
+
+func (m *EchoResponse) GetChecksum() uint32 {
+	if m != nil {
+		return m.Checksum
+	}
+	return 0
+}
+
+func (m *EchoResponse) GetCollectFailures() []*CollectFailure {
+	if m != nil {
+		return m.CollectFailures
+	}
+	return nil
+}
+
+func (m *EchoResponse) GetAckSequence() int64 {
+	if m != nil {
+		return m.AckSequence
+	}
+	return 0
+}
+
+func (m *EchoResponse) GetResendCount() int32 {
+	if m != nil {
+		return m.ResendCount
+	}
+	return 0
+}
+
+func (m *EchoResponse) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *EchoResponse) GetCompressedIndices() []int64 {
+	if m != nil {
+		return m.CompressedIndices
+	}
+	return nil
+}
+
+func (m *EchoResponse) GetTransportInfo() *TransportInfo {
+	if m != nil {
+		return m.TransportInfo
+	}
+	return nil
+}
+
+func (m *EchoResponse) GetQuotaProject() string {
+	if m != nil {
+		return m.QuotaProject
+	}
+	return ""
+}
+
+func (m *EchoResponse) GetForwardHops() []*ForwardHop {
+	if m != nil {
+		return m.ForwardHops
+	}
+	return nil
+}
+
+func (m *EchoResponse) GetLimitReached() bool {
+	if m != nil {
+		return m.LimitReached
+	}
+	return false
+}
+
+// A call of a chain of forwarded Echo calls.
+type ForwardHop struct {
+	// The position of the call in the chain, from 0 for the call of the client.
+	Hop int32 `protobuf:"varint,1,opt,name=hop,proto3" json:"hop,omitempty"`
+	// The time left until the deadline of the call when the server received
+	// it, or unset if it had no deadline.
+	RemainingDeadline *duration.Duration `protobuf:"bytes,2,opt,name=remaining_deadline,json=remainingDeadline,proto3" json:"remaining_deadline,omitempty"`
+	// The metadata the call carried, with the values of each key joined by
+	// commas.
+	Headers              map[string]string `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ForwardHop) Reset()         { *m = ForwardHop{} }
+func (m *ForwardHop) String() string { return proto.CompactTextString(m) }
+func (*ForwardHop) ProtoMessage()    {}
+func (*ForwardHop) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{4}
+}
+
+func (m *ForwardHop) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ForwardHop.Unmarshal(m, b)
+}
+func (m *ForwardHop) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ForwardHop.Marshal(b, m, deterministic)
+}
+func (m *ForwardHop) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForwardHop.Merge(m, src)
+}
+func (m *ForwardHop) XXX_Size() int {
+	return xxx_messageInfo_ForwardHop.Size(m)
+}
+func (m *ForwardHop) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForwardHop.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForwardHop proto.InternalMessageInfo
+
+func (m *ForwardHop) GetHop() int32 {
+	if m != nil {
+		return m.Hop
+	}
+	return 0
+}
+
+func (m *ForwardHop) GetRemainingDeadline() *duration.Duration {
+	if m != nil {
+		return m.RemainingDeadline
+	}
+	return nil
+}
+
+func (m *ForwardHop) GetHeaders() map[string]string {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+// How a request reached the server.
+type TransportInfo struct {
+	// The `user-agent` the client sent.
+	UserAgent string `protobuf:"bytes,1,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	// The `content-type` of the request: `application/grpc`, optionally with a
+	// subtype such as `+proto` or `+json`, or the type of an HTTP/JSON body.
+	ContentType string `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// The protocol of the connection, `h2` or `http/1.1`.
+	Protocol string `protobuf:"bytes,3,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	// The `accept` header of an HTTP/JSON request. gRPC requests have none.
+	Accept               string   `protobuf:"bytes,4,opt,name=accept,proto3" json:"accept,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransportInfo) Reset()         { *m = TransportInfo{} }
+func (m *TransportInfo) String() string { return proto.CompactTextString(m) }
+func (*TransportInfo) ProtoMessage()    {}
+func (*TransportInfo) Descriptor() ([]byte, []int) {
 	return fileDescriptor_6220e75e6899216f, []int{5}
 }
 
-func (m *WaitRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_WaitRequest.Unmarshal(m, b)
+func (m *TransportInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransportInfo.Unmarshal(m, b)
+}
+func (m *TransportInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransportInfo.Marshal(b, m, deterministic)
+}
+func (m *TransportInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransportInfo.Merge(m, src)
+}
+func (m *TransportInfo) XXX_Size() int {
+	return xxx_messageInfo_TransportInfo.Size(m)
+}
+func (m *TransportInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransportInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransportInfo proto.InternalMessageInfo
+
+func (m *TransportInfo) GetUserAgent() string {
+	if m != nil {
+		return m.UserAgent
+	}
+	return ""
+}
+
+func (m *TransportInfo) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *TransportInfo) GetProtocol() string {
+	if m != nil {
+		return m.Protocol
+	}
+	return ""
+}
+
+func (m *TransportInfo) GetAccept() string {
+	if m != nil {
+		return m.Accept
+	}
+	return ""
+}
+
+// The error of a message of a Collect stream.
+type CollectFailure struct {
+	// The position of the message in the stream, counting from zero.
+	Index int64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// The error the message carried.
+	Error                *status.Status `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *CollectFailure) Reset()         { *m = CollectFailure{} }
+func (m *CollectFailure) String() string { return proto.CompactTextString(m) }
+func (*CollectFailure) ProtoMessage()    {}
+func (*CollectFailure) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{6}
+}
+
+func (m *CollectFailure) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectFailure.Unmarshal(m, b)
+}
+func (m *CollectFailure) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectFailure.Marshal(b, m, deterministic)
+}
+func (m *CollectFailure) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectFailure.Merge(m, src)
+}
+func (m *CollectFailure) XXX_Size() int {
+	return xxx_messageInfo_CollectFailure.Size(m)
+}
+func (m *CollectFailure) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectFailure.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CollectFailure proto.InternalMessageInfo
+
+func (m *CollectFailure) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *CollectFailure) GetError() *status.Status {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+// The request message for the Expand method.
+type ExpandRequest struct {
+	// The content that will be split into words and returned on the stream.
+	Content string `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	// The error that is thrown after all words are sent on the stream.
+	Error *status.Status `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// The name of a corpus created with Testing.CreateEchoCorpus whose words
+	// are streamed instead of the words of `content`. The two must not both be
+	// set.
+	CorpusName string `protobuf:"bytes,3,opt,name=corpus_name,json=corpusName,proto3" json:"corpus_name,omitempty"`
+	// The number of times the words are streamed. Zero means once.
+	RepeatCount int32 `protobuf:"varint,4,opt,name=repeat_count,json=repeatCount,proto3" json:"repeat_count,omitempty"`
+	// How long the server waits before sending each word.
+	MessageDelay *duration.Duration `protobuf:"bytes,5,opt,name=message_delay,json=messageDelay,proto3" json:"message_delay,omitempty"`
+	// If set, the server sends a heartbeat, an empty response with
+	// `is_heartbeat` set, each time this long passes while it waits to send a
+	// word. Heartbeats are only sent between words, never after the last one.
+	HeartbeatInterval *duration.Duration `protobuf:"bytes,6,opt,name=heartbeat_interval,json=heartbeatInterval,proto3" json:"heartbeat_interval,omitempty"`
+	// If true, each word carries its `EchoResponse.index`, and the stream ends
+	// with a summary of the words sent, so that clients can check none were
+	// lost or reordered. The summary is sent before `error`, if it is set.
+	WithSummary bool `protobuf:"varint,7,opt,name=with_summary,json=withSummary,proto3" json:"with_summary,omitempty"`
+	// If set, the server streams messages whose content is exactly these
+	// sizes in bytes, cycling through the pattern, instead of the words of
+	// `content` or `corpus_name`, which must not be set. The content is
+	// lowercase ASCII filler: byte `j` of the message at position `i` of the
+	// stream, counting from zero, is `'a' + (i + j) % 26`. Sizes whose
+	// messages would exceed the server's message size limit fail with
+	// INVALID_ARGUMENT before anything is sent.
+	SizePattern []int32 `protobuf:"varint,8,rep,packed,name=size_pattern,json=sizePattern,proto3" json:"size_pattern,omitempty"`
+	// The number of messages streamed for `size_pattern`, which must be set.
+	// Zero means one for each size of the pattern. `repeat_count` repeats
+	// them.
+	MessageCount int32 `protobuf:"varint,9,opt,name=message_count,json=messageCount,proto3" json:"message_count,omitempty"`
+	// Trailing metadata to end the stream with, whatever its status. Keys and
+	// values are restricted as for `EchoRequest.trailers`.
+	Trailers map[string]string `protobuf:"bytes,10,rep,name=trailers,proto3" json:"trailers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// If true, `error`, which must have a code other than OK, ends the stream
+	// with an EchoResponse detail summarizing the words sent, with
+	// `is_summary`, `message_count` and `checksum` set as by `with_summary`.
+	ErrorSummary bool `protobuf:"varint,11,opt,name=error_summary,json=errorSummary,proto3" json:"error_summary,omitempty"`
+	// Whether to report the compression of the responses. The stream ends
+	// with the `showcase-compression` trailer naming it, `identity` for none,
+	// and a summary lists the indices of the compressed words in
+	// `compressed_indices`. The server cannot turn compression on and off for
+	// each message, so every response is compressed as the client compresses
+	// its request, such as with `grpc.UseCompressor("gzip")`.
+	ReportCompression bool `protobuf:"varint,12,opt,name=report_compression,json=reportCompression,proto3" json:"report_compression,omitempty"`
+	// If set, the progress of the stream is recorded under this ID when the
+	// stream ends, for GetLastExpandStatus to report. A later stream with the
+	// same ID replaces the record.
+	StreamId string `protobuf:"bytes,13,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"`
+	// The number of messages sent at once at the start of the stream, before
+	// it slows to `trickle_interval`, which must be set. The count runs across
+	// repeats, and the summary of `with_summary` is never delayed.
+	BurstCount int32 `protobuf:"varint,14,opt,name=burst_count,json=burstCount,proto3" json:"burst_count,omitempty"`
+	// How long the server waits before sending each message after the first
+	// `burst_count`, sending heartbeats as for `message_delay`, which must not
+	// also be set.
+	TrickleInterval *duration.Duration `protobuf:"bytes,15,opt,name=trickle_interval,json=trickleInterval,proto3" json:"trickle_interval,omitempty"`
+	// The number of responses with empty content to send, each repeated by
+	// `repeat_count`. `content`, `corpus_name` and `size_pattern` must not also
+	// be set.
+	EmitEmptyMessages    int32    `protobuf:"varint,16,opt,name=emit_empty_messages,json=emitEmptyMessages,proto3" json:"emit_empty_messages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExpandRequest) Reset()         { *m = ExpandRequest{} }
+func (m *ExpandRequest) String() string { return proto.CompactTextString(m) }
+func (*ExpandRequest) ProtoMessage()    {}
+func (*ExpandRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{7}
+}
+
+func (m *ExpandRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExpandRequest.Unmarshal(m, b)
+}
+func (m *ExpandRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExpandRequest.Marshal(b, m, deterministic)
+}
+func (m *ExpandRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExpandRequest.Merge(m, src)
+}
+func (m *ExpandRequest) XXX_Size() int {
+	return xxx_messageInfo_ExpandRequest.Size(m)
+}
+func (m *ExpandRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExpandRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExpandRequest proto.InternalMessageInfo
+
+func (m *ExpandRequest) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func (m *ExpandRequest) GetError() *status.Status {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *ExpandRequest) GetCorpusName() string {
+	if m != nil {
+		return m.CorpusName
+	}
+	return ""
+}
+
+func (m *ExpandRequest) GetRepeatCount() int32 {
+	if m != nil {
+		return m.RepeatCount
+	}
+	return 0
+}
+
+func (m *ExpandRequest) GetMessageDelay() *duration.Duration {
+	if m != nil {
+		return m.MessageDelay
+	}
+	return nil
+}
+
+func (m *ExpandRequest) GetHeartbeatInterval() *duration.Duration {
+	if m != nil {
+		return m.HeartbeatInterval
+	}
+	return nil
+}
+
+func (m *ExpandRequest) GetWithSummary() bool {
+	if m != nil {
+		return m.WithSummary
+	}
+	return false
+}
+
+func (m *ExpandRequest) GetSizePattern() []int32 {
+	if m != nil {
+		return m.SizePattern
+	}
+	return nil
+}
+
+func (m *ExpandRequest) GetMessageCount() int32 {
+	if m != nil {
+		return m.MessageCount
+	}
+	return 0
+}
+
+func (m *ExpandRequest) GetTrailers() map[string]string {
+	if m != nil {
+		return m.Trailers
+	}
+	return nil
+}
+
+func (m *ExpandRequest) GetErrorSummary() bool {
+	if m != nil {
+		return m.ErrorSummary
+	}
+	return false
+}
+
+func (m *ExpandRequest) GetReportCompression() bool {
+	if m != nil {
+		return m.ReportCompression
+	}
+	return false
+}
+
+func (m *ExpandRequest) GetStreamId() string {
+	if m != nil {
+		return m.StreamId
+	}
+	return ""
+}
+
+func (m *ExpandRequest) GetBurstCount() int32 {
+	if m != nil {
+		return m.BurstCount
+	}
+	return 0
+}
+
+func (m *ExpandRequest) GetTrickleInterval() *duration.Duration {
+	if m != nil {
+		return m.TrickleInterval
+	}
+	return nil
+}
+
+func (m *ExpandRequest) GetEmitEmptyMessages() int32 {
+	if m != nil {
+		return m.EmitEmptyMessages
+	}
+	return 0
+}
+
+// The request for the PagedExpand method.
+type PagedExpandRequest struct {
+	// The string to expand.
+	Content string `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	// The amount of words to returned in each page.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The position of the page to be returned.
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// The order of the words, as an AIP-132 order_by over `index`, the
+	// position of a word in `content` from 0, `parity`, whether that position
+	// is odd, and `length`, the number of characters of the word, such as
+	// `"length desc, index"`. Words that tie keep the order of `content`. If
+	// set, each response carries the `index` of its word, counting from 1,
+	// and a page token is only accepted with the order_by it was issued for.
+	OrderBy string `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// The words to expand, as an AIP-160 filter of comparisons by `=`, `!=`,
+	// `<` or `>` joined by `AND`, over `index`, `parity` and `length` as in
+	// `order_by` and the word itself as `content`, such as
+	// `length > 3 AND content != "the"`. Pages are made of the words that
+	// match. If set, each response carries the `index` of its word, counting
+	// from 1, and a page token is only accepted with the filter it was issued
+	// for.
+	Filter               string   `protobuf:"bytes,5,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PagedExpandRequest) Reset()         { *m = PagedExpandRequest{} }
+func (m *PagedExpandRequest) String() string { return proto.CompactTextString(m) }
+func (*PagedExpandRequest) ProtoMessage()    {}
+func (*PagedExpandRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{8}
+}
+
+func (m *PagedExpandRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PagedExpandRequest.Unmarshal(m, b)
+}
+func (m *PagedExpandRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PagedExpandRequest.Marshal(b, m, deterministic)
+}
+func (m *PagedExpandRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PagedExpandRequest.Merge(m, src)
+}
+func (m *PagedExpandRequest) XXX_Size() int {
+	return xxx_messageInfo_PagedExpandRequest.Size(m)
+}
+func (m *PagedExpandRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PagedExpandRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PagedExpandRequest proto.InternalMessageInfo
+
+func (m *PagedExpandRequest) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func (m *PagedExpandRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *PagedExpandRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *PagedExpandRequest) GetOrderBy() string {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ""
+}
+
+func (m *PagedExpandRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+// The response for the PagedExpand method.
+type PagedExpandResponse struct {
+	// The words that were expanded.
+	Responses []*EchoResponse `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"`
+	// The next page token.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PagedExpandResponse) Reset()         { *m = PagedExpandResponse{} }
+func (m *PagedExpandResponse) String() string { return proto.CompactTextString(m) }
+func (*PagedExpandResponse) ProtoMessage()    {}
+func (*PagedExpandResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{9}
+}
+
+func (m *PagedExpandResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PagedExpandResponse.Unmarshal(m, b)
+}
+func (m *PagedExpandResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PagedExpandResponse.Marshal(b, m, deterministic)
+}
+func (m *PagedExpandResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PagedExpandResponse.Merge(m, src)
+}
+func (m *PagedExpandResponse) XXX_Size() int {
+	return xxx_messageInfo_PagedExpandResponse.Size(m)
+}
+func (m *PagedExpandResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PagedExpandResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PagedExpandResponse proto.InternalMessageInfo
+
+func (m *PagedExpandResponse) GetResponses() []*EchoResponse {
+	if m != nil {
+		return m.Responses
+	}
+	return nil
+}
+
+func (m *PagedExpandResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+// The request for Wait method.
+type WaitRequest struct {
+	// Types that are valid to be assigned to End:
+	//	*WaitRequest_EndTime
+	//	*WaitRequest_Ttl
+	End isWaitRequest_End `protobuf_oneof:"end"`
+	// Types that are valid to be assigned to Response:
+	//	*WaitRequest_Error
+	//	*WaitRequest_Success
+	Response isWaitRequest_Response `protobuf_oneof:"response"`
+	// If true, the success response is packed under a type URL that does not
+	// name a WaitResponse, so that unpacking it as one fails on the client.
+	CorruptResultType bool `protobuf:"varint,5,opt,name=corrupt_result_type,json=corruptResultType,proto3" json:"corrupt_result_type,omitempty"`
+	// If true, the success response is packed under the WaitResponse type URL
+	// but with truncated bytes, so that unmarshalling it fails on the client.
+	CorruptResultBytes bool `protobuf:"varint,6,opt,name=corrupt_result_bytes,json=corruptResultBytes,proto3" json:"corrupt_result_bytes,omitempty"`
+	// If set, polling the operation with google.longrunning.Operations
+	// beyond this quota fails with RESOURCE_EXHAUSTED instead of returning the
+	// operation. The operation completes on schedule regardless.
+	PollQuota *PollQuota `protobuf:"bytes,7,opt,name=poll_quota,json=pollQuota,proto3" json:"poll_quota,omitempty"`
+	// A client-chosen ID of the operation, so that retried calls do not start
+	// it again. A request identical to the first one with the ID in the
+	// namespace returns the operation that one started. A different request
+	// with the ID fails with ALREADY_EXISTS and an ErrorInfo with reason
+	// `OPERATION_ID_REUSED`. Without an ID, every call starts an operation.
+	OperationId string `protobuf:"bytes,8,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	// Results the operation makes available before it completes, in the order
+	// they become available. While the operation is pending, its metadata
+	// holds the latest one available. If neither `error` nor `success` is
+	// set, the operation completes with the last of them.
+	PartialResults       []*PartialResult `protobuf:"bytes,9,rep,name=partial_results,json=partialResults,proto3" json:"partial_results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *WaitRequest) Reset()         { *m = WaitRequest{} }
+func (m *WaitRequest) String() string { return proto.CompactTextString(m) }
+func (*WaitRequest) ProtoMessage()    {}
+func (*WaitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{10}
+}
+
+func (m *WaitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WaitRequest.Unmarshal(m, b)
+}
+func (m *WaitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WaitRequest.Marshal(b, m, deterministic)
+}
+func (m *WaitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WaitRequest.Merge(m, src)
+}
+func (m *WaitRequest) XXX_Size() int {
+	return xxx_messageInfo_WaitRequest.Size(m)
+}
+func (m *WaitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WaitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WaitRequest proto.InternalMessageInfo
+
+type isWaitRequest_End interface {
+	isWaitRequest_End()
+}
+
+type WaitRequest_EndTime struct {
+	EndTime *timestamp.Timestamp `protobuf:"bytes,1,opt,name=end_time,json=endTime,proto3,oneof"`
+}
+
+type WaitRequest_Ttl struct {
+	Ttl *duration.Duration `protobuf:"bytes,4,opt,name=ttl,proto3,oneof"`
+}
+
+func (*WaitRequest_EndTime) isWaitRequest_End() {}
+
+func (*WaitRequest_Ttl) isWaitRequest_End() {}
+
+func (m *WaitRequest) GetEnd() isWaitRequest_End {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
+func (m *WaitRequest) GetEndTime() *timestamp.Timestamp {
+	if x, ok := m.GetEnd().(*WaitRequest_EndTime); ok {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (m *WaitRequest) GetTtl() *duration.Duration {
+	if x, ok := m.GetEnd().(*WaitRequest_Ttl); ok {
+		return x.Ttl
+	}
+	return nil
+}
+
+type isWaitRequest_Response interface {
+	isWaitRequest_Response()
+}
+
+type WaitRequest_Error struct {
+	Error *status.Status `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+type WaitRequest_Success struct {
+	Success *WaitResponse `protobuf:"bytes,3,opt,name=success,proto3,oneof"`
+}
+
+func (*WaitRequest_Error) isWaitRequest_Response() {}
+
+func (*WaitRequest_Success) isWaitRequest_Response() {}
+
+func (m *WaitRequest) GetResponse() isWaitRequest_Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *WaitRequest) GetError() *status.Status {
+	if x, ok := m.GetResponse().(*WaitRequest_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+func (m *WaitRequest) GetSuccess() *WaitResponse {
+	if x, ok := m.GetResponse().(*WaitRequest_Success); ok {
+		return x.Success
+	}
+	return nil
+}
+
+func (m *WaitRequest) GetCorruptResultType() bool {
+	if m != nil {
+		return m.CorruptResultType
+	}
+	return false
+}
+
+func (m *WaitRequest) GetCorruptResultBytes() bool {
+	if m != nil {
+		return m.CorruptResultBytes
+	}
+	return false
+}
+
+func (m *WaitRequest) GetPollQuota() *PollQuota {
+	if m != nil {
+		return m.PollQuota
+	}
+	return nil
+}
+
+func (m *WaitRequest) GetOperationId() string {
+	if m != nil {
+		return m.OperationId
+	}
+	return ""
+}
+
+func (m *WaitRequest) GetPartialResults() []*PartialResult {
+	if m != nil {
+		return m.PartialResults
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*WaitRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*WaitRequest_EndTime)(nil),
+		(*WaitRequest_Ttl)(nil),
+		(*WaitRequest_Error)(nil),
+		(*WaitRequest_Success)(nil),
+	}
+}
+
+// A result of a Wait operation available before it completes.
+type PartialResult struct {
+	// Types that are valid to be assigned to Available:
+	//	*PartialResult_AvailableTime
+	//	*PartialResult_Offset
+	Available isPartialResult_Available `protobuf_oneof:"available"`
+	// The result.
+	Response             *WaitResponse `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *PartialResult) Reset()         { *m = PartialResult{} }
+func (m *PartialResult) String() string { return proto.CompactTextString(m) }
+func (*PartialResult) ProtoMessage()    {}
+func (*PartialResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{11}
+}
+
+func (m *PartialResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartialResult.Unmarshal(m, b)
+}
+func (m *PartialResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartialResult.Marshal(b, m, deterministic)
+}
+func (m *PartialResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartialResult.Merge(m, src)
+}
+func (m *PartialResult) XXX_Size() int {
+	return xxx_messageInfo_PartialResult.Size(m)
+}
+func (m *PartialResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartialResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartialResult proto.InternalMessageInfo
+
+type isPartialResult_Available interface {
+	isPartialResult_Available()
+}
+
+type PartialResult_AvailableTime struct {
+	AvailableTime *timestamp.Timestamp `protobuf:"bytes,1,opt,name=available_time,json=availableTime,proto3,oneof"`
+}
+
+type PartialResult_Offset struct {
+	Offset *duration.Duration `protobuf:"bytes,2,opt,name=offset,proto3,oneof"`
+}
+
+func (*PartialResult_AvailableTime) isPartialResult_Available() {}
+
+func (*PartialResult_Offset) isPartialResult_Available() {}
+
+func (m *PartialResult) GetAvailable() isPartialResult_Available {
+	if m != nil {
+		return m.Available
+	}
+	return nil
+}
+
+func (m *PartialResult) GetAvailableTime() *timestamp.Timestamp {
+	if x, ok := m.GetAvailable().(*PartialResult_AvailableTime); ok {
+		return x.AvailableTime
+	}
+	return nil
+}
+
+func (m *PartialResult) GetOffset() *duration.Duration {
+	if x, ok := m.GetAvailable().(*PartialResult_Offset); ok {
+		return x.Offset
+	}
+	return nil
+}
+
+func (m *PartialResult) GetResponse() *WaitResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*PartialResult) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*PartialResult_AvailableTime)(nil),
+		(*PartialResult_Offset)(nil),
+	}
+}
+
+// A budget of polls for a long-running operation.
+type PollQuota struct {
+	// The number of polls the operation may have at once. Must be positive.
+	MaxPolls int32 `protobuf:"varint,1,opt,name=max_polls,json=maxPolls,proto3" json:"max_polls,omitempty"`
+	// How often one poll is added back to the budget, up to `max_polls`.
+	// Rejected polls carry a google.rpc.RetryInfo with the time until the next
+	// one. If unset, the budget is never replenished and rejected polls carry
+	// no RetryInfo.
+	ReplenishInterval    *duration.Duration `protobuf:"bytes,2,opt,name=replenish_interval,json=replenishInterval,proto3" json:"replenish_interval,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *PollQuota) Reset()         { *m = PollQuota{} }
+func (m *PollQuota) String() string { return proto.CompactTextString(m) }
+func (*PollQuota) ProtoMessage()    {}
+func (*PollQuota) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{12}
+}
+
+func (m *PollQuota) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PollQuota.Unmarshal(m, b)
+}
+func (m *PollQuota) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PollQuota.Marshal(b, m, deterministic)
+}
+func (m *PollQuota) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PollQuota.Merge(m, src)
+}
+func (m *PollQuota) XXX_Size() int {
+	return xxx_messageInfo_PollQuota.Size(m)
+}
+func (m *PollQuota) XXX_DiscardUnknown() {
+	xxx_messageInfo_PollQuota.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PollQuota proto.InternalMessageInfo
+
+func (m *PollQuota) GetMaxPolls() int32 {
+	if m != nil {
+		return m.MaxPolls
+	}
+	return 0
+}
+
+func (m *PollQuota) GetReplenishInterval() *duration.Duration {
+	if m != nil {
+		return m.ReplenishInterval
+	}
+	return nil
+}
+
+// The result of the Wait operation.
+type WaitResponse struct {
+	// This content of the result.
+	Content              string   `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WaitResponse) Reset()         { *m = WaitResponse{} }
+func (m *WaitResponse) String() string { return proto.CompactTextString(m) }
+func (*WaitResponse) ProtoMessage()    {}
+func (*WaitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{13}
+}
+
+func (m *WaitResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WaitResponse.Unmarshal(m, b)
+}
+func (m *WaitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WaitResponse.Marshal(b, m, deterministic)
+}
+func (m *WaitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WaitResponse.Merge(m, src)
+}
+func (m *WaitResponse) XXX_Size() int {
+	return xxx_messageInfo_WaitResponse.Size(m)
+}
+func (m *WaitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WaitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WaitResponse proto.InternalMessageInfo
+
+func (m *WaitResponse) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+// The metadata for Wait operation. Every Wait operation has it, done or not,
+// and it has the standard fields of the metadata of AIP-151 as well.
+type WaitMetadata struct {
+	// The time that this operation will complete, or completed once it is done.
+	EndTime *timestamp.Timestamp `protobuf:"bytes,1,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// The latest of the `partial_results` of the request available, if any.
+	PartialResponse *WaitResponse `protobuf:"bytes,2,opt,name=partial_response,json=partialResponse,proto3" json:"partial_response,omitempty"`
+	// The number of the `partial_results` of the request available.
+	PartialCount int32 `protobuf:"varint,3,opt,name=partial_count,json=partialCount,proto3" json:"partial_count,omitempty"`
+	// The time that this operation was created, which is when this server
+	// first saw it.
+	CreateTime *timestamp.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// The verb of the method that started this operation, which is always
+	// "wait".
+	Verb                 string   `protobuf:"bytes,5,opt,name=verb,proto3" json:"verb,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WaitMetadata) Reset()         { *m = WaitMetadata{} }
+func (m *WaitMetadata) String() string { return proto.CompactTextString(m) }
+func (*WaitMetadata) ProtoMessage()    {}
+func (*WaitMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{14}
+}
+
+func (m *WaitMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WaitMetadata.Unmarshal(m, b)
+}
+func (m *WaitMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WaitMetadata.Marshal(b, m, deterministic)
+}
+func (m *WaitMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WaitMetadata.Merge(m, src)
+}
+func (m *WaitMetadata) XXX_Size() int {
+	return xxx_messageInfo_WaitMetadata.Size(m)
+}
+func (m *WaitMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_WaitMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WaitMetadata proto.InternalMessageInfo
+
+func (m *WaitMetadata) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+func (m *WaitMetadata) GetPartialResponse() *WaitResponse {
+	if m != nil {
+		return m.PartialResponse
+	}
+	return nil
+}
+
+func (m *WaitMetadata) GetPartialCount() int32 {
+	if m != nil {
+		return m.PartialCount
+	}
+	return 0
+}
+
+func (m *WaitMetadata) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+func (m *WaitMetadata) GetVerb() string {
+	if m != nil {
+		return m.Verb
+	}
+	return ""
+}
+
+// The request for the FailEchoWithDetails method.
+type FailEchoWithDetailsRequest struct {
+	// The code and message of the error to be returned. The code must not be
+	// OK. Any details set on this status are ignored.
+	Error *status.Status `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// The details to attach to the error, in order. A detail type may be
+	// requested more than once.
+	Details []FailEchoWithDetailsRequest_DetailType `protobuf:"varint,2,rep,packed,name=details,proto3,enum=google.showcase.v1beta1.FailEchoWithDetailsRequest_DetailType" json:"details,omitempty"`
+	// The locale of the LocalizedMessage detail.
+	Locale string `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	// The message of the LocalizedMessage detail.
+	LocalizedMessage string `protobuf:"bytes,4,opt,name=localized_message,json=localizedMessage,proto3" json:"localized_message,omitempty"`
+	// The links of the Help detail.
+	Links []*errdetails.Help_Link `protobuf:"bytes,5,rep,name=links,proto3" json:"links,omitempty"`
+	// The violations of the QuotaFailure detail.
+	QuotaViolations []*errdetails.QuotaFailure_Violation `protobuf:"bytes,6,rep,name=quota_violations,json=quotaViolations,proto3" json:"quota_violations,omitempty"`
+	// The violations of the PreconditionFailure detail.
+	PreconditionViolations []*errdetails.PreconditionFailure_Violation `protobuf:"bytes,7,rep,name=precondition_violations,json=preconditionViolations,proto3" json:"precondition_violations,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                                    `json:"-"`
+	XXX_unrecognized       []byte                                      `json:"-"`
+	XXX_sizecache          int32                                       `json:"-"`
+}
+
+func (m *FailEchoWithDetailsRequest) Reset()         { *m = FailEchoWithDetailsRequest{} }
+func (m *FailEchoWithDetailsRequest) String() string { return proto.CompactTextString(m) }
+func (*FailEchoWithDetailsRequest) ProtoMessage()    {}
+func (*FailEchoWithDetailsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{15}
+}
+
+func (m *FailEchoWithDetailsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FailEchoWithDetailsRequest.Unmarshal(m, b)
+}
+func (m *FailEchoWithDetailsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FailEchoWithDetailsRequest.Marshal(b, m, deterministic)
+}
+func (m *FailEchoWithDetailsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FailEchoWithDetailsRequest.Merge(m, src)
+}
+func (m *FailEchoWithDetailsRequest) XXX_Size() int {
+	return xxx_messageInfo_FailEchoWithDetailsRequest.Size(m)
+}
+func (m *FailEchoWithDetailsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FailEchoWithDetailsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FailEchoWithDetailsRequest proto.InternalMessageInfo
+
+func (m *FailEchoWithDetailsRequest) GetError() *status.Status {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *FailEchoWithDetailsRequest) GetDetails() []FailEchoWithDetailsRequest_DetailType {
+	if m != nil {
+		return m.Details
+	}
+	return nil
+}
+
+func (m *FailEchoWithDetailsRequest) GetLocale() string {
+	if m != nil {
+		return m.Locale
+	}
+	return ""
+}
+
+func (m *FailEchoWithDetailsRequest) GetLocalizedMessage() string {
+	if m != nil {
+		return m.LocalizedMessage
+	}
+	return ""
+}
+
+func (m *FailEchoWithDetailsRequest) GetLinks() []*errdetails.Help_Link {
+	if m != nil {
+		return m.Links
+	}
+	return nil
+}
+
+func (m *FailEchoWithDetailsRequest) GetQuotaViolations() []*errdetails.QuotaFailure_Violation {
+	if m != nil {
+		return m.QuotaViolations
+	}
+	return nil
+}
+
+func (m *FailEchoWithDetailsRequest) GetPreconditionViolations() []*errdetails.PreconditionFailure_Violation {
+	if m != nil {
+		return m.PreconditionViolations
+	}
+	return nil
+}
+
+// The request for the InspectCredentials method.
+type InspectCredentialsRequest struct {
+	// If true, a duplicated `authorization` header fails the call with
+	// UNAUTHENTICATED instead of being reported as a warning.
+	Strict               bool     `protobuf:"varint,1,opt,name=strict,proto3" json:"strict,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InspectCredentialsRequest) Reset()         { *m = InspectCredentialsRequest{} }
+func (m *InspectCredentialsRequest) String() string { return proto.CompactTextString(m) }
+func (*InspectCredentialsRequest) ProtoMessage()    {}
+func (*InspectCredentialsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{16}
+}
+
+func (m *InspectCredentialsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InspectCredentialsRequest.Unmarshal(m, b)
+}
+func (m *InspectCredentialsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InspectCredentialsRequest.Marshal(b, m, deterministic)
+}
+func (m *InspectCredentialsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectCredentialsRequest.Merge(m, src)
+}
+func (m *InspectCredentialsRequest) XXX_Size() int {
+	return xxx_messageInfo_InspectCredentialsRequest.Size(m)
+}
+func (m *InspectCredentialsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectCredentialsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InspectCredentialsRequest proto.InternalMessageInfo
+
+func (m *InspectCredentialsRequest) GetStrict() bool {
+	if m != nil {
+		return m.Strict
+	}
+	return false
+}
+
+// The response for the InspectCredentials method.
+type InspectCredentialsResponse struct {
+	// The credential-bearing keys that were received, sorted by key.
+	Credentials []*InspectCredentialsResponse_Credential `protobuf:"bytes,1,rep,name=credentials,proto3" json:"credentials,omitempty"`
+	// Problems with the credentials that did not fail the call, such as a
+	// duplicated `authorization` header.
+	Warnings             []string `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InspectCredentialsResponse) Reset()         { *m = InspectCredentialsResponse{} }
+func (m *InspectCredentialsResponse) String() string { return proto.CompactTextString(m) }
+func (*InspectCredentialsResponse) ProtoMessage()    {}
+func (*InspectCredentialsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{17}
+}
+
+func (m *InspectCredentialsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InspectCredentialsResponse.Unmarshal(m, b)
+}
+func (m *InspectCredentialsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InspectCredentialsResponse.Marshal(b, m, deterministic)
+}
+func (m *InspectCredentialsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectCredentialsResponse.Merge(m, src)
+}
+func (m *InspectCredentialsResponse) XXX_Size() int {
+	return xxx_messageInfo_InspectCredentialsResponse.Size(m)
+}
+func (m *InspectCredentialsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectCredentialsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InspectCredentialsResponse proto.InternalMessageInfo
+
+func (m *InspectCredentialsResponse) GetCredentials() []*InspectCredentialsResponse_Credential {
+	if m != nil {
+		return m.Credentials
+	}
+	return nil
+}
+
+func (m *InspectCredentialsResponse) GetWarnings() []string {
+	if m != nil {
+		return m.Warnings
+	}
+	return nil
+}
+
+// A credential-bearing metadata key and the values received for it.
+type InspectCredentialsResponse_Credential struct {
+	// The metadata key, e.g. `authorization`.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// The values received for the key, in the order they were received.
+	Values []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	// Whether the key was received more than once.
+	Duplicated           bool     `protobuf:"varint,3,opt,name=duplicated,proto3" json:"duplicated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InspectCredentialsResponse_Credential) Reset()         { *m = InspectCredentialsResponse_Credential{} }
+func (m *InspectCredentialsResponse_Credential) String() string { return proto.CompactTextString(m) }
+func (*InspectCredentialsResponse_Credential) ProtoMessage()    {}
+func (*InspectCredentialsResponse_Credential) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{17, 0}
+}
+
+func (m *InspectCredentialsResponse_Credential) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InspectCredentialsResponse_Credential.Unmarshal(m, b)
+}
+func (m *InspectCredentialsResponse_Credential) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InspectCredentialsResponse_Credential.Marshal(b, m, deterministic)
+}
+func (m *InspectCredentialsResponse_Credential) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectCredentialsResponse_Credential.Merge(m, src)
+}
+func (m *InspectCredentialsResponse_Credential) XXX_Size() int {
+	return xxx_messageInfo_InspectCredentialsResponse_Credential.Size(m)
+}
+func (m *InspectCredentialsResponse_Credential) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectCredentialsResponse_Credential.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InspectCredentialsResponse_Credential proto.InternalMessageInfo
+
+func (m *InspectCredentialsResponse_Credential) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *InspectCredentialsResponse_Credential) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+func (m *InspectCredentialsResponse_Credential) GetDuplicated() bool {
+	if m != nil {
+		return m.Duplicated
+	}
+	return false
+}
+
+// The request for the ReadBlob method.
+type ReadBlobRequest struct {
+	// The size of the blob in bytes.
+	TotalSize int64 `protobuf:"varint,1,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	// The offset of the first byte to read. Offsets past `total_size` are
+	// OUT_OF_RANGE.
+	ReadOffset int64 `protobuf:"varint,2,opt,name=read_offset,json=readOffset,proto3" json:"read_offset,omitempty"`
+	// The most bytes to read. If zero, the blob is read to the end.
+	ReadLimit int64 `protobuf:"varint,3,opt,name=read_limit,json=readLimit,proto3" json:"read_limit,omitempty"`
+	// The size of each chunk in bytes, at most 4194304 unless the server is
+	// configured otherwise. If zero, the server's default chunk size is used,
+	// 65536 bytes unless configured otherwise.
+	ChunkSize int32 `protobuf:"varint,4,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	// The seed that determines the contents of the blob. The same seed always
+	// produces the same blob.
+	Seed int64 `protobuf:"varint,5,opt,name=seed,proto3" json:"seed,omitempty"`
+	// If positive, the stream fails with UNAVAILABLE after this many bytes have
+	// been sent, so that a client can resume the read from an offset.
+	FailAfterBytes       int64    `protobuf:"varint,6,opt,name=fail_after_bytes,json=failAfterBytes,proto3" json:"fail_after_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadBlobRequest) Reset()         { *m = ReadBlobRequest{} }
+func (m *ReadBlobRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadBlobRequest) ProtoMessage()    {}
+func (*ReadBlobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{18}
+}
+
+func (m *ReadBlobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadBlobRequest.Unmarshal(m, b)
+}
+func (m *ReadBlobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadBlobRequest.Marshal(b, m, deterministic)
+}
+func (m *ReadBlobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadBlobRequest.Merge(m, src)
+}
+func (m *ReadBlobRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadBlobRequest.Size(m)
+}
+func (m *ReadBlobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadBlobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadBlobRequest proto.InternalMessageInfo
+
+func (m *ReadBlobRequest) GetTotalSize() int64 {
+	if m != nil {
+		return m.TotalSize
+	}
+	return 0
+}
+
+func (m *ReadBlobRequest) GetReadOffset() int64 {
+	if m != nil {
+		return m.ReadOffset
+	}
+	return 0
+}
+
+func (m *ReadBlobRequest) GetReadLimit() int64 {
+	if m != nil {
+		return m.ReadLimit
+	}
+	return 0
+}
+
+func (m *ReadBlobRequest) GetChunkSize() int32 {
+	if m != nil {
+		return m.ChunkSize
+	}
+	return 0
+}
+
+func (m *ReadBlobRequest) GetSeed() int64 {
+	if m != nil {
+		return m.Seed
+	}
+	return 0
+}
+
+func (m *ReadBlobRequest) GetFailAfterBytes() int64 {
+	if m != nil {
+		return m.FailAfterBytes
+	}
+	return 0
+}
+
+// A chunk of the blob read by the ReadBlob method.
+type ReadBlobResponse struct {
+	// The bytes of the chunk.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// The offset within the blob of the first byte of `data`.
+	Offset int64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// The CRC32C checksum of `data`.
+	Crc32C uint32 `protobuf:"varint,3,opt,name=crc32c,proto3" json:"crc32c,omitempty"`
+	// Whether this is the last chunk of the requested range.
+	Final                bool     `protobuf:"varint,4,opt,name=final,proto3" json:"final,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadBlobResponse) Reset()         { *m = ReadBlobResponse{} }
+func (m *ReadBlobResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadBlobResponse) ProtoMessage()    {}
+func (*ReadBlobResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{19}
+}
+
+func (m *ReadBlobResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadBlobResponse.Unmarshal(m, b)
+}
+func (m *ReadBlobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadBlobResponse.Marshal(b, m, deterministic)
+}
+func (m *ReadBlobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadBlobResponse.Merge(m, src)
+}
+func (m *ReadBlobResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadBlobResponse.Size(m)
+}
+func (m *ReadBlobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadBlobResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadBlobResponse proto.InternalMessageInfo
+
+func (m *ReadBlobResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ReadBlobResponse) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ReadBlobResponse) GetCrc32C() uint32 {
+	if m != nil {
+		return m.Crc32C
+	}
+	return 0
+}
+
+func (m *ReadBlobResponse) GetFinal() bool {
+	if m != nil {
+		return m.Final
+	}
+	return false
+}
+
+// A message on the WriteBlob stream. The first message must be a spec and the
+// rest must be chunks.
+type WriteBlobRequest struct {
+	// Types that are valid to be assigned to Request:
+	//	*WriteBlobRequest_Spec_
+	//	*WriteBlobRequest_Chunk_
+	Request              isWriteBlobRequest_Request `protobuf_oneof:"request"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *WriteBlobRequest) Reset()         { *m = WriteBlobRequest{} }
+func (m *WriteBlobRequest) String() string { return proto.CompactTextString(m) }
+func (*WriteBlobRequest) ProtoMessage()    {}
+func (*WriteBlobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{20}
+}
+
+func (m *WriteBlobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteBlobRequest.Unmarshal(m, b)
+}
+func (m *WriteBlobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteBlobRequest.Marshal(b, m, deterministic)
+}
+func (m *WriteBlobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteBlobRequest.Merge(m, src)
+}
+func (m *WriteBlobRequest) XXX_Size() int {
+	return xxx_messageInfo_WriteBlobRequest.Size(m)
+}
+func (m *WriteBlobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteBlobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteBlobRequest proto.InternalMessageInfo
+
+type isWriteBlobRequest_Request interface {
+	isWriteBlobRequest_Request()
+}
+
+type WriteBlobRequest_Spec_ struct {
+	Spec *WriteBlobRequest_Spec `protobuf:"bytes,1,opt,name=spec,proto3,oneof"`
+}
+
+type WriteBlobRequest_Chunk_ struct {
+	Chunk *WriteBlobRequest_Chunk `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*WriteBlobRequest_Spec_) isWriteBlobRequest_Request() {}
+
+func (*WriteBlobRequest_Chunk_) isWriteBlobRequest_Request() {}
+
+func (m *WriteBlobRequest) GetRequest() isWriteBlobRequest_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *WriteBlobRequest) GetSpec() *WriteBlobRequest_Spec {
+	if x, ok := m.GetRequest().(*WriteBlobRequest_Spec_); ok {
+		return x.Spec
+	}
+	return nil
+}
+
+func (m *WriteBlobRequest) GetChunk() *WriteBlobRequest_Chunk {
+	if x, ok := m.GetRequest().(*WriteBlobRequest_Chunk_); ok {
+		return x.Chunk
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*WriteBlobRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*WriteBlobRequest_Spec_)(nil),
+		(*WriteBlobRequest_Chunk_)(nil),
+	}
+}
+
+// Declares the blob being written.
+type WriteBlobRequest_Spec struct {
+	// The ID of the blob. Writing to an existing ID resumes its upload.
+	BlobId string `protobuf:"bytes,1,opt,name=blob_id,json=blobId,proto3" json:"blob_id,omitempty"`
+	// The size of the blob in bytes. When resuming, this must match the size
+	// given when the upload started.
+	TotalSize            int64    `protobuf:"varint,2,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WriteBlobRequest_Spec) Reset()         { *m = WriteBlobRequest_Spec{} }
+func (m *WriteBlobRequest_Spec) String() string { return proto.CompactTextString(m) }
+func (*WriteBlobRequest_Spec) ProtoMessage()    {}
+func (*WriteBlobRequest_Spec) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{20, 0}
+}
+
+func (m *WriteBlobRequest_Spec) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteBlobRequest_Spec.Unmarshal(m, b)
+}
+func (m *WriteBlobRequest_Spec) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteBlobRequest_Spec.Marshal(b, m, deterministic)
+}
+func (m *WriteBlobRequest_Spec) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteBlobRequest_Spec.Merge(m, src)
+}
+func (m *WriteBlobRequest_Spec) XXX_Size() int {
+	return xxx_messageInfo_WriteBlobRequest_Spec.Size(m)
+}
+func (m *WriteBlobRequest_Spec) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteBlobRequest_Spec.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteBlobRequest_Spec proto.InternalMessageInfo
+
+func (m *WriteBlobRequest_Spec) GetBlobId() string {
+	if m != nil {
+		return m.BlobId
+	}
+	return ""
+}
+
+func (m *WriteBlobRequest_Spec) GetTotalSize() int64 {
+	if m != nil {
+		return m.TotalSize
+	}
+	return 0
+}
+
+// A chunk of the blob.
+type WriteBlobRequest_Chunk struct {
+	// The offset within the blob of the first byte of `data`. This must be
+	// the number of bytes committed so far.
+	Offset int64 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	// The bytes of the chunk.
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WriteBlobRequest_Chunk) Reset()         { *m = WriteBlobRequest_Chunk{} }
+func (m *WriteBlobRequest_Chunk) String() string { return proto.CompactTextString(m) }
+func (*WriteBlobRequest_Chunk) ProtoMessage()    {}
+func (*WriteBlobRequest_Chunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{20, 1}
+}
+
+func (m *WriteBlobRequest_Chunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteBlobRequest_Chunk.Unmarshal(m, b)
+}
+func (m *WriteBlobRequest_Chunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteBlobRequest_Chunk.Marshal(b, m, deterministic)
+}
+func (m *WriteBlobRequest_Chunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteBlobRequest_Chunk.Merge(m, src)
+}
+func (m *WriteBlobRequest_Chunk) XXX_Size() int {
+	return xxx_messageInfo_WriteBlobRequest_Chunk.Size(m)
+}
+func (m *WriteBlobRequest_Chunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteBlobRequest_Chunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteBlobRequest_Chunk proto.InternalMessageInfo
+
+func (m *WriteBlobRequest_Chunk) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *WriteBlobRequest_Chunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// The response for the WriteBlob method.
+type WriteBlobResponse struct {
+	// The ID of the blob.
+	BlobId string `protobuf:"bytes,1,opt,name=blob_id,json=blobId,proto3" json:"blob_id,omitempty"`
+	// The number of bytes committed for the blob.
+	ReceivedSize int64 `protobuf:"varint,2,opt,name=received_size,json=receivedSize,proto3" json:"received_size,omitempty"`
+	// The CRC32C checksum of the committed bytes.
+	Crc32C uint32 `protobuf:"varint,3,opt,name=crc32c,proto3" json:"crc32c,omitempty"`
+	// Whether all `total_size` bytes of the blob have been committed.
+	Complete             bool     `protobuf:"varint,4,opt,name=complete,proto3" json:"complete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WriteBlobResponse) Reset()         { *m = WriteBlobResponse{} }
+func (m *WriteBlobResponse) String() string { return proto.CompactTextString(m) }
+func (*WriteBlobResponse) ProtoMessage()    {}
+func (*WriteBlobResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{21}
+}
+
+func (m *WriteBlobResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteBlobResponse.Unmarshal(m, b)
+}
+func (m *WriteBlobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteBlobResponse.Marshal(b, m, deterministic)
+}
+func (m *WriteBlobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteBlobResponse.Merge(m, src)
+}
+func (m *WriteBlobResponse) XXX_Size() int {
+	return xxx_messageInfo_WriteBlobResponse.Size(m)
+}
+func (m *WriteBlobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteBlobResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteBlobResponse proto.InternalMessageInfo
+
+func (m *WriteBlobResponse) GetBlobId() string {
+	if m != nil {
+		return m.BlobId
+	}
+	return ""
+}
+
+func (m *WriteBlobResponse) GetReceivedSize() int64 {
+	if m != nil {
+		return m.ReceivedSize
+	}
+	return 0
+}
+
+func (m *WriteBlobResponse) GetCrc32C() uint32 {
+	if m != nil {
+		return m.Crc32C
+	}
+	return 0
+}
+
+func (m *WriteBlobResponse) GetComplete() bool {
+	if m != nil {
+		return m.Complete
+	}
+	return false
+}
+
+// The request for the GetWriteStatus method.
+type GetWriteStatusRequest struct {
+	// The ID of the blob.
+	BlobId               string   `protobuf:"bytes,1,opt,name=blob_id,json=blobId,proto3" json:"blob_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetWriteStatusRequest) Reset()         { *m = GetWriteStatusRequest{} }
+func (m *GetWriteStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetWriteStatusRequest) ProtoMessage()    {}
+func (*GetWriteStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{22}
+}
+
+func (m *GetWriteStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWriteStatusRequest.Unmarshal(m, b)
+}
+func (m *GetWriteStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWriteStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetWriteStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWriteStatusRequest.Merge(m, src)
+}
+func (m *GetWriteStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetWriteStatusRequest.Size(m)
+}
+func (m *GetWriteStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWriteStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWriteStatusRequest proto.InternalMessageInfo
+
+func (m *GetWriteStatusRequest) GetBlobId() string {
+	if m != nil {
+		return m.BlobId
+	}
+	return ""
+}
+
+// The status of a blob written by the WriteBlob method.
+type WriteStatus struct {
+	// The ID of the blob.
+	BlobId string `protobuf:"bytes,1,opt,name=blob_id,json=blobId,proto3" json:"blob_id,omitempty"`
+	// The number of bytes committed for the blob. A resumed upload must send
+	// its first chunk at this offset.
+	CommittedSize int64 `protobuf:"varint,2,opt,name=committed_size,json=committedSize,proto3" json:"committed_size,omitempty"`
+	// The size of the blob in bytes.
+	TotalSize int64 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	// Whether all `total_size` bytes of the blob have been committed.
+	Complete             bool     `protobuf:"varint,4,opt,name=complete,proto3" json:"complete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WriteStatus) Reset()         { *m = WriteStatus{} }
+func (m *WriteStatus) String() string { return proto.CompactTextString(m) }
+func (*WriteStatus) ProtoMessage()    {}
+func (*WriteStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{23}
+}
+
+func (m *WriteStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteStatus.Unmarshal(m, b)
+}
+func (m *WriteStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteStatus.Marshal(b, m, deterministic)
+}
+func (m *WriteStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteStatus.Merge(m, src)
+}
+func (m *WriteStatus) XXX_Size() int {
+	return xxx_messageInfo_WriteStatus.Size(m)
+}
+func (m *WriteStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteStatus proto.InternalMessageInfo
+
+func (m *WriteStatus) GetBlobId() string {
+	if m != nil {
+		return m.BlobId
+	}
+	return ""
+}
+
+func (m *WriteStatus) GetCommittedSize() int64 {
+	if m != nil {
+		return m.CommittedSize
+	}
+	return 0
+}
+
+func (m *WriteStatus) GetTotalSize() int64 {
+	if m != nil {
+		return m.TotalSize
+	}
+	return 0
+}
+
+func (m *WriteStatus) GetComplete() bool {
+	if m != nil {
+		return m.Complete
+	}
+	return false
+}
+
+// The request for the GetLastExpandStatus method.
+type GetLastExpandStatusRequest struct {
+	// The `stream_id` of the Expand stream.
+	StreamId             string   `protobuf:"bytes,1,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetLastExpandStatusRequest) Reset()         { *m = GetLastExpandStatusRequest{} }
+func (m *GetLastExpandStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLastExpandStatusRequest) ProtoMessage()    {}
+func (*GetLastExpandStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{24}
+}
+
+func (m *GetLastExpandStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLastExpandStatusRequest.Unmarshal(m, b)
+}
+func (m *GetLastExpandStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLastExpandStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetLastExpandStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLastExpandStatusRequest.Merge(m, src)
+}
+func (m *GetLastExpandStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetLastExpandStatusRequest.Size(m)
+}
+func (m *GetLastExpandStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLastExpandStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLastExpandStatusRequest proto.InternalMessageInfo
+
+func (m *GetLastExpandStatusRequest) GetStreamId() string {
+	if m != nil {
+		return m.StreamId
+	}
+	return ""
+}
+
+// How far an Expand stream got before it ended. The messages counted were
+// handed to the transport, so a client that cancels a stream may not have
+// received the last of them.
+type ExpandStatus struct {
+	// The `stream_id` of the Expand stream.
+	StreamId string `protobuf:"bytes,1,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"`
+	// The number of messages the stream sent, heartbeats and summaries
+	// included.
+	MessagesSent int64 `protobuf:"varint,2,opt,name=messages_sent,json=messagesSent,proto3" json:"messages_sent,omitempty"`
+	// The serialized size of the messages the stream sent.
+	BytesSent int64 `protobuf:"varint,3,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	// How the stream ended.
+	Termination          ExpandStatus_Termination `protobuf:"varint,4,opt,name=termination,proto3,enum=google.showcase.v1beta1.ExpandStatus_Termination" json:"termination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *ExpandStatus) Reset()         { *m = ExpandStatus{} }
+func (m *ExpandStatus) String() string { return proto.CompactTextString(m) }
+func (*ExpandStatus) ProtoMessage()    {}
+func (*ExpandStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{25}
+}
+
+func (m *ExpandStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExpandStatus.Unmarshal(m, b)
+}
+func (m *ExpandStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExpandStatus.Marshal(b, m, deterministic)
+}
+func (m *ExpandStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExpandStatus.Merge(m, src)
+}
+func (m *ExpandStatus) XXX_Size() int {
+	return xxx_messageInfo_ExpandStatus.Size(m)
+}
+func (m *ExpandStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExpandStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExpandStatus proto.InternalMessageInfo
+
+func (m *ExpandStatus) GetStreamId() string {
+	if m != nil {
+		return m.StreamId
+	}
+	return ""
+}
+
+func (m *ExpandStatus) GetMessagesSent() int64 {
+	if m != nil {
+		return m.MessagesSent
+	}
+	return 0
+}
+
+func (m *ExpandStatus) GetBytesSent() int64 {
+	if m != nil {
+		return m.BytesSent
+	}
+	return 0
+}
+
+func (m *ExpandStatus) GetTermination() ExpandStatus_Termination {
+	if m != nil {
+		return m.Termination
+	}
+	return ExpandStatus_TERMINATION_UNSPECIFIED
+}
+
+// The request for the CreateEchoResource method.
+type CreateEchoResourceRequest struct {
+	// The name of the resource, which must not already exist.
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateEchoResourceRequest) Reset()         { *m = CreateEchoResourceRequest{} }
+func (m *CreateEchoResourceRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateEchoResourceRequest) ProtoMessage()    {}
+func (*CreateEchoResourceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{26}
+}
+
+func (m *CreateEchoResourceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateEchoResourceRequest.Unmarshal(m, b)
+}
+func (m *CreateEchoResourceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateEchoResourceRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateEchoResourceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateEchoResourceRequest.Merge(m, src)
+}
+func (m *CreateEchoResourceRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateEchoResourceRequest.Size(m)
+}
+func (m *CreateEchoResourceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateEchoResourceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateEchoResourceRequest proto.InternalMessageInfo
+
+func (m *CreateEchoResourceRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// A named resource that exists once created.
+type EchoResource struct {
+	// The name of the resource.
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EchoResource) Reset()         { *m = EchoResource{} }
+func (m *EchoResource) String() string { return proto.CompactTextString(m) }
+func (*EchoResource) ProtoMessage()    {}
+func (*EchoResource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{27}
+}
+
+func (m *EchoResource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EchoResource.Unmarshal(m, b)
+}
+func (m *EchoResource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EchoResource.Marshal(b, m, deterministic)
+}
+func (m *EchoResource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EchoResource.Merge(m, src)
+}
+func (m *EchoResource) XXX_Size() int {
+	return xxx_messageInfo_EchoResource.Size(m)
+}
+func (m *EchoResource) XXX_DiscardUnknown() {
+	xxx_messageInfo_EchoResource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EchoResource proto.InternalMessageInfo
+
+func (m *EchoResource) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// The request for the GetEchoResource method.
+type GetEchoResourceRequest struct {
+	// The name of the resource.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// If true, a resource that does not exist yet is reported as UNAVAILABLE
+	// rather than NOT_FOUND.
+	UnavailableUntilCreated bool     `protobuf:"varint,2,opt,name=unavailable_until_created,json=unavailableUntilCreated,proto3" json:"unavailable_until_created,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
+	XXX_unrecognized        []byte   `json:"-"`
+	XXX_sizecache           int32    `json:"-"`
+}
+
+func (m *GetEchoResourceRequest) Reset()         { *m = GetEchoResourceRequest{} }
+func (m *GetEchoResourceRequest) String() string { return proto.CompactTextString(m) }
+func (*GetEchoResourceRequest) ProtoMessage()    {}
+func (*GetEchoResourceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{28}
+}
+
+func (m *GetEchoResourceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEchoResourceRequest.Unmarshal(m, b)
+}
+func (m *GetEchoResourceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEchoResourceRequest.Marshal(b, m, deterministic)
+}
+func (m *GetEchoResourceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEchoResourceRequest.Merge(m, src)
+}
+func (m *GetEchoResourceRequest) XXX_Size() int {
+	return xxx_messageInfo_GetEchoResourceRequest.Size(m)
+}
+func (m *GetEchoResourceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEchoResourceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEchoResourceRequest proto.InternalMessageInfo
+
+func (m *GetEchoResourceRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetEchoResourceRequest) GetUnavailableUntilCreated() bool {
+	if m != nil {
+		return m.UnavailableUntilCreated
+	}
+	return false
+}
+
+// The request for the DeleteEchoResource method.
+type DeleteEchoResourceRequest struct {
+	// The name of the resource to delete.
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteEchoResourceRequest) Reset()         { *m = DeleteEchoResourceRequest{} }
+func (m *DeleteEchoResourceRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteEchoResourceRequest) ProtoMessage()    {}
+func (*DeleteEchoResourceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{29}
+}
+
+func (m *DeleteEchoResourceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteEchoResourceRequest.Unmarshal(m, b)
+}
+func (m *DeleteEchoResourceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteEchoResourceRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteEchoResourceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteEchoResourceRequest.Merge(m, src)
+}
+func (m *DeleteEchoResourceRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteEchoResourceRequest.Size(m)
+}
+func (m *DeleteEchoResourceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteEchoResourceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteEchoResourceRequest proto.InternalMessageInfo
+
+func (m *DeleteEchoResourceRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// The request for the BatchEcho method.
+type BatchEchoRequest struct {
+	// The requests to echo.
+	Requests             []*EchoRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *BatchEchoRequest) Reset()         { *m = BatchEchoRequest{} }
+func (m *BatchEchoRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchEchoRequest) ProtoMessage()    {}
+func (*BatchEchoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{30}
+}
+
+func (m *BatchEchoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchEchoRequest.Unmarshal(m, b)
+}
+func (m *BatchEchoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchEchoRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchEchoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchEchoRequest.Merge(m, src)
+}
+func (m *BatchEchoRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchEchoRequest.Size(m)
+}
+func (m *BatchEchoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchEchoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchEchoRequest proto.InternalMessageInfo
+
+func (m *BatchEchoRequest) GetRequests() []*EchoRequest {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+// The response for the BatchEcho method.
+type BatchEchoResponse struct {
+	// The result of each request, in the order of the requests.
+	Results              []*BatchEchoResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *BatchEchoResponse) Reset()         { *m = BatchEchoResponse{} }
+func (m *BatchEchoResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchEchoResponse) ProtoMessage()    {}
+func (*BatchEchoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{31}
+}
+
+func (m *BatchEchoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchEchoResponse.Unmarshal(m, b)
+}
+func (m *BatchEchoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchEchoResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchEchoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchEchoResponse.Merge(m, src)
+}
+func (m *BatchEchoResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchEchoResponse.Size(m)
+}
+func (m *BatchEchoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchEchoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchEchoResponse proto.InternalMessageInfo
+
+func (m *BatchEchoResponse) GetResults() []*BatchEchoResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+// The result of one request of a BatchEcho call.
+type BatchEchoResult struct {
+	// Types that are valid to be assigned to Result:
+	//	*BatchEchoResult_Response
+	//	*BatchEchoResult_Error
+	Result               isBatchEchoResult_Result `protobuf_oneof:"result"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *BatchEchoResult) Reset()         { *m = BatchEchoResult{} }
+func (m *BatchEchoResult) String() string { return proto.CompactTextString(m) }
+func (*BatchEchoResult) ProtoMessage()    {}
+func (*BatchEchoResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{32}
+}
+
+func (m *BatchEchoResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchEchoResult.Unmarshal(m, b)
+}
+func (m *BatchEchoResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchEchoResult.Marshal(b, m, deterministic)
+}
+func (m *BatchEchoResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchEchoResult.Merge(m, src)
+}
+func (m *BatchEchoResult) XXX_Size() int {
+	return xxx_messageInfo_BatchEchoResult.Size(m)
+}
+func (m *BatchEchoResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchEchoResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchEchoResult proto.InternalMessageInfo
+
+type isBatchEchoResult_Result interface {
+	isBatchEchoResult_Result()
+}
+
+type BatchEchoResult_Response struct {
+	Response *EchoResponse `protobuf:"bytes,1,opt,name=response,proto3,oneof"`
+}
+
+type BatchEchoResult_Error struct {
+	Error *status.Status `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*BatchEchoResult_Response) isBatchEchoResult_Result() {}
+
+func (*BatchEchoResult_Error) isBatchEchoResult_Result() {}
+
+func (m *BatchEchoResult) GetResult() isBatchEchoResult_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *BatchEchoResult) GetResponse() *EchoResponse {
+	if x, ok := m.GetResult().(*BatchEchoResult_Response); ok {
+		return x.Response
+	}
+	return nil
+}
+
+func (m *BatchEchoResult) GetError() *status.Status {
+	if x, ok := m.GetResult().(*BatchEchoResult_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*BatchEchoResult) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*BatchEchoResult_Response)(nil),
+		(*BatchEchoResult_Error)(nil),
+	}
 }
-func (m *WaitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_WaitRequest.Marshal(b, m, deterministic)
+
+// The request for the ReturnStatus method.
+type ReturnStatusRequest struct {
+	// The canonical code to fail with, from 1 (CANCELLED) to 16
+	// (UNAUTHENTICATED). OK is rejected, since a unary call cannot fail with it.
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// The message of the status.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// If true, the status carries a google.rpc.ErrorInfo whose reason is the
+	// name of the code, such as `NOT_FOUND`, with the number of the code under
+	// `code`, and a google.rpc.LocalizedMessage that mirrors `message` in the
+	// locale negotiated from the `accept-language` metadata, or the server's
+	// first supported locale.
+	WithDetails          bool     `protobuf:"varint,3,opt,name=with_details,json=withDetails,proto3" json:"with_details,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *WaitRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_WaitRequest.Merge(m, src)
+
+func (m *ReturnStatusRequest) Reset()         { *m = ReturnStatusRequest{} }
+func (m *ReturnStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*ReturnStatusRequest) ProtoMessage()    {}
+func (*ReturnStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{33}
 }
-func (m *WaitRequest) XXX_Size() int {
-	return xxx_messageInfo_WaitRequest.Size(m)
+
+func (m *ReturnStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReturnStatusRequest.Unmarshal(m, b)
 }
-func (m *WaitRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_WaitRequest.DiscardUnknown(m)
+func (m *ReturnStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReturnStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *ReturnStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReturnStatusRequest.Merge(m, src)
+}
+func (m *ReturnStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_ReturnStatusRequest.Size(m)
+}
+func (m *ReturnStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReturnStatusRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_WaitRequest proto.InternalMessageInfo
+var xxx_messageInfo_ReturnStatusRequest proto.InternalMessageInfo
 
-type isWaitRequest_End interface {
-	isWaitRequest_End()
+func (m *ReturnStatusRequest) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
 }
 
-type WaitRequest_EndTime struct {
-	EndTime *timestamp.Timestamp `protobuf:"bytes,1,opt,name=end_time,json=endTime,proto3,oneof"`
+func (m *ReturnStatusRequest) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
 }
 
-type WaitRequest_Ttl struct {
-	Ttl *duration.Duration `protobuf:"bytes,4,opt,name=ttl,proto3,oneof"`
+func (m *ReturnStatusRequest) GetWithDetails() bool {
+	if m != nil {
+		return m.WithDetails
+	}
+	return false
 }
 
-func (*WaitRequest_EndTime) isWaitRequest_End() {}
+// The request for the StreamStatus method.
+type StreamStatusRequest struct {
+	// The canonical code to end the stream with, from 0 (OK) to 16
+	// (UNAUTHENTICATED).
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// The message of the status.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// If true, a status other than OK carries the details described by
+	// ReturnStatusRequest.
+	WithDetails bool `protobuf:"varint,3,opt,name=with_details,json=withDetails,proto3" json:"with_details,omitempty"`
+	// The number of responses to stream before the status, at most 1000. The
+	// content of each is its index.
+	MessageCount         int32    `protobuf:"varint,4,opt,name=message_count,json=messageCount,proto3" json:"message_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
 
-func (*WaitRequest_Ttl) isWaitRequest_End() {}
+func (m *StreamStatusRequest) Reset()         { *m = StreamStatusRequest{} }
+func (m *StreamStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamStatusRequest) ProtoMessage()    {}
+func (*StreamStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{34}
+}
 
-func (m *WaitRequest) GetEnd() isWaitRequest_End {
+func (m *StreamStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamStatusRequest.Unmarshal(m, b)
+}
+func (m *StreamStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamStatusRequest.Merge(m, src)
+}
+func (m *StreamStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamStatusRequest.Size(m)
+}
+func (m *StreamStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamStatusRequest proto.InternalMessageInfo
+
+func (m *StreamStatusRequest) GetCode() int32 {
 	if m != nil {
-		return m.End
+		return m.Code
 	}
-	return nil
+	return 0
 }
 
-func (m *WaitRequest) GetEndTime() *timestamp.Timestamp {
-	if x, ok := m.GetEnd().(*WaitRequest_EndTime); ok {
-		return x.EndTime
+func (m *StreamStatusRequest) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *StreamStatusRequest) GetWithDetails() bool {
+	if m != nil {
+		return m.WithDetails
+	}
+	return false
+}
+
+func (m *StreamStatusRequest) GetMessageCount() int32 {
+	if m != nil {
+		return m.MessageCount
+	}
+	return 0
+}
+
+// The request for the StreamOperationUpdates method.
+type StreamOperationUpdatesRequest struct {
+	// The name of an operation started by Wait.
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamOperationUpdatesRequest) Reset()         { *m = StreamOperationUpdatesRequest{} }
+func (m *StreamOperationUpdatesRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamOperationUpdatesRequest) ProtoMessage()    {}
+func (*StreamOperationUpdatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{35}
+}
+
+func (m *StreamOperationUpdatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamOperationUpdatesRequest.Unmarshal(m, b)
+}
+func (m *StreamOperationUpdatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamOperationUpdatesRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamOperationUpdatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamOperationUpdatesRequest.Merge(m, src)
+}
+func (m *StreamOperationUpdatesRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamOperationUpdatesRequest.Size(m)
+}
+func (m *StreamOperationUpdatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamOperationUpdatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamOperationUpdatesRequest proto.InternalMessageInfo
+
+func (m *StreamOperationUpdatesRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// The request for the PublishEcho method.
+type PublishEchoRequest struct {
+	// The name of the topic, of the form `topics/*`.
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// The contents of the messages to publish, in order.
+	Messages             []string `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishEchoRequest) Reset()         { *m = PublishEchoRequest{} }
+func (m *PublishEchoRequest) String() string { return proto.CompactTextString(m) }
+func (*PublishEchoRequest) ProtoMessage()    {}
+func (*PublishEchoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{36}
+}
+
+func (m *PublishEchoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishEchoRequest.Unmarshal(m, b)
+}
+func (m *PublishEchoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishEchoRequest.Marshal(b, m, deterministic)
+}
+func (m *PublishEchoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishEchoRequest.Merge(m, src)
+}
+func (m *PublishEchoRequest) XXX_Size() int {
+	return xxx_messageInfo_PublishEchoRequest.Size(m)
+}
+func (m *PublishEchoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishEchoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishEchoRequest proto.InternalMessageInfo
+
+func (m *PublishEchoRequest) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *PublishEchoRequest) GetMessages() []string {
+	if m != nil {
+		return m.Messages
 	}
 	return nil
 }
 
-func (m *WaitRequest) GetTtl() *duration.Duration {
-	if x, ok := m.GetEnd().(*WaitRequest_Ttl); ok {
-		return x.Ttl
+// The response of the PublishEcho method.
+type PublishEchoResponse struct {
+	// The IDs of the published messages, in the order of the request.
+	MessageIds           []string `protobuf:"bytes,1,rep,name=message_ids,json=messageIds,proto3" json:"message_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishEchoResponse) Reset()         { *m = PublishEchoResponse{} }
+func (m *PublishEchoResponse) String() string { return proto.CompactTextString(m) }
+func (*PublishEchoResponse) ProtoMessage()    {}
+func (*PublishEchoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{37}
+}
+
+func (m *PublishEchoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishEchoResponse.Unmarshal(m, b)
+}
+func (m *PublishEchoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishEchoResponse.Marshal(b, m, deterministic)
+}
+func (m *PublishEchoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishEchoResponse.Merge(m, src)
+}
+func (m *PublishEchoResponse) XXX_Size() int {
+	return xxx_messageInfo_PublishEchoResponse.Size(m)
+}
+func (m *PublishEchoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishEchoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishEchoResponse proto.InternalMessageInfo
+
+func (m *PublishEchoResponse) GetMessageIds() []string {
+	if m != nil {
+		return m.MessageIds
 	}
 	return nil
 }
 
-type isWaitRequest_Response interface {
-	isWaitRequest_Response()
+// A request of a StreamingPullEcho stream. The first request names the
+// topic, and any request may acknowledge messages.
+type StreamingPullEchoRequest struct {
+	// The name of the topic to pull from, of the form `topics/*`. Required in
+	// the first request of a stream; later requests must leave it empty or
+	// name the same topic.
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// The ack IDs of messages delivered on any stream of the topic that the
+	// client has processed. IDs of messages already acknowledged, or of
+	// deliveries since superseded by a redelivery, are ignored.
+	AckIds []string `protobuf:"bytes,2,rep,name=ack_ids,json=ackIds,proto3" json:"ack_ids,omitempty"`
+	// How long a message delivered on the stream may go unacknowledged before
+	// it is delivered again, from 1 second to 10 minutes. Only the first
+	// request sets it; the default is 10 seconds.
+	AckDeadline *duration.Duration `protobuf:"bytes,3,opt,name=ack_deadline,json=ackDeadline,proto3" json:"ack_deadline,omitempty"`
+	// The most messages delivered on the stream that may be unacknowledged at
+	// once, or 0 for no limit. Only the first request sets it.
+	MaxOutstandingMessages int32    `protobuf:"varint,4,opt,name=max_outstanding_messages,json=maxOutstandingMessages,proto3" json:"max_outstanding_messages,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
 }
 
-type WaitRequest_Error struct {
-	Error *status.Status `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+func (m *StreamingPullEchoRequest) Reset()         { *m = StreamingPullEchoRequest{} }
+func (m *StreamingPullEchoRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamingPullEchoRequest) ProtoMessage()    {}
+func (*StreamingPullEchoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{38}
 }
 
-type WaitRequest_Success struct {
-	Success *WaitResponse `protobuf:"bytes,3,opt,name=success,proto3,oneof"`
+func (m *StreamingPullEchoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamingPullEchoRequest.Unmarshal(m, b)
+}
+func (m *StreamingPullEchoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamingPullEchoRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamingPullEchoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamingPullEchoRequest.Merge(m, src)
+}
+func (m *StreamingPullEchoRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamingPullEchoRequest.Size(m)
+}
+func (m *StreamingPullEchoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamingPullEchoRequest.DiscardUnknown(m)
 }
 
-func (*WaitRequest_Error) isWaitRequest_Response() {}
+var xxx_messageInfo_StreamingPullEchoRequest proto.InternalMessageInfo
 
-func (*WaitRequest_Success) isWaitRequest_Response() {}
+func (m *StreamingPullEchoRequest) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
 
-func (m *WaitRequest) GetResponse() isWaitRequest_Response {
+func (m *StreamingPullEchoRequest) GetAckIds() []string {
 	if m != nil {
-		return m.Response
+		return m.AckIds
 	}
 	return nil
 }
 
-func (m *WaitRequest) GetError() *status.Status {
-	if x, ok := m.GetResponse().(*WaitRequest_Error); ok {
-		return x.Error
+func (m *StreamingPullEchoRequest) GetAckDeadline() *duration.Duration {
+	if m != nil {
+		return m.AckDeadline
 	}
 	return nil
 }
 
-func (m *WaitRequest) GetSuccess() *WaitResponse {
-	if x, ok := m.GetResponse().(*WaitRequest_Success); ok {
-		return x.Success
+func (m *StreamingPullEchoRequest) GetMaxOutstandingMessages() int32 {
+	if m != nil {
+		return m.MaxOutstandingMessages
+	}
+	return 0
+}
+
+// A response of a StreamingPullEcho stream.
+type StreamingPullEchoResponse struct {
+	// The messages delivered, in the order they were published.
+	ReceivedMessages     []*ReceivedEchoMessage `protobuf:"bytes,1,rep,name=received_messages,json=receivedMessages,proto3" json:"received_messages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *StreamingPullEchoResponse) Reset()         { *m = StreamingPullEchoResponse{} }
+func (m *StreamingPullEchoResponse) String() string { return proto.CompactTextString(m) }
+func (*StreamingPullEchoResponse) ProtoMessage()    {}
+func (*StreamingPullEchoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{39}
+}
+
+func (m *StreamingPullEchoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamingPullEchoResponse.Unmarshal(m, b)
+}
+func (m *StreamingPullEchoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamingPullEchoResponse.Marshal(b, m, deterministic)
+}
+func (m *StreamingPullEchoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamingPullEchoResponse.Merge(m, src)
+}
+func (m *StreamingPullEchoResponse) XXX_Size() int {
+	return xxx_messageInfo_StreamingPullEchoResponse.Size(m)
+}
+func (m *StreamingPullEchoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamingPullEchoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamingPullEchoResponse proto.InternalMessageInfo
+
+func (m *StreamingPullEchoResponse) GetReceivedMessages() []*ReceivedEchoMessage {
+	if m != nil {
+		return m.ReceivedMessages
 	}
 	return nil
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*WaitRequest) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*WaitRequest_EndTime)(nil),
-		(*WaitRequest_Ttl)(nil),
-		(*WaitRequest_Error)(nil),
-		(*WaitRequest_Success)(nil),
+// A message delivered by StreamingPullEcho.
+type ReceivedEchoMessage struct {
+	// The ID that acknowledges this delivery of the message.
+	AckId string `protobuf:"bytes,1,opt,name=ack_id,json=ackId,proto3" json:"ack_id,omitempty"`
+	// The ID of the message, as returned by PublishEcho.
+	MessageId string `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// The content of the message.
+	Content string `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// How many times the message has been delivered, counting this delivery.
+	DeliveryAttempt      int32    `protobuf:"varint,4,opt,name=delivery_attempt,json=deliveryAttempt,proto3" json:"delivery_attempt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReceivedEchoMessage) Reset()         { *m = ReceivedEchoMessage{} }
+func (m *ReceivedEchoMessage) String() string { return proto.CompactTextString(m) }
+func (*ReceivedEchoMessage) ProtoMessage()    {}
+func (*ReceivedEchoMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{40}
+}
+
+func (m *ReceivedEchoMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceivedEchoMessage.Unmarshal(m, b)
+}
+func (m *ReceivedEchoMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceivedEchoMessage.Marshal(b, m, deterministic)
+}
+func (m *ReceivedEchoMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceivedEchoMessage.Merge(m, src)
+}
+func (m *ReceivedEchoMessage) XXX_Size() int {
+	return xxx_messageInfo_ReceivedEchoMessage.Size(m)
+}
+func (m *ReceivedEchoMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceivedEchoMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceivedEchoMessage proto.InternalMessageInfo
+
+func (m *ReceivedEchoMessage) GetAckId() string {
+	if m != nil {
+		return m.AckId
 	}
+	return ""
 }
 
-// The result of the Wait operation.
-type WaitResponse struct {
-	// This content of the result.
-	Content              string   `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+func (m *ReceivedEchoMessage) GetMessageId() string {
+	if m != nil {
+		return m.MessageId
+	}
+	return ""
+}
+
+func (m *ReceivedEchoMessage) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func (m *ReceivedEchoMessage) GetDeliveryAttempt() int32 {
+	if m != nil {
+		return m.DeliveryAttempt
+	}
+	return 0
+}
+
+// Numeric values whose JSON forms are easy to get wrong.
+type NumericValues struct {
+	DoubleValue          float64  `protobuf:"fixed64,1,opt,name=double_value,json=doubleValue,proto3" json:"double_value,omitempty"`
+	FloatValue           float32  `protobuf:"fixed32,2,opt,name=float_value,json=floatValue,proto3" json:"float_value,omitempty"`
+	Int64Value           int64    `protobuf:"varint,3,opt,name=int64_value,json=int64Value,proto3" json:"int64_value,omitempty"`
+	Uint64Value          uint64   `protobuf:"varint,4,opt,name=uint64_value,json=uint64Value,proto3" json:"uint64_value,omitempty"`
+	Fixed64Value         uint64   `protobuf:"fixed64,5,opt,name=fixed64_value,json=fixed64Value,proto3" json:"fixed64_value,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *WaitResponse) Reset()         { *m = WaitResponse{} }
-func (m *WaitResponse) String() string { return proto.CompactTextString(m) }
-func (*WaitResponse) ProtoMessage()    {}
-func (*WaitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6220e75e6899216f, []int{6}
+func (m *NumericValues) Reset()         { *m = NumericValues{} }
+func (m *NumericValues) String() string { return proto.CompactTextString(m) }
+func (*NumericValues) ProtoMessage()    {}
+func (*NumericValues) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{41}
+}
+
+func (m *NumericValues) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NumericValues.Unmarshal(m, b)
+}
+func (m *NumericValues) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NumericValues.Marshal(b, m, deterministic)
+}
+func (m *NumericValues) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NumericValues.Merge(m, src)
+}
+func (m *NumericValues) XXX_Size() int {
+	return xxx_messageInfo_NumericValues.Size(m)
+}
+func (m *NumericValues) XXX_DiscardUnknown() {
+	xxx_messageInfo_NumericValues.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NumericValues proto.InternalMessageInfo
+
+func (m *NumericValues) GetDoubleValue() float64 {
+	if m != nil {
+		return m.DoubleValue
+	}
+	return 0
+}
+
+func (m *NumericValues) GetFloatValue() float32 {
+	if m != nil {
+		return m.FloatValue
+	}
+	return 0
+}
+
+func (m *NumericValues) GetInt64Value() int64 {
+	if m != nil {
+		return m.Int64Value
+	}
+	return 0
+}
+
+func (m *NumericValues) GetUint64Value() uint64 {
+	if m != nil {
+		return m.Uint64Value
+	}
+	return 0
+}
+
+func (m *NumericValues) GetFixed64Value() uint64 {
+	if m != nil {
+		return m.Fixed64Value
+	}
+	return 0
+}
+
+// The request for the RepeatNumericEdgeCases method.
+type RepeatNumericEdgeCasesRequest struct {
+	// The values to return.
+	Values []*NumericValues `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	// The edge cases to return after the values.
+	EdgeCases            RepeatNumericEdgeCasesRequest_EdgeCases `protobuf:"varint,2,opt,name=edge_cases,json=edgeCases,proto3,enum=google.showcase.v1beta1.RepeatNumericEdgeCasesRequest_EdgeCases" json:"edge_cases,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                `json:"-"`
+	XXX_unrecognized     []byte                                  `json:"-"`
+	XXX_sizecache        int32                                   `json:"-"`
 }
 
-func (m *WaitResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_WaitResponse.Unmarshal(m, b)
+func (m *RepeatNumericEdgeCasesRequest) Reset()         { *m = RepeatNumericEdgeCasesRequest{} }
+func (m *RepeatNumericEdgeCasesRequest) String() string { return proto.CompactTextString(m) }
+func (*RepeatNumericEdgeCasesRequest) ProtoMessage()    {}
+func (*RepeatNumericEdgeCasesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{42}
 }
-func (m *WaitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_WaitResponse.Marshal(b, m, deterministic)
+
+func (m *RepeatNumericEdgeCasesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RepeatNumericEdgeCasesRequest.Unmarshal(m, b)
 }
-func (m *WaitResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_WaitResponse.Merge(m, src)
+func (m *RepeatNumericEdgeCasesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RepeatNumericEdgeCasesRequest.Marshal(b, m, deterministic)
 }
-func (m *WaitResponse) XXX_Size() int {
-	return xxx_messageInfo_WaitResponse.Size(m)
+func (m *RepeatNumericEdgeCasesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RepeatNumericEdgeCasesRequest.Merge(m, src)
 }
-func (m *WaitResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_WaitResponse.DiscardUnknown(m)
+func (m *RepeatNumericEdgeCasesRequest) XXX_Size() int {
+	return xxx_messageInfo_RepeatNumericEdgeCasesRequest.Size(m)
+}
+func (m *RepeatNumericEdgeCasesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RepeatNumericEdgeCasesRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_WaitResponse proto.InternalMessageInfo
+var xxx_messageInfo_RepeatNumericEdgeCasesRequest proto.InternalMessageInfo
 
-func (m *WaitResponse) GetContent() string {
+func (m *RepeatNumericEdgeCasesRequest) GetValues() []*NumericValues {
 	if m != nil {
-		return m.Content
+		return m.Values
 	}
-	return ""
+	return nil
 }
 
-// The metadata for Wait operation.
-type WaitMetadata struct {
-	// The time that this operation will complete.
-	EndTime              *timestamp.Timestamp `protobuf:"bytes,1,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+func (m *RepeatNumericEdgeCasesRequest) GetEdgeCases() RepeatNumericEdgeCasesRequest_EdgeCases {
+	if m != nil {
+		return m.EdgeCases
+	}
+	return RepeatNumericEdgeCasesRequest_EDGE_CASES_UNSPECIFIED
 }
 
-func (m *WaitMetadata) Reset()         { *m = WaitMetadata{} }
-func (m *WaitMetadata) String() string { return proto.CompactTextString(m) }
-func (*WaitMetadata) ProtoMessage()    {}
-func (*WaitMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6220e75e6899216f, []int{7}
+// The response for the RepeatNumericEdgeCases method.
+type RepeatNumericEdgeCasesResponse struct {
+	// The values of the request, followed by the edge cases it selected.
+	Values               []*NumericValues `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *WaitMetadata) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_WaitMetadata.Unmarshal(m, b)
+func (m *RepeatNumericEdgeCasesResponse) Reset()         { *m = RepeatNumericEdgeCasesResponse{} }
+func (m *RepeatNumericEdgeCasesResponse) String() string { return proto.CompactTextString(m) }
+func (*RepeatNumericEdgeCasesResponse) ProtoMessage()    {}
+func (*RepeatNumericEdgeCasesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6220e75e6899216f, []int{43}
 }
-func (m *WaitMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_WaitMetadata.Marshal(b, m, deterministic)
+
+func (m *RepeatNumericEdgeCasesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RepeatNumericEdgeCasesResponse.Unmarshal(m, b)
 }
-func (m *WaitMetadata) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_WaitMetadata.Merge(m, src)
+func (m *RepeatNumericEdgeCasesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RepeatNumericEdgeCasesResponse.Marshal(b, m, deterministic)
 }
-func (m *WaitMetadata) XXX_Size() int {
-	return xxx_messageInfo_WaitMetadata.Size(m)
+func (m *RepeatNumericEdgeCasesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RepeatNumericEdgeCasesResponse.Merge(m, src)
 }
-func (m *WaitMetadata) XXX_DiscardUnknown() {
-	xxx_messageInfo_WaitMetadata.DiscardUnknown(m)
+func (m *RepeatNumericEdgeCasesResponse) XXX_Size() int {
+	return xxx_messageInfo_RepeatNumericEdgeCasesResponse.Size(m)
+}
+func (m *RepeatNumericEdgeCasesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RepeatNumericEdgeCasesResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_WaitMetadata proto.InternalMessageInfo
+var xxx_messageInfo_RepeatNumericEdgeCasesResponse proto.InternalMessageInfo
 
-func (m *WaitMetadata) GetEndTime() *timestamp.Timestamp {
+func (m *RepeatNumericEdgeCasesResponse) GetValues() []*NumericValues {
 	if m != nil {
-		return m.EndTime
+		return m.Values
 	}
 	return nil
 }
 
 func init() {
+	proto.RegisterEnum("google.showcase.v1beta1.FailEchoWithDetailsRequest_DetailType", FailEchoWithDetailsRequest_DetailType_name, FailEchoWithDetailsRequest_DetailType_value)
+	proto.RegisterEnum("google.showcase.v1beta1.ExpandStatus_Termination", ExpandStatus_Termination_name, ExpandStatus_Termination_value)
+	proto.RegisterEnum("google.showcase.v1beta1.RepeatNumericEdgeCasesRequest_EdgeCases", RepeatNumericEdgeCasesRequest_EdgeCases_name, RepeatNumericEdgeCasesRequest_EdgeCases_value)
 	proto.RegisterType((*EchoRequest)(nil), "google.showcase.v1beta1.EchoRequest")
+	proto.RegisterMapType((map[string]string)(nil), "google.showcase.v1beta1.EchoRequest.TrailersEntry")
+	proto.RegisterType((*ChatAck)(nil), "google.showcase.v1beta1.ChatAck")
+	proto.RegisterType((*CacheControl)(nil), "google.showcase.v1beta1.CacheControl")
 	proto.RegisterType((*EchoResponse)(nil), "google.showcase.v1beta1.EchoResponse")
+	proto.RegisterType((*ForwardHop)(nil), "google.showcase.v1beta1.ForwardHop")
+	proto.RegisterMapType((map[string]string)(nil), "google.showcase.v1beta1.ForwardHop.HeadersEntry")
+	proto.RegisterType((*TransportInfo)(nil), "google.showcase.v1beta1.TransportInfo")
+	proto.RegisterType((*CollectFailure)(nil), "google.showcase.v1beta1.CollectFailure")
 	proto.RegisterType((*ExpandRequest)(nil), "google.showcase.v1beta1.ExpandRequest")
+	proto.RegisterMapType((map[string]string)(nil), "google.showcase.v1beta1.ExpandRequest.TrailersEntry")
 	proto.RegisterType((*PagedExpandRequest)(nil), "google.showcase.v1beta1.PagedExpandRequest")
 	proto.RegisterType((*PagedExpandResponse)(nil), "google.showcase.v1beta1.PagedExpandResponse")
 	proto.RegisterType((*WaitRequest)(nil), "google.showcase.v1beta1.WaitRequest")
+	proto.RegisterType((*PartialResult)(nil), "google.showcase.v1beta1.PartialResult")
+	proto.RegisterType((*PollQuota)(nil), "google.showcase.v1beta1.PollQuota")
 	proto.RegisterType((*WaitResponse)(nil), "google.showcase.v1beta1.WaitResponse")
 	proto.RegisterType((*WaitMetadata)(nil), "google.showcase.v1beta1.WaitMetadata")
+	proto.RegisterType((*FailEchoWithDetailsRequest)(nil), "google.showcase.v1beta1.FailEchoWithDetailsRequest")
+	proto.RegisterType((*InspectCredentialsRequest)(nil), "google.showcase.v1beta1.InspectCredentialsRequest")
+	proto.RegisterType((*InspectCredentialsResponse)(nil), "google.showcase.v1beta1.InspectCredentialsResponse")
+	proto.RegisterType((*InspectCredentialsResponse_Credential)(nil), "google.showcase.v1beta1.InspectCredentialsResponse.Credential")
+	proto.RegisterType((*ReadBlobRequest)(nil), "google.showcase.v1beta1.ReadBlobRequest")
+	proto.RegisterType((*ReadBlobResponse)(nil), "google.showcase.v1beta1.ReadBlobResponse")
+	proto.RegisterType((*WriteBlobRequest)(nil), "google.showcase.v1beta1.WriteBlobRequest")
+	proto.RegisterType((*WriteBlobRequest_Spec)(nil), "google.showcase.v1beta1.WriteBlobRequest.Spec")
+	proto.RegisterType((*WriteBlobRequest_Chunk)(nil), "google.showcase.v1beta1.WriteBlobRequest.Chunk")
+	proto.RegisterType((*WriteBlobResponse)(nil), "google.showcase.v1beta1.WriteBlobResponse")
+	proto.RegisterType((*GetWriteStatusRequest)(nil), "google.showcase.v1beta1.GetWriteStatusRequest")
+	proto.RegisterType((*WriteStatus)(nil), "google.showcase.v1beta1.WriteStatus")
+	proto.RegisterType((*GetLastExpandStatusRequest)(nil), "google.showcase.v1beta1.GetLastExpandStatusRequest")
+	proto.RegisterType((*ExpandStatus)(nil), "google.showcase.v1beta1.ExpandStatus")
+	proto.RegisterType((*CreateEchoResourceRequest)(nil), "google.showcase.v1beta1.CreateEchoResourceRequest")
+	proto.RegisterType((*EchoResource)(nil), "google.showcase.v1beta1.EchoResource")
+	proto.RegisterType((*GetEchoResourceRequest)(nil), "google.showcase.v1beta1.GetEchoResourceRequest")
+	proto.RegisterType((*DeleteEchoResourceRequest)(nil), "google.showcase.v1beta1.DeleteEchoResourceRequest")
+	proto.RegisterType((*BatchEchoRequest)(nil), "google.showcase.v1beta1.BatchEchoRequest")
+	proto.RegisterType((*BatchEchoResponse)(nil), "google.showcase.v1beta1.BatchEchoResponse")
+	proto.RegisterType((*BatchEchoResult)(nil), "google.showcase.v1beta1.BatchEchoResult")
+	proto.RegisterType((*ReturnStatusRequest)(nil), "google.showcase.v1beta1.ReturnStatusRequest")
+	proto.RegisterType((*StreamStatusRequest)(nil), "google.showcase.v1beta1.StreamStatusRequest")
+	proto.RegisterType((*StreamOperationUpdatesRequest)(nil), "google.showcase.v1beta1.StreamOperationUpdatesRequest")
+	proto.RegisterType((*PublishEchoRequest)(nil), "google.showcase.v1beta1.PublishEchoRequest")
+	proto.RegisterType((*PublishEchoResponse)(nil), "google.showcase.v1beta1.PublishEchoResponse")
+	proto.RegisterType((*StreamingPullEchoRequest)(nil), "google.showcase.v1beta1.StreamingPullEchoRequest")
+	proto.RegisterType((*StreamingPullEchoResponse)(nil), "google.showcase.v1beta1.StreamingPullEchoResponse")
+	proto.RegisterType((*ReceivedEchoMessage)(nil), "google.showcase.v1beta1.ReceivedEchoMessage")
+	proto.RegisterType((*NumericValues)(nil), "google.showcase.v1beta1.NumericValues")
+	proto.RegisterType((*RepeatNumericEdgeCasesRequest)(nil), "google.showcase.v1beta1.RepeatNumericEdgeCasesRequest")
+	proto.RegisterType((*RepeatNumericEdgeCasesResponse)(nil), "google.showcase.v1beta1.RepeatNumericEdgeCasesResponse")
 }
 
 func init() { proto.RegisterFile("google/showcase/v1beta1/echo.proto", fileDescriptor_6220e75e6899216f) }
 
 var fileDescriptor_6220e75e6899216f = []byte{
-	// 765 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xcf, 0x4f, 0xdb, 0x48,
-	0x14, 0x66, 0x92, 0xf0, 0x6b, 0xb2, 0xec, 0x6a, 0x67, 0x77, 0x45, 0x30, 0x84, 0x8d, 0x5c, 0x8a,
-	0xa2, 0x14, 0x6c, 0x48, 0x69, 0x51, 0xa3, 0x5e, 0x12, 0x8a, 0x94, 0x4b, 0x55, 0x14, 0xa8, 0x90,
-	0x7a, 0x89, 0x26, 0xf6, 0xc3, 0xb1, 0xea, 0xcc, 0xb8, 0x9e, 0x09, 0x20, 0x8e, 0xdc, 0x5a, 0x55,
-	0xbd, 0xf4, 0xbf, 0xe2, 0xda, 0x5b, 0x4f, 0x3d, 0xf4, 0x7f, 0xe8, 0xb5, 0xf2, 0xd8, 0x0e, 0x4e,
-	0x68, 0x20, 0x95, 0xb8, 0x24, 0x9a, 0x99, 0xef, 0xbd, 0xef, 0xbd, 0xef, 0x7d, 0xe3, 0xc1, 0xba,
-	0xc3, 0xb9, 0xe3, 0x81, 0x29, 0xba, 0xfc, 0xcc, 0xa2, 0x02, 0xcc, 0xd3, 0xed, 0x0e, 0x48, 0xba,
-	0x6d, 0x82, 0xd5, 0xe5, 0x86, 0x1f, 0x70, 0xc9, 0xc9, 0x62, 0x84, 0x31, 0x12, 0x8c, 0x11, 0x63,
-	0xb4, 0x95, 0x38, 0x98, 0xfa, 0xae, 0x49, 0x19, 0xe3, 0x92, 0x4a, 0x97, 0x33, 0x11, 0x85, 0x69,
-	0x8b, 0xa9, 0x53, 0xcb, 0x73, 0x81, 0xc9, 0xf8, 0xe0, 0xff, 0xd4, 0xc1, 0x89, 0x0b, 0x9e, 0xdd,
-	0xee, 0x40, 0x97, 0x9e, 0xba, 0x3c, 0x88, 0x01, 0x0f, 0x62, 0x80, 0xc7, 0x99, 0x13, 0xf4, 0x19,
-	0x73, 0x99, 0x63, 0x72, 0x1f, 0x82, 0xa1, 0xf4, 0xab, 0x31, 0x48, 0xad, 0x3a, 0xfd, 0x13, 0xd3,
-	0xee, 0x47, 0x80, 0x11, 0x96, 0xc1, 0xb9, 0x74, 0x7b, 0x20, 0x24, 0xed, 0xf9, 0x23, 0xf5, 0x05,
-	0xbe, 0x65, 0x0a, 0x49, 0x65, 0x3f, 0xce, 0xac, 0x53, 0x9c, 0xdf, 0xb7, 0xba, 0xbc, 0x05, 0xef,
-	0xfa, 0x20, 0x24, 0xd1, 0xf0, 0xac, 0xc5, 0x99, 0x04, 0x26, 0x0b, 0xa8, 0x84, 0xca, 0xf3, 0xcd,
-	0xa9, 0x56, 0xb2, 0x41, 0x2a, 0x78, 0x1a, 0x82, 0x80, 0x07, 0x85, 0x4c, 0x09, 0x95, 0xf3, 0x55,
-	0x62, 0xc4, 0x52, 0x05, 0xbe, 0x65, 0x1c, 0xaa, 0x9c, 0xcd, 0xa9, 0x56, 0x04, 0x69, 0x60, 0x3c,
-	0x17, 0x80, 0xf0, 0x39, 0x13, 0xa0, 0x97, 0xf1, 0x1f, 0x11, 0x45, 0xb4, 0x26, 0x85, 0x11, 0x8e,
-	0x01, 0x83, 0x7e, 0x88, 0x17, 0xf6, 0xcf, 0x7d, 0xca, 0xec, 0xa4, 0x9c, 0xb1, 0x50, 0x52, 0xbe,
-	0xb3, 0x98, 0xb8, 0x14, 0x9d, 0x63, 0x72, 0x40, 0x1d, 0xb0, 0x87, 0x33, 0x17, 0x47, 0x32, 0x37,
-	0xb2, 0xdf, 0xea, 0x99, 0xeb, 0xf4, 0xcb, 0x78, 0xde, 0xa7, 0x0e, 0xb4, 0x85, 0x7b, 0x01, 0x8a,
-	0x62, 0xba, 0x35, 0x17, 0x6e, 0x1c, 0xba, 0x17, 0x40, 0x8a, 0x18, 0xab, 0x43, 0xc9, 0xdf, 0x02,
-	0x2b, 0x64, 0x55, 0x61, 0x0a, 0x7e, 0x14, 0x6e, 0xe8, 0x97, 0x08, 0xff, 0x33, 0xc4, 0x18, 0xf7,
-	0xbd, 0x87, 0xe7, 0x13, 0x4d, 0x44, 0x01, 0x95, 0xb2, 0xe5, 0x7c, 0xf5, 0xa1, 0x31, 0xc6, 0x6e,
-	0x46, 0x5a, 0xb1, 0xd6, 0x75, 0x1c, 0x59, 0xc7, 0x7f, 0x31, 0x38, 0x97, 0xed, 0x54, 0x01, 0x19,
-	0x55, 0xc0, 0x42, 0xb8, 0x7d, 0x30, 0x28, 0xe2, 0x07, 0xc2, 0xf9, 0x63, 0xea, 0xca, 0xa4, 0xdf,
-	0x5d, 0x3c, 0x07, 0xcc, 0x6e, 0x87, 0xbe, 0x50, 0x0d, 0xe7, 0xab, 0x5a, 0xc2, 0x9d, 0x98, 0xc6,
-	0x38, 0x4a, 0x4c, 0x13, 0x4e, 0x1d, 0x98, 0x1d, 0xae, 0xc9, 0x26, 0xce, 0x4a, 0xe9, 0x15, 0x72,
-	0x2a, 0x66, 0xe9, 0x46, 0xcc, 0x8b, 0xd8, 0x88, 0xcd, 0xa9, 0x56, 0x88, 0x9b, 0xc4, 0x24, 0x28,
-	0x9e, 0x0c, 0xa9, 0xe3, 0x59, 0xd1, 0xb7, 0x2c, 0x10, 0x42, 0x89, 0x78, 0x9b, 0x1c, 0x51, 0x2b,
-	0x91, 0x08, 0x4d, 0xd4, 0x4a, 0xe2, 0x1a, 0xd3, 0x38, 0x0b, 0xcc, 0x1e, 0xb5, 0x5b, 0x1a, 0x7d,
-	0x8b, 0xdd, 0xf6, 0x23, 0xe4, 0x4b, 0x90, 0xd4, 0xa6, 0x92, 0x92, 0x27, 0xbf, 0xa3, 0xd1, 0x40,
-	0xa1, 0xea, 0xc7, 0x19, 0x9c, 0x0b, 0xc7, 0x45, 0x82, 0xf8, 0x7f, 0xed, 0x8e, 0xa9, 0xaa, 0x89,
-	0x68, 0x93, 0xcd, 0x5e, 0x2f, 0x5e, 0x7e, 0xf9, 0xfe, 0x39, 0xb3, 0xa8, 0x93, 0xa1, 0xaf, 0x55,
-	0x4d, 0xfd, 0xa0, 0x0a, 0xf9, 0x80, 0xf0, 0x4c, 0xe4, 0x33, 0xb2, 0x3e, 0x3e, 0x61, 0xda, 0xfa,
-	0x93, 0x12, 0x9b, 0x5f, 0xeb, 0x0b, 0xb1, 0x52, 0x1b, 0x6a, 0x5e, 0xaa, 0x90, 0x25, 0xfd, 0xdf,
-	0x91, 0x42, 0x54, 0xee, 0x1a, 0xaa, 0x6c, 0x21, 0x72, 0x81, 0x67, 0xf7, 0xb8, 0xe7, 0x81, 0x25,
-	0xef, 0x57, 0x83, 0x92, 0xa2, 0xd6, 0xf4, 0xff, 0x86, 0xa9, 0xad, 0x88, 0xab, 0x86, 0x2a, 0x65,
-	0x44, 0x8e, 0x71, 0x6e, 0xaf, 0x4b, 0xef, 0x97, 0xb8, 0x8c, 0xb6, 0x10, 0xf9, 0x84, 0x70, 0x3e,
-	0x75, 0x9d, 0xc9, 0xa3, 0xb1, 0xa1, 0x37, 0x3f, 0x33, 0xda, 0xc6, 0x64, 0xe0, 0xb8, 0xcf, 0x35,
-	0xd5, 0xe7, 0xaa, 0xbe, 0x34, 0xdc, 0xa7, 0x7f, 0x0d, 0x0d, 0x47, 0xfe, 0x1e, 0xe1, 0x5c, 0xe8,
-	0xdb, 0x5b, 0x5a, 0x4d, 0xdd, 0x7c, 0xad, 0x98, 0xa0, 0x52, 0x2f, 0x8c, 0xf1, 0x2a, 0x79, 0x61,
-	0xf4, 0xe7, 0x57, 0xf5, 0x95, 0x91, 0x1b, 0x33, 0x74, 0x2b, 0x7e, 0x6d, 0xbf, 0x33, 0xea, 0x86,
-	0xba, 0x6b, 0x7f, 0x5f, 0xd5, 0xff, 0xf4, 0xb8, 0x45, 0xbd, 0x2e, 0x17, 0xb2, 0xb6, 0xbb, 0xf3,
-	0xf4, 0x59, 0xe3, 0x35, 0x5e, 0xb6, 0x78, 0x6f, 0x5c, 0x69, 0x07, 0xe8, 0xcd, 0x8e, 0xe3, 0xca,
-	0x6e, 0xbf, 0x63, 0x58, 0xbc, 0x67, 0x46, 0x28, 0xea, 0xbb, 0xc2, 0x74, 0xa8, 0xef, 0x5a, 0x9b,
-	0x83, 0xb7, 0x59, 0x40, 0x70, 0x0a, 0x81, 0xe9, 0x00, 0x8b, 0xee, 0xde, 0x8c, 0xfa, 0x7b, 0xfc,
-	0x33, 0x00, 0x00, 0xff, 0xff, 0x07, 0xd3, 0x4c, 0x6a, 0xc5, 0x07, 0x00, 0x00,
+	// 4537 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x3b, 0x5b, 0x6f, 0x23, 0xd7,
+	0x79, 0x3b, 0x22, 0x25, 0x91, 0x1f, 0x49, 0x89, 0x3a, 0xda, 0x95, 0x66, 0xb9, 0x96, 0x2d, 0xcf,
+	0xfa, 0xa2, 0x95, 0x6d, 0x6a, 0xad, 0xdd, 0xd8, 0xee, 0x26, 0x59, 0x84, 0xa2, 0xb8, 0x2b, 0x06,
+	0xba, 0x65, 0xa4, 0xf5, 0x26, 0x01, 0x8a, 0xc9, 0x68, 0xe6, 0x48, 0x9a, 0x6a, 0x38, 0x33, 0x9e,
+	0x39, 0xa3, 0x8b, 0x0b, 0xb7, 0x40, 0xd0, 0x4b, 0x92, 0xa6, 0x85, 0xd1, 0xa2, 0x7d, 0xe9, 0x5b,
+	0x0b, 0xf8, 0xa1, 0x6f, 0x7d, 0x2d, 0x0a, 0x14, 0x41, 0xdf, 0x02, 0xf4, 0xa9, 0x4f, 0xed, 0x53,
+	0x81, 0xf6, 0x07, 0x14, 0xfd, 0x05, 0xc5, 0x77, 0xce, 0x99, 0x0b, 0x29, 0x91, 0xd2, 0xc6, 0xce,
+	0xcb, 0x6a, 0xce, 0x77, 0x39, 0xf3, 0x9d, 0xef, 0x7c, 0xf7, 0xe1, 0x82, 0x76, 0xe4, 0xfb, 0x47,
+	0x2e, 0x5d, 0x89, 0x8e, 0xfd, 0x33, 0xcb, 0x8c, 0xe8, 0xca, 0xe9, 0x87, 0x07, 0x94, 0x99, 0x1f,
+	0xae, 0x50, 0xeb, 0xd8, 0x6f, 0x06, 0xa1, 0xcf, 0x7c, 0x32, 0x2f, 0x68, 0x9a, 0x09, 0x4d, 0x53,
+	0xd2, 0x34, 0x5e, 0x93, 0xcc, 0x66, 0xe0, 0xac, 0x98, 0x9e, 0xe7, 0x33, 0x93, 0x39, 0xbe, 0x17,
+	0x09, 0xb6, 0xc6, 0x7c, 0x0e, 0x6b, 0xb9, 0x0e, 0xf5, 0x98, 0x44, 0xbc, 0x91, 0x43, 0x1c, 0x3a,
+	0xd4, 0xb5, 0x8d, 0x03, 0x7a, 0x6c, 0x9e, 0x3a, 0x7e, 0x28, 0x09, 0xee, 0x4b, 0x02, 0xd7, 0xf7,
+	0x8e, 0xc2, 0xd8, 0xf3, 0x1c, 0xef, 0x68, 0xc5, 0x0f, 0x68, 0xd8, 0xb7, 0xfd, 0xeb, 0x92, 0x88,
+	0xaf, 0x0e, 0xe2, 0xc3, 0x15, 0x3b, 0x16, 0x04, 0x12, 0x7f, 0x6f, 0x10, 0x4f, 0x7b, 0x01, 0xbb,
+	0x90, 0xc8, 0xc5, 0x41, 0xa4, 0x90, 0xa3, 0x67, 0x46, 0x27, 0x03, 0x42, 0xa6, 0x14, 0xcc, 0xe9,
+	0xd1, 0x88, 0x99, 0xbd, 0x60, 0xd8, 0xfb, 0xcf, 0x42, 0x33, 0x08, 0x68, 0x38, 0x28, 0x5f, 0x18,
+	0x58, 0x2b, 0x34, 0x0c, 0xfd, 0xd0, 0xb0, 0x29, 0x33, 0x1d, 0x77, 0x50, 0x3d, 0x88, 0x8f, 0x98,
+	0xc9, 0x62, 0x89, 0xd0, 0xfe, 0xa5, 0x02, 0x95, 0x8e, 0x75, 0xec, 0xeb, 0xf4, 0xb3, 0x98, 0x46,
+	0x8c, 0x34, 0x60, 0xd2, 0xf2, 0x3d, 0x46, 0x3d, 0xa6, 0x2a, 0x8b, 0xca, 0x52, 0x79, 0xe3, 0x96,
+	0x9e, 0x00, 0xc8, 0x32, 0x8c, 0xf3, 0xbd, 0xd5, 0xb1, 0x45, 0x65, 0xa9, 0xb2, 0x4a, 0x9a, 0xf2,
+	0xaa, 0xc2, 0xc0, 0x6a, 0xee, 0xf1, 0x4d, 0x37, 0x6e, 0xe9, 0x82, 0x84, 0x3c, 0x86, 0xb9, 0x53,
+	0xd3, 0x75, 0x6c, 0x93, 0x51, 0x43, 0xf2, 0x1b, 0x21, 0x3d, 0xa2, 0xe7, 0x6a, 0x01, 0xb7, 0xd5,
+	0x6f, 0x27, 0xd8, 0xb6, 0x40, 0xea, 0x88, 0x23, 0xdf, 0x87, 0x9a, 0x65, 0x5a, 0xc7, 0x82, 0x25,
+	0xf4, 0x5d, 0xb5, 0xc8, 0xdf, 0xf4, 0x76, 0x73, 0x88, 0x51, 0x34, 0xdb, 0x48, 0xdd, 0x16, 0xc4,
+	0x7a, 0xd5, 0xca, 0xad, 0xc8, 0x77, 0xa0, 0xea, 0xd8, 0x2e, 0x35, 0x50, 0x95, 0x7e, 0xcc, 0xd4,
+	0x71, 0xbe, 0xd5, 0xdd, 0x64, 0xab, 0x44, 0x93, 0xcd, 0x75, 0x79, 0x93, 0x7a, 0x05, 0xc9, 0xf7,
+	0x05, 0x35, 0x79, 0x08, 0xb7, 0x23, 0x16, 0x3a, 0x81, 0x11, 0x7b, 0x27, 0x9e, 0x7f, 0xe6, 0x19,
+	0xfc, 0xce, 0x22, 0x75, 0x62, 0x51, 0x59, 0x2a, 0xe9, 0x84, 0xe3, 0x5e, 0x08, 0xd4, 0x33, 0x8e,
+	0x21, 0xef, 0xc2, 0xb4, 0x30, 0x3c, 0x23, 0x42, 0x5d, 0x7a, 0x16, 0x55, 0x27, 0x17, 0x95, 0xa5,
+	0x82, 0x3e, 0x25, 0xc0, 0x7b, 0x12, 0x4a, 0xde, 0x84, 0x6a, 0x48, 0x03, 0x6a, 0x32, 0xc3, 0xf2,
+	0x63, 0x8f, 0xa9, 0xa5, 0x45, 0x65, 0x69, 0x5c, 0xaf, 0x08, 0x58, 0x1b, 0x41, 0xe4, 0x3e, 0xd4,
+	0xd0, 0x25, 0x0c, 0x93, 0x31, 0x34, 0xa4, 0x48, 0x2d, 0xf3, 0xd7, 0x56, 0x11, 0xd8, 0x92, 0x30,
+	0x72, 0x1b, 0xc6, 0x0f, 0xdd, 0x38, 0x3a, 0x56, 0x81, 0x23, 0xc5, 0x82, 0x3c, 0x85, 0x9a, 0x4d,
+	0xed, 0x38, 0xa0, 0xc6, 0x99, 0xe3, 0xd9, 0xfe, 0x99, 0x5a, 0xb9, 0xee, 0xdc, 0x55, 0x41, 0xff,
+	0x92, 0x93, 0x93, 0x8f, 0xa1, 0x1c, 0x52, 0x53, 0x58, 0xa7, 0x5a, 0xe5, 0xbc, 0x8d, 0x4b, 0xbc,
+	0xfc, 0xc8, 0x5b, 0x66, 0x74, 0xa2, 0x97, 0x90, 0x18, 0x9f, 0xc8, 0x47, 0x30, 0x7f, 0x6c, 0x7e,
+	0x6e, 0x86, 0xb6, 0x1f, 0x47, 0x86, 0xb0, 0xc1, 0x1e, 0x8d, 0x22, 0xf3, 0x88, 0xaa, 0x35, 0x2e,
+	0xe0, 0x9d, 0x14, 0xdd, 0x41, 0xec, 0x96, 0x40, 0x92, 0x65, 0x98, 0xc1, 0xdb, 0x76, 0xbc, 0x98,
+	0x1a, 0xbe, 0x27, 0x38, 0xd5, 0x29, 0xce, 0x31, 0x9d, 0x20, 0x76, 0x3c, 0xce, 0x42, 0xee, 0x42,
+	0xc9, 0xb4, 0x4e, 0x8c, 0x9e, 0x6f, 0x53, 0x75, 0x9a, 0x93, 0x4c, 0x9a, 0xd6, 0xc9, 0x96, 0x6f,
+	0x53, 0xf2, 0x06, 0x54, 0x7a, 0xe6, 0xb9, 0x11, 0xd2, 0x88, 0x7a, 0x76, 0xa4, 0xd6, 0xb9, 0x52,
+	0xa1, 0x67, 0x9e, 0xeb, 0x02, 0x42, 0x56, 0xa1, 0x60, 0x5a, 0x27, 0xea, 0x0c, 0x3f, 0xd2, 0xe2,
+	0x70, 0x8b, 0x3a, 0x36, 0x59, 0xcb, 0x3a, 0xd1, 0x91, 0x98, 0x6c, 0x43, 0x89, 0x85, 0xa6, 0xe3,
+	0xd2, 0x30, 0x52, 0xc9, 0x62, 0x61, 0xa9, 0xb2, 0xba, 0x3a, 0x94, 0x31, 0xe7, 0x45, 0xcd, 0x7d,
+	0xc9, 0xd4, 0xf1, 0x58, 0x78, 0xa1, 0xa7, 0x7b, 0xf0, 0x7b, 0xe5, 0x9a, 0x89, 0xe2, 0x5e, 0xcf,
+	0x0c, 0x2f, 0xd4, 0x59, 0x79, 0xaf, 0x08, 0xdc, 0x13, 0x30, 0x74, 0x1d, 0xc7, 0xb3, 0xdc, 0xd8,
+	0xa6, 0x06, 0x0b, 0x4d, 0x2f, 0x0a, 0xfc, 0x90, 0x19, 0x8e, 0x77, 0xe8, 0xab, 0xb7, 0x39, 0xf5,
+	0x6d, 0x89, 0xdd, 0x4f, 0x90, 0x5d, 0xef, 0xd0, 0x27, 0x4f, 0x61, 0x46, 0x6c, 0x6d, 0x1e, 0x32,
+	0x1a, 0x1a, 0x96, 0xeb, 0x47, 0x54, 0xbd, 0x33, 0xcc, 0x51, 0xf5, 0x69, 0x4e, 0xdc, 0x42, 0xda,
+	0x36, 0x92, 0x92, 0x8f, 0xa1, 0x94, 0xda, 0xed, 0x1c, 0x67, 0xbb, 0x77, 0xe9, 0xda, 0xbb, 0x1e,
+	0xfb, 0xe8, 0xf1, 0xa7, 0xa6, 0x1b, 0x53, 0x3d, 0x25, 0x26, 0x1f, 0x00, 0x09, 0xe9, 0x67, 0xb1,
+	0x13, 0x0a, 0xaf, 0x75, 0x8e, 0x62, 0x3f, 0x8e, 0xd4, 0x79, 0x2e, 0xea, 0x8c, 0xc4, 0xb4, 0x53,
+	0x04, 0xaa, 0xe0, 0xd0, 0x0f, 0xcf, 0xcc, 0xd0, 0x36, 0x6c, 0x1a, 0xb0, 0x63, 0x55, 0xe5, 0x37,
+	0x55, 0x95, 0xc0, 0x75, 0x84, 0x91, 0x26, 0xcc, 0x1e, 0x9a, 0x8e, 0x6b, 0x1c, 0x3a, 0x61, 0xc4,
+	0x32, 0x2f, 0xb8, 0xcb, 0x49, 0x67, 0x10, 0xf5, 0x0c, 0x31, 0xa9, 0x2b, 0x2c, 0x00, 0x84, 0x42,
+	0xf5, 0x86, 0x63, 0xab, 0x0d, 0x1e, 0x61, 0xca, 0x12, 0xd2, 0xb5, 0xc9, 0x2a, 0xdc, 0xb1, 0xfc,
+	0x30, 0x8c, 0x03, 0x66, 0xc4, 0xec, 0xf0, 0x13, 0x34, 0x92, 0xc0, 0xf7, 0x22, 0xaa, 0xde, 0xe3,
+	0x52, 0xce, 0x4a, 0xe4, 0x0b, 0x76, 0xf8, 0x89, 0x2e, 0x51, 0xc8, 0x23, 0xec, 0xc9, 0xa2, 0xce,
+	0x29, 0xb5, 0x13, 0x5b, 0x8e, 0xd4, 0xd7, 0xb8, 0x10, 0xb3, 0xdc, 0xb2, 0x04, 0x4e, 0x5a, 0x72,
+	0x84, 0xa6, 0x8c, 0x3c, 0x11, 0x06, 0x81, 0x94, 0x7e, 0x81, 0xd3, 0x4f, 0xf7, 0xcc, 0xf3, 0x3d,
+	0xea, 0xb1, 0x84, 0xb6, 0xf1, 0x6d, 0xa8, 0xf5, 0x59, 0x09, 0xa9, 0x43, 0xe1, 0x84, 0x5e, 0x88,
+	0xa8, 0xab, 0xe3, 0x23, 0x3a, 0xf8, 0x29, 0x2a, 0x9b, 0xc7, 0xdb, 0xb2, 0x2e, 0x16, 0x4f, 0xc6,
+	0x3e, 0x51, 0xd6, 0x00, 0x4a, 0xc9, 0x19, 0xb4, 0xdf, 0x85, 0x49, 0x69, 0xb3, 0x18, 0x82, 0x4c,
+	0xeb, 0x84, 0xda, 0x69, 0x04, 0x8a, 0x54, 0x65, 0xb1, 0x80, 0x21, 0x88, 0x83, 0x93, 0x08, 0x14,
+	0x91, 0x07, 0x50, 0xf7, 0x06, 0x29, 0xc7, 0x38, 0xe5, 0xb4, 0xd7, 0x4f, 0xaa, 0xad, 0x41, 0x35,
+	0x1f, 0x64, 0xc9, 0x3c, 0x4c, 0xe2, 0x19, 0xd1, 0xad, 0x15, 0x7e, 0xb2, 0x89, 0x9e, 0x79, 0xde,
+	0x3a, 0xa2, 0xe8, 0x9b, 0x9e, 0x6f, 0x44, 0xcc, 0x0f, 0x85, 0xc0, 0x25, 0x7d, 0xd2, 0xf3, 0xf7,
+	0x70, 0xa9, 0x7d, 0x35, 0x09, 0x55, 0xe1, 0x1e, 0x52, 0xb9, 0xea, 0x40, 0x96, 0xc9, 0x72, 0xcc,
+	0x1c, 0x4c, 0xb8, 0xbe, 0x65, 0xba, 0xc9, 0xa1, 0xe5, 0xea, 0xaa, 0xe8, 0x5a, 0xb8, 0x32, 0xba,
+	0xbe, 0x0b, 0xd3, 0x11, 0x0d, 0x4f, 0x69, 0x98, 0x11, 0x16, 0x05, 0xa1, 0x00, 0xe7, 0xc3, 0xb0,
+	0x13, 0x19, 0xc7, 0xd4, 0x0c, 0xd9, 0x01, 0x35, 0x45, 0x7e, 0x28, 0xe9, 0x15, 0x27, 0xda, 0x48,
+	0x40, 0xa8, 0x26, 0x11, 0x95, 0xa9, 0x9d, 0x24, 0x31, 0x75, 0x62, 0xb1, 0xb0, 0x54, 0xd6, 0xa7,
+	0x13, 0xb8, 0x4c, 0x5f, 0x68, 0x2e, 0x41, 0x48, 0x4f, 0x1d, 0x0c, 0x7e, 0x61, 0x60, 0x65, 0x36,
+	0x2b, 0x72, 0xc0, 0x6c, 0x82, 0xd4, 0x03, 0x2b, 0xb5, 0xda, 0xb7, 0x41, 0x0a, 0x9f, 0x50, 0xf3,
+	0x54, 0x50, 0xd0, 0x6b, 0x02, 0x2a, 0xe9, 0xd0, 0xaa, 0xb8, 0xe8, 0xb6, 0x71, 0x18, 0xfa, 0x3d,
+	0x83, 0x27, 0x39, 0x99, 0x10, 0xc4, 0x51, 0xed, 0x67, 0xa1, 0xdf, 0xe3, 0x97, 0x84, 0x26, 0xe3,
+	0x78, 0x36, 0x3d, 0xe7, 0x39, 0xa1, 0xa0, 0x8b, 0x05, 0xba, 0x87, 0x13, 0xa5, 0x31, 0xa7, 0xc2,
+	0x59, 0xcb, 0x4e, 0x94, 0x04, 0x9c, 0xfb, 0x50, 0x93, 0xd6, 0x2a, 0x33, 0x52, 0x95, 0x33, 0x57,
+	0x25, 0x50, 0xa4, 0xa4, 0x06, 0x94, 0xac, 0x63, 0x6a, 0x9d, 0x44, 0x71, 0x8f, 0xc7, 0xf3, 0x9a,
+	0x9e, 0xae, 0x89, 0x0e, 0x75, 0xcb, 0x77, 0x5d, 0x6a, 0x31, 0x03, 0x7d, 0x33, 0x0e, 0x69, 0xa4,
+	0x4e, 0xf1, 0x70, 0xf9, 0xee, 0xf0, 0x38, 0x2b, 0x18, 0x9e, 0x09, 0x7a, 0x0c, 0xf5, 0xf9, 0x75,
+	0x84, 0xd7, 0x83, 0xa1, 0x3e, 0xbd, 0xc4, 0x69, 0x2e, 0x53, 0xc5, 0xb4, 0x4e, 0xfa, 0x13, 0x29,
+	0x06, 0x77, 0x29, 0x76, 0x3d, 0x49, 0xa4, 0x08, 0x13, 0x52, 0x2f, 0x00, 0x44, 0x34, 0x8a, 0x1c,
+	0xdf, 0xc3, 0xc0, 0x30, 0x23, 0x02, 0x83, 0x84, 0x74, 0x6d, 0x8c, 0x5d, 0x96, 0xdf, 0x0b, 0x42,
+	0x1a, 0x45, 0xd4, 0x36, 0x1c, 0xcf, 0x76, 0x2c, 0x2a, 0x22, 0x7d, 0x41, 0x9f, 0xc9, 0x30, 0x5d,
+	0x81, 0x20, 0x5b, 0x30, 0x35, 0x10, 0x91, 0x67, 0x79, 0xa4, 0x7c, 0x67, 0xe8, 0x29, 0xfb, 0x62,
+	0xb4, 0x5e, 0x63, 0xf9, 0x25, 0xea, 0xfd, 0xb3, 0xd8, 0x67, 0xa6, 0x11, 0x84, 0xfe, 0xef, 0x51,
+	0x8b, 0xf1, 0xf8, 0x5e, 0xd6, 0xab, 0x1c, 0xb8, 0x2b, 0x60, 0xe4, 0x19, 0x24, 0xa1, 0xd1, 0x38,
+	0xf6, 0x83, 0x48, 0xbd, 0xc3, 0xf5, 0x7a, 0x7f, 0xe8, 0x1b, 0x9f, 0x09, 0xe2, 0x0d, 0x3f, 0xd0,
+	0x2b, 0x87, 0xe9, 0x33, 0x8f, 0xbb, 0xae, 0xd3, 0x73, 0xb0, 0x0a, 0x43, 0x4b, 0xb1, 0x79, 0x90,
+	0x2f, 0xe9, 0x55, 0x0e, 0xd4, 0x05, 0x4c, 0xfb, 0x5f, 0x05, 0x20, 0xdb, 0x00, 0x43, 0xd2, 0xb1,
+	0x1f, 0x48, 0x3f, 0xc7, 0x47, 0xb2, 0x81, 0xc1, 0xbe, 0x67, 0x3a, 0x58, 0x25, 0x1b, 0x36, 0x35,
+	0x6d, 0xd7, 0xf1, 0xa8, 0x3a, 0x76, 0x5d, 0x89, 0x31, 0x93, 0x32, 0xad, 0x4b, 0x1e, 0xf2, 0x7d,
+	0x98, 0x3c, 0xa6, 0xa6, 0x8d, 0x99, 0xb5, 0xc0, 0x8f, 0xf4, 0xf0, 0x06, 0x47, 0x6a, 0x6e, 0x08,
+	0x16, 0x91, 0x57, 0x93, 0x0d, 0x1a, 0x4f, 0xa0, 0x9a, 0x47, 0xbc, 0x4a, 0x28, 0xd5, 0xfe, 0x58,
+	0xe1, 0x81, 0x38, 0x77, 0x2d, 0x0b, 0x00, 0x71, 0x44, 0x43, 0x0c, 0x71, 0x69, 0x7c, 0x2a, 0x23,
+	0xa4, 0x85, 0x00, 0xb4, 0xba, 0xa4, 0xa0, 0x65, 0x17, 0x41, 0xb2, 0x63, 0x45, 0xc2, 0xf6, 0x2f,
+	0x02, 0x8a, 0xbe, 0xc2, 0x75, 0x60, 0xf9, 0xae, 0x2c, 0x77, 0xd3, 0x35, 0x06, 0x38, 0xd3, 0xb2,
+	0x68, 0xc0, 0x78, 0x58, 0x2a, 0xeb, 0x72, 0xa5, 0xed, 0xc2, 0x54, 0xbf, 0x4b, 0x64, 0xbe, 0xac,
+	0xe4, 0x7d, 0x79, 0xe9, 0xda, 0x22, 0x5c, 0x96, 0xe0, 0xda, 0xdf, 0x4f, 0x40, 0xad, 0x73, 0x1e,
+	0x98, 0x9e, 0x9d, 0x14, 0xf7, 0xc3, 0xc3, 0xee, 0x8d, 0x77, 0xc5, 0x3a, 0xcb, 0xf2, 0xc3, 0x20,
+	0x8e, 0x0c, 0xcf, 0xec, 0x51, 0x79, 0x3c, 0x10, 0xa0, 0x6d, 0xb3, 0x77, 0xb9, 0xbc, 0x2d, 0x5e,
+	0x2e, 0x6f, 0x9f, 0x66, 0x01, 0xc7, 0xa6, 0xae, 0x79, 0x71, 0x7d, 0x6d, 0x9e, 0xc4, 0xa2, 0x75,
+	0x24, 0x47, 0x2b, 0x4c, 0xe3, 0xb6, 0xe1, 0x78, 0x8c, 0x86, 0xa7, 0xa6, 0xab, 0x4e, 0x5c, 0xb7,
+	0xc9, 0x4c, 0xca, 0xd4, 0x95, 0x3c, 0x28, 0xec, 0x99, 0xc3, 0x8e, 0xd3, 0xd8, 0x38, 0x29, 0x92,
+	0x00, 0xc2, 0x92, 0xe8, 0xf8, 0x26, 0x54, 0x23, 0xe7, 0x73, 0x6a, 0x04, 0x26, 0x63, 0x34, 0xf4,
+	0xd4, 0xd2, 0x62, 0x01, 0xcf, 0x83, 0xb0, 0x5d, 0x01, 0xba, 0x1c, 0x40, 0xcb, 0xa2, 0xa6, 0xe9,
+	0x0b, 0xa0, 0xbb, 0xb9, 0x5a, 0x12, 0xb8, 0xc5, 0x3f, 0x1e, 0x5e, 0x4b, 0xe6, 0xaf, 0xed, 0xe6,
+	0xd5, 0x64, 0xe5, 0x8a, 0x6a, 0x92, 0x97, 0x67, 0x3c, 0x60, 0x25, 0xf1, 0xcc, 0xf1, 0x3d, 0xb5,
+	0x9a, 0x94, 0x67, 0x88, 0x69, 0x67, 0x08, 0x72, 0x0f, 0xca, 0x11, 0x0b, 0xa9, 0xd9, 0xc3, 0x78,
+	0x59, 0x13, 0xb6, 0x2b, 0x00, 0x5d, 0x1b, 0xef, 0xfe, 0x20, 0xc6, 0x8a, 0x4c, 0x9c, 0x72, 0x4a,
+	0xd4, 0xd8, 0x1c, 0x24, 0xce, 0xb8, 0x0e, 0x75, 0x16, 0x3a, 0xd6, 0x89, 0x4b, 0xb3, 0x6b, 0x99,
+	0xbe, 0xee, 0x5a, 0xa6, 0x25, 0x4b, 0x7a, 0x29, 0x4d, 0x98, 0xa5, 0x18, 0xa9, 0x78, 0x0f, 0x9d,
+	0x15, 0x52, 0x22, 0xbc, 0xcf, 0x20, 0xaa, 0x83, 0x98, 0x6f, 0xa4, 0x94, 0xd2, 0xfe, 0x4e, 0x01,
+	0xb2, 0x6b, 0x1e, 0x51, 0xbb, 0xdf, 0x55, 0x16, 0x06, 0x5c, 0x65, 0xad, 0xf0, 0x5f, 0xad, 0xb1,
+	0xcc, 0x5f, 0xee, 0x41, 0x39, 0xc0, 0xeb, 0x46, 0x2b, 0xe0, 0x7b, 0x8e, 0xeb, 0x25, 0x04, 0xec,
+	0x39, 0x9f, 0x53, 0x0c, 0x20, 0x1c, 0xc9, 0xfc, 0x13, 0xea, 0x49, 0x0f, 0xe1, 0xe4, 0xfb, 0x08,
+	0xc0, 0x42, 0xc9, 0x0f, 0x6d, 0x1a, 0x1a, 0x07, 0x17, 0x32, 0x06, 0x4c, 0xf2, 0xf5, 0xda, 0x05,
+	0x06, 0x87, 0x43, 0xc7, 0x65, 0x34, 0xe4, 0x1e, 0x51, 0xd6, 0xe5, 0x4a, 0xfb, 0xa9, 0x02, 0xb3,
+	0x7d, 0x42, 0xca, 0x3a, 0xaa, 0x8d, 0xcd, 0x9a, 0x78, 0x16, 0xa5, 0xde, 0xa8, 0x5e, 0x39, 0x5f,
+	0x81, 0xe9, 0x19, 0x1f, 0x79, 0x07, 0xa6, 0x3d, 0x7a, 0xce, 0x8c, 0x9c, 0xcc, 0x42, 0x4b, 0x35,
+	0x04, 0xef, 0x26, 0x72, 0x6b, 0x5f, 0x16, 0xa1, 0xf2, 0xd2, 0x74, 0x58, 0xa2, 0xa2, 0x8f, 0xa1,
+	0x84, 0xb9, 0x17, 0xfb, 0x6b, 0x55, 0x19, 0xd2, 0x28, 0xee, 0x27, 0x73, 0x0c, 0x9c, 0x23, 0x50,
+	0xcf, 0xc6, 0x35, 0xf9, 0x00, 0x0a, 0x8c, 0x25, 0xbd, 0xfd, 0x70, 0xc3, 0xd8, 0xb8, 0xa5, 0x23,
+	0xdd, 0x4d, 0xc6, 0x0e, 0x4a, 0x12, 0x9d, 0x5a, 0x30, 0x19, 0xc5, 0x96, 0x45, 0xa3, 0x88, 0xeb,
+	0x7d, 0x94, 0x3a, 0xc4, 0x51, 0x84, 0x12, 0x36, 0x14, 0x3d, 0xe1, 0x43, 0xeb, 0x4b, 0x9a, 0x85,
+	0x90, 0x46, 0xb1, 0x2b, 0xc3, 0xbc, 0x28, 0x0f, 0x67, 0x24, 0x4a, 0xe7, 0x18, 0x1e, 0xec, 0x1f,
+	0xc2, 0xed, 0x01, 0xfa, 0x83, 0x0b, 0x46, 0xd3, 0x49, 0x41, 0x1f, 0xc3, 0x1a, 0x62, 0x48, 0x0b,
+	0x20, 0xf0, 0x5d, 0xd7, 0xe0, 0x79, 0x9e, 0x87, 0x9c, 0xca, 0xaa, 0x36, 0x54, 0xce, 0x5d, 0xdf,
+	0x75, 0x7f, 0x80, 0x94, 0x7a, 0x39, 0x48, 0x1e, 0x31, 0x28, 0xa5, 0x33, 0x2a, 0xf4, 0xd4, 0x92,
+	0x48, 0x42, 0x29, 0xac, 0x6b, 0x93, 0x1d, 0x98, 0x0e, 0xcc, 0x90, 0x39, 0xa6, 0x2b, 0xe5, 0xc2,
+	0x29, 0x42, 0x61, 0x64, 0xb5, 0xb2, 0x2b, 0xe8, 0x85, 0xac, 0xfa, 0x54, 0x90, 0x5f, 0x46, 0x6b,
+	0xe3, 0x50, 0xa0, 0x9e, 0xdd, 0xd7, 0x7b, 0xfc, 0x87, 0x02, 0xb5, 0x3e, 0x26, 0xd2, 0x86, 0x29,
+	0xf3, 0xd4, 0x74, 0x5c, 0xf3, 0xc0, 0xa5, 0x37, 0x37, 0x8d, 0x5a, 0xca, 0xc3, 0x0d, 0xe4, 0x11,
+	0x4c, 0xf8, 0x87, 0x87, 0x11, 0x65, 0xd7, 0x56, 0x16, 0x1b, 0xb7, 0x74, 0x49, 0x4a, 0x5a, 0x99,
+	0x5c, 0xaf, 0x74, 0xf7, 0x7a, 0xca, 0xb6, 0x56, 0x81, 0x72, 0x2a, 0x88, 0x16, 0x42, 0x39, 0x55,
+	0x3d, 0xfa, 0x3b, 0x76, 0x3d, 0x78, 0x01, 0x91, 0xac, 0x87, 0x4a, 0x3d, 0xf3, 0x1c, 0x09, 0x22,
+	0x51, 0x14, 0x05, 0x2e, 0xf5, 0x9c, 0xe8, 0x38, 0x8b, 0x7b, 0x37, 0x29, 0x8a, 0x24, 0x53, 0x12,
+	0xf9, 0xb4, 0x25, 0xa8, 0xe6, 0x45, 0x1b, 0x9e, 0xb0, 0xb5, 0x3f, 0x1f, 0x13, 0xa4, 0x5b, 0x94,
+	0x99, 0xb6, 0xc9, 0x4c, 0xf2, 0xad, 0x57, 0xf1, 0xc6, 0xcc, 0x17, 0x77, 0xa1, 0x9e, 0xb3, 0x12,
+	0xa1, 0xbd, 0xb1, 0x57, 0xd1, 0xde, 0x74, 0x66, 0x25, 0x42, 0xe6, 0xfb, 0x50, 0x4b, 0x76, 0x14,
+	0x69, 0xa2, 0x20, 0x92, 0xa1, 0x04, 0x8a, 0x44, 0xf1, 0x6d, 0xa8, 0x58, 0x21, 0xc5, 0xe1, 0x20,
+	0x17, 0xb8, 0x78, 0xad, 0xc0, 0x20, 0xc8, 0xb9, 0xcc, 0x04, 0x8a, 0xa7, 0x34, 0x3c, 0x90, 0x31,
+	0x92, 0x3f, 0x6b, 0xff, 0x5a, 0x84, 0x06, 0x16, 0x4e, 0x18, 0xe4, 0x5e, 0x3a, 0xec, 0x78, 0x5d,
+	0x8c, 0x3f, 0x93, 0x58, 0xf5, 0x41, 0x12, 0x43, 0x94, 0x61, 0x31, 0x44, 0x04, 0x78, 0x19, 0x46,
+	0x7e, 0x08, 0x93, 0x72, 0x7e, 0xca, 0xdb, 0xe2, 0xa9, 0xd5, 0xa7, 0xc3, 0x8b, 0xd3, 0xa1, 0x2f,
+	0x6d, 0x8a, 0x25, 0x06, 0x09, 0x3d, 0xd9, 0x2e, 0xd7, 0xdf, 0x16, 0xfa, 0xfa, 0xdb, 0xf7, 0x60,
+	0x86, 0x3f, 0x39, 0x9f, 0x67, 0xb3, 0x06, 0x99, 0x1d, 0xea, 0x29, 0x22, 0x19, 0x99, 0xbd, 0x07,
+	0xe3, 0xae, 0xe3, 0x9d, 0x44, 0xea, 0x38, 0x77, 0xe8, 0x3b, 0xf9, 0xd3, 0x6c, 0x50, 0x37, 0x68,
+	0x6e, 0x3a, 0xde, 0x89, 0x2e, 0x68, 0xc8, 0x16, 0xd4, 0x45, 0x97, 0x71, 0xea, 0xf8, 0xae, 0x18,
+	0x6a, 0xf3, 0x26, 0x36, 0x17, 0x73, 0x90, 0x8f, 0xdb, 0xb9, 0x2c, 0x3d, 0x9b, 0x9f, 0x26, 0xa4,
+	0xfa, 0x34, 0xe7, 0x4d, 0xd7, 0x11, 0x39, 0x80, 0xf9, 0x20, 0xa4, 0x96, 0xef, 0xd9, 0x0e, 0x0f,
+	0x3e, 0xb9, 0x5d, 0x27, 0xf9, 0xae, 0x0f, 0xf2, 0xbb, 0xee, 0xe6, 0x48, 0x2f, 0x6f, 0x3e, 0x97,
+	0xdf, 0x29, 0x7b, 0x87, 0x76, 0x06, 0x90, 0xe9, 0x8e, 0xdc, 0x83, 0xf9, 0xf5, 0xce, 0x7e, 0xab,
+	0xbb, 0x69, 0xec, 0xff, 0x68, 0xb7, 0x63, 0xbc, 0xd8, 0xde, 0xdb, 0xed, 0xb4, 0xbb, 0xcf, 0xba,
+	0x9d, 0xf5, 0xfa, 0x2d, 0x72, 0x07, 0x66, 0x36, 0x77, 0xda, 0xad, 0xcd, 0xee, 0x8f, 0x3b, 0xeb,
+	0xc6, 0x56, 0x67, 0x6f, 0xaf, 0xf5, 0xbc, 0x53, 0x57, 0x48, 0x09, 0x8a, 0x1b, 0x9d, 0xcd, 0xdd,
+	0xfa, 0x18, 0x99, 0x81, 0xda, 0x0f, 0x5e, 0xec, 0xec, 0xb7, 0x8c, 0x67, 0xad, 0xee, 0xe6, 0x0b,
+	0xbd, 0x53, 0x2f, 0x10, 0x15, 0x6e, 0xef, 0xea, 0x9d, 0xf6, 0xce, 0xf6, 0x7a, 0x77, 0xbf, 0xbb,
+	0xb3, 0x9d, 0x62, 0x8a, 0xda, 0x23, 0xb8, 0xdb, 0xf5, 0xa2, 0x80, 0x5a, 0xac, 0x1d, 0x52, 0x9b,
+	0x7a, 0x68, 0xb0, 0xa9, 0x0d, 0xcd, 0xc1, 0x44, 0x84, 0xa5, 0x8a, 0xf0, 0xc5, 0x92, 0x2e, 0x57,
+	0xda, 0xff, 0x29, 0xd0, 0xb8, 0x8a, 0x4b, 0xfa, 0xc3, 0x4f, 0xb8, 0xa9, 0x27, 0x60, 0x99, 0xa5,
+	0x87, 0xdb, 0xd3, 0xf0, 0x9d, 0x9a, 0x19, 0x4c, 0xcf, 0x6f, 0x89, 0xed, 0xc6, 0x99, 0x19, 0x62,
+	0x77, 0x25, 0xcc, 0xb5, 0xac, 0xa7, 0xeb, 0xc6, 0xa7, 0x00, 0x19, 0xdb, 0x15, 0x85, 0xd1, 0x1c,
+	0x4c, 0xf0, 0x5a, 0x28, 0xe1, 0x94, 0x2b, 0xf2, 0x3a, 0x80, 0x1d, 0x07, 0xae, 0x63, 0x99, 0x8c,
+	0xda, 0xdc, 0x56, 0x4b, 0x7a, 0x0e, 0xa2, 0xfd, 0x9b, 0x02, 0xd3, 0x3a, 0x35, 0xed, 0x35, 0xd7,
+	0x3f, 0xc8, 0x6a, 0x26, 0x60, 0x3e, 0x33, 0x5d, 0x51, 0x15, 0x89, 0xae, 0xa5, 0xcc, 0x21, 0xbc,
+	0x2c, 0x7a, 0x03, 0x2a, 0x7c, 0xb2, 0x9c, 0x0b, 0xed, 0x05, 0x1d, 0x10, 0xb4, 0xc3, 0x21, 0x62,
+	0x8a, 0x67, 0xda, 0x06, 0x6f, 0x49, 0xe5, 0x78, 0x87, 0x0f, 0xa3, 0x37, 0x11, 0x80, 0x68, 0xeb,
+	0x38, 0xf6, 0x4e, 0xc4, 0xf6, 0xa2, 0xad, 0x28, 0x73, 0x08, 0xdf, 0x9e, 0x40, 0x31, 0xa2, 0xd4,
+	0xe6, 0x51, 0xa1, 0xa0, 0xf3, 0x67, 0xb2, 0x04, 0x75, 0x3e, 0x47, 0x14, 0x33, 0xd1, 0x2c, 0x2f,
+	0x17, 0xf4, 0x29, 0x84, 0xf3, 0xf1, 0x27, 0xcf, 0xc9, 0x9a, 0x0b, 0xf5, 0xec, 0x38, 0xf2, 0xe6,
+	0x08, 0x14, 0x31, 0xb4, 0xf2, 0x93, 0x54, 0x75, 0xfe, 0x8c, 0xfa, 0xea, 0x93, 0x5f, 0xae, 0x10,
+	0x6e, 0x85, 0xd6, 0xa3, 0x55, 0x8b, 0xcb, 0x5d, 0xd3, 0xe5, 0x8a, 0x0f, 0xe9, 0x1d, 0xcf, 0x14,
+	0xd5, 0x4e, 0x49, 0x17, 0x0b, 0xed, 0xab, 0x31, 0xa8, 0xbf, 0x0c, 0x1d, 0x46, 0xf3, 0xea, 0x5b,
+	0x87, 0x22, 0x5e, 0xbd, 0x0c, 0x51, 0xcd, 0xe1, 0xe1, 0x77, 0x80, 0xb1, 0xb9, 0x17, 0x50, 0x6b,
+	0xe3, 0x96, 0xce, 0xb9, 0xc9, 0x73, 0x18, 0xe7, 0x3a, 0x91, 0x51, 0x7c, 0xe5, 0xe6, 0xdb, 0xb4,
+	0x91, 0x0d, 0xbf, 0xe0, 0x70, 0xfe, 0x46, 0x1b, 0x8a, 0xb8, 0x31, 0x79, 0x0d, 0x26, 0x0f, 0x5c,
+	0xff, 0x00, 0xab, 0x8c, 0x5c, 0x25, 0x3c, 0x81, 0xb0, 0xae, 0x3d, 0x70, 0xe7, 0x63, 0x03, 0x77,
+	0xde, 0x78, 0x04, 0xe3, 0x7c, 0xdb, 0x9c, 0xde, 0x94, 0x3e, 0xbd, 0x25, 0x3a, 0x1e, 0xcb, 0x74,
+	0xbc, 0x56, 0x86, 0x49, 0x39, 0xbb, 0xc5, 0xee, 0x7c, 0x26, 0x27, 0xa8, 0xbc, 0x98, 0xf9, 0x01,
+	0x91, 0x52, 0x69, 0xee, 0x43, 0x2d, 0x1d, 0xd8, 0xe6, 0x04, 0xaa, 0x26, 0x40, 0x6e, 0x28, 0xc3,
+	0xae, 0x0a, 0x27, 0x5c, 0x7e, 0x2f, 0x70, 0x29, 0xa3, 0xf2, 0xb6, 0xd2, 0xb5, 0xf6, 0x2d, 0xb8,
+	0xf3, 0x9c, 0x32, 0x2e, 0x89, 0x6c, 0x87, 0xe5, 0xa5, 0x8d, 0xd4, 0x8e, 0xf6, 0x33, 0x05, 0x2a,
+	0x39, 0xa6, 0xe1, 0x82, 0xe3, 0x28, 0xd0, 0xef, 0xf5, 0x1c, 0xc6, 0xfa, 0x25, 0xaf, 0xa5, 0xd0,
+	0xa4, 0xb3, 0xc8, 0x69, 0xbb, 0x30, 0xe8, 0x61, 0xa3, 0x4e, 0xf0, 0x14, 0x1a, 0xcf, 0x29, 0xdb,
+	0x34, 0x23, 0x26, 0x7a, 0x88, 0xfe, 0x63, 0x2c, 0xe6, 0xdb, 0xbe, 0xdc, 0x41, 0xd2, 0xde, 0x4f,
+	0xfb, 0xc7, 0x31, 0xa8, 0xe6, 0x39, 0xc9, 0xbd, 0x4b, 0x2c, 0x19, 0x75, 0xae, 0x23, 0x8e, 0xf8,
+	0x38, 0x3c, 0xb9, 0x88, 0x04, 0x88, 0xa3, 0x70, 0x3c, 0x0d, 0x77, 0x49, 0x41, 0x21, 0x4f, 0xc3,
+	0x21, 0x1c, 0xbd, 0x07, 0x15, 0x46, 0xc3, 0x9e, 0xe3, 0xf1, 0xac, 0xc0, 0x0f, 0x34, 0xb5, 0xfa,
+	0xe1, 0x35, 0x3d, 0xb3, 0x10, 0xae, 0xb9, 0x9f, 0x31, 0xea, 0xf9, 0x5d, 0xb4, 0x13, 0xa8, 0xe4,
+	0x70, 0x98, 0x5b, 0xf6, 0x3b, 0xfa, 0x56, 0x77, 0xbb, 0xc5, 0x33, 0x41, 0x7f, 0x6e, 0xa9, 0x41,
+	0xb9, 0xbd, 0xb3, 0xb5, 0xbb, 0xd9, 0xd9, 0xef, 0xac, 0xd7, 0x15, 0x02, 0x30, 0x81, 0x99, 0xa2,
+	0xb3, 0x5e, 0x1f, 0xe3, 0xa8, 0xd6, 0x76, 0xbb, 0xb3, 0x89, 0xcb, 0x02, 0x66, 0xa1, 0xf5, 0x4e,
+	0x6b, 0x7d, 0xb3, 0xbb, 0xdd, 0x31, 0x3a, 0x3f, 0x6c, 0x77, 0x3a, 0xeb, 0x9d, 0xf5, 0x7a, 0x51,
+	0x7b, 0x0c, 0x77, 0xdb, 0xbc, 0x6c, 0x91, 0xad, 0x97, 0x1f, 0x87, 0x16, 0x4d, 0x54, 0x3e, 0x0f,
+	0x45, 0x3e, 0x41, 0xc9, 0x69, 0x9b, 0x03, 0x34, 0x0d, 0xaa, 0x79, 0x7a, 0x74, 0x91, 0x8c, 0x50,
+	0xd2, 0xf4, 0x60, 0xee, 0x39, 0x65, 0xaf, 0xb2, 0x2d, 0x79, 0x02, 0x77, 0x63, 0x2f, 0xab, 0xcd,
+	0x63, 0x8f, 0x39, 0xae, 0x21, 0xaa, 0x2a, 0x5b, 0x0e, 0xec, 0xe7, 0x73, 0x04, 0x2f, 0x10, 0x2f,
+	0xa4, 0xb7, 0xf1, 0x20, 0xeb, 0x14, 0xcd, 0xe8, 0x95, 0x0e, 0xb2, 0x0f, 0xf5, 0x35, 0x93, 0x59,
+	0xc7, 0xf9, 0xef, 0xcb, 0xdf, 0xc3, 0x2a, 0x9d, 0x3f, 0x26, 0xa9, 0xf0, 0xad, 0x9b, 0x7c, 0x51,
+	0xd3, 0x53, 0x2e, 0xed, 0x25, 0xcc, 0xe4, 0x76, 0x95, 0x11, 0x61, 0x0d, 0x43, 0x86, 0x68, 0x72,
+	0xc4, 0xae, 0x4b, 0x43, 0x77, 0xcd, 0x33, 0x63, 0x9b, 0x93, 0x30, 0x6a, 0xbf, 0x54, 0x60, 0x7a,
+	0x00, 0x49, 0xda, 0xb9, 0xa6, 0x42, 0xb9, 0xa6, 0x2c, 0xce, 0x0b, 0xb4, 0x71, 0x2b, 0x6b, 0x2b,
+	0x5e, 0xe5, 0xbb, 0xf9, 0x5a, 0x09, 0x26, 0x84, 0x3c, 0xda, 0x21, 0xcc, 0xea, 0x94, 0xc5, 0xa1,
+	0xd7, 0xef, 0xa9, 0x04, 0x8a, 0x96, 0x6f, 0x0b, 0x69, 0xc6, 0x75, 0xfe, 0x8c, 0x6d, 0x42, 0x52,
+	0x32, 0x8a, 0xce, 0x3d, 0x59, 0xa6, 0xf3, 0xad, 0xa4, 0x9a, 0x2d, 0x64, 0xf3, 0x2d, 0x59, 0xac,
+	0x6a, 0x7f, 0xa6, 0xc0, 0xec, 0x1e, 0xf7, 0xdb, 0xdf, 0xee, 0x8b, 0x2e, 0x4f, 0xc9, 0x8a, 0x97,
+	0xa7, 0x64, 0xda, 0x27, 0xb0, 0x20, 0x84, 0xd9, 0x49, 0x5a, 0xd9, 0x17, 0x81, 0x6d, 0x32, 0x1a,
+	0x5d, 0x6b, 0x6d, 0xbb, 0x40, 0x76, 0xe3, 0x03, 0xd7, 0x89, 0xfa, 0xec, 0xed, 0x2e, 0x8c, 0x33,
+	0x3f, 0x70, 0xac, 0x3c, 0xbd, 0x80, 0x90, 0x37, 0xa0, 0x94, 0xce, 0x96, 0x78, 0xf1, 0x23, 0x43,
+	0x5e, 0x02, 0xd4, 0x3e, 0x82, 0xd9, 0xbe, 0x1d, 0xe5, 0x75, 0xe2, 0x97, 0x66, 0x79, 0x0e, 0xc7,
+	0x16, 0xf6, 0x56, 0xd6, 0x41, 0x82, 0xba, 0x76, 0xa4, 0xfd, 0x4a, 0x01, 0x55, 0x1c, 0xc2, 0xf1,
+	0x8e, 0x76, 0x63, 0xd7, 0xcd, 0x0b, 0x74, 0xbb, 0x4f, 0xa0, 0x44, 0x96, 0x79, 0xc0, 0x0f, 0xd9,
+	0x7c, 0x3f, 0x59, 0x87, 0x99, 0xd6, 0x49, 0xd7, 0x8e, 0xf0, 0x57, 0x0c, 0x88, 0x48, 0x47, 0xed,
+	0x85, 0x6b, 0x7f, 0xc5, 0x60, 0x5a, 0x27, 0xe9, 0x90, 0xfd, 0x13, 0x50, 0xb1, 0x6d, 0xf5, 0x63,
+	0x16, 0x31, 0xd3, 0xb3, 0x71, 0x68, 0x9f, 0x1e, 0x59, 0x68, 0x7f, 0xae, 0x67, 0x9e, 0xef, 0x64,
+	0xe8, 0x64, 0xa6, 0xa6, 0x9d, 0xc2, 0xdd, 0x2b, 0x8e, 0x20, 0x35, 0xf0, 0x23, 0x98, 0xb9, 0xfc,
+	0x5d, 0x54, 0xf8, 0xdd, 0xfb, 0x43, 0xdd, 0x23, 0xf9, 0x5a, 0x8a, 0x3b, 0xc9, 0xd7, 0xe8, 0xf5,
+	0x70, 0xe0, 0x13, 0xaa, 0xf6, 0xa5, 0x82, 0x66, 0x7f, 0x89, 0x92, 0xdc, 0x81, 0x09, 0xa1, 0xa0,
+	0x44, 0x6f, 0x5c, 0x3f, 0x98, 0x42, 0xb2, 0xbb, 0x90, 0x36, 0x59, 0x4e, 0xaf, 0x22, 0xdf, 0x3f,
+	0x17, 0xfa, 0x07, 0xde, 0x0f, 0xa0, 0x6e, 0x53, 0xd7, 0x39, 0xa5, 0xe1, 0x45, 0xfa, 0xf5, 0x4d,
+	0x68, 0x64, 0x3a, 0x81, 0xcb, 0xef, 0x6f, 0xda, 0x3f, 0x2b, 0x50, 0xdb, 0x8e, 0x7b, 0x34, 0x74,
+	0xac, 0x4f, 0x45, 0x71, 0xfc, 0x26, 0x54, 0x6d, 0x3f, 0xc6, 0x28, 0x2a, 0x86, 0x8a, 0x28, 0x92,
+	0xa2, 0x57, 0x04, 0x8c, 0xd3, 0xa0, 0x91, 0x1c, 0xba, 0xbe, 0xc9, 0x8c, 0x6c, 0xec, 0x38, 0xa6,
+	0x03, 0x07, 0xa5, 0x04, 0x0e, 0x7e, 0x4e, 0x97, 0x04, 0x22, 0xfb, 0x81, 0x93, 0x7e, 0x61, 0xc7,
+	0x97, 0xc4, 0x79, 0x0a, 0x94, 0xae, 0xa8, 0x57, 0xe2, 0x1c, 0x09, 0x7e, 0x4b, 0x77, 0xce, 0xa9,
+	0x9d, 0xd2, 0x60, 0xed, 0x3b, 0xa1, 0x57, 0x25, 0x90, 0x13, 0x69, 0xbf, 0x1a, 0x83, 0x05, 0x9d,
+	0x0f, 0xdf, 0xe5, 0x21, 0x3a, 0xf6, 0x11, 0x6d, 0x9b, 0x51, 0xe6, 0x52, 0x4f, 0xd3, 0x1e, 0x40,
+	0xb9, 0x66, 0x40, 0xd4, 0xa7, 0x86, 0xb4, 0x57, 0x30, 0x00, 0xa8, 0x8d, 0x5e, 0x8d, 0x9b, 0xf2,
+	0xa3, 0x4e, 0xad, 0x7e, 0x6f, 0x84, 0x1d, 0x8c, 0x90, 0xa5, 0x99, 0x01, 0xca, 0x34, 0x79, 0xd4,
+	0xfe, 0x10, 0xca, 0x29, 0x9c, 0x34, 0x60, 0xae, 0xb3, 0xfe, 0xbc, 0x63, 0xb4, 0x5b, 0x7b, 0x9d,
+	0xbd, 0x81, 0x8c, 0x5d, 0x87, 0xea, 0xf6, 0xce, 0xbe, 0xd1, 0x32, 0xb6, 0x5f, 0x6c, 0xad, 0x75,
+	0xf4, 0xba, 0x42, 0xa6, 0x00, 0xba, 0xdb, 0xcf, 0xba, 0xdb, 0xdd, 0xfd, 0x6e, 0x67, 0xaf, 0x3e,
+	0x46, 0x08, 0x4c, 0x75, 0xb7, 0xf7, 0x3b, 0xcf, 0x3b, 0xba, 0xb1, 0xd9, 0xdd, 0xea, 0xee, 0xef,
+	0xd5, 0x0b, 0x64, 0x1e, 0x66, 0xf7, 0x5a, 0xcf, 0x3a, 0x46, 0x82, 0x58, 0xdb, 0x79, 0xb1, 0xbd,
+	0xbe, 0x57, 0x2f, 0x92, 0x49, 0x28, 0xb4, 0x36, 0x37, 0xeb, 0xe3, 0xda, 0x4f, 0xe0, 0xf5, 0x61,
+	0x62, 0x4b, 0x97, 0xf8, 0x9a, 0x3a, 0x5c, 0xfd, 0x6f, 0x15, 0x8a, 0x68, 0xef, 0x24, 0x94, 0x7f,
+	0x6f, 0x94, 0x16, 0x1b, 0x37, 0xcb, 0x46, 0xda, 0xc2, 0x4f, 0xff, 0xfd, 0x7f, 0xfe, 0x6a, 0x6c,
+	0x5e, 0x23, 0x7d, 0xbf, 0xb8, 0x7b, 0xc2, 0xff, 0x51, 0x96, 0xc9, 0x9f, 0x28, 0x50, 0x4e, 0x33,
+	0x1f, 0x79, 0x70, 0x93, 0xd4, 0x29, 0x5e, 0xbf, 0x7c, 0x13, 0x52, 0x29, 0x83, 0xc6, 0x65, 0x78,
+	0x4d, 0x9b, 0xef, 0x97, 0xe1, 0x20, 0x21, 0x44, 0x41, 0x7e, 0xa1, 0xc0, 0x84, 0xa8, 0xe3, 0xc8,
+	0x3b, 0x37, 0xfb, 0x38, 0x72, 0x53, 0x0d, 0xac, 0xfc, 0x67, 0xab, 0x26, 0x7d, 0xff, 0x7d, 0x9e,
+	0x69, 0xb9, 0x34, 0x77, 0xb5, 0xdb, 0x03, 0x1a, 0xe1, 0x7b, 0x3f, 0x51, 0x96, 0x1f, 0x2a, 0xe4,
+	0x73, 0x98, 0x94, 0x5f, 0xe4, 0xbe, 0xd9, 0xcb, 0x58, 0xe4, 0xaf, 0x6e, 0x68, 0x77, 0xfa, 0x5f,
+	0x2d, 0x3f, 0x80, 0x3f, 0x51, 0x96, 0x97, 0x14, 0xf2, 0x12, 0x8a, 0xf8, 0xa3, 0x8e, 0x6f, 0xf4,
+	0xc5, 0x4b, 0xca, 0x43, 0x85, 0xfc, 0x85, 0x02, 0x95, 0xdc, 0x97, 0x04, 0xf2, 0xde, 0x88, 0x61,
+	0xf0, 0xe0, 0x47, 0x91, 0xc6, 0xfb, 0x37, 0x23, 0x96, 0xe7, 0x7c, 0x8b, 0x9f, 0xf3, 0x75, 0xed,
+	0x6e, 0xff, 0x39, 0x83, 0x8c, 0x14, 0xaf, 0xfc, 0xe7, 0x0a, 0x14, 0x71, 0xa0, 0x38, 0xe2, 0xa8,
+	0xb9, 0x8f, 0x0e, 0x8d, 0x85, 0x84, 0x2a, 0xf7, 0x73, 0xcd, 0x66, 0x5a, 0x2b, 0x68, 0xdf, 0xf9,
+	0x75, 0xeb, 0xb5, 0x81, 0x19, 0x6a, 0xdf, 0x98, 0xf4, 0x6a, 0x3f, 0x38, 0x33, 0x1d, 0xd4, 0x3b,
+	0xf9, 0x5b, 0x05, 0x66, 0xaf, 0x98, 0xe7, 0x91, 0x47, 0xbf, 0xc1, 0xf4, 0xef, 0xa6, 0xd6, 0xb0,
+	0xc4, 0x45, 0xd2, 0xb4, 0x85, 0x7e, 0x91, 0x70, 0x3c, 0x91, 0xdb, 0x14, 0xa5, 0xfb, 0x07, 0x05,
+	0xc8, 0xe5, 0xe9, 0x10, 0x59, 0x7d, 0xa5, 0x51, 0x92, 0x90, 0xed, 0xd1, 0x6f, 0x30, 0x7e, 0xd2,
+	0xde, 0xe3, 0x92, 0xbe, 0xad, 0x2d, 0xf6, 0x4b, 0xea, 0x5c, 0xe2, 0x40, 0x61, 0xff, 0x48, 0x81,
+	0x52, 0x32, 0x50, 0x21, 0x4b, 0x23, 0x92, 0x41, 0xdf, 0x08, 0xa9, 0xf1, 0xe0, 0x06, 0x94, 0x52,
+	0x9c, 0x37, 0xb9, 0x38, 0xf7, 0xb4, 0xb9, 0x7e, 0x71, 0x42, 0x49, 0x27, 0x7c, 0xf8, 0x67, 0x0a,
+	0x94, 0xd3, 0xf9, 0xc1, 0x88, 0xc8, 0x36, 0x38, 0x0c, 0x69, 0x2c, 0xdf, 0x84, 0x74, 0x74, 0x64,
+	0x3b, 0x4b, 0x08, 0x85, 0x4b, 0xff, 0x5c, 0x81, 0xa9, 0xfe, 0x19, 0x02, 0x19, 0x3e, 0xe3, 0xb9,
+	0x72, 0xd8, 0xd0, 0x78, 0x6b, 0xb4, 0x50, 0x82, 0x38, 0x51, 0x0c, 0xb9, 0x7b, 0x85, 0x38, 0xf2,
+	0xc5, 0x7f, 0xa9, 0x00, 0xb9, 0xdc, 0x99, 0x8e, 0x30, 0xa5, 0xa1, 0x6d, 0xec, 0xf5, 0x66, 0xce,
+	0xa9, 0x87, 0xdc, 0x56, 0x82, 0xe6, 0x26, 0xf3, 0xa5, 0x02, 0xd3, 0x03, 0x4d, 0x2d, 0x59, 0x19,
+	0xa5, 0xa1, 0xaf, 0x21, 0xce, 0xdb, 0x5c, 0x9c, 0x37, 0xc8, 0xc2, 0xd5, 0xe2, 0xac, 0xfc, 0x3e,
+	0xb6, 0x14, 0x5f, 0x90, 0x3f, 0x55, 0x80, 0x5c, 0x6e, 0x7c, 0x47, 0xe8, 0x69, 0x68, 0x97, 0xdc,
+	0x98, 0xbb, 0x54, 0xb2, 0xf3, 0x8f, 0xd8, 0x89, 0x24, 0xcb, 0xd7, 0x48, 0xf2, 0xd7, 0x0a, 0xcc,
+	0x5e, 0x31, 0xbf, 0x19, 0x11, 0x9a, 0x86, 0x4f, 0x7b, 0x46, 0x29, 0x29, 0x47, 0x9d, 0xd8, 0x35,
+	0x69, 0x5c, 0x95, 0x23, 0xe5, 0xfb, 0x7f, 0xa1, 0x40, 0x35, 0xdf, 0xa6, 0x92, 0x51, 0x0d, 0xc0,
+	0xa5, 0x6e, 0xf6, 0xa6, 0x41, 0x52, 0x2a, 0x49, 0x6b, 0x0c, 0xfa, 0x7a, 0xb6, 0x23, 0x5a, 0xd0,
+	0x2f, 0x15, 0xa8, 0xe6, 0x5b, 0xd9, 0x11, 0xc2, 0x5c, 0xd1, 0xf1, 0x7e, 0x4d, 0x61, 0xa2, 0xdc,
+	0x8e, 0x22, 0xf8, 0x7c, 0xa5, 0xc0, 0xdc, 0xd5, 0xcd, 0x2c, 0xf9, 0xe8, 0x1a, 0xc1, 0x86, 0x74,
+	0xbf, 0xd7, 0xa5, 0xbf, 0x47, 0x5c, 0xb4, 0x0f, 0xb4, 0xf7, 0x52, 0xd1, 0xb8, 0xf9, 0x7c, 0x37,
+	0xfb, 0xff, 0x0c, 0x2b, 0xcb, 0xcb, 0x5f, 0x48, 0x51, 0xe5, 0xd6, 0x0f, 0x15, 0xf2, 0x37, 0x58,
+	0x14, 0x64, 0x9d, 0xee, 0xa8, 0xa2, 0xe0, 0x52, 0x87, 0xdd, 0x78, 0xff, 0x66, 0xc4, 0x52, 0x79,
+	0xef, 0x73, 0x09, 0xdf, 0xd1, 0xde, 0xcc, 0x24, 0xe4, 0x1d, 0xf0, 0x77, 0xf9, 0xbf, 0xd1, 0xca,
+	0xf2, 0x17, 0x4f, 0x02, 0xc1, 0x86, 0x17, 0xfa, 0x07, 0x30, 0x73, 0xa9, 0x0b, 0x25, 0x1f, 0x5e,
+	0xa3, 0xbb, 0xcb, 0x4d, 0x77, 0x63, 0xf5, 0x55, 0x58, 0x72, 0xd5, 0xd2, 0x3f, 0x29, 0x30, 0x77,
+	0x75, 0xe1, 0x3f, 0xe2, 0x06, 0x47, 0x36, 0x38, 0x8d, 0x8f, 0x5f, 0x99, 0x2f, 0xa9, 0x60, 0xb9,
+	0xe6, 0x1e, 0x68, 0x6f, 0x0d, 0xfa, 0xc0, 0x55, 0x5c, 0x4f, 0x94, 0xe5, 0xc6, 0xcc, 0xaf, 0x5b,
+	0x53, 0xfc, 0xd3, 0xe1, 0xb1, 0x1f, 0xb1, 0x27, 0x1f, 0x3f, 0xfe, 0xe8, 0x77, 0xd6, 0x5e, 0xc0,
+	0x3d, 0xcb, 0xef, 0x0d, 0x93, 0x60, 0x57, 0xf9, 0xf1, 0xe3, 0x23, 0x87, 0x1d, 0xc7, 0x07, 0x4d,
+	0xcb, 0xef, 0xad, 0x08, 0x2a, 0x33, 0x70, 0xa2, 0x95, 0x23, 0x33, 0x70, 0xac, 0x0f, 0x12, 0xfa,
+	0x15, 0xf1, 0x4b, 0xda, 0x95, 0x23, 0xea, 0x89, 0x60, 0x36, 0xc1, 0xff, 0x3c, 0xfa, 0xff, 0x01,
+	0x00, 0x0a, 0xc6, 0xfd, 0x43, 0xe0, 0x33, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -596,8 +4075,16 @@ const _ = grpc.SupportPackageIsVersion4
 type EchoClient interface {
 	// This method simply echos the request. This method is showcases unary rpcs.
 	Echo(ctx context.Context, in *EchoRequest, opts ...grpc.CallOption) (*EchoResponse, error)
+	// Echoes each of a batch of requests as the Echo method would. Items fail
+	// individually, through the error of their request, without failing the
+	// call. A batch larger than the server's `max_batch_echo_size` setting
+	// fails with INVALID_ARGUMENT.
+	BatchEcho(ctx context.Context, in *BatchEchoRequest, opts ...grpc.CallOption) (*BatchEchoResponse, error)
 	// This method split the given content into words and will pass each word back
 	// through the stream. This method showcases server-side streaming rpcs.
+	// Every stream ends with the `showcase-expand-count` trailer counting the
+	// words sent, which is "0" for content with no words, so that an empty
+	// stream can be told from a stream of empty messages.
 	Expand(ctx context.Context, in *ExpandRequest, opts ...grpc.CallOption) (Echo_ExpandClient, error)
 	// This method will collect the words given to it. When the stream is closed
 	// by the client, this method will return the a concatenation of the strings
@@ -605,7 +4092,8 @@ type EchoClient interface {
 	Collect(ctx context.Context, opts ...grpc.CallOption) (Echo_CollectClient, error)
 	// This method, upon receiving a request on the stream, the same content will
 	// be passed  back on the stream. This method showcases bidirectional
-	// streaming rpcs.
+	// streaming rpcs. With the `showcase-chat-handshake` metadata set to
+	// "true", the server speaks first, sending a handshake with the session ID.
 	Chat(ctx context.Context, opts ...grpc.CallOption) (Echo_ChatClient, error)
 	// This is similar to the Expand method but instead of returning a stream of
 	// expanded words, this method returns a paged list of expanded words.
@@ -613,6 +4101,74 @@ type EchoClient interface {
 	// This method will wait the requested amount of and then return.
 	// This method showcases how a client handles a request timing out.
 	Wait(ctx context.Context, in *WaitRequest, opts ...grpc.CallOption) (*longrunning.Operation, error)
+	// This method always fails with the status given in the request, with the
+	// requested standard error details attached in the order they were
+	// requested. This method showcases how a client surfaces error details.
+	FailEchoWithDetails(ctx context.Context, in *FailEchoWithDetailsRequest, opts ...grpc.CallOption) (*EchoResponse, error)
+	// This method reports the credential-bearing metadata that the server
+	// received, in the order it was received. This method showcases how a
+	// client layers per-call credentials over channel credentials.
+	InspectCredentials(ctx context.Context, in *InspectCredentialsRequest, opts ...grpc.CallOption) (*InspectCredentialsResponse, error)
+	// This method streams a range of a large deterministic blob in chunks,
+	// each with a checksum. This method showcases ranged and resumable media
+	// downloads.
+	ReadBlob(ctx context.Context, in *ReadBlobRequest, opts ...grpc.CallOption) (Echo_ReadBlobClient, error)
+	// This method stores a blob sent in chunks, committing each chunk as it is
+	// received. An interrupted upload can be resumed on a new stream from the
+	// size reported by GetWriteStatus. This method showcases resumable media
+	// uploads.
+	WriteBlob(ctx context.Context, opts ...grpc.CallOption) (Echo_WriteBlobClient, error)
+	// This method reports how much of a blob written by WriteBlob has been
+	// committed.
+	GetWriteStatus(ctx context.Context, in *GetWriteStatusRequest, opts ...grpc.CallOption) (*WriteStatus, error)
+	// Creates a named resource for GetEchoResource to find, so that clients
+	// can test helpers that poll until a resource exists.
+	CreateEchoResource(ctx context.Context, in *CreateEchoResourceRequest, opts ...grpc.CallOption) (*EchoResource, error)
+	// Gets a resource created by CreateEchoResource. Until it is created, or
+	// after it is deleted, this fails with NOT_FOUND, or with UNAVAILABLE if
+	// `unavailable_until_created` is set so that retry policies engage.
+	GetEchoResource(ctx context.Context, in *GetEchoResourceRequest, opts ...grpc.CallOption) (*EchoResource, error)
+	// Deletes a resource created by CreateEchoResource.
+	DeleteEchoResource(ctx context.Context, in *DeleteEchoResourceRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// This method reports how far the last Expand stream with a `stream_id`
+	// got before it ended, so that a client that cancels a stream can learn
+	// how many messages the server sent.
+	GetLastExpandStatus(ctx context.Context, in *GetLastExpandStatusRequest, opts ...grpc.CallOption) (*ExpandStatus, error)
+	// This method always fails with exactly the canonical code and message of
+	// the request, so that one parametrized test can check how a client maps
+	// every code. With `with_details` it attaches the standard details
+	// described by ReturnStatusRequest.
+	ReturnStatus(ctx context.Context, in *ReturnStatusRequest, opts ...grpc.CallOption) (*EchoResponse, error)
+	// This method streams `message_count` responses, then ends the stream with
+	// the status of the request, which may be OK.
+	StreamStatus(ctx context.Context, in *StreamStatusRequest, opts ...grpc.CallOption) (Echo_StreamStatusClient, error)
+	// This method streams the state of an operation started by Wait: first as
+	// it is, then each time it changes as its partial results become available,
+	// ending the stream once it is done. If the operation is deleted first, the
+	// stream fails with NOT_FOUND and an ErrorInfo with reason
+	// `OPERATION_DELETED`. A client that reads slowly receives the latest state,
+	// skipping the ones it missed. This method showcases LRO helpers that are
+	// pushed updates rather than polling.
+	StreamOperationUpdates(ctx context.Context, in *StreamOperationUpdatesRequest, opts ...grpc.CallOption) (Echo_StreamOperationUpdatesClient, error)
+	// Publishes messages to a topic, creating it if it does not exist, for
+	// StreamingPullEcho to deliver. A topic holds at most 1000 messages that
+	// have not been acknowledged, and fails with RESOURCE_EXHAUSTED rather than
+	// hold more.
+	PublishEcho(ctx context.Context, in *PublishEchoRequest, opts ...grpc.CallOption) (*PublishEchoResponse, error)
+	// This method pushes the messages of a topic to the client, which
+	// acknowledges them by their ack IDs. A message that is not acknowledged
+	// within the ack deadline of the stream it was delivered on is delivered
+	// again, on any stream of the topic. The streams of a topic share its
+	// messages, each delivered to one stream at a time. This method showcases
+	// streaming pull with acknowledgements, as used by Pub/Sub.
+	StreamingPullEcho(ctx context.Context, opts ...grpc.CallOption) (Echo_StreamingPullEchoClient, error)
+	// This method returns the numeric values of the request, followed by the
+	// canned edge cases it selects. In proto3 JSON, NaN and infinite floating
+	// point values are written as the strings `"NaN"`, `"Infinity"` and
+	// `"-Infinity"`, and 64-bit integers as decimal strings, which clients
+	// must read back exactly. This method showcases the JSON encoding of
+	// numbers.
+	RepeatNumericEdgeCases(ctx context.Context, in *RepeatNumericEdgeCasesRequest, opts ...grpc.CallOption) (*RepeatNumericEdgeCasesResponse, error)
 }
 
 type echoClient struct {
@@ -632,6 +4188,15 @@ func (c *echoClient) Echo(ctx context.Context, in *EchoRequest, opts ...grpc.Cal
 	return out, nil
 }
 
+func (c *echoClient) BatchEcho(ctx context.Context, in *BatchEchoRequest, opts ...grpc.CallOption) (*BatchEchoResponse, error) {
+	out := new(BatchEchoResponse)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Echo/BatchEcho", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *echoClient) Expand(ctx context.Context, in *ExpandRequest, opts ...grpc.CallOption) (Echo_ExpandClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_Echo_serviceDesc.Streams[0], "/google.showcase.v1beta1.Echo/Expand", opts...)
 	if err != nil {
@@ -747,12 +4312,271 @@ func (c *echoClient) Wait(ctx context.Context, in *WaitRequest, opts ...grpc.Cal
 	return out, nil
 }
 
+func (c *echoClient) FailEchoWithDetails(ctx context.Context, in *FailEchoWithDetailsRequest, opts ...grpc.CallOption) (*EchoResponse, error) {
+	out := new(EchoResponse)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Echo/FailEchoWithDetails", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *echoClient) InspectCredentials(ctx context.Context, in *InspectCredentialsRequest, opts ...grpc.CallOption) (*InspectCredentialsResponse, error) {
+	out := new(InspectCredentialsResponse)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Echo/InspectCredentials", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *echoClient) ReadBlob(ctx context.Context, in *ReadBlobRequest, opts ...grpc.CallOption) (Echo_ReadBlobClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Echo_serviceDesc.Streams[3], "/google.showcase.v1beta1.Echo/ReadBlob", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &echoReadBlobClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Echo_ReadBlobClient interface {
+	Recv() (*ReadBlobResponse, error)
+	grpc.ClientStream
+}
+
+type echoReadBlobClient struct {
+	grpc.ClientStream
+}
+
+func (x *echoReadBlobClient) Recv() (*ReadBlobResponse, error) {
+	m := new(ReadBlobResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *echoClient) WriteBlob(ctx context.Context, opts ...grpc.CallOption) (Echo_WriteBlobClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Echo_serviceDesc.Streams[4], "/google.showcase.v1beta1.Echo/WriteBlob", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &echoWriteBlobClient{stream}
+	return x, nil
+}
+
+type Echo_WriteBlobClient interface {
+	Send(*WriteBlobRequest) error
+	CloseAndRecv() (*WriteBlobResponse, error)
+	grpc.ClientStream
+}
+
+type echoWriteBlobClient struct {
+	grpc.ClientStream
+}
+
+func (x *echoWriteBlobClient) Send(m *WriteBlobRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *echoWriteBlobClient) CloseAndRecv() (*WriteBlobResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(WriteBlobResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *echoClient) GetWriteStatus(ctx context.Context, in *GetWriteStatusRequest, opts ...grpc.CallOption) (*WriteStatus, error) {
+	out := new(WriteStatus)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Echo/GetWriteStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *echoClient) CreateEchoResource(ctx context.Context, in *CreateEchoResourceRequest, opts ...grpc.CallOption) (*EchoResource, error) {
+	out := new(EchoResource)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Echo/CreateEchoResource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *echoClient) GetEchoResource(ctx context.Context, in *GetEchoResourceRequest, opts ...grpc.CallOption) (*EchoResource, error) {
+	out := new(EchoResource)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Echo/GetEchoResource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *echoClient) DeleteEchoResource(ctx context.Context, in *DeleteEchoResourceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Echo/DeleteEchoResource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *echoClient) GetLastExpandStatus(ctx context.Context, in *GetLastExpandStatusRequest, opts ...grpc.CallOption) (*ExpandStatus, error) {
+	out := new(ExpandStatus)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Echo/GetLastExpandStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *echoClient) ReturnStatus(ctx context.Context, in *ReturnStatusRequest, opts ...grpc.CallOption) (*EchoResponse, error) {
+	out := new(EchoResponse)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Echo/ReturnStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *echoClient) StreamStatus(ctx context.Context, in *StreamStatusRequest, opts ...grpc.CallOption) (Echo_StreamStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Echo_serviceDesc.Streams[5], "/google.showcase.v1beta1.Echo/StreamStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &echoStreamStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Echo_StreamStatusClient interface {
+	Recv() (*EchoResponse, error)
+	grpc.ClientStream
+}
+
+type echoStreamStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *echoStreamStatusClient) Recv() (*EchoResponse, error) {
+	m := new(EchoResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *echoClient) StreamOperationUpdates(ctx context.Context, in *StreamOperationUpdatesRequest, opts ...grpc.CallOption) (Echo_StreamOperationUpdatesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Echo_serviceDesc.Streams[6], "/google.showcase.v1beta1.Echo/StreamOperationUpdates", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &echoStreamOperationUpdatesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Echo_StreamOperationUpdatesClient interface {
+	Recv() (*longrunning.Operation, error)
+	grpc.ClientStream
+}
+
+type echoStreamOperationUpdatesClient struct {
+	grpc.ClientStream
+}
+
+func (x *echoStreamOperationUpdatesClient) Recv() (*longrunning.Operation, error) {
+	m := new(longrunning.Operation)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *echoClient) PublishEcho(ctx context.Context, in *PublishEchoRequest, opts ...grpc.CallOption) (*PublishEchoResponse, error) {
+	out := new(PublishEchoResponse)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Echo/PublishEcho", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *echoClient) StreamingPullEcho(ctx context.Context, opts ...grpc.CallOption) (Echo_StreamingPullEchoClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Echo_serviceDesc.Streams[7], "/google.showcase.v1beta1.Echo/StreamingPullEcho", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &echoStreamingPullEchoClient{stream}
+	return x, nil
+}
+
+type Echo_StreamingPullEchoClient interface {
+	Send(*StreamingPullEchoRequest) error
+	Recv() (*StreamingPullEchoResponse, error)
+	grpc.ClientStream
+}
+
+type echoStreamingPullEchoClient struct {
+	grpc.ClientStream
+}
+
+func (x *echoStreamingPullEchoClient) Send(m *StreamingPullEchoRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *echoStreamingPullEchoClient) Recv() (*StreamingPullEchoResponse, error) {
+	m := new(StreamingPullEchoResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *echoClient) RepeatNumericEdgeCases(ctx context.Context, in *RepeatNumericEdgeCasesRequest, opts ...grpc.CallOption) (*RepeatNumericEdgeCasesResponse, error) {
+	out := new(RepeatNumericEdgeCasesResponse)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Echo/RepeatNumericEdgeCases", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EchoServer is the server API for Echo service.
 type EchoServer interface {
 	// This method simply echos the request. This method is showcases unary rpcs.
 	Echo(context.Context, *EchoRequest) (*EchoResponse, error)
+	// Echoes each of a batch of requests as the Echo method would. Items fail
+	// individually, through the error of their request, without failing the
+	// call. A batch larger than the server's `max_batch_echo_size` setting
+	// fails with INVALID_ARGUMENT.
+	BatchEcho(context.Context, *BatchEchoRequest) (*BatchEchoResponse, error)
 	// This method split the given content into words and will pass each word back
 	// through the stream. This method showcases server-side streaming rpcs.
+	// Every stream ends with the `showcase-expand-count` trailer counting the
+	// words sent, which is "0" for content with no words, so that an empty
+	// stream can be told from a stream of empty messages.
 	Expand(*ExpandRequest, Echo_ExpandServer) error
 	// This method will collect the words given to it. When the stream is closed
 	// by the client, this method will return the a concatenation of the strings
@@ -760,7 +4584,8 @@ type EchoServer interface {
 	Collect(Echo_CollectServer) error
 	// This method, upon receiving a request on the stream, the same content will
 	// be passed  back on the stream. This method showcases bidirectional
-	// streaming rpcs.
+	// streaming rpcs. With the `showcase-chat-handshake` metadata set to
+	// "true", the server speaks first, sending a handshake with the session ID.
 	Chat(Echo_ChatServer) error
 	// This is similar to the Expand method but instead of returning a stream of
 	// expanded words, this method returns a paged list of expanded words.
@@ -768,6 +4593,74 @@ type EchoServer interface {
 	// This method will wait the requested amount of and then return.
 	// This method showcases how a client handles a request timing out.
 	Wait(context.Context, *WaitRequest) (*longrunning.Operation, error)
+	// This method always fails with the status given in the request, with the
+	// requested standard error details attached in the order they were
+	// requested. This method showcases how a client surfaces error details.
+	FailEchoWithDetails(context.Context, *FailEchoWithDetailsRequest) (*EchoResponse, error)
+	// This method reports the credential-bearing metadata that the server
+	// received, in the order it was received. This method showcases how a
+	// client layers per-call credentials over channel credentials.
+	InspectCredentials(context.Context, *InspectCredentialsRequest) (*InspectCredentialsResponse, error)
+	// This method streams a range of a large deterministic blob in chunks,
+	// each with a checksum. This method showcases ranged and resumable media
+	// downloads.
+	ReadBlob(*ReadBlobRequest, Echo_ReadBlobServer) error
+	// This method stores a blob sent in chunks, committing each chunk as it is
+	// received. An interrupted upload can be resumed on a new stream from the
+	// size reported by GetWriteStatus. This method showcases resumable media
+	// uploads.
+	WriteBlob(Echo_WriteBlobServer) error
+	// This method reports how much of a blob written by WriteBlob has been
+	// committed.
+	GetWriteStatus(context.Context, *GetWriteStatusRequest) (*WriteStatus, error)
+	// Creates a named resource for GetEchoResource to find, so that clients
+	// can test helpers that poll until a resource exists.
+	CreateEchoResource(context.Context, *CreateEchoResourceRequest) (*EchoResource, error)
+	// Gets a resource created by CreateEchoResource. Until it is created, or
+	// after it is deleted, this fails with NOT_FOUND, or with UNAVAILABLE if
+	// `unavailable_until_created` is set so that retry policies engage.
+	GetEchoResource(context.Context, *GetEchoResourceRequest) (*EchoResource, error)
+	// Deletes a resource created by CreateEchoResource.
+	DeleteEchoResource(context.Context, *DeleteEchoResourceRequest) (*empty.Empty, error)
+	// This method reports how far the last Expand stream with a `stream_id`
+	// got before it ended, so that a client that cancels a stream can learn
+	// how many messages the server sent.
+	GetLastExpandStatus(context.Context, *GetLastExpandStatusRequest) (*ExpandStatus, error)
+	// This method always fails with exactly the canonical code and message of
+	// the request, so that one parametrized test can check how a client maps
+	// every code. With `with_details` it attaches the standard details
+	// described by ReturnStatusRequest.
+	ReturnStatus(context.Context, *ReturnStatusRequest) (*EchoResponse, error)
+	// This method streams `message_count` responses, then ends the stream with
+	// the status of the request, which may be OK.
+	StreamStatus(*StreamStatusRequest, Echo_StreamStatusServer) error
+	// This method streams the state of an operation started by Wait: first as
+	// it is, then each time it changes as its partial results become available,
+	// ending the stream once it is done. If the operation is deleted first, the
+	// stream fails with NOT_FOUND and an ErrorInfo with reason
+	// `OPERATION_DELETED`. A client that reads slowly receives the latest state,
+	// skipping the ones it missed. This method showcases LRO helpers that are
+	// pushed updates rather than polling.
+	StreamOperationUpdates(*StreamOperationUpdatesRequest, Echo_StreamOperationUpdatesServer) error
+	// Publishes messages to a topic, creating it if it does not exist, for
+	// StreamingPullEcho to deliver. A topic holds at most 1000 messages that
+	// have not been acknowledged, and fails with RESOURCE_EXHAUSTED rather than
+	// hold more.
+	PublishEcho(context.Context, *PublishEchoRequest) (*PublishEchoResponse, error)
+	// This method pushes the messages of a topic to the client, which
+	// acknowledges them by their ack IDs. A message that is not acknowledged
+	// within the ack deadline of the stream it was delivered on is delivered
+	// again, on any stream of the topic. The streams of a topic share its
+	// messages, each delivered to one stream at a time. This method showcases
+	// streaming pull with acknowledgements, as used by Pub/Sub.
+	StreamingPullEcho(Echo_StreamingPullEchoServer) error
+	// This method returns the numeric values of the request, followed by the
+	// canned edge cases it selects. In proto3 JSON, NaN and infinite floating
+	// point values are written as the strings `"NaN"`, `"Infinity"` and
+	// `"-Infinity"`, and 64-bit integers as decimal strings, which clients
+	// must read back exactly. This method showcases the JSON encoding of
+	// numbers.
+	RepeatNumericEdgeCases(context.Context, *RepeatNumericEdgeCasesRequest) (*RepeatNumericEdgeCasesResponse, error)
 }
 
 // UnimplementedEchoServer can be embedded to have forward compatible implementations.
@@ -777,6 +4670,9 @@ type UnimplementedEchoServer struct {
 func (*UnimplementedEchoServer) Echo(ctx context.Context, req *EchoRequest) (*EchoResponse, error) {
 	return nil, status1.Errorf(codes.Unimplemented, "method Echo not implemented")
 }
+func (*UnimplementedEchoServer) BatchEcho(ctx context.Context, req *BatchEchoRequest) (*BatchEchoResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method BatchEcho not implemented")
+}
 func (*UnimplementedEchoServer) Expand(req *ExpandRequest, srv Echo_ExpandServer) error {
 	return status1.Errorf(codes.Unimplemented, "method Expand not implemented")
 }
@@ -792,25 +4688,88 @@ func (*UnimplementedEchoServer) PagedExpand(ctx context.Context, req *PagedExpan
 func (*UnimplementedEchoServer) Wait(ctx context.Context, req *WaitRequest) (*longrunning.Operation, error) {
 	return nil, status1.Errorf(codes.Unimplemented, "method Wait not implemented")
 }
+func (*UnimplementedEchoServer) FailEchoWithDetails(ctx context.Context, req *FailEchoWithDetailsRequest) (*EchoResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method FailEchoWithDetails not implemented")
+}
+func (*UnimplementedEchoServer) InspectCredentials(ctx context.Context, req *InspectCredentialsRequest) (*InspectCredentialsResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method InspectCredentials not implemented")
+}
+func (*UnimplementedEchoServer) ReadBlob(req *ReadBlobRequest, srv Echo_ReadBlobServer) error {
+	return status1.Errorf(codes.Unimplemented, "method ReadBlob not implemented")
+}
+func (*UnimplementedEchoServer) WriteBlob(srv Echo_WriteBlobServer) error {
+	return status1.Errorf(codes.Unimplemented, "method WriteBlob not implemented")
+}
+func (*UnimplementedEchoServer) GetWriteStatus(ctx context.Context, req *GetWriteStatusRequest) (*WriteStatus, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method GetWriteStatus not implemented")
+}
+func (*UnimplementedEchoServer) CreateEchoResource(ctx context.Context, req *CreateEchoResourceRequest) (*EchoResource, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method CreateEchoResource not implemented")
+}
+func (*UnimplementedEchoServer) GetEchoResource(ctx context.Context, req *GetEchoResourceRequest) (*EchoResource, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method GetEchoResource not implemented")
+}
+func (*UnimplementedEchoServer) DeleteEchoResource(ctx context.Context, req *DeleteEchoResourceRequest) (*empty.Empty, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method DeleteEchoResource not implemented")
+}
+func (*UnimplementedEchoServer) GetLastExpandStatus(ctx context.Context, req *GetLastExpandStatusRequest) (*ExpandStatus, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method GetLastExpandStatus not implemented")
+}
+func (*UnimplementedEchoServer) ReturnStatus(ctx context.Context, req *ReturnStatusRequest) (*EchoResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method ReturnStatus not implemented")
+}
+func (*UnimplementedEchoServer) StreamStatus(req *StreamStatusRequest, srv Echo_StreamStatusServer) error {
+	return status1.Errorf(codes.Unimplemented, "method StreamStatus not implemented")
+}
+func (*UnimplementedEchoServer) StreamOperationUpdates(req *StreamOperationUpdatesRequest, srv Echo_StreamOperationUpdatesServer) error {
+	return status1.Errorf(codes.Unimplemented, "method StreamOperationUpdates not implemented")
+}
+func (*UnimplementedEchoServer) PublishEcho(ctx context.Context, req *PublishEchoRequest) (*PublishEchoResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method PublishEcho not implemented")
+}
+func (*UnimplementedEchoServer) StreamingPullEcho(srv Echo_StreamingPullEchoServer) error {
+	return status1.Errorf(codes.Unimplemented, "method StreamingPullEcho not implemented")
+}
+func (*UnimplementedEchoServer) RepeatNumericEdgeCases(ctx context.Context, req *RepeatNumericEdgeCasesRequest) (*RepeatNumericEdgeCasesResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method RepeatNumericEdgeCases not implemented")
+}
 
 func RegisterEchoServer(s *grpc.Server, srv EchoServer) {
 	s.RegisterService(&_Echo_serviceDesc, srv)
 }
 
-func _Echo_Echo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EchoRequest)
+func _Echo_Echo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EchoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoServer).Echo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Echo/Echo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoServer).Echo(ctx, req.(*EchoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Echo_BatchEcho_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchEchoRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(EchoServer).Echo(ctx, in)
+		return srv.(EchoServer).BatchEcho(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/google.showcase.v1beta1.Echo/Echo",
+		FullMethod: "/google.showcase.v1beta1.Echo/BatchEcho",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(EchoServer).Echo(ctx, req.(*EchoRequest))
+		return srv.(EchoServer).BatchEcho(ctx, req.(*BatchEchoRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -924,6 +4883,301 @@ func _Echo_Wait_Handler(srv interface{}, ctx context.Context, dec func(interface
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Echo_FailEchoWithDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FailEchoWithDetailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoServer).FailEchoWithDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Echo/FailEchoWithDetails",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoServer).FailEchoWithDetails(ctx, req.(*FailEchoWithDetailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Echo_InspectCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectCredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoServer).InspectCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Echo/InspectCredentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoServer).InspectCredentials(ctx, req.(*InspectCredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Echo_ReadBlob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadBlobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EchoServer).ReadBlob(m, &echoReadBlobServer{stream})
+}
+
+type Echo_ReadBlobServer interface {
+	Send(*ReadBlobResponse) error
+	grpc.ServerStream
+}
+
+type echoReadBlobServer struct {
+	grpc.ServerStream
+}
+
+func (x *echoReadBlobServer) Send(m *ReadBlobResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Echo_WriteBlob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EchoServer).WriteBlob(&echoWriteBlobServer{stream})
+}
+
+type Echo_WriteBlobServer interface {
+	SendAndClose(*WriteBlobResponse) error
+	Recv() (*WriteBlobRequest, error)
+	grpc.ServerStream
+}
+
+type echoWriteBlobServer struct {
+	grpc.ServerStream
+}
+
+func (x *echoWriteBlobServer) SendAndClose(m *WriteBlobResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *echoWriteBlobServer) Recv() (*WriteBlobRequest, error) {
+	m := new(WriteBlobRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Echo_GetWriteStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWriteStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoServer).GetWriteStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Echo/GetWriteStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoServer).GetWriteStatus(ctx, req.(*GetWriteStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Echo_CreateEchoResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEchoResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoServer).CreateEchoResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Echo/CreateEchoResource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoServer).CreateEchoResource(ctx, req.(*CreateEchoResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Echo_GetEchoResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEchoResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoServer).GetEchoResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Echo/GetEchoResource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoServer).GetEchoResource(ctx, req.(*GetEchoResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Echo_DeleteEchoResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEchoResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoServer).DeleteEchoResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Echo/DeleteEchoResource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoServer).DeleteEchoResource(ctx, req.(*DeleteEchoResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Echo_GetLastExpandStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLastExpandStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoServer).GetLastExpandStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Echo/GetLastExpandStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoServer).GetLastExpandStatus(ctx, req.(*GetLastExpandStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Echo_ReturnStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReturnStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoServer).ReturnStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Echo/ReturnStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoServer).ReturnStatus(ctx, req.(*ReturnStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Echo_StreamStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EchoServer).StreamStatus(m, &echoStreamStatusServer{stream})
+}
+
+type Echo_StreamStatusServer interface {
+	Send(*EchoResponse) error
+	grpc.ServerStream
+}
+
+type echoStreamStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *echoStreamStatusServer) Send(m *EchoResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Echo_StreamOperationUpdates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamOperationUpdatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EchoServer).StreamOperationUpdates(m, &echoStreamOperationUpdatesServer{stream})
+}
+
+type Echo_StreamOperationUpdatesServer interface {
+	Send(*longrunning.Operation) error
+	grpc.ServerStream
+}
+
+type echoStreamOperationUpdatesServer struct {
+	grpc.ServerStream
+}
+
+func (x *echoStreamOperationUpdatesServer) Send(m *longrunning.Operation) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Echo_PublishEcho_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishEchoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoServer).PublishEcho(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Echo/PublishEcho",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoServer).PublishEcho(ctx, req.(*PublishEchoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Echo_StreamingPullEcho_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EchoServer).StreamingPullEcho(&echoStreamingPullEchoServer{stream})
+}
+
+type Echo_StreamingPullEchoServer interface {
+	Send(*StreamingPullEchoResponse) error
+	Recv() (*StreamingPullEchoRequest, error)
+	grpc.ServerStream
+}
+
+type echoStreamingPullEchoServer struct {
+	grpc.ServerStream
+}
+
+func (x *echoStreamingPullEchoServer) Send(m *StreamingPullEchoResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *echoStreamingPullEchoServer) Recv() (*StreamingPullEchoRequest, error) {
+	m := new(StreamingPullEchoRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Echo_RepeatNumericEdgeCases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepeatNumericEdgeCasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EchoServer).RepeatNumericEdgeCases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Echo/RepeatNumericEdgeCases",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EchoServer).RepeatNumericEdgeCases(ctx, req.(*RepeatNumericEdgeCasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Echo_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "google.showcase.v1beta1.Echo",
 	HandlerType: (*EchoServer)(nil),
@@ -932,6 +5186,10 @@ var _Echo_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Echo",
 			Handler:    _Echo_Echo_Handler,
 		},
+		{
+			MethodName: "BatchEcho",
+			Handler:    _Echo_BatchEcho_Handler,
+		},
 		{
 			MethodName: "PagedExpand",
 			Handler:    _Echo_PagedExpand_Handler,
@@ -940,6 +5198,46 @@ var _Echo_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Wait",
 			Handler:    _Echo_Wait_Handler,
 		},
+		{
+			MethodName: "FailEchoWithDetails",
+			Handler:    _Echo_FailEchoWithDetails_Handler,
+		},
+		{
+			MethodName: "InspectCredentials",
+			Handler:    _Echo_InspectCredentials_Handler,
+		},
+		{
+			MethodName: "GetWriteStatus",
+			Handler:    _Echo_GetWriteStatus_Handler,
+		},
+		{
+			MethodName: "CreateEchoResource",
+			Handler:    _Echo_CreateEchoResource_Handler,
+		},
+		{
+			MethodName: "GetEchoResource",
+			Handler:    _Echo_GetEchoResource_Handler,
+		},
+		{
+			MethodName: "DeleteEchoResource",
+			Handler:    _Echo_DeleteEchoResource_Handler,
+		},
+		{
+			MethodName: "GetLastExpandStatus",
+			Handler:    _Echo_GetLastExpandStatus_Handler,
+		},
+		{
+			MethodName: "ReturnStatus",
+			Handler:    _Echo_ReturnStatus_Handler,
+		},
+		{
+			MethodName: "PublishEcho",
+			Handler:    _Echo_PublishEcho_Handler,
+		},
+		{
+			MethodName: "RepeatNumericEdgeCases",
+			Handler:    _Echo_RepeatNumericEdgeCases_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -958,6 +5256,32 @@ var _Echo_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "ReadBlob",
+			Handler:       _Echo_ReadBlob_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WriteBlob",
+			Handler:       _Echo_WriteBlob_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "StreamStatus",
+			Handler:       _Echo_StreamStatus_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamOperationUpdates",
+			Handler:       _Echo_StreamOperationUpdates_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamingPullEcho",
+			Handler:       _Echo_StreamingPullEcho_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "google/showcase/v1beta1/echo.proto",
 }
@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
 	empty "github.com/golang/protobuf/ptypes/empty"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
@@ -151,10 +152,13 @@ func (m *CreateUserRequest) GetUser() *User {
 // method.
 type GetUserRequest struct {
 	// The resource name of the requested user.
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The fields of the user to return. If unset, all of them are. Fields
+	// within repeated fields cannot be selected.
+	ReadMask             *field_mask.FieldMask `protobuf:"bytes,2,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *GetUserRequest) Reset()         { *m = GetUserRequest{} }
@@ -189,6 +193,13 @@ func (m *GetUserRequest) GetName() string {
 	return ""
 }
 
+func (m *GetUserRequest) GetReadMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.ReadMask
+	}
+	return nil
+}
+
 // The request message for the google.showcase.v1beta1.Identity\UpdateUser
 // method.
 type UpdateUserRequest struct {
@@ -292,7 +303,18 @@ type ListUsersRequest struct {
 	// The value of google.showcase.v1beta1.ListUsersResponse.next_page_token
 	// returned from the previous call to
 	// `google.showcase.v1beta1.Identity\ListUsers` method.
-	PageToken            string   `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// How long after it was issued the page token is accepted. Zero accepts
+	// tokens of any age. If unset, the server's `page_token_ttl` setting
+	// applies.
+	PageTokenTtl *duration.Duration `protobuf:"bytes,3,opt,name=page_token_ttl,json=pageTokenTtl,proto3" json:"page_token_ttl,omitempty"`
+	// The fields of each user to return. If unset, all of them are. Fields
+	// within repeated fields cannot be selected.
+	ReadMask *field_mask.FieldMask `protobuf:"bytes,4,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	// The users to return, as an AIP-160 filter of comparisons by `=`, `!=`,
+	// `<` or `>` joined by `AND`, over `display_name` and `email`, such as
+	// `display_name = "Ada"`. Pages are made of the users that match.
+	Filter               string   `protobuf:"bytes,5,opt,name=filter,proto3" json:"filter,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -337,6 +359,27 @@ func (m *ListUsersRequest) GetPageToken() string {
 	return ""
 }
 
+func (m *ListUsersRequest) GetPageTokenTtl() *duration.Duration {
+	if m != nil {
+		return m.PageTokenTtl
+	}
+	return nil
+}
+
+func (m *ListUsersRequest) GetReadMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.ReadMask
+	}
+	return nil
+}
+
+func (m *ListUsersRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
 // The response message for the google.showcase.v1beta1.Identity\ListUsers
 // method.
 type ListUsersResponse struct {
@@ -406,53 +449,57 @@ func init() {
 }
 
 var fileDescriptor_25043513edbd8d39 = []byte{
-	// 736 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x4b, 0x6f, 0x13, 0x49,
-	0x10, 0xd6, 0xf8, 0xb1, 0x9b, 0x94, 0x77, 0x93, 0xb8, 0x77, 0x95, 0x38, 0x93, 0x64, 0xd7, 0xcc,
-	0xc1, 0x09, 0x16, 0xcc, 0x10, 0x27, 0xe2, 0x11, 0x84, 0x84, 0x03, 0x04, 0x21, 0x41, 0x14, 0x99,
-	0xe4, 0xc2, 0xc5, 0x6a, 0x8f, 0x2b, 0x76, 0x2b, 0xf3, 0x62, 0xba, 0x1d, 0xf2, 0x50, 0x24, 0x04,
-	0xbf, 0x00, 0xf1, 0x1b, 0xf8, 0x33, 0x5c, 0xb9, 0xe5, 0x94, 0x03, 0x27, 0xfe, 0x00, 0x12, 0x27,
-	0xd4, 0x3d, 0xe3, 0x37, 0x8e, 0x23, 0x71, 0x1a, 0xbb, 0xea, 0xab, 0xfa, 0xbe, 0xaa, 0xfe, 0x7a,
-	0x06, 0x0a, 0x0d, 0xdf, 0x6f, 0x38, 0x68, 0xf1, 0xa6, 0xff, 0xc6, 0xa6, 0x1c, 0xad, 0xc3, 0xd5,
-	0x1a, 0x0a, 0xba, 0x6a, 0xb1, 0x3a, 0x7a, 0x82, 0x89, 0x63, 0x33, 0x08, 0x7d, 0xe1, 0x93, 0xb9,
-	0x08, 0x67, 0xb6, 0x71, 0x66, 0x8c, 0xd3, 0x17, 0xe3, 0x06, 0x34, 0x60, 0x16, 0xf5, 0x3c, 0x5f,
-	0x50, 0xc1, 0x7c, 0x8f, 0x47, 0x65, 0xfa, 0x5c, 0x4f, 0xd6, 0x76, 0x18, 0x7a, 0x22, 0x4e, 0xfc,
-	0xdf, 0x93, 0xd8, 0x67, 0xe8, 0xd4, 0xab, 0x35, 0x6c, 0xd2, 0x43, 0xe6, 0x87, 0x31, 0x60, 0xbe,
-	0x07, 0x10, 0x22, 0xf7, 0x5b, 0xa1, 0x8d, 0x71, 0x6a, 0x21, 0x4e, 0xa9, 0x7f, 0xb5, 0xd6, 0xbe,
-	0x85, 0x6e, 0xd0, 0x16, 0xaa, 0xe7, 0x07, 0x93, 0x51, 0x77, 0x97, 0xf2, 0x83, 0x01, 0xea, 0x0e,
-	0x42, 0x30, 0x17, 0xb9, 0xa0, 0x6e, 0x10, 0x01, 0x8c, 0xef, 0x1a, 0xa4, 0xf6, 0x38, 0x86, 0x64,
-	0x05, 0x52, 0x1e, 0x75, 0x31, 0xa7, 0xe5, 0xb5, 0x95, 0xc9, 0xcd, 0x7f, 0xbf, 0x95, 0xb3, 0x30,
-	0xdd, 0xe2, 0x18, 0x72, 0xeb, 0x54, 0x3e, 0xaa, 0xac, 0x7e, 0x56, 0x51, 0x08, 0x52, 0x80, 0xbf,
-	0xea, 0x8c, 0x07, 0x0e, 0x3d, 0xae, 0xaa, 0x8a, 0x84, 0xaa, 0x48, 0x5e, 0x94, 0x13, 0x95, 0x4c,
-	0x9c, 0xd8, 0x96, 0xb8, 0x79, 0x48, 0xa3, 0x4b, 0x99, 0x93, 0x4b, 0x76, 0x01, 0x51, 0x84, 0x3c,
-	0x84, 0x8c, 0x1d, 0x22, 0x15, 0x58, 0x95, 0x7a, 0x72, 0xa9, 0xbc, 0xb6, 0x92, 0x29, 0xe9, 0x66,
-	0xbc, 0xf7, 0xb6, 0x58, 0x73, 0xb7, 0x2d, 0x56, 0x16, 0x27, 0x2b, 0x10, 0xd5, 0xc8, 0xa8, 0xec,
-	0xd0, 0x0a, 0xea, 0x9d, 0x0e, 0xe9, 0x2b, 0x76, 0x88, 0x6a, 0x64, 0xd4, 0xd8, 0x82, 0xec, 0x23,
-	0xd5, 0x4f, 0x8e, 0x5f, 0xc1, 0xd7, 0x2d, 0xe4, 0x82, 0xac, 0x42, 0x4a, 0x4e, 0xab, 0xb6, 0x90,
-	0x29, 0x2d, 0x99, 0x23, 0x9c, 0x60, 0xaa, 0x1a, 0x05, 0x35, 0x6e, 0xc1, 0xd4, 0x53, 0x14, 0xbd,
-	0x4d, 0xfe, 0xeb, 0x5b, 0x25, 0x5c, 0x94, 0x13, 0x3f, 0xca, 0xa9, 0xa8, 0x42, 0xc6, 0x8d, 0xf7,
-	0x1a, 0x64, 0xf7, 0x94, 0x90, 0xdf, 0xa3, 0x26, 0xf7, 0x3b, 0x4b, 0x90, 0x47, 0xae, 0x0e, 0xe2,
-	0x57, 0x4b, 0xd8, 0x92, 0xae, 0x78, 0x41, 0xf9, 0x41, 0x7b, 0x7e, 0xf9, 0xdb, 0x58, 0x83, 0xec,
-	0x63, 0x74, 0xb0, 0x5f, 0xc4, 0x38, 0xe9, 0xdb, 0x30, 0xf3, 0x9c, 0x71, 0x35, 0x2d, 0x6f, 0xd7,
-	0x2c, 0xc0, 0x64, 0x40, 0x1b, 0x58, 0xe5, 0xec, 0x24, 0x2a, 0x4c, 0x57, 0x26, 0x64, 0xe0, 0x25,
-	0x3b, 0x41, 0xb2, 0x04, 0xa0, 0x92, 0xc2, 0x3f, 0x40, 0x2f, 0xb2, 0x4a, 0x45, 0xc1, 0x77, 0x65,
-	0xc0, 0x08, 0x20, 0xdb, 0xd3, 0x8f, 0x07, 0xbe, 0xc7, 0x91, 0xac, 0x41, 0x5a, 0x39, 0x2f, 0xa7,
-	0xe5, 0x93, 0xe3, 0x57, 0x11, 0x61, 0x49, 0x01, 0xa6, 0x3d, 0x3c, 0x12, 0xd5, 0x21, 0xb6, 0xbf,
-	0x65, 0x78, 0xa7, 0xcd, 0x58, 0xfa, 0x94, 0x86, 0x89, 0x67, 0xf1, 0x7d, 0x27, 0x1f, 0x34, 0x80,
-	0xae, 0x09, 0x48, 0x71, 0x24, 0xd3, 0x90, 0x53, 0xf4, 0xcb, 0x55, 0x19, 0x77, 0xcf, 0xcb, 0x8b,
-	0x52, 0x98, 0xd9, 0x7b, 0x55, 0x6e, 0xa8, 0x88, 0xba, 0x02, 0xef, 0xbe, 0x7c, 0xfd, 0x98, 0xf8,
-	0xc7, 0x98, 0xea, 0xbc, 0x83, 0xd4, 0x14, 0x1b, 0x5a, 0x91, 0x1c, 0xc3, 0x9f, 0xb1, 0x9f, 0xc8,
-	0xf2, 0x48, 0x8e, 0x7e, 0xc7, 0x8d, 0x13, 0x53, 0x38, 0x2f, 0xab, 0xe3, 0x53, 0xa4, 0xf3, 0x64,
-	0xae, 0x43, 0x7a, 0x2a, 0xa3, 0x0f, 0xa2, 0x6b, 0x5e, 0x3c, 0x23, 0x6f, 0x35, 0x80, 0xae, 0x31,
-	0x2f, 0x59, 0xc7, 0x90, 0x7b, 0xc7, 0x29, 0x58, 0x56, 0xd4, 0xd7, 0x4a, 0x8b, 0x5d, 0x6a, 0xb5,
-	0x8b, 0x3e, 0x7e, 0x39, 0xfd, 0x11, 0x40, 0xd7, 0x95, 0x97, 0x28, 0x18, 0xb2, 0xae, 0x3e, 0x3b,
-	0xe4, 0xfb, 0x27, 0xf2, 0x55, 0x39, 0x30, 0x7c, 0x71, 0xe4, 0xf0, 0x27, 0x30, 0xd9, 0xb1, 0x22,
-	0xb9, 0x3e, 0x92, 0x78, 0xd0, 0xfe, 0x7a, 0xf1, 0x2a, 0xd0, 0xc8, 0xd9, 0xc6, 0xac, 0x12, 0x31,
-	0x43, 0x06, 0x8e, 0x5d, 0xcf, 0x7e, 0x2e, 0x4f, 0x39, 0xbe, 0x4d, 0x9d, 0xa6, 0xcf, 0xc5, 0xc6,
-	0x9d, 0xf5, 0xdb, 0xf7, 0x36, 0xf7, 0x60, 0xc1, 0xf6, 0xdd, 0x51, 0xbd, 0x77, 0xb4, 0x57, 0xeb,
-	0x0d, 0x26, 0x9a, 0xad, 0x9a, 0x69, 0xfb, 0xae, 0x15, 0xa1, 0x68, 0xc0, 0xb8, 0xd5, 0xa0, 0x01,
-	0xb3, 0x6f, 0x76, 0x3e, 0x71, 0x1c, 0xc3, 0x43, 0x0c, 0xad, 0x06, 0x7a, 0xd1, 0x5a, 0xfe, 0x50,
-	0x8f, 0xb5, 0x9f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xcd, 0x1b, 0x81, 0x53, 0x0c, 0x07, 0x00, 0x00,
+	// 798 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x4b, 0x6f, 0x23, 0x45,
+	0x10, 0xd6, 0xf8, 0xb1, 0xc4, 0xe5, 0xc5, 0xbb, 0x6e, 0x50, 0x62, 0x4f, 0xb2, 0x8b, 0x99, 0x83,
+	0x37, 0x58, 0x30, 0xa3, 0x38, 0x2b, 0x16, 0x82, 0x10, 0x38, 0x84, 0x20, 0x24, 0x40, 0xd1, 0x90,
+	0x5c, 0xb8, 0x58, 0xed, 0x71, 0xc5, 0x6e, 0x65, 0x5e, 0x4c, 0xb7, 0x43, 0x1e, 0x8a, 0x84, 0xe0,
+	0x17, 0x20, 0x7e, 0x03, 0x7f, 0x86, 0x2b, 0xb7, 0x9c, 0x72, 0xe0, 0xc4, 0x1f, 0x40, 0xe2, 0x84,
+	0xba, 0x7b, 0x3c, 0x7e, 0xc9, 0x89, 0x11, 0x27, 0x67, 0xaa, 0xbe, 0xaa, 0xfa, 0xbe, 0x7a, 0x74,
+	0xa0, 0x39, 0x88, 0xa2, 0x81, 0x8f, 0x0e, 0x1f, 0x46, 0x3f, 0x78, 0x94, 0xa3, 0x73, 0xbe, 0xd3,
+	0x43, 0x41, 0x77, 0x1c, 0xd6, 0xc7, 0x50, 0x30, 0x71, 0x69, 0xc7, 0x49, 0x24, 0x22, 0xb2, 0xa1,
+	0x71, 0xf6, 0x18, 0x67, 0xa7, 0x38, 0x73, 0x2b, 0x4d, 0x40, 0x63, 0xe6, 0xd0, 0x30, 0x8c, 0x04,
+	0x15, 0x2c, 0x0a, 0xb9, 0x0e, 0x33, 0x37, 0xa6, 0xbc, 0x9e, 0xcf, 0x30, 0x14, 0xa9, 0xe3, 0xad,
+	0x29, 0xc7, 0x29, 0x43, 0xbf, 0xdf, 0xed, 0xe1, 0x90, 0x9e, 0xb3, 0x28, 0x49, 0x01, 0xf5, 0x29,
+	0x40, 0x82, 0x3c, 0x1a, 0x25, 0x1e, 0xa6, 0xae, 0xe7, 0xa9, 0x4b, 0x7d, 0xf5, 0x46, 0xa7, 0x4e,
+	0x7f, 0x94, 0xa8, 0xaa, 0xa9, 0x7f, 0x73, 0xde, 0x8f, 0x41, 0x3c, 0x16, 0x62, 0x36, 0xe6, 0x9d,
+	0xba, 0x7a, 0x40, 0xf9, 0xd9, 0x1c, 0xb5, 0x0c, 0x21, 0x58, 0x80, 0x5c, 0xd0, 0x20, 0xd6, 0x00,
+	0xeb, 0x6f, 0x03, 0x0a, 0x27, 0x1c, 0x13, 0xb2, 0x0d, 0x85, 0x90, 0x06, 0x58, 0x33, 0x1a, 0xc6,
+	0x76, 0x69, 0xff, 0xcd, 0xbf, 0x3a, 0x55, 0x78, 0x32, 0xe2, 0x98, 0x70, 0xe7, 0x5a, 0xfe, 0x74,
+	0x59, 0xff, 0xc6, 0x55, 0x08, 0xd2, 0x84, 0xc7, 0x7d, 0xc6, 0x63, 0x9f, 0x5e, 0x76, 0x55, 0x44,
+	0x4e, 0x45, 0xe4, 0xef, 0x3a, 0x39, 0xb7, 0x9c, 0x3a, 0xbe, 0x91, 0xb8, 0x3a, 0x14, 0x31, 0xa0,
+	0xcc, 0xaf, 0xe5, 0x27, 0x00, 0x6d, 0x21, 0x9f, 0x42, 0xd9, 0x4b, 0x90, 0x0a, 0xec, 0x4a, 0x3e,
+	0xb5, 0x42, 0xc3, 0xd8, 0x2e, 0xb7, 0x4d, 0x3b, 0x9d, 0xcb, 0x98, 0xac, 0x7d, 0x3c, 0x26, 0x2b,
+	0x83, 0xf3, 0x2e, 0xe8, 0x18, 0x69, 0x95, 0x19, 0x46, 0x71, 0x3f, 0xcb, 0x50, 0x5c, 0x31, 0x83,
+	0x8e, 0x91, 0x56, 0xeb, 0x10, 0xaa, 0x9f, 0xa9, 0x7c, 0x52, 0xbe, 0x8b, 0xdf, 0x8f, 0x90, 0x0b,
+	0xb2, 0x03, 0x05, 0xa9, 0x56, 0x75, 0xa1, 0xdc, 0x7e, 0x66, 0x2f, 0xd9, 0x14, 0x5b, 0xc5, 0x28,
+	0xa8, 0xc5, 0xa0, 0xf2, 0x05, 0x8a, 0xe9, 0x24, 0xcf, 0x67, 0x5a, 0x09, 0x77, 0x9d, 0xdc, 0x3f,
+	0x9d, 0x82, 0x8e, 0x50, 0x0d, 0x7c, 0x05, 0xa5, 0x04, 0xa9, 0x9e, 0x53, 0x2d, 0xb7, 0x84, 0xf9,
+	0xa1, 0x1c, 0xe5, 0xd7, 0x94, 0x9f, 0xb9, 0x6b, 0x12, 0x2c, 0xff, 0xb2, 0x7e, 0x36, 0xa0, 0x7a,
+	0xa2, 0x14, 0xfc, 0x3f, 0xce, 0xe4, 0xa3, 0xac, 0x7b, 0x2b, 0x72, 0x48, 0x1b, 0xa7, 0x58, 0xec,
+	0x42, 0xf5, 0x00, 0x7d, 0x14, 0xf8, 0x1f, 0x34, 0x5b, 0x77, 0x06, 0x3c, 0xfd, 0x8a, 0x71, 0xd5,
+	0x27, 0x3e, 0x0e, 0xda, 0x84, 0x52, 0x4c, 0x07, 0xd8, 0xe5, 0xec, 0x4a, 0x47, 0x16, 0xdd, 0x35,
+	0x69, 0xf8, 0x96, 0x5d, 0x21, 0x79, 0x06, 0xa0, 0x9c, 0x22, 0x3a, 0xc3, 0x50, 0x2f, 0x99, 0xab,
+	0xe0, 0xc7, 0xd2, 0x40, 0x3e, 0x81, 0xca, 0xc4, 0xdd, 0x15, 0x42, 0xaf, 0x59, 0xb9, 0x5d, 0x5f,
+	0x50, 0x71, 0x90, 0x5e, 0x94, 0xfb, 0x38, 0x8b, 0x3e, 0x16, 0xfe, 0xec, 0x14, 0x0a, 0xab, 0x4f,
+	0x81, 0xac, 0xc3, 0xa3, 0x53, 0xe6, 0x0b, 0x4c, 0xd4, 0xd6, 0x95, 0xdc, 0xf4, 0xcb, 0x8a, 0xa1,
+	0x3a, 0xa5, 0x90, 0xc7, 0x51, 0xc8, 0x91, 0xec, 0x42, 0x51, 0x5d, 0x51, 0xcd, 0x68, 0xe4, 0x1f,
+	0x9e, 0x8e, 0xc6, 0x92, 0x26, 0x3c, 0x09, 0xf1, 0x42, 0x74, 0x17, 0xf4, 0xbf, 0x2e, 0xcd, 0x47,
+	0x63, 0x15, 0xed, 0xdf, 0x8a, 0xb0, 0xf6, 0x65, 0xfa, 0xb6, 0x91, 0x5f, 0x0c, 0x80, 0xc9, 0x42,
+	0x93, 0xd6, 0xd2, 0x4a, 0x0b, 0x5b, 0x6f, 0xde, 0xcf, 0xca, 0xfa, 0xe0, 0xb6, 0xb3, 0x25, 0x89,
+	0xd9, 0xd3, 0x67, 0xff, 0xae, 0xb2, 0xa8, 0x73, 0xfe, 0xe9, 0x8f, 0x3f, 0x7f, 0xcd, 0xbd, 0x61,
+	0x55, 0xb2, 0xf7, 0x56, 0xa9, 0xd8, 0x33, 0x5a, 0xe4, 0x12, 0x5e, 0x4b, 0x6f, 0x83, 0xbc, 0x58,
+	0x5a, 0x63, 0xf6, 0x7a, 0x1e, 0x22, 0xd3, 0xbc, 0xed, 0xa8, 0x8d, 0x52, 0x45, 0xeb, 0x64, 0x23,
+	0x2b, 0x7a, 0x2d, 0xad, 0x1f, 0xeb, 0x27, 0xab, 0x75, 0x43, 0x7e, 0x34, 0x00, 0x26, 0xb7, 0x72,
+	0x4f, 0x3b, 0x16, 0x0e, 0xea, 0x21, 0x06, 0x2f, 0x54, 0xe9, 0xb7, 0xdb, 0x5b, 0x93, 0xd2, 0xaa,
+	0x17, 0x33, 0xf5, 0xa5, 0xfa, 0x0b, 0x80, 0xc9, 0xa1, 0xdc, 0xc3, 0x60, 0xe1, 0x9a, 0xcc, 0xf5,
+	0x85, 0x45, 0xfc, 0x5c, 0x3e, 0xfb, 0x73, 0xe2, 0x5b, 0x4b, 0xc5, 0x5f, 0x41, 0x29, 0x5b, 0x45,
+	0xf2, 0xce, 0xd2, 0xc2, 0xf3, 0x07, 0x69, 0xb6, 0x56, 0x81, 0xea, 0xcd, 0xb6, 0xd6, 0x15, 0x89,
+	0xa7, 0x64, 0x6e, 0xec, 0x66, 0xf5, 0xf7, 0x4e, 0xc5, 0x8f, 0x3c, 0xea, 0x0f, 0x23, 0x2e, 0xf6,
+	0x5e, 0xbd, 0x7c, 0xff, 0xc3, 0xfd, 0x13, 0xd8, 0xf4, 0xa2, 0x60, 0x59, 0xee, 0x23, 0xe3, 0xbb,
+	0x97, 0x03, 0x26, 0x86, 0xa3, 0x9e, 0xed, 0x45, 0x81, 0xa3, 0x51, 0x34, 0x66, 0xdc, 0x19, 0xd0,
+	0x98, 0x79, 0xef, 0x65, 0xff, 0xce, 0x39, 0x26, 0xe7, 0x98, 0x38, 0x03, 0x0c, 0x75, 0x5b, 0x1e,
+	0xa9, 0x9f, 0xdd, 0x7f, 0x07, 0x00, 0x16, 0xeb, 0x1d, 0xc2, 0xf8, 0x07, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
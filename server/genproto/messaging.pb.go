@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
 	empty "github.com/golang/protobuf/ptypes/empty"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
@@ -188,10 +189,13 @@ func (m *CreateRoomRequest) GetRoom() *Room {
 // method.
 type GetRoomRequest struct {
 	// The resource name of the requested room.
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The fields of the room to return. If unset, all of them are. Fields
+	// within repeated fields cannot be selected.
+	ReadMask             *field_mask.FieldMask `protobuf:"bytes,2,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *GetRoomRequest) Reset()         { *m = GetRoomRequest{} }
@@ -226,6 +230,13 @@ func (m *GetRoomRequest) GetName() string {
 	return ""
 }
 
+func (m *GetRoomRequest) GetReadMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.ReadMask
+	}
+	return nil
+}
+
 // The request message for the google.showcase.v1beta1.Messaging\UpdateRoom
 // method.
 type UpdateRoomRequest struct {
@@ -329,10 +340,17 @@ type ListRoomsRequest struct {
 	// The value of google.showcase.v1beta1.ListRoomsResponse.next_page_token
 	// returned from the previous call to
 	// `google.showcase.v1beta1.Messaging\ListRooms` method.
-	PageToken            string   `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// How long after it was issued the page token is accepted. Zero accepts
+	// tokens of any age. If unset, the server's `page_token_ttl` setting
+	// applies.
+	PageTokenTtl *duration.Duration `protobuf:"bytes,3,opt,name=page_token_ttl,json=pageTokenTtl,proto3" json:"page_token_ttl,omitempty"`
+	// The fields of each room to return. If unset, all of them are. Fields
+	// within repeated fields cannot be selected.
+	ReadMask             *field_mask.FieldMask `protobuf:"bytes,4,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *ListRoomsRequest) Reset()         { *m = ListRoomsRequest{} }
@@ -374,6 +392,20 @@ func (m *ListRoomsRequest) GetPageToken() string {
 	return ""
 }
 
+func (m *ListRoomsRequest) GetPageTokenTtl() *duration.Duration {
+	if m != nil {
+		return m.PageTokenTtl
+	}
+	return nil
+}
+
+func (m *ListRoomsRequest) GetReadMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.ReadMask
+	}
+	return nil
+}
+
 // The response message for the google.showcase.v1beta1.Messaging\ListRooms
 // method.
 type ListRoomsResponse struct {
@@ -602,10 +634,13 @@ func (m *CreateBlurbRequest) GetBlurb() *Blurb {
 // method.
 type GetBlurbRequest struct {
 	// The resource name of the requested blurb.
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The fields of the blurb to return. If unset, all of them are. Fields
+	// within repeated fields cannot be selected.
+	ReadMask             *field_mask.FieldMask `protobuf:"bytes,2,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *GetBlurbRequest) Reset()         { *m = GetBlurbRequest{} }
@@ -640,6 +675,13 @@ func (m *GetBlurbRequest) GetName() string {
 	return ""
 }
 
+func (m *GetBlurbRequest) GetReadMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.ReadMask
+	}
+	return nil
+}
+
 // The request message for the google.showcase.v1beta1.Messaging\UpdateBlurb
 // method.
 type UpdateBlurbRequest struct {
@@ -746,10 +788,17 @@ type ListBlurbsRequest struct {
 	// The value of google.showcase.v1beta1.ListBlurbsResponse.next_page_token
 	// returned from the previous call to
 	// `google.showcase.v1beta1.Messaging\ListBlurbs` method.
-	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// How long after it was issued the page token is accepted. Zero accepts
+	// tokens of any age. If unset, the server's `page_token_ttl` setting
+	// applies.
+	PageTokenTtl *duration.Duration `protobuf:"bytes,4,opt,name=page_token_ttl,json=pageTokenTtl,proto3" json:"page_token_ttl,omitempty"`
+	// The fields of each blurb to return. If unset, all of them are. Fields
+	// within repeated fields cannot be selected.
+	ReadMask             *field_mask.FieldMask `protobuf:"bytes,5,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *ListBlurbsRequest) Reset()         { *m = ListBlurbsRequest{} }
@@ -798,6 +847,20 @@ func (m *ListBlurbsRequest) GetPageToken() string {
 	return ""
 }
 
+func (m *ListBlurbsRequest) GetPageTokenTtl() *duration.Duration {
+	if m != nil {
+		return m.PageTokenTtl
+	}
+	return nil
+}
+
+func (m *ListBlurbsRequest) GetReadMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.ReadMask
+	}
+	return nil
+}
+
 // The response message for the google.showcase.v1beta1.Messaging\ListBlurbs
 // method.
 type ListBlurbsResponse struct {
@@ -1321,112 +1384,115 @@ func init() {
 }
 
 var fileDescriptor_35445f3e29a2c31d = []byte{
-	// 1671 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0x4f, 0x6f, 0xdb, 0x46,
-	0x16, 0xf7, 0xc8, 0x92, 0x12, 0x3f, 0x25, 0xfe, 0x33, 0xf6, 0xda, 0x8e, 0x92, 0x38, 0x5e, 0x2e,
-	0x62, 0x2b, 0x8a, 0x2d, 0xc6, 0xb2, 0x93, 0x60, 0xb3, 0xd8, 0x45, 0x68, 0x5b, 0x49, 0x0c, 0x38,
-	0x8e, 0x41, 0xdb, 0x97, 0x2c, 0x76, 0xb5, 0x34, 0x35, 0x96, 0x89, 0x48, 0x24, 0x43, 0x52, 0x5e,
-	0xdb, 0x81, 0x17, 0x8b, 0xf6, 0xd0, 0x0f, 0xd0, 0x02, 0x45, 0x81, 0x9e, 0xfa, 0x05, 0x8a, 0x7e,
-	0x85, 0xa2, 0xa7, 0x1c, 0x7a, 0xc9, 0xcd, 0x40, 0x81, 0x1c, 0x7a, 0xca, 0x07, 0x28, 0x8a, 0x1c,
-	0x8a, 0x62, 0xfe, 0x48, 0x22, 0x25, 0x91, 0x92, 0x12, 0xa0, 0x27, 0x91, 0x33, 0xbf, 0x37, 0xef,
-	0xfd, 0xde, 0xbc, 0x37, 0xf3, 0x13, 0x61, 0xbe, 0x6c, 0x59, 0xe5, 0x0a, 0x91, 0xdd, 0x43, 0xeb,
-	0xbf, 0xba, 0xe6, 0x12, 0xf9, 0x68, 0x69, 0x9f, 0x78, 0xda, 0x92, 0x5c, 0x25, 0xae, 0xab, 0x95,
-	0x0d, 0xb3, 0x9c, 0xb3, 0x1d, 0xcb, 0xb3, 0xf0, 0x14, 0x07, 0xe6, 0xea, 0xc0, 0x9c, 0x00, 0xa6,
-	0xaf, 0x89, 0x15, 0x34, 0xdb, 0x90, 0x35, 0xd3, 0xb4, 0x3c, 0xcd, 0x33, 0x2c, 0xd3, 0xe5, 0x66,
-	0xe9, 0x29, 0xdf, 0xac, 0x5e, 0x31, 0x88, 0xe9, 0x89, 0x89, 0x1b, 0xbe, 0x89, 0x03, 0x83, 0x54,
-	0x4a, 0xc5, 0x7d, 0x72, 0xa8, 0x1d, 0x19, 0x96, 0x23, 0x00, 0x57, 0x7c, 0x00, 0x87, 0xb8, 0x56,
-	0xcd, 0xd1, 0x89, 0x98, 0xfa, 0x8b, 0x98, 0xaa, 0x58, 0x66, 0xd9, 0xa9, 0x99, 0xa6, 0x61, 0x96,
-	0x65, 0xcb, 0x26, 0x4e, 0xc0, 0xf3, 0x55, 0x01, 0x62, 0x6f, 0xfb, 0xb5, 0x03, 0x99, 0x54, 0x6d,
-	0xef, 0x44, 0x4c, 0xce, 0xb6, 0x4e, 0xf2, 0x10, 0xaa, 0x9a, 0xfb, 0xa2, 0x25, 0xbe, 0x06, 0xc2,
-	0x33, 0xaa, 0xc4, 0xf5, 0xb4, 0xaa, 0x2d, 0x00, 0x33, 0x02, 0xe0, 0xd8, 0xba, 0x4c, 0x1c, 0xc7,
-	0x72, 0x8a, 0x25, 0xe2, 0x69, 0x46, 0x45, 0xf8, 0x97, 0x7e, 0x43, 0x10, 0x57, 0x2d, 0xab, 0x8a,
-	0x33, 0x10, 0x37, 0xb5, 0x2a, 0x99, 0x46, 0xb3, 0x28, 0x33, 0xb4, 0x3a, 0xf1, 0x4e, 0x19, 0x83,
-	0x11, 0xc7, 0xb2, 0xaa, 0xae, 0xfc, 0x8a, 0xfe, 0x14, 0x8d, 0xd2, 0x99, 0xca, 0x10, 0x78, 0x0e,
-	0x2e, 0x95, 0x0c, 0xd7, 0xae, 0x68, 0x27, 0x45, 0x66, 0x11, 0x63, 0x16, 0x83, 0x6f, 0x95, 0x98,
-	0x9a, 0x12, 0x13, 0x5b, 0x14, 0x37, 0x0b, 0xa9, 0x12, 0x71, 0x75, 0xc7, 0xb0, 0x29, 0xe1, 0xe9,
-	0x41, 0x0a, 0x53, 0xfd, 0x43, 0xf8, 0x21, 0xa4, 0x74, 0x87, 0x68, 0x1e, 0x29, 0xd2, 0xb0, 0xa7,
-	0xe3, 0xb3, 0x28, 0x93, 0xca, 0xa7, 0x73, 0x62, 0x0f, 0xeb, 0x9c, 0x72, 0xbb, 0x75, 0x4e, 0xd4,
-	0xc9, 0xa0, 0x0a, 0xdc, 0x86, 0x8e, 0xd2, 0x15, 0x6a, 0x76, 0xa9, 0xb1, 0x42, 0xa2, 0xc7, 0x15,
-	0xb8, 0x0d, 0x1d, 0x95, 0x1e, 0xc1, 0xd8, 0x1a, 0x5b, 0x8f, 0x66, 0x41, 0x25, 0x2f, 0x6b, 0xc4,
-	0xf5, 0xf0, 0x12, 0xc4, 0x29, 0x69, 0x96, 0x8c, 0x54, 0xfe, 0x7a, 0x2e, 0xa4, 0xaa, 0x72, 0xcc,
-	0x86, 0x41, 0xa5, 0x3b, 0x30, 0xfc, 0x98, 0x78, 0xfe, 0x45, 0x66, 0x02, 0x19, 0x85, 0xb7, 0x4a,
-	0xec, 0xbd, 0x12, 0xe7, 0x16, 0x74, 0x5c, 0xfa, 0x14, 0xc1, 0xd8, 0x1e, 0x0b, 0xe4, 0xe3, 0x5c,
-	0xe3, 0xbf, 0x35, 0x92, 0x40, 0x2b, 0x83, 0xed, 0x47, 0xa7, 0x24, 0x3c, 0xa2, 0xc5, 0xf3, 0x54,
-	0x73, 0x5f, 0xd4, 0xf9, 0xd3, 0x67, 0x69, 0x19, 0xc6, 0xd6, 0x49, 0x85, 0x04, 0x83, 0xe8, 0x16,
-	0xfa, 0x16, 0x8c, 0x6e, 0x1a, 0x2e, 0x63, 0xeb, 0xd6, 0x6d, 0xae, 0xc2, 0x90, 0xad, 0x95, 0x49,
-	0xd1, 0x35, 0x4e, 0xb9, 0x61, 0x42, 0xbd, 0x48, 0x07, 0x76, 0x8c, 0x53, 0x82, 0xaf, 0x03, 0xb0,
-	0x49, 0xcf, 0x7a, 0x41, 0x4c, 0x5e, 0x31, 0x2a, 0x83, 0xef, 0xd2, 0x01, 0xc9, 0x86, 0x31, 0xdf,
-	0x7a, 0xae, 0x6d, 0x99, 0x2e, 0xc1, 0xcb, 0x90, 0x60, 0x05, 0x38, 0x8d, 0x66, 0x07, 0xbb, 0xa7,
-	0x82, 0x63, 0xf1, 0x1c, 0x8c, 0x98, 0xe4, 0xd8, 0x2b, 0xb6, 0x79, 0xbb, 0x4c, 0x87, 0xb7, 0x1b,
-	0x1e, 0x7f, 0x45, 0x90, 0x58, 0xad, 0xd4, 0x9c, 0x7d, 0x8c, 0xfd, 0x5c, 0x45, 0x89, 0xcf, 0x40,
-	0xbc, 0xe6, 0x12, 0x47, 0x94, 0xb6, 0xe0, 0xbf, 0xe7, 0x12, 0x47, 0x65, 0xe3, 0x78, 0x02, 0xe2,
-	0x1e, 0x39, 0xf6, 0x78, 0x4d, 0x3f, 0x19, 0x50, 0xd9, 0x1b, 0x9e, 0x84, 0x84, 0x51, 0xd5, 0xca,
-	0xbc, 0x90, 0x2f, 0x3d, 0x19, 0x50, 0xf9, 0x6b, 0x6b, 0x99, 0x27, 0x3e, 0xba, 0xcc, 0x93, 0x7d,
-	0x97, 0xf9, 0xea, 0x10, 0x5c, 0xd0, 0x2d, 0xd3, 0x23, 0xa6, 0x27, 0xd5, 0x00, 0xf3, 0x8a, 0x67,
-	0xfc, 0xeb, 0xdb, 0x77, 0x0b, 0x92, 0xb6, 0xe6, 0x10, 0xd3, 0x13, 0x9b, 0x3e, 0xc6, 0x48, 0xa7,
-	0x18, 0x66, 0x9b, 0x4d, 0xa8, 0x02, 0x80, 0x57, 0x20, 0xb1, 0x4f, 0x87, 0x45, 0xa5, 0xcd, 0x84,
-	0x6e, 0x0c, 0x77, 0xc0, 0xc1, 0x52, 0x1e, 0x46, 0x1e, 0x13, 0x2f, 0xe0, 0xf3, 0x46, 0xa0, 0xcc,
-	0x52, 0xcc, 0x23, 0xdf, 0x15, 0x51, 0x67, 0x9f, 0x21, 0xc0, 0xbc, 0x45, 0x02, 0x76, 0x8d, 0x00,
-	0x50, 0x1f, 0x01, 0x7c, 0x5c, 0x9b, 0xdc, 0x05, 0xcc, 0xdb, 0xa4, 0x3f, 0x02, 0xa7, 0xbc, 0xb0,
-	0xd9, 0x90, 0xfb, 0x01, 0xa9, 0x0e, 0x34, 0x55, 0x2c, 0xb2, 0xa9, 0x06, 0x5b, 0x9b, 0xca, 0x03,
-	0xec, 0xf7, 0x2d, 0xba, 0xea, 0x1e, 0x24, 0x59, 0x3a, 0xea, 0x6d, 0xd5, 0x2d, 0x79, 0x02, 0xdd,
-	0x73, 0x63, 0x7d, 0x81, 0x60, 0x7c, 0x87, 0x68, 0x8e, 0x7e, 0x18, 0x24, 0x7d, 0x05, 0x12, 0x2f,
-	0x6b, 0xc4, 0x39, 0x11, 0x9c, 0xd9, 0x75, 0xc1, 0x47, 0xf0, 0x5c, 0x23, 0x1f, 0xbc, 0xdf, 0x86,
-	0x7b, 0x49, 0xc6, 0x60, 0x64, 0x32, 0xe2, 0xad, 0xc9, 0xd8, 0x84, 0x09, 0x7f, 0x54, 0x4f, 0x89,
-	0xa7, 0x95, 0x34, 0x4f, 0xc3, 0x2b, 0x00, 0x0e, 0xf1, 0x9c, 0x93, 0xa2, 0x61, 0x1e, 0x58, 0xa2,
-	0x9e, 0xfe, 0x54, 0x4f, 0x89, 0x63, 0xeb, 0x39, 0x95, 0xce, 0x6e, 0x98, 0x07, 0x96, 0x3a, 0xe4,
-	0xd4, 0x1f, 0xa5, 0xa3, 0xe0, 0x6a, 0x7f, 0x58, 0x72, 0xff, 0x07, 0xe3, 0x3b, 0x9e, 0x43, 0xb4,
-	0x6a, 0x30, 0xb7, 0x37, 0x03, 0x65, 0xd8, 0xa1, 0x9c, 0xf8, 0xa9, 0xf6, 0x10, 0x52, 0xe4, 0xd8,
-	0x36, 0x1c, 0x71, 0x8a, 0xc4, 0x7a, 0x39, 0x45, 0x62, 0x2a, 0x70, 0x1b, 0x76, 0x59, 0xbe, 0x45,
-	0x30, 0x11, 0x0c, 0x40, 0x10, 0xff, 0xb0, 0x8e, 0xdc, 0x84, 0xa4, 0xa6, 0x33, 0x71, 0x40, 0x63,
-	0x19, 0xce, 0xaf, 0x84, 0x9a, 0x75, 0x72, 0x9a, 0x53, 0x98, 0xad, 0x2a, 0xd6, 0x90, 0xd6, 0x21,
-	0xc9, 0x47, 0xf0, 0x24, 0x60, 0x65, 0x6d, 0x77, 0xe3, 0xd9, 0x56, 0x71, 0x6f, 0x6b, 0x67, 0xbb,
-	0xb0, 0xb6, 0xf1, 0x68, 0xa3, 0xb0, 0x3e, 0x3a, 0x80, 0x01, 0x92, 0x6b, 0x6a, 0x41, 0xd9, 0x2d,
-	0x8c, 0x22, 0xfa, 0xbc, 0xb7, 0xbd, 0x4e, 0x9f, 0x63, 0xf4, 0x79, 0xbd, 0xb0, 0x59, 0xd8, 0x2d,
-	0x8c, 0x0e, 0x4a, 0x59, 0xc0, 0x3b, 0xc4, 0x2c, 0xb5, 0xf0, 0x9b, 0x80, 0x04, 0x4d, 0x21, 0xdf,
-	0xd7, 0x21, 0x95, 0xbf, 0x48, 0x3f, 0x21, 0x18, 0x5e, 0xb3, 0x4c, 0x93, 0xe8, 0x5e, 0x7d, 0x2b,
-	0x9e, 0x41, 0x52, 0xb7, 0xcc, 0x03, 0xa3, 0x2c, 0x32, 0x71, 0x37, 0x94, 0x52, 0xd0, 0xb0, 0xfe,
-	0xba, 0xc6, 0x8c, 0x9f, 0x0c, 0xa8, 0x62, 0x19, 0x7c, 0xaf, 0xaf, 0xc3, 0x96, 0x5e, 0x3a, 0x0c,
-	0x9e, 0xbe, 0x0f, 0x97, 0x03, 0x4b, 0xfa, 0xba, 0x0c, 0x45, 0x75, 0x19, 0xbd, 0x29, 0x1c, 0x1e,
-	0x53, 0xfe, 0x9b, 0x71, 0x18, 0x7a, 0x5a, 0x57, 0xd8, 0xf8, 0x4b, 0x04, 0xd0, 0x94, 0x4a, 0x38,
-	0x1b, 0xce, 0xac, 0x55, 0x4f, 0xa5, 0xa3, 0xef, 0x6e, 0xe9, 0x1f, 0xe7, 0x8a, 0x44, 0xaf, 0xef,
-	0x9c, 0x5f, 0x57, 0x2e, 0xf0, 0x91, 0xa6, 0x5c, 0xfc, 0xe4, 0xcd, 0xcf, 0x9f, 0xc7, 0xc6, 0xa5,
-	0xe1, 0x86, 0xf8, 0x67, 0x37, 0xfe, 0x03, 0x94, 0xc5, 0x27, 0x70, 0x41, 0x68, 0x2f, 0x3c, 0x1f,
-	0xea, 0x29, 0xa8, 0xce, 0xba, 0x85, 0x34, 0x77, 0xae, 0xb0, 0xa6, 0x61, 0x4e, 0xaf, 0xe0, 0xa9,
-	0x86, 0xd3, 0x57, 0x74, 0xf4, 0xef, 0x5c, 0x19, 0x67, 0xcf, 0xf0, 0xff, 0x11, 0x40, 0x53, 0xc4,
-	0x45, 0x24, 0xa5, 0x4d, 0xe9, 0x75, 0x8b, 0x60, 0x9e, 0xb9, 0xfe, 0x73, 0xfe, 0x5a, 0xd3, 0x35,
-	0xcb, 0x48, 0xc0, 0x3f, 0x65, 0x7f, 0x0c, 0xd0, 0x54, 0x70, 0x11, 0x11, 0xb4, 0xc9, 0xbc, 0xf4,
-	0x64, 0x5b, 0xef, 0x17, 0xe8, 0xbf, 0x8f, 0x16, 0xf2, 0xd9, 0x50, 0xf2, 0xa7, 0x30, 0xd4, 0x90,
-	0x6d, 0xf8, 0x56, 0xa8, 0xe3, 0x56, 0xa9, 0x98, 0xce, 0xf6, 0x02, 0xe5, 0x9d, 0x27, 0x4d, 0xb2,
-	0x20, 0x46, 0x71, 0xcb, 0xb6, 0xe3, 0x5f, 0x10, 0xa4, 0x7c, 0x32, 0x06, 0xdf, 0xee, 0x52, 0x8e,
-	0xfe, 0x7b, 0x3b, 0xdd, 0xa5, 0x8b, 0xa4, 0xaf, 0xd0, 0xb9, 0x72, 0x8d, 0xf7, 0xc3, 0x02, 0x6b,
-	0xa7, 0x1c, 0x95, 0x7d, 0xe2, 0x91, 0x6a, 0xbd, 0x73, 0xe5, 0x7a, 0xd8, 0x34, 0xd3, 0x7c, 0x2c,
-	0xe8, 0x5d, 0x69, 0xb6, 0x99, 0x39, 0x0e, 0x6f, 0xe4, 0x4e, 0xe6, 0x27, 0xff, 0x03, 0x94, 0x7d,
-	0xbe, 0x28, 0x65, 0xda, 0x60, 0x74, 0x3d, 0x57, 0xce, 0xd2, 0xff, 0x75, 0x07, 0x46, 0x85, 0xf8,
-	0xe0, 0xf8, 0x3b, 0x04, 0x17, 0xeb, 0x42, 0x0a, 0x67, 0xa2, 0xca, 0xbd, 0x2f, 0xca, 0xff, 0xf4,
-	0xef, 0xf9, 0x16, 0x9e, 0xed, 0xbc, 0xe7, 0x22, 0x0e, 0x39, 0x7b, 0xf6, 0x3c, 0x8b, 0x33, 0x2d,
-	0x98, 0x96, 0xa0, 0x9b, 0x58, 0xfc, 0x3d, 0x82, 0x94, 0x4f, 0xc7, 0x45, 0x6c, 0x56, 0xbb, 0xda,
-	0xeb, 0x1a, 0xf9, 0x01, 0x0b, 0xf9, 0x3f, 0xf9, 0xb9, 0x66, 0x38, 0x7c, 0x27, 0x3a, 0x07, 0x4e,
-	0x53, 0xbe, 0x9c, 0xcf, 0x75, 0x04, 0x87, 0x32, 0xa0, 0x89, 0xff, 0x16, 0x41, 0xca, 0xa7, 0x01,
-	0x23, 0x48, 0xb4, 0x2b, 0xc5, 0xd0, 0x56, 0x0b, 0xa6, 0x3d, 0xdb, 0x43, 0xda, 0xb3, 0xbd, 0xa7,
-	0xfd, 0x07, 0x04, 0xd0, 0x94, 0x80, 0x38, 0xba, 0xed, 0x02, 0x92, 0x22, 0x7d, 0xbb, 0x27, 0xac,
-	0xe8, 0xd1, 0x7f, 0x9d, 0x2b, 0xe2, 0xf6, 0x68, 0xaf, 0x9e, 0xce, 0x75, 0x1f, 0xac, 0x9e, 0xe8,
-	0xa2, 0xc7, 0xef, 0x11, 0x5c, 0xf2, 0xcb, 0x2d, 0xbc, 0x10, 0xae, 0x13, 0xda, 0x95, 0x67, 0xf3,
-	0x9c, 0xf5, 0x7d, 0x88, 0xc9, 0x3d, 0xab, 0x7f, 0x88, 0x91, 0xbe, 0x46, 0xaf, 0x95, 0x85, 0x10,
-	0x3d, 0xd7, 0x51, 0x33, 0x9e, 0x2b, 0x5c, 0xb7, 0x32, 0xae, 0x45, 0x69, 0x3e, 0x84, 0xeb, 0x62,
-	0xa3, 0x69, 0x5d, 0xb6, 0x08, 0xad, 0xbb, 0x25, 0x49, 0x0e, 0x61, 0xbd, 0xd8, 0xd6, 0xea, 0xdc,
-	0x0a, 0xbf, 0xa1, 0xe4, 0x7d, 0xea, 0x27, 0x8a, 0x7c, 0xbb, 0x34, 0x4c, 0x2f, 0xf6, 0x25, 0xa9,
-	0xa4, 0x12, 0x23, 0xf5, 0x6f, 0xe9, 0x66, 0xc8, 0x91, 0xdf, 0x08, 0x8e, 0x19, 0x53, 0x4a, 0x79,
-	0x69, 0x31, 0xba, 0x1e, 0xdb, 0x6d, 0xee, 0x20, 0xfc, 0x23, 0x02, 0x68, 0xca, 0xac, 0xfe, 0x0e,
-	0xef, 0xdb, 0x11, 0xbb, 0xdf, 0x2a, 0xdc, 0x3a, 0x11, 0x0a, 0x39, 0x89, 0x5d, 0x62, 0x96, 0xda,
-	0x08, 0x75, 0x39, 0x8e, 0x85, 0x4d, 0x06, 0x61, 0x02, 0x17, 0x84, 0xdc, 0x8a, 0x90, 0x20, 0x41,
-	0xc9, 0xd7, 0xe7, 0xde, 0x64, 0xd0, 0x1d, 0x94, 0x1e, 0x7b, 0xad, 0x0c, 0x57, 0x2c, 0x5d, 0xab,
-	0x1c, 0x5a, 0xae, 0xf7, 0xe0, 0xfe, 0xca, 0xbd, 0xbf, 0xae, 0x5a, 0xef, 0x94, 0x29, 0x18, 0xe1,
-	0x11, 0xbe, 0xa2, 0x3f, 0x45, 0xa3, 0x74, 0x86, 0xd9, 0xd7, 0x0a, 0xb8, 0xaa, 0x5b, 0xd5, 0x30,
-	0x27, 0xdb, 0xe8, 0xf9, 0x4a, 0xd9, 0xf0, 0x0e, 0x6b, 0xfb, 0x39, 0xdd, 0xaa, 0xca, 0x1c, 0xa5,
-	0xd9, 0x86, 0x2b, 0x97, 0x35, 0xdb, 0xd0, 0x17, 0x1b, 0xdf, 0x5a, 0x5d, 0xe2, 0x1c, 0x11, 0x47,
-	0x2e, 0x13, 0x93, 0x9f, 0x5d, 0x49, 0xf6, 0xb3, 0xfc, 0x7b, 0x00, 0x00, 0x00, 0xff, 0xff, 0x3b,
-	0xef, 0xc2, 0x07, 0x95, 0x15, 0x00, 0x00,
+	// 1725 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xcb, 0x6e, 0xdb, 0xcc,
+	0x15, 0xf6, 0xc8, 0x92, 0x12, 0x1d, 0xf9, 0xf7, 0x65, 0xec, 0xfa, 0xa2, 0x24, 0xfe, 0x5d, 0x16,
+	0xb1, 0x15, 0xc5, 0x16, 0x63, 0xd9, 0x49, 0xd0, 0x14, 0xbd, 0xd0, 0xb6, 0x92, 0x18, 0x70, 0x1c,
+	0x83, 0xb6, 0x37, 0x29, 0x5a, 0x95, 0xa6, 0xc6, 0x32, 0x61, 0x89, 0x64, 0xc8, 0x91, 0x6b, 0x27,
+	0x48, 0x51, 0xb4, 0x8b, 0x3e, 0x40, 0x0b, 0x14, 0x05, 0xba, 0xea, 0x0b, 0x14, 0x7d, 0x85, 0xa2,
+	0x8b, 0x22, 0x8b, 0x6e, 0xb2, 0x33, 0x50, 0x20, 0x8b, 0xae, 0xb2, 0x2e, 0x8a, 0x22, 0x8b, 0xa2,
+	0x98, 0x8b, 0x24, 0x52, 0x32, 0x75, 0x89, 0x81, 0x7f, 0x45, 0x72, 0xe6, 0x9c, 0x39, 0xe7, 0x3b,
+	0x37, 0x7e, 0x24, 0x2c, 0x55, 0x1c, 0xa7, 0x52, 0x25, 0xaa, 0x7f, 0xe2, 0xfc, 0xdc, 0x34, 0x7c,
+	0xa2, 0x9e, 0xad, 0x1e, 0x11, 0x6a, 0xac, 0xaa, 0x35, 0xe2, 0xfb, 0x46, 0xc5, 0xb2, 0x2b, 0x79,
+	0xd7, 0x73, 0xa8, 0x83, 0x67, 0x84, 0x60, 0xbe, 0x21, 0x98, 0x97, 0x82, 0x99, 0xdb, 0xf2, 0x04,
+	0xc3, 0xb5, 0x54, 0xc3, 0xb6, 0x1d, 0x6a, 0x50, 0xcb, 0xb1, 0x7d, 0xa1, 0x96, 0x99, 0x09, 0xec,
+	0x9a, 0x55, 0x8b, 0xd8, 0x54, 0x6e, 0x7c, 0x1d, 0xd8, 0x38, 0xb6, 0x48, 0xb5, 0x5c, 0x3a, 0x22,
+	0x27, 0xc6, 0x99, 0xe5, 0x78, 0x52, 0x60, 0x2e, 0x20, 0xe0, 0x11, 0xdf, 0xa9, 0x7b, 0x26, 0x91,
+	0x5b, 0xdf, 0x91, 0x5b, 0x55, 0xc7, 0xae, 0x78, 0x75, 0xdb, 0xb6, 0xec, 0x8a, 0xea, 0xb8, 0xc4,
+	0x0b, 0x59, 0x9e, 0x97, 0x42, 0xfc, 0xe9, 0xa8, 0x7e, 0xac, 0x96, 0xeb, 0x42, 0x40, 0xee, 0xdf,
+	0x6a, 0xdf, 0x27, 0x35, 0x97, 0x5e, 0xc8, 0xcd, 0x85, 0xf6, 0x4d, 0xe1, 0x62, 0xcd, 0xf0, 0x4f,
+	0xdb, 0xfc, 0x6f, 0x4a, 0x50, 0xab, 0x46, 0x7c, 0x6a, 0xd4, 0xdc, 0x36, 0xfb, 0x9e, 0x6b, 0xaa,
+	0xc4, 0xf3, 0x1c, 0xaf, 0x54, 0x26, 0xd4, 0xb0, 0xaa, 0xd2, 0x3f, 0xe5, 0x7f, 0x08, 0xe2, 0xba,
+	0xe3, 0xd4, 0x70, 0x16, 0xe2, 0xb6, 0x51, 0x23, 0xb3, 0x68, 0x01, 0x65, 0x53, 0x1b, 0x53, 0x9f,
+	0xb4, 0x09, 0x18, 0xf3, 0x1c, 0xa7, 0xe6, 0xab, 0x6f, 0xd9, 0xa5, 0x64, 0x95, 0xdf, 0xe9, 0x5c,
+	0x02, 0x2f, 0xc2, 0x48, 0xd9, 0xf2, 0xdd, 0xaa, 0x71, 0x51, 0xe2, 0x1a, 0x31, 0xae, 0x31, 0xfc,
+	0x51, 0x8b, 0xe9, 0x69, 0xb9, 0xb1, 0xcb, 0xe4, 0x16, 0x20, 0x5d, 0x26, 0xbe, 0xe9, 0x59, 0x2e,
+	0xc3, 0x3b, 0x3b, 0xcc, 0xc4, 0xf4, 0xe0, 0x12, 0xfe, 0x11, 0xa4, 0x4d, 0x8f, 0x18, 0x94, 0x94,
+	0x98, 0xdb, 0xb3, 0xf1, 0x05, 0x94, 0x4d, 0x17, 0x32, 0x79, 0x99, 0xe3, 0x06, 0xa6, 0xfc, 0x41,
+	0x03, 0x13, 0x33, 0x32, 0xac, 0x83, 0xd0, 0x61, 0xab, 0xec, 0x84, 0xba, 0x5b, 0x6e, 0x9e, 0x90,
+	0xe8, 0xf3, 0x04, 0xa1, 0xc3, 0x56, 0x95, 0xa7, 0x30, 0xb1, 0xc9, 0xcf, 0x63, 0x51, 0xd0, 0xc9,
+	0xeb, 0x3a, 0xf1, 0x29, 0x5e, 0x85, 0x38, 0x03, 0xcd, 0x83, 0x91, 0x2e, 0xdc, 0xc9, 0x47, 0x54,
+	0x5d, 0x9e, 0xeb, 0x70, 0x51, 0xc5, 0x82, 0xd1, 0x67, 0x84, 0x06, 0x0f, 0x99, 0x0f, 0x45, 0x14,
+	0x3e, 0x6a, 0xb1, 0xcf, 0x5a, 0x5c, 0x68, 0xf0, 0x38, 0x3e, 0x86, 0x94, 0x47, 0x0c, 0x91, 0xce,
+	0xd9, 0x58, 0x84, 0xe7, 0x4f, 0x59, 0xc6, 0x5f, 0x18, 0xfe, 0xa9, 0x7e, 0x93, 0x09, 0xb3, 0x3b,
+	0xe5, 0xd7, 0x08, 0x26, 0x0e, 0x39, 0x82, 0xeb, 0xf9, 0x8c, 0xbf, 0xd7, 0x8c, 0x5e, 0x9f, 0x3e,
+	0xc8, 0xc0, 0x71, 0x2f, 0xd6, 0x60, 0x62, 0x8b, 0x54, 0x09, 0x25, 0x03, 0x60, 0x56, 0xfe, 0x8e,
+	0x60, 0x7c, 0xc7, 0xf2, 0x79, 0x9c, 0xfc, 0x86, 0xd2, 0x2d, 0x48, 0xb9, 0x46, 0x85, 0x94, 0x7c,
+	0xeb, 0x8d, 0xd0, 0x4c, 0xe8, 0x37, 0xd9, 0xc2, 0xbe, 0xf5, 0x86, 0xe0, 0x3b, 0x00, 0x7c, 0x93,
+	0x3a, 0xa7, 0xc4, 0x16, 0xb5, 0xa6, 0x73, 0xf1, 0x03, 0xb6, 0x80, 0x7f, 0x08, 0xa3, 0xad, 0xed,
+	0x12, 0xa5, 0x55, 0x5e, 0x67, 0xe9, 0xc2, 0x5c, 0x07, 0x8a, 0x2d, 0xd9, 0x78, 0xfa, 0x48, 0x53,
+	0xfb, 0x80, 0x56, 0xc3, 0x59, 0x88, 0x0f, 0x90, 0x05, 0x17, 0x26, 0x02, 0x48, 0x7c, 0xd7, 0xb1,
+	0x7d, 0x82, 0xd7, 0x20, 0xc1, 0x9b, 0x66, 0x16, 0x2d, 0x0c, 0xf7, 0xce, 0x82, 0x90, 0xc5, 0x8b,
+	0x30, 0x66, 0x93, 0x73, 0x5a, 0xea, 0xc0, 0xf9, 0x15, 0x5b, 0xde, 0x6b, 0x78, 0xab, 0xfc, 0x17,
+	0x41, 0x62, 0xa3, 0x5a, 0xf7, 0x8e, 0x30, 0x0e, 0x86, 0x59, 0x96, 0xd3, 0x3c, 0xc4, 0xeb, 0x3e,
+	0xf1, 0x66, 0x63, 0xc1, 0xd0, 0x1f, 0xfa, 0xc4, 0xd3, 0xf9, 0x3a, 0x9e, 0x82, 0x38, 0x25, 0xe7,
+	0x54, 0xf4, 0xe1, 0xf3, 0x21, 0x9d, 0x3f, 0xe1, 0x69, 0x48, 0x58, 0x35, 0xa3, 0x22, 0x9a, 0x6f,
+	0xe4, 0xf9, 0x90, 0x2e, 0x1e, 0xdb, 0x5b, 0x33, 0x71, 0xed, 0xd6, 0x4c, 0x0e, 0xdc, 0x9a, 0x1b,
+	0x29, 0xb8, 0x61, 0x3a, 0x36, 0x25, 0x36, 0x55, 0xea, 0x80, 0x45, 0x97, 0x72, 0xfc, 0x8d, 0xc2,
+	0xb9, 0x07, 0x49, 0xd7, 0xf0, 0x88, 0x4d, 0x65, 0xbd, 0x4d, 0x70, 0xd0, 0x69, 0x2e, 0xb3, 0xc7,
+	0x37, 0x74, 0x29, 0x80, 0xd7, 0x21, 0x71, 0xc4, 0x96, 0x65, 0x91, 0xcf, 0x47, 0x26, 0x46, 0x18,
+	0x10, 0xc2, 0xca, 0x29, 0x8c, 0x3d, 0x23, 0x34, 0x64, 0xf3, 0xeb, 0x50, 0x85, 0xa7, 0xb9, 0x45,
+	0x91, 0x95, 0xeb, 0xb6, 0xf5, 0x6f, 0x10, 0x60, 0xd1, 0xd6, 0x21, 0x83, 0x4d, 0xcf, 0xd1, 0x00,
+	0x9e, 0x5f, 0xaf, 0xb5, 0x1f, 0x02, 0x16, 0xad, 0x3d, 0x10, 0x72, 0xe5, 0xdf, 0x48, 0xb4, 0x04,
+	0x5f, 0xf3, 0xbf, 0x20, 0x49, 0xa1, 0x41, 0x10, 0xeb, 0x3a, 0x08, 0x86, 0x7b, 0x0f, 0x82, 0xf8,
+	0x35, 0x06, 0x41, 0x62, 0x80, 0xbc, 0x51, 0xc0, 0x41, 0xd4, 0x72, 0x12, 0x3c, 0x82, 0x24, 0xcf,
+	0x44, 0x63, 0x14, 0xf4, 0xca, 0x9b, 0x94, 0xee, 0x7b, 0x18, 0xfc, 0x0e, 0xc1, 0xe4, 0x3e, 0x31,
+	0x3c, 0xf3, 0x24, 0x1c, 0xee, 0x39, 0x48, 0xbc, 0xae, 0x13, 0xef, 0x62, 0x16, 0xb5, 0x5e, 0xcb,
+	0x62, 0x05, 0x2f, 0x36, 0x33, 0x21, 0x66, 0xc4, 0x68, 0x3f, 0x69, 0x18, 0xee, 0x9a, 0x86, 0x78,
+	0x5b, 0x1a, 0x94, 0x1d, 0x98, 0x0a, 0x7a, 0xf5, 0x82, 0x50, 0xa3, 0x6c, 0x50, 0x03, 0xaf, 0x03,
+	0x78, 0x84, 0x7a, 0x17, 0x25, 0xcb, 0x3e, 0x76, 0x64, 0x29, 0x7f, 0xab, 0x11, 0x12, 0xcf, 0x35,
+	0xf3, 0x3a, 0xdb, 0xdd, 0xb6, 0x8f, 0x1d, 0x3d, 0xe5, 0x35, 0x6e, 0x95, 0xb3, 0xf0, 0x69, 0xdf,
+	0x58, 0x70, 0x7f, 0x01, 0x93, 0xfb, 0xd4, 0x23, 0x46, 0x2d, 0x1c, 0xdb, 0xbb, 0xa1, 0x0e, 0xb8,
+	0xa2, 0x90, 0xf9, 0x36, 0x9b, 0x7c, 0xe4, 0xdc, 0xb5, 0x3c, 0x39, 0xf9, 0x62, 0xfd, 0x4c, 0xbe,
+	0x98, 0x0e, 0x42, 0x87, 0xad, 0x2a, 0x1f, 0x11, 0x4c, 0x85, 0x1d, 0x90, 0xc0, 0xbf, 0x6c, 0x18,
+	0xec, 0x40, 0xd2, 0x30, 0x39, 0x09, 0x63, 0xbe, 0x8c, 0x16, 0xd6, 0x23, 0xd5, 0xae, 0x32, 0x9a,
+	0xd7, 0xb8, 0xae, 0x2e, 0xcf, 0x50, 0xb6, 0x20, 0x29, 0x56, 0xf0, 0x34, 0x60, 0x6d, 0xf3, 0x60,
+	0xfb, 0xe5, 0x6e, 0xe9, 0x70, 0x77, 0x7f, 0xaf, 0xb8, 0xb9, 0xfd, 0x74, 0xbb, 0xb8, 0x35, 0x3e,
+	0x84, 0x01, 0x92, 0x9b, 0x7a, 0x51, 0x3b, 0x28, 0x8e, 0x23, 0x76, 0x7f, 0xb8, 0xb7, 0xc5, 0xee,
+	0x63, 0xec, 0x7e, 0xab, 0xb8, 0x53, 0x3c, 0x28, 0x8e, 0x0f, 0x2b, 0x39, 0xc0, 0xfb, 0xc4, 0x2e,
+	0xb7, 0xe1, 0x9b, 0x82, 0x04, 0x0b, 0xa1, 0xc8, 0x6b, 0x4a, 0x17, 0x0f, 0xca, 0x3f, 0x11, 0x8c,
+	0x6e, 0x3a, 0xb6, 0x4d, 0x4c, 0xda, 0x48, 0xc5, 0x4b, 0x48, 0x9a, 0x8e, 0x7d, 0x6c, 0x55, 0x64,
+	0x24, 0x1e, 0x46, 0x42, 0x0a, 0x2b, 0x36, 0x1e, 0x37, 0xb9, 0xf2, 0xf3, 0x21, 0x5d, 0x1e, 0x83,
+	0x1f, 0x0d, 0xf4, 0x82, 0x60, 0x2f, 0x4a, 0x2e, 0x9e, 0x79, 0x0c, 0x5f, 0x85, 0x8e, 0x0c, 0x74,
+	0x19, 0xea, 0xd6, 0x65, 0xec, 0xed, 0xe6, 0x09, 0x9f, 0x0a, 0x7f, 0x9a, 0x84, 0xd4, 0x8b, 0xc6,
+	0x97, 0x0e, 0xfe, 0x3d, 0x02, 0x68, 0x51, 0x52, 0x9c, 0x8b, 0x46, 0xd6, 0xce, 0x5b, 0x33, 0xdd,
+	0xf9, 0x86, 0xf2, 0x83, 0x4b, 0x4d, 0x61, 0x94, 0x23, 0x1f, 0xe4, 0xef, 0xcb, 0x62, 0xa5, 0x45,
+	0xcb, 0x7f, 0xf5, 0xe1, 0x5f, 0xbf, 0x8d, 0x4d, 0x2a, 0xa3, 0xcd, 0x8f, 0x30, 0x26, 0xe0, 0x3f,
+	0x41, 0x39, 0x7c, 0x01, 0x37, 0x24, 0xc7, 0xc5, 0x4b, 0x91, 0x96, 0xc2, 0x2c, 0xb8, 0x97, 0x4b,
+	0x8b, 0x97, 0x1a, 0x6f, 0x1a, 0x6e, 0x74, 0x0e, 0xcf, 0x34, 0x8d, 0xbe, 0x65, 0xab, 0xdf, 0x17,
+	0x5f, 0x20, 0xb9, 0x77, 0xf8, 0x97, 0x08, 0xa0, 0xc5, 0x79, 0xbb, 0x04, 0xa5, 0x83, 0x18, 0xf7,
+	0xf2, 0x60, 0x89, 0x9b, 0xfe, 0x76, 0xe1, 0x76, 0xcb, 0x34, 0x8f, 0x48, 0xc8, 0x3e, 0x43, 0x7f,
+	0x0e, 0xd0, 0x22, 0xbc, 0x5d, 0x3c, 0xe8, 0x60, 0xc5, 0x99, 0xe9, 0x8e, 0xde, 0x2f, 0xb2, 0xaf,
+	0xbc, 0x36, 0xf0, 0xb9, 0x48, 0xf0, 0x6f, 0x20, 0xd5, 0xa4, 0x9a, 0xf8, 0x5e, 0xa4, 0xe1, 0x76,
+	0x62, 0x9d, 0xc9, 0xf5, 0x23, 0x2a, 0x3a, 0x4f, 0x99, 0xe6, 0x4e, 0x8c, 0xe3, 0xb6, 0xb4, 0xe3,
+	0xff, 0x20, 0x48, 0x07, 0xa8, 0x17, 0xbe, 0xdf, 0xa3, 0x1c, 0x83, 0x94, 0x21, 0xd3, 0xa3, 0x8b,
+	0x94, 0x3f, 0xa0, 0x4b, 0xed, 0xb6, 0xe8, 0x87, 0x65, 0xde, 0x4e, 0xf9, 0xba, 0x4f, 0x3c, 0x79,
+	0xcb, 0xf8, 0xe9, 0xa5, 0x76, 0x27, 0x6a, 0x9b, 0xf3, 0x54, 0xee, 0xf4, 0x81, 0xb2, 0xd0, 0x8a,
+	0x9c, 0x10, 0x6f, 0xc6, 0x4e, 0xe5, 0xc2, 0xac, 0x7a, 0x5f, 0xad, 0x28, 0xd9, 0x0e, 0x31, 0x76,
+	0x9e, 0xaf, 0xe6, 0xd8, 0xf7, 0xf3, 0xb1, 0x55, 0x25, 0x01, 0x71, 0xfc, 0x17, 0x04, 0x37, 0x1b,
+	0xe4, 0x0f, 0x67, 0xbb, 0x95, 0xfb, 0x40, 0x90, 0x7f, 0x1c, 0xcc, 0xf9, 0x2e, 0x5e, 0xb8, 0x3a,
+	0xe7, 0xd2, 0x0f, 0x35, 0xf7, 0xee, 0x55, 0x0e, 0x67, 0xdb, 0x64, 0xda, 0x9c, 0x6e, 0xc9, 0xe2,
+	0xbf, 0x22, 0x48, 0x07, 0x28, 0x64, 0x97, 0x64, 0x75, 0x12, 0xcd, 0x9e, 0x9e, 0x1f, 0x73, 0x97,
+	0x7f, 0x56, 0x58, 0x6c, 0xb9, 0x23, 0x32, 0x71, 0xb5, 0xe3, 0x2c, 0xe4, 0x6b, 0x85, 0xfc, 0x95,
+	0xc2, 0x91, 0x08, 0x58, 0xe0, 0xff, 0x8c, 0x20, 0x1d, 0xa0, 0x9f, 0x5d, 0x40, 0x74, 0x92, 0xd4,
+	0xc8, 0x56, 0x0b, 0x87, 0x3d, 0xd7, 0x47, 0xd8, 0x73, 0xfd, 0x87, 0xfd, 0x6f, 0x08, 0xa0, 0x45,
+	0x01, 0x71, 0xf7, 0xb6, 0x0b, 0x51, 0x8a, 0xcc, 0xfd, 0xbe, 0x64, 0x65, 0x8f, 0xfe, 0xe4, 0x52,
+	0x93, 0x6f, 0x8f, 0xce, 0xea, 0xb9, 0xba, 0xee, 0xc3, 0xd5, 0xd3, 0xbd, 0xe8, 0xf1, 0x67, 0x04,
+	0x23, 0x41, 0xba, 0x85, 0x97, 0xa3, 0x79, 0x42, 0x27, 0xf3, 0x6c, 0xcd, 0xd9, 0xc0, 0x0f, 0xb1,
+	0xfc, 0xcb, 0xc6, 0x0f, 0x31, 0xe5, 0x8f, 0xe8, 0xbd, 0xb6, 0x1c, 0xc1, 0xe7, 0xae, 0xe4, 0x8c,
+	0x97, 0x9a, 0xe0, 0xad, 0x1c, 0x6b, 0x49, 0x59, 0x8a, 0xc0, 0xba, 0xd2, 0x6c, 0x5a, 0x9f, 0x1f,
+	0xc2, 0xea, 0x6e, 0x55, 0x51, 0x23, 0x50, 0xaf, 0x74, 0xb4, 0xba, 0xd0, 0xc2, 0x1f, 0x18, 0xf8,
+	0x00, 0xfb, 0xe9, 0x06, 0xbe, 0x93, 0x1a, 0x66, 0x56, 0x06, 0xa2, 0x54, 0x4a, 0x99, 0x83, 0xfa,
+	0xa9, 0x72, 0x37, 0x62, 0xe4, 0x37, 0x9d, 0xe3, 0xca, 0x0c, 0x52, 0x41, 0x59, 0xe9, 0x5e, 0x8f,
+	0x9d, 0x3a, 0x0f, 0x10, 0xfe, 0x07, 0x02, 0x68, 0xd1, 0xac, 0xc1, 0x86, 0xf7, 0xfd, 0x2e, 0xd9,
+	0xb7, 0xcb, 0xbd, 0x01, 0x45, 0x4c, 0x62, 0x9f, 0xd8, 0xe5, 0x0e, 0x40, 0x3d, 0xc6, 0xb1, 0xd4,
+	0xc9, 0x22, 0x4c, 0xe0, 0x86, 0xa4, 0x5b, 0x5d, 0x28, 0x48, 0x98, 0xf2, 0x0d, 0x98, 0x9b, 0x2c,
+	0x7a, 0x80, 0x32, 0x13, 0xef, 0xb5, 0xd1, 0xaa, 0x63, 0x1a, 0xd5, 0x13, 0xc7, 0xa7, 0x4f, 0x1e,
+	0xaf, 0x3f, 0xfa, 0xee, 0x86, 0xf3, 0x49, 0x9b, 0x81, 0x31, 0xe1, 0xe1, 0x5b, 0x76, 0x61, 0xbf,
+	0x44, 0x31, 0xff, 0xc3, 0x02, 0xb7, 0x4c, 0xa7, 0x16, 0x65, 0x64, 0x0f, 0xbd, 0x5a, 0xaf, 0x58,
+	0xf4, 0xa4, 0x7e, 0x94, 0x37, 0x9d, 0x9a, 0x2a, 0xa4, 0x0c, 0xd7, 0xf2, 0xd5, 0x8a, 0xe1, 0x5a,
+	0xe6, 0x4a, 0x43, 0x5e, 0xf5, 0x89, 0x77, 0x46, 0x3c, 0xb5, 0x42, 0x6c, 0x31, 0xbb, 0x92, 0xfc,
+	0xb2, 0xf6, 0xff, 0x01, 0x00, 0x71, 0xc5, 0xb2, 0x2e, 0x1d, 0x17, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
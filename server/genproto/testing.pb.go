@@ -7,8 +7,14 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	descriptor "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	any "github.com/golang/protobuf/ptypes/any"
+	duration "github.com/golang/protobuf/ptypes/duration"
 	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
+	code "google.golang.org/genproto/googleapis/rpc/code"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -26,6 +32,41 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// The resource name patterns understood by the ParseResourceNames method.
+// Segments are separated by `/` and must not be empty. Variable segments are
+// percent-decoded, so `%2F` is a slash within a value. An unencoded `*` in a
+// variable segment is a wildcard.
+type ResourceNamePattern int32
+
+const (
+	// No pattern.
+	ResourceNamePattern_RESOURCE_NAME_PATTERN_UNSPECIFIED ResourceNamePattern = 0
+	// `users/{user}`
+	ResourceNamePattern_USER ResourceNamePattern = 1
+	// `users/{user}/messages/{message}`
+	ResourceNamePattern_USER_MESSAGE ResourceNamePattern = 2
+)
+
+var ResourceNamePattern_name = map[int32]string{
+	0: "RESOURCE_NAME_PATTERN_UNSPECIFIED",
+	1: "USER",
+	2: "USER_MESSAGE",
+}
+
+var ResourceNamePattern_value = map[string]int32{
+	"RESOURCE_NAME_PATTERN_UNSPECIFIED": 0,
+	"USER":                              1,
+	"USER_MESSAGE":                      2,
+}
+
+func (x ResourceNamePattern) String() string {
+	return proto.EnumName(ResourceNamePattern_name, int32(x))
+}
+
+func (ResourceNamePattern) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{0}
+}
+
 // The specification versions understood by Showcase.
 type Session_Version int32
 
@@ -209,6 +250,41 @@ func (Issue_Severity) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_ec10fb45fe35d712, []int{9, 1}
 }
 
+// Whether the expected request arrived, and matched.
+type Expectation_State int32
+
+const (
+	Expectation_STATE_UNSPECIFIED Expectation_State = 0
+	// The request has not arrived yet.
+	Expectation_PENDING Expectation_State = 1
+	// The request arrived and matched over the masked fields.
+	Expectation_MATCHED Expectation_State = 2
+	// The request arrived and differed in `diffs`.
+	Expectation_MISMATCHED Expectation_State = 3
+)
+
+var Expectation_State_name = map[int32]string{
+	0: "STATE_UNSPECIFIED",
+	1: "PENDING",
+	2: "MATCHED",
+	3: "MISMATCHED",
+}
+
+var Expectation_State_value = map[string]int32{
+	"STATE_UNSPECIFIED": 0,
+	"PENDING":           1,
+	"MATCHED":           2,
+	"MISMATCHED":        3,
+}
+
+func (x Expectation_State) String() string {
+	return proto.EnumName(Expectation_State_name, int32(x))
+}
+
+func (Expectation_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{59, 0}
+}
+
 // A session is a suite of tests, generally being made in the context
 // of testing code generation.
 //
@@ -353,10 +429,14 @@ type ListSessionsRequest struct {
 	// The maximum number of sessions to return per page.
 	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	// The page token, for retrieving subsequent pages.
-	PageToken            string   `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// How long after it was issued the page token is accepted. Zero accepts
+	// tokens of any age. If unset, the server's `page_token_ttl` setting
+	// applies.
+	PageTokenTtl         *duration.Duration `protobuf:"bytes,3,opt,name=page_token_ttl,json=pageTokenTtl,proto3" json:"page_token_ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *ListSessionsRequest) Reset()         { *m = ListSessionsRequest{} }
@@ -398,6 +478,13 @@ func (m *ListSessionsRequest) GetPageToken() string {
 	return ""
 }
 
+func (m *ListSessionsRequest) GetPageTokenTtl() *duration.Duration {
+	if m != nil {
+		return m.PageTokenTtl
+	}
+	return nil
+}
+
 // Response for the ListSessions method.
 type ListSessionsResponse struct {
 	// The sessions being returned.
@@ -840,10 +927,14 @@ type ListTestsRequest struct {
 	// The maximum number of tests to return per page.
 	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	// The page token, for retrieving subsequent pages.
-	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// How long after it was issued the page token is accepted. Zero accepts
+	// tokens of any age. If unset, the server's `page_token_ttl` setting
+	// applies.
+	PageTokenTtl         *duration.Duration `protobuf:"bytes,4,opt,name=page_token_ttl,json=pageTokenTtl,proto3" json:"page_token_ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *ListTestsRequest) Reset()         { *m = ListTestsRequest{} }
@@ -892,6 +983,13 @@ func (m *ListTestsRequest) GetPageToken() string {
 	return ""
 }
 
+func (m *ListTestsRequest) GetPageTokenTtl() *duration.Duration {
+	if m != nil {
+		return m.PageTokenTtl
+	}
+	return nil
+}
+
 // The response for the ListTests method.
 type ListTestsResponse struct {
 	// The tests being returned.
@@ -1134,452 +1232,4624 @@ func (m *VerifyTestResponse) GetIssue() *Issue {
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("google.showcase.v1beta1.Session_Version", Session_Version_name, Session_Version_value)
-	proto.RegisterEnum("google.showcase.v1beta1.ReportSessionResponse_Result", ReportSessionResponse_Result_name, ReportSessionResponse_Result_value)
-	proto.RegisterEnum("google.showcase.v1beta1.Test_ExpectationLevel", Test_ExpectationLevel_name, Test_ExpectationLevel_value)
-	proto.RegisterEnum("google.showcase.v1beta1.Issue_Type", Issue_Type_name, Issue_Type_value)
-	proto.RegisterEnum("google.showcase.v1beta1.Issue_Severity", Issue_Severity_name, Issue_Severity_value)
-	proto.RegisterType((*Session)(nil), "google.showcase.v1beta1.Session")
-	proto.RegisterType((*CreateSessionRequest)(nil), "google.showcase.v1beta1.CreateSessionRequest")
-	proto.RegisterType((*GetSessionRequest)(nil), "google.showcase.v1beta1.GetSessionRequest")
-	proto.RegisterType((*ListSessionsRequest)(nil), "google.showcase.v1beta1.ListSessionsRequest")
-	proto.RegisterType((*ListSessionsResponse)(nil), "google.showcase.v1beta1.ListSessionsResponse")
-	proto.RegisterType((*DeleteSessionRequest)(nil), "google.showcase.v1beta1.DeleteSessionRequest")
-	proto.RegisterType((*ReportSessionRequest)(nil), "google.showcase.v1beta1.ReportSessionRequest")
-	proto.RegisterType((*ReportSessionResponse)(nil), "google.showcase.v1beta1.ReportSessionResponse")
-	proto.RegisterType((*Test)(nil), "google.showcase.v1beta1.Test")
-	proto.RegisterType((*Test_Blueprint)(nil), "google.showcase.v1beta1.Test.Blueprint")
-	proto.RegisterType((*Test_Blueprint_Invocation)(nil), "google.showcase.v1beta1.Test.Blueprint.Invocation")
-	proto.RegisterType((*Issue)(nil), "google.showcase.v1beta1.Issue")
-	proto.RegisterType((*ListTestsRequest)(nil), "google.showcase.v1beta1.ListTestsRequest")
-	proto.RegisterType((*ListTestsResponse)(nil), "google.showcase.v1beta1.ListTestsResponse")
-	proto.RegisterType((*TestRun)(nil), "google.showcase.v1beta1.TestRun")
-	proto.RegisterType((*DeleteTestRequest)(nil), "google.showcase.v1beta1.DeleteTestRequest")
-	proto.RegisterType((*VerifyTestRequest)(nil), "google.showcase.v1beta1.VerifyTestRequest")
-	proto.RegisterType((*VerifyTestResponse)(nil), "google.showcase.v1beta1.VerifyTestResponse")
+// The request for the GetOperationPollingReport method.
+type GetOperationPollingReportRequest struct {
+	// The name of the operation to report on.
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() {
-	proto.RegisterFile("google/showcase/v1beta1/testing.proto", fileDescriptor_ec10fb45fe35d712)
+func (m *GetOperationPollingReportRequest) Reset()         { *m = GetOperationPollingReportRequest{} }
+func (m *GetOperationPollingReportRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOperationPollingReportRequest) ProtoMessage()    {}
+func (*GetOperationPollingReportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{16}
 }
 
-var fileDescriptor_ec10fb45fe35d712 = []byte{
-	// 1415 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0x4f, 0x73, 0xdb, 0x44,
-	0x14, 0x47, 0x76, 0x12, 0xdb, 0xcf, 0x49, 0x6a, 0x6f, 0x4c, 0xe2, 0x3a, 0x6d, 0x49, 0xd5, 0x42,
-	0x53, 0x97, 0xc8, 0x8d, 0x5b, 0xd2, 0x92, 0xb6, 0x07, 0xc7, 0x51, 0x8b, 0xc1, 0xb1, 0xdd, 0xb5,
-	0x63, 0x28, 0x30, 0xe3, 0x51, 0x94, 0xad, 0xa3, 0xa9, 0x23, 0x09, 0xad, 0x9c, 0x36, 0x49, 0xcb,
-	0x81, 0x61, 0x7a, 0xe4, 0xc2, 0x81, 0xe1, 0x93, 0x30, 0x7c, 0x05, 0xae, 0x7c, 0x05, 0x4e, 0x9d,
-	0x61, 0x38, 0x71, 0xe9, 0x89, 0xd1, 0x6a, 0x25, 0xff, 0x8b, 0xff, 0x94, 0x93, 0xa5, 0x7d, 0xef,
-	0xf7, 0xfe, 0xfc, 0xf4, 0xf6, 0xbd, 0x67, 0xf8, 0xb0, 0x69, 0x18, 0xcd, 0x16, 0xc9, 0xd0, 0x03,
-	0xe3, 0xb9, 0xaa, 0x50, 0x92, 0x39, 0x5a, 0xdf, 0x23, 0xb6, 0xb2, 0x9e, 0xb1, 0x09, 0xb5, 0x35,
-	0xbd, 0x29, 0x99, 0x96, 0x61, 0x1b, 0x68, 0xc9, 0x55, 0x93, 0x3c, 0x35, 0x89, 0xab, 0xa5, 0x2e,
-	0x70, 0xbc, 0x62, 0x6a, 0x19, 0x45, 0xd7, 0x0d, 0x5b, 0xb1, 0x35, 0x43, 0xa7, 0x2e, 0x2c, 0xb5,
-	0xd4, 0x25, 0x55, 0x5b, 0x1a, 0xd1, 0x6d, 0x2e, 0x38, 0xdf, 0x25, 0xb0, 0x08, 0x35, 0xda, 0x96,
-	0x4a, 0xb8, 0x68, 0x99, 0x8b, 0xd8, 0xdb, 0x5e, 0xfb, 0x69, 0x86, 0x1c, 0x9a, 0xf6, 0xb1, 0x2b,
-	0x14, 0x7f, 0x13, 0x20, 0x54, 0x25, 0x94, 0x6a, 0x86, 0x8e, 0x6e, 0xc0, 0x94, 0xae, 0x1c, 0x92,
-	0xa4, 0xb0, 0x22, 0xac, 0x46, 0xb6, 0x96, 0xde, 0xe4, 0x12, 0x80, 0xa8, 0x2b, 0xa3, 0x99, 0x53,
-	0xfe, 0xf4, 0x0a, 0x33, 0x25, 0xb4, 0x05, 0xa1, 0x23, 0x62, 0x39, 0x27, 0xc9, 0xc0, 0x8a, 0xb0,
-	0x3a, 0x9f, 0x5d, 0x95, 0x86, 0xa4, 0x24, 0x71, 0xfb, 0x52, 0xdd, 0xd5, 0xc7, 0x1e, 0x50, 0xbc,
-	0x07, 0x21, 0x7e, 0x86, 0x96, 0x60, 0xa1, 0x2e, 0xe3, 0x6a, 0xa1, 0x5c, 0x6a, 0xec, 0x96, 0xaa,
-	0x15, 0x39, 0x5f, 0x78, 0x58, 0x90, 0xb7, 0x63, 0xef, 0xa1, 0x39, 0x88, 0xd4, 0xd7, 0x1b, 0xc5,
-	0x5c, 0x4d, 0xae, 0xd6, 0x62, 0x02, 0x0a, 0xc3, 0x54, 0x7d, 0xbd, 0x71, 0x33, 0x16, 0x10, 0x31,
-	0x24, 0xf2, 0x16, 0x51, 0x6c, 0xc2, 0xcd, 0x63, 0xf2, 0x5d, 0x9b, 0x50, 0x1b, 0x6d, 0x42, 0x88,
-	0x87, 0xca, 0x12, 0x89, 0x66, 0x57, 0xc6, 0x05, 0x86, 0x3d, 0x80, 0x78, 0x0b, 0xe2, 0x8f, 0x88,
-	0xdd, 0x67, 0xf0, 0x52, 0x0f, 0x2d, 0xf0, 0x36, 0xe7, 0x11, 0xe6, 0x32, 0x21, 0x3e, 0x86, 0x85,
-	0xa2, 0x46, 0x3d, 0x14, 0xf5, 0x60, 0xcb, 0x10, 0x31, 0x95, 0x26, 0x69, 0x50, 0xed, 0xc4, 0xc5,
-	0x4e, 0xe3, 0xb0, 0x73, 0x50, 0xd5, 0x4e, 0x08, 0xba, 0x08, 0xc0, 0x84, 0xb6, 0xf1, 0x8c, 0xb8,
-	0x04, 0x46, 0x30, 0x53, 0xaf, 0x39, 0x07, 0xe2, 0x4b, 0x48, 0xf4, 0x9a, 0xa4, 0xa6, 0xa1, 0x53,
-	0x82, 0xee, 0x43, 0xd8, 0xfb, 0x20, 0x49, 0x61, 0x25, 0x38, 0x51, 0x72, 0x3e, 0x02, 0x7d, 0x04,
-	0xe7, 0x74, 0xf2, 0xc2, 0x6e, 0x0c, 0x78, 0x9e, 0x73, 0x8e, 0x2b, 0xbe, 0xf7, 0x0d, 0x48, 0x6c,
-	0x93, 0x16, 0x19, 0x60, 0x76, 0x1c, 0x11, 0x1b, 0x90, 0xc0, 0xc4, 0x34, 0xac, 0x77, 0x25, 0xf0,
-	0x1f, 0x01, 0xde, 0xef, 0x03, 0xf2, 0x7c, 0x77, 0x60, 0xc6, 0x22, 0xb4, 0xdd, 0xb2, 0x19, 0x76,
-	0x3e, 0xfb, 0xc9, 0xd0, 0x6c, 0xcf, 0xc4, 0x4b, 0x98, 0x81, 0x31, 0x37, 0x82, 0x1e, 0x40, 0xc4,
-	0xb9, 0x85, 0x0d, 0xab, 0xad, 0xd3, 0x64, 0x60, 0x0c, 0x7f, 0x35, 0x42, 0x6d, 0xdc, 0xd6, 0x71,
-	0xd8, 0x76, 0x1f, 0xa8, 0xf8, 0x19, 0xcc, 0xb8, 0x06, 0xd1, 0x22, 0x20, 0x2c, 0x57, 0x77, 0x8b,
-	0xb5, 0xbe, 0x62, 0x05, 0x98, 0xa9, 0xe4, 0xaa, 0x55, 0x79, 0x3b, 0x26, 0x38, 0xcf, 0x0f, 0x73,
-	0x85, 0xa2, 0xbc, 0x1d, 0x0b, 0xa0, 0x79, 0x80, 0x42, 0x29, 0x5f, 0xde, 0xa9, 0x14, 0xe5, 0x9a,
-	0x1c, 0x0b, 0x8a, 0xff, 0x4e, 0xc3, 0x94, 0x63, 0x1f, 0xdd, 0xed, 0xa1, 0xe6, 0xea, 0x9b, 0xdc,
-	0x65, 0xf8, 0x60, 0xf0, 0xca, 0xb1, 0xde, 0x41, 0x33, 0xa7, 0xce, 0x8f, 0x77, 0xff, 0xbe, 0x81,
-	0x38, 0x79, 0x61, 0x12, 0xd5, 0xed, 0x0f, 0x8d, 0x16, 0x39, 0x22, 0x2d, 0x7e, 0x13, 0xa5, 0x91,
-	0x39, 0x49, 0x72, 0x07, 0x56, 0x74, 0x50, 0x38, 0x46, 0xfa, 0x4e, 0xd0, 0x0a, 0x44, 0xf7, 0x09,
-	0x55, 0x2d, 0xcd, 0x74, 0xce, 0x92, 0x41, 0x56, 0x25, 0xdd, 0x47, 0xe8, 0x11, 0xc0, 0x5e, 0xab,
-	0x4d, 0x4c, 0x4b, 0xd3, 0x6d, 0x9a, 0x9c, 0x62, 0x5c, 0x5e, 0x1b, 0xed, 0x77, 0xcb, 0xd3, 0xc7,
-	0x5d, 0xd0, 0xd4, 0xeb, 0x20, 0x44, 0x7c, 0x09, 0x2a, 0xf7, 0xf0, 0x71, 0xef, 0x4d, 0xee, 0x2e,
-	0x6c, 0x8c, 0xe1, 0x23, 0xd3, 0x31, 0x96, 0x39, 0xf5, 0x9f, 0x3d, 0x9a, 0xfa, 0x32, 0x09, 0x0c,
-	0x66, 0x52, 0x84, 0x90, 0xe5, 0x16, 0x2a, 0xcb, 0x33, 0x9a, 0xcd, 0x4e, 0x98, 0x86, 0x54, 0xd0,
-	0x8f, 0x0c, 0x95, 0xb1, 0x86, 0x3d, 0x13, 0x48, 0x85, 0x05, 0x65, 0x7f, 0x5f, 0x73, 0x0e, 0x95,
-	0x56, 0x83, 0x9f, 0x7a, 0x04, 0xfd, 0x1f, 0xcb, 0xa8, 0x63, 0x8e, 0xdf, 0x27, 0x9a, 0xaa, 0x02,
-	0x74, 0x34, 0xd0, 0x22, 0xcc, 0x1c, 0x12, 0xfb, 0xc0, 0xd8, 0x77, 0x59, 0xc3, 0xfc, 0x0d, 0xad,
-	0x39, 0xdd, 0xdb, 0xd2, 0x94, 0x96, 0x76, 0x42, 0xf6, 0xbd, 0x50, 0x18, 0x03, 0xb3, 0x38, 0xde,
-	0x91, 0x70, 0xab, 0xe2, 0x1e, 0xc4, 0xfa, 0x2b, 0x03, 0x5d, 0x86, 0x8b, 0xf2, 0x57, 0x15, 0x39,
-	0x5f, 0xcb, 0xd5, 0x9c, 0xce, 0x5c, 0x94, 0xeb, 0x72, 0xb1, 0xaf, 0xe4, 0x67, 0x21, 0x8c, 0xe5,
-	0xc7, 0xbb, 0x05, 0xcc, 0x8a, 0xfe, 0x1c, 0x44, 0xb1, 0x9c, 0x2f, 0xef, 0xec, 0xc8, 0xa5, 0x6d,
-	0x56, 0xf9, 0xb3, 0x10, 0x2e, 0x57, 0x1c, 0x70, 0xae, 0x18, 0x0b, 0x8a, 0xbf, 0x07, 0x60, 0xba,
-	0x40, 0x69, 0x9b, 0xa0, 0x3b, 0x30, 0x65, 0x1f, 0x9b, 0x84, 0xdf, 0xeb, 0x2b, 0x43, 0x89, 0x61,
-	0xda, 0x52, 0xed, 0xd8, 0x24, 0x98, 0x01, 0x50, 0xde, 0x69, 0x81, 0x47, 0xc4, 0xd2, 0xec, 0x63,
-	0x5e, 0xee, 0xd7, 0xc6, 0x80, 0xab, 0x5c, 0x1d, 0xfb, 0xc0, 0xf1, 0xf5, 0x2d, 0x62, 0x98, 0x72,
-	0x9c, 0xa2, 0x04, 0xc4, 0x6a, 0x4f, 0x2a, 0x72, 0x5f, 0xd2, 0x51, 0x08, 0x55, 0xbf, 0x28, 0x54,
-	0x2a, 0x2c, 0xe7, 0x28, 0x84, 0x2a, 0x72, 0x69, 0xbb, 0x50, 0x7a, 0x14, 0x0b, 0xa0, 0x14, 0x2c,
-	0x3a, 0x37, 0x1d, 0x63, 0x39, 0x5f, 0x6b, 0xe4, 0xcb, 0xa5, 0x87, 0x05, 0xbc, 0xc3, 0xc8, 0x8b,
-	0x05, 0xc5, 0xfb, 0x10, 0xf6, 0x62, 0x41, 0x49, 0x48, 0x54, 0xe5, 0xba, 0x8c, 0x0b, 0xb5, 0x27,
-	0x7d, 0xb6, 0x23, 0x30, 0x2d, 0x63, 0x5c, 0xc6, 0xae, 0xe5, 0x2f, 0x73, 0xb8, 0xc4, 0x2c, 0x8b,
-	0x16, 0xc4, 0x9c, 0x99, 0xe0, 0x54, 0x8a, 0x3f, 0x63, 0x44, 0x98, 0x31, 0x15, 0x8b, 0xe8, 0xf6,
-	0x19, 0xbd, 0x95, 0x4b, 0x7a, 0xe7, 0x50, 0x60, 0xe4, 0x1c, 0x0a, 0xf6, 0xcf, 0x21, 0x13, 0xe2,
-	0x5d, 0x3e, 0x79, 0x53, 0xbe, 0x05, 0xd3, 0xec, 0xfe, 0xf1, 0x09, 0x74, 0x71, 0x74, 0x07, 0x75,
-	0x75, 0x27, 0x9e, 0x3d, 0xdf, 0x42, 0x88, 0x37, 0x5e, 0xb4, 0x0c, 0x53, 0x0e, 0x96, 0xa7, 0x16,
-	0x7a, 0x9b, 0x63, 0x2d, 0x13, 0xb3, 0x43, 0x74, 0x1b, 0xa6, 0x35, 0xe7, 0xeb, 0x32, 0x2b, 0xd1,
-	0xec, 0xa5, 0xd1, 0x35, 0x80, 0x5d, 0x65, 0xf1, 0x26, 0xc4, 0xdd, 0xc9, 0xc6, 0x2c, 0xf9, 0x83,
-	0xba, 0xbb, 0xe7, 0x74, 0xfc, 0xb0, 0xd9, 0xb4, 0x07, 0xf1, 0x3a, 0xb1, 0xb4, 0xa7, 0xc7, 0x93,
-	0x22, 0x9c, 0xeb, 0xa8, 0xe8, 0xf4, 0x39, 0xb1, 0xf8, 0x55, 0xe3, 0x6f, 0x28, 0x09, 0x21, 0xf7,
-	0x89, 0x26, 0x83, 0x2b, 0xc1, 0xd5, 0x59, 0xec, 0xbd, 0x8a, 0x9f, 0x03, 0xea, 0xf6, 0xc1, 0x69,
-	0xf6, 0x33, 0x14, 0xde, 0x21, 0xc3, 0xec, 0xdf, 0x61, 0x97, 0x40, 0x4d, 0x6f, 0xa2, 0x1f, 0x05,
-	0x98, 0xeb, 0x59, 0x91, 0xd0, 0xda, 0x50, 0x23, 0x67, 0xad, 0x52, 0xa9, 0xb1, 0xcb, 0x85, 0x28,
-	0xfe, 0xf0, 0xe7, 0x5f, 0x3f, 0x07, 0x2e, 0x88, 0x71, 0x7f, 0xcd, 0xf5, 0xba, 0xf5, 0xa6, 0xb7,
-	0x54, 0xa1, 0xef, 0x01, 0x3a, 0x4b, 0x15, 0x4a, 0x0f, 0xb5, 0x39, 0xb0, 0x79, 0x4d, 0xee, 0x1f,
-	0xa5, 0x7c, 0xff, 0xa7, 0xce, 0x47, 0x78, 0xe0, 0xcf, 0x8c, 0xf4, 0x2b, 0xf4, 0x5a, 0x80, 0xd9,
-	0xee, 0x6d, 0x0a, 0x7d, 0x3c, 0xd4, 0xec, 0x19, 0x7b, 0x5c, 0x6a, 0x6d, 0x42, 0x6d, 0xf7, 0xb3,
-	0x89, 0xe7, 0x59, 0x44, 0x0b, 0x68, 0x90, 0x11, 0x74, 0x02, 0x73, 0x3d, 0x7b, 0xd5, 0x88, 0xcf,
-	0x71, 0xd6, 0xfe, 0x95, 0x5a, 0xf4, 0xd4, 0xbd, 0x4d, 0x5e, 0x92, 0x9d, 0x4d, 0xde, 0x23, 0x21,
-	0x3d, 0x8a, 0x84, 0x5f, 0x05, 0x98, 0xeb, 0xd9, 0x91, 0x46, 0x38, 0x3f, 0x6b, 0x89, 0x4b, 0x49,
-	0xef, 0xb6, 0x7a, 0x89, 0xd7, 0x59, 0x50, 0x57, 0xc4, 0xcb, 0xc3, 0x83, 0xda, 0xb4, 0x18, 0x12,
-	0xfd, 0x24, 0x40, 0xc4, 0x6f, 0x33, 0xe8, 0xfa, 0x48, 0xbe, 0xbb, 0xdb, 0x5f, 0x2a, 0x3d, 0x89,
-	0x2a, 0x8f, 0x27, 0xcd, 0xe2, 0xb9, 0x8a, 0xc4, 0x4e, 0x3c, 0x6e, 0x83, 0xec, 0x8e, 0xc8, 0x5d,
-	0x2c, 0xd0, 0x4b, 0x80, 0x4e, 0x9b, 0x18, 0x51, 0xb1, 0x03, 0xbd, 0x64, 0xe8, 0x27, 0xe2, 0xde,
-	0xd3, 0xe2, 0x50, 0x36, 0xf8, 0x4e, 0x93, 0x7e, 0x85, 0x7e, 0x11, 0x00, 0x3a, 0xfd, 0x60, 0x84,
-	0xfb, 0x81, 0xc6, 0x94, 0xba, 0x31, 0x91, 0x2e, 0x67, 0xe4, 0x26, 0x8b, 0x29, 0x2d, 0xae, 0x8e,
-	0x8f, 0x69, 0x53, 0x3d, 0x20, 0xea, 0xb3, 0x54, 0xfc, 0x8f, 0xdc, 0x7c, 0xcb, 0x50, 0x95, 0xd6,
-	0x81, 0x41, 0xed, 0xcd, 0x3b, 0xb7, 0x37, 0x3e, 0xdd, 0xda, 0x85, 0x65, 0xd5, 0x38, 0x1c, 0xe6,
-	0xb6, 0x22, 0x7c, 0x7d, 0xbb, 0xa9, 0xd9, 0x07, 0xed, 0x3d, 0x49, 0x35, 0x0e, 0x33, 0xae, 0x96,
-	0x62, 0x6a, 0x34, 0xd3, 0x54, 0x4c, 0x4d, 0x5d, 0xf3, 0xff, 0x24, 0x53, 0x62, 0x1d, 0x11, 0x2b,
-	0xd3, 0x24, 0xba, 0x4b, 0xdc, 0x0c, 0xfb, 0xb9, 0xf5, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x8e,
-	0x4b, 0x04, 0xc3, 0x4e, 0x0f, 0x00, 0x00,
+func (m *GetOperationPollingReportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOperationPollingReportRequest.Unmarshal(m, b)
+}
+func (m *GetOperationPollingReportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOperationPollingReportRequest.Marshal(b, m, deterministic)
+}
+func (m *GetOperationPollingReportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOperationPollingReportRequest.Merge(m, src)
+}
+func (m *GetOperationPollingReportRequest) XXX_Size() int {
+	return xxx_messageInfo_GetOperationPollingReportRequest.Size(m)
+}
+func (m *GetOperationPollingReportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOperationPollingReportRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_GetOperationPollingReportRequest proto.InternalMessageInfo
 
-// TestingClient is the client API for Testing service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type TestingClient interface {
-	// Creates a new testing session.
-	CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*Session, error)
-	// Gets a testing session.
-	GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*Session, error)
-	// Lists the current test sessions.
-	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
-	// Delete a test session.
-	DeleteSession(ctx context.Context, in *DeleteSessionRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// Report on the status of a session.
-	// This generates a report detailing which tests have been completed,
-	// and an overall rollup.
-	ReportSession(ctx context.Context, in *ReportSessionRequest, opts ...grpc.CallOption) (*ReportSessionResponse, error)
-	// List the tests of a sessesion.
-	ListTests(ctx context.Context, in *ListTestsRequest, opts ...grpc.CallOption) (*ListTestsResponse, error)
-	// Explicitly decline to implement a test.
-	//
-	// This removes the test from subsequent `ListTests` calls, and
-	// attempting to do the test will error.
-	//
-	// This method will error if attempting to delete a required test.
-	DeleteTest(ctx context.Context, in *DeleteTestRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// Register a response to a test.
-	//
-	// In cases where a test involves registering a final answer at the
-	// end of the test, this method provides the means to do so.
-	VerifyTest(ctx context.Context, in *VerifyTestRequest, opts ...grpc.CallOption) (*VerifyTestResponse, error)
+func (m *GetOperationPollingReportRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
 }
 
-type testingClient struct {
-	cc *grpc.ClientConn
+// The polling history of a long-running operation.
+type OperationPollingReport struct {
+	// The name of the operation.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The times at which the operation was polled, oldest first. Only the most
+	// recent 1000 polls are kept.
+	PollTimes []*timestamp.Timestamp `protobuf:"bytes,2,rep,name=poll_times,json=pollTimes,proto3" json:"poll_times,omitempty"`
+	// The intervals between consecutive polls. There is one fewer interval than
+	// there are poll times.
+	Intervals            []*duration.Duration `protobuf:"bytes,3,rep,name=intervals,proto3" json:"intervals,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func NewTestingClient(cc *grpc.ClientConn) TestingClient {
-	return &testingClient{cc}
+func (m *OperationPollingReport) Reset()         { *m = OperationPollingReport{} }
+func (m *OperationPollingReport) String() string { return proto.CompactTextString(m) }
+func (*OperationPollingReport) ProtoMessage()    {}
+func (*OperationPollingReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{17}
 }
 
-func (c *testingClient) CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*Session, error) {
-	out := new(Session)
-	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/CreateSession", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *OperationPollingReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OperationPollingReport.Unmarshal(m, b)
+}
+func (m *OperationPollingReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OperationPollingReport.Marshal(b, m, deterministic)
+}
+func (m *OperationPollingReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OperationPollingReport.Merge(m, src)
+}
+func (m *OperationPollingReport) XXX_Size() int {
+	return xxx_messageInfo_OperationPollingReport.Size(m)
+}
+func (m *OperationPollingReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_OperationPollingReport.DiscardUnknown(m)
 }
 
-func (c *testingClient) GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*Session, error) {
-	out := new(Session)
-	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/GetSession", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_OperationPollingReport proto.InternalMessageInfo
+
+func (m *OperationPollingReport) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return out, nil
+	return ""
 }
 
-func (c *testingClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
-	out := new(ListSessionsResponse)
-	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/ListSessions", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *OperationPollingReport) GetPollTimes() []*timestamp.Timestamp {
+	if m != nil {
+		return m.PollTimes
 	}
-	return out, nil
+	return nil
 }
 
-func (c *testingClient) DeleteSession(ctx context.Context, in *DeleteSessionRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/DeleteSession", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *OperationPollingReport) GetIntervals() []*duration.Duration {
+	if m != nil {
+		return m.Intervals
 	}
-	return out, nil
+	return nil
 }
 
-func (c *testingClient) ReportSession(ctx context.Context, in *ReportSessionRequest, opts ...grpc.CallOption) (*ReportSessionResponse, error) {
-	out := new(ReportSessionResponse)
-	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/ReportSession", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+// The request for the GetShowcaseDescriptors method.
+type GetShowcaseDescriptorsRequest struct {
+	// The fully qualified name of a service, e.g. `google.showcase.v1beta1.Echo`.
+	// If set, only the file that defines the service and its transitive
+	// dependencies are returned.
+	Service              string   `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *testingClient) ListTests(ctx context.Context, in *ListTestsRequest, opts ...grpc.CallOption) (*ListTestsResponse, error) {
-	out := new(ListTestsResponse)
-	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/ListTests", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *GetShowcaseDescriptorsRequest) Reset()         { *m = GetShowcaseDescriptorsRequest{} }
+func (m *GetShowcaseDescriptorsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetShowcaseDescriptorsRequest) ProtoMessage()    {}
+func (*GetShowcaseDescriptorsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{18}
 }
 
-func (c *testingClient) DeleteTest(ctx context.Context, in *DeleteTestRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/DeleteTest", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *GetShowcaseDescriptorsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetShowcaseDescriptorsRequest.Unmarshal(m, b)
+}
+func (m *GetShowcaseDescriptorsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetShowcaseDescriptorsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetShowcaseDescriptorsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetShowcaseDescriptorsRequest.Merge(m, src)
+}
+func (m *GetShowcaseDescriptorsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetShowcaseDescriptorsRequest.Size(m)
+}
+func (m *GetShowcaseDescriptorsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetShowcaseDescriptorsRequest.DiscardUnknown(m)
 }
 
-func (c *testingClient) VerifyTest(ctx context.Context, in *VerifyTestRequest, opts ...grpc.CallOption) (*VerifyTestResponse, error) {
-	out := new(VerifyTestResponse)
-	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/VerifyTest", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetShowcaseDescriptorsRequest proto.InternalMessageInfo
+
+func (m *GetShowcaseDescriptorsRequest) GetService() string {
+	if m != nil {
+		return m.Service
 	}
-	return out, nil
+	return ""
 }
 
-// TestingServer is the server API for Testing service.
-type TestingServer interface {
-	// Creates a new testing session.
-	CreateSession(context.Context, *CreateSessionRequest) (*Session, error)
-	// Gets a testing session.
-	GetSession(context.Context, *GetSessionRequest) (*Session, error)
-	// Lists the current test sessions.
-	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
-	// Delete a test session.
-	DeleteSession(context.Context, *DeleteSessionRequest) (*empty.Empty, error)
-	// Report on the status of a session.
-	// This generates a report detailing which tests have been completed,
-	// and an overall rollup.
-	ReportSession(context.Context, *ReportSessionRequest) (*ReportSessionResponse, error)
-	// List the tests of a sessesion.
-	ListTests(context.Context, *ListTestsRequest) (*ListTestsResponse, error)
-	// Explicitly decline to implement a test.
-	//
-	// This removes the test from subsequent `ListTests` calls, and
-	// attempting to do the test will error.
-	//
-	// This method will error if attempting to delete a required test.
-	DeleteTest(context.Context, *DeleteTestRequest) (*empty.Empty, error)
-	// Register a response to a test.
-	//
-	// In cases where a test involves registering a final answer at the
-	// end of the test, this method provides the means to do so.
-	VerifyTest(context.Context, *VerifyTestRequest) (*VerifyTestResponse, error)
+// The response for the GetShowcaseDescriptors method.
+type GetShowcaseDescriptorsResponse struct {
+	// The descriptors, ordered so that every file follows its dependencies.
+	FileDescriptorSet    *descriptor.FileDescriptorSet `protobuf:"bytes,1,opt,name=file_descriptor_set,json=fileDescriptorSet,proto3" json:"file_descriptor_set,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
 }
 
-// UnimplementedTestingServer can be embedded to have forward compatible implementations.
-type UnimplementedTestingServer struct {
+func (m *GetShowcaseDescriptorsResponse) Reset()         { *m = GetShowcaseDescriptorsResponse{} }
+func (m *GetShowcaseDescriptorsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetShowcaseDescriptorsResponse) ProtoMessage()    {}
+func (*GetShowcaseDescriptorsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{19}
 }
 
-func (*UnimplementedTestingServer) CreateSession(ctx context.Context, req *CreateSessionRequest) (*Session, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateSession not implemented")
-}
-func (*UnimplementedTestingServer) GetSession(ctx context.Context, req *GetSessionRequest) (*Session, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetSession not implemented")
-}
-func (*UnimplementedTestingServer) ListSessions(ctx context.Context, req *ListSessionsRequest) (*ListSessionsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+func (m *GetShowcaseDescriptorsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetShowcaseDescriptorsResponse.Unmarshal(m, b)
 }
-func (*UnimplementedTestingServer) DeleteSession(ctx context.Context, req *DeleteSessionRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteSession not implemented")
+func (m *GetShowcaseDescriptorsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetShowcaseDescriptorsResponse.Marshal(b, m, deterministic)
 }
-func (*UnimplementedTestingServer) ReportSession(ctx context.Context, req *ReportSessionRequest) (*ReportSessionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ReportSession not implemented")
+func (m *GetShowcaseDescriptorsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetShowcaseDescriptorsResponse.Merge(m, src)
 }
-func (*UnimplementedTestingServer) ListTests(ctx context.Context, req *ListTestsRequest) (*ListTestsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListTests not implemented")
+func (m *GetShowcaseDescriptorsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetShowcaseDescriptorsResponse.Size(m)
 }
-func (*UnimplementedTestingServer) DeleteTest(ctx context.Context, req *DeleteTestRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteTest not implemented")
+func (m *GetShowcaseDescriptorsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetShowcaseDescriptorsResponse.DiscardUnknown(m)
 }
-func (*UnimplementedTestingServer) VerifyTest(ctx context.Context, req *VerifyTestRequest) (*VerifyTestResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method VerifyTest not implemented")
+
+var xxx_messageInfo_GetShowcaseDescriptorsResponse proto.InternalMessageInfo
+
+func (m *GetShowcaseDescriptorsResponse) GetFileDescriptorSet() *descriptor.FileDescriptorSet {
+	if m != nil {
+		return m.FileDescriptorSet
+	}
+	return nil
 }
 
-func RegisterTestingServer(s *grpc.Server, srv TestingServer) {
-	s.RegisterService(&_Testing_serviceDesc, srv)
+// The request for the GetShowcaseSettings method.
+type GetShowcaseSettingsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func _Testing_CreateSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateSessionRequest)
+func (m *GetShowcaseSettingsRequest) Reset()         { *m = GetShowcaseSettingsRequest{} }
+func (m *GetShowcaseSettingsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetShowcaseSettingsRequest) ProtoMessage()    {}
+func (*GetShowcaseSettingsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{20}
+}
+
+func (m *GetShowcaseSettingsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetShowcaseSettingsRequest.Unmarshal(m, b)
+}
+func (m *GetShowcaseSettingsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetShowcaseSettingsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetShowcaseSettingsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetShowcaseSettingsRequest.Merge(m, src)
+}
+func (m *GetShowcaseSettingsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetShowcaseSettingsRequest.Size(m)
+}
+func (m *GetShowcaseSettingsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetShowcaseSettingsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetShowcaseSettingsRequest proto.InternalMessageInfo
+
+// The limits and defaults that the Showcase services enforce.
+type ShowcaseSettings struct {
+	// The most content, in bytes, that Echo.Collect buffers.
+	MaxCollectContentBytes int64 `protobuf:"varint,1,opt,name=max_collect_content_bytes,json=maxCollectContentBytes,proto3" json:"max_collect_content_bytes,omitempty"`
+	// The chunk size Echo.ReadBlob uses when none is requested.
+	DefaultBlobChunkSize int32 `protobuf:"varint,2,opt,name=default_blob_chunk_size,json=defaultBlobChunkSize,proto3" json:"default_blob_chunk_size,omitempty"`
+	// The largest chunk size Echo.ReadBlob accepts.
+	MaxBlobChunkSize int32 `protobuf:"varint,3,opt,name=max_blob_chunk_size,json=maxBlobChunkSize,proto3" json:"max_blob_chunk_size,omitempty"`
+	// The largest blob Echo.WriteBlob accepts.
+	MaxBlobSize int64 `protobuf:"varint,4,opt,name=max_blob_size,json=maxBlobSize,proto3" json:"max_blob_size,omitempty"`
+	// The most bytes Echo.WriteBlob stores across all blobs.
+	MaxBlobStorageSize int64 `protobuf:"varint,5,opt,name=max_blob_storage_size,json=maxBlobStorageSize,proto3" json:"max_blob_storage_size,omitempty"`
+	// The locales Echo.Echo chooses between, in order of preference.
+	SupportedLocales []string `protobuf:"bytes,6,rep,name=supported_locales,json=supportedLocales,proto3" json:"supported_locales,omitempty"`
+	// The number of polls of each operation that are kept for
+	// GetOperationPollingReport.
+	MaxRecordedPolls int32 `protobuf:"varint,7,opt,name=max_recorded_polls,json=maxRecordedPolls,proto3" json:"max_recorded_polls,omitempty"`
+	// The longest a GetOperation call holds for its `showcase-poll-wait`
+	// metadata.
+	MaxPollWait *duration.Duration `protobuf:"bytes,8,opt,name=max_poll_wait,json=maxPollWait,proto3" json:"max_poll_wait,omitempty"`
+	// The largest message, in bytes, the server sends, as set by
+	// `--max-send-message-bytes`. It cannot be updated.
+	MaxSendMessageBytes int32 `protobuf:"varint,9,opt,name=max_send_message_bytes,json=maxSendMessageBytes,proto3" json:"max_send_message_bytes,omitempty"`
+	// How long after they are issued page tokens are accepted, for requests
+	// that do not set their own `page_token_ttl`. Zero accepts tokens of any
+	// age.
+	PageTokenTtl *duration.Duration `protobuf:"bytes,10,opt,name=page_token_ttl,json=pageTokenTtl,proto3" json:"page_token_ttl,omitempty"`
+	// The metadata key of the attempt number that generated clients set when
+	// they retry calls themselves. Along with `grpc-previous-rpc-attempts`, it
+	// is counted in the `rpc_attempts` server metrics.
+	ClientAttemptHeader string `protobuf:"bytes,11,opt,name=client_attempt_header,json=clientAttemptHeader,proto3" json:"client_attempt_header,omitempty"`
+	// The most requests a BatchEcho call may carry.
+	MaxBatchEchoSize int32 `protobuf:"varint,12,opt,name=max_batch_echo_size,json=maxBatchEchoSize,proto3" json:"max_batch_echo_size,omitempty"`
+	// Whether the server was started with `--enable-admin`, which enables the
+	// DumpState method. It cannot be updated.
+	AdminEnabled bool `protobuf:"varint,13,opt,name=admin_enabled,json=adminEnabled,proto3" json:"admin_enabled,omitempty"`
+	// The artificial errors of every method without an entry in
+	// `method_error_injection`.
+	ErrorInjection *ErrorInjection `protobuf:"bytes,14,opt,name=error_injection,json=errorInjection,proto3" json:"error_injection,omitempty"`
+	// The artificial errors of each method, keyed by its full gRPC name such
+	// as `/google.showcase.v1beta1.Messaging/ListBlurbs`. An entry overrides
+	// `error_injection` even when its error rate is zero.
+	MethodErrorInjection map[string]*ErrorInjection `protobuf:"bytes,15,rep,name=method_error_injection,json=methodErrorInjection,proto3" json:"method_error_injection,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// How long after an operation is done GetOperation still returns it.
+	// Expired operations fail with NOT_FOUND and an ErrorInfo with reason
+	// `OPERATION_EXPIRED`, and their recorded polls are forgotten. Zero keeps
+	// operations forever.
+	OperationTtl *duration.Duration `protobuf:"bytes,16,opt,name=operation_ttl,json=operationTtl,proto3" json:"operation_ttl,omitempty"`
+	// The fields the server's request log redacts, keyed by the full name of
+	// the message that has them, such as `google.showcase.v1beta1.EchoRequest`.
+	// Redacted values are logged as `<redacted:N bytes>`. By default
+	// `error.details` of EchoRequest and `credentials.values` of
+	// InspectCredentialsResponse are redacted.
+	LogRedactions map[string]*LogRedaction `protobuf:"bytes,17,rep,name=log_redactions,json=logRedactions,proto3" json:"log_redactions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Bytes fields longer than this many bytes are redacted in the request log,
+	// in any message. 256 unless configured otherwise. Zero logs them whole.
+	MaxLoggedBytes int32 `protobuf:"varint,18,opt,name=max_logged_bytes,json=maxLoggedBytes,proto3" json:"max_logged_bytes,omitempty"`
+	// The ID of this server, recorded in the names of the operations it
+	// starts as `operations/<instance_id>/...`. GetOperation fails with
+	// FAILED_PRECONDITION and an ErrorInfo with reason `WRONG_INSTANCE` for
+	// operations of other instances. It is set with `--instance-id`, or at
+	// random when the server starts, and cannot be updated.
+	InstanceId string `protobuf:"bytes,19,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	// If not zero, the seed with which list methods shuffle the items of each
+	// page: Echo.PagedExpand, Identity.ListUsers, Messaging.ListRooms and
+	// Messaging.ListBlurbs. A page holds the same items in the same, seeded
+	// order each time it is listed, so paging still yields every item once.
+	// Zero keeps the server's order.
+	ListScrambleSeed int64 `protobuf:"varint,20,opt,name=list_scramble_seed,json=listScrambleSeed,proto3" json:"list_scramble_seed,omitempty"`
+	// If true, calls whose `x-goog-user-project` metadata is empty or not a
+	// valid project ID, 6 to 30 lowercase letters, digits and hyphens starting
+	// with a letter and not ending with a hyphen, fail with INVALID_ARGUMENT
+	// and an ErrorInfo with reason `INVALID_QUOTA_PROJECT`.
+	StrictQuotaProject bool `protobuf:"varint,21,opt,name=strict_quota_project,json=strictQuotaProject,proto3" json:"strict_quota_project,omitempty"`
+	// The longest a streaming call may run. Streams still open after it are
+	// ended with ABORTED and an ErrorInfo with reason
+	// `STREAM_MAX_DURATION_EXCEEDED` and the limit under `max_duration`. Zero
+	// lets streams run forever.
+	MaxStreamDuration *duration.Duration `protobuf:"bytes,22,opt,name=max_stream_duration,json=maxStreamDuration,proto3" json:"max_stream_duration,omitempty"`
+	// If positive, the most bytes of serialized requests and responses the
+	// calls of each namespace may exchange in a `byte_budget_window`. Once a
+	// namespace has used its budget, its calls fail with RESOURCE_EXHAUSTED, an
+	// ErrorInfo with reason `BYTE_BUDGET_EXCEEDED` and a QuotaFailure naming the
+	// namespace and the overage, until the window rolls over or the budget is
+	// reset with ResetByteBudget. Calls to the Testing service are exempt.
+	NamespaceByteBudget int64 `protobuf:"varint,23,opt,name=namespace_byte_budget,json=namespaceByteBudget,proto3" json:"namespace_byte_budget,omitempty"`
+	// How long the usage of a namespace's byte budget counts against it,
+	// starting from its first call. Zero counts usage until ResetByteBudget.
+	ByteBudgetWindow *duration.Duration `protobuf:"bytes,24,opt,name=byte_budget_window,json=byteBudgetWindow,proto3" json:"byte_budget_window,omitempty"`
+	// The oldest version of each client library the server accepts, keyed by
+	// the token that names the library in the `x-goog-api-client` metadata,
+	// such as `gccl`, and given as a semantic version, such as `2.3.0`. Calls
+	// from an older version fail with FAILED_PRECONDITION and an ErrorInfo with
+	// reason `LIBRARY_VERSION_TOO_OLD`, the token under `token`, the version
+	// under `version` and this minimum under `minimum_version`. Calls to the
+	// Testing service are exempt.
+	MinLibraryVersions map[string]string `protobuf:"bytes,25,rep,name=min_library_versions,json=minLibraryVersions,proto3" json:"min_library_versions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// If true, calls whose `x-goog-api-client` metadata lacks a token of
+	// `min_library_versions` fail with FAILED_PRECONDITION and an ErrorInfo
+	// with reason `LIBRARY_VERSION_MISSING`. Otherwise they pass.
+	StrictLibraryVersions bool `protobuf:"varint,26,opt,name=strict_library_versions,json=strictLibraryVersions,proto3" json:"strict_library_versions,omitempty"`
+	// Whether the server was started with `--enable-nonconforming`, which
+	// enables deliberately non-conforming responses such as those of
+	// `EchoRequest.corrupt_utf8_response`. It cannot be updated.
+	NonconformingEnabled bool `protobuf:"varint,27,opt,name=nonconforming_enabled,json=nonconformingEnabled,proto3" json:"nonconforming_enabled,omitempty"`
+	// How long after its last write a blob of Echo.WriteBlob is kept, whether
+	// or not it is complete. Expired blobs release their storage, and
+	// GetWriteStatus returns NOT_FOUND for them. Zero keeps blobs until their
+	// namespace is purged.
+	BlobTtl              *duration.Duration `protobuf:"bytes,28,opt,name=blob_ttl,json=blobTtl,proto3" json:"blob_ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ShowcaseSettings) Reset()         { *m = ShowcaseSettings{} }
+func (m *ShowcaseSettings) String() string { return proto.CompactTextString(m) }
+func (*ShowcaseSettings) ProtoMessage()    {}
+func (*ShowcaseSettings) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{21}
+}
+
+func (m *ShowcaseSettings) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ShowcaseSettings.Unmarshal(m, b)
+}
+func (m *ShowcaseSettings) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ShowcaseSettings.Marshal(b, m, deterministic)
+}
+func (m *ShowcaseSettings) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShowcaseSettings.Merge(m, src)
+}
+func (m *ShowcaseSettings) XXX_Size() int {
+	return xxx_messageInfo_ShowcaseSettings.Size(m)
+}
+func (m *ShowcaseSettings) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShowcaseSettings.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShowcaseSettings proto.InternalMessageInfo
+
+func (m *ShowcaseSettings) GetMaxCollectContentBytes() int64 {
+	if m != nil {
+		return m.MaxCollectContentBytes
+	}
+	return 0
+}
+
+func (m *ShowcaseSettings) GetDefaultBlobChunkSize() int32 {
+	if m != nil {
+		return m.DefaultBlobChunkSize
+	}
+	return 0
+}
+
+func (m *ShowcaseSettings) GetMaxBlobChunkSize() int32 {
+	if m != nil {
+		return m.MaxBlobChunkSize
+	}
+	return 0
+}
+
+func (m *ShowcaseSettings) GetMaxBlobSize() int64 {
+	if m != nil {
+		return m.MaxBlobSize
+	}
+	return 0
+}
+
+func (m *ShowcaseSettings) GetMaxBlobStorageSize() int64 {
+	if m != nil {
+		return m.MaxBlobStorageSize
+	}
+	return 0
+}
+
+func (m *ShowcaseSettings) GetSupportedLocales() []string {
+	if m != nil {
+		return m.SupportedLocales
+	}
+	return nil
+}
+
+func (m *ShowcaseSettings) GetMaxRecordedPolls() int32 {
+	if m != nil {
+		return m.MaxRecordedPolls
+	}
+	return 0
+}
+
+func (m *ShowcaseSettings) GetMaxPollWait() *duration.Duration {
+	if m != nil {
+		return m.MaxPollWait
+	}
+	return nil
+}
+
+func (m *ShowcaseSettings) GetMaxSendMessageBytes() int32 {
+	if m != nil {
+		return m.MaxSendMessageBytes
+	}
+	return 0
+}
+
+func (m *ShowcaseSettings) GetPageTokenTtl() *duration.Duration {
+	if m != nil {
+		return m.PageTokenTtl
+	}
+	return nil
+}
+
+func (m *ShowcaseSettings) GetClientAttemptHeader() string {
+	if m != nil {
+		return m.ClientAttemptHeader
+	}
+	return ""
+}
+
+func (m *ShowcaseSettings) GetMaxBatchEchoSize() int32 {
+	if m != nil {
+		return m.MaxBatchEchoSize
+	}
+	return 0
+}
+
+func (m *ShowcaseSettings) GetAdminEnabled() bool {
+	if m != nil {
+		return m.AdminEnabled
+	}
+	return false
+}
+
+func (m *ShowcaseSettings) GetErrorInjection() *ErrorInjection {
+	if m != nil {
+		return m.ErrorInjection
+	}
+	return nil
+}
+
+func (m *ShowcaseSettings) GetMethodErrorInjection() map[string]*ErrorInjection {
+	if m != nil {
+		return m.MethodErrorInjection
+	}
+	return nil
+}
+
+func (m *ShowcaseSettings) GetOperationTtl() *duration.Duration {
+	if m != nil {
+		return m.OperationTtl
+	}
+	return nil
+}
+
+func (m *ShowcaseSettings) GetLogRedactions() map[string]*LogRedaction {
+	if m != nil {
+		return m.LogRedactions
+	}
+	return nil
+}
+
+func (m *ShowcaseSettings) GetMaxLoggedBytes() int32 {
+	if m != nil {
+		return m.MaxLoggedBytes
+	}
+	return 0
+}
+
+func (m *ShowcaseSettings) GetInstanceId() string {
+	if m != nil {
+		return m.InstanceId
+	}
+	return ""
+}
+
+func (m *ShowcaseSettings) GetListScrambleSeed() int64 {
+	if m != nil {
+		return m.ListScrambleSeed
+	}
+	return 0
+}
+
+func (m *ShowcaseSettings) GetStrictQuotaProject() bool {
+	if m != nil {
+		return m.StrictQuotaProject
+	}
+	return false
+}
+
+func (m *ShowcaseSettings) GetMaxStreamDuration() *duration.Duration {
+	if m != nil {
+		return m.MaxStreamDuration
+	}
+	return nil
+}
+
+func (m *ShowcaseSettings) GetNamespaceByteBudget() int64 {
+	if m != nil {
+		return m.NamespaceByteBudget
+	}
+	return 0
+}
+
+func (m *ShowcaseSettings) GetByteBudgetWindow() *duration.Duration {
+	if m != nil {
+		return m.ByteBudgetWindow
+	}
+	return nil
+}
+
+func (m *ShowcaseSettings) GetMinLibraryVersions() map[string]string {
+	if m != nil {
+		return m.MinLibraryVersions
+	}
+	return nil
+}
+
+func (m *ShowcaseSettings) GetStrictLibraryVersions() bool {
+	if m != nil {
+		return m.StrictLibraryVersions
+	}
+	return false
+}
+
+func (m *ShowcaseSettings) GetNonconformingEnabled() bool {
+	if m != nil {
+		return m.NonconformingEnabled
+	}
+	return false
+}
+
+func (m *ShowcaseSettings) GetBlobTtl() *duration.Duration {
+	if m != nil {
+		return m.BlobTtl
+	}
+	return nil
+}
+
+// The fields of a message that the request log redacts.
+type LogRedaction struct {
+	// The paths of the fields, such as `error.details`. A path may go through
+	// repeated messages, and then applies to each of them.
+	Paths                []string `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogRedaction) Reset()         { *m = LogRedaction{} }
+func (m *LogRedaction) String() string { return proto.CompactTextString(m) }
+func (*LogRedaction) ProtoMessage()    {}
+func (*LogRedaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{22}
+}
+
+func (m *LogRedaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogRedaction.Unmarshal(m, b)
+}
+func (m *LogRedaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogRedaction.Marshal(b, m, deterministic)
+}
+func (m *LogRedaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogRedaction.Merge(m, src)
+}
+func (m *LogRedaction) XXX_Size() int {
+	return xxx_messageInfo_LogRedaction.Size(m)
+}
+func (m *LogRedaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogRedaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogRedaction proto.InternalMessageInfo
+
+func (m *LogRedaction) GetPaths() []string {
+	if m != nil {
+		return m.Paths
+	}
+	return nil
+}
+
+// A rate of artificial errors. UpdateShowcaseSettings is never failed, so
+// that the errors can always be turned off.
+type ErrorInjection struct {
+	// The fraction of calls, from 0 to 1, that fail before reaching their
+	// handler.
+	ErrorRate float64 `protobuf:"fixed64,1,opt,name=error_rate,json=errorRate,proto3" json:"error_rate,omitempty"`
+	// The google.rpc.Code value of the errors. UNAVAILABLE if unset.
+	Code int32 `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	// If set, the errors carry a google.rpc.RetryInfo with this delay.
+	RetryInfoDelay       *duration.Duration `protobuf:"bytes,3,opt,name=retry_info_delay,json=retryInfoDelay,proto3" json:"retry_info_delay,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ErrorInjection) Reset()         { *m = ErrorInjection{} }
+func (m *ErrorInjection) String() string { return proto.CompactTextString(m) }
+func (*ErrorInjection) ProtoMessage()    {}
+func (*ErrorInjection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{23}
+}
+
+func (m *ErrorInjection) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ErrorInjection.Unmarshal(m, b)
+}
+func (m *ErrorInjection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ErrorInjection.Marshal(b, m, deterministic)
+}
+func (m *ErrorInjection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ErrorInjection.Merge(m, src)
+}
+func (m *ErrorInjection) XXX_Size() int {
+	return xxx_messageInfo_ErrorInjection.Size(m)
+}
+func (m *ErrorInjection) XXX_DiscardUnknown() {
+	xxx_messageInfo_ErrorInjection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ErrorInjection proto.InternalMessageInfo
+
+func (m *ErrorInjection) GetErrorRate() float64 {
+	if m != nil {
+		return m.ErrorRate
+	}
+	return 0
+}
+
+func (m *ErrorInjection) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *ErrorInjection) GetRetryInfoDelay() *duration.Duration {
+	if m != nil {
+		return m.RetryInfoDelay
+	}
+	return nil
+}
+
+// The request for the UpdateShowcaseSettings method.
+type UpdateShowcaseSettingsRequest struct {
+	// The new values of the settings to update.
+	Settings *ShowcaseSettings `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	// The settings to update. If empty, all of them are replaced.
+	// `max_recorded_polls` cannot be updated.
+	UpdateMask           *field_mask.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *UpdateShowcaseSettingsRequest) Reset()         { *m = UpdateShowcaseSettingsRequest{} }
+func (m *UpdateShowcaseSettingsRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateShowcaseSettingsRequest) ProtoMessage()    {}
+func (*UpdateShowcaseSettingsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{24}
+}
+
+func (m *UpdateShowcaseSettingsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateShowcaseSettingsRequest.Unmarshal(m, b)
+}
+func (m *UpdateShowcaseSettingsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateShowcaseSettingsRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateShowcaseSettingsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateShowcaseSettingsRequest.Merge(m, src)
+}
+func (m *UpdateShowcaseSettingsRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateShowcaseSettingsRequest.Size(m)
+}
+func (m *UpdateShowcaseSettingsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateShowcaseSettingsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateShowcaseSettingsRequest proto.InternalMessageInfo
+
+func (m *UpdateShowcaseSettingsRequest) GetSettings() *ShowcaseSettings {
+	if m != nil {
+		return m.Settings
+	}
+	return nil
+}
+
+func (m *UpdateShowcaseSettingsRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+// The response for the UpdateShowcaseSettings method.
+type UpdateShowcaseSettingsResponse struct {
+	// The settings before the update.
+	Previous *ShowcaseSettings `protobuf:"bytes,1,opt,name=previous,proto3" json:"previous,omitempty"`
+	// The settings after the update.
+	Current              *ShowcaseSettings `protobuf:"bytes,2,opt,name=current,proto3" json:"current,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *UpdateShowcaseSettingsResponse) Reset()         { *m = UpdateShowcaseSettingsResponse{} }
+func (m *UpdateShowcaseSettingsResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateShowcaseSettingsResponse) ProtoMessage()    {}
+func (*UpdateShowcaseSettingsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{25}
+}
+
+func (m *UpdateShowcaseSettingsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateShowcaseSettingsResponse.Unmarshal(m, b)
+}
+func (m *UpdateShowcaseSettingsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateShowcaseSettingsResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateShowcaseSettingsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateShowcaseSettingsResponse.Merge(m, src)
+}
+func (m *UpdateShowcaseSettingsResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateShowcaseSettingsResponse.Size(m)
+}
+func (m *UpdateShowcaseSettingsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateShowcaseSettingsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateShowcaseSettingsResponse proto.InternalMessageInfo
+
+func (m *UpdateShowcaseSettingsResponse) GetPrevious() *ShowcaseSettings {
+	if m != nil {
+		return m.Previous
+	}
+	return nil
+}
+
+func (m *UpdateShowcaseSettingsResponse) GetCurrent() *ShowcaseSettings {
+	if m != nil {
+		return m.Current
+	}
+	return nil
+}
+
+// The request for the SetMethodOverload method.
+type SetMethodOverloadRequest struct {
+	// The full gRPC name of the method to limit, e.g.
+	// `/google.showcase.v1beta1.Echo/Echo`.
+	Method string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	// The number of calls per second to accept. Up to a second's worth of calls
+	// may be made in a burst. If zero, any limit on the method is cleared.
+	QpsLimit float64 `protobuf:"fixed64,2,opt,name=qps_limit,json=qpsLimit,proto3" json:"qps_limit,omitempty"`
+	// The delay that rejected calls are told to wait before retrying.
+	RetryDelay           *duration.Duration `protobuf:"bytes,3,opt,name=retry_delay,json=retryDelay,proto3" json:"retry_delay,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *SetMethodOverloadRequest) Reset()         { *m = SetMethodOverloadRequest{} }
+func (m *SetMethodOverloadRequest) String() string { return proto.CompactTextString(m) }
+func (*SetMethodOverloadRequest) ProtoMessage()    {}
+func (*SetMethodOverloadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{26}
+}
+
+func (m *SetMethodOverloadRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetMethodOverloadRequest.Unmarshal(m, b)
+}
+func (m *SetMethodOverloadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetMethodOverloadRequest.Marshal(b, m, deterministic)
+}
+func (m *SetMethodOverloadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetMethodOverloadRequest.Merge(m, src)
+}
+func (m *SetMethodOverloadRequest) XXX_Size() int {
+	return xxx_messageInfo_SetMethodOverloadRequest.Size(m)
+}
+func (m *SetMethodOverloadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetMethodOverloadRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetMethodOverloadRequest proto.InternalMessageInfo
+
+func (m *SetMethodOverloadRequest) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *SetMethodOverloadRequest) GetQpsLimit() float64 {
+	if m != nil {
+		return m.QpsLimit
+	}
+	return 0
+}
+
+func (m *SetMethodOverloadRequest) GetRetryDelay() *duration.Duration {
+	if m != nil {
+		return m.RetryDelay
+	}
+	return nil
+}
+
+// The request for the CreateEchoCorpus method.
+type CreateEchoCorpusRequest struct {
+	// The name of the corpus, which must not already exist.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The words of the corpus, in the order they are streamed.
+	Words                []string `protobuf:"bytes,2,rep,name=words,proto3" json:"words,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateEchoCorpusRequest) Reset()         { *m = CreateEchoCorpusRequest{} }
+func (m *CreateEchoCorpusRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateEchoCorpusRequest) ProtoMessage()    {}
+func (*CreateEchoCorpusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{27}
+}
+
+func (m *CreateEchoCorpusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateEchoCorpusRequest.Unmarshal(m, b)
+}
+func (m *CreateEchoCorpusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateEchoCorpusRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateEchoCorpusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateEchoCorpusRequest.Merge(m, src)
+}
+func (m *CreateEchoCorpusRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateEchoCorpusRequest.Size(m)
+}
+func (m *CreateEchoCorpusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateEchoCorpusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateEchoCorpusRequest proto.InternalMessageInfo
+
+func (m *CreateEchoCorpusRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateEchoCorpusRequest) GetWords() []string {
+	if m != nil {
+		return m.Words
+	}
+	return nil
+}
+
+// A named list of words stored by the server.
+type EchoCorpus struct {
+	// The name of the corpus.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The words of the corpus.
+	Words                []string `protobuf:"bytes,2,rep,name=words,proto3" json:"words,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EchoCorpus) Reset()         { *m = EchoCorpus{} }
+func (m *EchoCorpus) String() string { return proto.CompactTextString(m) }
+func (*EchoCorpus) ProtoMessage()    {}
+func (*EchoCorpus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{28}
+}
+
+func (m *EchoCorpus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EchoCorpus.Unmarshal(m, b)
+}
+func (m *EchoCorpus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EchoCorpus.Marshal(b, m, deterministic)
+}
+func (m *EchoCorpus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EchoCorpus.Merge(m, src)
+}
+func (m *EchoCorpus) XXX_Size() int {
+	return xxx_messageInfo_EchoCorpus.Size(m)
+}
+func (m *EchoCorpus) XXX_DiscardUnknown() {
+	xxx_messageInfo_EchoCorpus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EchoCorpus proto.InternalMessageInfo
+
+func (m *EchoCorpus) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *EchoCorpus) GetWords() []string {
+	if m != nil {
+		return m.Words
+	}
+	return nil
+}
+
+// The request for the DeleteEchoCorpus method.
+type DeleteEchoCorpusRequest struct {
+	// The name of the corpus to delete.
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteEchoCorpusRequest) Reset()         { *m = DeleteEchoCorpusRequest{} }
+func (m *DeleteEchoCorpusRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteEchoCorpusRequest) ProtoMessage()    {}
+func (*DeleteEchoCorpusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{29}
+}
+
+func (m *DeleteEchoCorpusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteEchoCorpusRequest.Unmarshal(m, b)
+}
+func (m *DeleteEchoCorpusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteEchoCorpusRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteEchoCorpusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteEchoCorpusRequest.Merge(m, src)
+}
+func (m *DeleteEchoCorpusRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteEchoCorpusRequest.Size(m)
+}
+func (m *DeleteEchoCorpusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteEchoCorpusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteEchoCorpusRequest proto.InternalMessageInfo
+
+func (m *DeleteEchoCorpusRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// The request for the PurgeNamespace method.
+type PurgeNamespaceRequest struct {
+	// The namespace to purge.
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PurgeNamespaceRequest) Reset()         { *m = PurgeNamespaceRequest{} }
+func (m *PurgeNamespaceRequest) String() string { return proto.CompactTextString(m) }
+func (*PurgeNamespaceRequest) ProtoMessage()    {}
+func (*PurgeNamespaceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{30}
+}
+
+func (m *PurgeNamespaceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PurgeNamespaceRequest.Unmarshal(m, b)
+}
+func (m *PurgeNamespaceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PurgeNamespaceRequest.Marshal(b, m, deterministic)
+}
+func (m *PurgeNamespaceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeNamespaceRequest.Merge(m, src)
+}
+func (m *PurgeNamespaceRequest) XXX_Size() int {
+	return xxx_messageInfo_PurgeNamespaceRequest.Size(m)
+}
+func (m *PurgeNamespaceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeNamespaceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PurgeNamespaceRequest proto.InternalMessageInfo
+
+func (m *PurgeNamespaceRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+// The request for the GetServerMetrics method.
+type GetServerMetricsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetServerMetricsRequest) Reset()         { *m = GetServerMetricsRequest{} }
+func (m *GetServerMetricsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetServerMetricsRequest) ProtoMessage()    {}
+func (*GetServerMetricsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{31}
+}
+
+func (m *GetServerMetricsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetServerMetricsRequest.Unmarshal(m, b)
+}
+func (m *GetServerMetricsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetServerMetricsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetServerMetricsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetServerMetricsRequest.Merge(m, src)
+}
+func (m *GetServerMetricsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetServerMetricsRequest.Size(m)
+}
+func (m *GetServerMetricsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetServerMetricsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetServerMetricsRequest proto.InternalMessageInfo
+
+// A snapshot of the server's metrics.
+type ServerMetrics struct {
+	// The metrics by name. These include `in_flight_unary_rpcs` and
+	// `in_flight_streaming_rpcs`, the RPCs being handled,
+	// `concurrency_rejected_rpcs`, the RPCs rejected for exceeding the server's
+	// concurrency limit, `recovered_panics`, the RPCs whose handler panicked,
+	// `abandoned_collects`, the Collect streams whose client went away before
+	// half-closing, `handled_rpcs{namespace="...",method="...",code="..."}`, the RPCs
+	// that ended with each status code, and
+	// `rpc_attempts{method="...",header="...",attempt="..."}`, the RPCs with
+	// each attempt number in a retry header.
+	Values               map[string]int64 `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ServerMetrics) Reset()         { *m = ServerMetrics{} }
+func (m *ServerMetrics) String() string { return proto.CompactTextString(m) }
+func (*ServerMetrics) ProtoMessage()    {}
+func (*ServerMetrics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{32}
+}
+
+func (m *ServerMetrics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServerMetrics.Unmarshal(m, b)
+}
+func (m *ServerMetrics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServerMetrics.Marshal(b, m, deterministic)
+}
+func (m *ServerMetrics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServerMetrics.Merge(m, src)
+}
+func (m *ServerMetrics) XXX_Size() int {
+	return xxx_messageInfo_ServerMetrics.Size(m)
+}
+func (m *ServerMetrics) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServerMetrics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServerMetrics proto.InternalMessageInfo
+
+func (m *ServerMetrics) GetValues() map[string]int64 {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+// The request for the ParseResourceNames method.
+type ParseResourceNamesRequest struct {
+	// A resource name to parse.
+	SingleParam string `protobuf:"bytes,1,opt,name=single_param,json=singleParam,proto3" json:"single_param,omitempty"`
+	// More resource names to parse.
+	RepeatedParams       []string `protobuf:"bytes,2,rep,name=repeated_params,json=repeatedParams,proto3" json:"repeated_params,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ParseResourceNamesRequest) Reset()         { *m = ParseResourceNamesRequest{} }
+func (m *ParseResourceNamesRequest) String() string { return proto.CompactTextString(m) }
+func (*ParseResourceNamesRequest) ProtoMessage()    {}
+func (*ParseResourceNamesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{33}
+}
+
+func (m *ParseResourceNamesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParseResourceNamesRequest.Unmarshal(m, b)
+}
+func (m *ParseResourceNamesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParseResourceNamesRequest.Marshal(b, m, deterministic)
+}
+func (m *ParseResourceNamesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParseResourceNamesRequest.Merge(m, src)
+}
+func (m *ParseResourceNamesRequest) XXX_Size() int {
+	return xxx_messageInfo_ParseResourceNamesRequest.Size(m)
+}
+func (m *ParseResourceNamesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParseResourceNamesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParseResourceNamesRequest proto.InternalMessageInfo
+
+func (m *ParseResourceNamesRequest) GetSingleParam() string {
+	if m != nil {
+		return m.SingleParam
+	}
+	return ""
+}
+
+func (m *ParseResourceNamesRequest) GetRepeatedParams() []string {
+	if m != nil {
+		return m.RepeatedParams
+	}
+	return nil
+}
+
+// A resource name broken down by the pattern it matched.
+type ParsedResourceName struct {
+	// The resource name as given.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The pattern the name matched.
+	Pattern ResourceNamePattern `protobuf:"varint,2,opt,name=pattern,proto3,enum=google.showcase.v1beta1.ResourceNamePattern" json:"pattern,omitempty"`
+	// The decoded values of the variable segments, keyed by variable name.
+	Segments map[string]string `protobuf:"bytes,3,rep,name=segments,proto3" json:"segments,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The variables whose segment is the wildcard `*`.
+	Wildcards            []string `protobuf:"bytes,4,rep,name=wildcards,proto3" json:"wildcards,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ParsedResourceName) Reset()         { *m = ParsedResourceName{} }
+func (m *ParsedResourceName) String() string { return proto.CompactTextString(m) }
+func (*ParsedResourceName) ProtoMessage()    {}
+func (*ParsedResourceName) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{34}
+}
+
+func (m *ParsedResourceName) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParsedResourceName.Unmarshal(m, b)
+}
+func (m *ParsedResourceName) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParsedResourceName.Marshal(b, m, deterministic)
+}
+func (m *ParsedResourceName) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParsedResourceName.Merge(m, src)
+}
+func (m *ParsedResourceName) XXX_Size() int {
+	return xxx_messageInfo_ParsedResourceName.Size(m)
+}
+func (m *ParsedResourceName) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParsedResourceName.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParsedResourceName proto.InternalMessageInfo
+
+func (m *ParsedResourceName) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ParsedResourceName) GetPattern() ResourceNamePattern {
+	if m != nil {
+		return m.Pattern
+	}
+	return ResourceNamePattern_RESOURCE_NAME_PATTERN_UNSPECIFIED
+}
+
+func (m *ParsedResourceName) GetSegments() map[string]string {
+	if m != nil {
+		return m.Segments
+	}
+	return nil
+}
+
+func (m *ParsedResourceName) GetWildcards() []string {
+	if m != nil {
+		return m.Wildcards
+	}
+	return nil
+}
+
+// The response for the ParseResourceNames method.
+type ParseResourceNamesResponse struct {
+	// The parsed `single_param`, if it was given.
+	SingleParam *ParsedResourceName `protobuf:"bytes,1,opt,name=single_param,json=singleParam,proto3" json:"single_param,omitempty"`
+	// The parsed `repeated_params`, in order.
+	RepeatedParams       []*ParsedResourceName `protobuf:"bytes,2,rep,name=repeated_params,json=repeatedParams,proto3" json:"repeated_params,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ParseResourceNamesResponse) Reset()         { *m = ParseResourceNamesResponse{} }
+func (m *ParseResourceNamesResponse) String() string { return proto.CompactTextString(m) }
+func (*ParseResourceNamesResponse) ProtoMessage()    {}
+func (*ParseResourceNamesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{35}
+}
+
+func (m *ParseResourceNamesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParseResourceNamesResponse.Unmarshal(m, b)
+}
+func (m *ParseResourceNamesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParseResourceNamesResponse.Marshal(b, m, deterministic)
+}
+func (m *ParseResourceNamesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParseResourceNamesResponse.Merge(m, src)
+}
+func (m *ParseResourceNamesResponse) XXX_Size() int {
+	return xxx_messageInfo_ParseResourceNamesResponse.Size(m)
+}
+func (m *ParseResourceNamesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParseResourceNamesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParseResourceNamesResponse proto.InternalMessageInfo
+
+func (m *ParseResourceNamesResponse) GetSingleParam() *ParsedResourceName {
+	if m != nil {
+		return m.SingleParam
+	}
+	return nil
+}
+
+func (m *ParseResourceNamesResponse) GetRepeatedParams() []*ParsedResourceName {
+	if m != nil {
+		return m.RepeatedParams
+	}
+	return nil
+}
+
+// The request for the GetChannelzSummary method.
+type GetChannelzSummaryRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetChannelzSummaryRequest) Reset()         { *m = GetChannelzSummaryRequest{} }
+func (m *GetChannelzSummaryRequest) String() string { return proto.CompactTextString(m) }
+func (*GetChannelzSummaryRequest) ProtoMessage()    {}
+func (*GetChannelzSummaryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{36}
+}
+
+func (m *GetChannelzSummaryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetChannelzSummaryRequest.Unmarshal(m, b)
+}
+func (m *GetChannelzSummaryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetChannelzSummaryRequest.Marshal(b, m, deterministic)
+}
+func (m *GetChannelzSummaryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetChannelzSummaryRequest.Merge(m, src)
+}
+func (m *GetChannelzSummaryRequest) XXX_Size() int {
+	return xxx_messageInfo_GetChannelzSummaryRequest.Size(m)
+}
+func (m *GetChannelzSummaryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetChannelzSummaryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetChannelzSummaryRequest proto.InternalMessageInfo
+
+// Totals of the channelz data of the server's open connections.
+type ChannelzSummary struct {
+	// The number of open connections.
+	OpenSockets int64 `protobuf:"varint,1,opt,name=open_sockets,json=openSockets,proto3" json:"open_sockets,omitempty"`
+	// The streams started on the open connections.
+	StreamsStarted int64 `protobuf:"varint,2,opt,name=streams_started,json=streamsStarted,proto3" json:"streams_started,omitempty"`
+	// The streams on the open connections that ended successfully.
+	StreamsSucceeded int64 `protobuf:"varint,3,opt,name=streams_succeeded,json=streamsSucceeded,proto3" json:"streams_succeeded,omitempty"`
+	// The streams on the open connections that ended with an error.
+	StreamsFailed int64 `protobuf:"varint,4,opt,name=streams_failed,json=streamsFailed,proto3" json:"streams_failed,omitempty"`
+	// The keepalive pings sent on the open connections.
+	KeepAlivesSent       int64    `protobuf:"varint,5,opt,name=keep_alives_sent,json=keepAlivesSent,proto3" json:"keep_alives_sent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChannelzSummary) Reset()         { *m = ChannelzSummary{} }
+func (m *ChannelzSummary) String() string { return proto.CompactTextString(m) }
+func (*ChannelzSummary) ProtoMessage()    {}
+func (*ChannelzSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{37}
+}
+
+func (m *ChannelzSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelzSummary.Unmarshal(m, b)
+}
+func (m *ChannelzSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelzSummary.Marshal(b, m, deterministic)
+}
+func (m *ChannelzSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelzSummary.Merge(m, src)
+}
+func (m *ChannelzSummary) XXX_Size() int {
+	return xxx_messageInfo_ChannelzSummary.Size(m)
+}
+func (m *ChannelzSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelzSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelzSummary proto.InternalMessageInfo
+
+func (m *ChannelzSummary) GetOpenSockets() int64 {
+	if m != nil {
+		return m.OpenSockets
+	}
+	return 0
+}
+
+func (m *ChannelzSummary) GetStreamsStarted() int64 {
+	if m != nil {
+		return m.StreamsStarted
+	}
+	return 0
+}
+
+func (m *ChannelzSummary) GetStreamsSucceeded() int64 {
+	if m != nil {
+		return m.StreamsSucceeded
+	}
+	return 0
+}
+
+func (m *ChannelzSummary) GetStreamsFailed() int64 {
+	if m != nil {
+		return m.StreamsFailed
+	}
+	return 0
+}
+
+func (m *ChannelzSummary) GetKeepAlivesSent() int64 {
+	if m != nil {
+		return m.KeepAlivesSent
+	}
+	return 0
+}
+
+// The request for the MeasureRoundTrip method.
+type MeasureRoundTripRequest struct {
+	// The number of messages to encode and decode. At most 10000, and
+	// `count` times `payload_size` may be at most 64 MiB.
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// The size, in bytes, of the content of each message.
+	PayloadSize          int32    `protobuf:"varint,2,opt,name=payload_size,json=payloadSize,proto3" json:"payload_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MeasureRoundTripRequest) Reset()         { *m = MeasureRoundTripRequest{} }
+func (m *MeasureRoundTripRequest) String() string { return proto.CompactTextString(m) }
+func (*MeasureRoundTripRequest) ProtoMessage()    {}
+func (*MeasureRoundTripRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{38}
+}
+
+func (m *MeasureRoundTripRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MeasureRoundTripRequest.Unmarshal(m, b)
+}
+func (m *MeasureRoundTripRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MeasureRoundTripRequest.Marshal(b, m, deterministic)
+}
+func (m *MeasureRoundTripRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MeasureRoundTripRequest.Merge(m, src)
+}
+func (m *MeasureRoundTripRequest) XXX_Size() int {
+	return xxx_messageInfo_MeasureRoundTripRequest.Size(m)
+}
+func (m *MeasureRoundTripRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MeasureRoundTripRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MeasureRoundTripRequest proto.InternalMessageInfo
+
+func (m *MeasureRoundTripRequest) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *MeasureRoundTripRequest) GetPayloadSize() int32 {
+	if m != nil {
+		return m.PayloadSize
+	}
+	return 0
+}
+
+// The response for the MeasureRoundTrip method.
+type MeasureRoundTripResponse struct {
+	// The number of messages that were encoded and decoded.
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// The median time to encode and decode a message.
+	P50 *duration.Duration `protobuf:"bytes,2,opt,name=p50,proto3" json:"p50,omitempty"`
+	// The 90th percentile time to encode and decode a message.
+	P90 *duration.Duration `protobuf:"bytes,3,opt,name=p90,proto3" json:"p90,omitempty"`
+	// The 99th percentile time to encode and decode a message.
+	P99 *duration.Duration `protobuf:"bytes,4,opt,name=p99,proto3" json:"p99,omitempty"`
+	// The longest time to encode and decode a message.
+	Max *duration.Duration `protobuf:"bytes,5,opt,name=max,proto3" json:"max,omitempty"`
+	// The settings the server is running with, including the waits it may
+	// impose on calls.
+	Settings             *ShowcaseSettings `protobuf:"bytes,6,opt,name=settings,proto3" json:"settings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *MeasureRoundTripResponse) Reset()         { *m = MeasureRoundTripResponse{} }
+func (m *MeasureRoundTripResponse) String() string { return proto.CompactTextString(m) }
+func (*MeasureRoundTripResponse) ProtoMessage()    {}
+func (*MeasureRoundTripResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{39}
+}
+
+func (m *MeasureRoundTripResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MeasureRoundTripResponse.Unmarshal(m, b)
+}
+func (m *MeasureRoundTripResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MeasureRoundTripResponse.Marshal(b, m, deterministic)
+}
+func (m *MeasureRoundTripResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MeasureRoundTripResponse.Merge(m, src)
+}
+func (m *MeasureRoundTripResponse) XXX_Size() int {
+	return xxx_messageInfo_MeasureRoundTripResponse.Size(m)
+}
+func (m *MeasureRoundTripResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MeasureRoundTripResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MeasureRoundTripResponse proto.InternalMessageInfo
+
+func (m *MeasureRoundTripResponse) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *MeasureRoundTripResponse) GetP50() *duration.Duration {
+	if m != nil {
+		return m.P50
+	}
+	return nil
+}
+
+func (m *MeasureRoundTripResponse) GetP90() *duration.Duration {
+	if m != nil {
+		return m.P90
+	}
+	return nil
+}
+
+func (m *MeasureRoundTripResponse) GetP99() *duration.Duration {
+	if m != nil {
+		return m.P99
+	}
+	return nil
+}
+
+func (m *MeasureRoundTripResponse) GetMax() *duration.Duration {
+	if m != nil {
+		return m.Max
+	}
+	return nil
+}
+
+func (m *MeasureRoundTripResponse) GetSettings() *ShowcaseSettings {
+	if m != nil {
+		return m.Settings
+	}
+	return nil
+}
+
+// The request for the DumpState method.
+type DumpStateRequest struct {
+	// If true, the words of corpora and the content of cached responses are
+	// replaced with empty strings, keeping their number.
+	RedactPayloads       bool     `protobuf:"varint,1,opt,name=redact_payloads,json=redactPayloads,proto3" json:"redact_payloads,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DumpStateRequest) Reset()         { *m = DumpStateRequest{} }
+func (m *DumpStateRequest) String() string { return proto.CompactTextString(m) }
+func (*DumpStateRequest) ProtoMessage()    {}
+func (*DumpStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{40}
+}
+
+func (m *DumpStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DumpStateRequest.Unmarshal(m, b)
+}
+func (m *DumpStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DumpStateRequest.Marshal(b, m, deterministic)
+}
+func (m *DumpStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DumpStateRequest.Merge(m, src)
+}
+func (m *DumpStateRequest) XXX_Size() int {
+	return xxx_messageInfo_DumpStateRequest.Size(m)
+}
+func (m *DumpStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DumpStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DumpStateRequest proto.InternalMessageInfo
+
+func (m *DumpStateRequest) GetRedactPayloads() bool {
+	if m != nil {
+		return m.RedactPayloads
+	}
+	return false
+}
+
+// The request for the ListConformanceScenarios method.
+type ListConformanceScenariosRequest struct {
+	// If set, only the scenarios that use this method, named as
+	// `google.showcase.v1beta1.Echo/Expand`, are listed.
+	Method               string   `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListConformanceScenariosRequest) Reset()         { *m = ListConformanceScenariosRequest{} }
+func (m *ListConformanceScenariosRequest) String() string { return proto.CompactTextString(m) }
+func (*ListConformanceScenariosRequest) ProtoMessage()    {}
+func (*ListConformanceScenariosRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{41}
+}
+
+func (m *ListConformanceScenariosRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListConformanceScenariosRequest.Unmarshal(m, b)
+}
+func (m *ListConformanceScenariosRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListConformanceScenariosRequest.Marshal(b, m, deterministic)
+}
+func (m *ListConformanceScenariosRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListConformanceScenariosRequest.Merge(m, src)
+}
+func (m *ListConformanceScenariosRequest) XXX_Size() int {
+	return xxx_messageInfo_ListConformanceScenariosRequest.Size(m)
+}
+func (m *ListConformanceScenariosRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListConformanceScenariosRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListConformanceScenariosRequest proto.InternalMessageInfo
+
+func (m *ListConformanceScenariosRequest) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+// The response for the ListConformanceScenarios method.
+type ListConformanceScenariosResponse struct {
+	// The scenarios, ordered by ID.
+	Scenarios            []*ConformanceScenario `protobuf:"bytes,1,rep,name=scenarios,proto3" json:"scenarios,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *ListConformanceScenariosResponse) Reset()         { *m = ListConformanceScenariosResponse{} }
+func (m *ListConformanceScenariosResponse) String() string { return proto.CompactTextString(m) }
+func (*ListConformanceScenariosResponse) ProtoMessage()    {}
+func (*ListConformanceScenariosResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{42}
+}
+
+func (m *ListConformanceScenariosResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListConformanceScenariosResponse.Unmarshal(m, b)
+}
+func (m *ListConformanceScenariosResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListConformanceScenariosResponse.Marshal(b, m, deterministic)
+}
+func (m *ListConformanceScenariosResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListConformanceScenariosResponse.Merge(m, src)
+}
+func (m *ListConformanceScenariosResponse) XXX_Size() int {
+	return xxx_messageInfo_ListConformanceScenariosResponse.Size(m)
+}
+func (m *ListConformanceScenariosResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListConformanceScenariosResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListConformanceScenariosResponse proto.InternalMessageInfo
+
+func (m *ListConformanceScenariosResponse) GetScenarios() []*ConformanceScenario {
+	if m != nil {
+		return m.Scenarios
+	}
+	return nil
+}
+
+// A behavior that clients are expected to show against this server.
+type ConformanceScenario struct {
+	// The ID of the scenario, such as `echo.error`. IDs are never reused.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// What the scenario does and what the client should observe.
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// The methods the scenario calls, named as
+	// `google.showcase.v1beta1.Echo/Expand`.
+	Methods []string `protobuf:"bytes,3,rep,name=methods,proto3" json:"methods,omitempty"`
+	// The fields of the requests the scenario must set, by their proto names.
+	RequiredFields []string `protobuf:"bytes,4,rep,name=required_fields,json=requiredFields,proto3" json:"required_fields,omitempty"`
+	// What the client observes when the scenario runs.
+	Outcome              *ConformanceOutcome `protobuf:"bytes,5,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ConformanceScenario) Reset()         { *m = ConformanceScenario{} }
+func (m *ConformanceScenario) String() string { return proto.CompactTextString(m) }
+func (*ConformanceScenario) ProtoMessage()    {}
+func (*ConformanceScenario) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{43}
+}
+
+func (m *ConformanceScenario) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConformanceScenario.Unmarshal(m, b)
+}
+func (m *ConformanceScenario) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConformanceScenario.Marshal(b, m, deterministic)
+}
+func (m *ConformanceScenario) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConformanceScenario.Merge(m, src)
+}
+func (m *ConformanceScenario) XXX_Size() int {
+	return xxx_messageInfo_ConformanceScenario.Size(m)
+}
+func (m *ConformanceScenario) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConformanceScenario.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConformanceScenario proto.InternalMessageInfo
+
+func (m *ConformanceScenario) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ConformanceScenario) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *ConformanceScenario) GetMethods() []string {
+	if m != nil {
+		return m.Methods
+	}
+	return nil
+}
+
+func (m *ConformanceScenario) GetRequiredFields() []string {
+	if m != nil {
+		return m.RequiredFields
+	}
+	return nil
+}
+
+func (m *ConformanceScenario) GetOutcome() *ConformanceOutcome {
+	if m != nil {
+		return m.Outcome
+	}
+	return nil
+}
+
+// The client-observable result of a conformance scenario.
+type ConformanceOutcome struct {
+	// The status code the call ends with.
+	Code code.Code `protobuf:"varint,1,opt,name=code,proto3,enum=google.rpc.Code" json:"code,omitempty"`
+	// The reasons of the google.rpc.ErrorInfo details of the error, if any.
+	Reasons []string `protobuf:"bytes,2,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	// The keys of the response headers or trailers the client receives.
+	Headers              []string `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConformanceOutcome) Reset()         { *m = ConformanceOutcome{} }
+func (m *ConformanceOutcome) String() string { return proto.CompactTextString(m) }
+func (*ConformanceOutcome) ProtoMessage()    {}
+func (*ConformanceOutcome) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{44}
+}
+
+func (m *ConformanceOutcome) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConformanceOutcome.Unmarshal(m, b)
+}
+func (m *ConformanceOutcome) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConformanceOutcome.Marshal(b, m, deterministic)
+}
+func (m *ConformanceOutcome) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConformanceOutcome.Merge(m, src)
+}
+func (m *ConformanceOutcome) XXX_Size() int {
+	return xxx_messageInfo_ConformanceOutcome.Size(m)
+}
+func (m *ConformanceOutcome) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConformanceOutcome.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConformanceOutcome proto.InternalMessageInfo
+
+func (m *ConformanceOutcome) GetCode() code.Code {
+	if m != nil {
+		return m.Code
+	}
+	return code.Code_OK
+}
+
+func (m *ConformanceOutcome) GetReasons() []string {
+	if m != nil {
+		return m.Reasons
+	}
+	return nil
+}
+
+func (m *ConformanceOutcome) GetHeaders() []string {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+// The contents of the server's stores at a single instant.
+type ServerState struct {
+	// How long the server has been running.
+	Uptime *duration.Duration `protobuf:"bytes,1,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	// The current settings.
+	Settings *ShowcaseSettings `protobuf:"bytes,2,opt,name=settings,proto3" json:"settings,omitempty"`
+	// The state of each namespace that holds any, ordered by namespace.
+	Namespaces           []*NamespaceState `protobuf:"bytes,3,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ServerState) Reset()         { *m = ServerState{} }
+func (m *ServerState) String() string { return proto.CompactTextString(m) }
+func (*ServerState) ProtoMessage()    {}
+func (*ServerState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{45}
+}
+
+func (m *ServerState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServerState.Unmarshal(m, b)
+}
+func (m *ServerState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServerState.Marshal(b, m, deterministic)
+}
+func (m *ServerState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServerState.Merge(m, src)
+}
+func (m *ServerState) XXX_Size() int {
+	return xxx_messageInfo_ServerState.Size(m)
+}
+func (m *ServerState) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServerState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServerState proto.InternalMessageInfo
+
+func (m *ServerState) GetUptime() *duration.Duration {
+	if m != nil {
+		return m.Uptime
+	}
+	return nil
+}
+
+func (m *ServerState) GetSettings() *ShowcaseSettings {
+	if m != nil {
+		return m.Settings
+	}
+	return nil
+}
+
+func (m *ServerState) GetNamespaces() []*NamespaceState {
+	if m != nil {
+		return m.Namespaces
+	}
+	return nil
+}
+
+// The state kept for one namespace. Each list is ordered by name.
+type NamespaceState struct {
+	// The namespace.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// The corpora created with CreateEchoCorpus.
+	Corpora []*EchoCorpus `protobuf:"bytes,2,rep,name=corpora,proto3" json:"corpora,omitempty"`
+	// The blobs written with Echo.WriteBlob.
+	Blobs []*BlobState `protobuf:"bytes,3,rep,name=blobs,proto3" json:"blobs,omitempty"`
+	// The names of the resources created with Echo.CreateEchoResource.
+	EchoResources []string `protobuf:"bytes,4,rep,name=echo_resources,json=echoResources,proto3" json:"echo_resources,omitempty"`
+	// The operations whose polls are recorded.
+	Operations []*PolledOperation `protobuf:"bytes,5,rep,name=operations,proto3" json:"operations,omitempty"`
+	// The responses the Echo method keeps for its `dedupe_window`.
+	CachedResponses      []*CachedEchoResponse `protobuf:"bytes,6,rep,name=cached_responses,json=cachedResponses,proto3" json:"cached_responses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *NamespaceState) Reset()         { *m = NamespaceState{} }
+func (m *NamespaceState) String() string { return proto.CompactTextString(m) }
+func (*NamespaceState) ProtoMessage()    {}
+func (*NamespaceState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{46}
+}
+
+func (m *NamespaceState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NamespaceState.Unmarshal(m, b)
+}
+func (m *NamespaceState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NamespaceState.Marshal(b, m, deterministic)
+}
+func (m *NamespaceState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NamespaceState.Merge(m, src)
+}
+func (m *NamespaceState) XXX_Size() int {
+	return xxx_messageInfo_NamespaceState.Size(m)
+}
+func (m *NamespaceState) XXX_DiscardUnknown() {
+	xxx_messageInfo_NamespaceState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NamespaceState proto.InternalMessageInfo
+
+func (m *NamespaceState) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *NamespaceState) GetCorpora() []*EchoCorpus {
+	if m != nil {
+		return m.Corpora
+	}
+	return nil
+}
+
+func (m *NamespaceState) GetBlobs() []*BlobState {
+	if m != nil {
+		return m.Blobs
+	}
+	return nil
+}
+
+func (m *NamespaceState) GetEchoResources() []string {
+	if m != nil {
+		return m.EchoResources
+	}
+	return nil
+}
+
+func (m *NamespaceState) GetOperations() []*PolledOperation {
+	if m != nil {
+		return m.Operations
+	}
+	return nil
+}
+
+func (m *NamespaceState) GetCachedResponses() []*CachedEchoResponse {
+	if m != nil {
+		return m.CachedResponses
+	}
+	return nil
+}
+
+// A blob written with Echo.WriteBlob.
+type BlobState struct {
+	// The ID of the blob.
+	BlobId string `protobuf:"bytes,1,opt,name=blob_id,json=blobId,proto3" json:"blob_id,omitempty"`
+	// The number of bytes committed.
+	CommittedSize int64 `protobuf:"varint,2,opt,name=committed_size,json=committedSize,proto3" json:"committed_size,omitempty"`
+	// The size of the blob in bytes.
+	TotalSize            int64    `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlobState) Reset()         { *m = BlobState{} }
+func (m *BlobState) String() string { return proto.CompactTextString(m) }
+func (*BlobState) ProtoMessage()    {}
+func (*BlobState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{47}
+}
+
+func (m *BlobState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlobState.Unmarshal(m, b)
+}
+func (m *BlobState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlobState.Marshal(b, m, deterministic)
+}
+func (m *BlobState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlobState.Merge(m, src)
+}
+func (m *BlobState) XXX_Size() int {
+	return xxx_messageInfo_BlobState.Size(m)
+}
+func (m *BlobState) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlobState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlobState proto.InternalMessageInfo
+
+func (m *BlobState) GetBlobId() string {
+	if m != nil {
+		return m.BlobId
+	}
+	return ""
+}
+
+func (m *BlobState) GetCommittedSize() int64 {
+	if m != nil {
+		return m.CommittedSize
+	}
+	return 0
+}
+
+func (m *BlobState) GetTotalSize() int64 {
+	if m != nil {
+		return m.TotalSize
+	}
+	return 0
+}
+
+// An operation whose polls are recorded for GetOperationPollingReport.
+type PolledOperation struct {
+	// The name of the operation.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The number of recorded polls.
+	PollCount int32 `protobuf:"varint,2,opt,name=poll_count,json=pollCount,proto3" json:"poll_count,omitempty"`
+	// The time of the latest poll.
+	LastPollTime         *timestamp.Timestamp `protobuf:"bytes,3,opt,name=last_poll_time,json=lastPollTime,proto3" json:"last_poll_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *PolledOperation) Reset()         { *m = PolledOperation{} }
+func (m *PolledOperation) String() string { return proto.CompactTextString(m) }
+func (*PolledOperation) ProtoMessage()    {}
+func (*PolledOperation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{48}
+}
+
+func (m *PolledOperation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PolledOperation.Unmarshal(m, b)
+}
+func (m *PolledOperation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PolledOperation.Marshal(b, m, deterministic)
+}
+func (m *PolledOperation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PolledOperation.Merge(m, src)
+}
+func (m *PolledOperation) XXX_Size() int {
+	return xxx_messageInfo_PolledOperation.Size(m)
+}
+func (m *PolledOperation) XXX_DiscardUnknown() {
+	xxx_messageInfo_PolledOperation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PolledOperation proto.InternalMessageInfo
+
+func (m *PolledOperation) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PolledOperation) GetPollCount() int32 {
+	if m != nil {
+		return m.PollCount
+	}
+	return 0
+}
+
+func (m *PolledOperation) GetLastPollTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastPollTime
+	}
+	return nil
+}
+
+// A response the Echo method keeps for a request's `dedupe_window`.
+type CachedEchoResponse struct {
+	// The hex-encoded SHA-256 of the request, without its `dedupe_window`.
+	RequestHash string `protobuf:"bytes,1,opt,name=request_hash,json=requestHash,proto3" json:"request_hash,omitempty"`
+	// When the response was stored.
+	StoreTime *timestamp.Timestamp `protobuf:"bytes,2,opt,name=store_time,json=storeTime,proto3" json:"store_time,omitempty"`
+	// When the response may be evicted.
+	ExpireTime *timestamp.Timestamp `protobuf:"bytes,3,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	// The content of the response.
+	Content string `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	// The server sequence of the response.
+	ServerSequence       int64    `protobuf:"varint,5,opt,name=server_sequence,json=serverSequence,proto3" json:"server_sequence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CachedEchoResponse) Reset()         { *m = CachedEchoResponse{} }
+func (m *CachedEchoResponse) String() string { return proto.CompactTextString(m) }
+func (*CachedEchoResponse) ProtoMessage()    {}
+func (*CachedEchoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{49}
+}
+
+func (m *CachedEchoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CachedEchoResponse.Unmarshal(m, b)
+}
+func (m *CachedEchoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CachedEchoResponse.Marshal(b, m, deterministic)
+}
+func (m *CachedEchoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CachedEchoResponse.Merge(m, src)
+}
+func (m *CachedEchoResponse) XXX_Size() int {
+	return xxx_messageInfo_CachedEchoResponse.Size(m)
+}
+func (m *CachedEchoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CachedEchoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CachedEchoResponse proto.InternalMessageInfo
+
+func (m *CachedEchoResponse) GetRequestHash() string {
+	if m != nil {
+		return m.RequestHash
+	}
+	return ""
+}
+
+func (m *CachedEchoResponse) GetStoreTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StoreTime
+	}
+	return nil
+}
+
+func (m *CachedEchoResponse) GetExpireTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpireTime
+	}
+	return nil
+}
+
+func (m *CachedEchoResponse) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func (m *CachedEchoResponse) GetServerSequence() int64 {
+	if m != nil {
+		return m.ServerSequence
+	}
+	return 0
+}
+
+// The request for the StartSession method.
+type StartSessionRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartSessionRequest) Reset()         { *m = StartSessionRequest{} }
+func (m *StartSessionRequest) String() string { return proto.CompactTextString(m) }
+func (*StartSessionRequest) ProtoMessage()    {}
+func (*StartSessionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{50}
+}
+
+func (m *StartSessionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartSessionRequest.Unmarshal(m, b)
+}
+func (m *StartSessionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartSessionRequest.Marshal(b, m, deterministic)
+}
+func (m *StartSessionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartSessionRequest.Merge(m, src)
+}
+func (m *StartSessionRequest) XXX_Size() int {
+	return xxx_messageInfo_StartSessionRequest.Size(m)
+}
+func (m *StartSessionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartSessionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartSessionRequest proto.InternalMessageInfo
+
+// The response of the StartSession method.
+type StartSessionResponse struct {
+	// The ID of the session, for the `showcase-session-id` metadata of its
+	// calls.
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// The namespace the calls of the session run in.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// How long the session lasts after its last call.
+	IdleTtl              *duration.Duration `protobuf:"bytes,3,opt,name=idle_ttl,json=idleTtl,proto3" json:"idle_ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *StartSessionResponse) Reset()         { *m = StartSessionResponse{} }
+func (m *StartSessionResponse) String() string { return proto.CompactTextString(m) }
+func (*StartSessionResponse) ProtoMessage()    {}
+func (*StartSessionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{51}
+}
+
+func (m *StartSessionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartSessionResponse.Unmarshal(m, b)
+}
+func (m *StartSessionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartSessionResponse.Marshal(b, m, deterministic)
+}
+func (m *StartSessionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartSessionResponse.Merge(m, src)
+}
+func (m *StartSessionResponse) XXX_Size() int {
+	return xxx_messageInfo_StartSessionResponse.Size(m)
+}
+func (m *StartSessionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartSessionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartSessionResponse proto.InternalMessageInfo
+
+func (m *StartSessionResponse) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *StartSessionResponse) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *StartSessionResponse) GetIdleTtl() *duration.Duration {
+	if m != nil {
+		return m.IdleTtl
+	}
+	return nil
+}
+
+// The request for the EndSession method.
+type EndSessionRequest struct {
+	// The ID of the session to end.
+	SessionId            string   `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EndSessionRequest) Reset()         { *m = EndSessionRequest{} }
+func (m *EndSessionRequest) String() string { return proto.CompactTextString(m) }
+func (*EndSessionRequest) ProtoMessage()    {}
+func (*EndSessionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{52}
+}
+
+func (m *EndSessionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndSessionRequest.Unmarshal(m, b)
+}
+func (m *EndSessionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndSessionRequest.Marshal(b, m, deterministic)
+}
+func (m *EndSessionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndSessionRequest.Merge(m, src)
+}
+func (m *EndSessionRequest) XXX_Size() int {
+	return xxx_messageInfo_EndSessionRequest.Size(m)
+}
+func (m *EndSessionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndSessionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndSessionRequest proto.InternalMessageInfo
+
+func (m *EndSessionRequest) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+// The response of the EndSession method.
+type EndSessionResponse struct {
+	// The number of entries deleted from each store: `polls`, `poll_budgets`,
+	// `corpora`, `blobs`, `echo_resources`, `deduplicated_responses`,
+	// `operation_ids`, `expand_statuses`, `expectations`, `byte_budgets`,
+	// `attempt_counts`, `scenarios` and `topics`.
+	Purged               map[string]int64 `protobuf:"bytes,1,rep,name=purged,proto3" json:"purged,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *EndSessionResponse) Reset()         { *m = EndSessionResponse{} }
+func (m *EndSessionResponse) String() string { return proto.CompactTextString(m) }
+func (*EndSessionResponse) ProtoMessage()    {}
+func (*EndSessionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{53}
+}
+
+func (m *EndSessionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndSessionResponse.Unmarshal(m, b)
+}
+func (m *EndSessionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndSessionResponse.Marshal(b, m, deterministic)
+}
+func (m *EndSessionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndSessionResponse.Merge(m, src)
+}
+func (m *EndSessionResponse) XXX_Size() int {
+	return xxx_messageInfo_EndSessionResponse.Size(m)
+}
+func (m *EndSessionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndSessionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndSessionResponse proto.InternalMessageInfo
+
+func (m *EndSessionResponse) GetPurged() map[string]int64 {
+	if m != nil {
+		return m.Purged
+	}
+	return nil
+}
+
+// The request for the GetEndpoints method.
+type GetEndpointsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetEndpointsRequest) Reset()         { *m = GetEndpointsRequest{} }
+func (m *GetEndpointsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetEndpointsRequest) ProtoMessage()    {}
+func (*GetEndpointsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{54}
+}
+
+func (m *GetEndpointsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEndpointsRequest.Unmarshal(m, b)
+}
+func (m *GetEndpointsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEndpointsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetEndpointsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEndpointsRequest.Merge(m, src)
+}
+func (m *GetEndpointsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetEndpointsRequest.Size(m)
+}
+func (m *GetEndpointsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEndpointsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEndpointsRequest proto.InternalMessageInfo
+
+// The response of the GetEndpoints method.
+type GetEndpointsResponse struct {
+	// The replicas of the server, by replica ID.
+	Endpoints            []*Endpoint `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *GetEndpointsResponse) Reset()         { *m = GetEndpointsResponse{} }
+func (m *GetEndpointsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetEndpointsResponse) ProtoMessage()    {}
+func (*GetEndpointsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{55}
+}
+
+func (m *GetEndpointsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEndpointsResponse.Unmarshal(m, b)
+}
+func (m *GetEndpointsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEndpointsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetEndpointsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEndpointsResponse.Merge(m, src)
+}
+func (m *GetEndpointsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetEndpointsResponse.Size(m)
+}
+func (m *GetEndpointsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEndpointsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEndpointsResponse proto.InternalMessageInfo
+
+func (m *GetEndpointsResponse) GetEndpoints() []*Endpoint {
+	if m != nil {
+		return m.Endpoints
+	}
+	return nil
+}
+
+// A replica of the server.
+type Endpoint struct {
+	// The ID of the replica, as sent in the `showcase-replica` header.
+	Replica int32 `protobuf:"varint,1,opt,name=replica,proto3" json:"replica,omitempty"`
+	// The address the replica listens on.
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// Whether the replica serves calls, rather than failing them with
+	// UNAVAILABLE.
+	Healthy              bool     `protobuf:"varint,3,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Endpoint) Reset()         { *m = Endpoint{} }
+func (m *Endpoint) String() string { return proto.CompactTextString(m) }
+func (*Endpoint) ProtoMessage()    {}
+func (*Endpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{56}
+}
+
+func (m *Endpoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Endpoint.Unmarshal(m, b)
+}
+func (m *Endpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Endpoint.Marshal(b, m, deterministic)
+}
+func (m *Endpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Endpoint.Merge(m, src)
+}
+func (m *Endpoint) XXX_Size() int {
+	return xxx_messageInfo_Endpoint.Size(m)
+}
+func (m *Endpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_Endpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Endpoint proto.InternalMessageInfo
+
+func (m *Endpoint) GetReplica() int32 {
+	if m != nil {
+		return m.Replica
+	}
+	return 0
+}
+
+func (m *Endpoint) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Endpoint) GetHealthy() bool {
+	if m != nil {
+		return m.Healthy
+	}
+	return false
+}
+
+// The request for the ExpectRequest method.
+type ExpectRequestRequest struct {
+	// The full gRPC name of the method, such as
+	// `/google.showcase.v1beta1.Echo/Echo`.
+	Method string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	// The expected request, a message of the request type of the method.
+	Expected *any.Any `protobuf:"bytes,2,opt,name=expected,proto3" json:"expected,omitempty"`
+	// The fields of the request to compare. All of them if empty.
+	FieldMask *field_mask.FieldMask `protobuf:"bytes,3,opt,name=field_mask,json=fieldMask,proto3" json:"field_mask,omitempty"`
+	// If true, a request that differs fails with FAILED_PRECONDITION and an
+	// ErrorInfo with reason `REQUEST_MISMATCH` instead of being handled.
+	FailOnMismatch       bool     `protobuf:"varint,4,opt,name=fail_on_mismatch,json=failOnMismatch,proto3" json:"fail_on_mismatch,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExpectRequestRequest) Reset()         { *m = ExpectRequestRequest{} }
+func (m *ExpectRequestRequest) String() string { return proto.CompactTextString(m) }
+func (*ExpectRequestRequest) ProtoMessage()    {}
+func (*ExpectRequestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{57}
+}
+
+func (m *ExpectRequestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExpectRequestRequest.Unmarshal(m, b)
+}
+func (m *ExpectRequestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExpectRequestRequest.Marshal(b, m, deterministic)
+}
+func (m *ExpectRequestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExpectRequestRequest.Merge(m, src)
+}
+func (m *ExpectRequestRequest) XXX_Size() int {
+	return xxx_messageInfo_ExpectRequestRequest.Size(m)
+}
+func (m *ExpectRequestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExpectRequestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExpectRequestRequest proto.InternalMessageInfo
+
+func (m *ExpectRequestRequest) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *ExpectRequestRequest) GetExpected() *any.Any {
+	if m != nil {
+		return m.Expected
+	}
+	return nil
+}
+
+func (m *ExpectRequestRequest) GetFieldMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.FieldMask
+	}
+	return nil
+}
+
+func (m *ExpectRequestRequest) GetFailOnMismatch() bool {
+	if m != nil {
+		return m.FailOnMismatch
+	}
+	return false
+}
+
+// The request for the GetExpectationResult method.
+type GetExpectationResultRequest struct {
+	// The ID of the expectation.
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetExpectationResultRequest) Reset()         { *m = GetExpectationResultRequest{} }
+func (m *GetExpectationResultRequest) String() string { return proto.CompactTextString(m) }
+func (*GetExpectationResultRequest) ProtoMessage()    {}
+func (*GetExpectationResultRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{58}
+}
+
+func (m *GetExpectationResultRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetExpectationResultRequest.Unmarshal(m, b)
+}
+func (m *GetExpectationResultRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetExpectationResultRequest.Marshal(b, m, deterministic)
+}
+func (m *GetExpectationResultRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetExpectationResultRequest.Merge(m, src)
+}
+func (m *GetExpectationResultRequest) XXX_Size() int {
+	return xxx_messageInfo_GetExpectationResultRequest.Size(m)
+}
+func (m *GetExpectationResultRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetExpectationResultRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetExpectationResultRequest proto.InternalMessageInfo
+
+func (m *GetExpectationResultRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// A request expected with ExpectRequest.
+type Expectation struct {
+	// The ID of the expectation.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The full gRPC name of the method.
+	Method string `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	// The state of the expectation.
+	State Expectation_State `protobuf:"varint,3,opt,name=state,proto3,enum=google.showcase.v1beta1.Expectation_State" json:"state,omitempty"`
+	// The fields the request differed in, in field order.
+	Diffs                []*FieldDiff `protobuf:"bytes,4,rep,name=diffs,proto3" json:"diffs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *Expectation) Reset()         { *m = Expectation{} }
+func (m *Expectation) String() string { return proto.CompactTextString(m) }
+func (*Expectation) ProtoMessage()    {}
+func (*Expectation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{59}
+}
+
+func (m *Expectation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Expectation.Unmarshal(m, b)
+}
+func (m *Expectation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Expectation.Marshal(b, m, deterministic)
+}
+func (m *Expectation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Expectation.Merge(m, src)
+}
+func (m *Expectation) XXX_Size() int {
+	return xxx_messageInfo_Expectation.Size(m)
+}
+func (m *Expectation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Expectation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Expectation proto.InternalMessageInfo
+
+func (m *Expectation) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Expectation) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *Expectation) GetState() Expectation_State {
+	if m != nil {
+		return m.State
+	}
+	return Expectation_STATE_UNSPECIFIED
+}
+
+func (m *Expectation) GetDiffs() []*FieldDiff {
+	if m != nil {
+		return m.Diffs
+	}
+	return nil
+}
+
+// A field a request differed in from an expected request.
+type FieldDiff struct {
+	// The path of the field, such as `error.code`.
+	FieldPath string `protobuf:"bytes,1,opt,name=field_path,json=fieldPath,proto3" json:"field_path,omitempty"`
+	// The expected value in protobuf text format, or empty if unset.
+	Expected string `protobuf:"bytes,2,opt,name=expected,proto3" json:"expected,omitempty"`
+	// The actual value in protobuf text format, or empty if unset.
+	Actual               string   `protobuf:"bytes,3,opt,name=actual,proto3" json:"actual,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FieldDiff) Reset()         { *m = FieldDiff{} }
+func (m *FieldDiff) String() string { return proto.CompactTextString(m) }
+func (*FieldDiff) ProtoMessage()    {}
+func (*FieldDiff) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{60}
+}
+
+func (m *FieldDiff) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FieldDiff.Unmarshal(m, b)
+}
+func (m *FieldDiff) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FieldDiff.Marshal(b, m, deterministic)
+}
+func (m *FieldDiff) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FieldDiff.Merge(m, src)
+}
+func (m *FieldDiff) XXX_Size() int {
+	return xxx_messageInfo_FieldDiff.Size(m)
+}
+func (m *FieldDiff) XXX_DiscardUnknown() {
+	xxx_messageInfo_FieldDiff.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FieldDiff proto.InternalMessageInfo
+
+func (m *FieldDiff) GetFieldPath() string {
+	if m != nil {
+		return m.FieldPath
+	}
+	return ""
+}
+
+func (m *FieldDiff) GetExpected() string {
+	if m != nil {
+		return m.Expected
+	}
+	return ""
+}
+
+func (m *FieldDiff) GetActual() string {
+	if m != nil {
+		return m.Actual
+	}
+	return ""
+}
+
+// The request for the ResetByteBudget method.
+type ResetByteBudgetRequest struct {
+	// The namespace whose byte budget to reset.
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResetByteBudgetRequest) Reset()         { *m = ResetByteBudgetRequest{} }
+func (m *ResetByteBudgetRequest) String() string { return proto.CompactTextString(m) }
+func (*ResetByteBudgetRequest) ProtoMessage()    {}
+func (*ResetByteBudgetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{61}
+}
+
+func (m *ResetByteBudgetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResetByteBudgetRequest.Unmarshal(m, b)
+}
+func (m *ResetByteBudgetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResetByteBudgetRequest.Marshal(b, m, deterministic)
+}
+func (m *ResetByteBudgetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResetByteBudgetRequest.Merge(m, src)
+}
+func (m *ResetByteBudgetRequest) XXX_Size() int {
+	return xxx_messageInfo_ResetByteBudgetRequest.Size(m)
+}
+func (m *ResetByteBudgetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResetByteBudgetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResetByteBudgetRequest proto.InternalMessageInfo
+
+func (m *ResetByteBudgetRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+// The response of the ResetByteBudget method.
+type ResetByteBudgetResponse struct {
+	// The bytes the namespace had used in the current window.
+	UsedBytes            int64    `protobuf:"varint,1,opt,name=used_bytes,json=usedBytes,proto3" json:"used_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResetByteBudgetResponse) Reset()         { *m = ResetByteBudgetResponse{} }
+func (m *ResetByteBudgetResponse) String() string { return proto.CompactTextString(m) }
+func (*ResetByteBudgetResponse) ProtoMessage()    {}
+func (*ResetByteBudgetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{62}
+}
+
+func (m *ResetByteBudgetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResetByteBudgetResponse.Unmarshal(m, b)
+}
+func (m *ResetByteBudgetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResetByteBudgetResponse.Marshal(b, m, deterministic)
+}
+func (m *ResetByteBudgetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResetByteBudgetResponse.Merge(m, src)
+}
+func (m *ResetByteBudgetResponse) XXX_Size() int {
+	return xxx_messageInfo_ResetByteBudgetResponse.Size(m)
+}
+func (m *ResetByteBudgetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResetByteBudgetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResetByteBudgetResponse proto.InternalMessageInfo
+
+func (m *ResetByteBudgetResponse) GetUsedBytes() int64 {
+	if m != nil {
+		return m.UsedBytes
+	}
+	return 0
+}
+
+// The request for the LoadScenario method.
+type LoadScenarioRequest struct {
+	// The name of the scenario, 1 to 63 lowercase letters, digits and hyphens.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// What the scenario does to the calls of the namespace.
+	Script               *ScenarioScript `protobuf:"bytes,2,opt,name=script,proto3" json:"script,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *LoadScenarioRequest) Reset()         { *m = LoadScenarioRequest{} }
+func (m *LoadScenarioRequest) String() string { return proto.CompactTextString(m) }
+func (*LoadScenarioRequest) ProtoMessage()    {}
+func (*LoadScenarioRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{63}
+}
+
+func (m *LoadScenarioRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LoadScenarioRequest.Unmarshal(m, b)
+}
+func (m *LoadScenarioRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LoadScenarioRequest.Marshal(b, m, deterministic)
+}
+func (m *LoadScenarioRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoadScenarioRequest.Merge(m, src)
+}
+func (m *LoadScenarioRequest) XXX_Size() int {
+	return xxx_messageInfo_LoadScenarioRequest.Size(m)
+}
+func (m *LoadScenarioRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoadScenarioRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoadScenarioRequest proto.InternalMessageInfo
+
+func (m *LoadScenarioRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LoadScenarioRequest) GetScript() *ScenarioScript {
+	if m != nil {
+		return m.Script
+	}
+	return nil
+}
+
+// The request for the GetScenarioState method.
+type GetScenarioStateRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetScenarioStateRequest) Reset()         { *m = GetScenarioStateRequest{} }
+func (m *GetScenarioStateRequest) String() string { return proto.CompactTextString(m) }
+func (*GetScenarioStateRequest) ProtoMessage()    {}
+func (*GetScenarioStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{64}
+}
+
+func (m *GetScenarioStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetScenarioStateRequest.Unmarshal(m, b)
+}
+func (m *GetScenarioStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetScenarioStateRequest.Marshal(b, m, deterministic)
+}
+func (m *GetScenarioStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetScenarioStateRequest.Merge(m, src)
+}
+func (m *GetScenarioStateRequest) XXX_Size() int {
+	return xxx_messageInfo_GetScenarioStateRequest.Size(m)
+}
+func (m *GetScenarioStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetScenarioStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetScenarioStateRequest proto.InternalMessageInfo
+
+// The steps of a scenario.
+type ScenarioScript struct {
+	// The steps, in the order they are tried for each call. At least one.
+	Steps                []*ScenarioStep `protobuf:"bytes,1,rep,name=steps,proto3" json:"steps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ScenarioScript) Reset()         { *m = ScenarioScript{} }
+func (m *ScenarioScript) String() string { return proto.CompactTextString(m) }
+func (*ScenarioScript) ProtoMessage()    {}
+func (*ScenarioScript) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{65}
+}
+
+func (m *ScenarioScript) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScenarioScript.Unmarshal(m, b)
+}
+func (m *ScenarioScript) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScenarioScript.Marshal(b, m, deterministic)
+}
+func (m *ScenarioScript) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScenarioScript.Merge(m, src)
+}
+func (m *ScenarioScript) XXX_Size() int {
+	return xxx_messageInfo_ScenarioScript.Size(m)
+}
+func (m *ScenarioScript) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScenarioScript.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScenarioScript proto.InternalMessageInfo
+
+func (m *ScenarioScript) GetSteps() []*ScenarioStep {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
+
+// What a scenario does to some of the calls of a method.
+type ScenarioStep struct {
+	// The full gRPC name of the method, such as
+	// `/google.showcase.v1beta1.Echo/Echo`. Methods of the Testing service
+	// cannot be scripted, so that scenarios can always be inspected and
+	// replaced.
+	Method string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	// The position among the calls of the method, counting from 1, of the
+	// first call the step applies to.
+	CallIndex int64 `protobuf:"varint,2,opt,name=call_index,json=callIndex,proto3" json:"call_index,omitempty"`
+	// How many calls from `call_index` on the step applies to. 1 if unset.
+	CallCount int64 `protobuf:"varint,3,opt,name=call_count,json=callCount,proto3" json:"call_count,omitempty"`
+	// How long to hold each call before anything else. The call still ends
+	// early if its deadline passes.
+	Delay *duration.Duration `protobuf:"bytes,4,opt,name=delay,proto3" json:"delay,omitempty"`
+	// The code to fail each call with, instead of handling it. OK handles
+	// the call.
+	Code code.Code `protobuf:"varint,5,opt,name=code,proto3,enum=google.rpc.Code" json:"code,omitempty"`
+	// The status message of the calls failed with `code`.
+	Message string `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	// The metadata to send in the response headers of each call, keyed by
+	// lowercase metadata keys.
+	Headers              map[string]string `protobuf:"bytes,7,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ScenarioStep) Reset()         { *m = ScenarioStep{} }
+func (m *ScenarioStep) String() string { return proto.CompactTextString(m) }
+func (*ScenarioStep) ProtoMessage()    {}
+func (*ScenarioStep) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{66}
+}
+
+func (m *ScenarioStep) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScenarioStep.Unmarshal(m, b)
+}
+func (m *ScenarioStep) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScenarioStep.Marshal(b, m, deterministic)
+}
+func (m *ScenarioStep) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScenarioStep.Merge(m, src)
+}
+func (m *ScenarioStep) XXX_Size() int {
+	return xxx_messageInfo_ScenarioStep.Size(m)
+}
+func (m *ScenarioStep) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScenarioStep.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScenarioStep proto.InternalMessageInfo
+
+func (m *ScenarioStep) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *ScenarioStep) GetCallIndex() int64 {
+	if m != nil {
+		return m.CallIndex
+	}
+	return 0
+}
+
+func (m *ScenarioStep) GetCallCount() int64 {
+	if m != nil {
+		return m.CallCount
+	}
+	return 0
+}
+
+func (m *ScenarioStep) GetDelay() *duration.Duration {
+	if m != nil {
+		return m.Delay
+	}
+	return nil
+}
+
+func (m *ScenarioStep) GetCode() code.Code {
+	if m != nil {
+		return m.Code
+	}
+	return code.Code_OK
+}
+
+func (m *ScenarioStep) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *ScenarioStep) GetHeaders() map[string]string {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+// A scenario loaded with LoadScenario.
+type Scenario struct {
+	// The name of the scenario.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The script of the scenario.
+	Script *ScenarioScript `protobuf:"bytes,2,opt,name=script,proto3" json:"script,omitempty"`
+	// The calls the scenario has counted, keyed by the full gRPC name of each
+	// method of the script.
+	CallCounts map[string]int64 `protobuf:"bytes,3,rep,name=call_counts,json=callCounts,proto3" json:"call_counts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// The calls each step of the script was applied to, in the order of the
+	// steps.
+	AppliedCalls []int64 `protobuf:"varint,4,rep,packed,name=applied_calls,json=appliedCalls,proto3" json:"applied_calls,omitempty"`
+	// Whether every step has passed the last call it applies to, so that the
+	// scenario no longer changes any call.
+	Exhausted            bool     `protobuf:"varint,5,opt,name=exhausted,proto3" json:"exhausted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Scenario) Reset()         { *m = Scenario{} }
+func (m *Scenario) String() string { return proto.CompactTextString(m) }
+func (*Scenario) ProtoMessage()    {}
+func (*Scenario) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ec10fb45fe35d712, []int{67}
+}
+
+func (m *Scenario) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Scenario.Unmarshal(m, b)
+}
+func (m *Scenario) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Scenario.Marshal(b, m, deterministic)
+}
+func (m *Scenario) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Scenario.Merge(m, src)
+}
+func (m *Scenario) XXX_Size() int {
+	return xxx_messageInfo_Scenario.Size(m)
+}
+func (m *Scenario) XXX_DiscardUnknown() {
+	xxx_messageInfo_Scenario.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Scenario proto.InternalMessageInfo
+
+func (m *Scenario) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Scenario) GetScript() *ScenarioScript {
+	if m != nil {
+		return m.Script
+	}
+	return nil
+}
+
+func (m *Scenario) GetCallCounts() map[string]int64 {
+	if m != nil {
+		return m.CallCounts
+	}
+	return nil
+}
+
+func (m *Scenario) GetAppliedCalls() []int64 {
+	if m != nil {
+		return m.AppliedCalls
+	}
+	return nil
+}
+
+func (m *Scenario) GetExhausted() bool {
+	if m != nil {
+		return m.Exhausted
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterEnum("google.showcase.v1beta1.ResourceNamePattern", ResourceNamePattern_name, ResourceNamePattern_value)
+	proto.RegisterEnum("google.showcase.v1beta1.Session_Version", Session_Version_name, Session_Version_value)
+	proto.RegisterEnum("google.showcase.v1beta1.ReportSessionResponse_Result", ReportSessionResponse_Result_name, ReportSessionResponse_Result_value)
+	proto.RegisterEnum("google.showcase.v1beta1.Test_ExpectationLevel", Test_ExpectationLevel_name, Test_ExpectationLevel_value)
+	proto.RegisterEnum("google.showcase.v1beta1.Issue_Type", Issue_Type_name, Issue_Type_value)
+	proto.RegisterEnum("google.showcase.v1beta1.Issue_Severity", Issue_Severity_name, Issue_Severity_value)
+	proto.RegisterEnum("google.showcase.v1beta1.Expectation_State", Expectation_State_name, Expectation_State_value)
+	proto.RegisterType((*Session)(nil), "google.showcase.v1beta1.Session")
+	proto.RegisterType((*CreateSessionRequest)(nil), "google.showcase.v1beta1.CreateSessionRequest")
+	proto.RegisterType((*GetSessionRequest)(nil), "google.showcase.v1beta1.GetSessionRequest")
+	proto.RegisterType((*ListSessionsRequest)(nil), "google.showcase.v1beta1.ListSessionsRequest")
+	proto.RegisterType((*ListSessionsResponse)(nil), "google.showcase.v1beta1.ListSessionsResponse")
+	proto.RegisterType((*DeleteSessionRequest)(nil), "google.showcase.v1beta1.DeleteSessionRequest")
+	proto.RegisterType((*ReportSessionRequest)(nil), "google.showcase.v1beta1.ReportSessionRequest")
+	proto.RegisterType((*ReportSessionResponse)(nil), "google.showcase.v1beta1.ReportSessionResponse")
+	proto.RegisterType((*Test)(nil), "google.showcase.v1beta1.Test")
+	proto.RegisterType((*Test_Blueprint)(nil), "google.showcase.v1beta1.Test.Blueprint")
+	proto.RegisterType((*Test_Blueprint_Invocation)(nil), "google.showcase.v1beta1.Test.Blueprint.Invocation")
+	proto.RegisterType((*Issue)(nil), "google.showcase.v1beta1.Issue")
+	proto.RegisterType((*ListTestsRequest)(nil), "google.showcase.v1beta1.ListTestsRequest")
+	proto.RegisterType((*ListTestsResponse)(nil), "google.showcase.v1beta1.ListTestsResponse")
+	proto.RegisterType((*TestRun)(nil), "google.showcase.v1beta1.TestRun")
+	proto.RegisterType((*DeleteTestRequest)(nil), "google.showcase.v1beta1.DeleteTestRequest")
+	proto.RegisterType((*VerifyTestRequest)(nil), "google.showcase.v1beta1.VerifyTestRequest")
+	proto.RegisterType((*VerifyTestResponse)(nil), "google.showcase.v1beta1.VerifyTestResponse")
+	proto.RegisterType((*GetOperationPollingReportRequest)(nil), "google.showcase.v1beta1.GetOperationPollingReportRequest")
+	proto.RegisterType((*OperationPollingReport)(nil), "google.showcase.v1beta1.OperationPollingReport")
+	proto.RegisterType((*GetShowcaseDescriptorsRequest)(nil), "google.showcase.v1beta1.GetShowcaseDescriptorsRequest")
+	proto.RegisterType((*GetShowcaseDescriptorsResponse)(nil), "google.showcase.v1beta1.GetShowcaseDescriptorsResponse")
+	proto.RegisterType((*GetShowcaseSettingsRequest)(nil), "google.showcase.v1beta1.GetShowcaseSettingsRequest")
+	proto.RegisterType((*ShowcaseSettings)(nil), "google.showcase.v1beta1.ShowcaseSettings")
+	proto.RegisterMapType((map[string]*LogRedaction)(nil), "google.showcase.v1beta1.ShowcaseSettings.LogRedactionsEntry")
+	proto.RegisterMapType((map[string]*ErrorInjection)(nil), "google.showcase.v1beta1.ShowcaseSettings.MethodErrorInjectionEntry")
+	proto.RegisterMapType((map[string]string)(nil), "google.showcase.v1beta1.ShowcaseSettings.MinLibraryVersionsEntry")
+	proto.RegisterType((*LogRedaction)(nil), "google.showcase.v1beta1.LogRedaction")
+	proto.RegisterType((*ErrorInjection)(nil), "google.showcase.v1beta1.ErrorInjection")
+	proto.RegisterType((*UpdateShowcaseSettingsRequest)(nil), "google.showcase.v1beta1.UpdateShowcaseSettingsRequest")
+	proto.RegisterType((*UpdateShowcaseSettingsResponse)(nil), "google.showcase.v1beta1.UpdateShowcaseSettingsResponse")
+	proto.RegisterType((*SetMethodOverloadRequest)(nil), "google.showcase.v1beta1.SetMethodOverloadRequest")
+	proto.RegisterType((*CreateEchoCorpusRequest)(nil), "google.showcase.v1beta1.CreateEchoCorpusRequest")
+	proto.RegisterType((*EchoCorpus)(nil), "google.showcase.v1beta1.EchoCorpus")
+	proto.RegisterType((*DeleteEchoCorpusRequest)(nil), "google.showcase.v1beta1.DeleteEchoCorpusRequest")
+	proto.RegisterType((*PurgeNamespaceRequest)(nil), "google.showcase.v1beta1.PurgeNamespaceRequest")
+	proto.RegisterType((*GetServerMetricsRequest)(nil), "google.showcase.v1beta1.GetServerMetricsRequest")
+	proto.RegisterType((*ServerMetrics)(nil), "google.showcase.v1beta1.ServerMetrics")
+	proto.RegisterMapType((map[string]int64)(nil), "google.showcase.v1beta1.ServerMetrics.ValuesEntry")
+	proto.RegisterType((*ParseResourceNamesRequest)(nil), "google.showcase.v1beta1.ParseResourceNamesRequest")
+	proto.RegisterType((*ParsedResourceName)(nil), "google.showcase.v1beta1.ParsedResourceName")
+	proto.RegisterMapType((map[string]string)(nil), "google.showcase.v1beta1.ParsedResourceName.SegmentsEntry")
+	proto.RegisterType((*ParseResourceNamesResponse)(nil), "google.showcase.v1beta1.ParseResourceNamesResponse")
+	proto.RegisterType((*GetChannelzSummaryRequest)(nil), "google.showcase.v1beta1.GetChannelzSummaryRequest")
+	proto.RegisterType((*ChannelzSummary)(nil), "google.showcase.v1beta1.ChannelzSummary")
+	proto.RegisterType((*MeasureRoundTripRequest)(nil), "google.showcase.v1beta1.MeasureRoundTripRequest")
+	proto.RegisterType((*MeasureRoundTripResponse)(nil), "google.showcase.v1beta1.MeasureRoundTripResponse")
+	proto.RegisterType((*DumpStateRequest)(nil), "google.showcase.v1beta1.DumpStateRequest")
+	proto.RegisterType((*ListConformanceScenariosRequest)(nil), "google.showcase.v1beta1.ListConformanceScenariosRequest")
+	proto.RegisterType((*ListConformanceScenariosResponse)(nil), "google.showcase.v1beta1.ListConformanceScenariosResponse")
+	proto.RegisterType((*ConformanceScenario)(nil), "google.showcase.v1beta1.ConformanceScenario")
+	proto.RegisterType((*ConformanceOutcome)(nil), "google.showcase.v1beta1.ConformanceOutcome")
+	proto.RegisterType((*ServerState)(nil), "google.showcase.v1beta1.ServerState")
+	proto.RegisterType((*NamespaceState)(nil), "google.showcase.v1beta1.NamespaceState")
+	proto.RegisterType((*BlobState)(nil), "google.showcase.v1beta1.BlobState")
+	proto.RegisterType((*PolledOperation)(nil), "google.showcase.v1beta1.PolledOperation")
+	proto.RegisterType((*CachedEchoResponse)(nil), "google.showcase.v1beta1.CachedEchoResponse")
+	proto.RegisterType((*StartSessionRequest)(nil), "google.showcase.v1beta1.StartSessionRequest")
+	proto.RegisterType((*StartSessionResponse)(nil), "google.showcase.v1beta1.StartSessionResponse")
+	proto.RegisterType((*EndSessionRequest)(nil), "google.showcase.v1beta1.EndSessionRequest")
+	proto.RegisterType((*EndSessionResponse)(nil), "google.showcase.v1beta1.EndSessionResponse")
+	proto.RegisterMapType((map[string]int64)(nil), "google.showcase.v1beta1.EndSessionResponse.PurgedEntry")
+	proto.RegisterType((*GetEndpointsRequest)(nil), "google.showcase.v1beta1.GetEndpointsRequest")
+	proto.RegisterType((*GetEndpointsResponse)(nil), "google.showcase.v1beta1.GetEndpointsResponse")
+	proto.RegisterType((*Endpoint)(nil), "google.showcase.v1beta1.Endpoint")
+	proto.RegisterType((*ExpectRequestRequest)(nil), "google.showcase.v1beta1.ExpectRequestRequest")
+	proto.RegisterType((*GetExpectationResultRequest)(nil), "google.showcase.v1beta1.GetExpectationResultRequest")
+	proto.RegisterType((*Expectation)(nil), "google.showcase.v1beta1.Expectation")
+	proto.RegisterType((*FieldDiff)(nil), "google.showcase.v1beta1.FieldDiff")
+	proto.RegisterType((*ResetByteBudgetRequest)(nil), "google.showcase.v1beta1.ResetByteBudgetRequest")
+	proto.RegisterType((*ResetByteBudgetResponse)(nil), "google.showcase.v1beta1.ResetByteBudgetResponse")
+	proto.RegisterType((*LoadScenarioRequest)(nil), "google.showcase.v1beta1.LoadScenarioRequest")
+	proto.RegisterType((*GetScenarioStateRequest)(nil), "google.showcase.v1beta1.GetScenarioStateRequest")
+	proto.RegisterType((*ScenarioScript)(nil), "google.showcase.v1beta1.ScenarioScript")
+	proto.RegisterType((*ScenarioStep)(nil), "google.showcase.v1beta1.ScenarioStep")
+	proto.RegisterMapType((map[string]string)(nil), "google.showcase.v1beta1.ScenarioStep.HeadersEntry")
+	proto.RegisterType((*Scenario)(nil), "google.showcase.v1beta1.Scenario")
+	proto.RegisterMapType((map[string]int64)(nil), "google.showcase.v1beta1.Scenario.CallCountsEntry")
+}
+
+func init() {
+	proto.RegisterFile("google/showcase/v1beta1/testing.proto", fileDescriptor_ec10fb45fe35d712)
+}
+
+var fileDescriptor_ec10fb45fe35d712 = []byte{
+	// 5025 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x3b, 0x4b, 0x6c, 0x1c, 0x5b,
+	0x56, 0x54, 0x77, 0xfc, 0xe9, 0x63, 0xbb, 0xd3, 0xbe, 0x6e, 0xdb, 0xed, 0xca, 0xcf, 0xa9, 0x97,
+	0x4c, 0xf2, 0x9c, 0x89, 0x9d, 0x38, 0x79, 0xf9, 0x38, 0x2f, 0xbc, 0x71, 0xda, 0x9d, 0xc4, 0x0f,
+	0x3b, 0xee, 0xa9, 0xee, 0xe4, 0xbd, 0x01, 0x44, 0xa9, 0x5c, 0x75, 0x6d, 0xd7, 0xa4, 0xba, 0xaa,
+	0x5e, 0xd5, 0x6d, 0x27, 0x7e, 0x99, 0xcc, 0x02, 0xa1, 0xc7, 0x67, 0x81, 0x46, 0x30, 0x1a, 0xc4,
+	0x02, 0x09, 0xb1, 0x00, 0x24, 0x10, 0x1b, 0x04, 0x08, 0x89, 0x0d, 0x2c, 0xd9, 0x00, 0x62, 0x89,
+	0x90, 0x58, 0xb0, 0xe1, 0x6d, 0x40, 0x48, 0x6c, 0x86, 0xcd, 0xe8, 0xfe, 0xaa, 0xaa, 0x3f, 0xd5,
+	0xdd, 0x7e, 0x2b, 0x77, 0x9d, 0x7b, 0xce, 0xbd, 0xe7, 0x9e, 0x73, 0xee, 0x39, 0xe7, 0x9e, 0x73,
+	0x0d, 0x57, 0x0f, 0x7d, 0xff, 0xd0, 0xc5, 0x6b, 0xd1, 0x91, 0xff, 0xc6, 0x32, 0x23, 0xbc, 0x76,
+	0x7c, 0x7b, 0x1f, 0x13, 0xf3, 0xf6, 0x1a, 0xc1, 0x11, 0x71, 0xbc, 0xc3, 0xd5, 0x20, 0xf4, 0x89,
+	0x8f, 0x16, 0x39, 0xda, 0xaa, 0x44, 0x5b, 0x15, 0x68, 0xea, 0x79, 0x41, 0x6f, 0x06, 0xce, 0x9a,
+	0xe9, 0x79, 0x3e, 0x31, 0x89, 0xe3, 0x7b, 0x11, 0x27, 0x53, 0x17, 0x53, 0xa3, 0x96, 0xeb, 0x60,
+	0x8f, 0x88, 0x81, 0x4b, 0xa9, 0x81, 0x03, 0x07, 0xbb, 0xb6, 0xb1, 0x8f, 0x8f, 0xcc, 0x63, 0xc7,
+	0x0f, 0x05, 0xc2, 0x52, 0x0a, 0x21, 0xc4, 0x91, 0xdf, 0x0e, 0x2d, 0xdc, 0x35, 0xc4, 0xbe, 0xf6,
+	0xdb, 0x07, 0x6b, 0xa6, 0x77, 0x22, 0x86, 0x96, 0xbb, 0x87, 0x6c, 0x1c, 0x59, 0xa1, 0x13, 0x90,
+	0x78, 0xde, 0x8b, 0x3d, 0x18, 0xed, 0x90, 0xb1, 0x2c, 0xc6, 0xcf, 0x75, 0x8f, 0xe3, 0x56, 0x40,
+	0x32, 0xa7, 0xe7, 0xac, 0xb7, 0xcc, 0xe8, 0x75, 0xd7, 0xbe, 0x62, 0x0c, 0xe2, 0xb4, 0x70, 0x44,
+	0xcc, 0x56, 0x20, 0x10, 0xe6, 0x05, 0x42, 0x18, 0x58, 0x6b, 0x96, 0x6f, 0x8b, 0x3d, 0x69, 0x7f,
+	0xa3, 0xc0, 0x44, 0x03, 0x47, 0x91, 0xe3, 0x7b, 0xe8, 0x06, 0x9c, 0xf1, 0xcc, 0x16, 0xae, 0x28,
+	0xcb, 0xca, 0xf5, 0xc2, 0x93, 0xc5, 0xaf, 0x37, 0xcb, 0x80, 0x22, 0x3e, 0x16, 0xad, 0xbd, 0x13,
+	0xbf, 0xde, 0xeb, 0x0c, 0x09, 0x3d, 0x81, 0x89, 0x63, 0x1c, 0x52, 0x48, 0x25, 0xb7, 0xac, 0x5c,
+	0x2f, 0xae, 0x5f, 0x5f, 0xcd, 0x50, 0xd5, 0xaa, 0x98, 0x7f, 0xf5, 0x15, 0xc7, 0xd7, 0x25, 0xa1,
+	0xf6, 0x08, 0x26, 0x04, 0x0c, 0x2d, 0xc2, 0xdc, 0xab, 0x9a, 0xde, 0xd8, 0xde, 0x7b, 0x61, 0xbc,
+	0x7c, 0xd1, 0xa8, 0xd7, 0xaa, 0xdb, 0x4f, 0xb7, 0x6b, 0x5b, 0xa5, 0x9f, 0x43, 0x33, 0x50, 0x78,
+	0x75, 0xdb, 0xd8, 0xd9, 0x6c, 0xd6, 0x1a, 0xcd, 0x92, 0x82, 0x26, 0xe1, 0xcc, 0xab, 0xdb, 0xc6,
+	0xad, 0x52, 0x4e, 0xd3, 0xa1, 0x5c, 0x0d, 0xb1, 0x49, 0xb0, 0x98, 0x5e, 0xc7, 0x5f, 0xb4, 0x71,
+	0x44, 0xd0, 0x06, 0x4c, 0x08, 0x56, 0xd9, 0x46, 0xa6, 0xd6, 0x97, 0x87, 0x31, 0xa6, 0x4b, 0x02,
+	0xed, 0x0e, 0xcc, 0x3e, 0xc3, 0xa4, 0x6b, 0xc2, 0x8b, 0x1d, 0x62, 0x81, 0x9f, 0x6e, 0x4a, 0x81,
+	0x71, 0x49, 0x68, 0xbf, 0xa3, 0xc0, 0xdc, 0x8e, 0x13, 0x49, 0xb2, 0x48, 0xd2, 0x9d, 0x83, 0x42,
+	0x60, 0x1e, 0x62, 0x23, 0x72, 0xbe, 0xe4, 0xc4, 0x63, 0xfa, 0x24, 0x05, 0x34, 0x9c, 0x2f, 0x31,
+	0xba, 0x00, 0xc0, 0x06, 0x89, 0xff, 0x1a, 0x73, 0x09, 0x16, 0x74, 0x86, 0xde, 0xa4, 0x00, 0xf4,
+	0x09, 0x14, 0x93, 0x61, 0x83, 0x10, 0xb7, 0x92, 0x67, 0x7b, 0x59, 0x92, 0x7b, 0x91, 0x7a, 0x5e,
+	0xdd, 0x12, 0x66, 0xa4, 0x4f, 0xc7, 0xd4, 0x4d, 0xe2, 0x6a, 0x3f, 0x80, 0x72, 0x27, 0x4f, 0x51,
+	0xe0, 0x7b, 0x11, 0x46, 0x1f, 0xc3, 0xa4, 0x54, 0x69, 0x45, 0x59, 0xce, 0x8f, 0x24, 0x9e, 0x98,
+	0x02, 0x7d, 0x0b, 0xce, 0x7a, 0xf8, 0x2d, 0x31, 0x7a, 0x58, 0x9f, 0xa1, 0xe0, 0xba, 0x64, 0x40,
+	0xbb, 0x07, 0xe5, 0x2d, 0xec, 0x62, 0x82, 0x4f, 0x29, 0xca, 0x7b, 0x50, 0xd6, 0x71, 0xe0, 0x87,
+	0xa7, 0x55, 0xc1, 0x7f, 0x2b, 0x30, 0xdf, 0x45, 0x28, 0xf6, 0xbb, 0x0b, 0xe3, 0x21, 0x8e, 0xda,
+	0x2e, 0x61, 0xb4, 0xc5, 0xf5, 0x8f, 0x32, 0x77, 0xdb, 0x97, 0x7e, 0x55, 0x67, 0xc4, 0xba, 0x98,
+	0x04, 0x3d, 0x86, 0x02, 0xc1, 0x11, 0x31, 0xc2, 0xb6, 0x17, 0x55, 0x72, 0x43, 0xe4, 0xd7, 0xc4,
+	0x11, 0xd1, 0xdb, 0x9e, 0x3e, 0x49, 0xf8, 0x8f, 0x48, 0x7b, 0x0e, 0xe3, 0x7c, 0x42, 0xb4, 0x00,
+	0x48, 0xaf, 0x35, 0x5e, 0xee, 0x34, 0xbb, 0xcc, 0x1d, 0x60, 0xbc, 0xbe, 0xd9, 0x68, 0xd4, 0xb6,
+	0x4a, 0x0a, 0xfd, 0xfd, 0x74, 0x73, 0x7b, 0xa7, 0xb6, 0x55, 0xca, 0xa1, 0x22, 0xc0, 0xf6, 0x8b,
+	0xea, 0xde, 0x6e, 0x7d, 0xa7, 0xd6, 0xac, 0x95, 0xf2, 0xda, 0xff, 0x8d, 0xc1, 0x19, 0x3a, 0x3f,
+	0x7a, 0xd0, 0x21, 0x9a, 0x2b, 0x5f, 0x6f, 0x5e, 0x86, 0x4b, 0xbd, 0x87, 0x96, 0x79, 0xd5, 0x68,
+	0xed, 0x1d, 0xfd, 0x23, 0x4f, 0xf0, 0x2f, 0xc1, 0x2c, 0x7e, 0x1b, 0x60, 0x8b, 0x7b, 0x4e, 0xc3,
+	0xc5, 0xc7, 0xd8, 0x15, 0x67, 0x79, 0x75, 0xe0, 0x9e, 0x56, 0x6b, 0x09, 0xd9, 0x0e, 0xa5, 0xd2,
+	0x4b, 0xb8, 0x0b, 0x82, 0x96, 0x61, 0x4a, 0xba, 0x40, 0x7a, 0x12, 0xf3, 0xcc, 0x4a, 0xd2, 0x20,
+	0xf4, 0x0c, 0x60, 0xdf, 0x6d, 0xe3, 0x20, 0x74, 0x3c, 0x12, 0x55, 0xce, 0x30, 0x59, 0x5e, 0x1b,
+	0xbc, 0xee, 0x13, 0x89, 0xaf, 0xa7, 0x48, 0xd5, 0xaf, 0xf2, 0x50, 0x88, 0x47, 0xd0, 0x5e, 0x87,
+	0x3c, 0x1e, 0x7d, 0xbd, 0xf9, 0x00, 0xee, 0x0d, 0x91, 0xc7, 0x5a, 0x32, 0xd9, 0xda, 0xbb, 0xf8,
+	0xb7, 0x14, 0x53, 0xd7, 0x4e, 0x72, 0xbd, 0x3b, 0xd9, 0x81, 0x89, 0x90, 0x1b, 0xaa, 0x38, 0xa5,
+	0xeb, 0x23, 0x6e, 0x63, 0x75, 0xdb, 0x3b, 0xf6, 0x2d, 0x7e, 0x7c, 0xe5, 0x14, 0xc8, 0x82, 0x39,
+	0xd3, 0xb6, 0x1d, 0x0a, 0x34, 0x5d, 0x43, 0x40, 0xa5, 0x80, 0xbe, 0xc9, 0xcc, 0x28, 0x99, 0x4e,
+	0x9c, 0xa7, 0x48, 0x6d, 0x00, 0x24, 0x18, 0x68, 0x01, 0xc6, 0x5b, 0x98, 0x1c, 0xf9, 0x36, 0x97,
+	0x9a, 0x2e, 0xbe, 0xd0, 0x4d, 0xea, 0xff, 0x43, 0xc7, 0x74, 0x9d, 0x2f, 0xb1, 0x2d, 0x59, 0x61,
+	0x12, 0x98, 0xd6, 0x67, 0x93, 0x11, 0x31, 0xab, 0xb6, 0x0f, 0xa5, 0x6e, 0xcb, 0x40, 0x97, 0xe1,
+	0x42, 0xed, 0xf3, 0x7a, 0xad, 0xda, 0xdc, 0x6c, 0x52, 0xdf, 0xbe, 0x53, 0x7b, 0x55, 0xdb, 0xe9,
+	0x32, 0xf9, 0x69, 0x98, 0xd4, 0x6b, 0xdf, 0x7d, 0xb9, 0xad, 0x33, 0xa3, 0x3f, 0x0b, 0x53, 0x7a,
+	0xad, 0xba, 0xb7, 0xbb, 0x5b, 0x7b, 0xb1, 0xc5, 0x2c, 0x7f, 0x1a, 0x26, 0xf7, 0xea, 0x94, 0x78,
+	0x73, 0xa7, 0x94, 0xd7, 0xfe, 0x36, 0x07, 0x63, 0xdb, 0x51, 0xd4, 0xc6, 0xe8, 0x3e, 0x9c, 0x21,
+	0x27, 0x01, 0x16, 0xe7, 0xfa, 0x83, 0x4c, 0xc1, 0x30, 0xec, 0xd5, 0xe6, 0x49, 0x80, 0x75, 0x46,
+	0x80, 0xaa, 0xd4, 0x05, 0x1e, 0xe3, 0xd0, 0x21, 0x27, 0xc2, 0xdc, 0xaf, 0x0d, 0x21, 0x6e, 0x08,
+	0x74, 0x3d, 0x26, 0x1c, 0x6e, 0xdf, 0x9a, 0x0e, 0x67, 0xe8, 0xa2, 0xa8, 0x0c, 0xa5, 0xe6, 0xf7,
+	0xea, 0xb5, 0xae, 0x4d, 0x4f, 0xc1, 0x44, 0xe3, 0x17, 0xb6, 0xeb, 0x75, 0xb6, 0xe7, 0x29, 0x98,
+	0xa8, 0xd7, 0x5e, 0x6c, 0x6d, 0xbf, 0x78, 0x56, 0xca, 0x21, 0x15, 0x16, 0xe8, 0x49, 0xd7, 0xf5,
+	0x5a, 0xb5, 0x69, 0x54, 0xf7, 0x5e, 0x3c, 0xdd, 0xd6, 0x77, 0x99, 0xf0, 0x4a, 0x79, 0xed, 0x63,
+	0x98, 0x94, 0xbc, 0xa0, 0x0a, 0x94, 0x1b, 0xb5, 0x57, 0x35, 0x7d, 0xbb, 0xf9, 0xbd, 0xae, 0xb9,
+	0x0b, 0x30, 0x56, 0xd3, 0xf5, 0x3d, 0x9d, 0xcf, 0xfc, 0xd9, 0xa6, 0xfe, 0x82, 0xcd, 0xac, 0xfd,
+	0xa5, 0x02, 0x25, 0x1a, 0x14, 0xa8, 0xa9, 0xc4, 0x51, 0x4a, 0x83, 0xf1, 0xc0, 0x0c, 0xb1, 0x47,
+	0xfa, 0x38, 0x57, 0x31, 0xd2, 0x19, 0xc9, 0x72, 0x03, 0x23, 0x59, 0x7e, 0x78, 0x24, 0x3b, 0x73,
+	0xba, 0x48, 0x16, 0xc0, 0x6c, 0x8a, 0x69, 0xe1, 0xd6, 0xef, 0xc0, 0x18, 0x3b, 0xc1, 0x22, 0x86,
+	0x5d, 0x18, 0xec, 0x83, 0x39, 0xee, 0xc8, 0xd1, 0xeb, 0x97, 0x61, 0x42, 0xb8, 0x6e, 0x74, 0x0e,
+	0xce, 0x50, 0x5a, 0x21, 0x9b, 0x89, 0x9f, 0x6e, 0x32, 0xa7, 0xab, 0x33, 0x20, 0xba, 0x0b, 0x63,
+	0x0e, 0xb5, 0x0f, 0x36, 0xcb, 0xd4, 0xfa, 0xc5, 0xc1, 0x56, 0xa4, 0x73, 0x64, 0xed, 0x16, 0xcc,
+	0xf2, 0xd8, 0xc8, 0x66, 0x8a, 0x73, 0x85, 0xb4, 0xd7, 0x4a, 0xd6, 0x61, 0xd1, 0x6d, 0x1f, 0x66,
+	0x5f, 0xe1, 0xd0, 0x39, 0x38, 0x19, 0x95, 0x82, 0x1e, 0x68, 0xd3, 0x8b, 0xde, 0xe0, 0x50, 0x1c,
+	0x56, 0xf1, 0x85, 0x2a, 0x30, 0xc1, 0x7f, 0x45, 0x95, 0xfc, 0x72, 0xfe, 0xfa, 0xb4, 0x2e, 0x3f,
+	0xb5, 0x4f, 0x01, 0xa5, 0xd7, 0x10, 0x62, 0x8e, 0x77, 0xa8, 0x9c, 0x66, 0x87, 0xf7, 0x60, 0xf9,
+	0x19, 0x26, 0x7b, 0x01, 0xe6, 0xfa, 0xac, 0xfb, 0xae, 0xeb, 0x78, 0x87, 0x3c, 0xbe, 0x4a, 0xf6,
+	0x51, 0x9a, 0x7d, 0xb1, 0xcf, 0x3f, 0x54, 0x60, 0xa1, 0x3f, 0x55, 0x3f, 0x74, 0xf4, 0x10, 0x20,
+	0xf0, 0x5d, 0xd7, 0x60, 0x99, 0xae, 0x08, 0xc6, 0x6a, 0x8f, 0x55, 0x35, 0x65, 0x1e, 0xac, 0x17,
+	0x28, 0x36, 0xfb, 0x44, 0xf7, 0xa1, 0xe0, 0x78, 0x04, 0x87, 0xc7, 0xa6, 0xcb, 0x25, 0x31, 0xd0,
+	0x1e, 0x13, 0x5c, 0xed, 0x21, 0x5c, 0xa0, 0x09, 0xa2, 0xd8, 0xfe, 0x56, 0x9c, 0xe4, 0xc7, 0xc7,
+	0xa9, 0x42, 0xb3, 0xcf, 0xf0, 0xd8, 0xb1, 0x24, 0xaf, 0xf2, 0x53, 0x23, 0x70, 0x31, 0x8b, 0x54,
+	0x48, 0x5b, 0x87, 0xb9, 0x03, 0xc7, 0xc5, 0x46, 0x72, 0x77, 0x30, 0x22, 0x4c, 0x84, 0xec, 0xb5,
+	0x1e, 0xfe, 0x9e, 0x3a, 0x6e, 0x6a, 0x9a, 0x06, 0x26, 0xfa, 0xec, 0x41, 0x37, 0x48, 0x3b, 0x0f,
+	0x6a, 0x6a, 0xd5, 0x06, 0x26, 0xf4, 0x6e, 0x25, 0xb9, 0xd5, 0xfe, 0xfe, 0x2c, 0x94, 0xba, 0xc7,
+	0xd0, 0x43, 0x58, 0x6a, 0x99, 0x6f, 0x0d, 0xcb, 0x77, 0x5d, 0x6c, 0x11, 0xc3, 0xf2, 0x3d, 0x82,
+	0x3d, 0x62, 0xec, 0x9f, 0x10, 0x1c, 0x31, 0x66, 0xf2, 0xfa, 0x42, 0xcb, 0x7c, 0x5b, 0xe5, 0xe3,
+	0x55, 0x3e, 0xfc, 0x84, 0x8e, 0xa2, 0x8f, 0x60, 0xd1, 0xc6, 0x07, 0x66, 0xdb, 0x25, 0xc6, 0xbe,
+	0xeb, 0xef, 0x1b, 0xd6, 0x51, 0xdb, 0x7b, 0x9d, 0x76, 0x1b, 0x65, 0x31, 0xfc, 0xc4, 0xf5, 0xf7,
+	0xab, 0x74, 0x90, 0xb9, 0x90, 0x9b, 0x30, 0x47, 0x57, 0xec, 0x26, 0xc9, 0x33, 0x92, 0x52, 0xcb,
+	0x7c, 0xdb, 0x89, 0xae, 0xc1, 0x4c, 0x8c, 0xce, 0x10, 0xcf, 0x30, 0xa6, 0xa6, 0x04, 0x22, 0xc3,
+	0xb9, 0x0d, 0xf3, 0x09, 0x0e, 0xf1, 0xc3, 0xd8, 0x7d, 0x8d, 0x31, 0x5c, 0x24, 0x71, 0xf9, 0x10,
+	0x23, 0xb9, 0x01, 0xb3, 0x51, 0x3b, 0xa0, 0xe6, 0x86, 0x6d, 0xc3, 0xf5, 0x2d, 0xd3, 0xc5, 0x51,
+	0x65, 0x7c, 0x39, 0x7f, 0xbd, 0xa0, 0x97, 0xe2, 0x81, 0x1d, 0x0e, 0x47, 0xdf, 0x06, 0x3a, 0x85,
+	0x11, 0x62, 0xcb, 0x0f, 0x6d, 0x6c, 0x1b, 0xd4, 0xb6, 0xa2, 0xca, 0x44, 0xcc, 0xb1, 0x2e, 0x06,
+	0xa8, 0x19, 0x47, 0xe8, 0x31, 0xe7, 0x98, 0x99, 0xeb, 0x1b, 0xd3, 0x21, 0x95, 0xc9, 0x61, 0x3e,
+	0x90, 0x6e, 0x86, 0xd2, 0x7e, 0x66, 0x3a, 0x04, 0xdd, 0x01, 0x2a, 0x70, 0x23, 0xc2, 0x9e, 0x6d,
+	0xb4, 0x70, 0x14, 0xd1, 0xcd, 0x70, 0x75, 0x14, 0xd8, 0x82, 0x54, 0x7a, 0x0d, 0xec, 0xd9, 0xbb,
+	0x7c, 0x8c, 0xeb, 0xa2, 0xd7, 0xf1, 0xc2, 0xa9, 0x1c, 0x2f, 0x5a, 0x87, 0x79, 0x7e, 0x75, 0x36,
+	0x4c, 0x42, 0xe8, 0x6d, 0xd4, 0x38, 0xc2, 0xa6, 0x8d, 0xc3, 0xca, 0x14, 0x33, 0xec, 0x39, 0x3e,
+	0xb8, 0xc9, 0xc7, 0x9e, 0xb3, 0xa1, 0x58, 0x93, 0x26, 0xb1, 0x8e, 0x0c, 0x6c, 0x1d, 0xf9, 0x5c,
+	0xe8, 0xd3, 0x89, 0x26, 0xe9, 0x48, 0xcd, 0x3a, 0xf2, 0x99, 0xc8, 0x3f, 0x80, 0x19, 0xd3, 0x6e,
+	0x39, 0x9e, 0x81, 0x3d, 0x73, 0xdf, 0xc5, 0x76, 0x65, 0x66, 0x59, 0xb9, 0x3e, 0xa9, 0x4f, 0x33,
+	0x60, 0x8d, 0xc3, 0x50, 0x1d, 0xce, 0xe2, 0x30, 0xf4, 0x43, 0xc3, 0xf1, 0xbe, 0x8f, 0x2d, 0x16,
+	0x6e, 0x8b, 0x6c, 0x27, 0xd9, 0x61, 0xbb, 0x46, 0xf1, 0xb7, 0x25, 0xba, 0x5e, 0xc4, 0x1d, 0xdf,
+	0xe8, 0x04, 0x16, 0x78, 0x86, 0x63, 0x74, 0x4f, 0x7c, 0x96, 0xf9, 0x82, 0x6a, 0xf6, 0x95, 0xa8,
+	0xeb, 0xb0, 0xac, 0xee, 0xb2, 0x79, 0x3a, 0xd7, 0xab, 0x79, 0x24, 0x3c, 0xd1, 0xcb, 0xad, 0x3e,
+	0x43, 0xe8, 0xe7, 0x61, 0xc6, 0x97, 0x2e, 0x8e, 0x29, 0xa5, 0x34, 0x54, 0x29, 0x31, 0x3e, 0x55,
+	0x8a, 0x05, 0x45, 0xd7, 0x3f, 0x34, 0x42, 0x6c, 0x9b, 0x6c, 0xc2, 0xa8, 0x32, 0xcb, 0x58, 0xfe,
+	0x78, 0x74, 0x96, 0x77, 0xfc, 0x43, 0x3d, 0x26, 0xe7, 0xbc, 0xce, 0xb8, 0x69, 0x18, 0xba, 0x0e,
+	0x54, 0x55, 0x86, 0xeb, 0x1f, 0x1e, 0x62, 0x5b, 0x58, 0x1a, 0x62, 0x2a, 0x2c, 0xb6, 0xcc, 0xb7,
+	0x3b, 0x0c, 0xcc, 0x8d, 0xec, 0x12, 0x4c, 0x39, 0x5e, 0x44, 0x4c, 0xcf, 0xc2, 0x86, 0x63, 0x57,
+	0xe6, 0x98, 0x65, 0x80, 0x04, 0x6d, 0xdb, 0xf4, 0x9c, 0xb8, 0x4e, 0x44, 0x8c, 0xc8, 0x0a, 0xcd,
+	0xd6, 0xbe, 0x8b, 0x8d, 0x08, 0x63, 0xbb, 0x52, 0x66, 0x87, 0xb0, 0x44, 0x47, 0x1a, 0x62, 0xa0,
+	0x81, 0xb1, 0x8d, 0x6e, 0x41, 0x39, 0x22, 0xa1, 0x63, 0x11, 0xe3, 0x8b, 0xb6, 0x4f, 0x4c, 0x23,
+	0x08, 0x7d, 0x2a, 0xb8, 0xca, 0x3c, 0x33, 0x0b, 0xc4, 0xc7, 0xbe, 0x4b, 0x87, 0xea, 0x7c, 0x04,
+	0x6d, 0x73, 0x83, 0x8b, 0x48, 0x88, 0xcd, 0x96, 0x21, 0x6b, 0x2a, 0x95, 0x85, 0x61, 0x52, 0x9d,
+	0xa5, 0x47, 0x86, 0x11, 0x49, 0x10, 0xb5, 0x77, 0x1a, 0x57, 0xa2, 0xc0, 0xb4, 0xf8, 0xf1, 0x32,
+	0xf6, 0xdb, 0xf6, 0x21, 0x26, 0x95, 0x45, 0xc6, 0xed, 0x5c, 0x3c, 0x48, 0xb7, 0xfe, 0x84, 0x0d,
+	0xa1, 0x67, 0x80, 0x52, 0x98, 0xc6, 0x1b, 0xc7, 0xb3, 0xfd, 0x37, 0x95, 0xca, 0xb0, 0xd5, 0x4b,
+	0xfb, 0xf1, 0x14, 0x9f, 0x31, 0x12, 0x14, 0x41, 0x99, 0x9e, 0x03, 0xd7, 0xd9, 0x0f, 0xcd, 0xf0,
+	0xc4, 0x10, 0x15, 0x92, 0xa8, 0xb2, 0xc4, 0xb4, 0xbb, 0x79, 0x0a, 0x83, 0x74, 0xbc, 0x1d, 0x3e,
+	0x89, 0x28, 0xad, 0x08, 0x15, 0xa3, 0x56, 0xcf, 0x00, 0xba, 0x07, 0x8b, 0x42, 0xdc, 0x3d, 0xeb,
+	0xaa, 0x4c, 0xe2, 0xf3, 0x7c, 0xb8, 0x9b, 0xee, 0x0e, 0xcc, 0x7b, 0xbe, 0x67, 0xf9, 0xde, 0x81,
+	0x1f, 0xb6, 0x1c, 0xef, 0x30, 0x3e, 0xbe, 0xe7, 0x18, 0x55, 0xb9, 0x63, 0x50, 0x1e, 0xe3, 0xbb,
+	0x30, 0xc9, 0xbc, 0x31, 0x35, 0xfa, 0xf3, 0xc3, 0x04, 0x34, 0x41, 0x51, 0x9b, 0xc4, 0x55, 0x03,
+	0x58, 0xca, 0x3c, 0x62, 0xa8, 0x04, 0xf9, 0xd7, 0xf8, 0x44, 0x04, 0x5a, 0xfa, 0x13, 0x3d, 0x86,
+	0xb1, 0x63, 0xd3, 0x8d, 0x53, 0xb2, 0x91, 0x3d, 0x04, 0xa7, 0xda, 0xc8, 0x3d, 0x50, 0xd4, 0x43,
+	0x40, 0xbd, 0x27, 0xa4, 0xcf, 0x52, 0x8f, 0x3a, 0x97, 0xba, 0x9a, 0xb9, 0x54, 0x7a, 0xb6, 0xf4,
+	0x42, 0x35, 0x58, 0xcc, 0x50, 0x56, 0x9f, 0xd5, 0xca, 0xe9, 0xd5, 0x0a, 0xa9, 0x69, 0xb4, 0x2b,
+	0x30, 0x9d, 0x5e, 0x81, 0x62, 0x06, 0x26, 0x39, 0xe2, 0xa9, 0x71, 0x41, 0xe7, 0x1f, 0xda, 0x6f,
+	0x28, 0x50, 0xec, 0x72, 0x45, 0x17, 0x00, 0xb8, 0xfb, 0x0b, 0x4d, 0xc2, 0xb3, 0x15, 0x45, 0x2f,
+	0x30, 0x88, 0x6e, 0x12, 0x4c, 0x53, 0x2e, 0xcb, 0xb7, 0x65, 0xe0, 0x66, 0xbf, 0x51, 0x15, 0x4a,
+	0x21, 0x26, 0xe1, 0x89, 0xe1, 0x78, 0x07, 0xbe, 0x61, 0x63, 0xd7, 0x3c, 0x19, 0x5e, 0x98, 0x2a,
+	0x32, 0x92, 0x6d, 0xef, 0xc0, 0xdf, 0xa2, 0x04, 0xda, 0x9f, 0x2a, 0x70, 0xe1, 0x65, 0x60, 0x9b,
+	0x04, 0x67, 0xa4, 0x25, 0xe8, 0x53, 0x7a, 0x43, 0xe3, 0x20, 0x91, 0xfd, 0x7c, 0x38, 0xf2, 0x01,
+	0x78, 0x92, 0xff, 0x8f, 0xcd, 0x9c, 0x1e, 0xd3, 0xa3, 0x47, 0x30, 0xd5, 0x66, 0x8b, 0xb1, 0x6a,
+	0xa9, 0x50, 0x96, 0xda, 0x27, 0x99, 0xc2, 0xae, 0xbd, 0x6b, 0x46, 0xaf, 0x75, 0xe0, 0xe8, 0xf4,
+	0xb7, 0xf6, 0xe7, 0x0a, 0x5c, 0xcc, 0x62, 0x55, 0x24, 0x6d, 0x35, 0x98, 0x0c, 0x42, 0x7c, 0xec,
+	0xf8, 0xed, 0xd3, 0xf3, 0xaa, 0xc7, 0xa4, 0xa8, 0x0a, 0x13, 0x56, 0x3b, 0x64, 0xf7, 0xb0, 0xdc,
+	0x69, 0x67, 0x91, 0x94, 0xda, 0x8f, 0x14, 0xa8, 0x34, 0x30, 0xe1, 0x07, 0x66, 0xef, 0x18, 0x87,
+	0xae, 0x6f, 0xda, 0xc9, 0x85, 0xa1, 0xe3, 0x92, 0xcf, 0xe5, 0x24, 0x40, 0xf4, 0x86, 0xf7, 0x45,
+	0x10, 0x19, 0xae, 0xd3, 0x72, 0x38, 0x03, 0x8a, 0x3e, 0xf9, 0x45, 0x10, 0xed, 0xd0, 0x6f, 0xb4,
+	0x01, 0x53, 0x5c, 0xeb, 0x23, 0x2a, 0x1c, 0x18, 0x36, 0x57, 0xf6, 0x2e, 0x2c, 0xf2, 0x2a, 0x2d,
+	0x8d, 0xf9, 0x55, 0x3f, 0x0c, 0xda, 0xb1, 0x96, 0x17, 0x3b, 0x6e, 0x30, 0x8c, 0x1d, 0x06, 0x40,
+	0x4b, 0x30, 0xf6, 0xc6, 0x0f, 0x6d, 0x9e, 0xd3, 0x8b, 0x11, 0x0e, 0xd1, 0xee, 0x01, 0x24, 0x13,
+	0xf5, 0xbd, 0x15, 0x94, 0x3b, 0x88, 0x25, 0xdd, 0x3a, 0x2c, 0xf2, 0x4b, 0xd7, 0xe8, 0x6c, 0x68,
+	0x1b, 0x30, 0x5f, 0x6f, 0x87, 0x87, 0xf8, 0x85, 0xf4, 0xfb, 0x92, 0xe2, 0x32, 0x14, 0xe2, 0x58,
+	0x90, 0x26, 0x4b, 0xa0, 0xda, 0x12, 0x2c, 0xb2, 0x42, 0x72, 0x78, 0x8c, 0xc3, 0x5d, 0x4c, 0x9d,
+	0x68, 0x9c, 0x73, 0xff, 0x44, 0x81, 0x99, 0x8e, 0x01, 0xf4, 0x29, 0x8c, 0xb3, 0xe3, 0x2c, 0x6f,
+	0xb3, 0xd9, 0x45, 0x9e, 0x0e, 0xba, 0xd5, 0x57, 0x8c, 0x88, 0xbb, 0x77, 0x31, 0x83, 0xfa, 0x10,
+	0xa6, 0x52, 0xe0, 0x61, 0x8e, 0x24, 0x9f, 0x76, 0x24, 0x87, 0xb0, 0x54, 0x37, 0xc3, 0x08, 0xeb,
+	0xa2, 0xeb, 0xc1, 0xf6, 0x9d, 0xec, 0x79, 0x3a, 0x72, 0xbc, 0x43, 0x17, 0x1b, 0x81, 0x19, 0x9a,
+	0x2d, 0x31, 0xe3, 0x14, 0x87, 0xd5, 0x29, 0x08, 0x5d, 0x83, 0xb3, 0x21, 0x0e, 0xa8, 0xae, 0x6d,
+	0x8e, 0x24, 0x75, 0x50, 0x94, 0x60, 0x86, 0x17, 0x69, 0x7f, 0x94, 0x03, 0xc4, 0x56, 0xb2, 0xd3,
+	0x4b, 0xf5, 0xd5, 0xe6, 0x53, 0x98, 0x08, 0x4c, 0x42, 0x70, 0x28, 0xbb, 0x0c, 0xdf, 0x1e, 0x50,
+	0xbf, 0x4d, 0xe6, 0xaa, 0x73, 0x1a, 0x5d, 0x12, 0xa3, 0x97, 0xd4, 0xa3, 0x1c, 0xb6, 0xb0, 0x47,
+	0xe4, 0x7d, 0xef, 0x61, 0xe6, 0x44, 0xbd, 0xac, 0xad, 0x36, 0x04, 0x2d, 0x97, 0x75, 0x3c, 0x15,
+	0x3a, 0x0f, 0x85, 0x37, 0x8e, 0x6b, 0x5b, 0x66, 0x68, 0xf3, 0x0a, 0x5d, 0x41, 0x4f, 0x00, 0xea,
+	0x23, 0xaa, 0xe8, 0x14, 0xe1, 0xa9, 0xdc, 0xfa, 0x3f, 0x28, 0xa0, 0xf6, 0x53, 0x87, 0x70, 0x3b,
+	0x2f, 0xfa, 0xe8, 0x63, 0x6a, 0xfd, 0xc6, 0x29, 0x36, 0xd5, 0xa9, 0xbc, 0x66, 0x7f, 0xe5, 0x9d,
+	0x72, 0xca, 0x6e, 0x4d, 0x9f, 0x83, 0xa5, 0x67, 0x98, 0x54, 0x8f, 0x4c, 0xcf, 0xc3, 0xee, 0x97,
+	0x8d, 0x76, 0xab, 0x65, 0x86, 0x27, 0xf2, 0x20, 0xfc, 0x9b, 0x02, 0x67, 0xbb, 0x86, 0xa8, 0x99,
+	0xf9, 0x01, 0xf6, 0x8c, 0xc8, 0xb7, 0x5e, 0x63, 0x22, 0xaf, 0x9b, 0x53, 0x14, 0xd6, 0xe0, 0x20,
+	0x6a, 0x66, 0x3c, 0xdb, 0x8b, 0x8c, 0x88, 0x98, 0xf4, 0x4e, 0x26, 0x4c, 0xb9, 0x28, 0xc0, 0x0d,
+	0x0e, 0x65, 0xf7, 0x39, 0x89, 0xd8, 0xb6, 0x2c, 0x8c, 0x6d, 0x6c, 0x33, 0xe7, 0x95, 0xd7, 0x4b,
+	0x12, 0x55, 0xc2, 0xd1, 0x55, 0x90, 0xe4, 0xc6, 0x81, 0xe9, 0xd0, 0x5c, 0x86, 0x5f, 0x2a, 0x67,
+	0x04, 0xf4, 0x29, 0x03, 0xd2, 0xcc, 0xf8, 0x35, 0xc6, 0x81, 0x61, 0xba, 0xce, 0x31, 0x8e, 0xe8,
+	0x8d, 0x8c, 0x88, 0x1b, 0x65, 0x91, 0xc2, 0x37, 0x19, 0xb8, 0x41, 0x7d, 0xf1, 0x67, 0xb0, 0xb8,
+	0x8b, 0xcd, 0xa8, 0x1d, 0x62, 0xdd, 0x6f, 0x7b, 0x76, 0x33, 0x74, 0x02, 0x79, 0x96, 0x96, 0x60,
+	0xcc, 0xf2, 0xdb, 0xa2, 0xe2, 0x36, 0x26, 0xfc, 0x1b, 0x83, 0xd0, 0xfd, 0x07, 0xe6, 0x09, 0x75,
+	0xdb, 0xe9, 0x5b, 0xf3, 0x94, 0x80, 0xd1, 0x3b, 0x93, 0xf6, 0x67, 0x39, 0xa8, 0xf4, 0xce, 0x2c,
+	0xcc, 0xa2, 0xdc, 0x31, 0xb5, 0x9c, 0xf5, 0x06, 0xe4, 0x83, 0x8f, 0x6e, 0x55, 0x72, 0xc3, 0x1c,
+	0x37, 0xc5, 0x62, 0xc8, 0x0f, 0x6f, 0x0d, 0xf7, 0xf2, 0x14, 0x8b, 0x23, 0x3f, 0x1c, 0x5e, 0xd2,
+	0xa3, 0x58, 0x14, 0xb9, 0x65, 0xbe, 0xad, 0x8c, 0x0d, 0x45, 0x6e, 0x99, 0x6f, 0x69, 0x5c, 0x8d,
+	0x73, 0x80, 0xf1, 0x53, 0xc7, 0x55, 0x49, 0xaa, 0x3d, 0x82, 0xd2, 0x56, 0xbb, 0x15, 0x34, 0x88,
+	0x49, 0x62, 0xff, 0xcd, 0x1c, 0x15, 0x4d, 0x97, 0x0c, 0x21, 0x57, 0x6e, 0x67, 0x93, 0x7a, 0x91,
+	0x83, 0xeb, 0x02, 0xaa, 0x3d, 0x84, 0x4b, 0xb4, 0xf4, 0x58, 0xe5, 0xb9, 0x2c, 0xbd, 0xd1, 0x34,
+	0x2c, 0xec, 0x99, 0xa1, 0xe3, 0xc7, 0x7e, 0x31, 0xa3, 0x74, 0xae, 0x79, 0xb0, 0x9c, 0x4d, 0x2a,
+	0x94, 0xf5, 0x29, 0x14, 0x22, 0x09, 0x14, 0xae, 0x3f, 0xdb, 0xbd, 0xf5, 0x99, 0x49, 0x4f, 0xc8,
+	0xb5, 0x7f, 0x51, 0x60, 0xae, 0x0f, 0x0a, 0x2a, 0x42, 0xce, 0x91, 0xbc, 0xe5, 0x1c, 0x7b, 0x84,
+	0x6e, 0x46, 0x05, 0x26, 0xf8, 0x1e, 0xb8, 0xa7, 0x2c, 0xe8, 0xf2, 0x93, 0xcb, 0xed, 0x8b, 0xb6,
+	0x13, 0x62, 0xdb, 0x60, 0x0d, 0x68, 0xe9, 0xf3, 0x8a, 0x12, 0xcc, 0xb2, 0xa8, 0x08, 0xd5, 0x60,
+	0xc2, 0x6f, 0x13, 0xcb, 0x6f, 0x61, 0xa1, 0xec, 0x1b, 0xa3, 0x6c, 0x6b, 0x8f, 0x93, 0xe8, 0x92,
+	0x56, 0xf3, 0x00, 0xf5, 0x0e, 0xa3, 0x2b, 0x22, 0x2f, 0xe5, 0x75, 0xff, 0x92, 0x9c, 0x39, 0x0c,
+	0xac, 0xd5, 0xaa, 0x6f, 0x63, 0x91, 0xa9, 0x56, 0x68, 0x4f, 0xc6, 0x8c, 0x7c, 0x4f, 0x06, 0x21,
+	0xf9, 0x49, 0x47, 0x78, 0x1d, 0x23, 0xde, 0x9f, 0xf8, 0xd4, 0xfe, 0x59, 0x81, 0x29, 0x1e, 0x61,
+	0x99, 0xb9, 0xa0, 0xdb, 0x30, 0xde, 0x0e, 0x88, 0xd3, 0x92, 0xe5, 0xcf, 0x01, 0x26, 0x2b, 0x10,
+	0x3b, 0xac, 0x36, 0xf7, 0x8d, 0xad, 0x96, 0xf6, 0xc6, 0xe2, 0x5c, 0x42, 0x06, 0xac, 0xec, 0xbb,
+	0x4c, 0x9c, 0xa0, 0x70, 0x2b, 0x4f, 0x91, 0x6a, 0xff, 0x93, 0x83, 0x62, 0xe7, 0x30, 0x8d, 0x59,
+	0x5d, 0xd9, 0x4b, 0x2a, 0x71, 0x41, 0x8f, 0x61, 0xc2, 0xf2, 0xc3, 0xc0, 0x0f, 0x4d, 0xe1, 0xff,
+	0xb3, 0x1b, 0x2b, 0xa9, 0x54, 0x4a, 0xd2, 0xa0, 0x07, 0x30, 0x46, 0x6f, 0x6e, 0x92, 0x67, 0x2d,
+	0x93, 0x98, 0x17, 0xdf, 0x28, 0xbb, 0x9c, 0x80, 0x3a, 0x60, 0x56, 0x2f, 0x92, 0x6f, 0x2e, 0xa4,
+	0x6d, 0xcd, 0x50, 0xa8, 0x0c, 0x32, 0x11, 0x7a, 0x0e, 0x10, 0xd7, 0x43, 0xa2, 0xca, 0x18, 0x5b,
+	0x25, 0xfb, 0xe5, 0x01, 0xad, 0xa0, 0x61, 0x3b, 0xae, 0x29, 0xeb, 0x29, 0x5a, 0xf4, 0x0a, 0x4a,
+	0x96, 0x69, 0x1d, 0xb1, 0xc6, 0x16, 0x3f, 0x90, 0xbc, 0xda, 0x37, 0xd0, 0x5a, 0x19, 0x41, 0x8d,
+	0x73, 0xc4, 0x68, 0xf4, 0xb3, 0x7c, 0x12, 0xf9, 0x1d, 0x69, 0xdf, 0x87, 0x42, 0xbc, 0x39, 0xb4,
+	0x08, 0xec, 0x26, 0x6b, 0xc4, 0x67, 0x70, 0x9c, 0x7e, 0x6e, 0xb3, 0x78, 0x63, 0xf9, 0xad, 0x96,
+	0x43, 0x08, 0x4e, 0xb9, 0xfa, 0xbc, 0x3e, 0x13, 0x43, 0x65, 0x73, 0x85, 0xf8, 0xc4, 0x74, 0x93,
+	0x82, 0x68, 0x5e, 0x2f, 0x30, 0x08, 0x8b, 0x05, 0x5f, 0x29, 0x70, 0xb6, 0x6b, 0x8f, 0x7d, 0xd3,
+	0xa8, 0x0b, 0xa2, 0x54, 0xce, 0x63, 0x03, 0x0f, 0x2a, 0xac, 0x1c, 0x5e, 0xa5, 0x00, 0xf4, 0x1d,
+	0x28, 0xba, 0x66, 0x44, 0x8c, 0xb8, 0x9c, 0x5e, 0xc9, 0x67, 0x5c, 0x93, 0x92, 0x6a, 0xfa, 0x34,
+	0xa5, 0xa8, 0x8b, 0x8a, 0xba, 0xf6, 0xbf, 0x0a, 0xa0, 0x5e, 0xe1, 0xd0, 0x70, 0x26, 0xba, 0x86,
+	0xc6, 0x91, 0x19, 0x1d, 0xc9, 0xac, 0x51, 0xc0, 0x9e, 0x9b, 0xd1, 0x11, 0xad, 0xe2, 0x47, 0xc4,
+	0x0f, 0x31, 0x5f, 0x37, 0x37, 0x74, 0xdd, 0x02, 0xc3, 0xa6, 0xdf, 0xf4, 0x6a, 0x87, 0xdf, 0x06,
+	0x4e, 0x88, 0x47, 0xe5, 0x19, 0x38, 0x3a, 0x23, 0xae, 0x50, 0x43, 0x67, 0xa5, 0x6b, 0x16, 0xbd,
+	0x0a, 0xba, 0xfc, 0x64, 0x09, 0x06, 0xf3, 0x02, 0x46, 0x44, 0xf9, 0xf4, 0x2c, 0x59, 0x34, 0x2e,
+	0x72, 0x70, 0x43, 0x40, 0xb5, 0x79, 0x98, 0x63, 0xb9, 0x46, 0xe7, 0x63, 0x05, 0xed, 0x37, 0x15,
+	0x28, 0x77, 0xc2, 0x85, 0x34, 0x2e, 0x00, 0x88, 0xfe, 0x73, 0x62, 0x0f, 0x05, 0x01, 0xd9, 0xb6,
+	0x3b, 0x0f, 0x66, 0xae, 0xfb, 0x60, 0xde, 0x85, 0x49, 0xc7, 0x76, 0xf1, 0x68, 0x6f, 0x41, 0x26,
+	0x28, 0x2a, 0x6d, 0x9e, 0xdd, 0x87, 0xd9, 0x9a, 0x67, 0x77, 0x32, 0x88, 0xb4, 0x5e, 0x3e, 0xc4,
+	0x05, 0x26, 0x66, 0x86, 0xf6, 0x62, 0x50, 0x9a, 0x52, 0x6c, 0x61, 0x0f, 0xc6, 0x03, 0x7a, 0x27,
+	0xb2, 0x45, 0xbc, 0xba, 0x9f, 0xed, 0x1d, 0x7a, 0x88, 0x57, 0xd9, 0x6d, 0xca, 0x16, 0xf7, 0x15,
+	0x3e, 0x0d, 0xbd, 0xaf, 0xa4, 0xc0, 0xa7, 0xba, 0xaf, 0xcc, 0xc3, 0xdc, 0x33, 0x4c, 0x6a, 0x9e,
+	0x1d, 0xf8, 0x8e, 0x17, 0x37, 0x34, 0xb5, 0xcf, 0xa0, 0xdc, 0x09, 0x16, 0xac, 0x7f, 0x02, 0x05,
+	0x2c, 0x81, 0x82, 0xfb, 0xcb, 0x83, 0xb8, 0x67, 0x98, 0x7a, 0x42, 0xa3, 0x7d, 0x0e, 0x93, 0x12,
+	0xcc, 0xc3, 0x4b, 0xe0, 0x3a, 0x96, 0x29, 0x32, 0x2d, 0xf9, 0x49, 0x47, 0x4c, 0xdb, 0x0e, 0x71,
+	0x14, 0x09, 0x1d, 0xca, 0x4f, 0x11, 0x78, 0x5c, 0x72, 0xc4, 0xaf, 0xd0, 0x93, 0xba, 0xfc, 0xd4,
+	0xfe, 0x49, 0x81, 0x32, 0xef, 0x9c, 0x8b, 0x4d, 0x8c, 0x74, 0x67, 0xff, 0x08, 0x26, 0xf9, 0xb3,
+	0x0b, 0x91, 0x01, 0x4f, 0xad, 0x97, 0x7b, 0x2c, 0x62, 0xd3, 0x3b, 0x11, 0x05, 0x11, 0x89, 0x4a,
+	0x0f, 0x5c, 0xf2, 0x7a, 0x2c, 0xf3, 0xd0, 0x24, 0xf5, 0x90, 0xc2, 0x81, 0xfc, 0x49, 0xb3, 0x5f,
+	0x9a, 0x1c, 0x1b, 0xbe, 0x67, 0xb4, 0x9c, 0xa8, 0x45, 0x4b, 0xf9, 0xec, 0xf0, 0x4c, 0xea, 0x45,
+	0x0a, 0xdf, 0xf3, 0x76, 0x05, 0x54, 0x5b, 0x87, 0x73, 0x54, 0x09, 0xc9, 0x6b, 0x00, 0xf1, 0x8e,
+	0x46, 0xec, 0x6b, 0x2e, 0xc9, 0x4a, 0x38, 0x7b, 0x39, 0xc7, 0xd6, 0xfe, 0x5f, 0x81, 0xa9, 0x14,
+	0x45, 0x4f, 0xea, 0x92, 0xa4, 0x5a, 0xb9, 0x8e, 0x57, 0x0a, 0xdf, 0x81, 0xb1, 0x88, 0x98, 0x84,
+	0x3b, 0x80, 0xe2, 0xfa, 0x4a, 0xb6, 0x52, 0x93, 0xc9, 0x57, 0x45, 0xec, 0x61, 0x84, 0x34, 0x6a,
+	0xd9, 0xce, 0xc1, 0x81, 0x7c, 0x64, 0x91, 0x1d, 0xb5, 0x98, 0x54, 0xb6, 0x9c, 0x83, 0x03, 0x9d,
+	0x13, 0x68, 0xcf, 0x61, 0x8c, 0x3b, 0xfa, 0x79, 0x98, 0x6d, 0x34, 0x37, 0x9b, 0x7d, 0xda, 0xfc,
+	0xb2, 0xb3, 0xcf, 0x9a, 0xf1, 0xbb, 0x9b, 0xcd, 0xea, 0x73, 0xf9, 0xa0, 0x67, 0x77, 0xbb, 0x21,
+	0xbf, 0xf3, 0xda, 0xaf, 0x40, 0x21, 0x9e, 0x9d, 0x7a, 0x0a, 0xae, 0x23, 0x5a, 0xbc, 0x93, 0x9e,
+	0x82, 0x41, 0xea, 0x26, 0x39, 0x42, 0x6a, 0x97, 0xe6, 0x0b, 0x29, 0xf5, 0xd2, 0xd6, 0xaf, 0x45,
+	0xda, 0xa6, 0x2b, 0x5a, 0xf1, 0xe2, 0x4b, 0xbb, 0x07, 0x0b, 0x3a, 0x8e, 0x30, 0x49, 0x8a, 0xd7,
+	0x52, 0x19, 0x03, 0x13, 0x02, 0xed, 0x01, 0x2c, 0xf6, 0xd0, 0x25, 0xfe, 0xac, 0x1d, 0xc5, 0x0d,
+	0x02, 0x7e, 0x55, 0x2b, 0x50, 0x08, 0xc5, 0x8d, 0x34, 0x02, 0x73, 0x3b, 0xf4, 0xd2, 0x22, 0xb3,
+	0xd5, 0x61, 0x65, 0x9f, 0x2d, 0x18, 0xe7, 0x59, 0xe8, 0xd0, 0xe2, 0xad, 0x9c, 0xb2, 0xc1, 0xd0,
+	0xc5, 0xa9, 0xe0, 0xb4, 0xb2, 0xf2, 0x22, 0x31, 0x52, 0x79, 0xbf, 0xb6, 0x0b, 0xc5, 0x4e, 0x4a,
+	0x5a, 0xc3, 0x8d, 0x08, 0x0e, 0xa4, 0x3f, 0xb8, 0x3a, 0x7c, 0x45, 0x82, 0x03, 0x9d, 0xd3, 0x68,
+	0xff, 0x95, 0x83, 0xe9, 0x34, 0x7c, 0xf0, 0x69, 0xd5, 0x00, 0x2c, 0xd3, 0x75, 0x0d, 0xc7, 0xb3,
+	0xf1, 0x5b, 0xee, 0xcc, 0x84, 0xd3, 0xa5, 0xe0, 0x6d, 0x0a, 0xa5, 0x02, 0xb5, 0xcc, 0x38, 0x4c,
+	0x8b, 0x68, 0x6f, 0x99, 0x32, 0x4c, 0xaf, 0xc1, 0x18, 0xaf, 0xc0, 0x0d, 0xbd, 0x6e, 0x71, 0xbc,
+	0x38, 0x55, 0x1e, 0x1b, 0x96, 0x2a, 0x8b, 0xa6, 0x22, 0xbb, 0x68, 0x15, 0x74, 0xf9, 0x49, 0x1f,
+	0x36, 0xc9, 0x54, 0x79, 0x62, 0x58, 0x65, 0x2a, 0x25, 0x88, 0x55, 0xde, 0x0c, 0x14, 0xd5, 0x12,
+	0x39, 0x85, 0xba, 0x01, 0xd3, 0xe9, 0x81, 0x53, 0x55, 0x43, 0xfe, 0x2a, 0x07, 0x93, 0xf1, 0x9d,
+	0xa6, 0x5f, 0x86, 0xf3, 0xc9, 0x37, 0x34, 0x1e, 0x69, 0x37, 0x48, 0x87, 0xa9, 0x44, 0xf6, 0x32,
+	0x7f, 0xbd, 0x3d, 0x74, 0x96, 0xd5, 0xaa, 0x54, 0x8f, 0xd8, 0x2e, 0xc4, 0xfa, 0x8a, 0x58, 0x7b,
+	0x33, 0x08, 0x5c, 0x07, 0xdb, 0x06, 0x85, 0x72, 0xff, 0x92, 0xd7, 0xa7, 0x05, 0x90, 0x92, 0xb2,
+	0x1a, 0x12, 0x7e, 0x7b, 0x64, 0xb6, 0x23, 0x7a, 0x9a, 0xc7, 0x98, 0x37, 0x4d, 0x00, 0xea, 0x63,
+	0x38, 0xdb, 0xb5, 0xc2, 0x69, 0x62, 0xe4, 0xca, 0xe7, 0x30, 0xd7, 0xa7, 0x2e, 0x86, 0xae, 0xc2,
+	0x65, 0xbd, 0xd6, 0xd8, 0x7b, 0xa9, 0x57, 0x6b, 0xc6, 0x8b, 0xcd, 0xdd, 0x9a, 0x51, 0xdf, 0x6c,
+	0x36, 0x6b, 0x7a, 0xf7, 0xdb, 0xdb, 0x49, 0x38, 0xf3, 0xb2, 0x51, 0xa3, 0xef, 0x88, 0x4a, 0x30,
+	0x4d, 0x7f, 0x19, 0xbb, 0xb5, 0x46, 0x63, 0xf3, 0x59, 0xad, 0x94, 0x5b, 0xff, 0x77, 0x8d, 0xbf,
+	0x92, 0x71, 0xbc, 0x43, 0xf4, 0x6b, 0x0a, 0xcc, 0x74, 0xbc, 0xc5, 0x45, 0x37, 0xb3, 0x53, 0xe8,
+	0x3e, 0x6f, 0x76, 0xd5, 0xa1, 0x6f, 0x50, 0x35, 0xed, 0x57, 0xff, 0xf5, 0x3f, 0x7f, 0x37, 0x77,
+	0x5e, 0x9b, 0x8d, 0xdf, 0x89, 0xcb, 0x47, 0x7d, 0x1b, 0xf2, 0xf5, 0x2e, 0xfa, 0x21, 0x40, 0xf2,
+	0x7a, 0x17, 0x65, 0xc7, 0x81, 0x9e, 0x27, 0xbe, 0xa3, 0xaf, 0x8f, 0xd4, 0x78, 0xfd, 0x77, 0xd4,
+	0xee, 0x1e, 0xc7, 0x4f, 0x0b, 0x57, 0xde, 0xa3, 0xaf, 0x14, 0x98, 0x4e, 0x3f, 0xba, 0x45, 0xd9,
+	0xb7, 0xf9, 0x3e, 0xef, 0x85, 0xd5, 0x9b, 0x23, 0x62, 0x73, 0xef, 0xab, 0x2d, 0x31, 0x8e, 0xe6,
+	0x50, 0xaf, 0x44, 0xd0, 0x97, 0x30, 0xd3, 0xf1, 0xfc, 0x76, 0x80, 0x3a, 0xfa, 0x3d, 0xd3, 0x55,
+	0x17, 0x7a, 0x3c, 0x4b, 0x8d, 0x3e, 0x46, 0x97, 0x42, 0x58, 0x19, 0x24, 0x84, 0xdf, 0x57, 0x60,
+	0xa6, 0xe3, 0x29, 0xed, 0x80, 0xc5, 0xfb, 0xbd, 0xf5, 0x55, 0x57, 0x4f, 0xf7, 0x42, 0x57, 0xfb,
+	0x90, 0x31, 0xf5, 0x81, 0x76, 0x39, 0x9b, 0xa9, 0x8d, 0x90, 0x51, 0xa2, 0xdf, 0x56, 0xa0, 0x10,
+	0xbf, 0x25, 0x43, 0x1f, 0x0e, 0x94, 0x77, 0xfa, 0x91, 0x9c, 0xba, 0x32, 0x0a, 0xaa, 0xe0, 0x67,
+	0x85, 0xf1, 0x73, 0x05, 0x69, 0x09, 0x3f, 0xfc, 0x19, 0x5d, 0x9a, 0x23, 0xfe, 0xfe, 0x14, 0xfd,
+	0x00, 0x20, 0x79, 0x0b, 0x36, 0xc0, 0x62, 0x7b, 0x1e, 0x8c, 0x65, 0xaa, 0x48, 0xac, 0xbe, 0xa2,
+	0x65, 0x4a, 0x83, 0x2f, 0x4d, 0x55, 0xf5, 0x7b, 0x0a, 0x40, 0xf2, 0xe8, 0x6b, 0xc0, 0xf2, 0x3d,
+	0xaf, 0xcf, 0xd4, 0x1b, 0x23, 0xe1, 0x0a, 0x89, 0xdc, 0x62, 0x3c, 0xad, 0x68, 0xd7, 0x87, 0xf3,
+	0xb4, 0x61, 0x1d, 0x61, 0xeb, 0x35, 0xfa, 0x3b, 0x85, 0x15, 0x8e, 0x33, 0x1e, 0x83, 0x3d, 0x1c,
+	0x74, 0xb2, 0x07, 0x3e, 0x3b, 0x53, 0xd7, 0x32, 0x49, 0xfb, 0xd3, 0x69, 0x77, 0x18, 0xef, 0x37,
+	0xd1, 0x8d, 0x2e, 0xde, 0x93, 0x42, 0xc2, 0xda, 0xca, 0xca, 0xfb, 0x8d, 0xa0, 0x83, 0xc1, 0x3f,
+	0x51, 0x60, 0xa1, 0xff, 0x5b, 0x2f, 0x74, 0x6f, 0xa0, 0x57, 0xca, 0x7c, 0x57, 0xa6, 0xde, 0x3f,
+	0x35, 0x9d, 0x10, 0xfe, 0x79, 0xb6, 0x81, 0x05, 0x54, 0x8e, 0x37, 0x60, 0xa7, 0xd8, 0xf9, 0x91,
+	0x02, 0x73, 0xa9, 0x09, 0xe2, 0x37, 0x60, 0x77, 0x46, 0x59, 0xae, 0xab, 0x6d, 0xab, 0x8e, 0x5e,
+	0xea, 0xea, 0xeb, 0xbc, 0xc4, 0xd2, 0x7f, 0xa1, 0xc0, 0x42, 0xff, 0x9e, 0xeb, 0x00, 0xe1, 0x0d,
+	0xec, 0x27, 0xab, 0xf7, 0x4f, 0x4d, 0x27, 0x84, 0xf7, 0x01, 0x63, 0xf3, 0xc2, 0x7a, 0x2f, 0x9b,
+	0x1b, 0x49, 0xb1, 0xee, 0x3d, 0xcc, 0xf6, 0x34, 0x5d, 0xd1, 0x80, 0xcc, 0x21, 0xa3, 0x41, 0x9b,
+	0x79, 0xa4, 0x2f, 0x30, 0x26, 0x16, 0x35, 0x14, 0x33, 0xe1, 0x0b, 0xca, 0x68, 0x43, 0x59, 0xa1,
+	0x51, 0xa7, 0xd4, 0xdd, 0x62, 0x45, 0xb7, 0x86, 0xc4, 0xdf, 0x9e, 0x36, 0xa8, 0x3a, 0x4a, 0x9d,
+	0x4f, 0x3b, 0xc7, 0x58, 0x99, 0xd7, 0x4a, 0x31, 0x2b, 0xa2, 0xf0, 0x47, 0x19, 0x79, 0x0f, 0xa5,
+	0xee, 0x1e, 0xeb, 0x00, 0x3e, 0x32, 0xda, 0xb1, 0x99, 0x52, 0xb8, 0xc4, 0x96, 0x5e, 0x5a, 0x59,
+	0xec, 0x5e, 0x9a, 0x1f, 0xc8, 0xf7, 0xe8, 0xd7, 0x15, 0x28, 0x76, 0xf6, 0x6b, 0x51, 0x76, 0x28,
+	0xe9, 0xdb, 0xd8, 0xcd, 0x5c, 0xfb, 0x26, 0x5b, 0xfb, 0x9a, 0x76, 0x35, 0x5e, 0x3b, 0x29, 0xb1,
+	0xae, 0xbd, 0x8b, 0x7f, 0xbf, 0xdf, 0x60, 0x45, 0x0d, 0xa6, 0x91, 0xee, 0xee, 0xef, 0x00, 0x49,
+	0x64, 0x34, 0x8a, 0xd5, 0x6f, 0x8d, 0xd6, 0x06, 0xd6, 0x2a, 0x8c, 0x3b, 0x84, 0x12, 0xa5, 0xb4,
+	0xc4, 0x9a, 0x7f, 0xac, 0x88, 0x46, 0x6b, 0x47, 0x0f, 0x11, 0xad, 0x0f, 0x6e, 0xe9, 0xf5, 0xeb,
+	0xff, 0xaa, 0x77, 0x4e, 0x45, 0x23, 0x8e, 0xcf, 0x35, 0xc6, 0xd9, 0x65, 0xed, 0x7c, 0xcc, 0x59,
+	0x98, 0xc6, 0xdb, 0x08, 0x28, 0x29, 0x35, 0x9d, 0x1f, 0x2b, 0x80, 0x7a, 0x1b, 0x85, 0x03, 0x18,
+	0xcd, 0xec, 0x2a, 0xaa, 0xd9, 0xc5, 0xe0, 0x2e, 0x02, 0x6d, 0x99, 0x71, 0xa7, 0xa2, 0x4a, 0x62,
+	0x51, 0x5d, 0xeb, 0xff, 0x81, 0x02, 0xa5, 0xee, 0x56, 0xdb, 0x00, 0x45, 0x66, 0xf4, 0xfb, 0xd4,
+	0xdb, 0xa7, 0xa0, 0x10, 0x92, 0xbb, 0xc2, 0x78, 0xbb, 0xa8, 0x2d, 0x49, 0xde, 0x36, 0x5a, 0x5d,
+	0xa8, 0x54, 0x6c, 0x04, 0x0a, 0x71, 0x73, 0x6b, 0x40, 0x3a, 0xd3, 0xdd, 0x00, 0x53, 0xaf, 0x0c,
+	0xb1, 0x2c, 0x86, 0xac, 0x2d, 0x30, 0x1e, 0x4a, 0xa8, 0x98, 0x38, 0x3f, 0xb6, 0xd0, 0x5f, 0x2b,
+	0x50, 0xc9, 0xea, 0x6d, 0xa1, 0x07, 0x03, 0x33, 0xa5, 0x01, 0x9d, 0x34, 0xf5, 0xe1, 0x37, 0xa0,
+	0x14, 0xd2, 0xba, 0xca, 0x38, 0xbd, 0x84, 0x2e, 0xa4, 0x7c, 0x43, 0x1f, 0xde, 0x7e, 0xac, 0xc0,
+	0x74, 0xba, 0x30, 0x3b, 0x20, 0x3f, 0xef, 0x53, 0xd7, 0x55, 0x6f, 0x8e, 0x88, 0x9d, 0x69, 0xfc,
+	0x4c, 0x7c, 0x0d, 0x79, 0x6d, 0x61, 0xcd, 0x6b, 0xaa, 0xc5, 0x9f, 0x28, 0x00, 0x49, 0xb5, 0x74,
+	0x40, 0x1a, 0xd6, 0x53, 0xc9, 0x55, 0x6f, 0x8c, 0x84, 0x2b, 0x18, 0x5a, 0x63, 0x0c, 0x7d, 0xa8,
+	0x5d, 0xeb, 0xcf, 0x50, 0xfc, 0xcf, 0x51, 0x86, 0x63, 0xbf, 0xdf, 0xc0, 0x9e, 0x4d, 0x3d, 0xea,
+	0x74, 0xba, 0x94, 0x3a, 0x40, 0x5e, 0x7d, 0x0a, 0xb1, 0xea, 0xcd, 0x11, 0xb1, 0x05, 0x7b, 0x2a,
+	0x63, 0xaf, 0x8c, 0x92, 0x30, 0x17, 0x97, 0x5e, 0xa9, 0x47, 0x9d, 0xe9, 0x28, 0x90, 0x0e, 0xb8,
+	0x54, 0xf4, 0x2b, 0xa4, 0xaa, 0x57, 0x86, 0xa0, 0xb3, 0x54, 0x4e, 0x7a, 0x04, 0x6d, 0x3e, 0x61,
+	0x21, 0x19, 0x8d, 0x84, 0xae, 0xca, 0xfd, 0x0a, 0x9b, 0xe8, 0xee, 0xc0, 0xcd, 0x66, 0xd4, 0x41,
+	0x47, 0x64, 0xab, 0xf7, 0xee, 0x99, 0x66, 0x6b, 0xed, 0x9d, 0x63, 0xbf, 0xa7, 0x59, 0xd3, 0xd9,
+	0xae, 0x3a, 0x1d, 0x5a, 0x1b, 0xf4, 0x56, 0xa6, 0x4f, 0x25, 0x50, 0xbd, 0x35, 0x3a, 0x81, 0x50,
+	0xda, 0x03, 0xc6, 0xda, 0xba, 0x76, 0x6b, 0x48, 0x64, 0x5c, 0x4b, 0x1e, 0xbc, 0x6e, 0x84, 0x74,
+	0x2e, 0xf4, 0x43, 0x98, 0x4e, 0x57, 0x07, 0x07, 0xdd, 0x95, 0x7b, 0x8b, 0x88, 0xea, 0xe5, 0xa1,
+	0x85, 0x99, 0x3e, 0x69, 0x53, 0xdc, 0x2d, 0xa7, 0x9a, 0xfc, 0x2d, 0x11, 0xa4, 0xd3, 0x85, 0xc2,
+	0x21, 0x41, 0xba, 0x4f, 0x4d, 0x71, 0x14, 0x46, 0x2e, 0x33, 0x46, 0xce, 0xa1, 0xa5, 0x3e, 0x8c,
+	0x98, 0x16, 0x71, 0x8e, 0xb1, 0x3a, 0xfb, 0x8f, 0x9b, 0x45, 0xf6, 0x1f, 0x07, 0x47, 0x7e, 0x44,
+	0x36, 0xee, 0xdf, 0xbd, 0xf7, 0xf0, 0xc9, 0x4b, 0x38, 0x67, 0xf9, 0xad, 0xac, 0xd9, 0xeb, 0xca,
+	0x2f, 0xde, 0x3d, 0x74, 0xc8, 0x51, 0x7b, 0x7f, 0xd5, 0xf2, 0x5b, 0x6b, 0x1c, 0xcb, 0x0c, 0x9c,
+	0x68, 0xed, 0xd0, 0x0c, 0x1c, 0xeb, 0xa6, 0xc4, 0x5f, 0xe3, 0x2d, 0xab, 0xb5, 0x43, 0xec, 0xf1,
+	0x9c, 0x66, 0x9c, 0xfd, 0xb9, 0xf3, 0xb3, 0x01, 0x00, 0x75, 0x1e, 0xec, 0xce, 0x7f, 0x3f, 0x00,
+	0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// TestingClient is the client API for Testing service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TestingClient interface {
+	// Creates a new testing session.
+	CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*Session, error)
+	// Gets a testing session.
+	GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*Session, error)
+	// Lists the current test sessions.
+	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	// Delete a test session.
+	DeleteSession(ctx context.Context, in *DeleteSessionRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Report on the status of a session.
+	// This generates a report detailing which tests have been completed,
+	// and an overall rollup.
+	ReportSession(ctx context.Context, in *ReportSessionRequest, opts ...grpc.CallOption) (*ReportSessionResponse, error)
+	// List the tests of a sessesion.
+	ListTests(ctx context.Context, in *ListTestsRequest, opts ...grpc.CallOption) (*ListTestsResponse, error)
+	// Explicitly decline to implement a test.
+	//
+	// This removes the test from subsequent `ListTests` calls, and
+	// attempting to do the test will error.
+	//
+	// This method will error if attempting to delete a required test.
+	DeleteTest(ctx context.Context, in *DeleteTestRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Register a response to a test.
+	//
+	// In cases where a test involves registering a final answer at the
+	// end of the test, this method provides the means to do so.
+	VerifyTest(ctx context.Context, in *VerifyTestRequest, opts ...grpc.CallOption) (*VerifyTestResponse, error)
+	// Report on the polling of a long-running operation.
+	//
+	// Every call to google.longrunning.Operations.GetOperation is recorded, so
+	// this can be used to verify the backoff of a client's operation poller.
+	GetOperationPollingReport(ctx context.Context, in *GetOperationPollingReportRequest, opts ...grpc.CallOption) (*OperationPollingReport, error)
+	// Returns the descriptors of the Showcase protos and everything they depend
+	// on, for clients that bootstrap from descriptors at runtime.
+	GetShowcaseDescriptors(ctx context.Context, in *GetShowcaseDescriptorsRequest, opts ...grpc.CallOption) (*GetShowcaseDescriptorsResponse, error)
+	// Returns the limits and defaults that the Showcase services currently
+	// enforce, so that test harnesses can configure themselves to match.
+	GetShowcaseSettings(ctx context.Context, in *GetShowcaseSettingsRequest, opts ...grpc.CallOption) (*ShowcaseSettings, error)
+	// Updates the limits and defaults of the Showcase services while they
+	// serve. The fields named by `update_mask` are replaced, and the result
+	// is validated and swapped in atomically, so calls see either the old or
+	// the new settings but never a mix. Returns the settings it replaced.
+	UpdateShowcaseSettings(ctx context.Context, in *UpdateShowcaseSettingsRequest, opts ...grpc.CallOption) (*UpdateShowcaseSettingsResponse, error)
+	// Simulates an overloaded method by limiting the rate of calls to it.
+	// Calls beyond the limit fail with RESOURCE_EXHAUSTED and a
+	// google.rpc.RetryInfo detail.
+	SetMethodOverload(ctx context.Context, in *SetMethodOverloadRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Stores a named list of words that the Echo.Expand method can stream in
+	// place of request content. At most 100 corpora of up to 10000 words each
+	// are kept.
+	CreateEchoCorpus(ctx context.Context, in *CreateEchoCorpusRequest, opts ...grpc.CallOption) (*EchoCorpus, error)
+	// Deletes a corpus. Expand calls already streaming it are unaffected.
+	DeleteEchoCorpus(ctx context.Context, in *DeleteEchoCorpusRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Deletes all state kept for a namespace at once: recorded polls, poll
+	// quotas, corpora, blobs, echo resources, deduplicated responses,
+	// operation IDs, Expand stream statuses, request expectations, byte budget
+	// usage, attempt counts, scenarios and topics. DumpState never sees the
+	// namespace partly deleted. The namespace of a call is given by its
+	// `showcase-namespace` metadata, and is `default` if that is absent.
+	PurgeNamespace(ctx context.Context, in *PurgeNamespaceRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Returns the current values of the server's metrics.
+	GetServerMetrics(ctx context.Context, in *GetServerMetricsRequest, opts ...grpc.CallOption) (*ServerMetrics, error)
+	// Parses resource names against the patterns of `ResourceNamePattern`,
+	// returning the pattern each name matched and its segment values.
+	ParseResourceNames(ctx context.Context, in *ParseResourceNamesRequest, opts ...grpc.CallOption) (*ParseResourceNamesResponse, error)
+	// Returns totals of the channelz data of the server's open connections.
+	// The summary is empty if the server was started without channelz.
+	GetChannelzSummary(ctx context.Context, in *GetChannelzSummaryRequest, opts ...grpc.CallOption) (*ChannelzSummary, error)
+	// Times the encoding and decoding of `count` synthetic messages of
+	// `payload_size` bytes, and returns the latencies along with the server's
+	// settings, so that harnesses can derive client deadlines rather than
+	// hard-code them.
+	MeasureRoundTrip(ctx context.Context, in *MeasureRoundTripRequest, opts ...grpc.CallOption) (*MeasureRoundTripResponse, error)
+	// Returns the settings and the corpora, blobs, echo resources, recorded
+	// polls and deduplicated responses of each namespace, taken at a single
+	// instant across all of them, for debugging failed test runs. The dump
+	// leaves out topics, request expectations, scenarios, byte budget usage,
+	// operation IDs, Expand stream statuses and attempt counts. It fails with
+	// PERMISSION_DENIED unless the server was started with `--enable-admin`.
+	DumpState(ctx context.Context, in *DumpStateRequest, opts ...grpc.CallOption) (*ServerState, error)
+	// Lists the behaviors a client of every language is expected to show
+	// against this server, with the methods that exercise them, so that
+	// test suites in different languages can check they cover the same
+	// ground. The catalogue is ordered by scenario ID and changes only when
+	// the server does.
+	ListConformanceScenarios(ctx context.Context, in *ListConformanceScenariosRequest, opts ...grpc.CallOption) (*ListConformanceScenariosResponse, error)
+	// Starts a state session. Calls whose `showcase-session-id` metadata names
+	// the session run in a namespace of its own, so that the state they create
+	// can be deleted with EndSession when the test ends. A session that no
+	// call names for 30 minutes expires, and its state is deleted.
+	StartSession(ctx context.Context, in *StartSessionRequest, opts ...grpc.CallOption) (*StartSessionResponse, error)
+	// Ends a state session, deleting the state created by its calls, and
+	// returns how much of each kind of state it deleted.
+	EndSession(ctx context.Context, in *EndSessionRequest, opts ...grpc.CallOption) (*EndSessionResponse, error)
+	// Lists the addresses the server serves its replicas on, and whether each
+	// is healthy. A server started with `--replicas` serves the same services
+	// on each replica, and names the replica of each call in its
+	// `showcase-replica` response header. An unhealthy replica fails every
+	// call but this one with UNAVAILABLE.
+	GetEndpoints(ctx context.Context, in *GetEndpointsRequest, opts ...grpc.CallOption) (*GetEndpointsResponse, error)
+	// Registers what the next request to a method in the namespace of the
+	// call should be. When it arrives, the server compares it with the
+	// expected request over the fields of `field_mask`, records the fields
+	// that differ for GetExpectationResult, and handles the call as usual
+	// unless `fail_on_mismatch` is set. Expectations of the same method are
+	// checked in the order they were registered.
+	ExpectRequest(ctx context.Context, in *ExpectRequestRequest, opts ...grpc.CallOption) (*Expectation, error)
+	// Returns an expectation registered with ExpectRequest, with the fields
+	// its request differed in once it arrived.
+	GetExpectationResult(ctx context.Context, in *GetExpectationResultRequest, opts ...grpc.CallOption) (*Expectation, error)
+	// Forgets the bytes a namespace has used of its `namespace_byte_budget`,
+	// so that its calls succeed again before the budget window rolls over.
+	ResetByteBudget(ctx context.Context, in *ResetByteBudgetRequest, opts ...grpc.CallOption) (*ResetByteBudgetResponse, error)
+	// Makes the script the active scenario of the namespace of the call,
+	// replacing the one before it. Each call in the namespace to a method of
+	// the script is counted, and the first step that covers its position among
+	// the calls of its method is applied to it. Calls no step covers are
+	// handled as usual.
+	LoadScenario(ctx context.Context, in *LoadScenarioRequest, opts ...grpc.CallOption) (*Scenario, error)
+	// Returns the active scenario of the namespace of the call, with the calls
+	// it has counted and the steps it has applied.
+	GetScenarioState(ctx context.Context, in *GetScenarioStateRequest, opts ...grpc.CallOption) (*Scenario, error)
+}
+
+type testingClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTestingClient(cc *grpc.ClientConn) TestingClient {
+	return &testingClient{cc}
+}
+
+func (c *testingClient) CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/CreateSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/GetSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/ListSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) DeleteSession(ctx context.Context, in *DeleteSessionRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/DeleteSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) ReportSession(ctx context.Context, in *ReportSessionRequest, opts ...grpc.CallOption) (*ReportSessionResponse, error) {
+	out := new(ReportSessionResponse)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/ReportSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) ListTests(ctx context.Context, in *ListTestsRequest, opts ...grpc.CallOption) (*ListTestsResponse, error) {
+	out := new(ListTestsResponse)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/ListTests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) DeleteTest(ctx context.Context, in *DeleteTestRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/DeleteTest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) VerifyTest(ctx context.Context, in *VerifyTestRequest, opts ...grpc.CallOption) (*VerifyTestResponse, error) {
+	out := new(VerifyTestResponse)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/VerifyTest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) GetOperationPollingReport(ctx context.Context, in *GetOperationPollingReportRequest, opts ...grpc.CallOption) (*OperationPollingReport, error) {
+	out := new(OperationPollingReport)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/GetOperationPollingReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) GetShowcaseDescriptors(ctx context.Context, in *GetShowcaseDescriptorsRequest, opts ...grpc.CallOption) (*GetShowcaseDescriptorsResponse, error) {
+	out := new(GetShowcaseDescriptorsResponse)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/GetShowcaseDescriptors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) GetShowcaseSettings(ctx context.Context, in *GetShowcaseSettingsRequest, opts ...grpc.CallOption) (*ShowcaseSettings, error) {
+	out := new(ShowcaseSettings)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/GetShowcaseSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) UpdateShowcaseSettings(ctx context.Context, in *UpdateShowcaseSettingsRequest, opts ...grpc.CallOption) (*UpdateShowcaseSettingsResponse, error) {
+	out := new(UpdateShowcaseSettingsResponse)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/UpdateShowcaseSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) SetMethodOverload(ctx context.Context, in *SetMethodOverloadRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/SetMethodOverload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) CreateEchoCorpus(ctx context.Context, in *CreateEchoCorpusRequest, opts ...grpc.CallOption) (*EchoCorpus, error) {
+	out := new(EchoCorpus)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/CreateEchoCorpus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) DeleteEchoCorpus(ctx context.Context, in *DeleteEchoCorpusRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/DeleteEchoCorpus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) PurgeNamespace(ctx context.Context, in *PurgeNamespaceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/PurgeNamespace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) GetServerMetrics(ctx context.Context, in *GetServerMetricsRequest, opts ...grpc.CallOption) (*ServerMetrics, error) {
+	out := new(ServerMetrics)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/GetServerMetrics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) ParseResourceNames(ctx context.Context, in *ParseResourceNamesRequest, opts ...grpc.CallOption) (*ParseResourceNamesResponse, error) {
+	out := new(ParseResourceNamesResponse)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/ParseResourceNames", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) GetChannelzSummary(ctx context.Context, in *GetChannelzSummaryRequest, opts ...grpc.CallOption) (*ChannelzSummary, error) {
+	out := new(ChannelzSummary)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/GetChannelzSummary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) MeasureRoundTrip(ctx context.Context, in *MeasureRoundTripRequest, opts ...grpc.CallOption) (*MeasureRoundTripResponse, error) {
+	out := new(MeasureRoundTripResponse)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/MeasureRoundTrip", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) DumpState(ctx context.Context, in *DumpStateRequest, opts ...grpc.CallOption) (*ServerState, error) {
+	out := new(ServerState)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/DumpState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) ListConformanceScenarios(ctx context.Context, in *ListConformanceScenariosRequest, opts ...grpc.CallOption) (*ListConformanceScenariosResponse, error) {
+	out := new(ListConformanceScenariosResponse)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/ListConformanceScenarios", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) StartSession(ctx context.Context, in *StartSessionRequest, opts ...grpc.CallOption) (*StartSessionResponse, error) {
+	out := new(StartSessionResponse)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/StartSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) EndSession(ctx context.Context, in *EndSessionRequest, opts ...grpc.CallOption) (*EndSessionResponse, error) {
+	out := new(EndSessionResponse)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/EndSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) GetEndpoints(ctx context.Context, in *GetEndpointsRequest, opts ...grpc.CallOption) (*GetEndpointsResponse, error) {
+	out := new(GetEndpointsResponse)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/GetEndpoints", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) ExpectRequest(ctx context.Context, in *ExpectRequestRequest, opts ...grpc.CallOption) (*Expectation, error) {
+	out := new(Expectation)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/ExpectRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) GetExpectationResult(ctx context.Context, in *GetExpectationResultRequest, opts ...grpc.CallOption) (*Expectation, error) {
+	out := new(Expectation)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/GetExpectationResult", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) ResetByteBudget(ctx context.Context, in *ResetByteBudgetRequest, opts ...grpc.CallOption) (*ResetByteBudgetResponse, error) {
+	out := new(ResetByteBudgetResponse)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/ResetByteBudget", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) LoadScenario(ctx context.Context, in *LoadScenarioRequest, opts ...grpc.CallOption) (*Scenario, error) {
+	out := new(Scenario)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/LoadScenario", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testingClient) GetScenarioState(ctx context.Context, in *GetScenarioStateRequest, opts ...grpc.CallOption) (*Scenario, error) {
+	out := new(Scenario)
+	err := c.cc.Invoke(ctx, "/google.showcase.v1beta1.Testing/GetScenarioState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TestingServer is the server API for Testing service.
+type TestingServer interface {
+	// Creates a new testing session.
+	CreateSession(context.Context, *CreateSessionRequest) (*Session, error)
+	// Gets a testing session.
+	GetSession(context.Context, *GetSessionRequest) (*Session, error)
+	// Lists the current test sessions.
+	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
+	// Delete a test session.
+	DeleteSession(context.Context, *DeleteSessionRequest) (*empty.Empty, error)
+	// Report on the status of a session.
+	// This generates a report detailing which tests have been completed,
+	// and an overall rollup.
+	ReportSession(context.Context, *ReportSessionRequest) (*ReportSessionResponse, error)
+	// List the tests of a sessesion.
+	ListTests(context.Context, *ListTestsRequest) (*ListTestsResponse, error)
+	// Explicitly decline to implement a test.
+	//
+	// This removes the test from subsequent `ListTests` calls, and
+	// attempting to do the test will error.
+	//
+	// This method will error if attempting to delete a required test.
+	DeleteTest(context.Context, *DeleteTestRequest) (*empty.Empty, error)
+	// Register a response to a test.
+	//
+	// In cases where a test involves registering a final answer at the
+	// end of the test, this method provides the means to do so.
+	VerifyTest(context.Context, *VerifyTestRequest) (*VerifyTestResponse, error)
+	// Report on the polling of a long-running operation.
+	//
+	// Every call to google.longrunning.Operations.GetOperation is recorded, so
+	// this can be used to verify the backoff of a client's operation poller.
+	GetOperationPollingReport(context.Context, *GetOperationPollingReportRequest) (*OperationPollingReport, error)
+	// Returns the descriptors of the Showcase protos and everything they depend
+	// on, for clients that bootstrap from descriptors at runtime.
+	GetShowcaseDescriptors(context.Context, *GetShowcaseDescriptorsRequest) (*GetShowcaseDescriptorsResponse, error)
+	// Returns the limits and defaults that the Showcase services currently
+	// enforce, so that test harnesses can configure themselves to match.
+	GetShowcaseSettings(context.Context, *GetShowcaseSettingsRequest) (*ShowcaseSettings, error)
+	// Updates the limits and defaults of the Showcase services while they
+	// serve. The fields named by `update_mask` are replaced, and the result
+	// is validated and swapped in atomically, so calls see either the old or
+	// the new settings but never a mix. Returns the settings it replaced.
+	UpdateShowcaseSettings(context.Context, *UpdateShowcaseSettingsRequest) (*UpdateShowcaseSettingsResponse, error)
+	// Simulates an overloaded method by limiting the rate of calls to it.
+	// Calls beyond the limit fail with RESOURCE_EXHAUSTED and a
+	// google.rpc.RetryInfo detail.
+	SetMethodOverload(context.Context, *SetMethodOverloadRequest) (*empty.Empty, error)
+	// Stores a named list of words that the Echo.Expand method can stream in
+	// place of request content. At most 100 corpora of up to 10000 words each
+	// are kept.
+	CreateEchoCorpus(context.Context, *CreateEchoCorpusRequest) (*EchoCorpus, error)
+	// Deletes a corpus. Expand calls already streaming it are unaffected.
+	DeleteEchoCorpus(context.Context, *DeleteEchoCorpusRequest) (*empty.Empty, error)
+	// Deletes all state kept for a namespace at once: recorded polls, poll
+	// quotas, corpora, blobs, echo resources, deduplicated responses,
+	// operation IDs, Expand stream statuses, request expectations, byte budget
+	// usage, attempt counts, scenarios and topics. DumpState never sees the
+	// namespace partly deleted. The namespace of a call is given by its
+	// `showcase-namespace` metadata, and is `default` if that is absent.
+	PurgeNamespace(context.Context, *PurgeNamespaceRequest) (*empty.Empty, error)
+	// Returns the current values of the server's metrics.
+	GetServerMetrics(context.Context, *GetServerMetricsRequest) (*ServerMetrics, error)
+	// Parses resource names against the patterns of `ResourceNamePattern`,
+	// returning the pattern each name matched and its segment values.
+	ParseResourceNames(context.Context, *ParseResourceNamesRequest) (*ParseResourceNamesResponse, error)
+	// Returns totals of the channelz data of the server's open connections.
+	// The summary is empty if the server was started without channelz.
+	GetChannelzSummary(context.Context, *GetChannelzSummaryRequest) (*ChannelzSummary, error)
+	// Times the encoding and decoding of `count` synthetic messages of
+	// `payload_size` bytes, and returns the latencies along with the server's
+	// settings, so that harnesses can derive client deadlines rather than
+	// hard-code them.
+	MeasureRoundTrip(context.Context, *MeasureRoundTripRequest) (*MeasureRoundTripResponse, error)
+	// Returns the settings and the corpora, blobs, echo resources, recorded
+	// polls and deduplicated responses of each namespace, taken at a single
+	// instant across all of them, for debugging failed test runs. The dump
+	// leaves out topics, request expectations, scenarios, byte budget usage,
+	// operation IDs, Expand stream statuses and attempt counts. It fails with
+	// PERMISSION_DENIED unless the server was started with `--enable-admin`.
+	DumpState(context.Context, *DumpStateRequest) (*ServerState, error)
+	// Lists the behaviors a client of every language is expected to show
+	// against this server, with the methods that exercise them, so that
+	// test suites in different languages can check they cover the same
+	// ground. The catalogue is ordered by scenario ID and changes only when
+	// the server does.
+	ListConformanceScenarios(context.Context, *ListConformanceScenariosRequest) (*ListConformanceScenariosResponse, error)
+	// Starts a state session. Calls whose `showcase-session-id` metadata names
+	// the session run in a namespace of its own, so that the state they create
+	// can be deleted with EndSession when the test ends. A session that no
+	// call names for 30 minutes expires, and its state is deleted.
+	StartSession(context.Context, *StartSessionRequest) (*StartSessionResponse, error)
+	// Ends a state session, deleting the state created by its calls, and
+	// returns how much of each kind of state it deleted.
+	EndSession(context.Context, *EndSessionRequest) (*EndSessionResponse, error)
+	// Lists the addresses the server serves its replicas on, and whether each
+	// is healthy. A server started with `--replicas` serves the same services
+	// on each replica, and names the replica of each call in its
+	// `showcase-replica` response header. An unhealthy replica fails every
+	// call but this one with UNAVAILABLE.
+	GetEndpoints(context.Context, *GetEndpointsRequest) (*GetEndpointsResponse, error)
+	// Registers what the next request to a method in the namespace of the
+	// call should be. When it arrives, the server compares it with the
+	// expected request over the fields of `field_mask`, records the fields
+	// that differ for GetExpectationResult, and handles the call as usual
+	// unless `fail_on_mismatch` is set. Expectations of the same method are
+	// checked in the order they were registered.
+	ExpectRequest(context.Context, *ExpectRequestRequest) (*Expectation, error)
+	// Returns an expectation registered with ExpectRequest, with the fields
+	// its request differed in once it arrived.
+	GetExpectationResult(context.Context, *GetExpectationResultRequest) (*Expectation, error)
+	// Forgets the bytes a namespace has used of its `namespace_byte_budget`,
+	// so that its calls succeed again before the budget window rolls over.
+	ResetByteBudget(context.Context, *ResetByteBudgetRequest) (*ResetByteBudgetResponse, error)
+	// Makes the script the active scenario of the namespace of the call,
+	// replacing the one before it. Each call in the namespace to a method of
+	// the script is counted, and the first step that covers its position among
+	// the calls of its method is applied to it. Calls no step covers are
+	// handled as usual.
+	LoadScenario(context.Context, *LoadScenarioRequest) (*Scenario, error)
+	// Returns the active scenario of the namespace of the call, with the calls
+	// it has counted and the steps it has applied.
+	GetScenarioState(context.Context, *GetScenarioStateRequest) (*Scenario, error)
+}
+
+// UnimplementedTestingServer can be embedded to have forward compatible implementations.
+type UnimplementedTestingServer struct {
+}
+
+func (*UnimplementedTestingServer) CreateSession(ctx context.Context, req *CreateSessionRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSession not implemented")
+}
+func (*UnimplementedTestingServer) GetSession(ctx context.Context, req *GetSessionRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSession not implemented")
+}
+func (*UnimplementedTestingServer) ListSessions(ctx context.Context, req *ListSessionsRequest) (*ListSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (*UnimplementedTestingServer) DeleteSession(ctx context.Context, req *DeleteSessionRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSession not implemented")
+}
+func (*UnimplementedTestingServer) ReportSession(ctx context.Context, req *ReportSessionRequest) (*ReportSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportSession not implemented")
+}
+func (*UnimplementedTestingServer) ListTests(ctx context.Context, req *ListTestsRequest) (*ListTestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTests not implemented")
+}
+func (*UnimplementedTestingServer) DeleteTest(ctx context.Context, req *DeleteTestRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTest not implemented")
+}
+func (*UnimplementedTestingServer) VerifyTest(ctx context.Context, req *VerifyTestRequest) (*VerifyTestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyTest not implemented")
+}
+func (*UnimplementedTestingServer) GetOperationPollingReport(ctx context.Context, req *GetOperationPollingReportRequest) (*OperationPollingReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOperationPollingReport not implemented")
+}
+func (*UnimplementedTestingServer) GetShowcaseDescriptors(ctx context.Context, req *GetShowcaseDescriptorsRequest) (*GetShowcaseDescriptorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShowcaseDescriptors not implemented")
+}
+func (*UnimplementedTestingServer) GetShowcaseSettings(ctx context.Context, req *GetShowcaseSettingsRequest) (*ShowcaseSettings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShowcaseSettings not implemented")
+}
+func (*UnimplementedTestingServer) UpdateShowcaseSettings(ctx context.Context, req *UpdateShowcaseSettingsRequest) (*UpdateShowcaseSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateShowcaseSettings not implemented")
+}
+func (*UnimplementedTestingServer) SetMethodOverload(ctx context.Context, req *SetMethodOverloadRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMethodOverload not implemented")
+}
+func (*UnimplementedTestingServer) CreateEchoCorpus(ctx context.Context, req *CreateEchoCorpusRequest) (*EchoCorpus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEchoCorpus not implemented")
+}
+func (*UnimplementedTestingServer) DeleteEchoCorpus(ctx context.Context, req *DeleteEchoCorpusRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEchoCorpus not implemented")
+}
+func (*UnimplementedTestingServer) PurgeNamespace(ctx context.Context, req *PurgeNamespaceRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeNamespace not implemented")
+}
+func (*UnimplementedTestingServer) GetServerMetrics(ctx context.Context, req *GetServerMetricsRequest) (*ServerMetrics, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServerMetrics not implemented")
+}
+func (*UnimplementedTestingServer) ParseResourceNames(ctx context.Context, req *ParseResourceNamesRequest) (*ParseResourceNamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ParseResourceNames not implemented")
+}
+func (*UnimplementedTestingServer) GetChannelzSummary(ctx context.Context, req *GetChannelzSummaryRequest) (*ChannelzSummary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChannelzSummary not implemented")
+}
+func (*UnimplementedTestingServer) MeasureRoundTrip(ctx context.Context, req *MeasureRoundTripRequest) (*MeasureRoundTripResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MeasureRoundTrip not implemented")
+}
+func (*UnimplementedTestingServer) DumpState(ctx context.Context, req *DumpStateRequest) (*ServerState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DumpState not implemented")
+}
+func (*UnimplementedTestingServer) ListConformanceScenarios(ctx context.Context, req *ListConformanceScenariosRequest) (*ListConformanceScenariosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConformanceScenarios not implemented")
+}
+func (*UnimplementedTestingServer) StartSession(ctx context.Context, req *StartSessionRequest) (*StartSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartSession not implemented")
+}
+func (*UnimplementedTestingServer) EndSession(ctx context.Context, req *EndSessionRequest) (*EndSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EndSession not implemented")
+}
+func (*UnimplementedTestingServer) GetEndpoints(ctx context.Context, req *GetEndpointsRequest) (*GetEndpointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEndpoints not implemented")
+}
+func (*UnimplementedTestingServer) ExpectRequest(ctx context.Context, req *ExpectRequestRequest) (*Expectation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExpectRequest not implemented")
+}
+func (*UnimplementedTestingServer) GetExpectationResult(ctx context.Context, req *GetExpectationResultRequest) (*Expectation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExpectationResult not implemented")
+}
+func (*UnimplementedTestingServer) ResetByteBudget(ctx context.Context, req *ResetByteBudgetRequest) (*ResetByteBudgetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetByteBudget not implemented")
+}
+func (*UnimplementedTestingServer) LoadScenario(ctx context.Context, req *LoadScenarioRequest) (*Scenario, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadScenario not implemented")
+}
+func (*UnimplementedTestingServer) GetScenarioState(ctx context.Context, req *GetScenarioStateRequest) (*Scenario, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScenarioState not implemented")
+}
+
+func RegisterTestingServer(s *grpc.Server, srv TestingServer) {
+	s.RegisterService(&_Testing_serviceDesc, srv)
+}
+
+func _Testing_CreateSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).CreateSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/CreateSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).CreateSession(ctx, req.(*CreateSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_GetSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).GetSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/GetSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).GetSession(ctx, req.(*GetSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/ListSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).ListSessions(ctx, req.(*ListSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_DeleteSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).DeleteSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/DeleteSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).DeleteSession(ctx, req.(*DeleteSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_ReportSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).ReportSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/ReportSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).ReportSession(ctx, req.(*ReportSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_ListTests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).ListTests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/ListTests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).ListTests(ctx, req.(*ListTestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_DeleteTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).DeleteTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/DeleteTest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).DeleteTest(ctx, req.(*DeleteTestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_VerifyTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyTestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).VerifyTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/VerifyTest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).VerifyTest(ctx, req.(*VerifyTestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_GetOperationPollingReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOperationPollingReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).GetOperationPollingReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/GetOperationPollingReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).GetOperationPollingReport(ctx, req.(*GetOperationPollingReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_GetShowcaseDescriptors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShowcaseDescriptorsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TestingServer).CreateSession(ctx, in)
+		return srv.(TestingServer).GetShowcaseDescriptors(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/google.showcase.v1beta1.Testing/CreateSession",
+		FullMethod: "/google.showcase.v1beta1.Testing/GetShowcaseDescriptors",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TestingServer).CreateSession(ctx, req.(*CreateSessionRequest))
+		return srv.(TestingServer).GetShowcaseDescriptors(ctx, req.(*GetShowcaseDescriptorsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Testing_GetSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetSessionRequest)
+func _Testing_GetShowcaseSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShowcaseSettingsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TestingServer).GetSession(ctx, in)
+		return srv.(TestingServer).GetShowcaseSettings(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/google.showcase.v1beta1.Testing/GetSession",
+		FullMethod: "/google.showcase.v1beta1.Testing/GetShowcaseSettings",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TestingServer).GetSession(ctx, req.(*GetSessionRequest))
+		return srv.(TestingServer).GetShowcaseSettings(ctx, req.(*GetShowcaseSettingsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Testing_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListSessionsRequest)
+func _Testing_UpdateShowcaseSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateShowcaseSettingsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TestingServer).ListSessions(ctx, in)
+		return srv.(TestingServer).UpdateShowcaseSettings(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/google.showcase.v1beta1.Testing/ListSessions",
+		FullMethod: "/google.showcase.v1beta1.Testing/UpdateShowcaseSettings",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TestingServer).ListSessions(ctx, req.(*ListSessionsRequest))
+		return srv.(TestingServer).UpdateShowcaseSettings(ctx, req.(*UpdateShowcaseSettingsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Testing_DeleteSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteSessionRequest)
+func _Testing_SetMethodOverload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMethodOverloadRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TestingServer).DeleteSession(ctx, in)
+		return srv.(TestingServer).SetMethodOverload(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/google.showcase.v1beta1.Testing/DeleteSession",
+		FullMethod: "/google.showcase.v1beta1.Testing/SetMethodOverload",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TestingServer).DeleteSession(ctx, req.(*DeleteSessionRequest))
+		return srv.(TestingServer).SetMethodOverload(ctx, req.(*SetMethodOverloadRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Testing_ReportSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReportSessionRequest)
+func _Testing_CreateEchoCorpus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEchoCorpusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TestingServer).ReportSession(ctx, in)
+		return srv.(TestingServer).CreateEchoCorpus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/google.showcase.v1beta1.Testing/ReportSession",
+		FullMethod: "/google.showcase.v1beta1.Testing/CreateEchoCorpus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TestingServer).ReportSession(ctx, req.(*ReportSessionRequest))
+		return srv.(TestingServer).CreateEchoCorpus(ctx, req.(*CreateEchoCorpusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Testing_ListTests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListTestsRequest)
+func _Testing_DeleteEchoCorpus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEchoCorpusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TestingServer).ListTests(ctx, in)
+		return srv.(TestingServer).DeleteEchoCorpus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/google.showcase.v1beta1.Testing/ListTests",
+		FullMethod: "/google.showcase.v1beta1.Testing/DeleteEchoCorpus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TestingServer).ListTests(ctx, req.(*ListTestsRequest))
+		return srv.(TestingServer).DeleteEchoCorpus(ctx, req.(*DeleteEchoCorpusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Testing_DeleteTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteTestRequest)
+func _Testing_PurgeNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeNamespaceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TestingServer).DeleteTest(ctx, in)
+		return srv.(TestingServer).PurgeNamespace(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/google.showcase.v1beta1.Testing/DeleteTest",
+		FullMethod: "/google.showcase.v1beta1.Testing/PurgeNamespace",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TestingServer).DeleteTest(ctx, req.(*DeleteTestRequest))
+		return srv.(TestingServer).PurgeNamespace(ctx, req.(*PurgeNamespaceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Testing_VerifyTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VerifyTestRequest)
+func _Testing_GetServerMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServerMetricsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TestingServer).VerifyTest(ctx, in)
+		return srv.(TestingServer).GetServerMetrics(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/google.showcase.v1beta1.Testing/VerifyTest",
+		FullMethod: "/google.showcase.v1beta1.Testing/GetServerMetrics",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TestingServer).VerifyTest(ctx, req.(*VerifyTestRequest))
+		return srv.(TestingServer).GetServerMetrics(ctx, req.(*GetServerMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_ParseResourceNames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParseResourceNamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).ParseResourceNames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/ParseResourceNames",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).ParseResourceNames(ctx, req.(*ParseResourceNamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_GetChannelzSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChannelzSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).GetChannelzSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/GetChannelzSummary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).GetChannelzSummary(ctx, req.(*GetChannelzSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_MeasureRoundTrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MeasureRoundTripRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).MeasureRoundTrip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/MeasureRoundTrip",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).MeasureRoundTrip(ctx, req.(*MeasureRoundTripRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_DumpState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DumpStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).DumpState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/DumpState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).DumpState(ctx, req.(*DumpStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_ListConformanceScenarios_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConformanceScenariosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).ListConformanceScenarios(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/ListConformanceScenarios",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).ListConformanceScenarios(ctx, req.(*ListConformanceScenariosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_StartSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).StartSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/StartSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).StartSession(ctx, req.(*StartSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_EndSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).EndSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/EndSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).EndSession(ctx, req.(*EndSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_GetEndpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEndpointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).GetEndpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/GetEndpoints",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).GetEndpoints(ctx, req.(*GetEndpointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_ExpectRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExpectRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).ExpectRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/ExpectRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).ExpectRequest(ctx, req.(*ExpectRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_GetExpectationResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExpectationResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).GetExpectationResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/GetExpectationResult",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).GetExpectationResult(ctx, req.(*GetExpectationResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_ResetByteBudget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetByteBudgetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).ResetByteBudget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/ResetByteBudget",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).ResetByteBudget(ctx, req.(*ResetByteBudgetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_LoadScenario_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadScenarioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).LoadScenario(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/LoadScenario",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).LoadScenario(ctx, req.(*LoadScenarioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Testing_GetScenarioState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScenarioStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestingServer).GetScenarioState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.showcase.v1beta1.Testing/GetScenarioState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestingServer).GetScenarioState(ctx, req.(*GetScenarioStateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1620,6 +5890,94 @@ var _Testing_serviceDesc = grpc.ServiceDesc{
 			MethodName: "VerifyTest",
 			Handler:    _Testing_VerifyTest_Handler,
 		},
+		{
+			MethodName: "GetOperationPollingReport",
+			Handler:    _Testing_GetOperationPollingReport_Handler,
+		},
+		{
+			MethodName: "GetShowcaseDescriptors",
+			Handler:    _Testing_GetShowcaseDescriptors_Handler,
+		},
+		{
+			MethodName: "GetShowcaseSettings",
+			Handler:    _Testing_GetShowcaseSettings_Handler,
+		},
+		{
+			MethodName: "UpdateShowcaseSettings",
+			Handler:    _Testing_UpdateShowcaseSettings_Handler,
+		},
+		{
+			MethodName: "SetMethodOverload",
+			Handler:    _Testing_SetMethodOverload_Handler,
+		},
+		{
+			MethodName: "CreateEchoCorpus",
+			Handler:    _Testing_CreateEchoCorpus_Handler,
+		},
+		{
+			MethodName: "DeleteEchoCorpus",
+			Handler:    _Testing_DeleteEchoCorpus_Handler,
+		},
+		{
+			MethodName: "PurgeNamespace",
+			Handler:    _Testing_PurgeNamespace_Handler,
+		},
+		{
+			MethodName: "GetServerMetrics",
+			Handler:    _Testing_GetServerMetrics_Handler,
+		},
+		{
+			MethodName: "ParseResourceNames",
+			Handler:    _Testing_ParseResourceNames_Handler,
+		},
+		{
+			MethodName: "GetChannelzSummary",
+			Handler:    _Testing_GetChannelzSummary_Handler,
+		},
+		{
+			MethodName: "MeasureRoundTrip",
+			Handler:    _Testing_MeasureRoundTrip_Handler,
+		},
+		{
+			MethodName: "DumpState",
+			Handler:    _Testing_DumpState_Handler,
+		},
+		{
+			MethodName: "ListConformanceScenarios",
+			Handler:    _Testing_ListConformanceScenarios_Handler,
+		},
+		{
+			MethodName: "StartSession",
+			Handler:    _Testing_StartSession_Handler,
+		},
+		{
+			MethodName: "EndSession",
+			Handler:    _Testing_EndSession_Handler,
+		},
+		{
+			MethodName: "GetEndpoints",
+			Handler:    _Testing_GetEndpoints_Handler,
+		},
+		{
+			MethodName: "ExpectRequest",
+			Handler:    _Testing_ExpectRequest_Handler,
+		},
+		{
+			MethodName: "GetExpectationResult",
+			Handler:    _Testing_GetExpectationResult_Handler,
+		},
+		{
+			MethodName: "ResetByteBudget",
+			Handler:    _Testing_ResetByteBudget_Handler,
+		},
+		{
+			MethodName: "LoadScenario",
+			Handler:    _Testing_LoadScenario_Handler,
+		},
+		{
+			MethodName: "GetScenarioState",
+			Handler:    _Testing_GetScenarioState_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "google/showcase/v1beta1/testing.proto",
@@ -0,0 +1,63 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// CacheControlHeader is the response metadata that carries the caching hints
+// of an Echo request, formatted as an HTTP Cache-Control header value. The
+// HTTP/JSON handler returns them in the Cache-Control header too.
+const CacheControlHeader = "showcase-cache-control"
+
+type headerCollectorKey struct{}
+
+// headerCollector holds the response metadata of a call made outside gRPC.
+type headerCollector struct {
+	mu sync.Mutex
+	md metadata.MD
+}
+
+// WithHeaderCollector returns a context for a call that is not served over
+// gRPC, such as by the HTTP/JSON handler, and a function that returns the
+// response metadata the call set with SetHeader.
+func WithHeaderCollector(ctx context.Context) (context.Context, func() metadata.MD) {
+	c := &headerCollector{md: metadata.MD{}}
+	collected := func() metadata.MD {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.md.Copy()
+	}
+	return context.WithValue(ctx, headerCollectorKey{}, c), collected
+}
+
+// SetHeader sets response metadata of the call of the context, whichever
+// transport serves it: as gRPC response headers, or to be collected for a
+// context of WithHeaderCollector.
+func SetHeader(ctx context.Context, md metadata.MD) error {
+	c, ok := ctx.Value(headerCollectorKey{}).(*headerCollector)
+	if !ok {
+		return grpc.SetHeader(ctx, md)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.md = metadata.Join(c.md, md)
+	return nil
+}
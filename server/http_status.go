@@ -0,0 +1,142 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrOKHTTPError is returned by WriteHTTPError when asked to write an OK
+// status, which is not an error.
+var ErrOKHTTPError = errors.New("an OK status cannot be written as an HTTP error")
+
+// HTTPStatusFromCode returns the HTTP status code that corresponds to the given
+// gRPC status code, as documented in google/rpc/code.proto. Unknown codes map
+// to 500.
+func HTTPStatusFromCode(c codes.Code) int {
+	switch c {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		// There is no standard HTTP status for a client closed request. 499 is
+		// used by convention.
+		return 499
+	case codes.Unknown:
+		return http.StatusInternalServerError
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.Aborted:
+		return http.StatusConflict
+	case codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Internal:
+		return http.StatusInternalServerError
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DataLoss:
+		return http.StatusInternalServerError
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// httpStatusForRequest refines HTTPStatusFromCode for a specific request.
+// A FAILED_PRECONDITION in response to a conditional request is reported as
+// 412 rather than 400, since the condition is what failed.
+func httpStatusForRequest(req *http.Request, c codes.Code) int {
+	if c == codes.FailedPrecondition && req != nil {
+		for _, h := range []string{"If-Match", "If-None-Match", "If-Unmodified-Since"} {
+			if req.Header.Get(h) != "" {
+				return http.StatusPreconditionFailed
+			}
+		}
+	}
+	return HTTPStatusFromCode(c)
+}
+
+// RetryAfter returns the value of a Retry-After header derived from the first
+// RetryInfo detail of the given status, in whole seconds rounded up. The
+// second return value is false if the status has no usable RetryInfo.
+func RetryAfter(st *status.Status) (string, bool) {
+	for _, d := range st.Proto().GetDetails() {
+		info := &errdetails.RetryInfo{}
+		if !ptypes.Is(d, info) {
+			continue
+		}
+		if err := ptypes.UnmarshalAny(d, info); err != nil {
+			continue
+		}
+		delay, err := ptypes.Duration(info.GetRetryDelay())
+		if err != nil || delay < 0 {
+			continue
+		}
+		return strconv.FormatInt(int64(math.Ceil(delay.Seconds())), 10), true
+	}
+	return "", false
+}
+
+// WriteHTTPError writes the given non-OK status as the response to an HTTP
+// request. The response status is mapped from the gRPC code, the body is the
+// JSON form of the full google.rpc.Status including its details, and a
+// Retry-After header is set when the status carries a RetryInfo detail.
+func WriteHTTPError(w http.ResponseWriter, req *http.Request, st *status.Status) error {
+	if st.Code() == codes.OK {
+		return ErrOKHTTPError
+	}
+	return writeHTTPStatus(w, st, httpStatusForRequest(req, st.Code()))
+}
+
+// writeHTTPStatus writes the status as the body of a response with the given
+// HTTP status code, as WriteHTTPError does.
+func writeHTTPStatus(w http.ResponseWriter, st *status.Status, code int) error {
+	m := &jsonpb.Marshaler{}
+	body, err := m.MarshalToString(st.Proto())
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if v, ok := RetryAfter(st); ok {
+		w.Header().Set("Retry-After", v)
+	}
+	w.WriteHeader(code)
+	_, err = w.Write([]byte(body))
+	return err
+}
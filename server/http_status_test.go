@@ -0,0 +1,187 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHTTPStatusFromCode(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		want int
+	}{
+		{codes.OK, 200},
+		{codes.Canceled, 499},
+		{codes.Unknown, 500},
+		{codes.InvalidArgument, 400},
+		{codes.DeadlineExceeded, 504},
+		{codes.NotFound, 404},
+		{codes.AlreadyExists, 409},
+		{codes.PermissionDenied, 403},
+		{codes.ResourceExhausted, 429},
+		{codes.FailedPrecondition, 400},
+		{codes.Aborted, 409},
+		{codes.OutOfRange, 400},
+		{codes.Unimplemented, 501},
+		{codes.Internal, 500},
+		{codes.Unavailable, 503},
+		{codes.DataLoss, 500},
+		{codes.Unauthenticated, 401},
+		{codes.Code(17), 500},
+		{codes.Code(1000), 500},
+	}
+	for _, test := range tests {
+		if got := HTTPStatusFromCode(test.code); got != test.want {
+			t.Errorf("HTTPStatusFromCode(%d): want %d got %d", test.code, test.want, got)
+		}
+	}
+}
+
+func TestWriteHTTPError_everyCode(t *testing.T) {
+	for c := codes.Canceled; c <= codes.Unauthenticated; c++ {
+		st := status.New(c, "oops")
+		w := httptest.NewRecorder()
+		if err := WriteHTTPError(w, httptest.NewRequest("GET", "/", nil), st); err != nil {
+			t.Errorf("WriteHTTPError(%s): unexpected err %+v", c, err)
+			continue
+		}
+		if w.Code != HTTPStatusFromCode(c) {
+			t.Errorf("WriteHTTPError(%s): want HTTP status %d got %d", c, HTTPStatusFromCode(c), w.Code)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("WriteHTTPError(%s): want JSON content type got %q", c, ct)
+		}
+		if ra := w.Header().Get("Retry-After"); ra != "" {
+			t.Errorf("WriteHTTPError(%s): want no Retry-After got %q", c, ra)
+		}
+
+		got := &spb.Status{}
+		if err := jsonpb.Unmarshal(w.Body, got); err != nil {
+			t.Errorf("WriteHTTPError(%s): body is not a google.rpc.Status: %+v", c, err)
+		}
+		if !proto.Equal(got, st.Proto()) {
+			t.Errorf("WriteHTTPError(%s): want body %v got %v", c, st.Proto(), got)
+		}
+	}
+}
+
+func TestWriteHTTPError_ok(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := WriteHTTPError(w, httptest.NewRequest("GET", "/", nil), status.New(codes.OK, ""))
+	if err != ErrOKHTTPError {
+		t.Errorf("WriteHTTPError(OK): want ErrOKHTTPError got %v", err)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("WriteHTTPError(OK): want nothing written got %q", w.Body.String())
+	}
+}
+
+func TestWriteHTTPError_details(t *testing.T) {
+	st, _ := status.New(codes.InvalidArgument, "bad").WithDetails(
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "content", Description: "Must not be empty."},
+			},
+		},
+		&errdetails.DebugInfo{Detail: "EMPTY_CONTENT"})
+
+	w := httptest.NewRecorder()
+	WriteHTTPError(w, httptest.NewRequest("POST", "/", nil), st)
+
+	body := w.Body.String()
+	for _, want := range []string{
+		`"@type":"type.googleapis.com/google.rpc.BadRequest"`,
+		`"@type":"type.googleapis.com/google.rpc.DebugInfo"`,
+		`"detail":"EMPTY_CONTENT"`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("WriteHTTPError: want body to contain %s got %s", want, body)
+		}
+	}
+}
+
+func TestWriteHTTPError_conditionalRequest(t *testing.T) {
+	tests := []struct {
+		header string
+		code   codes.Code
+		want   int
+	}{
+		{"", codes.FailedPrecondition, http.StatusBadRequest},
+		{"If-Match", codes.FailedPrecondition, http.StatusPreconditionFailed},
+		{"If-None-Match", codes.FailedPrecondition, http.StatusPreconditionFailed},
+		{"If-Unmodified-Since", codes.FailedPrecondition, http.StatusPreconditionFailed},
+		{"If-Match", codes.InvalidArgument, http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest("PATCH", "/", nil)
+		if test.header != "" {
+			req.Header.Set(test.header, "etag")
+		}
+		w := httptest.NewRecorder()
+		WriteHTTPError(w, req, status.New(test.code, "precondition"))
+		if w.Code != test.want {
+			t.Errorf("WriteHTTPError(%s, %q): want %d got %d", test.code, test.header, test.want, w.Code)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	retryInfo := func(d time.Duration) *errdetails.RetryInfo {
+		return &errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(d)}
+	}
+	tests := []struct {
+		details []proto.Message
+		want    string
+		ok      bool
+	}{
+		{nil, "", false},
+		{[]proto.Message{&errdetails.DebugInfo{Detail: "D"}}, "", false},
+		{[]proto.Message{retryInfo(0)}, "0", true},
+		{[]proto.Message{retryInfo(3 * time.Second)}, "3", true},
+		{[]proto.Message{retryInfo(1500 * time.Millisecond)}, "2", true},
+		{[]proto.Message{retryInfo(time.Millisecond)}, "1", true},
+		{[]proto.Message{retryInfo(-time.Second)}, "", false},
+		{[]proto.Message{&errdetails.DebugInfo{}, retryInfo(5 * time.Second)}, "5", true},
+	}
+	for _, test := range tests {
+		st, _ := status.New(codes.ResourceExhausted, "slow down").WithDetails(test.details...)
+		got, ok := RetryAfter(st)
+		if got != test.want || ok != test.ok {
+			t.Errorf("RetryAfter(%v): want (%q, %t) got (%q, %t)", test.details, test.want, test.ok, got, ok)
+		}
+
+		w := httptest.NewRecorder()
+		WriteHTTPError(w, httptest.NewRequest("GET", "/", nil), st)
+		if w.Header().Get("Retry-After") != test.want {
+			t.Errorf("WriteHTTPError(%v): want Retry-After %q got %q", test.details, test.want, w.Header().Get("Retry-After"))
+		}
+		if w.Code != http.StatusTooManyRequests {
+			t.Errorf("WriteHTTPError(%v): want 429 got %d", test.details, w.Code)
+		}
+	}
+}
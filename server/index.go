@@ -0,0 +1,178 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+// IndexJSONPath is the HTTP path of the JSON variant of the index of RPCs.
+const IndexJSONPath = "/index.json"
+
+// Index lists the services a gRPC server registers, for people exploring a
+// running Showcase server.
+type Index struct {
+	// The address clients dial to reach the gRPC server.
+	Address  string         `json:"address"`
+	Services []IndexService `json:"services"`
+}
+
+// IndexService is a registered service with its methods.
+type IndexService struct {
+	Name    string        `json:"name"`
+	Methods []IndexMethod `json:"methods"`
+}
+
+// IndexMethod is a method of a registered service.
+type IndexMethod struct {
+	Name string `json:"name"`
+	// The full names of the request and response messages. They are empty
+	// when the proto file of the service is not registered.
+	RequestType  string `json:"request_type"`
+	ResponseType string `json:"response_type"`
+	// One of "unary", "server streaming", "client streaming" or "bidi
+	// streaming".
+	Streaming string `json:"streaming"`
+	// A grpcurl command that calls the method on the server.
+	Grpcurl string `json:"grpcurl"`
+}
+
+// NewIndexHandler returns an http.Handler that serves the Index of the
+// services registered on s, which listens on addr, as HTML at "/" and as JSON
+// at IndexJSONPath. The index is built on every request, so services
+// registered after the handler is created are listed too.
+func NewIndexHandler(s *grpc.Server, addr net.Addr) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/" {
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := indexTemplate.Execute(w, BuildIndex(s.GetServiceInfo(), addr)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	mux.HandleFunc(IndexJSONPath, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(BuildIndex(s.GetServiceInfo(), addr))
+	})
+	return mux
+}
+
+// BuildIndex returns the Index of the services of a gRPC server listening on
+// addr, as returned by its GetServiceInfo method. Services and their methods
+// are sorted by name.
+func BuildIndex(services map[string]grpc.ServiceInfo, addr net.Addr) Index {
+	index := Index{Address: dialAddress(addr), Services: []IndexService{}}
+	for name, info := range services {
+		types := methodTypes(name, info.Metadata)
+		svc := IndexService{Name: name, Methods: []IndexMethod{}}
+		for _, m := range info.Methods {
+			t := types[m.Name]
+			svc.Methods = append(svc.Methods, IndexMethod{
+				Name:         m.Name,
+				RequestType:  t[0],
+				ResponseType: t[1],
+				Streaming:    streamingKind(m),
+				Grpcurl:      fmt.Sprintf("grpcurl -plaintext -d '{}' %s %s/%s", index.Address, name, m.Name),
+			})
+		}
+		sort.Slice(svc.Methods, func(i, j int) bool {
+			return svc.Methods[i].Name < svc.Methods[j].Name
+		})
+		index.Services = append(index.Services, svc)
+	}
+	sort.Slice(index.Services, func(i, j int) bool {
+		return index.Services[i].Name < index.Services[j].Name
+	})
+	return index
+}
+
+// methodTypes returns the request and response message names of the methods
+// of a service, by method name, from the proto file that is the metadata of
+// its registration.
+func methodTypes(service string, metadata interface{}) map[string][2]string {
+	types := map[string][2]string{}
+	file, ok := metadata.(string)
+	if !ok {
+		return types
+	}
+	fd, err := fileDescriptor(file)
+	if err != nil {
+		return types
+	}
+	for _, svc := range fd.GetService() {
+		if fd.GetPackage()+"."+svc.GetName() != service {
+			continue
+		}
+		for _, m := range svc.GetMethod() {
+			types[m.GetName()] = [2]string{
+				strings.TrimPrefix(m.GetInputType(), "."),
+				strings.TrimPrefix(m.GetOutputType(), "."),
+			}
+		}
+	}
+	return types
+}
+
+func streamingKind(m grpc.MethodInfo) string {
+	switch {
+	case m.IsClientStream && m.IsServerStream:
+		return "bidi streaming"
+	case m.IsClientStream:
+		return "client streaming"
+	case m.IsServerStream:
+		return "server streaming"
+	}
+	return "unary"
+}
+
+// dialAddress returns the address clients dial to reach a listener, with an
+// unspecified host replaced by localhost.
+func dialAddress(addr net.Addr) string {
+	host, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Showcase</title></head>
+<body>
+<h1>Showcase on {{.Address}}</h1>
+{{range .Services}}
+<h2 id="{{.Name}}">{{.Name}}</h2>
+<table>
+<tr><th>Method</th><th>Request</th><th>Response</th><th>Streaming</th><th>Example</th></tr>
+{{range .Methods}}<tr><td>{{.Name}}</td><td>{{.RequestType}}</td><td>{{.ResponseType}}</td><td>{{.Streaming}}</td><td><code>{{.Grpcurl}}</code></td></tr>
+{{end}}</table>
+{{end}}
+</body>
+</html>
+`))
@@ -0,0 +1,158 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"google.golang.org/grpc"
+)
+
+func indexTestServer() *grpc.Server {
+	s := grpc.NewServer()
+	pb.RegisterEchoServer(s, &pb.UnimplementedEchoServer{})
+	pb.RegisterTestingServer(s, &pb.UnimplementedTestingServer{})
+	return s
+}
+
+func TestIndexHandler_json(t *testing.T) {
+	s := indexTestServer()
+	h := NewIndexHandler(s, &net.TCPAddr{IP: net.IPv6unspecified, Port: 7469})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, IndexJSONPath, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET %s: want status 200 got %d", IndexJSONPath, w.Code)
+	}
+	index := Index{}
+	if err := json.Unmarshal(w.Body.Bytes(), &index); err != nil {
+		t.Fatalf("GET %s: unexpected err %+v", IndexJSONPath, err)
+	}
+	if index.Address != "localhost:7469" {
+		t.Errorf("GET %s: want address localhost:7469 got %q", IndexJSONPath, index.Address)
+	}
+
+	// Exactly the registered services and methods are listed.
+	want := map[string][]string{}
+	for name, info := range s.GetServiceInfo() {
+		for _, m := range info.Methods {
+			want[name] = append(want[name], m.Name)
+		}
+		sort.Strings(want[name])
+	}
+	got := map[string][]string{}
+	methods := map[string]IndexMethod{}
+	for _, svc := range index.Services {
+		for _, m := range svc.Methods {
+			got[svc.Name] = append(got[svc.Name], m.Name)
+			methods[svc.Name+"/"+m.Name] = m
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GET %s: want methods %v got %v", IndexJSONPath, want, got)
+	}
+	if _, ok := got["google.showcase.v1beta1.Identity"]; ok {
+		t.Errorf("GET %s: unregistered service Identity is listed", IndexJSONPath)
+	}
+
+	wantMethods := map[string]IndexMethod{
+		"google.showcase.v1beta1.Echo/Echo": {
+			Name:         "Echo",
+			RequestType:  "google.showcase.v1beta1.EchoRequest",
+			ResponseType: "google.showcase.v1beta1.EchoResponse",
+			Streaming:    "unary",
+			Grpcurl:      "grpcurl -plaintext -d '{}' localhost:7469 google.showcase.v1beta1.Echo/Echo",
+		},
+		"google.showcase.v1beta1.Echo/Expand": {
+			Name:         "Expand",
+			RequestType:  "google.showcase.v1beta1.ExpandRequest",
+			ResponseType: "google.showcase.v1beta1.EchoResponse",
+			Streaming:    "server streaming",
+			Grpcurl:      "grpcurl -plaintext -d '{}' localhost:7469 google.showcase.v1beta1.Echo/Expand",
+		},
+	}
+	for name, want := range wantMethods {
+		if got := methods[name]; got != want {
+			t.Errorf("GET %s: want %s to be %+v got %+v", IndexJSONPath, name, want, got)
+		}
+	}
+	if got := methods["google.showcase.v1beta1.Echo/Collect"].Streaming; got != "client streaming" {
+		t.Errorf("GET %s: want Collect to be client streaming got %q", IndexJSONPath, got)
+	}
+	if got := methods["google.showcase.v1beta1.Echo/Chat"].Streaming; got != "bidi streaming" {
+		t.Errorf("GET %s: want Chat to be bidi streaming got %q", IndexJSONPath, got)
+	}
+}
+
+func TestIndexHandler_html(t *testing.T) {
+	s := indexTestServer()
+	h := NewIndexHandler(s, &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 7469})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /: want status 200 got %d", w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/html") {
+		t.Errorf("GET /: want an HTML Content-Type got %q", got)
+	}
+	body := w.Body.String()
+	for name, info := range s.GetServiceInfo() {
+		if !strings.Contains(body, "<h2 id=\""+name+"\">") {
+			t.Errorf("GET /: want a heading for %s", name)
+		}
+		for _, m := range info.Methods {
+			if !strings.Contains(body, "<td>"+m.Name+"</td>") {
+				t.Errorf("GET /: want a row for %s/%s", name, m.Name)
+			}
+		}
+	}
+	if strings.Contains(body, "google.showcase.v1beta1.Identity") {
+		t.Errorf("GET /: unregistered service Identity is listed")
+	}
+	if want := "grpcurl -plaintext -d &#39;{}&#39; 127.0.0.1:7469 google.showcase.v1beta1.Echo/Echo"; !strings.Contains(body, want) {
+		t.Errorf("GET /: want the example %s", want)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("GET /missing: want status 404 got %d", w.Code)
+	}
+}
+
+func TestBuildIndex_unregisteredFile(t *testing.T) {
+	services := map[string]grpc.ServiceInfo{
+		"example.Unknown": {
+			Methods:  []grpc.MethodInfo{{Name: "Call"}},
+			Metadata: "example/unknown.proto",
+		},
+	}
+	index := BuildIndex(services, &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1})
+	want := IndexMethod{
+		Name:      "Call",
+		Streaming: "unary",
+		Grpcurl:   "grpcurl -plaintext -d '{}' 127.0.0.1:1 example.Unknown/Call",
+	}
+	if len(index.Services) != 1 || len(index.Services[0].Methods) != 1 || index.Services[0].Methods[0] != want {
+		t.Errorf("BuildIndex: want %+v got %+v", want, index.Services)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// instancePattern matches instance IDs, which are DNS labels so that they
+// fit in a resource name and never contain the dots of a method name.
+var instancePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// NewInstanceID returns a random instance ID for a server started without
+// one. It is fixed in deterministic mode.
+func NewInstanceID() string {
+	return fmt.Sprintf("%08x", NewRand().Uint32())
+}
+
+// OperationName returns the name of the operation of the method, such as
+// google.showcase.v1beta1.Echo/Wait, with the given ID. The name records the
+// instance of the server that started the operation, unless instance is
+// empty.
+func OperationName(instance, method, id string) string {
+	if instance == "" {
+		return fmt.Sprintf("operations/%s/%s", method, id)
+	}
+	return fmt.Sprintf("operations/%s/%s/%s", instance, method, id)
+}
+
+// SplitOperationName returns the instance recorded in the name of an
+// operation and the name without it. Names that record no instance are
+// returned unchanged with an empty instance.
+func SplitOperationName(name string) (instance, local string) {
+	rest := strings.TrimPrefix(name, "operations/")
+	parts := strings.SplitN(rest, "/", 3)
+	if len(rest) == len(name) || len(parts) < 2 || !instancePattern.MatchString(parts[0]) || !strings.Contains(parts[1], ".") {
+		return "", name
+	}
+	return parts[0], "operations/" + strings.TrimPrefix(rest, parts[0]+"/")
+}
@@ -0,0 +1,68 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+)
+
+func TestOperationName(t *testing.T) {
+	tests := []struct {
+		instance, name string
+	}{
+		{"", "operations/google.showcase.v1beta1.Echo/Wait/abc"},
+		{"a1", "operations/a1/google.showcase.v1beta1.Echo/Wait/abc"},
+	}
+	for _, test := range tests {
+		name := OperationName(test.instance, "google.showcase.v1beta1.Echo/Wait", "abc")
+		if name != test.name {
+			t.Errorf("OperationName(%q): want %q got %q", test.instance, test.name, name)
+		}
+		instance, local := SplitOperationName(name)
+		if instance != test.instance || local != "operations/google.showcase.v1beta1.Echo/Wait/abc" {
+			t.Errorf("SplitOperationName(%q): want %q and the local name got %q %q", name, test.instance, instance, local)
+		}
+	}
+}
+
+func TestSplitOperationName_noInstance(t *testing.T) {
+	for _, name := range []string{
+		"operations/unknown",
+		"operations/a/b",
+		"operations/Upper/google.showcase.v1beta1.Echo/Wait/abc",
+		"elsewhere/a/google.showcase.v1beta1.Echo/Wait/abc",
+	} {
+		if instance, local := SplitOperationName(name); instance != "" || local != name {
+			t.Errorf("SplitOperationName(%q): want no instance got %q %q", name, instance, local)
+		}
+	}
+}
+
+func TestNewInstanceID(t *testing.T) {
+	defer SetDeterministic(false)
+	SetDeterministic(true)
+	id := NewInstanceID()
+	if !instancePattern.MatchString(id) {
+		t.Errorf("NewInstanceID: want a valid instance ID got %q", id)
+	}
+	if again := NewInstanceID(); again != id {
+		t.Errorf("NewInstanceID in deterministic mode: want %q again got %q", id, again)
+	}
+	s := DefaultSettings()
+	s.InstanceID = "Not valid"
+	if err := s.Validate(); err == nil {
+		t.Errorf("Validate: want an error for the instance ID %q", s.InstanceID)
+	}
+}
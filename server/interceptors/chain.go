@@ -0,0 +1,236 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package interceptors composes the interceptors of the Showcase server in a
+// fixed order.
+package interceptors
+
+import (
+	"fmt"
+
+	"github.com/googleapis/gapic-showcase/server"
+	"google.golang.org/grpc"
+)
+
+// Options are the parts of the Showcase server that intercept calls. Nil
+// fields are left out of the chain.
+type Options struct {
+	// Metrics receives the counts of the Recovery and RPCMetrics
+	// interceptors.
+	Metrics server.Metrics
+
+	// Settings name the client attempt header that RPCMetrics counts,
+	// whether quota projects are checked, and the oldest client libraries
+	// accepted. Nil means the default settings.
+	Settings server.SettingsStore
+
+	// Replicas name the replica of each call in its header, and fail the
+	// calls of a failing replica.
+	Replicas *server.ReplicaSet
+
+	// StateSessions run the calls that name a session in its namespace.
+	StateSessions server.StateSessions
+
+	// ConcurrencyLimiter limits the calls handled at once.
+	ConcurrencyLimiter *server.ConcurrencyLimiter
+
+	// ByteBudgets fail the calls of namespaces that have exchanged too many
+	// bytes.
+	ByteBudgets server.ByteBudgets
+
+	// OverloadLimiter limits the rate of calls to each method.
+	OverloadLimiter server.OverloadLimiter
+
+	// ErrorInjector fails calls at the configured error rates.
+	ErrorInjector *server.ErrorInjector
+
+	// Scenarios delay, fail and add headers to the calls of namespaces with
+	// an active scenario.
+	Scenarios server.ScenarioStore
+
+	// Expectations check requests against the expectations of their
+	// namespace.
+	Expectations server.ExpectationStore
+
+	// StreamDuration ends streams that run too long.
+	StreamDuration *server.StreamDurationLimiter
+
+	// JSONCodec, if registered, fails calls whose messages it could not
+	// decode.
+	JSONCodec *server.JSONCodec
+
+	// Observers are told of every call.
+	Observers server.GrpcObserverRegistry
+
+	// ExtraUnaryInterceptors and ExtraStreamInterceptors are run, in order,
+	// just inside recovery and the JSON codec's interceptor, and outside
+	// everything else in the chain.
+	ExtraUnaryInterceptors  []grpc.UnaryServerInterceptor
+	ExtraStreamInterceptors []grpc.StreamServerInterceptor
+
+	// ExtraServerOptions, such as stats handlers, are appended to the
+	// options returned by ServerOptions.
+	ExtraServerOptions []grpc.ServerOption
+}
+
+// Validate fails if any of the extra interceptors or server options is nil.
+func (opts Options) Validate() error {
+	for i, u := range opts.ExtraUnaryInterceptors {
+		if u == nil {
+			return fmt.Errorf("interceptors: ExtraUnaryInterceptors[%d] is nil", i)
+		}
+	}
+	for i, s := range opts.ExtraStreamInterceptors {
+		if s == nil {
+			return fmt.Errorf("interceptors: ExtraStreamInterceptors[%d] is nil", i)
+		}
+	}
+	for i, o := range opts.ExtraServerOptions {
+		if o == nil {
+			return fmt.Errorf("interceptors: ExtraServerOptions[%d] is nil", i)
+		}
+	}
+	return nil
+}
+
+// ServerOptions returns the options of a server that intercepts calls with
+// the chain of the options, followed by their ExtraServerOptions.
+func ServerOptions(opts Options) ([]grpc.ServerOption, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	unary, stream := Chain(opts)
+	serverOpts := []grpc.ServerOption{grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream)}
+	return append(serverOpts, opts.ExtraServerOptions...), nil
+}
+
+// Chain returns the unary and stream interceptors of the options, outermost
+// first:
+//
+//  1. Recovery, so that a panic anywhere below becomes an INTERNAL error.
+//  2. The JSON codec's interceptor, so that requests it could not decode
+//     go no further, and no call that is rejected below leaves its
+//     decoding error held by the codec.
+//  3. The extra interceptors, so that they see every call, and their panics
+//     are recovered.
+//  4. The replica interceptor, so that every call reports its replica, and
+//     the calls of a failing replica do nothing else.
+//  5. The concurrency limiter, so that every call holds a slot, even one the
+//     interceptors below reject.
+//  6. The namespace interceptor, so that everything below sees the
+//     namespace of the call.
+//  7. The state session interceptor, which replaces the namespace of calls
+//     that name a session.
+//  8. RPCMetrics, so that calls rejected below are counted with their
+//     namespace.
+//  9. The decompression interceptor, so that requests a CheckedCompressor
+//     could not decompress go no further.
+//  10. The quota project interceptor.
+//  11. The library version interceptor, which rejects clients that are too
+//     old before anything below counts their calls.
+//  12. The response field mask interceptor, so that responses are masked
+//     before the byte budgets count them.
+//  13. The expectation interceptor, so that every request that reached the
+//     server is checked, even if it is then rejected.
+//  14. The stream duration limiter, so that the streams it ends are counted.
+//  15. The byte budgets, which count the messages of the calls they admit.
+//  16. The overload limiter.
+//  17. The error injector, so that injected errors are counted but do not
+//     spend overload tokens.
+//  18. The scenario interceptor, which, like the error injector, only
+//     counts and changes the calls that are admitted.
+//  19. The echo digest interceptor, which only hashes admitted requests.
+//  20. The observers, which see the calls as the handlers do.
+//
+// Chain panics if the options are not valid.
+func Chain(opts Options) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	if err := opts.Validate(); err != nil {
+		panic(err)
+	}
+	recovery := NewRecovery(opts.Metrics)
+	unary := []grpc.UnaryServerInterceptor{recovery.UnaryInterceptor}
+	stream := []grpc.StreamServerInterceptor{recovery.StreamInterceptor}
+	if opts.JSONCodec != nil {
+		unary = append(unary, opts.JSONCodec.UnaryInterceptor)
+		stream = append(stream, opts.JSONCodec.StreamInterceptor)
+	}
+	unary = append(unary, opts.ExtraUnaryInterceptors...)
+	stream = append(stream, opts.ExtraStreamInterceptors...)
+	if opts.Replicas != nil {
+		unary = append(unary, opts.Replicas.UnaryInterceptor)
+		stream = append(stream, opts.Replicas.StreamInterceptor)
+	}
+	if opts.ConcurrencyLimiter != nil {
+		unary = append(unary, opts.ConcurrencyLimiter.UnaryInterceptor)
+		stream = append(stream, opts.ConcurrencyLimiter.StreamInterceptor)
+	}
+	unary = append(unary, server.NamespaceUnaryInterceptor)
+	stream = append(stream, server.NamespaceStreamInterceptor)
+	if opts.StateSessions != nil {
+		sessions := server.NewStateSessionInterceptor(opts.StateSessions)
+		unary = append(unary, sessions.UnaryInterceptor)
+		stream = append(stream, sessions.StreamInterceptor)
+	}
+	settings := opts.Settings
+	if settings == nil {
+		settings = server.NewSettingsStore(server.DefaultSettings())
+	}
+	if opts.Metrics != nil {
+		metrics := NewRPCMetrics(opts.Metrics, settings)
+		unary = append(unary, metrics.UnaryInterceptor)
+		stream = append(stream, metrics.StreamInterceptor)
+	}
+	unary = append(unary, server.DecompressionUnaryInterceptor)
+	stream = append(stream, server.DecompressionStreamInterceptor)
+	quotaProject := server.NewQuotaProjectInterceptor(settings)
+	unary = append(unary, quotaProject.UnaryInterceptor)
+	stream = append(stream, quotaProject.StreamInterceptor)
+	libraryVersion := server.NewLibraryVersionInterceptor(settings)
+	unary = append(unary, libraryVersion.UnaryInterceptor)
+	stream = append(stream, libraryVersion.StreamInterceptor)
+	unary = append(unary, server.ResponseFieldMaskUnaryInterceptor)
+	stream = append(stream, server.ResponseFieldMaskStreamInterceptor)
+	if opts.Expectations != nil {
+		expectations := server.NewExpectationInterceptor(opts.Expectations)
+		unary = append(unary, expectations.UnaryInterceptor)
+		stream = append(stream, expectations.StreamInterceptor)
+	}
+	if opts.StreamDuration != nil {
+		stream = append(stream, opts.StreamDuration.StreamInterceptor)
+	}
+	if opts.ByteBudgets != nil {
+		unary = append(unary, opts.ByteBudgets.UnaryInterceptor)
+		stream = append(stream, opts.ByteBudgets.StreamInterceptor)
+	}
+	if opts.OverloadLimiter != nil {
+		unary = append(unary, opts.OverloadLimiter.UnaryInterceptor)
+		stream = append(stream, opts.OverloadLimiter.StreamInterceptor)
+	}
+	if opts.ErrorInjector != nil {
+		unary = append(unary, opts.ErrorInjector.UnaryInterceptor)
+		stream = append(stream, opts.ErrorInjector.StreamInterceptor)
+	}
+	if opts.Scenarios != nil {
+		scenarios := server.NewScenarioInterceptor(opts.Scenarios, nil)
+		unary = append(unary, scenarios.UnaryInterceptor)
+		stream = append(stream, scenarios.StreamInterceptor)
+	}
+	unary = append(unary, server.EchoDigestUnaryInterceptor)
+	stream = append(stream, server.EchoDigestStreamInterceptor)
+	if opts.Observers != nil {
+		unary = append(unary, opts.Observers.UnaryInterceptor)
+		stream = append(stream, opts.Observers.StreamInterceptor)
+	}
+	return server.ChainUnaryInterceptors(unary...), server.ChainStreamInterceptors(stream...)
+}
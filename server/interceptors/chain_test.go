@@ -0,0 +1,342 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptors
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/googleapis/gapic-showcase/server"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+func testOptions() Options {
+	metrics := server.NewMetrics()
+	return Options{
+		Metrics:            metrics,
+		ConcurrencyLimiter: server.NewConcurrencyLimiter(1, metrics),
+		OverloadLimiter:    server.NewOverloadLimiter(time.Now),
+		Observers:          server.ShowcaseObserverRegistry(),
+	}
+}
+
+func namespaceContext(namespace string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(server.NamespaceHeader, namespace))
+}
+
+func TestChain_recoversPanicsBelowEveryInterceptor(t *testing.T) {
+	opts := testOptions()
+	unary, stream := Chain(opts)
+
+	panics := func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	}
+	if _, err := unary(namespaceContext("a"), nil, &grpc.UnaryServerInfo{FullMethod: "/a.B/C"}, panics); status.Code(err) != codes.Internal {
+		t.Errorf("unary chain: want Internal got %v", err)
+	}
+	streamPanics := func(srv interface{}, ss grpc.ServerStream) error {
+		panic("boom")
+	}
+	ss := &contextStream{ctx: namespaceContext("a")}
+	if err := stream(nil, ss, &grpc.StreamServerInfo{FullMethod: "/a.B/D"}, streamPanics); status.Code(err) != codes.Internal {
+		t.Errorf("stream chain: want Internal got %v", err)
+	}
+	if got := opts.Metrics.Get(RecoveredPanicsMetric); got != 2 {
+		t.Errorf("chain: want 2 recovered panics got %d", got)
+	}
+
+	// The concurrency limiter, which allows one call, freed the slots of
+	// the panicking calls.
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	if _, err := unary(namespaceContext("a"), nil, &grpc.UnaryServerInfo{FullMethod: "/a.B/C"}, ok); err != nil {
+		t.Errorf("unary chain after panics: unexpected err %+v", err)
+	}
+}
+
+func TestChain_namespaceLabelsMetrics(t *testing.T) {
+	opts := testOptions()
+	unary, _ := Chain(opts)
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	if _, err := unary(namespaceContext("team-a"), nil, &grpc.UnaryServerInfo{FullMethod: "/a.B/C"}, ok); err != nil {
+		t.Fatal(err)
+	}
+	if got := opts.Metrics.Get(HandledRPCsMetric("team-a", "/a.B/C", codes.OK)); got != 1 {
+		t.Errorf("chain: want the call counted in namespace team-a got %v", opts.Metrics.Snapshot())
+	}
+}
+
+func TestChain_countsOverloadRejections(t *testing.T) {
+	opts := testOptions()
+	opts.OverloadLimiter.SetLimit("/a.B/C", 0.001, time.Second)
+	unary, _ := Chain(opts)
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	for i := 0; i < 2; i++ {
+		unary(namespaceContext("team-a"), nil, &grpc.UnaryServerInfo{FullMethod: "/a.B/C"}, ok)
+	}
+	if got := opts.Metrics.Get(HandledRPCsMetric("team-a", "/a.B/C", codes.ResourceExhausted)); got != 1 {
+		t.Errorf("chain: want the overloaded call counted got %v", opts.Metrics.Snapshot())
+	}
+}
+
+func TestChain_rejectsInvalidNamespaceBeforeHandling(t *testing.T) {
+	unary, _ := Chain(Options{})
+	handled := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handled = true
+		return nil, nil
+	}
+	_, err := unary(namespaceContext("Not Valid"), nil, &grpc.UnaryServerInfo{FullMethod: "/a.B/C"}, handler)
+	if status.Code(err) != codes.InvalidArgument || handled {
+		t.Errorf("chain with an invalid namespace: want InvalidArgument before the handler, got %v", err)
+	}
+}
+
+func TestChain_forgetsDecodeErrorsOfRejectedCalls(t *testing.T) {
+	codec := server.NewJSONCodec()
+	req := &pb.EchoRequest{}
+	if err := codec.Unmarshal([]byte(`{"bogus":true}`), req); err != nil {
+		t.Fatal(err)
+	}
+	unary, _ := Chain(Options{JSONCodec: codec})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/a.B/C"}
+	if _, err := unary(namespaceContext("Not Valid"), req, info, handler); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("chain with bad JSON and an invalid namespace: want InvalidArgument got %v", err)
+	}
+	// The codec no longer holds the error of the rejected call.
+	if _, err := codec.UnaryInterceptor(context.Background(), req, info, handler); err != nil {
+		t.Errorf("codec after the call was rejected: want no decoding error held got %v", err)
+	}
+}
+
+// eventLog records the order in which parts of a server see a call.
+type eventLog struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (l *eventLog) add(event string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+}
+
+func (l *eventLog) get() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.events...)
+}
+
+// loggingStatsHandler logs the start and end of every RPC.
+type loggingStatsHandler struct {
+	log *eventLog
+}
+
+func (h loggingStatsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (h loggingStatsHandler) HandleRPC(_ context.Context, s stats.RPCStats) {
+	switch s.(type) {
+	case *stats.Begin:
+		h.log.add("stats begin")
+	case *stats.End:
+		h.log.add("stats end")
+	}
+}
+
+func (h loggingStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h loggingStatsHandler) HandleConn(context.Context, stats.ConnStats) {}
+
+type loggingEchoServer struct {
+	log *eventLog
+	pb.EchoServer
+}
+
+func (s loggingEchoServer) Echo(_ context.Context, in *pb.EchoRequest) (*pb.EchoResponse, error) {
+	s.log.add("handler")
+	return &pb.EchoResponse{Content: in.GetContent()}, nil
+}
+
+func TestServerOptions_extras(t *testing.T) {
+	log := &eventLog{}
+	calls := 0
+	counting := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		calls++
+		log.add("interceptor")
+		return handler(ctx, req)
+	}
+	opts := testOptions()
+	opts.ExtraUnaryInterceptors = []grpc.UnaryServerInterceptor{counting}
+	opts.ExtraServerOptions = []grpc.ServerOption{grpc.StatsHandler(loggingStatsHandler{log})}
+	serverOpts, err := ServerOptions(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer(serverOpts...)
+	pb.RegisterEchoServer(s, loggingEchoServer{log: log})
+	go s.Serve(lis)
+	defer s.Stop()
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if _, err := pb.NewEchoClient(conn).Echo(context.Background(), &pb.EchoRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	s.GracefulStop()
+	want := []string{"stats begin", "interceptor", "handler", "stats end"}
+	if got := log.get(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ServerOptions: want the events %q, got %q", want, got)
+	}
+	if calls != 1 {
+		t.Errorf("ServerOptions: want the interceptor called once, got %d", calls)
+	}
+}
+
+func TestServerOptions_libraryVersions(t *testing.T) {
+	settings := server.DefaultSettings()
+	settings.MinLibraryVersions = map[string]string{"gccl": "2.3.0"}
+	store := server.NewSettingsStore(settings)
+	opts := testOptions()
+	opts.Settings = store
+	serverOpts, err := ServerOptions(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer(serverOpts...)
+	pb.RegisterEchoServer(s, loggingEchoServer{log: &eventLog{}})
+	go s.Serve(lis)
+	defer s.Stop()
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewEchoClient(conn)
+
+	tests := []struct {
+		strict    bool
+		apiClient string
+		want      codes.Code
+	}{
+		{false, "gl-go/1.12 gccl/2.3.0", codes.OK},
+		{false, "gl-go/1.12 gccl/2.2.1", codes.FailedPrecondition},
+		{false, "", codes.OK},
+		{true, "", codes.FailedPrecondition},
+		{true, "gccl/3.0.0", codes.OK},
+	}
+	for _, test := range tests {
+		store.Update(func(s *server.Settings) error {
+			s.StrictLibraryVersions = test.strict
+			return nil
+		})
+		ctx := context.Background()
+		if test.apiClient != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, server.APIClientHeader, test.apiClient)
+		}
+		_, err := client.Echo(ctx, &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}})
+		if status.Code(err) != test.want {
+			t.Errorf("Echo(%q, strict %t): want %s got %v", test.apiClient, test.strict, test.want, err)
+		}
+	}
+}
+
+func TestChain_extrasRunInsideRecovery(t *testing.T) {
+	opts := testOptions()
+	opts.ExtraUnaryInterceptors = []grpc.UnaryServerInterceptor{
+		func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			panic("boom")
+		},
+	}
+	var streamed []string
+	opts.ExtraStreamInterceptors = []grpc.StreamServerInterceptor{
+		func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			streamed = append(streamed, "first")
+			return handler(srv, ss)
+		},
+		func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			streamed = append(streamed, "second")
+			return handler(srv, ss)
+		},
+	}
+	unary, stream := Chain(opts)
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	if _, err := unary(namespaceContext("a"), nil, &grpc.UnaryServerInfo{FullMethod: "/a.B/C"}, ok); status.Code(err) != codes.Internal {
+		t.Errorf("unary chain: want Internal got %v", err)
+	}
+
+	// The extra interceptors see calls that the namespace interceptor
+	// rejects.
+	streamOK := func(srv interface{}, ss grpc.ServerStream) error {
+		return nil
+	}
+	ss := &contextStream{ctx: namespaceContext("Not Valid")}
+	if err := stream(nil, ss, &grpc.StreamServerInfo{FullMethod: "/a.B/D"}, streamOK); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("stream chain: want InvalidArgument got %v", err)
+	}
+	if want := []string{"first", "second"}; !reflect.DeepEqual(streamed, want) {
+		t.Errorf("stream chain: want the extra interceptors run in order, got %q", streamed)
+	}
+}
+
+func TestServerOptions_rejectsNil(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{"unary", Options{ExtraUnaryInterceptors: []grpc.UnaryServerInterceptor{nil}}},
+		{"stream", Options{ExtraStreamInterceptors: []grpc.StreamServerInterceptor{nil}}},
+		{"server option", Options{ExtraServerOptions: []grpc.ServerOption{grpc.MaxRecvMsgSize(1), nil}}},
+	}
+	for _, test := range tests {
+		if _, err := ServerOptions(test.opts); err == nil {
+			t.Errorf("ServerOptions(%s): want an error for a nil entry", test.name)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptors
+
+import (
+	"context"
+
+	"github.com/googleapis/gapic-showcase/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecoveredPanicsMetric counts the handler panics that Recovery turned into
+// errors.
+const RecoveredPanicsMetric = "recovered_panics"
+
+// Recovery turns panics of the calls it intercepts into INTERNAL errors, so
+// that a panicking handler fails its call rather than the server.
+type Recovery struct {
+	metrics server.Metrics
+}
+
+// NewRecovery returns a Recovery that counts the panics it recovers in the
+// given metrics, which may be nil.
+func NewRecovery(metrics server.Metrics) *Recovery {
+	return &Recovery{metrics: metrics}
+}
+
+func (r *Recovery) recovered(method string, p interface{}) error {
+	if r.metrics != nil {
+		r.metrics.Add(RecoveredPanicsMetric, 1)
+	}
+	return status.Errorf(codes.Internal, "The server panicked handling %s: %v", method, p)
+}
+
+// UnaryInterceptor implements the grpc.UnaryServerInterceptor type.
+func (r *Recovery) UnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			resp, err = nil, r.recovered(info.FullMethod, p)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// StreamInterceptor implements the grpc.StreamServerInterceptor type.
+func (r *Recovery) StreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = r.recovered(info.FullMethod, p)
+		}
+	}()
+	return handler(srv, ss)
+}
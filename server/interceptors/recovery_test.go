@@ -0,0 +1,59 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/googleapis/gapic-showcase/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecovery_UnaryInterceptor(t *testing.T) {
+	metrics := server.NewMetrics()
+	r := NewRecovery(metrics)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/a.B/C"}
+	resp, err := r.UnaryInterceptor(context.Background(), nil, info, handler)
+	if resp != nil || status.Code(err) != codes.Internal {
+		t.Errorf("UnaryInterceptor: want Internal got %v, %v", resp, err)
+	}
+	if got := metrics.Get(RecoveredPanicsMetric); got != 1 {
+		t.Errorf("UnaryInterceptor: want 1 recovered panic got %d", got)
+	}
+
+	handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	if resp, err := r.UnaryInterceptor(context.Background(), nil, info, handler); resp != "ok" || err != nil {
+		t.Errorf("UnaryInterceptor: want ok got %v, %v", resp, err)
+	}
+}
+
+func TestRecovery_StreamInterceptor(t *testing.T) {
+	r := NewRecovery(nil)
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		panic("boom")
+	}
+	err := r.StreamInterceptor(nil, &contextStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/a.B/C"}, handler)
+	if status.Code(err) != codes.Internal {
+		t.Errorf("StreamInterceptor: want Internal got %v", err)
+	}
+}
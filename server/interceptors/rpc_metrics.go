@@ -0,0 +1,100 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/googleapis/gapic-showcase/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// HandledRPCsMetric returns the name of the metric counting the calls of the
+// namespace to the method, given by its full gRPC name, that ended with the
+// code.
+func HandledRPCsMetric(namespace, method string, code codes.Code) string {
+	return fmt.Sprintf("handled_rpcs{namespace=%q,method=%q,code=%q}", namespace, method, code)
+}
+
+// InvalidAttempt is the attempt label of RPCAttemptsMetric for values that
+// are not attempt numbers.
+const InvalidAttempt = "invalid"
+
+// RPCAttemptsMetric returns the name of the metric counting the calls to the
+// method, given by its full gRPC name, whose retry header had the attempt
+// number.
+func RPCAttemptsMetric(method, header, attempt string) string {
+	return fmt.Sprintf("rpc_attempts{method=%q,header=%q,attempt=%q}", method, header, attempt)
+}
+
+// RPCMetrics counts the calls it intercepts by namespace, method and status
+// code. It must run after the namespace interceptor. Calls whose handler
+// panics are counted by Recovery instead.
+//
+// It also counts the calls of each method by the attempt numbers of their
+// grpc-previous-rpc-attempts metadata and of the client attempt metadata
+// of the settings, so that retries piled on retries show up.
+type RPCMetrics struct {
+	metrics  server.Metrics
+	settings server.SettingsStore
+}
+
+// NewRPCMetrics returns an RPCMetrics that counts calls in the given metrics,
+// reading the client attempt header from the given settings.
+func NewRPCMetrics(metrics server.Metrics, settings server.SettingsStore) *RPCMetrics {
+	return &RPCMetrics{metrics: metrics, settings: settings}
+}
+
+func (m *RPCMetrics) countAttempts(ctx context.Context, method string) {
+	for _, header := range []string{server.PreviousRPCAttemptsHeader, m.settings.Get().ClientAttemptHeader} {
+		label := InvalidAttempt
+		if attempt, err := server.Attempt(ctx, header); err == nil {
+			label = strconv.FormatInt(attempt, 10)
+		}
+		m.metrics.Add(RPCAttemptsMetric(method, header, label), 1)
+	}
+}
+
+func (m *RPCMetrics) count(ctx context.Context, method string, err error) {
+	m.metrics.Add(HandledRPCsMetric(server.NamespaceFromContext(ctx), method, status.Code(err)), 1)
+}
+
+// UnaryInterceptor implements the grpc.UnaryServerInterceptor type.
+func (m *RPCMetrics) UnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	m.countAttempts(ctx, info.FullMethod)
+	resp, err := handler(ctx, req)
+	m.count(ctx, info.FullMethod, err)
+	return resp, err
+}
+
+// StreamInterceptor implements the grpc.StreamServerInterceptor type.
+func (m *RPCMetrics) StreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	m.countAttempts(ss.Context(), info.FullMethod)
+	err := handler(srv, ss)
+	m.count(ss.Context(), info.FullMethod, err)
+	return err
+}
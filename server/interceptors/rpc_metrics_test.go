@@ -0,0 +1,104 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/googleapis/gapic-showcase/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// contextStream is a grpc.ServerStream that only has a context.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestHandledRPCsMetric(t *testing.T) {
+	want := `handled_rpcs{namespace="team-a",method="/a.B/C",code="NotFound"}`
+	if got := HandledRPCsMetric("team-a", "/a.B/C", codes.NotFound); got != want {
+		t.Errorf("HandledRPCsMetric: want %s got %s", want, got)
+	}
+}
+
+func TestRPCMetrics(t *testing.T) {
+	metrics := server.NewMetrics()
+	m := NewRPCMetrics(metrics, server.NewSettingsStore(server.DefaultSettings()))
+	ctx := server.WithNamespace(context.Background(), "team-a")
+
+	unary := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "")
+	}
+	m.UnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/a.B/C"}, unary)
+	m.UnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/a.B/C"}, unary)
+	stream := func(srv interface{}, ss grpc.ServerStream) error {
+		return nil
+	}
+	m.StreamInterceptor(nil, &contextStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/a.B/D"}, stream)
+
+	want := map[string]int64{
+		HandledRPCsMetric("team-a", "/a.B/C", codes.NotFound): 2,
+		HandledRPCsMetric("team-a", "/a.B/D", codes.OK):       1,
+	}
+	for name, count := range want {
+		if got := metrics.Get(name); got != count {
+			t.Errorf("RPCMetrics: want %s = %d got %d", name, count, got)
+		}
+	}
+}
+
+func TestRPCMetrics_attempts(t *testing.T) {
+	metrics := server.NewMetrics()
+	settings := server.DefaultSettings()
+	settings.ClientAttemptHeader = "my-attempt"
+	m := NewRPCMetrics(metrics, server.NewSettingsStore(settings))
+	info := &grpc.UnaryServerInfo{FullMethod: "/a.B/C"}
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	calls := []metadata.MD{
+		nil,
+		metadata.Pairs(server.PreviousRPCAttemptsHeader, "1"),
+		metadata.Pairs(server.PreviousRPCAttemptsHeader, "2", "my-attempt", "1"),
+		metadata.Pairs(server.PreviousRPCAttemptsHeader, "2", "my-attempt", "1"),
+		metadata.Pairs("my-attempt", "x"),
+	}
+	for _, md := range calls {
+		m.UnaryInterceptor(metadata.NewIncomingContext(context.Background(), md), nil, info, ok)
+	}
+
+	want := map[string]int64{
+		RPCAttemptsMetric("/a.B/C", server.PreviousRPCAttemptsHeader, "0"): 2,
+		RPCAttemptsMetric("/a.B/C", server.PreviousRPCAttemptsHeader, "1"): 1,
+		RPCAttemptsMetric("/a.B/C", server.PreviousRPCAttemptsHeader, "2"): 2,
+		RPCAttemptsMetric("/a.B/C", "my-attempt", "0"):                     2,
+		RPCAttemptsMetric("/a.B/C", "my-attempt", "1"):                     2,
+		RPCAttemptsMetric("/a.B/C", "my-attempt", InvalidAttempt):          1,
+	}
+	for name, count := range want {
+		if got := metrics.Get(name); got != count {
+			t.Errorf("RPCMetrics: want %s = %d got %d", name, count, got)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+func TestChainUnaryInterceptors(t *testing.T) {
+	calls := []string{}
+	interceptor := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			calls = append(calls, name+" before")
+			resp, err := handler(ctx, req.(string)+" "+name)
+			calls = append(calls, name+" after")
+			return resp, err
+		}
+	}
+	chain := ChainUnaryInterceptors(interceptor("a"), interceptor("b"))
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls = append(calls, "handler")
+		return req, nil
+	}
+	for i := 0; i < 2; i++ {
+		calls = []string{}
+		resp, _ := chain(context.Background(), "req", &grpc.UnaryServerInfo{}, handler)
+		if resp != "req a b" {
+			t.Errorf("ChainUnaryInterceptors: want the request to pass through a then b, got %q", resp)
+		}
+		want := []string{"a before", "b before", "handler", "b after", "a after"}
+		if !reflect.DeepEqual(calls, want) {
+			t.Errorf("ChainUnaryInterceptors: want calls %v got %v", want, calls)
+		}
+	}
+
+	resp, _ := ChainUnaryInterceptors()(context.Background(), "req", &grpc.UnaryServerInfo{}, handler)
+	if resp != "req" {
+		t.Errorf("ChainUnaryInterceptors: want an empty chain to call the handler, got %q", resp)
+	}
+}
+
+func TestChainStreamInterceptors(t *testing.T) {
+	calls := []string{}
+	interceptor := func(name string) grpc.StreamServerInterceptor {
+		return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			calls = append(calls, name+" before")
+			err := handler(srv, ss)
+			calls = append(calls, name+" after")
+			return err
+		}
+	}
+	chain := ChainStreamInterceptors(interceptor("a"), interceptor("b"))
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		calls = append(calls, "handler")
+		return nil
+	}
+	chain(nil, nil, &grpc.StreamServerInfo{}, handler)
+	want := []string{"a before", "b before", "handler", "b after", "a after"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("ChainStreamInterceptors: want calls %v got %v", want, calls)
+	}
+}
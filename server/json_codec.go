@@ -0,0 +1,137 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// JSONCodec encodes gRPC messages as proto3 JSON, for clients that call with
+// the content-type application/grpc+json. Unknown fields are rejected, and
+// 64-bit integers are accepted as numbers or strings.
+//
+// gRPC reports the errors of codecs as INTERNAL, before the interceptors
+// of unary calls run. So that requests it cannot decode fail with
+// INVALID_ARGUMENT instead, the codec holds their errors for its
+// interceptors to return, which the server must install right after
+// recovery: a unary call rejected before the interceptor runs would leave
+// its error, and its request, held by the codec. Clients in the
+// same process that use the codec are not told of the responses it cannot
+// decode.
+type JSONCodec struct {
+	// errs holds the decoding errors by the message that failed to decode.
+	errs sync.Map
+}
+
+// NewJSONCodec returns a JSONCodec, which is to be registered with
+// encoding.RegisterCodec.
+func NewJSONCodec() *JSONCodec {
+	return &JSONCodec{}
+}
+
+// Name returns the content-subtype of the codec.
+func (c *JSONCodec) Name() string {
+	return "json"
+}
+
+// Marshal returns the JSON encoding of the message.
+func (c *JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a proto message", v)
+	}
+	var b bytes.Buffer
+	if err := (&jsonpb.Marshaler{}).Marshal(&b, m); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// Unmarshal decodes the JSON into the message. If it cannot, or a
+// CheckedCompressor could not decompress it, the error is held for the
+// interceptors, and the message is left empty.
+func (c *JSONCodec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("%T is not a proto message", v)
+	}
+	if name, failed := failedEncoding(data); failed {
+		m.Reset()
+		c.errs.Store(m, decompressionFailed(name))
+		return nil
+	}
+	if err := jsonpb.Unmarshal(bytes.NewReader(data), m); err != nil {
+		m.Reset()
+		c.errs.Store(m, err)
+	}
+	return nil
+}
+
+// decodeError returns the error decoding the message, if there was one, and
+// forgets it.
+func (c *JSONCodec) decodeError(m interface{}) error {
+	err, ok := c.errs.Load(m)
+	if !ok {
+		return nil
+	}
+	c.errs.Delete(m)
+	if _, ok := status.FromError(err.(error)); ok {
+		return err.(error)
+	}
+	return showcaseerrors.RequestBody("The request is not a valid %s: %s.", proto.MessageName(m.(proto.Message)), err)
+}
+
+// UnaryInterceptor fails unary calls whose request the codec could not decode.
+func (c *JSONCodec) UnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	if err := c.decodeError(req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor fails the receipt of the messages of streaming calls
+// that the codec could not decode.
+func (c *JSONCodec) StreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	return handler(srv, &jsonStream{ServerStream: ss, codec: c})
+}
+
+type jsonStream struct {
+	grpc.ServerStream
+	codec *JSONCodec
+}
+
+func (s *jsonStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.codec.decodeError(m)
+}
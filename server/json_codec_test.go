@@ -0,0 +1,116 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestJSONCodec(t *testing.T) {
+	c := NewJSONCodec()
+	if c.Name() != "json" {
+		t.Errorf("Name: want json got %q", c.Name())
+	}
+	in := &pb.EchoResponse{Content: "hi", ServerSequence: 1 << 40}
+	b, err := c.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(b); got != `{"content":"hi","serverSequence":"1099511627776"}` {
+		t.Errorf("Marshal: got %s", got)
+	}
+	out := &pb.EchoResponse{}
+	if err := c.Unmarshal(b, out); err != nil || !proto.Equal(in, out) {
+		t.Errorf("Unmarshal: want %v got %v, %v", in, out, err)
+	}
+	if _, err := c.Marshal("not a message"); err == nil {
+		t.Errorf("Marshal: want an error for a value that is not a message")
+	}
+}
+
+func TestJSONCodec_int64(t *testing.T) {
+	c := NewJSONCodec()
+	for _, body := range []string{`{"clientSequence":"7"}`, `{"clientSequence":7}`} {
+		req := &pb.EchoRequest{}
+		c.Unmarshal([]byte(body), req)
+		if err := c.decodeError(req); err != nil || req.GetClientSequence() != 7 {
+			t.Errorf("Unmarshal(%s): want client_sequence 7 got %v, %v", body, req, err)
+		}
+	}
+}
+
+func TestJSONCodec_UnaryInterceptor(t *testing.T) {
+	c := NewJSONCodec()
+	called := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		return nil, nil
+	}
+
+	req := &pb.EchoRequest{}
+	c.Unmarshal([]byte(`{"content":"hi"}`), req)
+	if _, err := c.UnaryInterceptor(context.Background(), req, &grpc.UnaryServerInfo{}, handler); err != nil || !called {
+		t.Errorf("UnaryInterceptor: want a decoded request handled, got %v", err)
+	}
+
+	called = false
+	req = &pb.EchoRequest{}
+	c.Unmarshal([]byte(`{"content":"hi","bogus":1}`), req)
+	_, err := c.UnaryInterceptor(context.Background(), req, &grpc.UnaryServerInfo{}, handler)
+	if status.Code(err) != codes.InvalidArgument || called {
+		t.Errorf("UnaryInterceptor: want InvalidArgument without calling the handler, got %v", err)
+	}
+	if msg := status.Convert(err).Message(); !strings.Contains(msg, "google.showcase.v1beta1.EchoRequest") || !strings.Contains(msg, "bogus") {
+		t.Errorf("UnaryInterceptor: want a message naming the request and the field, got %q", msg)
+	}
+	// The error is returned once.
+	if err := c.decodeError(req); err != nil {
+		t.Errorf("decodeError: want the error forgotten, got %v", err)
+	}
+}
+
+type recvStream struct {
+	grpc.ServerStream
+	codec *JSONCodec
+	body  string
+}
+
+func (s *recvStream) RecvMsg(m interface{}) error {
+	return s.codec.Unmarshal([]byte(s.body), m)
+}
+
+func TestJSONCodec_StreamInterceptor(t *testing.T) {
+	c := NewJSONCodec()
+	for body, want := range map[string]codes.Code{
+		`{"content":"hi"}`:  codes.OK,
+		`{"contents":"hi"}`: codes.InvalidArgument,
+	} {
+		handler := func(srv interface{}, ss grpc.ServerStream) error {
+			return ss.RecvMsg(&pb.EchoRequest{})
+		}
+		err := c.StreamInterceptor(nil, &recvStream{codec: c, body: body}, &grpc.StreamServerInfo{}, handler)
+		if status.Code(err) != want {
+			t.Errorf("StreamInterceptor(%s): want %s got %v", body, want, err)
+		}
+	}
+}
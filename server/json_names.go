@@ -0,0 +1,156 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+)
+
+const (
+	// JSONNameStyleHeader is the HTTP header that selects the field names of
+	// JSON bodies: "json", the default, for lowerCamelCase names, or "proto"
+	// for the names of the proto definition.
+	JSONNameStyleHeader = "showcase-json-name-style"
+
+	// JSONStrictHeader is the HTTP header that, when "true", rejects request
+	// bodies with field names that are not of the selected style.
+	JSONStrictHeader = "showcase-json-strict"
+)
+
+// jsonNameStyle reports whether the request selects proto field names.
+func jsonNameStyle(req *http.Request) (bool, error) {
+	switch style := req.Header.Get(JSONNameStyleHeader); style {
+	case "", "json":
+		return false, nil
+	case "proto":
+		return true, nil
+	default:
+		return false, showcaseerrors.Metadata(
+			JSONNameStyleHeader,
+			"The %s header %q must be \"json\" or \"proto\".",
+			JSONNameStyleHeader,
+			style)
+	}
+}
+
+// jsonField is a field of a message as it appears in JSON.
+type jsonField struct {
+	origName string
+	jsonName string
+	typ      reflect.Type
+}
+
+// jsonFields returns the fields of the message struct type by both of their
+// JSON names.
+func jsonFields(t reflect.Type) map[string]jsonField {
+	props := proto.GetProperties(t)
+	fields := map[string]jsonField{}
+	add := func(p *proto.Properties, typ reflect.Type) {
+		f := jsonField{origName: p.OrigName, jsonName: p.JSONName, typ: typ}
+		if f.jsonName == "" {
+			// protoc omits the JSON name when it equals the proto name.
+			f.jsonName = f.origName
+		}
+		fields[f.origName] = f
+		fields[f.jsonName] = f
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if strings.HasPrefix(f.Name, "XXX_") || f.Tag.Get("protobuf_oneof") != "" {
+			continue
+		}
+		add(props.Prop[i], f.Type)
+	}
+	for _, oneof := range props.OneofTypes {
+		add(oneof.Prop, oneof.Type.Elem().Field(0).Type)
+	}
+	return fields
+}
+
+// messageType returns the struct type of a field holding messages, other
+// than well-known types whose JSON form is not an object of fields.
+func messageType(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return nil, false
+	}
+	if _, ok := reflect.New(t.Elem()).Interface().(interface{ XXX_WellKnownType() string }); ok {
+		return nil, false
+	}
+	return t.Elem(), true
+}
+
+// checkJSONNames returns an INVALID_ARGUMENT error with a BadRequest detail
+// if any field of the JSON form of the message is named in the other style.
+// Unknown fields are left for the decoder to reject.
+func checkJSONNames(body []byte, msg proto.Message, protoNames bool) error {
+	field, err := wrongJSONName(json.RawMessage(body), reflect.TypeOf(msg).Elem(), protoNames, "")
+	if err != nil || field == "" {
+		return err
+	}
+	want, got := "lowerCamelCase", "proto"
+	if protoNames {
+		want, got = got, want
+	}
+	return showcaseerrors.BadRequest(
+		showcaseerrors.FieldInvalid,
+		field,
+		fmt.Sprintf("The field `%s` has its %s name, but %s names were requested.", field, got, want))
+}
+
+// wrongJSONName returns the path of the first field named in the other style.
+func wrongJSONName(raw json.RawMessage, t reflect.Type, protoNames bool, prefix string) (string, error) {
+	obj := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return "", showcaseerrors.RequestBody("The request body is not a JSON object: %s.", err)
+	}
+	fields := jsonFields(t)
+	for key, value := range obj {
+		f, ok := fields[key]
+		if !ok {
+			continue
+		}
+		path := prefix + key
+		if protoNames && key != f.origName || !protoNames && key != f.jsonName {
+			return path, nil
+		}
+		if elem, ok := messageType(f.typ); ok {
+			if name, err := wrongJSONName(value, elem, protoNames, path+"."); name != "" || err != nil {
+				return name, err
+			}
+			continue
+		}
+		if f.typ.Kind() == reflect.Slice {
+			if elem, ok := messageType(f.typ.Elem()); ok {
+				var items []json.RawMessage
+				if err := json.Unmarshal(value, &items); err != nil {
+					continue
+				}
+				for i, item := range items {
+					if name, err := wrongJSONName(item, elem, protoNames, fmt.Sprintf("%s[%d].", path, i)); name != "" || err != nil {
+						return name, err
+					}
+				}
+			}
+		}
+	}
+	return "", nil
+}
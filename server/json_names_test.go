@@ -0,0 +1,89 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestJSONNameStyle(t *testing.T) {
+	tests := []struct {
+		header     string
+		protoNames bool
+		wantErr    bool
+	}{
+		{"", false, false},
+		{"json", false, false},
+		{"proto", true, false},
+		{"camel", false, true},
+	}
+	for _, test := range tests {
+		req, _ := http.NewRequest(http.MethodPost, EchoPath, nil)
+		req.Header.Set(JSONNameStyleHeader, test.header)
+		got, err := jsonNameStyle(req)
+		if (err != nil) != test.wantErr || got != test.protoNames {
+			t.Errorf("jsonNameStyle(%q): want %t, error %t got %t, %v", test.header, test.protoNames, test.wantErr, got, err)
+		}
+	}
+}
+
+func TestCheckJSONNames(t *testing.T) {
+	tests := []struct {
+		body       string
+		protoNames bool
+		want       string
+	}{
+		{`{"content":"hi","clientSequence":1}`, false, ""},
+		{`{"content":"hi","client_sequence":1}`, true, ""},
+		{`{"client_sequence":1}`, false, "client_sequence"},
+		{`{"clientSequence":1}`, true, "clientSequence"},
+		{`{"cacheControl":{"max_age":1}}`, false, "cacheControl.max_age"},
+		{`{"cache_control":{"maxAge":1}}`, true, "cache_control.maxAge"},
+		{`{"error":{"code":3,"message":"m"}}`, false, ""},
+		{`{"idleTimeout":"1s"}`, false, ""},
+		{`{"unknown_field":1}`, false, ""},
+	}
+	for _, test := range tests {
+		err := checkJSONNames([]byte(test.body), &pb.EchoRequest{}, test.protoNames)
+		if test.want == "" {
+			if err != nil {
+				t.Errorf("checkJSONNames(%s, %t): want no error got %v", test.body, test.protoNames, err)
+			}
+			continue
+		}
+		st := status.Convert(err)
+		if st.Code() != codes.InvalidArgument || len(st.Details()) != 2 {
+			t.Errorf("checkJSONNames(%s, %t): want INVALID_ARGUMENT with a BadRequest got %v", test.body, test.protoNames, err)
+			continue
+		}
+		br := st.Details()[0].(*errdetails.BadRequest)
+		if got := br.GetFieldViolations()[0].GetField(); got != test.want {
+			t.Errorf("checkJSONNames(%s, %t): want a violation of %s got %s", test.body, test.protoNames, test.want, got)
+		}
+	}
+}
+
+func TestCheckJSONNames_notAnObject(t *testing.T) {
+	err := checkJSONNames([]byte(`[1]`), &pb.EchoRequest{}, false)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("checkJSONNames: want INVALID_ARGUMENT got %v", err)
+	}
+}
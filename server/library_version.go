@@ -0,0 +1,316 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// APIClientHeader is the metadata key with which generated clients name
+// themselves and their versions, as space-separated tokens such as
+// `gl-go/1.12 gccl/2.3.0`.
+const APIClientHeader = "x-goog-api-client"
+
+// Version is a semantic version. Versions may leave out their minor and
+// patch numbers, which are then zero, and their build metadata is ignored.
+type Version struct {
+	Major, Minor, Patch int64
+
+	// The dot-separated pre-release identifiers, such as ["beta", "2"] for
+	// 1.0.0-beta.2.
+	PreRelease []string
+}
+
+// ParseVersion parses a semantic version such as 2.3.0, 2.3 or
+// 2.3.0-beta.1+build.5.
+func ParseVersion(s string) (Version, error) {
+	v := Version{}
+	rest := s
+	if i := strings.Index(rest, "+"); i >= 0 {
+		if !validIdentifiers(rest[i+1:]) {
+			return v, fmt.Errorf("the build metadata of %q is invalid", s)
+		}
+		rest = rest[:i]
+	}
+	if i := strings.Index(rest, "-"); i >= 0 {
+		if !validIdentifiers(rest[i+1:]) {
+			return v, fmt.Errorf("the pre-release of %q is invalid", s)
+		}
+		v.PreRelease = strings.Split(rest[i+1:], ".")
+		rest = rest[:i]
+	}
+	numbers := strings.Split(rest, ".")
+	if len(numbers) > 3 {
+		return v, fmt.Errorf("%q has more than three version numbers", s)
+	}
+	parts := []*int64{&v.Major, &v.Minor, &v.Patch}
+	for i, n := range numbers {
+		if !isNumeric(n) {
+			return v, fmt.Errorf("%q is not a semantic version", s)
+		}
+		var err error
+		if *parts[i], err = strconv.ParseInt(n, 10, 64); err != nil {
+			return v, fmt.Errorf("the version numbers of %q are too large", s)
+		}
+	}
+	return v, nil
+}
+
+// validIdentifiers reports whether s is dot-separated, non-empty identifiers
+// of ASCII letters, digits and hyphens.
+func validIdentifiers(s string) bool {
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return false
+		}
+		for _, c := range id {
+			if !(c == '-' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Compare returns -1, 0 or 1 as v precedes, equals or follows w. A
+// pre-release precedes its release, and pre-releases compare identifier by
+// identifier: numerically when both are numbers, with numbers before other
+// identifiers, and otherwise in ASCII order. A pre-release with more
+// identifiers follows one that it extends.
+func (v Version) Compare(w Version) int {
+	if c := compareInts(v.Major, w.Major); c != 0 {
+		return c
+	}
+	if c := compareInts(v.Minor, w.Minor); c != 0 {
+		return c
+	}
+	if c := compareInts(v.Patch, w.Patch); c != 0 {
+		return c
+	}
+	switch {
+	case len(v.PreRelease) == 0 && len(w.PreRelease) == 0:
+		return 0
+	case len(v.PreRelease) == 0:
+		return 1
+	case len(w.PreRelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.PreRelease) && i < len(w.PreRelease); i++ {
+		if c := compareIdentifiers(v.PreRelease[i], w.PreRelease[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInts(int64(len(v.PreRelease)), int64(len(w.PreRelease)))
+}
+
+func compareIdentifiers(a, b string) int {
+	aNumeric, bNumeric := isNumeric(a), isNumeric(b)
+	switch {
+	case aNumeric && bNumeric:
+		// Numbers too long to parse compare by their length first.
+		a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+		if c := compareInts(int64(len(a)), int64(len(b))); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	case aNumeric:
+		return -1
+	case bNumeric:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.PreRelease) > 0 {
+		s += "-" + strings.Join(v.PreRelease, ".")
+	}
+	return s
+}
+
+// ParseAPIClient returns the version of each token of x-goog-api-client
+// metadata, keyed by the name of the token. Tokens without a version map to
+// the empty string, and only the first of repeated tokens counts.
+func ParseAPIClient(values ...string) map[string]string {
+	tokens := map[string]string{}
+	for _, value := range values {
+		for _, token := range strings.Fields(value) {
+			name, version := token, ""
+			if i := strings.Index(token, "/"); i >= 0 {
+				name, version = token[:i], token[i+1:]
+			}
+			if _, ok := tokens[name]; !ok {
+				tokens[name] = version
+			}
+		}
+	}
+	return tokens
+}
+
+// LibraryVersionInterceptor fails the calls of client libraries older than
+// the MinLibraryVersions setting.
+type LibraryVersionInterceptor struct {
+	settings SettingsStore
+}
+
+// NewLibraryVersionInterceptor returns a LibraryVersionInterceptor that
+// reads the minimum versions from the settings.
+func NewLibraryVersionInterceptor(settings SettingsStore) *LibraryVersionInterceptor {
+	return &LibraryVersionInterceptor{settings: settings}
+}
+
+// check returns a FAILED_PRECONDITION error if the call is from a library
+// older than its minimum, or, under StrictLibraryVersions, does not name a
+// library that has one. Calls to the Testing service are exempt, so that
+// tests can always change the minimums.
+func (l *LibraryVersionInterceptor) check(ctx context.Context, method string) error {
+	if strings.HasPrefix(method, "/google.showcase.v1beta1.Testing/") {
+		return nil
+	}
+	settings := l.settings.Get()
+	if len(settings.MinLibraryVersions) == 0 {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	clients := ParseAPIClient(md.Get(APIClientHeader)...)
+	tokens := []string{}
+	for token := range settings.MinLibraryVersions {
+		tokens = append(tokens, token)
+	}
+	sort.Strings(tokens)
+	for _, token := range tokens {
+		minimum := settings.MinLibraryVersions[token]
+		version, ok := clients[token]
+		if !ok {
+			if !settings.StrictLibraryVersions {
+				continue
+			}
+			return libraryVersionError(
+				showcaseerrors.LibraryVersionMissing,
+				fmt.Sprintf("The %s metadata must name the version of %s, which must be at least %s.", APIClientHeader, token, minimum),
+				map[string]string{"token": token, "minimum_version": minimum})
+		}
+		got, err := ParseVersion(version)
+		if err == nil {
+			min, _ := ParseVersion(minimum)
+			if got.Compare(min) >= 0 {
+				continue
+			}
+		}
+		message := fmt.Sprintf("The client library %s/%s is older than the minimum version %s.", token, version, minimum)
+		if err != nil {
+			message = fmt.Sprintf("The client library %s/%s does not have a semantic version, so it cannot be shown to be at least %s.", token, version, minimum)
+		}
+		return libraryVersionError(
+			showcaseerrors.LibraryVersionTooOld,
+			message,
+			map[string]string{"token": token, "version": version, "minimum_version": minimum})
+	}
+	return nil
+}
+
+func libraryVersionError(reason, message string, md map[string]string) error {
+	return status.ErrorProto(&spb.Status{
+		Code:    int32(codes.FailedPrecondition),
+		Message: message,
+		Details: []*any.Any{showcaseerrors.ErrorInfo(reason, showcaseerrors.Domain, md)},
+	})
+}
+
+// UnaryInterceptor fails unary calls from client libraries that are too old.
+func (l *LibraryVersionInterceptor) UnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	if err := l.check(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor fails streaming calls from client libraries that are too
+// old.
+func (l *LibraryVersionInterceptor) StreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	if err := l.check(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// validateMinLibraryVersions returns an INVALID_ARGUMENT error describing
+// the first minimum version whose token or version is invalid.
+func validateMinLibraryVersions(versions map[string]string) error {
+	tokens := []string{}
+	for token := range versions {
+		tokens = append(tokens, token)
+	}
+	sort.Strings(tokens)
+	for _, token := range tokens {
+		if token == "" || strings.ContainsAny(token, "/ \t") {
+			return showcaseerrors.Setting(
+				"min_library_versions",
+				"The setting `min_library_versions` names the token %q, which must be non-empty without slashes or spaces.",
+				token)
+		}
+		if _, err := ParseVersion(versions[token]); err != nil {
+			return showcaseerrors.Setting(
+				"min_library_versions",
+				"The setting `min_library_versions[%q]` is invalid: %s.",
+				token,
+				err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Version
+	}{
+		{"2.3.0", Version{2, 3, 0, nil}},
+		{"2.3", Version{2, 3, 0, nil}},
+		{"2", Version{2, 0, 0, nil}},
+		{"0.0.0", Version{0, 0, 0, nil}},
+		{"10.20.30", Version{10, 20, 30, nil}},
+		{"1.0.0-alpha", Version{1, 0, 0, []string{"alpha"}}},
+		{"1.0.0-beta.11", Version{1, 0, 0, []string{"beta", "11"}}},
+		{"1.0.0-x-y.0-z", Version{1, 0, 0, []string{"x-y", "0-z"}}},
+		{"1.0.0+build.5", Version{1, 0, 0, nil}},
+		{"1.0.0-rc.1+build-5", Version{1, 0, 0, []string{"rc", "1"}}},
+		// A hyphen in the build metadata does not start a pre-release.
+		{"1.0.0+exp-sha", Version{1, 0, 0, nil}},
+	}
+	for _, test := range tests {
+		got, err := ParseVersion(test.in)
+		if err != nil {
+			t.Errorf("ParseVersion(%q): unexpected err %v", test.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ParseVersion(%q): want %+v got %+v", test.in, test.want, got)
+		}
+	}
+}
+
+func TestParseVersion_invalid(t *testing.T) {
+	tests := []struct {
+		in      string
+		wantMsg string
+	}{
+		{"", "is not a semantic version"},
+		{"v1.2.3", "is not a semantic version"},
+		{"1..3", "is not a semantic version"},
+		{"1.2.x", "is not a semantic version"},
+		{"1.2.3.4", "more than three version numbers"},
+		{"1.2.3-", "the pre-release"},
+		{"1.2.3-alpha..1", "the pre-release"},
+		{"1.2.3-alpha_1", "the pre-release"},
+		{"1.2.3+", "the build metadata"},
+		{"1.2.3+a+b", "the build metadata"},
+		{"99999999999999999999.0.0", "are too large"},
+	}
+	for _, test := range tests {
+		_, err := ParseVersion(test.in)
+		if err == nil || !strings.Contains(err.Error(), test.wantMsg) {
+			t.Errorf("ParseVersion(%q): want an error containing %q got %v", test.in, test.wantMsg, err)
+		}
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	// In increasing order, as in the semantic versioning specification.
+	ordered := []string{
+		"0.9.9",
+		"1.0.0-0",
+		"1.0.0-2",
+		"1.0.0-10",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"1.10.0",
+		"2.0.0-rc.1",
+		"2.0.0",
+		"10.0.0",
+	}
+	versions := []Version{}
+	for _, s := range ordered {
+		v, err := ParseVersion(s)
+		if err != nil {
+			t.Fatalf("ParseVersion(%q): unexpected err %v", s, err)
+		}
+		versions = append(versions, v)
+	}
+	for i, v := range versions {
+		for j, w := range versions {
+			want := compareInts(int64(i), int64(j))
+			if got := v.Compare(w); got != want {
+				t.Errorf("%s.Compare(%s): want %d got %d", ordered[i], ordered[j], want, got)
+			}
+		}
+	}
+
+	equal := [][2]string{
+		{"2.3", "2.3.0"},
+		{"2.3.0+a", "2.3.0+b"},
+		{"1.0.0-rc.01", "1.0.0-rc.1"},
+		{"1.0.0-rc.1+a", "1.0.0-rc.1"},
+	}
+	for _, e := range equal {
+		v, _ := ParseVersion(e[0])
+		w, _ := ParseVersion(e[1])
+		if v.Compare(w) != 0 || w.Compare(v) != 0 {
+			t.Errorf("%s.Compare(%s): want them equal", e[0], e[1])
+		}
+	}
+
+	// Numeric identifiers too long to parse still compare numerically.
+	v, _ := ParseVersion("1.0.0-99999999999999999999")
+	w, _ := ParseVersion("1.0.0-100000000000000000000")
+	if v.Compare(w) != -1 {
+		t.Errorf("%s.Compare(%s): want -1", v, w)
+	}
+}
+
+func TestVersion_String(t *testing.T) {
+	for in, want := range map[string]string{
+		"2.3":             "2.3.0",
+		"1.0.0-beta.2+b7": "1.0.0-beta.2",
+		"10.20.30":        "10.20.30",
+	} {
+		v, _ := ParseVersion(in)
+		if got := v.String(); got != want {
+			t.Errorf("ParseVersion(%q).String(): want %q got %q", in, want, got)
+		}
+	}
+}
+
+func TestParseAPIClient(t *testing.T) {
+	got := ParseAPIClient("gl-go/1.12.5 gapic/0.1.0  gax/2.0.0", "grpc/1.19.1 gccl gax/1.0.0")
+	want := map[string]string{
+		"gl-go": "1.12.5",
+		"gapic": "0.1.0",
+		"gax":   "2.0.0",
+		"grpc":  "1.19.1",
+		"gccl":  "",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseAPIClient: want %v got %v", want, got)
+	}
+	if got := ParseAPIClient(); len(got) != 0 {
+		t.Errorf("ParseAPIClient(): want no tokens got %v", got)
+	}
+}
+
+func apiClientContext(values ...string) context.Context {
+	md := metadata.MD{}
+	if len(values) > 0 {
+		md = metadata.MD{APIClientHeader: values}
+	}
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func TestLibraryVersionUnaryInterceptor(t *testing.T) {
+	minimums := map[string]string{"gccl": "2.3.0", "gax": "1.0.0-beta.2"}
+	tests := []struct {
+		strict     bool
+		method     string
+		apiClients []string
+		wantReason string
+		wantMD     map[string]string
+	}{
+		{false, "/google.showcase.v1beta1.Echo/Echo", []string{"gl-go/1.12 gccl/2.3.0 gax/1.0.0"}, "", nil},
+		{false, "/google.showcase.v1beta1.Echo/Echo", []string{"gccl/2.10.0", "gax/1.0.0-beta.10"}, "", nil},
+		{false, "/google.showcase.v1beta1.Echo/Echo", []string{"gccl/2.2.9"}, "LIBRARY_VERSION_TOO_OLD", map[string]string{"token": "gccl", "version": "2.2.9", "minimum_version": "2.3.0"}},
+		{false, "/google.showcase.v1beta1.Echo/Echo", []string{"gccl/2.3.0-rc.1"}, "LIBRARY_VERSION_TOO_OLD", map[string]string{"token": "gccl", "version": "2.3.0-rc.1", "minimum_version": "2.3.0"}},
+		{false, "/google.showcase.v1beta1.Echo/Echo", []string{"gax/1.0.0-beta"}, "LIBRARY_VERSION_TOO_OLD", map[string]string{"token": "gax", "version": "1.0.0-beta", "minimum_version": "1.0.0-beta.2"}},
+		{false, "/google.showcase.v1beta1.Echo/Echo", []string{"gccl/latest"}, "LIBRARY_VERSION_TOO_OLD", map[string]string{"token": "gccl", "version": "latest", "minimum_version": "2.3.0"}},
+		{false, "/google.showcase.v1beta1.Echo/Echo", []string{"gccl"}, "LIBRARY_VERSION_TOO_OLD", map[string]string{"token": "gccl", "version": "", "minimum_version": "2.3.0"}},
+		// Tokens without a minimum, or without a token, pass unless strict.
+		{false, "/google.showcase.v1beta1.Echo/Echo", []string{"gl-go/0.1"}, "", nil},
+		{false, "/google.showcase.v1beta1.Echo/Echo", nil, "", nil},
+		{true, "/google.showcase.v1beta1.Echo/Echo", []string{"gccl/2.3.0 gax/1.0.0"}, "", nil},
+		{true, "/google.showcase.v1beta1.Echo/Echo", []string{"gccl/2.3.0"}, "LIBRARY_VERSION_MISSING", map[string]string{"token": "gax", "minimum_version": "1.0.0-beta.2"}},
+		{true, "/google.showcase.v1beta1.Echo/Echo", nil, "LIBRARY_VERSION_MISSING", map[string]string{"token": "gax", "minimum_version": "1.0.0-beta.2"}},
+		// The Testing service is exempt.
+		{true, "/google.showcase.v1beta1.Testing/UpdateShowcaseSettings", []string{"gccl/1.0.0"}, "", nil},
+	}
+	for _, test := range tests {
+		settings := DefaultSettings()
+		settings.MinLibraryVersions = minimums
+		settings.StrictLibraryVersions = test.strict
+		l := NewLibraryVersionInterceptor(NewSettingsStore(settings))
+		handled := false
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			handled = true
+			return "ok", nil
+		}
+		_, err := l.UnaryInterceptor(apiClientContext(test.apiClients...), nil, &grpc.UnaryServerInfo{FullMethod: test.method}, handler)
+		if test.wantReason == "" {
+			if err != nil || !handled {
+				t.Errorf("UnaryInterceptor(%v, strict %t): want the call handled got %v", test.apiClients, test.strict, err)
+			}
+			continue
+		}
+		st := status.Convert(err)
+		if st.Code() != codes.FailedPrecondition || handled {
+			t.Errorf("UnaryInterceptor(%v, strict %t): want FailedPrecondition got %v", test.apiClients, test.strict, err)
+			continue
+		}
+		want := showcaseerrors.ErrorInfo(test.wantReason, showcaseerrors.Domain, test.wantMD)
+		if details := st.Proto().GetDetails(); len(details) != 1 || !proto.Equal(details[0], want) {
+			t.Errorf("UnaryInterceptor(%v, strict %t): want the ErrorInfo %s %v got %v", test.apiClients, test.strict, test.wantReason, test.wantMD, details)
+		}
+	}
+}
+
+func TestLibraryVersionUnaryInterceptor_messages(t *testing.T) {
+	settings := DefaultSettings()
+	settings.MinLibraryVersions = map[string]string{"gccl": "2.3.0"}
+	store := NewSettingsStore(settings)
+	l := NewLibraryVersionInterceptor(store)
+	info := &grpc.UnaryServerInfo{FullMethod: "/google.showcase.v1beta1.Echo/Echo"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+	tests := []struct {
+		apiClient string
+		want      string
+	}{
+		{"gccl/2.2.0", "The client library gccl/2.2.0 is older than the minimum version 2.3.0."},
+		{"gccl/next", "The client library gccl/next does not have a semantic version, so it cannot be shown to be at least 2.3.0."},
+	}
+	for _, test := range tests {
+		_, err := l.UnaryInterceptor(apiClientContext(test.apiClient), nil, info, handler)
+		if got := status.Convert(err).Message(); got != test.want {
+			t.Errorf("UnaryInterceptor(%q): want %q got %q", test.apiClient, test.want, got)
+		}
+	}
+
+	store.Update(func(s *Settings) error {
+		s.StrictLibraryVersions = true
+		return nil
+	})
+	_, err := l.UnaryInterceptor(apiClientContext(), nil, info, handler)
+	if got, want := status.Convert(err).Message(), "The x-goog-api-client metadata must name the version of gccl, which must be at least 2.3.0."; got != want {
+		t.Errorf("UnaryInterceptor without the token: want %q got %q", want, got)
+	}
+
+	// Settings changes apply to the next call.
+	store.Update(func(s *Settings) error {
+		s.MinLibraryVersions = nil
+		return nil
+	})
+	if _, err := l.UnaryInterceptor(apiClientContext(), nil, info, handler); err != nil {
+		t.Errorf("UnaryInterceptor without minimums: unexpected err %+v", err)
+	}
+}
+
+func TestLibraryVersionStreamInterceptor(t *testing.T) {
+	settings := DefaultSettings()
+	settings.MinLibraryVersions = map[string]string{"gccl": "2.3.0"}
+	l := NewLibraryVersionInterceptor(NewSettingsStore(settings))
+	info := &grpc.StreamServerInfo{FullMethod: "/google.showcase.v1beta1.Echo/Expand"}
+	handled := false
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		handled = true
+		return nil
+	}
+	if err := l.StreamInterceptor(nil, &contextStream{ctx: apiClientContext("gccl/2.3.1")}, info, handler); err != nil || !handled {
+		t.Errorf("StreamInterceptor(gccl/2.3.1): want the call handled got %v", err)
+	}
+	handled = false
+	err := l.StreamInterceptor(nil, &contextStream{ctx: apiClientContext("gccl/2.0.0")}, info, handler)
+	if status.Code(err) != codes.FailedPrecondition || handled {
+		t.Errorf("StreamInterceptor(gccl/2.0.0): want FailedPrecondition got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// DualStackNetwork is the network of Listen that requires a listener on all
+// interfaces to serve both IPv4 and IPv6.
+const DualStackNetwork = "tcp46"
+
+// Listen announces on the given address, which is either a port or a
+// host:port pair whose host may be a bracketed IPv6 literal. The network is
+// one of "tcp4", "tcp6", "tcp" or DualStackNetwork. A "tcp" listener serves
+// whichever families the host supports. A DualStackNetwork listener is
+// otherwise the same, except that on all interfaces it must be dual-stack; if
+// the host only supports IPv4 for it, an error is returned rather than
+// silently serving a single family.
+func Listen(network, address string) (net.Listener, error) {
+	if network != "tcp" && network != "tcp4" && network != "tcp6" && network != DualStackNetwork {
+		return nil, fmt.Errorf("unsupported network %q, must be tcp, tcp4, tcp6 or %s", network, DualStackNetwork)
+	}
+	dualStack := network == DualStackNetwork
+	if dualStack {
+		network = "tcp"
+	}
+	if !strings.Contains(address, ":") {
+		address = ":" + address
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %q: %v", address, err)
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		if network == "tcp4" && ip.To4() == nil {
+			return nil, fmt.Errorf("the address %q is not an IPv4 address, which network tcp4 requires", address)
+		}
+		if network == "tcp6" && ip.To4() != nil {
+			return nil, fmt.Errorf("the address %q is not an IPv6 address, which network tcp6 requires", address)
+		}
+	}
+
+	lis, err := net.Listen(network, address)
+	if err != nil {
+		return nil, err
+	}
+	if dualStack && (host == "" || host == "::") {
+		if addr, ok := lis.Addr().(*net.TCPAddr); ok && addr.IP.To4() != nil {
+			lis.Close()
+			return nil, fmt.Errorf(
+				"dual-stack listening on %q is not supported on this host, use network tcp4 or tcp6", address)
+		}
+	}
+	return lis, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net"
+	"strconv"
+	"testing"
+)
+
+// supportsIPv6 reports whether the host can listen on the IPv6 loopback.
+func supportsIPv6() bool {
+	lis, err := net.Listen("tcp6", "[::1]:0")
+	if err != nil {
+		return false
+	}
+	lis.Close()
+	return true
+}
+
+// checkDial dials the given host on the listener's port.
+func checkDial(t *testing.T, lis net.Listener, host string) {
+	port := strconv.Itoa(lis.Addr().(*net.TCPAddr).Port)
+	go func() {
+		if c, err := lis.Accept(); err == nil {
+			c.Close()
+		}
+	}()
+	c, err := net.Dial("tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		t.Errorf("Listen on %s: dialing %s failed: %v", lis.Addr(), host, err)
+		return
+	}
+	c.Close()
+}
+
+func TestListen_tcp4(t *testing.T) {
+	for _, address := range []string{"127.0.0.1:0", "0"} {
+		lis, err := Listen("tcp4", address)
+		if err != nil {
+			t.Fatalf("Listen(tcp4, %q): unexpected err %+v", address, err)
+		}
+		if lis.Addr().(*net.TCPAddr).IP.To4() == nil {
+			t.Errorf("Listen(tcp4, %q): want an IPv4 listener got %s", address, lis.Addr())
+		}
+		checkDial(t, lis, "127.0.0.1")
+		lis.Close()
+	}
+}
+
+func TestListen_tcp6(t *testing.T) {
+	if !supportsIPv6() {
+		t.Skip("IPv6 is not supported on this host")
+	}
+	lis, err := Listen("tcp6", "[::1]:0")
+	if err != nil {
+		t.Fatalf("Listen(tcp6): unexpected err %+v", err)
+	}
+	defer lis.Close()
+	if lis.Addr().(*net.TCPAddr).IP.To4() != nil {
+		t.Errorf("Listen(tcp6): want an IPv6 listener got %s", lis.Addr())
+	}
+	checkDial(t, lis, "::1")
+}
+
+func TestListen_tcp(t *testing.T) {
+	// The default network listens whether or not the host supports IPv6.
+	lis, err := Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("Listen(tcp): unexpected err %+v", err)
+	}
+	defer lis.Close()
+	checkDial(t, lis, "127.0.0.1")
+	if supportsIPv6() {
+		checkDial(t, lis, "::1")
+	}
+}
+
+func TestListen_dualStack(t *testing.T) {
+	lis, err := Listen(DualStackNetwork, ":0")
+	if !supportsIPv6() {
+		if err == nil {
+			lis.Close()
+			t.Fatal("Listen(tcp46): want an error when dual-stack is unsupported")
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("Listen(tcp46): unexpected err %+v", err)
+	}
+	defer lis.Close()
+	if lis.Addr().Network() != "tcp" {
+		t.Errorf("Listen(tcp46): want a tcp listener got %s", lis.Addr().Network())
+	}
+	checkDial(t, lis, "127.0.0.1")
+	checkDial(t, lis, "::1")
+}
+
+func TestListen_invalid(t *testing.T) {
+	tests := []struct {
+		network, address string
+	}{
+		{"udp", ":0"},
+		{"tcp", "[::1:0"},
+		{"tcp4", "[::1]:0"},
+		{"tcp6", "127.0.0.1:0"},
+		{"tcp4", "127.0.0.1:notaport"},
+	}
+	for _, test := range tests {
+		if lis, err := Listen(test.network, test.address); err == nil {
+			lis.Close()
+			t.Errorf("Listen(%s, %q): want err", test.network, test.address)
+		}
+	}
+}
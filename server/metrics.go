@@ -0,0 +1,68 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import "sync"
+
+var metricsSingleton = NewMetrics()
+
+// GetMetricsInstance returns the metrics singleton, which the Testing service
+// reports.
+func GetMetricsInstance() Metrics {
+	return metricsSingleton
+}
+
+// Metrics is a set of named integer values, such as counters and gauges, that
+// describe what the server is doing.
+type Metrics interface {
+	// Add adds delta to the named value, which starts at zero.
+	Add(name string, delta int64)
+	// Get returns the named value.
+	Get(name string) int64
+	// Snapshot returns a copy of all values.
+	Snapshot() map[string]int64
+}
+
+// NewMetrics returns an empty Metrics.
+func NewMetrics() Metrics {
+	return &metrics{values: map[string]int64{}}
+}
+
+type metrics struct {
+	mu     sync.Mutex
+	values map[string]int64
+}
+
+func (m *metrics) Add(name string, delta int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[name] += delta
+}
+
+func (m *metrics) Get(name string) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.values[name]
+}
+
+func (m *metrics) Snapshot() map[string]int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snapshot := make(map[string]int64, len(m.values))
+	for name, value := range m.values {
+		snapshot[name] = value
+	}
+	return snapshot
+}
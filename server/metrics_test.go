@@ -0,0 +1,48 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	m := NewMetrics()
+	m.Add("a", 2)
+	m.Add("a", -1)
+	m.Add("b", 5)
+	if got := m.Get("a"); got != 1 {
+		t.Errorf("Get(a): want 1 got %d", got)
+	}
+	if got := m.Get("missing"); got != 0 {
+		t.Errorf("Get(missing): want 0 got %d", got)
+	}
+
+	snapshot := m.Snapshot()
+	if want := map[string]int64{"a": 1, "b": 5}; !reflect.DeepEqual(snapshot, want) {
+		t.Errorf("Snapshot: want %v got %v", want, snapshot)
+	}
+	snapshot["a"] = 100
+	if got := m.Get("a"); got != 1 {
+		t.Errorf("Snapshot: want a copy, but modifying it changed the value to %d", got)
+	}
+}
+
+func TestGetMetricsInstance(t *testing.T) {
+	if GetMetricsInstance() != GetMetricsInstance() {
+		t.Error("GetMetricsInstance: want the same metrics on every call")
+	}
+}
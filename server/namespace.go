@@ -0,0 +1,136 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"regexp"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+)
+
+const (
+	// NamespaceHeader is the metadata key that selects the namespace of a
+	// call. Stateful stores keep the state of each namespace apart, so that
+	// tests sharing a server do not see each other's state.
+	NamespaceHeader = "showcase-namespace"
+
+	// DefaultNamespace is the namespace of calls without a NamespaceHeader.
+	DefaultNamespace = "default"
+)
+
+var namespacePattern = regexp.MustCompile("^[a-z0-9][a-z0-9-]{0,62}$")
+
+type namespaceKey struct{}
+
+// WithNamespace returns a copy of the context with the given namespace.
+func WithNamespace(ctx context.Context, namespace string) context.Context {
+	return context.WithValue(ctx, namespaceKey{}, namespace)
+}
+
+// NamespaceFromContext returns the namespace of the context, or the default
+// namespace if it has none.
+func NamespaceFromContext(ctx context.Context) string {
+	if namespace, ok := ctx.Value(namespaceKey{}).(string); ok {
+		return namespace
+	}
+	return DefaultNamespace
+}
+
+// ValidateNamespace returns an INVALID_ARGUMENT error if the namespace is not
+// 1 to 63 lowercase letters, digits and hyphens, starting with a letter or
+// digit.
+func ValidateNamespace(namespace string) error {
+	if !namespacePattern.MatchString(namespace) {
+		return showcaseerrors.Field(
+			showcaseerrors.FieldInvalid,
+			"namespace",
+			"The namespace %q must be 1 to 63 lowercase letters, digits and hyphens, "+
+				"starting with a letter or digit.",
+			namespace)
+	}
+	return nil
+}
+
+// namespaceFromMetadata returns a copy of the context with the namespace
+// given by its incoming metadata.
+func namespaceFromMetadata(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(NamespaceHeader)
+	if len(values) == 0 {
+		return WithNamespace(ctx, DefaultNamespace), nil
+	}
+	if len(values) > 1 {
+		return nil, showcaseerrors.Metadata(NamespaceHeader, "The %s metadata must be given at most once.", NamespaceHeader)
+	}
+	if err := ValidateNamespace(values[0]); err != nil {
+		return nil, err
+	}
+	return WithNamespace(ctx, values[0]), nil
+}
+
+// NamespaceUnaryInterceptor captures the namespace of a unary call into its
+// context.
+func NamespaceUnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := namespaceFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// NamespaceStreamInterceptor captures the namespace of a streaming call into
+// the context of its stream.
+func NamespaceStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	ctx, err := namespaceFromMetadata(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &namespacedStream{ServerStream: ss, ctx: ctx})
+}
+
+type namespacedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *namespacedStream) Context() context.Context {
+	return s.ctx
+}
+
+// namespacedName keys the state of a store by namespace.
+type namespacedName struct {
+	namespace string
+	name      string
+}
+
+// less orders names by namespace, then name.
+func (n namespacedName) less(o namespacedName) bool {
+	if n.namespace != o.namespace {
+		return n.namespace < o.namespace
+	}
+	return n.name < o.name
+}
@@ -0,0 +1,101 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestNamespaceFromContext(t *testing.T) {
+	if got := NamespaceFromContext(context.Background()); got != DefaultNamespace {
+		t.Errorf("NamespaceFromContext: want %q got %q", DefaultNamespace, got)
+	}
+	if got := NamespaceFromContext(WithNamespace(context.Background(), "a")); got != "a" {
+		t.Errorf("NamespaceFromContext: want %q got %q", "a", got)
+	}
+}
+
+func TestValidateNamespace(t *testing.T) {
+	for _, ns := range []string{"a", "team-1", "0"} {
+		if err := ValidateNamespace(ns); err != nil {
+			t.Errorf("ValidateNamespace(%q): unexpected err %+v", ns, err)
+		}
+	}
+	tooLong := string(make([]byte, 64))
+	for _, ns := range []string{"", "-a", "A", "a b", "a/b", tooLong} {
+		if err := ValidateNamespace(ns); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ValidateNamespace(%q): want InvalidArgument got %v", ns, err)
+		}
+	}
+}
+
+func TestNamespaceUnaryInterceptor(t *testing.T) {
+	tests := []struct {
+		md   metadata.MD
+		want string
+		code codes.Code
+	}{
+		{nil, DefaultNamespace, codes.OK},
+		{metadata.Pairs(NamespaceHeader, "team-a"), "team-a", codes.OK},
+		{metadata.Pairs(NamespaceHeader, "Team A"), "", codes.InvalidArgument},
+		{metadata.Pairs(NamespaceHeader, "a", NamespaceHeader, "b"), "", codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		ctx := metadata.NewIncomingContext(context.Background(), test.md)
+		got := ""
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			got = NamespaceFromContext(ctx)
+			return nil, nil
+		}
+		_, err := NamespaceUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+		if status.Code(err) != test.code || got != test.want {
+			t.Errorf("NamespaceUnaryInterceptor(%v): want (%q, %s) got (%q, %v)", test.md, test.want, test.code, got, err)
+		}
+	}
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestNamespaceStreamInterceptor(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(NamespaceHeader, "team-b"))
+	got := ""
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		got = NamespaceFromContext(ss.Context())
+		return nil
+	}
+	err := NamespaceStreamInterceptor(nil, &contextStream{ctx: ctx}, &grpc.StreamServerInfo{}, handler)
+	if err != nil || got != "team-b" {
+		t.Errorf("NamespaceStreamInterceptor: want team-b got %q, %v", got, err)
+	}
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(NamespaceHeader, "_"))
+	err = NamespaceStreamInterceptor(nil, &contextStream{ctx: ctx}, &grpc.StreamServerInfo{}, handler)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("NamespaceStreamInterceptor with an invalid namespace: want InvalidArgument got %v", err)
+	}
+}
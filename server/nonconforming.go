@@ -0,0 +1,32 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"github.com/golang/protobuf/proto"
+)
+
+// InvalidUTF8 is a pair of bytes that is not valid UTF-8.
+const InvalidUTF8 = "\xff\xfe"
+
+// AppendRawString appends a length-delimited field with the given number and
+// value to the wire bytes b. Unlike proto.Marshal, it does not check that the
+// value is valid UTF-8, so it can write string fields that break proto3.
+func AppendRawString(b []byte, field int, value string) []byte {
+	buf := proto.NewBuffer(b)
+	buf.EncodeVarint(uint64(field)<<3 | proto.WireBytes)
+	buf.EncodeStringBytes(value)
+	return buf.Bytes()
+}
@@ -0,0 +1,44 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+)
+
+func TestAppendRawString(t *testing.T) {
+	got := AppendRawString([]byte{0x10, 0x01}, 1, "hi"+InvalidUTF8)
+	want := []byte{0x10, 0x01, 0x0a, 0x04, 'h', 'i', 0xff, 0xfe}
+	if !bytes.Equal(got, want) {
+		t.Errorf("AppendRawString: want %x got %x", want, got)
+	}
+	if utf8.ValidString(InvalidUTF8) {
+		t.Errorf("InvalidUTF8: want invalid UTF-8")
+	}
+
+	// The field is what proto.Marshal would write for valid values.
+	valid, err := proto.Marshal(&pb.EchoResponse{Content: "hi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw := AppendRawString(nil, 1, "hi"); !bytes.Equal(raw, valid) {
+		t.Errorf("AppendRawString: want %x as marshaled got %x", valid, raw)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+const (
+	// OperationSweepsMetric counts the sweeps of the OperationCollector.
+	OperationSweepsMetric = "operation_sweeps"
+
+	// ExpiredOperationsMetric counts the operations the OperationCollector
+	// found expired.
+	ExpiredOperationsMetric = "expired_operations"
+)
+
+// idleSweepInterval is how often the OperationCollector checks for an
+// OperationTTL while none is set.
+const idleSweepInterval = time.Minute
+
+// OperationCollector forgets the recorded polls of operations that have been
+// done for longer than the OperationTTL setting.
+type OperationCollector struct {
+	recorder PollRecorder
+	settings SettingsStore
+	metrics  Metrics
+	doneTime func(name string) (time.Time, bool)
+	nowF     func() time.Time
+	afterF   func(time.Duration) <-chan time.Time
+}
+
+// NewOperationCollector returns an OperationCollector that sweeps the
+// recorder. doneTime returns when the named operation was done, or false if
+// it is pending or its done time is unknown; such operations never expire.
+func NewOperationCollector(
+	recorder PollRecorder,
+	settings SettingsStore,
+	metrics Metrics,
+	doneTime func(name string) (time.Time, bool),
+	nowF func() time.Time,
+	afterF func(time.Duration) <-chan time.Time) *OperationCollector {
+	return &OperationCollector{
+		recorder: recorder,
+		settings: settings,
+		metrics:  metrics,
+		doneTime: doneTime,
+		nowF:     nowF,
+		afterF:   afterF,
+	}
+}
+
+// Expired reports whether the named operation was done longer than the
+// OperationTTL ago.
+func (c *OperationCollector) Expired(name string) bool {
+	ttl := c.settings.Get().OperationTTL
+	if ttl <= 0 {
+		return false
+	}
+	done, ok := c.doneTime(name)
+	return ok && c.nowF().Sub(done) > ttl
+}
+
+// Sweep forgets the recorded polls of the expired operations and returns
+// their number.
+func (c *OperationCollector) Sweep() int {
+	expired := 0
+	for _, op := range c.recorder.List() {
+		if c.Expired(op.Name) {
+			c.recorder.Clear(op.Namespace, op.Name)
+			expired++
+		}
+	}
+	c.metrics.Add(OperationSweepsMetric, 1)
+	c.metrics.Add(ExpiredOperationsMetric, int64(expired))
+	return expired
+}
+
+// Start sweeps once every OperationTTL until the context is done. The
+// returned channel is closed once sweeping has stopped.
+func (c *OperationCollector) Start(ctx context.Context) <-chan struct{} {
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			interval := c.settings.Get().OperationTTL
+			if interval <= 0 {
+				interval = idleSweepInterval
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-c.afterF(interval):
+			}
+			if n := c.Sweep(); n > 0 {
+				log.Printf("Showcase expired %d operations.", n)
+			}
+		}
+	}()
+	return stopped
+}
@@ -0,0 +1,131 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock whose timers fire when the test says so.
+type fakeClock struct {
+	now    time.Time
+	waits  chan time.Duration
+	alarms chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(1000, 0), waits: make(chan time.Duration, 10), alarms: make(chan time.Time)}
+}
+
+func (c *fakeClock) nowF() time.Time { return c.now }
+
+func (c *fakeClock) afterF(d time.Duration) <-chan time.Time {
+	c.waits <- d
+	return c.alarms
+}
+
+func TestOperationCollector_sweep(t *testing.T) {
+	clock := newFakeClock()
+	recorder := NewPollRecorder(clock.nowF)
+	settings := DefaultSettings()
+	settings.OperationTTL = time.Minute
+	store := NewSettingsStore(settings)
+	metrics := NewMetrics()
+	doneTimes := map[string]time.Time{
+		"operations/old":    clock.now.Add(-2 * time.Minute),
+		"operations/recent": clock.now.Add(-30 * time.Second),
+		"operations/future": clock.now.Add(time.Hour),
+	}
+	doneTime := func(name string) (time.Time, bool) {
+		t, ok := doneTimes[name]
+		return t, ok
+	}
+	c := NewOperationCollector(recorder, store, metrics, doneTime, clock.nowF, clock.afterF)
+	for _, name := range []string{"operations/old", "operations/recent", "operations/future", "operations/pending"} {
+		recorder.Record("a", name)
+		recorder.Record("b", name)
+	}
+
+	if n := c.Sweep(); n != 2 {
+		t.Errorf("Sweep: want the old operation expired in both namespaces got %d", n)
+	}
+	for _, ns := range []string{"a", "b"} {
+		if len(recorder.Polls(ns, "operations/old")) != 0 {
+			t.Errorf("Sweep: want the polls of %s/operations/old forgotten", ns)
+		}
+		if len(recorder.Polls(ns, "operations/recent")) != 1 || len(recorder.Polls(ns, "operations/pending")) != 1 {
+			t.Errorf("Sweep: want the polls of operations that have not expired kept got %v", recorder.List())
+		}
+	}
+
+	// An hour later only pending operations and those done in the future
+	// remain.
+	clock.now = clock.now.Add(time.Hour)
+	if n := c.Sweep(); n != 2 {
+		t.Errorf("Sweep: want the recent operation expired in both namespaces got %d", n)
+	}
+	if !c.Expired("operations/recent") || c.Expired("operations/future") || c.Expired("operations/pending") {
+		t.Errorf("Expired: want only operations done over a minute ago expired")
+	}
+	if got := metrics.Get(OperationSweepsMetric); got != 2 {
+		t.Errorf("Sweep: want 2 sweeps counted got %d", got)
+	}
+	if got := metrics.Get(ExpiredOperationsMetric); got != 4 {
+		t.Errorf("Sweep: want 4 expired operations counted got %d", got)
+	}
+
+	settings.OperationTTL = 0
+	store.Set(settings)
+	if c.Expired("operations/old") {
+		t.Errorf("Expired: want nothing expired without a TTL")
+	}
+}
+
+func TestOperationCollector_start(t *testing.T) {
+	clock := newFakeClock()
+	recorder := NewPollRecorder(clock.nowF)
+	store := NewSettingsStore(DefaultSettings())
+	metrics := NewMetrics()
+	doneTime := func(string) (time.Time, bool) { return clock.now.Add(-time.Hour), true }
+	c := NewOperationCollector(recorder, store, metrics, doneTime, clock.nowF, clock.afterF)
+	recorder.Record("a", "operations/done")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := c.Start(ctx)
+
+	// Without a TTL the collector only checks for one now and then.
+	if d := <-clock.waits; d != idleSweepInterval {
+		t.Errorf("Start: want a wait of %s without a TTL got %s", idleSweepInterval, d)
+	}
+	settings := DefaultSettings()
+	settings.OperationTTL = time.Minute
+	store.Set(settings)
+	clock.alarms <- clock.now
+	if d := <-clock.waits; d != time.Minute {
+		t.Errorf("Start: want a wait of the TTL got %s", d)
+	}
+	if len(recorder.Polls("a", "operations/done")) != 0 || metrics.Get(ExpiredOperationsMetric) != 1 {
+		t.Errorf("Start: want the done operation swept got %v", recorder.List())
+	}
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Start: want sweeping to stop once the context is done")
+	}
+}
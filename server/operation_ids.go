@@ -0,0 +1,97 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+)
+
+// MaxOperationIDs is the most operation IDs the OperationIDStore singleton
+// keeps.
+const MaxOperationIDs = 10000
+
+var operationIDStoreSingleton = NewOperationIDStore(MaxOperationIDs)
+
+// GetOperationIDStoreInstance returns the operation ID store singleton.
+func GetOperationIDStoreInstance() OperationIDStore {
+	return operationIDStoreSingleton
+}
+
+// OperationIDStore holds the operations started with client-chosen IDs, so
+// that retried calls return the operation of the first call instead of
+// starting another.
+type OperationIDStore interface {
+	// Register records the named operation as started under the ID by the
+	// request with the hash, unless the ID is already registered. It returns
+	// the operation registered first and whether its request had the same
+	// hash.
+	Register(namespace, id, hash, name string) (registered string, same bool)
+
+	// PurgeNamespace removes all IDs of the namespace, and returns how many
+	// it removed.
+	PurgeNamespace(namespace string) int
+}
+
+// NewOperationIDStore returns an empty OperationIDStore that holds at most
+// maxIDs IDs, forgetting the oldest first.
+func NewOperationIDStore(maxIDs int) OperationIDStore {
+	return &operationIDStore{maxIDs: maxIDs, ops: map[namespacedName]registeredOperation{}}
+}
+
+type operationIDStore struct {
+	maxIDs int
+
+	mu    sync.Mutex
+	ops   map[namespacedName]registeredOperation
+	order []namespacedName
+}
+
+type registeredOperation struct {
+	hash string
+	name string
+}
+
+func (s *operationIDStore) Register(namespace, id, hash, name string) (string, bool) {
+	defer ChangeState()()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k := namespacedName{namespace, id}
+	if op, ok := s.ops[k]; ok {
+		return op.name, op.hash == hash
+	}
+	for len(s.order) > 0 && len(s.ops) >= s.maxIDs {
+		delete(s.ops, s.order[0])
+		s.order = s.order[1:]
+	}
+	s.ops[k] = registeredOperation{hash: hash, name: name}
+	s.order = append(s.order, k)
+	return name, true
+}
+
+func (s *operationIDStore) PurgeNamespace(namespace string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := len(s.order)
+	order := s.order[:0]
+	for _, k := range s.order {
+		if k.namespace == namespace {
+			delete(s.ops, k)
+			continue
+		}
+		order = append(order, k)
+	}
+	s.order = order
+	return n - len(order)
+}
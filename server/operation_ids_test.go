@@ -0,0 +1,49 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+)
+
+func TestOperationIDStore(t *testing.T) {
+	s := NewOperationIDStore(2)
+	if name, same := s.Register("a", "id", "h1", "operations/1"); name != "operations/1" || !same {
+		t.Errorf("Register of a new ID: want operations/1 got %q %t", name, same)
+	}
+	if name, same := s.Register("a", "id", "h1", "operations/2"); name != "operations/1" || !same {
+		t.Errorf("Register of the same request: want the first operation got %q %t", name, same)
+	}
+	if name, same := s.Register("a", "id", "h2", "operations/3"); name != "operations/1" || same {
+		t.Errorf("Register of a different request: want a conflict with the first operation got %q %t", name, same)
+	}
+	if name, same := s.Register("b", "id", "h2", "operations/4"); name != "operations/4" || !same {
+		t.Errorf("Register in another namespace: want operations/4 got %q %t", name, same)
+	}
+
+	// The store is full, so the oldest ID is forgotten.
+	s.Register("b", "other", "h", "operations/5")
+	if name, _ := s.Register("a", "id", "h2", "operations/6"); name != "operations/6" {
+		t.Errorf("Register after eviction: want operations/6 got %q", name)
+	}
+
+	s.PurgeNamespace("b")
+	if name, _ := s.Register("b", "id", "h1", "operations/7"); name != "operations/7" {
+		t.Errorf("Register after PurgeNamespace: want operations/7 got %q", name)
+	}
+	if name, _ := s.Register("a", "id", "h1", "operations/8"); name != "operations/6" {
+		t.Errorf("PurgeNamespace: want namespace a kept got %q", name)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+)
+
+var operationWatchersSingleton = NewOperationWatchers()
+
+// GetOperationWatchersInstance returns the operation watchers singleton.
+func GetOperationWatchersInstance() OperationWatchers {
+	return operationWatchersSingleton
+}
+
+// OperationWatchers tell the streams of an operation's updates when it is
+// deleted. Deleting an operation never waits for its streams.
+type OperationWatchers interface {
+	// Watch returns a channel that is closed once the named operation of the
+	// namespace is deleted, and the func that stops watching it.
+	Watch(namespace, name string) (deleted <-chan struct{}, stop func())
+
+	// Delete closes the channels of the operation's watchers, and returns
+	// how many there were.
+	Delete(namespace, name string) int
+}
+
+// NewOperationWatchers returns OperationWatchers without any watchers.
+func NewOperationWatchers() OperationWatchers {
+	return &operationWatchers{watchers: map[namespacedName]map[chan struct{}]bool{}}
+}
+
+type operationWatchers struct {
+	mu       sync.Mutex
+	watchers map[namespacedName]map[chan struct{}]bool
+}
+
+func (w *operationWatchers) Watch(namespace, name string) (<-chan struct{}, func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	k := namespacedName{namespace, name}
+	if w.watchers[k] == nil {
+		w.watchers[k] = map[chan struct{}]bool{}
+	}
+	deleted := make(chan struct{})
+	w.watchers[k][deleted] = true
+	stop := func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.watchers[k], deleted)
+		if len(w.watchers[k]) == 0 {
+			delete(w.watchers, k)
+		}
+	}
+	return deleted, stop
+}
+
+func (w *operationWatchers) Delete(namespace, name string) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	k := namespacedName{namespace, name}
+	watchers := w.watchers[k]
+	for deleted := range watchers {
+		close(deleted)
+	}
+	delete(w.watchers, k)
+	return len(watchers)
+}
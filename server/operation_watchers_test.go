@@ -0,0 +1,47 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import "testing"
+
+func TestOperationWatchers(t *testing.T) {
+	w := NewOperationWatchers()
+	a, stopA := w.Watch("ns", "operations/a")
+	b, stopB := w.Watch("ns", "operations/a")
+	other, stopOther := w.Watch("other", "operations/a")
+	defer stopOther()
+	stopB()
+
+	if n := w.Delete("ns", "operations/a"); n != 1 {
+		t.Errorf("Delete: want 1 watcher told, got %d", n)
+	}
+	select {
+	case <-a:
+	default:
+		t.Errorf("Delete: want the watcher's channel closed")
+	}
+	select {
+	case <-b:
+		t.Errorf("Delete: want a stopped watcher's channel left open")
+	case <-other:
+		t.Errorf("Delete: want the watcher of another namespace's channel left open")
+	default:
+	}
+	// Stopping after the deletion is harmless.
+	stopA()
+	if n := w.Delete("ns", "operations/a"); n != 0 {
+		t.Errorf("Delete again: want no watchers told, got %d", n)
+	}
+}
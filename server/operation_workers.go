@@ -0,0 +1,207 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// OperationQueueDepthMetric is the number of operations waiting for a
+	// worker of a WorkerOperationStore.
+	OperationQueueDepthMetric = "operation_queue_depth"
+
+	// OperationsInProgressMetric is the number of operations the workers of
+	// a WorkerOperationStore are completing.
+	OperationsInProgressMetric = "operations_in_progress"
+
+	// OperationsCompletedMetric counts the operations the workers of a
+	// WorkerOperationStore have completed.
+	OperationsCompletedMetric = "operations_completed"
+)
+
+var operationWorkersSingleton *WorkerOperationStore
+
+// UseOperationWorkers makes the waiter singleton complete Wait operations
+// with a WorkerOperationStore of the given parallelism, which it returns
+// for the server to shut down. It should be called before the services are
+// created, as they take the waiter when they are.
+func UseOperationWorkers(parallelism int) *WorkerOperationStore {
+	operationWorkersSingleton = NewWorkerOperationStore(parallelism, metricsSingleton, Now, time.After)
+	waiterSingleton = NewWorkerWaiter(Now, settingsSingleton, operationWorkersSingleton)
+	return operationWorkersSingleton
+}
+
+// GetOperationWorkersInstance returns the WorkerOperationStore of the waiter
+// singleton, or nil if operations complete at their end time.
+func GetOperationWorkersInstance() *WorkerOperationStore {
+	return operationWorkersSingleton
+}
+
+// WorkerOperationStore completes operations with a bounded pool of workers,
+// rather than as soon as their end time passes. Started operations wait in a
+// queue, in order, for a worker, which takes the work duration of the
+// operation to complete it. So operations complete whether or not they are
+// polled, and those queued behind a busy pool complete late.
+type WorkerOperationStore struct {
+	metrics Metrics
+	nowF    func() time.Time
+	afterF  func(time.Duration) <-chan time.Time
+
+	mu       sync.Mutex
+	queued   *sync.Cond
+	ops      map[string]*workerOperation
+	queue    []*workerOperation
+	shutdown bool
+
+	// stop is closed by Shutdown, which waits for the workers to return.
+	stop    chan struct{}
+	workers sync.WaitGroup
+}
+
+type workerOperation struct {
+	name string
+	work time.Duration
+
+	// done is closed when the operation completes, at doneTime.
+	done     chan struct{}
+	doneTime time.Time
+}
+
+// NewWorkerOperationStore returns a WorkerOperationStore whose parallelism
+// workers wait out the work of operations with afterF, and report their
+// progress to the metrics. The workers run until Shutdown.
+func NewWorkerOperationStore(
+	parallelism int,
+	metrics Metrics,
+	nowF func() time.Time,
+	afterF func(time.Duration) <-chan time.Time) *WorkerOperationStore {
+	s := &WorkerOperationStore{
+		metrics: metrics,
+		nowF:    nowF,
+		afterF:  afterF,
+		ops:     map[string]*workerOperation{},
+		stop:    make(chan struct{}),
+	}
+	s.queued = sync.NewCond(&s.mu)
+	for i := 0; i < parallelism; i++ {
+		s.workers.Add(1)
+		go s.work()
+	}
+	return s
+}
+
+// Start queues the named operation, which takes work to complete, unless it
+// has been started already. Operations started after Shutdown never
+// complete.
+func (s *WorkerOperationStore) Start(name string, work time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.ops[name]; ok {
+		return
+	}
+	op := &workerOperation{name: name, work: work, done: make(chan struct{})}
+	s.ops[name] = op
+	s.queue = append(s.queue, op)
+	s.metrics.Add(OperationQueueDepthMetric, 1)
+	s.queued.Signal()
+}
+
+// DoneTime returns when the named operation completed, or false if it has
+// not completed or was never started.
+func (s *WorkerOperationStore) DoneTime(name string) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	op, ok := s.ops[name]
+	if !ok {
+		return time.Time{}, false
+	}
+	select {
+	case <-op.done:
+		return op.doneTime, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// Done returns a channel that is closed when the named operation completes,
+// or nil if it was never started.
+func (s *WorkerOperationStore) Done(name string) <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if op, ok := s.ops[name]; ok {
+		return op.done
+	}
+	return nil
+}
+
+// Shutdown stops the workers and waits for them to return. Operations that
+// completed stay complete; those queued or in progress stay pending.
+func (s *WorkerOperationStore) Shutdown() {
+	s.mu.Lock()
+	if !s.shutdown {
+		s.shutdown = true
+		close(s.stop)
+		s.queued.Broadcast()
+	}
+	s.mu.Unlock()
+	s.workers.Wait()
+}
+
+func (s *WorkerOperationStore) work() {
+	defer s.workers.Done()
+	for {
+		op, ok := s.next()
+		if !ok {
+			return
+		}
+		select {
+		case <-s.afterF(op.work):
+			s.complete(op)
+		case <-s.stop:
+			s.metrics.Add(OperationsInProgressMetric, -1)
+			return
+		}
+	}
+}
+
+// next takes the first queued operation, waiting for one, or returns false
+// once the store is shut down.
+func (s *WorkerOperationStore) next() (*workerOperation, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(s.queue) == 0 && !s.shutdown {
+		s.queued.Wait()
+	}
+	if s.shutdown {
+		return nil, false
+	}
+	op := s.queue[0]
+	s.queue[0] = nil
+	s.queue = s.queue[1:]
+	s.metrics.Add(OperationQueueDepthMetric, -1)
+	s.metrics.Add(OperationsInProgressMetric, 1)
+	return op, true
+}
+
+func (s *WorkerOperationStore) complete(op *workerOperation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	op.doneTime = s.nowF()
+	close(op.done)
+	s.metrics.Add(OperationsInProgressMetric, -1)
+	s.metrics.Add(OperationsCompletedMetric, 1)
+}
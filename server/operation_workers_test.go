@@ -0,0 +1,178 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+// nextWork returns the work of the operation a worker takes next, failing
+// the test if none does.
+func nextWork(t *testing.T, clock *fakeClock) time.Duration {
+	t.Helper()
+	select {
+	case d := <-clock.waits:
+		return d
+	case <-time.After(time.Second):
+		t.Fatal("want a worker to take an operation")
+		return 0
+	}
+}
+
+// complete ends the work in progress, advancing the clock by work, and waits
+// for the named operation to be done.
+func complete(t *testing.T, s *WorkerOperationStore, clock *fakeClock, name string, work time.Duration) {
+	t.Helper()
+	clock.now = clock.now.Add(work)
+	clock.alarms <- clock.now
+	select {
+	case <-s.Done(name):
+	case <-time.After(time.Second):
+		t.Fatalf("want %s done", name)
+	}
+}
+
+func checkWorkerMetrics(t *testing.T, metrics Metrics, queued, inProgress, completed int64) {
+	t.Helper()
+	if got := metrics.Get(OperationQueueDepthMetric); got != queued {
+		t.Errorf("%s: want %d got %d", OperationQueueDepthMetric, queued, got)
+	}
+	if got := metrics.Get(OperationsInProgressMetric); got != inProgress {
+		t.Errorf("%s: want %d got %d", OperationsInProgressMetric, inProgress, got)
+	}
+	if got := metrics.Get(OperationsCompletedMetric); got != completed {
+		t.Errorf("%s: want %d got %d", OperationsCompletedMetric, completed, got)
+	}
+}
+
+func TestWorkerOperationStore_serial(t *testing.T) {
+	clock := newFakeClock()
+	metrics := NewMetrics()
+	s := NewWorkerOperationStore(1, metrics, clock.nowF, clock.afterF)
+	defer s.Shutdown()
+
+	start := clock.now
+	works := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}
+	names := []string{"operations/a", "operations/b", "operations/c"}
+	for i, name := range names {
+		s.Start(name, works[i])
+	}
+	// Starting an operation again neither queues nor delays it.
+	s.Start("operations/a", time.Hour)
+
+	var elapsed time.Duration
+	for i, name := range names {
+		if d := nextWork(t, clock); d != works[i] {
+			t.Fatalf("Start: want %s worked on for %s got %s", name, works[i], d)
+		}
+		checkWorkerMetrics(t, metrics, int64(len(names)-i-1), 1, int64(i))
+		if _, ok := s.DoneTime(name); ok {
+			t.Errorf("DoneTime(%s): want it pending while in progress", name)
+		}
+		// Nothing else is worked on until the operation completes.
+		select {
+		case d := <-clock.waits:
+			t.Fatalf("Start: want one operation at a time got a second one of %s", d)
+		default:
+		}
+
+		complete(t, s, clock, name, works[i])
+		elapsed += works[i]
+		if got, ok := s.DoneTime(name); !ok || !got.Equal(start.Add(elapsed)) {
+			t.Errorf("DoneTime(%s): want %s after the queued work got %s, %t", name, start.Add(elapsed), got, ok)
+		}
+	}
+	checkWorkerMetrics(t, metrics, 0, 0, 3)
+
+	if _, ok := s.DoneTime("operations/unknown"); ok {
+		t.Errorf("DoneTime: want unstarted operations pending")
+	}
+	if s.Done("operations/unknown") != nil {
+		t.Errorf("Done: want nil for unstarted operations")
+	}
+}
+
+func TestWorkerOperationStore_parallel(t *testing.T) {
+	clock := newFakeClock()
+	metrics := NewMetrics()
+	s := NewWorkerOperationStore(2, metrics, clock.nowF, clock.afterF)
+	defer s.Shutdown()
+
+	for _, name := range []string{"operations/a", "operations/b", "operations/c"} {
+		s.Start(name, time.Second)
+	}
+	nextWork(t, clock)
+	nextWork(t, clock)
+	checkWorkerMetrics(t, metrics, 1, 2, 0)
+}
+
+func TestWorkerOperationStore_shutdown(t *testing.T) {
+	clock := newFakeClock()
+	metrics := NewMetrics()
+	s := NewWorkerOperationStore(1, metrics, clock.nowF, clock.afterF)
+
+	for _, name := range []string{"operations/a", "operations/b", "operations/c"} {
+		s.Start(name, time.Second)
+	}
+	nextWork(t, clock)
+	complete(t, s, clock, "operations/a", time.Second)
+	doneTime, _ := s.DoneTime("operations/a")
+	nextWork(t, clock)
+
+	stopped := make(chan struct{})
+	go func() {
+		s.Shutdown()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Shutdown: want the workers stopped without finishing their work")
+	}
+	// Shutting down again does nothing.
+	s.Shutdown()
+
+	if got, ok := s.DoneTime("operations/a"); !ok || !got.Equal(doneTime) {
+		t.Errorf("DoneTime: want the completed operation kept done at %s got %s, %t", doneTime, got, ok)
+	}
+	for _, name := range []string{"operations/b", "operations/c"} {
+		if _, ok := s.DoneTime(name); ok {
+			t.Errorf("DoneTime(%s): want unfinished operations kept pending", name)
+		}
+	}
+	s.Start("operations/d", time.Second)
+	if _, ok := s.DoneTime("operations/d"); ok {
+		t.Errorf("DoneTime: want operations started after Shutdown pending")
+	}
+	checkWorkerMetrics(t, metrics, 2, 0, 1)
+}
+
+func TestUseOperationWorkers(t *testing.T) {
+	waiter, workers := waiterSingleton, operationWorkersSingleton
+	defer func() { waiterSingleton, operationWorkersSingleton = waiter, workers }()
+
+	if GetOperationWorkersInstance() != nil {
+		t.Fatal("GetOperationWorkersInstance: want no workers by default")
+	}
+	s := UseOperationWorkers(1)
+	defer s.Shutdown()
+	if GetOperationWorkersInstance() != s {
+		t.Errorf("GetOperationWorkersInstance: want the workers in use")
+	}
+	if w, ok := GetWaiterInstance().(*waiterImpl); !ok || w.workers != s {
+		t.Errorf("GetWaiterInstance: want a waiter completing operations with the workers")
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// OrderKey is a term of an order_by: a field to sort by, and the direction.
+type OrderKey struct {
+	Field string
+	Desc  bool
+}
+
+// OrderBySyntaxError is an order_by that does not parse, with the byte
+// offset of the token at fault.
+type OrderBySyntaxError struct {
+	Offset  int
+	Message string
+}
+
+func (e *OrderBySyntaxError) Error() string {
+	return fmt.Sprintf("offset %d: %s", e.Offset, e.Message)
+}
+
+// ParseOrderBy parses an AIP-132 order_by: a comma-separated list of the
+// given fields, each optionally followed by "asc" or "desc". Whitespace
+// around the tokens is ignored. An empty order_by has no keys. A field may
+// appear only once. Errors are OrderBySyntaxErrors.
+func ParseOrderBy(orderBy string, fields []string) ([]OrderKey, error) {
+	known := map[string]bool{}
+	for _, f := range fields {
+		known[f] = true
+	}
+	tokens := orderByTokens(orderBy)
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	keys := []OrderKey{}
+	seen := map[string]bool{}
+	for i := 0; i < len(tokens); {
+		t := tokens[i]
+		if t.text == "," {
+			return nil, &OrderBySyntaxError{t.offset, "expected a field but found `,`"}
+		}
+		if !known[t.text] {
+			return nil, &OrderBySyntaxError{t.offset, fmt.Sprintf("unknown field `%s`, the fields are %s", t.text, strings.Join(fields, ", "))}
+		}
+		if seen[t.text] {
+			return nil, &OrderBySyntaxError{t.offset, fmt.Sprintf("the field `%s` is repeated", t.text)}
+		}
+		seen[t.text] = true
+		key := OrderKey{Field: t.text}
+		i++
+		if i < len(tokens) && (tokens[i].text == "asc" || tokens[i].text == "desc") {
+			key.Desc = tokens[i].text == "desc"
+			i++
+		}
+		keys = append(keys, key)
+		if i == len(tokens) {
+			break
+		}
+		if tokens[i].text != "," {
+			return nil, &OrderBySyntaxError{tokens[i].offset, fmt.Sprintf("expected `,` but found `%s`", tokens[i].text)}
+		}
+		i++
+		if i == len(tokens) {
+			return nil, &OrderBySyntaxError{len(orderBy), "expected a field after `,`"}
+		}
+	}
+	return keys, nil
+}
+
+type orderByToken struct {
+	text   string
+	offset int
+}
+
+// orderByTokens splits an order_by into words and commas.
+func orderByTokens(s string) []orderByToken {
+	tokens := []orderByToken{}
+	start := -1
+	for i, r := range s + " " {
+		if r == ',' || r == ' ' || r == '\t' || r == '\n' {
+			if start >= 0 {
+				tokens = append(tokens, orderByToken{s[start:i], start})
+				start = -1
+			}
+			if r == ',' {
+				tokens = append(tokens, orderByToken{",", i})
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	return tokens
+}
+
+// FormatOrderBy returns the canonical order_by of the keys, such as
+// "length desc,index".
+func FormatOrderBy(keys []OrderKey) string {
+	terms := make([]string, len(keys))
+	for i, k := range keys {
+		terms[i] = k.Field
+		if k.Desc {
+			terms[i] += " desc"
+		}
+	}
+	return strings.Join(terms, ",")
+}
+
+// OrderedIndices returns the indices of n items in the order of the keys.
+// compare compares the values of a field for items i and j, returning a
+// negative number, zero or a positive number. Items that tie on every key
+// keep their order, so the order is the same for every call.
+func OrderedIndices(n int, keys []OrderKey, compare func(field string, i, j int) int) []int {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		for _, k := range keys {
+			c := compare(k.Field, order[a], order[b])
+			if k.Desc {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+	return order
+}
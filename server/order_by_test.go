@@ -0,0 +1,99 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseOrderBy(t *testing.T) {
+	fields := []string{"index", "length"}
+	tests := []struct {
+		in   string
+		want []OrderKey
+	}{
+		{"", nil},
+		{"  ", nil},
+		{"index", []OrderKey{{"index", false}}},
+		{"index asc", []OrderKey{{"index", false}}},
+		{"index desc", []OrderKey{{"index", true}}},
+		{"length desc,index", []OrderKey{{"length", true}, {"index", false}}},
+		{" length  desc ,  index asc ", []OrderKey{{"length", true}, {"index", false}}},
+	}
+	for _, test := range tests {
+		got, err := ParseOrderBy(test.in, fields)
+		if err != nil {
+			t.Errorf("ParseOrderBy(%q): unexpected err %v", test.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ParseOrderBy(%q): want %v got %v", test.in, test.want, got)
+		}
+	}
+}
+
+func TestParseOrderBy_invalid(t *testing.T) {
+	fields := []string{"index", "length"}
+	tests := []struct {
+		in         string
+		wantOffset int
+		wantMsg    string
+	}{
+		{"size", 0, "unknown field `size`"},
+		{"index, size desc", 7, "unknown field `size`"},
+		{",index", 0, "expected a field"},
+		{"index,", 6, "expected a field after"},
+		{"index desc desc", 11, "expected `,` but found `desc`"},
+		{"index length", 6, "expected `,` but found `length`"},
+		{"index,,length", 6, "expected a field"},
+		{"index, index desc", 7, "`index` is repeated"},
+		{"desc", 0, "unknown field `desc`"},
+	}
+	for _, test := range tests {
+		_, err := ParseOrderBy(test.in, fields)
+		syntax, ok := err.(*OrderBySyntaxError)
+		if !ok {
+			t.Errorf("ParseOrderBy(%q): want an OrderBySyntaxError got %v", test.in, err)
+			continue
+		}
+		if syntax.Offset != test.wantOffset || !strings.Contains(syntax.Message, test.wantMsg) {
+			t.Errorf("ParseOrderBy(%q): want %q at offset %d got %v", test.in, test.wantMsg, test.wantOffset, err)
+		}
+	}
+}
+
+func TestFormatOrderBy(t *testing.T) {
+	keys, _ := ParseOrderBy(" length  desc ,  index asc ", []string{"index", "length"})
+	if got := FormatOrderBy(keys); got != "length desc,index" {
+		t.Errorf("FormatOrderBy: want %q got %q", "length desc,index", got)
+	}
+}
+
+func TestOrderedIndices(t *testing.T) {
+	values := []int{3, 1, 3, 2, 1}
+	compare := func(_ string, i, j int) int { return values[i] - values[j] }
+	if got, want := OrderedIndices(5, []OrderKey{{"v", false}}, compare), []int{1, 4, 3, 0, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("OrderedIndices(asc): want %v got %v", want, got)
+	}
+	// Ties keep their order when descending too.
+	if got, want := OrderedIndices(5, []OrderKey{{"v", true}}, compare), []int{0, 2, 3, 1, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("OrderedIndices(desc): want %v got %v", want, got)
+	}
+	if got, want := OrderedIndices(3, nil, compare), []int{0, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("OrderedIndices without keys: want %v got %v", want, got)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var overloadLimiterSingleton = NewOverloadLimiter(Now)
+
+// GetOverloadLimiterInstance returns the overload limiter singleton.
+func GetOverloadLimiterInstance() OverloadLimiter {
+	return overloadLimiterSingleton
+}
+
+// OverloadLimiter simulates overloaded methods by rejecting calls beyond a
+// configured rate with RESOURCE_EXHAUSTED.
+type OverloadLimiter interface {
+	// SetLimit limits the method, given by its full gRPC name, to qps calls
+	// per second. Rejected calls carry a RetryInfo with the given delay. A
+	// qps of zero or less removes the limit.
+	SetLimit(method string, qps float64, retryDelay time.Duration)
+
+	// UnaryInterceptor implements the grpc.UnaryServerInterceptor type.
+	UnaryInterceptor(
+		context.Context,
+		interface{},
+		*grpc.UnaryServerInfo,
+		grpc.UnaryHandler) (interface{}, error)
+
+	// StreamInterceptor implements the grpc.StreamServerInterceptor type.
+	StreamInterceptor(
+		interface{},
+		grpc.ServerStream,
+		*grpc.StreamServerInfo,
+		grpc.StreamHandler) error
+}
+
+// NewOverloadLimiter returns an OverloadLimiter that measures time with the
+// given clock. The clock should be monotonic, as Now is.
+func NewOverloadLimiter(nowF func() time.Time) OverloadLimiter {
+	return &overloadLimiter{
+		nowF:    nowF,
+		buckets: map[string]*tokenBucket{},
+	}
+}
+
+type overloadLimiter struct {
+	nowF func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+// tokenBucket holds up to one second of calls, refilled at qps tokens per
+// second. It always holds at least one token, so that a limit below one call
+// per second still admits a call once the bucket has refilled.
+type tokenBucket struct {
+	qps        float64
+	capacity   float64
+	tokens     float64
+	retryDelay time.Duration
+	last       time.Time
+}
+
+func (l *overloadLimiter) SetLimit(method string, qps float64, retryDelay time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if qps <= 0 {
+		delete(l.buckets, method)
+		return
+	}
+	capacity := qps
+	if capacity < 1 {
+		capacity = 1
+	}
+	l.buckets[method] = &tokenBucket{
+		qps:        qps,
+		capacity:   capacity,
+		tokens:     capacity,
+		retryDelay: retryDelay,
+		last:       l.nowF(),
+	}
+}
+
+// admit takes a token for the method, returning an error if the method is
+// over its limit.
+func (l *overloadLimiter) admit(method string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[method]
+	if !ok {
+		return nil
+	}
+	now := l.nowF()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.qps
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return nil
+	}
+	st, _ := status.New(
+		codes.ResourceExhausted,
+		"The method is overloaded, retry after the delay in the RetryInfo.").WithDetails(
+		&errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(b.retryDelay)})
+	return st.Err()
+}
+
+func (l *overloadLimiter) UnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	if err := l.admit(info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (l *overloadLimiter) StreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	if err := l.admit(info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
@@ -0,0 +1,165 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const overloadedMethod = "/google.showcase.v1beta1.Echo/Echo"
+
+func TestGetOverloadLimiterInstance(t *testing.T) {
+	if GetOverloadLimiterInstance() != overloadLimiterSingleton {
+		t.Error("GetOverloadLimiterInstance: Expected to get overload limiter singleton.")
+	}
+}
+
+// callUnary makes n calls of the method through the limiter and returns the
+// number accepted and the last rejection.
+func callUnary(l OverloadLimiter, method string, n int) (int, error) {
+	accepted := 0
+	var rejection error
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		accepted++
+		return req, nil
+	}
+	for i := 0; i < n; i++ {
+		_, err := l.UnaryInterceptor(context.Background(), "req", &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		if err != nil {
+			rejection = err
+		}
+	}
+	return accepted, rejection
+}
+
+func TestOverloadLimiter(t *testing.T) {
+	now := time.Unix(100, 0)
+	l := NewOverloadLimiter(func() time.Time { return now })
+	l.SetLimit(overloadedMethod, 5, 2*time.Second)
+
+	// A burst admits one second's worth of calls.
+	accepted, err := callUnary(l, overloadedMethod, 8)
+	if accepted != 5 {
+		t.Errorf("OverloadLimiter: want 5 of 8 calls accepted got %d", accepted)
+	}
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("OverloadLimiter: want ResourceExhausted got %v", err)
+	}
+	if len(st.Details()) != 1 {
+		t.Fatalf("OverloadLimiter: want a RetryInfo got %v", st.Details())
+	}
+	info, ok := st.Details()[0].(*errdetails.RetryInfo)
+	if !ok {
+		t.Fatalf("OverloadLimiter: want a RetryInfo got %v", st.Details()[0])
+	}
+	if d, _ := ptypes.Duration(info.GetRetryDelay()); d != 2*time.Second {
+		t.Errorf("OverloadLimiter: want a retry delay of 2s got %s", d)
+	}
+
+	// Other methods are not limited.
+	if accepted, _ := callUnary(l, "/google.showcase.v1beta1.Echo/Expand", 8); accepted != 8 {
+		t.Errorf("OverloadLimiter: want other methods unlimited, got %d of 8 accepted", accepted)
+	}
+
+	// Tokens refill at the limit.
+	now = now.Add(400 * time.Millisecond)
+	if accepted, _ := callUnary(l, overloadedMethod, 5); accepted != 2 {
+		t.Errorf("OverloadLimiter: want 2 calls accepted after 400ms got %d", accepted)
+	}
+	now = now.Add(time.Hour)
+	if accepted, _ := callUnary(l, overloadedMethod, 8); accepted != 5 {
+		t.Errorf("OverloadLimiter: want the bucket capped at 5 calls got %d", accepted)
+	}
+
+	// Clearing the limit restores normal behavior.
+	l.SetLimit(overloadedMethod, 0, 0)
+	if accepted, err := callUnary(l, overloadedMethod, 100); accepted != 100 || err != nil {
+		t.Errorf("OverloadLimiter: want all calls accepted once cleared, got %d (%v)", accepted, err)
+	}
+}
+
+func TestOverloadLimiter_slow(t *testing.T) {
+	now := time.Unix(100, 0)
+	l := NewOverloadLimiter(func() time.Time { return now })
+	l.SetLimit(overloadedMethod, 0.5, time.Second)
+
+	if accepted, _ := callUnary(l, overloadedMethod, 3); accepted != 1 {
+		t.Errorf("OverloadLimiter: want 1 call accepted got %d", accepted)
+	}
+	now = now.Add(time.Second)
+	if accepted, _ := callUnary(l, overloadedMethod, 1); accepted != 0 {
+		t.Errorf("OverloadLimiter: want no call accepted after 1s got %d", accepted)
+	}
+	now = now.Add(time.Second)
+	if accepted, _ := callUnary(l, overloadedMethod, 3); accepted != 1 {
+		t.Errorf("OverloadLimiter: want 1 call accepted after 2s got %d", accepted)
+	}
+}
+
+func TestOverloadLimiter_stream(t *testing.T) {
+	now := time.Unix(100, 0)
+	l := NewOverloadLimiter(func() time.Time { return now })
+	l.SetLimit("/google.showcase.v1beta1.Echo/Chat", 1, time.Second)
+
+	accepted := 0
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		accepted++
+		return nil
+	}
+	info := &grpc.StreamServerInfo{FullMethod: "/google.showcase.v1beta1.Echo/Chat"}
+	l.StreamInterceptor(nil, nil, info, handler)
+	err := l.StreamInterceptor(nil, nil, info, handler)
+	if accepted != 1 || status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("OverloadLimiter: want the second stream rejected, got %d accepted (%v)", accepted, err)
+	}
+}
+
+func TestOverloadLimiter_concurrent(t *testing.T) {
+	l := NewOverloadLimiter(func() time.Time { return time.Unix(100, 0) })
+	l.SetLimit(overloadedMethod, 50, time.Second)
+
+	var mu sync.Mutex
+	accepted := 0
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		accepted++
+		return req, nil
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				l.UnaryInterceptor(context.Background(), "req", &grpc.UnaryServerInfo{FullMethod: overloadedMethod}, handler)
+			}
+		}()
+	}
+	wg.Wait()
+	if accepted != 50 {
+		t.Errorf("OverloadLimiter: want 50 of 100 concurrent calls accepted got %d", accepted)
+	}
+}
@@ -21,43 +21,78 @@ import (
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 // NewTokenGenerator provides a new instance of a TokenGenerator.
 func NewTokenGenerator() TokenGenerator {
-	return &tokenGenerator{salt: strconv.FormatInt(time.Now().Unix(), 10)}
+	return TokenGeneratorWithSalt(strconv.FormatInt(Now().Unix(), 10))
 }
 
 // TokenGeneratorWithSalt provieds an instance of a TokenGenerator which
 // uses the given salt.
 func TokenGeneratorWithSalt(salt string) TokenGenerator {
-	return &tokenGenerator{salt}
+	return TokenGeneratorWithClock(salt, Now)
 }
 
-// TokenGenerator generates a page token for a given index.
+// TokenGeneratorWithClock provides an instance of a TokenGenerator which
+// uses the given salt, and the given clock to stamp and expire tokens.
+func TokenGeneratorWithClock(salt string, nowF func() time.Time) TokenGenerator {
+	return &tokenGenerator{salt: salt, nowF: nowF, settings: GetSettingsInstance()}
+}
+
+// TokenGenerator generates a page token for a given index. Tokens record when
+// they were issued, so that they can expire.
 type TokenGenerator interface {
 	ForIndex(int) string
+
+	// GetIndex returns the index of the token, which must not be older than
+	// the PageTokenTTL setting.
 	GetIndex(string) (int, error)
+
+	// GetIndexWithTTL returns the index of the token, which must not be
+	// older than the given TTL. A nil TTL defers to the PageTokenTTL
+	// setting, and a zero TTL accepts tokens of any age.
+	GetIndexWithTTL(string, *duration.Duration) (int, error)
 }
 
 // InvalidTokenErr is the error returned if the token provided is not
 // parseable by the TokenGenerator.
-var InvalidTokenErr = status.Errorf(
-	codes.InvalidArgument,
+var InvalidTokenErr = showcaseerrors.Field(
+	showcaseerrors.PageTokenInvalid,
+	"page_token",
 	"The field `page_token` is invalid.")
 
 type tokenGenerator struct {
-	salt string
+	salt     string
+	nowF     func() time.Time
+	settings SettingsStore
 }
 
 func (t *tokenGenerator) ForIndex(i int) string {
 	return base64.StdEncoding.EncodeToString(
-		[]byte(fmt.Sprintf("%s%d", t.salt, i)))
+		[]byte(fmt.Sprintf("%s%d:%d", t.salt, i, t.nowF().UnixNano())))
 }
 
 func (t *tokenGenerator) GetIndex(s string) (int, error) {
+	return t.GetIndexWithTTL(s, nil)
+}
+
+func (t *tokenGenerator) GetIndexWithTTL(s string, ttlProto *duration.Duration) (int, error) {
+	ttl := t.settings.Get().PageTokenTTL
+	if ttlProto != nil {
+		var err error
+		ttl, err = ptypes.Duration(ttlProto)
+		if err != nil || ttl < 0 {
+			return -1, showcaseerrors.Field(showcaseerrors.FieldOutOfRange, "page_token_ttl", "The field `page_token_ttl` must be a non-negative duration.")
+		}
+	}
 	if s == "" {
 		return 0, nil
 	}
@@ -72,9 +107,24 @@ func (t *tokenGenerator) GetIndex(s string) (int, error) {
 		return -1, InvalidTokenErr
 	}
 
-	i, err := strconv.Atoi(strings.TrimPrefix(string(bs), t.salt))
+	parts := strings.Split(strings.TrimPrefix(string(bs), t.salt), ":")
+	if len(parts) != 2 {
+		return -1, InvalidTokenErr
+	}
+	i, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return -1, InvalidTokenErr
+	}
+	issued, err := strconv.ParseInt(parts[1], 10, 64)
 	if err != nil {
 		return -1, InvalidTokenErr
 	}
+	if age := t.nowF().Sub(time.Unix(0, issued)); ttl > 0 && age > ttl {
+		return -1, status.ErrorProto(&spb.Status{
+			Code:    int32(codes.FailedPrecondition),
+			Message: fmt.Sprintf("The field `page_token` is older than its TTL of %s.", ttl),
+			Details: []*any.Any{showcaseerrors.ErrorInfo(showcaseerrors.PageTokenExpired, showcaseerrors.Domain, nil)},
+		})
+	}
 	return i, nil
 }
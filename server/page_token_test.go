@@ -17,14 +17,21 @@ package server
 import (
 	"encoding/base64"
 	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func Test_tokenGenerator_ForIndex(t *testing.T) {
 	salt := "salt"
 	index := 1
 	want := base64.StdEncoding.EncodeToString(
-		[]byte("salt1"))
-	tok := TokenGeneratorWithSalt(salt)
+		[]byte("salt1:100000000000"))
+	tok := TokenGeneratorWithClock(salt, func() time.Time { return time.Unix(100, 0) })
 	if got := tok.ForIndex(index); got != want {
 		t.Errorf("tokenGenerator.ForIndex() = %v, want %v", got, want)
 	}
@@ -55,7 +62,7 @@ func Test_tokenGenerator_GetIndex_invalidIndex(t *testing.T) {
 
 func Test_tokenGenerator_GetIndex(t *testing.T) {
 	tok := TokenGeneratorWithSalt("salt")
-	i, err := tok.GetIndex(base64.StdEncoding.EncodeToString([]byte("salt1")))
+	i, err := tok.GetIndex(base64.StdEncoding.EncodeToString([]byte("salt1:100000000000")))
 	if err != nil {
 		t.Error("GetIndex: unexpected err")
 	}
@@ -63,3 +70,68 @@ func Test_tokenGenerator_GetIndex(t *testing.T) {
 		t.Errorf("GetIndex: want 1, got %d", i)
 	}
 }
+
+func Test_tokenGenerator_GetIndex_noIssueTime(t *testing.T) {
+	tok := TokenGeneratorWithSalt("salt")
+	_, err := tok.GetIndex(base64.StdEncoding.EncodeToString([]byte("salt1")))
+	if err == nil {
+		t.Error("GetIndex: want error for a token without an issue time.")
+	}
+}
+
+func Test_tokenGenerator_GetIndexWithTTL(t *testing.T) {
+	now := time.Unix(100, 0)
+	tok := TokenGeneratorWithClock("salt", func() time.Time { return now })
+	token := tok.ForIndex(3)
+	ttl := ptypes.DurationProto(time.Minute)
+
+	now = now.Add(time.Minute)
+	if i, err := tok.GetIndexWithTTL(token, ttl); err != nil || i != 3 {
+		t.Errorf("GetIndexWithTTL at the TTL: want 3 got %d, %v", i, err)
+	}
+
+	now = now.Add(time.Nanosecond)
+	_, err := tok.GetIndexWithTTL(token, ttl)
+	st := status.Convert(err)
+	if st.Code() != codes.FailedPrecondition {
+		t.Fatalf("GetIndexWithTTL past the TTL: want FailedPrecondition got %v", err)
+	}
+	want := showcaseerrors.ErrorInfo("PAGE_TOKEN_EXPIRED", showcaseerrors.Domain, nil)
+	if details := st.Proto().GetDetails(); len(details) != 1 || !proto.Equal(details[0], want) {
+		t.Errorf("GetIndexWithTTL past the TTL: want a PAGE_TOKEN_EXPIRED ErrorInfo got %v", details)
+	}
+
+	// A zero TTL accepts the token, as does the default setting.
+	if i, err := tok.GetIndexWithTTL(token, ptypes.DurationProto(0)); err != nil || i != 3 {
+		t.Errorf("GetIndexWithTTL with a zero TTL: want 3 got %d, %v", i, err)
+	}
+	if i, err := tok.GetIndex(token); err != nil || i != 3 {
+		t.Errorf("GetIndex: want 3 got %d, %v", i, err)
+	}
+
+	// A token issued after the expiry is fresh.
+	if i, err := tok.GetIndexWithTTL(tok.ForIndex(3), ttl); err != nil || i != 3 {
+		t.Errorf("GetIndexWithTTL with a fresh token: want 3 got %d, %v", i, err)
+	}
+
+	if _, err := tok.GetIndexWithTTL(token, ptypes.DurationProto(-time.Second)); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetIndexWithTTL with a negative TTL: want InvalidArgument got %v", err)
+	}
+}
+
+func Test_tokenGenerator_GetIndex_settingTTL(t *testing.T) {
+	now := time.Unix(100, 0)
+	settings := DefaultSettings()
+	settings.PageTokenTTL = time.Minute
+	tok := &tokenGenerator{salt: "salt", nowF: func() time.Time { return now }, settings: NewSettingsStore(settings)}
+	token := tok.ForIndex(1)
+
+	now = now.Add(2 * time.Minute)
+	if _, err := tok.GetIndex(token); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("GetIndex past the setting's TTL: want FailedPrecondition got %v", err)
+	}
+	// The TTL of the request overrides the setting.
+	if i, err := tok.GetIndexWithTTL(token, ptypes.DurationProto(time.Hour)); err != nil || i != 1 {
+		t.Errorf("GetIndexWithTTL within the request's TTL: want 1 got %d, %v", i, err)
+	}
+}
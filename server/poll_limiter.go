@@ -0,0 +1,121 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var pollLimiterSingleton = NewPollLimiter(Now)
+
+// GetPollLimiterInstance returns the poll limiter singleton.
+func GetPollLimiterInstance() PollLimiter {
+	return pollLimiterSingleton
+}
+
+// PollLimiter enforces the poll quotas of long-running operations.
+type PollLimiter interface {
+	// Poll spends one poll of the named operation's quota. Once the quota is
+	// spent it returns a RESOURCE_EXHAUSTED error, with a RetryInfo if the
+	// quota replenishes.
+	Poll(namespace, name string, quota *pb.PollQuota) error
+	// Clear forgets the polls spent by an operation.
+	Clear(namespace, name string)
+	// PurgeNamespace forgets the polls spent by all operations in the
+	// namespace, and returns how many operations it forgot.
+	PurgeNamespace(namespace string) int
+}
+
+// NewPollLimiter returns a PollLimiter that uses nowF as its clock.
+func NewPollLimiter(nowF func() time.Time) PollLimiter {
+	return &pollLimiterImpl{nowF: nowF, budgets: map[namespacedName]*pollBudget{}}
+}
+
+type pollLimiterImpl struct {
+	nowF func() time.Time
+
+	mu      sync.Mutex
+	budgets map[namespacedName]*pollBudget
+}
+
+// pollBudget is the remaining polls of an operation, as of the last time one
+// was added back.
+type pollBudget struct {
+	polls       int32
+	replenished time.Time
+}
+
+func (l *pollLimiterImpl) Poll(namespace, name string, quota *pb.PollQuota) error {
+	interval, _ := ptypes.Duration(quota.GetReplenishInterval())
+	key := namespacedName{namespace, name}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.nowF()
+	b, ok := l.budgets[key]
+	if !ok {
+		b = &pollBudget{polls: quota.GetMaxPolls(), replenished: now}
+		l.budgets[key] = b
+	}
+	if interval > 0 {
+		if b.polls >= quota.GetMaxPolls() {
+			b.replenished = now
+		} else if n := now.Sub(b.replenished) / interval; n > 0 {
+			b.polls += int32(n)
+			b.replenished = b.replenished.Add(n * interval)
+			if b.polls >= quota.GetMaxPolls() {
+				b.polls = quota.GetMaxPolls()
+				b.replenished = now
+			}
+		}
+	}
+	if b.polls > 0 {
+		b.polls--
+		return nil
+	}
+
+	st := status.Newf(codes.ResourceExhausted, "The poll quota of operation %q is exhausted.", name)
+	if interval > 0 {
+		delay := b.replenished.Add(interval).Sub(now)
+		st, _ = st.WithDetails(&errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(delay)})
+	}
+	return st.Err()
+}
+
+func (l *pollLimiterImpl) Clear(namespace, name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.budgets, namespacedName{namespace, name})
+}
+
+func (l *pollLimiterImpl) PurgeNamespace(namespace string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := 0
+	for key := range l.budgets {
+		if key.namespace == namespace {
+			delete(l.budgets, key)
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,131 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// retryDelay returns the delay of the RetryInfo detail of err, if any.
+func retryDelay(t *testing.T, err error) (time.Duration, bool) {
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok {
+			delay, err := ptypes.Duration(info.GetRetryDelay())
+			if err != nil {
+				t.Fatal(err)
+			}
+			return delay, true
+		}
+	}
+	return 0, false
+}
+
+func TestPollLimiter(t *testing.T) {
+	now := time.Unix(1000, 0)
+	limiter := NewPollLimiter(func() time.Time { return now })
+	quota := &pb.PollQuota{MaxPolls: 2, ReplenishInterval: ptypes.DurationProto(10 * time.Second)}
+
+	for i := 0; i < 2; i++ {
+		if err := limiter.Poll("ns", "op", quota); err != nil {
+			t.Fatalf("Poll %d: unexpected err %+v", i, err)
+		}
+	}
+	now = now.Add(4 * time.Second)
+	err := limiter.Poll("ns", "op", quota)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Poll beyond the quota: want ResourceExhausted got %v", err)
+	}
+	if delay, ok := retryDelay(t, err); !ok || delay != 6*time.Second {
+		t.Errorf("Poll beyond the quota: want a 6s RetryInfo got %s, %t", delay, ok)
+	}
+
+	// Other operations have their own budget.
+	if err := limiter.Poll("ns", "other", quota); err != nil {
+		t.Errorf("Poll of another operation: unexpected err %+v", err)
+	}
+
+	// One poll is added back per interval.
+	now = now.Add(6 * time.Second)
+	if err := limiter.Poll("ns", "op", quota); err != nil {
+		t.Errorf("Poll after the interval: unexpected err %+v", err)
+	}
+	if err := limiter.Poll("ns", "op", quota); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Poll after spending the replenished poll: want ResourceExhausted got %v", err)
+	}
+
+	// The budget refills up to max_polls only.
+	now = now.Add(time.Hour)
+	for i := 0; i < 2; i++ {
+		if err := limiter.Poll("ns", "op", quota); err != nil {
+			t.Fatalf("Poll %d after an hour: unexpected err %+v", i, err)
+		}
+	}
+	if err := limiter.Poll("ns", "op", quota); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Poll beyond max_polls: want ResourceExhausted got %v", err)
+	}
+}
+
+func TestPollLimiter_noReplenish(t *testing.T) {
+	now := time.Unix(1000, 0)
+	limiter := NewPollLimiter(func() time.Time { return now })
+	quota := &pb.PollQuota{MaxPolls: 1}
+
+	if err := limiter.Poll("ns", "op", quota); err != nil {
+		t.Fatalf("Poll: unexpected err %+v", err)
+	}
+	now = now.Add(time.Hour)
+	err := limiter.Poll("ns", "op", quota)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Poll beyond the quota: want ResourceExhausted got %v", err)
+	}
+	if _, ok := retryDelay(t, err); ok {
+		t.Error("Poll beyond a quota that never replenishes: want no RetryInfo")
+	}
+
+	limiter.Clear("ns", "op")
+	if err := limiter.Poll("ns", "op", quota); err != nil {
+		t.Errorf("Poll after Clear: unexpected err %+v", err)
+	}
+}
+
+func TestGetPollLimiterInstance(t *testing.T) {
+	if GetPollLimiterInstance() != GetPollLimiterInstance() {
+		t.Error("GetPollLimiterInstance: want the same limiter on every call")
+	}
+}
+
+func TestPollLimiter_namespaces(t *testing.T) {
+	limiter := NewPollLimiter(time.Now)
+	quota := &pb.PollQuota{MaxPolls: 1}
+
+	limiter.Poll("a", "op", quota)
+	if err := limiter.Poll("b", "op", quota); err != nil {
+		t.Errorf("Poll in another namespace: unexpected err %+v", err)
+	}
+	limiter.PurgeNamespace("a")
+	if err := limiter.Poll("a", "op", quota); err != nil {
+		t.Errorf("Poll after PurgeNamespace: unexpected err %+v", err)
+	}
+	if err := limiter.Poll("b", "op", quota); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Poll in a namespace that was not purged: want ResourceExhausted got %v", err)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// MaxRecordedPolls is the maximum number of polls that are kept for a single
+// operation. Once reached, the oldest polls are dropped.
+const MaxRecordedPolls = 1000
+
+// MaxRecordedOperations is the maximum number of operations whose polls are
+// kept. Once reached, the polls of the operation first recorded are dropped.
+const MaxRecordedOperations = 10000
+
+var pollRecorderSingleton PollRecorder = NewPollRecorder(Now)
+
+// GetPollRecorderInstance returns the poll recorder singleton.
+func GetPollRecorderInstance() PollRecorder {
+	return pollRecorderSingleton
+}
+
+// PollRecorder records the times at which operations are polled so that the
+// backoff of a client's poller can be verified.
+type PollRecorder interface {
+	// Record records that the operation with the given name was polled now.
+	Record(namespace, name string)
+	// Polls returns the recorded poll times of an operation, oldest first.
+	Polls(namespace, name string) []time.Time
+	// Clear forgets all recorded polls of an operation.
+	Clear(namespace, name string)
+	// PurgeNamespace forgets all recorded polls in the namespace, and
+	// returns how many operations it forgot.
+	PurgeNamespace(namespace string) int
+	// List returns the recorded polls of all operations, ordered by
+	// namespace and name.
+	List() []OperationPolls
+}
+
+// OperationPolls are the recorded polls of an operation, oldest first.
+type OperationPolls struct {
+	Namespace string
+	Name      string
+	Polls     []time.Time
+}
+
+// NewPollRecorder returns a PollRecorder that uses nowF as its clock.
+func NewPollRecorder(nowF func() time.Time) PollRecorder {
+	return newPollRecorder(nowF, MaxRecordedOperations)
+}
+
+// newPollRecorder returns a PollRecorder that keeps the polls of at most
+// maxOperations operations, forgetting the oldest first.
+func newPollRecorder(nowF func() time.Time, maxOperations int) *pollRecorderImpl {
+	return &pollRecorderImpl{nowF: nowF, maxOperations: maxOperations, polls: map[namespacedName][]time.Time{}}
+}
+
+type pollRecorderImpl struct {
+	nowF          func() time.Time
+	maxOperations int
+
+	mu    sync.Mutex
+	polls map[namespacedName][]time.Time
+	// order holds the operations in the order they were first recorded.
+	order []namespacedName
+}
+
+func (r *pollRecorderImpl) Record(namespace, name string) {
+	key := namespacedName{namespace, name}
+	defer ChangeState()()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.polls[key]; !ok {
+		for len(r.order) > 0 && len(r.polls) >= r.maxOperations {
+			delete(r.polls, r.order[0])
+			r.order = r.order[1:]
+		}
+		r.order = append(r.order, key)
+	}
+	// The clock is read under the lock so that concurrent polls are recorded
+	// in order.
+	polls := append(r.polls[key], r.nowF())
+	if len(polls) > MaxRecordedPolls {
+		polls = polls[len(polls)-MaxRecordedPolls:]
+	}
+	r.polls[key] = polls
+}
+
+func (r *pollRecorderImpl) Polls(namespace, name string) []time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]time.Time{}, r.polls[namespacedName{namespace, name}]...)
+}
+
+func (r *pollRecorderImpl) Clear(namespace, name string) {
+	defer ChangeState()()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := namespacedName{namespace, name}
+	if _, ok := r.polls[key]; !ok {
+		return
+	}
+	delete(r.polls, key)
+	r.removeLocked(func(k namespacedName) bool { return k == key })
+}
+
+// removeLocked drops the operations that match from the order, and returns
+// how many it dropped. r.mu must be held.
+func (r *pollRecorderImpl) removeLocked(match func(namespacedName) bool) int {
+	n := len(r.order)
+	order := r.order[:0]
+	for _, k := range r.order {
+		if !match(k) {
+			order = append(order, k)
+		}
+	}
+	r.order = order
+	return n - len(order)
+}
+
+func (r *pollRecorderImpl) PurgeNamespace(namespace string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key := range r.polls {
+		if key.namespace == namespace {
+			delete(r.polls, key)
+		}
+	}
+	return r.removeLocked(func(k namespacedName) bool { return k.namespace == namespace })
+}
+
+func (r *pollRecorderImpl) List() []OperationPolls {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ops := make([]OperationPolls, 0, len(r.polls))
+	for key, polls := range r.polls {
+		ops = append(ops, OperationPolls{
+			Namespace: key.namespace,
+			Name:      key.name,
+			Polls:     append([]time.Time{}, polls...),
+		})
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		return namespacedName{ops[i].Namespace, ops[i].Name}.less(namespacedName{ops[j].Namespace, ops[j].Name})
+	})
+	return ops
+}
@@ -0,0 +1,165 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGetPollRecorderInstance(t *testing.T) {
+	if GetPollRecorderInstance() != pollRecorderSingleton {
+		t.Error("GetPollRecorderInstance: Expected to get poll recorder singleton.")
+	}
+}
+
+func TestPollRecorder(t *testing.T) {
+	now := time.Unix(0, 0)
+	r := NewPollRecorder(func() time.Time { return now })
+
+	for _, d := range []time.Duration{0, time.Second, 2 * time.Second} {
+		now = now.Add(d)
+		r.Record(DefaultNamespace, "operations/a")
+	}
+	r.Record(DefaultNamespace, "operations/b")
+
+	polls := r.Polls(DefaultNamespace, "operations/a")
+	expected := []time.Time{time.Unix(0, 0), time.Unix(1, 0), time.Unix(3, 0)}
+	if len(polls) != len(expected) {
+		t.Fatalf("Polls: expected %d polls, got %d", len(expected), len(polls))
+	}
+	for i, p := range polls {
+		if !p.Equal(expected[i]) {
+			t.Errorf("Polls: expected poll %d at %v, got %v", i, expected[i], p)
+		}
+	}
+
+	r.Clear(DefaultNamespace, "operations/a")
+	if polls := r.Polls(DefaultNamespace, "operations/a"); len(polls) != 0 {
+		t.Errorf("Clear: expected no polls, got %v", polls)
+	}
+	if polls := r.Polls(DefaultNamespace, "operations/b"); len(polls) != 1 {
+		t.Errorf("Clear: expected other operations to be untouched, got %v", polls)
+	}
+}
+
+func TestPollRecorder_capped(t *testing.T) {
+	i := int64(0)
+	r := NewPollRecorder(func() time.Time {
+		i++
+		return time.Unix(i, 0)
+	})
+	for n := 0; n < MaxRecordedPolls+5; n++ {
+		r.Record(DefaultNamespace, "operations/a")
+	}
+
+	polls := r.Polls(DefaultNamespace, "operations/a")
+	if len(polls) != MaxRecordedPolls {
+		t.Fatalf("Polls: expected %d polls, got %d", MaxRecordedPolls, len(polls))
+	}
+	if !polls[0].Equal(time.Unix(6, 0)) {
+		t.Errorf("Polls: expected the oldest polls to be dropped, first poll at %v", polls[0])
+	}
+}
+
+func TestPollRecorder_maxOperations(t *testing.T) {
+	r := newPollRecorder(time.Now, 2)
+	r.Record(DefaultNamespace, "operations/a")
+	r.Record(DefaultNamespace, "operations/b")
+	r.Record(DefaultNamespace, "operations/a")
+	r.Record(DefaultNamespace, "operations/c")
+
+	// The operation recorded first is forgotten, however recently it was
+	// polled.
+	if polls := r.Polls(DefaultNamespace, "operations/a"); len(polls) != 0 {
+		t.Errorf("Polls(a): expected the oldest operation forgotten, got %v", polls)
+	}
+	for _, name := range []string{"operations/b", "operations/c"} {
+		if polls := r.Polls(DefaultNamespace, name); len(polls) != 1 {
+			t.Errorf("Polls(%s): expected 1 poll, got %v", name, polls)
+		}
+	}
+
+	// Cleared operations no longer count.
+	r.Clear(DefaultNamespace, "operations/b")
+	r.Record(DefaultNamespace, "operations/d")
+	if polls := r.Polls(DefaultNamespace, "operations/c"); len(polls) != 1 {
+		t.Errorf("Polls(c): expected the operation kept after another was cleared, got %v", polls)
+	}
+	if n := r.PurgeNamespace(DefaultNamespace); n != 2 {
+		t.Errorf("PurgeNamespace: expected 2 operations purged, got %d", n)
+	}
+	if len(r.order) != 0 {
+		t.Errorf("PurgeNamespace: expected no operations left, got %v", r.order)
+	}
+}
+
+func TestPollRecorder_concurrent(t *testing.T) {
+	r := NewPollRecorder(time.Now)
+	wg := sync.WaitGroup{}
+	for n := 0; n < 50; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.Record(DefaultNamespace, "operations/a")
+		}()
+	}
+	wg.Wait()
+
+	polls := r.Polls(DefaultNamespace, "operations/a")
+	if len(polls) != 50 {
+		t.Fatalf("Polls: expected 50 polls, got %d", len(polls))
+	}
+	for i := 1; i < len(polls); i++ {
+		if polls[i].Before(polls[i-1]) {
+			t.Errorf("Polls: expected polls in order, poll %d at %v is before %v", i, polls[i], polls[i-1])
+		}
+	}
+}
+
+func TestPollRecorder_namespaces(t *testing.T) {
+	r := NewPollRecorder(time.Now)
+	r.Record("a", "operations/x")
+	r.Record("b", "operations/x")
+	r.Record("b", "operations/x")
+	if polls := r.Polls("a", "operations/x"); len(polls) != 1 {
+		t.Errorf("Polls: expected namespaces to be recorded apart, got %v", polls)
+	}
+
+	r.PurgeNamespace("b")
+	if polls := r.Polls("b", "operations/x"); len(polls) != 0 {
+		t.Errorf("PurgeNamespace: expected no polls, got %v", polls)
+	}
+	if polls := r.Polls("a", "operations/x"); len(polls) != 1 {
+		t.Errorf("PurgeNamespace: expected other namespaces to be untouched, got %v", polls)
+	}
+}
+
+func TestPollRecorder_List(t *testing.T) {
+	now := time.Unix(0, 0)
+	r := NewPollRecorder(func() time.Time { return now })
+	r.Record("b", "op")
+	r.Record("a", "op")
+	now = now.Add(time.Second)
+	r.Record("a", "op")
+	got := r.List()
+	if len(got) != 2 || got[0].Namespace != "a" || got[1].Namespace != "b" {
+		t.Fatalf("List: want the operations of a then b got %v", got)
+	}
+	if len(got[0].Polls) != 2 || !got[0].Polls[1].Equal(now) {
+		t.Errorf("List: want two polls of a, the latest now, got %v", got[0].Polls)
+	}
+}
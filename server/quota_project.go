@@ -0,0 +1,129 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"regexp"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+)
+
+// QuotaProjectHeader is the metadata key with which clients name the project
+// a call is billed and counted against.
+const QuotaProjectHeader = "x-goog-user-project"
+
+var quotaProjectPattern = regexp.MustCompile("^[a-z][a-z0-9-]{4,28}[a-z0-9]$")
+
+type quotaProjectKey struct{}
+
+// WithQuotaProject returns a copy of the context with the given quota
+// project.
+func WithQuotaProject(ctx context.Context, project string) context.Context {
+	return context.WithValue(ctx, quotaProjectKey{}, project)
+}
+
+// QuotaProjectFromContext returns the quota project of the context, or the
+// empty string if it has none.
+func QuotaProjectFromContext(ctx context.Context) string {
+	project, _ := ctx.Value(quotaProjectKey{}).(string)
+	return project
+}
+
+// ValidateQuotaProject returns an INVALID_ARGUMENT error if the project is
+// not 6 to 30 lowercase letters, digits and hyphens, starting with a letter
+// and not ending with a hyphen.
+func ValidateQuotaProject(project string) error {
+	if !quotaProjectPattern.MatchString(project) {
+		return showcaseerrors.QuotaProject(
+			QuotaProjectHeader,
+			"The %s %q must be 6 to 30 lowercase letters, digits and hyphens, "+
+				"starting with a letter and not ending with a hyphen.",
+			QuotaProjectHeader,
+			project)
+	}
+	return nil
+}
+
+// QuotaProjectInterceptor captures the quota project of calls into their
+// context, and rejects invalid ones under the StrictQuotaProject setting.
+type QuotaProjectInterceptor struct {
+	settings SettingsStore
+}
+
+// NewQuotaProjectInterceptor returns a QuotaProjectInterceptor that reads
+// whether it is strict from the settings.
+func NewQuotaProjectInterceptor(settings SettingsStore) *QuotaProjectInterceptor {
+	return &QuotaProjectInterceptor{settings: settings}
+}
+
+// quotaProject returns a copy of the context with the quota project given by
+// its incoming metadata.
+func (q *QuotaProjectInterceptor) quotaProject(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(QuotaProjectHeader)
+	if len(values) == 0 {
+		return ctx, nil
+	}
+	if q.settings.Get().StrictQuotaProject {
+		if len(values) > 1 {
+			return nil, showcaseerrors.QuotaProject(QuotaProjectHeader, "The %s metadata must be given at most once.", QuotaProjectHeader)
+		}
+		if err := ValidateQuotaProject(values[0]); err != nil {
+			return nil, err
+		}
+	}
+	return WithQuotaProject(ctx, values[0]), nil
+}
+
+// UnaryInterceptor captures the quota project of a unary call into its
+// context.
+func (q *QuotaProjectInterceptor) UnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := q.quotaProject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor captures the quota project of a streaming call into the
+// context of its stream.
+func (q *QuotaProjectInterceptor) StreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	ctx, err := q.quotaProject(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &quotaProjectStream{ServerStream: ss, ctx: ctx})
+}
+
+type quotaProjectStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *quotaProjectStream) Context() context.Context {
+	return s.ctx
+}
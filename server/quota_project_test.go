@@ -0,0 +1,106 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateQuotaProject(t *testing.T) {
+	for _, project := range []string{"my-project", "abcdef", "a12345", "project-123", "a" + strings.Repeat("b", 29)} {
+		if err := ValidateQuotaProject(project); err != nil {
+			t.Errorf("ValidateQuotaProject(%q): %v", project, err)
+		}
+	}
+	invalid := []string{
+		"",
+		"abcde",
+		"a" + strings.Repeat("b", 30),
+		"1project",
+		"-project",
+		"project-",
+		"My-Project",
+		"my_project",
+		"my project",
+		"my.project",
+	}
+	for _, project := range invalid {
+		err := ValidateQuotaProject(project)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ValidateQuotaProject(%q): want InvalidArgument got %v", project, err)
+			continue
+		}
+		if !strings.Contains(status.Convert(err).Proto().String(), "INVALID_QUOTA_PROJECT") {
+			t.Errorf("ValidateQuotaProject(%q): want reason INVALID_QUOTA_PROJECT, got %v", project, status.Convert(err).Proto())
+		}
+	}
+}
+
+func TestQuotaProjectUnaryInterceptor(t *testing.T) {
+	tests := []struct {
+		strict bool
+		md     metadata.MD
+		want   string
+		code   codes.Code
+	}{
+		{false, nil, "", codes.OK},
+		{false, metadata.Pairs(QuotaProjectHeader, "my-project"), "my-project", codes.OK},
+		{false, metadata.Pairs(QuotaProjectHeader, ""), "", codes.OK},
+		{false, metadata.Pairs(QuotaProjectHeader, "Bad Project"), "Bad Project", codes.OK},
+		{true, nil, "", codes.OK},
+		{true, metadata.Pairs(QuotaProjectHeader, "my-project"), "my-project", codes.OK},
+		{true, metadata.Pairs(QuotaProjectHeader, ""), "", codes.InvalidArgument},
+		{true, metadata.Pairs(QuotaProjectHeader, "Bad Project"), "", codes.InvalidArgument},
+		{true, metadata.Pairs(QuotaProjectHeader, "project-a", QuotaProjectHeader, "project-b"), "", codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		settings := DefaultSettings()
+		settings.StrictQuotaProject = test.strict
+		q := NewQuotaProjectInterceptor(NewSettingsStore(settings))
+		ctx := metadata.NewIncomingContext(context.Background(), test.md)
+		got := ""
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			got = QuotaProjectFromContext(ctx)
+			return nil, nil
+		}
+		_, err := q.UnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+		if status.Code(err) != test.code || got != test.want {
+			t.Errorf("UnaryInterceptor(%t, %v): want (%q, %s) got (%q, %v)", test.strict, test.md, test.want, test.code, got, err)
+		}
+	}
+}
+
+func TestQuotaProjectStreamInterceptor(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(QuotaProjectHeader, "my-project"))
+	got := ""
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		got = QuotaProjectFromContext(ss.Context())
+		return nil
+	}
+	q := NewQuotaProjectInterceptor(NewSettingsStore(DefaultSettings()))
+	if err := q.StreamInterceptor(nil, &contextStream{ctx: ctx}, &grpc.StreamServerInfo{}, handler); err != nil {
+		t.Fatal(err)
+	}
+	if got != "my-project" {
+		t.Errorf("StreamInterceptor: want quota project my-project got %q", got)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"google.golang.org/grpc"
+)
+
+// ReadyBanner describes a server whose services are registered and whose
+// listeners accept connections, for harnesses that start the server to
+// know when and where to call it.
+type ReadyBanner struct {
+	// The address of the gRPC server, or of its first replica.
+	GRPCAddress string `json:"grpc_address"`
+
+	// The addresses of every replica, if there are several.
+	ReplicaAddresses []string `json:"replica_addresses,omitempty"`
+
+	// The address of the HTTP server, if it is enabled.
+	HTTPAddress string `json:"http_address,omitempty"`
+
+	// The full names of the registered gRPC services, sorted.
+	Services []string `json:"services"`
+
+	InstanceID string `json:"instance_id"`
+	PID        int    `json:"pid"`
+}
+
+// NewReadyBanner returns the banner of the gRPC server s, listening on the
+// listeners of its replicas, and of the HTTP server listening on http, which
+// is nil if there is none.
+func NewReadyBanner(s *grpc.Server, listeners []net.Listener, http net.Listener, instanceID string) ReadyBanner {
+	b := ReadyBanner{InstanceID: instanceID, PID: os.Getpid()}
+	if len(listeners) > 0 {
+		b.GRPCAddress = listeners[0].Addr().String()
+	}
+	if len(listeners) > 1 {
+		for _, l := range listeners {
+			b.ReplicaAddresses = append(b.ReplicaAddresses, l.Addr().String())
+		}
+	}
+	if http != nil {
+		b.HTTPAddress = http.Addr().String()
+	}
+	b.Services = []string{}
+	for name := range s.GetServiceInfo() {
+		b.Services = append(b.Services, name)
+	}
+	sort.Strings(b.Services)
+	return b
+}
+
+// Write writes the banner to w as a single line of JSON.
+func (b ReadyBanner) Write(w io.Writer) error {
+	line, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(line, '\n'))
+	return err
+}
+
+// WriteFile writes the banner to the file at path as Write does. The file is
+// written in full under another name and then renamed, so that a harness
+// watching for it never reads part of it.
+func (b ReadyBanner) WriteFile(path string) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err := f.Chmod(0644); err != nil {
+		f.Close()
+		return err
+	}
+	if err := b.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
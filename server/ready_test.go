@@ -0,0 +1,143 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+func TestReadyBanner(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	defer s.Stop()
+	reflection.Register(s)
+
+	var out bytes.Buffer
+	if err := NewReadyBanner(s, []net.Listener{lis}, nil, "abc").Write(&out); err != nil {
+		t.Fatalf("Write: unexpected err %+v", err)
+	}
+	line, err := bufio.NewReader(&out).ReadBytes('\n')
+	if err != nil || out.Len() != 0 {
+		t.Fatalf("Write: want a single line got %q", out.String())
+	}
+	var banner ReadyBanner
+	if err := json.Unmarshal(line, &banner); err != nil {
+		t.Fatalf("Write: want JSON got %q: %v", line, err)
+	}
+	want := ReadyBanner{
+		GRPCAddress: lis.Addr().String(),
+		Services:    []string{"grpc.reflection.v1alpha.ServerReflection"},
+		InstanceID:  "abc",
+		PID:         os.Getpid(),
+	}
+	if !reflect.DeepEqual(banner, want) {
+		t.Errorf("Write: want %+v got %+v", want, banner)
+	}
+
+	// A call to the advertised address made as soon as the banner is read
+	// is answered once the server serves.
+	go s.Serve(lis)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, banner.GRPCAddress, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		t.Fatalf("Dial(%s): unexpected err %+v", banner.GRPCAddress, err)
+	}
+	defer conn.Close()
+	err = conn.Invoke(ctx, "/google.showcase.v1beta1.Echo/Echo", &pb.EchoRequest{}, &pb.EchoResponse{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("Invoke: want Unimplemented from the server got %v", err)
+	}
+}
+
+func TestReadyBanner_addresses(t *testing.T) {
+	var listeners []net.Listener
+	var want []string
+	for i := 0; i < 2; i++ {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer l.Close()
+		listeners = append(listeners, l)
+		want = append(want, l.Addr().String())
+	}
+	http, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer http.Close()
+
+	banner := NewReadyBanner(grpc.NewServer(), listeners, http, "abc")
+	if banner.GRPCAddress != want[0] || !reflect.DeepEqual(banner.ReplicaAddresses, want) {
+		t.Errorf("NewReadyBanner: want the replicas %v got %s and %v", want, banner.GRPCAddress, banner.ReplicaAddresses)
+	}
+	if banner.HTTPAddress != http.Addr().String() {
+		t.Errorf("NewReadyBanner: want the HTTP address %s got %s", http.Addr(), banner.HTTPAddress)
+	}
+	if banner.Services == nil || len(banner.Services) != 0 {
+		t.Errorf("NewReadyBanner: want no services got %v", banner.Services)
+	}
+}
+
+func TestReadyBanner_writeFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ready")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	banner := ReadyBanner{GRPCAddress: "127.0.0.1:7469", Services: []string{}, InstanceID: "abc", PID: 1}
+	path := filepath.Join(dir, "ready.json")
+
+	// Writing again replaces the file.
+	for i := 0; i < 2; i++ {
+		if err := banner.WriteFile(path); err != nil {
+			t.Fatalf("WriteFile: unexpected err %+v", err)
+		}
+	}
+	var want bytes.Buffer
+	banner.Write(&want)
+	got, err := ioutil.ReadFile(path)
+	if err != nil || !bytes.Equal(got, want.Bytes()) {
+		t.Errorf("WriteFile: want %q got %q, %v", want.String(), got, err)
+	}
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 1 {
+		t.Errorf("WriteFile: want only the ready file left got %d files", len(files))
+	}
+
+	if err := banner.WriteFile(filepath.Join(dir, "missing", "ready.json")); err == nil {
+		t.Errorf("WriteFile: want an error for a missing directory")
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+)
+
+// DefaultLogRedactions returns the fields the request log redacts by
+// default: the error details an Echo request carries, and the credentials
+// InspectCredentials echoes.
+func DefaultLogRedactions() map[string][]string {
+	return map[string][]string{
+		"google.showcase.v1beta1.EchoRequest":                {"error.details"},
+		"google.showcase.v1beta1.InspectCredentialsResponse": {"credentials.values"},
+	}
+}
+
+// LogRedactor formats messages for the request log, redacting the fields
+// named by the LogRedactions setting and the bytes fields longer than the
+// MaxLoggedBytes setting.
+type LogRedactor struct {
+	settings SettingsStore
+}
+
+// NewLogRedactor returns a LogRedactor that reads its redactions from the
+// settings on every call.
+func NewLogRedactor(settings SettingsStore) *LogRedactor {
+	return &LogRedactor{settings: settings}
+}
+
+// Format returns the compact text form of msg, with each redacted value
+// replaced by `<redacted:N bytes>`. N is the length of a string or bytes
+// value, and the encoded size of a message; a repeated field counts all of
+// its values. Redactions keyed by a message apply wherever the message
+// appears, including within other messages. Values that are not messages
+// are formatted with %+v.
+func (r *LogRedactor) Format(msg interface{}) string {
+	v := reflect.ValueOf(msg)
+	if _, ok := msg.(proto.Message); !ok || v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Sprintf("%+v", msg)
+	}
+	settings := r.settings.Get()
+	f := redactingFormatter{redactions: settings.LogRedactions, maxBytes: int(settings.MaxLoggedBytes)}
+	return f.message(v.Elem(), nil)
+}
+
+type redactingFormatter struct {
+	redactions map[string][]string
+	maxBytes   int
+}
+
+// message formats the message struct v. The fields node selects are
+// redacted, along with those the redactions name for the type of v.
+func (f redactingFormatter) message(v reflect.Value, node maskNode) string {
+	t := v.Type()
+	node = unionMask(node, maskTree(f.redactions[protoName(t)]))
+	props := proto.GetProperties(t)
+	parts := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		sf, fv := t.Field(i), v.Field(i)
+		if strings.HasPrefix(sf.Name, "XXX_") {
+			continue
+		}
+		name := props.Prop[i].OrigName
+		if sf.Tag.Get("protobuf_oneof") != "" {
+			if fv.IsNil() {
+				continue
+			}
+			for _, oneof := range props.OneofTypes {
+				if oneof.Type == fv.Elem().Type() {
+					name = oneof.Prop.OrigName
+				}
+			}
+			fv = fv.Elem().Elem().Field(0)
+		} else if isEmptyField(fv) {
+			continue
+		}
+		child, ok := node[name]
+		if ok && len(child) == 0 {
+			parts = append(parts, fmt.Sprintf("%s:%q", name, redacted(valueSize(fv))))
+			continue
+		}
+		parts = append(parts, f.field(name, fv, child)...)
+	}
+	return strings.Join(parts, " ")
+}
+
+// field formats the values of a field, one for each value of a repeated
+// field and one for each entry of a map.
+func (f redactingFormatter) field(name string, v reflect.Value, node maskNode) []string {
+	switch {
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8:
+		parts := []string{}
+		for i := 0; i < v.Len(); i++ {
+			parts = append(parts, name+":"+f.value(v.Index(i), node))
+		}
+		return parts
+	case v.Kind() == reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		parts := []string{}
+		for _, k := range keys {
+			parts = append(parts, fmt.Sprintf("%s:<key:%s value:%s>", name, f.value(k, nil), f.value(v.MapIndex(k), node)))
+		}
+		return parts
+	}
+	return []string{name + ":" + f.value(v, node)}
+}
+
+func (f redactingFormatter) value(v reflect.Value, node maskNode) string {
+	switch {
+	case v.Kind() == reflect.Ptr && v.Type().Elem().Kind() == reflect.Struct:
+		if v.IsNil() {
+			return "<>"
+		}
+		return "<" + f.message(v.Elem(), node) + ">"
+	case v.Kind() == reflect.Slice:
+		if f.maxBytes > 0 && v.Len() > f.maxBytes {
+			return strconv.Quote(redacted(v.Len()))
+		}
+		return strconv.Quote(string(v.Bytes()))
+	case v.Kind() == reflect.String:
+		return strconv.Quote(v.String())
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		// Enums are formatted by name.
+		return s.String()
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func redacted(size int) string {
+	return fmt.Sprintf("<redacted:%d bytes>", size)
+}
+
+// isEmptyField reports whether a field that is not part of a oneof holds its
+// default value, which the text format omits.
+func isEmptyField(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.Slice, reflect.Map, reflect.String:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+// valueSize returns the size in bytes of the value of a field.
+func valueSize(v reflect.Value) int {
+	switch v.Kind() {
+	case reflect.String:
+		return v.Len()
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Len()
+		}
+		size := 0
+		for i := 0; i < v.Len(); i++ {
+			size += valueSize(v.Index(i))
+		}
+		return size
+	case reflect.Map:
+		size := 0
+		for _, k := range v.MapKeys() {
+			size += valueSize(k) + valueSize(v.MapIndex(k))
+		}
+		return size
+	case reflect.Ptr:
+		if msg, ok := v.Interface().(proto.Message); ok && !v.IsNil() {
+			return proto.Size(msg)
+		}
+		return 0
+	}
+	return int(v.Type().Size())
+}
+
+// unionMask returns the fields that either a or b select.
+func unionMask(a, b maskNode) maskNode {
+	if len(a) == 0 {
+		return b
+	}
+	if len(b) == 0 {
+		return a
+	}
+	union := maskNode{}
+	for name, child := range a {
+		union[name] = child
+	}
+	for name, child := range b {
+		other, ok := union[name]
+		switch {
+		case !ok:
+			union[name] = child
+		case len(other) == 0 || len(child) == 0:
+			union[name] = maskNode{}
+		default:
+			union[name] = unionMask(other, child)
+		}
+	}
+	return union
+}
+
+// validateLogRedactions returns an INVALID_ARGUMENT error if the redactions
+// name a message that is not registered, or a field it does not have.
+func validateLogRedactions(redactions map[string][]string) error {
+	names := []string{}
+	for name := range redactions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t := proto.MessageType(name)
+		if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+			return showcaseerrors.Setting(
+				"log_redactions",
+				"The setting `log_redactions` names %q, which is not a known message.",
+				name)
+		}
+		for _, path := range redactions[name] {
+			if desc := checkRedactionPath(t.Elem(), path); desc != "" {
+				return showcaseerrors.Setting(
+					"log_redactions",
+					"The setting `log_redactions[%q]` has the path `%s`, %s.",
+					name,
+					path,
+					desc)
+			}
+		}
+	}
+	return nil
+}
+
+// checkRedactionPath describes what is wrong with a redacted path of the
+// message struct type t, or returns "" if it is valid.
+func checkRedactionPath(t reflect.Type, path string) string {
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		f, ok := jsonFields(t)[segment]
+		if !ok || f.origName != segment {
+			return fmt.Sprintf("but %s has no field `%s`", protoName(t), segment)
+		}
+		if i == len(segments)-1 {
+			return ""
+		}
+		typ := f.typ
+		if typ.Kind() == reflect.Slice && typ.Elem().Kind() != reflect.Uint8 {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+			return fmt.Sprintf("but `%s` is not a message", segment)
+		}
+		t = typ.Elem()
+	}
+	return ""
+}
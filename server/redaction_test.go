@@ -0,0 +1,150 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/any"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLogRedactor_defaults(t *testing.T) {
+	r := NewLogRedactor(NewSettingsStore(DefaultSettings()))
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, "", 0)
+
+	logger.Printf("Request: %s", r.Format(&pb.EchoRequest{
+		Response: &pb.EchoRequest_Error{Error: &spb.Status{
+			Code:    int32(codes.InvalidArgument),
+			Message: "bad",
+			Details: []*any.Any{{TypeUrl: "type.googleapis.com/google.rpc.ErrorInfo", Value: []byte("secret")}},
+		}},
+		ClientSequence: 3,
+	}))
+	logger.Printf("Response: %s", r.Format(&pb.InspectCredentialsResponse{
+		Credentials: []*pb.InspectCredentialsResponse_Credential{{
+			Key:    "authorization",
+			Values: []string{"Bearer ya29.token", "Bearer other"},
+		}},
+	}))
+	logger.Printf("Response: %s", r.Format(&pb.ReadBlobResponse{Data: make([]byte, 300), Offset: 5}))
+	logger.Printf("Blurb: %s", r.Format(&pb.Blurb{Name: "b", Content: &pb.Blurb_Image{Image: []byte("small")}}))
+
+	want := []string{
+		`Request: error:<code:3 message:"bad" details:"<redacted:50 bytes>"> client_sequence:3`,
+		`Response: credentials:<key:"authorization" values:"<redacted:29 bytes>">`,
+		`Response: data:"<redacted:300 bytes>" offset:5`,
+		`Blurb: name:"b" image:"small"`,
+	}
+	got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(got) != len(want) {
+		t.Fatalf("Format: want %d log lines got %q", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Format: want %s got %s", want[i], got[i])
+		}
+	}
+	for _, secret := range []string{"secret", "ya29", "Bearer"} {
+		if strings.Contains(buf.String(), secret) {
+			t.Errorf("Format: the log leaked %q: %s", secret, buf.String())
+		}
+	}
+}
+
+func TestLogRedactor_settings(t *testing.T) {
+	store := NewSettingsStore(DefaultSettings())
+	r := NewLogRedactor(store)
+	blob := &pb.ReadBlobResponse{Data: make([]byte, 300), Offset: 5}
+
+	// Redactions keyed by a nested message apply wherever it appears.
+	s := store.Get()
+	s.LogRedactions = map[string][]string{"google.rpc.Status": {"message"}}
+	s.MaxLoggedBytes = 0
+	store.Set(s)
+	got := r.Format(&pb.EchoRequest{Response: &pb.EchoRequest_Error{Error: &spb.Status{Code: 3, Message: "hidden"}}})
+	if want := `error:<code:3 message:"<redacted:6 bytes>">`; got != want {
+		t.Errorf("Format: want %s got %s", want, got)
+	}
+	if got := r.Format(blob); strings.Contains(got, "redacted") {
+		t.Errorf("Format with max_logged_bytes 0: want the data whole got %.40s", got)
+	}
+
+	if got, want := r.Format("not a message"), "not a message"; got != want {
+		t.Errorf("Format: want %s got %s", want, got)
+	}
+	if got, want := r.Format((*pb.EchoRequest)(nil)), "<nil>"; got != want {
+		t.Errorf("Format: want %s got %s", want, got)
+	}
+}
+
+func TestLogRedactions_validate(t *testing.T) {
+	tests := []struct {
+		redactions map[string][]string
+		want       string
+	}{
+		{map[string][]string{"google.showcase.v1beta1.Nope": {"content"}}, "not a known message"},
+		{map[string][]string{"google.showcase.v1beta1.EchoRequest": {"nope"}}, "has no field `nope`"},
+		{map[string][]string{"google.showcase.v1beta1.EchoRequest": {"content.x"}}, "`content` is not a message"},
+		{map[string][]string{"google.showcase.v1beta1.EchoRequest": {"error.details.value"}}, ""},
+	}
+	for _, test := range tests {
+		s := DefaultSettings()
+		s.LogRedactions = test.redactions
+		err := s.Validate()
+		if test.want == "" {
+			if err != nil {
+				t.Errorf("Validate(%v): unexpected err %+v", test.redactions, err)
+			}
+			continue
+		}
+		if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("Validate(%v): want InvalidArgument containing %q got %v", test.redactions, test.want, err)
+		}
+	}
+
+	s := DefaultSettings()
+	s.MaxLoggedBytes = -1
+	if err := s.Validate(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Validate: want InvalidArgument for a negative max_logged_bytes got %v", err)
+	}
+}
+
+func TestUpdateSettings_logRedactions(t *testing.T) {
+	store := NewSettingsStore(DefaultSettings())
+	_, _, err := UpdateSettings(store, &pb.ShowcaseSettings{
+		LogRedactions: map[string]*pb.LogRedaction{
+			"google.showcase.v1beta1.Blurb": {Paths: []string{"text"}},
+		},
+		MaxLoggedBytes: 16,
+	}, []string{"log_redactions", "max_logged_bytes"})
+	if err != nil {
+		t.Fatalf("UpdateSettings: unexpected err %+v", err)
+	}
+	got := SettingsProto(store.Get())
+	if len(got.GetLogRedactions()) != 1 || got.GetMaxLoggedBytes() != 16 {
+		t.Errorf("UpdateSettings: want the new redactions got %v", got)
+	}
+	if got := NewLogRedactor(store).Format(&pb.Blurb{Content: &pb.Blurb_Text{Text: "hush"}}); got != `text:"<redacted:4 bytes>"` {
+		t.Errorf("Format: want the text redacted got %s", got)
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// ReplicaHeader is the response header in which a server serving several
+// replicas names the replica that handled a call.
+const ReplicaHeader = "showcase-replica"
+
+// getEndpointsMethod is the method that failing replicas still serve, so
+// that clients can discover the healthy ones from any replica.
+const getEndpointsMethod = "/google.showcase.v1beta1.Testing/GetEndpoints"
+
+var replicaSetSingleton = NewReplicaSet()
+
+// GetReplicaSetInstance returns the replica set singleton.
+func GetReplicaSetInstance() *ReplicaSet {
+	return replicaSetSingleton
+}
+
+// ReplicaSet is the set of listeners a server serves the same services on,
+// each a replica with an ID of its own.
+type ReplicaSet struct {
+	mu      sync.Mutex
+	addrs   []string
+	failing int
+}
+
+// NewReplicaSet returns an empty replica set.
+func NewReplicaSet() *ReplicaSet {
+	return &ReplicaSet{failing: -1}
+}
+
+// ListenReplicas announces n replicas on the given network, on consecutive
+// ports starting at the port of the address, or on a port of their own each
+// if the port is 0. The replica with the ID failing, if any, fails every
+// call but GetEndpoints with UNAVAILABLE. The listeners tag the connections
+// they accept with their replica ID.
+func (r *ReplicaSet) ListenReplicas(network, address string, n, failing int) ([]net.Listener, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("the number of replicas must be positive, not %d", n)
+	}
+	if failing >= n {
+		return nil, fmt.Errorf("the failing replica %d is not one of the %d replicas", failing, n)
+	}
+	lis, err := Listen(network, address)
+	if err != nil {
+		return nil, err
+	}
+	listeners := []net.Listener{lis}
+	closeAll := func() {
+		for _, l := range listeners {
+			l.Close()
+		}
+	}
+	host, port, err := net.SplitHostPort(lis.Addr().String())
+	if err != nil {
+		closeAll()
+		return nil, err
+	}
+	first, _ := strconv.Atoi(port)
+	consecutive := portOf(address) != "0"
+	for i := 1; i < n; i++ {
+		next := "0"
+		if consecutive {
+			next = strconv.Itoa(first + i)
+		}
+		l, err := Listen(network, net.JoinHostPort(host, next))
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+		listeners = append(listeners, l)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.addrs = nil
+	r.failing = failing
+	for i, l := range listeners {
+		r.addrs = append(r.addrs, l.Addr().String())
+		listeners[i] = &replicaListener{Listener: l, replica: i}
+	}
+	return listeners, nil
+}
+
+// portOf returns the port of an address given as to Listen.
+func portOf(address string) string {
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	return port
+}
+
+// Endpoints returns the addresses of the replicas and whether each is
+// healthy.
+func (r *ReplicaSet) Endpoints() []*pb.Endpoint {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	endpoints := make([]*pb.Endpoint, 0, len(r.addrs))
+	for i, addr := range r.addrs {
+		endpoints = append(endpoints, &pb.Endpoint{Replica: int32(i), Address: addr, Healthy: i != r.failing})
+	}
+	return endpoints
+}
+
+// replica checks the call of the context against the replica it arrived
+// on, and sends the ID of the replica in the ReplicaHeader.
+func (r *ReplicaSet) replica(ctx context.Context, method string, setHeader func(metadata.MD) error) error {
+	replica, ok := ReplicaFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	setHeader(metadata.Pairs(ReplicaHeader, strconv.Itoa(replica)))
+	r.mu.Lock()
+	failing := r.failing
+	r.mu.Unlock()
+	if replica == failing && method != getEndpointsMethod {
+		return status.Errorf(codes.Unavailable, "The replica %d is marked as failing.", replica)
+	}
+	return nil
+}
+
+// UnaryInterceptor sends the replica of a unary call in its header, and
+// fails it if the replica is failing.
+func (r *ReplicaSet) UnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	setHeader := func(md metadata.MD) error { return grpc.SetHeader(ctx, md) }
+	if err := r.replica(ctx, info.FullMethod, setHeader); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor sends the replica of a streaming call in its header,
+// and fails it if the replica is failing.
+func (r *ReplicaSet) StreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	if err := r.replica(ss.Context(), info.FullMethod, ss.SetHeader); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// ReplicaFromContext returns the ID of the replica the call of the context
+// arrived on, or reports false if it did not arrive on a replica.
+func ReplicaFromContext(ctx context.Context) (int, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return 0, false
+	}
+	addr, ok := p.Addr.(replicaAddr)
+	return addr.replica, ok
+}
+
+// replicaListener tags the connections it accepts with its replica ID, in
+// their remote address, which gRPC passes on to the peer of each call.
+type replicaListener struct {
+	net.Listener
+	replica int
+}
+
+func (l *replicaListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &replicaConn{Conn: conn, replica: l.replica}, nil
+}
+
+type replicaConn struct {
+	net.Conn
+	replica int
+}
+
+func (c *replicaConn) RemoteAddr() net.Addr {
+	return replicaAddr{Addr: c.Conn.RemoteAddr(), replica: c.replica}
+}
+
+// replicaAddr is the remote address of a connection to a replica.
+type replicaAddr struct {
+	net.Addr
+	replica int
+}
@@ -0,0 +1,167 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/balancer/roundrobin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+	"google.golang.org/grpc/status"
+)
+
+// startReplicas serves an echo server on n replicas, of which the one with
+// the ID failing fails.
+func startReplicas(t *testing.T, n, failing int) (*ReplicaSet, func()) {
+	replicas := NewReplicaSet()
+	listeners, err := replicas.ListenReplicas("tcp", "localhost:0", n, failing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(replicas.UnaryInterceptor),
+		grpc.StreamInterceptor(replicas.StreamInterceptor))
+	pb.RegisterEchoServer(s, testEchoServer{})
+	for _, l := range listeners {
+		go s.Serve(l)
+	}
+	return replicas, s.Stop
+}
+
+func TestReplicaSet_endpoints(t *testing.T) {
+	replicas, stop := startReplicas(t, 3, 1)
+	defer stop()
+	endpoints := replicas.Endpoints()
+	if len(endpoints) != 3 {
+		t.Fatalf("Endpoints: want 3, got %v", endpoints)
+	}
+	seen := map[string]bool{}
+	for i, e := range endpoints {
+		if e.GetReplica() != int32(i) || e.GetHealthy() != (i != 1) || seen[e.GetAddress()] {
+			t.Errorf("Endpoints: want replica %d at a distinct address, healthy unless 1, got %v", i, e)
+		}
+		seen[e.GetAddress()] = true
+	}
+}
+
+func TestReplicaSet_consecutivePorts(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := lis.Addr().(*net.TCPAddr).Port
+	lis.Close()
+	listeners, err := NewReplicaSet().ListenReplicas("tcp", "localhost:"+strconv.Itoa(port), 2, -1)
+	if err != nil {
+		t.Skipf("ports %d and %d are not both free: %v", port, port+1, err)
+	}
+	defer listeners[0].Close()
+	defer listeners[1].Close()
+	if got := listeners[1].Addr().(*net.TCPAddr).Port; got != port+1 {
+		t.Errorf("ListenReplicas: want the second replica on port %d, got %d", port+1, got)
+	}
+}
+
+func TestReplicaSet_invalid(t *testing.T) {
+	if _, err := NewReplicaSet().ListenReplicas("tcp", "localhost:0", 0, -1); err == nil {
+		t.Errorf("ListenReplicas of no replicas: want an error")
+	}
+	if _, err := NewReplicaSet().ListenReplicas("tcp", "localhost:0", 2, 2); err == nil {
+		t.Errorf("ListenReplicas with a failing replica out of range: want an error")
+	}
+}
+
+func TestReplicaSet_roundRobinAndFailover(t *testing.T) {
+	replicas, stop := startReplicas(t, 3, 0)
+	defer stop()
+	endpoints := replicas.Endpoints()
+
+	r, cleanup := manual.GenerateAndRegisterManualResolver()
+	defer cleanup()
+	var addrs []resolver.Address
+	for _, e := range endpoints {
+		addrs = append(addrs, resolver.Address{Addr: e.GetAddress()})
+	}
+	r.InitialAddrs(addrs)
+	conn, err := grpc.Dial(r.Scheme()+":///replicas", grpc.WithInsecure(), grpc.WithBalancerName(roundrobin.Name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewEchoClient(conn)
+
+	// Round robin reaches every replica once all are connected.
+	served := map[string]bool{}
+	failed := false
+	deadline := time.Now().Add(5 * time.Second)
+	for (len(served) < 2 || !failed) && time.Now().Before(deadline) {
+		var md metadata.MD
+		_, err := client.Echo(context.Background(), &pb.EchoRequest{}, grpc.Header(&md))
+		switch status.Code(err) {
+		case codes.OK:
+			served[md.Get(ReplicaHeader)[0]] = true
+		case codes.Unavailable:
+			failed = true
+		default:
+			t.Fatal(err)
+		}
+	}
+	if !served["1"] || !served["2"] || served["0"] || !failed {
+		t.Errorf("round robin: want calls served by replicas 1 and 2 and failed by 0, got %v, %t", served, failed)
+	}
+
+	// A client that fails over from the failing replica reaches the next.
+	for _, e := range endpoints {
+		conn, err := grpc.Dial(e.GetAddress(), grpc.WithInsecure())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var md metadata.MD
+		_, err = pb.NewEchoClient(conn).Echo(context.Background(), &pb.EchoRequest{}, grpc.Header(&md))
+		conn.Close()
+		if status.Code(err) == codes.Unavailable {
+			continue
+		}
+		if err != nil || md.Get(ReplicaHeader)[0] != "1" {
+			t.Errorf("failover: want replica 1 to serve the call, got %v, %v", md, err)
+		}
+		break
+	}
+}
+
+func TestReplicaSet_failingServesGetEndpoints(t *testing.T) {
+	replicas := NewReplicaSet()
+	replicas.failing = 0
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: replicaAddr{Addr: &net.TCPAddr{}, replica: 0}})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	if _, err := replicas.UnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: getEndpointsMethod}, handler); err != nil {
+		t.Errorf("GetEndpoints on a failing replica: want it served, got %v", err)
+	}
+	if _, err := replicas.UnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/a.B/C"}, handler); status.Code(err) != codes.Unavailable {
+		t.Errorf("a call on a failing replica: want Unavailable, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ResourceWildcard is the value of a variable segment that stands for any
+// value.
+const ResourceWildcard = "*"
+
+// ResourcePattern is a resource name template such as
+// `users/{user}/messages/{message}`.
+type ResourcePattern struct {
+	Template string
+	segments []string
+}
+
+// NewResourcePattern returns the pattern of the given template. Segments of
+// the form `{name}` are variables and the rest are literals.
+func NewResourcePattern(template string) ResourcePattern {
+	return ResourcePattern{Template: template, segments: strings.Split(template, "/")}
+}
+
+// variable returns the variable name of the i-th segment of the pattern, if
+// it is a variable.
+func (p ResourcePattern) variable(i int) (string, bool) {
+	seg := p.segments[i]
+	if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+		return seg[1 : len(seg)-1], true
+	}
+	return "", false
+}
+
+// matchedPrefix returns how many leading segments of the name the pattern
+// matches.
+func (p ResourcePattern) matchedPrefix(segments []string) int {
+	i := 0
+	for ; i < len(p.segments) && i < len(segments); i++ {
+		if _, ok := p.variable(i); !ok && p.segments[i] != segments[i] {
+			break
+		}
+	}
+	return i
+}
+
+// ParsedResourceName is a resource name broken down by the pattern it
+// matched.
+type ParsedResourceName struct {
+	Pattern ResourcePattern
+
+	// Segments holds the percent-decoded values of the variables.
+	Segments map[string]string
+
+	// Wildcards holds the variables whose segment is ResourceWildcard, in
+	// the order of the pattern.
+	Wildcards []string
+}
+
+// ParseResourceName returns the breakdown of the name by the first of the
+// patterns it matches. Otherwise, the error names the first offending
+// segment, counting from 1.
+func ParseResourceName(name string, patterns ...ResourcePattern) (*ParsedResourceName, error) {
+	if name == "" {
+		return nil, fmt.Errorf("it is empty")
+	}
+	segments := strings.Split(name, "/")
+	decoded := make([]string, len(segments))
+	for i, seg := range segments {
+		if seg == "" {
+			return nil, fmt.Errorf("segment %d is empty", i+1)
+		}
+		d, err := url.PathUnescape(seg)
+		if err != nil {
+			return nil, fmt.Errorf("segment %d (%q) is not validly percent-encoded", i+1, seg)
+		}
+		decoded[i] = d
+	}
+
+	furthest := 0
+	for _, p := range patterns {
+		n := p.matchedPrefix(segments)
+		if n == len(segments) && n == len(p.segments) {
+			parsed := &ParsedResourceName{Pattern: p, Segments: map[string]string{}}
+			for i := range p.segments {
+				if v, ok := p.variable(i); ok {
+					parsed.Segments[v] = decoded[i]
+					if segments[i] == ResourceWildcard {
+						parsed.Wildcards = append(parsed.Wildcards, v)
+					}
+				}
+			}
+			return parsed, nil
+		}
+		if n > furthest {
+			furthest = n
+		}
+	}
+	if furthest == len(segments) {
+		return nil, fmt.Errorf("it ends after segment %d, before any pattern is complete", furthest)
+	}
+	return nil, fmt.Errorf("segment %d (%q) does not match any pattern", furthest+1, segments[furthest])
+}
@@ -0,0 +1,84 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"reflect"
+	"testing"
+)
+
+var (
+	userPattern    = NewResourcePattern("users/{user}")
+	messagePattern = NewResourcePattern("users/{user}/messages/{message}")
+)
+
+func TestParseResourceName(t *testing.T) {
+	tests := []struct {
+		name      string
+		pattern   string
+		segments  map[string]string
+		wildcards []string
+	}{
+		{"users/alice", "users/{user}", map[string]string{"user": "alice"}, nil},
+		{"users/alice/messages/1", "users/{user}/messages/{message}", map[string]string{"user": "alice", "message": "1"}, nil},
+		// A variable may take the value of a literal.
+		{"users/messages", "users/{user}", map[string]string{"user": "messages"}, nil},
+		{"users/users/messages/messages", "users/{user}/messages/{message}", map[string]string{"user": "users", "message": "messages"}, nil},
+		// Values are percent-decoded, so encoded slashes do not split them.
+		{"users/a%2Fb", "users/{user}", map[string]string{"user": "a/b"}, nil},
+		{"users/al%20ice/messages/%E2%9C%93", "users/{user}/messages/{message}", map[string]string{"user": "al ice", "message": "✓"}, nil},
+		{"users/*/messages/1", "users/{user}/messages/{message}", map[string]string{"user": "*", "message": "1"}, []string{"user"}},
+		{"users/%2A", "users/{user}", map[string]string{"user": "*"}, nil},
+	}
+	for _, test := range tests {
+		got, err := ParseResourceName(test.name, userPattern, messagePattern)
+		if err != nil {
+			t.Errorf("ParseResourceName(%q): unexpected err %+v", test.name, err)
+			continue
+		}
+		if got.Pattern.Template != test.pattern ||
+			!reflect.DeepEqual(got.Segments, test.segments) ||
+			!reflect.DeepEqual(got.Wildcards, test.wildcards) {
+			t.Errorf("ParseResourceName(%q): want (%s, %v, %v) got (%s, %v, %v)",
+				test.name, test.pattern, test.segments, test.wildcards,
+				got.Pattern.Template, got.Segments, got.Wildcards)
+		}
+	}
+}
+
+func TestParseResourceName_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"", "it is empty"},
+		{"users/alice/", "segment 3 is empty"},
+		{"users//messages/1", "segment 2 is empty"},
+		{"/users/alice", "segment 1 is empty"},
+		{"users/a%zz", `segment 2 ("a%zz") is not validly percent-encoded`},
+		{"rooms/a", `segment 1 ("rooms") does not match any pattern`},
+		{"users/alice/posts/1", `segment 3 ("posts") does not match any pattern`},
+		{"users/alice/messages/1/x", `segment 5 ("x") does not match any pattern`},
+		{"users/alice/messages", "it ends after segment 3, before any pattern is complete"},
+		{"users", "it ends after segment 1, before any pattern is complete"},
+		{"users%2Falice", `segment 1 ("users%2Falice") does not match any pattern`},
+	}
+	for _, test := range tests {
+		_, err := ParseResourceName(test.name, userPattern, messagePattern)
+		if err == nil || err.Error() != test.want {
+			t.Errorf("ParseResourceName(%q): want err %q got %v", test.name, test.want, err)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ResponseFieldMaskHeader is the metadata key, and HTTP header, with which
+// REST clients select the fields of a response: a comma-separated list of
+// paths whose segments may use either the proto or the JSON names of the
+// fields, such as "content,transportInfo.user_agent".
+const ResponseFieldMaskHeader = "x-goog-fieldmask"
+
+// ResponseFieldMaskParameter is the HTTP query parameter that selects the
+// fields of a response as ResponseFieldMaskHeader does. It takes precedence
+// over the header.
+const ResponseFieldMaskParameter = "$fields"
+
+// ParseResponseFieldMask parses the paths of a ResponseFieldMaskHeader into a
+// mask over messages like msg, naming fields by their proto names. An empty
+// value selects every field. If a path is not valid, as CheckFieldMask
+// decides, it returns an INVALID_ARGUMENT error with a BadRequest detail on
+// source, the header or parameter that gave the value.
+func ParseResponseFieldMask(source, value string, msg proto.Message) (*field_mask.FieldMask, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	t := reflect.TypeOf(msg).Elem()
+	mask := &field_mask.FieldMask{}
+	for _, path := range strings.Split(value, ",") {
+		path = protoMaskPath(t, strings.TrimSpace(path))
+		if desc := checkMaskPath(t, path); desc != "" {
+			return nil, showcaseerrors.BadRequest(
+				showcaseerrors.FieldInvalid,
+				source,
+				fmt.Sprintf("The %s has the path `%s`, %s.", source, path, desc))
+		}
+		mask.Paths = append(mask.GetPaths(), path)
+	}
+	return mask, nil
+}
+
+// protoMaskPath returns the path over messages of the struct type t with the
+// JSON names of its fields replaced by their proto names. Segments that name
+// no field are left for checkMaskPath to reject.
+func protoMaskPath(t reflect.Type, path string) string {
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		f, ok := jsonFields(t)[segment]
+		if !ok {
+			break
+		}
+		segments[i] = f.origName
+		if f.typ.Kind() != reflect.Ptr || f.typ.Elem().Kind() != reflect.Struct {
+			break
+		}
+		t = f.typ.Elem()
+	}
+	return strings.Join(segments, ".")
+}
+
+// responseFieldMask returns the mask of the ResponseFieldMaskHeader of the
+// call, over the responses of the method, or nil if the call has none.
+func responseFieldMask(ctx context.Context, method string) (*field_mask.FieldMask, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(ResponseFieldMaskHeader)
+	if len(values) == 0 {
+		return nil, nil
+	}
+	output, ok := ShowcaseMethodOutput(method)
+	if !ok {
+		return nil, showcaseerrors.Metadata(
+			ResponseFieldMaskHeader,
+			"The %s metadata is only supported by the methods of the Showcase services.",
+			ResponseFieldMaskHeader)
+	}
+	msg := reflect.New(proto.MessageType(output).Elem()).Interface().(proto.Message)
+	return ParseResponseFieldMask(ResponseFieldMaskHeader, strings.Join(values, ","), msg)
+}
+
+// ResponseFieldMaskUnaryInterceptor rejects calls whose
+// ResponseFieldMaskHeader is not valid for the responses of their method, and
+// clears the fields it does not select from the responses of the others.
+func ResponseFieldMaskUnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	mask, err := responseFieldMask(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := handler(ctx, req)
+	if msg, ok := resp.(proto.Message); ok && err == nil && mask != nil {
+		// Responses may be cached, so they are copied rather than cleared.
+		resp = MaskedCopy(mask, msg)
+	}
+	return resp, err
+}
+
+// ResponseFieldMaskStreamInterceptor is ResponseFieldMaskUnaryInterceptor for
+// streams, clearing the fields of every response sent.
+func ResponseFieldMaskStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	mask, err := responseFieldMask(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	if mask == nil {
+		return handler(srv, ss)
+	}
+	return handler(srv, &maskedStream{ServerStream: ss, mask: mask})
+}
+
+// maskedStream clears the fields the mask does not select from the messages
+// it sends.
+type maskedStream struct {
+	grpc.ServerStream
+	mask *field_mask.FieldMask
+}
+
+func (s *maskedStream) SendMsg(m interface{}) error {
+	if msg, ok := m.(proto.Message); ok {
+		m = MaskedCopy(s.mask, msg)
+	}
+	return s.ServerStream.SendMsg(m)
+}
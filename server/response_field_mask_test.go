@@ -0,0 +1,171 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseResponseFieldMask(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"", nil},
+		{"content", []string{"content"}},
+		{"clientSequence", []string{"client_sequence"}},
+		{"client_sequence, locale", []string{"client_sequence", "locale"}},
+		{"transportInfo.userAgent", []string{"transport_info.user_agent"}},
+		{"transport_info.userAgent", []string{"transport_info.user_agent"}},
+		{"transportInfo", []string{"transport_info"}},
+		{"*", []string{"*"}},
+	}
+	for _, test := range tests {
+		mask, err := ParseResponseFieldMask(ResponseFieldMaskHeader, test.value, &pb.EchoResponse{})
+		if err != nil {
+			t.Errorf("ParseResponseFieldMask(%q): unexpected err %v", test.value, err)
+			continue
+		}
+		if got := mask.GetPaths(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ParseResponseFieldMask(%q): want %q got %q", test.value, test.want, got)
+		}
+	}
+}
+
+func TestParseResponseFieldMask_invalid(t *testing.T) {
+	tests := []struct {
+		value   string
+		msg     proto.Message
+		wantMsg string
+	}{
+		{"contents", &pb.EchoResponse{}, "has no field `contents`"},
+		{"transportInfo.agent", &pb.EchoResponse{}, "has no field `agent`"},
+		{"content.length", &pb.EchoResponse{}, "`content` is not a message"},
+		{"*.content", &pb.EchoResponse{}, "`*` may only end a path"},
+		{"results.index", &pb.BatchEchoResponse{}, "`results` is repeated"},
+	}
+	for _, test := range tests {
+		_, err := ParseResponseFieldMask(ResponseFieldMaskParameter, test.value, test.msg)
+		s, _ := status.FromError(err)
+		if s.Code() != codes.InvalidArgument || !strings.Contains(s.Message(), test.wantMsg) {
+			t.Errorf("ParseResponseFieldMask(%q): want InvalidArgument containing %q got %v", test.value, test.wantMsg, err)
+			continue
+		}
+		want := showcaseerrors.BadRequest(showcaseerrors.FieldInvalid, ResponseFieldMaskParameter, s.Message())
+		if !proto.Equal(s.Proto(), status.Convert(want).Proto()) {
+			t.Errorf("ParseResponseFieldMask(%q): want %v got %v", test.value, want, err)
+		}
+	}
+}
+
+func TestResponseFieldMaskUnaryInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/google.showcase.v1beta1.Echo/Echo"}
+	resp := &pb.EchoResponse{Content: "hi", ClientSequence: 2, TransportInfo: &pb.TransportInfo{UserAgent: "test", Protocol: "h2"}}
+	handler := func(context.Context, interface{}) (interface{}, error) { return resp, nil }
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ResponseFieldMaskHeader, "clientSequence,transportInfo.userAgent"))
+	got, err := ResponseFieldMaskUnaryInterceptor(ctx, &pb.EchoRequest{}, info, handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &pb.EchoResponse{ClientSequence: 2, TransportInfo: &pb.TransportInfo{UserAgent: "test"}}
+	if !proto.Equal(got.(proto.Message), want) {
+		t.Errorf("Echo: want %v got %v", want, got)
+	}
+	if resp.GetContent() != "hi" {
+		t.Errorf("Echo: the handler's response was cleared")
+	}
+
+	got, err = ResponseFieldMaskUnaryInterceptor(context.Background(), &pb.EchoRequest{}, info, handler)
+	if err != nil || got != resp {
+		t.Errorf("Echo without a mask: want the handler's response got %v, %v", got, err)
+	}
+
+	called := false
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(ResponseFieldMaskHeader, "contents"))
+	_, err = ResponseFieldMaskUnaryInterceptor(ctx, &pb.EchoRequest{}, info, func(context.Context, interface{}) (interface{}, error) {
+		called = true
+		return resp, nil
+	})
+	if status.Code(err) != codes.InvalidArgument || called {
+		t.Errorf("Echo with an invalid mask: want InvalidArgument before the handler got %v, called %t", err, called)
+	}
+
+	other := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(ResponseFieldMaskHeader, "status"))
+	if _, err := ResponseFieldMaskUnaryInterceptor(ctx, nil, other, handler); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Check with a mask: want InvalidArgument got %v", err)
+	}
+}
+
+// sentStream records the messages sent on it.
+type sentStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []interface{}
+}
+
+func (s *sentStream) Context() context.Context { return s.ctx }
+
+func (s *sentStream) SendMsg(m interface{}) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func TestResponseFieldMaskStreamInterceptor(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: "/google.showcase.v1beta1.Echo/Expand"}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ResponseFieldMaskHeader, "server_sequence"))
+	stream := &sentStream{ctx: ctx}
+	err := ResponseFieldMaskStreamInterceptor(nil, stream, info, func(_ interface{}, ss grpc.ServerStream) error {
+		for i := int64(1); i <= 2; i++ {
+			if err := ss.SendMsg(&pb.EchoResponse{Content: "hi", ServerSequence: i}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stream.sent) != 2 {
+		t.Fatalf("Expand: want 2 responses got %d", len(stream.sent))
+	}
+	for i, m := range stream.sent {
+		want := &pb.EchoResponse{ServerSequence: int64(i + 1)}
+		if !proto.Equal(m.(proto.Message), want) {
+			t.Errorf("Expand response %d: want %v got %v", i, want, m)
+		}
+	}
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(ResponseFieldMaskHeader, "serverSeq"))
+	err = ResponseFieldMaskStreamInterceptor(nil, &sentStream{ctx: ctx}, info, func(interface{}, grpc.ServerStream) error {
+		t.Error("Expand with an invalid mask: the handler was called")
+		return nil
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expand with an invalid mask: want InvalidArgument got %v", err)
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// EchoPath is the HTTP path of Echo.Echo, as given by its http rule.
+const EchoPath = "/v1beta1/echo:echo"
+
+// WriteStatusPath is the HTTP path of Echo.GetWriteStatus, as given by its
+// http rule.
+const WriteStatusPath = "/v1beta1/echo:writeStatus"
+
+// NumericEdgeCasesPath is the HTTP path of Echo.RepeatNumericEdgeCases, as
+// given by its http rule.
+const NumericEdgeCasesPath = "/v1beta1/echo:repeatNumericEdgeCases"
+
+// NewEchoHTTPHandler returns an http.Handler that serves Echo.Echo over
+// HTTP/JSON at EchoPath, Echo.GetWriteStatus at WriteStatusPath and
+// Echo.RepeatNumericEdgeCases at NumericEdgeCasesPath.
+// Response field names follow the JSONNameStyleHeader of the request, and
+// request bodies are checked against it when the JSONStrictHeader is "true".
+// Responses hold only the fields selected by the ResponseFieldMaskParameter
+// or ResponseFieldMaskHeader of the request, if it has one. Response
+// metadata that Echo.Echo sets with SetHeader is written as HTTP headers.
+// Errors are written with WriteHTTPError.
+func NewEchoHTTPHandler(echo pb.EchoServer) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(EchoPath, func(w http.ResponseWriter, req *http.Request) {
+		if err := serveEcho(w, req, echo); err != nil {
+			WriteHTTPError(w, req, status.Convert(err))
+		}
+	})
+	mux.HandleFunc(WriteStatusPath, func(w http.ResponseWriter, req *http.Request) {
+		if err := serveWriteStatus(w, req, echo); err != nil {
+			WriteHTTPError(w, req, status.Convert(err))
+		}
+	})
+	mux.HandleFunc(NumericEdgeCasesPath, func(w http.ResponseWriter, req *http.Request) {
+		if err := serveNumericEdgeCases(w, req, echo); err != nil {
+			WriteHTTPError(w, req, status.Convert(err))
+		}
+	})
+	return mux
+}
+
+func serveEcho(w http.ResponseWriter, req *http.Request, echo pb.EchoServer) error {
+	if req.Method != http.MethodPost {
+		methodNotAllowed(w, req, http.MethodPost)
+		return nil
+	}
+	protoNames, err := jsonNameStyle(req)
+	if err != nil {
+		return err
+	}
+	mask, err := httpResponseFieldMask(req, &pb.EchoResponse{})
+	if err != nil {
+		return err
+	}
+	in := &pb.EchoRequest{}
+	if err := readJSON(req, in, "EchoRequest", protoNames); err != nil {
+		return err
+	}
+
+	ctx, headers := WithHeaderCollector(WithHTTPTransportInfo(req.Context(), req))
+	resp, err := echo.Echo(ctx, in)
+	if err != nil {
+		return err
+	}
+	writeHeaders(w, headers())
+	return writeJSON(w, MaskedCopy(mask, resp), protoNames)
+}
+
+func serveNumericEdgeCases(w http.ResponseWriter, req *http.Request, echo pb.EchoServer) error {
+	if req.Method != http.MethodPost {
+		methodNotAllowed(w, req, http.MethodPost)
+		return nil
+	}
+	protoNames, err := jsonNameStyle(req)
+	if err != nil {
+		return err
+	}
+	mask, err := httpResponseFieldMask(req, &pb.RepeatNumericEdgeCasesResponse{})
+	if err != nil {
+		return err
+	}
+	in := &pb.RepeatNumericEdgeCasesRequest{}
+	if err := readJSON(req, in, "RepeatNumericEdgeCasesRequest", protoNames); err != nil {
+		return err
+	}
+
+	resp, err := echo.RepeatNumericEdgeCases(WithHTTPTransportInfo(req.Context(), req), in)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, MaskedCopy(mask, resp), protoNames)
+}
+
+// methodNotAllowed writes a 405 Method Not Allowed response to a request that
+// does not use the one method its path allows, which the Allow header names.
+// The body is the UNIMPLEMENTED status gRPC gives for an unknown method.
+func methodNotAllowed(w http.ResponseWriter, req *http.Request, allowed string) {
+	w.Header().Set("Allow", allowed)
+	st := status.Newf(codes.Unimplemented, "%s %s is not supported.", req.Method, req.URL.Path)
+	writeHTTPStatus(w, st, http.StatusMethodNotAllowed)
+}
+
+// writeHeaders writes the response metadata of a call as HTTP headers,
+// with the caching hints of CacheControlHeader in Cache-Control as well.
+
+func writeHeaders(w http.ResponseWriter, md metadata.MD) {
+	for key, values := range md {
+		for _, v := range values {
+			w.Header().Add(key, v)
+		}
+	}
+	if values := md.Get(CacheControlHeader); len(values) > 0 {
+		w.Header().Set("Cache-Control", strings.Join(values, ", "))
+	}
+}
+
+// readJSON reads the JSON body of the request into msg, a message of the
+// named type, checking its field names first if the JSONStrictHeader of the
+// request is "true".
+func readJSON(req *http.Request, msg proto.Message, name string, protoNames bool) error {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return showcaseerrors.RequestBody("The request body could not be read: %s.", err)
+	}
+	if req.Header.Get(JSONStrictHeader) == "true" {
+		if err := checkJSONNames(body, msg, protoNames); err != nil {
+			return err
+		}
+	}
+	if err := jsonpb.Unmarshal(bytes.NewReader(body), msg); err != nil {
+		return showcaseerrors.RequestBody("The request body is not a valid %s: %s.", name, err)
+	}
+	return nil
+}
+
+func serveWriteStatus(w http.ResponseWriter, req *http.Request, echo pb.EchoServer) error {
+	if req.Method != http.MethodGet {
+		methodNotAllowed(w, req, http.MethodGet)
+		return nil
+	}
+	protoNames, err := jsonNameStyle(req)
+	if err != nil {
+		return err
+	}
+	mask, err := httpResponseFieldMask(req, &pb.WriteStatus{})
+	if err != nil {
+		return err
+	}
+	// Query parameters may use either name of the field.
+	query := req.URL.Query()
+	blobID := query.Get("blobId")
+	if ids, ok := query["blob_id"]; ok {
+		blobID = ids[0]
+	}
+	resp, err := echo.GetWriteStatus(WithHTTPTransportInfo(req.Context(), req), &pb.GetWriteStatusRequest{BlobId: blobID})
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, MaskedCopy(mask, resp), protoNames)
+}
+
+// httpResponseFieldMask returns the mask given by the
+// ResponseFieldMaskParameter of the request, or else by its
+// ResponseFieldMaskHeader, over responses like msg.
+func httpResponseFieldMask(req *http.Request, msg proto.Message) (*field_mask.FieldMask, error) {
+	if values, ok := req.URL.Query()[ResponseFieldMaskParameter]; ok {
+		return ParseResponseFieldMask(ResponseFieldMaskParameter, strings.Join(values, ","), msg)
+	}
+	return ParseResponseFieldMask(ResponseFieldMaskHeader, strings.Join(req.Header[http.CanonicalHeaderKey(ResponseFieldMaskHeader)], ","), msg)
+}
+
+// writeJSON writes the response as JSON, with the proto names of its fields
+// if protoNames is set.
+func writeJSON(w http.ResponseWriter, resp proto.Message, protoNames bool) error {
+	m := &jsonpb.Marshaler{OrigName: protoNames}
+	out, err := m.MarshalToString(resp)
+	if err != nil {
+		return status.Errorf(codes.Internal, "The response could not be marshaled: %s.", err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, err = fmt.Fprint(w, out)
+	return err
+}
@@ -0,0 +1,179 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEchoHTTPHandler(t *testing.T) {
+	tests := []struct {
+		style      string
+		strict     bool
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"", false, `{"content":"hi","clientSequence":2}`, 200, `{"content":"hi","clientSequence":"2"}`},
+		{"json", true, `{"content":"hi","clientSequence":2}`, 200, `{"content":"hi","clientSequence":"2"}`},
+		{"proto", false, `{"content":"hi","clientSequence":2}`, 200, `{"content":"hi","client_sequence":"2"}`},
+		{"proto", true, `{"content":"hi","client_sequence":2}`, 200, `{"content":"hi","client_sequence":"2"}`},
+		// Without strict decoding, either name is accepted.
+		{"json", false, `{"client_sequence":2}`, 200, `{"clientSequence":"2"}`},
+		{"json", true, `{"client_sequence":2}`, 400, `"field":"client_sequence"`},
+		{"proto", true, `{"clientSequence":2}`, 400, `"field":"clientSequence"`},
+		{"camel", false, `{}`, 400, `"code":3`},
+		{"", false, `{"content":`, 400, `"code":3`},
+	}
+	h := NewEchoHTTPHandler(testEchoServer{})
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodPost, EchoPath, strings.NewReader(test.body))
+		req.Header.Set(JSONNameStyleHeader, test.style)
+		if test.strict {
+			req.Header.Set(JSONStrictHeader, "true")
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != test.wantStatus {
+			t.Errorf("Echo(%q, %t, %s): want status %d got %d", test.style, test.strict, test.body, test.wantStatus, w.Code)
+		}
+		if got := w.Body.String(); !strings.Contains(got, test.wantBody) {
+			t.Errorf("Echo(%q, %t, %s): want body containing %s got %s", test.style, test.strict, test.body, test.wantBody, got)
+		}
+		if got := w.Header().Get("Content-Type"); got != "application/json" {
+			t.Errorf("Echo(%q, %t, %s): want Content-Type application/json got %q", test.style, test.strict, test.body, got)
+		}
+	}
+}
+
+func TestEchoHTTPHandler_method(t *testing.T) {
+	w := httptest.NewRecorder()
+	NewEchoHTTPHandler(testEchoServer{}).ServeHTTP(w, httptest.NewRequest(http.MethodGet, EchoPath, nil))
+	if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != http.MethodPost {
+		t.Errorf("GET %s: want status 405 allowing POST got %d allowing %q", EchoPath, w.Code, w.Header().Get("Allow"))
+	}
+	if body := w.Body.String(); !strings.Contains(body, `"code":12`) {
+		t.Errorf("GET %s: want an UNIMPLEMENTED status body got %s", EchoPath, body)
+	}
+}
+
+func TestEchoHTTPHandler_cacheControl(t *testing.T) {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, EchoPath, strings.NewReader(`{"content":"hi","cacheControl":{"maxAge":5}}`))
+	NewEchoHTTPHandler(testEchoServer{}).ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Echo with cacheControl: want status 200 got %d: %s", w.Code, w.Body)
+	}
+	if got := w.Header().Get("Cache-Control"); got != "max-age=5" {
+		t.Errorf("Echo with cacheControl: want Cache-Control %q got %q", "max-age=5", got)
+	}
+}
+
+func TestEchoHTTPHandler_fieldMask(t *testing.T) {
+	tests := []struct {
+		target     string
+		header     string
+		wantStatus int
+		wantBody   string
+	}{
+		{EchoPath, "", 200, `{"content":"hi","clientSequence":"2"}`},
+		{EchoPath, "clientSequence", 200, `{"clientSequence":"2"}`},
+		{EchoPath, "client_sequence", 200, `{"clientSequence":"2"}`},
+		{EchoPath + "?$fields=content", "", 200, `{"content":"hi"}`},
+		// The parameter takes precedence over the header.
+		{EchoPath + "?$fields=content", "clientSequence", 200, `{"content":"hi"}`},
+		{EchoPath + "?$fields=contents", "", 400, `"field":"$fields"`},
+		{EchoPath, "contents", 400, `"field":"x-goog-fieldmask"`},
+		{EchoPath, "transportInfo.agent", 400, "has no field `agent`"},
+		{WriteStatusPath + "?blobId=b", "", 200, `{"blobId":"b","totalSize":"5"}`},
+		{WriteStatusPath + "?blob_id=b&$fields=totalSize", "", 200, `{"totalSize":"5"}`},
+		{WriteStatusPath + "?blob_id=b", "blobId", 200, `{"blobId":"b"}`},
+		{WriteStatusPath + "?blob_id=b", "content", 400, `"field":"x-goog-fieldmask"`},
+	}
+	h := NewEchoHTTPHandler(testEchoServer{})
+	for _, test := range tests {
+		method := http.MethodPost
+		if strings.HasPrefix(test.target, WriteStatusPath) {
+			method = http.MethodGet
+		}
+		req := httptest.NewRequest(method, test.target, strings.NewReader(`{"content":"hi","clientSequence":2}`))
+		if test.header != "" {
+			req.Header.Set(ResponseFieldMaskHeader, test.header)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != test.wantStatus {
+			t.Errorf("%s %s (%q): want status %d got %d", method, test.target, test.header, test.wantStatus, w.Code)
+		}
+		if got := w.Body.String(); !strings.Contains(got, test.wantBody) {
+			t.Errorf("%s %s (%q): want body containing %s got %s", method, test.target, test.header, test.wantBody, got)
+		}
+	}
+}
+
+func TestEchoHTTPHandler_numericEdgeCases(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{"values":[{"doubleValue":"NaN","floatValue":"-Infinity"}]}`, `{"values":[{"doubleValue":"NaN","floatValue":"-Infinity"}]}`},
+		{`{"values":[{"doubleValue":1.5,"floatValue":"Infinity"}]}`, `{"values":[{"doubleValue":1.5,"floatValue":"Infinity"}]}`},
+		// 64-bit integers are read exactly whether they are strings or bare
+		// numbers, even beyond the integers a double holds, and are
+		// always written as strings.
+		{`{"values":[{"int64Value":"9007199254740993"}]}`, `{"values":[{"int64Value":"9007199254740993"}]}`},
+		{`{"values":[{"int64Value":9007199254740993}]}`, `{"values":[{"int64Value":"9007199254740993"}]}`},
+		{`{"values":[{"uint64Value":18446744073709551615,"fixed64Value":"18446744073709551615"}]}`, `{"values":[{"uint64Value":"18446744073709551615","fixed64Value":"18446744073709551615"}]}`},
+		{`{"values":[{"int64Value":-9223372036854775808}]}`, `{"values":[{"int64Value":"-9223372036854775808"}]}`},
+	}
+	h := NewEchoHTTPHandler(testEchoServer{})
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, NumericEdgeCasesPath, strings.NewReader(test.body)))
+		if w.Code != http.StatusOK || w.Body.String() != test.want {
+			t.Errorf("RepeatNumericEdgeCases(%s): want 200 %s got %d %s", test.body, test.want, w.Code, w.Body.String())
+		}
+	}
+
+	// Integers out of range, or given as decimals, are rejected.
+	for _, body := range []string{
+		`{"values":[{"int64Value":"9223372036854775808"}]}`,
+		`{"values":[{"int64Value":1.5}]}`,
+		`{"values":[{"uint64Value":-1}]}`,
+	} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, NumericEdgeCasesPath, strings.NewReader(body)))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("RepeatNumericEdgeCases(%s): want status 400 got %d %s", body, w.Code, w.Body.String())
+		}
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, NumericEdgeCasesPath, nil))
+	if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != http.MethodPost {
+		t.Errorf("GET %s: want status 405 allowing POST got %d allowing %q", NumericEdgeCasesPath, w.Code, w.Header().Get("Allow"))
+	}
+}
+
+func TestEchoHTTPHandler_writeStatusMethod(t *testing.T) {
+	w := httptest.NewRecorder()
+	NewEchoHTTPHandler(testEchoServer{}).ServeHTTP(w, httptest.NewRequest(http.MethodPost, WriteStatusPath, nil))
+	if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != http.MethodGet {
+		t.Errorf("POST %s: want status 405 allowing GET got %d allowing %q", WriteStatusPath, w.Code, w.Header().Get("Allow"))
+	}
+}
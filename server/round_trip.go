@@ -0,0 +1,77 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// MaxRoundTripCount is the most messages MeasureRoundTrips times.
+	MaxRoundTripCount = 10000
+
+	// MaxRoundTripBytes is the most payload bytes, across all messages, that
+	// MeasureRoundTrips encodes.
+	MaxRoundTripBytes = 64 << 20
+)
+
+// MeasureRoundTrips times the encoding and decoding of count EchoResponses
+// whose content is payloadSize bytes, and returns the durations in ascending
+// order. It stops with the status of the context if the context ends first.
+func MeasureRoundTrips(ctx context.Context, count, payloadSize int) ([]time.Duration, error) {
+	msg := &pb.EchoResponse{Content: strings.Repeat("x", payloadSize)}
+	durations := make([]time.Duration, 0, count)
+	for i := 0; i < count; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+		start := Now()
+		b, err := proto.Marshal(msg)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "The payload could not be encoded: %s.", err)
+		}
+		if err := proto.Unmarshal(b, &pb.EchoResponse{}); err != nil {
+			return nil, status.Errorf(codes.Internal, "The payload could not be decoded: %s.", err)
+		}
+		durations = append(durations, Now().Sub(start))
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	return durations, nil
+}
+
+// Percentile returns the nearest-rank pth percentile of the ascending
+// durations, or zero if there are none.
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
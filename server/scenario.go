@@ -0,0 +1,311 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var scenarioStoreSingleton = NewScenarioStore()
+
+// GetScenarioStoreInstance returns the scenario store singleton.
+func GetScenarioStoreInstance() ScenarioStore {
+	return scenarioStoreSingleton
+}
+
+// ScenarioStore keeps the active scenario of each namespace, and counts the
+// calls of the methods of its script.
+type ScenarioStore interface {
+	// Load makes the script the active scenario of the namespace, with no
+	// calls counted, and returns its state.
+	Load(namespace, name string, script *pb.ScenarioScript) *pb.Scenario
+
+	// Get returns the state of the active scenario of the namespace.
+	Get(namespace string) (*pb.Scenario, bool)
+
+	// Next counts a call to the method in the namespace, and returns the
+	// step of the active scenario that applies to it, with the name of the
+	// scenario and the index of the step. The step is nil if none applies.
+	Next(namespace, method string) (name string, index int, step *pb.ScenarioStep)
+
+	// PurgeNamespace removes the scenario of the namespace, and returns how
+	// many it removed.
+	PurgeNamespace(namespace string) int
+}
+
+// NewScenarioStore returns a store without scenarios.
+func NewScenarioStore() ScenarioStore {
+	return &scenarioStore{scenarios: map[string]*pb.Scenario{}}
+}
+
+type scenarioStore struct {
+	mu        sync.Mutex
+	scenarios map[string]*pb.Scenario
+}
+
+func (s *scenarioStore) Load(namespace, name string, script *pb.ScenarioScript) *pb.Scenario {
+	defer ChangeState()()
+	sc := &pb.Scenario{
+		Name:         name,
+		Script:       proto.Clone(script).(*pb.ScenarioScript),
+		CallCounts:   map[string]int64{},
+		AppliedCalls: make([]int64, len(script.GetSteps())),
+	}
+	for _, step := range script.GetSteps() {
+		sc.CallCounts[step.GetMethod()] = 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scenarios[namespace] = sc
+	return proto.Clone(sc).(*pb.Scenario)
+}
+
+func (s *scenarioStore) Get(namespace string) (*pb.Scenario, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sc, ok := s.scenarios[namespace]
+	if !ok {
+		return nil, false
+	}
+	return proto.Clone(sc).(*pb.Scenario), true
+}
+
+func (s *scenarioStore) Next(namespace, method string) (string, int, *pb.ScenarioStep) {
+	defer ChangeState()()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sc, ok := s.scenarios[namespace]
+	if !ok {
+		return "", 0, nil
+	}
+	n, ok := sc.CallCounts[method]
+	if !ok {
+		return "", 0, nil
+	}
+	n++
+	sc.CallCounts[method] = n
+	index, applied := 0, (*pb.ScenarioStep)(nil)
+	for i, step := range sc.GetScript().GetSteps() {
+		if step.GetMethod() == method && step.GetCallIndex() <= n && n <= lastScenarioCall(step) {
+			sc.AppliedCalls[i]++
+			index, applied = i, proto.Clone(step).(*pb.ScenarioStep)
+			break
+		}
+	}
+	sc.Exhausted = true
+	for _, step := range sc.GetScript().GetSteps() {
+		if sc.CallCounts[step.GetMethod()] < lastScenarioCall(step) {
+			sc.Exhausted = false
+		}
+	}
+	return sc.GetName(), index, applied
+}
+
+func (s *scenarioStore) PurgeNamespace(namespace string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.scenarios[namespace]; !ok {
+		return 0
+	}
+	delete(s.scenarios, namespace)
+	return 1
+}
+
+// lastScenarioCall returns the position of the last call the step applies
+// to.
+func lastScenarioCall(step *pb.ScenarioStep) int64 {
+	count := step.GetCallCount()
+	if count == 0 {
+		count = 1
+	}
+	return step.GetCallIndex() + count - 1
+}
+
+var (
+	scenarioNamePattern = regexp.MustCompile("^[a-z0-9][a-z0-9-]{0,62}$")
+	metadataKeyPattern  = regexp.MustCompile("^[a-z0-9][a-z0-9_.-]*$")
+)
+
+// ValidateScenario returns an INVALID_ARGUMENT error describing the first
+// field of the scenario's name or script that is missing or invalid.
+func ValidateScenario(name string, script *pb.ScenarioScript) error {
+	if name == "" {
+		return showcaseerrors.Field(showcaseerrors.FieldRequired, "name", "The field `name` is required.")
+	}
+	if !scenarioNamePattern.MatchString(name) {
+		return showcaseerrors.Field(
+			showcaseerrors.FieldInvalid,
+			"name",
+			"The field `name` %q must be 1 to 63 lowercase letters, digits and hyphens, starting with a letter or digit.",
+			name)
+	}
+	if len(script.GetSteps()) == 0 {
+		return showcaseerrors.Field(showcaseerrors.FieldRequired, "script.steps", "The field `script.steps` must hold at least one step.")
+	}
+	for i, step := range script.GetSteps() {
+		if err := validateScenarioStep(fmt.Sprintf("script.steps[%d]", i), step); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateScenarioStep(field string, step *pb.ScenarioStep) error {
+	method := step.GetMethod()
+	switch _, ok := ShowcaseMethodInput(method); {
+	case method == "":
+		return showcaseerrors.Field(showcaseerrors.FieldRequired, field+".method", "The field `%s.method` is required.", field)
+	case !ok:
+		return showcaseerrors.Field(
+			showcaseerrors.FieldInvalid,
+			field+".method",
+			"The field `%s.method` names %q, which is not a Showcase method. The methods are: %s.",
+			field,
+			method,
+			strings.Join(ShowcaseMethods(), ", "))
+	case strings.HasPrefix(method, "/google.showcase.v1beta1.Testing/"):
+		return showcaseerrors.Field(
+			showcaseerrors.FieldInvalid,
+			field+".method",
+			"The field `%s.method` names %s, but the methods of the Testing service cannot be scripted.",
+			field,
+			method)
+	}
+	if step.GetCallIndex() <= 0 {
+		return showcaseerrors.Field(showcaseerrors.FieldOutOfRange, field+".call_index", "The field `%s.call_index` must be positive.", field)
+	}
+	if step.GetCallCount() < 0 {
+		return showcaseerrors.Field(showcaseerrors.FieldOutOfRange, field+".call_count", "The field `%s.call_count` must not be negative.", field)
+	}
+	if d := step.GetDelay(); d != nil {
+		delay, err := ptypes.Duration(d)
+		if err != nil {
+			return showcaseerrors.Field(showcaseerrors.FieldInvalid, field+".delay", "The field `%s.delay` is not a valid duration: %s.", field, err)
+		}
+		if delay < 0 {
+			return showcaseerrors.Field(showcaseerrors.FieldOutOfRange, field+".delay", "The field `%s.delay` must not be negative.", field)
+		}
+	}
+	if _, ok := code.Code_name[int32(step.GetCode())]; !ok {
+		return showcaseerrors.Field(showcaseerrors.FieldInvalid, field+".code", "The field `%s.code` is not a valid google.rpc.Code.", field)
+	}
+	for key := range step.GetHeaders() {
+		if !metadataKeyPattern.MatchString(key) || strings.HasPrefix(key, "grpc-") || strings.HasSuffix(key, "-bin") {
+			return showcaseerrors.Field(
+				showcaseerrors.FieldInvalid,
+				field+".headers",
+				"The field `%s.headers` has the key %q, which must be a lowercase metadata key not starting with grpc- or ending with -bin.",
+				field,
+				key)
+		}
+	}
+	return nil
+}
+
+// ScenarioInterceptor applies the steps of the active scenario of each
+// call's namespace to the call.
+type ScenarioInterceptor struct {
+	store  ScenarioStore
+	afterF func(time.Duration) <-chan time.Time
+}
+
+// NewScenarioInterceptor returns a ScenarioInterceptor of the store that
+// waits out delays with afterF. A nil afterF waits with time.After.
+func NewScenarioInterceptor(store ScenarioStore, afterF func(time.Duration) <-chan time.Time) *ScenarioInterceptor {
+	if afterF == nil {
+		afterF = time.After
+	}
+	return &ScenarioInterceptor{store: store, afterF: afterF}
+}
+
+// apply counts the call and applies the step of the scenario it is due: it
+// waits out its delay, sends its headers with setHeader, and returns its
+// error, if any.
+func (i *ScenarioInterceptor) apply(ctx context.Context, method string, setHeader func(metadata.MD) error) error {
+	name, index, step := i.store.Next(NamespaceFromContext(ctx), method)
+	if step == nil {
+		return nil
+	}
+	if delay, _ := ptypes.Duration(step.GetDelay()); delay > 0 {
+		select {
+		case <-i.afterF(delay):
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	if len(step.GetHeaders()) > 0 {
+		if err := setHeader(metadata.New(step.GetHeaders())); err != nil {
+			return err
+		}
+	}
+	if step.GetCode() == code.Code_OK {
+		return nil
+	}
+	message := step.GetMessage()
+	if message == "" {
+		message = fmt.Sprintf("The call failed at step %d of the scenario %q.", index, name)
+	}
+	return status.ErrorProto(&spb.Status{
+		Code:    int32(step.GetCode()),
+		Message: message,
+		Details: []*any.Any{showcaseerrors.ErrorInfo(showcaseerrors.ScenarioStep, showcaseerrors.Domain, map[string]string{
+			"scenario": name,
+			"step":     strconv.Itoa(index),
+		})},
+	})
+}
+
+// UnaryInterceptor applies the scenario to a unary call.
+func (i *ScenarioInterceptor) UnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	setHeader := func(md metadata.MD) error { return grpc.SetHeader(ctx, md) }
+	if err := i.apply(ctx, info.FullMethod, setHeader); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor applies the scenario to a streaming call before the
+// handler sees the stream.
+func (i *ScenarioInterceptor) StreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	if err := i.apply(ss.Context(), info.FullMethod, ss.SetHeader); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
@@ -0,0 +1,358 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	scenarioEcho   = "/google.showcase.v1beta1.Echo/Echo"
+	scenarioExpand = "/google.showcase.v1beta1.Echo/Expand"
+)
+
+// nextSteps counts n calls of the method in the namespace, and returns the
+// indices of the steps applied to them, or -1 for calls no step applied to.
+func nextSteps(s ScenarioStore, namespace, method string, n int) []int {
+	steps := []int{}
+	for i := 0; i < n; i++ {
+		_, index, step := s.Next(namespace, method)
+		if step == nil {
+			index = -1
+		}
+		steps = append(steps, index)
+	}
+	return steps
+}
+
+func TestScenarioStore_indices(t *testing.T) {
+	s := NewScenarioStore()
+	s.Load("a", "third-is-slow", &pb.ScenarioScript{Steps: []*pb.ScenarioStep{
+		{Method: scenarioEcho, CallIndex: 3},
+		{Method: scenarioEcho, CallIndex: 5, CallCount: 3},
+		// Covered by the step before it, which is tried first, except for
+		// its last call.
+		{Method: scenarioEcho, CallIndex: 6, CallCount: 3},
+		{Method: scenarioExpand, CallIndex: 1},
+	}})
+	if got, want := nextSteps(s, "a", scenarioEcho, 10), []int{-1, -1, 0, -1, 1, 1, 1, 2, -1, -1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Next(Echo): want the steps %v got %v", want, got)
+	}
+	if got, want := nextSteps(s, "a", scenarioExpand, 2), []int{3, -1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Next(Expand): want the steps %v got %v", want, got)
+	}
+
+	sc, ok := s.Get("a")
+	if !ok {
+		t.Fatal("Get: want the scenario")
+	}
+	want := map[string]int64{scenarioEcho: 10, scenarioExpand: 2}
+	if !reflect.DeepEqual(sc.GetCallCounts(), want) {
+		t.Errorf("Get: want the call counts %v got %v", want, sc.GetCallCounts())
+	}
+	if got, want := sc.GetAppliedCalls(), []int64{1, 3, 1, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Get: want the applied calls %v got %v", want, got)
+	}
+	if sc.GetName() != "third-is-slow" || !sc.GetExhausted() {
+		t.Errorf("Get: want the exhausted scenario third-is-slow got %v", sc)
+	}
+}
+
+func TestScenarioStore_returnsStep(t *testing.T) {
+	s := NewScenarioStore()
+	step := &pb.ScenarioStep{Method: scenarioEcho, CallIndex: 1, Code: code.Code_ABORTED, Headers: map[string]string{"x": "y"}}
+	s.Load("a", "abort", &pb.ScenarioScript{Steps: []*pb.ScenarioStep{step}})
+	name, index, got := s.Next("a", scenarioEcho)
+	if name != "abort" || index != 0 || !proto.Equal(got, step) {
+		t.Errorf("Next: want abort, 0, %v got %s, %d, %v", step, name, index, got)
+	}
+	// The step returned is a copy.
+	got.Headers["x"] = "z"
+	sc, _ := s.Get("a")
+	if sc.GetScript().GetSteps()[0].GetHeaders()["x"] != "y" {
+		t.Errorf("Next: want a copy of the step, but changing it changed the scenario")
+	}
+}
+
+func TestScenarioStore_exhaustion(t *testing.T) {
+	s := NewScenarioStore()
+	s.Load("a", "two-methods", &pb.ScenarioScript{Steps: []*pb.ScenarioStep{
+		{Method: scenarioEcho, CallIndex: 2},
+		{Method: scenarioExpand, CallIndex: 1, CallCount: 2},
+	}})
+	exhausted := func() bool {
+		sc, _ := s.Get("a")
+		return sc.GetExhausted()
+	}
+	if loaded, _ := s.Get("a"); loaded.GetExhausted() || len(loaded.GetAppliedCalls()) != 2 {
+		t.Errorf("Load: want a fresh scenario got %v", loaded)
+	}
+	nextSteps(s, "a", scenarioEcho, 2)
+	nextSteps(s, "a", scenarioExpand, 1)
+	if exhausted() {
+		t.Errorf("Next: want the scenario running while Expand has a call to come")
+	}
+	nextSteps(s, "a", scenarioExpand, 1)
+	if !exhausted() {
+		t.Errorf("Next: want the scenario exhausted after its last call")
+	}
+	// Once exhausted, calls are still counted but no longer changed.
+	if got := nextSteps(s, "a", scenarioEcho, 3); !reflect.DeepEqual(got, []int{-1, -1, -1}) {
+		t.Errorf("Next after exhaustion: want no steps got %v", got)
+	}
+	if sc, _ := s.Get("a"); sc.GetCallCounts()[scenarioEcho] != 5 {
+		t.Errorf("Next after exhaustion: want 5 Echo calls counted got %v", sc.GetCallCounts())
+	}
+
+	// Loading a scenario starts it over.
+	s.Load("a", "two-methods", &pb.ScenarioScript{Steps: []*pb.ScenarioStep{{Method: scenarioEcho, CallIndex: 2}}})
+	if got := nextSteps(s, "a", scenarioEcho, 2); !reflect.DeepEqual(got, []int{-1, 0}) {
+		t.Errorf("Next after reloading: want the steps to apply again got %v", got)
+	}
+}
+
+func TestScenarioStore_scoping(t *testing.T) {
+	s := NewScenarioStore()
+	s.Load("a", "first-fails", &pb.ScenarioScript{Steps: []*pb.ScenarioStep{{Method: scenarioEcho, CallIndex: 1}}})
+
+	// Other namespaces and methods are neither changed nor counted.
+	if got := nextSteps(s, "b", scenarioEcho, 2); !reflect.DeepEqual(got, []int{-1, -1}) {
+		t.Errorf("Next(b): want no steps got %v", got)
+	}
+	if got := nextSteps(s, "a", scenarioExpand, 2); !reflect.DeepEqual(got, []int{-1, -1}) {
+		t.Errorf("Next(a, Expand): want no steps got %v", got)
+	}
+	if _, ok := s.Get("b"); ok {
+		t.Errorf("Get(b): want no scenario")
+	}
+	sc, _ := s.Get("a")
+	if want := map[string]int64{scenarioEcho: 0}; !reflect.DeepEqual(sc.GetCallCounts(), want) {
+		t.Errorf("Get(a): want the call counts %v got %v", want, sc.GetCallCounts())
+	}
+	if got := nextSteps(s, "a", scenarioEcho, 1); !reflect.DeepEqual(got, []int{0}) {
+		t.Errorf("Next(a, Echo): want the first step got %v", got)
+	}
+
+	if n := s.PurgeNamespace("b"); n != 0 {
+		t.Errorf("PurgeNamespace(b): want 0 got %d", n)
+	}
+	if n := s.PurgeNamespace("a"); n != 1 {
+		t.Errorf("PurgeNamespace(a): want 1 got %d", n)
+	}
+	if _, ok := s.Get("a"); ok {
+		t.Errorf("Get(a) after PurgeNamespace: want no scenario")
+	}
+	if got := nextSteps(s, "a", scenarioEcho, 1); !reflect.DeepEqual(got, []int{-1}) {
+		t.Errorf("Next(a) after PurgeNamespace: want no steps got %v", got)
+	}
+}
+
+func TestValidateScenario(t *testing.T) {
+	valid := &pb.ScenarioScript{Steps: []*pb.ScenarioStep{{
+		Method:    scenarioEcho,
+		CallIndex: 1,
+		CallCount: 2,
+		Delay:     ptypes.DurationProto(time.Second),
+		Code:      code.Code_UNAVAILABLE,
+		Headers:   map[string]string{"x-scenario": "1", "retry.after_ms": "5"},
+	}}}
+	if err := ValidateScenario("slow-echo", valid); err != nil {
+		t.Errorf("ValidateScenario: unexpected err %+v", err)
+	}
+	step := func(f func(*pb.ScenarioStep)) *pb.ScenarioScript {
+		s := &pb.ScenarioStep{Method: scenarioEcho, CallIndex: 1}
+		f(s)
+		return &pb.ScenarioScript{Steps: []*pb.ScenarioStep{s}}
+	}
+	tests := []struct {
+		name    string
+		script  *pb.ScenarioScript
+		reason  string
+		field   string
+		wantMsg string
+	}{
+		{"", valid, showcaseerrors.FieldRequired, "name", "The field `name` is required."},
+		{"Slow", valid, showcaseerrors.FieldInvalid, "name", "The field `name` \"Slow\" must be 1 to 63"},
+		{"s", nil, showcaseerrors.FieldRequired, "script.steps", "must hold at least one step"},
+		{"s", step(func(s *pb.ScenarioStep) { s.Method = "" }), showcaseerrors.FieldRequired, "script.steps[0].method", "The field `script.steps[0].method` is required."},
+		{"s", step(func(s *pb.ScenarioStep) { s.Method = "/a.B/C" }), showcaseerrors.FieldInvalid, "script.steps[0].method", "names \"/a.B/C\", which is not a Showcase method"},
+		{"s", step(func(s *pb.ScenarioStep) { s.Method = updateSettingsMethod }), showcaseerrors.FieldInvalid, "script.steps[0].method", "the methods of the Testing service cannot be scripted"},
+		{"s", step(func(s *pb.ScenarioStep) { s.CallIndex = 0 }), showcaseerrors.FieldOutOfRange, "script.steps[0].call_index", "must be positive"},
+		{"s", step(func(s *pb.ScenarioStep) { s.CallCount = -1 }), showcaseerrors.FieldOutOfRange, "script.steps[0].call_count", "must not be negative"},
+		{"s", step(func(s *pb.ScenarioStep) { s.Delay = ptypes.DurationProto(-time.Second) }), showcaseerrors.FieldOutOfRange, "script.steps[0].delay", "must not be negative"},
+		{"s", step(func(s *pb.ScenarioStep) { s.Code = 17 }), showcaseerrors.FieldInvalid, "script.steps[0].code", "is not a valid google.rpc.Code"},
+		{"s", step(func(s *pb.ScenarioStep) { s.Headers = map[string]string{"X-Upper": "1"} }), showcaseerrors.FieldInvalid, "script.steps[0].headers", "has the key \"X-Upper\""},
+		{"s", step(func(s *pb.ScenarioStep) { s.Headers = map[string]string{"grpc-status": "1"} }), showcaseerrors.FieldInvalid, "script.steps[0].headers", "not starting with grpc-"},
+		{"s", step(func(s *pb.ScenarioStep) { s.Headers = map[string]string{"x-bin": "1"} }), showcaseerrors.FieldInvalid, "script.steps[0].headers", "or ending with -bin"},
+		{
+			"s",
+			&pb.ScenarioScript{Steps: []*pb.ScenarioStep{{Method: scenarioEcho, CallIndex: 1}, {Method: scenarioEcho}}},
+			showcaseerrors.FieldOutOfRange,
+			"script.steps[1].call_index",
+			"The field `script.steps[1].call_index` must be positive.",
+		},
+	}
+	for _, test := range tests {
+		err := ValidateScenario(test.name, test.script)
+		st := status.Convert(err)
+		if st.Code() != codes.InvalidArgument || !strings.Contains(st.Message(), test.wantMsg) {
+			t.Errorf("ValidateScenario(%q, %v): want InvalidArgument containing %q got %v", test.name, test.script, test.wantMsg, err)
+			continue
+		}
+		want := showcaseerrors.ErrorInfo(test.reason, showcaseerrors.Domain, map[string]string{"field": test.field})
+		if details := st.Proto().GetDetails(); len(details) != 1 || !proto.Equal(details[0], want) {
+			t.Errorf("ValidateScenario(%q, %v): want %s on %s got %v", test.name, test.script, test.reason, test.field, details)
+		}
+	}
+}
+
+// headerTransportStream records the headers set on it.
+type headerTransportStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *headerTransportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func TestScenarioUnaryInterceptor(t *testing.T) {
+	store := NewScenarioStore()
+	store.Load("a", "script", &pb.ScenarioScript{Steps: []*pb.ScenarioStep{
+		{Method: scenarioEcho, CallIndex: 2, Delay: ptypes.DurationProto(3 * time.Second), Headers: map[string]string{"x-step": "slow"}},
+		{Method: scenarioEcho, CallIndex: 3, Code: code.Code_UNAVAILABLE, Headers: map[string]string{"x-step": "fail"}},
+		{Method: scenarioEcho, CallIndex: 4, Code: code.Code_RESOURCE_EXHAUSTED, Message: "Slow down."},
+	}})
+	waits := []time.Duration{}
+	after := func(d time.Duration) <-chan time.Time {
+		waits = append(waits, d)
+		c := make(chan time.Time, 1)
+		c <- time.Time{}
+		return c
+	}
+	i := NewScenarioInterceptor(store, after)
+	info := &grpc.UnaryServerInfo{FullMethod: scenarioEcho}
+
+	tests := []struct {
+		wantCode   codes.Code
+		wantMsg    string
+		wantHeader string
+	}{
+		{codes.OK, "", ""},
+		{codes.OK, "", "slow"},
+		{codes.Unavailable, "The call failed at step 1 of the scenario \"script\".", "fail"},
+		{codes.ResourceExhausted, "Slow down.", ""},
+		{codes.OK, "", ""},
+	}
+	for n, test := range tests {
+		ts := &headerTransportStream{}
+		ctx := grpc.NewContextWithServerTransportStream(WithNamespace(context.Background(), "a"), ts)
+		handled := false
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			handled = true
+			return nil, nil
+		}
+		_, err := i.UnaryInterceptor(ctx, nil, info, handler)
+		st := status.Convert(err)
+		if st.Code() != test.wantCode || st.Message() != test.wantMsg || handled != (test.wantCode == codes.OK) {
+			t.Errorf("call %d: want %s %q got %v, handled %t", n+1, test.wantCode, test.wantMsg, err, handled)
+		}
+		if got := strings.Join(ts.header.Get("x-step"), ","); got != test.wantHeader {
+			t.Errorf("call %d: want the header %q got %q", n+1, test.wantHeader, got)
+		}
+		if test.wantCode != codes.OK {
+			want := showcaseerrors.ErrorInfo("SCENARIO_STEP", showcaseerrors.Domain, map[string]string{"scenario": "script", "step": strconv.Itoa(n - 1)})
+			if details := st.Proto().GetDetails(); len(details) != 1 || !proto.Equal(details[0], want) {
+				t.Errorf("call %d: want a SCENARIO_STEP ErrorInfo got %v", n+1, details)
+			}
+		}
+	}
+	if !reflect.DeepEqual(waits, []time.Duration{3 * time.Second}) {
+		t.Errorf("UnaryInterceptor: want one wait of 3s got %v", waits)
+	}
+}
+
+func TestScenarioUnaryInterceptor_deadline(t *testing.T) {
+	store := NewScenarioStore()
+	store.Load("a", "slow", &pb.ScenarioScript{Steps: []*pb.ScenarioStep{
+		{Method: scenarioEcho, CallIndex: 1, Delay: ptypes.DurationProto(time.Hour)},
+	}})
+	i := NewScenarioInterceptor(store, func(time.Duration) <-chan time.Time { return nil })
+	ctx, cancel := context.WithTimeout(WithNamespace(context.Background(), "a"), time.Millisecond)
+	defer cancel()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		t.Errorf("UnaryInterceptor: want the handler not called after the deadline")
+		return nil, nil
+	}
+	if _, err := i.UnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: scenarioEcho}, handler); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("UnaryInterceptor: want DeadlineExceeded got %v", err)
+	}
+}
+
+// headerStream is a stream of the context that records the headers set on
+// it.
+type headerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *headerStream) Context() context.Context { return s.ctx }
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func TestScenarioStreamInterceptor(t *testing.T) {
+	store := NewScenarioStore()
+	store.Load("a", "stream", &pb.ScenarioScript{Steps: []*pb.ScenarioStep{
+		{Method: scenarioExpand, CallIndex: 1, Headers: map[string]string{"x-step": "first"}},
+		{Method: scenarioExpand, CallIndex: 2, Code: code.Code_ABORTED},
+	}})
+	i := NewScenarioInterceptor(store, nil)
+	info := &grpc.StreamServerInfo{FullMethod: scenarioExpand}
+	calls := 0
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		calls++
+		return nil
+	}
+	ss := &headerStream{ctx: WithNamespace(context.Background(), "a")}
+	if err := i.StreamInterceptor(nil, ss, info, handler); err != nil || calls != 1 {
+		t.Errorf("StreamInterceptor(1): want the call handled got %v", err)
+	}
+	if got := ss.header.Get("x-step"); len(got) != 1 || got[0] != "first" {
+		t.Errorf("StreamInterceptor(1): want the header first got %v", got)
+	}
+	if err := i.StreamInterceptor(nil, &headerStream{ctx: ss.ctx}, info, handler); status.Code(err) != codes.Aborted || calls != 1 {
+		t.Errorf("StreamInterceptor(2): want Aborted got %v", err)
+	}
+}
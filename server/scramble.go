@@ -0,0 +1,35 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"math/rand"
+)
+
+// ScramblePage shuffles the n items of a page of a list response, which
+// starts at the index start of the list, if the ListScrambleSeed setting is
+// not zero. The order depends only on the seed and start, so a page is
+// shuffled alike each time it is listed, and its items and boundaries are
+// unchanged. A nil store never shuffles.
+func ScramblePage(settings SettingsStore, start, n int, swap func(i, j int)) {
+	if settings == nil {
+		return
+	}
+	seed := settings.Get().ListScrambleSeed
+	if seed == 0 {
+		return
+	}
+	rand.New(rand.NewSource(seed+int64(start))).Shuffle(n, swap)
+}
@@ -0,0 +1,65 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func scrambled(settings SettingsStore, start, n int) []int {
+	page := make([]int, n)
+	for i := range page {
+		page[i] = start + i
+	}
+	ScramblePage(settings, start, n, func(i, j int) {
+		page[i], page[j] = page[j], page[i]
+	})
+	return page
+}
+
+func TestScramblePage(t *testing.T) {
+	settings := DefaultSettings()
+	settings.ListScrambleSeed = 42
+	store := NewSettingsStore(settings)
+
+	page := scrambled(store, 10, 20)
+	if !reflect.DeepEqual(page, scrambled(store, 10, 20)) {
+		t.Errorf("ScramblePage: a page was shuffled differently when listed again")
+	}
+	if reflect.DeepEqual(page, scrambled(nil, 10, 20)) {
+		t.Errorf("ScramblePage: got the page in order, want it shuffled")
+	}
+	sorted := append([]int(nil), page...)
+	sort.Ints(sorted)
+	if !reflect.DeepEqual(sorted, scrambled(nil, 10, 20)) {
+		t.Errorf("ScramblePage: got %v, want a permutation of [10, 30)", page)
+	}
+
+	settings.ListScrambleSeed = 43
+	if reflect.DeepEqual(page, scrambled(NewSettingsStore(settings), 10, 20)) {
+		t.Errorf("ScramblePage: another seed shuffled the page alike")
+	}
+}
+
+func TestScramblePage_disabled(t *testing.T) {
+	want := []int{3, 4, 5, 6}
+	for _, store := range []SettingsStore{nil, NewSettingsStore(DefaultSettings())} {
+		if got := scrambled(store, 3, 4); !reflect.DeepEqual(got, want) {
+			t.Errorf("ScramblePage: got %v, want %v", got, want)
+		}
+	}
+}
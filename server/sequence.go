@@ -0,0 +1,45 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import "sync/atomic"
+
+var sequenceSingleton = NewSequence()
+
+// GetSequenceInstance returns the sequence singleton, shared by every method
+// that stamps its responses with a server sequence number.
+func GetSequenceInstance() Sequence {
+	return sequenceSingleton
+}
+
+// Sequence hands out increasing numbers to concurrent callers.
+type Sequence interface {
+	// Next returns the next number of the sequence, starting at 1. Each
+	// number is returned exactly once.
+	Next() int64
+}
+
+// NewSequence returns a new Sequence starting at 1.
+func NewSequence() Sequence {
+	return &sequence{}
+}
+
+type sequence struct {
+	last int64
+}
+
+func (s *sequence) Next() int64 {
+	return atomic.AddInt64(&s.last, 1)
+}
@@ -0,0 +1,56 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestGetSequenceInstance(t *testing.T) {
+	if GetSequenceInstance() != GetSequenceInstance() {
+		t.Error("GetSequenceInstance: want the same sequence on every call")
+	}
+}
+
+func TestSequence_concurrent(t *testing.T) {
+	const n = 1000
+	seq := NewSequence()
+	got := make(chan int64, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got <- seq.Next()
+		}()
+	}
+	wg.Wait()
+	close(got)
+
+	seen := map[int64]bool{}
+	for v := range got {
+		if v < 1 || v > n {
+			t.Errorf("Next: got %d, want a number in [1, %d]", v, n)
+		}
+		if seen[v] {
+			t.Errorf("Next: got %d more than once", v)
+		}
+		seen[v] = true
+	}
+	if next := seq.Next(); next != n+1 {
+		t.Errorf("Next after %d calls: got %d, want %d", n, next, n+1)
+	}
+}
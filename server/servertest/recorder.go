@@ -0,0 +1,501 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package servertest provides helpers for recording the messages exchanged
+// with a Showcase server and verifying them against golden files.
+package servertest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/googleapis/gapic-showcase/server"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	lropb "google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Redactor replaces nondeterministic fields of a recorded message, such as
+// generated names and timestamps, so that sessions can be compared. It is
+// given a copy of the message and may modify it in place.
+type Redactor func(m proto.Message)
+
+// Redacted is the value that redactors put in place of a redacted string.
+const Redacted = "(redacted)"
+
+// RedactOperationNames redacts the name of google.longrunning.Operation
+// messages, which encode the request that started the operation.
+func RedactOperationNames(m proto.Message) {
+	if op, ok := m.(*lropb.Operation); ok && op.GetName() != "" {
+		op.Name = Redacted
+	}
+}
+
+// RedactOperationMetadata clears the metadata of google.longrunning.Operation
+// messages, which records when the session ran.
+func RedactOperationMetadata(m proto.Message) {
+	if op, ok := m.(*lropb.Operation); ok {
+		op.Metadata = nil
+	}
+}
+
+// RedactServerSequences clears the server sequence number of Echo responses,
+// which depends on the calls the server handled before the session.
+func RedactServerSequences(m proto.Message) {
+	if resp, ok := m.(*pb.EchoResponse); ok {
+		resp.ServerSequence = 0
+	}
+}
+
+// Exchange is a single message observed by a Recorder.
+type Exchange struct {
+	// The full gRPC method name, e.g. /google.showcase.v1beta1.Echo/Echo.
+	Method string `json:"method"`
+
+	// One of "request", "response" or "error".
+	Kind string `json:"kind"`
+
+	// The full name of the message type. Errors are google.rpc.Status.
+	Type string `json:"type"`
+
+	// The message in the proto3 JSON format.
+	Message json.RawMessage `json:"message"`
+}
+
+type session struct {
+	Exchanges []Exchange `json:"exchanges"`
+}
+
+// Recorder observes the requests and responses of a Showcase server, including
+// each message of a stream, in the order they are handled. Sessions are
+// expected to make one call at a time; the messages of concurrent calls are
+// interleaved.
+type Recorder struct {
+	name      string
+	redactors []Redactor
+
+	mu        sync.Mutex
+	exchanges []Exchange
+	err       error
+}
+
+// NewRecorder returns a recorder that applies the given redactors to every
+// recorded message. The name identifies the recorder in an observer registry.
+func NewRecorder(name string, redactors ...Redactor) *Recorder {
+	return &Recorder{name: name, redactors: redactors}
+}
+
+// Register registers the recorder with all of the observer hooks of the
+// registry.
+func (r *Recorder) Register(registry server.GrpcObserverRegistry) {
+	registry.RegisterUnaryObserver(r)
+	registry.RegisterStreamRequestObserver(r)
+	registry.RegisterStreamResponseObserver(r)
+}
+
+// GetName returns the name of the recorder.
+func (r *Recorder) GetName() string {
+	return r.name
+}
+
+// ObserveUnary records the request and the response or error of a unary call.
+func (r *Recorder) ObserveUnary(
+	ctx context.Context,
+	req interface{},
+	resp interface{},
+	info *grpc.UnaryServerInfo,
+	err error) {
+	r.record(info.FullMethod, "request", req)
+	if err != nil {
+		r.record(info.FullMethod, "error", status.Convert(err).Proto())
+		return
+	}
+	r.record(info.FullMethod, "response", resp)
+}
+
+// ObserveStreamRequest records a message received on a stream.
+func (r *Recorder) ObserveStreamRequest(
+	ctx context.Context,
+	req interface{},
+	info *grpc.StreamServerInfo,
+	err error) {
+	if err == io.EOF {
+		return
+	}
+	if err != nil {
+		r.record(info.FullMethod, "error", status.Convert(err).Proto())
+		return
+	}
+	r.record(info.FullMethod, "request", req)
+}
+
+// ObserveStreamResponse records a message sent on a stream.
+func (r *Recorder) ObserveStreamResponse(
+	ctx context.Context,
+	resp interface{},
+	info *grpc.StreamServerInfo,
+	err error) {
+	if err != nil {
+		r.record(info.FullMethod, "error", status.Convert(err).Proto())
+		return
+	}
+	r.record(info.FullMethod, "response", resp)
+}
+
+func (r *Recorder) record(method, kind string, m interface{}) {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return
+	}
+	msg = proto.Clone(msg)
+	for _, redact := range r.redactors {
+		redact(msg)
+	}
+
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	b, err := marshaler.MarshalToString(msg)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		if r.err == nil {
+			r.err = fmt.Errorf("servertest: recording %s %s: %v", method, kind, err)
+		}
+		return
+	}
+	r.exchanges = append(r.exchanges, Exchange{
+		Method:  method,
+		Kind:    kind,
+		Type:    proto.MessageName(msg),
+		Message: json.RawMessage(b),
+	})
+}
+
+// Exchanges returns the messages recorded so far, or the first error
+// encountered while recording them.
+func (r *Recorder) Exchanges() ([]Exchange, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Exchange(nil), r.exchanges...), r.err
+}
+
+// Save writes the recorded session to a golden file.
+func (r *Recorder) Save(path string) error {
+	exchanges, err := r.Exchanges()
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(session{Exchanges: exchanges}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(b, '\n'), 0644)
+}
+
+// Reset forgets the messages recorded so far and the error recording them.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exchanges = nil
+	r.err = nil
+}
+
+// Verify replays the session of a golden file written by Save on the
+// connection, which must reach a server the recorder observes, and compares
+// the session recorded then with the golden as Compare does. Messages
+// recorded before Verify are forgotten.
+//
+// The calls of the golden are made one at a time, in order, with the
+// requests as they were recorded, so fields that were redacted are sent
+// unset. The golden does not mark where a call of a bidirectional streaming
+// method ends, unless it ends with an error, so consecutive calls of such a
+// method are replayed as one call.
+func (r *Recorder) Verify(ctx context.Context, conn *grpc.ClientConn, goldenPath string) error {
+	golden, err := readGolden(goldenPath)
+	if err != nil {
+		return err
+	}
+	calls, err := splitCalls(golden.Exchanges)
+	if err != nil {
+		return err
+	}
+	r.Reset()
+	for _, c := range calls {
+		if err := c.replay(ctx, conn); err != nil {
+			return err
+		}
+	}
+	return r.compare(goldenPath, golden)
+}
+
+// Compare compares the session recorded so far with a golden file written by
+// Save, without replaying it. The returned error lists every difference,
+// naming the exchange and field path.
+func (r *Recorder) Compare(goldenPath string) error {
+	golden, err := readGolden(goldenPath)
+	if err != nil {
+		return err
+	}
+	return r.compare(goldenPath, golden)
+}
+
+func readGolden(path string) (session, error) {
+	golden := session{}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return golden, err
+	}
+	if err := json.Unmarshal(b, &golden); err != nil {
+		return golden, fmt.Errorf("servertest: reading golden %s: %v", path, err)
+	}
+	return golden, nil
+}
+
+func (r *Recorder) compare(goldenPath string, golden session) error {
+	exchanges, err := r.Exchanges()
+	if err != nil {
+		return err
+	}
+	diffs := []string{}
+	for i := 0; i < len(exchanges) || i < len(golden.Exchanges); i++ {
+		if i >= len(exchanges) {
+			want := golden.Exchanges[i]
+			diffs = append(diffs, fmt.Sprintf("exchange %d (%s %s): missing", i, want.Method, want.Kind))
+			continue
+		}
+		got := exchanges[i]
+		prefix := fmt.Sprintf("exchange %d (%s %s)", i, got.Method, got.Kind)
+		if i >= len(golden.Exchanges) {
+			diffs = append(diffs, prefix+": unexpected")
+			continue
+		}
+		want := golden.Exchanges[i]
+		if got.Method != want.Method || got.Kind != want.Kind || got.Type != want.Type {
+			diffs = append(diffs, fmt.Sprintf(
+				"%s: got %s of type %s, want %s %s of type %s",
+				prefix, got.Kind, got.Type, want.Method, want.Kind, want.Type))
+			continue
+		}
+		var gotMsg, wantMsg interface{}
+		json.Unmarshal(got.Message, &gotMsg)
+		json.Unmarshal(want.Message, &wantMsg)
+		for _, d := range diffJSON("", gotMsg, wantMsg) {
+			diffs = append(diffs, prefix+": "+d)
+		}
+	}
+	if len(diffs) > 0 {
+		return fmt.Errorf("servertest: session differs from %s:\n%s", goldenPath, strings.Join(diffs, "\n"))
+	}
+	return nil
+}
+
+// call is the exchanges of a single call of a golden session.
+type call struct {
+	method                       string
+	clientStreams, serverStreams bool
+	exchanges                    []Exchange
+}
+
+// splitCalls splits the exchanges of a session into its calls. A call ends
+// with an error, with the response of a method the server does not stream,
+// or where a request follows the request of a method the client does not
+// stream.
+func splitCalls(exchanges []Exchange) ([]*call, error) {
+	calls := []*call{}
+	var c *call
+	for _, e := range exchanges {
+		if c != nil && (e.Method != c.method || e.Kind == "request" && !c.clientStreams && len(c.exchanges) > 0) {
+			c = nil
+		}
+		if c == nil {
+			clientStreams, serverStreams, ok := server.ShowcaseMethodStreams(e.Method)
+			if !ok {
+				return nil, fmt.Errorf("servertest: replaying %s: not a Showcase method", e.Method)
+			}
+			c = &call{method: e.Method, clientStreams: clientStreams, serverStreams: serverStreams}
+			calls = append(calls, c)
+		}
+		c.exchanges = append(c.exchanges, e)
+		if e.Kind == "error" || e.Kind == "response" && !c.serverStreams {
+			c = nil
+		}
+	}
+	return calls, nil
+}
+
+// replay makes the call on the connection. It sends the requests of the call
+// and receives its responses in the order they were recorded, then receives
+// until the call ends. The status of the call is left for the recorder to
+// observe.
+func (c *call) replay(ctx context.Context, conn *grpc.ClientConn) error {
+	output, _ := server.ShowcaseMethodOutput(c.method)
+	if !c.clientStreams && !c.serverStreams {
+		req, err := c.request(0)
+		if err != nil {
+			return err
+		}
+		reply, err := newMessage(output)
+		if err != nil {
+			return err
+		}
+		conn.Invoke(ctx, c.method, req, reply)
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := conn.NewStream(
+		ctx,
+		&grpc.StreamDesc{ClientStreams: c.clientStreams, ServerStreams: c.serverStreams},
+		c.method)
+	if err != nil {
+		return fmt.Errorf("servertest: replaying %s: %v", c.method, err)
+	}
+	// Only the server of a bidirectional stream responds before the client
+	// is done sending.
+	bidi := c.clientStreams && c.serverStreams
+	for i, e := range c.exchanges {
+		switch e.Kind {
+		case "request":
+			req, err := c.request(i)
+			if err != nil {
+				return err
+			}
+			if err := stream.SendMsg(req); err != nil {
+				return drain(stream, output)
+			}
+		case "response":
+			if !bidi {
+				stream.CloseSend()
+			}
+			reply, err := newMessage(output)
+			if err != nil {
+				return err
+			}
+			if err := stream.RecvMsg(reply); err != nil {
+				return nil
+			}
+		}
+	}
+	stream.CloseSend()
+	return drain(stream, output)
+}
+
+// request decodes the request of the i-th exchange of the call.
+func (c *call) request(i int) (proto.Message, error) {
+	e := c.exchanges[i]
+	req, err := newMessage(e.Type)
+	if err != nil {
+		return nil, err
+	}
+	if err := jsonpb.UnmarshalString(string(e.Message), req); err != nil {
+		return nil, fmt.Errorf("servertest: replaying %s: decoding request %d: %v", c.method, i, err)
+	}
+	return req, nil
+}
+
+// drain receives the messages of the stream until it ends.
+func drain(stream grpc.ClientStream, output string) error {
+	for {
+		reply, err := newMessage(output)
+		if err != nil {
+			return err
+		}
+		if stream.RecvMsg(reply) != nil {
+			return nil
+		}
+	}
+}
+
+// newMessage returns an empty message of the registered type with the full
+// proto name.
+func newMessage(name string) (proto.Message, error) {
+	t := proto.MessageType(name)
+	if t == nil {
+		return nil, fmt.Errorf("servertest: the message type %q is not registered", name)
+	}
+	return reflect.New(t.Elem()).Interface().(proto.Message), nil
+}
+
+// diffJSON returns the differences between two decoded JSON values, each
+// prefixed with the path of the differing field.
+func diffJSON(path string, got, want interface{}) []string {
+	gotMap, gotIsMap := got.(map[string]interface{})
+	wantMap, wantIsMap := want.(map[string]interface{})
+	if gotIsMap && wantIsMap {
+		keys := []string{}
+		for k := range gotMap {
+			keys = append(keys, k)
+		}
+		for k := range wantMap {
+			if _, ok := gotMap[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		diffs := []string{}
+		for _, k := range keys {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			diffs = append(diffs, diffJSON(p, gotMap[k], wantMap[k])...)
+		}
+		return diffs
+	}
+
+	gotList, gotIsList := got.([]interface{})
+	wantList, wantIsList := want.([]interface{})
+	if gotIsList && wantIsList {
+		diffs := []string{}
+		for i := 0; i < len(gotList) || i < len(wantList); i++ {
+			var g, w interface{}
+			if i < len(gotList) {
+				g = gotList[i]
+			}
+			if i < len(wantList) {
+				w = wantList[i]
+			}
+			diffs = append(diffs, diffJSON(fmt.Sprintf("%s[%d]", path, i), g, w)...)
+		}
+		return diffs
+	}
+
+	if reflect.DeepEqual(got, want) {
+		return nil
+	}
+	if path == "" {
+		path = "message"
+	}
+	return []string{fmt.Sprintf("%s: got %s, want %s", path, formatJSON(got), formatJSON(want))}
+}
+
+func formatJSON(v interface{}) string {
+	if v == nil {
+		return "<unset>"
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
@@ -0,0 +1,260 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servertest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/googleapis/gapic-showcase/server"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/services"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// redactWaitEndTime clears the end time of a WaitRequest, which the server
+// computes from the requested TTL.
+func redactWaitEndTime(m proto.Message) {
+	if req, ok := m.(*pb.WaitRequest); ok {
+		req.End = nil
+	}
+}
+
+// observedServer starts an Echo server observed by a new recorder, and
+// returns the recorder, a connection to the server and a func that stops
+// both.
+func observedServer(t *testing.T) (*Recorder, *grpc.ClientConn, func()) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := server.ShowcaseObserverRegistry()
+	recorder := NewRecorder("recorder", RedactOperationNames, RedactOperationMetadata, RedactServerSequences, redactWaitEndTime)
+	recorder.Register(registry)
+
+	s := grpc.NewServer(
+		grpc.StreamInterceptor(registry.StreamInterceptor),
+		grpc.UnaryInterceptor(registry.UnaryInterceptor))
+	pb.RegisterEchoServer(s, services.NewEchoServer())
+	go s.Serve(lis)
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		s.Stop()
+		t.Fatal(err)
+	}
+	return recorder, conn, func() {
+		conn.Close()
+		s.Stop()
+	}
+}
+
+// recordSession runs a small session against an Echo server and returns the
+// recorder that observed it.
+func recordSession(t *testing.T) *Recorder {
+	recorder, conn, stop := observedServer(t)
+	defer stop()
+	client := pb.NewEchoClient(conn)
+	ctx := context.Background()
+
+	client.Echo(ctx, &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hello"}})
+	client.Echo(ctx, &pb.EchoRequest{Response: &pb.EchoRequest_Error{Error: &spb.Status{
+		Code:    int32(codes.NotFound),
+		Message: "nope",
+	}}})
+	client.Wait(ctx, &pb.WaitRequest{
+		End:      &pb.WaitRequest_Ttl{Ttl: ptypes.DurationProto(0)},
+		Response: &pb.WaitRequest_Success{Success: &pb.WaitResponse{Content: "done"}},
+	})
+
+	stream, err := client.Expand(ctx, &pb.ExpandRequest{Content: "one two"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		if _, err := stream.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	collect, err := client.Collect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, content := range []string{"three", "four"} {
+		collect.Send(&pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: content}})
+	}
+	if _, err := collect.CloseAndRecv(); err != nil {
+		t.Fatal(err)
+	}
+
+	chat, err := client.Chat(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, content := range []string{"five", "six"} {
+		chat.Send(&pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: content}})
+		if _, err := chat.Recv(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	chat.CloseSend()
+	if _, err := chat.Recv(); err != io.EOF {
+		t.Fatalf("Chat: want the stream to end got %v", err)
+	}
+
+	return recorder
+}
+
+func TestRecorder(t *testing.T) {
+	recorder := recordSession(t)
+	exchanges, err := recorder.Exchanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		method, kind, message string
+	}{
+		{"/google.showcase.v1beta1.Echo/Echo", "request", `{"content":"hello"}`},
+		{"/google.showcase.v1beta1.Echo/Echo", "response", `{"content":"hello"}`},
+		{"/google.showcase.v1beta1.Echo/Echo", "request", `{"error":{"code":5,"message":"nope"}}`},
+		{"/google.showcase.v1beta1.Echo/Echo", "error", `{"code":5,"message":"nope"}`},
+		{"/google.showcase.v1beta1.Echo/Wait", "request", `{"success":{"content":"done"}}`},
+		{"/google.showcase.v1beta1.Echo/Wait", "response", ""},
+		{"/google.showcase.v1beta1.Echo/Expand", "request", `{"content":"one two"}`},
+		{"/google.showcase.v1beta1.Echo/Expand", "response", `{"content":"one"}`},
+		{"/google.showcase.v1beta1.Echo/Expand", "response", `{"content":"two"}`},
+		{"/google.showcase.v1beta1.Echo/Collect", "request", `{"content":"three"}`},
+		{"/google.showcase.v1beta1.Echo/Collect", "request", `{"content":"four"}`},
+		{"/google.showcase.v1beta1.Echo/Collect", "response", `{"content":"three four"}`},
+		{"/google.showcase.v1beta1.Echo/Chat", "request", `{"content":"five"}`},
+		{"/google.showcase.v1beta1.Echo/Chat", "response", `{"content":"five"}`},
+		{"/google.showcase.v1beta1.Echo/Chat", "request", `{"content":"six"}`},
+		{"/google.showcase.v1beta1.Echo/Chat", "response", `{"content":"six"}`},
+	}
+	if len(exchanges) != len(want) {
+		t.Fatalf("Recorder: want %d exchanges got %d: %v", len(want), len(exchanges), exchanges)
+	}
+	for i, w := range want {
+		got := exchanges[i]
+		if got.Method != w.method || got.Kind != w.kind {
+			t.Errorf("exchange %d: want %s %s got %s %s", i, w.method, w.kind, got.Method, got.Kind)
+		}
+		if w.message != "" && string(got.Message) != w.message {
+			t.Errorf("exchange %d: want %s got %s", i, w.message, got.Message)
+		}
+	}
+	if op := string(exchanges[5].Message); !strings.Contains(op, `"name":"(redacted)"`) {
+		t.Errorf("Recorder: want the operation name redacted, got %s", op)
+	}
+}
+
+func TestRecorder_verify(t *testing.T) {
+	dir, err := ioutil.TempDir("", "servertest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	golden := filepath.Join(dir, "session.json")
+
+	if err := recordSession(t).Save(golden); err != nil {
+		t.Fatalf("Save: unexpected err %+v", err)
+	}
+
+	// Replaying the session on another server matches the golden, since the
+	// operation names and metadata are redacted.
+	recorder, conn, stop := observedServer(t)
+	defer stop()
+	ctx := context.Background()
+	if err := recorder.Verify(ctx, conn, golden); err != nil {
+		t.Errorf("Verify: unexpected err %+v", err)
+	}
+
+	b, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mutated := strings.Replace(string(b), `"content": "two"`, `"content": "three"`, 1)
+	if err := ioutil.WriteFile(golden, []byte(mutated), 0644); err != nil {
+		t.Fatal(err)
+	}
+	err = recorder.Verify(ctx, conn, golden)
+	want := "exchange 8 (/google.showcase.v1beta1.Echo/Expand response): content: got \"two\", want \"three\""
+	if err == nil || !strings.HasSuffix(err.Error(), "\n"+want) {
+		t.Errorf("Verify: want error ending with %q got %v", want, err)
+	}
+	// Comparing without a replay finds the same difference in the session
+	// Verify recorded.
+	if err := recorder.Compare(golden); err == nil || !strings.HasSuffix(err.Error(), "\n"+want) {
+		t.Errorf("Compare: want error ending with %q got %v", want, err)
+	}
+}
+
+func TestRecorder_verifyLength(t *testing.T) {
+	dir, err := ioutil.TempDir("", "servertest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	golden := filepath.Join(dir, "session.json")
+
+	if err := NewRecorder("empty").Save(golden); err != nil {
+		t.Fatal(err)
+	}
+	err = recordSession(t).Compare(golden)
+	if err == nil || !strings.Contains(err.Error(), "exchange 0 (/google.showcase.v1beta1.Echo/Echo request): unexpected") {
+		t.Errorf("Compare: want unexpected exchanges, got %v", err)
+	}
+}
+
+func TestDiffJSON(t *testing.T) {
+	tests := []struct {
+		got, want string
+		diffs     []string
+	}{
+		{`{"a":1}`, `{"a":1}`, nil},
+		{`{"a":1}`, `{"a":2}`, []string{"a: got 1, want 2"}},
+		{`{"a":{"b":"x"}}`, `{"a":{"b":"y","c":true}}`, []string{`a.b: got "x", want "y"`, "a.c: got <unset>, want true"}},
+		{`{"a":[1,2]}`, `{"a":[1,3,4]}`, []string{"a[1]: got 2, want 3", "a[2]: got <unset>, want 4"}},
+		{`"x"`, `"y"`, []string{`message: got "x", want "y"`}},
+	}
+	for _, test := range tests {
+		var got, want interface{}
+		if err := json.Unmarshal([]byte(test.got), &got); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal([]byte(test.want), &want); err != nil {
+			t.Fatal(err)
+		}
+		diffs := diffJSON("", got, want)
+		if strings.Join(diffs, "|") != strings.Join(test.diffs, "|") {
+			t.Errorf("diffJSON(%s, %s): want %q got %q", test.got, test.want, test.diffs, diffs)
+		}
+	}
+}
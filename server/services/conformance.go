@@ -0,0 +1,805 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"github.com/googleapis/gapic-showcase/server"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	code "google.golang.org/genproto/googleapis/rpc/code"
+)
+
+// method returns the full name of a method of a Showcase service.
+func method(service, name string) string {
+	return "google.showcase.v1beta1." + service + "/" + name
+}
+
+// succeeds returns the outcome of a call that ends with OK, after the given
+// headers or trailers are received.
+func succeeds(headers ...string) *pb.ConformanceOutcome {
+	return &pb.ConformanceOutcome{Code: code.Code_OK, Headers: headers}
+}
+
+// fails returns the outcome of a call that ends with the code, with
+// ErrorInfo details of the given reasons.
+func fails(c code.Code, reasons ...string) *pb.ConformanceOutcome {
+	return &pb.ConformanceOutcome{Code: c, Reasons: reasons}
+}
+
+// conformanceScenarios are the behaviors listed by ListConformanceScenarios.
+// Every method of the Showcase services must be used by a scenario, which
+// TestConformanceScenarios checks, so a scenario is added along with each
+// feature. IDs must never be reused.
+var conformanceScenarios = []*pb.ConformanceScenario{
+	// Echo.
+	{
+		Id:          "echo.content",
+		Description: "Echo returns the content of the request.",
+		Methods:     []string{method("Echo", "Echo")},
+		Outcome:     succeeds(),
+	},
+	{
+		Id:          "echo.error",
+		Description: "Echo fails with the status of the request.",
+		Methods:     []string{method("Echo", "Echo")},
+		Outcome:     fails(code.Code_ABORTED),
+	},
+	{
+		Id:             "echo.validate_content_regex",
+		Description:    "Echo fails with a BadRequest detail if the content does not match validate_content_regex.",
+		Methods:        []string{method("Echo", "Echo")},
+		RequiredFields: []string{"content", "validate_content_regex"},
+		Outcome:        fails(code.Code_INVALID_ARGUMENT, showcaseerrors.FieldInvalid),
+	},
+	{
+		Id:             "echo.cache_control",
+		Description:    "Echo returns the caching directive of cache_control in a response header.",
+		Methods:        []string{method("Echo", "Echo")},
+		RequiredFields: []string{"cache_control"},
+		Outcome:        succeeds(server.CacheControlHeader),
+	},
+	{
+		Id:             "echo.dedupe_window",
+		Description:    "A repeated Echo within dedupe_window returns the first response with served_from_cache set.",
+		Methods:        []string{method("Echo", "Echo")},
+		RequiredFields: []string{"dedupe_window"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:             "echo.fail_first_attempts",
+		Description:    "Echo fails the first fail_first_attempts attempts of a request_id with UNAVAILABLE, so a client that retries succeeds.",
+		Methods:        []string{method("Echo", "Echo")},
+		RequiredFields: []string{"fail_first_attempts", "request_id"},
+		Outcome:        fails(code.Code_UNAVAILABLE, showcaseerrors.AttemptFailed),
+	},
+	{
+		Id:             "echo.repeat_count_too_large",
+		Description:    "Echo fails, suggesting Expand, if repeat_count makes the response larger than the server sends.",
+		Methods:        []string{method("Echo", "Echo")},
+		RequiredFields: []string{"content", "repeat_count"},
+		Outcome:        fails(code.Code_RESOURCE_EXHAUSTED, showcaseerrors.ResponseTooLarge),
+	},
+	{
+		Id:             "echo.attempts",
+		Description:    "Echo reports the grpc-previous-rpc-attempts and client attempt metadata of a retried call.",
+		Methods:        []string{method("Echo", "Echo")},
+		RequiredFields: []string{"echo_attempts"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:          "echo.accept_language",
+		Description: "Echo greets in the supported locale that best matches the accept-language metadata, and reports it.",
+		Methods:     []string{method("Echo", "Echo")},
+		Outcome:     succeeds(),
+	},
+	{
+		Id:             "echo.hazardous_error_message",
+		Description:    "Echo fails with a message holding characters the grpc-message trailer must escape, which clients must decode once.",
+		Methods:        []string{method("Echo", "Echo")},
+		RequiredFields: []string{"error", "hazardous_error_message"},
+		Outcome:        fails(code.Code_ABORTED),
+	},
+	{
+		Id:          "echo.unknown_fields",
+		Description: "Echo returns the fields of the request it does not know, unless strip_unknown_fields is set.",
+		Methods:     []string{method("Echo", "Echo")},
+		Outcome:     succeeds(),
+	},
+	{
+		Id:             "echo.read_mask",
+		Description:    "Echo returns only the response fields named by read_mask.",
+		Methods:        []string{method("Echo", "Echo")},
+		RequiredFields: []string{"read_mask"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:             "echo.transport_info",
+		Description:    "Echo reports the user-agent, content-type and protocol of the request.",
+		Methods:        []string{method("Echo", "Echo")},
+		RequiredFields: []string{"include_transport_info"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:          "echo.quota_project",
+		Description: "Echo returns the x-goog-user-project metadata in quota_project.",
+		Methods:     []string{method("Echo", "Echo")},
+		Outcome:     succeeds(),
+	},
+	{
+		Id:          "echo.namespace_invalid",
+		Description: "Calls with a malformed showcase-namespace metadata fail.",
+		Methods:     []string{method("Echo", "Echo")},
+		Outcome:     fails(code.Code_INVALID_ARGUMENT, showcaseerrors.FieldInvalid),
+	},
+	{
+		Id:             "echo.corrupt_utf8_response",
+		Description:    "Echo refuses to return invalid UTF-8 in its content unless the server was started with --enable-nonconforming.",
+		Methods:        []string{method("Echo", "Echo")},
+		RequiredFields: []string{"content", "corrupt_utf8_response"},
+		Outcome:        fails(code.Code_PERMISSION_DENIED),
+	},
+	{
+		Id:             "batch_echo.items",
+		Description:    "BatchEcho echoes each request, failing items individually without failing the call.",
+		Methods:        []string{method("Echo", "BatchEcho")},
+		RequiredFields: []string{"requests"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:          "expand.words",
+		Description: "Expand streams the words of the content, then ends with the status of the request.",
+		Methods:     []string{method("Echo", "Expand")},
+		Outcome:     succeeds(),
+	},
+	{
+		Id:             "expand.emit_empty_messages",
+		Description:    "Expand streams emit_empty_messages responses with empty content, and counts the messages of every stream in a trailer, even when there are none.",
+		Methods:        []string{method("Echo", "Expand")},
+		RequiredFields: []string{"emit_empty_messages"},
+		Outcome:        succeeds(expandCountTrailer),
+	},
+	{
+		Id:             "expand.error_summary",
+		Description:    "Expand ends with the error of the request and an EchoResponse detail summarizing the stream.",
+		Methods:        []string{method("Echo", "Expand")},
+		RequiredFields: []string{"error", "error_summary"},
+		Outcome:        fails(code.Code_ABORTED),
+	},
+	{
+		Id:             "expand.trailers",
+		Description:    "Expand ends with the trailing metadata of the request, whatever its status.",
+		Methods:        []string{method("Echo", "Expand")},
+		RequiredFields: []string{"trailers"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:             "expand.report_compression",
+		Description:    "Expand names the compression of its responses in a trailer.",
+		Methods:        []string{method("Echo", "Expand")},
+		RequiredFields: []string{"report_compression"},
+		Outcome:        succeeds(expandCompressionTrailer),
+	},
+	{
+		Id:             "expand.heartbeats",
+		Description:    "Expand sends heartbeats every heartbeat_interval while it waits message_delay between words.",
+		Methods:        []string{method("Echo", "Expand")},
+		RequiredFields: []string{"message_delay", "heartbeat_interval"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:             "expand.slow_start",
+		Description:    "Expand sends burst_count words at once, then waits trickle_interval before each of the rest.",
+		Methods:        []string{method("Echo", "Expand")},
+		RequiredFields: []string{"burst_count", "trickle_interval"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:             "expand.cancel_status",
+		Description:    "After a client cancels an Expand stream, GetLastExpandStatus reports how many messages the server sent.",
+		Methods:        []string{method("Echo", "Expand"), method("Echo", "GetLastExpandStatus")},
+		RequiredFields: []string{"stream_id"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:          "expand.corpus",
+		Description: "Expand streams the words of a corpus created with CreateEchoCorpus.",
+		Methods: []string{
+			method("Testing", "CreateEchoCorpus"),
+			method("Echo", "Expand"),
+			method("Testing", "DeleteEchoCorpus"),
+		},
+		RequiredFields: []string{"name", "words", "corpus_name"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:          "collect.join",
+		Description: "Collect returns the content of the messages of the stream joined with spaces.",
+		Methods:     []string{method("Echo", "Collect")},
+		Outcome:     succeeds(),
+	},
+	{
+		Id:             "collect.continue_on_error",
+		Description:    "Collect reports the errors of messages in collect_failures instead of failing.",
+		Methods:        []string{method("Echo", "Collect")},
+		RequiredFields: []string{"continue_on_error"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:             "collect.flush",
+		Description:    "Collect responds on a flush before the client half-closes, and counts the messages it discards.",
+		Methods:        []string{method("Echo", "Collect")},
+		RequiredFields: []string{"flush"},
+		Outcome:        succeeds(collectDiscardedTrailer),
+	},
+	{
+		Id:             "collect.error_after_close",
+		Description:    "Collect reads the whole stream, then fails with an EchoResponse detail holding the response it would have sent.",
+		Methods:        []string{method("Echo", "Collect")},
+		RequiredFields: []string{"error_after_close"},
+		Outcome:        fails(code.Code_ABORTED),
+	},
+	{
+		Id:             "collect.sequence_out_of_order",
+		Description:    "Collect fails if a message's sequence does not increase, or with require_contiguous skips one.",
+		Methods:        []string{method("Echo", "Collect")},
+		RequiredFields: []string{"sequence"},
+		Outcome:        fails(code.Code_FAILED_PRECONDITION, showcaseerrors.SequenceOutOfOrder),
+	},
+	{
+		Id:             "echo.forward",
+		Description:    "Echo calls itself forward_depth times, keeping the deadline and propagated metadata, and reports each hop.",
+		Methods:        []string{method("Echo", "Echo")},
+		RequiredFields: []string{"forward_depth"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:             "echo.forward_loop",
+		Description:    "Echo fails a forwarded call that would make the chain longer than 5 calls.",
+		Methods:        []string{method("Echo", "Echo")},
+		RequiredFields: []string{"forward_depth"},
+		Outcome:        fails(code.Code_ABORTED, showcaseerrors.ForwardLoop),
+	},
+	{
+		Id:          "chat.echo",
+		Description: "Chat echoes each message of the stream.",
+		Methods:     []string{method("Echo", "Chat")},
+		Outcome:     succeeds(),
+	},
+	{
+		Id:          "chat.handshake",
+		Description: "With the handshake metadata, Chat speaks first, sending the session ID before reading a message.",
+		Methods:     []string{method("Echo", "Chat")},
+		Outcome:     succeeds(),
+	},
+	{
+		Id:             "chat.ack_mode",
+		Description:    "Chat resends what the client does not acknowledge, and reports unacknowledged and resent responses in trailers.",
+		Methods:        []string{method("Echo", "Chat")},
+		RequiredFields: []string{"ack_mode"},
+		Outcome:        succeeds(chatUnackedTrailer, chatResentTrailer),
+	},
+	{
+		Id:             "chat.idle_timeout",
+		Description:    "Chat fails if the client sends nothing for idle_timeout.",
+		Methods:        []string{method("Echo", "Chat")},
+		RequiredFields: []string{"idle_timeout"},
+		Outcome:        fails(code.Code_ABORTED, showcaseerrors.IdleTimeout),
+	},
+	{
+		Id:             "chat.max_received_messages",
+		Description:    "Chat stops reading after max_received_messages, sending a response with limit_reached before failing.",
+		Methods:        []string{method("Echo", "Chat")},
+		RequiredFields: []string{"max_received_messages"},
+		Outcome:        fails(code.Code_OUT_OF_RANGE, showcaseerrors.ReceiveLimitReached),
+	},
+	{
+		Id:             "chat.max_sent_messages",
+		Description:    "Chat stops answering after max_sent_messages but reads until the client closes, reporting the responses it dropped in a trailer.",
+		Methods:        []string{method("Echo", "Chat")},
+		RequiredFields: []string{"max_sent_messages"},
+		Outcome:        succeeds(chatDroppedTrailer),
+	},
+	{
+		Id:             "paged_expand.pages",
+		Description:    "PagedExpand returns the words of the content in pages of page_size.",
+		Methods:        []string{method("Echo", "PagedExpand")},
+		RequiredFields: []string{"content", "page_size"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:             "paged_expand.order_by",
+		Description:    "PagedExpand returns the words in the order of order_by, keeping it across pages.",
+		Methods:        []string{method("Echo", "PagedExpand")},
+		RequiredFields: []string{"content", "page_size", "order_by"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:             "paged_expand.filter",
+		Description:    "PagedExpand pages through only the words that match filter, keeping it across pages.",
+		Methods:        []string{method("Echo", "PagedExpand")},
+		RequiredFields: []string{"content", "page_size", "filter"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:             "paged_expand.filter_invalid",
+		Description:    "PagedExpand fails for a filter that does not parse, naming the offset at fault.",
+		Methods:        []string{method("Echo", "PagedExpand")},
+		RequiredFields: []string{"filter"},
+		Outcome:        fails(code.Code_INVALID_ARGUMENT, showcaseerrors.FieldInvalid),
+	},
+	{
+		Id:             "paged_expand.page_token_invalid",
+		Description:    "PagedExpand fails for a page token it did not issue.",
+		Methods:        []string{method("Echo", "PagedExpand")},
+		RequiredFields: []string{"page_token"},
+		Outcome:        fails(code.Code_INVALID_ARGUMENT, showcaseerrors.PageTokenInvalid),
+	},
+	{
+		Id:          "wait.poll",
+		Description: "Wait starts an operation that completes at end_time or after ttl, for clients to poll with GetOperation.",
+		Methods: []string{
+			method("Echo", "Wait"),
+			"google.longrunning.Operations/GetOperation",
+		},
+		Outcome: succeeds(),
+	},
+	{
+		Id:          "wait.polling_report",
+		Description: "GetOperationPollingReport reports when the operation of a Wait was polled, to check the backoff of clients.",
+		Methods: []string{
+			method("Echo", "Wait"),
+			"google.longrunning.Operations/GetOperation",
+			method("Testing", "GetOperationPollingReport"),
+		},
+		Outcome: succeeds(),
+	},
+	{
+		Id:          "wait.partial_results",
+		Description: "GetOperation of a pending Wait returns the latest partial result in its metadata, and the last one once done.",
+		Methods: []string{
+			method("Echo", "Wait"),
+			"google.longrunning.Operations/GetOperation",
+		},
+		RequiredFields: []string{"partial_results"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:          "wait.stream_operation_updates",
+		Description: "StreamOperationUpdates pushes the state of a Wait operation as it changes, ending once it is done.",
+		Methods: []string{
+			method("Echo", "Wait"),
+			method("Echo", "StreamOperationUpdates"),
+		},
+		RequiredFields: []string{"name"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:          "wait.stream_operation_deleted",
+		Description: "Deleting an operation ends the streams of its updates with NOT_FOUND.",
+		Methods: []string{
+			method("Echo", "StreamOperationUpdates"),
+			"google.longrunning.Operations/DeleteOperation",
+		},
+		RequiredFields: []string{"name"},
+		Outcome:        fails(code.Code_NOT_FOUND, showcaseerrors.OperationDeleted),
+	},
+	{
+		Id:             "wait.operation_id_reused",
+		Description:    "A Wait retried with its operation_id returns the same operation, but another request with the ID fails.",
+		Methods:        []string{method("Echo", "Wait")},
+		RequiredFields: []string{"operation_id"},
+		Outcome:        fails(code.Code_ALREADY_EXISTS, showcaseerrors.OperationIDReused),
+	},
+	{
+		Id:          "wait.wrong_instance",
+		Description: "GetOperation fails for the operations of another server instance.",
+		Methods:     []string{"google.longrunning.Operations/GetOperation"},
+		Outcome:     fails(code.Code_FAILED_PRECONDITION, showcaseerrors.WrongInstance),
+	},
+	{
+		Id:             "fail_echo_with_details.details",
+		Description:    "FailEchoWithDetails fails with the status of the request and the standard error details it names.",
+		Methods:        []string{method("Echo", "FailEchoWithDetails")},
+		RequiredFields: []string{"error"},
+		Outcome:        fails(code.Code_ABORTED),
+	},
+	{
+		Id:             "return_status.code",
+		Description:    "ReturnStatus fails with exactly the requested canonical code and message, such as NOT_FOUND, for every code but OK.",
+		Methods:        []string{method("Echo", "ReturnStatus")},
+		RequiredFields: []string{"code"},
+		Outcome:        fails(code.Code_NOT_FOUND, "NOT_FOUND"),
+	},
+	{
+		Id:             "stream_status.code",
+		Description:    "StreamStatus streams message_count responses before ending the stream with the requested status.",
+		Methods:        []string{method("Echo", "StreamStatus")},
+		RequiredFields: []string{"code", "message_count"},
+		Outcome:        fails(code.Code_UNAVAILABLE, "UNAVAILABLE"),
+	},
+	{
+		Id:          "inspect_credentials.metadata",
+		Description: "InspectCredentials reports the credential-bearing metadata of the call in the order received.",
+		Methods:     []string{method("Echo", "InspectCredentials")},
+		Outcome:     succeeds(),
+	},
+	{
+		Id:             "inspect_credentials.strict",
+		Description:    "InspectCredentials fails in strict mode if the authorization metadata is given more than once.",
+		Methods:        []string{method("Echo", "InspectCredentials")},
+		RequiredFields: []string{"strict"},
+		Outcome:        fails(code.Code_UNAUTHENTICATED),
+	},
+	{
+		Id:             "read_blob.resume",
+		Description:    "ReadBlob streams a range of a deterministic blob, and a download failed by fail_after_bytes resumes from read_offset.",
+		Methods:        []string{method("Echo", "ReadBlob")},
+		RequiredFields: []string{"total_size"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:             "write_blob.resume",
+		Description:    "An interrupted WriteBlob upload resumes on a new stream from the size GetWriteStatus reports.",
+		Methods:        []string{method("Echo", "WriteBlob"), method("Echo", "GetWriteStatus")},
+		RequiredFields: []string{"spec.blob_id", "spec.total_size", "blob_id"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:          "echo_resource.lifecycle",
+		Description: "GetEchoResource fails with NOT_FOUND until CreateEchoResource, and again after DeleteEchoResource.",
+		Methods: []string{
+			method("Echo", "CreateEchoResource"),
+			method("Echo", "GetEchoResource"),
+			method("Echo", "DeleteEchoResource"),
+		},
+		RequiredFields: []string{"name"},
+		Outcome:        fails(code.Code_NOT_FOUND),
+	},
+	{
+		Id:             "echo_resource.unavailable_until_created",
+		Description:    "GetEchoResource fails with UNAVAILABLE until the resource is created, so that retry policies engage.",
+		Methods:        []string{method("Echo", "GetEchoResource")},
+		RequiredFields: []string{"name", "unavailable_until_created"},
+		Outcome:        fails(code.Code_UNAVAILABLE),
+	},
+	{
+		Id:          "topic.streaming_pull",
+		Description: "StreamingPullEcho delivers the messages published with PublishEcho in order, and stops delivering those acknowledged.",
+		Methods: []string{
+			method("Echo", "PublishEcho"),
+			method("Echo", "StreamingPullEcho"),
+		},
+		RequiredFields: []string{"topic", "messages", "ack_ids"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:             "topic.redelivery",
+		Description:    "StreamingPullEcho delivers a message again once it goes unacknowledged for the ack_deadline of its stream.",
+		Methods:        []string{method("Echo", "StreamingPullEcho")},
+		RequiredFields: []string{"topic", "ack_deadline"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:          "topic.not_found",
+		Description: "StreamingPullEcho fails for a topic that nothing has been published to.",
+		Methods:     []string{method("Echo", "StreamingPullEcho")},
+		Outcome:     fails(code.Code_NOT_FOUND),
+	},
+	{
+		Id:             "numeric.edge_cases",
+		Description:    "RepeatNumericEdgeCases returns NaN, infinities and 64-bit integer limits, which clients must read back exactly, particularly from JSON.",
+		Methods:        []string{method("Echo", "RepeatNumericEdgeCases")},
+		RequiredFields: []string{"edge_cases"},
+		Outcome:        succeeds(),
+	},
+
+	// Identity.
+	{
+		Id:          "identity.user_lifecycle",
+		Description: "A user can be created, read, updated and deleted.",
+		Methods: []string{
+			method("Identity", "CreateUser"),
+			method("Identity", "GetUser"),
+			method("Identity", "UpdateUser"),
+			method("Identity", "DeleteUser"),
+		},
+		RequiredFields: []string{"user.display_name", "user.email", "name"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:          "identity.user_required_fields",
+		Description: "CreateUser fails without a display name or email.",
+		Methods:     []string{method("Identity", "CreateUser")},
+		Outcome:     fails(code.Code_INVALID_ARGUMENT, showcaseerrors.FieldRequired),
+	},
+	{
+		Id:             "identity.list_users_pages",
+		Description:    "ListUsers returns every user once across its pages.",
+		Methods:        []string{method("Identity", "ListUsers")},
+		RequiredFields: []string{"page_size"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:             "identity.page_token_expired",
+		Description:    "ListUsers fails for a page token older than page_token_ttl.",
+		Methods:        []string{method("Identity", "ListUsers")},
+		RequiredFields: []string{"page_token", "page_token_ttl"},
+		Outcome:        fails(code.Code_FAILED_PRECONDITION, showcaseerrors.PageTokenExpired),
+	},
+
+	// Messaging.
+	{
+		Id:          "messaging.room_lifecycle",
+		Description: "A room can be created, read, updated, listed and deleted.",
+		Methods: []string{
+			method("Messaging", "CreateRoom"),
+			method("Messaging", "GetRoom"),
+			method("Messaging", "UpdateRoom"),
+			method("Messaging", "ListRooms"),
+			method("Messaging", "DeleteRoom"),
+		},
+		RequiredFields: []string{"room.display_name", "name"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:          "messaging.blurb_lifecycle",
+		Description: "A blurb can be created, read, updated, listed and deleted under a room or user.",
+		Methods: []string{
+			method("Messaging", "CreateBlurb"),
+			method("Messaging", "GetBlurb"),
+			method("Messaging", "UpdateBlurb"),
+			method("Messaging", "ListBlurbs"),
+			method("Messaging", "DeleteBlurb"),
+		},
+		RequiredFields: []string{"parent", "blurb.user", "name"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:             "messaging.search_blurbs",
+		Description:    "SearchBlurbs starts an operation whose result holds the blurbs matching the query.",
+		Methods:        []string{method("Messaging", "SearchBlurbs"), "google.longrunning.Operations/GetOperation"},
+		RequiredFields: []string{"query"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:             "messaging.stream_blurbs",
+		Description:    "StreamBlurbs streams the changes to the blurbs of a room or user until expire_time.",
+		Methods:        []string{method("Messaging", "StreamBlurbs")},
+		RequiredFields: []string{"name", "expire_time"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:          "messaging.send_blurbs",
+		Description: "SendBlurbs creates a blurb for each message of the stream and returns their names.",
+		Methods:     []string{method("Messaging", "SendBlurbs")},
+		Outcome:     succeeds(),
+	},
+	{
+		Id:             "messaging.connect",
+		Description:    "Connect creates the blurbs the client sends and streams the changes to the blurbs of the configured parent.",
+		Methods:        []string{method("Messaging", "Connect")},
+		RequiredFields: []string{"config.parent"},
+		Outcome:        succeeds(),
+	},
+
+	// Testing.
+	{
+		Id:          "testing.session_lifecycle",
+		Description: "A session can be created, read, listed, reported on and deleted.",
+		Methods: []string{
+			method("Testing", "CreateSession"),
+			method("Testing", "GetSession"),
+			method("Testing", "ListSessions"),
+			method("Testing", "ReportSession"),
+			method("Testing", "DeleteSession"),
+		},
+		RequiredFields: []string{"name"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:          "testing.tests",
+		Description: "The tests of a session can be listed, verified and declined.",
+		Methods: []string{
+			method("Testing", "ListTests"),
+			method("Testing", "VerifyTest"),
+			method("Testing", "DeleteTest"),
+		},
+		RequiredFields: []string{"parent", "name"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:          "testing.descriptors",
+		Description: "GetShowcaseDescriptors returns the descriptors of the Showcase protos and their dependencies.",
+		Methods:     []string{method("Testing", "GetShowcaseDescriptors")},
+		Outcome:     succeeds(),
+	},
+	{
+		Id:          "testing.settings",
+		Description: "UpdateShowcaseSettings replaces the settings named by update_mask, which GetShowcaseSettings then reports.",
+		Methods: []string{
+			method("Testing", "UpdateShowcaseSettings"),
+			method("Testing", "GetShowcaseSettings"),
+		},
+		RequiredFields: []string{"settings", "update_mask"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:             "testing.settings_invalid",
+		Description:    "UpdateShowcaseSettings fails for settings the server cannot run with.",
+		Methods:        []string{method("Testing", "UpdateShowcaseSettings")},
+		RequiredFields: []string{"settings", "update_mask"},
+		Outcome:        fails(code.Code_INVALID_ARGUMENT, showcaseerrors.SettingInvalid),
+	},
+	{
+		Id:          "testing.strict_quota_project",
+		Description: "Under strict_quota_project, calls with an invalid x-goog-user-project metadata fail.",
+		Methods: []string{
+			method("Testing", "UpdateShowcaseSettings"),
+			method("Echo", "Echo"),
+		},
+		Outcome: fails(code.Code_INVALID_ARGUMENT, showcaseerrors.QuotaProjectInvalid),
+	},
+	{
+		Id:          "testing.library_version_too_old",
+		Description: "Calls whose x-goog-api-client names a library older than its min_library_versions fail, naming the minimum.",
+		Methods: []string{
+			method("Testing", "UpdateShowcaseSettings"),
+			method("Echo", "Echo"),
+		},
+		Outcome: fails(code.Code_FAILED_PRECONDITION, showcaseerrors.LibraryVersionTooOld),
+	},
+	{
+		Id:          "testing.method_overload",
+		Description: "Calls beyond the rate SetMethodOverload sets fail with a RetryInfo detail.",
+		Methods: []string{
+			method("Testing", "SetMethodOverload"),
+			method("Echo", "Echo"),
+		},
+		RequiredFields: []string{"method", "qps_limit"},
+		Outcome:        fails(code.Code_RESOURCE_EXHAUSTED),
+	},
+	{
+		Id:          "testing.purge_namespace",
+		Description: "PurgeNamespace deletes the state of a namespace, leaving other namespaces alone.",
+		Methods: []string{
+			method("Testing", "CreateEchoCorpus"),
+			method("Testing", "PurgeNamespace"),
+		},
+		RequiredFields: []string{"namespace"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:          "testing.state_session",
+		Description: "EndSession deletes the state created by the calls naming the session, and counts what it deleted.",
+		Methods: []string{
+			method("Testing", "StartSession"),
+			method("Testing", "CreateEchoCorpus"),
+			method("Testing", "EndSession"),
+		},
+		RequiredFields: []string{"session_id"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:          "testing.state_session_ended",
+		Description: "Calls naming a session that ended or expired fail.",
+		Methods: []string{
+			method("Testing", "EndSession"),
+			method("Echo", "Echo"),
+		},
+		RequiredFields: []string{"session_id"},
+		Outcome:        fails(code.Code_NOT_FOUND),
+	},
+	{
+		Id:          "testing.endpoints",
+		Description: "GetEndpoints lists the replicas of the server and their health, even on a failing replica.",
+		Methods:     []string{method("Testing", "GetEndpoints")},
+		Outcome:     succeeds(),
+	},
+	{
+		Id:          "testing.failing_replica",
+		Description: "Calls to a replica marked as failing fail, so that clients fail over to another replica.",
+		Methods: []string{
+			method("Testing", "GetEndpoints"),
+			method("Echo", "Echo"),
+		},
+		Outcome: fails(code.Code_UNAVAILABLE),
+	},
+	{
+		Id:          "testing.expect_request",
+		Description: "GetExpectationResult reports whether the next request to a method matched the request registered with ExpectRequest, and the paths of the fields that differ.",
+		Methods: []string{
+			method("Testing", "ExpectRequest"),
+			method("Echo", "Echo"),
+			method("Testing", "GetExpectationResult"),
+		},
+		RequiredFields: []string{"method", "expected", "id"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:          "testing.expect_request_fail_on_mismatch",
+		Description: "With fail_on_mismatch, a request that does not match its expectation fails instead of reaching the method.",
+		Methods: []string{
+			method("Testing", "ExpectRequest"),
+			method("Echo", "Echo"),
+		},
+		RequiredFields: []string{"method", "expected", "fail_on_mismatch"},
+		Outcome:        fails(code.Code_FAILED_PRECONDITION, showcaseerrors.RequestMismatch),
+	},
+	{
+		Id:          "testing.byte_budget_exceeded",
+		Description: "Once a namespace has exchanged its namespace_byte_budget of bytes, its calls fail until ResetByteBudget or the budget window rolls over.",
+		Methods: []string{
+			method("Testing", "UpdateShowcaseSettings"),
+			method("Echo", "Echo"),
+			method("Testing", "ResetByteBudget"),
+		},
+		Outcome: fails(code.Code_RESOURCE_EXHAUSTED, showcaseerrors.ByteBudgetExceeded),
+	},
+	{
+		Id:          "testing.scenario",
+		Description: "A scenario loaded with LoadScenario fails, delays or adds headers to the calls at the positions its steps name, and GetScenarioState reports its progress.",
+		Methods: []string{
+			method("Testing", "LoadScenario"),
+			method("Echo", "Echo"),
+			method("Testing", "GetScenarioState"),
+		},
+		RequiredFields: []string{"name", "script"},
+		Outcome:        fails(code.Code_UNAVAILABLE, showcaseerrors.ScenarioStep),
+	},
+	{
+		Id:             "testing.scenario_invalid",
+		Description:    "LoadScenario fails for a script whose steps name no Showcase method or no positive call_index, or script the Testing service.",
+		Methods:        []string{method("Testing", "LoadScenario")},
+		RequiredFields: []string{"script"},
+		Outcome:        fails(code.Code_INVALID_ARGUMENT, showcaseerrors.FieldRequired, showcaseerrors.FieldInvalid, showcaseerrors.FieldOutOfRange),
+	},
+	{
+		Id:          "testing.server_metrics",
+		Description: "GetServerMetrics reports the counts of the server's calls.",
+		Methods:     []string{method("Testing", "GetServerMetrics")},
+		Outcome:     succeeds(),
+	},
+	{
+		Id:          "testing.parse_resource_names",
+		Description: "ParseResourceNames returns the pattern each name matched and its segment values.",
+		Methods:     []string{method("Testing", "ParseResourceNames")},
+		Outcome:     succeeds(),
+	},
+	{
+		Id:          "testing.channelz_summary",
+		Description: "GetChannelzSummary returns totals of the channelz data of the server's connections.",
+		Methods:     []string{method("Testing", "GetChannelzSummary")},
+		Outcome:     succeeds(),
+	},
+	{
+		Id:             "testing.measure_round_trip",
+		Description:    "MeasureRoundTrip returns the latencies of encoding and decoding messages, with the server's settings.",
+		Methods:        []string{method("Testing", "MeasureRoundTrip")},
+		RequiredFields: []string{"count"},
+		Outcome:        succeeds(),
+	},
+	{
+		Id:          "testing.dump_state",
+		Description: "DumpState fails unless the server was started with --enable-admin.",
+		Methods:     []string{method("Testing", "DumpState")},
+		Outcome:     fails(code.Code_PERMISSION_DENIED),
+	},
+	{
+		Id:          "testing.conformance_scenarios",
+		Description: "ListConformanceScenarios lists these scenarios, ordered by ID.",
+		Methods:     []string{method("Testing", "ListConformanceScenarios")},
+		Outcome:     succeeds(),
+	},
+}
@@ -0,0 +1,139 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	lropb "google.golang.org/genproto/googleapis/longrunning"
+)
+
+// serviceMethods returns the full names of the methods of the server
+// interface of a service.
+func serviceMethods(prefix string, server interface{}) []string {
+	t := reflect.TypeOf(server).Elem()
+	methods := []string{}
+	for i := 0; i < t.NumMethod(); i++ {
+		methods = append(methods, prefix+"/"+t.Method(i).Name)
+	}
+	return methods
+}
+
+func TestConformanceScenarios(t *testing.T) {
+	showcase := map[string][]string{
+		"Echo":      serviceMethods("google.showcase.v1beta1.Echo", (*pb.EchoServer)(nil)),
+		"Identity":  serviceMethods("google.showcase.v1beta1.Identity", (*pb.IdentityServer)(nil)),
+		"Messaging": serviceMethods("google.showcase.v1beta1.Messaging", (*pb.MessagingServer)(nil)),
+		"Testing":   serviceMethods("google.showcase.v1beta1.Testing", (*pb.TestingServer)(nil)),
+	}
+	known := map[string]bool{}
+	for _, methods := range showcase {
+		for _, m := range methods {
+			known[m] = true
+		}
+	}
+	for _, m := range serviceMethods("google.longrunning.Operations", (*lropb.OperationsServer)(nil)) {
+		known[m] = true
+	}
+
+	used := map[string]bool{}
+	ids := map[string]bool{}
+	for _, scenario := range conformanceScenarios {
+		if scenario.GetId() == "" || scenario.GetDescription() == "" || scenario.GetOutcome() == nil {
+			t.Errorf("scenario %v: want an ID, a description and an outcome", scenario)
+		}
+		if ids[scenario.GetId()] {
+			t.Errorf("scenario %s: the ID is used by another scenario", scenario.GetId())
+		}
+		ids[scenario.GetId()] = true
+		if len(scenario.GetMethods()) == 0 {
+			t.Errorf("scenario %s: want at least one method", scenario.GetId())
+		}
+		for _, m := range scenario.GetMethods() {
+			if !known[m] {
+				t.Errorf("scenario %s: the method %s does not exist", scenario.GetId(), m)
+			}
+			used[m] = true
+		}
+	}
+	for service, methods := range showcase {
+		for _, m := range methods {
+			if !used[m] {
+				t.Errorf("%s: the method %s has no conformance scenario; add one to conformanceScenarios", service, m)
+			}
+		}
+	}
+}
+
+func TestListConformanceScenarios(t *testing.T) {
+	s := &testingServerImpl{}
+	resp, err := s.ListConformanceScenarios(context.Background(), &pb.ListConformanceScenariosRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetScenarios()) != len(conformanceScenarios) {
+		t.Errorf("ListConformanceScenarios: want %d scenarios got %d", len(conformanceScenarios), len(resp.GetScenarios()))
+	}
+	if !sort.SliceIsSorted(resp.GetScenarios(), func(i, j int) bool {
+		return resp.GetScenarios()[i].GetId() < resp.GetScenarios()[j].GetId()
+	}) {
+		t.Errorf("ListConformanceScenarios: want the scenarios ordered by ID")
+	}
+
+	marshal := func() []byte {
+		resp, err := s.ListConformanceScenarios(context.Background(), &pb.ListConformanceScenariosRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := proto.NewBuffer(nil)
+		b.SetDeterministic(true)
+		if err := b.Marshal(resp); err != nil {
+			t.Fatal(err)
+		}
+		return b.Bytes()
+	}
+	if first := marshal(); !bytes.Equal(first, marshal()) {
+		t.Errorf("ListConformanceScenarios: want the same bytes on every call")
+	}
+
+	// Callers cannot change the registry through a response.
+	resp.GetScenarios()[0].Id = "changed"
+	if conformanceScenarios[0].GetId() == "changed" {
+		t.Errorf("ListConformanceScenarios: want copies of the scenarios")
+	}
+}
+
+func TestListConformanceScenarios_method(t *testing.T) {
+	s := &testingServerImpl{}
+	method := "google.showcase.v1beta1.Echo/GetLastExpandStatus"
+	resp, err := s.ListConformanceScenarios(context.Background(), &pb.ListConformanceScenariosRequest{Method: method})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetScenarios()) == 0 {
+		t.Fatalf("ListConformanceScenarios(%s): want at least one scenario", method)
+	}
+	for _, scenario := range resp.GetScenarios() {
+		if !usesMethod(scenario, method) {
+			t.Errorf("ListConformanceScenarios(%s): got %s, which does not use it", method, scenario.GetId())
+		}
+	}
+}
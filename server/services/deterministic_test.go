@@ -0,0 +1,133 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/googleapis/gapic-showcase/server"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	lropb "google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// deterministicWorkloadEnv makes TestDeterministicWorkload_helper run the
+// workload in a process of its own.
+const deterministicWorkloadEnv = "SHOWCASE_DETERMINISTIC_WORKLOAD"
+
+func TestDeterministicWorkload(t *testing.T) {
+	var runs [2][]byte
+	for i := range runs {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestDeterministicWorkload_helper$")
+		cmd.Env = append(os.Environ(), deterministicWorkloadEnv+"=1")
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("The workload failed: %v\n%s", err, out)
+		}
+		runs[i] = out
+	}
+	if !bytes.Contains(runs[0], []byte("response ")) {
+		t.Fatalf("The workload recorded no responses:\n%s", runs[0])
+	}
+	if !bytes.Equal(runs[0], runs[1]) {
+		t.Errorf("Two deterministic servers responded differently:\n%s\n---\n%s", runs[0], runs[1])
+	}
+}
+
+// TestDeterministicWorkload_helper runs a scripted workload against a fresh
+// deterministic server, printing every response in its wire encoding.
+func TestDeterministicWorkload_helper(t *testing.T) {
+	if os.Getenv(deterministicWorkloadEnv) == "" {
+		t.Skip("Run by TestDeterministicWorkload in a process of its own.")
+	}
+	server.SetDeterministic(true)
+	defer server.SetDeterministic(false)
+	ctx := context.Background()
+	record := func(name string, resp proto.Message, err error) {
+		if err != nil {
+			resp = status.Convert(err).Proto()
+		}
+		b, merr := proto.Marshal(resp)
+		if merr != nil {
+			t.Fatalf("%s: %v", name, merr)
+		}
+		fmt.Printf("response %s %x\n", name, b)
+	}
+
+	identity := NewIdentityServer()
+	messaging := NewMessagingServer(identity)
+	echo := NewEchoServer()
+	operations := NewOperationsServer(messaging)
+
+	user, err := identity.CreateUser(ctx, &pb.CreateUserRequest{
+		User: &pb.User{DisplayName: "Musubi", Email: "musubi@example.com"},
+	})
+	record("CreateUser", user, err)
+	room, err := messaging.CreateRoom(ctx, &pb.CreateRoomRequest{Room: &pb.Room{DisplayName: "Kitchen"}})
+	record("CreateRoom", room, err)
+	for _, text := range []string{"meow", "purr", "hiss"} {
+		blurb, err := messaging.CreateBlurb(ctx, &pb.CreateBlurbRequest{
+			Parent: room.GetName(),
+			Blurb:  &pb.Blurb{User: user.GetName(), Content: &pb.Blurb_Text{Text: text}},
+		})
+		record("CreateBlurb", blurb, err)
+	}
+	page, err := messaging.ListBlurbs(ctx, &pb.ListBlurbsRequest{Parent: room.GetName(), PageSize: 2})
+	record("ListBlurbs", page, err)
+	page, err = messaging.ListBlurbs(ctx, &pb.ListBlurbsRequest{
+		Parent:    room.GetName(),
+		PageSize:  2,
+		PageToken: page.GetNextPageToken(),
+	})
+	record("ListBlurbs", page, err)
+
+	for _, content := range []string{"one", "two"} {
+		resp, err := echo.Echo(ctx, &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: content}})
+		record("Echo", resp, err)
+	}
+	op, err := echo.Wait(ctx, &pb.WaitRequest{
+		End:      &pb.WaitRequest_Ttl{Ttl: ptypes.DurationProto(5 * time.Millisecond)},
+		Response: &pb.WaitRequest_Success{Success: &pb.WaitResponse{Content: "done"}},
+	})
+	record("Wait", op, err)
+	for i := 0; i < 3; i++ {
+		got, err := operations.GetOperation(ctx, &lropb.GetOperationRequest{Name: op.GetName()})
+		record("GetOperation", got, err)
+	}
+
+	settings := server.NewSettingsStore(server.DefaultSettings())
+	s := settings.Get()
+	s.ErrorInjection.ErrorRate = 0.5
+	settings.Set(s)
+	injector := server.NewErrorInjector(settings, nil)
+	info := &grpc.UnaryServerInfo{FullMethod: "/google.showcase.v1beta1.Echo/Echo"}
+	handler := func(context.Context, interface{}) (interface{}, error) { return &pb.EchoResponse{}, nil }
+	outcomes := []string{}
+	for i := 0; i < 20; i++ {
+		_, err := injector.UnaryInterceptor(ctx, nil, info, handler)
+		outcomes = append(outcomes, status.Code(err).String())
+	}
+	fmt.Printf("response ErrorInjection %s\n", strings.Join(outcomes, ","))
+}
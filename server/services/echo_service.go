@@ -15,136 +15,2481 @@
 package services
 
 import (
+	"container/list"
 	"context"
+	"fmt"
+	"hash/crc32"
 	"io"
+	"log"
+	"math"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/googleapis/gapic-showcase/server"
+	"github.com/googleapis/gapic-showcase/server/filtering"
 	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
 	lropb "google.golang.org/genproto/googleapis/longrunning"
+	code "google.golang.org/genproto/googleapis/rpc/code"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
 // NewEchoServer returns a new EchoServer for the Showcase API.
 func NewEchoServer() pb.EchoServer {
-	return &echoServerImpl{waiter: server.GetWaiterInstance()}
+	return &echoServerImpl{
+		waiter:       server.GetWaiterInstance(),
+		nowF:         server.Now,
+		afterF:       time.After,
+		settings:     server.GetSettingsInstance(),
+		regexes:      newRegexCache(maxCachedRegexes, regexp.Compile),
+		blobs:        blobStoreSingleton,
+		sequence:     server.GetSequenceInstance(),
+		corpora:      server.GetCorpusStoreInstance(),
+		resources:    server.GetEchoResourceStoreInstance(),
+		dedupe:       server.GetDedupeCacheInstance(),
+		operationIDs: server.GetOperationIDStoreInstance(),
+		forwarder:    server.GetForwarderInstance(),
+		expandStatus: server.GetExpandStatusStoreInstance(),
+		attempts:     server.GetAttemptCounterInstance(),
+		topics:       server.GetTopicStoreInstance(),
+		workers:      server.GetOperationWorkersInstance(),
+		metrics:      server.GetMetricsInstance(),
+
+		operationWatchers: server.GetOperationWatchersInstance(),
+
+		sessionPrefix: fmt.Sprintf("%08x", server.NewRand().Uint32()),
+	}
+}
+
+type echoServerImpl struct {
+	waiter       server.Waiter
+	nowF         func() time.Time
+	afterF       func(time.Duration) <-chan time.Time
+	settings     server.SettingsStore
+	regexes      *regexCache
+	blobs        *blobStore
+	sequence     server.Sequence
+	corpora      server.CorpusStore
+	resources    server.EchoResourceStore
+	dedupe       server.DedupeCache
+	operationIDs server.OperationIDStore
+	forwarder    server.Forwarder
+	expandStatus server.ExpandStatusStore
+	attempts     server.AttemptCounter
+	topics       server.TopicStore
+	workers      *server.WorkerOperationStore
+	metrics      server.Metrics
+
+	// operationWatchers end the StreamOperationUpdates streams of deleted
+	// operations.
+	operationWatchers server.OperationWatchers
+
+	// sessionPrefix and sessions make the IDs of Chat sessions, which are
+	// unique to this server. sessions must be accessed atomically.
+	sessionPrefix string
+	sessions      int64
+
+	// subscribers numbers the StreamingPullEcho streams, whose IDs share
+	// sessionPrefix. It must be accessed atomically.
+	subscribers int64
+}
+
+func (s *echoServerImpl) Echo(ctx context.Context, in *pb.EchoRequest) (*pb.EchoResponse, error) {
+	if err := server.CheckFieldMask("read_mask", in.GetReadMask(), &pb.EchoResponse{}); err != nil {
+		return nil, err
+	}
+	if in.GetCorruptUtf8Response() && !s.settings.Get().EnableNonconforming {
+		return nil, status.Error(
+			codes.PermissionDenied,
+			"The field `corrupt_utf8_response` requires the server to be started with --enable-nonconforming.")
+	}
+	resp, err := s.dedupedEcho(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	server.ApplyFieldMask(in.GetReadMask(), resp)
+	if in.GetCorruptUtf8Response() {
+		resp = corruptUTF8(resp)
+	}
+	return resp, nil
+}
+
+// corruptUTF8 returns a copy of resp whose content is followed by invalid
+// UTF-8. proto.Marshal refuses to write it, so the content is written by
+// hand, as field 1, into the unknown fields, which are marshaled as they are.
+func corruptUTF8(resp *pb.EchoResponse) *pb.EchoResponse {
+	corrupt := proto.Clone(resp).(*pb.EchoResponse)
+	corrupt.XXX_unrecognized = server.AppendRawString(corrupt.XXX_unrecognized, 1, corrupt.GetContent()+server.InvalidUTF8)
+	corrupt.Content = ""
+	return corrupt
+}
+
+// dedupedEcho answers an Echo request, from the cache if it asks for
+// deduplication.
+func (s *echoServerImpl) dedupedEcho(ctx context.Context, in *pb.EchoRequest) (*pb.EchoResponse, error) {
+	if in.GetDedupeWindow() == nil {
+		return s.forwardingEcho(ctx, in)
+	}
+	window, err := ptypes.Duration(in.GetDedupeWindow())
+	if err != nil || window < 0 {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldOutOfRange, "dedupe_window", "The field `dedupe_window` must be a non-negative duration.")
+	}
+	if window == 0 {
+		return s.forwardingEcho(ctx, in)
+	}
+	keyed := proto.Clone(in).(*pb.EchoRequest)
+	keyed.DedupeWindow = nil
+	key, err := server.RequestHash(keyed)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "The request could not be hashed: %s.", err)
+	}
+	namespace := server.NamespaceFromContext(ctx)
+	if cached, ok := s.dedupe.Get(namespace, key, window); ok {
+		resp := cached.(*pb.EchoResponse)
+		resp.ServedFromCache = true
+		return resp, nil
+	}
+	resp, err := s.forwardingEcho(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	s.dedupe.Put(namespace, key, resp, window)
+	return resp, nil
+}
+
+// forwardingEcho answers an Echo request, by forwarding it to the server
+// itself if it sets forward_depth, and records the hop of a forwarded call.
+func (s *echoServerImpl) forwardingEcho(ctx context.Context, in *pb.EchoRequest) (*pb.EchoResponse, error) {
+	depth := in.GetForwardDepth()
+	if depth < 0 || depth > server.MaxForwardDepth {
+		return nil, showcaseerrors.Field(
+			showcaseerrors.FieldOutOfRange,
+			"forward_depth",
+			"The field `forward_depth` must be between 0 and %d.",
+			server.MaxForwardDepth)
+	}
+	hop, ok, err := server.ForwardHop(ctx, depth)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return s.echo(ctx, in)
+	}
+	var resp *pb.EchoResponse
+	if depth == 0 {
+		resp, err = s.echo(ctx, in)
+	} else {
+		forwarded := proto.Clone(in).(*pb.EchoRequest)
+		forwarded.ForwardDepth--
+		resp, err = s.forwarder.Forward(ctx, hop.GetHop(), forwarded)
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp.ForwardHops = append([]*pb.ForwardHop{hop}, resp.GetForwardHops()...)
+	return resp, nil
+}
+
+// failAttempt counts the attempt of an Echo request with fail_first_attempts,
+// and fails it if it is one of the first fail_first_attempts.
+func (s *echoServerImpl) failAttempt(ctx context.Context, in *pb.EchoRequest) error {
+	fail := in.GetFailFirstAttempts()
+	if fail < 0 {
+		return showcaseerrors.Field(showcaseerrors.FieldOutOfRange, "fail_first_attempts", "The field `fail_first_attempts` must not be negative.")
+	}
+	if fail == 0 {
+		return nil
+	}
+	key := "request_id/" + in.GetRequestId()
+	if in.GetRequestId() == "" {
+		hash, err := server.RequestHash(in)
+		if err != nil {
+			return status.Errorf(codes.Internal, "The request could not be hashed: %s.", err)
+		}
+		key = "hash/" + hash
+	}
+	attempt := s.attempts.Next(server.NamespaceFromContext(ctx), key)
+	if attempt > int64(fail) {
+		return nil
+	}
+	return status.ErrorProto(&spb.Status{
+		Code:    int32(codes.Unavailable),
+		Message: fmt.Sprintf("Attempt %d of the request fails, as the first %d attempts do.", attempt, fail),
+		Details: []*any.Any{showcaseerrors.ErrorInfo(showcaseerrors.AttemptFailed, showcaseerrors.Domain, map[string]string{
+			"attempt":             strconv.FormatInt(attempt, 10),
+			"fail_first_attempts": strconv.Itoa(int(fail)),
+		})},
+	})
+}
+
+// hazardousMessageSuffix is appended to the status messages of Echo requests
+// with hazardous_error_message set. It holds the characters that encoding a
+// message in the grpc-message trailer has to escape, and sequences that a
+// client decoding it twice would change.
+const hazardousMessageSuffix = "\r\n100% %41%%0A ✓ 🙂"
+
+// echo answers an Echo request without deduplication.
+func (s *echoServerImpl) echo(ctx context.Context, in *pb.EchoRequest) (*pb.EchoResponse, error) {
+	if err := s.failAttempt(ctx, in); err != nil {
+		return nil, err
+	}
+	if in.GetHazardousErrorMessage() {
+		if in.GetError().GetCode() == int32(codes.OK) {
+			return nil, showcaseerrors.Field(
+				showcaseerrors.FieldConflict,
+				"hazardous_error_message",
+				"The field `hazardous_error_message` requires an `error` with a code other than OK.")
+		}
+		st := proto.Clone(in.GetError()).(*spb.Status)
+		st.Message += hazardousMessageSuffix
+		return nil, status.ErrorProto(st)
+	}
+	err := status.ErrorProto(in.GetError())
+	if err != nil {
+		return nil, err
+	}
+	if pattern := in.GetValidateContentRegex(); pattern != "" {
+		re, err := s.regexes.get(pattern)
+		if err != nil {
+			return nil, showcaseerrors.BadRequest(
+				showcaseerrors.FieldInvalid,
+				"validate_content_regex",
+				fmt.Sprintf("The field `validate_content_regex` is not a valid regular expression: %s", err))
+		}
+		if !re.MatchString(in.GetContent()) {
+			return nil, showcaseerrors.BadRequest(
+				showcaseerrors.FieldInvalid,
+				"content",
+				fmt.Sprintf("The field `content` does not match the regular expression `%s`.", pattern))
+		}
+	}
+	resp := &pb.EchoResponse{
+		Content:        in.GetContent(),
+		ClientSequence: in.GetClientSequence(),
+		ServerSequence: s.sequence.Next(),
+		QuotaProject:   server.QuotaProjectFromContext(ctx),
+	}
+	if !in.GetStripUnknownFields() {
+		// Fields from newer clients survive the round trip.
+		resp.XXX_unrecognized = append([]byte(nil), in.XXX_unrecognized...)
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("accept-language"); len(values) > 0 {
+		ranges, err := server.ParseAcceptLanguage(strings.Join(values, ","))
+		if err != nil {
+			return nil, showcaseerrors.Metadata("accept-language", "The accept-language metadata is invalid: %s.", err)
+		}
+		resp.Locale = server.MatchLanguage(ranges, s.settings.Get().SupportedLocales)
+		if greeting, ok := greetings[resp.Locale]; ok {
+			resp.Content = greeting + " " + resp.Content
+		}
+	}
+	if err := s.repeatContent(resp, in); err != nil {
+		return nil, err
+	}
+	if in.GetEchoAttempts() {
+		if resp.PreviousRpcAttempts, err = server.Attempt(ctx, server.PreviousRPCAttemptsHeader); err != nil {
+			return nil, showcaseerrors.Metadata(server.PreviousRPCAttemptsHeader, "The %s.", err)
+		}
+		header := s.settings.Get().ClientAttemptHeader
+		if resp.ClientAttempt, err = server.Attempt(ctx, header); err != nil {
+			return nil, showcaseerrors.Metadata(header, "The %s.", err)
+		}
+	}
+	if in.GetIncludeTransportInfo() {
+		resp.TransportInfo = server.TransportInfo(ctx)
+	}
+	if cc := in.GetCacheControl(); cc != nil {
+		value, err := cacheControlValue(cc)
+		if err != nil {
+			return nil, err
+		}
+		if err := server.SetHeader(ctx, metadata.Pairs(server.CacheControlHeader, value)); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// repeatContent fills the repeated content of the response, unless the
+// response would then exceed the server's message size limit. The size is
+// computed before the content is repeated, so that huge repeat counts use no
+// memory.
+func (s *echoServerImpl) repeatContent(resp *pb.EchoResponse, in *pb.EchoRequest) error {
+	count := in.GetRepeatCount()
+	if count < 0 {
+		return showcaseerrors.Field(showcaseerrors.FieldOutOfRange, "repeat_count", "The field `repeat_count` must not be negative.")
+	}
+	if count == 0 {
+		return nil
+	}
+	size := repeatedContentSize(resp, in.GetContent(), count)
+	if max := int64(s.settings.Get().MaxSendMessageBytes); size > max {
+		return status.ErrorProto(&spb.Status{
+			Code: int32(codes.ResourceExhausted),
+			Message: fmt.Sprintf(
+				"The response would be %d bytes, more than the %d the server sends. "+
+					"Use Expand to stream the content instead.",
+				size,
+				max),
+			Details: []*any.Any{showcaseerrors.ErrorInfo(showcaseerrors.ResponseTooLarge, showcaseerrors.Domain, map[string]string{
+				"suggested_method":   "google.showcase.v1beta1.Echo/Expand",
+				"response_bytes":     strconv.FormatInt(size, 10),
+				"max_response_bytes": strconv.FormatInt(max, 10),
+			})},
+		})
+	}
+	resp.RepeatedContent = make([]string, count)
+	for i := range resp.RepeatedContent {
+		resp.RepeatedContent[i] = in.GetContent()
+	}
+	return nil
+}
+
+// repeatedContentSize returns the serialized size of the response once it
+// holds the content repeated count times.
+func repeatedContentSize(resp *pb.EchoResponse, content string, count int32) int64 {
+	// Each element is a one byte tag, its length and its bytes.
+	element := 1 + proto.SizeVarint(uint64(len(content))) + len(content)
+	return int64(proto.Size(resp)) + int64(count)*int64(element)
+}
+
+// greetings are the greetings the Echo method prefixes content with.
+var greetings = map[string]string{
+	"en": "Hello",
+	"es": "Hola",
+	"ja": "こんにちは",
+}
+
+// cacheControlValue formats caching hints as a Cache-Control header value.
+func cacheControlValue(cc *pb.CacheControl) (string, error) {
+	if cc.GetMaxAge() < 0 {
+		return "", showcaseerrors.Field(showcaseerrors.FieldOutOfRange, "cache_control.max_age", "The field `cache_control.max_age` must not be negative.")
+	}
+	if cc.GetNoStore() {
+		if cc.GetMaxAge() > 0 {
+			return "", showcaseerrors.Field(
+				showcaseerrors.FieldConflict,
+				"cache_control.max_age",
+				"The field `cache_control.max_age` must be zero when `cache_control.no_store` is set.")
+		}
+		return "no-store", nil
+	}
+	return fmt.Sprintf("max-age=%d", cc.GetMaxAge()), nil
+}
+
+// credentialKeys are the metadata keys that carry credentials.
+var credentialKeys = []string{
+	"authorization",
+	"x-goog-api-key",
+	"x-goog-iam-authority-selector",
+	"x-goog-iam-authorization-token",
+}
+
+func (s *echoServerImpl) InspectCredentials(ctx context.Context, in *pb.InspectCredentialsRequest) (*pb.InspectCredentialsResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	resp := &pb.InspectCredentialsResponse{}
+	for _, key := range credentialKeys {
+		values := md.Get(key)
+		if len(values) == 0 {
+			continue
+		}
+		duplicated := len(values) > 1
+		resp.Credentials = append(resp.Credentials, &pb.InspectCredentialsResponse_Credential{
+			Key:        key,
+			Values:     values,
+			Duplicated: duplicated,
+		})
+		if duplicated && key == "authorization" {
+			msg := fmt.Sprintf("The `authorization` header was received %d times.", len(values))
+			if in.GetStrict() {
+				return nil, status.Error(codes.Unauthenticated, msg)
+			}
+			resp.Warnings = append(resp.Warnings, msg)
+		}
+	}
+	return resp, nil
+}
+
+// maxCachedRegexes is the number of compiled content validation patterns the
+// Echo server keeps.
+const maxCachedRegexes = 100
+
+// regexCache is a bounded, least recently used cache of compiled regular
+// expressions. It is safe for concurrent use.
+type regexCache struct {
+	mu      sync.Mutex
+	size    int
+	compile func(string) (*regexp.Regexp, error)
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type regexCacheEntry struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+func newRegexCache(size int, compile func(string) (*regexp.Regexp, error)) *regexCache {
+	return &regexCache{
+		size:    size,
+		compile: compile,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+// get returns the compiled pattern, compiling and caching it if necessary.
+// Patterns that fail to compile are not cached.
+func (c *regexCache) get(pattern string) (*regexp.Regexp, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[pattern]; ok {
+		c.order.MoveToFront(e)
+		return e.Value.(*regexCacheEntry).re, nil
+	}
+
+	re, err := c.compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	c.entries[pattern] = c.order.PushFront(&regexCacheEntry{pattern: pattern, re: re})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*regexCacheEntry).pattern)
+	}
+	return re, nil
+}
+
+func (s *echoServerImpl) BatchEcho(ctx context.Context, in *pb.BatchEchoRequest) (*pb.BatchEchoResponse, error) {
+	if max := s.settings.Get().MaxBatchEchoSize; int64(len(in.GetRequests())) > int64(max) {
+		return nil, showcaseerrors.Field(
+			showcaseerrors.FieldOutOfRange,
+			"requests",
+			"The field `requests` may have at most %d items, but has %d.",
+			max,
+			len(in.GetRequests()))
+	}
+	resp := &pb.BatchEchoResponse{Results: make([]*pb.BatchEchoResult, 0, len(in.GetRequests()))}
+	for _, req := range in.GetRequests() {
+		result := &pb.BatchEchoResult{}
+		if echoed, err := s.Echo(ctx, req); err != nil {
+			result.Result = &pb.BatchEchoResult_Error{Error: status.Convert(err).Proto()}
+		} else {
+			result.Result = &pb.BatchEchoResult_Response{Response: echoed}
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	return resp, nil
+}
+
+func (s *echoServerImpl) Expand(in *pb.ExpandRequest, stream pb.Echo_ExpandServer) error {
+	if in.GetStreamId() == "" {
+		return s.expand(in, stream)
+	}
+	counted := &countedExpandStream{Echo_ExpandServer: stream}
+	err := s.expand(in, counted)
+	s.expandStatus.Put(server.NamespaceFromContext(stream.Context()), &pb.ExpandStatus{
+		StreamId:     in.GetStreamId(),
+		MessagesSent: counted.messages,
+		BytesSent:    counted.bytes,
+		Termination:  expandTermination(stream.Context(), err),
+	})
+	return err
+}
+
+// countedExpandStream counts the messages an Expand stream sends.
+type countedExpandStream struct {
+	pb.Echo_ExpandServer
+	messages int64
+	bytes    int64
+}
+
+func (s *countedExpandStream) Send(resp *pb.EchoResponse) error {
+	if err := s.Echo_ExpandServer.Send(resp); err != nil {
+		return err
+	}
+	s.messages++
+	s.bytes += int64(proto.Size(resp))
+	return nil
+}
+
+// expandTermination returns how an Expand stream that returned err ended.
+func expandTermination(ctx context.Context, err error) pb.ExpandStatus_Termination {
+	switch {
+	case err == nil:
+		return pb.ExpandStatus_COMPLETED
+	case ctx.Err() == context.Canceled:
+		return pb.ExpandStatus_CANCELLED
+	case ctx.Err() == context.DeadlineExceeded:
+		return pb.ExpandStatus_DEADLINE_EXCEEDED
+	default:
+		return pb.ExpandStatus_FAILED
+	}
+}
+
+func (s *echoServerImpl) GetLastExpandStatus(ctx context.Context, in *pb.GetLastExpandStatusRequest) (*pb.ExpandStatus, error) {
+	if in.GetStreamId() == "" {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldRequired, "stream_id", "The field `stream_id` is required.")
+	}
+	st, ok := s.expandStatus.Get(server.NamespaceFromContext(ctx), in.GetStreamId())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "No Expand stream with the ID %q has ended.", in.GetStreamId())
+	}
+	return st, nil
+}
+
+func (s *echoServerImpl) expand(in *pb.ExpandRequest, stream pb.Echo_ExpandServer) error {
+	if in.GetRepeatCount() < 0 {
+		return showcaseerrors.Field(showcaseerrors.FieldOutOfRange, "repeat_count", "The field `repeat_count` must not be negative.")
+	}
+	empties := int(in.GetEmitEmptyMessages())
+	if empties < 0 {
+		return showcaseerrors.Field(showcaseerrors.FieldOutOfRange, "emit_empty_messages", "The field `emit_empty_messages` must not be negative.")
+	}
+	if empties > 0 && (in.GetContent() != "" || in.GetCorpusName() != "" || len(in.GetSizePattern()) > 0) {
+		return showcaseerrors.Field(
+			showcaseerrors.FieldConflict,
+			"emit_empty_messages",
+			"The field `emit_empty_messages` must not be set with `content`, `corpus_name` or `size_pattern`.")
+	}
+	words := strings.Fields(in.GetContent())
+	content := func(i int) string { return words[i%len(words)] }
+	count := len(words)
+	if pattern := in.GetSizePattern(); len(pattern) > 0 {
+		var err error
+		count, err = s.checkSizePattern(in)
+		if err != nil {
+			return err
+		}
+		content = func(i int) string { return sizedContent(i, int(pattern[i%len(pattern)])) }
+	} else if in.GetMessageCount() != 0 {
+		return showcaseerrors.Field(showcaseerrors.FieldConflict, "message_count", "The field `message_count` requires `size_pattern` to be set.")
+	} else if name := in.GetCorpusName(); name != "" {
+		if in.GetContent() != "" {
+			return showcaseerrors.Field(showcaseerrors.FieldConflict, "content", "The fields `content` and `corpus_name` must not both be set.")
+		}
+		// The corpus is a snapshot, so deleting it does not disturb the stream.
+		corpus, ok := s.corpora.Get(server.NamespaceFromContext(stream.Context()), name)
+		if !ok {
+			return status.Errorf(codes.NotFound, "The corpus %q does not exist.", name)
+		}
+		words = corpus
+		count = len(words)
+	} else if empties > 0 {
+		count = empties
+		content = func(int) string { return "" }
+	}
+	delay, err := optionalDuration("message_delay", in.GetMessageDelay())
+	if err != nil {
+		return err
+	}
+	interval, err := optionalDuration("heartbeat_interval", in.GetHeartbeatInterval())
+	if err != nil {
+		return err
+	}
+	burst, trickle, err := expandThrottle(in)
+	if err != nil {
+		return err
+	}
+	if in.GetErrorSummary() && codes.Code(in.GetError().GetCode()) == codes.OK {
+		return showcaseerrors.Field(
+			showcaseerrors.FieldConflict,
+			"error_summary",
+			"The field `error_summary` requires `error` to have a code other than OK.")
+	}
+	trailers, err := streamTrailers(in.GetTrailers())
+	if err != nil {
+		return err
+	}
+	if trailers != nil {
+		defer stream.SetTrailer(trailers)
+	}
+	repeats := int(in.GetRepeatCount())
+	if repeats == 0 {
+		repeats = 1
+	}
+	summary := &streamSummary{}
+	if in.GetReportCompression() {
+		compression := sendCompression(stream.Context())
+		summary.compressed = compression != identityCompression
+		defer stream.SetTrailer(metadata.Pairs(expandCompressionTrailer, compression))
+	}
+	sent := 0
+	defer func() { stream.SetTrailer(metadata.Pairs(expandCountTrailer, strconv.Itoa(sent))) }()
+	for i := 0; i < repeats; i++ {
+		for j := 0; j < count; j++ {
+			if in.GetTrickleInterval() != nil {
+				delay = 0
+				if i*count+j >= burst {
+					delay = trickle
+				}
+			}
+			if err := s.expandDelay(stream, delay, interval); err != nil {
+				return err
+			}
+			word := content(i*count + j)
+			summary.add(word)
+			resp := &pb.EchoResponse{Content: word}
+			if in.GetWithSummary() {
+				resp.Index = summary.count
+			}
+			err := stream.Send(resp)
+			if err != nil {
+				return err
+			}
+			sent++
+		}
+	}
+	if in.GetWithSummary() {
+		if err := stream.Send(summary.response()); err != nil {
+			return err
+		}
+	}
+	if in.GetErrorSummary() {
+		return summary.error(in.GetError())
+	}
+	if in.GetError() != nil {
+		return status.ErrorProto(in.GetError())
+	}
+	return nil
+}
+
+// expandCountTrailer is the trailer of every Expand stream that counts the
+// words it sent.
+const expandCountTrailer = "showcase-expand-count"
+
+// The trailer of an Expand stream with `report_compression` that names the
+// compression of its responses, and the name of no compression.
+const (
+	expandCompressionTrailer = "showcase-compression"
+	identityCompression      = "identity"
+)
+
+// sendCompression returns the compression of the responses of the call. gRPC
+// compresses them as the client compressed its request, and offers no way to
+// change it for each message.
+func sendCompression(ctx context.Context) string {
+	s, ok := grpc.ServerTransportStreamFromContext(ctx).(interface{ RecvCompress() string })
+	if !ok || s.RecvCompress() == "" {
+		return identityCompression
+	}
+	return s.RecvCompress()
+}
+
+// streamSummary tallies the messages a stream sends.
+type streamSummary struct {
+	count    int64
+	checksum uint32
+
+	// Whether the messages are compressed, which the summary reports.
+	compressed bool
+}
+
+func (t *streamSummary) add(content string) {
+	t.count++
+	t.checksum = crc32.Update(t.checksum, crc32cTable, []byte(content+"\n"))
+}
+
+// response returns the summary as the EchoResponse that ends a summarized
+// stream.
+func (t *streamSummary) response() *pb.EchoResponse {
+	resp := &pb.EchoResponse{IsSummary: true, MessageCount: t.count, Checksum: t.checksum}
+	if t.compressed {
+		for i := int64(1); i <= t.count; i++ {
+			resp.CompressedIndices = append(resp.CompressedIndices, i)
+		}
+	}
+	return resp
+}
+
+// error returns st as an error, with the summary response appended to its
+// details.
+func (t *streamSummary) error(st *spb.Status) error {
+	return errorWithResponse(st, t.response())
+}
+
+// errorWithResponse returns st as an error, with the response appended to its
+// details.
+func errorWithResponse(st *spb.Status, resp *pb.EchoResponse) error {
+	detail, err := ptypes.MarshalAny(resp)
+	if err != nil {
+		return status.Errorf(codes.Internal, "The response could not be encoded: %s.", err)
+	}
+	st = proto.Clone(st).(*spb.Status)
+	st.Details = append(st.Details, detail)
+	return status.ErrorProto(st)
+}
+
+// trailerKey matches the metadata keys a request may ask a stream to end
+// with. Binary keys, ending in `-bin`, are excluded as the values are
+// strings.
+var trailerKey = regexp.MustCompile(`^[0-9a-z_.-]+$`)
+
+// streamTrailers returns the trailing metadata a request asks its stream to
+// end with, or nil if there is none.
+func streamTrailers(trailers map[string]string) (metadata.MD, error) {
+	if len(trailers) == 0 {
+		return nil, nil
+	}
+	keys := []string{}
+	for key := range trailers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	md := metadata.MD{}
+	for _, key := range keys {
+		if !trailerKey.MatchString(key) || strings.HasPrefix(key, "grpc-") || strings.HasSuffix(key, "-bin") {
+			return nil, showcaseerrors.Field(
+				showcaseerrors.FieldInvalid,
+				"trailers",
+				"The field `trailers` has the key %q, which is not a lowercase ASCII metadata key "+
+					"without a `grpc-` prefix or `-bin` suffix.",
+				key)
+		}
+		value := trailers[key]
+		for _, r := range value {
+			if r < 0x20 || r > 0x7e {
+				return nil, showcaseerrors.Field(
+					showcaseerrors.FieldInvalid,
+					"trailers",
+					"The field `trailers[%q]` must be printable ASCII.",
+					key)
+			}
+		}
+		md.Set(key, value)
+	}
+	return md, nil
+}
+
+// checkSizePattern validates the size_pattern of an Expand request, and
+// returns the number of messages it streams on each repeat.
+func (s *echoServerImpl) checkSizePattern(in *pb.ExpandRequest) (int, error) {
+	if in.GetContent() != "" || in.GetCorpusName() != "" {
+		return 0, showcaseerrors.Field(
+			showcaseerrors.FieldConflict,
+			"size_pattern",
+			"The field `size_pattern` must not be set with `content` or `corpus_name`.")
+	}
+	if in.GetMessageCount() < 0 {
+		return 0, showcaseerrors.Field(showcaseerrors.FieldOutOfRange, "message_count", "The field `message_count` must not be negative.")
+	}
+	count := int(in.GetMessageCount())
+	if count == 0 {
+		count = len(in.GetSizePattern())
+	}
+	max := int(s.settings.Get().MaxSendMessageBytes)
+	for i, size := range in.GetSizePattern() {
+		if size < 0 {
+			return 0, showcaseerrors.Field(showcaseerrors.FieldOutOfRange, "size_pattern", "The field `size_pattern[%d]` must not be negative.", i)
+		}
+		// The content is a one byte tag, its length and its bytes, and the
+		// index of a summarized stream at most an 11 byte field.
+		n := 1 + proto.SizeVarint(uint64(size)) + int(size)
+		if in.GetWithSummary() {
+			n += 11
+		}
+		if n > max {
+			return 0, showcaseerrors.Field(
+				showcaseerrors.FieldOutOfRange,
+				"size_pattern",
+				"The field `size_pattern[%d]` asks for a %d byte message, more than the %d bytes the server sends.",
+				i,
+				size,
+				max)
+		}
+	}
+	return count, nil
+}
+
+// sizedContent returns the filler content of the given size for the Expand
+// message at position i of the stream.
+func sizedContent(i, size int) string {
+	b := make([]byte, size)
+	for j := range b {
+		b[j] = byte('a' + (i+j)%26)
+	}
+	return string(b)
+}
+
+// optionalDuration converts a duration field, which must not be negative if
+// it is set.
+func optionalDuration(field string, d *duration.Duration) (time.Duration, error) {
+	if d == nil {
+		return 0, nil
+	}
+	value, err := ptypes.Duration(d)
+	if err != nil || value < 0 {
+		return 0, showcaseerrors.Field(showcaseerrors.FieldOutOfRange, field, "The field `%s` must be a non-negative duration.", field)
+	}
+	return value, nil
+}
+
+// expandThrottle returns the `burst_count` and `trickle_interval` of an Expand
+// request, checking that they make a throttle profile.
+func expandThrottle(in *pb.ExpandRequest) (int, time.Duration, error) {
+	if in.GetBurstCount() < 0 {
+		return 0, 0, showcaseerrors.Field(showcaseerrors.FieldOutOfRange, "burst_count", "The field `burst_count` must not be negative.")
+	}
+	trickle, err := optionalDuration("trickle_interval", in.GetTrickleInterval())
+	if err != nil {
+		return 0, 0, err
+	}
+	if in.GetBurstCount() > 0 && in.GetTrickleInterval() == nil {
+		return 0, 0, showcaseerrors.Field(
+			showcaseerrors.FieldConflict,
+			"burst_count",
+			"The field `burst_count` requires `trickle_interval` to be set.")
+	}
+	if in.GetTrickleInterval() != nil && in.GetMessageDelay() != nil {
+		return 0, 0, showcaseerrors.Field(
+			showcaseerrors.FieldConflict,
+			"trickle_interval",
+			"The fields `trickle_interval` and `message_delay` must not both be set.")
+	}
+	return int(in.GetBurstCount()), trickle, nil
+}
+
+// expandDelay waits before an Expand message is sent, sending a heartbeat
+// every interval that passes strictly before the delay is up. It returns early
+// if the stream is cancelled.
+func (s *echoServerImpl) expandDelay(stream pb.Echo_ExpandServer, delay, interval time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+	ctx := stream.Context()
+	for delay > 0 {
+		wait := delay
+		if interval > 0 && interval < delay {
+			wait = interval
+		}
+		select {
+		case <-s.afterF(wait):
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		delay -= wait
+		if delay > 0 {
+			if err := stream.Send(&pb.EchoResponse{IsHeartbeat: true}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// AbandonedCollectsMetric counts the Collect streams whose client went away
+// before half-closing.
+const AbandonedCollectsMetric = "abandoned_collects"
+
+// abandonCollect counts and logs a Collect stream whose client went away
+// before half-closing, and returns the error that ends it.
+func (s *echoServerImpl) abandonCollect(ctx context.Context) error {
+	s.metrics.Add(AbandonedCollectsMetric, 1)
+	log.Printf("Showcase abandoned a Collect stream: %v.", ctx.Err())
+	return ctx.Err()
+}
+
+func (s *echoServerImpl) Collect(stream pb.Echo_CollectServer) error {
+	ctx := stream.Context()
+	reqs := make(chan *pb.EchoRequest)
+	errs := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case reqs <- req:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var resp []string
+	var failures []*pb.CollectFailure
+	collected := func() *pb.EchoResponse {
+		return &pb.EchoResponse{Content: strings.Join(resp, " "), CollectFailures: failures}
+	}
+	// The error the first message asked to end the stream with in place of
+	// the response.
+	var errorAfterClose *spb.Status
+	var sequences *collectSequences
+	length := int64(0)
+	index := int64(-1)
+	continueOnError := false
+	for {
+		select {
+		case <-ctx.Done():
+			return s.abandonCollect(ctx)
+		case err := <-errs:
+			if err == io.EOF {
+				if errorAfterClose != nil {
+					return errorWithResponse(errorAfterClose, collected())
+				}
+				return stream.SendAndClose(collected())
+			}
+			if ctx.Err() != nil {
+				return s.abandonCollect(ctx)
+			}
+			return err
+		case req := <-reqs:
+			index++
+			if index == 0 {
+				continueOnError = req.GetContinueOnError()
+				errorAfterClose = req.GetErrorAfterClose()
+				if errorAfterClose != nil && codes.Code(errorAfterClose.GetCode()) == codes.OK {
+					return showcaseerrors.Field(
+						showcaseerrors.FieldInvalid,
+						"error_after_close",
+						"The field `error_after_close` must have a code other than OK.")
+				}
+				if req.GetSequence() != nil {
+					sequences = &collectSequences{
+						contiguous: req.GetRequireContiguous(),
+						last:       req.GetSequence().GetValue(),
+					}
+				}
+			} else if sequences != nil {
+				if err := sequences.check(index, req.GetSequence()); err != nil {
+					return err
+				}
+			}
+			if err := status.ErrorProto(req.GetError()); err != nil {
+				if !continueOnError {
+					return err
+				}
+				failures = append(failures, &pb.CollectFailure{Index: index, Error: req.GetError()})
+			} else if req.GetContent() != "" {
+				length += int64(len(req.GetContent()))
+				if max := s.settings.Get().MaxCollectContentBytes; length > max {
+					return status.Errorf(
+						codes.ResourceExhausted,
+						"The collected content exceeds %d bytes.",
+						max)
+				}
+				resp = append(resp, req.GetContent())
+			}
+			if req.GetFlush() {
+				if errorAfterClose != nil {
+					return errorWithResponse(errorAfterClose, collected())
+				}
+				if err := stream.SendAndClose(collected()); err != nil {
+					return err
+				}
+				return discardCollect(stream, reqs, errs)
+			}
+		}
+	}
+}
+
+// collectSequences checks the sequences of the messages of a Collect stream
+// whose first message carried one.
+type collectSequences struct {
+	contiguous bool
+	last       int64
+}
+
+// check fails if the sequence of the message at index does not follow the
+// sequence of the message before it.
+func (c *collectSequences) check(index int64, seq *wrappers.Int64Value) error {
+	actual := "none"
+	if seq != nil {
+		actual = strconv.FormatInt(seq.GetValue(), 10)
+	}
+	expected := c.last + 1
+	if seq != nil && (seq.GetValue() == expected || (!c.contiguous && seq.GetValue() > expected)) {
+		c.last = seq.GetValue()
+		return nil
+	}
+	wanted := fmt.Sprintf("at least %d", expected)
+	if c.contiguous {
+		wanted = strconv.FormatInt(expected, 10)
+	}
+	return status.ErrorProto(&spb.Status{
+		Code:    int32(codes.FailedPrecondition),
+		Message: fmt.Sprintf("The message at index %d has sequence %s, expected %s.", index, actual, wanted),
+		Details: []*any.Any{showcaseerrors.ErrorInfo(
+			showcaseerrors.SequenceOutOfOrder,
+			showcaseerrors.Domain,
+			map[string]string{
+				"index":    strconv.FormatInt(index, 10),
+				"expected": strconv.FormatInt(expected, 10),
+				"actual":   actual,
+			})},
+	})
+}
+
+// collectDiscardedTrailer is the trailer in which Collect reports the number
+// of messages it discarded after a flush.
+const collectDiscardedTrailer = "showcase-collect-discarded"
+
+// discardCollect drains the rest of a flushed Collect stream and reports the
+// number of messages it discarded.
+func discardCollect(stream pb.Echo_CollectServer, reqs <-chan *pb.EchoRequest, errs <-chan error) error {
+	discarded := 0
+	defer func() {
+		stream.SetTrailer(metadata.Pairs(collectDiscardedTrailer, strconv.Itoa(discarded)))
+	}()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case err := <-errs:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case <-reqs:
+			discarded++
+		}
+	}
+}
+
+func (s *echoServerImpl) Chat(stream pb.Echo_ChatServer) error {
+	stream, err := s.chatHandshake(stream)
+	if err != nil {
+		return err
+	}
+	req, err := stream.Recv()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	acks, err := newChatAcks(req)
+	if err != nil {
+		return err
+	}
+	limits, err := newChatLimits(req)
+	if err != nil {
+		return err
+	}
+	trailers, err := streamTrailers(req.GetTrailers())
+	if err != nil {
+		return err
+	}
+	if acks != nil {
+		defer func() { stream.SetTrailer(acks.trailer()) }()
+	}
+	if trailers != nil {
+		defer stream.SetTrailer(trailers)
+	}
+	if limits != nil {
+		if limits.maxSent > 0 {
+			defer func() { stream.SetTrailer(limits.trailer()) }()
+		}
+		stream = &chatLimitedStream{Echo_ChatServer: stream, limits: limits}
+	}
+	var summary *streamSummary
+	if req.GetErrorSummary() {
+		summary = &streamSummary{}
+	}
+	if req.GetIdleTimeout() != nil {
+		return s.chatWithIdleTimeout(stream, req, acks, limits, summary)
+	}
+
+	for {
+		if err := s.chatReply(stream, req, acks, summary); err != nil {
+			return err
+		}
+		if limits != nil {
+			if err := limits.receive(stream); err != nil {
+				return err
+			}
+		}
+		req, err = stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// chatHandshakeHeader is the metadata key that, when "true", makes a Chat
+// stream start with a handshake from the server.
+const chatHandshakeHeader = "showcase-chat-handshake"
+
+// chatHandshake sends the handshake of a Chat stream whose metadata asks for
+// one, and returns the stream that tags every later response with the same
+// session ID. Other streams are returned unchanged.
+func (s *echoServerImpl) chatHandshake(stream pb.Echo_ChatServer) (pb.Echo_ChatServer, error) {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	values := md.Get(chatHandshakeHeader)
+	if len(values) == 0 {
+		return stream, nil
+	}
+	if len(values) > 1 || (values[0] != "true" && values[0] != "false") {
+		return nil, showcaseerrors.Metadata(
+			chatHandshakeHeader,
+			"The %s metadata must be given once, as \"true\" or \"false\".",
+			chatHandshakeHeader)
+	}
+	if values[0] == "false" {
+		return stream, nil
+	}
+	id := fmt.Sprintf("%s-%d", s.sessionPrefix, atomic.AddInt64(&s.sessions, 1))
+	log.Printf("Showcase started Chat session %s.", id)
+	if err := stream.Send(&pb.EchoResponse{SessionId: id}); err != nil {
+		return nil, err
+	}
+	return &chatSessionStream{Echo_ChatServer: stream, id: id}, nil
+}
+
+// chatSessionStream is a Chat stream that sets the session ID on each
+// response it sends.
+type chatSessionStream struct {
+	pb.Echo_ChatServer
+	id string
+}
+
+func (c *chatSessionStream) Send(resp *pb.EchoResponse) error {
+	resp.SessionId = c.id
+	return c.Echo_ChatServer.Send(resp)
+}
+
+// chatReply answers a message of a Chat stream. The responses are tallied in
+// summary, unless it is nil, and an error the message carries is returned
+// with the summary in its details.
+func (s *echoServerImpl) chatReply(stream pb.Echo_ChatServer, req *pb.EchoRequest, acks *chatAcks, summary *streamSummary) error {
+	if req.GetAck() != nil {
+		if acks == nil {
+			return showcaseerrors.Field(showcaseerrors.FieldConflict, "ack", "The field `ack` requires `ack_mode` on the first message of the stream.")
+		}
+		if err := acks.handle(stream, req.GetAck()); err != nil {
+			return err
+		}
+		if req.GetResponse() == nil {
+			return nil
+		}
+	}
+	if err := status.ErrorProto(req.GetError()); err != nil {
+		if summary != nil {
+			return summary.error(req.GetError())
+		}
+		return err
+	}
+	resp := &pb.EchoResponse{
+		Content:        req.GetContent(),
+		ClientSequence: req.GetClientSequence(),
+		ServerSequence: s.sequence.Next(),
+	}
+	if summary != nil {
+		summary.add(resp.GetContent())
+	}
+	if acks != nil {
+		return acks.send(stream, resp)
+	}
+	stream.Send(resp)
+	return nil
+}
+
+// defaultMaxResends is the most times a Chat stream in ack mode sends a
+// response again when the client does not set `max_resends`.
+const defaultMaxResends = 3
+
+// The trailers in which a Chat stream in ack mode reports the responses that
+// were never acknowledged and the number of responses it sent again.
+const (
+	chatUnackedTrailer = "showcase-chat-unacked"
+	chatResentTrailer  = "showcase-chat-resent"
+)
+
+// chatAcks tracks the responses of a Chat stream in ack mode that the client
+// has not acknowledged yet. It belongs to a single stream.
+type chatAcks struct {
+	maxResends int32
+	next       int64
+	pending    map[int64]*pb.EchoResponse
+	resent     int
+}
+
+// newChatAcks returns the ack state of a Chat stream whose first message is
+// req, or nil if the stream is not in ack mode.
+func newChatAcks(req *pb.EchoRequest) (*chatAcks, error) {
+	if !req.GetAckMode() {
+		if req.GetMaxResends() != 0 {
+			return nil, showcaseerrors.Field(showcaseerrors.FieldConflict, "max_resends", "The field `max_resends` requires `ack_mode`.")
+		}
+		return nil, nil
+	}
+	maxResends := req.GetMaxResends()
+	if maxResends < 0 {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldOutOfRange, "max_resends", "The field `max_resends` must not be negative.")
+	}
+	if maxResends == 0 {
+		maxResends = defaultMaxResends
+	}
+	return &chatAcks{
+		maxResends: maxResends,
+		next:       1,
+		pending:    map[int64]*pb.EchoResponse{},
+	}, nil
+}
+
+// send sends a new response, with the next sequence of the stream.
+func (a *chatAcks) send(stream pb.Echo_ChatServer, resp *pb.EchoResponse) error {
+	resp.AckSequence = a.next
+	a.next++
+	a.pending[resp.GetAckSequence()] = resp
+	return stream.Send(resp)
+}
+
+// handle forgets the acked responses and sends the nacked ones again. A
+// response that was already sent again max_resends times fails the stream
+// with DATA_LOSS instead.
+func (a *chatAcks) handle(stream pb.Echo_ChatServer, ack *pb.ChatAck) error {
+	for _, seq := range ack.GetAckedSequences() {
+		if err := a.checkSent("acked_sequences", seq); err != nil {
+			return err
+		}
+		delete(a.pending, seq)
+	}
+	for _, seq := range ack.GetNackedSequences() {
+		if err := a.checkSent("nacked_sequences", seq); err != nil {
+			return err
+		}
+		resp, ok := a.pending[seq]
+		if !ok {
+			return showcaseerrors.Field(
+				showcaseerrors.FieldInvalid,
+				"ack.nacked_sequences",
+				"The field `ack.nacked_sequences` has %d, which was already acknowledged.",
+				seq)
+		}
+		if resp.GetResendCount() >= a.maxResends {
+			return status.ErrorProto(&spb.Status{
+				Code: int32(codes.DataLoss),
+				Message: fmt.Sprintf(
+					"The response %d was not received after being sent %d times.",
+					seq,
+					resp.GetResendCount()+1),
+				Details: []*any.Any{showcaseerrors.ErrorInfo(
+					showcaseerrors.ResendLimitExceeded,
+					showcaseerrors.Domain,
+					map[string]string{"ack_sequence": strconv.FormatInt(seq, 10)})},
+			})
+		}
+		resp = proto.Clone(resp).(*pb.EchoResponse)
+		resp.ResendCount++
+		a.pending[seq] = resp
+		a.resent++
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *chatAcks) checkSent(field string, seq int64) error {
+	if seq < 1 || seq >= a.next {
+		return showcaseerrors.Field(
+			showcaseerrors.FieldOutOfRange,
+			"ack."+field,
+			"The field `ack.%s` has %d, but only responses 1 to %d were sent.",
+			field,
+			seq,
+			a.next-1)
+	}
+	return nil
+}
+
+// trailer reports the responses that were never acknowledged and the number
+// of responses sent again.
+func (a *chatAcks) trailer() metadata.MD {
+	return metadata.Pairs(
+		chatUnackedTrailer, strconv.Itoa(len(a.pending)),
+		chatResentTrailer, strconv.Itoa(a.resent))
+}
+
+// maxChatMessageLimit is the most that `max_received_messages` and
+// `max_sent_messages` may be.
+const maxChatMessageLimit = 10000
+
+// chatDroppedTrailer is the trailer in which a Chat stream with
+// `max_sent_messages` reports the number of responses it dropped.
+const chatDroppedTrailer = "showcase-chat-dropped"
+
+// chatLimits counts the messages of a Chat stream with message limits. It
+// belongs to a single stream.
+type chatLimits struct {
+	maxReceived int32
+	maxSent     int32
+	received    int32
+	sent        int32
+	dropped     int
+}
+
+// newChatLimits returns the limits of a Chat stream whose first message is
+// req, or nil if it sets none.
+func newChatLimits(req *pb.EchoRequest) (*chatLimits, error) {
+	limits := []struct {
+		field string
+		limit int32
+	}{
+		{"max_received_messages", req.GetMaxReceivedMessages()},
+		{"max_sent_messages", req.GetMaxSentMessages()},
+	}
+	for _, l := range limits {
+		if field := l.field; l.limit < 0 || l.limit > maxChatMessageLimit {
+			return nil, showcaseerrors.Field(
+				showcaseerrors.FieldOutOfRange,
+				field,
+				"The field `%s` must be between 0 and %d.",
+				field,
+				maxChatMessageLimit)
+		}
+	}
+	if req.GetMaxReceivedMessages() == 0 && req.GetMaxSentMessages() == 0 {
+		return nil, nil
+	}
+	return &chatLimits{maxReceived: req.GetMaxReceivedMessages(), maxSent: req.GetMaxSentMessages()}, nil
+}
+
+// receive counts a message that was answered. Once the receive limit is
+// reached, it notifies the client and returns the error that ends the
+// stream, without another message being read.
+func (l *chatLimits) receive(stream pb.Echo_ChatServer) error {
+	l.received++
+	if l.maxReceived == 0 || l.received < l.maxReceived {
+		return nil
+	}
+	if err := stream.Send(&pb.EchoResponse{
+		Content:      fmt.Sprintf("Closing the stream after receiving %d messages.", l.maxReceived),
+		LimitReached: true,
+	}); err != nil {
+		return err
+	}
+	return status.ErrorProto(&spb.Status{
+		Code:    int32(codes.OutOfRange),
+		Message: fmt.Sprintf("The stream reached its limit of %d received messages.", l.maxReceived),
+		Details: []*any.Any{showcaseerrors.ErrorInfo(
+			showcaseerrors.ReceiveLimitReached,
+			showcaseerrors.Domain,
+			map[string]string{"max_received_messages": strconv.Itoa(int(l.maxReceived))})},
+	})
+}
+
+// trailer reports the number of responses dropped past the send limit.
+func (l *chatLimits) trailer() metadata.MD {
+	return metadata.Pairs(chatDroppedTrailer, strconv.Itoa(l.dropped))
+}
+
+// chatLimitedStream is a Chat stream that drops the responses past its send
+// limit, except for the notification that the receive limit was reached.
+type chatLimitedStream struct {
+	pb.Echo_ChatServer
+	limits *chatLimits
+}
+
+func (c *chatLimitedStream) Send(resp *pb.EchoResponse) error {
+	if resp.GetLimitReached() {
+		return c.Echo_ChatServer.Send(resp)
+	}
+	if c.limits.maxSent > 0 && c.limits.sent >= c.limits.maxSent {
+		c.limits.dropped++
+		return nil
+	}
+	c.limits.sent++
+	return c.Echo_ChatServer.Send(resp)
+}
+
+type chatRecv struct {
+	req *pb.EchoRequest
+	err error
+}
+
+// chatWithIdleTimeout runs a Chat stream that is closed when the client does
+// not send a message within the idle timeout of the first message.
+func (s *echoServerImpl) chatWithIdleTimeout(stream pb.Echo_ChatServer, req *pb.EchoRequest, acks *chatAcks, limits *chatLimits, summary *streamSummary) error {
+	timeout, err := ptypes.Duration(req.GetIdleTimeout())
+	if err != nil || timeout <= 0 {
+		return showcaseerrors.Field(showcaseerrors.FieldOutOfRange, "idle_timeout", "The field `idle_timeout` must be a positive duration.")
+	}
+
+	recvs := make(chan chatRecv, 1)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			select {
+			case recvs <- chatRecv{req, err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		if err := s.chatReply(stream, req, acks, summary); err != nil {
+			return err
+		}
+		if limits != nil {
+			if err := limits.receive(stream); err != nil {
+				return err
+			}
+		}
+
+		var r chatRecv
+		select {
+		case r = <-recvs:
+		case <-s.afterF(timeout):
+			// A message that arrived as the timer fired is still honored.
+			select {
+			case r = <-recvs:
+			default:
+				stream.Send(&pb.EchoResponse{
+					Content: fmt.Sprintf("Closing the stream after %s without a message.", timeout),
+				})
+				return status.ErrorProto(&spb.Status{
+					Code:    int32(codes.Aborted),
+					Message: fmt.Sprintf("The stream was idle for %s.", timeout),
+					Details: []*any.Any{showcaseerrors.ErrorInfo(showcaseerrors.IdleTimeout, showcaseerrors.Domain, nil)},
+				})
+			}
+		}
+		if r.err == io.EOF {
+			return nil
+		}
+		if r.err != nil {
+			return r.err
+		}
+		req = r.req
+	}
+}
+
+func (s *echoServerImpl) PagedExpand(ctx context.Context, in *pb.PagedExpandRequest) (*pb.PagedExpandResponse, error) {
+	if in.GetPageSize() < 0 {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldOutOfRange, "page_size", "The page size provided must not be negative.")
+	}
+	keys, err := server.ParseOrderBy(in.GetOrderBy(), pagedExpandOrderFields)
+	if err != nil {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldInvalid, "order_by", "The field `order_by` is invalid at %s.", err)
+	}
+	orderBy := server.FormatOrderBy(keys)
+	f, err := filtering.Parse(in.GetFilter(), pagedExpandFilterFields)
+	if err != nil {
+		return nil, showcaseerrors.BadRequest(
+			showcaseerrors.FieldInvalid,
+			"filter",
+			fmt.Sprintf("The field `filter` is invalid at %s.", err))
+	}
+	filter := f.String()
+
+	// The index of each word is its position in the content, whichever
+	// words the filter drops.
+	words, indices := []string{}, []int{}
+	for i, word := range strings.Fields(in.GetContent()) {
+		match := f.Matches(func(field string) interface{} {
+			switch field {
+			case "index":
+				return int64(i)
+			case "parity":
+				return int64(i % 2)
+			case "length":
+				return int64(utf8.RuneCountInString(word))
+			default:
+				return word
+			}
+		})
+		if match {
+			words, indices = append(words, word), append(indices, i)
+		}
+	}
+
+	start := int32(0)
+	if in.GetPageToken() != "" {
+		// The tokens of ordered or filtered pages carry their order_by,
+		// then their filter, after the position.
+		parts := strings.SplitN(in.GetPageToken(), ":", 3)
+		position, tokenOrderBy, tokenFilter := parts[0], "", ""
+		if len(parts) > 1 {
+			tokenOrderBy = parts[1]
+		}
+		if len(parts) > 2 {
+			tokenFilter = parts[2]
+		}
+		if tokenOrderBy != orderBy {
+			return nil, showcaseerrors.Field(
+				showcaseerrors.PageTokenInvalid,
+				"page_token",
+				"The page token was issued for the order_by `%s`, but the request has `%s`.",
+				tokenOrderBy,
+				orderBy)
+		}
+		if tokenFilter != filter {
+			return nil, showcaseerrors.Field(
+				showcaseerrors.PageTokenInvalid,
+				"page_token",
+				"The page token was issued for the filter `%s`, but the request has `%s`.",
+				tokenFilter,
+				filter)
+		}
+		// The position counts only the words that match.
+		token, err := strconv.Atoi(position)
+		token32 := int32(token)
+		if err != nil || token32 < 0 || token32 >= int32(len(words)) {
+			return nil, showcaseerrors.Field(
+				showcaseerrors.PageTokenInvalid,
+				"page_token",
+				"Invalid page token: %s. Token must be within the range [0, %d)",
+				in.GetPageToken(),
+				len(words))
+		}
+		start = token32
+	}
+	order := server.OrderedIndices(len(words), keys, func(field string, i, j int) int {
+		switch field {
+		case "parity":
+			return indices[i]%2 - indices[j]%2
+		case "length":
+			return utf8.RuneCountInString(words[i]) - utf8.RuneCountInString(words[j])
+		default:
+			return indices[i] - indices[j]
+		}
+	})
+
+	pageSize := in.GetPageSize()
+	if pageSize == 0 {
+		pageSize = int32(len(words))
+	}
+	end := min(start+pageSize, int32(len(words)))
+
+	responses := []*pb.EchoResponse{}
+	for _, i := range order[start:end] {
+		resp := &pb.EchoResponse{Content: words[i]}
+		if keys != nil || f != nil {
+			resp.Index = int64(indices[i] + 1)
+		}
+		responses = append(responses, resp)
+	}
+	server.ScramblePage(s.settings, int(start), len(responses), func(i, j int) {
+		responses[i], responses[j] = responses[j], responses[i]
+	})
+
+	nextToken := ""
+	if end < int32(len(words)) {
+		nextToken = strconv.Itoa(int(end))
+		if keys != nil || f != nil {
+			nextToken += ":" + orderBy
+		}
+		if f != nil {
+			nextToken += ":" + filter
+		}
+	}
+
+	return &pb.PagedExpandResponse{
+		Responses:     responses,
+		NextPageToken: nextToken,
+	}, nil
 }
 
-type echoServerImpl struct {
-	waiter server.Waiter
+// pagedExpandOrderFields are the fields a PagedExpand order_by may sort by.
+var pagedExpandOrderFields = []string{"index", "parity", "length"}
+
+// pagedExpandFilterFields are the fields a PagedExpand filter may compare.
+var pagedExpandFilterFields = map[string]filtering.Type{
+	"index":   filtering.Int,
+	"parity":  filtering.Int,
+	"length":  filtering.Int,
+	"content": filtering.String,
 }
 
-func (s *echoServerImpl) Echo(ctx context.Context, in *pb.EchoRequest) (*pb.EchoResponse, error) {
-	err := status.ErrorProto(in.GetError())
-	if err != nil {
-		return nil, err
+func min(x int32, y int32) int32 {
+	if x < y {
+		return x
 	}
-	return &pb.EchoResponse{Content: in.GetContent()}, nil
+	return y
 }
 
-func (s *echoServerImpl) Expand(in *pb.ExpandRequest, stream pb.Echo_ExpandServer) error {
-	for _, word := range strings.Fields(in.GetContent()) {
-		err := stream.Send(&pb.EchoResponse{Content: word})
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+func (s *echoServerImpl) ReadBlob(in *pb.ReadBlobRequest, stream pb.Echo_ReadBlobServer) error {
+	settings := s.settings.Get()
+	if in.GetTotalSize() < 0 {
+		return showcaseerrors.Field(showcaseerrors.FieldOutOfRange, "total_size", "The field `total_size` must not be negative.")
+	}
+	if in.GetReadOffset() < 0 {
+		return showcaseerrors.Field(showcaseerrors.FieldOutOfRange, "read_offset", "The field `read_offset` must not be negative.")
+	}
+	if in.GetReadLimit() < 0 {
+		return showcaseerrors.Field(showcaseerrors.FieldOutOfRange, "read_limit", "The field `read_limit` must not be negative.")
+	}
+	if in.GetChunkSize() < 0 || in.GetChunkSize() > settings.MaxBlobChunkSize {
+		return showcaseerrors.Field(
+			showcaseerrors.FieldOutOfRange,
+			"chunk_size",
+			"The field `chunk_size` must be within the range [0, %d].",
+			settings.MaxBlobChunkSize)
+	}
+	if in.GetReadOffset() > in.GetTotalSize() {
+		return status.Errorf(
+			codes.OutOfRange,
+			"The field `read_offset` is past the end of the %d byte blob.",
+			in.GetTotalSize())
+	}
+
+	chunkSize := int64(in.GetChunkSize())
+	if chunkSize == 0 {
+		chunkSize = int64(settings.DefaultBlobChunkSize)
+	}
+	// The sizes are compared with what is left of the blob rather than
+	// added to the offset, which could overflow.
+	end := in.GetTotalSize()
+	if limit := in.GetReadLimit(); limit > 0 && limit < end-in.GetReadOffset() {
+		end = in.GetReadOffset() + limit
+	}
+	failAt := int64(-1)
+	if fail := in.GetFailAfterBytes(); fail > 0 && fail <= end-in.GetReadOffset() {
+		failAt = in.GetReadOffset() + fail
+	}
+
+	offset := in.GetReadOffset()
+	for {
+		if offset == failAt {
+			return status.Errorf(
+				codes.Unavailable,
+				"The stream failed after %d bytes, at offset %d.",
+				in.GetFailAfterBytes(),
+				offset)
+		}
+		next := offset + chunkSize
+		if next > end {
+			next = end
+		}
+		if failAt > offset && next > failAt {
+			next = failAt
+		}
+
+		data := blobBytes(in.GetSeed(), offset, next)
+		err := stream.Send(&pb.ReadBlobResponse{
+			Data:   data,
+			Offset: offset,
+			Crc32C: crc32.Checksum(data, crc32cTable),
+			Final:  next == end,
+		})
 		if err != nil {
 			return err
 		}
+		if next == end {
+			return nil
+		}
+		offset = next
 	}
-	if in.GetError() != nil {
-		return status.ErrorProto(in.GetError())
+}
+
+// blobBytes returns the bytes in [start, end) of the blob determined by the
+// seed. Every 8 bytes of the blob are a SplitMix64 hash of the seed and
+// their position, so any range can be produced independently.
+func blobBytes(seed, start, end int64) []byte {
+	data := make([]byte, 0, end-start)
+	for i := start; i < end; i++ {
+		data = append(data, byte(splitMix64(uint64(seed)+uint64(i/8))>>(8*uint(i%8))))
 	}
-	return nil
+	return data
 }
 
-func (s *echoServerImpl) Collect(stream pb.Echo_CollectServer) error {
-	var resp []string
+func splitMix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+func (s *echoServerImpl) WriteBlob(stream pb.Echo_WriteBlobServer) error {
+	req, err := stream.Recv()
+	if err == io.EOF {
+		return showcaseerrors.Field(showcaseerrors.StreamOutOfOrder, "spec", "The first message on the stream must be a `spec`.")
+	}
+	if err != nil {
+		return err
+	}
+	spec := req.GetSpec()
+	if spec == nil {
+		return showcaseerrors.Field(showcaseerrors.StreamOutOfOrder, "spec", "The first message on the stream must be a `spec`.")
+	}
+	blob, err := s.blobs.open(server.NamespaceFromContext(stream.Context()), spec.GetBlobId(), spec.GetTotalSize())
+	if err != nil {
+		return err
+	}
 
 	for {
 		req, err := stream.Recv()
 		if err == io.EOF {
-			return stream.SendAndClose(&pb.EchoResponse{Content: strings.Join(resp, " ")})
+			return stream.SendAndClose(s.blobs.response(blob))
 		}
 		if err != nil {
 			return err
 		}
-		s := status.ErrorProto(req.GetError())
-		if s != nil {
-			return s
+		chunk := req.GetChunk()
+		if chunk == nil {
+			return showcaseerrors.Field(showcaseerrors.StreamOutOfOrder, "spec", "Only the first message on the stream may be a `spec`.")
 		}
-		if req.GetContent() != "" {
-			resp = append(resp, req.GetContent())
+		if err := s.blobs.write(blob, chunk.GetOffset(), chunk.GetData()); err != nil {
+			return err
 		}
 	}
 }
 
-func (s *echoServerImpl) Chat(stream pb.Echo_ChatServer) error {
+func (s *echoServerImpl) GetWriteStatus(ctx context.Context, in *pb.GetWriteStatusRequest) (*pb.WriteStatus, error) {
+	return s.blobs.status(server.NamespaceFromContext(ctx), in.GetBlobId())
+}
+
+func (s *echoServerImpl) CreateEchoResource(ctx context.Context, in *pb.CreateEchoResourceRequest) (*pb.EchoResource, error) {
+	if in.GetName() == "" {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldRequired, "name", "The field `name` is required.")
+	}
+	if err := s.resources.Create(server.NamespaceFromContext(ctx), in.GetName()); err != nil {
+		return nil, err
+	}
+	return &pb.EchoResource{Name: in.GetName()}, nil
+}
+
+func (s *echoServerImpl) GetEchoResource(ctx context.Context, in *pb.GetEchoResourceRequest) (*pb.EchoResource, error) {
+	if in.GetName() == "" {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldRequired, "name", "The field `name` is required.")
+	}
+	if !s.resources.Exists(server.NamespaceFromContext(ctx), in.GetName()) {
+		code := codes.NotFound
+		if in.GetUnavailableUntilCreated() {
+			code = codes.Unavailable
+		}
+		return nil, status.Errorf(code, "The echo resource %q does not exist.", in.GetName())
+	}
+	return &pb.EchoResource{Name: in.GetName()}, nil
+}
+
+func (s *echoServerImpl) DeleteEchoResource(ctx context.Context, in *pb.DeleteEchoResourceRequest) (*empty.Empty, error) {
+	if in.GetName() == "" {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldRequired, "name", "The field `name` is required.")
+	}
+	if !s.resources.Delete(server.NamespaceFromContext(ctx), in.GetName()) {
+		return nil, status.Errorf(codes.NotFound, "The echo resource %q does not exist.", in.GetName())
+	}
+	return &empty.Empty{}, nil
+}
+
+// blobStoreSingleton is shared by the Echo server, which writes blobs, and the
+// Testing server, which purges them.
+var blobStoreSingleton = newBlobStore(server.GetSettingsInstance(), server.Now)
+
+// blobStore holds the blobs written by WriteBlob in memory. The full size of
+// a blob is reserved against the store's cap when its upload starts, and is
+// released when the blob expires, BlobTTL after it was last written. Each
+// namespace has its own blobs, but the cap applies to the whole store. It is
+// safe for concurrent use.
+type blobStore struct {
+	mu       sync.Mutex
+	settings server.SettingsStore
+	nowF     func() time.Time
+	reserved int64
+	blobs    map[blobKey]*blob
+}
+
+type blobKey struct {
+	namespace string
+	id        string
+}
+
+type blob struct {
+	id        string
+	totalSize int64
+	data      []byte
+	// written is when the blob was last opened or written to.
+	written time.Time
+}
+
+func newBlobStore(settings server.SettingsStore, nowF func() time.Time) *blobStore {
+	return &blobStore{
+		settings: settings,
+		nowF:     nowF,
+		blobs:    map[blobKey]*blob{},
+	}
+}
+
+// blobExpired reports whether the blob was last written longer than the TTL ago.
+func blobExpired(b *blob, ttl time.Duration, now time.Time) bool {
+	return ttl > 0 && now.Sub(b.written) >= ttl
+}
+
+// expireLocked removes the expired blobs, releasing their reserved storage.
+// s.mu must be held.
+func (s *blobStore) expireLocked(ttl time.Duration, now time.Time) {
+	for key, b := range s.blobs {
+		if blobExpired(b, ttl, now) {
+			s.reserved -= b.totalSize
+			delete(s.blobs, key)
+		}
+	}
+}
+
+// open returns the blob with the given ID, creating it if it does not exist.
+func (s *blobStore) open(namespace, id string, totalSize int64) (*blob, error) {
+	if id == "" {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldRequired, "spec.blob_id", "The field `spec.blob_id` is required.")
+	}
+	settings := s.settings.Get()
+	if totalSize < 0 || totalSize > settings.MaxBlobSize {
+		return nil, showcaseerrors.Field(
+			showcaseerrors.FieldOutOfRange,
+			"spec.total_size",
+			"The field `spec.total_size` must be within the range [0, %d].",
+			settings.MaxBlobSize)
+	}
+
+	key := blobKey{namespace, id}
+	defer server.ChangeState()()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.nowF()
+	s.expireLocked(settings.BlobTTL, now)
+	if b, ok := s.blobs[key]; ok {
+		if b.totalSize != totalSize {
+			return nil, status.Errorf(
+				codes.FailedPrecondition,
+				"The blob %q was started with a total size of %d, not %d.",
+				id,
+				b.totalSize,
+				totalSize)
+		}
+		b.written = now
+		return b, nil
+	}
+	if s.reserved+totalSize > settings.MaxBlobStorageSize {
+		return nil, status.Errorf(
+			codes.ResourceExhausted,
+			"Storing %d more bytes would exceed the %d byte blob storage limit.",
+			totalSize,
+			settings.MaxBlobStorageSize)
+	}
+	b := &blob{id: id, totalSize: totalSize, written: now}
+	s.blobs[key] = b
+	s.reserved += totalSize
+	return b, nil
+}
+
+// write commits a chunk of the blob. The chunk must start where the
+// committed bytes end and must not extend past the blob's total size.
+func (s *blobStore) write(b *blob, offset int64, data []byte) error {
+	defer server.ChangeState()()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	committed := int64(len(b.data))
+	if offset < committed {
+		return showcaseerrors.Field(
+			showcaseerrors.StreamOutOfOrder,
+			"chunk.offset",
+			"The chunk at offset %d overlaps the %d bytes already committed.",
+			offset,
+			committed)
+	}
+	if offset > committed {
+		return showcaseerrors.Field(
+			showcaseerrors.StreamOutOfOrder,
+			"chunk.offset",
+			"The chunk at offset %d is out of order, the next chunk must be at offset %d.",
+			offset,
+			committed)
+	}
+	if offset+int64(len(data)) > b.totalSize {
+		return showcaseerrors.Field(
+			showcaseerrors.FieldOutOfRange,
+			"chunk.data",
+			"The chunk at offset %d extends past the %d byte blob.",
+			offset,
+			b.totalSize)
+	}
+	b.data = append(b.data, data...)
+	b.written = s.nowF()
+	return nil
+}
+
+func (s *blobStore) response(b *blob) *pb.WriteBlobResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &pb.WriteBlobResponse{
+		BlobId:       b.id,
+		ReceivedSize: int64(len(b.data)),
+		Crc32C:       crc32.Checksum(b.data, crc32cTable),
+		Complete:     int64(len(b.data)) == b.totalSize,
+	}
+}
+
+func (s *blobStore) status(namespace, id string) (*pb.WriteStatus, error) {
+	if id == "" {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldRequired, "blob_id", "The field `blob_id` is required.")
+	}
+	ttl := s.settings.Get().BlobTTL
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.blobs[blobKey{namespace, id}]
+	if !ok || blobExpired(b, ttl, s.nowF()) {
+		return nil, status.Errorf(codes.NotFound, "The blob %q was not found.", id)
+	}
+	return &pb.WriteStatus{
+		BlobId:        b.id,
+		CommittedSize: int64(len(b.data)),
+		TotalSize:     b.totalSize,
+		Complete:      int64(len(b.data)) == b.totalSize,
+	}, nil
+}
+
+// purgeNamespace removes the blobs of the namespace, releasing their
+// reserved storage, and returns how many it removed.
+func (s *blobStore) purgeNamespace(namespace string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for key, b := range s.blobs {
+		if key.namespace == namespace {
+			s.reserved -= b.totalSize
+			delete(s.blobs, key)
+			n++
+		}
+	}
+	return n
+}
+
+// list returns the state of the unexpired blobs of each namespace, ordered
+// by ID.
+func (s *blobStore) list() map[string][]*pb.BlobState {
+	ttl := s.settings.Get().BlobTTL
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.nowF()
+	blobs := map[string][]*pb.BlobState{}
+	for key, b := range s.blobs {
+		if blobExpired(b, ttl, now) {
+			continue
+		}
+		blobs[key.namespace] = append(blobs[key.namespace], &pb.BlobState{
+			BlobId:        b.id,
+			CommittedSize: int64(len(b.data)),
+			TotalSize:     b.totalSize,
+		})
+	}
+	for _, states := range blobs {
+		sort.Slice(states, func(i, j int) bool { return states[i].GetBlobId() < states[j].GetBlobId() })
+	}
+	return blobs
+}
+
+func (s *echoServerImpl) Wait(ctx context.Context, in *pb.WaitRequest) (*lropb.Operation, error) {
+	if quota := in.GetPollQuota(); quota != nil {
+		if quota.GetMaxPolls() <= 0 {
+			return nil, showcaseerrors.Field(showcaseerrors.FieldOutOfRange, "poll_quota.max_polls", "The field `poll_quota.max_polls` must be positive.")
+		}
+		if _, err := optionalDuration("poll_quota.replenish_interval", quota.GetReplenishInterval()); err != nil {
+			return nil, err
+		}
+	}
+	if err := validatePartialResults(in); err != nil {
+		return nil, err
+	}
+	id := in.GetOperationId()
+	if id == "" {
+		return s.waiter.Wait(in), nil
+	}
+	// The hash is of the request as sent, before the waiter resolves its ttl.
+	hash, err := server.RequestHash(in)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "The request could not be hashed: %s.", err)
+	}
+	op := s.waiter.Wait(in)
+	name, same := s.operationIDs.Register(server.NamespaceFromContext(ctx), id, hash, op.GetName())
+	if !same {
+		return nil, status.ErrorProto(&spb.Status{
+			Code: int32(codes.AlreadyExists),
+			Message: fmt.Sprintf(
+				"The operation ID %q was used by a different request, which started %s.",
+				id,
+				name),
+			Details: []*any.Any{showcaseerrors.ErrorInfo(showcaseerrors.OperationIDReused, showcaseerrors.Domain, map[string]string{
+				"operation_id": id,
+				"operation":    name,
+			})},
+		})
+	}
+	if name == op.GetName() {
+		return op, nil
+	}
+	// A retry returns the operation of the first call as it is now.
+	first, ok := waitOperationRequest(name)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "The operation %q could not be decoded.", name)
+	}
+	return s.waiter.Wait(first), nil
+}
+
+// validatePartialResults fails unless the partial results of a Wait request
+// each have a response and become available in order, all given as offsets
+// or all as times, and none after the operation completes.
+func validatePartialResults(in *pb.WaitRequest) error {
+	partials := in.GetPartialResults()
+	if len(partials) == 0 {
+		return nil
+	}
+	offsets := partials[0].GetOffset() != nil
+	// The positions of the results, as offsets or as Unix times.
+	var last time.Duration
+	for i, p := range partials {
+		field := fmt.Sprintf("partial_results[%d]", i)
+		if p.GetResponse() == nil {
+			return showcaseerrors.Field(showcaseerrors.FieldRequired, field+".response", "The field `%s.response` is required.", field)
+		}
+		var at time.Duration
+		switch {
+		case p.GetOffset() != nil && offsets:
+			d, err := optionalDuration(field+".offset", p.GetOffset())
+			if err != nil {
+				return err
+			}
+			at = d
+		case p.GetAvailableTime() != nil && !offsets:
+			t, err := ptypes.Timestamp(p.GetAvailableTime())
+			if err != nil {
+				return showcaseerrors.Field(showcaseerrors.FieldInvalid, field+".available_time", "The field `%s.available_time` is not a valid time.", field)
+			}
+			at = time.Duration(t.UnixNano())
+		default:
+			return showcaseerrors.Field(
+				showcaseerrors.FieldInvalid,
+				field,
+				"The field `%s` must give when it is available as the first result does, by an `offset` or an `available_time`.",
+				field)
+		}
+		if i > 0 && at <= last {
+			return showcaseerrors.Field(
+				showcaseerrors.FieldInvalid,
+				field,
+				"The field `%s` must become available after `partial_results[%d]`.",
+				field,
+				i-1)
+		}
+		last = at
+	}
+	end := time.Duration(-1)
+	if ttl := in.GetTtl(); ttl != nil && offsets {
+		end, _ = ptypes.Duration(ttl)
+	}
+	if endTime := in.GetEndTime(); endTime != nil && !offsets {
+		t, _ := ptypes.Timestamp(endTime)
+		end = time.Duration(t.UnixNano())
+	}
+	if end >= 0 && last > end {
+		return showcaseerrors.Field(
+			showcaseerrors.FieldOutOfRange,
+			fmt.Sprintf("partial_results[%d]", len(partials)-1),
+			"The field `partial_results[%d]` must become available before the operation completes.",
+			len(partials)-1)
+	}
+	return nil
+}
+
+func (s *echoServerImpl) StreamOperationUpdates(in *pb.StreamOperationUpdatesRequest, stream pb.Echo_StreamOperationUpdatesServer) error {
+	if in.GetName() == "" {
+		return showcaseerrors.Field(showcaseerrors.FieldRequired, "name", "The field `name` is required.")
+	}
+	local, err := localOperationName(s.settings, in.GetName())
+	if err != nil {
+		return err
+	}
+	req, ok := waitOperationRequest(local)
+	if !ok {
+		return status.Errorf(codes.NotFound, "Operation %q not found.", in.GetName())
+	}
+	deleted, stop := s.operationWatchers.Watch(server.NamespaceFromContext(stream.Context()), in.GetName())
+	defer stop()
+
+	var last *lropb.Operation
 	for {
-		req, err := stream.Recv()
-		if err == io.EOF {
+		// The state is taken afresh after every send, so the states a slow
+		// client missed while a send blocked are skipped.
+		at := s.nowF()
+		op := s.waiter.Wait(req)
+		if !proto.Equal(op, last) {
+			if err := stream.Send(op); err != nil {
+				return err
+			}
+			last = op
+		}
+		if op.GetDone() {
 			return nil
 		}
-		if err != nil {
-			return err
+		// Passing the transition by a little ensures it has happened.
+		wait := nextWaitTransition(req, at).Sub(s.nowF()) + time.Millisecond
+		// Past its end time, an operation the workers have yet to complete
+		// has no transition left but its completion.
+		var completed <-chan struct{}
+		if s.workers != nil {
+			completed = s.workers.Done(op.GetName())
+		}
+		var timer <-chan time.Time
+		if wait > 0 {
+			timer = s.afterF(wait)
+		} else if completed == nil {
+			continue
 		}
+		select {
+		case <-timer:
+		case <-completed:
+		case <-deleted:
+			return status.ErrorProto(&spb.Status{
+				Code:    int32(codes.NotFound),
+				Message: fmt.Sprintf("Operation %q was deleted.", in.GetName()),
+				Details: []*any.Any{showcaseerrors.ErrorInfo(showcaseerrors.OperationDeleted, showcaseerrors.Domain, map[string]string{
+					"operation": in.GetName(),
+				})},
+			})
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		}
+	}
+}
 
-		s := status.ErrorProto(req.GetError())
-		if s != nil {
-			return s
+// nextWaitTransition returns the first time after the given one that the
+// state of a Wait operation changes: when one of its partial results becomes
+// available, or when it is done.
+func nextWaitTransition(req *pb.WaitRequest, after time.Time) time.Time {
+	next, _ := ptypes.Timestamp(req.GetEndTime())
+	for _, p := range req.GetPartialResults() {
+		if at, err := ptypes.Timestamp(p.GetAvailableTime()); err == nil && at.After(after) && at.Before(next) {
+			next = at
 		}
-		stream.Send(&pb.EchoResponse{Content: req.GetContent()})
 	}
+	return next
 }
 
-func (s *echoServerImpl) PagedExpand(ctx context.Context, in *pb.PagedExpandRequest) (*pb.PagedExpandResponse, error) {
-	if in.GetPageSize() < 0 {
-		return nil, status.Error(codes.InvalidArgument, "The page size provided must not be negative.")
+// The ack deadlines of StreamingPullEcho streams.
+const (
+	defaultAckDeadline = 10 * time.Second
+	minAckDeadline     = time.Second
+	maxAckDeadline     = 10 * time.Minute
+)
+
+// topicName is the form of the names of topics.
+var topicName = regexp.MustCompile(`^topics/[^/]+$`)
+
+func validateTopic(topic string) error {
+	if topic == "" {
+		return showcaseerrors.Field(showcaseerrors.FieldRequired, "topic", "The field `topic` is required.")
 	}
-	words := strings.Fields(in.GetContent())
+	if !topicName.MatchString(topic) {
+		return showcaseerrors.Field(showcaseerrors.FieldInvalid, "topic", "The field `topic` %q must be of the form `topics/*`.", topic)
+	}
+	return nil
+}
 
-	start := int32(0)
-	if in.GetPageToken() != "" {
-		token, err := strconv.Atoi(in.GetPageToken())
-		token32 := int32(token)
-		if err != nil || token32 < 0 || token32 >= int32(len(words)) {
-			return nil, status.Errorf(
-				codes.InvalidArgument,
-				"Invalid page token: %s. Token must be within the range [0, %d)",
-				in.GetPageToken(),
-				len(words))
+func (s *echoServerImpl) PublishEcho(ctx context.Context, in *pb.PublishEchoRequest) (*pb.PublishEchoResponse, error) {
+	if err := validateTopic(in.GetTopic()); err != nil {
+		return nil, err
+	}
+	if len(in.GetMessages()) == 0 {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldRequired, "messages", "The field `messages` must hold at least one message.")
+	}
+	ids, err := s.topics.Publish(server.NamespaceFromContext(ctx), in.GetTopic(), in.GetMessages())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PublishEchoResponse{MessageIds: ids}, nil
+}
+
+type pullRecv struct {
+	req *pb.StreamingPullEchoRequest
+	err error
+}
+
+func (s *echoServerImpl) StreamingPullEcho(stream pb.Echo_StreamingPullEchoServer) error {
+	req, err := stream.Recv()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	topic := req.GetTopic()
+	if err := validateTopic(topic); err != nil {
+		return err
+	}
+	deadline := defaultAckDeadline
+	if req.GetAckDeadline() != nil {
+		deadline, err = ptypes.Duration(req.GetAckDeadline())
+		if err != nil || deadline < minAckDeadline || deadline > maxAckDeadline {
+			return showcaseerrors.Field(
+				showcaseerrors.FieldOutOfRange,
+				"ack_deadline",
+				"The field `ack_deadline` must be from %s to %s.",
+				minAckDeadline,
+				maxAckDeadline)
 		}
-		start = token32
 	}
+	max := int(req.GetMaxOutstandingMessages())
+	if max < 0 {
+		return showcaseerrors.Field(showcaseerrors.FieldOutOfRange, "max_outstanding_messages", "The field `max_outstanding_messages` must not be negative.")
+	}
+	namespace := server.NamespaceFromContext(stream.Context())
+	subscriber := fmt.Sprintf("%s-%d", s.sessionPrefix, atomic.AddInt64(&s.subscribers, 1))
 
-	pageSize := in.GetPageSize()
-	if pageSize == 0 {
-		pageSize = int32(len(words))
+	recvs := make(chan pullRecv, 1)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			select {
+			case recvs <- pullRecv{req, err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	acks := req.GetAckIds()
+	for {
+		s.topics.Ack(namespace, topic, acks)
+		acks = nil
+		pull, err := s.topics.Pull(namespace, topic, subscriber, max, s.nowF(), deadline)
+		if err != nil {
+			return err
+		}
+		if len(pull.Messages) > 0 {
+			if err := stream.Send(&pb.StreamingPullEchoResponse{ReceivedMessages: pull.Messages}); err != nil {
+				return err
+			}
+		}
+		// Waiting for the next lease to expire, of any stream, redelivers
+		// the messages its stream did not acknowledge in time.
+		var expired <-chan time.Time
+		if !pull.NextExpiry.IsZero() {
+			expired = s.afterF(pull.NextExpiry.Sub(s.nowF()))
+		}
+		select {
+		case r := <-recvs:
+			if r.err == io.EOF {
+				return nil
+			}
+			if r.err != nil {
+				return r.err
+			}
+			if t := r.req.GetTopic(); t != "" && t != topic {
+				return showcaseerrors.Field(
+					showcaseerrors.FieldInvalid,
+					"topic",
+					"The field `topic` must be empty or %q, the topic of the stream.",
+					topic)
+			}
+			acks = r.req.GetAckIds()
+		case <-pull.Changed:
+		case <-expired:
+		}
 	}
-	end := min(start+pageSize, int32(len(words)))
+}
 
-	responses := []*pb.EchoResponse{}
-	for _, word := range words[start:end] {
-		responses = append(responses, &pb.EchoResponse{Content: word})
+// numericEdgeCases are the canned sets of RepeatNumericEdgeCases. ALL is
+// built from them.
+var numericEdgeCases = map[pb.RepeatNumericEdgeCasesRequest_EdgeCases][]*pb.NumericValues{
+	pb.RepeatNumericEdgeCasesRequest_NOT_A_NUMBER: {
+		{DoubleValue: math.NaN(), FloatValue: float32(math.NaN())},
+	},
+	pb.RepeatNumericEdgeCasesRequest_INFINITIES: {
+		{DoubleValue: math.Inf(1), FloatValue: float32(math.Inf(1))},
+		{DoubleValue: math.Inf(-1), FloatValue: float32(math.Inf(-1))},
+	},
+	pb.RepeatNumericEdgeCasesRequest_INTEGER_LIMITS: {
+		{Int64Value: math.MaxInt64, Uint64Value: math.MaxUint64, Fixed64Value: math.MaxUint64},
+		{Int64Value: math.MinInt64},
+	},
+	pb.RepeatNumericEdgeCasesRequest_SAFE_INTEGER_BOUNDS: {
+		{Int64Value: 1<<53 - 1, Uint64Value: 1<<53 - 1, Fixed64Value: 1<<53 - 1},
+		{Int64Value: 1<<53 + 1, Uint64Value: 1<<53 + 1, Fixed64Value: 1<<53 + 1},
+	},
+}
+
+func (s *echoServerImpl) RepeatNumericEdgeCases(ctx context.Context, in *pb.RepeatNumericEdgeCasesRequest) (*pb.RepeatNumericEdgeCasesResponse, error) {
+	sets := []pb.RepeatNumericEdgeCasesRequest_EdgeCases{in.GetEdgeCases()}
+	switch in.GetEdgeCases() {
+	case pb.RepeatNumericEdgeCasesRequest_EDGE_CASES_UNSPECIFIED:
+		sets = nil
+	case pb.RepeatNumericEdgeCasesRequest_ALL:
+		sets = []pb.RepeatNumericEdgeCasesRequest_EdgeCases{
+			pb.RepeatNumericEdgeCasesRequest_NOT_A_NUMBER,
+			pb.RepeatNumericEdgeCasesRequest_INFINITIES,
+			pb.RepeatNumericEdgeCasesRequest_INTEGER_LIMITS,
+			pb.RepeatNumericEdgeCasesRequest_SAFE_INTEGER_BOUNDS,
+		}
+	default:
+		if _, ok := numericEdgeCases[in.GetEdgeCases()]; !ok {
+			return nil, showcaseerrors.Field(
+				showcaseerrors.FieldInvalid,
+				"edge_cases",
+				"The field `edge_cases` has the unknown value %d.",
+				in.GetEdgeCases())
+		}
+	}
+	resp := &pb.RepeatNumericEdgeCasesResponse{Values: in.GetValues()}
+	for _, set := range sets {
+		for _, values := range numericEdgeCases[set] {
+			resp.Values = append(resp.Values, proto.Clone(values).(*pb.NumericValues))
+		}
 	}
+	return resp, nil
+}
 
-	nextToken := ""
-	if end < int32(len(words)) {
-		nextToken = strconv.Itoa(int(end))
+func (s *echoServerImpl) FailEchoWithDetails(ctx context.Context, in *pb.FailEchoWithDetailsRequest) (*pb.EchoResponse, error) {
+	if codes.Code(in.GetError().GetCode()) == codes.OK {
+		return nil, showcaseerrors.Field(
+			showcaseerrors.FieldInvalid,
+			"error",
+			"The field `error` must have a non-OK code.")
 	}
 
-	return &pb.PagedExpandResponse{
-		Responses:     responses,
-		NextPageToken: nextToken,
-	}, nil
+	st := &spb.Status{
+		Code:    in.GetError().GetCode(),
+		Message: in.GetError().GetMessage(),
+	}
+	for i, t := range in.GetDetails() {
+		var detail proto.Message
+		switch t {
+		case pb.FailEchoWithDetailsRequest_LOCALIZED_MESSAGE:
+			detail = &errdetails.LocalizedMessage{
+				Locale:  in.GetLocale(),
+				Message: in.GetLocalizedMessage(),
+			}
+		case pb.FailEchoWithDetailsRequest_HELP:
+			detail = &errdetails.Help{Links: in.GetLinks()}
+		case pb.FailEchoWithDetailsRequest_QUOTA_FAILURE:
+			detail = &errdetails.QuotaFailure{Violations: in.GetQuotaViolations()}
+		case pb.FailEchoWithDetailsRequest_PRECONDITION_FAILURE:
+			detail = &errdetails.PreconditionFailure{Violations: in.GetPreconditionViolations()}
+		default:
+			return nil, showcaseerrors.Field(
+				showcaseerrors.FieldInvalid,
+				"details",
+				"The field `details[%d]` has an unsupported detail type %s.",
+				i,
+				t)
+		}
+		any, err := ptypes.MarshalAny(detail)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		st.Details = append(st.Details, any)
+	}
+	return nil, status.ErrorProto(st)
 }
 
-func min(x int32, y int32) int32 {
-	if x < y {
-		return x
+// maxStatusStreamMessages is the most responses a StreamStatus call streams.
+const maxStatusStreamMessages = 1000
+
+func (s *echoServerImpl) ReturnStatus(ctx context.Context, in *pb.ReturnStatusRequest) (*pb.EchoResponse, error) {
+	if in.GetCode() == int32(codes.OK) {
+		return nil, showcaseerrors.Field(
+			showcaseerrors.FieldInvalid,
+			"code",
+			"The field `code` must not be OK, since a unary call cannot fail with it.")
 	}
-	return y
+	if err := validateStatusCode(in.GetCode()); err != nil {
+		return nil, err
+	}
+	return nil, s.returnedStatus(ctx, in.GetCode(), in.GetMessage(), in.GetWithDetails())
 }
 
-func (s *echoServerImpl) Wait(ctx context.Context, in *pb.WaitRequest) (*lropb.Operation, error) {
-	return s.waiter.Wait(in), nil
+func (s *echoServerImpl) StreamStatus(in *pb.StreamStatusRequest, stream pb.Echo_StreamStatusServer) error {
+	if err := validateStatusCode(in.GetCode()); err != nil {
+		return err
+	}
+	if n := in.GetMessageCount(); n < 0 || n > maxStatusStreamMessages {
+		return showcaseerrors.Field(
+			showcaseerrors.FieldOutOfRange,
+			"message_count",
+			"The field `message_count` must be between 0 and %d, not %d.",
+			maxStatusStreamMessages,
+			n)
+	}
+	for i := int32(0); i < in.GetMessageCount(); i++ {
+		if err := stream.Send(&pb.EchoResponse{Content: strconv.Itoa(int(i))}); err != nil {
+			return err
+		}
+	}
+	if in.GetCode() == int32(codes.OK) {
+		return nil
+	}
+	return s.returnedStatus(stream.Context(), in.GetCode(), in.GetMessage(), in.GetWithDetails())
+}
+
+// validateStatusCode fails unless c is a canonical code.
+func validateStatusCode(c int32) error {
+	if c < int32(codes.OK) || c > int32(codes.Unauthenticated) {
+		return showcaseerrors.Field(
+			showcaseerrors.FieldOutOfRange,
+			"code",
+			"The field `code` must be a canonical code from 0 to %d, not %d.",
+			codes.Unauthenticated,
+			c)
+	}
+	return nil
+}
+
+// returnedStatus returns the error of a ReturnStatus or StreamStatus call,
+// with the standard details if they were requested.
+func (s *echoServerImpl) returnedStatus(ctx context.Context, c int32, message string, withDetails bool) error {
+	st := &spb.Status{Code: c, Message: message}
+	if !withDetails {
+		return status.ErrorProto(st)
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	ranges, err := server.ParseAcceptLanguage(strings.Join(md.Get("accept-language"), ","))
+	if err != nil {
+		return showcaseerrors.Metadata("accept-language", "The accept-language metadata is invalid: %s.", err)
+	}
+	localized, err := ptypes.MarshalAny(&errdetails.LocalizedMessage{
+		Locale:  server.MatchLanguage(ranges, s.settings.Get().SupportedLocales),
+		Message: message,
+	})
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	st.Details = []*any.Any{
+		showcaseerrors.ErrorInfo(code.Code(c).String(), showcaseerrors.Domain, map[string]string{
+			"code": strconv.Itoa(int(c)),
+		}),
+		localized,
+	}
+	return status.ErrorProto(st)
 }
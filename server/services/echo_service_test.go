@@ -15,18 +15,46 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"hash/crc32"
 	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/googleapis/gapic-showcase/server"
 	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/interceptors"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	"github.com/googleapis/gapic-showcase/server/showcasefuzz"
+	lropb "google.golang.org/genproto/googleapis/longrunning"
+	code "google.golang.org/genproto/googleapis/rpc/code"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
 	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -75,314 +103,4861 @@ func TestEcho_error(t *testing.T) {
 	}
 }
 
-type mockExpandStream struct {
-	exp []string
-	t   *testing.T
-	pb.Echo_ExpandServer
-}
-
-func (m *mockExpandStream) Send(resp *pb.EchoResponse) error {
-	if resp.GetContent() != m.exp[0] {
-		m.t.Errorf("Expand expected to send %s but sent %s", m.exp[0], resp.GetContent())
+func TestEcho_validateContentRegex(t *testing.T) {
+	tests := []struct {
+		content string
+		pattern string
+		field   string
+	}{
+		{"hello world", "", ""},
+		{"hello world", "^hello", ""},
+		{"hello world", "^[a-z ]+$", ""},
+		{"hello world", "^world", "content"},
+		{"", ".+", "content"},
+		{"hello world", "(unclosed", "validate_content_regex"},
 	}
-	m.exp = m.exp[1:]
-	return nil
-}
 
-func (m *mockExpandStream) verify() {
-	if len(m.exp) > 0 {
-		m.t.Errorf("Exand did not stream all expected values. %d expected values remaining.", len(m.exp))
+	server := NewEchoServer()
+	for _, test := range tests {
+		in := &pb.EchoRequest{
+			Response:             &pb.EchoRequest_Content{Content: test.content},
+			ValidateContentRegex: test.pattern,
+		}
+		out, err := server.Echo(context.Background(), in)
+		if test.field == "" {
+			if err != nil {
+				t.Errorf("Echo(%q, %q): unexpected err %+v", test.content, test.pattern, err)
+			} else if out.GetContent() != test.content {
+				t.Errorf("Echo(%q, %q): want %q got %q", test.content, test.pattern, test.content, out.GetContent())
+			}
+			continue
+		}
+
+		st, _ := status.FromError(err)
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf("Echo(%q, %q): want InvalidArgument got %v", test.content, test.pattern, err)
+			continue
+		}
+		details := st.Details()
+		if len(details) != 2 {
+			t.Errorf("Echo(%q, %q): want a BadRequest and an ErrorInfo got %v", test.content, test.pattern, details)
+			continue
+		}
+		br, ok := details[0].(*errdetails.BadRequest)
+		if !ok || len(br.GetFieldViolations()) != 1 || br.GetFieldViolations()[0].GetField() != test.field {
+			t.Errorf("Echo(%q, %q): want BadRequest on %s got %v", test.content, test.pattern, test.field, details[0])
+		}
 	}
 }
 
-func TestExpand(t *testing.T) {
-	contentTable := []string{"Hello World", "Hola", ""}
-	errTable := []*spb.Status{
-		{Code: int32(codes.OK)},
-		{Code: int32(codes.InvalidArgument)},
-		nil,
-	}
+func TestEcho_unknownFields(t *testing.T) {
+	known, _ := proto.Marshal(&pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}})
 
-	server := NewEchoServer()
-	for _, c := range contentTable {
-		for _, e := range errTable {
-			stream := &mockExpandStream{exp: strings.Fields(c), t: t}
-			err := server.Expand(&pb.ExpandRequest{Content: c, Error: e}, stream)
-			status, _ := status.FromError(err)
-			if int32(status.Code()) != e.GetCode() {
-				t.Errorf("Expand expected stream to return status with code %d but code %d", status.Code(), e.GetCode())
-			}
-			stream.verify()
+	// Fields 1000 and 1001 are unknown to the server.
+	unknown := proto.NewBuffer(nil)
+	unknown.EncodeVarint(1000<<3 | proto.WireVarint)
+	unknown.EncodeVarint(42)
+	unknown.EncodeVarint(1001<<3 | proto.WireBytes)
+	unknown.EncodeStringBytes("from the future")
+
+	for _, strip := range []bool{false, true} {
+		b := append(append([]byte{}, known...), unknown.Bytes()...)
+		if strip {
+			flag, _ := proto.Marshal(&pb.EchoRequest{StripUnknownFields: true})
+			b = append(b, flag...)
+		}
+		in := &pb.EchoRequest{}
+		if err := proto.Unmarshal(b, in); err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := NewEchoServer().Echo(context.Background(), in)
+		if err != nil {
+			t.Fatalf("Echo: unexpected err %+v", err)
+		}
+		got, _ := proto.Marshal(out)
+		if contains := bytes.Contains(got, unknown.Bytes()); contains == strip {
+			t.Errorf("Echo(strip=%t): want unknown fields in response %t, got %x", strip, !strip, got)
+		}
+		if out.GetContent() != "hi" {
+			t.Errorf("Echo(strip=%t): want content hi got %q", strip, out.GetContent())
 		}
 	}
 }
 
-type errorExpandStream struct {
-	err error
-	pb.Echo_ExpandServer
-}
+func TestEcho_corruptUTF8Response(t *testing.T) {
+	in := &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}, CorruptUtf8Response: true}
+	if _, err := NewEchoServer().Echo(context.Background(), in); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Echo without --enable-nonconforming: want PermissionDenied got %v", err)
+	}
 
-func (s *errorExpandStream) Send(resp *pb.EchoResponse) error {
-	return s.err
-}
+	settings := server.DefaultSettings()
+	settings.EnableNonconforming = true
+	echo := NewEchoServer().(*echoServerImpl)
+	echo.settings = server.NewSettingsStore(settings)
+	out, err := echo.Echo(context.Background(), in)
+	if err != nil {
+		t.Fatalf("Echo: unexpected err %+v", err)
+	}
+	got, err := proto.Marshal(out)
+	if err != nil {
+		t.Fatalf("Marshal: unexpected err %+v", err)
+	}
+	// Field 1, content, holds "hi" followed by 0xFF 0xFE.
+	want := []byte{0x0a, 0x04, 'h', 'i', 0xff, 0xfe}
+	if !bytes.Contains(got, want) || out.GetContent() != "" {
+		t.Errorf("Echo: want the content as the wire bytes %x got %x", want, got)
+	}
+	// The Go runtime is one of the clients that rejects the response.
+	if err := proto.Unmarshal(got, &pb.EchoResponse{}); err == nil {
+		t.Errorf("Unmarshal: want the invalid UTF-8 rejected")
+	}
 
-func TestExpand_streamErr(t *testing.T) {
-	e := errors.New("Test Error")
-	stream := &errorExpandStream{err: e}
-	server := NewEchoServer()
-	err := server.Expand(&pb.ExpandRequest{Content: "Hello World"}, stream)
-	if e != err {
-		t.Error("Expand expected to pass through stream errors.")
+	// Without the flag, the same server answers as usual.
+	in.CorruptUtf8Response = false
+	if out, err := echo.Echo(context.Background(), in); err != nil || out.GetContent() != "hi" || len(out.XXX_unrecognized) != 0 {
+		t.Errorf("Echo: want the content hi got %v, %v", out, err)
 	}
 }
 
-type mockCollectStream struct {
-	reqs []*pb.EchoRequest
-	exp  *string
-	t    *testing.T
-	pb.Echo_CollectServer
-}
+func TestRepeatNumericEdgeCases(t *testing.T) {
+	echoed := &pb.NumericValues{DoubleValue: 0.5, Int64Value: -1}
+	resp, err := NewEchoServer().RepeatNumericEdgeCases(context.Background(), &pb.RepeatNumericEdgeCasesRequest{
+		Values:    []*pb.NumericValues{echoed},
+		EdgeCases: pb.RepeatNumericEdgeCasesRequest_INFINITIES,
+	})
+	if err != nil {
+		t.Fatalf("RepeatNumericEdgeCases: unexpected err %+v", err)
+	}
+	values := resp.GetValues()
+	if len(values) != 3 || !proto.Equal(values[0], echoed) {
+		t.Fatalf("RepeatNumericEdgeCases: want the request values then two infinities got %v", values)
+	}
+	if !math.IsInf(values[1].GetDoubleValue(), 1) || !math.IsInf(float64(values[2].GetFloatValue()), -1) {
+		t.Errorf("RepeatNumericEdgeCases: want positive then negative infinity got %v", values[1:])
+	}
 
-func (m *mockCollectStream) SendAndClose(r *pb.EchoResponse) error {
-	if m.exp == nil {
-		m.t.Errorf("Collect Stream SendAndClose called unexpectedly")
+	resp, err = NewEchoServer().RepeatNumericEdgeCases(context.Background(), &pb.RepeatNumericEdgeCasesRequest{})
+	if err != nil || len(resp.GetValues()) != 0 {
+		t.Errorf("RepeatNumericEdgeCases: want no values for an empty request got %v, %v", resp, err)
 	}
-	if r.GetContent() != *m.exp {
-		m.t.Errorf("Collect expected to return '%s', but returned '%s'", *m.exp, r.GetContent())
+
+	_, err = NewEchoServer().RepeatNumericEdgeCases(context.Background(), &pb.RepeatNumericEdgeCasesRequest{EdgeCases: 99})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("RepeatNumericEdgeCases(99): want InvalidArgument got %v", err)
 	}
-	return nil
 }
 
-func (m *mockCollectStream) Recv() (*pb.EchoRequest, error) {
-	if len(m.reqs) > 0 {
-		ret := m.reqs[0]
-		m.reqs = m.reqs[1:]
-		return ret, nil
+func TestRepeatNumericEdgeCases_json(t *testing.T) {
+	h := server.NewEchoHTTPHandler(NewEchoServer())
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, server.NumericEdgeCasesPath, strings.NewReader(`{"edgeCases":"ALL"}`)))
+	want := `{"values":[` +
+		`{"doubleValue":"NaN","floatValue":"NaN"},` +
+		`{"doubleValue":"Infinity","floatValue":"Infinity"},` +
+		`{"doubleValue":"-Infinity","floatValue":"-Infinity"},` +
+		`{"int64Value":"9223372036854775807","uint64Value":"18446744073709551615","fixed64Value":"18446744073709551615"},` +
+		`{"int64Value":"-9223372036854775808"},` +
+		`{"int64Value":"9007199254740991","uint64Value":"9007199254740991","fixed64Value":"9007199254740991"},` +
+		`{"int64Value":"9007199254740993","uint64Value":"9007199254740993","fixed64Value":"9007199254740993"}]}`
+	if w.Code != http.StatusOK || w.Body.String() != want {
+		t.Errorf("RepeatNumericEdgeCases(ALL): want 200 %s got %d %s", want, w.Code, w.Body.String())
 	}
-	return nil, io.EOF
 }
 
-func TestCollect(t *testing.T) {
-	strPtr := func(s string) *string { return &s }
+func TestEcho_acceptLanguage(t *testing.T) {
 	tests := []struct {
-		reqs []string
-		exp  *string
-		err  *spb.Status
+		values  []string
+		content string
+		locale  string
 	}{
-		{[]string{"Hello", "", "World"}, strPtr("Hello World"), nil},
-		{[]string{"Hello", "World"}, strPtr("Hello World"), &spb.Status{Code: int32(codes.OK)}},
-		{[]string{"Hello", "World"}, nil, &spb.Status{Code: int32(codes.InvalidArgument)}},
-		{[]string{}, nil, &spb.Status{Code: int32(codes.InvalidArgument)}},
-		{[]string{}, strPtr(""), nil},
+		{nil, "world", ""},
+		{[]string{"es-MX, en;q=0.5"}, "Hola world", "es"},
+		{[]string{"*"}, "Hello world", "en"},
+		{[]string{"en;q=0, *"}, "Hola world", "es"},
+		{[]string{"fr, de"}, "Hello world", "en"},
+		{[]string{"es;q=0.5, ja;q=0.5"}, "Hola world", "es"},
+		{[]string{"fr", "ja"}, "こんにちは world", "ja"},
 	}
-
 	server := NewEchoServer()
 	for _, test := range tests {
-		reqs := []*pb.EchoRequest{}
-		for _, req := range test.reqs {
-			reqs = append(reqs, &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: req}})
+		md := metadata.MD{}
+		for _, v := range test.values {
+			md.Append("accept-language", v)
 		}
-		if test.err != nil {
-			reqs = append(reqs, &pb.EchoRequest{Response: &pb.EchoRequest_Error{Error: test.err}})
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		out, err := server.Echo(ctx, &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "world"}})
+		if err != nil {
+			t.Errorf("Echo(%q): unexpected err %+v", test.values, err)
+			continue
 		}
-		mockStream := &mockCollectStream{reqs: reqs, exp: test.exp, t: t}
-		err := server.Collect(mockStream)
-		expCode := status.FromProto(test.err).Code()
-		s, _ := status.FromError(err)
-		if expCode != s.Code() {
-			t.Errorf("Collect expected to return with code %d, but returned %d", expCode, s.Code())
+		if out.GetContent() != test.content || out.GetLocale() != test.locale {
+			t.Errorf("Echo(%q): want (%q, %q) got (%q, %q)", test.values, test.content, test.locale, out.GetContent(), out.GetLocale())
 		}
 	}
 }
 
-type errorCollectStream struct {
-	err error
-	pb.Echo_CollectServer
+func TestEcho_acceptLanguageConfigured(t *testing.T) {
+	settings := server.DefaultSettings()
+	settings.SupportedLocales = []string{"fr", "es"}
+	echo := &echoServerImpl{settings: server.NewSettingsStore(settings), sequence: server.NewSequence()}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("accept-language", "fr"))
+	out, err := echo.Echo(ctx, &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "monde"}})
+	if err != nil {
+		t.Fatalf("Echo: unexpected err %+v", err)
+	}
+	// There is no French greeting, so only the locale is reported.
+	if out.GetContent() != "monde" || out.GetLocale() != "fr" {
+		t.Errorf("Echo: want (monde, fr) got (%q, %q)", out.GetContent(), out.GetLocale())
+	}
 }
 
-func (s *errorCollectStream) Recv() (*pb.EchoRequest, error) {
-	return nil, s.err
+func TestEcho_acceptLanguageInvalid(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("accept-language", "en, e!s"))
+	_, err := NewEchoServer().Echo(ctx, &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "world"}})
+	if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), `"e!s"`) {
+		t.Errorf("Echo: want InvalidArgument naming \"e!s\", got %v", err)
+	}
 }
 
-func TestCollect_streamErr(t *testing.T) {
-	e := errors.New("Test Error")
-	stream := &errorCollectStream{err: e}
-	server := NewEchoServer()
-	err := server.Collect(stream)
-	if e != err {
-		t.Error("Expand expected to pass through stream errors.")
+func TestCacheControlValue(t *testing.T) {
+	tests := []struct {
+		cc   *pb.CacheControl
+		want string
+		code codes.Code
+	}{
+		{&pb.CacheControl{}, "max-age=0", codes.OK},
+		{&pb.CacheControl{MaxAge: 60}, "max-age=60", codes.OK},
+		{&pb.CacheControl{NoStore: true}, "no-store", codes.OK},
+		{&pb.CacheControl{NoStore: true, MaxAge: 60}, "", codes.InvalidArgument},
+		{&pb.CacheControl{MaxAge: -1}, "", codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		got, err := cacheControlValue(test.cc)
+		if got != test.want || status.Code(err) != test.code {
+			t.Errorf("cacheControlValue(%v): want (%q, %s) got (%q, %v)", test.cc, test.want, test.code, got, err)
+		}
 	}
 }
 
-type mockChatStream struct {
-	reqs []*pb.EchoRequest
-	curr *pb.EchoRequest
-	t    *testing.T
-	pb.Echo_ChatServer
-}
+func TestEcho_cacheControl(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	pb.RegisterEchoServer(s, NewEchoServer())
+	go s.Serve(lis)
+	defer s.Stop()
 
-func (m *mockChatStream) Recv() (*pb.EchoRequest, error) {
-	if len(m.reqs) > 0 {
-		m.curr = m.reqs[0]
-		m.reqs = m.reqs[1:]
-		return m.curr, nil
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
 	}
-	return nil, io.EOF
-}
+	defer conn.Close()
+	client := pb.NewEchoClient(conn)
 
-func (m *mockChatStream) Send(r *pb.EchoResponse) error {
-	if m.curr == nil {
-		m.t.Errorf("Chat unexpectedly tried to send content.")
+	tests := []struct {
+		cc   *pb.CacheControl
+		want []string
+		code codes.Code
+	}{
+		{nil, nil, codes.OK},
+		{&pb.CacheControl{MaxAge: 3600}, []string{"max-age=3600"}, codes.OK},
+		{&pb.CacheControl{NoStore: true}, []string{"no-store"}, codes.OK},
+		{&pb.CacheControl{NoStore: true, MaxAge: 1}, nil, codes.InvalidArgument},
 	}
-	if r.GetContent() != m.curr.GetContent() {
-		m.t.Errorf("Chat expected to send content %s, but sent %s", m.curr.GetContent(), r.GetContent())
-		m.curr = nil
+	for _, test := range tests {
+		var header metadata.MD
+		_, err := client.Echo(
+			context.Background(),
+			&pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}, CacheControl: test.cc},
+			grpc.Header(&header))
+		if status.Code(err) != test.code {
+			t.Errorf("Echo(%v): want %s got %v", test.cc, test.code, err)
+		}
+		if got := header.Get(server.CacheControlHeader); fmt.Sprint(got) != fmt.Sprint(test.want) {
+			t.Errorf("Echo(%v): want %s header %v got %v", test.cc, server.CacheControlHeader, test.want, got)
+		}
 	}
-	return nil
 }
 
-func TestChat(t *testing.T) {
+func TestEcho_cacheControlHTTP(t *testing.T) {
 	tests := []struct {
-		reqs []string
-		err  *spb.Status
+		body       string
+		wantStatus int
+		want       string
 	}{
-		{[]string{"Hello", "World"}, nil},
-		{[]string{"Hello", "World"}, &spb.Status{Code: int32(codes.InvalidArgument)}},
-		{[]string{}, &spb.Status{Code: int32(codes.InvalidArgument)}},
+		{`{"content":"hi"}`, http.StatusOK, ""},
+		{`{"content":"hi","cacheControl":{"maxAge":5}}`, http.StatusOK, "max-age=5"},
+		{`{"content":"hi","cacheControl":{"noStore":true}}`, http.StatusOK, "no-store"},
+		{`{"content":"hi","cacheControl":{"noStore":true,"maxAge":5}}`, http.StatusBadRequest, ""},
 	}
-
-	server := NewEchoServer()
+	h := server.NewEchoHTTPHandler(NewEchoServer())
 	for _, test := range tests {
-		reqs := []*pb.EchoRequest{}
-		for _, req := range test.reqs {
-			reqs = append(reqs, &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: req}})
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, server.EchoPath, strings.NewReader(test.body)))
+		if w.Code != test.wantStatus {
+			t.Errorf("POST %s: want status %d got %d: %s", test.body, test.wantStatus, w.Code, w.Body)
 		}
-		if test.err != nil {
-			reqs = append(reqs, &pb.EchoRequest{Response: &pb.EchoRequest_Error{Error: test.err}})
+		if got := w.Header().Get("Cache-Control"); got != test.want {
+			t.Errorf("POST %s: want Cache-Control %q got %q", test.body, test.want, got)
 		}
+	}
+}
 
-		mockStream := &mockChatStream{reqs: reqs, t: t}
-		err := server.Chat(mockStream)
-		expCode := status.FromProto(test.err).Code()
-		s, _ := status.FromError(err)
-		if expCode != s.Code() {
-			t.Errorf("Chat expected to return status with code %d, but returned %d", expCode, s.Code())
+func TestEcho_sequence(t *testing.T) {
+	const n = 1000
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	echo := NewEchoServer().(*echoServerImpl)
+	echo.sequence = server.NewSequence()
+	s := grpc.NewServer()
+	pb.RegisterEchoServer(s, echo)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewEchoClient(conn)
+
+	responses := make([]*pb.EchoResponse, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := client.Echo(context.Background(), &pb.EchoRequest{
+				Response:       &pb.EchoRequest_Content{Content: fmt.Sprint(i)},
+				ClientSequence: int64(i),
+			})
+			if err != nil {
+				t.Errorf("Echo(%d): unexpected err %+v", i, err)
+				return
+			}
+			responses[i] = resp
+		}(i)
+	}
+	wg.Wait()
+
+	// Each client sequence maps to exactly one server sequence in [1, n].
+	servers := map[int64]int64{}
+	for i, resp := range responses {
+		if resp == nil {
+			continue
 		}
+		if resp.GetClientSequence() != int64(i) || resp.GetContent() != fmt.Sprint(i) {
+			t.Errorf("Echo(%d): got the response to request %d (%q)", i, resp.GetClientSequence(), resp.GetContent())
+		}
+		seq := resp.GetServerSequence()
+		if seq < 1 || seq > n {
+			t.Errorf("Echo(%d): want a server sequence in [1, %d] got %d", i, n, seq)
+		}
+		if prev, ok := servers[seq]; ok {
+			t.Errorf("Echo(%d): server sequence %d was already given to request %d", i, seq, prev)
+		}
+		servers[seq] = int64(i)
 	}
 }
 
-type errorChatStream struct {
-	err error
-	pb.Echo_ChatServer
-}
+func TestEcho_sequenceSharedWithChat(t *testing.T) {
+	echo := &echoServerImpl{sequence: server.NewSequence()}
+	resp, err := echo.Echo(context.Background(), &pb.EchoRequest{ClientSequence: 7})
+	if err != nil {
+		t.Fatalf("Echo: unexpected err %+v", err)
+	}
+	if resp.GetClientSequence() != 7 || resp.GetServerSequence() != 1 {
+		t.Errorf("Echo: want sequences (7, 1) got (%d, %d)", resp.GetClientSequence(), resp.GetServerSequence())
+	}
 
-func (s *errorChatStream) Recv() (*pb.EchoRequest, error) {
-	return nil, s.err
+	stream := &mockChatStream{
+		reqs: []*pb.EchoRequest{
+			{Response: &pb.EchoRequest_Content{Content: "a"}, ClientSequence: 8},
+		},
+		t: t,
+	}
+	if err := echo.Chat(stream); err != nil {
+		t.Fatalf("Chat: unexpected err %+v", err)
+	}
+	if len(stream.resps) != 1 {
+		t.Fatalf("Chat: want 1 response got %d", len(stream.resps))
+	}
+	if got := stream.resps[0]; got.GetClientSequence() != 8 || got.GetServerSequence() != 2 {
+		t.Errorf("Chat: want sequences (8, 2) got (%d, %d)", got.GetClientSequence(), got.GetServerSequence())
+	}
 }
 
-func TestChat_streamErr(t *testing.T) {
-	e := errors.New("Test Error")
-	stream := &errorChatStream{err: e}
-	server := NewEchoServer()
-	err := server.Chat(stream)
-	if e != err {
-		t.Error("Expand expected to pass through stream errors.")
+func TestRegexCache(t *testing.T) {
+	compiles := 0
+	cache := newRegexCache(2, func(p string) (*regexp.Regexp, error) {
+		compiles++
+		return regexp.Compile(p)
+	})
+
+	for _, p := range []string{"a", "a", "b", "a", "b"} {
+		if _, err := cache.get(p); err != nil {
+			t.Fatalf("get(%q): unexpected err %+v", p, err)
+		}
+	}
+	if compiles != 2 {
+		t.Errorf("regexCache: want 2 compiles for 2 patterns got %d", compiles)
+	}
+
+	// "c" evicts the least recently used pattern, "a".
+	cache.get("c")
+	cache.get("b")
+	if compiles != 3 {
+		t.Errorf("regexCache: want 3 compiles got %d", compiles)
+	}
+	cache.get("a")
+	if compiles != 4 {
+		t.Errorf("regexCache: want evicted pattern to be recompiled, got %d compiles", compiles)
+	}
+
+	// Invalid patterns are not cached.
+	for i := 0; i < 2; i++ {
+		if _, err := cache.get("("); err == nil {
+			t.Errorf("get(\"(\"): want err")
+		}
+	}
+	if compiles != 6 {
+		t.Errorf("regexCache: want invalid patterns to be recompiled, got %d compiles", compiles)
+	}
+	if cache.order.Len() != 2 || len(cache.entries) != 2 {
+		t.Errorf("regexCache: want 2 entries got %d", cache.order.Len())
 	}
 }
 
-func TestPagedExpand_invalidArgs(t *testing.T) {
-	tests := []*pb.PagedExpandRequest{
-		{PageSize: -1},
-		{PageToken: "-1"},
-		{PageToken: "BOGUS"},
-		{Content: "one", PageToken: "1"},
-		{Content: "one", PageToken: "2"},
+func TestEcho_validateContentRegexCached(t *testing.T) {
+	compiles := 0
+	server := &echoServerImpl{
+		sequence: server.NewSequence(),
+		regexes: newRegexCache(maxCachedRegexes, func(p string) (*regexp.Regexp, error) {
+			compiles++
+			return regexp.Compile(p)
+		}),
 	}
-	server := NewEchoServer()
-	for _, in := range tests {
-		_, err := server.PagedExpand(context.Background(), in)
-		s, _ := status.FromError(err)
-		if s.Code() != codes.InvalidArgument {
-			t.Errorf("PagedExpand() expected error code: %d, got error code %d",
-				codes.InvalidArgument, s.Code())
+	for i := 0; i < 3; i++ {
+		in := &pb.EchoRequest{
+			Response:             &pb.EchoRequest_Content{Content: "hello"},
+			ValidateContentRegex: "^h",
 		}
+		if _, err := server.Echo(context.Background(), in); err != nil {
+			t.Errorf("Echo: unexpected err %+v", err)
+		}
+	}
+	if compiles != 1 {
+		t.Errorf("Echo: want the pattern to be compiled once, got %d", compiles)
 	}
 }
 
-func TestPagedExpand(t *testing.T) {
-	tests := []struct {
-		in  *pb.PagedExpandRequest
-		out *pb.PagedExpandResponse
-	}{
-		{
-			&pb.PagedExpandRequest{Content: "Hello world!"},
-			&pb.PagedExpandResponse{
-				Responses: []*pb.EchoResponse{
-					&pb.EchoResponse{Content: "Hello"},
-					&pb.EchoResponse{Content: "world!"},
-				},
+func TestInspectCredentials(t *testing.T) {
+	md := metadata.Pairs(
+		"authorization", "Bearer call",
+		"x-goog-api-key", "key",
+		"x-goog-user-project", "ignored")
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	out, err := NewEchoServer().InspectCredentials(ctx, &pb.InspectCredentialsRequest{})
+	if err != nil {
+		t.Fatalf("InspectCredentials: unexpected err %+v", err)
+	}
+	want := &pb.InspectCredentialsResponse{
+		Credentials: []*pb.InspectCredentialsResponse_Credential{
+			{Key: "authorization", Values: []string{"Bearer call"}},
+			{Key: "x-goog-api-key", Values: []string{"key"}},
+		},
+	}
+	if !proto.Equal(out, want) {
+		t.Errorf("InspectCredentials: want %v got %v", want, out)
+	}
+}
+
+func TestInspectCredentials_duplicated(t *testing.T) {
+	md := metadata.Pairs(
+		"authorization", "Bearer channel",
+		"x-goog-api-key", "key",
+		"authorization", "Bearer call")
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	out, err := NewEchoServer().InspectCredentials(ctx, &pb.InspectCredentialsRequest{})
+	if err != nil {
+		t.Fatalf("InspectCredentials: unexpected err %+v", err)
+	}
+	want := &pb.InspectCredentialsResponse{
+		Credentials: []*pb.InspectCredentialsResponse_Credential{
+			{Key: "authorization", Values: []string{"Bearer channel", "Bearer call"}, Duplicated: true},
+			{Key: "x-goog-api-key", Values: []string{"key"}},
+		},
+		Warnings: []string{"The `authorization` header was received 2 times."},
+	}
+	if !proto.Equal(out, want) {
+		t.Errorf("InspectCredentials: want %v got %v", want, out)
+	}
+
+	_, err = NewEchoServer().InspectCredentials(ctx, &pb.InspectCredentialsRequest{Strict: true})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("InspectCredentials(strict): want Unauthenticated got %v", err)
+	}
+}
+
+func TestInspectCredentials_none(t *testing.T) {
+	out, err := NewEchoServer().InspectCredentials(context.Background(), &pb.InspectCredentialsRequest{Strict: true})
+	if err != nil {
+		t.Fatalf("InspectCredentials: unexpected err %+v", err)
+	}
+	if len(out.GetCredentials()) != 0 || len(out.GetWarnings()) != 0 {
+		t.Errorf("InspectCredentials: want empty response got %v", out)
+	}
+}
+
+type mockReadBlobStream struct {
+	resps []*pb.ReadBlobResponse
+	pb.Echo_ReadBlobServer
+}
+
+func (m *mockReadBlobStream) Send(r *pb.ReadBlobResponse) error {
+	m.resps = append(m.resps, r)
+	return nil
+}
+
+// readBlob reads the blob described by the request, checking every chunk,
+// and returns its data along with the stream error.
+func readBlob(t *testing.T, in *pb.ReadBlobRequest) ([]byte, []*pb.ReadBlobResponse, error) {
+	stream := &mockReadBlobStream{}
+	err := NewEchoServer().ReadBlob(in, stream)
+
+	data := []byte{}
+	offset := in.GetReadOffset()
+	for i, r := range stream.resps {
+		if r.GetOffset() != offset {
+			t.Errorf("ReadBlob(%v): chunk %d want offset %d got %d", in, i, offset, r.GetOffset())
+		}
+		if crc := crc32.Checksum(r.GetData(), crc32.MakeTable(crc32.Castagnoli)); crc != r.GetCrc32C() {
+			t.Errorf("ReadBlob(%v): chunk %d want crc32c %d got %d", in, i, crc, r.GetCrc32C())
+		}
+		if r.GetFinal() != (err == nil && i == len(stream.resps)-1) {
+			t.Errorf("ReadBlob(%v): chunk %d has final=%t", in, i, r.GetFinal())
+		}
+		data = append(data, r.GetData()...)
+		offset += int64(len(r.GetData()))
+	}
+	return data, stream.resps, err
+}
+
+func TestReadBlob(t *testing.T) {
+	full, _, err := readBlob(t, &pb.ReadBlobRequest{TotalSize: 1000, Seed: 7, ChunkSize: 1000})
+	if err != nil || len(full) != 1000 {
+		t.Fatalf("ReadBlob: want 1000 bytes got %d, err %v", len(full), err)
+	}
+
+	tests := []struct {
+		offset, limit int64
+		chunkSize     int32
+		wantChunks    []int
+	}{
+		{0, 0, 300, []int{300, 300, 300, 100}},
+		{0, 0, 250, []int{250, 250, 250, 250}},
+		{100, 0, 300, []int{300, 300, 300}},
+		{100, 500, 300, []int{300, 200}},
+		{990, 500, 300, []int{10}},
+		{1000, 0, 300, []int{0}},
+		{0, 0, 0, []int{1000}},
+		{500, math.MaxInt64, 300, []int{300, 200}},
+	}
+	for _, test := range tests {
+		in := &pb.ReadBlobRequest{
+			TotalSize:  1000,
+			ReadOffset: test.offset,
+			ReadLimit:  test.limit,
+			ChunkSize:  test.chunkSize,
+			Seed:       7,
+		}
+		data, resps, err := readBlob(t, in)
+		if err != nil {
+			t.Errorf("ReadBlob(%v): unexpected err %+v", in, err)
+			continue
+		}
+		chunks := []int{}
+		for _, r := range resps {
+			chunks = append(chunks, len(r.GetData()))
+		}
+		if fmt.Sprint(chunks) != fmt.Sprint(test.wantChunks) {
+			t.Errorf("ReadBlob(%v): want chunks %v got %v", in, test.wantChunks, chunks)
+		}
+		if !bytes.Equal(data, full[test.offset:test.offset+int64(len(data))]) {
+			t.Errorf("ReadBlob(%v): data does not match the full blob", in)
+		}
+	}
+
+	other, _, _ := readBlob(t, &pb.ReadBlobRequest{TotalSize: 1000, Seed: 8})
+	if bytes.Equal(full, other) {
+		t.Errorf("ReadBlob: want different seeds to produce different blobs")
+	}
+}
+
+func TestReadBlob_resume(t *testing.T) {
+	full, _, _ := readBlob(t, &pb.ReadBlobRequest{TotalSize: 10000, Seed: 3, ChunkSize: 512})
+
+	in := &pb.ReadBlobRequest{TotalSize: 10000, Seed: 3, ChunkSize: 512, FailAfterBytes: 3000}
+	data := []byte{}
+	for len(data) < len(full) {
+		in.ReadOffset = int64(len(data))
+		got, _, err := readBlob(t, in)
+		data = append(data, got...)
+		if err == nil {
+			break
+		}
+		if status.Code(err) != codes.Unavailable {
+			t.Fatalf("ReadBlob(%v): want Unavailable got %v", in, err)
+		}
+		if len(got) != 3000 {
+			t.Fatalf("ReadBlob(%v): want 3000 bytes before failing got %d", in, len(got))
+		}
+	}
+	if !bytes.Equal(data, full) {
+		t.Errorf("ReadBlob: want resumed reads to equal a straight read")
+	}
+
+	// A failure past the end of the blob never happens.
+	in = &pb.ReadBlobRequest{TotalSize: 10, ReadOffset: 5, FailAfterBytes: math.MaxInt64}
+	if got, _, err := readBlob(t, in); err != nil || len(got) != 5 {
+		t.Errorf("ReadBlob(%v): want the last 5 bytes got %d, %v", in, len(got), err)
+	}
+}
+
+func TestReadBlob_invalid(t *testing.T) {
+	tests := []struct {
+		in   *pb.ReadBlobRequest
+		code codes.Code
+	}{
+		{&pb.ReadBlobRequest{TotalSize: 10, ReadOffset: 11}, codes.OutOfRange},
+		{&pb.ReadBlobRequest{TotalSize: -1}, codes.InvalidArgument},
+		{&pb.ReadBlobRequest{TotalSize: 10, ReadOffset: -1}, codes.InvalidArgument},
+		{&pb.ReadBlobRequest{TotalSize: 10, ReadLimit: -1}, codes.InvalidArgument},
+		{&pb.ReadBlobRequest{TotalSize: 10, ChunkSize: -1}, codes.InvalidArgument},
+		{&pb.ReadBlobRequest{TotalSize: 10, ChunkSize: server.DefaultSettings().MaxBlobChunkSize + 1}, codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		stream := &mockReadBlobStream{}
+		err := NewEchoServer().ReadBlob(test.in, stream)
+		if status.Code(err) != test.code {
+			t.Errorf("ReadBlob(%v): want %s got %v", test.in, test.code, err)
+		}
+		if len(stream.resps) != 0 {
+			t.Errorf("ReadBlob(%v): want no chunks got %d", test.in, len(stream.resps))
+		}
+	}
+}
+
+type mockWriteBlobStream struct {
+	reqs []*pb.WriteBlobRequest
+	err  error
+	resp *pb.WriteBlobResponse
+	pb.Echo_WriteBlobServer
+}
+
+func (m *mockWriteBlobStream) Recv() (*pb.WriteBlobRequest, error) {
+	if len(m.reqs) > 0 {
+		ret := m.reqs[0]
+		m.reqs = m.reqs[1:]
+		return ret, nil
+	}
+	if m.err != nil {
+		return nil, m.err
+	}
+	return nil, io.EOF
+}
+
+func (m *mockWriteBlobStream) Context() context.Context {
+	return context.Background()
+}
+
+func (m *mockWriteBlobStream) SendAndClose(r *pb.WriteBlobResponse) error {
+	m.resp = r
+	return nil
+}
+
+func blobSpec(id string, size int64) *pb.WriteBlobRequest {
+	return &pb.WriteBlobRequest{
+		Request: &pb.WriteBlobRequest_Spec_{Spec: &pb.WriteBlobRequest_Spec{BlobId: id, TotalSize: size}},
+	}
+}
+
+func blobChunk(offset int64, data []byte) *pb.WriteBlobRequest {
+	return &pb.WriteBlobRequest{
+		Request: &pb.WriteBlobRequest_Chunk_{Chunk: &pb.WriteBlobRequest_Chunk{Offset: offset, Data: data}},
+	}
+}
+
+func TestWriteBlob_resume(t *testing.T) {
+	echo := NewEchoServer().(*echoServerImpl)
+	echo.blobs = newBlobStore(server.GetSettingsInstance(), time.Now)
+	data := blobBytes(1, 0, 1000)
+
+	// The first stream is interrupted after two chunks.
+	interrupted := &mockWriteBlobStream{
+		reqs: []*pb.WriteBlobRequest{
+			blobSpec("blob", 1000),
+			blobChunk(0, data[:300]),
+			blobChunk(300, data[300:600]),
+		},
+		err: status.Error(codes.Unavailable, "connection reset"),
+	}
+	if err := echo.WriteBlob(interrupted); status.Code(err) != codes.Unavailable {
+		t.Fatalf("WriteBlob: want the stream error, got %v", err)
+	}
+
+	ws, err := echo.GetWriteStatus(context.Background(), &pb.GetWriteStatusRequest{BlobId: "blob"})
+	if err != nil {
+		t.Fatalf("GetWriteStatus: unexpected err %+v", err)
+	}
+	want := &pb.WriteStatus{BlobId: "blob", CommittedSize: 600, TotalSize: 1000}
+	if !proto.Equal(ws, want) {
+		t.Errorf("GetWriteStatus: want %v got %v", want, ws)
+	}
+
+	// The second stream resumes from the committed size.
+	resumed := &mockWriteBlobStream{
+		reqs: []*pb.WriteBlobRequest{
+			blobSpec("blob", 1000),
+			blobChunk(ws.GetCommittedSize(), data[ws.GetCommittedSize():]),
+		},
+	}
+	if err := echo.WriteBlob(resumed); err != nil {
+		t.Fatalf("WriteBlob: unexpected err %+v", err)
+	}
+	wantResp := &pb.WriteBlobResponse{
+		BlobId:       "blob",
+		ReceivedSize: 1000,
+		Crc32C:       crc32.Checksum(data, crc32.MakeTable(crc32.Castagnoli)),
+		Complete:     true,
+	}
+	if !proto.Equal(resumed.resp, wantResp) {
+		t.Errorf("WriteBlob: want %v got %v", wantResp, resumed.resp)
+	}
+	ws, _ = echo.GetWriteStatus(context.Background(), &pb.GetWriteStatusRequest{BlobId: "blob"})
+	if !ws.GetComplete() || ws.GetCommittedSize() != 1000 {
+		t.Errorf("GetWriteStatus: want a complete blob got %v", ws)
+	}
+}
+
+func TestWriteBlob_badChunks(t *testing.T) {
+	tests := []struct {
+		chunks []*pb.WriteBlobRequest
+		code   codes.Code
+		offset string
+	}{
+		// Duplicate chunk.
+		{[]*pb.WriteBlobRequest{blobChunk(0, []byte("abc")), blobChunk(0, []byte("abc"))}, codes.InvalidArgument, "offset 0"},
+		// Overlapping chunk.
+		{[]*pb.WriteBlobRequest{blobChunk(0, []byte("abc")), blobChunk(2, []byte("cd"))}, codes.InvalidArgument, "offset 2"},
+		// Out of order chunk.
+		{[]*pb.WriteBlobRequest{blobChunk(4, []byte("e"))}, codes.InvalidArgument, "offset 4"},
+		// Past the end.
+		{[]*pb.WriteBlobRequest{blobChunk(0, []byte("abcdefghijk"))}, codes.InvalidArgument, "offset 0"},
+		// A second spec.
+		{[]*pb.WriteBlobRequest{blobSpec("blob", 10)}, codes.InvalidArgument, ""},
+	}
+	echo := NewEchoServer().(*echoServerImpl)
+	echo.blobs = newBlobStore(server.GetSettingsInstance(), time.Now)
+	for i, test := range tests {
+		id := fmt.Sprintf("blob-%d", i)
+		stream := &mockWriteBlobStream{reqs: append([]*pb.WriteBlobRequest{blobSpec(id, 10)}, test.chunks...)}
+		err := echo.WriteBlob(stream)
+		if status.Code(err) != test.code {
+			t.Errorf("WriteBlob(%d): want %s got %v", i, test.code, err)
+		}
+		if !strings.Contains(status.Convert(err).Message(), test.offset) {
+			t.Errorf("WriteBlob(%d): want error naming %q got %v", i, test.offset, err)
+		}
+	}
+}
+
+func TestWriteBlob_invalidSpec(t *testing.T) {
+	settings := server.DefaultSettings()
+	settings.MaxBlobSize = 100
+	settings.MaxBlobStorageSize = 150
+	echo := &echoServerImpl{blobs: newBlobStore(server.NewSettingsStore(settings), time.Now)}
+	tests := []struct {
+		reqs []*pb.WriteBlobRequest
+		code codes.Code
+	}{
+		{nil, codes.InvalidArgument},
+		{[]*pb.WriteBlobRequest{blobChunk(0, []byte("a"))}, codes.InvalidArgument},
+		{[]*pb.WriteBlobRequest{blobSpec("", 10)}, codes.InvalidArgument},
+		{[]*pb.WriteBlobRequest{blobSpec("a", -1)}, codes.InvalidArgument},
+		{[]*pb.WriteBlobRequest{blobSpec("a", 101)}, codes.InvalidArgument},
+		{[]*pb.WriteBlobRequest{blobSpec("a", 100)}, codes.OK},
+		{[]*pb.WriteBlobRequest{blobSpec("a", 50)}, codes.FailedPrecondition},
+		{[]*pb.WriteBlobRequest{blobSpec("b", 51)}, codes.ResourceExhausted},
+		{[]*pb.WriteBlobRequest{blobSpec("b", 50)}, codes.OK},
+	}
+	for i, test := range tests {
+		err := echo.WriteBlob(&mockWriteBlobStream{reqs: test.reqs})
+		if status.Code(err) != test.code {
+			t.Errorf("WriteBlob(%d): want %s got %v", i, test.code, err)
+		}
+	}
+}
+
+func TestWriteBlob_expiry(t *testing.T) {
+	settings := server.DefaultSettings()
+	settings.MaxBlobStorageSize = 100
+	settings.BlobTTL = time.Minute
+	now := time.Unix(100, 0)
+	echo := &echoServerImpl{blobs: newBlobStore(server.NewSettingsStore(settings), func() time.Time { return now })}
+	ctx := context.Background()
+
+	// A complete blob and an abandoned one fill the storage.
+	if err := echo.WriteBlob(&mockWriteBlobStream{reqs: []*pb.WriteBlobRequest{blobSpec("complete", 60), blobChunk(0, make([]byte, 60))}}); err != nil {
+		t.Fatalf("WriteBlob(complete): unexpected err %+v", err)
+	}
+	now = now.Add(30 * time.Second)
+	if err := echo.WriteBlob(&mockWriteBlobStream{reqs: []*pb.WriteBlobRequest{blobSpec("abandoned", 40), blobChunk(0, make([]byte, 10))}}); err != nil {
+		t.Fatalf("WriteBlob(abandoned): unexpected err %+v", err)
+	}
+	if err := echo.WriteBlob(&mockWriteBlobStream{reqs: []*pb.WriteBlobRequest{blobSpec("new", 60)}}); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("WriteBlob(new) with full storage: want ResourceExhausted got %v", err)
+	}
+
+	// The complete blob expires first, which reclaims its storage.
+	now = now.Add(30 * time.Second)
+	if _, err := echo.GetWriteStatus(ctx, &pb.GetWriteStatusRequest{BlobId: "complete"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetWriteStatus(complete) after the TTL: want NotFound got %v", err)
+	}
+	if _, err := echo.GetWriteStatus(ctx, &pb.GetWriteStatusRequest{BlobId: "abandoned"}); err != nil {
+		t.Errorf("GetWriteStatus(abandoned) within the TTL: unexpected err %+v", err)
+	}
+	if err := echo.WriteBlob(&mockWriteBlobStream{reqs: []*pb.WriteBlobRequest{blobSpec("new", 60)}}); err != nil {
+		t.Errorf("WriteBlob(new) after the complete blob expired: unexpected err %+v", err)
+	}
+
+	// Once the abandoned blob expires too, only the new blob holds storage.
+	now = now.Add(30 * time.Second)
+	if err := echo.WriteBlob(&mockWriteBlobStream{reqs: []*pb.WriteBlobRequest{blobSpec("other", 40)}}); err != nil {
+		t.Errorf("WriteBlob(other) after the abandoned blob expired: unexpected err %+v", err)
+	}
+	if got := echo.blobs.reserved; got != 100 {
+		t.Errorf("WriteBlob: want 100 bytes reserved got %d", got)
+	}
+}
+
+func TestGetWriteStatus_invalid(t *testing.T) {
+	server := NewEchoServer()
+	_, err := server.GetWriteStatus(context.Background(), &pb.GetWriteStatusRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetWriteStatus: want InvalidArgument got %v", err)
+	}
+	_, err = server.GetWriteStatus(context.Background(), &pb.GetWriteStatusRequest{BlobId: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetWriteStatus: want NotFound got %v", err)
+	}
+}
+
+type mockExpandStream struct {
+	exp []string
+	t   *testing.T
+	pb.Echo_ExpandServer
+}
+
+func (m *mockExpandStream) Send(resp *pb.EchoResponse) error {
+	if resp.GetContent() != m.exp[0] {
+		m.t.Errorf("Expand expected to send %s but sent %s", m.exp[0], resp.GetContent())
+	}
+	m.exp = m.exp[1:]
+	return nil
+}
+
+func (m *mockExpandStream) Context() context.Context {
+	return context.Background()
+}
+
+func (m *mockExpandStream) SetTrailer(metadata.MD) {}
+
+func (m *mockExpandStream) verify() {
+	if len(m.exp) > 0 {
+		m.t.Errorf("Exand did not stream all expected values. %d expected values remaining.", len(m.exp))
+	}
+}
+
+func TestExpand(t *testing.T) {
+	contentTable := []string{"Hello World", "Hola", ""}
+	errTable := []*spb.Status{
+		{Code: int32(codes.OK)},
+		{Code: int32(codes.InvalidArgument)},
+		nil,
+	}
+
+	server := NewEchoServer()
+	for _, c := range contentTable {
+		for _, e := range errTable {
+			stream := &mockExpandStream{exp: strings.Fields(c), t: t}
+			err := server.Expand(&pb.ExpandRequest{Content: c, Error: e}, stream)
+			status, _ := status.FromError(err)
+			if int32(status.Code()) != e.GetCode() {
+				t.Errorf("Expand expected stream to return status with code %d but code %d", status.Code(), e.GetCode())
+			}
+			stream.verify()
+		}
+	}
+}
+
+// collectingExpandStream records the responses and trailers sent on it.
+type collectingExpandStream struct {
+	sent    []*pb.EchoResponse
+	trailer metadata.MD
+	pb.Echo_ExpandServer
+}
+
+func (m *collectingExpandStream) Send(resp *pb.EchoResponse) error {
+	m.sent = append(m.sent, resp)
+	return nil
+}
+
+func (m *collectingExpandStream) Context() context.Context {
+	return context.Background()
+}
+
+func (m *collectingExpandStream) SetTrailer(md metadata.MD) {
+	m.trailer = metadata.Join(m.trailer, md)
+}
+
+func TestExpand_withSummary(t *testing.T) {
+	for _, e := range []*spb.Status{nil, {Code: int32(codes.Aborted)}} {
+		stream := &collectingExpandStream{}
+		err := NewEchoServer().Expand(&pb.ExpandRequest{Content: "a bb a", RepeatCount: 2, Error: e, WithSummary: true}, stream)
+		if status.Code(err) != codes.Code(e.GetCode()) {
+			t.Errorf("Expand with error %v: want code %d got %v", e, e.GetCode(), err)
+		}
+		// The words, then the summary, each sent before any error.
+		if len(stream.sent) != 7 {
+			t.Fatalf("Expand: want 6 words and a summary got %v", stream.sent)
+		}
+		want := crc32.Checksum([]byte("a\nbb\na\na\nbb\na\n"), crc32.MakeTable(crc32.Castagnoli))
+		for i, resp := range stream.sent[:6] {
+			if resp.GetIndex() != int64(i+1) || resp.GetIsSummary() {
+				t.Errorf("Expand: want word %d to have index %d got %v", i, i+1, resp)
+			}
+		}
+		summary := stream.sent[6]
+		if !summary.GetIsSummary() || summary.GetMessageCount() != 6 || summary.GetChecksum() != want || summary.GetIndex() != 0 {
+			t.Errorf("Expand: want a summary of 6 words with checksum %d got %v", want, summary)
+		}
+	}
+}
+
+func TestExpand_withoutSummary(t *testing.T) {
+	stream := &collectingExpandStream{}
+	if err := NewEchoServer().Expand(&pb.ExpandRequest{Content: "a b"}, stream); err != nil {
+		t.Fatal(err)
+	}
+	for _, resp := range stream.sent {
+		if resp.GetIndex() != 0 || resp.GetIsSummary() {
+			t.Errorf("Expand without with_summary: want plain words got %v", resp)
+		}
+	}
+	if len(stream.sent) != 2 {
+		t.Errorf("Expand without with_summary: want 2 words got %v", stream.sent)
+	}
+}
+
+func TestExpand_summaryOfNothing(t *testing.T) {
+	stream := &collectingExpandStream{}
+	if err := NewEchoServer().Expand(&pb.ExpandRequest{WithSummary: true}, stream); err != nil {
+		t.Fatal(err)
+	}
+	if len(stream.sent) != 1 || !proto.Equal(stream.sent[0], &pb.EchoResponse{IsSummary: true}) {
+		t.Errorf("Expand of no words: want an empty summary got %v", stream.sent)
+	}
+}
+
+func TestExpand_corpus(t *testing.T) {
+	corpora := server.NewCorpusStore()
+	if err := corpora.Create(server.DefaultNamespace, "greetings", []string{"hello", "hi"}); err != nil {
+		t.Fatal(err)
+	}
+	echo := &echoServerImpl{corpora: corpora}
+
+	tests := []struct {
+		repeats int32
+		want    []string
+	}{
+		{0, []string{"hello", "hi"}},
+		{1, []string{"hello", "hi"}},
+		{3, []string{"hello", "hi", "hello", "hi", "hello", "hi"}},
+	}
+	for _, test := range tests {
+		stream := &mockExpandStream{exp: test.want, t: t}
+		err := echo.Expand(&pb.ExpandRequest{CorpusName: "greetings", RepeatCount: test.repeats}, stream)
+		if err != nil {
+			t.Errorf("Expand with %d repeats: unexpected err %+v", test.repeats, err)
+		}
+		stream.verify()
+	}
+
+	// Repeats apply to content as well.
+	stream := &mockExpandStream{exp: []string{"a", "b", "a", "b"}, t: t}
+	if err := echo.Expand(&pb.ExpandRequest{Content: "a b", RepeatCount: 2}, stream); err != nil {
+		t.Errorf("Expand of content with repeats: unexpected err %+v", err)
+	}
+	stream.verify()
+}
+
+func TestExpand_corpusInvalid(t *testing.T) {
+	corpora := server.NewCorpusStore()
+	if err := corpora.Create(server.DefaultNamespace, "greetings", []string{"hello"}); err != nil {
+		t.Fatal(err)
+	}
+	echo := &echoServerImpl{corpora: corpora}
+
+	tests := []struct {
+		req  *pb.ExpandRequest
+		code codes.Code
+	}{
+		{&pb.ExpandRequest{CorpusName: "missing"}, codes.NotFound},
+		{&pb.ExpandRequest{CorpusName: "greetings", Content: "hello"}, codes.InvalidArgument},
+		{&pb.ExpandRequest{CorpusName: "greetings", RepeatCount: -1}, codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		stream := &mockExpandStream{t: t}
+		if err := echo.Expand(test.req, stream); status.Code(err) != test.code {
+			t.Errorf("Expand(%v): want %s got %v", test.req, test.code, err)
+		}
+	}
+}
+
+// deletingExpandStream deletes a corpus when the first message is sent.
+type deletingExpandStream struct {
+	mockExpandStream
+	delete func()
+}
+
+func (m *deletingExpandStream) Send(resp *pb.EchoResponse) error {
+	if m.delete != nil {
+		m.delete()
+		m.delete = nil
+	}
+	return m.mockExpandStream.Send(resp)
+}
+
+// eventExpandStream logs the messages sent on an Expand stream.
+type eventExpandStream struct {
+	ctx    context.Context
+	events *[]string
+	pb.Echo_ExpandServer
+}
+
+func (m *eventExpandStream) Context() context.Context {
+	return m.ctx
+}
+
+func (m *eventExpandStream) SetTrailer(metadata.MD) {}
+
+func (m *eventExpandStream) Send(resp *pb.EchoResponse) error {
+	if resp.GetIsHeartbeat() {
+		*m.events = append(*m.events, "heartbeat")
+	} else if resp.GetIsSummary() {
+		*m.events = append(*m.events, "summary")
+	} else {
+		*m.events = append(*m.events, resp.GetContent())
+	}
+	return nil
+}
+
+func TestExpand_heartbeats(t *testing.T) {
+	tests := []struct {
+		delay, interval time.Duration
+		want            []string
+	}{
+		{
+			25 * time.Second,
+			10 * time.Second,
+			[]string{
+				"wait 10s", "heartbeat", "wait 10s", "heartbeat", "wait 5s", "a",
+				"wait 10s", "heartbeat", "wait 10s", "heartbeat", "wait 5s", "b",
+			},
+		},
+		{
+			// No heartbeat is due as the word is sent.
+			20 * time.Second,
+			10 * time.Second,
+			[]string{"wait 10s", "heartbeat", "wait 10s", "a", "wait 10s", "heartbeat", "wait 10s", "b"},
+		},
+		{
+			5 * time.Second,
+			10 * time.Second,
+			[]string{"wait 5s", "a", "wait 5s", "b"},
+		},
+		{
+			5 * time.Second,
+			0,
+			[]string{"wait 5s", "a", "wait 5s", "b"},
+		},
+		{
+			0,
+			time.Second,
+			[]string{"a", "b"},
+		},
+	}
+	for _, test := range tests {
+		events := []string{}
+		echo := &echoServerImpl{
+			afterF: func(d time.Duration) <-chan time.Time {
+				events = append(events, fmt.Sprintf("wait %s", d))
+				c := make(chan time.Time, 1)
+				c <- time.Time{}
+				return c
+			},
+		}
+		req := &pb.ExpandRequest{
+			Content:           "a b",
+			MessageDelay:      ptypes.DurationProto(test.delay),
+			HeartbeatInterval: ptypes.DurationProto(test.interval),
+		}
+		stream := &eventExpandStream{ctx: context.Background(), events: &events}
+		if err := echo.Expand(req, stream); err != nil {
+			t.Errorf("Expand(%s, %s): unexpected err %+v", test.delay, test.interval, err)
+		}
+		if fmt.Sprint(events) != fmt.Sprint(test.want) {
+			t.Errorf("Expand(%s, %s):\n want %v\n got  %v", test.delay, test.interval, test.want, events)
+		}
+	}
+}
+
+func TestExpand_slowStart(t *testing.T) {
+	tests := []struct {
+		burst             int32
+		trickle, interval time.Duration
+		repeats           int32
+		want              []string
+	}{
+		{2, 10 * time.Second, 0, 0, []string{"a", "b", "wait 10s", "c", "wait 10s", "d"}},
+		{0, 10 * time.Second, 0, 0, []string{"wait 10s", "a", "wait 10s", "b", "wait 10s", "c", "wait 10s", "d"}},
+		{4, 10 * time.Second, 0, 0, []string{"a", "b", "c", "d"}},
+		{9, 10 * time.Second, 0, 0, []string{"a", "b", "c", "d"}},
+		// The burst runs across repeats.
+		{5, 10 * time.Second, 0, 2, []string{"a", "b", "c", "d", "a", "wait 10s", "b", "wait 10s", "c", "wait 10s", "d"}},
+		{3, 0, 0, 0, []string{"a", "b", "c", "d"}},
+		{
+			3,
+			25 * time.Second,
+			10 * time.Second,
+			0,
+			[]string{"a", "b", "c", "wait 10s", "heartbeat", "wait 10s", "heartbeat", "wait 5s", "d"},
+		},
+	}
+	for _, test := range tests {
+		events := []string{}
+		echo := &echoServerImpl{
+			afterF: func(d time.Duration) <-chan time.Time {
+				events = append(events, fmt.Sprintf("wait %s", d))
+				c := make(chan time.Time, 1)
+				c <- time.Time{}
+				return c
+			},
+		}
+		req := &pb.ExpandRequest{
+			Content:         "a b c d",
+			BurstCount:      test.burst,
+			TrickleInterval: ptypes.DurationProto(test.trickle),
+			RepeatCount:     test.repeats,
+			WithSummary:     true,
+		}
+		if test.interval > 0 {
+			req.HeartbeatInterval = ptypes.DurationProto(test.interval)
+		}
+		stream := &eventExpandStream{ctx: context.Background(), events: &events}
+		if err := echo.Expand(req, stream); err != nil {
+			t.Errorf("Expand(%d, %s): unexpected err %+v", test.burst, test.trickle, err)
+		}
+		// The summary follows the last word at once, and counts every word.
+		want := append(test.want, "summary")
+		if fmt.Sprint(events) != fmt.Sprint(want) {
+			t.Errorf("Expand(%d, %s):\n want %v\n got  %v", test.burst, test.trickle, want, events)
+		}
+	}
+}
+
+func TestExpand_slowStartCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	events := []string{}
+	echo := &echoServerImpl{
+		afterF: func(d time.Duration) <-chan time.Time {
+			// Cancel while the first trickle runs.
+			cancel()
+			return make(chan time.Time)
+		},
+	}
+	req := &pb.ExpandRequest{
+		Content:         "a b c",
+		BurstCount:      1,
+		TrickleInterval: ptypes.DurationProto(time.Hour),
+	}
+	err := echo.Expand(req, &eventExpandStream{ctx: ctx, events: &events})
+	if status.Code(err) != codes.Canceled {
+		t.Errorf("Expand: want Canceled got %v", err)
+	}
+	if want := []string{"a"}; fmt.Sprint(events) != fmt.Sprint(want) {
+		t.Errorf("Expand: want %v before the cancellation got %v", want, events)
+	}
+}
+
+func TestExpand_slowStartInvalid(t *testing.T) {
+	tests := []struct {
+		req    *pb.ExpandRequest
+		field  string
+		reason string
+	}{
+		{&pb.ExpandRequest{Content: "a", BurstCount: -1, TrickleInterval: ptypes.DurationProto(time.Second)}, "burst_count", showcaseerrors.FieldOutOfRange},
+		{&pb.ExpandRequest{Content: "a", BurstCount: 2}, "burst_count", showcaseerrors.FieldConflict},
+		{&pb.ExpandRequest{Content: "a", TrickleInterval: ptypes.DurationProto(-time.Second)}, "trickle_interval", showcaseerrors.FieldOutOfRange},
+		{
+			&pb.ExpandRequest{Content: "a", TrickleInterval: ptypes.DurationProto(time.Second), MessageDelay: ptypes.DurationProto(time.Second)},
+			"trickle_interval",
+			showcaseerrors.FieldConflict,
+		},
+	}
+	for _, test := range tests {
+		err := (&echoServerImpl{}).Expand(test.req, &mockExpandStream{t: t})
+		st, _ := status.FromError(err)
+		details := st.Proto().GetDetails()
+		if st.Code() != codes.InvalidArgument || len(details) != 1 {
+			t.Errorf("Expand(%v): want InvalidArgument with an ErrorInfo got %v", test.req, err)
+			continue
+		}
+		if reason, _, md := decodeErrorInfo(t, details[0].GetValue()); reason != test.reason || md["field"] != test.field {
+			t.Errorf("Expand(%v): want %s on %s got %s on %s", test.req, test.reason, test.field, reason, md["field"])
+		}
+	}
+}
+
+func TestExpand_heartbeatsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	events := []string{}
+	waits := 0
+	echo := &echoServerImpl{
+		afterF: func(d time.Duration) <-chan time.Time {
+			c := make(chan time.Time, 1)
+			// Fire the first two timers, then cancel while the third runs.
+			if waits++; waits <= 2 {
+				c <- time.Time{}
+			} else {
+				cancel()
+			}
+			return c
+		},
+	}
+	req := &pb.ExpandRequest{
+		Content:           "a b",
+		MessageDelay:      ptypes.DurationProto(20 * time.Second),
+		HeartbeatInterval: ptypes.DurationProto(10 * time.Second),
+	}
+	err := echo.Expand(req, &eventExpandStream{ctx: ctx, events: &events})
+	if status.Code(err) != codes.Canceled {
+		t.Errorf("Expand: want Canceled got %v", err)
+	}
+	if want := []string{"heartbeat", "a"}; fmt.Sprint(events) != fmt.Sprint(want) {
+		t.Errorf("Expand: want %v before the cancellation got %v", want, events)
+	}
+}
+
+func TestExpand_invalidDelays(t *testing.T) {
+	echo := &echoServerImpl{}
+	reqs := []*pb.ExpandRequest{
+		{Content: "a", MessageDelay: ptypes.DurationProto(-time.Second)},
+		{Content: "a", HeartbeatInterval: ptypes.DurationProto(-time.Second)},
+	}
+	for _, req := range reqs {
+		if err := echo.Expand(req, &mockExpandStream{t: t}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expand(%v): want InvalidArgument got %v", req, err)
+		}
+	}
+}
+
+func TestExpand_corpusDeletedMidStream(t *testing.T) {
+	corpora := server.NewCorpusStore()
+	ts := &testingServerImpl{corpora: corpora}
+	echo := &echoServerImpl{corpora: corpora}
+	if _, err := ts.CreateEchoCorpus(
+		context.Background(),
+		&pb.CreateEchoCorpusRequest{Name: "numbers", Words: []string{"one", "two", "three"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	stream := &deletingExpandStream{
+		mockExpandStream: mockExpandStream{exp: []string{"one", "two", "three", "one", "two", "three"}, t: t},
+		delete: func() {
+			if _, err := ts.DeleteEchoCorpus(context.Background(), &pb.DeleteEchoCorpusRequest{Name: "numbers"}); err != nil {
+				t.Errorf("DeleteEchoCorpus: unexpected err %+v", err)
+			}
+		},
+	}
+	if err := echo.Expand(&pb.ExpandRequest{CorpusName: "numbers", RepeatCount: 2}, stream); err != nil {
+		t.Errorf("Expand: unexpected err %+v", err)
+	}
+	stream.verify()
+
+	// Later calls no longer find the corpus.
+	err := echo.Expand(&pb.ExpandRequest{CorpusName: "numbers"}, &mockExpandStream{t: t})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expand of a deleted corpus: want NotFound got %v", err)
+	}
+}
+
+type errorExpandStream struct {
+	err error
+	pb.Echo_ExpandServer
+}
+
+func (s *errorExpandStream) Send(resp *pb.EchoResponse) error {
+	return s.err
+}
+
+func (s *errorExpandStream) SetTrailer(metadata.MD) {}
+
+func TestExpand_streamErr(t *testing.T) {
+	e := errors.New("Test Error")
+	stream := &errorExpandStream{err: e}
+	server := NewEchoServer()
+	err := server.Expand(&pb.ExpandRequest{Content: "Hello World"}, stream)
+	if e != err {
+		t.Error("Expand expected to pass through stream errors.")
+	}
+}
+
+type mockCollectStream struct {
+	reqs    []*pb.EchoRequest
+	exp     *string
+	t       *testing.T
+	trailer metadata.MD
+	sent    *pb.EchoResponse
+	pb.Echo_CollectServer
+}
+
+func (m *mockCollectStream) SetTrailer(md metadata.MD) {
+	m.trailer = metadata.Join(m.trailer, md)
+}
+
+func (m *mockCollectStream) SendAndClose(r *pb.EchoResponse) error {
+	if m.exp == nil {
+		m.t.Errorf("Collect Stream SendAndClose called unexpectedly")
+	}
+	if r.GetContent() != *m.exp {
+		m.t.Errorf("Collect expected to return '%s', but returned '%s'", *m.exp, r.GetContent())
+	}
+	m.sent = r
+	return nil
+}
+
+func (m *mockCollectStream) Context() context.Context {
+	return context.Background()
+}
+
+func (m *mockCollectStream) Recv() (*pb.EchoRequest, error) {
+	if len(m.reqs) > 0 {
+		ret := m.reqs[0]
+		m.reqs = m.reqs[1:]
+		return ret, nil
+	}
+	return nil, io.EOF
+}
+
+func TestCollect(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	tests := []struct {
+		reqs []string
+		exp  *string
+		err  *spb.Status
+	}{
+		{[]string{"Hello", "", "World"}, strPtr("Hello World"), nil},
+		{[]string{"Hello", "World"}, strPtr("Hello World"), &spb.Status{Code: int32(codes.OK)}},
+		{[]string{"Hello", "World"}, nil, &spb.Status{Code: int32(codes.InvalidArgument)}},
+		{[]string{}, nil, &spb.Status{Code: int32(codes.InvalidArgument)}},
+		{[]string{}, strPtr(""), nil},
+	}
+
+	server := NewEchoServer()
+	for _, test := range tests {
+		reqs := []*pb.EchoRequest{}
+		for _, req := range test.reqs {
+			reqs = append(reqs, &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: req}})
+		}
+		if test.err != nil {
+			reqs = append(reqs, &pb.EchoRequest{Response: &pb.EchoRequest_Error{Error: test.err}})
+		}
+		mockStream := &mockCollectStream{reqs: reqs, exp: test.exp, t: t}
+		err := server.Collect(mockStream)
+		expCode := status.FromProto(test.err).Code()
+		s, _ := status.FromError(err)
+		if expCode != s.Code() {
+			t.Errorf("Collect expected to return with code %d, but returned %d", expCode, s.Code())
+		}
+	}
+}
+
+func TestCollect_flush(t *testing.T) {
+	content := func(c string) *pb.EchoRequest {
+		return &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: c}}
+	}
+	flush := func(c string) *pb.EchoRequest {
+		return &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: c}, Flush: true}
+	}
+	tests := []struct {
+		name          string
+		reqs          []*pb.EchoRequest
+		want          string
+		wantDiscarded []string
+	}{
+		{"no flush", []*pb.EchoRequest{content("Hello"), content("World")}, "Hello World", nil},
+		{"flush", []*pb.EchoRequest{content("Hello"), flush("World"), content("Late"), content("")}, "Hello World", []string{"2"}},
+		{"first message", []*pb.EchoRequest{flush(""), content("Late")}, "", []string{"1"}},
+		{"last message", []*pb.EchoRequest{content("Hello"), flush("")}, "Hello", []string{"0"}},
+		{"flushes", []*pb.EchoRequest{flush("Hello"), flush("World")}, "Hello", []string{"1"}},
+	}
+	for _, test := range tests {
+		stream := &mockCollectStream{reqs: test.reqs, exp: &test.want, t: t}
+		if err := NewEchoServer().Collect(stream); err != nil {
+			t.Errorf("Collect(%s): %v", test.name, err)
+		}
+		if got := stream.trailer.Get("showcase-collect-discarded"); !reflect.DeepEqual(got, test.wantDiscarded) {
+			t.Errorf("Collect(%s): want discarded trailer %v got %v", test.name, test.wantDiscarded, got)
+		}
+	}
+}
+
+func TestCollect_flushThenError(t *testing.T) {
+	// Errors requested after the flush are discarded like any other message.
+	reqs := []*pb.EchoRequest{
+		{Response: &pb.EchoRequest_Content{Content: "Hello"}, Flush: true},
+		{Response: &pb.EchoRequest_Error{Error: &spb.Status{Code: int32(codes.InvalidArgument)}}},
+	}
+	want := "Hello"
+	stream := &mockCollectStream{reqs: reqs, exp: &want, t: t}
+	if err := NewEchoServer().Collect(stream); err != nil {
+		t.Errorf("Collect: want the flushed response got %v", err)
+	}
+}
+
+type errorCollectStream struct {
+	err error
+	pb.Echo_CollectServer
+}
+
+func (s *errorCollectStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *errorCollectStream) Recv() (*pb.EchoRequest, error) {
+	return nil, s.err
+}
+
+func TestCollect_streamErr(t *testing.T) {
+	e := errors.New("Test Error")
+	stream := &errorCollectStream{err: e}
+	server := NewEchoServer()
+	err := server.Collect(stream)
+	if e != err {
+		t.Error("Expand expected to pass through stream errors.")
+	}
+}
+
+func TestCollect_contentTooLong(t *testing.T) {
+	chunk := strings.Repeat("a", int(server.DefaultSettings().MaxCollectContentBytes/4))
+	reqs := []*pb.EchoRequest{}
+	for i := 0; i < 5; i++ {
+		reqs = append(reqs, &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: chunk}})
+	}
+	stream := &mockCollectStream{reqs: reqs, t: t}
+	err := NewEchoServer().Collect(stream)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Collect: want ResourceExhausted for too much content, got %v", err)
+	}
+}
+
+// countingStream signals on received each time a message is received.
+type countingStream struct {
+	grpc.ServerStream
+	received chan struct{}
+}
+
+func (s *countingStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received <- struct{}{}
+	}
+	return err
+}
+
+func TestCollect_clientDisconnect(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	received := make(chan struct{}, 2)
+	exited := make(chan error, 1)
+	echo := NewEchoServer().(*echoServerImpl)
+	echo.metrics = server.NewMetrics()
+	s := grpc.NewServer(grpc.StreamInterceptor(
+		func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			err := handler(srv, &countingStream{ServerStream: ss, received: received})
+			exited <- err
+			return err
+		}))
+	pb.RegisterEchoServer(s, echo)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	var conn net.Conn
+	client, err := grpc.Dial(
+		lis.Addr().String(),
+		grpc.WithInsecure(),
+		grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+			c, err := net.DialTimeout("tcp", addr, timeout)
+			conn = c
+			return c, err
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	stream, err := pb.NewEchoClient(client).Collect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, word := range []string{"Hello", "World"} {
+		if err := stream.Send(&pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: word}}); err != nil {
+			t.Fatal(err)
+		}
+		<-received
+	}
+
+	// Kill the connection without half-closing the stream.
+	conn.Close()
+
+	select {
+	case err := <-exited:
+		if err != context.Canceled {
+			t.Errorf("Collect: want context.Canceled after the client disconnected, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Collect did not return after the client disconnected")
+	}
+	if n := echo.metrics.Get(AbandonedCollectsMetric); n != 1 {
+		t.Errorf("Collect: want 1 abandoned stream, got %d", n)
+	}
+}
+
+type mockChatStream struct {
+	reqs  []*pb.EchoRequest
+	curr  *pb.EchoRequest
+	resps []*pb.EchoResponse
+	t     *testing.T
+	pb.Echo_ChatServer
+}
+
+func (m *mockChatStream) Recv() (*pb.EchoRequest, error) {
+	if len(m.reqs) > 0 {
+		m.curr = m.reqs[0]
+		m.reqs = m.reqs[1:]
+		return m.curr, nil
+	}
+	return nil, io.EOF
+}
+
+func (m *mockChatStream) Context() context.Context {
+	return context.Background()
+}
+
+func (m *mockChatStream) Send(r *pb.EchoResponse) error {
+	m.resps = append(m.resps, r)
+	if m.curr == nil {
+		m.t.Errorf("Chat unexpectedly tried to send content.")
+	}
+	if r.GetContent() != m.curr.GetContent() {
+		m.t.Errorf("Chat expected to send content %s, but sent %s", m.curr.GetContent(), r.GetContent())
+		m.curr = nil
+	}
+	return nil
+}
+
+func TestChat(t *testing.T) {
+	tests := []struct {
+		reqs []string
+		err  *spb.Status
+	}{
+		{[]string{"Hello", "World"}, nil},
+		{[]string{"Hello", "World"}, &spb.Status{Code: int32(codes.InvalidArgument)}},
+		{[]string{}, &spb.Status{Code: int32(codes.InvalidArgument)}},
+	}
+
+	server := NewEchoServer()
+	for _, test := range tests {
+		reqs := []*pb.EchoRequest{}
+		for _, req := range test.reqs {
+			reqs = append(reqs, &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: req}})
+		}
+		if test.err != nil {
+			reqs = append(reqs, &pb.EchoRequest{Response: &pb.EchoRequest_Error{Error: test.err}})
+		}
+
+		mockStream := &mockChatStream{reqs: reqs, t: t}
+		err := server.Chat(mockStream)
+		expCode := status.FromProto(test.err).Code()
+		s, _ := status.FromError(err)
+		if expCode != s.Code() {
+			t.Errorf("Chat expected to return status with code %d, but returned %d", expCode, s.Code())
+		}
+	}
+}
+
+type errorChatStream struct {
+	err error
+	pb.Echo_ChatServer
+}
+
+func (s *errorChatStream) Recv() (*pb.EchoRequest, error) {
+	return nil, s.err
+}
+
+func (s *errorChatStream) Context() context.Context {
+	return context.Background()
+}
+
+func TestChat_streamErr(t *testing.T) {
+	e := errors.New("Test Error")
+	stream := &errorChatStream{err: e}
+	server := NewEchoServer()
+	err := server.Chat(stream)
+	if e != err {
+		t.Error("Expand expected to pass through stream errors.")
+	}
+}
+
+// idleChatStream is a Chat stream whose messages are supplied by the test.
+// Each call to Recv is signalled on recvCalls.
+type idleChatStream struct {
+	recvs     chan chatRecv
+	recvCalls chan struct{}
+	sent      chan string
+	pb.Echo_ChatServer
+}
+
+func newIdleChatStream() *idleChatStream {
+	return &idleChatStream{
+		recvs:     make(chan chatRecv),
+		recvCalls: make(chan struct{}, 10),
+		sent:      make(chan string, 10),
+	}
+}
+
+func (m *idleChatStream) Recv() (*pb.EchoRequest, error) {
+	m.recvCalls <- struct{}{}
+	r := <-m.recvs
+	return r.req, r.err
+}
+
+func (m *idleChatStream) Context() context.Context {
+	return context.Background()
+}
+
+func (m *idleChatStream) Send(r *pb.EchoResponse) error {
+	m.sent <- r.GetContent()
+	return nil
+}
+
+func (m *idleChatStream) send(content string) {
+	m.recvs <- chatRecv{req: &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: content}}}
+}
+
+// startIdleChat starts a Chat with an idle timeout of 10s on a server whose
+// timers are created on the returned channel and fired by the test.
+func startIdleChat(t *testing.T) (*idleChatStream, chan chan time.Time, chan error) {
+	timers := make(chan chan time.Time, 10)
+	server := &echoServerImpl{
+		sequence: server.NewSequence(),
+		afterF: func(d time.Duration) <-chan time.Time {
+			if d != 10*time.Second {
+				t.Errorf("Chat: want a 10s idle timer got %s", d)
+			}
+			c := make(chan time.Time, 1)
+			timers <- c
+			return c
+		},
+	}
+	stream := newIdleChatStream()
+	result := make(chan error, 1)
+	go func() { result <- server.Chat(stream) }()
+
+	<-stream.recvCalls
+	stream.recvs <- chatRecv{req: &pb.EchoRequest{
+		Response:    &pb.EchoRequest_Content{Content: "first"},
+		IdleTimeout: ptypes.DurationProto(10 * time.Second),
+	}}
+	if got := <-stream.sent; got != "first" {
+		t.Errorf("Chat: want first got %s", got)
+	}
+	return stream, timers, result
+}
+
+func TestChat_idleTimeout(t *testing.T) {
+	stream, timers, result := startIdleChat(t)
+
+	(<-timers) <- time.Time{}
+	if got := <-stream.sent; !strings.HasPrefix(got, "Closing the stream") {
+		t.Errorf("Chat: want a final informational response got %q", got)
+	}
+
+	st := status.Convert(<-result)
+	if st.Code() != codes.Aborted {
+		t.Fatalf("Chat: want Aborted got %v", st.Err())
+	}
+	details := st.Proto().GetDetails()
+	if len(details) != 1 || details[0].GetTypeUrl() != "type.googleapis.com/google.rpc.ErrorInfo" {
+		t.Fatalf("Chat: want an ErrorInfo detail got %v", details)
+	}
+	reason, domain, _ := decodeErrorInfo(t, details[0].GetValue())
+	if reason != "IDLE_TIMEOUT" || domain != "showcase.googleapis.com" {
+		t.Errorf("Chat: want reason IDLE_TIMEOUT got %q in %q", reason, domain)
+	}
+}
+
+func TestChat_idleTimeoutResets(t *testing.T) {
+	stream, timers, result := startIdleChat(t)
+
+	first := <-timers
+	<-stream.recvCalls
+	stream.send("second")
+	if got := <-stream.sent; got != "second" {
+		t.Errorf("Chat: want second got %s", got)
+	}
+	second := <-timers
+
+	// The timer from before the second message no longer applies.
+	first <- time.Time{}
+	<-stream.recvCalls
+	stream.send("third")
+	if got := <-stream.sent; got != "third" {
+		t.Errorf("Chat: want third got %s", got)
+	}
+	second <- time.Time{}
+	(<-timers) <- time.Time{}
+
+	if got := <-stream.sent; !strings.HasPrefix(got, "Closing the stream") {
+		t.Errorf("Chat: want a final informational response got %q", got)
+	}
+	if err := <-result; status.Code(err) != codes.Aborted {
+		t.Errorf("Chat: want Aborted got %v", err)
+	}
+}
+
+func TestChat_idleTimeoutBoundary(t *testing.T) {
+	stream, timers, result := startIdleChat(t)
+
+	timer := <-timers
+	<-stream.recvCalls
+	stream.send("boundary")
+	// Once Recv is called again, the message is waiting to be handled when the
+	// timer fires.
+	<-stream.recvCalls
+	timer <- time.Time{}
+
+	if got := <-stream.sent; got != "boundary" {
+		t.Errorf("Chat: want the message at the boundary to be honored, got %q", got)
+	}
+	stream.recvs <- chatRecv{err: io.EOF}
+	if err := <-result; err != nil {
+		t.Errorf("Chat: unexpected err %+v", err)
+	}
+}
+
+func TestChat_invalidIdleTimeout(t *testing.T) {
+	stream := &mockChatStream{
+		reqs: []*pb.EchoRequest{{
+			Response:    &pb.EchoRequest_Content{Content: "hi"},
+			IdleTimeout: ptypes.DurationProto(-time.Second),
+		}},
+		t: t,
+	}
+	if err := NewEchoServer().Chat(stream); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Chat: want InvalidArgument got %v", err)
+	}
+}
+
+// ackChatStream replays scripted messages and records every response and the
+// trailer.
+type ackChatStream struct {
+	ctx     context.Context
+	reqs    []*pb.EchoRequest
+	resps   []*pb.EchoResponse
+	trailer metadata.MD
+	pb.Echo_ChatServer
+}
+
+func (m *ackChatStream) Context() context.Context {
+	if m.ctx == nil {
+		return context.Background()
+	}
+	return m.ctx
+}
+
+func (m *ackChatStream) Recv() (*pb.EchoRequest, error) {
+	if len(m.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := m.reqs[0]
+	m.reqs = m.reqs[1:]
+	return req, nil
+}
+
+func (m *ackChatStream) Send(r *pb.EchoResponse) error {
+	m.resps = append(m.resps, r)
+	return nil
+}
+
+func (m *ackChatStream) SetTrailer(md metadata.MD) {
+	m.trailer = metadata.Join(m.trailer, md)
+}
+
+func ackChatContent(content string) *pb.EchoRequest {
+	return &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: content}}
+}
+
+func ackChatControl(acked, nacked []int64) *pb.EchoRequest {
+	return &pb.EchoRequest{Ack: &pb.ChatAck{AckedSequences: acked, NackedSequences: nacked}}
+}
+
+func checkChatTrailer(t *testing.T, md metadata.MD, unacked, resent string) {
+	t.Helper()
+	if got := md.Get(chatUnackedTrailer); len(got) != 1 || got[0] != unacked {
+		t.Errorf("Chat: want %s %s got %v", chatUnackedTrailer, unacked, got)
+	}
+	if got := md.Get(chatResentTrailer); len(got) != 1 || got[0] != resent {
+		t.Errorf("Chat: want %s %s got %v", chatResentTrailer, resent, got)
+	}
+}
+
+func TestChat_ackMode(t *testing.T) {
+	first := ackChatContent("a")
+	first.AckMode = true
+	stream := &ackChatStream{reqs: []*pb.EchoRequest{
+		first,
+		ackChatContent("b"),
+		ackChatControl([]int64{1, 2}, nil),
+		ackChatControl([]int64{1}, nil),
+		ackChatContent("c"),
+	}}
+	if err := NewEchoServer().Chat(stream); err != nil {
+		t.Fatalf("Chat: unexpected err %+v", err)
+	}
+
+	var got []string
+	for _, r := range stream.resps {
+		got = append(got, fmt.Sprintf("%s:%d:%d", r.GetContent(), r.GetAckSequence(), r.GetResendCount()))
+	}
+	if want := []string{"a:1:0", "b:2:0", "c:3:0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Chat: want %v got %v", want, got)
+	}
+	checkChatTrailer(t, stream.trailer, "1", "0")
+}
+
+func TestChat_ackModeNack(t *testing.T) {
+	first := ackChatContent("a")
+	first.AckMode = true
+	stream := &ackChatStream{reqs: []*pb.EchoRequest{
+		first,
+		ackChatContent("b"),
+		ackChatControl([]int64{1}, []int64{2}),
+		ackChatControl([]int64{2}, nil),
+	}}
+	if err := NewEchoServer().Chat(stream); err != nil {
+		t.Fatalf("Chat: unexpected err %+v", err)
+	}
+
+	if len(stream.resps) != 3 {
+		t.Fatalf("Chat: want 3 responses got %v", stream.resps)
+	}
+	resent := stream.resps[2]
+	if resent.GetContent() != "b" || resent.GetAckSequence() != 2 || resent.GetResendCount() != 1 {
+		t.Errorf("Chat: want b sent again as sequence 2 got %v", resent)
+	}
+	if original := stream.resps[1]; original.GetResendCount() != 0 {
+		t.Errorf("Chat: sending a response again changed the original %v", original)
+	}
+	checkChatTrailer(t, stream.trailer, "0", "1")
+}
+
+func TestChat_ackModeResendLimit(t *testing.T) {
+	first := ackChatContent("a")
+	first.AckMode = true
+	first.MaxResends = 2
+	nack := ackChatControl(nil, []int64{1})
+	stream := &ackChatStream{reqs: []*pb.EchoRequest{first, nack, nack, nack, ackChatContent("never")}}
+
+	st := status.Convert(NewEchoServer().Chat(stream))
+	if st.Code() != codes.DataLoss {
+		t.Fatalf("Chat: want DataLoss got %v", st.Err())
+	}
+	details := st.Proto().GetDetails()
+	if len(details) != 1 {
+		t.Fatalf("Chat: want an ErrorInfo detail got %v", details)
+	}
+	if reason, _, md := decodeErrorInfo(t, details[0].GetValue()); reason != "RESEND_LIMIT_EXCEEDED" || md["ack_sequence"] != "1" {
+		t.Errorf("Chat: want a RESEND_LIMIT_EXCEEDED ErrorInfo for sequence 1 got %q %v", reason, md)
+	}
+	if len(stream.resps) != 3 {
+		t.Errorf("Chat: want the response sent 3 times got %v", stream.resps)
+	}
+	checkChatTrailer(t, stream.trailer, "1", "2")
+}
+
+func limitChatStream(maxReceived, maxSent int32, idle bool) *ackChatStream {
+	first := ackChatContent("a")
+	first.MaxReceivedMessages = maxReceived
+	first.MaxSentMessages = maxSent
+	if idle {
+		first.IdleTimeout = ptypes.DurationProto(time.Minute)
+	}
+	return &ackChatStream{reqs: []*pb.EchoRequest{first, ackChatContent("b"), ackChatContent("c"), ackChatContent("d")}}
+}
+
+func chatContents(resps []*pb.EchoResponse) []string {
+	var got []string
+	for _, r := range resps {
+		got = append(got, fmt.Sprintf("%s:%t", r.GetContent(), r.GetLimitReached()))
+	}
+	return got
+}
+
+func checkReceiveLimitReached(t *testing.T, err error, limit string) {
+	t.Helper()
+	st := status.Convert(err)
+	if st.Code() != codes.OutOfRange || len(st.Proto().GetDetails()) != 1 {
+		t.Fatalf("Chat: want OutOfRange with an ErrorInfo got %v", err)
+	}
+	if reason, _, md := decodeErrorInfo(t, st.Proto().GetDetails()[0].GetValue()); reason != "RECEIVE_LIMIT_REACHED" || md["max_received_messages"] != limit {
+		t.Errorf("Chat: want a RECEIVE_LIMIT_REACHED ErrorInfo for %s got %q %v", limit, reason, md)
+	}
+}
+
+func TestChat_maxReceivedMessages(t *testing.T) {
+	for _, idle := range []bool{false, true} {
+		stream := limitChatStream(2, 0, idle)
+		checkReceiveLimitReached(t, NewEchoServer().Chat(stream), "2")
+		want := []string{"a:false", "b:false", "Closing the stream after receiving 2 messages.:true"}
+		if got := chatContents(stream.resps); !reflect.DeepEqual(got, want) {
+			t.Errorf("Chat(idle=%t): want %v got %v", idle, want, got)
+		}
+		if got := stream.trailer.Get(chatDroppedTrailer); len(got) != 0 {
+			t.Errorf("Chat(idle=%t): want no %s trailer without a send limit got %v", idle, chatDroppedTrailer, got)
+		}
+		// The stream that reads ahead for its idle timeout may have read one
+		// more message.
+		if !idle && len(stream.reqs) != 2 {
+			t.Errorf("Chat: want reading stopped at the limit got %d messages left", len(stream.reqs))
+		}
+	}
+}
+
+func TestChat_maxSentMessages(t *testing.T) {
+	for _, idle := range []bool{false, true} {
+		stream := limitChatStream(0, 2, idle)
+		if err := NewEchoServer().Chat(stream); err != nil {
+			t.Fatalf("Chat(idle=%t): unexpected err %+v", idle, err)
+		}
+		if got, want := chatContents(stream.resps), []string{"a:false", "b:false"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Chat(idle=%t): want %v got %v", idle, want, got)
+		}
+		if got := stream.trailer.Get(chatDroppedTrailer); len(got) != 1 || got[0] != "2" {
+			t.Errorf("Chat(idle=%t): want %s 2 got %v", idle, chatDroppedTrailer, got)
+		}
+	}
+}
+
+func TestChat_messageLimits(t *testing.T) {
+	stream := limitChatStream(3, 1, false)
+	checkReceiveLimitReached(t, NewEchoServer().Chat(stream), "3")
+
+	// The notification is sent past the send limit.
+	want := []string{"a:false", "Closing the stream after receiving 3 messages.:true"}
+	if got := chatContents(stream.resps); !reflect.DeepEqual(got, want) {
+		t.Errorf("Chat: want %v got %v", want, got)
+	}
+	if got := stream.trailer.Get(chatDroppedTrailer); len(got) != 1 || got[0] != "2" {
+		t.Errorf("Chat: want %s 2 got %v", chatDroppedTrailer, got)
+	}
+	if len(stream.reqs) != 1 {
+		t.Errorf("Chat: want reading stopped at the limit got %d messages left", len(stream.reqs))
+	}
+}
+
+func TestChat_messageLimitsInvalid(t *testing.T) {
+	tests := []struct {
+		req   *pb.EchoRequest
+		field string
+	}{
+		{&pb.EchoRequest{MaxReceivedMessages: -1}, "max_received_messages"},
+		{&pb.EchoRequest{MaxReceivedMessages: maxChatMessageLimit + 1}, "max_received_messages"},
+		{&pb.EchoRequest{MaxSentMessages: -1, MaxReceivedMessages: -1}, "max_received_messages"},
+		{&pb.EchoRequest{MaxSentMessages: maxChatMessageLimit + 1}, "max_sent_messages"},
+	}
+	for _, test := range tests {
+		stream := &ackChatStream{reqs: []*pb.EchoRequest{test.req}}
+		err := NewEchoServer().Chat(stream)
+		st := status.Convert(err)
+		if st.Code() != codes.InvalidArgument || !strings.Contains(st.Message(), test.field) {
+			t.Errorf("Chat(%v): want InvalidArgument on %s got %v", test.req, test.field, err)
+		}
+		if len(stream.resps) != 0 {
+			t.Errorf("Chat(%v): want no responses got %v", test.req, stream.resps)
+		}
+	}
+}
+
+func TestChat_ackModeInvalid(t *testing.T) {
+	ackMode := func(req *pb.EchoRequest) *pb.EchoRequest {
+		req.AckMode = true
+		return req
+	}
+	tests := []struct {
+		name string
+		reqs []*pb.EchoRequest
+	}{
+		{"ack without ack mode", []*pb.EchoRequest{ackChatContent("a"), ackChatControl([]int64{1}, nil)}},
+		{"max resends without ack mode", []*pb.EchoRequest{{MaxResends: 1}}},
+		{"negative max resends", []*pb.EchoRequest{{AckMode: true, MaxResends: -1}}},
+		{"ack of an unsent response", []*pb.EchoRequest{ackMode(ackChatContent("a")), ackChatControl([]int64{2}, nil)}},
+		{"nack of an unsent response", []*pb.EchoRequest{ackMode(ackChatContent("a")), ackChatControl(nil, []int64{0})}},
+		{"nack of an acked response", []*pb.EchoRequest{ackMode(ackChatContent("a")), ackChatControl([]int64{1}, []int64{1})}},
+	}
+	for _, test := range tests {
+		stream := &ackChatStream{reqs: test.reqs}
+		if err := NewEchoServer().Chat(stream); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Chat with %s: want InvalidArgument got %v", test.name, err)
+		}
+	}
+}
+
+func handshakeContext(value string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(chatHandshakeHeader, value))
+}
+
+func TestChat_handshake(t *testing.T) {
+	server := NewEchoServer()
+	sessions := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		stream := &ackChatStream{
+			ctx:  handshakeContext("true"),
+			reqs: []*pb.EchoRequest{ackChatContent("a"), ackChatContent("b")},
+		}
+		if err := server.Chat(stream); err != nil {
+			t.Fatalf("Chat with a handshake: %v", err)
+		}
+		if len(stream.resps) != 3 {
+			t.Fatalf("Chat with a handshake: want the handshake and 2 echoes got %v", stream.resps)
+		}
+		handshake := stream.resps[0]
+		id := handshake.GetSessionId()
+		if id == "" || !proto.Equal(handshake, &pb.EchoResponse{SessionId: id}) {
+			t.Errorf("Chat with a handshake: want a handshake with only a session ID first got %v", handshake)
+		}
+		for _, resp := range stream.resps[1:] {
+			if resp.GetSessionId() != id {
+				t.Errorf("Chat with a handshake: want echoes of session %q got %v", id, resp)
+			}
+		}
+		if sessions[id] {
+			t.Errorf("Chat with a handshake: want a new session ID got %q again", id)
+		}
+		sessions[id] = true
+	}
+}
+
+func TestChat_handshakeFirst(t *testing.T) {
+	// The handshake is sent before the first message is read.
+	stream := newIdleChatStream()
+	handshakes := make(chan *pb.EchoResponse, 1)
+	done := make(chan error)
+	go func() {
+		done <- NewEchoServer().Chat(&handshakeChatStream{idleChatStream: stream, handshakes: handshakes})
+	}()
+	select {
+	case <-stream.recvCalls:
+		t.Fatal("Chat with a handshake: want the handshake before the first Recv")
+	case resp := <-handshakes:
+		if resp.GetSessionId() == "" {
+			t.Errorf("Chat with a handshake: want a session ID got %v", resp)
+		}
+	}
+	<-stream.recvCalls
+	stream.recvs <- chatRecv{err: io.EOF}
+	if err := <-done; err != nil {
+		t.Errorf("Chat with a handshake: %v", err)
+	}
+}
+
+// handshakeChatStream is an idleChatStream that asks for a handshake, which
+// it passes to the test.
+type handshakeChatStream struct {
+	*idleChatStream
+	handshakes chan *pb.EchoResponse
+}
+
+func (m *handshakeChatStream) Context() context.Context {
+	return handshakeContext("true")
+}
+
+func (m *handshakeChatStream) Send(r *pb.EchoResponse) error {
+	if r.GetContent() == "" {
+		m.handshakes <- r
+		return nil
+	}
+	return m.idleChatStream.Send(r)
+}
+
+func TestChat_noHandshake(t *testing.T) {
+	stream := &ackChatStream{ctx: handshakeContext("false"), reqs: []*pb.EchoRequest{ackChatContent("a")}}
+	if err := NewEchoServer().Chat(stream); err != nil {
+		t.Fatalf("Chat without a handshake: %v", err)
+	}
+	if len(stream.resps) != 1 || stream.resps[0].GetSessionId() != "" {
+		t.Errorf("Chat without a handshake: want one echo without a session ID got %v", stream.resps)
+	}
+
+	stream = &ackChatStream{ctx: handshakeContext("yes")}
+	if err := NewEchoServer().Chat(stream); status.Code(err) != codes.InvalidArgument || len(stream.resps) != 0 {
+		t.Errorf("Chat with an invalid %s: want InvalidArgument and no handshake got %v", chatHandshakeHeader, err)
+	}
+}
+
+func decodeErrorInfo(t *testing.T, b []byte) (reason, domain string, md map[string]string) {
+	// Every field of an ErrorInfo, and of its metadata entries, is
+	// length-delimited.
+	decodeFields := func(b []byte) map[uint64][]string {
+		values := map[uint64][]string{}
+		for len(b) > 0 {
+			key, n := proto.DecodeVarint(b)
+			length, m := proto.DecodeVarint(b[n:])
+			if n == 0 || m == 0 || uint64(len(b)-n-m) < length {
+				t.Fatalf("malformed ErrorInfo %q", b)
+			}
+			b = b[n+m:]
+			values[key>>3] = append(values[key>>3], string(b[:length]))
+			b = b[length:]
+		}
+		return values
+	}
+	values := decodeFields(b)
+	md = map[string]string{}
+	for _, entry := range values[3] {
+		kv := decodeFields([]byte(entry))
+		md[kv[1][0]] = kv[2][0]
+	}
+	return values[1][0], values[2][0], md
+}
+
+func TestPagedExpand_invalidArgs(t *testing.T) {
+	tests := []*pb.PagedExpandRequest{
+		{PageSize: -1},
+		{PageToken: "-1"},
+		{PageToken: "BOGUS"},
+		{Content: "one", PageToken: "1"},
+		{Content: "one", PageToken: "2"},
+	}
+	server := NewEchoServer()
+	for _, in := range tests {
+		_, err := server.PagedExpand(context.Background(), in)
+		s, _ := status.FromError(err)
+		if s.Code() != codes.InvalidArgument {
+			t.Errorf("PagedExpand() expected error code: %d, got error code %d",
+				codes.InvalidArgument, s.Code())
+		}
+	}
+}
+
+func TestPagedExpand(t *testing.T) {
+	tests := []struct {
+		in  *pb.PagedExpandRequest
+		out *pb.PagedExpandResponse
+	}{
+		{
+			&pb.PagedExpandRequest{Content: "Hello world!"},
+			&pb.PagedExpandResponse{
+				Responses: []*pb.EchoResponse{
+					&pb.EchoResponse{Content: "Hello"},
+					&pb.EchoResponse{Content: "world!"},
+				},
+			},
+		},
+		{
+			&pb.PagedExpandRequest{PageSize: 3, Content: "Hello world!"},
+			&pb.PagedExpandResponse{
+				Responses: []*pb.EchoResponse{
+					&pb.EchoResponse{Content: "Hello"},
+					&pb.EchoResponse{Content: "world!"},
+				},
+			},
+		},
+		{
+			&pb.PagedExpandRequest{
+				PageSize: 3,
+				Content:  "The rain in Spain falls mainly on the plain!",
+			},
+			&pb.PagedExpandResponse{
+				Responses: []*pb.EchoResponse{
+					&pb.EchoResponse{Content: "The"},
+					&pb.EchoResponse{Content: "rain"},
+					&pb.EchoResponse{Content: "in"},
+				},
+				NextPageToken: "3",
+			},
+		},
+		{
+			&pb.PagedExpandRequest{
+				PageSize:  3,
+				PageToken: "3",
+				Content:   "The rain in Spain falls mainly on the plain!",
+			},
+			&pb.PagedExpandResponse{
+				Responses: []*pb.EchoResponse{
+					&pb.EchoResponse{Content: "Spain"},
+					&pb.EchoResponse{Content: "falls"},
+					&pb.EchoResponse{Content: "mainly"},
+				},
+				NextPageToken: "6",
+			},
+		},
+	}
+
+	server := NewEchoServer()
+	for _, test := range tests {
+		out, err := server.PagedExpand(context.Background(), test.in)
+		if err != nil {
+			t.Error(err)
+		}
+		if !proto.Equal(test.out, out) {
+			t.Errorf("PagedExpand with input '%q', expected: '%q', got: %q",
+				test.in.String(), test.out.String(), out.String())
+		}
+	}
+}
+
+func TestWait(t *testing.T) {
+	endTime, _ := ptypes.TimestampProto(time.Now())
+	req := &pb.WaitRequest{End: &pb.WaitRequest_EndTime{EndTime: endTime}}
+	waiter := &mockWaiter{}
+	server := &echoServerImpl{waiter: waiter}
+	server.Wait(context.Background(), req)
+	if !proto.Equal(waiter.req, req) {
+		t.Error("Expected echo.Wait to defer to waiter.")
+	}
+}
+
+func TestWait_operationID(t *testing.T) {
+	echo := &echoServerImpl{
+		waiter:       server.GetWaiterInstance(),
+		operationIDs: server.NewOperationIDStore(10),
+	}
+	ctx := server.WithNamespace(context.Background(), "a")
+	req := func(content string) *pb.WaitRequest {
+		return &pb.WaitRequest{
+			End:         &pb.WaitRequest_Ttl{Ttl: ptypes.DurationProto(time.Hour)},
+			Response:    &pb.WaitRequest_Success{Success: &pb.WaitResponse{Content: content}},
+			OperationId: "op",
+		}
+	}
+
+	first, err := echo.Wait(ctx, req("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The retry resolves its ttl later, which would name another operation.
+	retry, err := echo.Wait(ctx, req("hello"))
+	if err != nil || retry.GetName() != first.GetName() {
+		t.Errorf("Wait retried with the same operation_id: want %q got %v %v", first.GetName(), retry, err)
+	}
+
+	_, err = echo.Wait(ctx, req("goodbye"))
+	details := status.Convert(err).Proto().GetDetails()
+	if status.Code(err) != codes.AlreadyExists || len(details) != 1 {
+		t.Fatalf("Wait with a reused operation_id: want AlreadyExists with an ErrorInfo got %v", err)
+	}
+	if reason, _, md := decodeErrorInfo(t, details[0].GetValue()); reason != "OPERATION_ID_REUSED" || md["operation_id"] != "op" || md["operation"] != first.GetName() {
+		t.Errorf("Wait with a reused operation_id: want an OPERATION_ID_REUSED ErrorInfo got %q %v", reason, md)
+	}
+
+	other, err := echo.Wait(server.WithNamespace(context.Background(), "b"), req("goodbye"))
+	if err != nil || other.GetName() == first.GetName() {
+		t.Errorf("Wait with the operation_id in another namespace: want a new operation got %v %v", other, err)
+	}
+
+	// Without an ID, every call starts an operation.
+	in := req("hello")
+	in.OperationId = ""
+	if op, err := echo.Wait(ctx, in); err != nil || op.GetName() == first.GetName() {
+		t.Errorf("Wait without an operation_id: want a new operation got %v %v", op, err)
+	}
+}
+
+// detailedFailure asks for one of each detail type, out of order.
+func detailedFailure() *pb.FailEchoWithDetailsRequest {
+	return &pb.FailEchoWithDetailsRequest{
+		Error: &spb.Status{Code: int32(codes.FailedPrecondition), Message: "Nope."},
+		Details: []pb.FailEchoWithDetailsRequest_DetailType{
+			pb.FailEchoWithDetailsRequest_PRECONDITION_FAILURE,
+			pb.FailEchoWithDetailsRequest_LOCALIZED_MESSAGE,
+			pb.FailEchoWithDetailsRequest_HELP,
+			pb.FailEchoWithDetailsRequest_QUOTA_FAILURE,
+		},
+		Locale:           "ja-JP",
+		LocalizedMessage: "失敗しました。",
+		Links: []*errdetails.Help_Link{
+			{Description: "Docs", Url: "https://example.com/docs"},
+		},
+		QuotaViolations: []*errdetails.QuotaFailure_Violation{
+			{Subject: "project:showcase", Description: "Quota exceeded."},
+		},
+		PreconditionViolations: []*errdetails.PreconditionFailure_Violation{
+			{Type: "TOS", Subject: "users/ekko", Description: "Conditions générales non acceptées."},
+		},
+	}
+}
+
+func TestFailEchoWithDetails(t *testing.T) {
+	in := detailedFailure()
+	server := NewEchoServer()
+	out, err := server.FailEchoWithDetails(context.Background(), in)
+	if out != nil {
+		t.Error("FailEchoWithDetails returned a non-nil response proto.")
+	}
+	s, _ := status.FromError(err)
+	if s.Code() != codes.FailedPrecondition || s.Message() != "Nope." {
+		t.Errorf("FailEchoWithDetails returned unexpected status %v", s.Proto())
+	}
+
+	expected := []proto.Message{
+		&errdetails.PreconditionFailure{Violations: in.GetPreconditionViolations()},
+		&errdetails.LocalizedMessage{Locale: "ja-JP", Message: "失敗しました。"},
+		&errdetails.Help{Links: in.GetLinks()},
+		&errdetails.QuotaFailure{Violations: in.GetQuotaViolations()},
+	}
+	details := s.Details()
+	if len(details) != len(expected) {
+		t.Fatalf("FailEchoWithDetails expected %d details, got %d", len(expected), len(details))
+	}
+	for i, d := range details {
+		got, ok := d.(proto.Message)
+		if !ok {
+			t.Fatalf("FailEchoWithDetails detail %d could not be unpacked: %v", i, d)
+		}
+		if !proto.Equal(got, expected[i]) {
+			t.Errorf("FailEchoWithDetails detail %d: expected %v, got %v", i, expected[i], got)
+		}
+	}
+}
+
+func TestFailEchoWithDetails_client(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	pb.RegisterEchoServer(s, NewEchoServer())
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	in := detailedFailure()
+	_, err = pb.NewEchoClient(conn).FailEchoWithDetails(context.Background(), in)
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.FailedPrecondition || st.Message() != "Nope." {
+		t.Fatalf("FailEchoWithDetails: want FailedPrecondition \"Nope.\" got %v", err)
+	}
+	details := st.Proto().GetDetails()
+	if len(details) != 4 {
+		t.Fatalf("FailEchoWithDetails: want 4 details got %d", len(details))
+	}
+
+	precondition := &errdetails.PreconditionFailure{}
+	if err := ptypes.UnmarshalAny(details[0], precondition); err != nil {
+		t.Errorf("FailEchoWithDetails: want a PreconditionFailure first got %v", err)
+	} else if !proto.Equal(precondition, &errdetails.PreconditionFailure{Violations: in.GetPreconditionViolations()}) {
+		t.Errorf("FailEchoWithDetails: unexpected PreconditionFailure %v", precondition)
+	}
+	localized := &errdetails.LocalizedMessage{}
+	if err := ptypes.UnmarshalAny(details[1], localized); err != nil {
+		t.Errorf("FailEchoWithDetails: want a LocalizedMessage second got %v", err)
+	} else if localized.GetLocale() != "ja-JP" || localized.GetMessage() != "失敗しました。" {
+		t.Errorf("FailEchoWithDetails: unexpected LocalizedMessage %v", localized)
+	}
+	help := &errdetails.Help{}
+	if err := ptypes.UnmarshalAny(details[2], help); err != nil {
+		t.Errorf("FailEchoWithDetails: want a Help third got %v", err)
+	} else if !proto.Equal(help, &errdetails.Help{Links: in.GetLinks()}) {
+		t.Errorf("FailEchoWithDetails: unexpected Help %v", help)
+	}
+	quota := &errdetails.QuotaFailure{}
+	if err := ptypes.UnmarshalAny(details[3], quota); err != nil {
+		t.Errorf("FailEchoWithDetails: want a QuotaFailure fourth got %v", err)
+	} else if !proto.Equal(quota, &errdetails.QuotaFailure{Violations: in.GetQuotaViolations()}) {
+		t.Errorf("FailEchoWithDetails: unexpected QuotaFailure %v", quota)
+	}
+}
+
+func TestFailEchoWithDetails_noDetails(t *testing.T) {
+	in := &pb.FailEchoWithDetailsRequest{
+		Error: &spb.Status{Code: int32(codes.Aborted), Message: "Bare."},
+	}
+
+	server := NewEchoServer()
+	_, err := server.FailEchoWithDetails(context.Background(), in)
+	s, _ := status.FromError(err)
+	if s.Code() != codes.Aborted || s.Message() != "Bare." {
+		t.Errorf("FailEchoWithDetails returned unexpected status %v", s.Proto())
+	}
+	if len(s.Details()) != 0 {
+		t.Errorf("FailEchoWithDetails expected no details, got %v", s.Details())
+	}
+}
+
+func TestFailEchoWithDetails_invalidArgs(t *testing.T) {
+	tests := []*pb.FailEchoWithDetailsRequest{
+		{},
+		{Error: &spb.Status{Code: int32(codes.OK)}},
+		{
+			Error: &spb.Status{Code: int32(codes.Internal)},
+			Details: []pb.FailEchoWithDetailsRequest_DetailType{
+				pb.FailEchoWithDetailsRequest_DETAIL_TYPE_UNSPECIFIED,
+			},
+		},
+	}
+
+	server := NewEchoServer()
+	for _, in := range tests {
+		_, err := server.FailEchoWithDetails(context.Background(), in)
+		s, _ := status.FromError(err)
+		if s.Code() != codes.InvalidArgument {
+			t.Errorf("FailEchoWithDetails(%v) expected error code %d, got %d", in, codes.InvalidArgument, s.Code())
+		}
+	}
+}
+
+func TestReturnStatus(t *testing.T) {
+	echo := NewEchoServer()
+	for c := codes.Canceled; c <= codes.Unauthenticated; c++ {
+		for _, withDetails := range []bool{false, true} {
+			in := &pb.ReturnStatusRequest{Code: int32(c), Message: "Status " + c.String(), WithDetails: withDetails}
+			out, err := echo.ReturnStatus(context.Background(), in)
+			st := status.Convert(err)
+			if out != nil || st.Code() != c || st.Message() != in.GetMessage() {
+				t.Errorf("ReturnStatus(%v): want %v with message %q, got %v, %v", in, c, in.GetMessage(), out, st.Proto())
+				continue
+			}
+			details := st.Proto().GetDetails()
+			if !withDetails {
+				if len(details) != 0 {
+					t.Errorf("ReturnStatus(%v): want no details, got %v", in, details)
+				}
+				continue
+			}
+			if len(details) != 2 {
+				t.Errorf("ReturnStatus(%v): want an ErrorInfo and a LocalizedMessage, got %v", in, details)
+				continue
+			}
+			reason, _, md := decodeErrorInfo(t, details[0].GetValue())
+			if want := code.Code(c).String(); reason != want || md["code"] != strconv.Itoa(int(c)) {
+				t.Errorf("ReturnStatus(%v): want the reason %s, got %s %v", in, want, reason, md)
+			}
+			var localized errdetails.LocalizedMessage
+			if err := ptypes.UnmarshalAny(details[1], &localized); err != nil {
+				t.Fatal(err)
+			}
+			if localized.GetLocale() != "en" || localized.GetMessage() != in.GetMessage() {
+				t.Errorf("ReturnStatus(%v): want the message in en, got %v", in, localized)
+			}
+		}
+	}
+}
+
+func TestReturnStatus_locale(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("accept-language", "fr, ja;q=0.8"))
+	_, err := NewEchoServer().ReturnStatus(ctx, &pb.ReturnStatusRequest{Code: int32(codes.NotFound), WithDetails: true})
+	var localized errdetails.LocalizedMessage
+	if err := ptypes.UnmarshalAny(status.Convert(err).Proto().GetDetails()[1], &localized); err != nil {
+		t.Fatal(err)
+	}
+	if localized.GetLocale() != "ja" {
+		t.Errorf("ReturnStatus: want the negotiated locale ja, got %q", localized.GetLocale())
+	}
+}
+
+func TestReturnStatus_invalid(t *testing.T) {
+	tests := []*pb.ReturnStatusRequest{
+		{Code: int32(codes.OK)},
+		{Code: -1},
+		{Code: 17},
+	}
+	echo := NewEchoServer()
+	for _, in := range tests {
+		if _, err := echo.ReturnStatus(context.Background(), in); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ReturnStatus(%v): want InvalidArgument, got %v", in, err)
+		}
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("accept-language", "en;q=2"))
+	if _, err := echo.ReturnStatus(ctx, &pb.ReturnStatusRequest{Code: 5, WithDetails: true}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ReturnStatus with an invalid accept-language: want InvalidArgument, got %v", err)
+	}
+}
+
+func TestStreamStatus(t *testing.T) {
+	echo := NewEchoServer()
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		stream := &mockExpandStream{exp: []string{"0", "1", "2"}, t: t}
+		err := echo.StreamStatus(&pb.StreamStatusRequest{Code: int32(c), Message: "Done.", WithDetails: true, MessageCount: 3}, stream)
+		stream.verify()
+		if st := status.Convert(err); st.Code() != c {
+			t.Errorf("StreamStatus: want %v, got %v", c, st.Proto())
+		} else if want := map[bool]int{true: 0, false: 2}[c == codes.OK]; len(st.Proto().GetDetails()) != want {
+			t.Errorf("StreamStatus(%v): want %d details, got %v", c, want, st.Proto().GetDetails())
+		}
+	}
+}
+
+func TestStreamStatus_invalid(t *testing.T) {
+	tests := []*pb.StreamStatusRequest{
+		{Code: -1},
+		{Code: 17},
+		{MessageCount: -1},
+		{MessageCount: maxStatusStreamMessages + 1},
+	}
+	echo := NewEchoServer()
+	for _, in := range tests {
+		if err := echo.StreamStatus(in, &mockExpandStream{t: t}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("StreamStatus(%v): want InvalidArgument, got %v", in, err)
+		}
+	}
+}
+
+func TestWait_invalidPollQuota(t *testing.T) {
+	echo := &echoServerImpl{waiter: server.GetWaiterInstance()}
+	tests := []*pb.PollQuota{
+		{},
+		{MaxPolls: -1},
+		{MaxPolls: 1, ReplenishInterval: ptypes.DurationProto(-time.Second)},
+	}
+	for _, quota := range tests {
+		_, err := echo.Wait(context.Background(), &pb.WaitRequest{PollQuota: quota})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Wait with poll quota %v: want InvalidArgument got %v", quota, err)
+		}
+	}
+	quota := &pb.PollQuota{MaxPolls: 1, ReplenishInterval: ptypes.DurationProto(time.Second)}
+	if _, err := echo.Wait(context.Background(), &pb.WaitRequest{PollQuota: quota}); err != nil {
+		t.Errorf("Wait with poll quota %v: unexpected err %+v", quota, err)
+	}
+}
+
+func TestWait_partialResults(t *testing.T) {
+	echo := &echoServerImpl{waiter: server.GetWaiterInstance()}
+	offset := func(d time.Duration) *pb.PartialResult {
+		return &pb.PartialResult{
+			Available: &pb.PartialResult_Offset{Offset: ptypes.DurationProto(d)},
+			Response:  &pb.WaitResponse{Content: d.String()},
+		}
+	}
+	at := func(sec int64) *pb.PartialResult {
+		return &pb.PartialResult{
+			Available: &pb.PartialResult_AvailableTime{AvailableTime: &timestamp.Timestamp{Seconds: sec}},
+			Response:  &pb.WaitResponse{Content: "at"},
+		}
+	}
+	ttl := func(d time.Duration) *pb.WaitRequest_Ttl {
+		return &pb.WaitRequest_Ttl{Ttl: ptypes.DurationProto(d)}
+	}
+	tests := []struct {
+		name     string
+		req      *pb.WaitRequest
+		wantCode codes.Code
+	}{
+		{"in order", &pb.WaitRequest{End: ttl(time.Hour), PartialResults: []*pb.PartialResult{offset(time.Second), offset(time.Minute)}}, codes.OK},
+		{"times in order", &pb.WaitRequest{
+			End:            &pb.WaitRequest_EndTime{EndTime: &timestamp.Timestamp{Seconds: 100}},
+			PartialResults: []*pb.PartialResult{at(10), at(20)},
+		}, codes.OK},
+		{"out of order", &pb.WaitRequest{End: ttl(time.Hour), PartialResults: []*pb.PartialResult{offset(time.Minute), offset(time.Second)}}, codes.InvalidArgument},
+		{"repeated offset", &pb.WaitRequest{End: ttl(time.Hour), PartialResults: []*pb.PartialResult{offset(time.Second), offset(time.Second)}}, codes.InvalidArgument},
+		{"negative offset", &pb.WaitRequest{End: ttl(time.Hour), PartialResults: []*pb.PartialResult{offset(-time.Second)}}, codes.InvalidArgument},
+		{"after the end", &pb.WaitRequest{End: ttl(time.Second), PartialResults: []*pb.PartialResult{offset(time.Minute)}}, codes.InvalidArgument},
+		{"mixed", &pb.WaitRequest{End: ttl(time.Hour), PartialResults: []*pb.PartialResult{offset(time.Second), at(20)}}, codes.InvalidArgument},
+		{"no response", &pb.WaitRequest{End: ttl(time.Hour), PartialResults: []*pb.PartialResult{{Available: offset(0).Available}}}, codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		if _, err := echo.Wait(context.Background(), test.req); status.Code(err) != test.wantCode {
+			t.Errorf("Wait(%s): want %s, got %v", test.name, test.wantCode, err)
+		}
+	}
+}
+
+type mockOperationUpdatesStream struct {
+	ctx    context.Context
+	sent   []*lropb.Operation
+	onSend func()
+	pb.Echo_StreamOperationUpdatesServer
+}
+
+func (m *mockOperationUpdatesStream) Send(op *lropb.Operation) error {
+	m.sent = append(m.sent, op)
+	if m.onSend != nil {
+		m.onSend()
+	}
+	return nil
+}
+
+func (m *mockOperationUpdatesStream) Context() context.Context {
+	return m.ctx
+}
+
+// partialCounts returns the partial counts of the pending operations sent,
+// and "done" for a done one.
+func (m *mockOperationUpdatesStream) partialCounts(t *testing.T) []string {
+	var counts []string
+	for _, op := range m.sent {
+		if op.GetDone() {
+			counts = append(counts, "done")
+			continue
+		}
+		var md pb.WaitMetadata
+		if err := ptypes.UnmarshalAny(op.GetMetadata(), &md); err != nil {
+			t.Fatal(err)
+		}
+		counts = append(counts, fmt.Sprint(md.GetPartialCount()))
+	}
+	return counts
+}
+
+// newOperationUpdatesServer returns an echo server whose clock starts at
+// start, and jumps to the next of the times each time it waits.
+func newOperationUpdatesServer(start time.Time, times ...time.Time) (echo *echoServerImpl, now *time.Time, waits *[]time.Duration) {
+	now = &start
+	waits = &[]time.Duration{}
+	nowF := func() time.Time { return *now }
+	echo = &echoServerImpl{
+		waiter:            server.NewWaiter(nowF, nil),
+		nowF:              nowF,
+		settings:          server.NewSettingsStore(server.DefaultSettings()),
+		operationWatchers: server.NewOperationWatchers(),
+		afterF: func(d time.Duration) <-chan time.Time {
+			*waits = append(*waits, d)
+			if len(times) == 0 {
+				return nil
+			}
+			*now, times = times[0], times[1:]
+			fired := make(chan time.Time, 1)
+			fired <- *now
+			return fired
+		},
+	}
+	return echo, now, waits
+}
+
+func startPartialWait(t *testing.T, echo *echoServerImpl) string {
+	partial := func(d time.Duration) *pb.PartialResult {
+		return &pb.PartialResult{
+			Available: &pb.PartialResult_Offset{Offset: ptypes.DurationProto(d)},
+			Response:  &pb.WaitResponse{Content: d.String()},
+		}
+	}
+	op, err := echo.Wait(context.Background(), &pb.WaitRequest{
+		End:            &pb.WaitRequest_Ttl{Ttl: ptypes.DurationProto(4 * time.Second)},
+		Response:       &pb.WaitRequest_Success{Success: &pb.WaitResponse{Content: "done"}},
+		PartialResults: []*pb.PartialResult{partial(time.Second), partial(2 * time.Second), partial(3 * time.Second)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return op.GetName()
+}
+
+func TestStreamOperationUpdates(t *testing.T) {
+	start := time.Unix(1000, 0)
+	echo, _, waits := newOperationUpdatesServer(start, start.Add(2500*time.Millisecond), start.Add(5*time.Second))
+	name := startPartialWait(t, echo)
+	stream := &mockOperationUpdatesStream{ctx: context.Background()}
+	if err := echo.StreamOperationUpdates(&pb.StreamOperationUpdatesRequest{Name: name}, stream); err != nil {
+		t.Fatal(err)
+	}
+	// The partial result at 1s is coalesced into the one at 2s, since both
+	// were available by the time the server woke.
+	if got, want := stream.partialCounts(t), []string{"0", "2", "done"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("StreamOperationUpdates: want updates %v, got %v", want, got)
+	}
+	if want := []time.Duration{time.Second + time.Millisecond, 500*time.Millisecond + time.Millisecond}; !reflect.DeepEqual(*waits, want) {
+		t.Errorf("StreamOperationUpdates: want waits %v, got %v", want, *waits)
+	}
+	var resp pb.WaitResponse
+	if err := ptypes.UnmarshalAny(stream.sent[2].GetResponse(), &resp); err != nil || resp.GetContent() != "done" {
+		t.Errorf("StreamOperationUpdates: want the done operation's response, got %v, %v", stream.sent[2], err)
+	}
+}
+
+func TestStreamOperationUpdates_slowClient(t *testing.T) {
+	start := time.Unix(1000, 0)
+	echo, now, waits := newOperationUpdatesServer(start, start.Add(5*time.Second))
+	name := startPartialWait(t, echo)
+	// The first send takes until two partial results are available.
+	stream := &mockOperationUpdatesStream{ctx: context.Background()}
+	stream.onSend = func() {
+		if len(stream.sent) == 1 {
+			*now = start.Add(2500 * time.Millisecond)
+		}
+	}
+	if err := echo.StreamOperationUpdates(&pb.StreamOperationUpdatesRequest{Name: name}, stream); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := stream.partialCounts(t), []string{"0", "2", "done"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("StreamOperationUpdates to a slow client: want updates %v, got %v", want, got)
+	}
+	if len(*waits) != 1 {
+		t.Errorf("StreamOperationUpdates to a slow client: want a single wait, got %v", *waits)
+	}
+}
+
+func TestStreamOperationUpdates_deleted(t *testing.T) {
+	start := time.Unix(1000, 0)
+	echo, _, _ := newOperationUpdatesServer(start)
+	ops := &operationsServerImpl{
+		pollRecorder: server.NewPollRecorder(time.Now),
+		pollLimiter:  server.NewPollLimiter(time.Now),
+		watchers:     echo.operationWatchers,
+	}
+	name := startPartialWait(t, echo)
+	errs := make(chan error)
+	stream := &mockOperationUpdatesStream{ctx: context.Background()}
+	go func() {
+		errs <- echo.StreamOperationUpdates(&pb.StreamOperationUpdatesRequest{Name: name}, stream)
+	}()
+	// The stream waits for its next partial result until the operation is
+	// deleted. It may not be watching yet, so the operation is deleted until
+	// the stream ends.
+	var err error
+	for done := false; !done; {
+		if _, err := ops.DeleteOperation(context.Background(), &lropb.DeleteOperationRequest{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case err = <-errs:
+			done = true
+		case <-time.After(time.Millisecond):
+		}
+	}
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("StreamOperationUpdates of a deleted operation: want NotFound, got %v", err)
+	}
+	details := status.Convert(err).Proto().GetDetails()
+	if reason, _, md := decodeErrorInfo(t, details[0].GetValue()); reason != "OPERATION_DELETED" || md["operation"] != name {
+		t.Errorf("StreamOperationUpdates of a deleted operation: want OPERATION_DELETED, got %s %v", reason, md)
+	}
+}
+
+func TestStreamOperationUpdates_invalid(t *testing.T) {
+	echo, _, _ := newOperationUpdatesServer(time.Unix(1000, 0))
+	tests := []struct {
+		name string
+		want codes.Code
+	}{
+		{"", codes.InvalidArgument},
+		{"operations/google.showcase.v1beta1.Messaging/SearchBlurbs/abc", codes.NotFound},
+		{"operations/unknown", codes.NotFound},
+	}
+	for _, test := range tests {
+		err := echo.StreamOperationUpdates(&pb.StreamOperationUpdatesRequest{Name: test.name}, &mockOperationUpdatesStream{ctx: context.Background()})
+		if status.Code(err) != test.want {
+			t.Errorf("StreamOperationUpdates(%q): want %v, got %v", test.name, test.want, err)
+		}
+	}
+}
+
+func TestEcho_repeatCount(t *testing.T) {
+	store := server.NewSettingsStore(server.DefaultSettings())
+	echo := &echoServerImpl{settings: store, sequence: server.NewSequence()}
+	in := &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hello"}, RepeatCount: 3}
+	resp, err := echo.Echo(context.Background(), in)
+	if err != nil {
+		t.Fatalf("Echo: unexpected err %+v", err)
+	}
+	if want := []string{"hello", "hello", "hello"}; !reflect.DeepEqual(resp.GetRepeatedContent(), want) {
+		t.Errorf("Echo: want repeated content %q got %q", want, resp.GetRepeatedContent())
+	}
+
+	in.RepeatCount = -1
+	if _, err := echo.Echo(context.Background(), in); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Echo with a negative repeat_count: want InvalidArgument got %v", err)
+	}
+}
+
+func TestEcho_repeatCountLimit(t *testing.T) {
+	content := strings.Repeat("x", 200)
+	// A response with one element is the content field, the element, and the
+	// server sequence.
+	resp := &pb.EchoResponse{Content: content, ServerSequence: 1, RepeatedContent: []string{content}}
+	single := int32(proto.Size(resp))
+	element := single - int32(proto.Size(&pb.EchoResponse{Content: content, ServerSequence: 1}))
+
+	settings := server.DefaultSettings()
+	settings.MaxSendMessageBytes = single + 9*element
+	echo := &echoServerImpl{settings: server.NewSettingsStore(settings), sequence: server.NewSequence()}
+
+	for _, count := range []int32{9, 10} {
+		in := &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: content}, RepeatCount: count}
+		resp, err := echo.Echo(context.Background(), in)
+		if err != nil {
+			t.Fatalf("Echo with repeat_count %d: unexpected err %+v", count, err)
+		}
+		if size := int32(proto.Size(resp)); size > settings.MaxSendMessageBytes {
+			t.Errorf("Echo with repeat_count %d: want at most %d bytes got %d", count, settings.MaxSendMessageBytes, size)
+		}
+	}
+
+	in := &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: content}, RepeatCount: 11}
+	_, err := echo.Echo(context.Background(), in)
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("Echo above the limit: want ResourceExhausted got %v", err)
+	}
+	details := st.Proto().GetDetails()
+	if len(details) != 1 || details[0].GetTypeUrl() != "type.googleapis.com/google.rpc.ErrorInfo" {
+		t.Fatalf("Echo above the limit: want an ErrorInfo detail got %v", details)
+	}
+	reason, _, md := decodeErrorInfo(t, details[0].GetValue())
+	if reason != "RESPONSE_TOO_LARGE" || md["suggested_method"] != "google.showcase.v1beta1.Echo/Expand" {
+		t.Errorf("Echo above the limit: want RESPONSE_TOO_LARGE suggesting Expand got %q %v", reason, md)
+	}
+
+	// The size is computed without building the response.
+	in.RepeatCount = math.MaxInt32
+	if _, err := echo.Echo(context.Background(), in); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Echo with a huge repeat_count: want ResourceExhausted got %v", err)
+	}
+}
+
+func TestRepeatedContentSize(t *testing.T) {
+	for _, content := range []string{"", "a", strings.Repeat("b", 127), strings.Repeat("c", 128), strings.Repeat("d", 20000)} {
+		for _, count := range []int32{1, 7, 300} {
+			resp := &pb.EchoResponse{Content: content, Locale: "en", ServerSequence: 12345}
+			got := repeatedContentSize(resp, content, count)
+			for i := int32(0); i < count; i++ {
+				resp.RepeatedContent = append(resp.RepeatedContent, content)
+			}
+			// The estimate is exact.
+			if want := int64(proto.Size(resp)); got != want {
+				t.Errorf("repeatedContentSize(%d bytes, %d): want %d got %d", len(content), count, want, got)
+			}
+		}
+	}
+}
+
+func TestEcho_echoAttempts(t *testing.T) {
+	settings := server.DefaultSettings()
+	settings.ClientAttemptHeader = "my-attempt"
+	echo := &echoServerImpl{settings: server.NewSettingsStore(settings), sequence: server.NewSequence()}
+	tests := []struct {
+		md               metadata.MD
+		previous, client int64
+	}{
+		{nil, 0, 0},
+		{metadata.Pairs(server.PreviousRPCAttemptsHeader, "2"), 2, 0},
+		{metadata.Pairs(server.PreviousRPCAttemptsHeader, "1", "my-attempt", "3"), 1, 3},
+	}
+	for _, test := range tests {
+		ctx := metadata.NewIncomingContext(context.Background(), test.md)
+		resp, err := echo.Echo(ctx, &pb.EchoRequest{EchoAttempts: true})
+		if err != nil {
+			t.Fatalf("Echo(%v): unexpected err %+v", test.md, err)
+		}
+		if resp.GetPreviousRpcAttempts() != test.previous || resp.GetClientAttempt() != test.client {
+			t.Errorf("Echo(%v): want attempts (%d, %d) got (%d, %d)",
+				test.md, test.previous, test.client, resp.GetPreviousRpcAttempts(), resp.GetClientAttempt())
+		}
+	}
+
+	// Attempts are echoed only when asked for.
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(server.PreviousRPCAttemptsHeader, "2"))
+	if resp, _ := echo.Echo(ctx, &pb.EchoRequest{}); resp.GetPreviousRpcAttempts() != 0 {
+		t.Errorf("Echo without echo_attempts: want no attempts got %d", resp.GetPreviousRpcAttempts())
+	}
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("my-attempt", "first"))
+	if _, err := echo.Echo(ctx, &pb.EchoRequest{EchoAttempts: true}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Echo with an invalid attempt: want InvalidArgument got %v", err)
+	}
+}
+
+func TestEchoResource_lifecycle(t *testing.T) {
+	for _, unavailable := range []bool{false, true} {
+		echo := &echoServerImpl{resources: server.NewEchoResourceStore()}
+		ctx := context.Background()
+		get := &pb.GetEchoResourceRequest{Name: "r", UnavailableUntilCreated: unavailable}
+		missing := codes.NotFound
+		if unavailable {
+			missing = codes.Unavailable
+		}
+
+		if _, err := echo.GetEchoResource(ctx, get); status.Code(err) != missing {
+			t.Errorf("GetEchoResource before Create (unavailable %t): want %s got %v", unavailable, missing, err)
+		}
+		if _, err := echo.CreateEchoResource(ctx, &pb.CreateEchoResourceRequest{Name: "r"}); err != nil {
+			t.Fatalf("CreateEchoResource: unexpected err %+v", err)
+		}
+		got, err := echo.GetEchoResource(ctx, get)
+		if err != nil || got.GetName() != "r" {
+			t.Errorf("GetEchoResource after Create (unavailable %t): want r got %v, %v", unavailable, got, err)
+		}
+		if _, err := echo.DeleteEchoResource(ctx, &pb.DeleteEchoResourceRequest{Name: "r"}); err != nil {
+			t.Fatalf("DeleteEchoResource: unexpected err %+v", err)
+		}
+		if _, err := echo.GetEchoResource(ctx, get); status.Code(err) != missing {
+			t.Errorf("GetEchoResource after Delete (unavailable %t): want %s got %v", unavailable, missing, err)
+		}
+	}
+}
+
+func TestEchoResource_invalid(t *testing.T) {
+	echo := &echoServerImpl{resources: server.NewEchoResourceStore()}
+	ctx := context.Background()
+	if _, err := echo.CreateEchoResource(ctx, &pb.CreateEchoResourceRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CreateEchoResource without a name: want InvalidArgument got %v", err)
+	}
+	if _, err := echo.GetEchoResource(ctx, &pb.GetEchoResourceRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetEchoResource without a name: want InvalidArgument got %v", err)
+	}
+	if _, err := echo.DeleteEchoResource(ctx, &pb.DeleteEchoResourceRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("DeleteEchoResource without a name: want InvalidArgument got %v", err)
+	}
+	if _, err := echo.DeleteEchoResource(ctx, &pb.DeleteEchoResourceRequest{Name: "r"}); status.Code(err) != codes.NotFound {
+		t.Errorf("DeleteEchoResource of a missing resource: want NotFound got %v", err)
+	}
+	echo.CreateEchoResource(ctx, &pb.CreateEchoResourceRequest{Name: "r"})
+	if _, err := echo.CreateEchoResource(ctx, &pb.CreateEchoResourceRequest{Name: "r"}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("CreateEchoResource of an existing resource: want AlreadyExists got %v", err)
+	}
+}
+
+func TestBatchEcho(t *testing.T) {
+	echo := &echoServerImpl{settings: server.NewSettingsStore(server.DefaultSettings()), sequence: server.NewSequence()}
+	in := &pb.BatchEchoRequest{Requests: []*pb.EchoRequest{
+		{Response: &pb.EchoRequest_Content{Content: "first"}},
+		{Response: &pb.EchoRequest_Error{Error: &spb.Status{Code: int32(codes.NotFound), Message: "second"}}},
+		{Response: &pb.EchoRequest_Content{Content: "third"}},
+		{Response: &pb.EchoRequest_Content{Content: "fourth"}, RepeatCount: -1},
+	}}
+	got, err := echo.BatchEcho(context.Background(), in)
+	if err != nil {
+		t.Fatalf("BatchEcho: unexpected err %+v", err)
+	}
+	if len(got.GetResults()) != 4 {
+		t.Fatalf("BatchEcho: want 4 results got %v", got.GetResults())
+	}
+	results := got.GetResults()
+	if results[0].GetResponse().GetContent() != "first" || results[2].GetResponse().GetContent() != "third" {
+		t.Errorf("BatchEcho: want the successes in request order got %v", results)
+	}
+	if e := results[1].GetError(); e.GetCode() != int32(codes.NotFound) || e.GetMessage() != "second" {
+		t.Errorf("BatchEcho: want the requested error second got %v", results[1])
+	}
+	if e := results[3].GetError(); e.GetCode() != int32(codes.InvalidArgument) {
+		t.Errorf("BatchEcho: want an InvalidArgument for the negative repeat_count got %v", results[3])
+	}
+}
+
+func TestBatchEcho_size(t *testing.T) {
+	settings := server.DefaultSettings()
+	settings.MaxBatchEchoSize = 2
+	echo := &echoServerImpl{settings: server.NewSettingsStore(settings), sequence: server.NewSequence()}
+
+	got, err := echo.BatchEcho(context.Background(), &pb.BatchEchoRequest{})
+	if err != nil || len(got.GetResults()) != 0 {
+		t.Errorf("BatchEcho of an empty batch: want no results got %v, %v", got, err)
+	}
+
+	req := &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}}
+	if _, err := echo.BatchEcho(context.Background(), &pb.BatchEchoRequest{Requests: []*pb.EchoRequest{req, req}}); err != nil {
+		t.Errorf("BatchEcho at the limit: unexpected err %+v", err)
+	}
+	_, err = echo.BatchEcho(context.Background(), &pb.BatchEchoRequest{Requests: []*pb.EchoRequest{req, req, req}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("BatchEcho over the limit: want InvalidArgument got %v", err)
+	}
+}
+
+func TestEcho_dedupe(t *testing.T) {
+	now := time.Unix(0, 0)
+	echo := &echoServerImpl{
+		settings: server.NewSettingsStore(server.DefaultSettings()),
+		sequence: server.NewSequence(),
+		dedupe:   server.NewDedupeCache(func() time.Time { return now }, 10),
+	}
+	request := func(content string) *pb.EchoRequest {
+		return &pb.EchoRequest{
+			Response:     &pb.EchoRequest_Content{Content: content},
+			DedupeWindow: ptypes.DurationProto(time.Minute),
+		}
+	}
+	ctx := context.Background()
+
+	first, err := echo.Echo(ctx, request("hi"))
+	if err != nil || first.GetServedFromCache() {
+		t.Fatalf("Echo: want a fresh response got %v, %v", first, err)
+	}
+	hit, err := echo.Echo(ctx, request("hi"))
+	if err != nil || !hit.GetServedFromCache() || hit.GetServerSequence() != first.GetServerSequence() {
+		t.Errorf("Echo of an identical request: want the cached response got %v, %v", hit, err)
+	}
+	// The window is not part of the request's identity.
+	wider := request("hi")
+	wider.DedupeWindow = ptypes.DurationProto(time.Hour)
+	if got, _ := echo.Echo(ctx, wider); !got.GetServedFromCache() {
+		t.Errorf("Echo with another window: want the cached response got %v", got)
+	}
+	if got, _ := echo.Echo(ctx, request("other")); got.GetServedFromCache() {
+		t.Errorf("Echo of different content: want a fresh response got %v", got)
+	}
+	if got, _ := echo.Echo(ctx, &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}}); got.GetServedFromCache() {
+		t.Errorf("Echo without a window: want a fresh response got %v", got)
+	}
+
+	now = now.Add(time.Minute)
+	if got, _ := echo.Echo(ctx, request("hi")); got.GetServedFromCache() || got.GetServerSequence() == first.GetServerSequence() {
+		t.Errorf("Echo after the window: want a fresh response got %v", got)
+	}
+}
+
+func TestEcho_dedupeErrors(t *testing.T) {
+	echo := &echoServerImpl{
+		settings: server.NewSettingsStore(server.DefaultSettings()),
+		sequence: server.NewSequence(),
+		dedupe:   server.NewDedupeCache(time.Now, 10),
+	}
+	in := &pb.EchoRequest{
+		Response:     &pb.EchoRequest_Content{Content: "hi"},
+		RepeatCount:  -1,
+		DedupeWindow: ptypes.DurationProto(time.Minute),
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := echo.Echo(context.Background(), in); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Echo of an invalid request: want InvalidArgument every time got %v", err)
+		}
+	}
+
+	in = &pb.EchoRequest{DedupeWindow: ptypes.DurationProto(-time.Second)}
+	if _, err := echo.Echo(context.Background(), in); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Echo with a negative dedupe_window: want InvalidArgument got %v", err)
+	}
+}
+
+func TestEcho_failFirstAttempts(t *testing.T) {
+	now := time.Unix(0, 0)
+	echo := &echoServerImpl{
+		settings: server.NewSettingsStore(server.DefaultSettings()),
+		sequence: server.NewSequence(),
+		attempts: server.NewAttemptCounter(func() time.Time { return now }, 10, time.Minute),
+	}
+	request := func(id, content string) *pb.EchoRequest {
+		return &pb.EchoRequest{
+			Response:          &pb.EchoRequest_Content{Content: content},
+			FailFirstAttempts: 2,
+			RequestId:         id,
+		}
+	}
+	ctx := context.Background()
+	expect := func(in *pb.EchoRequest, wantAttempt int64) {
+		t.Helper()
+		_, err := echo.Echo(ctx, in)
+		if wantAttempt == 0 {
+			if err != nil {
+				t.Errorf("Echo(%q, %q): want success got %v", in.GetRequestId(), in.GetContent(), err)
+			}
+			return
+		}
+		st, _ := status.FromError(err)
+		details := st.Proto().GetDetails()
+		if st.Code() != codes.Unavailable || len(details) != 1 {
+			t.Errorf("Echo(%q, %q): want Unavailable with an ErrorInfo got %v", in.GetRequestId(), in.GetContent(), err)
+			return
+		}
+		reason, _, md := decodeErrorInfo(t, details[0].GetValue())
+		if reason != "ATTEMPT_FAILED" || md["attempt"] != strconv.FormatInt(wantAttempt, 10) || md["fail_first_attempts"] != "2" {
+			t.Errorf("Echo(%q, %q): want ATTEMPT_FAILED for attempt %d got %s %v", in.GetRequestId(), in.GetContent(), wantAttempt, reason, md)
+		}
+	}
+
+	expect(request("a", "hi"), 1)
+	// Attempts of another request are counted apart.
+	expect(request("b", "hi"), 1)
+	// Attempts share a request ID whatever their content.
+	expect(request("a", "changed"), 2)
+	expect(request("a", "hi"), 0)
+	expect(request("a", "hi"), 0)
+	expect(request("b", "hi"), 2)
+
+	// Without a request ID, identical requests are attempts of one.
+	expect(request("", "x"), 1)
+	expect(request("", "y"), 1)
+	expect(request("", "x"), 2)
+	expect(request("", "x"), 0)
+
+	// A request not attempted for the ttl fails again.
+	now = now.Add(time.Minute)
+	expect(request("a", "hi"), 1)
+
+	in := request("c", "hi")
+	in.FailFirstAttempts = -1
+	if _, err := echo.Echo(ctx, in); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Echo with a negative fail_first_attempts: want InvalidArgument got %v", err)
+	}
+}
+
+func TestEcho_readMask(t *testing.T) {
+	s := NewEchoServer().(*echoServerImpl)
+	s.dedupe = server.NewDedupeCache(time.Now, 10)
+	in := &pb.EchoRequest{
+		Response:       &pb.EchoRequest_Content{Content: "hi"},
+		ClientSequence: 3,
+		RepeatCount:    2,
+		DedupeWindow:   ptypes.DurationProto(time.Minute),
+		ReadMask:       &field_mask.FieldMask{Paths: []string{"content", "client_sequence", "served_from_cache"}},
+	}
+	want := &pb.EchoResponse{Content: "hi", ClientSequence: 3}
+	got, err := s.Echo(context.Background(), in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("Echo with a read_mask: want %v got %v", want, got)
+	}
+	// Cached responses are masked too.
+	want.ServedFromCache = true
+	if got, _ := s.Echo(context.Background(), in); !proto.Equal(got, want) {
+		t.Errorf("Echo with a read_mask from the cache: want %v got %v", want, got)
+	}
+
+	in.ReadMask = &field_mask.FieldMask{Paths: []string{"*"}}
+	got, _ = s.Echo(context.Background(), in)
+	if len(got.GetRepeatedContent()) != 2 || got.GetServerSequence() == 0 {
+		t.Errorf("Echo with a read_mask of *: want the whole response got %v", got)
+	}
+
+	for _, path := range []string{"contents", "repeated_content.length"} {
+		in.ReadMask = &field_mask.FieldMask{Paths: []string{"content", path}}
+		_, err := s.Echo(context.Background(), in)
+		if st := status.Convert(err); st.Code() != codes.InvalidArgument || !strings.Contains(st.Message(), "`"+path+"`") {
+			t.Errorf("Echo with the read_mask path %s: want InvalidArgument naming it got %v", path, err)
+		}
+	}
+}
+
+func TestExpand_sizePattern(t *testing.T) {
+	stream := &collectingExpandStream{}
+	req := &pb.ExpandRequest{SizePattern: []int32{10, 1 << 20, 10}, MessageCount: 5, WithSummary: true}
+	if err := NewEchoServer().Expand(req, stream); err != nil {
+		t.Fatal(err)
+	}
+	if len(stream.sent) != 6 || !stream.sent[5].GetIsSummary() || stream.sent[5].GetMessageCount() != 5 {
+		t.Fatalf("Expand with a size pattern: want 5 messages and a summary got %d", len(stream.sent))
+	}
+	for i, want := range []int{10, 1 << 20, 10, 10, 1 << 20} {
+		content := stream.sent[i].GetContent()
+		if len(content) != want {
+			t.Errorf("Expand with a size pattern: want message %d to be %d bytes got %d", i, want, len(content))
+			continue
+		}
+		for j := 0; j < len(content); j++ {
+			if content[j] != byte('a'+(i+j)%26) {
+				t.Errorf("Expand with a size pattern: want byte %d of message %d to be %q got %q", j, i, rune('a'+(i+j)%26), content[j])
+				break
+			}
+		}
+	}
+}
+
+func TestExpand_sizePatternCycles(t *testing.T) {
+	tests := []struct {
+		req  *pb.ExpandRequest
+		want []string
+	}{
+		{&pb.ExpandRequest{SizePattern: []int32{1, 0, 3}}, []string{"a", "", "cde"}},
+		{&pb.ExpandRequest{SizePattern: []int32{1, 2}, MessageCount: 5}, []string{"a", "bc", "c", "de", "e"}},
+		// Repeats continue the cycle.
+		{&pb.ExpandRequest{SizePattern: []int32{1, 2, 3}, MessageCount: 2, RepeatCount: 2}, []string{"a", "bc", "cde", "d"}},
+	}
+	for _, test := range tests {
+		stream := &collectingExpandStream{}
+		if err := NewEchoServer().Expand(test.req, stream); err != nil {
+			t.Fatal(err)
+		}
+		got := []string{}
+		for _, resp := range stream.sent {
+			got = append(got, resp.GetContent())
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Expand(%v): want %q got %q", test.req, test.want, got)
+		}
+	}
+}
+
+func TestExpand_sizePatternInvalid(t *testing.T) {
+	settings := server.DefaultSettings()
+	settings.MaxSendMessageBytes = 100
+	echo := &echoServerImpl{settings: server.NewSettingsStore(settings)}
+	tests := []struct {
+		req  *pb.ExpandRequest
+		want string
+	}{
+		{
+			&pb.ExpandRequest{SizePattern: []int32{10, 99}},
+			"The field `size_pattern[1]` asks for a 99 byte message, more than the 100 bytes the server sends.",
+		},
+		{
+			&pb.ExpandRequest{SizePattern: []int32{88}, WithSummary: true},
+			"The field `size_pattern[0]` asks for a 88 byte message, more than the 100 bytes the server sends.",
+		},
+		{&pb.ExpandRequest{SizePattern: []int32{1, -1}}, "The field `size_pattern[1]` must not be negative."},
+		{&pb.ExpandRequest{SizePattern: []int32{1}, MessageCount: -1}, "The field `message_count` must not be negative."},
+		{
+			&pb.ExpandRequest{SizePattern: []int32{1}, Content: "a"},
+			"The field `size_pattern` must not be set with `content` or `corpus_name`.",
+		},
+		{&pb.ExpandRequest{Content: "a", MessageCount: 2}, "The field `message_count` requires `size_pattern` to be set."},
+	}
+	for _, test := range tests {
+		stream := &collectingExpandStream{}
+		err := echo.Expand(test.req, stream)
+		if st := status.Convert(err); st.Code() != codes.InvalidArgument || st.Message() != test.want {
+			t.Errorf("Expand(%v): want InvalidArgument %q got %v", test.req, test.want, err)
+		}
+		if len(stream.sent) != 0 {
+			t.Errorf("Expand(%v): want nothing sent got %v", test.req, stream.sent)
+		}
+	}
+
+	// The largest message that fits is accepted.
+	stream := &collectingExpandStream{}
+	if err := echo.Expand(&pb.ExpandRequest{SizePattern: []int32{98}}, stream); err != nil || proto.Size(stream.sent[0]) != 100 {
+		t.Errorf("Expand of a 100 byte message: want it sent got %v", err)
+	}
+}
+
+func TestExpand_sizePatternDelays(t *testing.T) {
+	events := []string{}
+	echo := &echoServerImpl{
+		settings: server.NewSettingsStore(server.DefaultSettings()),
+		afterF: func(d time.Duration) <-chan time.Time {
+			events = append(events, fmt.Sprintf("wait %s", d))
+			c := make(chan time.Time, 1)
+			c <- time.Time{}
+			return c
+		},
+	}
+	req := &pb.ExpandRequest{
+		SizePattern:       []int32{1, 3},
+		MessageDelay:      ptypes.DurationProto(5 * time.Second),
+		HeartbeatInterval: ptypes.DurationProto(3 * time.Second),
+	}
+	stream := &eventExpandStream{ctx: context.Background(), events: &events}
+	if err := echo.Expand(req, stream); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"wait 3s", "heartbeat", "wait 2s", "a", "wait 3s", "heartbeat", "wait 2s", "bcd"}
+	if fmt.Sprint(events) != fmt.Sprint(want) {
+		t.Errorf("Expand with a size pattern and delays:\n want %v\n got  %v", want, events)
+	}
+}
+
+func TestEcho_statusMessageOnTheWire(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The whole interceptor chain, so that none of it may alter messages.
+	unary, stream := interceptors.Chain(interceptors.Options{Metrics: server.NewMetrics()})
+	s := grpc.NewServer(grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+	pb.RegisterEchoServer(s, NewEchoServer())
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewEchoClient(conn)
+
+	// About 7KB, which percent-encodes to several times that in the
+	// grpc-message trailer.
+	unit := "ünïcødé 🙂 100% %2F\r\n"
+	long := strings.Repeat(unit, 7*1024/len(unit)+1)
+	tests := []struct {
+		in   *spb.Status
+		want string
+	}{
+		{&spb.Status{Code: int32(codes.Aborted), Message: "plain"}, "plain"},
+		{&spb.Status{Code: int32(codes.Aborted), Message: "%25 %%% %zz %"}, "%25 %%% %zz %"},
+		{&spb.Status{Code: int32(codes.Aborted), Message: "line\nbreaks\r\n\ttabs"}, "line\nbreaks\r\n\ttabs"},
+		{&spb.Status{Code: int32(codes.Aborted), Message: long}, long},
+		{&spb.Status{Code: int32(codes.Aborted)}, ""},
+	}
+	for _, test := range tests {
+		for _, hazardous := range []bool{false, true} {
+			want := test.want
+			if hazardous {
+				want += "\r\n100% %41%%0A ✓ 🙂"
+			}
+			_, err := client.Echo(
+				context.Background(),
+				&pb.EchoRequest{Response: &pb.EchoRequest_Error{Error: test.in}, HazardousErrorMessage: hazardous})
+			st := status.Convert(err)
+			if st.Code() != codes.Aborted || st.Message() != want {
+				t.Errorf("Echo(%.20q, hazardous %t): want Aborted %.40q got %v %.40q", test.in.GetMessage(), hazardous, want, st.Code(), st.Message())
+			}
+			if got := st.Proto().GetMessage(); got != want {
+				t.Errorf("Echo(%.20q, hazardous %t): want the status proto to carry %.40q got %.40q", test.in.GetMessage(), hazardous, want, got)
+			}
+		}
+	}
+	for _, in := range []*pb.EchoRequest{
+		{Response: &pb.EchoRequest_Content{Content: "hi"}, HazardousErrorMessage: true},
+		{Response: &pb.EchoRequest_Error{Error: &spb.Status{}}, HazardousErrorMessage: true},
+	} {
+		if _, err := client.Echo(context.Background(), in); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Echo(%v): want InvalidArgument got %v", in, err)
+		}
+	}
+}
+
+// detailedStatus returns a status whose details include types the client
+// cannot decode, so that only their bytes can be compared.
+func detailedStatus(t *testing.T) *spb.Status {
+	retry, err := ptypes.MarshalAny(&errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(time.Second)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &spb.Status{
+		Code:    int32(codes.FailedPrecondition),
+		Message: "with details",
+		Details: []*any.Any{
+			retry,
+			showcaseerrors.ErrorInfo("REASON", showcaseerrors.Domain, map[string]string{"b": "2", "a": "1"}),
+			{TypeUrl: "type.googleapis.com/example.Unregistered", Value: []byte{0x08, 0x96, 0x01, 0xff, 0x00}},
+			{TypeUrl: "example.com/empty"},
+		},
+	}
+}
+
+// checkDetails fails the test unless err carries exactly the status want,
+// details byte for byte.
+func checkDetails(t *testing.T, method string, err error, want *spb.Status) {
+	t.Helper()
+	got := status.Convert(err).Proto()
+	if !proto.Equal(got, want) {
+		t.Errorf("%s: want %v got %v", method, want, got)
+	}
+	gotBytes, _ := proto.Marshal(got)
+	wantBytes, _ := proto.Marshal(want)
+	if !bytes.Equal(gotBytes, wantBytes) {
+		t.Errorf("%s: want the status bytes %x got %x", method, wantBytes, gotBytes)
+	}
+}
+
+func TestErrorDetailsOnTheWire(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unary, stream := interceptors.Chain(interceptors.Options{Metrics: server.NewMetrics()})
+	s := grpc.NewServer(grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+	pb.RegisterEchoServer(s, NewEchoServer())
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewEchoClient(conn)
+	ctx := context.Background()
+	want := detailedStatus(t)
+	failing := &pb.EchoRequest{Response: &pb.EchoRequest_Error{Error: want}}
+
+	_, err = client.Echo(ctx, failing)
+	checkDetails(t, "Echo", err, want)
+
+	batch, err := client.BatchEcho(ctx, &pb.BatchEchoRequest{Requests: []*pb.EchoRequest{failing}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDetails(t, "BatchEcho", status.ErrorProto(batch.GetResults()[0].GetError()), want)
+
+	expand, err := client.Expand(ctx, &pb.ExpandRequest{Content: "a", Error: want})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for err == nil {
+		_, err = expand.Recv()
+	}
+	checkDetails(t, "Expand", err, want)
+
+	collect, err := client.Collect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	collect.Send(failing)
+	_, err = collect.CloseAndRecv()
+	checkDetails(t, "Collect", err, want)
+
+	chat, err := client.Chat(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chat.Send(failing)
+	for err == nil || err == io.EOF {
+		_, err = chat.Recv()
+	}
+	checkDetails(t, "Chat", err, want)
+
+	op, err := client.Wait(ctx, &pb.WaitRequest{End: &pb.WaitRequest_Ttl{Ttl: ptypes.DurationProto(0)}, Response: &pb.WaitRequest_Error{Error: want}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDetails(t, "Wait", status.ErrorProto(op.GetError()), want)
+}
+
+func TestCollect_continueOnError(t *testing.T) {
+	content := func(c string) *pb.EchoRequest {
+		return &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: c}}
+	}
+	failure := func(code codes.Code) *pb.EchoRequest {
+		return &pb.EchoRequest{Response: &pb.EchoRequest_Error{Error: &spb.Status{Code: int32(code), Message: code.String()}}}
+	}
+	first := func(req *pb.EchoRequest) *pb.EchoRequest {
+		req.ContinueOnError = true
+		return req
+	}
+	tests := []struct {
+		name string
+		reqs []*pb.EchoRequest
+		want *pb.EchoResponse
+	}{
+		{
+			"interleaved",
+			[]*pb.EchoRequest{
+				first(content("Hello")),
+				failure(codes.InvalidArgument),
+				content("World"),
+				failure(codes.NotFound),
+				content("again"),
+			},
+			&pb.EchoResponse{
+				Content: "Hello World again",
+				CollectFailures: []*pb.CollectFailure{
+					{Index: 1, Error: &spb.Status{Code: int32(codes.InvalidArgument), Message: "InvalidArgument"}},
+					{Index: 3, Error: &spb.Status{Code: int32(codes.NotFound), Message: "NotFound"}},
+				},
 			},
 		},
 		{
-			&pb.PagedExpandRequest{PageSize: 3, Content: "Hello world!"},
-			&pb.PagedExpandResponse{
-				Responses: []*pb.EchoResponse{
-					&pb.EchoResponse{Content: "Hello"},
-					&pb.EchoResponse{Content: "world!"},
+			"all errors",
+			[]*pb.EchoRequest{first(failure(codes.Aborted)), failure(codes.Aborted)},
+			&pb.EchoResponse{
+				CollectFailures: []*pb.CollectFailure{
+					{Index: 0, Error: &spb.Status{Code: int32(codes.Aborted), Message: "Aborted"}},
+					{Index: 1, Error: &spb.Status{Code: int32(codes.Aborted), Message: "Aborted"}},
 				},
 			},
 		},
 		{
-			&pb.PagedExpandRequest{
-				PageSize: 3,
-				Content:  "The rain in Spain falls mainly on the plain!",
+			"all success",
+			[]*pb.EchoRequest{first(content("Hello")), content("World")},
+			&pb.EchoResponse{Content: "Hello World"},
+		},
+		{
+			// An OK status is not an error.
+			"ok status",
+			[]*pb.EchoRequest{first(content("Hello")), failure(codes.OK)},
+			&pb.EchoResponse{Content: "Hello"},
+		},
+		{
+			"flushed",
+			[]*pb.EchoRequest{
+				first(failure(codes.Internal)),
+				{Response: &pb.EchoRequest_Content{Content: "Hello"}, Flush: true},
+				failure(codes.Internal),
 			},
-			&pb.PagedExpandResponse{
-				Responses: []*pb.EchoResponse{
-					&pb.EchoResponse{Content: "The"},
-					&pb.EchoResponse{Content: "rain"},
-					&pb.EchoResponse{Content: "in"},
-				},
-				NextPageToken: "3",
+			&pb.EchoResponse{
+				Content:         "Hello",
+				CollectFailures: []*pb.CollectFailure{{Index: 0, Error: &spb.Status{Code: int32(codes.Internal), Message: "Internal"}}},
 			},
 		},
+	}
+	for _, test := range tests {
+		stream := &mockCollectStream{reqs: test.reqs, exp: &test.want.Content, t: t}
+		if err := NewEchoServer().Collect(stream); err != nil {
+			t.Errorf("Collect %s: unexpected err %v", test.name, err)
+			continue
+		}
+		if !proto.Equal(stream.sent, test.want) {
+			t.Errorf("Collect %s: want %v got %v", test.name, test.want, stream.sent)
+		}
+	}
+}
+
+func TestCollect_continueOnErrorOnlyOnFirstMessage(t *testing.T) {
+	reqs := []*pb.EchoRequest{
+		{Response: &pb.EchoRequest_Content{Content: "Hello"}},
+		{Response: &pb.EchoRequest_Error{Error: &spb.Status{Code: int32(codes.Aborted)}}, ContinueOnError: true},
+	}
+	stream := &mockCollectStream{reqs: reqs, t: t}
+	if err := NewEchoServer().Collect(stream); status.Code(err) != codes.Aborted {
+		t.Errorf("Collect with continue_on_error after the first message: want Aborted got %v", err)
+	}
+}
+
+func TestStreamEndOnTheWire(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	pb.RegisterEchoServer(s, NewEchoServer())
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewEchoClient(conn)
+	ctx := context.Background()
+	trailers := map[string]string{"x-showcase-end": "fine", "x-showcase-note": "a b"}
+	failure := &spb.Status{Code: int32(codes.Aborted), Message: "stop"}
+	checkTrailer := func(method string, md metadata.MD) {
+		for key, want := range trailers {
+			if got := md.Get(key); len(got) != 1 || got[0] != want {
+				t.Errorf("%s: want trailer %s %q got %v", method, key, want, got)
+			}
+		}
+	}
+	checkSummary := func(method string, err error, count int64, words ...string) {
+		st := status.Convert(err)
+		if st.Code() != codes.Aborted || st.Message() != "stop" {
+			t.Fatalf("%s: want the requested error got %v", method, err)
+		}
+		details := st.Proto().GetDetails()
+		if len(details) != 1 {
+			t.Fatalf("%s: want a summary detail got %v", method, details)
+		}
+		summary := &pb.EchoResponse{}
+		if err := ptypes.UnmarshalAny(details[0], summary); err != nil {
+			t.Fatalf("%s: unexpected err %+v", method, err)
+		}
+		checksum := crc32.Checksum([]byte(strings.Join(words, "\n")+"\n"), crc32cTable)
+		if !summary.GetIsSummary() || summary.GetMessageCount() != count || summary.GetChecksum() != checksum {
+			t.Errorf("%s: want a summary of %d messages with checksum %d got %v", method, count, checksum, summary)
+		}
+	}
+
+	// An OK Expand stream ends with the trailers.
+	expand, err := client.Expand(ctx, &pb.ExpandRequest{Content: "a b c", Trailers: trailers})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for err == nil {
+		_, err = expand.Recv()
+	}
+	if err != io.EOF {
+		t.Fatalf("Expand: want OK got %v", err)
+	}
+	checkTrailer("Expand", expand.Trailer())
+	if got := expand.Trailer().Get(expandCountTrailer); len(got) != 1 || got[0] != "3" {
+		t.Errorf("Expand: want the trailer %s 3 got %v", expandCountTrailer, got)
+	}
+
+	// A failed one carries a summary of the words sent, and the trailers.
+	expand, err = client.Expand(ctx, &pb.ExpandRequest{
+		Content:      "a b c",
+		RepeatCount:  2,
+		Error:        failure,
+		ErrorSummary: true,
+		Trailers:     trailers,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for err == nil {
+		_, err = expand.Recv()
+	}
+	checkSummary("Expand", err, 6, "a", "b", "c", "a", "b", "c")
+	checkTrailer("Expand", expand.Trailer())
+
+	chat, err := client.Chat(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chat.Send(&pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}, Trailers: trailers})
+	chat.CloseSend()
+	for err == nil {
+		_, err = chat.Recv()
+	}
+	if err != io.EOF {
+		t.Fatalf("Chat: want OK got %v", err)
+	}
+	checkTrailer("Chat", chat.Trailer())
+
+	chat, err = client.Chat(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chat.Send(&pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}, ErrorSummary: true})
+	chat.Send(&pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "there"}})
+	chat.Send(&pb.EchoRequest{Response: &pb.EchoRequest_Error{Error: failure}})
+	for err == nil {
+		_, err = chat.Recv()
+	}
+	checkSummary("Chat", err, 2, "hi", "there")
+}
+
+func TestExpand_reportCompression(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	pb.RegisterEchoServer(s, NewEchoServer())
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewEchoClient(conn)
+
+	tests := []struct {
+		opts        []grpc.CallOption
+		compression string
+		indices     []int64
+	}{
+		{[]grpc.CallOption{grpc.UseCompressor(gzip.Name)}, "gzip", []int64{1, 2, 3}},
+		{nil, "identity", nil},
+	}
+	for _, test := range tests {
+		stream, err := client.Expand(
+			context.Background(),
+			&pb.ExpandRequest{Content: "one two three", WithSummary: true, ReportCompression: true},
+			test.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		words := []string{}
+		var summary *pb.EchoResponse
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Expand with %s: %v", test.compression, err)
+			}
+			if resp.GetIsSummary() {
+				summary = resp
+				continue
+			}
+			words = append(words, resp.GetContent())
+		}
+		if got := strings.Join(words, " "); got != "one two three" {
+			t.Errorf("Expand with %s: want the words decoded got %q", test.compression, got)
+		}
+		if !reflect.DeepEqual(summary.GetCompressedIndices(), test.indices) {
+			t.Errorf("Expand with %s: want the compressed indices %v got %v", test.compression, test.indices, summary.GetCompressedIndices())
+		}
+		if got := stream.Trailer().Get(expandCompressionTrailer); len(got) != 1 || got[0] != test.compression {
+			t.Errorf("Expand with %s: want the trailer %s %q got %v", test.compression, expandCompressionTrailer, test.compression, got)
+		}
+	}
+}
+
+func TestStreamEnd_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *pb.ExpandRequest
+	}{
+		{"uppercase key", &pb.ExpandRequest{Trailers: map[string]string{"X-End": "v"}}},
+		{"reserved key", &pb.ExpandRequest{Trailers: map[string]string{"grpc-status": "0"}}},
+		{"binary key", &pb.ExpandRequest{Trailers: map[string]string{"x-end-bin": "v"}}},
+		{"non-ASCII value", &pb.ExpandRequest{Trailers: map[string]string{"x-end": "✓"}}},
+		{"control character", &pb.ExpandRequest{Trailers: map[string]string{"x-end": "a\nb"}}},
+		{"summary without error", &pb.ExpandRequest{Content: "a", ErrorSummary: true}},
+		{"summary of an OK error", &pb.ExpandRequest{Content: "a", ErrorSummary: true, Error: &spb.Status{}}},
+	}
+	for _, test := range tests {
+		stream := &mockExpandStream{exp: []string{}, t: t}
+		if err := NewEchoServer().Expand(test.in, stream); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expand with %s: want InvalidArgument got %v", test.name, err)
+		}
+	}
+
+	chat := &mockChatStream{
+		reqs: []*pb.EchoRequest{{Trailers: map[string]string{"grpc-message": "x"}}},
+		t:    t,
+	}
+	if err := NewEchoServer().Chat(chat); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Chat with a reserved trailer: want InvalidArgument got %v", err)
+	}
+}
+
+func TestPagedExpand_orderBy(t *testing.T) {
+	content := "aa b cccc dd e fff"
+	tests := []struct {
+		orderBy string
+		want    []int64
+	}{
+		{"index desc", []int64{6, 5, 4, 3, 2, 1}},
+		{"length", []int64{2, 5, 1, 4, 6, 3}},
+		{"length desc", []int64{3, 6, 1, 4, 2, 5}},
+		// Positions 1, 3 and 5 are odd.
+		{"parity, length desc", []int64{3, 1, 5, 6, 4, 2}},
+		{"parity desc,index desc", []int64{6, 4, 2, 5, 3, 1}},
+	}
+	s := NewEchoServer()
+	for _, test := range tests {
+		req := &pb.PagedExpandRequest{Content: content, PageSize: 4, OrderBy: test.orderBy}
+		got := []int64{}
+		for pages := 0; pages < 3; pages++ {
+			resp, err := s.PagedExpand(context.Background(), req)
+			if err != nil {
+				t.Fatalf("PagedExpand(%q): unexpected err %+v", test.orderBy, err)
+			}
+			for _, r := range resp.GetResponses() {
+				if r.GetContent() != strings.Fields(content)[r.GetIndex()-1] {
+					t.Errorf("PagedExpand(%q): want the word at index %d got %q", test.orderBy, r.GetIndex(), r.GetContent())
+				}
+				got = append(got, r.GetIndex())
+			}
+			if resp.GetNextPageToken() == "" {
+				break
+			}
+			req.PageToken = resp.GetNextPageToken()
+		}
+		if fmt.Sprint(got) != fmt.Sprint(test.want) {
+			t.Errorf("PagedExpand(%q): want indices %v got %v", test.orderBy, test.want, got)
+		}
+	}
+
+	// Without an order_by, responses carry no index.
+	resp, err := s.PagedExpand(context.Background(), &pb.PagedExpandRequest{Content: content, PageSize: 4})
+	if err != nil || resp.GetResponses()[0].GetIndex() != 0 || resp.GetNextPageToken() != "4" {
+		t.Errorf("PagedExpand without order_by: want plain pages got %v, %v", resp, err)
+	}
+}
+
+func TestPagedExpand_orderByInvalid(t *testing.T) {
+	s := NewEchoServer()
+	first, err := s.PagedExpand(context.Background(), &pb.PagedExpandRequest{Content: "a b c", PageSize: 1, OrderBy: "length desc, index"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		in      *pb.PagedExpandRequest
+		reason  string
+		wantMsg string
+	}{
+		{&pb.PagedExpandRequest{Content: "a b", OrderBy: "length, size"}, showcaseerrors.FieldInvalid, "invalid at offset 8: unknown field `size`"},
+		{&pb.PagedExpandRequest{Content: "a b", OrderBy: "length up"}, showcaseerrors.FieldInvalid, "at offset 7: expected `,` but found `up`"},
+		// The same order, written differently, keeps its pages.
+		{&pb.PagedExpandRequest{Content: "a b c", OrderBy: "length desc,index asc", PageToken: first.GetNextPageToken()}, "", ""},
+		{&pb.PagedExpandRequest{Content: "a b c", OrderBy: "length", PageToken: first.GetNextPageToken()}, showcaseerrors.PageTokenInvalid, "issued for the order_by `length desc,index`"},
+		{&pb.PagedExpandRequest{Content: "a b c", PageToken: first.GetNextPageToken()}, showcaseerrors.PageTokenInvalid, "issued for the order_by"},
+		{&pb.PagedExpandRequest{Content: "a b c", OrderBy: "length desc,index", PageToken: "1"}, showcaseerrors.PageTokenInvalid, "but the request has `length desc,index`"},
+	}
+	for _, test := range tests {
+		_, err := s.PagedExpand(context.Background(), test.in)
+		if test.reason == "" {
+			if err != nil {
+				t.Errorf("PagedExpand(%v): unexpected err %+v", test.in, err)
+			}
+			continue
+		}
+		st, _ := status.FromError(err)
+		details := st.Proto().GetDetails()
+		if st.Code() != codes.InvalidArgument || len(details) != 1 || !strings.Contains(st.Message(), test.wantMsg) {
+			t.Errorf("PagedExpand(%v): want InvalidArgument containing %q got %v", test.in, test.wantMsg, err)
+			continue
+		}
+		if reason, _, _ := decodeErrorInfo(t, details[0].GetValue()); reason != test.reason {
+			t.Errorf("PagedExpand(%v): want %s got %s", test.in, test.reason, reason)
+		}
+	}
+}
+
+func TestPagedExpand_filter(t *testing.T) {
+	// The words are at positions 0 to 5, with lengths 2, 1, 4, 2, 1 and 3.
+	content := "aa b cccc dd e fff"
+	tests := []struct {
+		filter  string
+		orderBy string
+		want    []int64
+	}{
+		{"length > 1", "", []int64{1, 3, 4, 6}},
+		{"length > 1", "length desc", []int64{3, 6, 1, 4}},
+		{"parity = 1 AND content != dd", "", []int64{2, 6}},
+		{"parity = 1 AND content != dd", "index desc", []int64{6, 2}},
+		{"content > c", "", []int64{3, 4, 5, 6}},
+		{"content > c", "length", []int64{5, 4, 6, 3}},
+		{"index > 0 AND index < 5 AND length < 3", "parity desc", []int64{2, 4, 5}},
+		{"content = \"fff\"", "", []int64{6}},
+		{"index < 0", "", []int64{}},
+	}
+	s := NewEchoServer()
+	for _, test := range tests {
+		req := &pb.PagedExpandRequest{Content: content, PageSize: 2, Filter: test.filter, OrderBy: test.orderBy}
+		got := []int64{}
+		for pages := 0; pages < 4; pages++ {
+			resp, err := s.PagedExpand(context.Background(), req)
+			if err != nil {
+				t.Fatalf("PagedExpand(%q, %q): unexpected err %+v", test.filter, test.orderBy, err)
+			}
+			for _, r := range resp.GetResponses() {
+				if r.GetContent() != strings.Fields(content)[r.GetIndex()-1] {
+					t.Errorf("PagedExpand(%q, %q): want the word at index %d got %q", test.filter, test.orderBy, r.GetIndex(), r.GetContent())
+				}
+				got = append(got, r.GetIndex())
+			}
+			if resp.GetNextPageToken() == "" {
+				break
+			}
+			req.PageToken = resp.GetNextPageToken()
+		}
+		if fmt.Sprint(got) != fmt.Sprint(test.want) {
+			t.Errorf("PagedExpand(%q, %q): want indices %v got %v", test.filter, test.orderBy, test.want, got)
+		}
+	}
+}
+
+func TestPagedExpand_filterInvalid(t *testing.T) {
+	s := NewEchoServer()
+	first, err := s.PagedExpand(context.Background(), &pb.PagedExpandRequest{Content: "a bb ccc", PageSize: 1, Filter: "length>1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ordered, err := s.PagedExpand(context.Background(), &pb.PagedExpandRequest{Content: "a bb ccc", PageSize: 1, Filter: "length>1", OrderBy: "index desc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		in      *pb.PagedExpandRequest
+		reason  string
+		wantMsg string
+	}{
+		{&pb.PagedExpandRequest{Content: "a b", Filter: "length > 1 AND size = 2"}, showcaseerrors.FieldInvalid, "The field `filter` is invalid at offset 15: unknown field `size`"},
+		{&pb.PagedExpandRequest{Content: "a b", Filter: "length = a"}, showcaseerrors.FieldInvalid, "at offset 9: the field `length` is compared with an integer"},
+		{&pb.PagedExpandRequest{Content: "a b", Filter: "content = \"a"}, showcaseerrors.FieldInvalid, "at offset 10: the string is not terminated"},
+		// The same filter, written differently, keeps its pages.
+		{&pb.PagedExpandRequest{Content: "a bb ccc", Filter: "length > 1", PageToken: first.GetNextPageToken()}, "", ""},
+		{&pb.PagedExpandRequest{Content: "a bb ccc", Filter: " length>1 ", OrderBy: "index desc", PageToken: ordered.GetNextPageToken()}, "", ""},
+		{&pb.PagedExpandRequest{Content: "a bb ccc", Filter: "length > 2", PageToken: first.GetNextPageToken()}, showcaseerrors.PageTokenInvalid, "issued for the filter `length > 1`, but the request has `length > 2`"},
+		{&pb.PagedExpandRequest{Content: "a bb ccc", PageToken: first.GetNextPageToken()}, showcaseerrors.PageTokenInvalid, "issued for the filter"},
+		{&pb.PagedExpandRequest{Content: "a bb ccc", Filter: "length > 1", PageToken: ordered.GetNextPageToken()}, showcaseerrors.PageTokenInvalid, "issued for the order_by `index desc`"},
+		{&pb.PagedExpandRequest{Content: "a bb ccc", Filter: "length > 1", PageToken: "1"}, showcaseerrors.PageTokenInvalid, "but the request has `length > 1`"},
+		// Only the words that match are in range.
+		{&pb.PagedExpandRequest{Content: "a bb ccc", Filter: "length > 2", PageToken: "1::length > 2"}, showcaseerrors.PageTokenInvalid, "within the range [0, 1)"},
+	}
+	for _, test := range tests {
+		_, err := s.PagedExpand(context.Background(), test.in)
+		if test.reason == "" {
+			if err != nil {
+				t.Errorf("PagedExpand(%v): unexpected err %+v", test.in, err)
+			}
+			continue
+		}
+		st, _ := status.FromError(err)
+		if st.Code() != codes.InvalidArgument || !strings.Contains(st.Message(), test.wantMsg) {
+			t.Errorf("PagedExpand(%v): want InvalidArgument containing %q got %v", test.in, test.wantMsg, err)
+			continue
+		}
+		details := st.Proto().GetDetails()
+		info := details[len(details)-1]
+		if reason, _, md := decodeErrorInfo(t, info.GetValue()); reason != test.reason {
+			t.Errorf("PagedExpand(%v): want %s got %s", test.in, test.reason, reason)
+		} else if test.reason == showcaseerrors.FieldInvalid {
+			br, ok := st.Details()[0].(*errdetails.BadRequest)
+			if len(details) != 2 || !ok || br.GetFieldViolations()[0].GetField() != "filter" || md["field"] != "filter" {
+				t.Errorf("PagedExpand(%v): want a BadRequest on filter got %v", test.in, st.Details())
+			}
+		}
+	}
+}
+
+func TestPagedExpand_fuzz(t *testing.T) {
+	s := NewEchoServer()
+	for seed := int64(0); seed < 200; seed++ {
+		in, _ := showcasefuzz.ValidRequest("/google.showcase.v1beta1.Echo/PagedExpand", seed, showcasefuzz.DefaultOptions())
+		req := in.(*pb.PagedExpandRequest)
+		words := strings.Fields(req.GetContent())
+		got := []string{}
+		// Every page but the last holds a word, so the pages end.
+		for pages := 0; pages <= len(words); pages++ {
+			resp, err := s.PagedExpand(context.Background(), req)
+			if err != nil {
+				t.Fatalf("PagedExpand(%v), seed %d: unexpected err %+v", req, seed, err)
+			}
+			for _, r := range resp.GetResponses() {
+				got = append(got, r.GetContent())
+			}
+			if resp.GetNextPageToken() == "" {
+				break
+			}
+			req.PageToken = resp.GetNextPageToken()
+		}
+		if fmt.Sprint(got) != fmt.Sprint(words) {
+			t.Errorf("PagedExpand(%q, %d), seed %d: want the words %v got %v", req.GetContent(), req.GetPageSize(), seed, words, got)
+		}
+	}
+}
+
+func TestPagedExpand_scramble(t *testing.T) {
+	words := strings.Fields("the quick brown fox jumps over the lazy dog at last")
+	settings := server.DefaultSettings()
+	settings.ListScrambleSeed = 7
+	scrambledEcho := &echoServerImpl{settings: server.NewSettingsStore(settings)}
+	plainEcho := &echoServerImpl{settings: server.NewSettingsStore(server.DefaultSettings())}
+
+	contents := func(resp *pb.PagedExpandResponse) []string {
+		got := []string{}
+		for _, r := range resp.GetResponses() {
+			got = append(got, r.GetContent())
+		}
+		return got
+	}
+	all := []string{}
+	token := ""
+	for {
+		in := &pb.PagedExpandRequest{Content: strings.Join(words, " "), PageSize: 4, PageToken: token}
+		got, err := scrambledEcho.PagedExpand(context.Background(), in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		again, err := scrambledEcho.PagedExpand(context.Background(), in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := plainEcho.PagedExpand(context.Background(), in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.GetNextPageToken() != want.GetNextPageToken() {
+			t.Errorf("PagedExpand(%q): got next page token %q, want %q", token, got.GetNextPageToken(), want.GetNextPageToken())
+		}
+		if !reflect.DeepEqual(contents(got), contents(again)) {
+			t.Errorf("PagedExpand(%q): got %v, then %v", token, contents(got), contents(again))
+		}
+		sortedGot, sortedWant := contents(got), contents(want)
+		sort.Strings(sortedGot)
+		sort.Strings(sortedWant)
+		if !reflect.DeepEqual(sortedGot, sortedWant) {
+			t.Errorf("PagedExpand(%q): got %v, want a permutation of %v", token, contents(got), contents(want))
+		}
+		all = append(all, contents(got)...)
+		if token = got.GetNextPageToken(); token == "" {
+			break
+		}
+	}
+	if reflect.DeepEqual(all, words) {
+		t.Errorf("PagedExpand: got the words in order, want them shuffled")
+	}
+	sort.Strings(all)
+	sort.Strings(words)
+	if !reflect.DeepEqual(all, words) {
+		t.Errorf("PagedExpand: got the words %v, want %v", all, words)
+	}
+}
+
+func TestEcho_transportInfo(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	pb.RegisterEchoServer(s, NewEchoServer())
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure(), grpc.WithUserAgent("showcase-test/1.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewEchoClient(conn)
+
+	resp, err := client.Echo(context.Background(), &pb.EchoRequest{IncludeTransportInfo: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := resp.GetTransportInfo()
+	if !strings.HasPrefix(info.GetUserAgent(), "showcase-test/1.0 grpc-go/") {
+		t.Errorf("Echo: want a user agent starting with %q, got %q", "showcase-test/1.0 grpc-go/", info.GetUserAgent())
+	}
+	if got := info.GetContentType(); got != "application/grpc" {
+		t.Errorf("Echo: want content type application/grpc, got %q", got)
+	}
+	if got := info.GetProtocol(); got != "h2" {
+		t.Errorf("Echo: want protocol h2, got %q", got)
+	}
+	if got := info.GetAccept(); got != "" {
+		t.Errorf("Echo: want no accept, got %q", got)
+	}
+
+	resp, err = client.Echo(context.Background(), &pb.EchoRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetTransportInfo() != nil {
+		t.Errorf("Echo: want no transport info unless requested, got %v", resp.GetTransportInfo())
+	}
+}
+
+func TestEcho_transportInfoHTTP(t *testing.T) {
+	h := server.NewEchoHTTPHandler(NewEchoServer())
+	for _, include := range []bool{true, false} {
+		body := fmt.Sprintf(`{"includeTransportInfo":%t}`, include)
+		req := httptest.NewRequest(http.MethodPost, server.EchoPath, strings.NewReader(body))
+		req.Header.Set("User-Agent", "showcase-test/1.0")
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		resp := &pb.EchoResponse{}
+		if err := jsonpb.Unmarshal(w.Body, resp); err != nil {
+			t.Fatalf("Echo(%s): %s", body, err)
+		}
+		var want *pb.TransportInfo
+		if include {
+			want = &pb.TransportInfo{
+				UserAgent:   "showcase-test/1.0",
+				ContentType: "application/json",
+				Protocol:    "http/1.1",
+				Accept:      "application/json",
+			}
+		}
+		if !proto.Equal(resp.GetTransportInfo(), want) {
+			t.Errorf("Echo(%s): want transport info %v, got %v", body, want, resp.GetTransportInfo())
+		}
+	}
+}
+
+func TestCollect_errorAfterClose(t *testing.T) {
+	content := func(c string) *pb.EchoRequest {
+		return &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: c}}
+	}
+	first := content("Hello")
+	first.ErrorAfterClose = &spb.Status{Code: int32(codes.Aborted), Message: "Aborted after the stream was read."}
+	tests := []struct {
+		name string
+		reqs []*pb.EchoRequest
+		want string
+	}{
+		{"end of stream", []*pb.EchoRequest{first, content("World")}, "Hello World"},
+		{"flush", []*pb.EchoRequest{first, {Response: &pb.EchoRequest_Content{Content: "World"}, Flush: true}, content("Late")}, "Hello World"},
+	}
+	for _, test := range tests {
+		// A nil expectation fails the test if a response is sent.
+		stream := &mockCollectStream{reqs: test.reqs, t: t}
+		err := NewEchoServer().Collect(stream)
+		st := status.Convert(err)
+		if st.Code() != codes.Aborted || st.Message() != "Aborted after the stream was read." {
+			t.Errorf("Collect(%s): want the scripted error, got %v", test.name, err)
+		}
+		details := st.Proto().GetDetails()
+		if len(details) != 1 {
+			t.Fatalf("Collect(%s): want 1 detail, got %d", test.name, len(details))
+		}
+		resp := &pb.EchoResponse{}
+		if err := ptypes.UnmarshalAny(details[0], resp); err != nil {
+			t.Fatalf("Collect(%s): %v", test.name, err)
+		}
+		if !proto.Equal(resp, &pb.EchoResponse{Content: test.want}) {
+			t.Errorf("Collect(%s): want the detail %q, got %v", test.name, test.want, resp)
+		}
+		if stream.sent != nil {
+			t.Errorf("Collect(%s): want no response, got %v", test.name, stream.sent)
+		}
+	}
+}
+
+func TestCollect_errorAfterCloseOK(t *testing.T) {
+	stream := &mockCollectStream{
+		reqs: []*pb.EchoRequest{{ErrorAfterClose: &spb.Status{Code: int32(codes.OK)}}},
+		t:    t,
+	}
+	err := NewEchoServer().Collect(stream)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Collect: want INVALID_ARGUMENT for an OK error_after_close, got %v", err)
+	}
+}
+
+func TestCollect_sequence(t *testing.T) {
+	msg := func(c string, seq int64) *pb.EchoRequest {
+		return &pb.EchoRequest{
+			Response: &pb.EchoRequest_Content{Content: c},
+			Sequence: &wrappers.Int64Value{Value: seq},
+		}
+	}
+	contiguous := func(r *pb.EchoRequest) *pb.EchoRequest {
+		r.RequireContiguous = true
+		return r
+	}
+	tests := []struct {
+		name string
+		reqs []*pb.EchoRequest
+		// The metadata of the error, or nil if the stream succeeds.
+		md map[string]string
+	}{
+		{"in order", []*pb.EchoRequest{contiguous(msg("a", 1)), msg("b", 2), msg("c", 3)}, nil},
+		{"gap allowed", []*pb.EchoRequest{msg("a", 1), msg("b", 5), msg("c", 9)}, nil},
+		{
+			"gap rejected",
+			[]*pb.EchoRequest{contiguous(msg("a", 1)), msg("b", 2), msg("c", 4)},
+			map[string]string{"index": "2", "expected": "3", "actual": "4"},
+		},
 		{
-			&pb.PagedExpandRequest{
-				PageSize:  3,
-				PageToken: "3",
-				Content:   "The rain in Spain falls mainly on the plain!",
-			},
-			&pb.PagedExpandResponse{
-				Responses: []*pb.EchoResponse{
-					&pb.EchoResponse{Content: "Spain"},
-					&pb.EchoResponse{Content: "falls"},
-					&pb.EchoResponse{Content: "mainly"},
-				},
-				NextPageToken: "6",
-			},
+			"duplicate",
+			[]*pb.EchoRequest{msg("a", 1), msg("b", 3), msg("c", 3)},
+			map[string]string{"index": "2", "expected": "4", "actual": "3"},
+		},
+		{
+			"decreasing",
+			[]*pb.EchoRequest{msg("a", 5), msg("b", 2)},
+			map[string]string{"index": "1", "expected": "6", "actual": "2"},
 		},
+		{
+			"missing",
+			[]*pb.EchoRequest{msg("a", 1), {Response: &pb.EchoRequest_Content{Content: "b"}}},
+			map[string]string{"index": "1", "expected": "2", "actual": "none"},
+		},
+	}
+	for _, test := range tests {
+		stream := &mockCollectStream{reqs: test.reqs, t: t}
+		if test.md == nil {
+			exp := "a b c"
+			stream.exp = &exp
+		}
+		err := NewEchoServer().Collect(stream)
+		if test.md == nil {
+			if err != nil {
+				t.Errorf("Collect(%s): %v", test.name, err)
+			}
+			continue
+		}
+		st := status.Convert(err)
+		if st.Code() != codes.FailedPrecondition {
+			t.Errorf("Collect(%s): want FAILED_PRECONDITION, got %v", test.name, err)
+			continue
+		}
+		reason, _, md := decodeErrorInfo(t, st.Proto().GetDetails()[0].GetValue())
+		if reason != "SEQUENCE_OUT_OF_ORDER" || !reflect.DeepEqual(md, test.md) {
+			t.Errorf("Collect(%s): want SEQUENCE_OUT_OF_ORDER with %v, got %s with %v", test.name, test.md, reason, md)
+		}
 	}
+}
 
-	server := NewEchoServer()
+func TestCollect_noSequence(t *testing.T) {
+	// Without a sequence on the first message, later sequences are ignored.
+	exp := "a b"
+	stream := &mockCollectStream{
+		reqs: []*pb.EchoRequest{
+			{Response: &pb.EchoRequest_Content{Content: "a"}},
+			{Response: &pb.EchoRequest_Content{Content: "b"}, Sequence: &wrappers.Int64Value{Value: -1}},
+		},
+		exp: &exp,
+		t:   t,
+	}
+	if err := NewEchoServer().Collect(stream); err != nil {
+		t.Errorf("Collect: %v", err)
+	}
+}
+
+func TestEcho_forward(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	echo := NewEchoServer().(*echoServerImpl)
+	echo.forwarder = server.NewForwarder()
+	echo.forwarder.Watch(lis.Addr())
+	s := grpc.NewServer()
+	pb.RegisterEchoServer(s, echo)
+	go s.Serve(lis)
+	defer s.Stop()
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewEchoClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs(
+		server.ForwardPropagatePrefix+"trace", "abc",
+		"x-other", "first hop only"))
+	resp, err := client.Echo(ctx, &pb.EchoRequest{
+		Response:     &pb.EchoRequest_Content{Content: "hi"},
+		ForwardDepth: 3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetContent() != "hi" {
+		t.Errorf("Echo: want the content of the innermost call, got %q", resp.GetContent())
+	}
+	hops := resp.GetForwardHops()
+	if len(hops) != 4 {
+		t.Fatalf("Echo: want 4 hops, got %v", hops)
+	}
+	last := 10 * time.Second
+	for i, hop := range hops {
+		if hop.GetHop() != int32(i) {
+			t.Errorf("Echo: want hop %d, got %d", i, hop.GetHop())
+		}
+		remaining, err := ptypes.Duration(hop.GetRemainingDeadline())
+		if err != nil || remaining > last {
+			t.Errorf("Echo: want the deadline of hop %d at most %s, got %v", i, last, hop.GetRemainingDeadline())
+		}
+		last = remaining
+		if got := hop.GetHeaders()[server.ForwardPropagatePrefix+"trace"]; got != "abc" {
+			t.Errorf("Echo: want the propagated header on hop %d, got %q", i, got)
+		}
+		if _, ok := hop.GetHeaders()["x-other"]; ok != (i == 0) {
+			t.Errorf("Echo: want x-other only on the first hop, got it on hop %d: %t", i, ok)
+		}
+	}
+
+	if _, err := client.Echo(context.Background(), &pb.EchoRequest{ForwardDepth: server.MaxForwardDepth + 1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Echo: want InvalidArgument for a forward_depth over %d, got %v", server.MaxForwardDepth, err)
+	}
+	// A chain that started elsewhere is cut short.
+	ctx = metadata.NewOutgoingContext(context.Background(), metadata.Pairs(server.ForwardHopHeader, "4"))
+	_, err = client.Echo(ctx, &pb.EchoRequest{ForwardDepth: 2})
+	if st := status.Convert(err); st.Code() != codes.Aborted {
+		t.Errorf("Echo: want Aborted for a chain longer than %d, got %v", server.MaxForwardDepth, err)
+	} else if reason, _, _ := decodeErrorInfo(t, st.Proto().GetDetails()[0].GetValue()); reason != "FORWARD_LOOP" {
+		t.Errorf("Echo: want the reason FORWARD_LOOP, got %s", reason)
+	}
+}
+
+func TestEcho_quotaProject(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings := server.NewSettingsStore(server.DefaultSettings())
+	unary, stream := interceptors.Chain(interceptors.Options{Settings: settings})
+	s := grpc.NewServer(grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+	pb.RegisterEchoServer(s, NewEchoServer())
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewEchoClient(conn)
+	echo := func(md ...string) (*pb.EchoResponse, error) {
+		ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs(md...))
+		return client.Echo(ctx, &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}})
+	}
+
+	resp, err := echo(server.QuotaProjectHeader, "my-project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.GetQuotaProject(); got != "my-project" {
+		t.Errorf("Echo: want quota project my-project, got %q", got)
+	}
+	resp, err = echo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.GetQuotaProject(); got != "" {
+		t.Errorf("Echo: want no quota project without the header, got %q", got)
+	}
+	// Invalid projects are echoed unless the server is strict.
+	resp, err = echo(server.QuotaProjectHeader, "My Project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.GetQuotaProject(); got != "My Project" {
+		t.Errorf("Echo: want quota project %q, got %q", "My Project", got)
+	}
+
+	settings.Update(func(s *server.Settings) error {
+		s.StrictQuotaProject = true
+		return nil
+	})
+	for _, project := range []string{"", "My Project", "abc"} {
+		_, err := echo(server.QuotaProjectHeader, project)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Echo(%q): want InvalidArgument, got %v", project, err)
+			continue
+		}
+		details := status.Convert(err).Proto().GetDetails()
+		if len(details) != 1 {
+			t.Fatalf("Echo(%q): want 1 detail, got %d", project, len(details))
+		}
+		reason, _, md := decodeErrorInfo(t, details[0].GetValue())
+		if reason != "INVALID_QUOTA_PROJECT" || md["metadata"] != server.QuotaProjectHeader {
+			t.Errorf("Echo(%q): want reason INVALID_QUOTA_PROJECT about %s, got %s %v", project, server.QuotaProjectHeader, reason, md)
+		}
+	}
+	if resp, err := echo(server.QuotaProjectHeader, "my-project"); err != nil || resp.GetQuotaProject() != "my-project" {
+		t.Errorf("Echo: want quota project my-project when strict, got %v, %v", resp, err)
+	}
+	if _, err := echo(); err != nil {
+		t.Errorf("Echo: want no error without the header when strict, got %v", err)
+	}
+}
+
+func TestExpand_streamStatus(t *testing.T) {
+	echo := &echoServerImpl{expandStatus: server.NewExpandStatusStore(time.Now, 10, time.Minute)}
+	tests := []struct {
+		id   string
+		err  *spb.Status
+		want pb.ExpandStatus_Termination
+	}{
+		{"ok", nil, pb.ExpandStatus_COMPLETED},
+		{"failed", &spb.Status{Code: int32(codes.Aborted)}, pb.ExpandStatus_FAILED},
+	}
 	for _, test := range tests {
-		out, err := server.PagedExpand(context.Background(), test.in)
+		stream := &collectingExpandStream{}
+		echo.Expand(&pb.ExpandRequest{Content: "a bb ccc", Error: test.err, StreamId: test.id}, stream)
+		got, err := echo.GetLastExpandStatus(context.Background(), &pb.GetLastExpandStatusRequest{StreamId: test.id})
 		if err != nil {
-			t.Error(err)
+			t.Fatalf("GetLastExpandStatus(%s): %v", test.id, err)
 		}
-		if !proto.Equal(test.out, out) {
-			t.Errorf("PagedExpand with input '%q', expected: '%q', got: %q",
-				test.in.String(), test.out.String(), out.String())
+		bytes := int64(0)
+		for _, resp := range stream.sent {
+			bytes += int64(proto.Size(resp))
+		}
+		want := &pb.ExpandStatus{StreamId: test.id, MessagesSent: 3, BytesSent: bytes, Termination: test.want}
+		if !proto.Equal(got, want) {
+			t.Errorf("GetLastExpandStatus(%s): want %v got %v", test.id, want, got)
 		}
 	}
+
+	_, err := echo.GetLastExpandStatus(context.Background(), &pb.GetLastExpandStatusRequest{StreamId: "unknown"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetLastExpandStatus(unknown): want NotFound got %v", err)
+	}
+	_, err = echo.GetLastExpandStatus(context.Background(), &pb.GetLastExpandStatusRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetLastExpandStatus(): want InvalidArgument got %v", err)
+	}
 }
 
-func TestWait(t *testing.T) {
-	endTime, _ := ptypes.TimestampProto(time.Now())
-	req := &pb.WaitRequest{End: &pb.WaitRequest_EndTime{EndTime: endTime}}
-	waiter := &mockWaiter{}
-	server := &echoServerImpl{waiter: waiter}
-	server.Wait(context.Background(), req)
-	if !proto.Equal(waiter.req, req) {
-		t.Error("Expected echo.Wait to defer to waiter.")
+func TestExpand_streamStatusCancelled(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	pb.RegisterEchoServer(s, &echoServerImpl{
+		settings:     server.NewSettingsStore(server.DefaultSettings()),
+		expandStatus: server.NewExpandStatusStore(time.Now, 10, time.Minute),
+	})
+	go s.Serve(lis)
+	defer s.Stop()
+
+	// A fixed window turns off window growth, which bounds what the server
+	// can send ahead of the client.
+	const window = 64 * 1024
+	conn, err := grpc.Dial(
+		lis.Addr().String(),
+		grpc.WithInsecure(),
+		grpc.WithInitialWindowSize(window),
+		grpc.WithInitialConnWindowSize(window))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewEchoClient(conn)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.Expand(ctx, &pb.ExpandRequest{
+		Content:     strings.Repeat("x", 1024),
+		RepeatCount: 100000,
+		StreamId:    "cancelled",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	received, receivedBytes := int64(0), int64(0)
+	for ; received < 10; received++ {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		receivedBytes += int64(proto.Size(resp))
+	}
+	cancel()
+
+	var got *pb.ExpandStatus
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		got, err = client.GetLastExpandStatus(context.Background(), &pb.GetLastExpandStatusRequest{StreamId: "cancelled"})
+		if status.Code(err) != codes.NotFound {
+			break
+		}
+	}
+	if err != nil {
+		t.Fatalf("GetLastExpandStatus: %v", err)
+	}
+	if got.GetTermination() != pb.ExpandStatus_CANCELLED {
+		t.Errorf("GetLastExpandStatus: want termination CANCELLED got %s", got.GetTermination())
+	}
+	// Besides the windows of the stream and the connection, the server
+	// buffers a window of writes and a write buffer of 32 KiB.
+	const slack = 3*window + 32*1024
+	if got.GetMessagesSent() < received || got.GetBytesSent() > receivedBytes+slack {
+		t.Errorf("GetLastExpandStatus: want between %d and %d bytes from at least %d messages, got %d bytes from %d messages",
+			receivedBytes, receivedBytes+slack, received, got.GetBytesSent(), got.GetMessagesSent())
+	}
+}
+
+func TestChat_maxStreamDuration(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings := server.DefaultSettings()
+	settings.MaxStreamDuration = 200 * time.Millisecond
+	limiter := server.NewStreamDurationLimiter(server.NewSettingsStore(settings))
+	s := grpc.NewServer(grpc.StreamInterceptor(limiter.StreamInterceptor))
+	pb.RegisterEchoServer(s, NewEchoServer())
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewEchoClient(conn)
+
+	// A stream that ends before its timer fires is unaffected.
+	expand, err := client.Expand(context.Background(), &pb.ExpandRequest{Content: "a b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		if _, err := expand.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("Expand: want no error got %v", err)
+		}
+	}
+
+	chat, err := client.Chat(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := chat.Send(&pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chat.Recv(); err != nil {
+		t.Fatal(err)
+	}
+	// The server is blocked receiving the next message when the stream
+	// runs out of time.
+	_, err = chat.Recv()
+	if status.Code(err) != codes.Aborted {
+		t.Fatalf("Chat: want Aborted after the limit got %v", err)
+	}
+	details := status.Convert(err).Proto().GetDetails()
+	if len(details) != 1 {
+		t.Fatalf("Chat: want 1 detail got %d", len(details))
+	}
+	reason, _, md := decodeErrorInfo(t, details[0].GetValue())
+	if reason != "STREAM_MAX_DURATION_EXCEEDED" || md["max_duration"] != "200ms" {
+		t.Errorf("Chat: want reason STREAM_MAX_DURATION_EXCEEDED with max_duration 200ms got %s %v", reason, md)
+	}
+}
+
+// rawJSONCodec sends and receives the bytes of calls as they are.
+type rawJSONCodec struct{}
+
+func (rawJSONCodec) Name() string { return "json" }
+
+func (rawJSONCodec) Marshal(v interface{}) ([]byte, error) { return *v.(*[]byte), nil }
+
+func (rawJSONCodec) Unmarshal(data []byte, v interface{}) error {
+	*v.(*[]byte) = append([]byte(nil), data...)
+	return nil
+}
+
+func TestEcho_jsonCodec(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	codec := server.NewJSONCodec()
+	encoding.RegisterCodec(codec)
+	unary, stream := interceptors.Chain(interceptors.Options{JSONCodec: codec})
+	s := grpc.NewServer(grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+	pb.RegisterEchoServer(s, NewEchoServer())
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewEchoClient(conn)
+	asJSON := grpc.CallContentSubtype("json")
+
+	// The responses over JSON match those over proto, but for the server
+	// sequence.
+	in := &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}, ClientSequence: 1 << 40, RepeatCount: 2}
+	viaProto, err := client.Echo(context.Background(), in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	viaJSON, err := client.Echo(context.Background(), in, asJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	viaProto.ServerSequence, viaJSON.ServerSequence = 0, 0
+	if !proto.Equal(viaProto, viaJSON) {
+		t.Errorf("Echo: want %v over JSON got %v", viaProto, viaJSON)
+	}
+
+	pagedIn := &pb.PagedExpandRequest{Content: "one two three four five", PageSize: 2}
+	for {
+		viaProto, err := client.PagedExpand(context.Background(), pagedIn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		viaJSON, err := client.PagedExpand(context.Background(), pagedIn, asJSON)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(viaProto, viaJSON) {
+			t.Errorf("PagedExpand(%q): want %v over JSON got %v", pagedIn.GetPageToken(), viaProto, viaJSON)
+		}
+		if pagedIn.PageToken = viaJSON.GetNextPageToken(); pagedIn.PageToken == "" {
+			break
+		}
+	}
+
+	raw := func(body string) (string, error) {
+		req, resp := []byte(body), []byte(nil)
+		err := conn.Invoke(context.Background(), "/google.showcase.v1beta1.Echo/Echo", &req, &resp, asJSON, grpc.ForceCodec(rawJSONCodec{}))
+		return string(resp), err
+	}
+	// 64-bit integers are accepted as strings.
+	if got, err := raw(`{"content":"hi","clientSequence":"7"}`); err != nil || !strings.Contains(got, `"clientSequence":"7"`) {
+		t.Errorf("Echo: want the client sequence echoed, got %s, %v", got, err)
+	}
+	_, err = raw(`{"content":"hi","bogus":true}`)
+	if status.Code(err) != codes.InvalidArgument || !strings.Contains(status.Convert(err).Message(), "bogus") {
+		t.Errorf("Echo: want InvalidArgument naming the unknown field, got %v", err)
+	}
+}
+
+type mockPullStream struct {
+	pb.Echo_StreamingPullEchoServer
+	recvs chan pullRecv
+	sent  chan *pb.StreamingPullEchoResponse
+}
+
+func newMockPullStream() *mockPullStream {
+	return &mockPullStream{recvs: make(chan pullRecv, 1), sent: make(chan *pb.StreamingPullEchoResponse, 10)}
+}
+
+func (m *mockPullStream) Recv() (*pb.StreamingPullEchoRequest, error) {
+	r := <-m.recvs
+	return r.req, r.err
+}
+
+func (m *mockPullStream) Context() context.Context {
+	return context.Background()
+}
+
+func (m *mockPullStream) Send(r *pb.StreamingPullEchoResponse) error {
+	m.sent <- r
+	return nil
+}
+
+func (m *mockPullStream) send(req *pb.StreamingPullEchoRequest) {
+	m.recvs <- pullRecv{req: req}
+}
+
+// received returns the ack IDs of the next response of the stream.
+func (m *mockPullStream) received(t *testing.T) []string {
+	select {
+	case r := <-m.sent:
+		ids := []string{}
+		for _, msg := range r.GetReceivedMessages() {
+			ids = append(ids, msg.GetAckId())
+		}
+		return ids
+	case <-time.After(5 * time.Second):
+		t.Fatal("StreamingPullEcho sent nothing")
+		return nil
+	}
+}
+
+// newTopicServer returns an echo server with its own topics, whose timers
+// are created on the returned channel, with their durations, and fired by
+// the test after it moves the clock.
+func newTopicServer() (echo *echoServerImpl, now *time.Time, timers chan topicTimer) {
+	start := time.Unix(1000, 0)
+	now = &start
+	timers = make(chan topicTimer, 10)
+	echo = &echoServerImpl{
+		topics: server.NewTopicStore(),
+		nowF:   func() time.Time { return *now },
+		afterF: func(d time.Duration) <-chan time.Time {
+			c := make(chan time.Time, 1)
+			timers <- topicTimer{d, c}
+			return c
+		},
+	}
+	return echo, now, timers
+}
+
+type topicTimer struct {
+	d time.Duration
+	c chan time.Time
+}
+
+func publish(t *testing.T, echo *echoServerImpl, messages ...string) {
+	if _, err := echo.PublishEcho(context.Background(), &pb.PublishEchoRequest{Topic: "topics/t", Messages: messages}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStreamingPullEcho(t *testing.T) {
+	echo, now, timers := newTopicServer()
+	publish(t, echo, "one", "two")
+	publish(t, echo, "three")
+
+	stream := newMockPullStream()
+	result := make(chan error, 1)
+	go func() { result <- echo.StreamingPullEcho(stream) }()
+	stream.send(&pb.StreamingPullEchoRequest{Topic: "topics/t", AckDeadline: ptypes.DurationProto(30 * time.Second)})
+	if got, want := stream.received(t), []string{"1-1", "2-1", "3-1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("StreamingPullEcho: want the messages in order %v got %v", want, got)
+	}
+	if timer := <-timers; timer.d != 30*time.Second {
+		t.Errorf("StreamingPullEcho: want to wait out the 30s ack deadline got %s", timer.d)
+	}
+
+	// The acknowledged messages are not delivered again.
+	stream.send(&pb.StreamingPullEchoRequest{AckIds: []string{"1-1", "3-1"}})
+	timer := <-timers
+	*now = now.Add(30 * time.Second)
+	timer.c <- *now
+	if got, want := stream.received(t), []string{"2-2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("StreamingPullEcho after the ack deadline: want only the unacknowledged %v got %v", want, got)
+	}
+	<-timers
+
+	// The stream pushes messages as they are published.
+	publish(t, echo, "four")
+	if got, want := stream.received(t), []string{"4-1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("StreamingPullEcho after a publish: want %v got %v", want, got)
+	}
+	<-timers
+
+	stream.recvs <- pullRecv{err: io.EOF}
+	if err := <-result; err != nil {
+		t.Errorf("StreamingPullEcho: unexpected err %v", err)
+	}
+}
+
+func TestStreamingPullEcho_subscribers(t *testing.T) {
+	echo, _, _ := newTopicServer()
+	echo.afterF = func(time.Duration) <-chan time.Time { return nil }
+	publish(t, echo, "one", "two", "three", "four")
+
+	start := func() *mockPullStream {
+		stream := newMockPullStream()
+		go echo.StreamingPullEcho(stream)
+		stream.send(&pb.StreamingPullEchoRequest{Topic: "topics/t", MaxOutstandingMessages: 1})
+		return stream
+	}
+	a := start()
+	if got := a.received(t); !reflect.DeepEqual(got, []string{"1-1"}) {
+		t.Errorf("StreamingPullEcho(a): want 1-1 got %v", got)
+	}
+	b := start()
+	if got := b.received(t); !reflect.DeepEqual(got, []string{"2-1"}) {
+		t.Errorf("StreamingPullEcho(b): want 2-1 got %v", got)
+	}
+	a.send(&pb.StreamingPullEchoRequest{AckIds: []string{"1-1"}})
+	if got := a.received(t); !reflect.DeepEqual(got, []string{"3-1"}) {
+		t.Errorf("StreamingPullEcho(a) after an ack: want 3-1 got %v", got)
+	}
+	// A stream may acknowledge the messages of another.
+	a.send(&pb.StreamingPullEchoRequest{AckIds: []string{"2-1", "3-1"}})
+	if got := a.received(t); !reflect.DeepEqual(got, []string{"4-1"}) {
+		t.Errorf("StreamingPullEcho(a) after acks: want 4-1 got %v", got)
+	}
+	for _, s := range []*mockPullStream{a, b} {
+		s.recvs <- pullRecv{err: io.EOF}
+	}
+}
+
+func TestStreamingPullEcho_invalid(t *testing.T) {
+	echo, _, _ := newTopicServer()
+	echo.afterF = func(time.Duration) <-chan time.Time { return nil }
+	tests := []struct {
+		req      *pb.StreamingPullEchoRequest
+		wantCode codes.Code
+	}{
+		{&pb.StreamingPullEchoRequest{}, codes.InvalidArgument},
+		{&pb.StreamingPullEchoRequest{Topic: "t"}, codes.InvalidArgument},
+		{&pb.StreamingPullEchoRequest{Topic: "topics/t", AckDeadline: ptypes.DurationProto(time.Millisecond)}, codes.InvalidArgument},
+		{&pb.StreamingPullEchoRequest{Topic: "topics/t", AckDeadline: ptypes.DurationProto(time.Hour)}, codes.InvalidArgument},
+		{&pb.StreamingPullEchoRequest{Topic: "topics/t", MaxOutstandingMessages: -1}, codes.InvalidArgument},
+		{&pb.StreamingPullEchoRequest{Topic: "topics/t"}, codes.NotFound},
+	}
+	for _, test := range tests {
+		stream := newMockPullStream()
+		stream.send(test.req)
+		if err := echo.StreamingPullEcho(stream); status.Code(err) != test.wantCode {
+			t.Errorf("StreamingPullEcho(%v): want %s got %v", test.req, test.wantCode, err)
+		}
+	}
+
+	publish(t, echo, "one")
+	stream := newMockPullStream()
+	result := make(chan error, 1)
+	go func() { result <- echo.StreamingPullEcho(stream) }()
+	stream.send(&pb.StreamingPullEchoRequest{Topic: "topics/t"})
+	stream.received(t)
+	stream.send(&pb.StreamingPullEchoRequest{Topic: "topics/other"})
+	if err := <-result; status.Code(err) != codes.InvalidArgument {
+		t.Errorf("StreamingPullEcho of another topic: want InvalidArgument got %v", err)
+	}
+}
+
+func TestPublishEcho_invalid(t *testing.T) {
+	echo, _, _ := newTopicServer()
+	for _, req := range []*pb.PublishEchoRequest{
+		{Messages: []string{"one"}},
+		{Topic: "topics/a/b", Messages: []string{"one"}},
+		{Topic: "topics/t"},
+	} {
+		if _, err := echo.PublishEcho(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("PublishEcho(%v): want InvalidArgument got %v", req, err)
+		}
+	}
+}
+
+func TestExpand_emitEmptyMessages(t *testing.T) {
+	tests := []struct {
+		req       *pb.ExpandRequest
+		wantCount int
+		wantErr   codes.Code
+	}{
+		{&pb.ExpandRequest{}, 0, codes.OK},
+		{&pb.ExpandRequest{Content: " \t\n "}, 0, codes.OK},
+		{&pb.ExpandRequest{Content: "a b"}, 2, codes.OK},
+		{&pb.ExpandRequest{EmitEmptyMessages: 3}, 3, codes.OK},
+		{&pb.ExpandRequest{EmitEmptyMessages: 2, RepeatCount: 2}, 4, codes.OK},
+		{&pb.ExpandRequest{EmitEmptyMessages: 1, Error: &spb.Status{Code: int32(codes.Aborted)}}, 1, codes.Aborted},
+		{&pb.ExpandRequest{Error: &spb.Status{Code: int32(codes.Aborted)}}, 0, codes.Aborted},
+	}
+	for _, test := range tests {
+		stream := &collectingExpandStream{}
+		err := NewEchoServer().Expand(test.req, stream)
+		if status.Code(err) != test.wantErr {
+			t.Errorf("Expand(%v): want %s got %v", test.req, test.wantErr, err)
+		}
+		if len(stream.sent) != test.wantCount {
+			t.Errorf("Expand(%v): want %d responses got %d", test.req, test.wantCount, len(stream.sent))
+		}
+		for _, resp := range stream.sent {
+			if test.req.GetEmitEmptyMessages() > 0 && resp.GetContent() != "" {
+				t.Errorf("Expand(%v): want empty responses got %v", test.req, resp)
+			}
+		}
+		if got, want := stream.trailer.Get(expandCountTrailer), []string{strconv.Itoa(test.wantCount)}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Expand(%v): want the trailer %s %v got %v", test.req, expandCountTrailer, want, got)
+		}
+	}
+}
+
+func TestExpand_countTrailerOnSendErr(t *testing.T) {
+	stream := &countTrailerExpandStream{failAt: 2}
+	err := NewEchoServer().Expand(&pb.ExpandRequest{Content: "a b c"}, stream)
+	if err != io.ErrClosedPipe {
+		t.Errorf("Expand: want the send error got %v", err)
+	}
+	if got := stream.trailer.Get(expandCountTrailer); !reflect.DeepEqual(got, []string{"1"}) {
+		t.Errorf("Expand: want the trailer %s of the one word sent got %v", expandCountTrailer, got)
+	}
+}
+
+// countTrailerExpandStream fails the send of message failAt, counting from 1,
+// and records the trailers.
+type countTrailerExpandStream struct {
+	collectingExpandStream
+	failAt int
+}
+
+func (m *countTrailerExpandStream) Send(resp *pb.EchoResponse) error {
+	if len(m.sent)+1 == m.failAt {
+		return io.ErrClosedPipe
+	}
+	return m.collectingExpandStream.Send(resp)
+}
+
+func TestExpand_emitEmptyMessagesInvalid(t *testing.T) {
+	server := NewEchoServer()
+	tests := []struct {
+		req    *pb.ExpandRequest
+		reason string
+	}{
+		{&pb.ExpandRequest{EmitEmptyMessages: -1}, showcaseerrors.FieldOutOfRange},
+		{&pb.ExpandRequest{EmitEmptyMessages: 1, Content: "a"}, showcaseerrors.FieldConflict},
+		{&pb.ExpandRequest{EmitEmptyMessages: 1, Content: " "}, showcaseerrors.FieldConflict},
+		{&pb.ExpandRequest{EmitEmptyMessages: 1, CorpusName: "words"}, showcaseerrors.FieldConflict},
+		{&pb.ExpandRequest{EmitEmptyMessages: 1, SizePattern: []int32{1}}, showcaseerrors.FieldConflict},
+	}
+	for _, test := range tests {
+		stream := &collectingExpandStream{}
+		err := server.Expand(test.req, stream)
+		st := status.Convert(err)
+		if st.Code() != codes.InvalidArgument || len(stream.sent) != 0 {
+			t.Errorf("Expand(%v): want InvalidArgument before anything is sent got %v", test.req, err)
+			continue
+		}
+		reason, _, md := decodeErrorInfo(t, st.Proto().GetDetails()[0].GetValue())
+		if reason != test.reason || md["field"] != "emit_empty_messages" {
+			t.Errorf("Expand(%v): want %s on emit_empty_messages got %s %v", test.req, test.reason, reason, md)
+		}
 	}
 }
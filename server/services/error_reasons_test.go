@@ -0,0 +1,120 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/googleapis/gapic-showcase/server"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidationErrorReasons(t *testing.T) {
+	echo := NewEchoServer()
+	testingServer := NewTestingServer(server.ShowcaseObserverRegistry())
+	withMetadata := func(kv ...string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...))
+	}
+	call := func(_ interface{}, err error) error { return err }
+
+	tests := []struct {
+		name   string
+		err    error
+		reason string
+		md     map[string]string
+	}{
+		{
+			"required field",
+			call(testingServer.GetOperationPollingReport(context.Background(), &pb.GetOperationPollingReportRequest{})),
+			showcaseerrors.FieldRequired,
+			map[string]string{"field": "name"},
+		},
+		{
+			"field out of range",
+			call(testingServer.SetMethodOverload(context.Background(), &pb.SetMethodOverloadRequest{Method: "/m", QpsLimit: -1})),
+			showcaseerrors.FieldOutOfRange,
+			map[string]string{"field": "qps_limit"},
+		},
+		{
+			"invalid field",
+			call(echo.Echo(context.Background(), &pb.EchoRequest{ValidateContentRegex: "(unclosed"})),
+			showcaseerrors.FieldInvalid,
+			map[string]string{"field": "validate_content_regex"},
+		},
+		{
+			"conflicting fields",
+			call(echo.Echo(context.Background(), &pb.EchoRequest{CacheControl: &pb.CacheControl{NoStore: true, MaxAge: 1}})),
+			showcaseerrors.FieldConflict,
+			map[string]string{"field": "cache_control.max_age"},
+		},
+		{
+			"page token",
+			call(echo.PagedExpand(context.Background(), &pb.PagedExpandRequest{Content: "a b", PageToken: "x"})),
+			showcaseerrors.PageTokenInvalid,
+			map[string]string{"field": "page_token"},
+		},
+		{
+			"stream order",
+			echo.WriteBlob(&mockWriteBlobStream{}),
+			showcaseerrors.StreamOutOfOrder,
+			map[string]string{"field": "spec"},
+		},
+		{
+			"metadata",
+			call(echo.Echo(withMetadata("accept-language", "!"), &pb.EchoRequest{})),
+			showcaseerrors.MetadataInvalid,
+			map[string]string{"metadata": "accept-language"},
+		},
+		{
+			"setting",
+			call(testingServer.UpdateShowcaseSettings(context.Background(), &pb.UpdateShowcaseSettingsRequest{
+				Settings:   &pb.ShowcaseSettings{MaxPollWait: ptypes.DurationProto(-1)},
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"max_poll_wait"}},
+			})),
+			showcaseerrors.SettingInvalid,
+			map[string]string{"setting": "max_poll_wait"},
+		},
+	}
+	for _, test := range tests {
+		st := status.Convert(test.err)
+		details := st.Proto().GetDetails()
+		if st.Code() != codes.InvalidArgument || len(details) == 0 {
+			t.Errorf("%s: want INVALID_ARGUMENT with an ErrorInfo got %v", test.name, test.err)
+			continue
+		}
+		// The ErrorInfo follows any BadRequest detail.
+		info := details[len(details)-1]
+		if info.GetTypeUrl() != "type.googleapis.com/google.rpc.ErrorInfo" {
+			t.Errorf("%s: want an ErrorInfo detail got %v", test.name, details)
+			continue
+		}
+		reason, domain, md := decodeErrorInfo(t, info.GetValue())
+		if reason != test.reason || domain != showcaseerrors.Domain {
+			t.Errorf("%s: want the reason %s in %s got %s in %s", test.name, test.reason, showcaseerrors.Domain, reason, domain)
+		}
+		for k, v := range test.md {
+			if md[k] != v {
+				t.Errorf("%s: want the metadata %s=%s got %v", test.name, k, v, md)
+			}
+		}
+	}
+}
@@ -19,10 +19,11 @@ import (
 	"fmt"
 	"sync"
 
-	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/googleapis/gapic-showcase/server"
+	"github.com/googleapis/gapic-showcase/server/filtering"
 	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -30,8 +31,9 @@ import (
 // NewIdentityServer returns a new instance of showcase identity server.
 func NewIdentityServer() pb.IdentityServer {
 	return &identityServerImpl{
-		token: server.NewTokenGenerator(),
-		keys:  map[string]int{},
+		token:    server.NewTokenGenerator(),
+		keys:     map[string]int{},
+		settings: server.GetSettingsInstance(),
 	}
 }
 
@@ -47,8 +49,9 @@ type ReadOnlyIdentityServer interface {
 }
 
 type identityServerImpl struct {
-	uid   server.UniqID
-	token server.TokenGenerator
+	uid      server.UniqID
+	token    server.TokenGenerator
+	settings server.SettingsStore
 
 	mu    sync.Mutex
 	keys  map[string]int
@@ -73,7 +76,7 @@ func (s *identityServerImpl) CreateUser(_ context.Context, in *pb.CreateUserRequ
 	// Assign info.
 	id := s.uid.Next()
 	name := fmt.Sprintf("users/%d", id)
-	now := ptypes.TimestampNow()
+	now := server.TimestampNow()
 
 	u.Name = name
 	u.CreateTime = now
@@ -89,6 +92,9 @@ func (s *identityServerImpl) CreateUser(_ context.Context, in *pb.CreateUserRequ
 
 // Retrieves the User with the given uri.
 func (s *identityServerImpl) GetUser(_ context.Context, in *pb.GetUserRequest) (*pb.User, error) {
+	if err := server.CheckFieldMask("read_mask", in.GetReadMask(), &pb.User{}); err != nil {
+		return nil, err
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -96,7 +102,7 @@ func (s *identityServerImpl) GetUser(_ context.Context, in *pb.GetUserRequest) (
 	if i, ok := s.keys[name]; ok {
 		entry := s.users[i]
 		if !entry.deleted {
-			return entry.user, nil
+			return server.MaskedCopy(in.GetReadMask(), entry.user).(*pb.User), nil
 		}
 	}
 
@@ -136,7 +142,7 @@ func (s *identityServerImpl) UpdateUser(_ context.Context, in *pb.UpdateUserRequ
 		DisplayName: u.GetDisplayName(),
 		Email:       u.GetEmail(),
 		CreateTime:  entry.user.GetCreateTime(),
-		UpdateTime:  ptypes.TimestampNow(),
+		UpdateTime:  server.TimestampNow(),
 	}
 	s.users[i] = userEntry{user: updated}
 	return u, nil
@@ -163,7 +169,17 @@ func (s *identityServerImpl) DeleteUser(_ context.Context, in *pb.DeleteUserRequ
 
 // Lists all users.
 func (s *identityServerImpl) ListUsers(_ context.Context, in *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
-	start, err := s.token.GetIndex(in.GetPageToken())
+	if err := server.CheckFieldMask("read_mask", in.GetReadMask(), &pb.User{}); err != nil {
+		return nil, err
+	}
+	f, err := filtering.Parse(in.GetFilter(), userFilterFields)
+	if err != nil {
+		return nil, showcaseerrors.BadRequest(
+			showcaseerrors.FieldInvalid,
+			"filter",
+			fmt.Sprintf("The field `filter` is invalid at %s.", err))
+	}
+	start, err := s.token.GetIndexWithTTL(in.GetPageToken(), in.GetPageTokenTtl())
 	if err != nil {
 		return nil, err
 	}
@@ -172,14 +188,17 @@ func (s *identityServerImpl) ListUsers(_ context.Context, in *pb.ListUsersReques
 	users := []*pb.User{}
 	for _, entry := range s.users[start:] {
 		offset++
-		if entry.deleted {
+		if entry.deleted || !f.Matches(userField(entry.user)) {
 			continue
 		}
-		users = append(users, entry.user)
+		users = append(users, server.MaskedCopy(in.GetReadMask(), entry.user).(*pb.User))
 		if len(users) >= int(in.GetPageSize()) {
 			break
 		}
 	}
+	server.ScramblePage(s.settings, start, len(users), func(i, j int) {
+		users[i], users[j] = users[j], users[i]
+	})
 
 	nextToken := ""
 	if start+offset < len(s.users) {
@@ -189,16 +208,34 @@ func (s *identityServerImpl) ListUsers(_ context.Context, in *pb.ListUsersReques
 	return &pb.ListUsersResponse{Users: users, NextPageToken: nextToken}, nil
 }
 
+// userFilterFields are the fields a ListUsers filter may compare.
+var userFilterFields = map[string]filtering.Type{
+	"display_name": filtering.String,
+	"email":        filtering.String,
+}
+
+// userField returns the values of the filter fields of a user.
+func userField(u *pb.User) func(string) interface{} {
+	return func(field string) interface{} {
+		if field == "email" {
+			return u.GetEmail()
+		}
+		return u.GetDisplayName()
+	}
+}
+
 func (s *identityServerImpl) validate(u *pb.User) error {
 	// Validate Required Fields.
 	if u.GetDisplayName() == "" {
-		return status.Errorf(
-			codes.InvalidArgument,
+		return showcaseerrors.Field(
+			showcaseerrors.FieldRequired,
+			"display_name",
 			"The field `display_name` is required.")
 	}
 	if u.GetEmail() == "" {
-		return status.Errorf(
-			codes.InvalidArgument,
+		return showcaseerrors.Field(
+			showcaseerrors.FieldRequired,
+			"email",
 			"The field `email` is required.")
 	}
 	// Validate Unique Fields.
@@ -17,11 +17,19 @@ package services
 import (
 	"context"
 	"encoding/base64"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/googleapis/gapic-showcase/server"
 	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/showcasefuzz"
 	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -380,3 +388,209 @@ func Test_List_invalidToken(t *testing.T) {
 		}
 	}
 }
+
+func Test_ListUsers_pageTokenTTL(t *testing.T) {
+	now := time.Unix(100, 0)
+	s := &identityServerImpl{
+		token: server.TokenGeneratorWithClock("salt", func() time.Time { return now }),
+		keys:  map[string]int{},
+	}
+	for _, name := range []string{"a", "b"} {
+		user := &pb.User{DisplayName: name, Email: name + "@example.com"}
+		if _, err := s.CreateUser(context.Background(), &pb.CreateUserRequest{User: user}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ttl := ptypes.DurationProto(time.Minute)
+	list := func(token string) (*pb.ListUsersResponse, error) {
+		return s.ListUsers(context.Background(), &pb.ListUsersRequest{PageSize: 1, PageToken: token, PageTokenTtl: ttl})
+	}
+
+	first, err := list("")
+	if err != nil {
+		t.Fatalf("ListUsers: unexpected err %+v", err)
+	}
+	now = now.Add(time.Minute + time.Second)
+	if _, err := list(first.GetNextPageToken()); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("ListUsers with an expired token: want FailedPrecondition got %v", err)
+	}
+
+	// Starting over mints a fresh token.
+	first, err = list("")
+	if err != nil {
+		t.Fatalf("ListUsers: unexpected err %+v", err)
+	}
+	second, err := list(first.GetNextPageToken())
+	if err != nil || len(second.GetUsers()) != 1 || second.GetUsers()[0].GetDisplayName() != "b" {
+		t.Errorf("ListUsers with a fresh token: want user b got %v, %v", second, err)
+	}
+}
+
+func Test_User_readMask(t *testing.T) {
+	s := NewIdentityServer()
+	created, err := s.CreateUser(
+		context.Background(),
+		&pb.CreateUserRequest{User: &pb.User{DisplayName: "ekkodog", Email: "ekko@google.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mask := &field_mask.FieldMask{Paths: []string{"name", "create_time.seconds"}}
+	want := &pb.User{
+		Name:       created.GetName(),
+		CreateTime: &timestamp.Timestamp{Seconds: created.GetCreateTime().GetSeconds()},
+	}
+
+	got, err := s.GetUser(context.Background(), &pb.GetUserRequest{Name: created.GetName(), ReadMask: mask})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("GetUser with a read_mask: want %v got %v", want, got)
+	}
+	list, err := s.ListUsers(context.Background(), &pb.ListUsersRequest{PageSize: 10, ReadMask: mask})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.GetUsers()) != 1 || !proto.Equal(list.GetUsers()[0], want) {
+		t.Errorf("ListUsers with a read_mask: want [%v] got %v", want, list.GetUsers())
+	}
+	if got, _ := s.GetUser(context.Background(), &pb.GetUserRequest{Name: created.GetName()}); !proto.Equal(got, created) {
+		t.Errorf("GetUser: want masks to leave the stored user unchanged got %v", got)
+	}
+
+	bad := &field_mask.FieldMask{Paths: []string{"nickname"}}
+	if _, err := s.GetUser(context.Background(), &pb.GetUserRequest{Name: created.GetName(), ReadMask: bad}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetUser with an unknown read_mask path: want InvalidArgument got %v", err)
+	}
+	if _, err := s.ListUsers(context.Background(), &pb.ListUsersRequest{ReadMask: bad}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListUsers with an unknown read_mask path: want InvalidArgument got %v", err)
+	}
+}
+
+func Test_ListUsers_filter(t *testing.T) {
+	s := NewIdentityServer()
+	for _, name := range []string{"Ada", "Bob", "Cy", "Dee", "Eve"} {
+		user := &pb.User{DisplayName: name, Email: strings.ToLower(name) + "@example.com"}
+		if _, err := s.CreateUser(context.Background(), &pb.CreateUserRequest{User: user}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{"", []string{"Ada", "Bob", "Cy", "Dee", "Eve"}},
+		{"display_name = Cy", []string{"Cy"}},
+		{"display_name != \"Cy\"", []string{"Ada", "Bob", "Dee", "Eve"}},
+		{"display_name > B AND email < \"e\"", []string{"Bob", "Cy", "Dee"}},
+		{"email = \"nobody@example.com\"", []string{}},
+	}
+	for _, test := range tests {
+		req := &pb.ListUsersRequest{PageSize: 2, Filter: test.filter}
+		got := []string{}
+		for pages := 0; pages < 5; pages++ {
+			resp, err := s.ListUsers(context.Background(), req)
+			if err != nil {
+				t.Fatalf("ListUsers(%q): unexpected err %+v", test.filter, err)
+			}
+			for _, u := range resp.GetUsers() {
+				got = append(got, u.GetDisplayName())
+			}
+			if resp.GetNextPageToken() == "" {
+				break
+			}
+			req.PageToken = resp.GetNextPageToken()
+		}
+		if fmt.Sprint(got) != fmt.Sprint(test.want) {
+			t.Errorf("ListUsers(%q): want %v got %v", test.filter, test.want, got)
+		}
+	}
+
+	_, err := s.ListUsers(context.Background(), &pb.ListUsersRequest{Filter: "name = Ada"})
+	st, _ := status.FromError(err)
+	if st.Code() != codes.InvalidArgument || !strings.Contains(st.Message(), "invalid at offset 0: unknown field `name`") {
+		t.Errorf("ListUsers with an unknown field: want InvalidArgument got %v", err)
+	}
+}
+
+func Test_ListUsers_fuzz(t *testing.T) {
+	s := NewIdentityServer()
+	want := []string{}
+	for seed := int64(0); seed < 20; seed++ {
+		in, _ := showcasefuzz.ValidRequest("/google.showcase.v1beta1.Identity/CreateUser", seed, showcasefuzz.DefaultOptions())
+		u, err := s.CreateUser(context.Background(), in.(*pb.CreateUserRequest))
+		if err != nil {
+			t.Fatalf("CreateUser(%v): unexpected err %+v", in, err)
+		}
+		want = append(want, u.GetName())
+	}
+	for seed := int64(0); seed < 100; seed++ {
+		in, _ := showcasefuzz.ValidRequest("/google.showcase.v1beta1.Identity/ListUsers", seed, showcasefuzz.DefaultOptions())
+		req := in.(*pb.ListUsersRequest)
+		got := []string{}
+		// Every page holds a user, so the pages end.
+		for pages := 0; pages <= len(want); pages++ {
+			resp, err := s.ListUsers(context.Background(), req)
+			if err != nil {
+				t.Fatalf("ListUsers(%v), seed %d: unexpected err %+v", req, seed, err)
+			}
+			for _, u := range resp.GetUsers() {
+				got = append(got, u.GetName())
+			}
+			if resp.GetNextPageToken() == "" {
+				break
+			}
+			req.PageToken = resp.GetNextPageToken()
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("ListUsers(%d), seed %d: want the users %v got %v", req.GetPageSize(), seed, want, got)
+		}
+	}
+}
+
+func Test_ListUsers_scramble(t *testing.T) {
+	settings := server.DefaultSettings()
+	settings.ListScrambleSeed = 11
+	s := NewIdentityServer().(*identityServerImpl)
+	s.settings = server.NewSettingsStore(settings)
+
+	created := []string{}
+	for i := 0; i < 9; i++ {
+		u, err := s.CreateUser(
+			context.Background(),
+			&pb.CreateUserRequest{
+				User: &pb.User{DisplayName: fmt.Sprintf("user%d", i), Email: fmt.Sprintf("user%d@example.com", i)},
+			})
+		if err != nil {
+			t.Fatalf("Create: unexpected err %+v", err)
+		}
+		created = append(created, u.GetName())
+	}
+
+	listed := []string{}
+	token := ""
+	for page := 0; ; page++ {
+		r, err := s.ListUsers(context.Background(), &pb.ListUsersRequest{PageSize: 3, PageToken: token})
+		if err != nil {
+			t.Fatalf("ListUsers: unexpected err %+v", err)
+		}
+		got := []string{}
+		for _, u := range r.GetUsers() {
+			got = append(got, u.GetName())
+		}
+		want := append([]string(nil), created[page*3:page*3+3]...)
+		sort.Strings(want)
+		sortedGot := append([]string(nil), got...)
+		sort.Strings(sortedGot)
+		if !reflect.DeepEqual(sortedGot, want) {
+			t.Errorf("ListUsers page %d: got %v, want a permutation of %v", page, got, want)
+		}
+		listed = append(listed, got...)
+		if token = r.GetNextPageToken(); token == "" {
+			break
+		}
+	}
+	if reflect.DeepEqual(listed, created) {
+		t.Errorf("ListUsers: got the users in order, want them shuffled")
+	}
+}
@@ -26,9 +26,11 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/googleapis/gapic-showcase/server"
 	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
 	"google.golang.org/genproto/googleapis/longrunning"
 	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
@@ -39,7 +41,8 @@ import (
 func NewMessagingServer(identityServer ReadOnlyIdentityServer) MessagingServer {
 	return &messagingServerImpl{
 		identityServer: identityServer,
-		nowF:           time.Now,
+		nowF:           server.Now,
+		settings:       server.GetSettingsInstance(),
 		token:          server.NewTokenGenerator(),
 		roomKeys:       map[string]int{},
 		blurbKeys:      map[string]blurbIndex{},
@@ -59,6 +62,7 @@ type MessagingServer interface {
 
 type messagingServerImpl struct {
 	nowF           func() time.Time
+	settings       server.SettingsStore
 	token          server.TokenGenerator
 	identityServer ReadOnlyIdentityServer
 
@@ -125,7 +129,7 @@ func (s *messagingServerImpl) CreateRoom(ctx context.Context, in *pb.CreateRoomR
 	// Assign info.
 	id := s.roomUID.Next()
 	name := fmt.Sprintf("rooms/%d", id)
-	now := ptypes.TimestampNow()
+	now := server.TimestampNow()
 
 	r.Name = name
 	r.CreateTime = now
@@ -141,6 +145,9 @@ func (s *messagingServerImpl) CreateRoom(ctx context.Context, in *pb.CreateRoomR
 
 // Retrieves the Room with the given resource name.
 func (s *messagingServerImpl) GetRoom(ctx context.Context, in *pb.GetRoomRequest) (*pb.Room, error) {
+	if err := server.CheckFieldMask("read_mask", in.GetReadMask(), &pb.Room{}); err != nil {
+		return nil, err
+	}
 	s.roomMu.Lock()
 	defer s.roomMu.Unlock()
 
@@ -148,7 +155,7 @@ func (s *messagingServerImpl) GetRoom(ctx context.Context, in *pb.GetRoomRequest
 	if i, ok := s.roomKeys[name]; ok {
 		entry := s.rooms[i]
 		if !entry.deleted {
-			return entry.room, nil
+			return server.MaskedCopy(in.GetReadMask(), entry.room).(*pb.Room), nil
 		}
 	}
 
@@ -200,7 +207,7 @@ func (s *messagingServerImpl) UpdateRoom(ctx context.Context, in *pb.UpdateRoomR
 		DisplayName: r.GetDisplayName(),
 		Description: r.GetDescription(),
 		CreateTime:  entry.room.GetCreateTime(),
-		UpdateTime:  ptypes.TimestampNow(),
+		UpdateTime:  server.TimestampNow(),
 	}
 	s.rooms[i] = roomEntry{room: updated}
 	return updated, nil
@@ -227,7 +234,10 @@ func (s *messagingServerImpl) DeleteRoom(ctx context.Context, in *pb.DeleteRoomR
 
 // Lists all chat rooms.
 func (s *messagingServerImpl) ListRooms(ctx context.Context, in *pb.ListRoomsRequest) (*pb.ListRoomsResponse, error) {
-	start, err := s.token.GetIndex(in.GetPageToken())
+	if err := server.CheckFieldMask("read_mask", in.GetReadMask(), &pb.Room{}); err != nil {
+		return nil, err
+	}
+	start, err := s.token.GetIndexWithTTL(in.GetPageToken(), in.GetPageTokenTtl())
 	if err != nil {
 		return nil, err
 	}
@@ -239,11 +249,14 @@ func (s *messagingServerImpl) ListRooms(ctx context.Context, in *pb.ListRoomsReq
 		if entry.deleted {
 			continue
 		}
-		rooms = append(rooms, entry.room)
+		rooms = append(rooms, server.MaskedCopy(in.GetReadMask(), entry.room).(*pb.Room))
 		if len(rooms) >= int(in.GetPageSize()) {
 			break
 		}
 	}
+	server.ScramblePage(s.settings, start, len(rooms), func(i, j int) {
+		rooms[i], rooms[j] = rooms[j], rooms[i]
+	})
 
 	nextToken := ""
 	if start+offset < len(s.rooms) {
@@ -265,8 +278,9 @@ func (s *messagingServerImpl) anyRoom(f func(*pb.Room) bool) bool {
 func validateRoom(r *pb.Room) error {
 	// Validate Required Fields.
 	if r.GetDisplayName() == "" {
-		return status.Errorf(
-			codes.InvalidArgument,
+		return showcaseerrors.Field(
+			showcaseerrors.FieldRequired,
+			"display_name",
 			"The field `display_name` is required.")
 	}
 	return nil
@@ -302,7 +316,7 @@ func (s *messagingServerImpl) CreateBlurb(ctx context.Context, in *pb.CreateBlur
 
 	id := puid.Next()
 	name := fmt.Sprintf("%s/blurbs/%d", parent, id)
-	now := ptypes.TimestampNow()
+	now := server.TimestampNow()
 
 	b.Name = name
 	b.CreateTime = now
@@ -327,13 +341,16 @@ func (s *messagingServerImpl) CreateBlurb(ctx context.Context, in *pb.CreateBlur
 
 // Retrieves the Blurb with the given resource name.
 func (s *messagingServerImpl) GetBlurb(ctx context.Context, in *pb.GetBlurbRequest) (*pb.Blurb, error) {
+	if err := server.CheckFieldMask("read_mask", in.GetReadMask(), &pb.Blurb{}); err != nil {
+		return nil, err
+	}
 	s.blurbMu.Lock()
 	defer s.blurbMu.Unlock()
 
 	if i, ok := s.blurbKeys[in.GetName()]; ok {
 		entry := s.blurbs[i.row][i.col]
 		if !entry.deleted {
-			return entry.blurb, nil
+			return server.MaskedCopy(in.GetReadMask(), entry.blurb).(*pb.Blurb), nil
 		}
 	}
 
@@ -367,7 +384,7 @@ func (s *messagingServerImpl) UpdateBlurb(ctx context.Context, in *pb.UpdateBlur
 	}
 	// Update store.
 	updated := proto.Clone(b).(*pb.Blurb)
-	updated.UpdateTime = ptypes.TimestampNow()
+	updated.UpdateTime = server.TimestampNow()
 	s.blurbs[i.row][i.col] = blurbEntry{blurb: updated}
 
 	// Call observers.
@@ -421,13 +438,16 @@ func (s *messagingServerImpl) FilteredListBlurbs(ctx context.Context, in *pb.Lis
 	if err := s.validateParent(in.GetParent()); err != nil {
 		return nil, err
 	}
+	if err := server.CheckFieldMask("read_mask", in.GetReadMask(), &pb.Blurb{}); err != nil {
+		return nil, err
+	}
 
 	bs, ok := s.blurbs[in.GetParent()]
 	if !ok {
 		return &pb.ListBlurbsResponse{}, nil
 	}
 
-	start, err := s.token.GetIndex(in.GetPageToken())
+	start, err := s.token.GetIndexWithTTL(in.GetPageToken(), in.GetPageTokenTtl())
 	if err != nil {
 		return nil, err
 	}
@@ -440,12 +460,15 @@ func (s *messagingServerImpl) FilteredListBlurbs(ctx context.Context, in *pb.Lis
 			continue
 		}
 		if f(entry.blurb) {
-			blurbs = append(blurbs, entry.blurb)
+			blurbs = append(blurbs, server.MaskedCopy(in.GetReadMask(), entry.blurb).(*pb.Blurb))
 		}
 		if len(blurbs) >= int(in.GetPageSize()) {
 			break
 		}
 	}
+	server.ScramblePage(s.settings, start, len(blurbs), func(i, j int) {
+		blurbs[i], blurbs[j] = blurbs[j], blurbs[i]
+	})
 
 	nextToken := ""
 	if start+offset < len(s.blurbs[in.GetParent()]) {
@@ -464,8 +487,9 @@ func (s *messagingServerImpl) SearchBlurbs(ctx context.Context, in *pb.SearchBlu
 	}
 	reqBytes, _ := proto.Marshal(in)
 
-	name := fmt.Sprintf(
-		"operations/google.showcase.v1beta1.Messaging/SearchBlurbs/%s",
+	name := server.OperationName(
+		s.settings.Get().InstanceID,
+		"google.showcase.v1beta1.Messaging/SearchBlurbs",
 		base64.StdEncoding.EncodeToString(reqBytes))
 	// TODO(landrito) Add randomization to the retry delay.
 	meta, _ := ptypes.MarshalAny(
@@ -488,7 +512,7 @@ func (s *messagingServerImpl) StreamBlurbs(in *pb.StreamBlurbsRequest, stream pb
 
 	expireTime, err := ptypes.Timestamp(in.GetExpireTime())
 	if err != nil {
-		return status.Error(codes.InvalidArgument, err.Error())
+		return showcaseerrors.Field(showcaseerrors.FieldInvalid, "expire_time", "%s", err)
 	}
 	observer := &streamBlurbsObserver{
 		stream: stream.(BlurbsOutStream),
@@ -542,9 +566,11 @@ func withCreatedNames(err error, names []string) error {
 	s, _ := status.FromError(err)
 	spb := s.Proto()
 
+	// The created names come first, ahead of the details of the error
+	// itself, where clients have always found them.
 	details, err := ptypes.MarshalAny(&pb.SendBlurbsResponse{Names: names})
 	if err == nil {
-		spb.Details = append(spb.Details, details)
+		spb.Details = append([]*any.Any{details}, spb.Details...)
 	}
 
 	return status.ErrorProto(spb)
@@ -573,8 +599,9 @@ func (s *messagingServerImpl) Connect(stream pb.Messaging_ConnectServer) error {
 		// Setup Configuration
 		if !configured && req != nil {
 			if req.GetConfig() == nil {
-				return status.Error(
-					codes.InvalidArgument,
+				return showcaseerrors.Field(
+					showcaseerrors.StreamOutOfOrder,
+					"config",
 					"The first request to Connect, must contain a config field")
 			}
 
@@ -617,8 +644,9 @@ func (s *messagingServerImpl) Connect(stream pb.Messaging_ConnectServer) error {
 func validateBlurb(b *pb.Blurb) error {
 	// Validate Required Fields.
 	if b.GetUser() == "" {
-		return status.Errorf(
-			codes.InvalidArgument,
+		return showcaseerrors.Field(
+			showcaseerrors.FieldRequired,
+			"user",
 			"The field `user` is required.")
 	}
 	return nil
@@ -699,7 +727,7 @@ func (o *streamBlurbsObserver) OnDelete(b *pb.Blurb) {
 			}))
 }
 
-func (s messagingServerImpl) registerObserver(parent string, o blurbObserver) string {
+func (s *messagingServerImpl) registerObserver(parent string, o blurbObserver) string {
 	s.obsMu.Lock()
 	defer s.obsMu.Unlock()
 	name := strconv.FormatInt(s.obsUID.Next(), 10)
@@ -710,7 +738,7 @@ func (s messagingServerImpl) registerObserver(parent string, o blurbObserver) st
 	return name
 }
 
-func (s messagingServerImpl) hasObservers(parent string) bool {
+func (s *messagingServerImpl) hasObservers(parent string) bool {
 	s.obsMu.Lock()
 	defer s.obsMu.Unlock()
 	if os, ok := s.observers[parent]; ok && len(os) > 0 {
@@ -719,7 +747,7 @@ func (s messagingServerImpl) hasObservers(parent string) bool {
 	return false
 }
 
-func (s messagingServerImpl) removeObserver(parent string, name string) {
+func (s *messagingServerImpl) removeObserver(parent string, name string) {
 	s.obsMu.Lock()
 	defer s.obsMu.Unlock()
 	delete(s.observers[parent], name)
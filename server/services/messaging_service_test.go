@@ -1385,9 +1385,10 @@ func TestSendBlurbs_invalidBlurb(t *testing.T) {
 	if !ok {
 		t.Errorf("SendBlurbs: expected err to be status %+v", err)
 	}
+	// The ErrorInfo of the invalid blurb follows the created names.
 	details := st.Proto().GetDetails()
-	if len(details) > 1 {
-		t.Errorf("SendBlurbs: expected err details to be of length 1")
+	if len(details) != 2 || details[1].GetTypeUrl() != "type.googleapis.com/google.rpc.ErrorInfo" {
+		t.Errorf("SendBlurbs: expected err details to be of length 2, ending with an ErrorInfo")
 	}
 	resp := &pb.SendBlurbsResponse{}
 	ptypes.UnmarshalAny(details[0], resp)
@@ -1470,7 +1471,7 @@ func TestConnect(t *testing.T) {
 	go (func() {
 		err := s.Connect(m)
 		if err != nil {
-			t.Fatalf("Connect: unexpected err %+v", err)
+			t.Errorf("Connect: unexpected err %+v", err)
 		}
 		wg.Done()
 	})()
@@ -1789,7 +1790,7 @@ func TestConnect_parentNotFoundLater(t *testing.T) {
 	go (func() {
 		err := s.Connect(m)
 		if err == nil {
-			t.Fatalf("Connect: expected err")
+			t.Errorf("Connect: expected err")
 		}
 		status, _ := status.FromError(err)
 		if status.Code() != codes.NotFound {
@@ -1852,3 +1853,44 @@ func Test_Connect_creationFailure(t *testing.T) {
 			status.Code())
 	}
 }
+
+func Test_Blurb_readMask(t *testing.T) {
+	s := NewMessagingServer(&mockIdentityServer{})
+	created, err := s.CreateBlurb(
+		context.Background(),
+		&pb.CreateBlurbRequest{
+			Parent: "users/rumble/profile",
+			Blurb:  &pb.Blurb{User: "users/rumble", Content: &pb.Blurb_Text{Text: "woof"}},
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mask := &field_mask.FieldMask{Paths: []string{"name", "text"}}
+	want := &pb.Blurb{Name: created.GetName(), Content: &pb.Blurb_Text{Text: "woof"}}
+
+	got, err := s.GetBlurb(context.Background(), &pb.GetBlurbRequest{Name: created.GetName(), ReadMask: mask})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("GetBlurb with a read_mask: want %v got %v", want, got)
+	}
+	list, err := s.ListBlurbs(context.Background(), &pb.ListBlurbsRequest{Parent: "users/rumble/profile", PageSize: 10, ReadMask: mask})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.GetBlurbs()) != 1 || !proto.Equal(list.GetBlurbs()[0], want) {
+		t.Errorf("ListBlurbs with a read_mask: want [%v] got %v", want, list.GetBlurbs())
+	}
+	if got, _ := s.GetBlurb(context.Background(), &pb.GetBlurbRequest{Name: created.GetName()}); got.GetUser() != "users/rumble" {
+		t.Errorf("GetBlurb: want masks to leave the stored blurb unchanged got %v", got)
+	}
+
+	bad := &field_mask.FieldMask{Paths: []string{"text.length"}}
+	if _, err := s.GetBlurb(context.Background(), &pb.GetBlurbRequest{Name: created.GetName(), ReadMask: bad}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetBlurb with an invalid read_mask path: want InvalidArgument got %v", err)
+	}
+	if _, err := s.ListRooms(context.Background(), &pb.ListRoomsRequest{ReadMask: bad}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListRooms with an invalid read_mask path: want InvalidArgument got %v", err)
+	}
+}
@@ -17,67 +17,251 @@ package services
 import (
 	"context"
 	"encoding/base64"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/googleapis/gapic-showcase/server"
 	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
 	lropb "google.golang.org/genproto/googleapis/longrunning"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
 // NewOperationsServer returns a new OperationsServer for the Showcase API.
 func NewOperationsServer(messagingServer MessagingServer) lropb.OperationsServer {
-	return &operationsServerImpl{waiter: server.GetWaiterInstance(), messagingServer: messagingServer}
+	return &operationsServerImpl{
+		waiter:          server.GetWaiterInstance(),
+		pollRecorder:    server.GetPollRecorderInstance(),
+		pollLimiter:     server.GetPollLimiterInstance(),
+		settings:        server.GetSettingsInstance(),
+		collector:       NewOperationCollector(),
+		watchers:        server.GetOperationWatchersInstance(),
+		workers:         server.GetOperationWorkersInstance(),
+		nowF:            server.Now,
+		afterF:          time.After,
+		messagingServer: messagingServer,
+	}
+}
+
+// NewOperationCollector returns an OperationCollector of the operations of
+// the Showcase API, which expire with the settings singleton.
+func NewOperationCollector() *server.OperationCollector {
+	return server.NewOperationCollector(
+		server.GetPollRecorderInstance(),
+		server.GetSettingsInstance(),
+		server.GetMetricsInstance(),
+		OperationDoneTime,
+		server.Now,
+		time.After)
+}
+
+// OperationDoneTime returns when the named operation was done, if it is known.
+// Wait operations are done at their end time, or when the operation workers
+// complete them if the server has any. SearchBlurbs operations are done as
+// soon as they start, at a time their names do not record.
+func OperationDoneTime(name string) (time.Time, bool) {
+	if workers := server.GetOperationWorkersInstance(); workers != nil {
+		if _, ok := waitOperationRequest(name); ok {
+			return workers.DoneTime(name)
+		}
+	}
+	req, ok := waitOperationRequest(name)
+	if !ok || req.GetEndTime() == nil {
+		return time.Time{}, false
+	}
+	end, err := ptypes.Timestamp(req.GetEndTime())
+	return end, err == nil
+}
+
+// waitOperationRequest returns the request a Wait operation was started
+// with, which its name encodes.
+func waitOperationRequest(name string) (*pb.WaitRequest, bool) {
+	_, local := server.SplitOperationName(name)
+	if !strings.HasPrefix(local, waitOperationPrefix) {
+		return nil, false
+	}
+	b, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(local, waitOperationPrefix))
+	if err != nil {
+		return nil, false
+	}
+	req := &pb.WaitRequest{}
+	if err := proto.Unmarshal(b, req); err != nil {
+		return nil, false
+	}
+	return req, true
 }
 
 type operationsServerImpl struct {
 	messagingServer MessagingServer
 	waiter          server.Waiter
+	pollRecorder    server.PollRecorder
+	pollLimiter     server.PollLimiter
+	settings        server.SettingsStore
+	collector       *server.OperationCollector
+	watchers        server.OperationWatchers
+	workers         *server.WorkerOperationStore
+	nowF            func() time.Time
+	afterF          func(time.Duration) <-chan time.Time
 }
 
 func (s *operationsServerImpl) GetOperation(ctx context.Context, in *lropb.GetOperationRequest) (*lropb.Operation, error) {
-	if op, err := s.handleWait(in); op != nil || err != nil {
+	local, err := s.localOperationName(in.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if !isKnownOperation(local) {
+		return nil, status.Errorf(codes.NotFound, "Operation %q not found.", in.Name)
+	}
+	wait, err := s.pollWait(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.collector != nil && s.collector.Expired(in.GetName()) {
+		return nil, status.ErrorProto(&spb.Status{
+			Code: int32(codes.NotFound),
+			Message: fmt.Sprintf(
+				"Operation %q expired more than %s after it was done.",
+				in.GetName(),
+				s.settings.Get().OperationTTL),
+			Details: []*any.Any{showcaseerrors.ErrorInfo(showcaseerrors.OperationExpired, showcaseerrors.Domain, map[string]string{
+				"operation": in.GetName(),
+			})},
+		})
+	}
+	namespace := server.NamespaceFromContext(ctx)
+	s.pollRecorder.Record(namespace, in.GetName())
+
+	if op, err := s.handleWait(ctx, namespace, in, local, wait); op != nil || err != nil {
 		return op, err
 	}
-	if op, err := s.handleSearchBlurbs(in); op != nil || err != nil {
+	if op, err := s.handleSearchBlurbs(in, local); op != nil || err != nil {
 		return op, err
 	}
 	return nil, status.Errorf(codes.NotFound, "Operation %q not found.", in.Name)
 }
 
-func (s *operationsServerImpl) handleWait(in *lropb.GetOperationRequest) (*lropb.Operation, error) {
-	prefix := "operations/google.showcase.v1beta1.Echo/Wait/"
-	if !strings.HasPrefix(in.Name, prefix) {
-		return nil, nil
+const (
+	waitOperationPrefix         = "operations/google.showcase.v1beta1.Echo/Wait/"
+	searchBlurbsOperationPrefix = "operations/google.showcase.v1beta1.Messaging/SearchBlurbs/"
+)
+
+func (s *operationsServerImpl) localOperationName(name string) (string, error) {
+	return localOperationName(s.settings, name)
+}
+
+// localOperationName returns the name of an operation without the instance
+// it records, or a FAILED_PRECONDITION error if it records another instance
+// than the one of the settings. Names that record no instance are accepted
+// from any server.
+func localOperationName(settings server.SettingsStore, name string) (string, error) {
+	instance, local := server.SplitOperationName(name)
+	if instance != "" && instance != settings.Get().InstanceID {
+		return "", status.ErrorProto(&spb.Status{
+			Code: int32(codes.FailedPrecondition),
+			Message: fmt.Sprintf(
+				"Operation %q was started by the Showcase instance %q, not this one. "+
+					"Route the calls of an operation to the instance that started it.",
+				name,
+				instance),
+			Details: []*any.Any{showcaseerrors.ErrorInfo(showcaseerrors.WrongInstance, showcaseerrors.Domain, map[string]string{
+				"operation": name,
+				"instance":  instance,
+			})},
+		})
 	}
+	return local, nil
+}
 
-	waitReq := &pb.WaitRequest{}
-	encodedBytes := strings.TrimPrefix(in.Name, prefix)
-	waitReqBytes, err := base64.StdEncoding.DecodeString(encodedBytes)
-	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "Operation %q not found.", in.Name)
+func isKnownOperation(name string) bool {
+	return strings.HasPrefix(name, waitOperationPrefix) ||
+		strings.HasPrefix(name, searchBlurbsOperationPrefix)
+}
+
+// pollWait returns how long the call asks to hold for the operation, capped by
+// the MaxPollWait setting.
+func (s *operationsServerImpl) pollWait(ctx context.Context) (time.Duration, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(server.PollWaitHeader)
+	if len(values) == 0 {
+		return 0, nil
+	}
+	if len(values) > 1 {
+		return 0, showcaseerrors.Metadata(server.PollWaitHeader, "The %s metadata must be given at most once.", server.PollWaitHeader)
+	}
+	wait, err := time.ParseDuration(values[0])
+	if err != nil || wait < 0 {
+		return 0, showcaseerrors.Metadata(
+			server.PollWaitHeader,
+			"The %s metadata %q must be a non-negative duration such as \"1.5s\".",
+			server.PollWaitHeader,
+			values[0])
+	}
+	if max := s.settings.Get().MaxPollWait; wait > max {
+		wait = max
 	}
+	return wait, nil
+}
 
-	err = proto.Unmarshal(waitReqBytes, waitReq)
-	if err != nil {
+// holdWait holds a pending Wait operation until it completes or the wait
+// elapses, whichever is first, and returns its state then.
+func (s *operationsServerImpl) holdWait(ctx context.Context, req *pb.WaitRequest, wait time.Duration) (*lropb.Operation, error) {
+	op := s.waiter.Wait(req)
+	if op.GetDone() || wait <= 0 {
+		return op, nil
+	}
+	// The operation workers complete operations when they get to them, which
+	// may be well after their end time.
+	var completed <-chan struct{}
+	if s.workers != nil {
+		completed = s.workers.Done(op.GetName())
+	} else if endTime, err := ptypes.Timestamp(req.GetEndTime()); err == nil {
+		// Passing the end time by a little ensures the operation is done.
+		if untilEnd := endTime.Sub(s.nowF()) + time.Millisecond; untilEnd < wait {
+			wait = untilEnd
+		}
+	}
+	select {
+	case <-s.afterF(wait):
+	case <-completed:
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	return s.waiter.Wait(req), nil
+}
+
+func (s *operationsServerImpl) handleWait(ctx context.Context, namespace string, in *lropb.GetOperationRequest, local string, wait time.Duration) (*lropb.Operation, error) {
+	if !strings.HasPrefix(local, waitOperationPrefix) {
+		return nil, nil
+	}
+	waitReq, ok := waitOperationRequest(local)
+	if !ok {
 		return nil, status.Errorf(codes.NotFound, "Operation %q not found.", in.Name)
 	}
 
-	return s.waiter.Wait(waitReq), nil
+	if quota := waitReq.GetPollQuota(); quota != nil {
+		if err := s.pollLimiter.Poll(namespace, in.GetName(), quota); err != nil {
+			return nil, err
+		}
+	}
+	return s.holdWait(ctx, waitReq, wait)
 }
 
-func (s *operationsServerImpl) handleSearchBlurbs(in *lropb.GetOperationRequest) (*lropb.Operation, error) {
-	prefix := "operations/google.showcase.v1beta1.Messaging/SearchBlurbs/"
-	if !strings.HasPrefix(in.GetName(), prefix) {
+func (s *operationsServerImpl) handleSearchBlurbs(in *lropb.GetOperationRequest, local string) (*lropb.Operation, error) {
+	prefix := searchBlurbsOperationPrefix
+	if !strings.HasPrefix(local, prefix) {
 		return nil, nil
 	}
 
 	req := &pb.SearchBlurbsRequest{}
-	encodedBytes := strings.TrimPrefix(in.GetName(), prefix)
+	encodedBytes := strings.TrimPrefix(local, prefix)
 	waitReqBytes, err := base64.StdEncoding.DecodeString(encodedBytes)
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "Operation %q not found.", in.Name)
@@ -133,8 +317,23 @@ func (s operationsServerImpl) ListOperations(ctx context.Context, in *lropb.List
 	return nil, status.Error(codes.Unimplemented, "google.longrunning.ListOperations is unimplemented.")
 }
 
+// Showcase operations are derived from their names, so deleting one only
+// forgets what has been recorded about it.
 func (s operationsServerImpl) DeleteOperation(ctx context.Context, in *lropb.DeleteOperationRequest) (*empty.Empty, error) {
-	return nil, status.Error(codes.Unimplemented, "google.longrunning.DeleteOperation is unimplemented.")
+	local, err := s.localOperationName(in.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if !isKnownOperation(local) {
+		return nil, status.Errorf(codes.NotFound, "Operation %q not found.", in.GetName())
+	}
+	namespace := server.NamespaceFromContext(ctx)
+	s.pollRecorder.Clear(namespace, in.GetName())
+	s.pollLimiter.Clear(namespace, in.GetName())
+	if s.watchers != nil {
+		s.watchers.Delete(namespace, in.GetName())
+	}
+	return &empty.Empty{}, nil
 }
 
 func (s operationsServerImpl) WaitOperation(ctx context.Context, in *lropb.WaitOperationRequest) (*lropb.Operation, error) {
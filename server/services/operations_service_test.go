@@ -18,6 +18,8 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -26,7 +28,9 @@ import (
 	"github.com/googleapis/gapic-showcase/server"
 	pb "github.com/googleapis/gapic-showcase/server/genproto"
 	lropb "google.golang.org/genproto/googleapis/longrunning"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -41,7 +45,10 @@ func TestGetOperation_wait(t *testing.T) {
 	}
 
 	waiter := &mockWaiter{}
-	server := &operationsServerImpl{waiter: waiter}
+	server := &operationsServerImpl{
+		waiter:       waiter,
+		pollRecorder: server.NewPollRecorder(time.Now),
+	}
 	server.GetOperation(context.Background(), req)
 	if !proto.Equal(waiter.req, waitReq) {
 		t.Error("Expected echo.Wait to defer to waiter.")
@@ -253,11 +260,119 @@ func TestServerListOperation(t *testing.T) {
 }
 
 func TestServerDeleteOperation(t *testing.T) {
+	recorder := server.NewPollRecorder(time.Now)
+	ops := &operationsServerImpl{
+		waiter:       server.GetWaiterInstance(),
+		pollRecorder: recorder,
+		pollLimiter:  server.NewPollLimiter(time.Now),
+	}
+	name := waitOperationName(t, &pb.WaitRequest{End: &pb.WaitRequest_Ttl{Ttl: ptypes.DurationProto(time.Hour)}})
+	ops.GetOperation(context.Background(), &lropb.GetOperationRequest{Name: name})
+	if len(recorder.Polls(server.DefaultNamespace, name)) != 1 {
+		t.Fatalf("GetOperation expected to record a poll")
+	}
+
+	_, err := ops.DeleteOperation(context.Background(), &lropb.DeleteOperationRequest{Name: name})
+	if err != nil {
+		t.Errorf("DeleteOperation: unexpected err %+v", err)
+	}
+	if polls := recorder.Polls(server.DefaultNamespace, name); len(polls) != 0 {
+		t.Errorf("DeleteOperation expected to clear polls, got %v", polls)
+	}
+}
+
+func TestServerDeleteOperation_notFound(t *testing.T) {
 	server := NewOperationsServer(nil)
-	_, err := server.DeleteOperation(context.Background(), nil)
+	_, err := server.DeleteOperation(context.Background(), &lropb.DeleteOperationRequest{Name: "BOGUS"})
 	s, _ := status.FromError(err)
-	if codes.Unimplemented != s.Code() {
-		t.Errorf("DeleteOperations expected code=%d, got %d", codes.Unimplemented, s.Code())
+	if codes.NotFound != s.Code() {
+		t.Errorf("DeleteOperation expected code=%d, got %d", codes.NotFound, s.Code())
+	}
+}
+
+func waitOperationName(t *testing.T, req *pb.WaitRequest) string {
+	op, err := NewEchoServer().Wait(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Wait: unexpected err %+v", err)
+	}
+	return op.GetName()
+}
+
+func TestGetOperation_recordsPolls(t *testing.T) {
+	now := time.Unix(100, 0)
+	recorder := server.NewPollRecorder(func() time.Time { return now })
+	ops := &operationsServerImpl{
+		waiter:       server.GetWaiterInstance(),
+		pollRecorder: recorder,
+	}
+	name := waitOperationName(t, &pb.WaitRequest{End: &pb.WaitRequest_Ttl{Ttl: ptypes.DurationProto(time.Hour)}})
+
+	// Simulate a poller with an initial delay of 1s and a multiplier of 2.
+	delay := time.Second
+	for i := 0; i < 4; i++ {
+		ops.GetOperation(context.Background(), &lropb.GetOperationRequest{Name: name})
+		now = now.Add(delay)
+		delay *= 2
+	}
+	ops.GetOperation(context.Background(), &lropb.GetOperationRequest{Name: "BOGUS"})
+
+	polls := recorder.Polls(server.DefaultNamespace, name)
+	if len(polls) != 4 {
+		t.Fatalf("GetOperation expected to record 4 polls, got %d", len(polls))
+	}
+	for i := 1; i < len(polls); i++ {
+		if interval, want := polls[i].Sub(polls[i-1]), time.Second<<uint(i-1); interval != want {
+			t.Errorf("GetOperation poll interval %d: expected %v, got %v", i, want, interval)
+		}
+	}
+	if polls := recorder.Polls(server.DefaultNamespace, "BOGUS"); len(polls) != 0 {
+		t.Errorf("GetOperation expected unknown operations not to be recorded, got %v", polls)
+	}
+}
+
+func TestGetOperation_recordsPollsRacingCompletion(t *testing.T) {
+	recorder := server.NewPollRecorder(time.Now)
+	ops := &operationsServerImpl{
+		waiter:       server.GetWaiterInstance(),
+		pollRecorder: recorder,
+	}
+	name := waitOperationName(t, &pb.WaitRequest{
+		End:      &pb.WaitRequest_Ttl{Ttl: ptypes.DurationProto(5 * time.Millisecond)},
+		Response: &pb.WaitRequest_Success{Success: &pb.WaitResponse{Content: "done"}},
+	})
+
+	wg := sync.WaitGroup{}
+	done := int32(0)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				op, err := ops.GetOperation(context.Background(), &lropb.GetOperationRequest{Name: name})
+				if err != nil {
+					t.Errorf("GetOperation: unexpected err %+v", err)
+					return
+				}
+				if op.GetDone() {
+					atomic.AddInt32(&done, 1)
+				}
+				time.Sleep(time.Millisecond)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if done == 0 {
+		t.Error("GetOperation expected the operation to complete while being polled")
+	}
+	polls := recorder.Polls(server.DefaultNamespace, name)
+	if len(polls) != 100 {
+		t.Fatalf("GetOperation expected to record 100 polls, got %d", len(polls))
+	}
+	for i := 1; i < len(polls); i++ {
+		if polls[i].Before(polls[i-1]) {
+			t.Errorf("GetOperation expected polls to be recorded in order, poll %d is out of order", i)
+		}
 	}
 }
 
@@ -269,3 +384,281 @@ func TestServerWaitOperation(t *testing.T) {
 		t.Errorf("DeleteOperations expected code=%d, got %d", codes.Unimplemented, s.Code())
 	}
 }
+
+func TestGetOperation_pollQuota(t *testing.T) {
+	now := time.Unix(100, 0)
+	ops := &operationsServerImpl{
+		waiter:       server.GetWaiterInstance(),
+		pollRecorder: server.NewPollRecorder(time.Now),
+		pollLimiter:  server.NewPollLimiter(func() time.Time { return now }),
+	}
+	endTime, _ := ptypes.TimestampProto(time.Now())
+	name := waitOperationName(t, &pb.WaitRequest{
+		End:       &pb.WaitRequest_EndTime{EndTime: endTime},
+		Response:  &pb.WaitRequest_Success{Success: &pb.WaitResponse{Content: "done"}},
+		PollQuota: &pb.PollQuota{MaxPolls: 2, ReplenishInterval: ptypes.DurationProto(time.Minute)},
+	})
+	poll := func() (*lropb.Operation, error) {
+		return ops.GetOperation(context.Background(), &lropb.GetOperationRequest{Name: name})
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := poll(); err != nil {
+			t.Fatalf("GetOperation %d: unexpected err %+v", i, err)
+		}
+	}
+	_, err := poll()
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("GetOperation beyond the quota: want ResourceExhausted got %v", err)
+	}
+	if len(st.Details()) != 1 {
+		t.Fatalf("GetOperation beyond the quota: want a RetryInfo got %v", st.Details())
+	}
+	info, ok := st.Details()[0].(*errdetails.RetryInfo)
+	if delay, _ := ptypes.Duration(info.GetRetryDelay()); !ok || delay != time.Minute {
+		t.Errorf("GetOperation beyond the quota: want a 1m RetryInfo got %v", st.Details()[0])
+	}
+
+	now = now.Add(time.Minute)
+	op, err := poll()
+	if err != nil {
+		t.Fatalf("GetOperation after replenishing: unexpected err %+v", err)
+	}
+	resp := &pb.WaitResponse{}
+	if !op.GetDone() || ptypes.UnmarshalAny(op.GetResponse(), resp) != nil || resp.GetContent() != "done" {
+		t.Errorf("GetOperation after replenishing: want the completed operation got %v", op)
+	}
+
+	// Deleting the operation resets its quota.
+	if _, err := poll(); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("GetOperation beyond the quota: want ResourceExhausted got %v", err)
+	}
+	if _, err := ops.DeleteOperation(context.Background(), &lropb.DeleteOperationRequest{Name: name}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := poll(); err != nil {
+		t.Errorf("GetOperation after DeleteOperation: unexpected err %+v", err)
+	}
+}
+
+// longPoll calls GetOperation for the operation with the given poll wait.
+func longPoll(ctx context.Context, ops *operationsServerImpl, name, wait string) (*lropb.Operation, error) {
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(server.PollWaitHeader, wait))
+	return ops.GetOperation(ctx, &lropb.GetOperationRequest{Name: name})
+}
+
+func newLongPollServer(maxWait time.Duration, afterF func(time.Duration) <-chan time.Time) *operationsServerImpl {
+	settings := server.DefaultSettings()
+	settings.MaxPollWait = maxWait
+	return &operationsServerImpl{
+		waiter:       server.GetWaiterInstance(),
+		pollRecorder: server.NewPollRecorder(time.Now),
+		settings:     server.NewSettingsStore(settings),
+		nowF:         time.Now,
+		afterF:       afterF,
+	}
+}
+
+func TestGetOperation_pollWaitCompletes(t *testing.T) {
+	var waited time.Duration
+	ops := newLongPollServer(time.Minute, func(d time.Duration) <-chan time.Time {
+		waited = d
+		return time.After(d)
+	})
+	name := waitOperationName(t, &pb.WaitRequest{
+		End:      &pb.WaitRequest_Ttl{Ttl: ptypes.DurationProto(50 * time.Millisecond)},
+		Response: &pb.WaitRequest_Success{Success: &pb.WaitResponse{Content: "done"}},
+	})
+	op, err := longPoll(context.Background(), ops, name, "10s")
+	if err != nil {
+		t.Fatalf("GetOperation: unexpected err %+v", err)
+	}
+	if !op.GetDone() {
+		t.Errorf("GetOperation: want the operation to complete during the wait, got %v", op)
+	}
+	if waited > 100*time.Millisecond {
+		t.Errorf("GetOperation: want to hold only until the operation ends, held %s", waited)
+	}
+}
+
+func TestGetOperation_pollWaitExpires(t *testing.T) {
+	var waited time.Duration
+	ops := newLongPollServer(time.Second, func(d time.Duration) <-chan time.Time {
+		waited = d
+		return time.After(0)
+	})
+	name := waitOperationName(t, &pb.WaitRequest{
+		End: &pb.WaitRequest_Ttl{Ttl: ptypes.DurationProto(time.Hour)},
+	})
+	op, err := longPoll(context.Background(), ops, name, "1m")
+	if err != nil {
+		t.Fatalf("GetOperation: unexpected err %+v", err)
+	}
+	if op.GetDone() {
+		t.Errorf("GetOperation: want the operation still pending, got %v", op)
+	}
+	if waited != time.Second {
+		t.Errorf("GetOperation: want the wait capped at 1s, held %s", waited)
+	}
+}
+
+func TestGetOperation_pollWaitCancelled(t *testing.T) {
+	held := make(chan struct{})
+	ops := newLongPollServer(time.Minute, func(d time.Duration) <-chan time.Time {
+		close(held)
+		return nil
+	})
+	name := waitOperationName(t, &pb.WaitRequest{
+		End: &pb.WaitRequest_Ttl{Ttl: ptypes.DurationProto(time.Hour)},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-held
+		cancel()
+	}()
+	if _, err := longPoll(ctx, ops, name, "30s"); status.Code(err) != codes.Canceled {
+		t.Errorf("GetOperation cancelled mid-wait: want Canceled got %v", err)
+	}
+}
+
+func TestGetOperation_pollWaitWorkers(t *testing.T) {
+	worked := make(chan time.Time)
+	workers := server.NewWorkerOperationStore(1, server.NewMetrics(), time.Now, func(time.Duration) <-chan time.Time {
+		return worked
+	})
+	defer workers.Shutdown()
+	held := make(chan struct{})
+	ops := newLongPollServer(time.Minute, func(time.Duration) <-chan time.Time {
+		close(held)
+		return nil
+	})
+	ops.waiter = server.NewWorkerWaiter(time.Now, nil, workers)
+	ops.workers = workers
+	name := waitOperationName(t, &pb.WaitRequest{
+		End:      &pb.WaitRequest_Ttl{Ttl: ptypes.DurationProto(time.Hour)},
+		Response: &pb.WaitRequest_Success{Success: &pb.WaitResponse{Content: "done"}},
+	})
+	go func() {
+		<-held
+		close(worked)
+	}()
+	op, err := longPoll(context.Background(), ops, name, "30s")
+	if err != nil {
+		t.Fatalf("GetOperation: unexpected err %+v", err)
+	}
+	if !op.GetDone() {
+		t.Errorf("GetOperation: want to hold until the workers complete the operation, got %v", op)
+	}
+}
+
+func TestGetOperation_pollWaitInvalid(t *testing.T) {
+	ops := newLongPollServer(time.Minute, time.After)
+	name := waitOperationName(t, &pb.WaitRequest{
+		End: &pb.WaitRequest_Ttl{Ttl: ptypes.DurationProto(time.Hour)},
+	})
+	for _, wait := range []string{"soon", "-1s"} {
+		if _, err := longPoll(context.Background(), ops, name, wait); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("GetOperation with poll wait %q: want InvalidArgument got %v", wait, err)
+		}
+	}
+}
+
+func TestOperationDoneTime(t *testing.T) {
+	end := time.Unix(500, 0).UTC()
+	endProto, _ := ptypes.TimestampProto(end)
+	name := waitOperationName(t, &pb.WaitRequest{End: &pb.WaitRequest_EndTime{EndTime: endProto}})
+	if got, ok := OperationDoneTime(name); !ok || !got.Equal(end) {
+		t.Errorf("OperationDoneTime(Wait): want %s got %s, %t", end, got, ok)
+	}
+	for _, name := range []string{
+		searchBlurbsOperationPrefix + "abc",
+		waitOperationPrefix + "not base64!",
+		"operations/unknown",
+	} {
+		if _, ok := OperationDoneTime(name); ok {
+			t.Errorf("OperationDoneTime(%s): want no done time", name)
+		}
+	}
+}
+
+func TestGetOperation_expired(t *testing.T) {
+	now := time.Now()
+	settings := server.DefaultSettings()
+	settings.OperationTTL = time.Minute
+	store := server.NewSettingsStore(settings)
+	recorder := server.NewPollRecorder(time.Now)
+	ops := &operationsServerImpl{
+		waiter:       server.GetWaiterInstance(),
+		pollRecorder: recorder,
+		pollLimiter:  server.NewPollLimiter(time.Now),
+		settings:     store,
+		collector: server.NewOperationCollector(
+			recorder, store, server.NewMetrics(), OperationDoneTime, func() time.Time { return now }, time.After),
+	}
+	at := func(t time.Time) *pb.WaitRequest {
+		ts, _ := ptypes.TimestampProto(t)
+		return &pb.WaitRequest{End: &pb.WaitRequest_EndTime{EndTime: ts}}
+	}
+
+	expired := waitOperationName(t, at(now.Add(-2*time.Minute)))
+	_, err := ops.GetOperation(context.Background(), &lropb.GetOperationRequest{Name: expired})
+	details := status.Convert(err).Proto().GetDetails()
+	if status.Code(err) != codes.NotFound || len(details) != 1 {
+		t.Fatalf("GetOperation of an expired operation: want NotFound with an ErrorInfo got %v", err)
+	}
+	if reason, _, md := decodeErrorInfo(t, details[0].GetValue()); reason != "OPERATION_EXPIRED" || md["operation"] != expired {
+		t.Errorf("GetOperation of an expired operation: want an OPERATION_EXPIRED ErrorInfo got %q %v", reason, md)
+	}
+	if len(recorder.Polls(server.DefaultNamespace, expired)) != 0 {
+		t.Errorf("GetOperation of an expired operation: want no poll recorded")
+	}
+
+	// Operations that never existed have no ErrorInfo.
+	_, err = ops.GetOperation(context.Background(), &lropb.GetOperationRequest{Name: "operations/unknown"})
+	if status.Code(err) != codes.NotFound || len(status.Convert(err).Proto().GetDetails()) != 0 {
+		t.Errorf("GetOperation of an unknown operation: want NotFound without details got %v", err)
+	}
+
+	for _, end := range []time.Time{now.Add(-30 * time.Second), now.Add(time.Hour)} {
+		name := waitOperationName(t, at(end))
+		if _, err := ops.GetOperation(context.Background(), &lropb.GetOperationRequest{Name: name}); err != nil {
+			t.Errorf("GetOperation of an operation ending at %s: want it returned got %v", end.Sub(now), err)
+		}
+	}
+}
+
+func TestGetOperation_wrongInstance(t *testing.T) {
+	instance := func(id string) *operationsServerImpl {
+		s := NewOperationsServer(nil).(*operationsServerImpl)
+		settings := server.DefaultSettings()
+		settings.InstanceID = id
+		s.settings = server.NewSettingsStore(settings)
+		return s
+	}
+	a, b := instance("a"), instance("b")
+	reqBytes, _ := proto.Marshal(&pb.WaitRequest{End: &pb.WaitRequest_EndTime{EndTime: ptypes.TimestampNow()}})
+	id := base64.StdEncoding.EncodeToString(reqBytes)
+	name := server.OperationName("a", "google.showcase.v1beta1.Echo/Wait", id)
+
+	if _, err := a.GetOperation(context.Background(), &lropb.GetOperationRequest{Name: name}); err != nil {
+		t.Errorf("GetOperation of instance a on a: %v", err)
+	}
+	_, err := b.GetOperation(context.Background(), &lropb.GetOperationRequest{Name: name})
+	details := status.Convert(err).Proto().GetDetails()
+	if status.Code(err) != codes.FailedPrecondition || len(details) != 1 {
+		t.Fatalf("GetOperation of instance a on b: want FailedPrecondition with an ErrorInfo got %v", err)
+	}
+	if reason, _, md := decodeErrorInfo(t, details[0].GetValue()); reason != "WRONG_INSTANCE" || md["instance"] != "a" || md["operation"] != name {
+		t.Errorf("GetOperation of instance a on b: want a WRONG_INSTANCE ErrorInfo naming a got %q %v", reason, md)
+	}
+	if _, err := b.DeleteOperation(context.Background(), &lropb.DeleteOperationRequest{Name: name}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("DeleteOperation of instance a on b: want FailedPrecondition got %v", err)
+	}
+
+	// Names that record no instance are served anywhere.
+	legacy := server.OperationName("", "google.showcase.v1beta1.Echo/Wait", id)
+	if _, err := b.GetOperation(context.Background(), &lropb.GetOperationRequest{Name: legacy}); err != nil {
+		t.Errorf("GetOperation of no instance on b: %v", err)
+	}
+}
@@ -15,14 +15,25 @@
 package services
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
+	"io/ioutil"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/googleapis/gapic-showcase/server"
 	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
 	"github.com/googleapis/gapic-showcase/server/spec"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -39,6 +50,27 @@ func NewTestingServer(observerRegistry server.GrpcObserverRegistry) pb.TestingSe
 	s := &testingServerImpl{
 		token:            server.NewTokenGenerator(),
 		observerRegistry: observerRegistry,
+		pollRecorder:     server.GetPollRecorderInstance(),
+		pollLimiter:      server.GetPollLimiterInstance(),
+		settings:         server.GetSettingsInstance(),
+		overloadLimiter:  server.GetOverloadLimiterInstance(),
+		corpora:          server.GetCorpusStoreInstance(),
+		echoResources:    server.GetEchoResourceStoreInstance(),
+		dedupe:           server.GetDedupeCacheInstance(),
+		operationIDs:     server.GetOperationIDStoreInstance(),
+		expandStatus:     server.GetExpandStatusStoreInstance(),
+		stateSessions:    server.GetStateSessionsInstance(),
+		replicas:         server.GetReplicaSetInstance(),
+		expectations:     server.GetExpectationStoreInstance(),
+		scenarios:        server.GetScenarioStoreInstance(),
+		topics:           server.GetTopicStoreInstance(),
+		byteBudgets:      server.GetByteBudgetsInstance(),
+		attempts:         server.GetAttemptCounterInstance(),
+		blobs:            blobStoreSingleton,
+		metrics:          server.GetMetricsInstance(),
+		channelz:         server.GetChannelzSummarizerInstance(),
+		nowF:             server.Now,
+		started:          server.Now(),
 		keys:             keys,
 		sessions:         sessions,
 	}
@@ -55,6 +87,27 @@ type testingServerImpl struct {
 	uid              server.UniqID
 	token            server.TokenGenerator
 	observerRegistry server.GrpcObserverRegistry
+	pollRecorder     server.PollRecorder
+	pollLimiter      server.PollLimiter
+	settings         server.SettingsStore
+	overloadLimiter  server.OverloadLimiter
+	corpora          server.CorpusStore
+	echoResources    server.EchoResourceStore
+	dedupe           server.DedupeCache
+	operationIDs     server.OperationIDStore
+	expandStatus     server.ExpandStatusStore
+	stateSessions    server.StateSessions
+	replicas         *server.ReplicaSet
+	expectations     server.ExpectationStore
+	scenarios        server.ScenarioStore
+	topics           server.TopicStore
+	byteBudgets      server.ByteBudgets
+	attempts         server.AttemptCounter
+	blobs            *blobStore
+	metrics          server.Metrics
+	channelz         server.ChannelzSummarizer
+	nowF             func() time.Time
+	started          time.Time
 
 	mu       sync.Mutex
 	keys     map[string]int
@@ -96,7 +149,7 @@ func (s *testingServerImpl) ListSessions(_ context.Context, in *pb.ListSessionsR
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	start, err := s.token.GetIndex(in.GetPageToken())
+	start, err := s.token.GetIndexWithTTL(in.GetPageToken(), in.GetPageTokenTtl())
 	if err != nil {
 		return nil, err
 	}
@@ -190,3 +243,514 @@ func (s *testingServerImpl) VerifyTest(context.Context, *pb.VerifyTestRequest) (
 	// This should be handled by the test observers.
 	return &pb.VerifyTestResponse{}, nil
 }
+
+func (s *testingServerImpl) GetOperationPollingReport(ctx context.Context, req *pb.GetOperationPollingReportRequest) (*pb.OperationPollingReport, error) {
+	if req.GetName() == "" {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldRequired, "name", "The field `name` is required.")
+	}
+
+	polls := s.pollRecorder.Polls(server.NamespaceFromContext(ctx), req.GetName())
+	pollTimes := []*timestamp.Timestamp{}
+	intervals := []*duration.Duration{}
+	for i, p := range polls {
+		t, _ := ptypes.TimestampProto(p)
+		pollTimes = append(pollTimes, t)
+		if i > 0 {
+			intervals = append(intervals, ptypes.DurationProto(p.Sub(polls[i-1])))
+		}
+	}
+
+	return &pb.OperationPollingReport{
+		Name:      req.GetName(),
+		PollTimes: pollTimes,
+		Intervals: intervals,
+	}, nil
+}
+
+func (s *testingServerImpl) GetShowcaseSettings(_ context.Context, _ *pb.GetShowcaseSettingsRequest) (*pb.ShowcaseSettings, error) {
+	return server.SettingsProto(s.settings.Get()), nil
+}
+
+func (s *testingServerImpl) UpdateShowcaseSettings(_ context.Context, req *pb.UpdateShowcaseSettingsRequest) (*pb.UpdateShowcaseSettingsResponse, error) {
+	if req.GetSettings() == nil {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldRequired, "settings", "The field `settings` is required.")
+	}
+	previous, current, err := server.UpdateSettings(s.settings, req.GetSettings(), req.GetUpdateMask().GetPaths())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UpdateShowcaseSettingsResponse{
+		Previous: server.SettingsProto(previous),
+		Current:  server.SettingsProto(current),
+	}, nil
+}
+
+// setMethodOverloadMethod is the full name of the SetMethodOverload method,
+// which cannot be limited since that would prevent clearing its limit.
+const setMethodOverloadMethod = "/google.showcase.v1beta1.Testing/SetMethodOverload"
+
+func (s *testingServerImpl) SetMethodOverload(_ context.Context, req *pb.SetMethodOverloadRequest) (*empty.Empty, error) {
+	if req.GetMethod() == "" {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldRequired, "method", "The field `method` is required.")
+	}
+	if req.GetMethod() == setMethodOverloadMethod {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldInvalid, "method", "The method %s cannot be overloaded.", setMethodOverloadMethod)
+	}
+	if req.GetQpsLimit() < 0 {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldOutOfRange, "qps_limit", "The field `qps_limit` must not be negative.")
+	}
+	retryDelay := time.Duration(0)
+	if req.GetRetryDelay() != nil {
+		d, err := ptypes.Duration(req.GetRetryDelay())
+		if err != nil || d < 0 {
+			return nil, showcaseerrors.Field(showcaseerrors.FieldOutOfRange, "retry_delay", "The field `retry_delay` must be a non-negative duration.")
+		}
+		retryDelay = d
+	}
+	s.overloadLimiter.SetLimit(req.GetMethod(), req.GetQpsLimit(), retryDelay)
+	return &empty.Empty{}, nil
+}
+
+func (s *testingServerImpl) CreateEchoCorpus(ctx context.Context, req *pb.CreateEchoCorpusRequest) (*pb.EchoCorpus, error) {
+	if req.GetName() == "" {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldRequired, "name", "The field `name` is required.")
+	}
+	if len(req.GetWords()) == 0 {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldRequired, "words", "The field `words` is required.")
+	}
+	if err := s.corpora.Create(server.NamespaceFromContext(ctx), req.GetName(), req.GetWords()); err != nil {
+		return nil, err
+	}
+	return &pb.EchoCorpus{Name: req.GetName(), Words: req.GetWords()}, nil
+}
+
+func (s *testingServerImpl) DeleteEchoCorpus(ctx context.Context, req *pb.DeleteEchoCorpusRequest) (*empty.Empty, error) {
+	if req.GetName() == "" {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldRequired, "name", "The field `name` is required.")
+	}
+	if !s.corpora.Delete(server.NamespaceFromContext(ctx), req.GetName()) {
+		return nil, status.Errorf(codes.NotFound, "The corpus %q does not exist.", req.GetName())
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *testingServerImpl) PurgeNamespace(_ context.Context, req *pb.PurgeNamespaceRequest) (*empty.Empty, error) {
+	if err := server.ValidateNamespace(req.GetNamespace()); err != nil {
+		return nil, err
+	}
+	s.purgeNamespace(req.GetNamespace())
+	return &empty.Empty{}, nil
+}
+
+// purgeNamespace deletes the state of the namespace from every store at a
+// single instant, and returns how many entries it deleted from each.
+func (s *testingServerImpl) purgeNamespace(namespace string) map[string]int64 {
+	defer server.FreezeState()()
+	return map[string]int64{
+		"polls":                  int64(s.pollRecorder.PurgeNamespace(namespace)),
+		"poll_budgets":           int64(s.pollLimiter.PurgeNamespace(namespace)),
+		"corpora":                int64(s.corpora.PurgeNamespace(namespace)),
+		"blobs":                  int64(s.blobs.purgeNamespace(namespace)),
+		"echo_resources":         int64(s.echoResources.PurgeNamespace(namespace)),
+		"deduplicated_responses": int64(s.dedupe.PurgeNamespace(namespace)),
+		"operation_ids":          int64(s.operationIDs.PurgeNamespace(namespace)),
+		"expand_statuses":        int64(s.expandStatus.PurgeNamespace(namespace)),
+		"expectations":           int64(s.expectations.PurgeNamespace(namespace)),
+		"byte_budgets":           int64(s.byteBudgets.PurgeNamespace(namespace)),
+		"attempt_counts":         int64(s.attempts.PurgeNamespace(namespace)),
+		"scenarios":              int64(s.scenarios.PurgeNamespace(namespace)),
+		"topics":                 int64(s.topics.PurgeNamespace(namespace)),
+	}
+}
+
+// expireStateSessions deletes the state of the state sessions that have
+// expired since it was last called.
+func (s *testingServerImpl) expireStateSessions() {
+	for _, namespace := range s.stateSessions.Expire() {
+		s.purgeNamespace(namespace)
+	}
+}
+
+func (s *testingServerImpl) StartSession(_ context.Context, _ *pb.StartSessionRequest) (*pb.StartSessionResponse, error) {
+	s.expireStateSessions()
+	id := s.stateSessions.Start()
+	return &pb.StartSessionResponse{
+		SessionId: id,
+		Namespace: server.StateSessionNamespace(id),
+		IdleTtl:   ptypes.DurationProto(s.stateSessions.TTL()),
+	}, nil
+}
+
+func (s *testingServerImpl) EndSession(_ context.Context, req *pb.EndSessionRequest) (*pb.EndSessionResponse, error) {
+	if req.GetSessionId() == "" {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldRequired, "session_id", "The field `session_id` is required.")
+	}
+	s.expireStateSessions()
+	namespace, ok := s.stateSessions.End(req.GetSessionId())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "The session %q does not exist or has expired.", req.GetSessionId())
+	}
+	return &pb.EndSessionResponse{Purged: s.purgeNamespace(namespace)}, nil
+}
+
+func (s *testingServerImpl) GetEndpoints(_ context.Context, _ *pb.GetEndpointsRequest) (*pb.GetEndpointsResponse, error) {
+	return &pb.GetEndpointsResponse{Endpoints: s.replicas.Endpoints()}, nil
+}
+
+func (s *testingServerImpl) ExpectRequest(ctx context.Context, req *pb.ExpectRequestRequest) (*pb.Expectation, error) {
+	if req.GetMethod() == "" {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldRequired, "method", "The field `method` is required.")
+	}
+	input, ok := server.ShowcaseMethodInput(req.GetMethod())
+	if !ok {
+		return nil, showcaseerrors.Field(
+			showcaseerrors.FieldInvalid,
+			"method",
+			"The field `method` names %q, which is not a Showcase method. The methods are: %s.",
+			req.GetMethod(),
+			strings.Join(server.ShowcaseMethods(), ", "))
+	}
+	if req.GetExpected() == nil {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldRequired, "expected", "The field `expected` is required.")
+	}
+	var expected ptypes.DynamicAny
+	if err := ptypes.UnmarshalAny(req.GetExpected(), &expected); err != nil {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldInvalid, "expected", "The field `expected` could not be unpacked: %s.", err)
+	}
+	if name := proto.MessageName(expected.Message); name != input {
+		return nil, showcaseerrors.Field(
+			showcaseerrors.FieldInvalid,
+			"expected",
+			"The field `expected` is a %s, but %s takes a %s.",
+			name,
+			req.GetMethod(),
+			input)
+	}
+	if err := server.CheckFieldMask("field_mask", req.GetFieldMask(), expected.Message); err != nil {
+		return nil, err
+	}
+	return s.expectations.Expect(
+		server.NamespaceFromContext(ctx),
+		req.GetMethod(),
+		expected.Message,
+		req.GetFieldMask(),
+		req.GetFailOnMismatch()), nil
+}
+
+func (s *testingServerImpl) GetExpectationResult(ctx context.Context, req *pb.GetExpectationResultRequest) (*pb.Expectation, error) {
+	if req.GetId() == "" {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldRequired, "id", "The field `id` is required.")
+	}
+	e, ok := s.expectations.Get(server.NamespaceFromContext(ctx), req.GetId())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "The expectation %q does not exist.", req.GetId())
+	}
+	return e, nil
+}
+
+func (s *testingServerImpl) LoadScenario(ctx context.Context, req *pb.LoadScenarioRequest) (*pb.Scenario, error) {
+	if err := server.ValidateScenario(req.GetName(), req.GetScript()); err != nil {
+		return nil, err
+	}
+	return s.scenarios.Load(server.NamespaceFromContext(ctx), req.GetName(), req.GetScript()), nil
+}
+
+func (s *testingServerImpl) GetScenarioState(ctx context.Context, _ *pb.GetScenarioStateRequest) (*pb.Scenario, error) {
+	sc, ok := s.scenarios.Get(server.NamespaceFromContext(ctx))
+	if !ok {
+		return nil, status.Error(codes.NotFound, "The namespace has no active scenario. Load one with LoadScenario.")
+	}
+	return sc, nil
+}
+
+func (s *testingServerImpl) ResetByteBudget(_ context.Context, req *pb.ResetByteBudgetRequest) (*pb.ResetByteBudgetResponse, error) {
+	if err := server.ValidateNamespace(req.GetNamespace()); err != nil {
+		return nil, err
+	}
+	return &pb.ResetByteBudgetResponse{UsedBytes: s.byteBudgets.Reset(req.GetNamespace())}, nil
+}
+
+func (s *testingServerImpl) GetServerMetrics(_ context.Context, _ *pb.GetServerMetricsRequest) (*pb.ServerMetrics, error) {
+	return &pb.ServerMetrics{Values: s.metrics.Snapshot()}, nil
+}
+
+func (s *testingServerImpl) GetChannelzSummary(ctx context.Context, _ *pb.GetChannelzSummaryRequest) (*pb.ChannelzSummary, error) {
+	return s.channelz.Summary(ctx)
+}
+
+// resourceNamePatterns are the patterns of ParseResourceNames, in the order
+// they are tried.
+var resourceNamePatterns = []struct {
+	pattern pb.ResourceNamePattern
+	server.ResourcePattern
+}{
+	{pb.ResourceNamePattern_USER, server.NewResourcePattern("users/{user}")},
+	{pb.ResourceNamePattern_USER_MESSAGE, server.NewResourcePattern("users/{user}/messages/{message}")},
+}
+
+func parseResourceName(field, name string) (*pb.ParsedResourceName, error) {
+	patterns := make([]server.ResourcePattern, len(resourceNamePatterns))
+	for i, p := range resourceNamePatterns {
+		patterns[i] = p.ResourcePattern
+	}
+	parsed, err := server.ParseResourceName(name, patterns...)
+	if err != nil {
+		return nil, showcaseerrors.Field(
+			showcaseerrors.FieldInvalid,
+			field,
+			"The field `%s` is not a valid resource name: %s.",
+			field,
+			err)
+	}
+	ret := &pb.ParsedResourceName{Name: name, Segments: parsed.Segments, Wildcards: parsed.Wildcards}
+	for _, p := range resourceNamePatterns {
+		if p.Template == parsed.Pattern.Template {
+			ret.Pattern = p.pattern
+		}
+	}
+	return ret, nil
+}
+
+func (s *testingServerImpl) ParseResourceNames(_ context.Context, req *pb.ParseResourceNamesRequest) (*pb.ParseResourceNamesResponse, error) {
+	resp := &pb.ParseResourceNamesResponse{}
+	if req.GetSingleParam() != "" {
+		parsed, err := parseResourceName("single_param", req.GetSingleParam())
+		if err != nil {
+			return nil, err
+		}
+		resp.SingleParam = parsed
+	}
+	for i, name := range req.GetRepeatedParams() {
+		parsed, err := parseResourceName(fmt.Sprintf("repeated_params[%d]", i), name)
+		if err != nil {
+			return nil, err
+		}
+		resp.RepeatedParams = append(resp.RepeatedParams, parsed)
+	}
+	return resp, nil
+}
+
+// showcaseProtoFiles are the files that define the Showcase API.
+var showcaseProtoFiles = []string{
+	"google/showcase/v1beta1/echo.proto",
+	"google/showcase/v1beta1/identity.proto",
+	"google/showcase/v1beta1/messaging.proto",
+	"google/showcase/v1beta1/testing.proto",
+}
+
+func (s *testingServerImpl) GetShowcaseDescriptors(_ context.Context, req *pb.GetShowcaseDescriptorsRequest) (*pb.GetShowcaseDescriptorsResponse, error) {
+	files := map[string]*descriptor.FileDescriptorProto{}
+	roots := showcaseProtoFiles
+	if req.GetService() != "" {
+		roots = nil
+		for _, name := range showcaseProtoFiles {
+			fd, err := loadFileDescriptor(name, files)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			for _, svc := range fd.GetService() {
+				if fd.GetPackage()+"."+svc.GetName() == req.GetService() {
+					roots = append(roots, name)
+				}
+			}
+		}
+		if len(roots) == 0 {
+			return nil, status.Errorf(codes.NotFound, "The service %q is not a Showcase service.", req.GetService())
+		}
+	}
+
+	set := &descriptor.FileDescriptorSet{}
+	seen := map[string]bool{}
+	var visit func(name string) error
+	visit = func(name string) error {
+		if seen[name] {
+			return nil
+		}
+		seen[name] = true
+		fd, err := loadFileDescriptor(name, files)
+		if err != nil {
+			return err
+		}
+		for _, dep := range fd.GetDependency() {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		set.File = append(set.File, fd)
+		return nil
+	}
+	for _, name := range roots {
+		if err := visit(name); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &pb.GetShowcaseDescriptorsResponse{FileDescriptorSet: set}, nil
+}
+
+// loadFileDescriptor returns the descriptor of the named file from the proto
+// registry, caching it in files.
+func loadFileDescriptor(name string, files map[string]*descriptor.FileDescriptorProto) (*descriptor.FileDescriptorProto, error) {
+	if fd, ok := files[name]; ok {
+		return fd, nil
+	}
+	gz := proto.FileDescriptor(name)
+	if gz == nil {
+		return nil, fmt.Errorf("the file %q is not registered", name)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	fd := &descriptor.FileDescriptorProto{}
+	if err := proto.Unmarshal(b, fd); err != nil {
+		return nil, err
+	}
+	files[name] = fd
+	return fd, nil
+}
+
+func (s *testingServerImpl) MeasureRoundTrip(ctx context.Context, req *pb.MeasureRoundTripRequest) (*pb.MeasureRoundTripResponse, error) {
+	if req.GetCount() <= 0 || req.GetCount() > server.MaxRoundTripCount {
+		return nil, showcaseerrors.Field(
+			showcaseerrors.FieldOutOfRange,
+			"count",
+			"The field `count` must be between 1 and %d.",
+			server.MaxRoundTripCount)
+	}
+	if req.GetPayloadSize() < 0 {
+		return nil, showcaseerrors.Field(showcaseerrors.FieldOutOfRange, "payload_size", "The field `payload_size` must not be negative.")
+	}
+	if int64(req.GetCount())*int64(req.GetPayloadSize()) > server.MaxRoundTripBytes {
+		return nil, showcaseerrors.Field(
+			showcaseerrors.FieldOutOfRange,
+			"count",
+			"The fields `count` and `payload_size` must have a product of at most %d.",
+			server.MaxRoundTripBytes)
+	}
+
+	durations, err := server.MeasureRoundTrips(ctx, int(req.GetCount()), int(req.GetPayloadSize()))
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.GetShowcaseSettings(ctx, &pb.GetShowcaseSettingsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.MeasureRoundTripResponse{
+		Count:    int32(len(durations)),
+		P50:      ptypes.DurationProto(server.Percentile(durations, 50)),
+		P90:      ptypes.DurationProto(server.Percentile(durations, 90)),
+		P99:      ptypes.DurationProto(server.Percentile(durations, 99)),
+		Max:      ptypes.DurationProto(server.Percentile(durations, 100)),
+		Settings: settings,
+	}, nil
+}
+
+func (s *testingServerImpl) DumpState(_ context.Context, req *pb.DumpStateRequest) (*pb.ServerState, error) {
+	if !s.settings.Get().EnableAdmin {
+		return nil, status.Error(
+			codes.PermissionDenied,
+			"DumpState requires the server to be started with --enable-admin.")
+	}
+
+	thaw := server.FreezeState()
+	settings := s.settings.Get()
+	corpora := s.corpora.List()
+	blobs := s.blobs.list()
+	resources := s.echoResources.List()
+	polls := s.pollRecorder.List()
+	cached := s.dedupe.List()
+	thaw()
+
+	redact := func(words []string) []string {
+		if !req.GetRedactPayloads() {
+			return words
+		}
+		return make([]string, len(words))
+	}
+	namespaces := map[string]*pb.NamespaceState{}
+	namespace := func(name string) *pb.NamespaceState {
+		ns, ok := namespaces[name]
+		if !ok {
+			ns = &pb.NamespaceState{Namespace: name}
+			namespaces[name] = ns
+		}
+		return ns
+	}
+	for _, c := range corpora {
+		ns := namespace(c.Namespace)
+		ns.Corpora = append(ns.Corpora, &pb.EchoCorpus{Name: c.Name, Words: redact(c.Words)})
+	}
+	for name, states := range blobs {
+		namespace(name).Blobs = states
+	}
+	for name, names := range resources {
+		namespace(name).EchoResources = names
+	}
+	for _, op := range polls {
+		last, _ := ptypes.TimestampProto(op.Polls[len(op.Polls)-1])
+		ns := namespace(op.Namespace)
+		ns.Operations = append(ns.Operations, &pb.PolledOperation{
+			Name:         op.Name,
+			PollCount:    int32(len(op.Polls)),
+			LastPollTime: last,
+		})
+	}
+	for _, e := range cached {
+		resp, _ := e.Response.(*pb.EchoResponse)
+		content := resp.GetContent()
+		if req.GetRedactPayloads() {
+			content = ""
+		}
+		stored, _ := ptypes.TimestampProto(e.Stored)
+		expires, _ := ptypes.TimestampProto(e.Expires)
+		ns := namespace(e.Namespace)
+		ns.CachedResponses = append(ns.CachedResponses, &pb.CachedEchoResponse{
+			RequestHash:    e.Key,
+			StoreTime:      stored,
+			ExpireTime:     expires,
+			Content:        content,
+			ServerSequence: resp.GetServerSequence(),
+		})
+	}
+
+	state := &pb.ServerState{
+		Uptime:   ptypes.DurationProto(s.nowF().Sub(s.started)),
+		Settings: server.SettingsProto(settings),
+	}
+	for _, ns := range namespaces {
+		state.Namespaces = append(state.Namespaces, ns)
+	}
+	sort.Slice(state.Namespaces, func(i, j int) bool {
+		return state.Namespaces[i].GetNamespace() < state.Namespaces[j].GetNamespace()
+	})
+	return state, nil
+}
+
+func (s *testingServerImpl) ListConformanceScenarios(_ context.Context, in *pb.ListConformanceScenariosRequest) (*pb.ListConformanceScenariosResponse, error) {
+	resp := &pb.ListConformanceScenariosResponse{}
+	for _, scenario := range conformanceScenarios {
+		if in.GetMethod() == "" || usesMethod(scenario, in.GetMethod()) {
+			resp.Scenarios = append(resp.Scenarios, proto.Clone(scenario).(*pb.ConformanceScenario))
+		}
+	}
+	sort.Slice(resp.Scenarios, func(i, j int) bool {
+		return resp.Scenarios[i].GetId() < resp.Scenarios[j].GetId()
+	})
+	return resp, nil
+}
+
+// usesMethod returns whether the scenario calls the method.
+func usesMethod(scenario *pb.ConformanceScenario, method string) bool {
+	for _, m := range scenario.GetMethods() {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
@@ -17,13 +17,31 @@ package services
 import (
 	"context"
 	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/googleapis/gapic-showcase/server"
 	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/interceptors"
+	lropb "google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -330,3 +348,1224 @@ func Test_VerifyTest(t *testing.T) {
 		t.Errorf("VerifyTest want %+v got %+v", &pb.VerifyTestResponse{}, got)
 	}
 }
+
+func Test_GetOperationPollingReport(t *testing.T) {
+	now := time.Unix(100, 0)
+	recorder := server.NewPollRecorder(func() time.Time { return now })
+	s := &testingServerImpl{pollRecorder: recorder}
+
+	name := "operations/google.showcase.v1beta1.Echo/Wait/abc"
+	for _, d := range []time.Duration{time.Second, 2 * time.Second, 0} {
+		recorder.Record(server.DefaultNamespace, name)
+		now = now.Add(d)
+	}
+
+	got, err := s.GetOperationPollingReport(
+		context.Background(),
+		&pb.GetOperationPollingReportRequest{Name: name})
+	if err != nil {
+		t.Errorf("GetOperationPollingReport: unexpected err %+v", err)
+	}
+	want := &pb.OperationPollingReport{
+		Name: name,
+		PollTimes: []*timestamp.Timestamp{
+			{Seconds: 100},
+			{Seconds: 101},
+			{Seconds: 103},
+		},
+		Intervals: []*duration.Duration{
+			{Seconds: 1},
+			{Seconds: 2},
+		},
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("GetOperationPollingReport want %+v got %+v", want, got)
+	}
+}
+
+func Test_GetOperationPollingReport_noPolls(t *testing.T) {
+	s := &testingServerImpl{pollRecorder: server.NewPollRecorder(time.Now)}
+	got, err := s.GetOperationPollingReport(
+		context.Background(),
+		&pb.GetOperationPollingReportRequest{Name: "operations/never"})
+	if err != nil {
+		t.Errorf("GetOperationPollingReport: unexpected err %+v", err)
+	}
+	if len(got.GetPollTimes()) != 0 || len(got.GetIntervals()) != 0 {
+		t.Errorf("GetOperationPollingReport expected an empty report, got %+v", got)
+	}
+
+	_, err = s.GetOperationPollingReport(context.Background(), &pb.GetOperationPollingReportRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetOperationPollingReport: Want error code %d got %d", codes.InvalidArgument, status.Code(err))
+	}
+}
+
+func Test_GetShowcaseDescriptors(t *testing.T) {
+	s := NewTestingServer(server.ShowcaseObserverRegistry())
+	res, err := s.GetShowcaseDescriptors(context.Background(), &pb.GetShowcaseDescriptorsRequest{})
+	if err != nil {
+		t.Fatalf("GetShowcaseDescriptors: unexpected err %+v", err)
+	}
+	services := checkDescriptorSet(t, res.GetFileDescriptorSet())
+	for _, want := range []string{
+		"google.showcase.v1beta1.Echo",
+		"google.showcase.v1beta1.Identity",
+		"google.showcase.v1beta1.Messaging",
+		"google.showcase.v1beta1.Testing",
+	} {
+		if services[want] != 1 {
+			t.Errorf("GetShowcaseDescriptors: want service %s once, got %d", want, services[want])
+		}
+	}
+}
+
+func Test_GetShowcaseDescriptors_service(t *testing.T) {
+	s := NewTestingServer(server.ShowcaseObserverRegistry())
+	res, err := s.GetShowcaseDescriptors(
+		context.Background(),
+		&pb.GetShowcaseDescriptorsRequest{Service: "google.showcase.v1beta1.Echo"})
+	if err != nil {
+		t.Fatalf("GetShowcaseDescriptors: unexpected err %+v", err)
+	}
+	services := checkDescriptorSet(t, res.GetFileDescriptorSet())
+	if services["google.showcase.v1beta1.Echo"] != 1 || services["google.showcase.v1beta1.Messaging"] != 0 {
+		t.Errorf("GetShowcaseDescriptors: want only the Echo Showcase service, got %v", services)
+	}
+
+	files := map[string]bool{}
+	for _, f := range res.GetFileDescriptorSet().GetFile() {
+		files[f.GetName()] = true
+	}
+	for _, want := range []string{
+		"google/showcase/v1beta1/echo.proto",
+		"google/longrunning/operations.proto",
+		"google/protobuf/duration.proto",
+		"google/protobuf/any.proto",
+	} {
+		if !files[want] {
+			t.Errorf("GetShowcaseDescriptors: want file %s in %v", want, files)
+		}
+	}
+}
+
+func Test_GetShowcaseDescriptors_notFound(t *testing.T) {
+	s := NewTestingServer(server.ShowcaseObserverRegistry())
+	for _, name := range []string{"Echo", "google.showcase.v1beta1.Nope", "google.longrunning.Operations"} {
+		_, err := s.GetShowcaseDescriptors(context.Background(), &pb.GetShowcaseDescriptorsRequest{Service: name})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("GetShowcaseDescriptors(%q): want NotFound got %v", name, err)
+		}
+	}
+}
+
+// checkDescriptorSet verifies that every file in the set appears once and
+// after its dependencies, and that every referenced type is defined exactly
+// once. It returns a count of the services defined in the set.
+func checkDescriptorSet(t *testing.T, set *descriptor.FileDescriptorSet) map[string]int {
+	seen := map[string]bool{}
+	defined := map[string]int{}
+	services := map[string]int{}
+	var defineMessages func(prefix string, msgs []*descriptor.DescriptorProto)
+	defineMessages = func(prefix string, msgs []*descriptor.DescriptorProto) {
+		for _, m := range msgs {
+			name := prefix + "." + m.GetName()
+			defined[name]++
+			for _, e := range m.GetEnumType() {
+				defined[name+"."+e.GetName()]++
+			}
+			defineMessages(name, m.GetNestedType())
+		}
+	}
+	for _, f := range set.GetFile() {
+		if seen[f.GetName()] {
+			t.Errorf("file %s appears more than once", f.GetName())
+		}
+		for _, dep := range f.GetDependency() {
+			if !seen[dep] {
+				t.Errorf("file %s appears before its dependency %s", f.GetName(), dep)
+			}
+		}
+		seen[f.GetName()] = true
+
+		prefix := "." + f.GetPackage()
+		defineMessages(prefix, f.GetMessageType())
+		for _, e := range f.GetEnumType() {
+			defined[prefix+"."+e.GetName()]++
+		}
+		for _, svc := range f.GetService() {
+			services[f.GetPackage()+"."+svc.GetName()]++
+		}
+	}
+
+	check := func(name string) {
+		if defined[name] != 1 {
+			t.Errorf("type %s is defined %d times, want once", name, defined[name])
+		}
+	}
+	var checkMessages func(msgs []*descriptor.DescriptorProto)
+	checkMessages = func(msgs []*descriptor.DescriptorProto) {
+		for _, m := range msgs {
+			for _, field := range m.GetField() {
+				if field.GetTypeName() != "" {
+					check(field.GetTypeName())
+				}
+			}
+			checkMessages(m.GetNestedType())
+		}
+	}
+	for _, f := range set.GetFile() {
+		checkMessages(f.GetMessageType())
+		for _, svc := range f.GetService() {
+			for _, m := range svc.GetMethod() {
+				check(m.GetInputType())
+				check(m.GetOutputType())
+			}
+		}
+	}
+	return services
+}
+
+func Test_GetShowcaseSettings(t *testing.T) {
+	s := NewTestingServer(server.ShowcaseObserverRegistry())
+	got, err := s.GetShowcaseSettings(context.Background(), &pb.GetShowcaseSettingsRequest{})
+	if err != nil {
+		t.Fatalf("GetShowcaseSettings: unexpected err %+v", err)
+	}
+	want := &pb.ShowcaseSettings{
+		MaxCollectContentBytes: 1 << 20,
+		DefaultBlobChunkSize:   64 * 1024,
+		MaxBlobChunkSize:       4 * 1024 * 1024,
+		MaxBlobSize:            16 * 1024 * 1024,
+		MaxBlobStorageSize:     256 * 1024 * 1024,
+		BlobTtl:                ptypes.DurationProto(time.Hour),
+		SupportedLocales:       []string{"en", "es", "ja"},
+		MaxRecordedPolls:       server.MaxRecordedPolls,
+		MaxPollWait:            ptypes.DurationProto(30 * time.Second),
+		MaxSendMessageBytes:    4 * 1024 * 1024,
+		PageTokenTtl:           ptypes.DurationProto(0),
+		ClientAttemptHeader:    server.DefaultClientAttemptHeader,
+		MaxBatchEchoSize:       1000,
+		ErrorInjection:         &pb.ErrorInjection{},
+		OperationTtl:           ptypes.DurationProto(0),
+		LogRedactions: map[string]*pb.LogRedaction{
+			"google.showcase.v1beta1.EchoRequest":                {Paths: []string{"error.details"}},
+			"google.showcase.v1beta1.InspectCredentialsResponse": {Paths: []string{"credentials.values"}},
+		},
+		MaxLoggedBytes:    256,
+		MaxStreamDuration: ptypes.DurationProto(0),
+		ByteBudgetWindow:  ptypes.DurationProto(0),
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("GetShowcaseSettings: want %v got %v", want, got)
+	}
+}
+
+func Test_GetShowcaseSettings_live(t *testing.T) {
+	store := server.NewSettingsStore(server.DefaultSettings())
+	ts := &testingServerImpl{settings: store}
+	echo := &echoServerImpl{settings: store, blobs: newBlobStore(store, time.Now)}
+
+	settings := server.DefaultSettings()
+	settings.MaxCollectContentBytes = 5
+	settings.MaxBlobSize = 10
+	settings.SupportedLocales = []string{"ja"}
+	store.Set(settings)
+
+	got, _ := ts.GetShowcaseSettings(context.Background(), &pb.GetShowcaseSettingsRequest{})
+	if got.GetMaxCollectContentBytes() != 5 || got.GetMaxBlobSize() != 10 || len(got.GetSupportedLocales()) != 1 {
+		t.Errorf("GetShowcaseSettings: want the changed settings, got %v", got)
+	}
+
+	// The Echo service enforces the same settings.
+	err := echo.Collect(&mockCollectStream{
+		reqs: []*pb.EchoRequest{{Response: &pb.EchoRequest_Content{Content: "too long"}}},
+		t:    t,
+	})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Collect: want ResourceExhausted for content over the changed limit, got %v", err)
+	}
+	_, err = echo.blobs.open(server.DefaultNamespace, "blob", 11)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("WriteBlob: want InvalidArgument for a blob over the changed limit, got %v", err)
+	}
+}
+
+func Test_UpdateShowcaseSettings(t *testing.T) {
+	store := server.NewSettingsStore(server.DefaultSettings())
+	ts := &testingServerImpl{settings: store}
+	got, err := ts.UpdateShowcaseSettings(context.Background(), &pb.UpdateShowcaseSettingsRequest{
+		Settings:   &pb.ShowcaseSettings{MaxBlobSize: 10},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"max_blob_size"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := server.SettingsProto(server.DefaultSettings()); !proto.Equal(got.GetPrevious(), want) {
+		t.Errorf("UpdateShowcaseSettings: want previous %v got %v", want, got.GetPrevious())
+	}
+	if got.GetCurrent().GetMaxBlobSize() != 10 || store.Get().MaxBlobSize != 10 {
+		t.Errorf("UpdateShowcaseSettings: want max_blob_size 10 got %v", got.GetCurrent())
+	}
+
+	_, err = ts.UpdateShowcaseSettings(context.Background(), &pb.UpdateShowcaseSettingsRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("UpdateShowcaseSettings without settings: want InvalidArgument got %v", err)
+	}
+}
+
+func Test_UpdateShowcaseSettings_midWorkload(t *testing.T) {
+	store := server.NewSettingsStore(server.DefaultSettings())
+	ts := &testingServerImpl{settings: store}
+	echo := &echoServerImpl{settings: store, sequence: server.NewSequence()}
+	echoIn := &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}}
+	in := &pb.BatchEchoRequest{Requests: []*pb.EchoRequest{echoIn, echoIn}}
+
+	// Calls that end before the update starts must succeed, and calls that
+	// start after it returns must fail. Calls overlapping it may do either.
+	const (
+		beforeUpdate int32 = iota
+		duringUpdate
+		afterUpdate
+	)
+	var phase int32
+	var succeeded, failed, early, late int64
+	done := make(chan struct{})
+	errs := make(chan error, 8)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				start := atomic.LoadInt32(&phase)
+				_, err := echo.BatchEcho(context.Background(), in)
+				end := atomic.LoadInt32(&phase)
+				if err != nil && status.Code(err) != codes.InvalidArgument {
+					errs <- err
+					return
+				}
+				switch {
+				case end == beforeUpdate && err == nil:
+					atomic.AddInt64(&succeeded, 1)
+				case end == beforeUpdate:
+					atomic.AddInt64(&early, 1)
+				case start == afterUpdate && err != nil:
+					atomic.AddInt64(&failed, 1)
+				case start == afterUpdate:
+					atomic.AddInt64(&late, 1)
+				}
+			}
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	atomic.StoreInt32(&phase, duringUpdate)
+	_, err := ts.UpdateShowcaseSettings(context.Background(), &pb.UpdateShowcaseSettingsRequest{
+		Settings:   &pb.ShowcaseSettings{MaxBatchEchoSize: 1},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"max_batch_echo_size"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	atomic.StoreInt32(&phase, afterUpdate)
+	time.Sleep(20 * time.Millisecond)
+	close(done)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("BatchEcho: want only successes or InvalidArgument got %v", err)
+	}
+	if succeeded == 0 || early != 0 {
+		t.Errorf("BatchEcho before the update: want only successes got %d successes and %d failures", succeeded, early)
+	}
+	if failed == 0 || late != 0 {
+		t.Errorf("BatchEcho after the update: want only failures got %d successes and %d failures", late, failed)
+	}
+}
+
+func Test_SetMethodOverload(t *testing.T) {
+	limiter := server.NewOverloadLimiter(time.Now)
+	s := &testingServerImpl{overloadLimiter: limiter}
+	method := "/google.showcase.v1beta1.Echo/Echo"
+
+	_, err := s.SetMethodOverload(context.Background(), &pb.SetMethodOverloadRequest{
+		Method:     method,
+		QpsLimit:   1,
+		RetryDelay: &duration.Duration{Seconds: 3},
+	})
+	if err != nil {
+		t.Fatalf("SetMethodOverload: unexpected err %+v", err)
+	}
+
+	calls := 0
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		return req, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	limiter.UnaryInterceptor(context.Background(), nil, info, handler)
+	_, err = limiter.UnaryInterceptor(context.Background(), nil, info, handler)
+	if calls != 1 || status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("SetMethodOverload: want the second call rejected, got %d calls (%v)", calls, err)
+	}
+
+	s.SetMethodOverload(context.Background(), &pb.SetMethodOverloadRequest{Method: method})
+	if _, err := limiter.UnaryInterceptor(context.Background(), nil, info, handler); err != nil {
+		t.Errorf("SetMethodOverload: want the limit cleared, got %v", err)
+	}
+}
+
+func Test_SetMethodOverload_invalid(t *testing.T) {
+	s := &testingServerImpl{overloadLimiter: server.NewOverloadLimiter(time.Now)}
+	tests := []*pb.SetMethodOverloadRequest{
+		{QpsLimit: 1},
+		{Method: "/google.showcase.v1beta1.Testing/SetMethodOverload", QpsLimit: 1},
+		{Method: "/google.showcase.v1beta1.Echo/Echo", QpsLimit: -1},
+		{Method: "/google.showcase.v1beta1.Echo/Echo", QpsLimit: 1, RetryDelay: &duration.Duration{Seconds: -1}},
+	}
+	for _, req := range tests {
+		if _, err := s.SetMethodOverload(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("SetMethodOverload(%v): want InvalidArgument got %v", req, err)
+		}
+	}
+}
+
+func Test_CreateEchoCorpus(t *testing.T) {
+	ts := &testingServerImpl{corpora: server.NewCorpusStore()}
+	got, err := ts.CreateEchoCorpus(
+		context.Background(),
+		&pb.CreateEchoCorpusRequest{Name: "words", Words: []string{"a", "b"}})
+	if err != nil {
+		t.Fatalf("CreateEchoCorpus: unexpected err %+v", err)
+	}
+	want := &pb.EchoCorpus{Name: "words", Words: []string{"a", "b"}}
+	if !proto.Equal(got, want) {
+		t.Errorf("CreateEchoCorpus: want %v got %v", want, got)
+	}
+
+	tests := []struct {
+		req  *pb.CreateEchoCorpusRequest
+		code codes.Code
+	}{
+		{&pb.CreateEchoCorpusRequest{Words: []string{"a"}}, codes.InvalidArgument},
+		{&pb.CreateEchoCorpusRequest{Name: "empty"}, codes.InvalidArgument},
+		{&pb.CreateEchoCorpusRequest{Name: "words", Words: []string{"a"}}, codes.AlreadyExists},
+	}
+	for _, test := range tests {
+		if _, err := ts.CreateEchoCorpus(context.Background(), test.req); status.Code(err) != test.code {
+			t.Errorf("CreateEchoCorpus(%v): want %s got %v", test.req, test.code, err)
+		}
+	}
+}
+
+func Test_CreateEchoCorpus_storeCap(t *testing.T) {
+	ts := &testingServerImpl{corpora: server.NewCorpusStore()}
+	for i := 0; i < server.MaxCorpora; i++ {
+		req := &pb.CreateEchoCorpusRequest{Name: fmt.Sprint(i), Words: []string{"w"}}
+		if _, err := ts.CreateEchoCorpus(context.Background(), req); err != nil {
+			t.Fatalf("CreateEchoCorpus(%d): unexpected err %+v", i, err)
+		}
+	}
+	req := &pb.CreateEchoCorpusRequest{Name: "one-too-many", Words: []string{"w"}}
+	if _, err := ts.CreateEchoCorpus(context.Background(), req); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("CreateEchoCorpus in a full store: want ResourceExhausted got %v", err)
+	}
+}
+
+func Test_DeleteEchoCorpus(t *testing.T) {
+	ts := &testingServerImpl{corpora: server.NewCorpusStore()}
+	ts.CreateEchoCorpus(context.Background(), &pb.CreateEchoCorpusRequest{Name: "words", Words: []string{"a"}})
+
+	if _, err := ts.DeleteEchoCorpus(context.Background(), &pb.DeleteEchoCorpusRequest{Name: "words"}); err != nil {
+		t.Errorf("DeleteEchoCorpus: unexpected err %+v", err)
+	}
+	if _, err := ts.DeleteEchoCorpus(context.Background(), &pb.DeleteEchoCorpusRequest{Name: "words"}); status.Code(err) != codes.NotFound {
+		t.Errorf("DeleteEchoCorpus of a deleted corpus: want NotFound got %v", err)
+	}
+	if _, err := ts.DeleteEchoCorpus(context.Background(), &pb.DeleteEchoCorpusRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("DeleteEchoCorpus without a name: want InvalidArgument got %v", err)
+	}
+}
+
+// namespaceClient is a logical client of a Showcase server in one namespace.
+type namespaceClient struct {
+	ctx        context.Context
+	echo       pb.EchoClient
+	testing    pb.TestingClient
+	operations lropb.OperationsClient
+}
+
+func (c *namespaceClient) expandCorpus(t *testing.T, name string) ([]string, error) {
+	stream, err := c.echo.Expand(c.ctx, &pb.ExpandRequest{CorpusName: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	words := []string{}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return words, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		words = append(words, resp.GetContent())
+	}
+}
+
+func (c *namespaceClient) writeBlob(t *testing.T, id string) {
+	stream, err := c.echo.WriteBlob(c.ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Send(&pb.WriteBlobRequest{Request: &pb.WriteBlobRequest_Spec_{Spec: &pb.WriteBlobRequest_Spec{BlobId: id, TotalSize: 2}}})
+	stream.Send(&pb.WriteBlobRequest{Request: &pb.WriteBlobRequest_Chunk_{Chunk: &pb.WriteBlobRequest_Chunk{Data: []byte("hi")}}})
+	if _, err := stream.CloseAndRecv(); err != nil {
+		t.Fatalf("WriteBlob: unexpected err %+v", err)
+	}
+}
+
+func (c *namespaceClient) polls(t *testing.T, name string) int {
+	report, err := c.testing.GetOperationPollingReport(c.ctx, &pb.GetOperationPollingReportRequest{Name: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(report.GetPollTimes())
+}
+
+func Test_PurgeNamespace_isolation(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(server.NamespaceUnaryInterceptor),
+		grpc.StreamInterceptor(server.NamespaceStreamInterceptor))
+	pb.RegisterEchoServer(s, NewEchoServer())
+	pb.RegisterTestingServer(s, NewTestingServer(server.ShowcaseObserverRegistry()))
+	lropb.RegisterOperationsServer(s, NewOperationsServer(NewMessagingServer(NewIdentityServer())))
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := func(namespace string) *namespaceClient {
+		return &namespaceClient{
+			ctx:        metadata.AppendToOutgoingContext(context.Background(), server.NamespaceHeader, namespace),
+			echo:       pb.NewEchoClient(conn),
+			testing:    pb.NewTestingClient(conn),
+			operations: lropb.NewOperationsClient(conn),
+		}
+	}
+	a, b := client("isolation-a"), client("isolation-b")
+
+	// Both clients use the same names, interleaving their calls.
+	if _, err := a.testing.CreateEchoCorpus(a.ctx, &pb.CreateEchoCorpusRequest{Name: "words", Words: []string{"a"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.testing.CreateEchoCorpus(b.ctx, &pb.CreateEchoCorpusRequest{Name: "words", Words: []string{"b", "b"}}); err != nil {
+		t.Fatalf("CreateEchoCorpus of a name used in another namespace: unexpected err %+v", err)
+	}
+	a.writeBlob(t, "blob")
+	if _, err := a.echo.CreateEchoResource(a.ctx, &pb.CreateEchoResourceRequest{Name: "resource"}); err != nil {
+		t.Fatal(err)
+	}
+	op, err := a.echo.Wait(a.ctx, &pb.WaitRequest{End: &pb.WaitRequest_Ttl{Ttl: ptypes.DurationProto(time.Hour)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.operations.GetOperation(a.ctx, &lropb.GetOperationRequest{Name: op.GetName()})
+	b.operations.GetOperation(b.ctx, &lropb.GetOperationRequest{Name: op.GetName()})
+	a.operations.GetOperation(a.ctx, &lropb.GetOperationRequest{Name: op.GetName()})
+
+	if words, err := a.expandCorpus(t, "words"); err != nil || fmt.Sprint(words) != "[a]" {
+		t.Errorf("Expand in namespace a: want [a] got %v, %v", words, err)
+	}
+	if words, err := b.expandCorpus(t, "words"); err != nil || fmt.Sprint(words) != "[b b]" {
+		t.Errorf("Expand in namespace b: want [b b] got %v, %v", words, err)
+	}
+	if n := a.polls(t, op.GetName()); n != 2 {
+		t.Errorf("GetOperationPollingReport in namespace a: want 2 polls got %d", n)
+	}
+	if n := b.polls(t, op.GetName()); n != 1 {
+		t.Errorf("GetOperationPollingReport in namespace b: want 1 poll got %d", n)
+	}
+	if _, err := b.echo.GetWriteStatus(b.ctx, &pb.GetWriteStatusRequest{BlobId: "blob"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetWriteStatus of a blob of another namespace: want NotFound got %v", err)
+	}
+
+	// Purging a leaves b intact.
+	if _, err := b.testing.PurgeNamespace(b.ctx, &pb.PurgeNamespaceRequest{Namespace: "isolation-a"}); err != nil {
+		t.Fatalf("PurgeNamespace: unexpected err %+v", err)
+	}
+	if _, err := a.expandCorpus(t, "words"); status.Code(err) != codes.NotFound {
+		t.Errorf("Expand of a purged corpus: want NotFound got %v", err)
+	}
+	if _, err := a.echo.GetWriteStatus(a.ctx, &pb.GetWriteStatusRequest{BlobId: "blob"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetWriteStatus of a purged blob: want NotFound got %v", err)
+	}
+	if n := a.polls(t, op.GetName()); n != 0 {
+		t.Errorf("GetOperationPollingReport in a purged namespace: want 0 polls got %d", n)
+	}
+	if _, err := a.echo.GetEchoResource(a.ctx, &pb.GetEchoResourceRequest{Name: "resource"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetEchoResource of a purged resource: want NotFound got %v", err)
+	}
+	if words, err := b.expandCorpus(t, "words"); err != nil || fmt.Sprint(words) != "[b b]" {
+		t.Errorf("Expand in namespace b after purging a: want [b b] got %v, %v", words, err)
+	}
+	if n := b.polls(t, op.GetName()); n != 1 {
+		t.Errorf("GetOperationPollingReport in namespace b after purging a: want 1 poll got %d", n)
+	}
+
+	b.testing.PurgeNamespace(b.ctx, &pb.PurgeNamespaceRequest{Namespace: "isolation-b"})
+}
+
+func Test_StateSessions(t *testing.T) {
+	now := time.Unix(1000, 0)
+	sessions := server.NewStateSessions(func() time.Time { return now }, time.Minute)
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unary, stream := interceptors.Chain(interceptors.Options{StateSessions: sessions})
+	s := grpc.NewServer(grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+	ts := NewTestingServer(server.ShowcaseObserverRegistry()).(*testingServerImpl)
+	ts.stateSessions = sessions
+	pb.RegisterEchoServer(s, NewEchoServer())
+	pb.RegisterTestingServer(s, ts)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	testingClient := pb.NewTestingClient(conn)
+	client := func(key, value string) *namespaceClient {
+		return &namespaceClient{
+			ctx:     metadata.AppendToOutgoingContext(context.Background(), key, value),
+			echo:    pb.NewEchoClient(conn),
+			testing: testingClient,
+		}
+	}
+	start := func() (*namespaceClient, *pb.StartSessionResponse) {
+		resp, err := testingClient.StartSession(context.Background(), &pb.StartSessionRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return client(server.StateSessionHeader, resp.GetSessionId()), resp
+	}
+	a, startA := start()
+	b, startB := start()
+	if d, _ := ptypes.Duration(startA.GetIdleTtl()); d != time.Minute {
+		t.Errorf("StartSession: want an idle TTL of a minute, got %v", startA.GetIdleTtl())
+	}
+
+	// Both sessions create state under the same names.
+	for _, c := range []*namespaceClient{a, b} {
+		if _, err := c.testing.CreateEchoCorpus(c.ctx, &pb.CreateEchoCorpusRequest{Name: "words", Words: []string{"a"}}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.echo.CreateEchoResource(c.ctx, &pb.CreateEchoResourceRequest{Name: "resource"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	a.writeBlob(t, "blob")
+
+	resp, err := testingClient.EndSession(context.Background(), &pb.EndSessionRequest{SessionId: startA.GetSessionId()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for store, want := range map[string]int64{"corpora": 1, "echo_resources": 1, "blobs": 1, "expand_statuses": 0} {
+		if got := resp.GetPurged()[store]; got != want {
+			t.Errorf("EndSession: want %d %s purged, got %d", want, store, got)
+		}
+	}
+	if _, err := a.echo.GetEchoResource(a.ctx, &pb.GetEchoResourceRequest{Name: "resource"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetEchoResource in an ended session: want NotFound got %v", err)
+	}
+	if _, err := testingClient.EndSession(context.Background(), &pb.EndSessionRequest{SessionId: startA.GetSessionId()}); status.Code(err) != codes.NotFound {
+		t.Errorf("EndSession of an ended session: want NotFound got %v", err)
+	}
+	inA := client(server.NamespaceHeader, startA.GetNamespace())
+	if _, err := inA.expandCorpus(t, "words"); status.Code(err) != codes.NotFound {
+		t.Errorf("Expand of a corpus of an ended session: want NotFound got %v", err)
+	}
+	if words, err := b.expandCorpus(t, "words"); err != nil || fmt.Sprint(words) != "[a]" {
+		t.Errorf("Expand in the other session: want [a] got %v, %v", words, err)
+	}
+
+	// b expires once idle for a minute, and its state with it.
+	now = now.Add(time.Minute + time.Second)
+	if _, err := b.echo.GetEchoResource(b.ctx, &pb.GetEchoResourceRequest{Name: "resource"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetEchoResource in an expired session: want NotFound got %v", err)
+	}
+	start()
+	inB := client(server.NamespaceHeader, startB.GetNamespace())
+	if _, err := inB.echo.GetEchoResource(inB.ctx, &pb.GetEchoResourceRequest{Name: "resource"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetEchoResource of a resource of an expired session: want NotFound got %v", err)
+	}
+}
+
+func Test_GetEndpoints(t *testing.T) {
+	replicas := server.NewReplicaSet()
+	listeners, err := replicas.ListenReplicas("tcp", "localhost:0", 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, l := range listeners {
+		defer l.Close()
+	}
+	ts := &testingServerImpl{replicas: replicas}
+	resp, err := ts.GetEndpoints(context.Background(), &pb.GetEndpointsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &pb.GetEndpointsResponse{Endpoints: []*pb.Endpoint{
+		{Replica: 0, Address: listeners[0].Addr().String(), Healthy: true},
+		{Replica: 1, Address: listeners[1].Addr().String()},
+	}}
+	if !proto.Equal(resp, want) {
+		t.Errorf("GetEndpoints: want %v, got %v", want, resp)
+	}
+}
+
+func Test_EndSession_invalid(t *testing.T) {
+	ts := &testingServerImpl{stateSessions: server.NewStateSessions(time.Now, time.Minute)}
+	if _, err := ts.EndSession(context.Background(), &pb.EndSessionRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("EndSession without a session ID: want InvalidArgument got %v", err)
+	}
+}
+
+func Test_PurgeNamespace_invalid(t *testing.T) {
+	ts := &testingServerImpl{}
+	if _, err := ts.PurgeNamespace(context.Background(), &pb.PurgeNamespaceRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("PurgeNamespace without a namespace: want InvalidArgument got %v", err)
+	}
+}
+
+func Test_PurgeNamespace_frozen(t *testing.T) {
+	ts := NewTestingServer(server.ShowcaseObserverRegistry())
+	thaw := server.FreezeState()
+	purged := make(chan error)
+	go func() {
+		_, err := ts.PurgeNamespace(context.Background(), &pb.PurgeNamespaceRequest{Namespace: "frozen"})
+		purged <- err
+	}()
+	select {
+	case <-purged:
+		t.Fatal("PurgeNamespace: want the purge to wait while the state is frozen")
+	case <-time.After(20 * time.Millisecond):
+	}
+	thaw()
+	select {
+	case err := <-purged:
+		if err != nil {
+			t.Errorf("PurgeNamespace: unexpected err %+v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("PurgeNamespace: want the purge to finish once the state is thawed")
+	}
+}
+
+func Test_GetServerMetrics(t *testing.T) {
+	metrics := server.NewMetrics()
+	metrics.Add(server.InFlightUnaryRPCsMetric, 3)
+	ts := &testingServerImpl{metrics: metrics}
+	got, err := ts.GetServerMetrics(context.Background(), &pb.GetServerMetricsRequest{})
+	if err != nil {
+		t.Fatalf("GetServerMetrics: unexpected err %+v", err)
+	}
+	want := &pb.ServerMetrics{Values: map[string]int64{server.InFlightUnaryRPCsMetric: 3}}
+	if !proto.Equal(got, want) {
+		t.Errorf("GetServerMetrics: want %v got %v", want, got)
+	}
+}
+
+func Test_ParseResourceNames(t *testing.T) {
+	ts := &testingServerImpl{}
+	got, err := ts.ParseResourceNames(context.Background(), &pb.ParseResourceNamesRequest{
+		SingleParam:    "users/a%2Fb",
+		RepeatedParams: []string{"users/alice/messages/1", "users/*/messages/2"},
+	})
+	if err != nil {
+		t.Fatalf("ParseResourceNames: unexpected err %+v", err)
+	}
+	want := &pb.ParseResourceNamesResponse{
+		SingleParam: &pb.ParsedResourceName{
+			Name:     "users/a%2Fb",
+			Pattern:  pb.ResourceNamePattern_USER,
+			Segments: map[string]string{"user": "a/b"},
+		},
+		RepeatedParams: []*pb.ParsedResourceName{
+			{
+				Name:     "users/alice/messages/1",
+				Pattern:  pb.ResourceNamePattern_USER_MESSAGE,
+				Segments: map[string]string{"user": "alice", "message": "1"},
+			},
+			{
+				Name:      "users/*/messages/2",
+				Pattern:   pb.ResourceNamePattern_USER_MESSAGE,
+				Segments:  map[string]string{"user": "*", "message": "2"},
+				Wildcards: []string{"user"},
+			},
+		},
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("ParseResourceNames: want %v got %v", want, got)
+	}
+}
+
+func Test_ParseResourceNames_invalid(t *testing.T) {
+	tests := []struct {
+		req  *pb.ParseResourceNamesRequest
+		want string
+	}{
+		{
+			&pb.ParseResourceNamesRequest{SingleParam: "users/alice/"},
+			"The field `single_param` is not a valid resource name: segment 3 is empty.",
+		},
+		{
+			&pb.ParseResourceNamesRequest{RepeatedParams: []string{"users/alice", "rooms/1"}},
+			"The field `repeated_params[1]` is not a valid resource name: segment 1 (\"rooms\") does not match any pattern.",
+		},
+	}
+	ts := &testingServerImpl{}
+	for _, test := range tests {
+		_, err := ts.ParseResourceNames(context.Background(), test.req)
+		s, _ := status.FromError(err)
+		if s.Code() != codes.InvalidArgument || s.Message() != test.want {
+			t.Errorf("ParseResourceNames(%v): want InvalidArgument %q got %v", test.req, test.want, err)
+		}
+	}
+}
+
+func Test_GetChannelzSummary_disabled(t *testing.T) {
+	ts := &testingServerImpl{channelz: server.NewChannelzSummarizer()}
+	got, err := ts.GetChannelzSummary(context.Background(), &pb.GetChannelzSummaryRequest{})
+	if err != nil || !proto.Equal(got, &pb.ChannelzSummary{}) {
+		t.Errorf("GetChannelzSummary without channelz: want an empty summary got %v, %v", got, err)
+	}
+}
+
+func Test_MeasureRoundTrip(t *testing.T) {
+	ts := &testingServerImpl{settings: server.NewSettingsStore(server.DefaultSettings())}
+	got, err := ts.MeasureRoundTrip(context.Background(), &pb.MeasureRoundTripRequest{Count: 100, PayloadSize: 512})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GetCount() != 100 {
+		t.Errorf("MeasureRoundTrip: want count 100 got %d", got.GetCount())
+	}
+	var last time.Duration
+	for _, p := range []*duration.Duration{got.GetP50(), got.GetP90(), got.GetP99(), got.GetMax()} {
+		d, err := ptypes.Duration(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d < last {
+			t.Errorf("MeasureRoundTrip: want monotone percentiles got %v", got)
+		}
+		last = d
+	}
+	if got.GetSettings().GetMaxPollWait() == nil {
+		t.Errorf("MeasureRoundTrip: want the server settings got %v", got.GetSettings())
+	}
+}
+
+func Test_MeasureRoundTrip_invalid(t *testing.T) {
+	tests := []*pb.MeasureRoundTripRequest{
+		{},
+		{Count: -1},
+		{Count: server.MaxRoundTripCount + 1},
+		{Count: 1, PayloadSize: -1},
+		{Count: 2, PayloadSize: server.MaxRoundTripBytes/2 + 1},
+	}
+	ts := &testingServerImpl{settings: server.NewSettingsStore(server.DefaultSettings())}
+	for _, req := range tests {
+		if _, err := ts.MeasureRoundTrip(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("MeasureRoundTrip(%v): want InvalidArgument got %v", req, err)
+		}
+	}
+}
+
+func Test_MeasureRoundTrip_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ts := &testingServerImpl{settings: server.NewSettingsStore(server.DefaultSettings())}
+	if _, err := ts.MeasureRoundTrip(ctx, &pb.MeasureRoundTripRequest{Count: 10}); status.Code(err) != codes.Canceled {
+		t.Errorf("MeasureRoundTrip with a canceled context: want Canceled got %v", err)
+	}
+}
+
+func Test_DumpState(t *testing.T) {
+	now := time.Unix(100, 0)
+	clock := func() time.Time { return now }
+	settings := server.DefaultSettings()
+	settings.EnableAdmin = true
+	store := server.NewSettingsStore(settings)
+	ts := &testingServerImpl{
+		settings:      store,
+		corpora:       server.NewCorpusStore(),
+		blobs:         newBlobStore(store, time.Now),
+		echoResources: server.NewEchoResourceStore(),
+		pollRecorder:  server.NewPollRecorder(clock),
+		dedupe:        server.NewDedupeCache(clock, 10),
+		nowF:          clock,
+		started:       now.Add(-time.Minute),
+	}
+	ts.corpora.Create("a", "words", []string{"hello", "world"})
+	ts.corpora.Create("a", "gone", []string{"x"})
+	ts.corpora.Create("b", "words", []string{"b"})
+	blob, _ := ts.blobs.open("a", "blob", 4)
+	ts.blobs.write(blob, 0, []byte("ab"))
+	ts.echoResources.Create("b", "resource")
+	ts.pollRecorder.Record("a", "operations/op")
+	ts.dedupe.Put("b", "hash", &pb.EchoResponse{Content: "cached", ServerSequence: 7}, time.Minute)
+	ts.corpora.Delete("a", "gone")
+
+	got, err := ts.DumpState(context.Background(), &pb.DumpStateRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(t time.Time) *timestamp.Timestamp {
+		ts, _ := ptypes.TimestampProto(t)
+		return ts
+	}
+	want := &pb.ServerState{
+		Uptime:   ptypes.DurationProto(time.Minute),
+		Settings: server.SettingsProto(settings),
+		Namespaces: []*pb.NamespaceState{
+			{
+				Namespace:  "a",
+				Corpora:    []*pb.EchoCorpus{{Name: "words", Words: []string{"hello", "world"}}},
+				Blobs:      []*pb.BlobState{{BlobId: "blob", CommittedSize: 2, TotalSize: 4}},
+				Operations: []*pb.PolledOperation{{Name: "operations/op", PollCount: 1, LastPollTime: at(now)}},
+			},
+			{
+				Namespace:     "b",
+				Corpora:       []*pb.EchoCorpus{{Name: "words", Words: []string{"b"}}},
+				EchoResources: []string{"resource"},
+				CachedResponses: []*pb.CachedEchoResponse{{
+					RequestHash:    "hash",
+					StoreTime:      at(now),
+					ExpireTime:     at(now.Add(time.Minute)),
+					Content:        "cached",
+					ServerSequence: 7,
+				}},
+			},
+		},
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("DumpState: want %v got %v", want, got)
+	}
+
+	// Redaction keeps the shape of the payloads but not their content.
+	got, _ = ts.DumpState(context.Background(), &pb.DumpStateRequest{RedactPayloads: true})
+	want.Namespaces[0].Corpora[0].Words = []string{"", ""}
+	want.Namespaces[1].Corpora[0].Words = []string{""}
+	want.Namespaces[1].CachedResponses[0].Content = ""
+	if !proto.Equal(got, want) {
+		t.Errorf("DumpState with redaction: want %v got %v", want, got)
+	}
+
+	// Purged state is gone from the dump.
+	ts.corpora.PurgeNamespace("a")
+	ts.blobs.purgeNamespace("a")
+	ts.pollRecorder.PurgeNamespace("a")
+	got, _ = ts.DumpState(context.Background(), &pb.DumpStateRequest{})
+	if len(got.GetNamespaces()) != 1 || got.GetNamespaces()[0].GetNamespace() != "b" {
+		t.Errorf("DumpState after purging a: want only namespace b got %v", got.GetNamespaces())
+	}
+}
+
+func Test_DumpState_disabled(t *testing.T) {
+	ts := &testingServerImpl{settings: server.NewSettingsStore(server.DefaultSettings())}
+	if _, err := ts.DumpState(context.Background(), &pb.DumpStateRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("DumpState without --enable-admin: want PermissionDenied got %v", err)
+	}
+}
+
+func Test_ExpectRequest(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectations := server.NewExpectationStore(server.MaxExpectations)
+	unary, stream := interceptors.Chain(interceptors.Options{Expectations: expectations})
+	s := grpc.NewServer(grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+	ts := NewTestingServer(server.ShowcaseObserverRegistry()).(*testingServerImpl)
+	ts.expectations = expectations
+	pb.RegisterEchoServer(s, NewEchoServer())
+	pb.RegisterIdentityServer(s, NewIdentityServer())
+	pb.RegisterTestingServer(s, ts)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ctx := context.Background()
+	testingClient := pb.NewTestingClient(conn)
+	expect := func(method string, m proto.Message, mask []string, fail bool) *pb.Expectation {
+		expected, err := ptypes.MarshalAny(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := &pb.ExpectRequestRequest{Method: method, Expected: expected, FailOnMismatch: fail}
+		if mask != nil {
+			req.FieldMask = &field_mask.FieldMask{Paths: mask}
+		}
+		e, err := testingClient.ExpectRequest(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return e
+	}
+	result := func(e *pb.Expectation) *pb.Expectation {
+		got, err := testingClient.GetExpectationResult(ctx, &pb.GetExpectationResultRequest{Id: e.GetId()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	const echoMethod = "/google.showcase.v1beta1.Echo/Echo"
+	matched := expect(echoMethod, &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}}, nil, false)
+	mismatched := expect(echoMethod, &pb.EchoRequest{Response: &pb.EchoRequest_Error{Error: &spb.Status{Code: 3}}}, []string{"error.code"}, false)
+	if result(matched).GetState() != pb.Expectation_PENDING {
+		t.Errorf("GetExpectationResult before the request: want PENDING, got %v", result(matched))
+	}
+	echo := pb.NewEchoClient(conn)
+	if _, err := echo.Echo(ctx, &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := echo.Echo(ctx, &pb.EchoRequest{Response: &pb.EchoRequest_Error{Error: &spb.Status{Code: 5}}}); status.Code(err) != codes.NotFound {
+		t.Errorf("Echo of a mismatch without fail_on_mismatch: want the echoed NotFound, got %v", err)
+	}
+	if got := result(matched); got.GetState() != pb.Expectation_MATCHED || len(got.GetDiffs()) != 0 {
+		t.Errorf("GetExpectationResult of a matching request: want MATCHED, got %v", got)
+	}
+	want := []*pb.FieldDiff{{FieldPath: "error.code", Expected: "3", Actual: "5"}}
+	if got := result(mismatched); got.GetState() != pb.Expectation_MISMATCHED || len(got.GetDiffs()) != 1 || !proto.Equal(got.GetDiffs()[0], want[0]) {
+		t.Errorf("GetExpectationResult of a mismatch: want MISMATCHED with %v, got %v", want, got)
+	}
+
+	// A mismatch that fails never reaches the method.
+	identity := pb.NewIdentityClient(conn)
+	const createUser = "/google.showcase.v1beta1.Identity/CreateUser"
+	user := expect(createUser, &pb.CreateUserRequest{User: &pb.User{DisplayName: "ada", Email: "ada@example.com"}}, nil, true)
+	_, err = identity.CreateUser(ctx, &pb.CreateUserRequest{User: &pb.User{DisplayName: "ada", Email: "ada@example.org"}})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("CreateUser of a mismatch with fail_on_mismatch: want FailedPrecondition, got %v", err)
+	}
+	reason, _, md := decodeErrorInfo(t, status.Convert(err).Proto().GetDetails()[0].GetValue())
+	if reason != "REQUEST_MISMATCH" || md["expectation"] != user.GetId() || md["fields"] != "user.email" {
+		t.Errorf("CreateUser of a mismatch: want REQUEST_MISMATCH for %s in user.email, got %s %v", user.GetId(), reason, md)
+	}
+	if users, err := identity.ListUsers(ctx, &pb.ListUsersRequest{}); err != nil || len(users.GetUsers()) != 0 {
+		t.Errorf("ListUsers after a failed mismatch: want no users, got %v, %v", users, err)
+	}
+}
+
+func Test_ExpectRequest_invalid(t *testing.T) {
+	ts := &testingServerImpl{expectations: server.NewExpectationStore(server.MaxExpectations)}
+	echoRequest, _ := ptypes.MarshalAny(&pb.EchoRequest{})
+	waitRequest, _ := ptypes.MarshalAny(&pb.WaitRequest{})
+	tests := []*pb.ExpectRequestRequest{
+		{Expected: echoRequest},
+		{Method: "/google.showcase.v1beta1.Echo/Nothing", Expected: echoRequest},
+		{Method: "/google.showcase.v1beta1.Echo/Echo"},
+		{Method: "/google.showcase.v1beta1.Echo/Echo", Expected: waitRequest},
+		{Method: "/google.showcase.v1beta1.Echo/Echo", Expected: echoRequest, FieldMask: &field_mask.FieldMask{Paths: []string{"nothing"}}},
+	}
+	for _, req := range tests {
+		if _, err := ts.ExpectRequest(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ExpectRequest(%v): want InvalidArgument, got %v", req, err)
+		}
+	}
+	if _, err := ts.GetExpectationResult(context.Background(), &pb.GetExpectationResultRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetExpectationResult without an ID: want InvalidArgument, got %v", err)
+	}
+	if _, err := ts.GetExpectationResult(context.Background(), &pb.GetExpectationResultRequest{Id: "1"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetExpectationResult of an unknown ID: want NotFound, got %v", err)
+	}
+}
+
+func Test_ResetByteBudget(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1000, 0)
+	settings := server.NewSettingsStore(server.DefaultSettings())
+	budgets := server.NewByteBudgets(settings, func() time.Time { return now })
+	unary, stream := interceptors.Chain(interceptors.Options{Settings: settings, ByteBudgets: budgets})
+	s := grpc.NewServer(grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+	ts := NewTestingServer(server.ShowcaseObserverRegistry()).(*testingServerImpl)
+	ts.settings = settings
+	ts.byteBudgets = budgets
+	pb.RegisterEchoServer(s, NewEchoServer())
+	pb.RegisterTestingServer(s, ts)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), server.NamespaceHeader, "budgeted")
+	testingClient := pb.NewTestingClient(conn)
+	echo := pb.NewEchoClient(conn)
+	_, err = testingClient.UpdateShowcaseSettings(ctx, &pb.UpdateShowcaseSettingsRequest{
+		Settings:   &pb.ShowcaseSettings{NamespaceByteBudget: 100, ByteBudgetWindow: ptypes.DurationProto(time.Hour)},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"namespace_byte_budget", "byte_budget_window"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Echo until the budget is used up.
+	req := &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: strings.Repeat("a", 20)}}
+	calls := 0
+	for ; calls < 10; calls++ {
+		if _, err = echo.Echo(ctx, req); err != nil {
+			break
+		}
+	}
+	if status.Code(err) != codes.ResourceExhausted || calls != 3 {
+		t.Fatalf("Echo: want ResourceExhausted after 3 calls, got %v after %d", err, calls)
+	}
+	resp, err := testingClient.ResetByteBudget(ctx, &pb.ResetByteBudgetRequest{Namespace: "budgeted"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetUsedBytes() < 100 {
+		t.Errorf("ResetByteBudget: want at least the budget of 100 bytes used, got %d", resp.GetUsedBytes())
+	}
+	if _, err := echo.Echo(ctx, req); err != nil {
+		t.Errorf("Echo once the budget was reset: %v", err)
+	}
+
+	// Exhaust it again, and let the window roll over.
+	for i := 0; i < 2; i++ {
+		echo.Echo(ctx, req)
+	}
+	if _, err := echo.Echo(ctx, req); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Echo over the budget again: want ResourceExhausted, got %v", err)
+	}
+	now = now.Add(time.Hour)
+	if _, err := echo.Echo(ctx, req); err != nil {
+		t.Errorf("Echo once the window rolled over: %v", err)
+	}
+}
+
+func Test_ResetByteBudget_invalid(t *testing.T) {
+	ts := &testingServerImpl{byteBudgets: server.NewByteBudgets(server.NewSettingsStore(server.DefaultSettings()), time.Now)}
+	if _, err := ts.ResetByteBudget(context.Background(), &pb.ResetByteBudgetRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ResetByteBudget without a namespace: want InvalidArgument got %v", err)
+	}
+}
+
+func Test_LoadScenario(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	scenarios := server.NewScenarioStore()
+	unary, stream := interceptors.Chain(interceptors.Options{Scenarios: scenarios})
+	s := grpc.NewServer(grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+	ts := NewTestingServer(server.ShowcaseObserverRegistry()).(*testingServerImpl)
+	ts.scenarios = scenarios
+	pb.RegisterEchoServer(s, NewEchoServer())
+	pb.RegisterTestingServer(s, ts)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), server.NamespaceHeader, "scenario")
+	testingClient := pb.NewTestingClient(conn)
+	echo := pb.NewEchoClient(conn)
+
+	if _, err := testingClient.GetScenarioState(ctx, &pb.GetScenarioStateRequest{}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetScenarioState before LoadScenario: want NotFound got %v", err)
+	}
+	const echoMethod = "/google.showcase.v1beta1.Echo/Echo"
+	loaded, err := testingClient.LoadScenario(ctx, &pb.LoadScenarioRequest{
+		Name: "second-fails",
+		Script: &pb.ScenarioScript{Steps: []*pb.ScenarioStep{
+			{Method: echoMethod, CallIndex: 2, Code: code.Code_UNAVAILABLE, Headers: map[string]string{"x-scenario": "retry"}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.GetName() != "second-fails" || loaded.GetExhausted() || loaded.GetCallCounts()[echoMethod] != 0 {
+		t.Errorf("LoadScenario: want a fresh second-fails got %v", loaded)
+	}
+
+	req := &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: "hi"}}
+	if _, err := echo.Echo(ctx, req); err != nil {
+		t.Errorf("Echo(1): unexpected err %v", err)
+	}
+	var header metadata.MD
+	_, err = echo.Echo(ctx, req, grpc.Header(&header))
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Echo(2): want Unavailable got %v", err)
+	} else if reason, _, md := decodeErrorInfo(t, status.Convert(err).Proto().GetDetails()[0].GetValue()); reason != "SCENARIO_STEP" || md["scenario"] != "second-fails" || md["step"] != "0" {
+		t.Errorf("Echo(2): want SCENARIO_STEP for step 0 of second-fails got %s %v", reason, md)
+	}
+	if got := header.Get("x-scenario"); len(got) != 1 || got[0] != "retry" {
+		t.Errorf("Echo(2): want the header x-scenario: retry got %v", header)
+	}
+	if _, err := echo.Echo(ctx, req); err != nil {
+		t.Errorf("Echo(3): unexpected err %v", err)
+	}
+	// Other namespaces do not share the scenario.
+	if _, err := echo.Echo(context.Background(), req); err != nil {
+		t.Errorf("Echo without a namespace: unexpected err %v", err)
+	}
+
+	got, err := testingClient.GetScenarioState(ctx, &pb.GetScenarioStateRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.GetExhausted() || got.GetCallCounts()[echoMethod] != 3 || len(got.GetAppliedCalls()) != 1 || got.GetAppliedCalls()[0] != 1 {
+		t.Errorf("GetScenarioState: want 3 calls with the step applied once got %v", got)
+	}
+}
+
+func Test_LoadScenario_invalid(t *testing.T) {
+	ts := &testingServerImpl{scenarios: server.NewScenarioStore()}
+	tests := []*pb.LoadScenarioRequest{
+		{Script: &pb.ScenarioScript{Steps: []*pb.ScenarioStep{{Method: "/google.showcase.v1beta1.Echo/Echo", CallIndex: 1}}}},
+		{Name: "empty"},
+		{Name: "unknown", Script: &pb.ScenarioScript{Steps: []*pb.ScenarioStep{{Method: "/google.showcase.v1beta1.Echo/Nothing", CallIndex: 1}}}},
+	}
+	for _, req := range tests {
+		if _, err := ts.LoadScenario(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("LoadScenario(%v): want InvalidArgument got %v", req, err)
+		}
+	}
+	if _, ok := ts.scenarios.Get(""); ok {
+		t.Errorf("LoadScenario of invalid scripts: want no scenario loaded")
+	}
+}
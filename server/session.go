@@ -118,7 +118,7 @@ func (s *sessionImpl) GetReport() *pb.ReportSessionResponse {
 }
 
 func (s *sessionImpl) ListTests(in *pb.ListTestsRequest) (*pb.ListTestsResponse, error) {
-	start, err := s.token.GetIndex(in.GetPageToken())
+	start, err := s.token.GetIndexWithTTL(in.GetPageToken(), in.GetPageTokenTtl())
 	if err != nil {
 		return nil, err
 	}
@@ -17,6 +17,7 @@ package server
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	pb "github.com/googleapis/gapic-showcase/server/genproto"
@@ -133,7 +134,7 @@ func Test_sessionImpl_TestLifeCycle(t *testing.T) {
 	// Register tests and ensure they are all listed.
 	session := &sessionImpl{
 		observerRegistry: ShowcaseObserverRegistry(),
-		token:            &tokenGenerator{salt: ""},
+		token:            TokenGeneratorWithClock("", func() time.Time { return time.Unix(0, 0) }),
 		keys:             map[string]int{},
 		tests:            []testEntry{},
 	}
@@ -179,7 +180,7 @@ func Test_sessionImpl_TestLifeCycle(t *testing.T) {
 			TestProto(failed),
 			TestProto(pending),
 		},
-		NextPageToken: "Mg==", // Deterministic since we hard coded the page token salt.
+		NextPageToken: "Mjow", // Deterministic since we hard coded the page token salt and clock.
 	}
 	got, _ = session.ListTests(&pb.ListTestsRequest{PageSize: 2})
 	if !proto.Equal(got, wantList) {
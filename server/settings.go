@@ -0,0 +1,225 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// Settings are the configurable limits and defaults of the Showcase services.
+type Settings struct {
+	// The most content, in bytes, that Echo.Collect buffers.
+	MaxCollectContentBytes int64
+
+	// The chunk size Echo.ReadBlob uses when none is requested.
+	DefaultBlobChunkSize int32
+
+	// The largest chunk size Echo.ReadBlob accepts.
+	MaxBlobChunkSize int32
+
+	// The largest blob Echo.WriteBlob accepts.
+	MaxBlobSize int64
+
+	// The most bytes Echo.WriteBlob stores across all blobs.
+	MaxBlobStorageSize int64
+
+	// How long after its last write a blob of Echo.WriteBlob is kept. Zero
+	// keeps blobs until their namespace is purged.
+	BlobTTL time.Duration
+
+	// The locales Echo.Echo chooses between, in order of preference.
+	SupportedLocales []string
+
+	// The longest a GetOperation call holds for its PollWaitHeader.
+	MaxPollWait time.Duration
+
+	// The largest message, in bytes, the server sends.
+	MaxSendMessageBytes int32
+
+	// How long after they are issued page tokens are accepted, unless a
+	// request sets its own TTL. Zero accepts tokens of any age.
+	PageTokenTTL time.Duration
+
+	// The metadata key of the attempt number that generated clients set
+	// when they retry calls themselves.
+	ClientAttemptHeader string
+
+	// The most requests an Echo.BatchEcho call may carry.
+	MaxBatchEchoSize int32
+
+	// Whether Testing.DumpState is enabled. It cannot be updated.
+	EnableAdmin bool
+
+	// Whether responses that deliberately break the protocol, for testing
+	// how clients handle them, are enabled. It cannot be updated.
+	EnableNonconforming bool
+
+	// The artificial errors of every method not in MethodErrorInjection.
+	ErrorInjection ErrorInjection
+
+	// The artificial errors of each method, keyed by its full gRPC name.
+	// An entry overrides ErrorInjection even when its rate is zero.
+	MethodErrorInjection map[string]ErrorInjection
+
+	// How long after an operation is done it expires. Zero keeps operations
+	// forever.
+	OperationTTL time.Duration
+
+	// The paths of the fields the request log redacts, keyed by the full
+	// proto name of the message that has them.
+	LogRedactions map[string][]string
+
+	// Bytes fields longer than this are redacted in the request log. Zero
+	// logs them whole.
+	MaxLoggedBytes int32
+
+	// The ID recorded in the names of the operations this server starts.
+	// Empty records none. It cannot be updated.
+	InstanceID string
+
+	// If not zero, the seed with which list methods shuffle each page.
+	ListScrambleSeed int64
+
+	// If true, calls with an invalid quota project are rejected.
+	StrictQuotaProject bool
+
+	// The longest a streaming call may run. Zero lets streams run forever.
+	MaxStreamDuration time.Duration
+
+	// If positive, the most bytes of requests and responses the calls of
+	// each namespace may exchange in a ByteBudgetWindow.
+	NamespaceByteBudget int64
+
+	// How long usage counts against a namespace's byte budget. Zero counts
+	// it until the budget is reset.
+	ByteBudgetWindow time.Duration
+
+	// The oldest version of each client library that calls are accepted
+	// from, keyed by its token in the APIClientHeader.
+	MinLibraryVersions map[string]string
+
+	// If true, calls that do not name the version of every library in
+	// MinLibraryVersions are rejected.
+	StrictLibraryVersions bool
+}
+
+// DefaultSettings returns the settings Showcase runs with by default.
+func DefaultSettings() Settings {
+	return Settings{
+		MaxCollectContentBytes: 1 << 20,
+		DefaultBlobChunkSize:   64 * 1024,
+		// Keeps each chunk under the default gRPC message size limit.
+		MaxBlobChunkSize:   4 * 1024 * 1024,
+		MaxBlobSize:        16 * 1024 * 1024,
+		MaxBlobStorageSize: 256 * 1024 * 1024,
+		BlobTTL:            time.Hour,
+		SupportedLocales:   []string{"en", "es", "ja"},
+		MaxPollWait:        30 * time.Second,
+		// The default gRPC limit on the messages a client receives.
+		MaxSendMessageBytes: 4 * 1024 * 1024,
+		ClientAttemptHeader: DefaultClientAttemptHeader,
+		MaxBatchEchoSize:    1000,
+		LogRedactions:       DefaultLogRedactions(),
+		MaxLoggedBytes:      256,
+	}
+}
+
+var settingsSingleton = NewSettingsStore(DefaultSettings())
+
+// GetSettingsInstance returns the settings store singleton.
+func GetSettingsInstance() SettingsStore {
+	return settingsSingleton
+}
+
+// SettingsStore holds the live settings of the Showcase services. The
+// services read the settings on every call, so changes take effect
+// immediately.
+type SettingsStore interface {
+	// Get returns a copy of the current settings.
+	Get() Settings
+
+	// Set replaces the current settings.
+	Set(Settings)
+
+	// Update calls f with a copy of the current settings and, unless it
+	// returns an error, replaces them with its changes. No other Set or
+	// Update happens in between. It returns the settings from before.
+	Update(f func(*Settings) error) (Settings, error)
+}
+
+// NewSettingsStore returns a store holding the given settings.
+func NewSettingsStore(s Settings) SettingsStore {
+	store := &settingsStore{}
+	store.Set(s)
+	return store
+}
+
+type settingsStore struct {
+	mu       sync.RWMutex
+	settings Settings
+}
+
+func (s *settingsStore) Get() Settings {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.settings.clone()
+}
+
+func (s *settingsStore) Set(settings Settings) {
+	defer ChangeState()()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settings = settings.clone()
+}
+
+func (s *settingsStore) Update(f func(*Settings) error) (Settings, error) {
+	defer ChangeState()()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous := s.settings.clone()
+	updated := s.settings.clone()
+	if err := f(&updated); err != nil {
+		return previous, err
+	}
+	s.settings = updated.clone()
+	return previous, nil
+}
+
+func (s Settings) clone() Settings {
+	s.SupportedLocales = append([]string(nil), s.SupportedLocales...)
+	if s.MethodErrorInjection != nil {
+		methods := make(map[string]ErrorInjection, len(s.MethodErrorInjection))
+		for method, inj := range s.MethodErrorInjection {
+			methods[method] = inj
+		}
+		s.MethodErrorInjection = methods
+	}
+	if s.LogRedactions != nil {
+		redactions := make(map[string][]string, len(s.LogRedactions))
+		for msg, paths := range s.LogRedactions {
+			redactions[msg] = append([]string(nil), paths...)
+		}
+		s.LogRedactions = redactions
+	}
+	if s.MinLibraryVersions != nil {
+		versions := make(map[string]string, len(s.MinLibraryVersions))
+		for token, version := range s.MinLibraryVersions {
+			versions[token] = version
+		}
+		s.MinLibraryVersions = versions
+	}
+	return s
+}
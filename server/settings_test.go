@@ -0,0 +1,76 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGetSettingsInstance(t *testing.T) {
+	if GetSettingsInstance() != settingsSingleton {
+		t.Error("GetSettingsInstance: Expected to get settings singleton.")
+	}
+	if !reflect.DeepEqual(GetSettingsInstance().Get(), DefaultSettings()) {
+		t.Errorf("GetSettingsInstance: want default settings got %+v", GetSettingsInstance().Get())
+	}
+}
+
+func TestSettingsStore(t *testing.T) {
+	settings := DefaultSettings()
+	store := NewSettingsStore(settings)
+
+	// Neither the settings passed in nor the ones returned alias the store.
+	settings.SupportedLocales[0] = "fr"
+	got := store.Get()
+	if got.SupportedLocales[0] != "en" {
+		t.Errorf("NewSettingsStore: want the store to copy its settings, got %v", got.SupportedLocales)
+	}
+	got.SupportedLocales[0] = "de"
+	if store.Get().SupportedLocales[0] != "en" {
+		t.Errorf("Get: want a copy of the settings, got %v", store.Get().SupportedLocales)
+	}
+
+	settings.MaxBlobSize = 10
+	store.Set(settings)
+	if !reflect.DeepEqual(store.Get(), settings) {
+		t.Errorf("Set: want %+v got %+v", settings, store.Get())
+	}
+}
+
+func TestSettingsStore_Update(t *testing.T) {
+	store := NewSettingsStore(DefaultSettings())
+
+	previous, err := store.Update(func(s *Settings) error {
+		s.MaxBlobSize = 10
+		return nil
+	})
+	if err != nil || !reflect.DeepEqual(previous, DefaultSettings()) {
+		t.Errorf("Update: want the previous settings got %+v, %v", previous, err)
+	}
+	if store.Get().MaxBlobSize != 10 {
+		t.Errorf("Update: want MaxBlobSize 10 got %d", store.Get().MaxBlobSize)
+	}
+
+	want := errors.New("invalid")
+	_, err = store.Update(func(s *Settings) error {
+		s.MaxBlobSize = 20
+		return want
+	})
+	if err != want || store.Get().MaxBlobSize != 10 {
+		t.Errorf("Update: want a failed update to change nothing got %d, %v", store.Get().MaxBlobSize, err)
+	}
+}
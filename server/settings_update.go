@@ -0,0 +1,417 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SettingsProto returns the settings as a ShowcaseSettings message.
+func SettingsProto(s Settings) *pb.ShowcaseSettings {
+	var methods map[string]*pb.ErrorInjection
+	if len(s.MethodErrorInjection) > 0 {
+		methods = map[string]*pb.ErrorInjection{}
+		for method, inj := range s.MethodErrorInjection {
+			methods[method] = errorInjectionProto(inj)
+		}
+	}
+	var redactions map[string]*pb.LogRedaction
+	if len(s.LogRedactions) > 0 {
+		redactions = map[string]*pb.LogRedaction{}
+		for msg, paths := range s.LogRedactions {
+			redactions[msg] = &pb.LogRedaction{Paths: append([]string(nil), paths...)}
+		}
+	}
+	var versions map[string]string
+	if len(s.MinLibraryVersions) > 0 {
+		versions = map[string]string{}
+		for token, version := range s.MinLibraryVersions {
+			versions[token] = version
+		}
+	}
+	return &pb.ShowcaseSettings{
+		MaxCollectContentBytes: s.MaxCollectContentBytes,
+		DefaultBlobChunkSize:   s.DefaultBlobChunkSize,
+		MaxBlobChunkSize:       s.MaxBlobChunkSize,
+		MaxBlobSize:            s.MaxBlobSize,
+		MaxBlobStorageSize:     s.MaxBlobStorageSize,
+		BlobTtl:                ptypes.DurationProto(s.BlobTTL),
+		SupportedLocales:       append([]string(nil), s.SupportedLocales...),
+		MaxRecordedPolls:       MaxRecordedPolls,
+		MaxPollWait:            ptypes.DurationProto(s.MaxPollWait),
+		MaxSendMessageBytes:    s.MaxSendMessageBytes,
+		PageTokenTtl:           ptypes.DurationProto(s.PageTokenTTL),
+		ClientAttemptHeader:    s.ClientAttemptHeader,
+		MaxBatchEchoSize:       s.MaxBatchEchoSize,
+		AdminEnabled:           s.EnableAdmin,
+		NonconformingEnabled:   s.EnableNonconforming,
+		ErrorInjection:         errorInjectionProto(s.ErrorInjection),
+		MethodErrorInjection:   methods,
+		OperationTtl:           ptypes.DurationProto(s.OperationTTL),
+		LogRedactions:          redactions,
+		MaxLoggedBytes:         s.MaxLoggedBytes,
+		InstanceId:             s.InstanceID,
+		ListScrambleSeed:       s.ListScrambleSeed,
+		StrictQuotaProject:     s.StrictQuotaProject,
+		MaxStreamDuration:      ptypes.DurationProto(s.MaxStreamDuration),
+		NamespaceByteBudget:    s.NamespaceByteBudget,
+		ByteBudgetWindow:       ptypes.DurationProto(s.ByteBudgetWindow),
+		MinLibraryVersions:     versions,
+		StrictLibraryVersions:  s.StrictLibraryVersions,
+	}
+}
+
+func errorInjectionProto(inj ErrorInjection) *pb.ErrorInjection {
+	p := &pb.ErrorInjection{ErrorRate: inj.ErrorRate, Code: int32(inj.Code)}
+	if inj.RetryInfoDelay > 0 {
+		p.RetryInfoDelay = ptypes.DurationProto(inj.RetryInfoDelay)
+	}
+	return p
+}
+
+func errorInjectionSettings(field string, p *pb.ErrorInjection) (ErrorInjection, error) {
+	delay, err := settingsDuration(field+".retry_info_delay", p.GetRetryInfoDelay())
+	return ErrorInjection{
+		ErrorRate:      p.GetErrorRate(),
+		Code:           codes.Code(p.GetCode()),
+		RetryInfoDelay: delay,
+	}, err
+}
+
+// settingsFields copy each updatable field of a ShowcaseSettings message,
+// keyed by its proto name, into Settings.
+var settingsFields = map[string]func(*Settings, *pb.ShowcaseSettings) error{
+	"max_collect_content_bytes": func(s *Settings, p *pb.ShowcaseSettings) error {
+		s.MaxCollectContentBytes = p.GetMaxCollectContentBytes()
+		return nil
+	},
+	"default_blob_chunk_size": func(s *Settings, p *pb.ShowcaseSettings) error {
+		s.DefaultBlobChunkSize = p.GetDefaultBlobChunkSize()
+		return nil
+	},
+	"max_blob_chunk_size": func(s *Settings, p *pb.ShowcaseSettings) error {
+		s.MaxBlobChunkSize = p.GetMaxBlobChunkSize()
+		return nil
+	},
+	"max_blob_size": func(s *Settings, p *pb.ShowcaseSettings) error {
+		s.MaxBlobSize = p.GetMaxBlobSize()
+		return nil
+	},
+	"max_blob_storage_size": func(s *Settings, p *pb.ShowcaseSettings) error {
+		s.MaxBlobStorageSize = p.GetMaxBlobStorageSize()
+		return nil
+	},
+	"supported_locales": func(s *Settings, p *pb.ShowcaseSettings) error {
+		s.SupportedLocales = append([]string(nil), p.GetSupportedLocales()...)
+		return nil
+	},
+	"max_poll_wait": func(s *Settings, p *pb.ShowcaseSettings) (err error) {
+		s.MaxPollWait, err = settingsDuration("max_poll_wait", p.GetMaxPollWait())
+		return err
+	},
+	"page_token_ttl": func(s *Settings, p *pb.ShowcaseSettings) (err error) {
+		s.PageTokenTTL, err = settingsDuration("page_token_ttl", p.GetPageTokenTtl())
+		return err
+	},
+	"client_attempt_header": func(s *Settings, p *pb.ShowcaseSettings) error {
+		s.ClientAttemptHeader = p.GetClientAttemptHeader()
+		return nil
+	},
+	"max_batch_echo_size": func(s *Settings, p *pb.ShowcaseSettings) error {
+		s.MaxBatchEchoSize = p.GetMaxBatchEchoSize()
+		return nil
+	},
+	"error_injection": func(s *Settings, p *pb.ShowcaseSettings) (err error) {
+		s.ErrorInjection, err = errorInjectionSettings("error_injection", p.GetErrorInjection())
+		return err
+	},
+	"blob_ttl": func(s *Settings, p *pb.ShowcaseSettings) (err error) {
+		s.BlobTTL, err = settingsDuration("blob_ttl", p.GetBlobTtl())
+		return err
+	},
+	"operation_ttl": func(s *Settings, p *pb.ShowcaseSettings) (err error) {
+		s.OperationTTL, err = settingsDuration("operation_ttl", p.GetOperationTtl())
+		return err
+	},
+	"method_error_injection": func(s *Settings, p *pb.ShowcaseSettings) error {
+		s.MethodErrorInjection = nil
+		for method, inj := range p.GetMethodErrorInjection() {
+			v, err := errorInjectionSettings("method_error_injection", inj)
+			if err != nil {
+				return err
+			}
+			if s.MethodErrorInjection == nil {
+				s.MethodErrorInjection = map[string]ErrorInjection{}
+			}
+			s.MethodErrorInjection[method] = v
+		}
+		return nil
+	},
+	"log_redactions": func(s *Settings, p *pb.ShowcaseSettings) error {
+		s.LogRedactions = nil
+		for msg, r := range p.GetLogRedactions() {
+			if s.LogRedactions == nil {
+				s.LogRedactions = map[string][]string{}
+			}
+			s.LogRedactions[msg] = append([]string(nil), r.GetPaths()...)
+		}
+		return nil
+	},
+	"max_logged_bytes": func(s *Settings, p *pb.ShowcaseSettings) error {
+		s.MaxLoggedBytes = p.GetMaxLoggedBytes()
+		return nil
+	},
+	"list_scramble_seed": func(s *Settings, p *pb.ShowcaseSettings) error {
+		s.ListScrambleSeed = p.GetListScrambleSeed()
+		return nil
+	},
+	"strict_quota_project": func(s *Settings, p *pb.ShowcaseSettings) error {
+		s.StrictQuotaProject = p.GetStrictQuotaProject()
+		return nil
+	},
+	"max_stream_duration": func(s *Settings, p *pb.ShowcaseSettings) (err error) {
+		s.MaxStreamDuration, err = settingsDuration("max_stream_duration", p.GetMaxStreamDuration())
+		return err
+	},
+	"namespace_byte_budget": func(s *Settings, p *pb.ShowcaseSettings) error {
+		s.NamespaceByteBudget = p.GetNamespaceByteBudget()
+		return nil
+	},
+	"byte_budget_window": func(s *Settings, p *pb.ShowcaseSettings) (err error) {
+		s.ByteBudgetWindow, err = settingsDuration("byte_budget_window", p.GetByteBudgetWindow())
+		return err
+	},
+	"min_library_versions": func(s *Settings, p *pb.ShowcaseSettings) error {
+		s.MinLibraryVersions = nil
+		for token, version := range p.GetMinLibraryVersions() {
+			if s.MinLibraryVersions == nil {
+				s.MinLibraryVersions = map[string]string{}
+			}
+			s.MinLibraryVersions[token] = version
+		}
+		return nil
+	},
+	"strict_library_versions": func(s *Settings, p *pb.ShowcaseSettings) error {
+		s.StrictLibraryVersions = p.GetStrictLibraryVersions()
+		return nil
+	},
+}
+
+// readOnlySettings are the fields of ShowcaseSettings that report how the
+// server was started rather than settings that can change. The gRPC server
+// applies max_send_message_bytes only when it starts.
+var readOnlySettings = map[string]bool{
+	"max_recorded_polls":     true,
+	"max_send_message_bytes": true,
+	"admin_enabled":          true,
+	"nonconforming_enabled":  true,
+	"instance_id":            true,
+}
+
+// settingsDuration converts a duration setting, treating unset as zero.
+func settingsDuration(field string, d *duration.Duration) (time.Duration, error) {
+	if d == nil {
+		return 0, nil
+	}
+	v, err := ptypes.Duration(d)
+	if err != nil {
+		return 0, showcaseerrors.Setting(field, "The field `%s` is not a valid duration: %s.", field, err)
+	}
+	return v, nil
+}
+
+// UpdateSettings replaces the settings in the store named by paths with
+// those of update, or all of the updatable settings if paths is empty. The
+// result is validated, and the store is left unchanged if it is invalid.
+// It returns the settings from before and after the update.
+func UpdateSettings(store SettingsStore, update *pb.ShowcaseSettings, paths []string) (previous, current Settings, err error) {
+	if len(paths) == 0 {
+		for path := range settingsFields {
+			paths = append(paths, path)
+		}
+	}
+	previous, err = store.Update(func(s *Settings) error {
+		for _, path := range paths {
+			set, ok := settingsFields[path]
+			if !ok {
+				if readOnlySettings[path] {
+					return showcaseerrors.Setting(path, "The setting `%s` cannot be updated.", path)
+				}
+				return showcaseerrors.Setting(path, "The setting `%s` does not exist.", path)
+			}
+			if err := set(s, update); err != nil {
+				return err
+			}
+		}
+		if err := s.Validate(); err != nil {
+			return err
+		}
+		current = s.clone()
+		return nil
+	})
+	if err != nil {
+		return previous, previous, err
+	}
+	return previous, current, nil
+}
+
+// Validate returns an INVALID_ARGUMENT error describing the first setting
+// whose value the services cannot run with.
+func (s Settings) Validate() error {
+	positive := []struct {
+		field string
+		value int64
+	}{
+		{"max_collect_content_bytes", s.MaxCollectContentBytes},
+		{"default_blob_chunk_size", int64(s.DefaultBlobChunkSize)},
+		{"max_blob_chunk_size", int64(s.MaxBlobChunkSize)},
+		{"max_blob_size", s.MaxBlobSize},
+		{"max_blob_storage_size", s.MaxBlobStorageSize},
+		{"max_send_message_bytes", int64(s.MaxSendMessageBytes)},
+		{"max_batch_echo_size", int64(s.MaxBatchEchoSize)},
+	}
+	for _, p := range positive {
+		if p.value <= 0 {
+			return showcaseerrors.Setting(p.field, "The setting `%s` must be positive.", p.field)
+		}
+	}
+	if s.DefaultBlobChunkSize > s.MaxBlobChunkSize {
+		return showcaseerrors.Setting(
+			"default_blob_chunk_size",
+			"The setting `default_blob_chunk_size` must not exceed `max_blob_chunk_size`.")
+	}
+	if len(s.SupportedLocales) == 0 {
+		return showcaseerrors.Setting("supported_locales", "The setting `supported_locales` must not be empty.")
+	}
+	for i, l := range s.SupportedLocales {
+		if l == "" {
+			return showcaseerrors.Setting("supported_locales", "The setting `supported_locales[%d]` must not be empty.", i)
+		}
+	}
+	if s.MaxPollWait < 0 {
+		return showcaseerrors.Setting("max_poll_wait", "The setting `max_poll_wait` must not be negative.")
+	}
+	if s.PageTokenTTL < 0 {
+		return showcaseerrors.Setting("page_token_ttl", "The setting `page_token_ttl` must not be negative.")
+	}
+	if s.OperationTTL < 0 {
+		return showcaseerrors.Setting("operation_ttl", "The setting `operation_ttl` must not be negative.")
+	}
+	if s.BlobTTL < 0 {
+		return showcaseerrors.Setting("blob_ttl", "The setting `blob_ttl` must not be negative.")
+	}
+	if s.MaxStreamDuration < 0 {
+		return showcaseerrors.Setting("max_stream_duration", "The setting `max_stream_duration` must not be negative.")
+	}
+	if s.NamespaceByteBudget < 0 {
+		return showcaseerrors.Setting("namespace_byte_budget", "The setting `namespace_byte_budget` must not be negative.")
+	}
+	if s.ByteBudgetWindow < 0 {
+		return showcaseerrors.Setting("byte_budget_window", "The setting `byte_budget_window` must not be negative.")
+	}
+	if s.MaxLoggedBytes < 0 {
+		return showcaseerrors.Setting("max_logged_bytes", "The setting `max_logged_bytes` must not be negative.")
+	}
+	if s.InstanceID != "" && !instancePattern.MatchString(s.InstanceID) {
+		return showcaseerrors.Setting(
+			"instance_id",
+			"The setting `instance_id` %q must be 1 to 63 lowercase letters, digits and hyphens, "+
+				"starting with a letter or digit.",
+			s.InstanceID)
+	}
+	if err := validateLogRedactions(s.LogRedactions); err != nil {
+		return err
+	}
+	if h := s.ClientAttemptHeader; h == "" || h != strings.ToLower(h) {
+		return showcaseerrors.Setting(
+			"client_attempt_header",
+			"The setting `client_attempt_header` must be a non-empty lowercase metadata key.")
+	}
+	if err := validateErrorInjection("error_injection", s.ErrorInjection); err != nil {
+		return err
+	}
+	if err := validateMinLibraryVersions(s.MinLibraryVersions); err != nil {
+		return err
+	}
+	methods := []string{}
+	for method := range s.MethodErrorInjection {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	for _, method := range methods {
+		if method == updateSettingsMethod {
+			return showcaseerrors.Setting(
+				"method_error_injection",
+				"The setting `method_error_injection` cannot fail %s, which turns the errors off.",
+				method)
+		}
+		if _, ok := ShowcaseMethodInput(method); !ok {
+			return showcaseerrors.Setting(
+				"method_error_injection",
+				"The setting `method_error_injection` names %q, which is not a Showcase method. The methods are: %s.",
+				method,
+				strings.Join(ShowcaseMethods(), ", "))
+		}
+		if err := validateErrorInjection(fmt.Sprintf("method_error_injection[%q]", method), s.MethodErrorInjection[method]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadSettingsFile updates the settings in the store from a file holding a
+// ShowcaseSettings message in JSON. Only the settings present in the file
+// are updated. It returns the settings from before the update.
+func LoadSettingsFile(store SettingsStore, path string) (Settings, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return store.Get(), err
+	}
+	update := &pb.ShowcaseSettings{}
+	if err := jsonpb.UnmarshalString(string(b), update); err != nil {
+		return store.Get(), fmt.Errorf("%s is not a valid ShowcaseSettings: %v", path, err)
+	}
+	keys := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &keys); err != nil {
+		return store.Get(), fmt.Errorf("%s is not a valid ShowcaseSettings: %v", path, err)
+	}
+	fields := jsonFields(reflect.TypeOf(update).Elem())
+	paths := []string{}
+	for key := range keys {
+		paths = append(paths, fields[key].origName)
+	}
+	if len(paths) == 0 {
+		return store.Get(), nil
+	}
+	previous, _, err := UpdateSettings(store, update, paths)
+	if err != nil {
+		return previous, fmt.Errorf("%s: %s", path, status.Convert(err).Message())
+	}
+	return previous, nil
+}
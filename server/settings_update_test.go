@@ -0,0 +1,204 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSettingsProto(t *testing.T) {
+	settings := DefaultSettings()
+	// Read-only settings are not updated.
+	store := NewSettingsStore(Settings{MaxSendMessageBytes: settings.MaxSendMessageBytes})
+	settings.PageTokenTTL = time.Minute
+	settings.MinLibraryVersions = map[string]string{"gccl": "2.3.0"}
+	settings.StrictLibraryVersions = true
+	if _, _, err := UpdateSettings(store, SettingsProto(settings), nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := store.Get(); !reflect.DeepEqual(got, settings) {
+		t.Errorf("UpdateSettings(SettingsProto(%+v)): want the same settings got %+v", settings, got)
+	}
+}
+
+func TestUpdateSettings(t *testing.T) {
+	store := NewSettingsStore(DefaultSettings())
+	update := &pb.ShowcaseSettings{
+		MaxBlobSize: 42,
+		MaxPollWait: ptypes.DurationProto(time.Second),
+		// Not in the mask, so not updated.
+		MaxCollectContentBytes: 1,
+	}
+	previous, current, err := UpdateSettings(store, update, []string{"max_blob_size", "max_poll_wait"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(previous, DefaultSettings()) {
+		t.Errorf("UpdateSettings: want the default settings as previous got %+v", previous)
+	}
+	want := DefaultSettings()
+	want.MaxBlobSize = 42
+	want.MaxPollWait = time.Second
+	if !reflect.DeepEqual(current, want) || !reflect.DeepEqual(store.Get(), want) {
+		t.Errorf("UpdateSettings: want %+v got %+v stored %+v", want, current, store.Get())
+	}
+}
+
+func TestUpdateSettings_invalid(t *testing.T) {
+	tests := []struct {
+		update *pb.ShowcaseSettings
+		paths  []string
+		want   string
+	}{
+		{&pb.ShowcaseSettings{}, []string{"max_blob_size"}, "The setting `max_blob_size` must be positive."},
+		{&pb.ShowcaseSettings{DefaultBlobChunkSize: 1 << 30}, []string{"default_blob_chunk_size"}, "The setting `default_blob_chunk_size` must not exceed `max_blob_chunk_size`."},
+		{&pb.ShowcaseSettings{}, []string{"supported_locales"}, "The setting `supported_locales` must not be empty."},
+		{&pb.ShowcaseSettings{SupportedLocales: []string{"en", ""}}, []string{"supported_locales"}, "The setting `supported_locales[1]` must not be empty."},
+		{&pb.ShowcaseSettings{MaxPollWait: ptypes.DurationProto(-time.Second)}, []string{"max_poll_wait"}, "The setting `max_poll_wait` must not be negative."},
+		{&pb.ShowcaseSettings{PageTokenTtl: ptypes.DurationProto(-time.Second)}, []string{"page_token_ttl"}, "The setting `page_token_ttl` must not be negative."},
+		{&pb.ShowcaseSettings{BlobTtl: ptypes.DurationProto(-time.Second)}, []string{"blob_ttl"}, "The setting `blob_ttl` must not be negative."},
+		{&pb.ShowcaseSettings{MaxStreamDuration: ptypes.DurationProto(-time.Second)}, []string{"max_stream_duration"}, "The setting `max_stream_duration` must not be negative."},
+		{&pb.ShowcaseSettings{NamespaceByteBudget: -1}, []string{"namespace_byte_budget"}, "The setting `namespace_byte_budget` must not be negative."},
+		{&pb.ShowcaseSettings{ByteBudgetWindow: ptypes.DurationProto(-time.Second)}, []string{"byte_budget_window"}, "The setting `byte_budget_window` must not be negative."},
+		{&pb.ShowcaseSettings{ClientAttemptHeader: "X-Attempt"}, []string{"client_attempt_header"}, "The setting `client_attempt_header` must be a non-empty lowercase metadata key."},
+		{&pb.ShowcaseSettings{MaxRecordedPolls: 1}, []string{"max_recorded_polls"}, "The setting `max_recorded_polls` cannot be updated."},
+		{&pb.ShowcaseSettings{MaxSendMessageBytes: 1}, []string{"max_send_message_bytes"}, "The setting `max_send_message_bytes` cannot be updated."},
+		{&pb.ShowcaseSettings{AdminEnabled: true}, []string{"admin_enabled"}, "The setting `admin_enabled` cannot be updated."},
+		{&pb.ShowcaseSettings{NonconformingEnabled: true}, []string{"nonconforming_enabled"}, "The setting `nonconforming_enabled` cannot be updated."},
+		{&pb.ShowcaseSettings{InstanceId: "b"}, []string{"instance_id"}, "The setting `instance_id` cannot be updated."},
+		{&pb.ShowcaseSettings{}, []string{"chaos_rate"}, "The setting `chaos_rate` does not exist."},
+		{&pb.ShowcaseSettings{ErrorInjection: &pb.ErrorInjection{ErrorRate: 1.5}}, []string{"error_injection"}, "The setting `error_injection.error_rate` must be between 0 and 1."},
+		{&pb.ShowcaseSettings{ErrorInjection: &pb.ErrorInjection{Code: 17}}, []string{"error_injection"}, "The setting `error_injection.code` is not a valid google.rpc.Code."},
+		{
+			&pb.ShowcaseSettings{MethodErrorInjection: map[string]*pb.ErrorInjection{
+				"/google.showcase.v1beta1.Echo/Echo": {RetryInfoDelay: ptypes.DurationProto(-time.Second)},
+			}},
+			[]string{"method_error_injection"},
+			"The setting `method_error_injection[\"/google.showcase.v1beta1.Echo/Echo\"].retry_info_delay` must not be negative.",
+		},
+		{
+			&pb.ShowcaseSettings{MethodErrorInjection: map[string]*pb.ErrorInjection{updateSettingsMethod: {ErrorRate: 1}}},
+			[]string{"method_error_injection"},
+			"The setting `method_error_injection` cannot fail " + updateSettingsMethod + ", which turns the errors off.",
+		},
+		{
+			&pb.ShowcaseSettings{MinLibraryVersions: map[string]string{"gccl": "2.x"}},
+			[]string{"min_library_versions"},
+			"The setting `min_library_versions[\"gccl\"]` is invalid: \"2.x\" is not a semantic version.",
+		},
+		{
+			&pb.ShowcaseSettings{MinLibraryVersions: map[string]string{"gl/go": "1.0.0"}},
+			[]string{"min_library_versions"},
+			"The setting `min_library_versions` names the token \"gl/go\", which must be non-empty without slashes or spaces.",
+		},
+		// A full replacement with unset fields.
+		{&pb.ShowcaseSettings{}, nil, ""},
+	}
+	for _, test := range tests {
+		store := NewSettingsStore(DefaultSettings())
+		_, _, err := UpdateSettings(store, test.update, test.paths)
+		if s := status.Convert(err); s.Code() != codes.InvalidArgument || test.want != "" && s.Message() != test.want {
+			t.Errorf("UpdateSettings(%v, %v): want InvalidArgument %q got %v", test.update, test.paths, test.want, err)
+		}
+		if !reflect.DeepEqual(store.Get(), DefaultSettings()) {
+			t.Errorf("UpdateSettings(%v, %v): want an invalid update to change nothing got %+v", test.update, test.paths, store.Get())
+		}
+	}
+}
+
+func TestUpdateSettings_concurrent(t *testing.T) {
+	// Each update sets both chunk sizes to the same value, so a reader seeing
+	// them differ has seen a torn update.
+	settings := DefaultSettings()
+	settings.DefaultBlobChunkSize = settings.MaxBlobChunkSize
+	store := NewSettingsStore(settings)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if s := store.Get(); s.DefaultBlobChunkSize != s.MaxBlobChunkSize {
+					t.Errorf("Get: saw a torn update %d, %d", s.DefaultBlobChunkSize, s.MaxBlobChunkSize)
+					return
+				}
+			}
+		}()
+	}
+	paths := []string{"default_blob_chunk_size", "max_blob_chunk_size"}
+	for i := int32(1); i <= 1000; i++ {
+		update := &pb.ShowcaseSettings{DefaultBlobChunkSize: i, MaxBlobChunkSize: i}
+		if _, _, err := UpdateSettings(store, update, paths); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(done)
+	wg.Wait()
+}
+
+func TestLoadSettingsFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "settings")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "settings.json")
+
+	store := NewSettingsStore(DefaultSettings())
+	ioutil.WriteFile(path, []byte(`{"maxBlobSize": "42", "page_token_ttl": "60s"}`), 0644)
+	previous, err := LoadSettingsFile(store, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(previous, DefaultSettings()) {
+		t.Errorf("LoadSettingsFile: want the default settings as previous got %+v", previous)
+	}
+	want := DefaultSettings()
+	want.MaxBlobSize = 42
+	want.PageTokenTTL = time.Minute
+	if !reflect.DeepEqual(store.Get(), want) {
+		t.Errorf("LoadSettingsFile: want %+v got %+v", want, store.Get())
+	}
+
+	for _, body := range []string{`{"maxBlobSize": "0"}`, `{"chaosRate": 1}`, `[`} {
+		ioutil.WriteFile(path, []byte(body), 0644)
+		if _, err := LoadSettingsFile(store, path); err == nil {
+			t.Errorf("LoadSettingsFile(%s): want an error", body)
+		}
+		if !reflect.DeepEqual(store.Get(), want) {
+			t.Errorf("LoadSettingsFile(%s): want an invalid file to change nothing got %+v", body, store.Get())
+		}
+	}
+	if _, err := LoadSettingsFile(store, filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("LoadSettingsFile of a missing file: want an error")
+	}
+}
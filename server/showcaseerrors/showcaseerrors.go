@@ -0,0 +1,252 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package showcaseerrors defines the stable reasons of the errors Showcase
+// returns. Every INVALID_ARGUMENT error carries a google.rpc.ErrorInfo with
+// one of the reasons, as do the other errors that explain themselves, so
+// that clients can match on the reason and its metadata rather than on the
+// message, which may be reworded at any time.
+package showcaseerrors
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain is the domain of the ErrorInfo details Showcase returns.
+const Domain = "showcase.googleapis.com"
+
+// The reasons of INVALID_ARGUMENT errors. The metadata of their ErrorInfo
+// names what was invalid: a request field under "field", a metadata key
+// under "metadata", a setting under "setting", or the grpc-encoding of a
+// request under "encoding".
+const (
+	// A required field is unset.
+	FieldRequired = "FIELD_REQUIRED"
+
+	// A number, duration or count is outside the range the field allows.
+	FieldOutOfRange = "FIELD_OUT_OF_RANGE"
+
+	// A value is malformed or not supported.
+	FieldInvalid = "FIELD_INVALID"
+
+	// A field is set along with one it must not be, or without one it
+	// requires.
+	FieldConflict = "FIELD_CONFLICT"
+
+	// A page token was not issued by the server for the request.
+	PageTokenInvalid = "PAGE_TOKEN_INVALID"
+
+	// A message of a client stream arrived out of order.
+	StreamOutOfOrder = "STREAM_OUT_OF_ORDER"
+
+	// Request metadata is malformed or given more than once.
+	MetadataInvalid = "METADATA_INVALID"
+
+	// The body of an HTTP/JSON request cannot be decoded.
+	RequestBodyInvalid = "REQUEST_BODY_INVALID"
+
+	// A request cannot be decompressed with the grpc-encoding it claims.
+	RequestDecompressionFailed = "REQUEST_DECOMPRESSION_FAILED"
+
+	// A setting has a value the server cannot run with, or cannot be
+	// updated.
+	SettingInvalid = "SETTING_INVALID"
+
+	// The x-goog-user-project metadata is not a valid project ID, under
+	// strict_quota_project.
+	QuotaProjectInvalid = "INVALID_QUOTA_PROJECT"
+)
+
+// The reasons of the errors with other codes, which the servers build with
+// ErrorInfo. The metadata of each is described where it is returned.
+const (
+	// A namespace has exchanged more bytes than its byte budget in the
+	// current window.
+	ByteBudgetExceeded = "BYTE_BUDGET_EXCEEDED"
+
+	// An operation is called on a Showcase instance other than the one that
+	// started it.
+	WrongInstance = "WRONG_INSTANCE"
+
+	// An operation ID was already used by a different request in the namespace.
+	OperationIDReused = "OPERATION_ID_REUSED"
+
+	// An attempt of a request fails because it is among the first attempts the
+	// request asked to fail.
+	AttemptFailed = "ATTEMPT_FAILED"
+
+	// A forwarded call is part of a chain of forwarded calls longer than the
+	// server allows.
+	ForwardLoop = "FORWARD_LOOP"
+
+	// A request differs from the expectation set for it.
+	RequestMismatch = "REQUEST_MISMATCH"
+
+	// A call fails because a step of the scenario of its namespace says so.
+	ScenarioStep = "SCENARIO_STEP"
+
+	// A Chat stream received as many messages as its max_received_messages
+	// allows.
+	ReceiveLimitReached = "RECEIVE_LIMIT_REACHED"
+
+	// An operation being waited on was deleted.
+	OperationDeleted = "OPERATION_DELETED"
+
+	// A response would be larger than the server allows.
+	ResponseTooLarge = "RESPONSE_TOO_LARGE"
+
+	// A Chat stream received no message within its idle timeout.
+	IdleTimeout = "IDLE_TIMEOUT"
+
+	// A page token is older than the page token TTL.
+	PageTokenExpired = "PAGE_TOKEN_EXPIRED"
+
+	// A done operation is older than the operation TTL, so it was collected.
+	OperationExpired = "OPERATION_EXPIRED"
+
+	// A Chat response in ack mode was sent as many times as allowed without
+	// being acknowledged.
+	ResendLimitExceeded = "RESEND_LIMIT_EXCEEDED"
+
+	// A stream ran longer than the max stream duration.
+	StreamMaxDurationExceeded = "STREAM_MAX_DURATION_EXCEEDED"
+
+	// A message of a Collect stream has a sequence out of the required order.
+	SequenceOutOfOrder = "SEQUENCE_OUT_OF_ORDER"
+
+	// With strict library versions, a call does not name the version of a client
+	// library that has a minimum version.
+	LibraryVersionMissing = "LIBRARY_VERSION_MISSING"
+
+	// A call names a version of a client library older than its minimum version.
+	LibraryVersionTooOld = "LIBRARY_VERSION_TOO_OLD"
+)
+
+// Field returns an INVALID_ARGUMENT error with the reason, about a field of
+// the request.
+func Field(reason, field, format string, args ...interface{}) error {
+	return invalid(reason, map[string]string{"field": field}, fmt.Sprintf(format, args...))
+}
+
+// Metadata returns an INVALID_ARGUMENT error with the reason
+// METADATA_INVALID, about a metadata key of the request.
+func Metadata(key, format string, args ...interface{}) error {
+	return invalid(MetadataInvalid, map[string]string{"metadata": key}, fmt.Sprintf(format, args...))
+}
+
+// QuotaProject returns an INVALID_ARGUMENT error with the reason
+// INVALID_QUOTA_PROJECT, about the metadata key naming the quota project.
+func QuotaProject(key, format string, args ...interface{}) error {
+	return invalid(QuotaProjectInvalid, map[string]string{"metadata": key}, fmt.Sprintf(format, args...))
+}
+
+// Setting returns an INVALID_ARGUMENT error with the reason SETTING_INVALID,
+// about a setting of the server.
+func Setting(setting, format string, args ...interface{}) error {
+	return invalid(SettingInvalid, map[string]string{"setting": setting}, fmt.Sprintf(format, args...))
+}
+
+// RequestBody returns an INVALID_ARGUMENT error with the reason
+// REQUEST_BODY_INVALID.
+func RequestBody(format string, args ...interface{}) error {
+	return invalid(RequestBodyInvalid, nil, fmt.Sprintf(format, args...))
+}
+
+// Decompression returns an INVALID_ARGUMENT error with the reason
+// REQUEST_DECOMPRESSION_FAILED, about a request compressed with the
+// encoding.
+func Decompression(encoding, format string, args ...interface{}) error {
+	return invalid(RequestDecompressionFailed, map[string]string{"encoding": encoding}, fmt.Sprintf(format, args...))
+}
+
+// BadRequest returns an INVALID_ARGUMENT error with a BadRequest detail
+// describing a violation of the given field, followed by an ErrorInfo with
+// the reason.
+func BadRequest(reason, field, description string) error {
+	violation, err := ptypes.MarshalAny(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: description},
+		},
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "The BadRequest detail could not be encoded: %s.", err)
+	}
+	return status.ErrorProto(&spb.Status{
+		Code:    int32(codes.InvalidArgument),
+		Message: description,
+		Details: []*any.Any{violation, ErrorInfo(reason, Domain, map[string]string{"field": field})},
+	})
+}
+
+func invalid(reason string, metadata map[string]string, message string) error {
+	return status.ErrorProto(&spb.Status{
+		Code:    int32(codes.InvalidArgument),
+		Message: message,
+		Details: []*any.Any{ErrorInfo(reason, Domain, metadata)},
+	})
+}
+
+// errorInfo is google.rpc.ErrorInfo. The genproto version this module uses
+// predates ErrorInfo, so it is declared here and registered for the details
+// of statuses to be decoded and rendered as JSON.
+type errorInfo struct {
+	Reason   string            `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	Domain   string            `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	Metadata map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *errorInfo) Reset()         { *m = errorInfo{} }
+func (m *errorInfo) String() string { return proto.CompactTextString(m) }
+func (*errorInfo) ProtoMessage()    {}
+
+func init() {
+	proto.RegisterType((*errorInfo)(nil), "google.rpc.ErrorInfo")
+}
+
+// ErrorInfo returns a packed google.rpc.ErrorInfo. It is encoded by hand so
+// that the metadata entries are ordered by key.
+func ErrorInfo(reason, domain string, metadata map[string]string) *any.Any {
+	b := proto.NewBuffer(nil)
+	b.EncodeVarint(1<<3 | proto.WireBytes)
+	b.EncodeStringBytes(reason)
+	b.EncodeVarint(2<<3 | proto.WireBytes)
+	b.EncodeStringBytes(domain)
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		entry := proto.NewBuffer(nil)
+		entry.EncodeVarint(1<<3 | proto.WireBytes)
+		entry.EncodeStringBytes(k)
+		entry.EncodeVarint(2<<3 | proto.WireBytes)
+		entry.EncodeStringBytes(metadata[k])
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		b.EncodeRawBytes(entry.Bytes())
+	}
+	return &any.Any{
+		TypeUrl: "type.googleapis.com/google.rpc.ErrorInfo",
+		Value:   b.Bytes(),
+	}
+}
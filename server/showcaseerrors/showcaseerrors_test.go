@@ -0,0 +1,164 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package showcaseerrors
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorInfo(t *testing.T) {
+	got := ErrorInfo("R", "d", map[string]string{"b": "2", "a": "1"})
+	if got.GetTypeUrl() != "type.googleapis.com/google.rpc.ErrorInfo" {
+		t.Errorf("ErrorInfo: want the ErrorInfo type URL got %q", got.GetTypeUrl())
+	}
+	// The reason, the domain, then the metadata entries ordered by key.
+	want := []byte("\x0a\x01R\x12\x01d\x1a\x06\x0a\x01a\x12\x011\x1a\x06\x0a\x01b\x12\x012")
+	if !bytes.Equal(got.GetValue(), want) {
+		t.Errorf("ErrorInfo: want %q got %q", want, got.GetValue())
+	}
+}
+
+func TestBadRequest(t *testing.T) {
+	st := status.Convert(BadRequest(FieldInvalid, "a.b", "The field `a.b` is bad."))
+	if st.Code() != codes.InvalidArgument || st.Message() != "The field `a.b` is bad." {
+		t.Errorf("BadRequest: want INVALID_ARGUMENT with the description got %v", st)
+	}
+	details := st.Proto().GetDetails()
+	if len(details) != 2 {
+		t.Fatalf("BadRequest: want a BadRequest and an ErrorInfo got %v", details)
+	}
+	br := &errdetails.BadRequest{}
+	if err := proto.Unmarshal(details[0].GetValue(), br); err != nil || len(br.GetFieldViolations()) != 1 || br.GetFieldViolations()[0].GetField() != "a.b" {
+		t.Errorf("BadRequest: want a violation of a.b got %v", details[0])
+	}
+	want := ErrorInfo(FieldInvalid, Domain, map[string]string{"field": "a.b"})
+	if !proto.Equal(details[1], want) {
+		t.Errorf("BadRequest: want %v got %v", want, details[1])
+	}
+}
+
+func TestHelpers(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		msg    string
+		detail *any.Any
+	}{
+		{
+			"Field",
+			Field(FieldRequired, "name", "The field `%s` is required.", "name"),
+			"The field `name` is required.",
+			ErrorInfo(FieldRequired, Domain, map[string]string{"field": "name"}),
+		},
+		{
+			"Metadata",
+			Metadata("x-key", "The metadata `%s` is bad.", "x-key"),
+			"The metadata `x-key` is bad.",
+			ErrorInfo(MetadataInvalid, Domain, map[string]string{"metadata": "x-key"}),
+		},
+		{
+			"Setting",
+			Setting("max_poll_wait", "The setting is %d.", -1),
+			"The setting is -1.",
+			ErrorInfo(SettingInvalid, Domain, map[string]string{"setting": "max_poll_wait"}),
+		},
+		{
+			"RequestBody",
+			RequestBody("The body is %s.", "bad"),
+			"The body is bad.",
+			ErrorInfo(RequestBodyInvalid, Domain, nil),
+		},
+		{
+			"Decompression",
+			Decompression("gzip", "The request is not %s.", "gzip"),
+			"The request is not gzip.",
+			ErrorInfo(RequestDecompressionFailed, Domain, map[string]string{"encoding": "gzip"}),
+		},
+	}
+	for _, test := range tests {
+		st := status.Convert(test.err)
+		if st.Code() != codes.InvalidArgument || st.Message() != test.msg {
+			t.Errorf("%s: want INVALID_ARGUMENT %q got %v", test.name, test.msg, st)
+		}
+		details := st.Proto().GetDetails()
+		if len(details) != 1 || !proto.Equal(details[0], test.detail) {
+			t.Errorf("%s: want the detail %v got %v", test.name, test.detail, details)
+		}
+	}
+}
+
+// TestInvalidArgumentsHaveReasons fails on INVALID_ARGUMENT errors the server
+// builds without this package, which would lack an ErrorInfo reason.
+func TestInvalidArgumentsHaveReasons(t *testing.T) {
+	isInvalidArgument := func(e ast.Expr) bool {
+		if call, ok := e.(*ast.CallExpr); ok && len(call.Args) == 1 {
+			e = call.Args[0]
+		}
+		sel, ok := e.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "InvalidArgument" {
+			return false
+		}
+		pkg, ok := sel.X.(*ast.Ident)
+		return ok && pkg.Name == "codes"
+	}
+	for _, dir := range []string{"..", "../services"} {
+		files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, file := range files {
+			if strings.HasSuffix(file, "_test.go") {
+				continue
+			}
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, file, nil, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ast.Inspect(f, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.CallExpr:
+					sel, ok := n.Fun.(*ast.SelectorExpr)
+					if !ok {
+						return true
+					}
+					if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "status" {
+						return true
+					}
+					if len(n.Args) > 0 && isInvalidArgument(n.Args[0]) {
+						t.Errorf("%s: status.%s builds an INVALID_ARGUMENT error without a reason", fset.Position(n.Pos()), sel.Sel.Name)
+					}
+				case *ast.KeyValueExpr:
+					if key, ok := n.Key.(*ast.Ident); ok && key.Name == "Code" && isInvalidArgument(n.Value) {
+						t.Errorf("%s: a google.rpc.Status has the code INVALID_ARGUMENT without a reason", fset.Position(n.Pos()))
+					}
+				}
+				return true
+			})
+		}
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package showcasefuzz generates deterministic pseudo-random request messages
+// of the Showcase services, for fuzzing generated clients and for
+// property-style tests of the server.
+package showcasefuzz
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
+)
+
+// Options bound the messages that GenerateRequest generates.
+type Options struct {
+	// The most bytes of a string or bytes field.
+	MaxStringLength int
+
+	// The most elements of a repeated or map field.
+	MaxRepeated int
+
+	// How deeply messages are nested. Message fields below it are left
+	// unset.
+	MaxDepth int
+
+	// The longest duration of a Duration field. Durations are never
+	// negative.
+	MaxDuration time.Duration
+
+	// The probability that a field is left unset, between 0 and 1.
+	AbsentRate float64
+}
+
+// DefaultOptions returns the options of small, mostly populated messages.
+func DefaultOptions() Options {
+	return Options{
+		MaxStringLength: 16,
+		MaxRepeated:     3,
+		MaxDepth:        3,
+		MaxDuration:     time.Minute,
+		AbsentRate:      0.2,
+	}
+}
+
+// stringAlphabet holds the runes of generated strings. The spaces make the
+// strings split into words, as the Expand methods split their content.
+const stringAlphabet = "abcdefghijklmnopqrstuvwxyz     "
+
+// GenerateRequest returns a new message of the type of prototype, populated
+// with pseudo-random values chosen by seed: the same seed and options always
+// give the same message. Strings and bytes have at most MaxStringLength
+// bytes, enums only take their defined values, and Durations and Timestamps
+// are valid. Any and FieldMask fields are left unset, as random ones would
+// rarely be valid.
+func GenerateRequest(prototype proto.Message, seed int64, opts Options) proto.Message {
+	g := &generator{rand: rand.New(rand.NewSource(seed)), opts: opts}
+	msg := reflect.New(reflect.TypeOf(prototype).Elem())
+	g.message(msg, 0)
+	return msg.Interface().(proto.Message)
+}
+
+type generator struct {
+	rand *rand.Rand
+	opts Options
+}
+
+// oneofWrappers is implemented by messages with oneofs.
+type oneofWrappers interface {
+	XXX_OneofWrappers() []interface{}
+}
+
+// message populates the fields of msg, a pointer to a generated struct,
+// nested depth messages deep.
+func (g *generator) message(msg reflect.Value, depth int) {
+	switch msg.Interface().(type) {
+	case *duration.Duration:
+		d := time.Duration(g.rand.Int63n(int64(g.opts.MaxDuration) + 1))
+		msg.Elem().Set(reflect.ValueOf(ptypes.DurationProto(d)).Elem())
+		return
+	case *timestamp.Timestamp:
+		// Any moment from 1970 to 2100.
+		ts, _ := ptypes.TimestampProto(time.Unix(g.rand.Int63n(4102444800), g.rand.Int63n(1e9)).UTC())
+		msg.Elem().Set(reflect.ValueOf(ts).Elem())
+		return
+	}
+	v := msg.Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if strings.HasPrefix(f.Name, "XXX_") {
+			continue
+		}
+		if g.rand.Float64() < g.opts.AbsentRate {
+			continue
+		}
+		if _, ok := f.Tag.Lookup("protobuf_oneof"); ok {
+			g.oneof(msg, v.Field(i), depth)
+			continue
+		}
+		g.value(v.Field(i), f.Tag.Get("protobuf"), depth)
+	}
+}
+
+// oneof sets field, the interface of a oneof of msg, to one of its cases.
+func (g *generator) oneof(msg, field reflect.Value, depth int) {
+	w, ok := msg.Interface().(oneofWrappers)
+	if !ok {
+		return
+	}
+	var cases []reflect.Type
+	for _, wrapper := range w.XXX_OneofWrappers() {
+		if t := reflect.TypeOf(wrapper); t.Implements(field.Type()) {
+			cases = append(cases, t.Elem())
+		}
+	}
+	if len(cases) == 0 {
+		return
+	}
+	c := reflect.New(cases[g.rand.Intn(len(cases))])
+	if g.unset(c.Elem().Field(0).Type(), depth) {
+		return
+	}
+	g.value(c.Elem().Field(0), c.Elem().Type().Field(0).Tag.Get("protobuf"), depth)
+	field.Set(c)
+}
+
+// unset reports whether fields of type t are left unset depth messages deep:
+// messages below MaxDepth, Any and FieldMask messages, and the repeated and
+// map fields of such messages, which cannot hold unset elements.
+func (g *generator) unset(t reflect.Type, depth int) bool {
+	switch t.Kind() {
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.Ptr && g.unset(t.Elem(), depth)
+	case reflect.Map:
+		return t.Elem().Kind() == reflect.Ptr && g.unset(t.Elem(), depth)
+	case reflect.Ptr:
+		return depth >= g.opts.MaxDepth || t == reflect.TypeOf(&any.Any{}) || t == reflect.TypeOf(&field_mask.FieldMask{})
+	}
+	return false
+}
+
+// value sets v, a field with the protobuf struct tag, to a random value.
+func (g *generator) value(v reflect.Value, tag string, depth int) {
+	if g.unset(v.Type(), depth) {
+		return
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(g.string())
+	case reflect.Bool:
+		v.SetBool(g.rand.Intn(2) == 1)
+	case reflect.Int32:
+		if values := enumValues(tag); len(values) > 0 {
+			v.SetInt(int64(values[g.rand.Intn(len(values))]))
+			return
+		}
+		v.SetInt(int64(int32(g.rand.Uint32())))
+	case reflect.Int64:
+		v.SetInt(int64(g.rand.Uint64()))
+	case reflect.Uint32, reflect.Uint64:
+		v.SetUint(g.rand.Uint64() >> uint(64-8*v.Type().Size()))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(g.rand.NormFloat64() * 1000)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, g.rand.Intn(g.opts.MaxStringLength+1))
+			g.rand.Read(b)
+			v.SetBytes(b)
+			return
+		}
+		n := g.rand.Intn(g.opts.MaxRepeated + 1)
+		s := reflect.MakeSlice(v.Type(), n, n)
+		for i := 0; i < n; i++ {
+			g.value(s.Index(i), tag, depth)
+		}
+		v.Set(s)
+	case reflect.Map:
+		m := reflect.MakeMap(v.Type())
+		for n := g.rand.Intn(g.opts.MaxRepeated + 1); n > 0; n-- {
+			key := reflect.New(v.Type().Key()).Elem()
+			g.value(key, "", depth)
+			elem := reflect.New(v.Type().Elem()).Elem()
+			g.value(elem, "", depth)
+			m.SetMapIndex(key, elem)
+		}
+		v.Set(m)
+	case reflect.Ptr:
+		msg := reflect.New(v.Type().Elem())
+		g.message(msg, depth+1)
+		v.Set(msg)
+	}
+}
+
+func (g *generator) string() string {
+	b := make([]byte, g.rand.Intn(g.opts.MaxStringLength+1))
+	for i := range b {
+		b[i] = stringAlphabet[g.rand.Intn(len(stringAlphabet))]
+	}
+	return string(b)
+}
+
+// enumValues returns the values of the enum of a field with the protobuf
+// struct tag, in increasing order, or nil if it is not an enum.
+func enumValues(tag string) []int32 {
+	for _, part := range strings.Split(tag, ",") {
+		if strings.HasPrefix(part, "enum=") {
+			var values []int32
+			for _, value := range proto.EnumValueMap(strings.TrimPrefix(part, "enum=")) {
+				values = append(values, value)
+			}
+			sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+			return values
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package showcasefuzz
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/googleapis/gapic-showcase/server"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+)
+
+// showcaseRequests returns a request of each Showcase method.
+func showcaseRequests(t *testing.T) map[string]proto.Message {
+	requests := map[string]proto.Message{}
+	for _, method := range server.ShowcaseMethods() {
+		input, _ := server.ShowcaseMethodInput(method)
+		typ := proto.MessageType(input)
+		if typ == nil {
+			t.Fatalf("%s: the request type %s is not registered", method, input)
+		}
+		requests[method] = reflect.New(typ.Elem()).Interface().(proto.Message)
+	}
+	return requests
+}
+
+func TestGenerateRequest_deterministic(t *testing.T) {
+	for method, prototype := range showcaseRequests(t) {
+		for seed := int64(0); seed < 20; seed++ {
+			a := GenerateRequest(prototype, seed, DefaultOptions())
+			b := GenerateRequest(prototype, seed, DefaultOptions())
+			if !proto.Equal(a, b) {
+				t.Errorf("%s, seed %d: want the same request twice got %v and %v", method, seed, a, b)
+			}
+			if _, err := proto.Marshal(a); err != nil {
+				t.Errorf("%s, seed %d: the request %v does not marshal: %v", method, seed, a, err)
+			}
+		}
+	}
+}
+
+func TestGenerateRequest_seeds(t *testing.T) {
+	seen := map[string]bool{}
+	for seed := int64(0); seed < 50; seed++ {
+		seen[proto.CompactTextString(GenerateRequest(&pb.ExpandRequest{}, seed, DefaultOptions()))] = true
+	}
+	if len(seen) < 45 {
+		t.Errorf("GenerateRequest: want nearly every seed to give another request got %d of 50", len(seen))
+	}
+}
+
+func TestGenerateRequest_bounds(t *testing.T) {
+	opts := DefaultOptions()
+	opts.MaxStringLength = 5
+	opts.MaxRepeated = 2
+	opts.MaxDuration = time.Second
+	absent := 0
+	for seed := int64(0); seed < 200; seed++ {
+		in := GenerateRequest(&pb.ExpandRequest{}, seed, opts).(*pb.ExpandRequest)
+		if len(in.GetContent()) > 5 || len(in.GetStreamId()) > 5 {
+			t.Errorf("seed %d: want strings of at most 5 bytes got %q, %q", seed, in.GetContent(), in.GetStreamId())
+		}
+		if len(in.GetSizePattern()) > 2 || len(in.GetTrailers()) > 2 {
+			t.Errorf("seed %d: want at most 2 elements got %v, %v", seed, in.GetSizePattern(), in.GetTrailers())
+		}
+		if in.GetMessageDelay() == nil {
+			absent++
+			continue
+		}
+		if d, err := ptypes.Duration(in.GetMessageDelay()); err != nil || d < 0 || d > time.Second {
+			t.Errorf("seed %d: want a duration of at most 1s got %v, %v", seed, in.GetMessageDelay(), err)
+		}
+	}
+	// About a fifth of the delays are absent.
+	if absent < 10 || absent > 90 {
+		t.Errorf("GenerateRequest: want about 40 of 200 delays absent got %d", absent)
+	}
+
+	opts.AbsentRate = 1
+	if in := GenerateRequest(&pb.ExpandRequest{}, 1, opts); !proto.Equal(in, &pb.ExpandRequest{}) {
+		t.Errorf("GenerateRequest with every field absent: want an empty request got %v", in)
+	}
+}
+
+func TestGenerateRequest_enumsAndOneofs(t *testing.T) {
+	opts := DefaultOptions()
+	opts.AbsentRate = 0
+	cases := map[string]bool{}
+	for seed := int64(0); seed < 100; seed++ {
+		details := GenerateRequest(&pb.FailEchoWithDetailsRequest{}, seed, opts).(*pb.FailEchoWithDetailsRequest)
+		for _, d := range details.GetDetails() {
+			if _, ok := pb.FailEchoWithDetailsRequest_DetailType_name[int32(d)]; !ok {
+				t.Errorf("seed %d: want defined detail types got %v", seed, details.GetDetails())
+			}
+		}
+		in := GenerateRequest(&pb.EchoRequest{}, seed, opts).(*pb.EchoRequest)
+		if in.GetResponse() == nil {
+			t.Errorf("seed %d: want the response oneof set", seed)
+			continue
+		}
+		cases[reflect.TypeOf(in.GetResponse()).String()] = true
+		if in.GetReadMask() != nil {
+			t.Errorf("seed %d: want no read mask got %v", seed, in.GetReadMask())
+		}
+	}
+	if len(cases) != 2 {
+		t.Errorf("GenerateRequest: want both cases of the response oneof got %v", cases)
+	}
+
+	opts.MaxDepth = 0
+	for seed := int64(0); seed < 20; seed++ {
+		in := GenerateRequest(&pb.EchoRequest{}, seed, opts).(*pb.EchoRequest)
+		if in.GetError() != nil || in.GetDedupeWindow() != nil || in.GetAck() != nil {
+			t.Errorf("seed %d: want no messages with depth 0 got %v", seed, in)
+		}
+	}
+}
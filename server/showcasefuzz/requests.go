@@ -0,0 +1,114 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package showcasefuzz
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+)
+
+// validRequest generates the requests of a method that its handler answers
+// without an error.
+type validRequest struct {
+	// A request of the method.
+	prototype proto.Message
+
+	// valid makes a request the handler answers from a generated one. It
+	// keeps only the fields that cannot fail the call or need other state,
+	// and brings those into range.
+	valid func(in proto.Message, seed int64, opts Options) proto.Message
+}
+
+var validRequests = map[string]validRequest{
+	"/google.showcase.v1beta1.Echo/Echo": {&pb.EchoRequest{}, func(in proto.Message, _ int64, opts Options) proto.Message {
+		r := in.(*pb.EchoRequest)
+		return &pb.EchoRequest{
+			Response:             &pb.EchoRequest_Content{Content: r.GetContent()},
+			ClientSequence:       r.GetClientSequence(),
+			RepeatCount:          bounded(r.GetRepeatCount(), opts.MaxRepeated),
+			StripUnknownFields:   r.GetStripUnknownFields(),
+			IncludeTransportInfo: r.GetIncludeTransportInfo(),
+			RequestId:            r.GetRequestId(),
+		}
+	}},
+	"/google.showcase.v1beta1.Echo/Expand": {&pb.ExpandRequest{}, func(in proto.Message, _ int64, opts Options) proto.Message {
+		r := in.(*pb.ExpandRequest)
+		return &pb.ExpandRequest{
+			Content:     r.GetContent(),
+			RepeatCount: bounded(r.GetRepeatCount(), opts.MaxRepeated),
+			WithSummary: r.GetWithSummary(),
+		}
+	}},
+	"/google.showcase.v1beta1.Echo/PagedExpand": {&pb.PagedExpandRequest{}, func(in proto.Message, _ int64, opts Options) proto.Message {
+		r := in.(*pb.PagedExpandRequest)
+		return &pb.PagedExpandRequest{
+			Content:  r.GetContent(),
+			PageSize: bounded(r.GetPageSize(), opts.MaxRepeated),
+		}
+	}},
+	"/google.showcase.v1beta1.Identity/CreateUser": {&pb.CreateUserRequest{}, func(in proto.Message, seed int64, _ Options) proto.Message {
+		r := in.(*pb.CreateUserRequest)
+		return &pb.CreateUserRequest{User: &pb.User{
+			DisplayName: fmt.Sprintf("%s %d", r.GetUser().GetDisplayName(), seed),
+			Email:       fmt.Sprintf("user-%d@example.com", seed),
+		}}
+	}},
+	"/google.showcase.v1beta1.Identity/ListUsers": {&pb.ListUsersRequest{}, func(in proto.Message, _ int64, _ Options) proto.Message {
+		r := in.(*pb.ListUsersRequest)
+		return &pb.ListUsersRequest{PageSize: r.GetPageSize(), PageTokenTtl: r.GetPageTokenTtl()}
+	}},
+	"/google.showcase.v1beta1.Messaging/CreateRoom": {&pb.CreateRoomRequest{}, func(in proto.Message, seed int64, _ Options) proto.Message {
+		r := in.(*pb.CreateRoomRequest)
+		return &pb.CreateRoomRequest{Room: &pb.Room{
+			DisplayName: fmt.Sprintf("%s %d", r.GetRoom().GetDisplayName(), seed),
+			Description: r.GetRoom().GetDescription(),
+		}}
+	}},
+	"/google.showcase.v1beta1.Messaging/ListRooms": {&pb.ListRoomsRequest{}, func(in proto.Message, _ int64, _ Options) proto.Message {
+		r := in.(*pb.ListRoomsRequest)
+		return &pb.ListRoomsRequest{PageSize: r.GetPageSize(), PageTokenTtl: r.GetPageTokenTtl()}
+	}},
+}
+
+// ValidRequestMethods returns the full gRPC names of the methods that
+// ValidRequest generates requests for, sorted.
+func ValidRequestMethods() []string {
+	methods := []string{}
+	for method := range validRequests {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// ValidRequest returns a request of the method with the full gRPC name,
+// generated as by GenerateRequest, that the Showcase server answers without
+// an error. The names and emails of created resources are unique to the
+// seed. It reports false if the method is not one of ValidRequestMethods.
+func ValidRequest(method string, seed int64, opts Options) (proto.Message, bool) {
+	r, ok := validRequests[method]
+	if !ok {
+		return nil, false
+	}
+	return r.valid(GenerateRequest(r.prototype, seed, opts), seed, opts), true
+}
+
+// bounded folds n into the range from 0 to max.
+func bounded(n int32, max int) int32 {
+	return int32(uint32(n) % uint32(max+1))
+}
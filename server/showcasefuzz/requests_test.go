@@ -0,0 +1,103 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package showcasefuzz
+
+import (
+	"context"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/googleapis/gapic-showcase/server"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"github.com/googleapis/gapic-showcase/server/services"
+	"google.golang.org/grpc"
+)
+
+func TestValidRequestMethods(t *testing.T) {
+	showcase := map[string]bool{}
+	for _, method := range server.ShowcaseMethods() {
+		showcase[method] = true
+	}
+	for _, method := range ValidRequestMethods() {
+		if !showcase[method] {
+			t.Errorf("%s is not a Showcase method", method)
+		}
+	}
+	if _, ok := ValidRequest("/google.showcase.v1beta1.Echo/Chat", 1, DefaultOptions()); ok {
+		t.Errorf("ValidRequest(Chat): want no request for a method without a helper")
+	}
+}
+
+func TestValidRequest(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	identity := services.NewIdentityServer()
+	pb.RegisterEchoServer(s, services.NewEchoServer())
+	pb.RegisterIdentityServer(s, identity)
+	pb.RegisterMessagingServer(s, services.NewMessagingServer(identity))
+	go s.Serve(lis)
+	defer s.Stop()
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	for _, method := range ValidRequestMethods() {
+		output, _ := server.ShowcaseMethodOutput(method)
+		for seed := int64(0); seed < 50; seed++ {
+			in, _ := ValidRequest(method, seed, DefaultOptions())
+			again, _ := ValidRequest(method, seed, DefaultOptions())
+			if !proto.Equal(in, again) {
+				t.Errorf("%s, seed %d: want the same request twice got %v and %v", method, seed, in, again)
+			}
+			if err := call(conn, method, in, proto.MessageType(output)); err != nil {
+				t.Errorf("%s(%v), seed %d: want success got %v", method, in, seed, err)
+			}
+		}
+	}
+}
+
+// call calls the unary or server-streaming method with the request, and
+// reads every response.
+func call(conn *grpc.ClientConn, method string, in proto.Message, output reflect.Type) error {
+	ctx := context.Background()
+	if method != "/google.showcase.v1beta1.Echo/Expand" {
+		return conn.Invoke(ctx, method, in, reflect.New(output.Elem()).Interface())
+	}
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, method)
+	if err != nil {
+		return err
+	}
+	if err := stream.SendMsg(in); err != nil {
+		return err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+	for {
+		if err := stream.RecvMsg(reflect.New(output.Elem()).Interface()); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
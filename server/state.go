@@ -0,0 +1,38 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import "sync"
+
+// stateLock makes a dump of the stores consistent across them. Every change
+// to a store holds it for reading, and FreezeState holds it for writing.
+var stateLock sync.RWMutex
+
+// ChangeState marks the start of a change to a store, and returns the func
+// that marks its end. Changes must not be nested. Purges of a namespace,
+// which span the stores, are made under FreezeState instead, so the
+// PurgeNamespace methods of the stores do not call it.
+func ChangeState() (done func()) {
+	stateLock.RLock()
+	return stateLock.RUnlock
+}
+
+// FreezeState waits for the changes in progress to end and blocks new ones
+// until the returned func is called, so that the stores can be read at a
+// single instant.
+func FreezeState() (thaw func()) {
+	stateLock.Lock()
+	return stateLock.Unlock
+}
@@ -0,0 +1,215 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+)
+
+const (
+	// StateSessionHeader is the metadata key that names the state session of
+	// a call.
+	StateSessionHeader = "showcase-session-id"
+
+	// StateSessionIdleTTL is how long a state session lasts after its last
+	// call.
+	StateSessionIdleTTL = 30 * time.Minute
+)
+
+var stateSessionsSingleton = NewStateSessions(Now, StateSessionIdleTTL)
+
+// GetStateSessionsInstance returns the state sessions singleton.
+func GetStateSessionsInstance() StateSessions {
+	return stateSessionsSingleton
+}
+
+// StateSessions group the state created by the calls of a test, so that it
+// can be deleted when the test ends. Each session has a namespace of its
+// own, in which the calls that name the session run.
+type StateSessions interface {
+	// Start starts a session and returns its ID.
+	Start() string
+
+	// Touch returns the namespace of the session and marks it used, or
+	// reports false if the session does not exist or has expired.
+	Touch(id string) (namespace string, ok bool)
+
+	// End ends the session and returns its namespace, or reports false if
+	// the session does not exist or has expired.
+	End(id string) (namespace string, ok bool)
+
+	// Expire ends the sessions that have been idle for longer than the TTL,
+	// and returns their namespaces.
+	Expire() []string
+
+	// TTL returns how long a session lasts after its last use.
+	TTL() time.Duration
+}
+
+// StateSessionNamespace returns the namespace of the state session with the
+// ID.
+func StateSessionNamespace(id string) string {
+	return "session-" + id
+}
+
+// NewStateSessions returns sessions that expire once idle for the TTL, by
+// the clock of nowF.
+func NewStateSessions(nowF func() time.Time, ttl time.Duration) StateSessions {
+	return &stateSessions{nowF: nowF, ttl: ttl, lastUse: map[string]time.Time{}}
+}
+
+type stateSessions struct {
+	nowF func() time.Time
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	rand    *rand.Rand
+	lastUse map[string]time.Time
+}
+
+func (s *stateSessions) Start() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rand == nil {
+		s.rand = NewRand()
+	}
+	for {
+		id := fmt.Sprintf("%016x", s.rand.Uint64())
+		if _, ok := s.lastUse[id]; !ok {
+			s.lastUse[id] = s.nowF()
+			return id
+		}
+	}
+}
+
+// live reports whether the session exists and has not expired. The caller
+// must hold mu.
+func (s *stateSessions) live(id string, now time.Time) bool {
+	last, ok := s.lastUse[id]
+	return ok && now.Sub(last) <= s.ttl
+}
+
+func (s *stateSessions) Touch(id string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.nowF()
+	if !s.live(id, now) {
+		return "", false
+	}
+	s.lastUse[id] = now
+	return StateSessionNamespace(id), true
+}
+
+func (s *stateSessions) End(id string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.live(id, s.nowF()) {
+		return "", false
+	}
+	delete(s.lastUse, id)
+	return StateSessionNamespace(id), true
+}
+
+func (s *stateSessions) Expire() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.nowF()
+	var namespaces []string
+	for id := range s.lastUse {
+		if !s.live(id, now) {
+			delete(s.lastUse, id)
+			namespaces = append(namespaces, StateSessionNamespace(id))
+		}
+	}
+	return namespaces
+}
+
+func (s *stateSessions) TTL() time.Duration {
+	return s.ttl
+}
+
+// StateSessionInterceptor runs the calls that name a state session in the
+// namespace of the session.
+type StateSessionInterceptor struct {
+	sessions StateSessions
+}
+
+// NewStateSessionInterceptor returns a StateSessionInterceptor of the
+// sessions.
+func NewStateSessionInterceptor(sessions StateSessions) *StateSessionInterceptor {
+	return &StateSessionInterceptor{sessions: sessions}
+}
+
+// session returns a copy of the context in the namespace of the session
+// named by its incoming metadata, if any.
+func (i *StateSessionInterceptor) session(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(StateSessionHeader)
+	if len(values) == 0 {
+		return ctx, nil
+	}
+	if len(values) > 1 {
+		return nil, showcaseerrors.Metadata(StateSessionHeader, "The %s metadata must be given at most once.", StateSessionHeader)
+	}
+	if len(md.Get(NamespaceHeader)) > 0 {
+		return nil, showcaseerrors.Metadata(
+			StateSessionHeader,
+			"The %s and %s metadata must not both be given.",
+			StateSessionHeader,
+			NamespaceHeader)
+	}
+	namespace, ok := i.sessions.Touch(values[0])
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "The session %q does not exist or has expired.", values[0])
+	}
+	return WithNamespace(ctx, namespace), nil
+}
+
+// UnaryInterceptor runs a unary call in the namespace of its session.
+func (i *StateSessionInterceptor) UnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := i.session(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor runs a streaming call in the namespace of its session.
+func (i *StateSessionInterceptor) StreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	ctx, err := i.session(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &namespacedStream{ServerStream: ss, ctx: ctx})
+}
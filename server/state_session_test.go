@@ -0,0 +1,103 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestStateSessions(t *testing.T) {
+	now := time.Unix(1000, 0)
+	sessions := NewStateSessions(func() time.Time { return now }, time.Minute)
+	a, b := sessions.Start(), sessions.Start()
+	if a == b {
+		t.Fatalf("Start: want distinct IDs, got %q twice", a)
+	}
+	if namespace, ok := sessions.Touch(a); !ok || namespace != StateSessionNamespace(a) {
+		t.Errorf("Touch: want %q, got %q, %t", StateSessionNamespace(a), namespace, ok)
+	}
+	if err := ValidateNamespace(StateSessionNamespace(a)); err != nil {
+		t.Errorf("StateSessionNamespace: want a valid namespace, got %v", err)
+	}
+
+	// Using a keeps it alive while b expires.
+	now = now.Add(40 * time.Second)
+	sessions.Touch(a)
+	now = now.Add(40 * time.Second)
+	if got := sessions.Expire(); len(got) != 1 || got[0] != StateSessionNamespace(b) {
+		t.Errorf("Expire: want only %q, got %q", StateSessionNamespace(b), got)
+	}
+	if _, ok := sessions.Touch(b); ok {
+		t.Errorf("Touch of an expired session: want false")
+	}
+	if namespace, ok := sessions.End(a); !ok || namespace != StateSessionNamespace(a) {
+		t.Errorf("End: want %q, got %q, %t", StateSessionNamespace(a), namespace, ok)
+	}
+	if _, ok := sessions.End(a); ok {
+		t.Errorf("End of an ended session: want false")
+	}
+	if got := sessions.Expire(); len(got) != 0 {
+		t.Errorf("Expire after End: want nothing, got %q", got)
+	}
+}
+
+func TestStateSessionInterceptor(t *testing.T) {
+	sessions := NewStateSessions(time.Now, time.Minute)
+	id := sessions.Start()
+	i := NewStateSessionInterceptor(sessions)
+	tests := []struct {
+		md        metadata.MD
+		want      codes.Code
+		namespace string
+	}{
+		{metadata.MD{}, codes.OK, DefaultNamespace},
+		{metadata.Pairs(StateSessionHeader, id), codes.OK, StateSessionNamespace(id)},
+		{metadata.Pairs(StateSessionHeader, "unknown"), codes.NotFound, ""},
+		{metadata.Pairs(StateSessionHeader, id, StateSessionHeader, id), codes.InvalidArgument, ""},
+		{metadata.Pairs(StateSessionHeader, id, NamespaceHeader, "other"), codes.InvalidArgument, ""},
+	}
+	for _, test := range tests {
+		var got string
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			got = NamespaceFromContext(ctx)
+			return nil, nil
+		}
+		ctx := metadata.NewIncomingContext(context.Background(), test.md)
+		_, err := i.UnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+		if status.Code(err) != test.want {
+			t.Errorf("UnaryInterceptor(%v): want %s, got %v", test.md, test.want, err)
+		}
+		if got != test.namespace {
+			t.Errorf("UnaryInterceptor(%v): want the namespace %q, got %q", test.md, test.namespace, got)
+		}
+	}
+
+	var got string
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		got = NamespaceFromContext(ss.Context())
+		return nil
+	}
+	ss := &contextStream{ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(StateSessionHeader, id))}
+	if err := i.StreamInterceptor(nil, ss, &grpc.StreamServerInfo{}, handler); err != nil || got != StateSessionNamespace(id) {
+		t.Errorf("StreamInterceptor: want the namespace %q, got %q, %v", StateSessionNamespace(id), got, err)
+	}
+}
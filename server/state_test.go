@@ -0,0 +1,40 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFreezeState(t *testing.T) {
+	thaw := FreezeState()
+	changed := make(chan struct{})
+	go func() {
+		defer ChangeState()()
+		close(changed)
+	}()
+	select {
+	case <-changed:
+		t.Fatal("ChangeState: want changes blocked while the state is frozen")
+	case <-time.After(20 * time.Millisecond):
+	}
+	thaw()
+	select {
+	case <-changed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("ChangeState: want changes to resume once the state is thawed")
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/googleapis/gapic-showcase/server/showcaseerrors"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StreamDurationLimiter ends the streaming calls that run longer than the
+// MaxStreamDuration setting with ABORTED.
+type StreamDurationLimiter struct {
+	settings SettingsStore
+}
+
+// NewStreamDurationLimiter returns a StreamDurationLimiter that reads the
+// limit from the settings.
+func NewStreamDurationLimiter(settings SettingsStore) *StreamDurationLimiter {
+	return &StreamDurationLimiter{settings: settings}
+}
+
+// StreamInterceptor runs the stream with a context whose deadline is the
+// limit, and ends the call with ABORTED if the handler returns after the
+// deadline with an error. Once the deadline passes, the sends and receives
+// of the stream fail with context.DeadlineExceeded, so handlers blocked on
+// them return, and the interceptor waits for the handler before it returns.
+func (l *StreamDurationLimiter) StreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	limit := l.settings.Get().MaxStreamDuration
+	if limit <= 0 {
+		return handler(srv, ss)
+	}
+	ctx, cancel := context.WithDeadline(ss.Context(), time.Now().Add(limit))
+	defer cancel()
+	err := handler(srv, &limitedStream{ServerStream: ss, ctx: ctx})
+	if err != nil && ss.Context().Err() == nil && ctx.Err() == context.DeadlineExceeded {
+		return streamDurationExceeded(limit)
+	}
+	return err
+}
+
+// limitedStream is a stream whose sends and receives give up once its
+// context is done. A send or receive that was blocked then still runs
+// until the call ends, but its result is dropped.
+type limitedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *limitedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *limitedStream) SendMsg(m interface{}) error {
+	return s.untilDone(func() error { return s.ServerStream.SendMsg(m) })
+}
+
+func (s *limitedStream) RecvMsg(m interface{}) error {
+	return s.untilDone(func() error { return s.ServerStream.RecvMsg(m) })
+}
+
+// untilDone returns the result of op, or the error of the context if it is
+// done first.
+func (s *limitedStream) untilDone(op func() error) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	errs := make(chan error, 1)
+	go func() {
+		errs <- op()
+	}()
+	select {
+	case err := <-errs:
+		return err
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+func streamDurationExceeded(limit time.Duration) error {
+	return status.ErrorProto(&spb.Status{
+		Code:    int32(codes.Aborted),
+		Message: fmt.Sprintf("The stream ran longer than the %s the server allows.", limit),
+		Details: []*any.Any{showcaseerrors.ErrorInfo(showcaseerrors.StreamMaxDurationExceeded, showcaseerrors.Domain, map[string]string{
+			"max_duration": limit.String(),
+		})},
+	})
+}
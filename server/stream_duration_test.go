@@ -0,0 +1,126 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStreamDurationLimiter(t *testing.T) {
+	settings := DefaultSettings()
+	settings.MaxStreamDuration = 10 * time.Millisecond
+	l := NewStreamDurationLimiter(NewSettingsStore(settings))
+
+	var handlerErr error
+	var deadline time.Time
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		deadline, _ = ss.Context().Deadline()
+		<-ss.Context().Done()
+		handlerErr = ss.Context().Err()
+		return handlerErr
+	}
+	start := time.Now()
+	err := l.StreamInterceptor(nil, &contextStream{ctx: context.Background()}, &grpc.StreamServerInfo{}, handler)
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("StreamInterceptor: want Aborted got %v", err)
+	}
+	details := status.Convert(err).Proto().GetDetails()
+	if len(details) != 1 {
+		t.Fatalf("StreamInterceptor: want an ErrorInfo detail got %v", details)
+	}
+	if deadline.Before(start) || deadline.After(start.Add(time.Second)) {
+		t.Errorf("StreamInterceptor: want the handler's context to have a deadline 10ms away got %v", deadline)
+	}
+	// The handler returned before the interceptor did.
+	if handlerErr != context.DeadlineExceeded {
+		t.Errorf("StreamInterceptor: want the handler's context past its deadline got %v", handlerErr)
+	}
+}
+
+// blockedStream is a stream whose sends and receives block until it is
+// released.
+type blockedStream struct {
+	contextStream
+	release chan struct{}
+}
+
+func (s *blockedStream) SendMsg(m interface{}) error {
+	<-s.release
+	return nil
+}
+
+func (s *blockedStream) RecvMsg(m interface{}) error {
+	<-s.release
+	return nil
+}
+
+func TestStreamDurationLimiter_blockedHandler(t *testing.T) {
+	settings := DefaultSettings()
+	settings.MaxStreamDuration = 10 * time.Millisecond
+	l := NewStreamDurationLimiter(NewSettingsStore(settings))
+	ss := &blockedStream{contextStream: contextStream{ctx: context.Background()}, release: make(chan struct{})}
+	defer close(ss.release)
+
+	for _, op := range []string{"RecvMsg", "SendMsg"} {
+		returned := false
+		handler := func(srv interface{}, ss grpc.ServerStream) error {
+			defer func() { returned = true }()
+			if op == "RecvMsg" {
+				return ss.RecvMsg(nil)
+			}
+			return ss.SendMsg(nil)
+		}
+		err := l.StreamInterceptor(nil, ss, &grpc.StreamServerInfo{}, handler)
+		if status.Code(err) != codes.Aborted {
+			t.Errorf("StreamInterceptor with a handler blocked in %s: want Aborted got %v", op, err)
+		}
+		if !returned {
+			t.Errorf("StreamInterceptor with a handler blocked in %s: want the handler to return first", op)
+		}
+	}
+}
+
+func TestStreamDurationLimiter_shortStream(t *testing.T) {
+	settings := DefaultSettings()
+	settings.MaxStreamDuration = time.Second
+	l := NewStreamDurationLimiter(NewSettingsStore(settings))
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		return status.Error(codes.NotFound, "not found")
+	}
+	err := l.StreamInterceptor(nil, &contextStream{ctx: context.Background()}, &grpc.StreamServerInfo{}, handler)
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("StreamInterceptor: want the handler's error got %v", err)
+	}
+}
+
+func TestStreamDurationLimiter_disabled(t *testing.T) {
+	l := NewStreamDurationLimiter(NewSettingsStore(DefaultSettings()))
+	ss := &contextStream{ctx: context.Background()}
+	handler := func(srv interface{}, got grpc.ServerStream) error {
+		if got != ss {
+			t.Errorf("StreamInterceptor: want the stream passed through unchanged")
+		}
+		return nil
+	}
+	if err := l.StreamInterceptor(nil, ss, &grpc.StreamServerInfo{}, handler); err != nil {
+		t.Errorf("StreamInterceptor: %v", err)
+	}
+}
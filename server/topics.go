@@ -0,0 +1,230 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// MaxTopics is the maximum number of topics a TopicStore keeps.
+	MaxTopics = 100
+
+	// MaxTopicMessages is the maximum number of unacknowledged messages a
+	// topic holds.
+	MaxTopicMessages = 1000
+)
+
+var topicStoreSingleton = NewTopicStore()
+
+// GetTopicStoreInstance returns the topic store singleton.
+func GetTopicStoreInstance() TopicStore {
+	return topicStoreSingleton
+}
+
+// TopicStore holds the messages published with Echo.PublishEcho until
+// Echo.StreamingPullEcho delivers them and they are acknowledged. Each
+// namespace has its own topics, but the limit on topics applies to the whole
+// store.
+type TopicStore interface {
+	// Publish appends the messages to the topic, creating it if it does not
+	// exist, and returns their IDs. It fails with RESOURCE_EXHAUSTED if the
+	// topic would hold more than MaxTopicMessages unacknowledged messages,
+	// or the store more than MaxTopics topics.
+	Publish(namespace, topic string, contents []string) ([]string, error)
+
+	// Pull leases to the subscriber the messages of the topic that are not
+	// leased, or whose lease has expired, until the deadline after now. It
+	// leases no more than bring the unexpired leases of the subscriber to
+	// max, unless max is 0. It fails with NOT_FOUND if the topic does not
+	// exist.
+	Pull(namespace, topic, subscriber string, max int, now time.Time, deadline time.Duration) (TopicPull, error)
+
+	// Ack removes the messages of the ack IDs from the topic, and returns
+	// how many it removed. IDs of earlier deliveries of a message, and of
+	// messages already removed, are ignored.
+	Ack(namespace, topic string, ackIDs []string) int
+
+	// PurgeNamespace removes all topics of the namespace, and returns how
+	// many it removed.
+	PurgeNamespace(namespace string) int
+}
+
+// TopicPull is the result of TopicStore.Pull.
+type TopicPull struct {
+	// Messages are the messages leased, in the order they were published.
+	Messages []*pb.ReceivedEchoMessage
+
+	// Changed is closed when messages are next published to the topic, or
+	// the topic is purged.
+	Changed <-chan struct{}
+
+	// NextExpiry is when the next lease of any subscriber of the topic
+	// expires, or zero if no message is leased.
+	NextExpiry time.Time
+}
+
+// NewTopicStore returns an empty TopicStore.
+func NewTopicStore() TopicStore {
+	return &topicStore{topics: map[namespacedName]*topic{}}
+}
+
+type topicStore struct {
+	mu     sync.Mutex
+	topics map[namespacedName]*topic
+}
+
+type topic struct {
+	// messages are the unacknowledged messages, in the order they were
+	// published.
+	messages []*topicMessage
+
+	// published is the number of messages ever published, which numbers
+	// their IDs.
+	published int64
+
+	changed chan struct{}
+}
+
+type topicMessage struct {
+	id       string
+	content  string
+	attempts int32
+
+	// subscriber holds the lease of the message until expiry, if the
+	// message has been delivered.
+	subscriber string
+	expiry     time.Time
+}
+
+func (m *topicMessage) ackID() string {
+	return fmt.Sprintf("%s-%d", m.id, m.attempts)
+}
+
+func (s *topicStore) Publish(namespace, name string, contents []string) ([]string, error) {
+	key := namespacedName{namespace, name}
+	defer ChangeState()()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.topics[key]
+	if !ok && len(s.topics) >= MaxTopics {
+		return nil, status.Errorf(codes.ResourceExhausted, "At most %d topics may be stored.", MaxTopics)
+	}
+	held := 0
+	if ok {
+		held = len(t.messages)
+	}
+	if held+len(contents) > MaxTopicMessages {
+		return nil, status.Errorf(codes.ResourceExhausted, "A topic may hold at most %d unacknowledged messages.", MaxTopicMessages)
+	}
+	if !ok {
+		t = &topic{changed: make(chan struct{})}
+		s.topics[key] = t
+	}
+	ids := make([]string, 0, len(contents))
+	for _, content := range contents {
+		t.published++
+		m := &topicMessage{id: fmt.Sprint(t.published), content: content}
+		t.messages = append(t.messages, m)
+		ids = append(ids, m.id)
+	}
+	close(t.changed)
+	t.changed = make(chan struct{})
+	return ids, nil
+}
+
+func (s *topicStore) Pull(namespace, name, subscriber string, max int, now time.Time, deadline time.Duration) (TopicPull, error) {
+	defer ChangeState()()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.topics[namespacedName{namespace, name}]
+	if !ok {
+		return TopicPull{}, status.Errorf(codes.NotFound, "The topic %q does not exist. Publish to it to create it.", name)
+	}
+	leased := func(m *topicMessage) bool {
+		return !m.expiry.IsZero() && now.Before(m.expiry)
+	}
+	held := 0
+	for _, m := range t.messages {
+		if leased(m) && m.subscriber == subscriber {
+			held++
+		}
+	}
+	pull := TopicPull{Changed: t.changed}
+	for _, m := range t.messages {
+		if !leased(m) && (max == 0 || held < max) {
+			m.attempts++
+			m.subscriber = subscriber
+			m.expiry = now.Add(deadline)
+			held++
+			pull.Messages = append(pull.Messages, &pb.ReceivedEchoMessage{
+				AckId:           m.ackID(),
+				MessageId:       m.id,
+				Content:         m.content,
+				DeliveryAttempt: m.attempts,
+			})
+		}
+		if leased(m) && (pull.NextExpiry.IsZero() || m.expiry.Before(pull.NextExpiry)) {
+			pull.NextExpiry = m.expiry
+		}
+	}
+	return pull, nil
+}
+
+func (s *topicStore) Ack(namespace, name string, ackIDs []string) int {
+	defer ChangeState()()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.topics[namespacedName{namespace, name}]
+	if !ok {
+		return 0
+	}
+	acked := map[string]bool{}
+	for _, id := range ackIDs {
+		acked[id] = true
+	}
+	kept := t.messages[:0]
+	for _, m := range t.messages {
+		if m.attempts == 0 || !acked[m.ackID()] {
+			kept = append(kept, m)
+		}
+	}
+	n := len(t.messages) - len(kept)
+	for i := len(kept); i < len(t.messages); i++ {
+		t.messages[i] = nil
+	}
+	t.messages = kept
+	return n
+}
+
+func (s *topicStore) PurgeNamespace(namespace string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for key, t := range s.topics {
+		if key.namespace == namespace {
+			close(t.changed)
+			delete(s.topics, key)
+			n++
+		}
+	}
+	return n
+}
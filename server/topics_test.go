@@ -0,0 +1,204 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pulledIDs returns the ack IDs of the messages of the pull.
+func pulledIDs(p TopicPull) []string {
+	ids := []string{}
+	for _, m := range p.Messages {
+		ids = append(ids, m.GetAckId())
+	}
+	return ids
+}
+
+func TestTopicStore_publishAndPull(t *testing.T) {
+	s := NewTopicStore()
+	now := time.Unix(1000, 0)
+	if _, err := s.Pull("a", "topics/t", "sub", 0, now, time.Second); status.Code(err) != codes.NotFound {
+		t.Errorf("Pull of a new topic: want NotFound got %v", err)
+	}
+	ids, err := s.Publish("a", "topics/t", []string{"one", "two"})
+	if err != nil || !reflect.DeepEqual(ids, []string{"1", "2"}) {
+		t.Fatalf("Publish: want the IDs 1 and 2 got %v, %v", ids, err)
+	}
+	if ids, _ := s.Publish("a", "topics/t", []string{"three"}); !reflect.DeepEqual(ids, []string{"3"}) {
+		t.Errorf("Publish: want the ID 3 got %v", ids)
+	}
+
+	p, err := s.Pull("a", "topics/t", "sub", 0, now, 10*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := pulledIDs(p), []string{"1-1", "2-1", "3-1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Pull: want %v got %v", want, got)
+	}
+	if m := p.Messages[1]; m.GetMessageId() != "2" || m.GetContent() != "two" || m.GetDeliveryAttempt() != 1 {
+		t.Errorf("Pull: want the second message got %v", m)
+	}
+	if want := now.Add(10 * time.Second); !p.NextExpiry.Equal(want) {
+		t.Errorf("Pull: want the next expiry %v got %v", want, p.NextExpiry)
+	}
+
+	// Leased messages are not delivered again, to any subscriber, until
+	// their lease expires.
+	if p, _ := s.Pull("a", "topics/t", "other", 0, now.Add(5*time.Second), 10*time.Second); len(p.Messages) != 0 {
+		t.Errorf("Pull of leased messages: want none got %v", pulledIDs(p))
+	}
+	// Other namespaces have their own topics.
+	if _, err := s.Pull("b", "topics/t", "sub", 0, now, time.Second); status.Code(err) != codes.NotFound {
+		t.Errorf("Pull in another namespace: want NotFound got %v", err)
+	}
+}
+
+func TestTopicStore_ack(t *testing.T) {
+	s := NewTopicStore()
+	now := time.Unix(1000, 0)
+	s.Publish("a", "topics/t", []string{"one", "two", "three"})
+	s.Pull("a", "topics/t", "sub", 0, now, 10*time.Second)
+
+	if n := s.Ack("a", "topics/t", []string{"1-1", "3-1", "9-1"}); n != 2 {
+		t.Errorf("Ack: want 2 acknowledged got %d", n)
+	}
+	if n := s.Ack("a", "topics/t", []string{"1-1"}); n != 0 {
+		t.Errorf("Ack again: want 0 acknowledged got %d", n)
+	}
+	if n := s.Ack("b", "topics/t", []string{"2-1"}); n != 0 {
+		t.Errorf("Ack in another namespace: want 0 acknowledged got %d", n)
+	}
+
+	// Only the unacknowledged message is delivered again, with a new ack ID
+	// that supersedes the old one.
+	later := now.Add(10 * time.Second)
+	p, _ := s.Pull("a", "topics/t", "other", 0, later, 10*time.Second)
+	if got, want := pulledIDs(p), []string{"2-2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Pull after the deadline: want %v got %v", want, got)
+	}
+	if p.Messages[0].GetDeliveryAttempt() != 2 {
+		t.Errorf("Pull after the deadline: want the second attempt got %v", p.Messages[0])
+	}
+	if n := s.Ack("a", "topics/t", []string{"2-1"}); n != 0 {
+		t.Errorf("Ack of a superseded delivery: want 0 acknowledged got %d", n)
+	}
+	if n := s.Ack("a", "topics/t", []string{"2-2"}); n != 1 {
+		t.Errorf("Ack of the redelivery: want 1 acknowledged got %d", n)
+	}
+	if p, _ := s.Pull("a", "topics/t", "sub", 0, later.Add(time.Hour), time.Second); len(p.Messages) != 0 || !p.NextExpiry.IsZero() {
+		t.Errorf("Pull of an acknowledged topic: want nothing got %v, next expiry %v", pulledIDs(p), p.NextExpiry)
+	}
+}
+
+func TestTopicStore_maxOutstanding(t *testing.T) {
+	s := NewTopicStore()
+	now := time.Unix(1000, 0)
+	s.Publish("a", "topics/t", []string{"one", "two", "three", "four"})
+
+	a, _ := s.Pull("a", "topics/t", "a", 1, now, 10*time.Second)
+	b, _ := s.Pull("a", "topics/t", "b", 2, now.Add(time.Second), 10*time.Second)
+	if got, want := pulledIDs(a), []string{"1-1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Pull(a): want %v got %v", want, got)
+	}
+	if got, want := pulledIDs(b), []string{"2-1", "3-1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Pull(b): want %v got %v", want, got)
+	}
+	if want := now.Add(10 * time.Second); !b.NextExpiry.Equal(want) {
+		t.Errorf("Pull(b): want the earliest expiry %v got %v", want, b.NextExpiry)
+	}
+	if a, _ := s.Pull("a", "topics/t", "a", 1, now.Add(time.Second), 10*time.Second); len(a.Messages) != 0 {
+		t.Errorf("Pull(a) with a message outstanding: want none got %v", pulledIDs(a))
+	}
+	s.Ack("a", "topics/t", []string{"1-1"})
+	if a, _ := s.Pull("a", "topics/t", "a", 1, now.Add(time.Second), 10*time.Second); !reflect.DeepEqual(pulledIDs(a), []string{"4-1"}) {
+		t.Errorf("Pull(a) after acknowledging: want 4-1 got %v", pulledIDs(a))
+	}
+	// An expired lease no longer counts against its subscriber.
+	if b, _ := s.Pull("a", "topics/t", "b", 2, now.Add(11*time.Second), 10*time.Second); !reflect.DeepEqual(pulledIDs(b), []string{"2-2", "3-2"}) {
+		t.Errorf("Pull(b) after its leases expired: want 2-2 and 3-2 got %v", pulledIDs(b))
+	}
+}
+
+func TestTopicStore_changed(t *testing.T) {
+	s := NewTopicStore()
+	s.Publish("a", "topics/t", []string{"one"})
+	p, _ := s.Pull("a", "topics/t", "sub", 0, time.Unix(1000, 0), time.Second)
+	select {
+	case <-p.Changed:
+		t.Fatal("Changed: want it open until the next publish")
+	default:
+	}
+	s.Publish("a", "topics/t", []string{"two"})
+	select {
+	case <-p.Changed:
+	default:
+		t.Error("Changed: want it closed by the next publish")
+	}
+
+	p, _ = s.Pull("a", "topics/t", "sub", 0, time.Unix(1000, 0), time.Second)
+	if n := s.PurgeNamespace("b"); n != 0 {
+		t.Errorf("PurgeNamespace(b): want 0 got %d", n)
+	}
+	if n := s.PurgeNamespace("a"); n != 1 {
+		t.Errorf("PurgeNamespace(a): want 1 got %d", n)
+	}
+	select {
+	case <-p.Changed:
+	default:
+		t.Error("Changed: want it closed by a purge")
+	}
+	if _, err := s.Pull("a", "topics/t", "sub", 0, time.Unix(1000, 0), time.Second); status.Code(err) != codes.NotFound {
+		t.Errorf("Pull of a purged topic: want NotFound got %v", err)
+	}
+}
+
+func TestTopicStore_caps(t *testing.T) {
+	s := NewTopicStore()
+	if _, err := s.Publish("a", "topics/t", make([]string, MaxTopicMessages+1)); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Publish of too many messages: want ResourceExhausted got %v", err)
+	}
+	if _, err := s.Publish("a", "topics/t", make([]string, MaxTopicMessages)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Publish("a", "topics/t", []string{"more"}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Publish to a full topic: want ResourceExhausted got %v", err)
+	}
+	s.Pull("a", "topics/t", "sub", 0, time.Unix(1000, 0), time.Second)
+	if n := s.Ack("a", "topics/t", []string{"1-1"}); n != 1 {
+		t.Fatalf("Ack: want 1 acknowledged got %d", n)
+	}
+	if _, err := s.Publish("a", "topics/t", []string{"more"}); err != nil {
+		t.Errorf("Publish after an acknowledgement: unexpected err %v", err)
+	}
+
+	for i := 1; i < MaxTopics; i++ {
+		if _, err := s.Publish("b", fmt.Sprintf("topics/t%d", i), []string{"m"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.Publish("c", "topics/t", []string{"m"}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Publish to a new topic of a full store: want ResourceExhausted got %v", err)
+	}
+	if _, err := s.Publish("b", "topics/t1", []string{"m"}); err != nil {
+		t.Errorf("Publish to an existing topic of a full store: unexpected err %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"google.golang.org/grpc/metadata"
+)
+
+type transportInfoKey struct{}
+
+// WithHTTPTransportInfo returns a copy of the context that records how the
+// HTTP request reached the server, for TransportInfo.
+func WithHTTPTransportInfo(ctx context.Context, req *http.Request) context.Context {
+	protocol := "http/1.1"
+	if req.ProtoMajor == 2 {
+		protocol = "h2"
+	}
+	return context.WithValue(ctx, transportInfoKey{}, &pb.TransportInfo{
+		UserAgent:   req.UserAgent(),
+		ContentType: req.Header.Get("Content-Type"),
+		Protocol:    protocol,
+		Accept:      req.Header.Get("Accept"),
+	})
+}
+
+// TransportInfo returns how the request of the context reached the server:
+// as recorded by WithHTTPTransportInfo, or else from the incoming metadata of
+// a gRPC call, which is always served over HTTP/2.
+func TransportInfo(ctx context.Context) *pb.TransportInfo {
+	if info, ok := ctx.Value(transportInfoKey{}).(*pb.TransportInfo); ok {
+		return info
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	return &pb.TransportInfo{
+		UserAgent:   strings.Join(md.Get("user-agent"), " "),
+		ContentType: strings.Join(md.Get("content-type"), ","),
+		Protocol:    "h2",
+	}
+}
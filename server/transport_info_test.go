@@ -0,0 +1,45 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestTransportInfo(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"user-agent", "grpc-go/1.19.1",
+		"content-type", "application/grpc+proto"))
+	want := &pb.TransportInfo{UserAgent: "grpc-go/1.19.1", ContentType: "application/grpc+proto", Protocol: "h2"}
+	if got := TransportInfo(ctx); !proto.Equal(got, want) {
+		t.Errorf("TransportInfo: want %v, got %v", want, got)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, EchoPath, nil)
+	req.ProtoMajor, req.ProtoMinor = 2, 0
+	req.Header.Set("User-Agent", "curl/7.64")
+	req.Header.Set("Accept", "*/*")
+	want = &pb.TransportInfo{UserAgent: "curl/7.64", Protocol: "h2", Accept: "*/*"}
+	if got := TransportInfo(WithHTTPTransportInfo(ctx, req)); !proto.Equal(got, want) {
+		t.Errorf("TransportInfo: want %v, got %v", want, got)
+	}
+}
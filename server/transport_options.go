@@ -0,0 +1,92 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc"
+)
+
+// MinWindowSize is the smallest HTTP/2 flow-control window gRPC applies.
+// It ignores smaller windows, keeping its default of the same size.
+const MinWindowSize = 65535
+
+// TransportOptions size the buffers and flow-control windows of the
+// server's HTTP/2 connections, to test clients against unusual framing.
+// They are best-effort: gRPC cuts each message into DATA frames of at most
+// 16KiB and of no more than the peer's window allows, so no option here
+// sets the size of a frame directly, and a codec cannot either.
+type TransportOptions struct {
+	// The bytes written to a connection at once. Zero writes each frame as
+	// it is framed, so that frames reach the client one by one, and a
+	// negative size keeps gRPC's default of 32KiB.
+	WriteBufferSize int
+
+	// The most bytes read from a connection at once. Zero reads unbuffered,
+	// and a negative size keeps gRPC's default of 32KiB.
+	ReadBufferSize int
+
+	// The flow-control window of each stream, which bounds the bytes a
+	// client sends on a stream before the server acknowledges them. Zero
+	// keeps gRPC's default; otherwise it must be at least MinWindowSize.
+	InitialWindowSize int32
+
+	// The flow-control window of each connection, across its streams. Zero
+	// keeps gRPC's default; otherwise it must be at least MinWindowSize.
+	InitialConnWindowSize int32
+}
+
+// DefaultTransportOptions returns the options that keep gRPC's defaults.
+func DefaultTransportOptions() TransportOptions {
+	return TransportOptions{WriteBufferSize: -1, ReadBufferSize: -1}
+}
+
+// Validate returns an error if a window is set below MinWindowSize, which
+// gRPC would silently ignore.
+func (t TransportOptions) Validate() error {
+	windows := []struct {
+		name string
+		size int32
+	}{
+		{"initial window size", t.InitialWindowSize},
+		{"initial connection window size", t.InitialConnWindowSize},
+	}
+	for _, w := range windows {
+		if w.size != 0 && w.size < MinWindowSize {
+			return fmt.Errorf("the %s %d must be zero or at least %d bytes, the smallest gRPC applies", w.name, w.size, MinWindowSize)
+		}
+	}
+	return nil
+}
+
+// ServerOptions returns the gRPC server options that apply the transport
+// options, leaving out those that keep gRPC's defaults.
+func (t TransportOptions) ServerOptions() []grpc.ServerOption {
+	opts := []grpc.ServerOption{}
+	if t.WriteBufferSize >= 0 {
+		opts = append(opts, grpc.WriteBufferSize(t.WriteBufferSize))
+	}
+	if t.ReadBufferSize >= 0 {
+		opts = append(opts, grpc.ReadBufferSize(t.ReadBufferSize))
+	}
+	if t.InitialWindowSize > 0 {
+		opts = append(opts, grpc.InitialWindowSize(t.InitialWindowSize))
+	}
+	if t.InitialConnWindowSize > 0 {
+		opts = append(opts, grpc.InitialConnWindowSize(t.InitialConnWindowSize))
+	}
+	return opts
+}
@@ -0,0 +1,173 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/googleapis/gapic-showcase/server/genproto"
+	"google.golang.org/grpc"
+)
+
+// appliedTransport returns the transport options a server was created with,
+// read from its unexported options.
+func appliedTransport(s *grpc.Server) TransportOptions {
+	opts := reflect.ValueOf(s).Elem().FieldByName("opts")
+	return TransportOptions{
+		WriteBufferSize:       int(opts.FieldByName("writeBufferSize").Int()),
+		ReadBufferSize:        int(opts.FieldByName("readBufferSize").Int()),
+		InitialWindowSize:     int32(opts.FieldByName("initialWindowSize").Int()),
+		InitialConnWindowSize: int32(opts.FieldByName("initialConnWindowSize").Int()),
+	}
+}
+
+func TestTransportOptions_ServerOptions(t *testing.T) {
+	grpcDefaults := appliedTransport(grpc.NewServer())
+	if len(DefaultTransportOptions().ServerOptions()) != 0 {
+		t.Errorf("DefaultTransportOptions: want no server options")
+	}
+	if got := appliedTransport(grpc.NewServer(DefaultTransportOptions().ServerOptions()...)); got != grpcDefaults {
+		t.Errorf("DefaultTransportOptions: want gRPC's defaults %+v got %+v", grpcDefaults, got)
+	}
+
+	tests := []TransportOptions{
+		{WriteBufferSize: 0, ReadBufferSize: 0, InitialWindowSize: MinWindowSize, InitialConnWindowSize: MinWindowSize},
+		{WriteBufferSize: 1 << 20, ReadBufferSize: 1 << 20, InitialWindowSize: 1 << 30, InitialConnWindowSize: 1 << 30},
+		{WriteBufferSize: 1, ReadBufferSize: 7, InitialWindowSize: 1 << 20, InitialConnWindowSize: 1 << 24},
+	}
+	for _, want := range tests {
+		if got := appliedTransport(grpc.NewServer(want.ServerOptions()...)); got != want {
+			t.Errorf("ServerOptions(%+v): want the server to apply them got %+v", want, got)
+		}
+	}
+
+	// Unset windows keep gRPC's, even when the buffers are set.
+	opts := TransportOptions{WriteBufferSize: 10, ReadBufferSize: -1}
+	want := grpcDefaults
+	want.WriteBufferSize = 10
+	if got := appliedTransport(grpc.NewServer(opts.ServerOptions()...)); got != want {
+		t.Errorf("ServerOptions(%+v): want %+v got %+v", opts, want, got)
+	}
+}
+
+func TestTransportOptions_Validate(t *testing.T) {
+	valid := []TransportOptions{
+		DefaultTransportOptions(),
+		{},
+		{InitialWindowSize: MinWindowSize, InitialConnWindowSize: 1 << 30},
+	}
+	for _, opts := range valid {
+		if err := opts.Validate(); err != nil {
+			t.Errorf("Validate(%+v): unexpected err %v", opts, err)
+		}
+	}
+	invalid := []struct {
+		opts    TransportOptions
+		wantMsg string
+	}{
+		{TransportOptions{InitialWindowSize: 1}, "the initial window size 1 must be zero or at least 65535"},
+		{TransportOptions{InitialWindowSize: -1}, "the initial window size -1"},
+		{TransportOptions{InitialConnWindowSize: MinWindowSize - 1}, "the initial connection window size 65534"},
+	}
+	for _, test := range invalid {
+		if err := test.opts.Validate(); err == nil || !strings.Contains(err.Error(), test.wantMsg) {
+			t.Errorf("Validate(%+v): want an error containing %q got %v", test.opts, test.wantMsg, err)
+		}
+	}
+}
+
+func TestTransportOptions_largeEcho(t *testing.T) {
+	content := strings.Repeat("showcase", 1<<17)
+	tests := []struct {
+		opts TransportOptions
+		// Whether each write to the client holds exactly one frame.
+		unbuffered bool
+	}{
+		{TransportOptions{WriteBufferSize: 0, ReadBufferSize: 0, InitialWindowSize: MinWindowSize, InitialConnWindowSize: MinWindowSize}, true},
+		{TransportOptions{WriteBufferSize: 1 << 20, ReadBufferSize: 1 << 20, InitialWindowSize: 1 << 30, InitialConnWindowSize: 1 << 30}, false},
+	}
+	for _, test := range tests {
+		buf := &bytes.Buffer{}
+		capture, err := NewCapture(buf, DefaultCaptureMaxBytes, time.Now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lis, err := net.Listen("tcp", "localhost:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := grpc.NewServer(test.opts.ServerOptions()...)
+		pb.RegisterEchoServer(s, testEchoServer{})
+		go s.Serve(capture.Listener(lis))
+
+		// The client's windows match the server's, so that both directions
+		// are sent at the extreme.
+		dialOpts := []grpc.DialOption{grpc.WithInsecure()}
+		if w := test.opts.InitialWindowSize; w > 0 {
+			dialOpts = append(dialOpts, grpc.WithInitialWindowSize(w), grpc.WithInitialConnWindowSize(test.opts.InitialConnWindowSize))
+		}
+		conn, err := grpc.Dial(lis.Addr().String(), dialOpts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := pb.NewEchoClient(conn).Echo(context.Background(), &pb.EchoRequest{Response: &pb.EchoRequest_Content{Content: content}})
+		conn.Close()
+		s.Stop()
+		if err != nil {
+			t.Fatalf("Echo(%+v): unexpected err %+v", test.opts, err)
+		}
+		if resp.GetContent() != content {
+			t.Fatalf("Echo(%+v): want the %d bytes of content back got %d", test.opts, len(content), len(resp.GetContent()))
+		}
+
+		records, err := ReadCapture(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		writes, frames, data := 0, 0, 0
+		for _, r := range records {
+			if r.Direction == CaptureSent {
+				writes++
+			}
+		}
+		for _, f := range CaptureFrames(records) {
+			if f.Direction != CaptureSent {
+				continue
+			}
+			frames++
+			if f.Type == "DATA" {
+				data += f.Length
+				if f.Length > 16384 {
+					t.Errorf("Echo(%+v): want DATA frames of at most 16KiB got %d bytes", test.opts, f.Length)
+				}
+			}
+		}
+		if data < len(content) {
+			t.Errorf("Echo(%+v): want at least %d bytes of DATA sent got %d", test.opts, len(content), data)
+		}
+		if test.unbuffered && writes != frames {
+			t.Errorf("Echo(%+v): want each of the %d frames written on its own got %d writes", test.opts, frames, writes)
+		}
+		if !test.unbuffered && writes >= frames {
+			t.Errorf("Echo(%+v): want the %d frames batched into fewer writes got %d", test.opts, frames, writes)
+		}
+	}
+}
@@ -16,7 +16,7 @@ package server
 
 import (
 	"encoding/base64"
-	"fmt"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -25,9 +25,16 @@ import (
 	lropb "google.golang.org/genproto/googleapis/longrunning"
 )
 
-var waiterSingleton Waiter = &waiterImpl{
-	nowF: time.Now,
-}
+// PollWaitHeader is the metadata key that makes a GetOperation call hold until
+// the operation completes or the given wait elapses, whichever is first. Its
+// value is a duration such as "1.5s", and is capped by Settings.MaxPollWait.
+const PollWaitHeader = "showcase-poll-wait"
+
+// MaxWaitCreateTimes is the most Wait operations whose create times a waiter
+// keeps.
+const MaxWaitCreateTimes = 10000
+
+var waiterSingleton = NewWaiter(Now, settingsSingleton)
 
 // GetWaiterInstance returns the waiter singleton.
 func GetWaiterInstance() Waiter {
@@ -39,8 +46,58 @@ type Waiter interface {
 	Wait(req *pb.WaitRequest) *lropb.Operation
 }
 
+// NewWaiter returns a Waiter that measures time with the given clock and
+// names operations with the instance ID of the settings.
+func NewWaiter(nowF func() time.Time, settings SettingsStore) Waiter {
+	return &waiterImpl{nowF: nowF, settings: settings}
+}
+
+// NewWorkerWaiter returns a Waiter like NewWaiter's, except that operations
+// are done once the workers complete them rather than at their end time.
+// Each operation is started on the workers the first time it is waited on,
+// with the time left until its end time as its work. Partial results still
+// become available at their times.
+func NewWorkerWaiter(nowF func() time.Time, settings SettingsStore, workers *WorkerOperationStore) Waiter {
+	return &waiterImpl{nowF: nowF, settings: settings, workers: workers}
+}
+
+// corruptResultTypeURL is the type URL that a WaitResponse is packed under
+// when WaitRequest.corrupt_result_type is set. It names no registered type.
+const corruptResultTypeURL = "type.googleapis.com/google.showcase.v1beta1.NotAWaitResponse"
+
 type waiterImpl struct {
-	nowF func() time.Time
+	nowF     func() time.Time
+	settings SettingsStore
+	workers  *WorkerOperationStore
+	created  createTimes
+}
+
+// createTimes records when operations were first seen, since their names do
+// not. The oldest are forgotten first, and are then seen again as new.
+type createTimes struct {
+	mu    sync.Mutex
+	times map[string]time.Time
+	order []string
+}
+
+// record returns when the named operation was first recorded, recording it
+// at now if it was not.
+func (c *createTimes) record(name string, now time.Time) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t, ok := c.times[name]; ok {
+		return t
+	}
+	if c.times == nil {
+		c.times = map[string]time.Time{}
+	}
+	for len(c.order) > 0 && len(c.times) >= MaxWaitCreateTimes {
+		delete(c.times, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.times[name] = now
+	c.order = append(c.order, name)
+	return now
 }
 
 func (w *waiterImpl) Wait(req *pb.WaitRequest) *lropb.Operation {
@@ -57,11 +114,37 @@ func (w *waiterImpl) Wait(req *pb.WaitRequest) *lropb.Operation {
 		EndTime: endTimeProto,
 	}
 
+	// Offsets of partial results are from now, as the ttl is.
+	now := w.nowF()
+	var partial *pb.WaitResponse
+	partialCount := int32(0)
+	for _, p := range req.GetPartialResults() {
+		if offset := p.GetOffset(); offset != nil {
+			d, _ := ptypes.Duration(offset)
+			at, _ := ptypes.TimestampProto(now.Add(d))
+			p.Available = &pb.PartialResult_AvailableTime{AvailableTime: at}
+		}
+		if at, _ := ptypes.Timestamp(p.GetAvailableTime()); !now.Before(at) {
+			partial = p.GetResponse()
+			partialCount++
+		}
+	}
+
 	done := w.nowF().After(endTime)
 	reqBytes, _ := proto.Marshal(req)
-	name := fmt.Sprintf(
-		"operations/google.showcase.v1beta1.Echo/Wait/%s",
-		base64.StdEncoding.EncodeToString(reqBytes))
+	instance := ""
+	if w.settings != nil {
+		instance = w.settings.Get().InstanceID
+	}
+	name := OperationName(instance, "google.showcase.v1beta1.Echo/Wait", base64.StdEncoding.EncodeToString(reqBytes))
+	createTimeProto, _ := ptypes.TimestampProto(w.created.record(name, now))
+	if w.workers != nil {
+		w.workers.Start(name, endTime.Sub(now))
+		var doneTime time.Time
+		if doneTime, done = w.workers.DoneTime(name); done {
+			endTimeProto, _ = ptypes.TimestampProto(doneTime)
+		}
+	}
 	answer := &lropb.Operation{
 		Name: name,
 		Done: done,
@@ -71,15 +154,40 @@ func (w *waiterImpl) Wait(req *pb.WaitRequest) *lropb.Operation {
 		answer.Result = &lropb.Operation_Error{Error: req.GetError()}
 	}
 
-	if done && (req.GetSuccess() != nil) {
-		resp, _ := ptypes.MarshalAny(req.GetSuccess())
-		answer.Result = &lropb.Operation_Response{Response: resp}
+	// Without an explicit result, the operation completes with its last
+	// partial result.
+	success := req.GetSuccess()
+	if partials := req.GetPartialResults(); req.GetResponse() == nil && len(partials) > 0 {
+		success = partials[len(partials)-1].GetResponse()
 	}
-
-	if !done {
-		meta, _ := ptypes.MarshalAny(&pb.WaitMetadata{EndTime: endTimeProto})
-		answer.Metadata = meta
+	if done && success != nil {
+		resp, _ := ptypes.MarshalAny(success)
+		if req.GetCorruptResultType() {
+			resp.TypeUrl = corruptResultTypeURL
+		}
+		if req.GetCorruptResultBytes() {
+			resp.Value = truncate(resp.Value)
+		}
+		answer.Result = &lropb.Operation_Response{Response: resp}
 	}
 
+	meta, _ := ptypes.MarshalAny(&pb.WaitMetadata{
+		EndTime:         endTimeProto,
+		PartialResponse: partial,
+		PartialCount:    partialCount,
+		CreateTime:      createTimeProto,
+		Verb:            "wait",
+	})
+	answer.Metadata = meta
 	return answer
 }
+
+// truncate returns the encoded message with its last byte removed, which
+// always leaves the final field incomplete. An empty message is replaced with
+// a lone field tag that has no value.
+func truncate(b []byte) []byte {
+	if len(b) == 0 {
+		return []byte{0x0a}
+	}
+	return b[:len(b)-1]
+}
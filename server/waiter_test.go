@@ -100,9 +100,7 @@ func TestWait_success(t *testing.T) {
 	if !op.Done {
 		t.Errorf("Wait() for %q expected done=true got done=false", req)
 	}
-	if op.Metadata != nil {
-		t.Errorf("Wait() for %q expected nil metadata, got %q", req, op.Metadata)
-	}
+	checkDoneMetadata(t, req, op, endTime)
 	if op.GetError() != nil {
 		t.Errorf("Wait() expected op.Error=nil, got %q", op.GetError())
 	}
@@ -116,6 +114,57 @@ func TestWait_success(t *testing.T) {
 	}
 }
 
+func TestWait_partialResults(t *testing.T) {
+	now := time.Unix(10, 0)
+	waiter := &waiterImpl{nowF: func() time.Time { return now }}
+	partial := func(offset time.Duration, content string) *pb.PartialResult {
+		return &pb.PartialResult{
+			Available: &pb.PartialResult_Offset{Offset: ptypes.DurationProto(offset)},
+			Response:  &pb.WaitResponse{Content: content},
+		}
+	}
+	req := &pb.WaitRequest{
+		End:            &pb.WaitRequest_Ttl{Ttl: ptypes.DurationProto(4 * time.Second)},
+		PartialResults: []*pb.PartialResult{partial(time.Second, "a"), partial(2*time.Second, "ab"), partial(3*time.Second, "abc")},
+	}
+	op := waiter.Wait(req)
+	checkName(t, req, op)
+	// The offsets are resolved into times in the name, as the ttl is.
+	if want := timestampProto(time.Unix(12, 0)); !proto.Equal(req.GetPartialResults()[1].GetAvailableTime(), want) {
+		t.Errorf("Wait: want the second partial result available at %v, got %v", want, req.GetPartialResults()[1])
+	}
+
+	// Polls decode the request from the name, as GetOperation does.
+	for i, want := range []string{"", "a", "ab", "abc"} {
+		op := waiter.Wait(proto.Clone(req).(*pb.WaitRequest))
+		if op.GetDone() {
+			t.Fatalf("Wait after %d partial results: want pending, got %v", i, op)
+		}
+		meta := &pb.WaitMetadata{}
+		if err := ptypes.UnmarshalAny(op.GetMetadata(), meta); err != nil {
+			t.Fatal(err)
+		}
+		if meta.GetPartialCount() != int32(i) || meta.GetPartialResponse().GetContent() != want {
+			t.Errorf("Wait after %d partial results: want %q and a count of %d, got %v", i, want, i, meta)
+		}
+		now = now.Add(time.Second)
+	}
+
+	now = now.Add(time.Second)
+	op = waiter.Wait(proto.Clone(req).(*pb.WaitRequest))
+	resp := &pb.WaitResponse{}
+	if err := ptypes.UnmarshalAny(op.GetResponse(), resp); !op.GetDone() || err != nil || resp.GetContent() != "abc" {
+		t.Errorf("Wait after completion: want the last partial result, got %v", op)
+	}
+
+	// An explicit result replaces the last partial result.
+	req.Response = &pb.WaitRequest_Success{Success: &pb.WaitResponse{Content: "final"}}
+	op = waiter.Wait(proto.Clone(req).(*pb.WaitRequest))
+	if err := ptypes.UnmarshalAny(op.GetResponse(), resp); err != nil || resp.GetContent() != "final" {
+		t.Errorf("Wait after completion: want the explicit result, got %v", op)
+	}
+}
+
 func TestWait_error(t *testing.T) {
 	nowF := func() time.Time { return time.Unix(3, 0) }
 	endTime := timestampProto(time.Unix(2, 0))
@@ -135,9 +184,7 @@ func TestWait_error(t *testing.T) {
 	if !op.Done {
 		t.Errorf("Wait() for %q expected done=true got done=false", req)
 	}
-	if op.Metadata != nil {
-		t.Errorf("Wait() for %q expected nil metadata, got %q", req, op.Metadata)
-	}
+	checkDoneMetadata(t, req, op, endTime)
 	if op.GetResponse() != nil {
 		t.Errorf("Wait() expected op.Response=nil, got %q", op.GetResponse())
 	}
@@ -146,6 +193,94 @@ func TestWait_error(t *testing.T) {
 	}
 }
 
+// checkDoneMetadata checks that a done operation still has its metadata,
+// with the time it ended.
+func checkDoneMetadata(t *testing.T, req *pb.WaitRequest, op *lropb.Operation, endTime *timestamp.Timestamp) {
+	meta := &pb.WaitMetadata{}
+	if err := ptypes.UnmarshalAny(op.GetMetadata(), meta); err != nil {
+		t.Fatalf("Wait() for %q expected metadata, got %v", req, err)
+	}
+	if !proto.Equal(meta.GetEndTime(), endTime) || meta.GetVerb() != "wait" {
+		t.Errorf("Wait() for %q expected metadata ending at %v with the verb wait, got %v", req, endTime, meta)
+	}
+}
+
+func TestWait_metadata(t *testing.T) {
+	now := time.Unix(10, 0)
+	waiter := &waiterImpl{nowF: func() time.Time { return now }}
+	req := &pb.WaitRequest{
+		End:      &pb.WaitRequest_Ttl{Ttl: ptypes.DurationProto(2 * time.Second)},
+		Response: &pb.WaitRequest_Error{Error: &status.Status{Code: 5, Message: "Gone"}},
+	}
+	waiter.Wait(req)
+
+	// Polls decode the request from the name, so the create time of the
+	// operation stays that of its first call.
+	for _, wantDone := range []bool{false, true} {
+		now = now.Add(time.Second + time.Millisecond)
+		op := waiter.Wait(proto.Clone(req).(*pb.WaitRequest))
+		meta := &pb.WaitMetadata{}
+		if err := ptypes.UnmarshalAny(op.GetMetadata(), meta); err != nil {
+			t.Fatalf("Wait with done=%t: want metadata got %v", op.GetDone(), err)
+		}
+		if op.GetDone() != wantDone {
+			t.Errorf("Wait: want done=%t got %v", wantDone, op)
+		}
+		if want := timestampProto(time.Unix(10, 0)); !proto.Equal(meta.GetCreateTime(), want) {
+			t.Errorf("Wait with done=%t: want the create time %v got %v", wantDone, want, meta.GetCreateTime())
+		}
+		if want := timestampProto(time.Unix(12, 0)); !proto.Equal(meta.GetEndTime(), want) {
+			t.Errorf("Wait with done=%t: want the end time %v got %v", wantDone, want, meta.GetEndTime())
+		}
+		if meta.GetVerb() != "wait" {
+			t.Errorf("Wait with done=%t: want the verb wait got %q", wantDone, meta.GetVerb())
+		}
+	}
+}
+
+func TestWait_corruptResult(t *testing.T) {
+	nowF := func() time.Time { return time.Unix(3, 0) }
+	tests := []struct {
+		success     *pb.WaitResponse
+		corruptType bool
+		typeURL     string
+	}{
+		{&pb.WaitResponse{Content: "Hello World!"}, true, corruptResultTypeURL},
+		{&pb.WaitResponse{Content: "Hello World!"}, false, "type.googleapis.com/google.showcase.v1beta1.WaitResponse"},
+		{&pb.WaitResponse{}, false, "type.googleapis.com/google.showcase.v1beta1.WaitResponse"},
+	}
+	for _, test := range tests {
+		req := &pb.WaitRequest{
+			End:                &pb.WaitRequest_EndTime{EndTime: timestampProto(time.Unix(2, 0))},
+			Response:           &pb.WaitRequest_Success{Success: test.success},
+			CorruptResultType:  test.corruptType,
+			CorruptResultBytes: !test.corruptType,
+		}
+
+		waiter := &waiterImpl{nowF: nowF}
+		op := waiter.Wait(req)
+		checkName(t, req, op)
+
+		// The operation itself is well formed; the corruption only shows when
+		// the client unpacks the response.
+		if !op.Done || op.GetError() != nil || op.GetResponse() == nil {
+			t.Fatalf("Wait() for %q expected a done operation with a response, got %q", req, op)
+		}
+		if op.GetResponse().GetTypeUrl() != test.typeURL {
+			t.Errorf("Wait() for %q expected type URL %q, got %q", req, test.typeURL, op.GetResponse().GetTypeUrl())
+		}
+		good, _ := proto.Marshal(test.success)
+		if test.corruptType && string(op.GetResponse().GetValue()) != string(good) {
+			t.Errorf("Wait() for %q expected the response bytes to be intact", req)
+		}
+
+		// Clients fail to unpack the response into a WaitResponse.
+		if err := ptypes.UnmarshalAny(op.GetResponse(), &pb.WaitResponse{}); err == nil {
+			t.Errorf("Wait() for %q expected unpacking the response to fail", req)
+		}
+	}
+}
+
 func timestampProto(t time.Time) *timestamp.Timestamp {
 	ts, _ := ptypes.TimestampProto(t)
 	return ts
@@ -171,3 +306,50 @@ func checkName(t *testing.T, req *pb.WaitRequest, op *lropb.Operation) {
 			nameProto)
 	}
 }
+
+func TestWait_instance(t *testing.T) {
+	waiter := &waiterImpl{
+		nowF:     func() time.Time { return time.Unix(1, 0) },
+		settings: NewSettingsStore(Settings{InstanceID: "a"}),
+	}
+	op := waiter.Wait(&pb.WaitRequest{})
+	if instance, _ := SplitOperationName(op.GetName()); instance != "a" {
+		t.Errorf("Wait() on instance a: want a name recording it got %q", op.GetName())
+	}
+}
+
+func TestWorkerWaiter(t *testing.T) {
+	clock := newFakeClock()
+	workers := NewWorkerOperationStore(1, NewMetrics(), clock.nowF, clock.afterF)
+	defer workers.Shutdown()
+	waiter := NewWorkerWaiter(clock.nowF, nil, workers)
+	success := &pb.WaitResponse{Content: "Hello World!"}
+	req := &pb.WaitRequest{
+		End:      &pb.WaitRequest_EndTime{EndTime: timestampProto(clock.now.Add(time.Second))},
+		Response: &pb.WaitRequest_Success{Success: success},
+	}
+
+	op := waiter.Wait(req)
+	if op.GetDone() {
+		t.Fatalf("Wait: want the operation pending got %v", op)
+	}
+	if d := nextWork(t, clock); d != time.Second {
+		t.Errorf("Wait: want the operation worked on until its end time got %s", d)
+	}
+
+	// Past its end time, the operation is pending until the worker is done.
+	clock.now = clock.now.Add(time.Minute)
+	if op := waiter.Wait(req); op.GetDone() {
+		t.Errorf("Wait: want the operation pending until the worker completes it got %v", op)
+	}
+	clock.alarms <- clock.now
+	<-workers.Done(op.GetName())
+	op = waiter.Wait(req)
+	resp := &pb.WaitResponse{}
+	ptypes.UnmarshalAny(op.GetResponse(), resp)
+	if !op.GetDone() || !proto.Equal(resp, success) {
+		t.Errorf("Wait: want the operation done with %v got %v", success, op)
+	}
+	// The metadata ends when the worker completed the operation.
+	checkDoneMetadata(t, req, op, timestampProto(clock.now))
+}